@@ -3,49 +3,25 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"go.uber.org/zap"
 	
+	"github.com/DuckDHD/BuyOrBye/internal/app"
 	"github.com/DuckDHD/BuyOrBye/internal/config"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
 	"github.com/DuckDHD/BuyOrBye/internal/server"
 )
 
-func gracefulShutdown(apiServer *http.Server, done chan bool) {
-	// Create context that listens for the interrupt signal from the OS.
-	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
-	defer stop()
-
-	// Listen for the interrupt signal.
-	<-ctx.Done()
-
-	logger := logging.GetLogger()
-	logger.Info("Shutting down gracefully, press Ctrl+C again to force")
-	stop() // Allow Ctrl+C to force shutdown
-
-	// The context is used to inform the server it has 5 seconds to finish
-	// the request it is currently handling
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := apiServer.Shutdown(ctx); err != nil {
-		logger.Error("Server forced to shutdown", logging.WithError(err))
-	}
-
-	logger.Info("Server exiting")
-
-	// Notify the main goroutine that the shutdown is complete
-	done <- true
-}
-
 func main() {
 	// Load configuration first
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		panic(fmt.Sprintf("Failed to load configuration: %v", err))
+		// Every problem is listed; a stack trace would only bury them
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
 	}
 
 	// Initialize logger with config
@@ -61,31 +37,48 @@ func main() {
 		zap.String("environment", cfg.Server.Environment),
 		zap.String("config_file", config.GetConfigPath(cfg.Server.Environment)))
 
-	// Initialize the server with config
-	apiServer, err := server.NewServerWithConfig(cfg)
+	// Initialize database service with config; migrations are run by cmd/app
+	dbService, err := config.NewDatabaseService(&cfg.Database, &cfg.Logging)
 	if err != nil {
-		logger.Fatal("Failed to initialize server", logging.WithError(err))
+		logger.Fatal("Failed to initialize database", logging.WithError(err))
 	}
-	
+
+	// Build the same services and routes as cmd/app
+	deps, err := server.NewDeps(cfg, dbService)
+	if err != nil {
+		logger.Fatal("Failed to initialize services", logging.WithError(err))
+	}
+	router, err := server.BuildRouter(deps)
+	if err != nil {
+		logger.Fatal("Failed to build router", logging.WithError(err))
+	}
+
 	serverService := config.NewServerService(&cfg.Server)
+	apiServer := serverService.CreateServer(router)
 	logger.Info("Starting BuyOrBye API server", 
 		logging.WithComponent("main"), 
 		zap.String("address", serverService.GetAddress()),
 		zap.String("environment", cfg.Server.Environment))
 
-	// Create a done channel to signal when the shutdown is complete
-	done := make(chan bool, 1)
+	deps.Start()
 
-	// Run graceful shutdown in a separate goroutine
-	go gracefulShutdown(apiServer, done)
+	lifecycle := app.NewLifecycle(apiServer, cfg.Server.ShutdownTimeout,
+		app.WithDrain(deps.Readiness.Drain, cfg.Server.ReadinessDrainDelay))
+	deps.RegisterShutdown(lifecycle, cfg.Server.ComponentShutdownTimeout)
+	lifecycle.Register("database", cfg.Server.ComponentShutdownTimeout, func(ctx context.Context) error {
+		return dbService.Close()
+	})
 
-	// Start the server
-	err = apiServer.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
-		panic(fmt.Sprintf("http server error: %s", err))
-	}
+	// Create context that listens for the interrupt signal from the OS.
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, func() {
+		logger.Info("Shutting down gracefully, press Ctrl+C again to force")
+		stop() // Allow Ctrl+C to force shutdown
+	})
 
-	// Wait for the graceful shutdown to complete
-	<-done
+	if err := lifecycle.Run(ctx); err != nil {
+		logger.Fatal("Server stopped with errors", logging.WithError(err))
+	}
 	logger.Info("Graceful shutdown complete")
 }
@@ -0,0 +1,11192 @@
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
+package docs
+
+import "github.com/swaggo/swag"
+
+const docTemplate = `{
+    "schemes": {{ marshal .Schemes }},
+    "swagger": "2.0",
+    "info": {
+        "description": "{{escape .Description}}",
+        "title": "{{.Title}}",
+        "contact": {},
+        "version": "{{.Version}}"
+    },
+    "host": "{{.Host}}",
+    "basePath": "{{.BasePath}}",
+    "paths": {
+        "/account/audit-log": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "List my audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuditLogPageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/demo-data": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Seeded records already deleted are skipped. The seeded health profile is kept\nwhile health data the user added themselves still belongs to it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Remove the demo data from my account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DemoDataRemovedResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Get my account",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserProfileDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Update my account",
+                "parameters": [
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateUserProfileDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserProfileDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The new password must follow the password policy; each broken rule is listed under fields.new_password.\nEvery refresh token is revoked, so all sessions have to log in again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Change my password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ChangePasswordDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/account/seed-demo-data": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates 2-3 incomes, 8-12 expenses tagged \"demo\", 1-2 loans, a health profile with two conditions,\nan insurance policy and 3-5 medical expenses. Only available when demo data is enabled.\nRefused with 409 if the account already has finance or health data.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Seed demo data into my account",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DemoDataBatchResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/audit-log": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List the audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only entries by this user",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuditLogPageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/finance/by-health": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List finance summaries by financial health",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Excellent, Good, Fair or Poor",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.FinanceSummaryResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/finance/high-debt": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List users with a high debt-to-income ratio",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Debt-to-income ratio, default 0.4",
+                        "name": "threshold",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.FinanceSummaryResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/health/recalculate-risk": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Recalculate every user's health risk score",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RiskRecalculationResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/maintenance/cleanup-tokens": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Purge expired refresh tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TokenCleanupResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number, from 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserListResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserProfileDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/deactivate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Deactivate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Change a user's role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateUserRoleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List API keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.APIKeyResponseDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Create an API key",
+                "parameters": [
+                    {
+                        "description": "API key",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateAPIKeyDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.APIKeyResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "List my audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100 (default 50)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AuditLogPageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoginRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TokenResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
+                "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefreshTokenRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/me": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the account with all of its finance and health records and revokes every refresh token.\nAccess tokens already issued are rejected from then on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Delete my account",
+                "parameters": [
+                    {
+                        "description": "Password confirming the deletion",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DeleteAccountRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RefreshTokenRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TokenResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "New account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RegisterRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.TokenResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/affordability": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Get the maximum affordable purchase",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AffordabilityResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/affordability/check": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cash purchases are weighed against the disposable income saved over horizon_months.\nInstallment purchases add the implied monthly payment to the debt-to-income ratio and take it off the savings rate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Check whether a specific purchase is affordable",
+                "parameters": [
+                    {
+                        "description": "Purchase and payment method",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PurchaseAffordabilityCheckDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PurchaseAffordabilityResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/budgets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List budgets",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.BudgetResponseDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Add a budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Budget",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddBudgetDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/budgets/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Update a budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateBudgetDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Delete a budget",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Budget ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/debt-breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Break debt down by loan type",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DebtBreakdownResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/expense": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Add an expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the expense even if it duplicates a recent one",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Expense",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddExpenseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DuplicateRecordResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/expense/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Update an expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateExpenseDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Delete an expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/expense/{id}/installment-paid": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Record an installment payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/expense/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Restore a deleted expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List and search expenses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive name substring",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return expenses in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum amount, inclusive",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum amount, inclusive",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fixed (true) or variable (false) expenses",
+                        "name": "is_fixed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Priority, 1-3",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "monthly, weekly or daily",
+                        "name": "frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return expenses carrying this tag, matched case-insensitively",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or after this date (YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or before this date (YYYY-MM-DD)",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ExpenseResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Delete several expenses",
+                "parameters": [
+                    {
+                        "description": "IDs of the expenses to delete, at most 100",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BulkDeleteExpensesDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.BulkDeleteExpensesResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams records as a file download named after the type and the current date.\nCSV exports one type per file with a header row; JSON is an array, or an object keyed by type for type=all.\nThe date range applies to every type; the other filters only to expenses.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Export finance records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or json (default json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "expenses, incomes, loans or all (default all, JSON only)",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Case-insensitive expense name substring",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export expenses in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum expense amount, inclusive",
+                        "name": "min_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum expense amount, inclusive",
+                        "name": "max_amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Fixed (true) or variable (false) expenses",
+                        "name": "is_fixed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expense priority, 1-3",
+                        "name": "priority",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Expense frequency: monthly, weekly or daily",
+                        "name": "frequency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or after this date (YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created on or before this date (YYYY-MM-DD)",
+                        "name": "created_to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ExpenseResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/goals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List savings goals",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.SavingsGoalResponseDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Add a savings goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Savings goal",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddSavingsGoalDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/goals/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Monthly savings goal contribution history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of months including the current one, 1-24",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.GoalContributionMonthDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/goals/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Update a savings goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Savings goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateSavingsGoalDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Delete a savings goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Savings goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/goals/{id}/contributions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Contribute to a savings goal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Savings goal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Contribution",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddGoalContributionDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/health-explanation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rates financial health as financial_health in the finance summary does, and explains how the\ndebt-to-income ratio, disposable income and savings rate each measure up against the thresholds.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Explain the financial health rating",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FinancialHealthExplanationResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/income": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List incomes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page; with cursor or limit the response is a dtos.IncomePageResponseDTO",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.IncomeResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Add an income source",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Add the income even if it duplicates a recent one",
+                        "name": "force",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Income",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddIncomeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.DuplicateRecordResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/income-diversification": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Groups active recurring incomes by source and reports each source's share of the monthly income,\nthe largest share and the Herfindahl-Hirschman concentration index. single_source_dependent is set\nwhen one source is more than 90% of the income.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Measure income diversification",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.IncomeDiversificationResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/income/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Update an income",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateIncomeDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Delete an income",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/income/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Restore a deleted income",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Income ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Add a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Loan",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.AddLoanDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Update a loan",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateLoanDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan/{id}/extra-payment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Calculate the impact of an extra monthly loan payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Extra monthly payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoanExtraPaymentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoanExtraPaymentImpactResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Loan balance history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.LoanBalanceEntryResponseDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan/{id}/payment": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Record a loan payment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Payment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoanPaymentDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoanBalanceEntryResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loan/{id}/simulate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Simulate extra loan payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Loan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Extra payments",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimulateLoanPayoffDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.LoanPayoffSimulationResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loans": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List loans",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page; with cursor or limit the response is a dtos.LoanPageResponseDTO",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 1-100 (default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum interest rate in percent, inclusive (default 0); can't be combined with cursor or limit",
+                        "name": "min_rate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum interest rate in percent, inclusive (default 100); can't be combined with cursor or limit",
+                        "name": "max_rate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.LoanResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/loans/near-payoff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "List loans that are nearly paid off",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "Percentage of the principal still owed, above 0 and at most 100 (default 10)",
+                        "name": "threshold_percent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.NearPayoffLoanResponseDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/suggest-cuts": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Suggest expenses to cut to reach a savings target",
+                "parameters": [
+                    {
+                        "description": "Monthly savings target",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SuggestExpenseCutsDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseCutSuggestionResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/finance/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "finance"
+                ],
+                "summary": "Get the finance summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FinanceSummaryResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/conditions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List medical conditions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MedicalConditionListResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Give a catalog_id from the condition catalog to record the condition under its canonical name\nand category and score it by the catalog's risk weight. Free-text conditions are flagged uncatalogued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Add a medical condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Condition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateMedicalConditionRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MedicalConditionCreatedResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/conditions/catalog": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Matches the query against each entry's name and aliases, ignoring case and accents and\ntolerating small typos. Pass a suggestion's id as catalog_id when adding a condition.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Search the condition catalog",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of a condition name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Most suggestions to return, 1-50; default 10",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ConditionCatalogSearchResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/conditions/timeline": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get the condition timeline",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ConditionTimelineResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/conditions/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Update a medical condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Condition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateMedicalConditionRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Resolve a medical condition",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Condition ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/cost-projection": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Project medical costs for 12 months",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CostProjectionResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/coverage-gaps": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Analyze coverage gaps",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CoverageGapsResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List medical expenses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MedicalExpenseListResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Add a medical expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Medical expense",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateMedicalExpenseRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/analytics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Analyze medical expenses year to date",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseAnalyticsResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/breakdown": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Totals every recorded medical expense by category, split into what insurance paid and what the user paid out of pocket, with each category's share of the total",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Break down medical expenses by category",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseBreakdownResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/recurring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List recurring medical expenses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MedicalExpenseListResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List a medical expense's attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseAttachmentListResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Attach a document to a medical expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Receipt or EOB: PDF, JPEG or PNG",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseAttachmentResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported Media Type",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/{id}/attachments/{attachmentId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/pdf",
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Download a medical expense attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Delete a medical expense attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/expenses/{id}/occurrences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List the occurrences of a recurring medical expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseOccurrenceListResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The occurrence inherits the expense's category and coverage.\nThe health summary uses recorded occurrences in place of the expense's projected cost for the months that have them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Record an occurrence of a recurring medical expense",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Medical expense ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Actual amount and date",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateExpenseOccurrenceRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpenseOccurrenceResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/family": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List family profiles",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include the family risk and cost rollup",
+                        "name": "aggregate",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.FamilyProfilesResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Add a family member profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Dependent profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateDependentProfileRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/family/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Update a family member profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Family member profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateDependentProfileRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Remove a family member profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Family member profile ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/hsa-recommendation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Recommend an HSA contribution",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HSARecommendationResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List active insurance policies",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.InsurancePolicyListResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Add an insurance policy",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Insurance policy",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateInsurancePolicyRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance/compare": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Compare insurance policies",
+                "parameters": [
+                    {
+                        "description": "Expected spend and optional candidate policies",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ComparePoliciesRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PolicyComparisonResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance/evaluation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Evaluate insurance adequacy",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.InsuranceEvaluationResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance/expiring": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List insurance policies due for renewal",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days ahead to look, 0-365 (default 30)",
+                        "name": "within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExpiringPolicyListResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance/{id}/deductible": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Update deductible progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Amount met",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateDeductibleRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/insurance/{id}/oop-status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get out-of-pocket maximum progress",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Policy ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OutOfPocketStatusDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/medications": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Track medication refills",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Medication schedule",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateMedicationScheduleRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/medications/refills": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "List upcoming medication refills",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Days ahead to look, 0-365",
+                        "name": "within",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MedicationRefillListResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get the health profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HealthProfileResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Update the health profile",
+                "parameters": [
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateHealthProfileRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Create the health profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Makes retries safe; see Idempotent Retries",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Health profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateHealthProfileRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Delete the health profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/profile/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get weight and BMI history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Months of history, 1-120",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProfileHistoryResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/risk-history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get health risk score history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Months of history, 1-120",
+                        "name": "months",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RiskHistoryResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/risk-model": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get the health risk scoring model",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RiskModelResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/risk/what-if": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Scores the risk the user would have with the given weight, age and condition changes,\nand attributes the change from the current score to each factor.\nCondition IDs that aren't the user's own active conditions are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Score a hypothetical health risk",
+                "parameters": [
+                    {
+                        "description": "Hypothetical changes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RiskWhatIfRequestDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RiskWhatIfResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/summary": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Get the health summary",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.HealthSummaryResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the caller's account (without the password), all finance records and all health records:\nhealth profiles, conditions, medical expenses and insurance policies, including inactive ones.\nUnlike /finance/export this is a complete personal data download, not a report. Sent as an attachment.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "account"
+                ],
+                "summary": "Export all my personal data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PersonalDataExportDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/overview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "overview"
+                ],
+                "summary": "Get the combined finance and health overview",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OverviewResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/monthly": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Income received, expenses by category, loan payments, net savings, health spending and how the\nfinancial health score moved over a calendar month, in UTC. A month without records renders an\nempty statement. The ETag changes whenever a record behind the statement changes; send it back\nin If-None-Match to get 304 Not Modified.",
+                "produces": [
+                    "text/html",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get a monthly statement",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Year, 2000 or later",
+                        "name": "year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Month, 1-12",
+                        "name": "month",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "html or pdf; defaults to the Accept header, then html",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The statement",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "406": {
+                        "description": "Not Acceptable",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.WebhookResponseDTO"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UpdateWebhookDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.WebhookResponseDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ValidationErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.MessageResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List a webhook's delivery attempts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of attempts, 1-200",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.WebhookDeliveryDTO"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ErrorResponseDTO"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.AuditChange": {
+            "type": "object",
+            "properties": {
+                "after": {},
+                "before": {}
+            }
+        },
+        "dtos.APIKeyResponseDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "key-123"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "bob_4f3c2a1b0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b"
+                },
+                "last_used_at": {
+                    "type": "string",
+                    "example": "2024-01-16T08:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Nightly export"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "bob_4f3c2a1b"
+                },
+                "revoked_at": {
+                    "type": "string",
+                    "example": "2024-02-01T12:00:00Z"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance:read"
+                    ]
+                }
+            }
+        },
+        "dtos.AddBudgetDTO": {
+            "type": "object",
+            "required": [
+                "category",
+                "monthly_limit"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "housing",
+                        "food",
+                        "transport",
+                        "entertainment",
+                        "utilities",
+                        "other"
+                    ],
+                    "example": "food"
+                },
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 600
+                },
+                "rollover": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "dtos.AddExpenseDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "category",
+                "frequency",
+                "name",
+                "priority"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1200
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "housing",
+                        "food",
+                        "transport",
+                        "entertainment",
+                        "utilities",
+                        "other"
+                    ],
+                    "example": "housing"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "force": {
+                    "description": "Force adds the expense even if it duplicates a recently added one",
+                    "type": "boolean",
+                    "example": false
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "installment_start": {
+                    "description": "InstallmentStart is when the first installment is due; defaults to now",
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "installments_paid": {
+                    "description": "InstallmentsPaid counts installments already paid when the purchase is recorded",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "installments_total": {
+                    "description": "InstallmentsTotal splits a one-time purchase of Amount into monthly installments;\nonly allowed with monthly frequency",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 2,
+                    "example": 12
+                },
+                "is_fixed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Monthly Rent"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "tags": {
+                    "description": "Tags are free-form labels; they are lowercased, trimmed and deduplicated before saving",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vacation",
+                        "travel"
+                    ]
+                }
+            }
+        },
+        "dtos.AddGoalContributionDTO": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 250
+                },
+                "contributed_at": {
+                    "type": "string",
+                    "example": "2025-01-31T09:00:00Z"
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Bonus"
+                }
+            }
+        },
+        "dtos.AddIncomeDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "frequency",
+                "source"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "force": {
+                    "description": "Force adds the income even if it duplicates a recently added one",
+                    "type": "boolean",
+                    "example": false
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Software Engineer Salary"
+                }
+            }
+        },
+        "dtos.AddLoanDTO": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "interest_rate",
+                "lender",
+                "monthly_payment",
+                "principal_amount",
+                "remaining_balance",
+                "type"
+            ],
+            "properties": {
+                "auto_accrue_interest": {
+                    "description": "AutoAccrueInterest adds a month of interest to the balance at the start of every month",
+                    "type": "boolean",
+                    "example": false
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2054-01-15T00:00:00Z"
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 4.5
+                },
+                "lender": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Chase Bank"
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "principal_amount": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "remaining_balance": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 245000
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "mortgage",
+                        "auto",
+                        "personal",
+                        "student"
+                    ],
+                    "example": "mortgage"
+                }
+            }
+        },
+        "dtos.AddSavingsGoalDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "priority",
+                "target_amount",
+                "target_date"
+            ],
+            "properties": {
+                "current_amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1500
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Emergency Fund"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "target_amount": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                }
+            }
+        },
+        "dtos.AffordabilityResponseDTO": {
+            "type": "object",
+            "properties": {
+                "calculation_date": {
+                    "type": "string",
+                    "example": "now"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "max_affordable_amount": {
+                    "type": "number",
+                    "example": 1599.87
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.AuditEntryDTO": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "update"
+                },
+                "changes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/domain.AuditChange"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "audit-2f6c1f5e-8f0b-4c1e-9d7a-3b1d2c4e5f60"
+                },
+                "ip_address": {
+                    "type": "string",
+                    "example": "203.0.113.7"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "req-1705314600000000000"
+                },
+                "resource_id": {
+                    "type": "string",
+                    "example": "income-123"
+                },
+                "resource_type": {
+                    "type": "string",
+                    "example": "income"
+                },
+                "user_agent": {
+                    "type": "string",
+                    "example": "Mozilla/5.0"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-123"
+                }
+            }
+        },
+        "dtos.AuditLogPageResponseDTO": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.AuditEntryDTO"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "next_cursor": {
+                    "type": "string",
+                    "example": "eyJjIjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpIjoiYXVkaXQtMTIzIn0"
+                }
+            }
+        },
+        "dtos.BudgetResponseDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "food"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "budget-123"
+                },
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 600
+                },
+                "rollover": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.BudgetStatusDTO": {
+            "type": "object",
+            "properties": {
+                "budget_id": {
+                    "type": "string",
+                    "example": "budget-123"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "food"
+                },
+                "limit": {
+                    "type": "number",
+                    "example": 650
+                },
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 600
+                },
+                "over_budget": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "percent_used": {
+                    "type": "number",
+                    "example": 110.77
+                },
+                "remaining": {
+                    "type": "number",
+                    "example": -70
+                },
+                "rollover_amount": {
+                    "type": "number",
+                    "example": 50
+                },
+                "spent": {
+                    "type": "number",
+                    "example": 720
+                }
+            }
+        },
+        "dtos.BulkDeleteExpensesDTO": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "maxItems": 100,
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "expense-123",
+                        "expense-456"
+                    ]
+                }
+            }
+        },
+        "dtos.BulkDeleteExpensesResponseDTO": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Expenses deleted successfully"
+                }
+            }
+        },
+        "dtos.ChangePasswordDTO": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "OldPassword123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "NewPassword456!"
+                }
+            }
+        },
+        "dtos.ComparePoliciesRequestDTO": {
+            "type": "object",
+            "properties": {
+                "expected_annual_spend": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "policies": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/dtos.PolicyCandidateDTO"
+                    }
+                }
+            }
+        },
+        "dtos.ConditionCatalogEntryDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "chronic"
+                },
+                "default_severity": {
+                    "type": "string",
+                    "example": "moderate"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "E11"
+                },
+                "matched_name": {
+                    "description": "the name or alias the query matched",
+                    "type": "string",
+                    "example": "diabetes type ii"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Type 2 Diabetes"
+                },
+                "risk_weight": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 80
+                }
+            }
+        },
+        "dtos.ConditionCatalogSearchResponseDTO": {
+            "type": "object",
+            "properties": {
+                "query": {
+                    "type": "string",
+                    "example": "diab"
+                },
+                "suggestions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ConditionCatalogEntryDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dtos.ConditionSeverityDTO": {
+            "type": "object",
+            "required": [
+                "condition_id",
+                "severity"
+            ],
+            "properties": {
+                "condition_id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe",
+                        "critical"
+                    ],
+                    "example": "mild"
+                }
+            }
+        },
+        "dtos.ConditionStatusChangeDTO": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ConditionTimelineEntryDTO": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "$ref": "#/definitions/dtos.MedicalConditionResponseDTO"
+                },
+                "days_since_diagnosis": {
+                    "type": "integer"
+                },
+                "duration_days": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "status_history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ConditionStatusChangeDTO"
+                    }
+                }
+            }
+        },
+        "dtos.ConditionTimelineResponseDTO": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ConditionTimelineEntryDTO"
+                    }
+                },
+                "resolved": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ConditionTimelineEntryDTO"
+                    }
+                }
+            }
+        },
+        "dtos.CostProjectionResponseDTO": {
+            "type": "object",
+            "properties": {
+                "annual_premiums": {
+                    "type": "number"
+                },
+                "covered_gross_cost": {
+                    "type": "number"
+                },
+                "excluded_one_time_expenses": {
+                    "type": "integer"
+                },
+                "expected_insurance_payments": {
+                    "type": "number"
+                },
+                "net_out_of_pocket": {
+                    "type": "number"
+                },
+                "projected_gross_cost": {
+                    "type": "number"
+                },
+                "recurring_expenses": {
+                    "type": "integer"
+                },
+                "uncovered_gross_cost": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CoverageGapDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "estimated_exposure": {
+                    "type": "number"
+                },
+                "recommendation": {
+                    "type": "string"
+                },
+                "risk_level": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CoverageGapsResponseDTO": {
+            "type": "object",
+            "properties": {
+                "coverage_lapses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CoverageLapseDTO"
+                    }
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CoverageGapDTO"
+                    }
+                },
+                "recurring_monthly_cost": {
+                    "type": "number"
+                },
+                "uncovered_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.UncoveredCategoryDTO"
+                    }
+                },
+                "uncovered_recurring_monthly": {
+                    "type": "number"
+                },
+                "uncovered_recurring_share": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CoverageLapseDTO": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "integer"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateAPIKeyDTO": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Nightly export"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "finance:read"
+                    ]
+                }
+            }
+        },
+        "dtos.CreateDependentProfileRequestDTO": {
+            "type": "object",
+            "required": [
+                "age",
+                "gender",
+                "height",
+                "name",
+                "relation_to_owner",
+                "weight"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "height": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "relation_to_owner": {
+                    "type": "string",
+                    "enum": [
+                        "spouse",
+                        "child",
+                        "parent",
+                        "other"
+                    ]
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.CreateExpenseOccurrenceRequestDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "date"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "example": 112.5
+                },
+                "date": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateHealthProfileRequestDTO": {
+            "type": "object",
+            "required": [
+                "age",
+                "family_size",
+                "gender",
+                "height",
+                "user_id",
+                "weight"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "emergency_fund_health": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "family_size": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "has_chronic_conditions": {
+                    "type": "boolean"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.CreateInsurancePolicyRequestDTO": {
+            "type": "object",
+            "required": [
+                "coverage_percentage",
+                "deductible",
+                "end_date",
+                "monthly_premium",
+                "out_of_pocket_max",
+                "policy_number",
+                "provider",
+                "start_date",
+                "type",
+                "user_id"
+            ],
+            "properties": {
+                "coverage_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "deductible": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_premium": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "out_of_pocket_max": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "policy_number": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "health",
+                        "dental",
+                        "vision",
+                        "life",
+                        "disability"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateMedicalConditionRequestDTO": {
+            "type": "object",
+            "required": [
+                "diagnosed_date",
+                "user_id"
+            ],
+            "properties": {
+                "catalog_id": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "chronic",
+                        "acute",
+                        "mental_health",
+                        "preventive"
+                    ]
+                },
+                "diagnosed_date": {
+                    "type": "string"
+                },
+                "family_member_id": {
+                    "description": "optional alias for profile_id naming a dependent",
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_med_cost": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "profile_id": {
+                    "description": "optional, defaults to the owner's profile",
+                    "type": "string"
+                },
+                "requires_medication": {
+                    "type": "boolean"
+                },
+                "risk_factor": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe",
+                        "critical"
+                    ]
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateMedicalExpenseRequestDTO": {
+            "type": "object",
+            "required": [
+                "amount",
+                "category",
+                "date",
+                "description",
+                "frequency",
+                "user_id"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "maximum": 1000000000
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "doctor_visit",
+                        "medication",
+                        "hospital",
+                        "lab_test",
+                        "therapy",
+                        "equipment"
+                    ]
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "family_member_id": {
+                    "description": "optional alias for profile_id naming a dependent",
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string",
+                    "enum": [
+                        "one_time",
+                        "daily",
+                        "weekly",
+                        "biweekly",
+                        "bi-weekly",
+                        "monthly",
+                        "quarterly",
+                        "semiannual",
+                        "semiannually",
+                        "semi-annually",
+                        "annual",
+                        "annually",
+                        "yearly"
+                    ]
+                },
+                "insurance_payment": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "is_covered": {
+                    "type": "boolean"
+                },
+                "is_recurring": {
+                    "type": "boolean"
+                },
+                "out_of_pocket": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "profile_id": {
+                    "description": "optional, defaults to the owner's profile",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateMedicationScheduleRequestDTO": {
+            "type": "object",
+            "required": [
+                "condition_id",
+                "last_filled_date",
+                "medication_name",
+                "refill_every_days"
+            ],
+            "properties": {
+                "condition_id": {
+                    "type": "string"
+                },
+                "last_filled_date": {
+                    "type": "string"
+                },
+                "medication_name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "refill_every_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CreateWebhookDTO": {
+            "type": "object",
+            "required": [
+                "event_types",
+                "url"
+            ],
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "financial_health.changed"
+                    ]
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "0123456789abcdef0123456789abcdef"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://hooks.example.com/buyorbye"
+                }
+            }
+        },
+        "dtos.DebtBreakdownResponseDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "total_balance": {
+                    "type": "number",
+                    "example": 270000
+                },
+                "total_monthly_payment": {
+                    "type": "number",
+                    "example": 2100
+                },
+                "types": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.LoanTypeDebtDTO"
+                    }
+                }
+            }
+        },
+        "dtos.DeductibleProgressDTO": {
+            "type": "object",
+            "properties": {
+                "deductible": {
+                    "type": "number"
+                },
+                "deductible_met": {
+                    "type": "number"
+                },
+                "deductible_remaining": {
+                    "type": "number"
+                },
+                "out_of_pocket_max": {
+                    "type": "number"
+                },
+                "out_of_pocket_met": {
+                    "type": "number"
+                },
+                "out_of_pocket_remaining": {
+                    "type": "number"
+                },
+                "policy_id": {
+                    "type": "string"
+                },
+                "projected_deductible_met_month": {
+                    "type": "string",
+                    "example": "2025-09"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "will_meet_deductible_this_year": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.DeleteAccountRequestDTO": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "SecurePassword123"
+                }
+            }
+        },
+        "dtos.DemoDataBatchResponseDTO": {
+            "type": "object",
+            "properties": {
+                "batch_id": {
+                    "type": "string",
+                    "example": "demo-5b0f6c1e-8d3a-4c47-9f2e-1a7b3c9d2e4f"
+                },
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
+        "dtos.DemoDataRemovedResponseDTO": {
+            "type": "object",
+            "properties": {
+                "removed": {
+                    "type": "integer",
+                    "example": 22
+                }
+            }
+        },
+        "dtos.DuplicateRecordResponseDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ErrorCode"
+                        }
+                    ],
+                    "example": "FIN_INCOME_NOT_FOUND"
+                },
+                "existing_id": {
+                    "type": "string",
+                    "example": "expense-123"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "BAD_REQUEST",
+                "VALIDATION_FAILED",
+                "AUTH_REQUIRED",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "CONFLICT",
+                "UNPROCESSABLE",
+                "PAYLOAD_TOO_LARGE",
+                "TOO_MANY_REQUESTS",
+                "TIMEOUT",
+                "REQUEST_CANCELED",
+                "INTERNAL_ERROR",
+                "AUTH_INVALID_CREDENTIALS",
+                "AUTH_ACCOUNT_INACTIVE",
+                "AUTH_INVALID_TOKEN",
+                "AUTH_TOKEN_EXPIRED",
+                "AUTH_TOKEN_REVOKED",
+                "AUTH_USER_EXISTS",
+                "AUTH_INVALID_USER_DATA",
+                "AUTH_INVALID_API_KEY",
+                "AUTH_API_KEY_REVOKED",
+                "AUTH_API_KEY_NOT_FOUND",
+                "AUTH_INVALID_API_KEY_DATA",
+                "AUTH_INSUFFICIENT_SCOPE",
+                "AUTH_CSRF_TOKEN_INVALID",
+                "FIN_INCOME_NOT_FOUND",
+                "FIN_EXPENSE_NOT_FOUND",
+                "FIN_LOAN_NOT_FOUND",
+                "FIN_SAVINGS_GOAL_NOT_FOUND",
+                "FIN_SUMMARY_NOT_FOUND",
+                "FIN_INCOME_NOT_OWNED",
+                "FIN_EXPENSE_NOT_OWNED",
+                "FIN_LOAN_NOT_OWNED",
+                "FIN_SAVINGS_GOAL_NOT_OWNED",
+                "FIN_ACCESS_DENIED",
+                "FIN_INVALID_DATA",
+                "FIN_INVALID_INCOME",
+                "FIN_INVALID_EXPENSE",
+                "FIN_INVALID_LOAN",
+                "FIN_INVALID_SAVINGS_GOAL",
+                "FIN_INVALID_EXPENSE_FILTER",
+                "FIN_INVALID_CURSOR",
+                "FIN_UNSUPPORTED_CURRENCY",
+                "FIN_DUPLICATE_RECORD",
+                "FIN_INSTALLMENTS_COMPLETE",
+                "FIN_PAYMENT_BELOW_INTEREST",
+                "FIN_INVALID_PURCHASE",
+                "FIN_NO_INCOME",
+                "FIN_BUDGET_NOT_FOUND",
+                "FIN_BUDGET_NOT_OWNED",
+                "FIN_BUDGET_EXISTS",
+                "FIN_INVALID_BUDGET",
+                "HEALTH_PROFILE_NOT_FOUND",
+                "HEALTH_FAMILY_MEMBER_NOT_FOUND",
+                "HEALTH_PROFILE_EXISTS",
+                "HEALTH_PROFILE_REQUIRED",
+                "HEALTH_CONDITION_NOT_FOUND",
+                "HEALTH_POLICY_NOT_FOUND",
+                "HEALTH_POLICY_EXISTS",
+                "HEALTH_NO_POLICIES",
+                "HEALTH_ACCESS_DENIED",
+                "HEALTH_INVALID_DATA",
+                "HEALTH_EXPENSE_NOT_FOUND",
+                "HEALTH_EXPENSE_NOT_RECURRING",
+                "HEALTH_ATTACHMENT_NOT_FOUND",
+                "HEALTH_ATTACHMENT_TOO_LARGE",
+                "HEALTH_UNSUPPORTED_MEDIA_TYPE",
+                "HEALTH_CATALOG_ENTRY_NOT_FOUND",
+                "REPORT_INVALID_PERIOD",
+                "REPORT_PDF_UNAVAILABLE"
+            ],
+            "x-enum-varnames": [
+                "ErrorCodeBadRequest",
+                "ErrorCodeValidationFailed",
+                "ErrorCodeAuthRequired",
+                "ErrorCodeForbidden",
+                "ErrorCodeNotFound",
+                "ErrorCodeConflict",
+                "ErrorCodeUnprocessable",
+                "ErrorCodePayloadTooLarge",
+                "ErrorCodeTooManyRequests",
+                "ErrorCodeTimeout",
+                "ErrorCodeRequestCanceled",
+                "ErrorCodeInternal",
+                "ErrorCodeAuthInvalidCredentials",
+                "ErrorCodeAuthAccountInactive",
+                "ErrorCodeAuthInvalidToken",
+                "ErrorCodeAuthTokenExpired",
+                "ErrorCodeAuthTokenRevoked",
+                "ErrorCodeAuthUserExists",
+                "ErrorCodeAuthInvalidUserData",
+                "ErrorCodeAuthInvalidAPIKey",
+                "ErrorCodeAuthAPIKeyRevoked",
+                "ErrorCodeAuthAPIKeyNotFound",
+                "ErrorCodeAuthInvalidAPIKeyData",
+                "ErrorCodeAuthInsufficientScope",
+                "ErrorCodeAuthCSRFTokenInvalid",
+                "ErrorCodeFinIncomeNotFound",
+                "ErrorCodeFinExpenseNotFound",
+                "ErrorCodeFinLoanNotFound",
+                "ErrorCodeFinSavingsGoalNotFound",
+                "ErrorCodeFinSummaryNotFound",
+                "ErrorCodeFinIncomeNotOwned",
+                "ErrorCodeFinExpenseNotOwned",
+                "ErrorCodeFinLoanNotOwned",
+                "ErrorCodeFinSavingsGoalNotOwned",
+                "ErrorCodeFinAccessDenied",
+                "ErrorCodeFinInvalidData",
+                "ErrorCodeFinInvalidIncome",
+                "ErrorCodeFinInvalidExpense",
+                "ErrorCodeFinInvalidLoan",
+                "ErrorCodeFinInvalidSavingsGoal",
+                "ErrorCodeFinInvalidExpenseFilter",
+                "ErrorCodeFinInvalidCursor",
+                "ErrorCodeFinUnsupportedCurrency",
+                "ErrorCodeFinDuplicateRecord",
+                "ErrorCodeFinInstallmentsComplete",
+                "ErrorCodeFinPaymentBelowInterest",
+                "ErrorCodeFinInvalidPurchase",
+                "ErrorCodeFinNoIncome",
+                "ErrorCodeFinBudgetNotFound",
+                "ErrorCodeFinBudgetNotOwned",
+                "ErrorCodeFinBudgetExists",
+                "ErrorCodeFinInvalidBudget",
+                "ErrorCodeHealthProfileNotFound",
+                "ErrorCodeHealthFamilyMemberNotFound",
+                "ErrorCodeHealthProfileExists",
+                "ErrorCodeHealthProfileRequired",
+                "ErrorCodeHealthConditionNotFound",
+                "ErrorCodeHealthPolicyNotFound",
+                "ErrorCodeHealthPolicyExists",
+                "ErrorCodeHealthNoPolicies",
+                "ErrorCodeHealthAccessDenied",
+                "ErrorCodeHealthInvalidData",
+                "ErrorCodeHealthExpenseNotFound",
+                "ErrorCodeHealthExpenseNotRecurring",
+                "ErrorCodeHealthAttachmentNotFound",
+                "ErrorCodeHealthAttachmentTooLarge",
+                "ErrorCodeHealthUnsupportedMediaType",
+                "ErrorCodeHealthCatalogEntryNotFound",
+                "ErrorCodeReportInvalidPeriod",
+                "ErrorCodeReportPDFUnavailable"
+            ]
+        },
+        "dtos.ErrorResponseDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ErrorCode"
+                        }
+                    ],
+                    "example": "FIN_INCOME_NOT_FOUND"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ExpenseAnalyticsResponseDTO": {
+            "type": "object",
+            "properties": {
+                "by_category": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseCategoryTotalDTO"
+                    }
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "insurance_paid_total": {
+                    "type": "number"
+                },
+                "monthly_run_rate": {
+                    "type": "number"
+                },
+                "out_of_pocket_total": {
+                    "type": "number"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.DeductibleProgressDTO"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                },
+                "year_to_date_total": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ExpenseAttachmentListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseAttachmentResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ExpenseAttachmentResponseDTO": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "application/pdf"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "string",
+                    "example": "7"
+                },
+                "file_name": {
+                    "type": "string",
+                    "example": "eob-march.pdf"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "size": {
+                    "type": "integer",
+                    "example": 48213
+                }
+            }
+        },
+        "dtos.ExpenseBreakdownResponseDTO": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseCategoryBreakdownDTO"
+                    }
+                },
+                "covered_amount": {
+                    "type": "number"
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "out_of_pocket_amount": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ExpenseCategoryBreakdownDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "covered_amount": {
+                    "type": "number"
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "out_of_pocket_amount": {
+                    "type": "number"
+                },
+                "share": {
+                    "type": "number"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ExpenseCategoryTotalDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "number"
+                },
+                "total_insurance_paid": {
+                    "type": "number"
+                },
+                "total_out_of_pocket": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ExpenseCutDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "entertainment"
+                },
+                "expense_id": {
+                    "type": "string",
+                    "example": "expense-123"
+                },
+                "monthly_savings": {
+                    "type": "number",
+                    "example": 300
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Dining out"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dtos.ExpenseCutSuggestionResponseDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "cuts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseCutDTO"
+                    }
+                },
+                "disposable_income": {
+                    "type": "number",
+                    "example": 850
+                },
+                "resulting_disposable_income": {
+                    "type": "number",
+                    "example": 1300
+                },
+                "shortfall": {
+                    "type": "number",
+                    "example": 0
+                },
+                "target_reachable": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "target_savings": {
+                    "type": "number",
+                    "example": 400
+                },
+                "total_savings": {
+                    "type": "number",
+                    "example": 450
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.ExpenseOccurrenceListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseOccurrenceResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ExpenseOccurrenceResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 112.5
+                },
+                "category": {
+                    "type": "string",
+                    "example": "medication"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "expense_id": {
+                    "type": "string",
+                    "example": "7"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "31"
+                },
+                "insurance_payment": {
+                    "type": "number",
+                    "example": 90
+                },
+                "is_covered": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "out_of_pocket": {
+                    "type": "number",
+                    "example": 22.5
+                },
+                "profile_id": {
+                    "type": "string",
+                    "example": "3"
+                }
+            }
+        },
+        "dtos.ExpenseResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1200
+                },
+                "category": {
+                    "type": "string",
+                    "example": "housing"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "final_installment_date": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "expense-123"
+                },
+                "installment_amount": {
+                    "type": "number",
+                    "example": 100
+                },
+                "installment_start": {
+                    "type": "string",
+                    "example": "2024-02-01T00:00:00Z"
+                },
+                "installments_paid": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "installments_total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "is_active": {
+                    "description": "IsActive is false once every installment of an installment expense is paid",
+                    "type": "boolean",
+                    "example": true
+                },
+                "is_fixed": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Monthly Rent"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vacation",
+                        "travel"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.ExpiringPolicyListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpiringPolicyResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "within_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ExpiringPolicyResponseDTO": {
+            "type": "object",
+            "properties": {
+                "coverage_percentage": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "days_until_expiry": {
+                    "type": "integer"
+                },
+                "deductible": {
+                    "type": "number"
+                },
+                "deductible_met": {
+                    "type": "number"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_premium": {
+                    "type": "number"
+                },
+                "out_of_pocket_current": {
+                    "type": "number"
+                },
+                "out_of_pocket_max": {
+                    "type": "number"
+                },
+                "policy_number": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.FamilyMemberRollupDTO": {
+            "type": "object",
+            "properties": {
+                "active_conditions": {
+                    "type": "integer"
+                },
+                "health_risk_level": {
+                    "type": "string"
+                },
+                "health_risk_score": {
+                    "type": "integer"
+                },
+                "monthly_medical_cost": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "projected_annual_cost": {
+                    "type": "number"
+                },
+                "relation_to_owner": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.FamilyProfilesResponseDTO": {
+            "type": "object",
+            "properties": {
+                "profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HealthProfileResponseDTO"
+                    }
+                },
+                "rollup": {
+                    "$ref": "#/definitions/dtos.FamilyRollupDTO"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.FamilyRollupDTO": {
+            "type": "object",
+            "properties": {
+                "average_risk_score": {
+                    "type": "number"
+                },
+                "highest_risk_level": {
+                    "type": "string"
+                },
+                "highest_risk_score": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.FamilyMemberRollupDTO"
+                    }
+                },
+                "total_monthly_medical_cost": {
+                    "type": "number"
+                },
+                "total_projected_annual_cost": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.FinanceSummaryResponseDTO": {
+            "type": "object",
+            "properties": {
+                "budget_remaining": {
+                    "type": "number",
+                    "example": 533.29
+                },
+                "budgets": {
+                    "description": "Budgets compares each budget with this month's spending in its category;\noverspent_categories lists up to three over-budget categories, the most overspent first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BudgetStatusDTO"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "debt_to_income_ratio": {
+                    "type": "number",
+                    "example": 0.253
+                },
+                "disposable_income": {
+                    "type": "number",
+                    "example": 533.29
+                },
+                "financial_health": {
+                    "type": "string",
+                    "example": "Good"
+                },
+                "financial_health_components": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HealthScoreComponentDTO"
+                    }
+                },
+                "financial_health_score": {
+                    "description": "FinancialHealthScore is the 0-100 score financial_health is derived from, and\nFinancialHealthComponents what it is built from",
+                    "type": "number",
+                    "example": 71.4
+                },
+                "goal_projections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GoalProjectionDTO"
+                    }
+                },
+                "goal_warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "goals_achievable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "goals_monthly_commitment": {
+                    "type": "number",
+                    "example": 708.33
+                },
+                "installments": {
+                    "description": "Installments lists the installment expenses still being paid off and when each drops\nout of monthly_expenses",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.InstallmentScheduleDTO"
+                    }
+                },
+                "monthly_expenses": {
+                    "type": "number",
+                    "example": 3200
+                },
+                "monthly_income": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "monthly_loan_payments": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "overspent_categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BudgetStatusDTO"
+                    }
+                },
+                "savings_rate": {
+                    "type": "number",
+                    "example": 0.107
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.FinancialHealthExplanationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "debt_to_income_ratio": {
+                    "type": "number",
+                    "example": 0.2
+                },
+                "disposable_income": {
+                    "type": "number",
+                    "example": 400
+                },
+                "financial_health": {
+                    "type": "string",
+                    "example": "Fair"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "DTI of 20% is healthy",
+                        "savings rate of 8% is below the 10% minimum"
+                    ]
+                },
+                "savings_rate": {
+                    "type": "number",
+                    "example": 0.08
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.FinancialResilienceDTO": {
+            "type": "object",
+            "properties": {
+                "emergency_fund_coverage_months": {
+                    "type": "number",
+                    "example": 2.4
+                },
+                "grade": {
+                    "type": "string",
+                    "example": "C"
+                },
+                "health_adjusted_disposable_income": {
+                    "type": "number",
+                    "example": 283.29
+                },
+                "includes_health": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "score": {
+                    "type": "integer",
+                    "example": 62
+                }
+            }
+        },
+        "dtos.GoalContributionMonthDTO": {
+            "type": "object",
+            "properties": {
+                "contributions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.GoalContributionResponseDTO"
+                    }
+                },
+                "month": {
+                    "type": "string",
+                    "example": "2025-01"
+                },
+                "total": {
+                    "type": "number",
+                    "example": 250
+                }
+            }
+        },
+        "dtos.GoalContributionResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 250
+                },
+                "contributed_at": {
+                    "type": "string",
+                    "example": "2025-01-31T09:00:00Z"
+                },
+                "goal_id": {
+                    "type": "string",
+                    "example": "goal-123"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "contrib-123"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Bonus"
+                }
+            }
+        },
+        "dtos.GoalProjectionDTO": {
+            "type": "object",
+            "properties": {
+                "achievable": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "allocated_monthly": {
+                    "type": "number",
+                    "example": 533.29
+                },
+                "goal_id": {
+                    "type": "string",
+                    "example": "goal-123"
+                },
+                "months_remaining": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emergency Fund"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "projected_amount": {
+                    "type": "number",
+                    "example": 7899.48
+                },
+                "required_monthly": {
+                    "type": "number",
+                    "example": 708.33
+                },
+                "shortfall": {
+                    "type": "number",
+                    "example": 2100.52
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                }
+            }
+        },
+        "dtos.HSARecommendationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "annual_contribution_limit": {
+                    "type": "number"
+                },
+                "coverage_tier": {
+                    "type": "string"
+                },
+                "eligible": {
+                    "type": "boolean"
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "projected_annual_expenses": {
+                    "type": "number"
+                },
+                "qualifying_policy_id": {
+                    "type": "string"
+                },
+                "recommended_annual_contribution": {
+                    "type": "number"
+                },
+                "recommended_monthly_contribution": {
+                    "type": "number"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HealthProfileResponseDTO": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "bmi": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "emergency_fund_health": {
+                    "type": "number"
+                },
+                "family_size": {
+                    "type": "integer"
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "has_chronic_conditions": {
+                    "type": "boolean"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "relation_to_owner": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.HealthScoreComponentDTO": {
+            "type": "object",
+            "properties": {
+                "contribution": {
+                    "type": "number",
+                    "example": 23.19
+                },
+                "name": {
+                    "type": "string",
+                    "example": "debt_to_income"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 83.5
+                },
+                "value": {
+                    "type": "number",
+                    "example": 0.253
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 0.278
+                }
+            }
+        },
+        "dtos.HealthSummaryResponseDTO": {
+            "type": "object",
+            "properties": {
+                "annual_deductible_remaining": {
+                    "type": "number"
+                },
+                "coverage_gap_risk": {
+                    "type": "number"
+                },
+                "emergency_fund_balance": {
+                    "type": "number"
+                },
+                "financial_vulnerability": {
+                    "type": "string"
+                },
+                "health_risk_level": {
+                    "type": "string"
+                },
+                "health_risk_score": {
+                    "type": "integer"
+                },
+                "member_expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MemberMedicalExpensesDTO"
+                    }
+                },
+                "monthly_insurance_premiums": {
+                    "type": "number"
+                },
+                "monthly_medical_expenses": {
+                    "type": "number"
+                },
+                "out_of_pocket_remaining": {
+                    "type": "number"
+                },
+                "out_of_pocket_statuses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OutOfPocketStatusDTO"
+                    }
+                },
+                "priority_adjustment": {
+                    "type": "number"
+                },
+                "recommended_emergency_fund": {
+                    "type": "number"
+                },
+                "recurring_variance": {
+                    "description": "RecurringVariance is present when actual costs of recurring expenses can be recorded",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.RecurringExpenseVarianceDTO"
+                        }
+                    ]
+                },
+                "total_health_costs": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.HypotheticalConditionDTO": {
+            "type": "object",
+            "required": [
+                "category",
+                "name",
+                "severity"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "chronic",
+                        "acute",
+                        "mental_health",
+                        "preventive"
+                    ],
+                    "example": "chronic"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Type 2 diabetes"
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe",
+                        "critical"
+                    ],
+                    "example": "moderate"
+                }
+            }
+        },
+        "dtos.IncomeDiversificationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "concentration_index": {
+                    "type": "number",
+                    "example": 0.375
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "largest_source_share": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "monthly_income": {
+                    "type": "number",
+                    "example": 6000
+                },
+                "single_source_dependent": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "source_count": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.IncomeSourceShareDTO"
+                    }
+                }
+            }
+        },
+        "dtos.IncomeResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "income-123"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "source": {
+                    "type": "string",
+                    "example": "Software Engineer Salary"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.IncomeSourceShareDTO": {
+            "type": "object",
+            "properties": {
+                "income_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "monthly_amount": {
+                    "type": "number",
+                    "example": 3000
+                },
+                "share": {
+                    "type": "number",
+                    "example": 0.5
+                },
+                "source": {
+                    "type": "string",
+                    "example": "Salary"
+                }
+            }
+        },
+        "dtos.InstallmentScheduleDTO": {
+            "type": "object",
+            "properties": {
+                "expense_id": {
+                    "type": "string",
+                    "example": "expense-123"
+                },
+                "final_installment_date": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "installments_paid": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "installments_total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "monthly_amount": {
+                    "type": "number",
+                    "example": 100
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Laptop"
+                }
+            }
+        },
+        "dtos.InsuranceEvaluationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PolicyAdequacyDTO"
+                    }
+                },
+                "projected_annual_expenses": {
+                    "type": "number"
+                },
+                "risk_level": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.InsurancePolicyListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.InsurancePolicyResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.InsurancePolicyResponseDTO": {
+            "type": "object",
+            "properties": {
+                "coverage_percentage": {
+                    "type": "number"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deductible": {
+                    "type": "number"
+                },
+                "deductible_met": {
+                    "type": "number"
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_premium": {
+                    "type": "number"
+                },
+                "out_of_pocket_current": {
+                    "type": "number"
+                },
+                "out_of_pocket_max": {
+                    "type": "number"
+                },
+                "policy_number": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LoanBalanceEntryResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-06-01T09:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "loanbal-123"
+                },
+                "interest_portion": {
+                    "type": "number",
+                    "example": 918.75
+                },
+                "loan_id": {
+                    "type": "string",
+                    "example": "loan-123"
+                },
+                "new_balance": {
+                    "type": "number",
+                    "example": 244652.04
+                },
+                "note": {
+                    "type": "string",
+                    "example": "June payment"
+                },
+                "old_balance": {
+                    "type": "number",
+                    "example": 245000
+                },
+                "principal_portion": {
+                    "type": "number",
+                    "example": 347.96
+                },
+                "type": {
+                    "type": "string",
+                    "example": "payment"
+                }
+            }
+        },
+        "dtos.LoanExtraPaymentDTO": {
+            "type": "object",
+            "required": [
+                "extra_monthly_payment"
+            ],
+            "properties": {
+                "extra_monthly_payment": {
+                    "type": "number",
+                    "example": 500
+                }
+            }
+        },
+        "dtos.LoanExtraPaymentImpactResponseDTO": {
+            "type": "object",
+            "properties": {
+                "baseline_months": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "baseline_payoff_date": {
+                    "type": "string",
+                    "example": "2055-01-15T00:00:00Z"
+                },
+                "baseline_total_interest": {
+                    "type": "number",
+                    "example": 347509.14
+                },
+                "extra_monthly_payment": {
+                    "type": "number",
+                    "example": 500
+                },
+                "interest_saved": {
+                    "type": "number",
+                    "example": 160290.92
+                },
+                "loan_id": {
+                    "type": "string",
+                    "example": "loan-123"
+                },
+                "months": {
+                    "type": "integer",
+                    "example": 212
+                },
+                "months_saved": {
+                    "type": "integer",
+                    "example": 148
+                },
+                "near_payoff": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "payoff_date": {
+                    "type": "string",
+                    "example": "2042-09-15T00:00:00Z"
+                },
+                "total_interest": {
+                    "type": "number",
+                    "example": 187218.22
+                }
+            }
+        },
+        "dtos.LoanPaymentDTO": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "June payment"
+                }
+            }
+        },
+        "dtos.LoanPayoffSimulationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "baseline_months": {
+                    "type": "integer",
+                    "example": 348
+                },
+                "baseline_payoff_date": {
+                    "type": "string",
+                    "example": "2054-01-15T00:00:00Z"
+                },
+                "baseline_total_interest": {
+                    "type": "number",
+                    "example": 195811.42
+                },
+                "debt_to_income_ratio": {
+                    "type": "number",
+                    "example": 0.253
+                },
+                "debt_to_income_ratio_after_payoff": {
+                    "type": "number",
+                    "example": 0
+                },
+                "extra_monthly_payment": {
+                    "type": "number",
+                    "example": 200
+                },
+                "interest_saved": {
+                    "type": "number",
+                    "example": 63306.55
+                },
+                "loan_id": {
+                    "type": "string",
+                    "example": "loan-123"
+                },
+                "months": {
+                    "type": "integer",
+                    "example": 257
+                },
+                "months_saved": {
+                    "type": "integer",
+                    "example": 91
+                },
+                "one_time_payment": {
+                    "type": "number",
+                    "example": 5000
+                },
+                "payoff_date": {
+                    "type": "string",
+                    "example": "2046-06-15T00:00:00Z"
+                },
+                "simulated_debt_to_income_ratio": {
+                    "type": "number",
+                    "example": 0.293
+                },
+                "total_interest": {
+                    "type": "number",
+                    "example": 132504.87
+                }
+            }
+        },
+        "dtos.LoanResponseDTO": {
+            "type": "object",
+            "properties": {
+                "auto_accrue_interest": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2054-01-15T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "loan-123"
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "lender": {
+                    "type": "string",
+                    "example": "Chase Bank"
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "principal_amount": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "remaining_balance": {
+                    "type": "number",
+                    "example": 245000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "mortgage"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.LoanTypeDebtDTO": {
+            "type": "object",
+            "properties": {
+                "loan_count": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 1800
+                },
+                "remaining_balance": {
+                    "type": "number",
+                    "example": 240000
+                },
+                "share_percent": {
+                    "type": "number",
+                    "example": 88.89
+                },
+                "type": {
+                    "type": "string",
+                    "example": "mortgage"
+                }
+            }
+        },
+        "dtos.LoginRequestDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "dtos.MedicalConditionCreatedResponseDTO": {
+            "type": "object",
+            "properties": {
+                "condition": {
+                    "$ref": "#/definitions/dtos.MedicalConditionResponseDTO"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Condition added successfully"
+                }
+            }
+        },
+        "dtos.MedicalConditionListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MedicalConditionResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.MedicalConditionResponseDTO": {
+            "type": "object",
+            "properties": {
+                "catalog_id": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "diagnosed_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_med_cost": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "requires_medication": {
+                    "type": "boolean"
+                },
+                "resolved_date": {
+                    "type": "string"
+                },
+                "risk_factor": {
+                    "type": "number"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "uncatalogued": {
+                    "description": "true for a free-text condition not added from the catalog",
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MedicalExpenseListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MedicalExpenseResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.MedicalExpenseResponseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "frequency": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "insurance_payment": {
+                    "type": "number"
+                },
+                "is_covered": {
+                    "type": "boolean"
+                },
+                "is_recurring": {
+                    "type": "boolean"
+                },
+                "out_of_pocket": {
+                    "type": "number"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MedicationRefillListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "refills": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MedicationRefillResponseDTO"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "within_days": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.MedicationRefillResponseDTO": {
+            "type": "object",
+            "properties": {
+                "condition_id": {
+                    "type": "string"
+                },
+                "days_until_due": {
+                    "type": "integer"
+                },
+                "due_date": {
+                    "type": "string"
+                },
+                "is_overdue": {
+                    "type": "boolean"
+                },
+                "last_filled_date": {
+                    "type": "string"
+                },
+                "medication_name": {
+                    "type": "string"
+                },
+                "refill_every_days": {
+                    "type": "integer"
+                },
+                "schedule_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MemberMedicalExpensesDTO": {
+            "type": "object",
+            "properties": {
+                "monthly_medical_expenses": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "profile_id": {
+                    "type": "string"
+                },
+                "relation_to_owner": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.MessageResponseDTO": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Income added successfully"
+                }
+            }
+        },
+        "dtos.NearPayoffLoanResponseDTO": {
+            "type": "object",
+            "properties": {
+                "auto_accrue_interest": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2054-01-15T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "loan-123"
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "example": 4.5
+                },
+                "lender": {
+                    "type": "string",
+                    "example": "Chase Bank"
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 1266.71
+                },
+                "payoff_threshold_balance": {
+                    "type": "number",
+                    "example": 2500
+                },
+                "percent_remaining": {
+                    "type": "number",
+                    "example": 4
+                },
+                "principal_amount": {
+                    "type": "number",
+                    "example": 250000
+                },
+                "remaining_balance": {
+                    "type": "number",
+                    "example": 245000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "mortgage"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.OutOfPocketStatusDTO": {
+            "type": "object",
+            "properties": {
+                "max_reached": {
+                    "type": "boolean"
+                },
+                "near_max": {
+                    "type": "boolean"
+                },
+                "out_of_pocket_current": {
+                    "type": "number"
+                },
+                "out_of_pocket_max": {
+                    "type": "number"
+                },
+                "percent_reached": {
+                    "type": "number"
+                },
+                "policy_id": {
+                    "type": "string"
+                },
+                "policy_number": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.OverviewResponseDTO": {
+            "type": "object",
+            "properties": {
+                "affordability": {
+                    "$ref": "#/definitions/dtos.AffordabilityResponseDTO"
+                },
+                "errors": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dtos.SimpleErrorResponseDTO"
+                    }
+                },
+                "finance": {
+                    "$ref": "#/definitions/dtos.FinanceSummaryResponseDTO"
+                },
+                "health": {
+                    "$ref": "#/definitions/dtos.HealthSummaryResponseDTO"
+                },
+                "resilience": {
+                    "$ref": "#/definitions/dtos.FinancialResilienceDTO"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.PersonalDataExportDTO": {
+            "type": "object",
+            "properties": {
+                "finance": {
+                    "$ref": "#/definitions/dtos.PersonalFinanceDataDTO"
+                },
+                "generated_at": {
+                    "type": "string",
+                    "example": "2025-03-01T09:00:00Z"
+                },
+                "health": {
+                    "$ref": "#/definitions/dtos.PersonalHealthDataDTO"
+                },
+                "schema_version": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user": {
+                    "$ref": "#/definitions/dtos.UserProfileDTO"
+                }
+            }
+        },
+        "dtos.PersonalFinanceDataDTO": {
+            "type": "object",
+            "properties": {
+                "budgets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BudgetResponseDTO"
+                    }
+                },
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExpenseResponseDTO"
+                    }
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.IncomeResponseDTO"
+                    }
+                },
+                "loans": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.LoanResponseDTO"
+                    }
+                },
+                "savings_goals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SavingsGoalResponseDTO"
+                    }
+                }
+            }
+        },
+        "dtos.PersonalHealthDataDTO": {
+            "type": "object",
+            "properties": {
+                "conditions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MedicalConditionResponseDTO"
+                    }
+                },
+                "insurance_policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.InsurancePolicyResponseDTO"
+                    }
+                },
+                "medical_expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.MedicalExpenseResponseDTO"
+                    }
+                },
+                "profiles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.HealthProfileResponseDTO"
+                    }
+                }
+            }
+        },
+        "dtos.PolicyAdequacyDTO": {
+            "type": "object",
+            "properties": {
+                "annual_premium": {
+                    "type": "number"
+                },
+                "coverage_sufficient": {
+                    "type": "boolean"
+                },
+                "expected_benefit": {
+                    "type": "number"
+                },
+                "expected_out_of_pocket": {
+                    "type": "number"
+                },
+                "flags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "out_of_pocket_max_sufficient": {
+                    "type": "boolean"
+                },
+                "policy_id": {
+                    "type": "string"
+                },
+                "policy_number": {
+                    "type": "string"
+                },
+                "premium_to_benefit_ratio": {
+                    "type": "number"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.PolicyCandidateDTO": {
+            "type": "object",
+            "required": [
+                "out_of_pocket_max",
+                "policy_number",
+                "provider",
+                "type"
+            ],
+            "properties": {
+                "coverage_percentage": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0
+                },
+                "deductible": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "monthly_premium": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "out_of_pocket_max": {
+                    "type": "number",
+                    "maximum": 1000000000
+                },
+                "policy_number": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "provider": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "health",
+                        "dental",
+                        "vision",
+                        "comprehensive"
+                    ]
+                }
+            }
+        },
+        "dtos.PolicyComparisonResponseDTO": {
+            "type": "object",
+            "properties": {
+                "cheapest": {
+                    "$ref": "#/definitions/dtos.PolicyCostProjectionDTO"
+                },
+                "expected_annual_spend": {
+                    "type": "number"
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.PolicyCostProjectionDTO"
+                    }
+                }
+            }
+        },
+        "dtos.PolicyCostProjectionDTO": {
+            "type": "object",
+            "properties": {
+                "annual_premium": {
+                    "type": "number"
+                },
+                "insurance_coverage": {
+                    "type": "number"
+                },
+                "policy_id": {
+                    "type": "string"
+                },
+                "policy_number": {
+                    "type": "string"
+                },
+                "projected_out_of_pocket": {
+                    "type": "number"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "total_projected_cost": {
+                    "type": "number"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ProfileHistoryResponseDTO": {
+            "type": "object",
+            "properties": {
+                "bmi_change": {
+                    "type": "number"
+                },
+                "months": {
+                    "type": "integer"
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ProfileSnapshotDTO"
+                    }
+                },
+                "weight_change": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.ProfileSnapshotDTO": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer"
+                },
+                "bmi": {
+                    "type": "number"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.PurchaseAffordabilityCheckDTO": {
+            "type": "object",
+            "required": [
+                "payment_method",
+                "price"
+            ],
+            "properties": {
+                "horizon_months": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 0,
+                    "example": 3
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 9.9
+                },
+                "months": {
+                    "type": "integer",
+                    "maximum": 600,
+                    "minimum": 0,
+                    "example": 12
+                },
+                "payment_method": {
+                    "type": "string",
+                    "enum": [
+                        "cash",
+                        "installments"
+                    ],
+                    "example": "installments"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1200
+                }
+            }
+        },
+        "dtos.PurchaseAffordabilityResponseDTO": {
+            "type": "object",
+            "properties": {
+                "available_cash": {
+                    "type": "number",
+                    "example": 2400
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "debt_to_income_ratio": {
+                    "type": "number",
+                    "example": 0.38
+                },
+                "horizon_months": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "example": 9.9
+                },
+                "limiting_factor": {
+                    "type": "string",
+                    "example": "debt_to_income"
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 105.4
+                },
+                "months": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "installments"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 1200
+                },
+                "savings_rate": {
+                    "type": "number",
+                    "example": 0.12
+                },
+                "total_cost": {
+                    "type": "number",
+                    "example": 1264.8
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                },
+                "verdict": {
+                    "type": "string",
+                    "example": "borderline"
+                }
+            }
+        },
+        "dtos.RecurringExpenseVarianceDTO": {
+            "type": "object",
+            "properties": {
+                "actual_ytd": {
+                    "type": "number",
+                    "example": 337.5
+                },
+                "projected_ytd": {
+                    "type": "number",
+                    "example": 300
+                },
+                "reconciled_months": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "variance": {
+                    "type": "number",
+                    "example": 37.5
+                }
+            }
+        },
+        "dtos.RefreshTokenRequestDTO": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.RegisterRequestDTO": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                }
+            }
+        },
+        "dtos.RiskBandDTO": {
+            "type": "object",
+            "properties": {
+                "include_max": {
+                    "type": "boolean"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.RiskFactorChangeDTO": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "type": "integer",
+                    "example": -8
+                },
+                "condition_id": {
+                    "type": "string",
+                    "example": "12"
+                },
+                "current_points": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "factor": {
+                    "type": "string",
+                    "example": "condition"
+                },
+                "hypothetical_points": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Hypertension"
+                }
+            }
+        },
+        "dtos.RiskHistoryResponseDTO": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskSnapshotDTO"
+                    }
+                },
+                "months": {
+                    "type": "integer"
+                },
+                "score_change": {
+                    "type": "integer"
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskLevelTransitionDTO"
+                    }
+                }
+            }
+        },
+        "dtos.RiskLevelCutoffsDTO": {
+            "type": "object",
+            "properties": {
+                "high": {
+                    "type": "integer",
+                    "example": 75
+                },
+                "low": {
+                    "type": "integer",
+                    "example": 25
+                },
+                "max_score": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "moderate": {
+                    "type": "integer",
+                    "example": 50
+                }
+            }
+        },
+        "dtos.RiskLevelTransitionDTO": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string",
+                    "example": "low"
+                },
+                "to": {
+                    "type": "string",
+                    "example": "moderate"
+                }
+            }
+        },
+        "dtos.RiskModelResponseDTO": {
+            "type": "object",
+            "properties": {
+                "age_bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskBandDTO"
+                    }
+                },
+                "bmi_bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskBandDTO"
+                    }
+                },
+                "category_weights": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number",
+                        "format": "float64"
+                    }
+                },
+                "family_size_bands": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskBandDTO"
+                    }
+                },
+                "level_cutoffs": {
+                    "$ref": "#/definitions/dtos.RiskLevelCutoffsDTO"
+                },
+                "severity_points": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dtos.RiskRecalculationResponseDTO": {
+            "type": "object",
+            "properties": {
+                "processed": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "updated": {
+                    "type": "integer",
+                    "example": 7
+                }
+            }
+        },
+        "dtos.RiskSnapshotDTO": {
+            "type": "object",
+            "properties": {
+                "recorded_at": {
+                    "type": "string"
+                },
+                "risk_level": {
+                    "type": "string",
+                    "example": "moderate"
+                },
+                "risk_score": {
+                    "type": "integer",
+                    "example": 35
+                }
+            }
+        },
+        "dtos.RiskWhatIfRequestDTO": {
+            "type": "object",
+            "required": [
+                "remove_condition_ids"
+            ],
+            "properties": {
+                "add_conditions": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "$ref": "#/definitions/dtos.HypotheticalConditionDTO"
+                    }
+                },
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 45
+                },
+                "remove_condition_ids": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "severity_changes": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/dtos.ConditionSeverityDTO"
+                    }
+                },
+                "weight": {
+                    "type": "number",
+                    "example": 70
+                }
+            }
+        },
+        "dtos.RiskWhatIfResponseDTO": {
+            "type": "object",
+            "properties": {
+                "current_level": {
+                    "type": "string",
+                    "example": "moderate"
+                },
+                "current_score": {
+                    "type": "integer",
+                    "example": 37
+                },
+                "factors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RiskFactorChangeDTO"
+                    }
+                },
+                "hypothetical_level": {
+                    "type": "string",
+                    "example": "low"
+                },
+                "hypothetical_score": {
+                    "type": "integer",
+                    "example": 14
+                },
+                "score_change": {
+                    "type": "integer",
+                    "example": -23
+                }
+            }
+        },
+        "dtos.SavingsGoalResponseDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "current_amount": {
+                    "type": "number",
+                    "example": 1500
+                },
+                "id": {
+                    "type": "string",
+                    "example": "goal-123"
+                },
+                "is_complete": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Emergency Fund"
+                },
+                "priority": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "progress_percent": {
+                    "type": "number",
+                    "example": 15
+                },
+                "remaining_amount": {
+                    "type": "number",
+                    "example": 8500
+                },
+                "required_monthly": {
+                    "type": "number",
+                    "example": 708.33
+                },
+                "target_amount": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2026-12-31T00:00:00Z"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "user-456"
+                }
+            }
+        },
+        "dtos.SimpleErrorResponseDTO": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "Health profile not found"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ErrorCode"
+                        }
+                    ],
+                    "example": "HEALTH_PROFILE_NOT_FOUND"
+                }
+            }
+        },
+        "dtos.SimulateLoanPayoffDTO": {
+            "type": "object",
+            "properties": {
+                "extra_monthly_payment": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "one_time_payment": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 5000
+                }
+            }
+        },
+        "dtos.SuggestExpenseCutsDTO": {
+            "type": "object",
+            "required": [
+                "target_savings"
+            ],
+            "properties": {
+                "target_savings": {
+                    "type": "number",
+                    "example": 400
+                }
+            }
+        },
+        "dtos.TokenCleanupResponseDTO": {
+            "type": "object",
+            "properties": {
+                "purged_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.TokenResponseDTO": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.UncoveredCategoryDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "expense_count": {
+                    "type": "integer"
+                },
+                "total_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.UpdateBudgetDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "housing",
+                        "food",
+                        "transport",
+                        "entertainment",
+                        "utilities",
+                        "other"
+                    ],
+                    "example": "food"
+                },
+                "monthly_limit": {
+                    "type": "number",
+                    "example": 650
+                },
+                "rollover": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.UpdateDeductibleRequestDTO": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "maximum": 1000000000
+                }
+            }
+        },
+        "dtos.UpdateDependentProfileRequestDTO": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "height": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "relation_to_owner": {
+                    "type": "string",
+                    "enum": [
+                        "spouse",
+                        "child",
+                        "parent",
+                        "other"
+                    ]
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.UpdateExpenseDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 150
+                },
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "housing",
+                        "food",
+                        "transport",
+                        "entertainment",
+                        "utilities",
+                        "other"
+                    ],
+                    "example": "utilities"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "is_fixed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Electricity Bill"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "tags": {
+                    "description": "Tags replaces every tag on the expense; an empty list removes them all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vacation"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateHealthProfileRequestDTO": {
+            "type": "object",
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0
+                },
+                "emergency_fund_health": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "family_size": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "gender": {
+                    "type": "string",
+                    "enum": [
+                        "male",
+                        "female",
+                        "other"
+                    ]
+                },
+                "has_chronic_conditions": {
+                    "type": "boolean"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "dtos.UpdateIncomeDTO": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 5500
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "frequency": {
+                    "type": "string",
+                    "example": "monthly"
+                },
+                "source": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Senior Software Engineer"
+                }
+            }
+        },
+        "dtos.UpdateLoanDTO": {
+            "type": "object",
+            "properties": {
+                "auto_accrue_interest": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "EUR"
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2050-01-15T00:00:00Z"
+                },
+                "interest_rate": {
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 3.5
+                },
+                "lender": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Wells Fargo"
+                },
+                "monthly_payment": {
+                    "type": "number",
+                    "example": 1200
+                },
+                "principal_amount": {
+                    "type": "number",
+                    "example": 240000
+                },
+                "remaining_balance": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 235000
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "mortgage",
+                        "auto",
+                        "personal",
+                        "student"
+                    ],
+                    "example": "auto"
+                }
+            }
+        },
+        "dtos.UpdateMedicalConditionRequestDTO": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "enum": [
+                        "chronic",
+                        "acute",
+                        "mental_health",
+                        "preventive"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "monthly_med_cost": {
+                    "type": "number",
+                    "maximum": 1000000000,
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "requires_medication": {
+                    "type": "boolean"
+                },
+                "risk_factor": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "severity": {
+                    "type": "string",
+                    "enum": [
+                        "mild",
+                        "moderate",
+                        "severe",
+                        "critical"
+                    ]
+                }
+            }
+        },
+        "dtos.UpdateSavingsGoalDTO": {
+            "type": "object",
+            "properties": {
+                "current_amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 2000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2,
+                    "example": "Rainy Day Fund"
+                },
+                "priority": {
+                    "type": "integer",
+                    "maximum": 3,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "target_amount": {
+                    "type": "number",
+                    "example": 12000
+                },
+                "target_date": {
+                    "type": "string",
+                    "example": "2027-06-30T00:00:00Z"
+                }
+            }
+        },
+        "dtos.UpdateUserProfileDTO": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "johnsmith@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 1,
+                    "example": "John Smith"
+                }
+            }
+        },
+        "dtos.UpdateUserRoleDTO": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "admin"
+                    ],
+                    "example": "admin"
+                }
+            }
+        },
+        "dtos.UpdateWebhookDTO": {
+            "type": "object",
+            "properties": {
+                "event_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "insurance.deductible_met"
+                    ]
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "secret": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16,
+                    "example": "0123456789abcdef0123456789abcdef"
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048,
+                    "example": "https://hooks.example.com/buyorbye"
+                }
+            }
+        },
+        "dtos.UserListResponseDTO": {
+            "type": "object",
+            "properties": {
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "page_size": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.UserProfileDTO"
+                    }
+                }
+            }
+        },
+        "dtos.UserProfileDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "user-123"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "last_login_at": {
+                    "type": "string",
+                    "example": "2024-01-15T14:30:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                }
+            }
+        },
+        "dtos.ValidationErrorResponseDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "error_code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.ErrorCode"
+                        }
+                    ],
+                    "example": "VALIDATION_FAILED"
+                },
+                "fields": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.WebhookDeliveryDTO": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "duration_ms": {
+                    "type": "integer",
+                    "example": 142
+                },
+                "error": {
+                    "type": "string",
+                    "example": "unexpected status 503"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "2f6c1f5e-8f0b-4c1e-9d7a-3b1d2c4e5f60"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "insurance.deductible_met"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "succeeded": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "dtos.WebhookResponseDTO": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "financial_health.changed"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "wh-123"
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "0123456789abcdef0123456789abcdef"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2024-01-15T10:30:00Z"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://hooks.example.com/buyorbye"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "API key from /auth/api-keys. Accepted by the finance, health and overview routes its scopes cover.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "Access token from /auth/login, sent as \"Bearer \u003ctoken\u003e\".",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
+    }
+}`
+
+// SwaggerInfo holds exported Swagger Info so clients can modify it
+var SwaggerInfo = &swag.Spec{
+	Version:          "1.0",
+	Host:             "",
+	BasePath:         "/api/v1",
+	Schemes:          []string{},
+	Title:            "BuyOrBye API",
+	Description:      "Personal finance and health cost tracking API.",
+	InfoInstanceName: "swagger",
+	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
+}
+
+func init() {
+	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
+}
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultShutdownTimeout is how long in-flight requests get to finish when no timeout is configured
+const DefaultShutdownTimeout = 5 * time.Second
+
+// Lifecycle runs the HTTP server and shuts the application down in order. Shutting down first
+// drains, if configured, then stops accepting requests, waits for in-flight handlers, and
+// closes the registered components in the order they were registered, each bounded by its
+// own timeout.
+type Lifecycle struct {
+	server          *http.Server
+	shutdownTimeout time.Duration
+	drain           func()
+	drainDelay      time.Duration
+	serving         atomic.Bool
+
+	mu           sync.Mutex
+	components   []component
+	shutdownOnce sync.Once
+	shutdownErr  error
+}
+
+// component is a dependency closed after the server stops
+type component struct {
+	name    string
+	timeout time.Duration
+	close   func(ctx context.Context) error
+}
+
+// Option customizes a Lifecycle created by NewLifecycle
+type Option func(*Lifecycle)
+
+// WithDrain calls drain as shutdown begins, typically to fail the readiness probe, then keeps
+// serving for delay before the listener closes so load balancers stop routing here first.
+// The delay is skipped if the server isn't serving.
+func WithDrain(drain func(), delay time.Duration) Option {
+	return func(l *Lifecycle) {
+		l.drain = drain
+		l.drainDelay = delay
+	}
+}
+
+// NewLifecycle creates a lifecycle for server. shutdownTimeout bounds the wait for
+// in-flight requests; a non-positive value uses DefaultShutdownTimeout.
+func NewLifecycle(server *http.Server, shutdownTimeout time.Duration, opts ...Option) *Lifecycle {
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = DefaultShutdownTimeout
+	}
+	l := &Lifecycle{
+		server:          server,
+		shutdownTimeout: shutdownTimeout,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Register adds a component to close on shutdown, after the server and every component
+// registered before it. close gets a context that expires after timeout; if it hasn't
+// returned by then shutdown moves on to the next component. A non-positive timeout waits
+// for close to return.
+func (l *Lifecycle) Register(name string, timeout time.Duration, close func(ctx context.Context) error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.components = append(l.components, component{name: name, timeout: timeout, close: close})
+}
+
+// StopFunc adapts a blocking Stop method without a context, such as a background job's,
+// for Register
+func StopFunc(stop func()) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		stop()
+		return nil
+	}
+}
+
+// Run listens on the server's address and serves until ctx is done, then shuts down
+func (l *Lifecycle) Run(ctx context.Context) error {
+	addr := l.server.Addr
+	if addr == "" {
+		addr = ":http"
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to listen on %s: %w", addr, err), l.Shutdown())
+	}
+	return l.Serve(ctx, listener)
+}
+
+// Serve serves on listener until ctx is done or the server fails, then shuts down.
+// Returns the server's error, if it failed, joined with any shutdown errors.
+func (l *Lifecycle) Serve(ctx context.Context, listener net.Listener) error {
+	serveErr := make(chan error, 1)
+	l.serving.Store(true)
+	go func() {
+		serveErr <- l.server.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return l.Shutdown()
+	case err := <-serveErr:
+		l.serving.Store(false)
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+		return errors.Join(err, l.Shutdown())
+	}
+}
+
+// Shutdown drains, stops the server and closes the registered components. Every component is
+// closed even if an earlier step fails; the failures are returned joined together.
+// Calls after the first return its result.
+func (l *Lifecycle) Shutdown() error {
+	l.shutdownOnce.Do(func() {
+		var errs []error
+
+		if l.drain != nil {
+			l.drain()
+			if l.drainDelay > 0 && l.serving.Load() {
+				time.Sleep(l.drainDelay)
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), l.shutdownTimeout)
+		if err := l.server.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("http server: %w", err))
+		}
+		cancel()
+
+		l.mu.Lock()
+		components := append([]component(nil), l.components...)
+		l.mu.Unlock()
+
+		for _, c := range components {
+			if err := c.stop(); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+			}
+		}
+
+		l.shutdownErr = errors.Join(errs...)
+	})
+	return l.shutdownErr
+}
+
+// stop closes the component, giving up once its timeout expires
+func (c component) stop() error {
+	ctx := context.Background()
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.close(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("did not stop within %s: %w", c.timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// shutdownLog records the order shutdown steps happen in
+type shutdownLog struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (l *shutdownLog) add(step string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.steps = append(l.steps, step)
+}
+
+func (l *shutdownLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.steps...)
+}
+
+func TestLifecycle_SIGTERM_DrainsRequestsThenClosesDatabase(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	log := &shutdownLog{}
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		// Still running when the signal arrives, and needs the database to finish
+		time.Sleep(200 * time.Millisecond)
+		var one int
+		if err := db.Raw("SELECT 1").Scan(&one).Error; err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		log.add("request")
+		_, _ = io.WriteString(w, "done")
+	})
+
+	lifecycle := NewLifecycle(&http.Server{Handler: mux}, time.Second)
+	lifecycle.Register("database", time.Second, func(ctx context.Context) error {
+		log.add("database")
+		return sqlDB.Close()
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	exited := make(chan error, 1)
+	go func() {
+		exited <- lifecycle.Serve(ctx, listener)
+	}()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body)}
+	}()
+
+	<-started
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGTERM))
+
+	select {
+	case err := <-exited:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("lifecycle did not exit after SIGTERM")
+	}
+
+	response := <-responses
+	require.NoError(t, response.err)
+	assert.Equal(t, http.StatusOK, response.status)
+	assert.Equal(t, "done", response.body)
+	assert.Equal(t, []string{"request", "database"}, log.get())
+	assert.Error(t, sqlDB.Ping(), "the pool should be closed")
+}
+
+func TestLifecycle_WithDrain_FailsReadinessBeforeListenerCloses(t *testing.T) {
+	var draining atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if draining.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	lifecycle := NewLifecycle(&http.Server{Handler: mux}, time.Second, WithDrain(func() { draining.Store(true) }, 300*time.Millisecond))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	readyURL := "http://" + listener.Addr().String() + "/readyz"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exited := make(chan error, 1)
+	go func() {
+		exited <- lifecycle.Serve(ctx, listener)
+	}()
+
+	resp, err := http.Get(readyURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	cancel()
+	require.Eventually(t, draining.Load, time.Second, 5*time.Millisecond)
+
+	// Still listening while the load balancer notices the failing probe
+	client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+	resp, err = client.Get(readyURL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	select {
+	case err := <-exited:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("lifecycle did not exit after draining")
+	}
+	_, err = client.Get(readyURL)
+	assert.Error(t, err, "the listener is closed once the drain delay is over")
+}
+
+func TestLifecycle_WithDrain_SkipsDelayWhenNotServing(t *testing.T) {
+	drained := false
+	lifecycle := NewLifecycle(&http.Server{}, 0, WithDrain(func() { drained = true }, time.Hour))
+
+	start := time.Now()
+	require.NoError(t, lifecycle.Shutdown())
+
+	assert.True(t, drained)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestLifecycle_Shutdown_ClosesComponentsInOrderDespiteTimeouts(t *testing.T) {
+	log := &shutdownLog{}
+	lifecycle := NewLifecycle(&http.Server{}, 0)
+	lifecycle.Register("stuck", 50*time.Millisecond, func(ctx context.Context) error {
+		log.add("stuck")
+		time.Sleep(time.Second)
+		return nil
+	})
+	lifecycle.Register("failing", time.Second, func(ctx context.Context) error {
+		log.add("failing")
+		return errors.New("close failed")
+	})
+	lifecycle.Register("job", time.Second, StopFunc(func() {
+		log.add("job")
+	}))
+
+	err := lifecycle.Shutdown()
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "stuck: did not stop within 50ms")
+	assert.Contains(t, err.Error(), "failing: close failed")
+	assert.Equal(t, []string{"stuck", "failing", "job"}, log.get())
+
+	// Shutting down again doesn't close anything twice
+	assert.Equal(t, err, lifecycle.Shutdown())
+	assert.Len(t, log.get(), 3)
+}
@@ -4,21 +4,28 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/spf13/viper"
+
+	"github.com/DuckDHD/BuyOrBye/internal/database"
 )
 
 // Config represents the complete application configuration
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server" validate:"required"`
-	Database DatabaseConfig `mapstructure:"database" validate:"required"`
-	Auth     AuthConfig     `mapstructure:"auth" validate:"required"`
-	Logging  LoggingConfig  `mapstructure:"logging" validate:"required"`
-	Finance  FinanceConfig  `mapstructure:"finance" validate:"required"`
+	Server      ServerConfig      `mapstructure:"server" validate:"required"`
+	Database    DatabaseConfig    `mapstructure:"database" validate:"required"`
+	Auth        AuthConfig        `mapstructure:"auth" validate:"required"`
+	Logging     LoggingConfig     `mapstructure:"logging" validate:"required"`
+	Finance     FinanceConfig     `mapstructure:"finance" validate:"required"`
+	Maintenance MaintenanceConfig `mapstructure:"maintenance"`
+	Health      HealthConfig      `mapstructure:"health"`
+	Webhooks    WebhooksConfig    `mapstructure:"webhooks"`
+	Audit       AuditConfig       `mapstructure:"audit"`
+	Reports     ReportsConfig     `mapstructure:"reports"`
 }
 
 // ServerConfig holds server-related configuration
@@ -28,10 +35,43 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `mapstructure:"read_timeout" validate:"required"`
 	WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"required"`
 	IdleTimeout  time.Duration `mapstructure:"idle_timeout" validate:"required"`
+	// IdempotencyTTL is how long responses to requests with an Idempotency-Key are replayed; 0 uses the middleware default
+	IdempotencyTTL time.Duration `mapstructure:"idempotency_ttl" validate:"min=0"`
+	// EnableSwagger serves the OpenAPI spec at /api/v1/openapi.json with a UI under /docs, and
+	// the Swagger 2.0 document under /swagger; keep it off in production
+	EnableSwagger bool `mapstructure:"enable_swagger"`
+	// EnableDemoData lets users seed their empty account with demo data under
+	// /api/v1/account/seed-demo-data and remove it again; keep it off in production
+	EnableDemoData bool `mapstructure:"enable_demo_data"`
+	// MetricsSkipPaths are request paths left out of the Prometheus metrics (e.g., health checks)
+	MetricsSkipPaths []string `mapstructure:"metrics_skip_paths"`
+	// ShutdownTimeout is how long in-flight requests get to finish on shutdown; 0 uses 5s
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout" validate:"min=0"`
+	// ComponentShutdownTimeout bounds how long each background job, the database pool and
+	// the logger get to close once the server has stopped; 0 waits for each to finish
+	ComponentShutdownTimeout time.Duration `mapstructure:"component_shutdown_timeout" validate:"min=0"`
+	// ReadinessDrainDelay is how long the readiness probe fails before the listener closes on
+	// shutdown, so load balancers stop routing new requests first; 0 closes it right away
+	ReadinessDrainDelay time.Duration `mapstructure:"readiness_drain_delay" validate:"min=0"`
+	// CORS is the cross-origin policy for browser clients; unset values use DefaultCORSConfig
+	CORS CORSConfig `mapstructure:"cors"`
+	// TrustedProxies are the IPs and CIDRs of the reverse proxies and load balancers in front of
+	// the app. X-Forwarded-For is only believed when the connection comes from one of them;
+	// when empty the client IP is always the TCP peer address.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
+// Supported database drivers
+const (
+	DriverMySQL  = database.DriverMySQL
+	DriverSQLite = database.DriverSQLite
+)
+
 // DatabaseConfig holds database-related configuration
 type DatabaseConfig struct {
+	// Driver is "mysql" or "sqlite". When empty it is inferred from Host and Port.
+	// With "sqlite", Database is the file path, or ":memory:" for a throwaway database.
+	Driver          string        `mapstructure:"driver" validate:"omitempty,oneof=mysql sqlite"`
 	Host            string        `mapstructure:"host"`
 	Port            int           `mapstructure:"port" validate:"min=0,max=65535"`
 	Database        string        `mapstructure:"database" validate:"required"`
@@ -40,15 +80,59 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `mapstructure:"max_idle_conns" validate:"min=1"`
 	MaxOpenConns    int           `mapstructure:"max_open_conns" validate:"min=1"`
 	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+	// StatementTimeout bounds each database statement; 0 disables it
+	StatementTimeout time.Duration `mapstructure:"statement_timeout" validate:"min=0"`
+	// SlowQueryThreshold is how long a query may take before it is logged as slow; 0 disables it
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold" validate:"min=0"`
 }
 
 // AuthConfig holds authentication-related configuration
 type AuthConfig struct {
-	JWTSecret       string        `mapstructure:"jwt_secret" validate:"required,min=32"`
-	BCryptCost      int           `mapstructure:"bcrypt_cost" validate:"min=4,max=20"`
-	AccessTokenTTL  time.Duration `mapstructure:"access_token_ttl" validate:"required"`
-	RefreshTokenTTL time.Duration `mapstructure:"refresh_token_ttl" validate:"required"`
-	CSRFSecret      string        `mapstructure:"csrf_secret" validate:"required,min=32"`
+	// JWTSecret signs new tokens, with JWTKeyID ("primary" when empty) as their kid header
+	JWTSecret string `mapstructure:"jwt_secret" validate:"required,min=32"`
+	JWTKeyID  string `mapstructure:"jwt_key_id"`
+	// JWTVerificationKeys are retired signing keys that still verify the tokens they signed,
+	// so rotating JWTSecret doesn't log everyone out; drop a key once its tokens have expired
+	JWTVerificationKeys []JWTKeyConfig `mapstructure:"jwt_verification_keys" validate:"dive"`
+	BCryptCost          int            `mapstructure:"bcrypt_cost" validate:"min=4,max=20"`
+	AccessTokenTTL      time.Duration  `mapstructure:"access_token_ttl" validate:"required"`
+	RefreshTokenTTL     time.Duration  `mapstructure:"refresh_token_ttl" validate:"required"`
+	CSRFSecret          string         `mapstructure:"csrf_secret" validate:"required,min=32"`
+	AdminEmails         []string       `mapstructure:"admin_emails" validate:"dive,email"`
+	// PasswordPolicy sets the rules new passwords must follow
+	PasswordPolicy PasswordPolicyConfig `mapstructure:"password_policy"`
+	// Cookies turns on cookie sessions for browser clients
+	Cookies AuthCookiesConfig `mapstructure:"cookies"`
+}
+
+// AuthCookiesConfig holds the cookie session settings. When enabled, login, registration and
+// refresh also set the tokens as cookies, and a state-changing request authenticated by cookie
+// must echo the csrf_token cookie in an X-CSRF-Token header. Clients that send an Authorization
+// header are unaffected.
+type AuthCookiesConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Secure restricts the cookies to HTTPS; required in production
+	Secure bool `mapstructure:"secure"`
+	// Domain is the cookies' domain; empty means the host that set them
+	Domain string `mapstructure:"domain"`
+	// SameSite is strict (the default) or lax
+	SameSite string `mapstructure:"same_site" validate:"omitempty,oneof=strict lax"`
+}
+
+// PasswordPolicyConfig holds the password rules. Unset lengths keep the defaults of 8 to 72;
+// the minimum can only be raised and the maximum can't exceed bcrypt's 72-byte limit.
+type PasswordPolicyConfig struct {
+	MinLength     int  `mapstructure:"min_length" validate:"omitempty,min=8,max=72"`
+	MaxLength     int  `mapstructure:"max_length" validate:"omitempty,min=8,max=72,gtefield=MinLength"`
+	RequireDigit  bool `mapstructure:"require_digit"`
+	RequireUpper  bool `mapstructure:"require_upper"`
+	RequireSymbol bool `mapstructure:"require_symbol"`
+}
+
+// JWTKeyConfig is a JWT signing key identified by the kid header of the tokens it signed
+type JWTKeyConfig struct {
+	ID     string `mapstructure:"id" validate:"required"`
+	Secret string `mapstructure:"secret" validate:"required,min=32"`
 }
 
 // LoggingConfig holds logging-related configuration
@@ -58,10 +142,93 @@ type LoggingConfig struct {
 }
 
 // FinanceConfig holds finance-related configuration
+// The ratio, rate and multiplier settings rate financial health and affordability;
+// see Thresholds for how unset values are filled in.
 type FinanceConfig struct {
-	HealthyDTIRatio     float64 `mapstructure:"healthy_dti_ratio" validate:"min=0,max=1"`
-	MinSavingsRate      float64 `mapstructure:"min_savings_rate" validate:"min=0,max=1"`
-	EmergencyFundMonths int     `mapstructure:"emergency_fund_months" validate:"min=1"`
+	ExcellentDTIRatio float64 `mapstructure:"excellent_dti_ratio" validate:"min=0,max=1"`
+	HealthyDTIRatio   float64 `mapstructure:"healthy_dti_ratio" validate:"min=0,max=1"`
+	PoorDTIRatio      float64 `mapstructure:"poor_dti_ratio" validate:"min=0,max=1"`
+	// MinSavingsRate is the savings rate needed for Excellent health
+	MinSavingsRate  float64 `mapstructure:"min_savings_rate" validate:"min=0,max=1"`
+	GoodSavingsRate float64 `mapstructure:"good_savings_rate" validate:"min=0,max=1"`
+	FairSavingsRate float64 `mapstructure:"fair_savings_rate" validate:"min=0,max=1"`
+	// AffordabilityMultipliers scale disposable income into the largest affordable purchase per debt-to-income band
+	AffordabilityMultipliers AffordabilityMultipliersConfig `mapstructure:"affordability_multipliers"`
+	EmergencyFundMonths      int                            `mapstructure:"emergency_fund_months" validate:"min=1"`
+	// HealthScore weighs the financial health score components; EmergencyFundMonths is the
+	// emergency fund they are measured against
+	HealthScore HealthScoreConfig `mapstructure:"health_score"`
+	// SummaryCacheTTL is how long a user's finance summary is cached; 0 disables the cache
+	SummaryCacheTTL time.Duration `mapstructure:"summary_cache_ttl" validate:"min=0"`
+	// DuplicateWindow is how far back a new income or expense is checked against matching records; 0 disables the check
+	DuplicateWindow time.Duration `mapstructure:"duplicate_window" validate:"min=0"`
+	// BaseCurrency is the ISO 4217 code summaries are computed in and new records default to; empty means USD
+	BaseCurrency string `mapstructure:"base_currency" validate:"omitempty,iso4217"`
+	// ExchangeRates maps a currency code to how many units of it one unit of BaseCurrency buys
+	ExchangeRates map[string]float64 `mapstructure:"exchange_rates" validate:"dive,gt=0"`
+}
+
+// MaintenanceConfig holds configuration for background maintenance jobs
+type MaintenanceConfig struct {
+	// TokenCleanupInterval is how often expired refresh tokens are purged; 0 disables the job
+	TokenCleanupInterval time.Duration `mapstructure:"token_cleanup_interval" validate:"min=0"`
+	// InterestAccrualInterval is how often auto-accruing loans are checked for a month of interest
+	// that hasn't been added yet; each loan accrues at most once a month. 0 disables the job.
+	InterestAccrualInterval time.Duration `mapstructure:"interest_accrual_interval" validate:"min=0"`
+}
+
+// HealthConfig holds health-related configuration.
+// Zero values fall back to the service defaults.
+type HealthConfig struct {
+	HSASelfOnlyContributionLimit float64 `mapstructure:"hsa_self_only_contribution_limit" validate:"min=0"`
+	HSAFamilyContributionLimit   float64 `mapstructure:"hsa_family_contribution_limit" validate:"min=0"`
+	HDHPSelfOnlyMinDeductible    float64 `mapstructure:"hdhp_self_only_min_deductible" validate:"min=0"`
+	HDHPFamilyMinDeductible      float64 `mapstructure:"hdhp_family_min_deductible" validate:"min=0"`
+	// OOPWarningPercent flags policies within this percent of their out-of-pocket maximum
+	OOPWarningPercent float64 `mapstructure:"oop_warning_percent" validate:"min=0,max=100"`
+	// SummaryCacheEnabled caches each user's health summary until their health data changes or the day ends
+	SummaryCacheEnabled bool `mapstructure:"summary_cache_enabled"`
+	// RiskModel tunes the health risk score; it is validated at startup
+	RiskModel RiskModelConfig `mapstructure:"risk_model"`
+	// Attachments configures the receipts and EOB documents attached to medical expenses
+	Attachments AttachmentsConfig `mapstructure:"attachments"`
+}
+
+// AttachmentsConfig holds configuration for medical expense attachments
+type AttachmentsConfig struct {
+	// StoragePath is the directory attachment files are kept in; it is created if missing
+	StoragePath string `mapstructure:"storage_path" validate:"required"`
+	// MaxSize is the largest attachment accepted, in bytes; 0 uses 10MB
+	MaxSize int64 `mapstructure:"max_size" validate:"min=0"`
+}
+
+// WebhooksConfig holds configuration for webhook event delivery.
+// Zero values fall back to the dispatcher defaults.
+type WebhooksConfig struct {
+	Workers        int           `mapstructure:"workers" validate:"min=0"`
+	QueueSize      int           `mapstructure:"queue_size" validate:"min=0"`
+	MaxAttempts    int           `mapstructure:"max_attempts" validate:"min=0"`
+	InitialBackoff time.Duration `mapstructure:"initial_backoff" validate:"min=0"`
+	MaxBackoff     time.Duration `mapstructure:"max_backoff" validate:"min=0"`
+	RequestTimeout time.Duration `mapstructure:"request_timeout" validate:"min=0"`
+	// AllowPrivateNetworks lets webhooks target loopback and private addresses; keep it off in production
+	AllowPrivateNetworks bool `mapstructure:"allow_private_networks"`
+}
+
+// AuditConfig holds configuration for the audit log writer.
+// Zero values fall back to the audit service defaults.
+type AuditConfig struct {
+	// QueueSize is how many entries can wait to be written; entries recorded while it is full are dropped
+	QueueSize    int           `mapstructure:"queue_size" validate:"min=0"`
+	BatchSize    int           `mapstructure:"batch_size" validate:"min=0"`
+	WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"min=0"`
+}
+
+// ReportsConfig holds configuration for the monthly statements
+type ReportsConfig struct {
+	// PDFCommand is the wkhtmltopdf executable statements are converted to PDF with, looked up
+	// on the PATH if it isn't a path. Empty disables PDF statements; they are served as HTML only.
+	PDFCommand string `mapstructure:"pdf_command"`
 }
 
 // LoadConfig loads configuration from files and environment variables
@@ -79,9 +246,11 @@ func LoadConfig() (*Config, error) {
 	v.AddConfigPath("../configs")
 	v.AddConfigPath("../../configs")
 
-	// Enable environment variable substitution
+	// Every setting can be overridden by an environment variable named after its key,
+	// e.g. AUTH_ACCESS_TOKEN_TTL for auth.access_token_ttl; see bindEnvOverrides
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	bindEnvOverrides(v, reflect.TypeOf(Config{}), "")
 
 	// Read config file
 	if err := v.ReadInConfig(); err != nil {
@@ -96,21 +265,60 @@ func LoadConfig() (*Config, error) {
 	v.Set("database.password", expandEnvWithDefault(v.GetString("database.password"), ""))
 	v.Set("auth.jwt_secret", expandEnvWithDefault(v.GetString("auth.jwt_secret"), ""))
 	v.Set("auth.csrf_secret", expandEnvWithDefault(v.GetString("auth.csrf_secret"), ""))
+	v.Set("auth.jwt_key_id", expandEnvWithDefault(v.GetString("auth.jwt_key_id"), ""))
+	v.Set("auth.admin_emails", expandEnvList(v.Get("auth.admin_emails")))
+	v.Set("server.cors.allowed_origins", expandEnvList(v.Get("server.cors.allowed_origins")))
+	v.Set("server.trusted_proxies", expandEnvList(v.Get("server.trusted_proxies")))
 
 	// Unmarshal into config struct
 	var config Config
 	if err := v.Unmarshal(&config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
+	for i, key := range config.Auth.JWTVerificationKeys {
+		config.Auth.JWTVerificationKeys[i].Secret = expandEnvWithDefault(key.Secret, "")
+	}
 
-	// Validate configuration
-	if err := validateConfig(&config); err != nil {
-		return nil, fmt.Errorf("config validation failed: %w", err)
+	// Validate every section, reporting all problems at once
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
 
 	return &config, nil
 }
 
+// bindEnvOverrides binds an environment variable to every setting of t, so settings missing
+// from the config file can be overridden too; AutomaticEnv only covers the keys the file sets.
+// The variable is the key in upper case with dots replaced by underscores. Lists take a comma
+// separated value; maps and lists of objects can only be set in the config file.
+//
+// Precedence, highest first: the environment variable, the config file value (with a ${VAR}
+// placeholder replaced by VAR, or by the built-in default when VAR is unset), then the zero
+// value, which most settings treat as "use the default".
+func bindEnvOverrides(v *viper.Viper, t reflect.Type, prefix string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("mapstructure")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Duration(0)):
+			bindEnvOverrides(v, field.Type, key)
+		case field.Type.Kind() == reflect.Map,
+			field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.Struct:
+			continue
+		default:
+			// Only fails without a key
+			_ = v.BindEnv(key)
+		}
+	}
+}
+
 // getEnvironment determines the current environment
 func getEnvironment() string {
 	// Check various environment variables
@@ -195,13 +403,21 @@ func expandEnvIntWithDefault(value string, defaultValue int) int {
 	return defaultValue
 }
 
-// validateConfig validates the configuration using struct tags
-func validateConfig(config *Config) error {
-	validator := validator.New()
-	if err := validator.Struct(config); err != nil {
-		return fmt.Errorf("validation errors: %w", err)
+// expandEnvList expands a comma separated environment variable into a list.
+// Values already given as a YAML list are returned unchanged.
+func expandEnvList(value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+
+	var items []string
+	for _, item := range strings.Split(expandEnvWithDefault(str, ""), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
-	return nil
+	return items
 }
 
 // GetConfigPath returns the path to the config file being used
@@ -233,25 +449,24 @@ func MustLoadConfig() *Config {
 
 // GetDSN returns the database connection string
 func (d *DatabaseConfig) GetDSN() string {
-	if d.Database == ":memory:" {
-		// SQLite in-memory database
-		return ":memory:"
-	}
-	if d.Host == "" {
-		// SQLite file database
+	if d.IsSQLite() {
+		// SQLite file path, or ":memory:"
 		return d.Database
 	}
-	// MySQL connection string
-	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s",
+	// MySQL connection string; times are stored in UTC, matching GORM's NowFunc
+	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=UTC",
 		d.Username, d.Password, d.Host, d.Port, d.Database)
 }
 
 // IsMySQL returns true if this is a MySQL database configuration
 func (d *DatabaseConfig) IsMySQL() bool {
-	return d.Host != "" && d.Port > 0
+	if d.Driver != "" {
+		return d.Driver == DriverMySQL
+	}
+	return d.Host != "" && d.Port > 0 && d.Database != ":memory:"
 }
 
 // IsSQLite returns true if this is a SQLite database configuration
 func (d *DatabaseConfig) IsSQLite() bool {
-	return d.Host == "" || d.Database == ":memory:"
+	return !d.IsMySQL()
 }
@@ -0,0 +1,240 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// useEnvironment makes LoadConfig read configs/<env>.yaml
+func useEnvironment(t *testing.T, env string) {
+	t.Setenv("GO_ENV", "")
+	t.Setenv("GIN_MODE", "")
+	t.Setenv("APP_ENV", env)
+}
+
+func TestLoadConfig_EnvironmentOverridesConfigFile(t *testing.T) {
+	useEnvironment(t, "test")
+	// Set in test.yaml
+	t.Setenv("SERVER_PORT", "9191")
+	t.Setenv("AUTH_REFRESH_TOKEN_TTL", "1h")
+	// Not in test.yaml
+	t.Setenv("SERVER_SHUTDOWN_TIMEOUT", "7s")
+	t.Setenv("AUTH_PASSWORD_POLICY_REQUIRE_SYMBOL", "true")
+	t.Setenv("SERVER_TRUSTED_PROXIES", "10.0.0.0/8, 192.168.0.1")
+
+	cfg, err := LoadConfig()
+
+	require.NoError(t, err)
+	assert.Equal(t, 9191, cfg.Server.Port)
+	assert.Equal(t, time.Hour, cfg.Auth.RefreshTokenTTL)
+	assert.Equal(t, 7*time.Second, cfg.Server.ShutdownTimeout)
+	assert.True(t, cfg.Auth.PasswordPolicy.RequireSymbol)
+	assert.Equal(t, []string{"10.0.0.0/8", "192.168.0.1"}, cfg.Server.TrustedProxies)
+	// Settings without a variable keep the file value
+	assert.Equal(t, time.Minute, cfg.Auth.AccessTokenTTL)
+	assert.Equal(t, "test", cfg.Server.Environment)
+}
+
+func TestLoadConfig_OverrideBeatsPlaceholder(t *testing.T) {
+	useEnvironment(t, "production")
+	t.Setenv("JWT_SECRET", strings.Repeat("j", 32))
+	t.Setenv("AUTH_JWT_SECRET", strings.Repeat("o", 32))
+	t.Setenv("CSRF_SECRET", strings.Repeat("c", 32))
+	t.Setenv("DB_USERNAME", "app")
+
+	cfg, err := LoadConfig()
+
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("o", 32), cfg.Auth.JWTSecret)
+	assert.Equal(t, strings.Repeat("c", 32), cfg.Auth.CSRFSecret, "the ${CSRF_SECRET} placeholder is expanded")
+	assert.Equal(t, "localhost", cfg.Database.Host, "an unset placeholder falls back to the default")
+}
+
+func TestLoadConfig_ReportsEveryProblem(t *testing.T) {
+	useEnvironment(t, "production")
+	t.Setenv("JWT_SECRET", "")
+	t.Setenv("CSRF_SECRET", "")
+	t.Setenv("DB_USERNAME", "app")
+	t.Setenv("AUTH_ACCESS_TOKEN_TTL", "0s")
+	t.Setenv("LOGGING_LEVEL", "verbose")
+
+	cfg, err := LoadConfig()
+
+	assert.Nil(t, cfg)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "got %v", err)
+	assert.Equal(t, []string{
+		"auth.access_token_ttl: is required",
+		"auth.csrf_secret: is required",
+		"auth.jwt_secret: is required",
+		`logging.level: must be one of: debug, info, warn, error (got "verbose")`,
+	}, validationErr.Problems)
+	assert.True(t, strings.HasPrefix(err.Error(), "invalid configuration (4 problems):\n  - auth.access_token_ttl"))
+}
+
+// validConfig returns a configuration that passes Validate
+func validConfig() *Config {
+	return &Config{
+		Server: ServerConfig{
+			Port: 8080, Environment: "production",
+			ReadTimeout: time.Second, WriteTimeout: time.Second, IdleTimeout: time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver: DriverMySQL, Host: "db", Port: 3306, Database: "buyorbye", Username: "app",
+			MaxIdleConns: 1, MaxOpenConns: 1,
+		},
+		Auth: AuthConfig{
+			JWTSecret: strings.Repeat("j", 32), CSRFSecret: strings.Repeat("c", 32), BCryptCost: 10,
+			AccessTokenTTL: 15 * time.Minute, RefreshTokenTTL: 168 * time.Hour,
+		},
+		Logging: LoggingConfig{Level: "info", Environment: "production"},
+		Finance: FinanceConfig{EmergencyFundMonths: 6},
+		Health:  HealthConfig{Attachments: AttachmentsConfig{StoragePath: "/var/lib/buyorbye"}},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   []string
+	}{
+		{
+			name:   "valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "short and reused secrets",
+			modify: func(c *Config) {
+				c.Auth.JWTSecret = "too-short"
+				c.Auth.CSRFSecret = c.Auth.JWTSecret
+			},
+			want: []string{
+				"auth.csrf_secret: must be at least 32 characters long",
+				"auth.csrf_secret: must differ from auth.jwt_secret",
+				"auth.jwt_secret: must be at least 32 characters long",
+			},
+		},
+		{
+			name: "access token outlives refresh token",
+			modify: func(c *Config) {
+				c.Auth.AccessTokenTTL = 2 * time.Hour
+				c.Auth.RefreshTokenTTL = time.Hour
+			},
+			want: []string{"auth.access_token_ttl: must be shorter than auth.refresh_token_ttl (1h)"},
+		},
+		{
+			name: "token lifetimes too long",
+			modify: func(c *Config) {
+				c.Auth.AccessTokenTTL = 25 * time.Hour
+				c.Auth.RefreshTokenTTL = 31 * 24 * time.Hour
+			},
+			want: []string{
+				"auth.access_token_ttl: must be at most 24h",
+				"auth.refresh_token_ttl: must be at most 720h",
+			},
+		},
+		{
+			name: "verification key reuses the signing key",
+			modify: func(c *Config) {
+				c.Auth.JWTVerificationKeys = []JWTKeyConfig{{ID: "primary", Secret: c.Auth.JWTSecret}}
+			},
+			want: []string{
+				`auth.jwt_verification_keys[0].id: "primary" is already used by another key`,
+				"auth.jwt_verification_keys[0].secret: must differ from auth.jwt_secret",
+			},
+		},
+		{
+			name: "mysql without connection settings",
+			modify: func(c *Config) {
+				c.Database.Host = ""
+				c.Database.Port = 0
+				c.Database.Username = ""
+			},
+			want: []string{
+				"database.host: is required for mysql",
+				"database.port: must be between 1 and 65535 for mysql",
+				"database.username: is required for mysql",
+			},
+		},
+		{
+			name: "sqlite needs no connection settings",
+			modify: func(c *Config) {
+				c.Database = DatabaseConfig{Driver: DriverSQLite, Database: ":memory:", MaxIdleConns: 1, MaxOpenConns: 1}
+			},
+		},
+		{
+			name: "unknown environment, level and port",
+			modify: func(c *Config) {
+				c.Server.Environment = "staging"
+				c.Server.Port = 70000
+				c.Logging.Level = "trace"
+				c.Auth.Cookies.SameSite = "none"
+			},
+			want: []string{
+				`auth.cookies.same_site: must be one of: strict, lax (got "none")`,
+				`logging.level: must be one of: debug, info, warn, error (got "trace")`,
+				`server.environment: must be one of: development, production, test (got "staging")`,
+				"server.port: must be at most 65535",
+			},
+		},
+		{
+			name: "development features in production",
+			modify: func(c *Config) {
+				c.Server.EnableSwagger = true
+				c.Server.EnableDemoData = true
+				c.Webhooks.AllowPrivateNetworks = true
+				c.Auth.Cookies.Enabled = true
+			},
+			want: []string{
+				"auth.cookies.secure: must be true in production when cookie sessions are enabled",
+				"server.enable_demo_data: must be false in production",
+				"server.enable_swagger: must be false in production",
+				"webhooks.allow_private_networks: must be false in production",
+			},
+		},
+		{
+			name: "development features outside production",
+			modify: func(c *Config) {
+				c.Server.Environment = "development"
+				c.Server.EnableSwagger = true
+				c.Server.EnableDemoData = true
+				c.Webhooks.AllowPrivateNetworks = true
+				c.Auth.Cookies.Enabled = true
+			},
+		},
+		{
+			name: "invalid list entries",
+			modify: func(c *Config) {
+				c.Auth.AdminEmails = []string{"admin@example.com", "not-an-email"}
+				c.Server.TrustedProxies = []string{"lb.internal"}
+			},
+			want: []string{
+				`auth.admin_emails[1]: "not-an-email" is not a valid email address`,
+				`server.trusted_proxies: "lb.internal" is neither an IP address nor a CIDR range`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr), "got %v", err)
+			assert.Equal(t, tt.want, validationErr.Problems)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CORSConfig holds the cross-origin policy for browser clients.
+// Empty lists and a zero max_age are filled from DefaultCORSConfig for the environment.
+type CORSConfig struct {
+	// AllowedOrigins are exact origins ("https://app.example.com"), subdomain
+	// patterns ("https://*.example.com") or "*" for any origin
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+	AllowedMethods []string `mapstructure:"allowed_methods"`
+	// AllowedHeaders are the request headers preflights may ask for; "*" allows any
+	// but Authorization, which browsers require to be listed by name
+	AllowedHeaders []string `mapstructure:"allowed_headers"`
+	// ExposedHeaders are the response headers browser scripts may read
+	ExposedHeaders []string `mapstructure:"exposed_headers"`
+	// AllowCredentials lets browsers send cookies and HTTP authentication cross-origin;
+	// it can't be combined with a "*" origin
+	AllowCredentials bool `mapstructure:"allow_credentials"`
+	// MaxAge is how long browsers may cache a preflight response
+	MaxAge time.Duration `mapstructure:"max_age" validate:"min=0"`
+}
+
+// DefaultCORSConfig returns the CORS policy for environment. Development and test allow
+// any origin; production allows none until origins are configured.
+func DefaultCORSConfig(environment string) CORSConfig {
+	config := CORSConfig{
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{
+			"Authorization",
+			"Content-Type",
+			"Idempotency-Key",
+			"If-None-Match",
+			"X-API-Key",
+			"X-CSRF-Token",
+			"X-Request-ID",
+		},
+		ExposedHeaders: []string{
+			"X-Request-ID",
+			"ETag",
+			"Idempotent-Replayed",
+			"X-RateLimit-Limit",
+			"X-RateLimit-Remaining",
+			"X-RateLimit-Reset",
+		},
+	}
+
+	switch environment {
+	case "production":
+		config.MaxAge = 2 * time.Hour // The most Chromium honours
+	default:
+		config.AllowedOrigins = []string{"*"}
+		config.MaxAge = 10 * time.Minute
+	}
+
+	return config
+}
+
+// WithDefaults returns the config with unset settings taken from DefaultCORSConfig(environment).
+// AllowCredentials is kept as configured.
+func (c CORSConfig) WithDefaults(environment string) CORSConfig {
+	defaults := DefaultCORSConfig(environment)
+	if len(c.AllowedOrigins) == 0 {
+		c.AllowedOrigins = defaults.AllowedOrigins
+	}
+	if len(c.AllowedMethods) == 0 {
+		c.AllowedMethods = defaults.AllowedMethods
+	}
+	if len(c.AllowedHeaders) == 0 {
+		c.AllowedHeaders = defaults.AllowedHeaders
+	}
+	if len(c.ExposedHeaders) == 0 {
+		c.ExposedHeaders = defaults.ExposedHeaders
+	}
+	if c.MaxAge == 0 {
+		c.MaxAge = defaults.MaxAge
+	}
+	return c
+}
+
+// Validate checks that every origin is well formed and that credentials aren't
+// allowed for any origin
+func (c CORSConfig) Validate() error {
+	for _, origin := range c.AllowedOrigins {
+		if origin == "*" {
+			if c.AllowCredentials {
+				return errors.New("allow_credentials can't be combined with the \"*\" origin; list the allowed origins instead")
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateOrigin checks that origin is a scheme and host, optionally with a port, where
+// the host may start with "*." to match any subdomain
+func validateOrigin(origin string) error {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || (scheme != "http" && scheme != "https") {
+		return fmt.Errorf("origin %q must start with http:// or https://", origin)
+	}
+
+	host = strings.TrimPrefix(host, "*.")
+	parsed, err := url.Parse(scheme + "://" + host)
+	// A path, query or userinfo leaves parsed.Host different from host
+	if err != nil || parsed.Hostname() == "" || parsed.Host != host || strings.Contains(host, "*") {
+		return fmt.Errorf("origin %q must be a scheme and host with an optional port, and may only use a wildcard as a leading \"*.\"", origin)
+	}
+	return nil
+}
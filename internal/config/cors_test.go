@@ -0,0 +1,72 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CORSConfig
+		wantErr string
+	}{
+		{
+			name:   "development defaults",
+			config: CORSConfig{}.WithDefaults("development"),
+		},
+		{
+			name:   "credentials with listed origins",
+			config: CORSConfig{AllowedOrigins: []string{"https://app.example.com", "https://*.example.com:8443"}, AllowCredentials: true},
+		},
+		{
+			name:    "credentials with the wildcard origin",
+			config:  CORSConfig{AllowedOrigins: []string{"https://app.example.com", "*"}, AllowCredentials: true},
+			wantErr: "allow_credentials",
+		},
+		{
+			name:    "credentials with the development default origin",
+			config:  CORSConfig{AllowCredentials: true}.WithDefaults("development"),
+			wantErr: "allow_credentials",
+		},
+		{
+			name:    "origin without a scheme",
+			config:  CORSConfig{AllowedOrigins: []string{"app.example.com"}},
+			wantErr: "http:// or https://",
+		},
+		{
+			name:    "origin with a path",
+			config:  CORSConfig{AllowedOrigins: []string{"https://app.example.com/login"}},
+			wantErr: "scheme and host",
+		},
+		{
+			name:    "wildcard inside the host",
+			config:  CORSConfig{AllowedOrigins: []string{"https://app.*.example.com"}},
+			wantErr: "scheme and host",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCORSConfig_WithDefaults(t *testing.T) {
+	production := CORSConfig{AllowedOrigins: []string{"https://app.example.com"}, AllowCredentials: true}.WithDefaults("production")
+
+	assert.Equal(t, []string{"https://app.example.com"}, production.AllowedOrigins)
+	assert.True(t, production.AllowCredentials)
+	assert.NotContains(t, production.AllowedHeaders, "*")
+	assert.NotZero(t, production.MaxAge)
+
+	assert.Empty(t, DefaultCORSConfig("production").AllowedOrigins)
+	assert.Equal(t, []string{"*"}, DefaultCORSConfig("development").AllowedOrigins)
+}
@@ -1,12 +1,13 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/DuckDHD/BuyOrBye/internal/database"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
-	"gorm.io/driver/mysql"
-	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
@@ -19,6 +20,9 @@ type DatabaseService interface {
 	// Health returns database health status
 	Health() map[string]string
 
+	// Ping checks the database is reachable through the underlying *sql.DB
+	Ping(ctx context.Context) error
+
 	// Close closes the database connection
 	Close() error
 }
@@ -30,6 +34,10 @@ func NewDatabaseService(config *DatabaseConfig, logConfig *LoggingConfig) (Datab
 		return nil, fmt.Errorf("failed to setup database: %w", err)
 	}
 
+	if err := database.HashLegacyRefreshTokens(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate refresh tokens: %w", err)
+	}
+
 	// Auto-migrate the schema for all models
 	if err := db.AutoMigrate(
 		&models.UserModel{},
@@ -101,6 +109,17 @@ func (d *databaseService) Health() map[string]string {
 	return stats
 }
 
+func (d *databaseService) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql.DB: %w", err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("db ping failed: %w", err)
+	}
+	return nil
+}
+
 func (d *databaseService) Close() error {
 	sqlDB, err := d.db.DB()
 	if err != nil {
@@ -111,34 +130,28 @@ func (d *databaseService) Close() error {
 
 // setupDatabase initializes the database connection
 func setupDatabase(config *DatabaseConfig, logConfig *LoggingConfig) (*gorm.DB, error) {
-	var dialector gorm.Dialector
-
-	if config.IsMySQL() {
-		dialector = mysql.Open(config.GetDSN())
-	} else {
-		dialector = sqlite.Open(config.GetDSN())
-	}
-
-	// Configure GORM logger based on logging config
-	gormLogger := logger.Default
-	switch logConfig.Level {
-	case "debug":
-		gormLogger = logger.Default.LogMode(logger.Info)
-	case "info":
-		gormLogger = logger.Default.LogMode(logger.Warn)
-	case "warn", "error":
-		gormLogger = logger.Default.LogMode(logger.Error)
-	default:
-		gormLogger = logger.Default.LogMode(logger.Silent)
-	}
+	// Route GORM's logs through zap at a level matching the logging config
+	gormLogger := logging.NewGormLogger(logging.DatabaseLogger(), logging.GormLoggerConfig{
+		LogLevel:       logging.GormLogLevel(logConfig.Level),
+		SlowThreshold:  config.SlowQueryThreshold,
+		RedactedTables: logging.SensitiveTables,
+	})
 
-	// Open database connection
-	db, err := gorm.Open(dialector, &gorm.Config{
+	gormConfig := &gorm.Config{
 		Logger: gormLogger,
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
-	})
+	}
+
+	// Open database connection
+	var db *gorm.DB
+	var err error
+	if config.IsMySQL() {
+		db, err = database.ConnectMySQL(config.GetDSN(), gormConfig)
+	} else {
+		db, err = database.ConnectSQLite(config.GetDSN(), gormConfig)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -0,0 +1,80 @@
+package config
+
+import "github.com/DuckDHD/BuyOrBye/internal/domain"
+
+// AffordabilityMultipliersConfig holds the disposable income multiplier for each debt-to-income band
+type AffordabilityMultipliersConfig struct {
+	Excellent float64 `mapstructure:"excellent" validate:"min=0"`
+	Good      float64 `mapstructure:"good" validate:"min=0"`
+	Fair      float64 `mapstructure:"fair" validate:"min=0"`
+	Poor      float64 `mapstructure:"poor" validate:"min=0"`
+}
+
+// HealthScoreConfig weighs the components of the financial health score and sets the score
+// each health label needs
+type HealthScoreConfig struct {
+	Weights        HealthScoreWeightsConfig `mapstructure:"weights"`
+	ExcellentScore float64                  `mapstructure:"excellent_score" validate:"min=0,max=100"`
+	GoodScore      float64                  `mapstructure:"good_score" validate:"min=0,max=100"`
+	FairScore      float64                  `mapstructure:"fair_score" validate:"min=0,max=100"`
+	// Expenses, before loan payments, as a share of income that score as Excellent and as the Poor boundary
+	ExcellentExpenseRatio float64 `mapstructure:"excellent_expense_ratio" validate:"min=0,max=1"`
+	PoorExpenseRatio      float64 `mapstructure:"poor_expense_ratio" validate:"min=0,max=1"`
+}
+
+// HealthScoreWeightsConfig holds the relative weight of each financial health score component
+type HealthScoreWeightsConfig struct {
+	DebtToIncome  float64 `mapstructure:"debt_to_income" validate:"min=0"`
+	SavingsRate   float64 `mapstructure:"savings_rate" validate:"min=0"`
+	ExpenseRatio  float64 `mapstructure:"expense_ratio" validate:"min=0"`
+	EmergencyFund float64 `mapstructure:"emergency_fund" validate:"min=0"`
+	DebtTrend     float64 `mapstructure:"debt_trend" validate:"min=0"`
+}
+
+// Thresholds builds the financial thresholds, filling any ratio or rate left at 0 from
+// domain.DefaultFinancialThresholds. The multipliers and the health score weights are each taken
+// as a set: if none is configured the defaults are used, otherwise all of them are used as given.
+func (c FinanceConfig) Thresholds() domain.FinancialThresholds {
+	thresholds := domain.DefaultFinancialThresholds()
+
+	setIfConfigured(&thresholds.ExcellentDTI, c.ExcellentDTIRatio)
+	setIfConfigured(&thresholds.HealthyDTI, c.HealthyDTIRatio)
+	setIfConfigured(&thresholds.PoorDTI, c.PoorDTIRatio)
+	setIfConfigured(&thresholds.ExcellentSavingsRate, c.MinSavingsRate)
+	setIfConfigured(&thresholds.GoodSavingsRate, c.GoodSavingsRate)
+	setIfConfigured(&thresholds.FairSavingsRate, c.FairSavingsRate)
+	setIfConfigured(&thresholds.EmergencyFundMonths, float64(c.EmergencyFundMonths))
+	setIfConfigured(&thresholds.ExcellentScore, c.HealthScore.ExcellentScore)
+	setIfConfigured(&thresholds.GoodScore, c.HealthScore.GoodScore)
+	setIfConfigured(&thresholds.FairScore, c.HealthScore.FairScore)
+	setIfConfigured(&thresholds.ExcellentExpenseRatio, c.HealthScore.ExcellentExpenseRatio)
+	setIfConfigured(&thresholds.PoorExpenseRatio, c.HealthScore.PoorExpenseRatio)
+
+	if c.AffordabilityMultipliers != (AffordabilityMultipliersConfig{}) {
+		thresholds.AffordabilityMultipliers = domain.AffordabilityMultipliers{
+			Excellent: c.AffordabilityMultipliers.Excellent,
+			Good:      c.AffordabilityMultipliers.Good,
+			Fair:      c.AffordabilityMultipliers.Fair,
+			Poor:      c.AffordabilityMultipliers.Poor,
+		}
+	}
+
+	if weights := c.HealthScore.Weights; weights != (HealthScoreWeightsConfig{}) {
+		thresholds.ScoreWeights = domain.HealthScoreWeights{
+			DebtToIncome:  weights.DebtToIncome,
+			SavingsRate:   weights.SavingsRate,
+			ExpenseRatio:  weights.ExpenseRatio,
+			EmergencyFund: weights.EmergencyFund,
+			DebtTrend:     weights.DebtTrend,
+		}
+	}
+
+	return thresholds
+}
+
+// setIfConfigured overwrites target with value unless value was left unset
+func setIfConfigured(target *float64, value float64) {
+	if value != 0 {
+		*target = value
+	}
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// RiskModelConfig tunes the health risk score. Every section is optional:
+// missing band lists and cutoffs use the defaults, and severity points and
+// category weights are merged over the defaults key by key.
+type RiskModelConfig struct {
+	AgeBands        []RiskBandConfig       `mapstructure:"age_bands"`
+	BMIBands        []RiskBandConfig       `mapstructure:"bmi_bands"`
+	FamilySizeBands []RiskBandConfig       `mapstructure:"family_size_bands"`
+	SeverityPoints  map[string]int         `mapstructure:"severity_points"`
+	CategoryWeights map[string]float64     `mapstructure:"category_weights"`
+	LevelCutoffs    RiskLevelCutoffsConfig `mapstructure:"level_cutoffs"`
+}
+
+// RiskBandConfig awards points to values from min up to max (exclusive unless include_max is set);
+// leave max out on the last band to make it open-ended
+type RiskBandConfig struct {
+	Min        float64 `mapstructure:"min"`
+	Max        float64 `mapstructure:"max"`
+	IncludeMax bool    `mapstructure:"include_max"`
+	Points     int     `mapstructure:"points"`
+}
+
+// RiskLevelCutoffsConfig holds the highest score of the low, moderate and high risk levels
+type RiskLevelCutoffsConfig struct {
+	Low      int `mapstructure:"low"`
+	Moderate int `mapstructure:"moderate"`
+	High     int `mapstructure:"high"`
+}
+
+// ToDomain builds the risk model, filling anything not configured from domain.DefaultRiskModel
+func (c RiskModelConfig) ToDomain() domain.RiskModel {
+	model := domain.DefaultRiskModel()
+
+	if len(c.AgeBands) > 0 {
+		model.AgeBands = toRiskBands(c.AgeBands)
+	}
+	if len(c.BMIBands) > 0 {
+		model.BMIBands = toRiskBands(c.BMIBands)
+	}
+	if len(c.FamilySizeBands) > 0 {
+		model.FamilySizeBands = toRiskBands(c.FamilySizeBands)
+	}
+	for severity, points := range c.SeverityPoints {
+		model.SeverityPoints[severity] = points
+	}
+	for category, weight := range c.CategoryWeights {
+		model.CategoryWeights[category] = weight
+	}
+	if c.LevelCutoffs != (RiskLevelCutoffsConfig{}) {
+		model.LevelCutoffs = domain.RiskLevelCutoffs{
+			Low:      c.LevelCutoffs.Low,
+			Moderate: c.LevelCutoffs.Moderate,
+			High:     c.LevelCutoffs.High,
+		}
+	}
+
+	return model
+}
+
+// toRiskBands converts configured bands to domain bands
+func toRiskBands(bands []RiskBandConfig) []domain.RiskBand {
+	result := make([]domain.RiskBand, len(bands))
+	for i, band := range bands {
+		result[i] = domain.RiskBand{
+			Min:        band.Min,
+			Max:        band.Max,
+			IncludeMax: band.IncludeMax,
+			Points:     band.Points,
+		}
+	}
+	return result
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 // LoggingService provides logging configuration management
@@ -139,52 +140,77 @@ func GetLoggingFormatForEnvironment(environment string) string {
 	}
 }
 
-// LoggingMiddlewareConfig returns middleware configuration for logging
+// LoggingMiddlewareConfig returns middleware configuration for logging, response compression
+// and request timeouts
 type LoggingMiddlewareConfig struct {
 	SkipPaths       []string
 	LogRequestBody  bool
 	LogResponseBody bool
 	MaxBodySize     int64
+
+	// CompressionEnabled turns on gzip/deflate response compression
+	CompressionEnabled bool
+	// CompressionMinSize is the smallest response body, in bytes, that is compressed
+	CompressionMinSize int
+
+	// RequestTimeout bounds each request; it stays below the server's write timeout so the
+	// client gets the 503 rather than a dropped connection
+	RequestTimeout time.Duration
 }
 
-// GetMiddlewareConfig returns logging middleware configuration for environment
+// GetMiddlewareConfig returns logging, compression and timeout middleware configuration for environment
 func GetMiddlewareConfig(environment string) LoggingMiddlewareConfig {
 	switch environment {
 	case "production":
 		return LoggingMiddlewareConfig{
 			SkipPaths: []string{
 				"/health",
+				"/health/live",
+				"/health/ready",
 				"/healthz", 
 				"/ping",
 				"/metrics",
 				"/favicon.ico",
 			},
-			LogRequestBody:  false, // Disabled for security
-			LogResponseBody: false, // Disabled for performance
-			MaxBodySize:     512,   // 512 bytes limit
+			LogRequestBody:     false, // Disabled for security
+			LogResponseBody:    false, // Disabled for performance
+			MaxBodySize:        512,   // 512 bytes limit
+			CompressionEnabled: true,
+			CompressionMinSize: 1024, // 1KB threshold
+			RequestTimeout:     25 * time.Second,
 		}
 	case "development":
 		return LoggingMiddlewareConfig{
 			SkipPaths: []string{
 				"/health",
+				"/health/live",
+				"/health/ready",
 				"/healthz",
 				"/ping",
 			},
-			LogRequestBody:  true,  // Enabled for debugging
-			LogResponseBody: true,  // Enabled for debugging
-			MaxBodySize:     2048,  // 2KB limit
+			LogRequestBody:     true, // Enabled for debugging
+			LogResponseBody:    true, // Enabled for debugging
+			MaxBodySize:        2048, // 2KB limit
+			CompressionEnabled: true,
+			CompressionMinSize: 1024, // 1KB threshold
+			RequestTimeout:     25 * time.Second,
 		}
 	case "test":
 		return LoggingMiddlewareConfig{
 			SkipPaths: []string{
 				"/health",
+				"/health/live",
+				"/health/ready",
 				"/healthz",
 				"/ping", 
 				"/metrics",
 			},
-			LogRequestBody:  false, // Disabled for test speed
-			LogResponseBody: false, // Disabled for test speed
-			MaxBodySize:     256,   // 256 bytes limit
+			LogRequestBody:     false, // Disabled for test speed
+			LogResponseBody:    false, // Disabled for test speed
+			MaxBodySize:        256,   // 256 bytes limit
+			CompressionEnabled: false, // Keeps test responses readable
+			CompressionMinSize: 1024,
+			RequestTimeout:     5 * time.Second,
 		}
 	default:
 		return GetMiddlewareConfig("development")
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
 	"time"
@@ -123,4 +124,17 @@ func ValidateServerConfig(config *ServerConfig) error {
 	}
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// ValidateTrustedProxies checks that every trusted proxy is an IP address or a CIDR range
+func ValidateTrustedProxies(proxies []string) error {
+	for _, proxy := range proxies {
+		if _, _, err := net.ParseCIDR(proxy); err == nil {
+			continue
+		}
+		if net.ParseIP(proxy) == nil {
+			return fmt.Errorf("%q is neither an IP address nor a CIDR range", proxy)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTrustedProxies(t *testing.T) {
+	assert.NoError(t, ValidateTrustedProxies(nil))
+	assert.NoError(t, ValidateTrustedProxies([]string{"10.0.0.0/8", "192.168.1.10", "2001:db8::/32", "::1"}))
+	assert.ErrorContains(t, ValidateTrustedProxies([]string{"10.0.0.0/8", "lb.internal"}), `"lb.internal"`)
+	assert.Error(t, ValidateTrustedProxies([]string{"10.0.0.0/33"}))
+}
@@ -0,0 +1,222 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Token lifetime limits; longer-lived tokens widen the window a stolen one can be used in
+const (
+	MaxAccessTokenTTL  = 24 * time.Hour
+	MaxRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// ValidationError lists every problem found in a configuration, each naming the setting
+// by its config file key (e.g. "auth.jwt_secret")
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invalid configuration (%d problem", len(e.Problems))
+	if len(e.Problems) != 1 {
+		b.WriteString("s")
+	}
+	b.WriteString("):")
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem)
+	}
+	return b.String()
+}
+
+// Validate checks every section of the configuration and returns a *ValidationError listing
+// all the problems found, so they can be fixed in one pass. LoadConfig calls it before
+// returning; configs built in code should call it too.
+func (c *Config) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	problems = append(problems, fieldProblems(c)...)
+	c.Database.validate(add)
+	c.Auth.validate(add)
+	c.validateFeatureFlags(add)
+
+	if err := c.Server.CORS.WithDefaults(c.Server.Environment).Validate(); err != nil {
+		add("server.cors: %v", err)
+	}
+	if err := ValidateTrustedProxies(c.Server.TrustedProxies); err != nil {
+		add("server.trusted_proxies: %v", err)
+	}
+	if err := c.Finance.Thresholds().Validate(); err != nil {
+		add("finance: %v", err)
+	}
+	if err := c.Health.RiskModel.ToDomain().Validate(); err != nil {
+		add("health.risk_model: %v", err)
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validate checks the connection settings the driver needs
+func (d *DatabaseConfig) validate(add func(string, ...any)) {
+	if !d.IsMySQL() {
+		return
+	}
+	if d.Host == "" {
+		add("database.host: is required for mysql")
+	}
+	if d.Port < 1 {
+		add("database.port: must be between 1 and 65535 for mysql")
+	}
+	if d.Username == "" {
+		add("database.username: is required for mysql")
+	}
+}
+
+// validate checks the token lifetimes and that no secret is reused for another purpose
+func (a *AuthConfig) validate(add func(string, ...any)) {
+	if a.AccessTokenTTL > MaxAccessTokenTTL {
+		add("auth.access_token_ttl: must be at most %s", shortDuration(MaxAccessTokenTTL))
+	}
+	if a.RefreshTokenTTL > MaxRefreshTokenTTL {
+		add("auth.refresh_token_ttl: must be at most %s", shortDuration(MaxRefreshTokenTTL))
+	}
+	if a.AccessTokenTTL > 0 && a.AccessTokenTTL >= a.RefreshTokenTTL {
+		add("auth.access_token_ttl: must be shorter than auth.refresh_token_ttl (%s)", shortDuration(a.RefreshTokenTTL))
+	}
+	if a.JWTSecret != "" && a.JWTSecret == a.CSRFSecret {
+		add("auth.csrf_secret: must differ from auth.jwt_secret")
+	}
+
+	signingKeyID := a.JWTKeyID
+	if signingKeyID == "" {
+		signingKeyID = "primary"
+	}
+	seen := map[string]bool{signingKeyID: true}
+	for i, key := range a.JWTVerificationKeys {
+		if key.ID != "" && seen[key.ID] {
+			add("auth.jwt_verification_keys[%d].id: %q is already used by another key", i, key.ID)
+		}
+		seen[key.ID] = true
+		if key.Secret != "" && key.Secret == a.JWTSecret {
+			add("auth.jwt_verification_keys[%d].secret: must differ from auth.jwt_secret", i)
+		}
+	}
+}
+
+// validateFeatureFlags rejects the development conveniences that must stay off in production
+func (c *Config) validateFeatureFlags(add func(string, ...any)) {
+	if c.Server.Environment != "production" {
+		return
+	}
+	if c.Server.EnableSwagger {
+		add("server.enable_swagger: must be false in production")
+	}
+	if c.Server.EnableDemoData {
+		add("server.enable_demo_data: must be false in production")
+	}
+	if c.Webhooks.AllowPrivateNetworks {
+		add("webhooks.allow_private_networks: must be false in production")
+	}
+	if c.Auth.Cookies.Enabled && !c.Auth.Cookies.Secure {
+		add("auth.cookies.secure: must be true in production when cookie sessions are enabled")
+	}
+}
+
+// fieldProblems checks the validate struct tags, naming each field by its config file key
+func fieldProblems(c *Config) []string {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("mapstructure")
+	})
+
+	err := validate.Struct(c)
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return nil
+	}
+
+	problems := make([]string, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		// Drop the leading "Config."
+		_, key, _ := strings.Cut(fe.Namespace(), ".")
+		problems = append(problems, key+": "+describeFieldError(fe))
+	}
+	return problems
+}
+
+// describeFieldError explains a failed validate tag in words
+func describeFieldError(fe validator.FieldError) string {
+	isString := fe.Kind() == reflect.String
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		if isString {
+			return fmt.Sprintf("must be at least %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at least %s", fe.Param())
+	case "max":
+		if isString {
+			return fmt.Sprintf("must be at most %s characters long", fe.Param())
+		}
+		return fmt.Sprintf("must be at most %s", fe.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gtefield":
+		return fmt.Sprintf("must not be less than %s", snakeCase(fe.Param()))
+	case "oneof":
+		return fmt.Sprintf("must be one of: %s (got %q)", strings.ReplaceAll(fe.Param(), " ", ", "), fmt.Sprint(fe.Value()))
+	case "email":
+		return fmt.Sprintf("%q is not a valid email address", fmt.Sprint(fe.Value()))
+	case "iso4217":
+		return fmt.Sprintf("%q is not an ISO 4217 currency code", fmt.Sprint(fe.Value()))
+	default:
+		return fmt.Sprintf("failed the %q rule", fe.Tag())
+	}
+}
+
+// snakeCase turns a Go field name into its config file key, e.g. MinLength into min_length
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// shortDuration formats d without trailing zero units, e.g. 24h rather than 24h0m0s
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
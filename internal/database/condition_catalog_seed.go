@@ -0,0 +1,88 @@
+package database
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+// conditionCatalogSeed is the condition catalog loaded by seedConditionCatalog
+//
+//go:embed migrations/condition_catalog.json
+var conditionCatalogSeed []byte
+
+// conditionCatalogSeedEntry is one entry of the condition catalog seed file
+type conditionCatalogSeedEntry struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Category        string   `json:"category"`
+	DefaultSeverity string   `json:"default_severity"`
+	RiskWeight      int      `json:"risk_weight"`
+	Aliases         []string `json:"aliases"`
+}
+
+// loadConditionCatalogSeed parses the embedded condition catalog, checking that every entry has
+// a unique ID, a name, a known category and severity and a risk weight of at least 0
+func loadConditionCatalogSeed() ([]models.ConditionCatalogModel, error) {
+	var entries []conditionCatalogSeedEntry
+	if err := json.Unmarshal(conditionCatalogSeed, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse condition catalog seed: %w", err)
+	}
+
+	categories := map[string]bool{"chronic": true, "acute": true, "mental_health": true, "preventive": true}
+	severities := map[string]bool{"mild": true, "moderate": true, "severe": true, "critical": true}
+	seen := make(map[string]bool, len(entries))
+
+	catalog := make([]models.ConditionCatalogModel, len(entries))
+	for i, entry := range entries {
+		switch {
+		case entry.ID == "" || entry.Name == "":
+			return nil, fmt.Errorf("condition catalog seed entry %d needs an ID and a name", i)
+		case seen[entry.ID]:
+			return nil, fmt.Errorf("condition catalog seed has duplicate ID %s", entry.ID)
+		case !categories[entry.Category]:
+			return nil, fmt.Errorf("condition catalog seed entry %s has unknown category %q", entry.ID, entry.Category)
+		case !severities[entry.DefaultSeverity]:
+			return nil, fmt.Errorf("condition catalog seed entry %s has unknown severity %q", entry.ID, entry.DefaultSeverity)
+		case entry.RiskWeight < 0:
+			return nil, fmt.Errorf("condition catalog seed entry %s has a negative risk weight", entry.ID)
+		}
+		seen[entry.ID] = true
+
+		catalog[i] = models.ConditionCatalogModel{
+			ID:              entry.ID,
+			Name:            entry.Name,
+			Category:        entry.Category,
+			DefaultSeverity: entry.DefaultSeverity,
+			RiskWeight:      entry.RiskWeight,
+		}
+		if len(entry.Aliases) > 0 {
+			catalog[i].Aliases = entry.Aliases
+		}
+	}
+	return catalog, nil
+}
+
+// seedConditionCatalog writes the embedded condition catalog to the condition_catalog table.
+// Existing entries are updated from the seed, so it is safe to run on every start; entries
+// dropped from the seed are kept because conditions may still reference them.
+func seedConditionCatalog(db *gorm.DB) error {
+	catalog, err := loadConditionCatalogSeed()
+	if err != nil {
+		return err
+	}
+
+	err = db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "category", "default_severity", "risk_weight", "aliases"}),
+	}).Create(&catalog).Error
+	if err != nil {
+		return fmt.Errorf("failed to seed condition catalog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Database drivers, as named by the Dialector of a connection
+const (
+	DriverMySQL  = "mysql"
+	DriverSQLite = "sqlite"
+)
+
+// SQLiteMemory is the path that opens a private in-memory SQLite database
+const SQLiteMemory = ":memory:"
+
+// ConnectMySQL opens a MySQL database from a go-sql-driver DSN
+func ConnectMySQL(dsn string, opts ...gorm.Option) (*gorm.DB, error) {
+	db, err := gorm.Open(mysql.Open(dsn), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to mysql: %w", err)
+	}
+	return db, nil
+}
+
+// ConnectSQLite opens the SQLite database file at path, creating it if needed, or a private
+// in-memory database when path is SQLiteMemory. Foreign keys are enforced so ON DELETE CASCADE
+// behaves as it does on MySQL.
+//
+// An in-memory database only exists inside the connection that created it, so its pool is
+// limited to that one connection and it is gone once closed.
+func ConnectSQLite(path string, opts ...gorm.Option) (*gorm.DB, error) {
+	db, err := gorm.Open(sqlite.Open(sqliteDSN(path)), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to sqlite: %w", err)
+	}
+
+	if path == SQLiteMemory {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sql.DB: %w", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+		sqlDB.SetMaxIdleConns(1)
+		sqlDB.SetConnMaxLifetime(0)
+		sqlDB.SetConnMaxIdleTime(0)
+	}
+
+	return db, nil
+}
+
+// sqliteDSN adds the connection options every SQLite connection needs to path.
+// The busy timeout makes writers wait for a lock instead of failing with "database is locked".
+func sqliteDSN(path string) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + "_foreign_keys=1&_busy_timeout=5000"
+}
+
+// isMySQL reports whether db is connected to MySQL, for statements only MySQL supports
+func isMySQL(db *gorm.DB) bool {
+	return db.Dialector.Name() == DriverMySQL
+}
@@ -51,6 +51,12 @@ func mustStartMySQLContainer() (func(context.Context, ...testcontainers.Terminat
 }
 
 func TestMain(m *testing.M) {
+	// BLUEPRINT_DB_DRIVER=sqlite runs the suite against in-memory SQLite, without Docker
+	if os.Getenv("BLUEPRINT_DB_DRIVER") == DriverSQLite {
+		os.Unsetenv("BLUEPRINT_DB_DATABASE")
+		os.Exit(m.Run())
+	}
+
 	teardown, err := mustStartMySQLContainer()
 	if err != nil {
 		log.Fatalf("could not start mysql container: %v", err)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
@@ -16,33 +15,49 @@ type GormService struct {
 	db *gorm.DB
 }
 
-// NewGormService creates a new GORM database service
+// NewGormService creates a new GORM database service.
+// BLUEPRINT_DB_DRIVER=sqlite uses the SQLite file named by BLUEPRINT_DB_DATABASE, or an in-memory
+// database when that is unset; otherwise MySQL is configured from the BLUEPRINT_DB_* variables.
 func NewGormService() (*GormService, error) {
 	// Get database configuration from environment
+	driver := os.Getenv("BLUEPRINT_DB_DRIVER")
 	dbname := os.Getenv("BLUEPRINT_DB_DATABASE")
 	password := os.Getenv("BLUEPRINT_DB_PASSWORD")
 	username := os.Getenv("BLUEPRINT_DB_USERNAME")
 	port := os.Getenv("BLUEPRINT_DB_PORT")
 	host := os.Getenv("BLUEPRINT_DB_HOST")
 
-	// Build MySQL DSN
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
-		username, password, host, port, dbname)
-
 	// Configure GORM logger based on environment
 	logLevel := logger.Info
 	if os.Getenv("APP_ENV") == "production" {
 		logLevel = logger.Warn
 	}
+	gormConfig := &gorm.Config{
+		Logger: logger.Default.LogMode(logLevel),
+	}
 
 	// Open GORM connection
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logLevel),
-	})
+	var db *gorm.DB
+	var err error
+	if driver == DriverSQLite {
+		if dbname == "" {
+			dbname = SQLiteMemory
+		}
+		db, err = ConnectSQLite(dbname, gormConfig)
+	} else {
+		// Build MySQL DSN
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=True&loc=Local",
+			username, password, host, port, dbname)
+		db, err = ConnectMySQL(dsn, gormConfig)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := HashLegacyRefreshTokens(db); err != nil {
+		return nil, fmt.Errorf("failed to migrate refresh tokens: %w", err)
+	}
+
 	// Auto-migrate the schema for all models
 	if err := db.AutoMigrate(
 		&models.UserModel{},
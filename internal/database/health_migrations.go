@@ -9,17 +9,29 @@ import (
 
 // RunHealthMigrations runs all health-related database migrations
 func RunHealthMigrations(db *gorm.DB) error {
+	if err := dropLegacyProfileUniqueness(db); err != nil {
+		return err
+	}
+	if err := dropLegacyExpenseFrequencyCheck(db); err != nil {
+		return err
+	}
+	if err := dropLegacyPolicyNumberUniqueness(db); err != nil {
+		return err
+	}
+
 	// Auto-migrate health models in dependency order
-	// HealthProfile must be created first as others reference it
-	if err := db.AutoMigrate(
-		&models.HealthProfileModel{},
-		&models.MedicalConditionModel{},
-		&models.MedicalExpenseModel{},
-		&models.InsurancePolicyModel{},
-	); err != nil {
+	if err := db.AutoMigrate(healthModels()...); err != nil {
 		return fmt.Errorf("failed to auto-migrate health models: %w", err)
 	}
 
+	if err := seedConditionCatalog(db); err != nil {
+		return err
+	}
+
+	if err := backfillSelfProfiles(db); err != nil {
+		return err
+	}
+
 	// Create custom indexes for better query performance
 	if err := createHealthIndexes(db); err != nil {
 		return fmt.Errorf("failed to create health indexes: %w", err)
@@ -28,58 +40,115 @@ func RunHealthMigrations(db *gorm.DB) error {
 	return nil
 }
 
-// createHealthIndexes creates custom composite indexes for health tables
-func createHealthIndexes(db *gorm.DB) error {
-	// Health profiles indexes
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_health_profiles_user_active ON health_profiles(user_id, created_at)").Error; err != nil {
-		return fmt.Errorf("failed to create health_profiles user_active index: %w", err)
-	}
+// legacyProfileUniqueIndexes are the one-profile-per-user indexes and constraints created
+// before dependent profiles were supported
+var legacyProfileUniqueIndexes = []string{
+	"idx_health_profiles_user_id",
+	"unique_user_profile",
+	"unique_user_health_profile",
+}
 
-	// Medical conditions indexes  
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_medical_conditions_user_category_active ON medical_conditions(user_id, category, is_active)").Error; err != nil {
-		return fmt.Errorf("failed to create medical_conditions composite index: %w", err)
-	}
-	
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_medical_conditions_profile_severity ON medical_conditions(profile_id, severity, is_active)").Error; err != nil {
-		return fmt.Errorf("failed to create medical_conditions severity index: %w", err)
+// dropLegacyProfileUniqueness removes the unique index on health_profiles.user_id so an account can
+// hold dependent profiles. One self profile per user is enforced by the unique self_user_id column instead.
+func dropLegacyProfileUniqueness(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.HealthProfileModel{}) || migrator.HasColumn(&models.HealthProfileModel{}, "relation_to_owner") {
+		return nil
 	}
 
-	// Medical expenses indexes
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_medical_expenses_user_date_category ON medical_expenses(user_id, date DESC, category)").Error; err != nil {
-		return fmt.Errorf("failed to create medical_expenses date_category index: %w", err)
-	}
-	
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_medical_expenses_profile_recurring ON medical_expenses(profile_id, is_recurring, frequency)").Error; err != nil {
-		return fmt.Errorf("failed to create medical_expenses recurring index: %w", err)
+	for _, name := range legacyProfileUniqueIndexes {
+		if migrator.HasIndex(&models.HealthProfileModel{}, name) {
+			if err := migrator.DropIndex(&models.HealthProfileModel{}, name); err != nil {
+				return fmt.Errorf("failed to drop legacy health profile index %s: %w", name, err)
+			}
+		}
 	}
-	
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_medical_expenses_amount_covered ON medical_expenses(amount, is_covered, insurance_payment)").Error; err != nil {
-		return fmt.Errorf("failed to create medical_expenses coverage index: %w", err)
+
+	return nil
+}
+
+// legacyPolicyNumberUniqueIndexes made policy numbers unique across all users. Two insurers can
+// issue the same number, so numbers are now unique per user through idx_insurance_policies_user_number.
+var legacyPolicyNumberUniqueIndexes = []string{
+	"idx_insurance_policies_policy_number",
+	"unique_policy_number",
+}
+
+// dropLegacyPolicyNumberUniqueness removes the global unique index on insurance_policies.policy_number.
+// AutoMigrate creates the per-user index but never drops an index the model no longer declares.
+func dropLegacyPolicyNumberUniqueness(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.InsurancePolicyModel{}) {
+		return nil
 	}
 
-	// Insurance policies indexes
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_insurance_policies_user_active_dates ON insurance_policies(user_id, is_active, start_date, end_date)").Error; err != nil {
-		return fmt.Errorf("failed to create insurance_policies active_dates index: %w", err)
+	for _, name := range legacyPolicyNumberUniqueIndexes {
+		if migrator.HasIndex(&models.InsurancePolicyModel{}, name) {
+			if err := migrator.DropIndex(&models.InsurancePolicyModel{}, name); err != nil {
+				return fmt.Errorf("failed to drop legacy policy number index %s: %w", name, err)
+			}
+		}
 	}
-	
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_insurance_policies_profile_type_active ON insurance_policies(profile_id, type, is_active)").Error; err != nil {
-		return fmt.Errorf("failed to create insurance_policies type index: %w", err)
+
+	return nil
+}
+
+// legacyExpenseFrequencyCheck is the check constraint on medical_expenses.frequency from before the
+// finance frequency names were accepted; chk_medical_expenses_frequencies replaces it
+const legacyExpenseFrequencyCheck = "chk_medical_expenses_frequency"
+
+// dropLegacyExpenseFrequencyCheck removes the old frequency check so AutoMigrate can add the new one.
+// AutoMigrate only adds missing constraints and never updates an existing one.
+func dropLegacyExpenseFrequencyCheck(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.MedicalExpenseModel{}) || !migrator.HasConstraint(&models.MedicalExpenseModel{}, legacyExpenseFrequencyCheck) {
+		return nil
 	}
-	
-	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_insurance_policies_deductible_tracking ON insurance_policies(deductible_met, out_of_pocket_current, annual_deductible)").Error; err != nil {
-		return fmt.Errorf("failed to create insurance_policies tracking index: %w", err)
+
+	if err := migrator.DropConstraint(&models.MedicalExpenseModel{}, legacyExpenseFrequencyCheck); err != nil {
+		return fmt.Errorf("failed to drop legacy medical expense frequency check: %w", err)
 	}
+	return nil
+}
 
+// backfillSelfProfiles marks profiles without a self_user_id as the owner's self profile.
+// Existing rows default to relation "self" when the column is added.
+func backfillSelfProfiles(db *gorm.DB) error {
+	if err := db.Exec("UPDATE health_profiles SET self_user_id = user_id WHERE relation_to_owner = 'self' AND self_user_id IS NULL").Error; err != nil {
+		return fmt.Errorf("failed to backfill self health profiles: %w", err)
+	}
 	return nil
 }
 
+// createHealthIndexes creates custom composite indexes for health tables
+func createHealthIndexes(db *gorm.DB) error {
+	return createIndexes(db, []compositeIndex{
+		// Health profiles indexes
+		{"idx_health_profiles_user_active", "health_profiles", "user_id, created_at"},
+		// Medical conditions indexes
+		{"idx_medical_conditions_user_category_active", "medical_conditions", "user_id, category, is_active"},
+		{"idx_medical_conditions_profile_severity", "medical_conditions", "profile_id, severity, is_active"},
+		// Medical expenses indexes
+		{"idx_medical_expenses_user_date_category", "medical_expenses", "user_id, date DESC, category"},
+		{"idx_medical_expenses_profile_recurring", "medical_expenses", "profile_id, is_recurring, frequency"},
+		{"idx_medical_expenses_amount_covered", "medical_expenses", "amount, is_covered, insurance_payment"},
+		// Insurance policies indexes
+		{"idx_insurance_policies_user_active_dates", "insurance_policies", "user_id, is_active, start_date, end_date"},
+		{"idx_insurance_policies_profile_type_active", "insurance_policies", "profile_id, type, is_active"},
+		{"idx_insurance_policies_deductible_tracking", "insurance_policies", "deductible_met, out_of_pocket_current, annual_deductible"},
+	})
+}
+
 // DropHealthTables drops all health-related tables (for testing/cleanup)
 func DropHealthTables(db *gorm.DB) error {
 	// Drop in reverse dependency order
 	tables := []string{
+		"profile_snapshots",
+		"medication_schedules",
 		"insurance_policies",
 		"medical_expenses", 
 		"medical_conditions",
+		"condition_catalog",
 		"health_profiles",
 	}
 	
@@ -97,21 +166,9 @@ func CreateHealthConstraints(db *gorm.DB) error {
 	// Note: GORM's AutoMigrate should handle most constraints, 
 	// but we can add additional ones here if needed
 	
-	// Ensure health profiles have unique user constraint
-	if err := db.Exec("ALTER TABLE health_profiles ADD CONSTRAINT unique_user_profile UNIQUE (user_id)").Error; err != nil {
-		// Ignore error if constraint already exists
-		if !isConstraintExistsError(err) {
-			return fmt.Errorf("failed to create unique user profile constraint: %w", err)
-		}
-	}
-	
-	// Ensure policy numbers are globally unique
-	if err := db.Exec("ALTER TABLE insurance_policies ADD CONSTRAINT unique_policy_number UNIQUE (policy_number)").Error; err != nil {
-		// Ignore error if constraint already exists
-		if !isConstraintExistsError(err) {
-			return fmt.Errorf("failed to create unique policy number constraint: %w", err)
-		}
-	}
+	// One self profile per user is enforced by the unique index on health_profiles.self_user_id;
+	// dependents share the owner's user_id. Policy numbers are unique per user through the
+	// model's idx_insurance_policies_user_number index.
 	
 	return nil
 }
@@ -124,6 +181,7 @@ func isConstraintExistsError(err error) bool {
 	errStr := err.Error()
 	// MySQL error patterns for existing constraints
 	return contains(errStr, "Duplicate key name") || 
+		   contains(errStr, "Duplicate check constraint name") ||
 		   contains(errStr, "already exists") ||
 		   contains(errStr, "Duplicate entry")
 }
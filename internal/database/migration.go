@@ -1,7 +1,9 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"gorm.io/gorm"
 
@@ -33,17 +35,53 @@ func RunAllMigrations(db *gorm.DB) error {
 	return nil
 }
 
-// runCoreMigrations runs core system table migrations
-func runCoreMigrations(db *gorm.DB) error {
-	// Auto-migrate core models in dependency order
-	if err := db.AutoMigrate(
+// coreModels returns the core system models in dependency order
+func coreModels() []interface{} {
+	return []interface{}{
 		&models.UserModel{},
 		&models.RefreshTokenModel{},
+		&models.APIKeyModel{},
 		&models.ExpenseModel{},
 		&models.IncomeModel{},
 		&models.LoanModel{},
+		&models.LoanBalanceEntryModel{},
+		&models.SavingsGoalModel{},
+		&models.GoalContributionModel{},
+		&models.BudgetModel{},
 		&models.FinanceSummaryModel{},
-	); err != nil {
+		&models.IdempotencyKeyModel{},
+		&models.WebhookModel{},
+		&models.WebhookDeliveryModel{},
+		&models.AuditLogModel{},
+		&models.DemoDataRecordModel{},
+	}
+}
+
+// healthModels returns the health domain models in dependency order.
+// HealthProfile must be created first as others reference it.
+func healthModels() []interface{} {
+	return []interface{}{
+		&models.HealthProfileModel{},
+		&models.ConditionCatalogModel{},
+		&models.MedicalConditionModel{},
+		&models.MedicalExpenseModel{},
+		&models.ExpenseAttachmentModel{},
+		&models.MedicalExpenseOccurrenceModel{},
+		&models.InsurancePolicyModel{},
+		&models.MedicationScheduleModel{},
+		&models.ProfileSnapshotModel{},
+		&models.RiskSnapshotModel{},
+	}
+}
+
+// runCoreMigrations runs core system table migrations
+func runCoreMigrations(db *gorm.DB) error {
+	if err := HashLegacyRefreshTokens(db); err != nil {
+		return err
+	}
+
+	// Auto-migrate core models in dependency order
+	if err := db.AutoMigrate(coreModels()...); err != nil {
 		return fmt.Errorf("failed to auto-migrate core models: %w", err)
 	}
 
@@ -52,69 +90,117 @@ func runCoreMigrations(db *gorm.DB) error {
 
 // runHealthDomainMigrations runs health domain specific migrations
 func runHealthDomainMigrations(db *gorm.DB) error {
+	if err := dropLegacyProfileUniqueness(db); err != nil {
+		return err
+	}
+	if err := dropLegacyExpenseFrequencyCheck(db); err != nil {
+		return err
+	}
+	if err := dropLegacyPolicyNumberUniqueness(db); err != nil {
+		return err
+	}
+
 	// Run health domain migrations
-	err := db.AutoMigrate(
-		&models.HealthProfileModel{},
-		&models.MedicalConditionModel{},
-		&models.MedicalExpenseModel{},
-		&models.InsurancePolicyModel{},
-	)
+	err := db.AutoMigrate(healthModels()...)
 	if err != nil {
 		return fmt.Errorf("health migration failed: %w", err)
 	}
 
+	if err := seedConditionCatalog(db); err != nil {
+		return err
+	}
+
+	return backfillSelfProfiles(db)
+}
+
+// legacyRefreshTokenColumn held refresh tokens in plain text before only their hash was stored
+const legacyRefreshTokenColumn = "token"
+
+// legacyRefreshTokenIndex is the unique index on the legacy token column
+const legacyRefreshTokenIndex = "idx_refresh_tokens_token"
+
+// HashLegacyRefreshTokens moves refresh tokens stored in plain text into the token_hash column and
+// drops the old token column, so signed-in users keep their sessions. It has to run before
+// AutoMigrate, which can't add the not null token_hash column to a table that already has rows.
+func HashLegacyRefreshTokens(db *gorm.DB) error {
+	model := &models.RefreshTokenModel{}
+	migrator := db.Migrator()
+	if !migrator.HasTable(model) || !migrator.HasColumn(model, legacyRefreshTokenColumn) {
+		return nil
+	}
+
+	if !migrator.HasColumn(model, "token_hash") {
+		if err := db.Exec("ALTER TABLE refresh_tokens ADD COLUMN token_hash CHAR(64)").Error; err != nil {
+			return fmt.Errorf("failed to add refresh token hash column: %w", err)
+		}
+	}
+
+	var legacy []struct {
+		ID    uint
+		Token string
+	}
+	if err := db.Table("refresh_tokens").Select("id, token").Where("token_hash IS NULL").Find(&legacy).Error; err != nil {
+		return fmt.Errorf("failed to load legacy refresh tokens: %w", err)
+	}
+	for _, token := range legacy {
+		if err := db.Table("refresh_tokens").Where("id = ?", token.ID).
+			Update("token_hash", models.HashRefreshToken(token.Token)).Error; err != nil {
+			return fmt.Errorf("failed to hash legacy refresh token %d: %w", token.ID, err)
+		}
+	}
+
+	if migrator.HasIndex(model, legacyRefreshTokenIndex) {
+		if err := migrator.DropIndex(model, legacyRefreshTokenIndex); err != nil {
+			return fmt.Errorf("failed to drop legacy refresh token index: %w", err)
+		}
+	}
+	if err := migrator.DropColumn(model, legacyRefreshTokenColumn); err != nil {
+		return fmt.Errorf("failed to drop legacy refresh token column: %w", err)
+	}
+
 	return nil
 }
 
 // createCompositeIndexes creates composite indexes for better query performance
 func createCompositeIndexes(db *gorm.DB) error {
-	// Health-related composite indexes
-	indexes := []struct {
-		name  string
-		query string
-	}{
+	return createIndexes(db, []compositeIndex{
 		// Medical expenses indexes for common queries
-		{
-			name:  "idx_expenses_user_date",
-			query: "CREATE INDEX IF NOT EXISTS idx_expenses_user_date ON medical_expenses(user_id, date DESC)",
-		},
-		{
-			name:  "idx_conditions_profile_severity",
-			query: "CREATE INDEX IF NOT EXISTS idx_conditions_profile_severity ON medical_conditions(profile_id, severity, is_active)",
-		},
+		{"idx_expenses_user_date", "medical_expenses", "user_id, date DESC"},
+		{"idx_conditions_profile_severity", "medical_conditions", "profile_id, severity, is_active"},
 		// Insurance policies for coverage lookups
-		{
-			name:  "idx_policies_user_active_type",
-			query: "CREATE INDEX IF NOT EXISTS idx_policies_user_active_type ON insurance_policies(user_id, is_active, type)",
-		},
+		{"idx_policies_user_active_type", "insurance_policies", "user_id, is_active, type"},
 		// Health profiles for user lookups
-		{
-			name:  "idx_health_profiles_user",
-			query: "CREATE INDEX IF NOT EXISTS idx_health_profiles_user ON health_profiles(user_id, created_at)",
-		},
+		{"idx_health_profiles_user", "health_profiles", "user_id, created_at"},
 		// Financial data indexes for affordability calculations
-		{
-			name:  "idx_expenses_user_category_date",
-			query: "CREATE INDEX IF NOT EXISTS idx_expenses_user_category_date ON expenses(user_id, category, date DESC)",
-		},
-		{
-			name:  "idx_incomes_user_active_date",
-			query: "CREATE INDEX IF NOT EXISTS idx_incomes_user_active_date ON incomes(user_id, is_active, date DESC)",
-		},
+		{"idx_expenses_user_category_created", "expenses", "user_id, category, created_at DESC"},
+		{"idx_incomes_user_active_created", "incomes", "user_id, is_active, created_at DESC"},
+		// Every user-scoped finance query also filters out soft-deleted rows
+		{"idx_incomes_user_deleted", "incomes", "user_id, deleted_at"},
+		{"idx_expenses_user_deleted", "expenses", "user_id, deleted_at"},
 		// Medical expenses coverage analysis
-		{
-			name:  "idx_medical_expenses_coverage",
-			query: "CREATE INDEX IF NOT EXISTS idx_medical_expenses_coverage ON medical_expenses(user_id, is_covered, insurance_payment)",
-		},
+		{"idx_medical_expenses_coverage", "medical_expenses", "user_id, is_covered, insurance_payment"},
 		// Recurring expenses tracking
-		{
-			name:  "idx_medical_expenses_recurring",
-			query: "CREATE INDEX IF NOT EXISTS idx_medical_expenses_recurring ON medical_expenses(user_id, is_recurring, frequency)",
-		},
-	}
+		{"idx_medical_expenses_recurring", "medical_expenses", "user_id, is_recurring, frequency"},
+	})
+}
 
+// compositeIndex is an index the models don't declare. Names must be unique across the
+// whole database, as SQLite requires, not just within the table.
+type compositeIndex struct {
+	name    string
+	table   string
+	columns string
+}
+
+// createIndexes creates each index that doesn't exist yet. MySQL has no CREATE INDEX IF NOT EXISTS,
+// so the index is looked up first.
+func createIndexes(db *gorm.DB, indexes []compositeIndex) error {
+	migrator := db.Migrator()
 	for _, idx := range indexes {
-		if err := db.Exec(idx.query).Error; err != nil {
+		if migrator.HasIndex(idx.table, idx.name) {
+			continue
+		}
+		if err := db.Exec(fmt.Sprintf("CREATE INDEX %s ON %s(%s)", idx.name, idx.table, idx.columns)).Error; err != nil {
 			return fmt.Errorf("failed to create index %s: %w", idx.name, err)
 		}
 	}
@@ -122,22 +208,17 @@ func createCompositeIndexes(db *gorm.DB) error {
 	return nil
 }
 
-// createAdditionalConstraints creates additional database constraints
+// createAdditionalConstraints creates additional database constraints.
+// SQLite can't add constraints to an existing table, so it relies on the checks declared on the models.
 func createAdditionalConstraints(db *gorm.DB) error {
+	if !isMySQL(db) {
+		return nil
+	}
+
 	constraints := []struct {
 		name  string
 		query string
 	}{
-		// Ensure one health profile per user
-		{
-			name:  "unique_user_health_profile",
-			query: "ALTER TABLE health_profiles ADD CONSTRAINT unique_user_health_profile UNIQUE (user_id)",
-		},
-		// Ensure policy numbers are globally unique
-		{
-			name:  "unique_policy_number",
-			query: "ALTER TABLE insurance_policies ADD CONSTRAINT unique_policy_number UNIQUE (policy_number)",
-		},
 		// Ensure reasonable BMI constraints
 		{
 			name:  "check_reasonable_bmi",
@@ -202,6 +283,31 @@ func GetMigrationStatus(db *gorm.DB) map[string]bool {
 	return status
 }
 
+// CheckMigrations returns an error naming the tables of every migrated model that doesn't
+// exist yet, which means the migrations for this build haven't been run against the database.
+// New columns aren't checked.
+func CheckMigrations(ctx context.Context, db *gorm.DB) error {
+	migrator := db.WithContext(ctx).Migrator()
+
+	var missing []string
+	for _, model := range append(coreModels(), healthModels()...) {
+		if !migrator.HasTable(model) {
+			stmt := &gorm.Statement{DB: db}
+			if err := stmt.Parse(model); err != nil {
+				return fmt.Errorf("failed to parse model %T: %w", model, err)
+			}
+			missing = append(missing, stmt.Schema.Table)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("pending migrations, missing tables: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // ValidateMigrationIntegrity checks that all expected tables and constraints exist
 func ValidateMigrationIntegrity(db *gorm.DB) error {
 	migrator := db.Migrator()
@@ -0,0 +1,248 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+func setupSQLiteTestDB(t *testing.T) *gorm.DB {
+	db, err := ConnectSQLite(SQLiteMemory, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db
+}
+
+func TestRunAllMigrations_SQLite_IsRepeatable(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+
+	require.NoError(t, RunAllMigrations(db))
+	require.NoError(t, RunAllMigrations(db), "migrations must be safe to run on every start")
+	require.NoError(t, RunHealthMigrations(db))
+
+	assert.NoError(t, ValidateMigrationIntegrity(db))
+	assert.True(t, db.Migrator().HasIndex("expenses", "idx_expenses_user_category_created"))
+	assert.True(t, db.Migrator().HasIndex("medical_expenses", "idx_medical_expenses_user_date_category"))
+}
+
+func TestRunAllMigrations_SQLite_SeedsConditionCatalog(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	entries, err := loadConditionCatalogSeed()
+	require.NoError(t, err)
+
+	require.NoError(t, RunAllMigrations(db))
+	require.NoError(t, RunAllMigrations(db))
+
+	var count int64
+	require.NoError(t, db.Model(&models.ConditionCatalogModel{}).Count(&count).Error)
+	assert.Equal(t, int64(len(entries)), count, "reseeding must not duplicate entries")
+
+	var diabetes models.ConditionCatalogModel
+	require.NoError(t, db.First(&diabetes, "id = ?", "E11").Error)
+	assert.Equal(t, "Type 2 Diabetes", diabetes.Name)
+	assert.Equal(t, "chronic", diabetes.Category)
+	assert.Equal(t, 8, diabetes.RiskWeight)
+	assert.Contains(t, diabetes.Aliases, "diabetes type ii")
+
+	// Entries edited in the database are restored from the seed
+	require.NoError(t, db.Model(&diabetes).Update("risk_weight", 1).Error)
+	require.NoError(t, RunHealthMigrations(db))
+	require.NoError(t, db.First(&diabetes, "id = ?", "E11").Error)
+	assert.Equal(t, 8, diabetes.RiskWeight)
+}
+
+func TestCheckMigrations_SQLite(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+
+	err := CheckMigrations(context.Background(), db)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "users")
+	assert.Contains(t, err.Error(), "medical_expenses")
+
+	require.NoError(t, RunAllMigrations(db))
+	assert.NoError(t, CheckMigrations(context.Background(), db))
+
+	require.NoError(t, db.Migrator().DropTable("budgets"))
+	err = CheckMigrations(context.Background(), db)
+	require.Error(t, err)
+	assert.Equal(t, "pending migrations, missing tables: budgets", err.Error())
+}
+
+// legacyMedicalExpenseModel is medical_expenses as it was created before the frequency check was renamed
+type legacyMedicalExpenseModel struct {
+	gorm.Model
+	UserID      string    `gorm:"not null;size:36"`
+	ProfileID   uint      `gorm:"not null"`
+	Amount      float64   `gorm:"not null"`
+	Category    string    `gorm:"not null;size:20"`
+	Description string    `gorm:"not null;size:200"`
+	IsRecurring bool      `gorm:"not null;default:false"`
+	Frequency   string    `gorm:"size:20;check:frequency IN ('monthly','quarterly','annually','one_time')"`
+	IsCovered   bool      `gorm:"not null;default:false"`
+	OutOfPocket float64   `gorm:"not null"`
+	Date        time.Time `gorm:"not null"`
+}
+
+func (legacyMedicalExpenseModel) TableName() string {
+	return "medical_expenses"
+}
+
+func TestRunAllMigrations_SQLite_ReplacesLegacyFrequencyCheck(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&legacyMedicalExpenseModel{}))
+	require.True(t, db.Migrator().HasConstraint(&models.MedicalExpenseModel{}, legacyExpenseFrequencyCheck))
+
+	require.NoError(t, RunAllMigrations(db))
+
+	assert.False(t, db.Migrator().HasConstraint(&models.MedicalExpenseModel{}, legacyExpenseFrequencyCheck))
+	assert.True(t, db.Migrator().HasConstraint(&models.MedicalExpenseModel{}, "chk_medical_expenses_frequencies"))
+
+	profile := models.HealthProfileModel{UserID: "user-1", Age: 30, Gender: "female", Height: 165, Weight: 60, FamilySize: 1}
+	require.NoError(t, db.Create(&profile).Error)
+	expense := models.MedicalExpenseModel{
+		UserID: "user-1", ProfileID: profile.ID, Amount: 120, Category: "medication", Description: "Refill",
+		IsRecurring: true, Frequency: "yearly", Date: time.Now(),
+	}
+	assert.NoError(t, db.Create(&expense).Error)
+
+	expense = models.MedicalExpenseModel{
+		UserID: "user-1", ProfileID: profile.ID, Amount: 120, Category: "medication", Description: "Refill",
+		IsRecurring: true, Frequency: "fortnightly", Date: time.Now(),
+	}
+	assert.Error(t, db.Create(&expense).Error)
+}
+
+// legacyRefreshTokenModel is refresh_tokens as it was created when tokens were stored in plain text
+type legacyRefreshTokenModel struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	Token     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	IsRevoked bool      `gorm:"default:false"`
+	RevokedAt *time.Time
+}
+
+func (legacyRefreshTokenModel) TableName() string {
+	return "refresh_tokens"
+}
+
+func TestRunAllMigrations_SQLite_HashesLegacyRefreshTokens(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&models.UserModel{}, &legacyRefreshTokenModel{}))
+	user := models.UserModel{Email: "ada@example.com", Name: "Ada", PasswordHash: "hash"}
+	require.NoError(t, db.Create(&user).Error)
+	expiresAt := time.Now().Add(time.Hour)
+	require.NoError(t, db.Create(&[]legacyRefreshTokenModel{
+		{UserID: user.ID, Token: "token-1", ExpiresAt: expiresAt, IsRevoked: true},
+		{UserID: user.ID, Token: "token-2", ExpiresAt: expiresAt},
+	}).Error)
+
+	require.NoError(t, RunAllMigrations(db))
+	require.NoError(t, RunAllMigrations(db))
+
+	assert.False(t, db.Migrator().HasColumn(&models.RefreshTokenModel{}, legacyRefreshTokenColumn))
+	var stored models.RefreshTokenModel
+	require.NoError(t, db.Where("token_hash = ?", models.HashRefreshToken("token-2")).First(&stored).Error)
+	assert.Equal(t, user.ID, stored.UserID)
+	assert.False(t, stored.IsRevoked)
+
+	// The hash is unique, like the token was
+	duplicate := models.RefreshTokenFromDomain(stored.ToUserID(), "token-1", expiresAt)
+	assert.Error(t, db.Create(&duplicate).Error)
+}
+
+// legacyInsurancePolicyModel is insurance_policies as it was created when policy numbers were globally unique
+type legacyInsurancePolicyModel struct {
+	gorm.Model
+	UserID       string `gorm:"not null;size:36"`
+	ProfileID    uint   `gorm:"not null"`
+	PolicyNumber string `gorm:"uniqueIndex;not null;size:50"`
+}
+
+func (legacyInsurancePolicyModel) TableName() string {
+	return "insurance_policies"
+}
+
+func TestRunAllMigrations_SQLite_MakesPolicyNumbersUniquePerUser(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, db.AutoMigrate(&legacyInsurancePolicyModel{}))
+	require.True(t, db.Migrator().HasIndex(&models.InsurancePolicyModel{}, "idx_insurance_policies_policy_number"))
+
+	require.NoError(t, RunAllMigrations(db))
+
+	assert.False(t, db.Migrator().HasIndex(&models.InsurancePolicyModel{}, "idx_insurance_policies_policy_number"))
+	assert.True(t, db.Migrator().HasIndex(&models.InsurancePolicyModel{}, "idx_insurance_policies_user_number"))
+
+	profile := models.HealthProfileModel{UserID: "user-1", Age: 30, Gender: "female", Height: 165, Weight: 60, FamilySize: 1}
+	require.NoError(t, db.Create(&profile).Error)
+	newPolicy := func(userID string) *models.InsurancePolicyModel {
+		return &models.InsurancePolicyModel{
+			UserID: userID, ProfileID: profile.ID, Provider: "Acme Health", PolicyNumber: "POL-1", Type: "health",
+			MonthlyPremium: 300, AnnualDeductible: 1000, OutOfPocketMax: 5000, CoveragePercentage: 80,
+			StartDate: time.Now(), EndDate: time.Now().AddDate(1, 0, 0), IsActive: true,
+		}
+	}
+	require.NoError(t, db.Create(newPolicy("user-1")).Error)
+	assert.NoError(t, db.Create(newPolicy("user-2")).Error, "another user can hold the same number")
+	assert.Error(t, db.Create(newPolicy("user-1")).Error)
+}
+
+func TestConnectSQLite_EnforcesForeignKeyCascades(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, RunAllMigrations(db))
+
+	profile := models.HealthProfileModel{UserID: "user-1", Age: 40, Gender: "female", Height: 170, Weight: 65, BMI: 22.5, FamilySize: 1}
+	require.NoError(t, db.Create(&profile).Error)
+	condition := models.MedicalConditionModel{
+		UserID:        "user-1",
+		ProfileID:     profile.ID,
+		Name:          "Asthma",
+		Category:      "chronic",
+		Severity:      "mild",
+		DiagnosedDate: time.Now(),
+	}
+	require.NoError(t, db.Omit(clause.Associations).Create(&condition).Error)
+
+	// A condition can't point at a profile that doesn't exist
+	orphan := condition
+	orphan.ID = 0
+	orphan.ProfileID = profile.ID + 100
+	assert.Error(t, db.Omit(clause.Associations).Create(&orphan).Error)
+
+	// Hard deleting the profile removes its conditions, as ON DELETE CASCADE does on MySQL
+	require.NoError(t, db.Unscoped().Delete(&profile).Error)
+	var remaining int64
+	require.NoError(t, db.Unscoped().Model(&models.MedicalConditionModel{}).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
+func TestConnectSQLite_MemoryDatabaseSurvivesIdleConnections(t *testing.T) {
+	db := setupSQLiteTestDB(t)
+	require.NoError(t, RunAllMigrations(db))
+
+	// Every query must see the same database, not a fresh in-memory one per connection
+	for i := 0; i < 3; i++ {
+		assert.True(t, db.Migrator().HasTable("users"))
+	}
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Equal(t, 1, sqlDB.Stats().MaxOpenConnections)
+}
+
+func TestSQLiteDSN(t *testing.T) {
+	assert.Equal(t, ":memory:?_foreign_keys=1&_busy_timeout=5000", sqliteDSN(SQLiteMemory))
+	assert.Equal(t, "buyorbye.db?_foreign_keys=1&_busy_timeout=5000", sqliteDSN("buyorbye.db"))
+	assert.Equal(t, "file:buyorbye.db?mode=rwc&_foreign_keys=1&_busy_timeout=5000", sqliteDSN("file:buyorbye.db?mode=rwc"))
+}
@@ -0,0 +1,6 @@
+-- Migration: Add role to users table
+-- Description: Users default to the 'user' role; admins are promoted via auth.admin_emails or the admin API
+
+ALTER TABLE `users`
+    ADD COLUMN `role` VARCHAR(20) NOT NULL DEFAULT 'user' AFTER `is_active`,
+    ADD INDEX `idx_users_role` (`role`);
@@ -0,0 +1,38 @@
+-- Migration: Create webhooks and webhook_deliveries tables
+-- Description: User-registered webhook URLs and a log of every delivery attempt
+
+CREATE TABLE IF NOT EXISTS `webhooks` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL,
+    `url` VARCHAR(2048) NOT NULL,
+    `secret` VARCHAR(255) NOT NULL,
+    `event_types` VARCHAR(512) NOT NULL,
+    `is_active` BOOLEAN NOT NULL DEFAULT TRUE,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at` TIMESTAMP NULL DEFAULT NULL,
+
+    INDEX `idx_webhooks_user_id` (`user_id`),
+    INDEX `idx_webhooks_deleted_at` (`deleted_at`),
+
+    CONSTRAINT `fk_webhooks_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+    `id` BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    `webhook_id` VARCHAR(64) NOT NULL,
+    `event_id` VARCHAR(36) NOT NULL,
+    `event_type` VARCHAR(64) NOT NULL,
+    `attempt` INT NOT NULL,
+    `status_code` INT NOT NULL DEFAULT 0,
+    `error` VARCHAR(1024) NULL,
+    `succeeded` BOOLEAN NOT NULL DEFAULT FALSE,
+    `duration_ms` BIGINT NOT NULL DEFAULT 0,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX `idx_webhook_deliveries_webhook_created` (`webhook_id`, `created_at`),
+    INDEX `idx_webhook_deliveries_event_id` (`event_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
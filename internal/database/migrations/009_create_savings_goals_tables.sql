@@ -0,0 +1,54 @@
+-- Migration: Create savings_goals and goal_contributions tables
+-- Description: User savings goals and the contributions recorded toward them
+
+CREATE TABLE IF NOT EXISTS `savings_goals` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `target_amount` DECIMAL(12,2) NOT NULL,
+    `current_amount` DECIMAL(12,2) NOT NULL DEFAULT 0,
+    `target_date` DATE NOT NULL,
+    `priority` INT NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    `deleted_at` TIMESTAMP NULL DEFAULT NULL,
+
+    INDEX `idx_savings_goals_user_id` (`user_id`),
+    INDEX `idx_savings_goals_deleted_at` (`deleted_at`),
+
+    CONSTRAINT `fk_savings_goals_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT `chk_savings_goals_target_amount`
+        CHECK (`target_amount` > 0),
+
+    CONSTRAINT `chk_savings_goals_current_amount`
+        CHECK (`current_amount` >= 0),
+
+    CONSTRAINT `chk_savings_goals_priority`
+        CHECK (`priority` BETWEEN 1 AND 3)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Contributions are kept when their goal is soft deleted, so goal_id has no foreign key
+CREATE TABLE IF NOT EXISTS `goal_contributions` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `goal_id` VARCHAR(64) NOT NULL,
+    `user_id` VARCHAR(36) NOT NULL,
+    `amount` DECIMAL(12,2) NOT NULL,
+    `note` VARCHAR(255) NULL,
+    `contributed_at` TIMESTAMP NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX `idx_goal_contributions_goal_id` (`goal_id`),
+    INDEX `idx_goal_contributions_user_date` (`user_id`, `contributed_at`),
+
+    CONSTRAINT `fk_goal_contributions_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT `chk_goal_contributions_amount`
+        CHECK (`amount` > 0)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,9 @@
+-- Migration: Add soft delete indexes to incomes and expenses
+-- Description: Deleted incomes and expenses keep deleted_at set so they can be restored; every
+-- user-scoped query filters on user_id and deleted_at IS NULL, so index the pair
+
+ALTER TABLE `incomes`
+    ADD INDEX `idx_incomes_user_deleted` (`user_id`, `deleted_at`);
+
+ALTER TABLE `expenses`
+    ADD INDEX `idx_expenses_user_deleted` (`user_id`, `deleted_at`);
@@ -0,0 +1,19 @@
+-- Migration: Create audit_logs table
+-- Description: Append-only audit trail of logins, token revocations and changes to health and
+-- finance data. No foreign key to users so entries outlive the account.
+
+CREATE TABLE IF NOT EXISTS `audit_logs` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL DEFAULT '',
+    `action` VARCHAR(32) NOT NULL,
+    `resource_type` VARCHAR(32) NOT NULL,
+    `resource_id` VARCHAR(64) NOT NULL DEFAULT '',
+    `request_id` VARCHAR(64) NOT NULL DEFAULT '',
+    `ip_address` VARCHAR(45) NOT NULL DEFAULT '',
+    `user_agent` VARCHAR(512) NOT NULL DEFAULT '',
+    `changes` TEXT NULL,
+    `created_at` TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+
+    INDEX `idx_audit_logs_user_created` (`user_id`, `created_at`),
+    INDEX `idx_audit_logs_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,15 @@
+-- Migration: Add currency to incomes, expenses, loans and finance summaries
+-- Description: Amounts carry an ISO 4217 currency code; rows recorded before currencies were
+-- tracked are in the default base currency, USD. Summaries record the currency they were computed in
+
+ALTER TABLE `incomes`
+    ADD COLUMN `currency` VARCHAR(3) NOT NULL DEFAULT 'USD' AFTER `amount`;
+
+ALTER TABLE `expenses`
+    ADD COLUMN `currency` VARCHAR(3) NOT NULL DEFAULT 'USD' AFTER `amount`;
+
+ALTER TABLE `loans`
+    ADD COLUMN `currency` VARCHAR(3) NOT NULL DEFAULT 'USD' AFTER `interest_rate`;
+
+ALTER TABLE `finance_summaries`
+    ADD COLUMN `currency` VARCHAR(3) NOT NULL DEFAULT 'USD' AFTER `user_id`;
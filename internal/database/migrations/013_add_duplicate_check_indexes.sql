@@ -0,0 +1,10 @@
+-- Migration: Add duplicate check indexes to incomes and expenses
+-- Description: Adding an income or expense first looks for a recent record of the same user with
+-- the same source or name, frequency and amount, newest first; index those columns so the check
+-- doesn't scan every record the user has
+
+ALTER TABLE `incomes`
+    ADD INDEX `idx_incomes_duplicate_check` (`user_id`, `source`, `frequency`, `amount`, `created_at`);
+
+ALTER TABLE `expenses`
+    ADD INDEX `idx_expenses_duplicate_check` (`user_id`, `name`, `frequency`, `amount`, `created_at`);
@@ -0,0 +1,9 @@
+-- Migration: Add installment plans to expenses
+-- Description: A one-time purchase can be split into monthly installments. The expense keeps the
+-- full price; installments_total is the number of payments (0 for regular expenses),
+-- installments_paid how many have been made and installment_start_date when the first is due
+
+ALTER TABLE `expenses`
+    ADD COLUMN `installments_total` TINYINT NOT NULL DEFAULT 0 AFTER `priority`,
+    ADD COLUMN `installments_paid` TINYINT NOT NULL DEFAULT 0 AFTER `installments_total`,
+    ADD COLUMN `installment_start_date` DATE NULL AFTER `installments_paid`;
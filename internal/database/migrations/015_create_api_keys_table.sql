@@ -0,0 +1,22 @@
+-- Migration: Create api_keys table
+-- Description: Long-lived API keys for programmatic access; only a SHA-256 hash of each key is stored
+
+CREATE TABLE IF NOT EXISTS `api_keys` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL,
+    `name` VARCHAR(100) NOT NULL,
+    `hashed_key` VARCHAR(64) NOT NULL,
+    `prefix` VARCHAR(16) NOT NULL,
+    `scopes` VARCHAR(255) NOT NULL,
+    `last_used_at` TIMESTAMP NULL DEFAULT NULL,
+    `revoked_at` TIMESTAMP NULL DEFAULT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    UNIQUE INDEX `idx_api_keys_hashed_key` (`hashed_key`),
+    INDEX `idx_api_keys_user_id` (`user_id`),
+
+    CONSTRAINT `fk_api_keys_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
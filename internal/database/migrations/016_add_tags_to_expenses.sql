@@ -0,0 +1,6 @@
+-- Migration: Add tags to expenses
+-- Description: Expenses carry free-form tags alongside their fixed category. Tags are stored as a
+-- JSON array of normalized strings (lowercased, trimmed, deduplicated); NULL means no tags
+
+ALTER TABLE `expenses`
+    ADD COLUMN `tags` TEXT NULL AFTER `installment_start_date`;
@@ -0,0 +1,19 @@
+-- Migration: Create demo_data_records table
+-- Description: Seeding demo data into an account records the ID of every record it creates, so
+-- removing the demo data deletes exactly those records and nothing the user added since
+
+CREATE TABLE IF NOT EXISTS `demo_data_records` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `user_id` VARCHAR(36) NOT NULL,
+    `batch_id` VARCHAR(64) NOT NULL,
+    `resource` VARCHAR(50) NOT NULL,
+    `record_id` VARCHAR(256) NOT NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX `idx_demo_data_records_user_id` (`user_id`),
+
+    CONSTRAINT `fk_demo_data_records_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
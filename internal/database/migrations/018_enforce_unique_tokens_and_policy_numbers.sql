@@ -0,0 +1,21 @@
+-- Migration: Store refresh tokens hashed and make policy numbers unique per user
+-- Description: Refresh tokens are looked up by their SHA-256 hash, which is unique, so the same
+-- token can't be stored twice. Existing tokens are hashed in place so signed-in users keep their
+-- sessions. Policy numbers were unique across all users, but two insurers can issue the same
+-- number; they are now unique per user. One self health profile per user is already enforced by
+-- the unique index on health_profiles.self_user_id.
+
+ALTER TABLE `refresh_tokens`
+    ADD COLUMN `token_hash` CHAR(64) NULL AFTER `user_id`;
+
+UPDATE `refresh_tokens` SET `token_hash` = SHA2(`token`, 256) WHERE `token_hash` IS NULL;
+
+ALTER TABLE `refresh_tokens`
+    MODIFY COLUMN `token_hash` CHAR(64) NOT NULL,
+    ADD UNIQUE INDEX `idx_refresh_tokens_token_hash` (`token_hash`),
+    DROP INDEX `idx_refresh_tokens_token`,
+    DROP COLUMN `token`;
+
+ALTER TABLE `insurance_policies`
+    DROP INDEX `idx_insurance_policies_policy_number`,
+    ADD UNIQUE INDEX `idx_insurance_policies_user_number` (`user_id`, `policy_number`);
@@ -0,0 +1,33 @@
+-- Migration: Create loan_balance_entries table
+-- Description: Every change to a loan's remaining balance - a payment split into interest and
+-- principal, a manual adjustment or a month of accrued interest - is recorded with the balance
+-- before and after it. Loans marked auto_accrue_interest have interest added monthly by a
+-- background job, which finds the loans not accrued yet this month through the loan/created_at index.
+
+ALTER TABLE `loans`
+    ADD COLUMN `auto_accrue_interest` BOOLEAN NOT NULL DEFAULT FALSE AFTER `end_date`;
+
+CREATE TABLE IF NOT EXISTS `loan_balance_entries` (
+    `id` VARCHAR(64) PRIMARY KEY,
+    `loan_id` VARCHAR(36) NOT NULL,
+    `user_id` VARCHAR(36) NOT NULL,
+    `type` VARCHAR(20) NOT NULL,
+    `old_balance` DECIMAL(12,2) NOT NULL,
+    `new_balance` DECIMAL(12,2) NOT NULL,
+    `amount` DECIMAL(12,2) NOT NULL,
+    `interest_portion` DECIMAL(12,2) NOT NULL,
+    `principal_portion` DECIMAL(12,2) NOT NULL,
+    `note` VARCHAR(255) NULL,
+    `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX `idx_loan_balance_entries_loan_created` (`loan_id`, `created_at`),
+    INDEX `idx_loan_balance_entries_user_id` (`user_id`),
+
+    CONSTRAINT `fk_loan_balance_entries_user_id`
+        FOREIGN KEY (`user_id`)
+        REFERENCES `users` (`id`)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+
+    CONSTRAINT `chk_loan_balance_entries_type`
+        CHECK (`type` IN ('payment', 'adjustment', 'interest-accrual'))
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
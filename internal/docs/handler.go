@@ -0,0 +1,44 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+)
+
+// Handler serves the OpenAPI 3 spec and a Swagger UI that browses it
+type Handler struct {
+	spec    []byte
+	specURL string
+}
+
+// NewHandler builds the spec once; specURL is where Spec is mounted, so the UI can load it
+func NewHandler(specURL string) (*Handler, error) {
+	spec, err := BuildOpenAPISpec()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{spec: spec, specURL: specURL}, nil
+}
+
+// Spec serves the OpenAPI 3 spec as JSON
+func (h *Handler) Spec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// UI returns a handler serving Swagger UI; mount it on a catch-all route such as /docs/*any
+func (h *Handler) UI() gin.HandlerFunc {
+	// A dedicated file handler, since gin-swagger pins the shared one to the first prefix it serves
+	ui := ginSwagger.WrapHandler(swaggerFiles.NewHandler(), ginSwagger.URL(h.specURL))
+
+	return func(c *gin.Context) {
+		if c.Param("any") == "/" {
+			c.Redirect(http.StatusMovedPermanently, c.Request.URL.Path+"index.html")
+			return
+		}
+		ui(c)
+	}
+}
@@ -0,0 +1,348 @@
+// Package docs assembles the OpenAPI 3 spec of the API and serves it along with Swagger UI.
+// The spec is built from the Swagger 2.0 document that swag generates into ./docs from the
+// handler annotations and request/response DTOs, so it never drifts from the code.
+package docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	generated "github.com/DuckDHD/BuyOrBye/docs"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification the spec conforms to
+const OpenAPIVersion = "3.0.3"
+
+const (
+	swaggerDefinitionsRef = "#/definitions/"
+	openAPISchemasRef     = "#/components/schemas/"
+	defaultMediaType      = "application/json"
+
+	formMediaType           = "multipart/form-data"
+	urlEncodedFormMediaType = "application/x-www-form-urlencoded"
+)
+
+// schemaKeywords are the Swagger 2.0 non-body parameter and header fields that belong in an
+// OpenAPI 3 schema object
+var schemaKeywords = []string{
+	"type", "format", "items", "enum", "default",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum",
+	"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems", "multipleOf",
+}
+
+// BuildOpenAPISpec converts the generated Swagger 2.0 document into an OpenAPI 3 spec
+func BuildOpenAPISpec() ([]byte, error) {
+	return ConvertSwagger2([]byte(generated.SwaggerInfo.ReadDoc()))
+}
+
+// ConvertSwagger2 converts a Swagger 2.0 JSON document into an OpenAPI 3 JSON document
+// Body and form parameters become request bodies, definitions become component schemas and an
+// Authorization header API key becomes an HTTP bearer scheme
+func ConvertSwagger2(raw []byte) ([]byte, error) {
+	var swagger map[string]interface{}
+	if err := json.Unmarshal(raw, &swagger); err != nil {
+		return nil, fmt.Errorf("failed to parse swagger document: %w", err)
+	}
+	if version, _ := swagger["swagger"].(string); version != "2.0" {
+		return nil, fmt.Errorf("unsupported swagger version %q", version)
+	}
+
+	spec := map[string]interface{}{
+		"openapi": OpenAPIVersion,
+		"info":    swagger["info"],
+		"servers": []interface{}{map[string]interface{}{"url": serverURL(swagger)}},
+	}
+
+	paths, err := convertPaths(asMap(swagger["paths"]), stringList(swagger["consumes"]), stringList(swagger["produces"]))
+	if err != nil {
+		return nil, err
+	}
+	spec["paths"] = paths
+
+	components := map[string]interface{}{}
+	if definitions, ok := swagger["definitions"]; ok {
+		components["schemas"] = definitions
+	}
+	if securityDefinitions := asMap(swagger["securityDefinitions"]); len(securityDefinitions) > 0 {
+		schemes := make(map[string]interface{}, len(securityDefinitions))
+		for name, definition := range securityDefinitions {
+			schemes[name] = convertSecurityScheme(asMap(definition))
+		}
+		components["securitySchemes"] = schemes
+	}
+	spec["components"] = components
+
+	for _, key := range []string{"tags", "security", "externalDocs"} {
+		if value, ok := swagger[key]; ok {
+			spec[key] = value
+		}
+	}
+
+	return json.Marshal(rewriteRefs(spec))
+}
+
+// serverURL builds the server URL from the host, scheme and base path; without a host the
+// URL is relative to wherever the spec is served from
+func serverURL(swagger map[string]interface{}) string {
+	basePath, _ := swagger["basePath"].(string)
+	host, _ := swagger["host"].(string)
+	if host == "" {
+		if basePath == "" {
+			return "/"
+		}
+		return basePath
+	}
+
+	scheme := "https"
+	if schemes := stringList(swagger["schemes"]); len(schemes) > 0 {
+		scheme = schemes[0]
+	}
+	return scheme + "://" + host + basePath
+}
+
+func convertPaths(paths map[string]interface{}, consumes, produces []string) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(paths))
+
+	for path, item := range paths {
+		operations := make(map[string]interface{})
+		for method, operation := range asMap(item) {
+			if method == "parameters" {
+				return nil, fmt.Errorf("path-level parameters on %s are not supported", path)
+			}
+			op, err := convertOperation(asMap(operation), consumes, produces)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", strings.ToUpper(method), path, err)
+			}
+			operations[method] = op
+		}
+		converted[path] = operations
+	}
+
+	return converted, nil
+}
+
+func convertOperation(operation map[string]interface{}, consumes, produces []string) (map[string]interface{}, error) {
+	converted := make(map[string]interface{})
+	for _, key := range []string{"tags", "summary", "description", "operationId", "deprecated", "security"} {
+		if value, ok := operation[key]; ok {
+			converted[key] = value
+		}
+	}
+
+	if opConsumes := stringList(operation["consumes"]); len(opConsumes) > 0 {
+		consumes = opConsumes
+	}
+	if opProduces := stringList(operation["produces"]); len(opProduces) > 0 {
+		produces = opProduces
+	}
+
+	var parameters, formParameters []interface{}
+	for _, param := range asList(operation["parameters"]) {
+		parameter := asMap(param)
+		switch parameter["in"] {
+		case "body":
+			body := map[string]interface{}{
+				"content": mediaTypes(consumes, parameter["schema"]),
+			}
+			copyKeys(body, parameter, "description", "required")
+			converted["requestBody"] = body
+		case "formData":
+			formParameters = append(formParameters, parameter)
+		default:
+			parameters = append(parameters, convertParameter(parameter))
+		}
+	}
+	if len(parameters) > 0 {
+		converted["parameters"] = parameters
+	}
+	if len(formParameters) > 0 {
+		if _, ok := converted["requestBody"]; ok {
+			return nil, fmt.Errorf("body and form parameters can't be combined")
+		}
+		converted["requestBody"] = convertFormParameters(formParameters, consumes)
+	}
+
+	responses := make(map[string]interface{})
+	for code, resp := range asMap(operation["responses"]) {
+		responses[code] = convertResponse(asMap(resp), produces)
+	}
+	converted["responses"] = responses
+
+	return converted, nil
+}
+
+func convertParameter(parameter map[string]interface{}) map[string]interface{} {
+	converted := map[string]interface{}{
+		"schema": extractSchema(parameter),
+	}
+	copyKeys(converted, parameter, "name", "in", "description", "required")
+	// Path parameters are always required in OpenAPI 3
+	if parameter["in"] == "path" {
+		converted["required"] = true
+	}
+	return converted
+}
+
+// convertFormParameters combines an operation's form parameters into a request body with one
+// object schema. The form is sent as multipart/form-data unless the operation consumes
+// application/x-www-form-urlencoded, which can't carry files.
+func convertFormParameters(parameters []interface{}, consumes []string) map[string]interface{} {
+	properties := make(map[string]interface{}, len(parameters))
+	var required []interface{}
+	for _, param := range parameters {
+		parameter := asMap(param)
+		name := fmt.Sprint(parameter["name"])
+		property := extractSchema(parameter)
+		copyKeys(property, parameter, "description")
+		properties[name] = property
+		if isRequired, _ := parameter["required"].(bool); isRequired {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+
+	mediaType := formMediaType
+	for _, consumed := range consumes {
+		if consumed == urlEncodedFormMediaType {
+			mediaType = urlEncodedFormMediaType
+		}
+	}
+	return map[string]interface{}{
+		"required": len(required) > 0,
+		"content":  mediaTypes([]string{mediaType}, schema),
+	}
+}
+
+func convertResponse(resp map[string]interface{}, produces []string) map[string]interface{} {
+	converted := map[string]interface{}{
+		"description": resp["description"],
+	}
+	if schema, ok := resp["schema"]; ok {
+		if isFileType(asMap(schema)) {
+			schema = binarySchema()
+		}
+		converted["content"] = mediaTypes(produces, schema)
+	}
+	if headers := asMap(resp["headers"]); len(headers) > 0 {
+		convertedHeaders := make(map[string]interface{}, len(headers))
+		for name, header := range headers {
+			h := asMap(header)
+			convertedHeader := map[string]interface{}{"schema": extractSchema(h)}
+			copyKeys(convertedHeader, h, "description")
+			convertedHeaders[name] = convertedHeader
+		}
+		converted["headers"] = convertedHeaders
+	}
+	return converted
+}
+
+// convertSecurityScheme maps a Swagger 2.0 security definition to an OpenAPI 3 security scheme
+// An API key in the Authorization header carries a bearer token, so it becomes an HTTP bearer scheme
+func convertSecurityScheme(definition map[string]interface{}) map[string]interface{} {
+	scheme := make(map[string]interface{})
+	copyKeys(scheme, definition, "description")
+
+	switch definition["type"] {
+	case "apiKey":
+		if definition["in"] == "header" && strings.EqualFold(fmt.Sprint(definition["name"]), "Authorization") {
+			scheme["type"] = "http"
+			scheme["scheme"] = "bearer"
+			scheme["bearerFormat"] = "JWT"
+			return scheme
+		}
+		scheme["type"] = "apiKey"
+		copyKeys(scheme, definition, "name", "in")
+	case "basic":
+		scheme["type"] = "http"
+		scheme["scheme"] = "basic"
+	default:
+		// oauth2 flows aren't used by this API; keep the definition as-is
+		for key, value := range definition {
+			scheme[key] = value
+		}
+	}
+
+	return scheme
+}
+
+func mediaTypes(types []string, schema interface{}) map[string]interface{} {
+	if len(types) == 0 {
+		types = []string{defaultMediaType}
+	}
+	content := make(map[string]interface{}, len(types))
+	for _, mediaType := range types {
+		content[mediaType] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+func extractSchema(field map[string]interface{}) map[string]interface{} {
+	if isFileType(field) {
+		return binarySchema()
+	}
+	schema := make(map[string]interface{})
+	copyKeys(schema, field, schemaKeywords...)
+	return schema
+}
+
+// isFileType reports whether a Swagger 2.0 parameter or schema is a file, which OpenAPI 3
+// describes as a binary string
+func isFileType(field map[string]interface{}) bool {
+	return field["type"] == "file"
+}
+
+func binarySchema() map[string]interface{} {
+	return map[string]interface{}{"type": "string", "format": "binary"}
+}
+
+// rewriteRefs points every Swagger 2.0 definition reference at the OpenAPI 3 component schemas
+func rewriteRefs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				v[key] = strings.Replace(ref, swaggerDefinitionsRef, openAPISchemasRef, 1)
+				continue
+			}
+			v[key] = rewriteRefs(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = rewriteRefs(child)
+		}
+	}
+	return value
+}
+
+func copyKeys(dst, src map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if value, ok := src[key]; ok {
+			dst[key] = value
+		}
+	}
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+func asList(value interface{}) []interface{} {
+	l, _ := value.([]interface{})
+	return l
+}
+
+func stringList(value interface{}) []string {
+	var result []string
+	for _, item := range asList(value) {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
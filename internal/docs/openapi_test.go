@@ -0,0 +1,234 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpecURL = "/api/v1/openapi.json"
+
+func setupDocsTestRouter(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handler, err := NewHandler(testSpecURL)
+	require.NoError(t, err)
+
+	r := gin.New()
+	r.GET(testSpecURL, handler.Spec)
+	r.GET("/docs/*any", handler.UI())
+	return r
+}
+
+func fetchSpec(t *testing.T, router *gin.Engine) map[string]interface{} {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, testSpecURL, nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	return spec
+}
+
+func TestHandler_ServesOpenAPISpec(t *testing.T) {
+	spec := fetchSpec(t, setupDocsTestRouter(t))
+
+	assert.Equal(t, OpenAPIVersion, spec["openapi"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "/api/v1"}}, spec["servers"])
+
+	paths := asMap(spec["paths"])
+	for _, path := range []string{"/auth/login", "/finance/summary", "/health/profile"} {
+		assert.Contains(t, paths, path)
+	}
+
+	summary := asMap(asMap(paths["/finance/summary"])["get"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"BearerAuth": []interface{}{}}}, summary["security"])
+	okSchema := asMap(asMap(asMap(asMap(asMap(summary["responses"])["200"])["content"])["application/json"])["schema"])
+	assert.Equal(t, "#/components/schemas/dtos.FinanceSummaryResponseDTO", okSchema["$ref"])
+
+	bearer := asMap(asMap(asMap(spec["components"])["securitySchemes"])["BearerAuth"])
+	assert.Equal(t, "http", bearer["type"])
+	assert.Equal(t, "bearer", bearer["scheme"])
+	assert.Equal(t, "JWT", bearer["bearerFormat"])
+}
+
+func TestHandler_SpecDerivesSchemasFromDTOs(t *testing.T) {
+	spec := fetchSpec(t, setupDocsTestRouter(t))
+
+	login := asMap(asMap(asMap(spec["paths"])["/auth/login"])["post"])
+	body := asMap(login["requestBody"])
+	assert.Equal(t, true, body["required"])
+	schema := asMap(asMap(asMap(body["content"])["application/json"])["schema"])
+	assert.Equal(t, "#/components/schemas/dtos.LoginRequestDTO", schema["$ref"])
+
+	loginDTO := asMap(asMap(asMap(spec["components"])["schemas"])["dtos.LoginRequestDTO"])
+	assert.Contains(t, asMap(loginDTO["properties"]), "email")
+	assert.Contains(t, asMap(loginDTO["properties"]), "password")
+	for _, param := range asList(login["parameters"]) {
+		assert.NotEqual(t, "body", asMap(param)["in"])
+	}
+
+	raw, err := json.Marshal(spec)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "#/definitions/")
+}
+
+func TestConvertSwagger2_Parameters(t *testing.T) {
+	raw := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"host": "api.example.com",
+		"basePath": "/v1",
+		"schemes": ["https"],
+		"paths": {
+			"/items/{id}": {
+				"get": {
+					"produces": ["application/json"],
+					"parameters": [
+						{"name": "id", "in": "path", "type": "string"},
+						{"name": "limit", "in": "query", "type": "integer", "minimum": 1, "maximum": 200},
+						{"name": "Idempotency-Key", "in": "header", "type": "string", "required": false}
+					],
+					"responses": {
+						"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Item"}}},
+						"204": {"description": "No Content"}
+					}
+				}
+			}
+		},
+		"definitions": {"Item": {"type": "object", "properties": {"id": {"type": "string"}}}},
+		"securityDefinitions": {"Key": {"type": "apiKey", "in": "header", "name": "X-API-Key"}}
+	}`)
+
+	converted, err := ConvertSwagger2(raw)
+	require.NoError(t, err)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(converted, &spec))
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "https://api.example.com/v1"}}, spec["servers"])
+
+	get := asMap(asMap(asMap(spec["paths"])["/items/{id}"])["get"])
+	params := asList(get["parameters"])
+	require.Len(t, params, 3)
+	assert.Equal(t, map[string]interface{}{"name": "id", "in": "path", "required": true, "schema": map[string]interface{}{"type": "string"}}, params[0])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "minimum": 1.0, "maximum": 200.0}, asMap(params[1])["schema"])
+
+	responses := asMap(get["responses"])
+	items := asMap(asMap(asMap(asMap(asMap(responses["200"])["content"])["application/json"])["schema"])["items"])
+	assert.Equal(t, "#/components/schemas/Item", items["$ref"])
+	assert.NotContains(t, asMap(responses["204"]), "content")
+
+	key := asMap(asMap(asMap(spec["components"])["securitySchemes"])["Key"])
+	assert.Equal(t, map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"}, key)
+}
+
+func TestConvertSwagger2_FormParametersAndFiles(t *testing.T) {
+	raw := []byte(`{
+		"swagger": "2.0",
+		"info": {"title": "Test", "version": "1.0"},
+		"paths": {
+			"/files": {
+				"post": {
+					"consumes": ["multipart/form-data"],
+					"parameters": [
+						{"name": "file", "in": "formData", "type": "file", "required": true, "description": "Document"},
+						{"name": "note", "in": "formData", "type": "string", "maxLength": 100}
+					],
+					"responses": {"201": {"description": "Created"}}
+				}
+			},
+			"/files/{id}": {
+				"get": {
+					"produces": ["application/pdf"],
+					"parameters": [{"name": "id", "in": "path", "type": "string"}],
+					"responses": {"200": {"description": "OK", "schema": {"type": "file"}}}
+				}
+			}
+		}
+	}`)
+
+	converted, err := ConvertSwagger2(raw)
+	require.NoError(t, err)
+
+	var spec map[string]interface{}
+	require.NoError(t, json.Unmarshal(converted, &spec))
+	paths := asMap(spec["paths"])
+
+	post := asMap(asMap(paths["/files"])["post"])
+	assert.NotContains(t, post, "parameters")
+	body := asMap(post["requestBody"])
+	assert.Equal(t, true, body["required"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"file": map[string]interface{}{"type": "string", "format": "binary", "description": "Document"},
+			"note": map[string]interface{}{"type": "string", "maxLength": 100.0},
+		},
+		"required": []interface{}{"file"},
+	}, asMap(asMap(asMap(body["content"])["multipart/form-data"])["schema"]))
+
+	get := asMap(asMap(paths["/files/{id}"])["get"])
+	download := asMap(asMap(asMap(asMap(get["responses"])["200"])["content"])["application/pdf"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "binary"}, download["schema"])
+}
+
+func TestConvertSwagger2_Rejects(t *testing.T) {
+	tests := []struct {
+		name          string
+		raw           string
+		expectedError string
+	}{
+		{name: "invalid json", raw: `{`, expectedError: "failed to parse swagger document"},
+		{name: "openapi 3 input", raw: `{"openapi": "3.0.0"}`, expectedError: "unsupported swagger version"},
+		{
+			name:          "body and form parameters",
+			raw:           `{"swagger": "2.0", "paths": {"/upload": {"post": {"parameters": [{"name": "request", "in": "body", "schema": {}}, {"name": "file", "in": "formData"}], "responses": {}}}}}`,
+			expectedError: "POST /upload: body and form parameters can't be combined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertSwagger2([]byte(tt.raw))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestHandler_ServesSwaggerUI(t *testing.T) {
+	router := setupDocsTestRouter(t)
+
+	// gin-swagger matches on RequestURI, which only httptest.NewRequest sets
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/docs/", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMovedPermanently, w.Code)
+	assert.Equal(t, "/docs/index.html", w.Header().Get("Location"))
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/docs/index.html", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+
+	// The UI loads the OpenAPI 3 spec rather than swag's Swagger 2.0 doc.json
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/docs/swagger-initializer.js", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), testSpecURL)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Scopes an API key can be granted. Read scopes allow GET requests to a resource's
+// routes; write scopes allow every other method.
+const (
+	APIKeyScopeFinanceRead  = "finance:read"
+	APIKeyScopeFinanceWrite = "finance:write"
+	APIKeyScopeHealthRead   = "health:read"
+	APIKeyScopeHealthWrite  = "health:write"
+)
+
+// ValidAPIKeyScopes contains all scopes an API key can be granted
+var ValidAPIKeyScopes = []string{
+	APIKeyScopeFinanceRead,
+	APIKeyScopeFinanceWrite,
+	APIKeyScopeHealthRead,
+	APIKeyScopeHealthWrite,
+}
+
+const (
+	// APIKeyPrefix starts every generated API key so leaked keys are easy to recognise
+	APIKeyPrefix = "bob_"
+	// APIKeyDisplayPrefixLength is how many leading characters of a key are kept to identify it in listings
+	APIKeyDisplayPrefixLength = 12
+	// MaxAPIKeyNameLength bounds the length of an API key's name
+	MaxAPIKeyNameLength = 100
+)
+
+// APIKey is a long-lived credential scripts use instead of a JWT.
+// Only a hash of the key is stored; the key itself is returned once, when it is created.
+type APIKey struct {
+	ID         string
+	UserID     string
+	Name       string
+	HashedKey  string
+	Prefix     string // The first APIKeyDisplayPrefixLength characters of the key
+	Scopes     []string
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// Validate validates the APIKey struct
+// Returns an error wrapping ErrInvalidAPIKeyData that describes every problem found
+func (k *APIKey) Validate() error {
+	var errors []string
+
+	if k.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	name := strings.TrimSpace(k.Name)
+	if name == "" {
+		errors = append(errors, "name is required")
+	} else if len(name) > MaxAPIKeyNameLength {
+		errors = append(errors, fmt.Sprintf("name must be at most %d characters", MaxAPIKeyNameLength))
+	}
+
+	if k.HashedKey == "" {
+		errors = append(errors, "hashed key is required")
+	}
+
+	if len(k.Scopes) == 0 {
+		errors = append(errors, "at least one scope is required")
+	}
+	seen := make(map[string]bool, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		if !isValidAPIKeyScope(scope) {
+			errors = append(errors, fmt.Sprintf("unknown scope %q", scope))
+		} else if seen[scope] {
+			errors = append(errors, fmt.Sprintf("duplicate scope %q", scope))
+		}
+		seen[scope] = true
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidAPIKeyData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// IsRevoked returns true once the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// HasScope returns true if the key was granted scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidAPIKeyScope checks if the scope is one API keys can be granted
+func isValidAPIKeyScope(scope string) bool {
+	for _, valid := range ValidAPIKeyScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validAPIKey() APIKey {
+	return APIKey{
+		UserID:    "user-123",
+		Name:      "Nightly export",
+		HashedKey: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		Prefix:    "bob_0123abcd",
+		Scopes:    []string{APIKeyScopeFinanceRead, APIKeyScopeHealthRead},
+	}
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(*APIKey)
+		expectedError string
+	}{
+		{name: "valid key", modify: func(k *APIKey) {}},
+		{name: "missing user", modify: func(k *APIKey) { k.UserID = "" }, expectedError: "user ID is required"},
+		{name: "blank name", modify: func(k *APIKey) { k.Name = "   " }, expectedError: "name is required"},
+		{name: "name too long", modify: func(k *APIKey) { k.Name = strings.Repeat("a", MaxAPIKeyNameLength+1) }, expectedError: "name must be at most"},
+		{name: "missing hash", modify: func(k *APIKey) { k.HashedKey = "" }, expectedError: "hashed key is required"},
+		{name: "no scopes", modify: func(k *APIKey) { k.Scopes = nil }, expectedError: "at least one scope is required"},
+		{name: "unknown scope", modify: func(k *APIKey) { k.Scopes = []string{"admin"} }, expectedError: `unknown scope "admin"`},
+		{
+			name:          "duplicate scope",
+			modify:        func(k *APIKey) { k.Scopes = []string{APIKeyScopeHealthWrite, APIKeyScopeHealthWrite} },
+			expectedError: `duplicate scope "health:write"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := validAPIKey()
+			tt.modify(&key)
+
+			err := key.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidAPIKeyData)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestAPIKey_HasScopeAndIsRevoked(t *testing.T) {
+	key := validAPIKey()
+
+	assert.True(t, key.HasScope(APIKeyScopeFinanceRead))
+	assert.False(t, key.HasScope(APIKeyScopeFinanceWrite))
+	assert.False(t, key.IsRevoked())
+
+	revokedAt := time.Now()
+	key.RevokedAt = &revokedAt
+	assert.True(t, key.IsRevoked())
+}
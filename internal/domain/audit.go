@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Audit actions
+const (
+	AuditActionLogin          = "login"
+	AuditActionLoginFailed    = "login_failed"
+	AuditActionPasswordChange = "password_change"
+	AuditActionTokenRevoke    = "token_revoke"
+	AuditActionCreate         = "create"
+	AuditActionUpdate         = "update"
+	AuditActionDelete         = "delete"
+	AuditActionRestore        = "restore"
+)
+
+// Audited resource types
+const (
+	AuditResourceUser             = "user"
+	AuditResourceRefreshToken     = "refresh_token"
+	AuditResourceHealthProfile    = "health_profile"
+	AuditResourceMedicalCondition = "medical_condition"
+	AuditResourceInsurancePolicy  = "insurance_policy"
+	AuditResourceMedicalExpense   = "medical_expense"
+	AuditResourceIncome           = "income"
+	AuditResourceExpense          = "expense"
+	AuditResourceLoan             = "loan"
+	AuditResourceSavingsGoal      = "savings_goal"
+	AuditResourceGoalContribution = "goal_contribution"
+	AuditResourceBudget           = "budget"
+	AuditResourceLoanBalanceEntry = "loan_balance_entry"
+)
+
+// AuditRedacted replaces the value of sensitive fields in audit changes
+const AuditRedacted = "[REDACTED]"
+
+// auditSensitiveFields are never written to the audit log in the clear
+var auditSensitiveFields = map[string]bool{
+	"password":      true,
+	"password_hash": true,
+	"policy_number": true,
+	"secret":        true,
+	"token":         true,
+	"refresh_token": true,
+}
+
+// auditIgnoredFields change on every write and would only add noise to diffs
+var auditIgnoredFields = map[string]bool{
+	"updated_at": true,
+}
+
+// AuditEntry is one immutable record of a sensitive action.
+// UserID is the user who acted, or for logins the account that was signed into.
+type AuditEntry struct {
+	ID           string
+	UserID       string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	RequestID    string
+	IPAddress    string
+	UserAgent    string
+	// Changes holds the changed fields keyed by their JSON name, with sensitive values redacted
+	Changes   map[string]AuditChange
+	CreatedAt time.Time
+}
+
+// AuditChange is the value of one field before and after an action; either is nil
+// when the resource was created or deleted
+type AuditChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditDiff compares two snapshots of a resource and returns the fields that differ.
+// before and after may be structs, pointers to structs or maps with string keys, and
+// either may be nil. Fields are keyed by their JSON name, or the snake_case field name
+// when there is none; sensitive fields are redacted but still show that they changed.
+func AuditDiff(before, after interface{}) map[string]AuditChange {
+	beforeFields := auditFields(before)
+	afterFields := auditFields(after)
+
+	changes := make(map[string]AuditChange)
+	for name, value := range beforeFields {
+		if other, ok := afterFields[name]; !ok || !auditValuesEqual(value, other) {
+			changes[name] = AuditChange{Before: value, After: other}
+		}
+	}
+	for name, value := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = AuditChange{After: value}
+		}
+	}
+
+	for name, change := range changes {
+		if auditSensitiveFields[name] {
+			changes[name] = AuditChange{Before: redactAuditValue(change.Before), After: redactAuditValue(change.After)}
+		}
+	}
+
+	return changes
+}
+
+// auditFields flattens a snapshot into its top-level fields
+func auditFields(snapshot interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if snapshot == nil {
+		return fields
+	}
+
+	value := reflect.ValueOf(snapshot)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return fields
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		valueType := value.Type()
+		for i := 0; i < valueType.NumField(); i++ {
+			field := valueType.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name := auditFieldName(field)
+			if auditIgnoredFields[name] {
+				continue
+			}
+			fields[name] = value.Field(i).Interface()
+		}
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return fields
+		}
+		for _, key := range value.MapKeys() {
+			if auditIgnoredFields[key.String()] {
+				continue
+			}
+			fields[key.String()] = value.MapIndex(key).Interface()
+		}
+	}
+
+	return fields
+}
+
+// auditFieldName returns the JSON name of a struct field, falling back to snake_case so
+// fields hidden from JSON, like password hashes, are still recognised as sensitive
+func auditFieldName(field reflect.StructField) string {
+	if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" && tag != "-" {
+		return tag
+	}
+	return toSnakeCase(field.Name)
+}
+
+func auditValuesEqual(a, b interface{}) bool {
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// redactAuditValue hides a sensitive value, keeping nil and empty values so a diff still shows
+// whether the field was set or cleared
+func redactAuditValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	if s, ok := value.(string); ok && s == "" {
+		return ""
+	}
+	return AuditRedacted
+}
+
+// toSnakeCase converts a Go field name such as PasswordHash or UserID to password_hash or user_id
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1])))
+			if startsWord {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
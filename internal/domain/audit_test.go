@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditDiff_Create(t *testing.T) {
+	income := Income{ID: "income-1", UserID: "user-1", Source: "Salary", Amount: 5000, Frequency: "monthly", IsActive: true}
+
+	changes := AuditDiff(nil, income)
+
+	assert.Equal(t, AuditChange{After: "Salary"}, changes["source"])
+	assert.Equal(t, AuditChange{After: 5000.0}, changes["amount"])
+	assert.Contains(t, changes, "user_id")
+	assert.NotContains(t, changes, "updated_at")
+}
+
+func TestAuditDiff_UpdateOnlyChangedFields(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC)
+	before := Income{ID: "income-1", UserID: "user-1", Source: "Salary", Amount: 5000, IsActive: true, CreatedAt: created, UpdatedAt: created}
+	after := before
+	after.Amount = 5500
+	after.UpdatedAt = created.Add(time.Hour)
+	// The same instant in another location is not a change
+	after.CreatedAt = created.In(time.FixedZone("UTC+2", 2*60*60))
+
+	changes := AuditDiff(before, &after)
+
+	assert.Equal(t, map[string]AuditChange{"amount": {Before: 5000.0, After: 5500.0}}, changes)
+}
+
+func TestAuditDiff_Delete(t *testing.T) {
+	loan := &Loan{ID: "loan-1", UserID: "user-1", Lender: "Bank"}
+
+	changes := AuditDiff(loan, nil)
+
+	assert.Equal(t, AuditChange{Before: "Bank"}, changes["lender"])
+	assert.Empty(t, AuditDiff((*Loan)(nil), nil))
+}
+
+func TestAuditDiff_RedactsSensitiveFields(t *testing.T) {
+	before := User{ID: "user-1", Email: "a@example.com", PasswordHash: "$2a$10$old"}
+	after := before
+	after.PasswordHash = "$2a$10$new"
+
+	changes := AuditDiff(before, after)
+
+	assert.Equal(t, map[string]AuditChange{"password_hash": {Before: AuditRedacted, After: AuditRedacted}}, changes)
+
+	policy := InsurancePolicy{ID: "policy-1", PolicyNumber: "POL-123456", Provider: "Acme"}
+	changes = AuditDiff(nil, policy)
+	assert.Equal(t, AuditChange{After: AuditRedacted}, changes["policy_number"])
+	assert.Equal(t, AuditChange{After: "Acme"}, changes["provider"])
+
+	// Clearing a sensitive field stays visible
+	changes = AuditDiff(map[string]interface{}{"secret": "s3cret"}, map[string]interface{}{"secret": ""})
+	assert.Equal(t, AuditChange{Before: AuditRedacted, After: ""}, changes["secret"])
+}
+
+func TestAuditDiff_Maps(t *testing.T) {
+	changes := AuditDiff(nil, map[string]interface{}{"email": "a@example.com", "reason": "invalid_password"})
+
+	assert.Equal(t, map[string]AuditChange{
+		"email":  {After: "a@example.com"},
+		"reason": {After: "invalid_password"},
+	}, changes)
+}
+
+func TestToSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"ID":           "id",
+		"UserID":       "user_id",
+		"PasswordHash": "password_hash",
+		"IsActive":     "is_active",
+		"HTTPStatus":   "http_status",
+	}
+
+	for input, want := range tests {
+		assert.Equal(t, want, toSnakeCase(input), input)
+	}
+}
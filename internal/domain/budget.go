@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Budget caps a user's monthly spending in one expense category. A user has at most one
+// budget per category.
+type Budget struct {
+	ID           string
+	UserID       string
+	Category     string
+	MonthlyLimit float64
+	// Rollover carries the part of last month's limit that wasn't spent into this month
+	Rollover  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// MaxOverspentCategories is how many of the most overspent categories the summary lists
+const MaxOverspentCategories = 3
+
+// Validate validates the Budget struct
+// Returns an error wrapping ErrInvalidBudgetData that describes every problem found
+func (b *Budget) Validate() error {
+	var errors []string
+
+	if b.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	if b.Category == "" {
+		errors = append(errors, "category is required")
+	} else if !isValidCategory(b.Category) {
+		errors = append(errors, "category must be one of: "+strings.Join(ValidCategories, ", "))
+	}
+
+	if b.MonthlyLimit <= 0 {
+		errors = append(errors, "monthly limit must be greater than 0")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidBudgetData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// BudgetStatus compares the spending in a budget's category this month with its limit
+type BudgetStatus struct {
+	BudgetID     string
+	Category     string
+	MonthlyLimit float64
+	// RolloverAmount is last month's unspent limit carried into this month; 0 without rollover
+	RolloverAmount float64
+	// Limit is MonthlyLimit plus RolloverAmount
+	Limit       float64
+	Spent       float64
+	Remaining   float64
+	PercentUsed float64
+	OverBudget  bool
+}
+
+// CompareBudgets compares each budget with the month's spending in its category, ordered by
+// category. spent and previousSpent map a category to its spending this month and last month;
+// a rollover budget carries over what it left unspent last month, provided it existed before
+// this month began. Categories without a budget are left out.
+func CompareBudgets(budgets []Budget, spent, previousSpent map[string]float64, now time.Time) []BudgetStatus {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	statuses := make([]BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		rollover := 0.0
+		if budget.Rollover && budget.CreatedAt.Before(monthStart) {
+			rollover = math.Max(budget.MonthlyLimit-previousSpent[budget.Category], 0)
+		}
+
+		limit := budget.MonthlyLimit + rollover
+		status := BudgetStatus{
+			BudgetID:       budget.ID,
+			Category:       budget.Category,
+			MonthlyLimit:   budget.MonthlyLimit,
+			RolloverAmount: rollover,
+			Limit:          limit,
+			Spent:          spent[budget.Category],
+			Remaining:      limit - spent[budget.Category],
+		}
+		status.PercentUsed = math.Round(status.Spent/limit*10000) / 100
+		status.OverBudget = status.Spent > limit
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Category < statuses[j].Category
+	})
+	return statuses
+}
+
+// MostOverspent returns up to n over-budget statuses, the highest share of their limit first
+func MostOverspent(statuses []BudgetStatus, n int) []BudgetStatus {
+	var over []BudgetStatus
+	for _, status := range statuses {
+		if status.OverBudget {
+			over = append(over, status)
+		}
+	}
+
+	sort.SliceStable(over, func(i, j int) bool {
+		return over[i].PercentUsed > over[j].PercentUsed
+	})
+	if len(over) > n {
+		over = over[:n]
+	}
+	return over
+}
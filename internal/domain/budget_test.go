@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(*Budget)
+		expectedError string
+	}{
+		{name: "valid budget", modify: func(b *Budget) {}},
+		{name: "missing user", modify: func(b *Budget) { b.UserID = "" }, expectedError: "user ID is required"},
+		{name: "missing category", modify: func(b *Budget) { b.Category = "" }, expectedError: "category is required"},
+		{name: "unknown category", modify: func(b *Budget) { b.Category = "travel" }, expectedError: "category must be one of"},
+		{name: "zero limit", modify: func(b *Budget) { b.MonthlyLimit = 0 }, expectedError: "monthly limit must be greater than 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget := Budget{UserID: "user-1", Category: "food", MonthlyLimit: 500}
+			tt.modify(&budget)
+
+			err := budget.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidBudgetData)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestCompareBudgets(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	lastYear := now.AddDate(-1, 0, 0)
+	budgets := []Budget{
+		{ID: "budget-2", Category: "transport", MonthlyLimit: 200, CreatedAt: lastYear},
+		{ID: "budget-1", Category: "food", MonthlyLimit: 500, Rollover: true, CreatedAt: lastYear},
+	}
+	spent := map[string]float64{"food": 560, "transport": 150, "entertainment": 90}
+	previousSpent := map[string]float64{"food": 400}
+
+	statuses := CompareBudgets(budgets, spent, previousSpent, now)
+
+	require.Len(t, statuses, 2, "categories without a budget are left out")
+	food := statuses[0]
+	assert.Equal(t, "food", food.Category)
+	assert.Equal(t, 100.0, food.RolloverAmount)
+	assert.Equal(t, 600.0, food.Limit)
+	assert.Equal(t, 40.0, food.Remaining)
+	assert.Equal(t, 93.33, food.PercentUsed)
+	assert.False(t, food.OverBudget)
+
+	transport := statuses[1]
+	assert.Equal(t, "transport", transport.Category)
+	assert.Zero(t, transport.RolloverAmount)
+	assert.Equal(t, 75.0, transport.PercentUsed)
+}
+
+func TestCompareBudgets_RolloverNeedsLastMonth(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+	budgets := []Budget{
+		{Category: "food", MonthlyLimit: 500, Rollover: true, CreatedAt: now.AddDate(0, 0, -5)},
+	}
+
+	statuses := CompareBudgets(budgets, map[string]float64{"food": 520}, nil, now)
+
+	require.Len(t, statuses, 1)
+	assert.Zero(t, statuses[0].RolloverAmount, "a budget created this month has nothing to carry over")
+	assert.Equal(t, -20.0, statuses[0].Remaining)
+	assert.True(t, statuses[0].OverBudget)
+}
+
+func TestMostOverspent(t *testing.T) {
+	statuses := []BudgetStatus{
+		{Category: "entertainment", PercentUsed: 150, OverBudget: true},
+		{Category: "food", PercentUsed: 110, OverBudget: true},
+		{Category: "housing", PercentUsed: 100},
+		{Category: "transport", PercentUsed: 180, OverBudget: true},
+		{Category: "utilities", PercentUsed: 125, OverBudget: true},
+	}
+
+	over := MostOverspent(statuses, MaxOverspentCategories)
+
+	require.Len(t, over, 3)
+	assert.Equal(t, "transport", over[0].Category)
+	assert.Equal(t, "entertainment", over[1].Category)
+	assert.Equal(t, "utilities", over[2].Category)
+	assert.Empty(t, MostOverspent(statuses[2:3], MaxOverspentCategories))
+}
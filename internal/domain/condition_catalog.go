@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Condition catalog search limits
+const (
+	DefaultConditionCatalogMatches = 10
+	MaxConditionCatalogMatches     = 50
+)
+
+// Match scores, from the closest match down. A query matches a name when it equals the name,
+// starts it, starts one of its words for each query word, appears anywhere in it, or is within
+// a typo or two of it word by word.
+const (
+	catalogMatchExact      = 100
+	catalogMatchPrefix     = 90
+	catalogMatchWordPrefix = 80
+	catalogMatchContains   = 70
+	catalogMatchFuzzy      = 50
+)
+
+// ConditionCatalogEntry is a standard medical condition that users' conditions can reference so
+// the same condition is always recorded under the same name
+type ConditionCatalogEntry struct {
+	// ID is the condition's ICD-10 code, e.g. "E11"
+	ID       string
+	Name     string
+	Category string
+	// DefaultSeverity is suggested when a condition added from the catalog gives no severity
+	DefaultSeverity string
+	// RiskWeight is the points an active condition adds to the health risk score in place of the
+	// points for its severity; the risk model's category weight still applies
+	RiskWeight int
+	// Aliases are other names the condition is searched by, e.g. "diabetes type ii"
+	Aliases []string
+}
+
+// ApplyTo fills in condition from the entry: the canonical name and category, the risk weight
+// and, if the condition has none, the suggested severity
+func (e ConditionCatalogEntry) ApplyTo(condition *MedicalCondition) {
+	condition.CatalogID = e.ID
+	condition.Name = e.Name
+	condition.Category = e.Category
+	condition.CatalogRiskWeight = e.RiskWeight
+	if condition.Severity == "" {
+		condition.Severity = e.DefaultSeverity
+	}
+}
+
+// ConditionCatalogMatch is a catalog entry suggested for a search query
+type ConditionCatalogMatch struct {
+	Entry ConditionCatalogEntry
+	// MatchedName is the name or alias the query matched
+	MatchedName string
+	// Score ranks the match from 100 for an exact match down
+	Score int
+}
+
+// NormalizeConditionName folds a condition name for matching: accents are removed, letters are
+// lowercased and anything other than letters and digits separates words
+func NormalizeConditionName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// MatchConditionCatalog returns up to limit entries matching query, best match first and
+// entries matching equally well in name order. Case and accents are ignored.
+func MatchConditionCatalog(entries []ConditionCatalogEntry, query string, limit int) []ConditionCatalogMatch {
+	query = NormalizeConditionName(query)
+	if query == "" || limit <= 0 {
+		return nil
+	}
+
+	var matches []ConditionCatalogMatch
+	for _, entry := range entries {
+		best := ConditionCatalogMatch{Entry: entry}
+		for _, name := range append([]string{entry.Name}, entry.Aliases...) {
+			if score := conditionNameScore(query, NormalizeConditionName(name)); score > best.Score {
+				best.Score = score
+				best.MatchedName = name
+			}
+		}
+		if best.Score > 0 {
+			matches = append(matches, best)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Entry.Name < matches[j].Entry.Name
+	})
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// conditionNameScore scores how well a normalized query matches a normalized name, or 0 if it doesn't
+func conditionNameScore(query, name string) int {
+	switch {
+	case name == query:
+		return catalogMatchExact
+	case strings.HasPrefix(name, query):
+		return catalogMatchPrefix
+	}
+
+	queryWords, nameWords := strings.Fields(query), strings.Fields(name)
+	switch {
+	case everyWordMatches(queryWords, nameWords, strings.HasPrefix):
+		return catalogMatchWordPrefix
+	case strings.Contains(name, query):
+		return catalogMatchContains
+	case everyWordMatches(queryWords, nameWords, withinTypos):
+		return catalogMatchFuzzy
+	default:
+		return 0
+	}
+}
+
+// everyWordMatches returns true if each query word matches at least one name word
+func everyWordMatches(queryWords, nameWords []string, matches func(nameWord, queryWord string) bool) bool {
+	for _, queryWord := range queryWords {
+		found := false
+		for _, nameWord := range nameWords {
+			if matches(nameWord, queryWord) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// withinTypos returns true if queryWord is a misspelling of nameWord or of its start: one edit
+// away for words of 4 to 7 letters, two for longer ones. Shorter words must match exactly.
+func withinTypos(nameWord, queryWord string) bool {
+	query := []rune(queryWord)
+	allowed := 0
+	switch {
+	case len(query) >= 8:
+		allowed = 2
+	case len(query) >= 4:
+		allowed = 1
+	default:
+		return false
+	}
+
+	name := []rune(nameWord)
+	if editDistance(query, name) <= allowed {
+		return true
+	}
+	if len(name) > len(query) {
+		return editDistance(query, name[:len(query)]) <= allowed
+	}
+	return false
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConditionCatalog() []ConditionCatalogEntry {
+	return []ConditionCatalogEntry{
+		{ID: "E10", Name: "Type 1 Diabetes", Category: "chronic", DefaultSeverity: "severe", RiskWeight: 10, Aliases: []string{"juvenile diabetes"}},
+		{ID: "E11", Name: "Type 2 Diabetes", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 8, Aliases: []string{"diabetes type ii", "adult-onset diabetes"}},
+		{ID: "R73.03", Name: "Prediabetes", Category: "chronic", DefaultSeverity: "mild", RiskWeight: 3},
+		{ID: "I10", Name: "Hypertension", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 6, Aliases: []string{"high blood pressure"}},
+		{ID: "M35.00", Name: "Sjögren's Syndrome", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 5},
+		{ID: "H81.09", Name: "Ménière's Disease", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 4},
+	}
+}
+
+func matchedIDs(matches []ConditionCatalogMatch) []string {
+	ids := make([]string, len(matches))
+	for i, match := range matches {
+		ids[i] = match.Entry.ID
+	}
+	return ids
+}
+
+func TestNormalizeConditionName(t *testing.T) {
+	assert.Equal(t, "sjogren s syndrome", NormalizeConditionName("Sjögren's Syndrome"))
+	assert.Equal(t, "guillain barre syndrome", NormalizeConditionName("  Guillain-Barré   SYNDROME "))
+	assert.Equal(t, "type 2 diabetes", NormalizeConditionName("Type 2 Diabetes"))
+	assert.Empty(t, NormalizeConditionName(" -'. "))
+}
+
+func TestMatchConditionCatalog_RanksPrefixMatchesFirst(t *testing.T) {
+	matches := MatchConditionCatalog(testConditionCatalog(), "diab", DefaultConditionCatalogMatches)
+
+	// Both diabetes types start a word with "diab"; prediabetes only contains it
+	assert.Equal(t, []string{"E11", "E10", "R73.03"}, matchedIDs(matches))
+	assert.Equal(t, catalogMatchPrefix, matches[0].Score)
+	assert.Equal(t, "diabetes type ii", matches[0].MatchedName)
+	assert.Equal(t, catalogMatchWordPrefix, matches[1].Score)
+	assert.Equal(t, catalogMatchContains, matches[2].Score)
+}
+
+func TestMatchConditionCatalog_MatchesAliases(t *testing.T) {
+	matches := MatchConditionCatalog(testConditionCatalog(), "Diabetes Type II", DefaultConditionCatalogMatches)
+
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "E11", matches[0].Entry.ID)
+	assert.Equal(t, catalogMatchExact, matches[0].Score)
+
+	matches = MatchConditionCatalog(testConditionCatalog(), "high blood", DefaultConditionCatalogMatches)
+	assert.Equal(t, []string{"I10"}, matchedIDs(matches))
+}
+
+func TestMatchConditionCatalog_MatchesWordsInAnyOrder(t *testing.T) {
+	matches := MatchConditionCatalog(testConditionCatalog(), "diab type 2", DefaultConditionCatalogMatches)
+
+	require.NotEmpty(t, matches)
+	assert.Equal(t, "E11", matches[0].Entry.ID)
+}
+
+func TestMatchConditionCatalog_IgnoresCaseAndAccents(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"MENIERE", "H81.09"},
+		{"ménière", "H81.09"},
+		{"sjogren", "M35.00"},
+		{"SJÖGREN'S", "M35.00"},
+		{"HyperTension", "I10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			matches := MatchConditionCatalog(testConditionCatalog(), tt.query, DefaultConditionCatalogMatches)
+			require.NotEmpty(t, matches)
+			assert.Equal(t, tt.want, matches[0].Entry.ID)
+		})
+	}
+}
+
+func TestMatchConditionCatalog_ToleratesTypos(t *testing.T) {
+	matches := MatchConditionCatalog(testConditionCatalog(), "diabets", DefaultConditionCatalogMatches)
+	assert.Contains(t, matchedIDs(matches), "E11")
+	assert.Equal(t, catalogMatchFuzzy, matches[0].Score)
+
+	matches = MatchConditionCatalog(testConditionCatalog(), "hypertensoin", DefaultConditionCatalogMatches)
+	assert.Equal(t, []string{"I10"}, matchedIDs(matches))
+
+	// Short words must match exactly
+	assert.Empty(t, MatchConditionCatalog(testConditionCatalog(), "tpe", DefaultConditionCatalogMatches))
+	assert.Empty(t, MatchConditionCatalog(testConditionCatalog(), "xyz", DefaultConditionCatalogMatches))
+}
+
+func TestMatchConditionCatalog_LimitsMatches(t *testing.T) {
+	matches := MatchConditionCatalog(testConditionCatalog(), "diab", 2)
+	assert.Equal(t, []string{"E11", "E10"}, matchedIDs(matches))
+
+	assert.Empty(t, MatchConditionCatalog(testConditionCatalog(), "diab", 0))
+	assert.Empty(t, MatchConditionCatalog(testConditionCatalog(), "  ", DefaultConditionCatalogMatches))
+}
+
+func TestConditionCatalogEntry_ApplyTo(t *testing.T) {
+	entry := testConditionCatalog()[1]
+
+	condition := &MedicalCondition{CatalogID: "E11", Name: "sugar", Category: "acute"}
+	entry.ApplyTo(condition)
+	assert.Equal(t, "Type 2 Diabetes", condition.Name)
+	assert.Equal(t, "chronic", condition.Category)
+	assert.Equal(t, "moderate", condition.Severity)
+	assert.Equal(t, 8, condition.CatalogRiskWeight)
+	assert.True(t, condition.IsCatalogued())
+
+	condition = &MedicalCondition{CatalogID: "E11", Severity: "critical"}
+	entry.ApplyTo(condition)
+	assert.Equal(t, "critical", condition.Severity, "a severity given by the user is kept")
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "strings"
+
+// DefaultCurrency is the base currency used when none is configured, and the currency
+// of every income, expense and loan recorded before currencies were tracked
+const DefaultCurrency = "USD"
+
+// NormalizeCurrency trims and upper-cases an ISO 4217 currency code
+func NormalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCurrency reports whether code is shaped like an ISO 4217 currency code:
+// three upper-case letters. Whether a rate exists for it is up to the rate provider.
+func IsValidCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidCurrency(t *testing.T) {
+	tests := []struct {
+		code  string
+		valid bool
+	}{
+		{"USD", true},
+		{"EUR", true},
+		{"usd", false},
+		{"US", false},
+		{"USDT", false},
+		{"U$D", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.valid, IsValidCurrency(tt.code))
+		})
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	assert.Equal(t, "EUR", NormalizeCurrency(" eur "))
+	assert.Equal(t, "", NormalizeCurrency(""))
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cursor marks a position in a list ordered by creation time, then ID.
+// The zero Cursor is the start of the list.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// cursorPayload is the JSON encoded inside a cursor token
+type cursorPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"i"`
+}
+
+// IsZero reports whether the cursor is the start of the list
+func (c Cursor) IsZero() bool {
+	return c.ID == "" && c.CreatedAt.IsZero()
+}
+
+// Encode returns the cursor as an opaque URL-safe token. The zero Cursor encodes to "".
+func (c Cursor) Encode() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	data, _ := json.Marshal(cursorPayload{CreatedAt: c.CreatedAt, ID: c.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode. An empty token is the start of the list.
+// Returns an error wrapping ErrInvalidCursor if the token is malformed.
+func DecodeCursor(token string) (Cursor, error) {
+	if token == "" {
+		return Cursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: not a valid token", ErrInvalidCursor)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" || payload.CreatedAt.IsZero() {
+		return Cursor{}, fmt.Errorf("%w: not a valid token", ErrInvalidCursor)
+	}
+
+	return Cursor{CreatedAt: payload.CreatedAt, ID: payload.ID}, nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode_RoundTrip(t *testing.T) {
+	cursor := Cursor{
+		CreatedAt: time.Date(2026, 3, 4, 10, 30, 0, 123456789, time.UTC),
+		ID:        "income-abc",
+	}
+
+	token := cursor.Encode()
+	require.NotEmpty(t, token)
+	assert.NotContains(t, token, "income-abc", "the token is opaque")
+
+	decoded, err := DecodeCursor(token)
+	require.NoError(t, err)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+}
+
+func TestCursor_ZeroIsStartOfList(t *testing.T) {
+	assert.True(t, Cursor{}.IsZero())
+	assert.Empty(t, Cursor{}.Encode())
+
+	decoded, err := DecodeCursor("")
+	require.NoError(t, err)
+	assert.True(t, decoded.IsZero())
+}
+
+func TestDecodeCursor_InvalidTokens(t *testing.T) {
+	tests := map[string]string{
+		"not_base64":   "not a cursor!",
+		"not_json":     base64.RawURLEncoding.EncodeToString([]byte("hello")),
+		"missing_id":   base64.RawURLEncoding.EncodeToString([]byte(`{"c":"2026-01-01T00:00:00Z"}`)),
+		"missing_time": base64.RawURLEncoding.EncodeToString([]byte(`{"i":"income-1"}`)),
+	}
+
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeCursor(token)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
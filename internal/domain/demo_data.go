@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// DemoDataRecord names one record created when demo data was seeded, so removing the demo data
+// deletes exactly what was seeded and nothing the user added since
+type DemoDataRecord struct {
+	ID     string
+	UserID string
+	// BatchID groups the records created by one seeding
+	BatchID string
+	// Resource is the kind of record, an AuditResource* value such as "income" or "medical_condition"
+	Resource  string
+	RecordID  string
+	CreatedAt time.Time
+}
+
+// DemoDataBatch is the set of records created by one seeding
+type DemoDataBatch struct {
+	ID        string
+	UserID    string
+	Records   []DemoDataRecord
+	CreatedAt time.Time
+}
+
+// Counts returns how many records of each resource the batch holds
+func (b DemoDataBatch) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, record := range b.Records {
+		counts[record.Resource]++
+	}
+	return counts
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // User-related errors
 var (
@@ -12,6 +16,9 @@ var (
 
 	// ErrInvalidUserData is returned when user data validation fails
 	ErrInvalidUserData = errors.New("invalid user data")
+
+	// ErrWeakPassword is returned when a new password breaks the password policy
+	ErrWeakPassword = errors.New("password does not meet the password policy")
 )
 
 // Token-related errors
@@ -27,6 +34,15 @@ var (
 
 	// ErrInvalidToken is returned when a token is malformed or invalid
 	ErrInvalidToken = errors.New("invalid token")
+
+	// ErrTokenRoleChanged is returned when an access token carries a role the user no longer has
+	ErrTokenRoleChanged = errors.New("token role no longer matches the account")
+
+	// ErrTokenCleanupInProgress is returned when a token cleanup is requested while another is running
+	ErrTokenCleanupInProgress = errors.New("token cleanup already in progress")
+
+	// ErrTokenAlreadyExists is returned when a refresh token that is already stored is saved again
+	ErrTokenAlreadyExists = errors.New("token already exists")
 )
 
 // Authentication-related errors
@@ -36,6 +52,24 @@ var (
 
 	// ErrAccountInactive is returned when trying to authenticate with an inactive account
 	ErrAccountInactive = errors.New("account is inactive")
+
+	// ErrCannotModifySelf is returned when an admin tries to deactivate or demote their own account
+	ErrCannotModifySelf = errors.New("cannot modify own account")
+)
+
+// API key-related errors
+var (
+	// ErrAPIKeyNotFound is returned when an API key cannot be found or belongs to another user
+	ErrAPIKeyNotFound = errors.New("API key not found")
+
+	// ErrInvalidAPIKey is returned when a presented API key doesn't match any stored key
+	ErrInvalidAPIKey = errors.New("invalid API key")
+
+	// ErrAPIKeyRevoked is returned when a presented API key has been revoked
+	ErrAPIKeyRevoked = errors.New("API key has been revoked")
+
+	// ErrInvalidAPIKeyData is returned when API key data validation fails
+	ErrInvalidAPIKeyData = errors.New("invalid API key data")
 )
 
 // Finance-related errors
@@ -52,6 +86,15 @@ var (
 	// ErrLoanNotFound is returned when a loan record cannot be found
 	ErrLoanNotFound = errors.New("loan not found")
 
+	// ErrSavingsGoalNotFound is returned when a savings goal cannot be found
+	ErrSavingsGoalNotFound = errors.New("savings goal not found")
+
+	// ErrBudgetNotFound is returned when a budget cannot be found
+	ErrBudgetNotFound = errors.New("budget not found")
+
+	// ErrBudgetExists is returned when the user already has a budget for the category
+	ErrBudgetExists = errors.New("budget already exists for category")
+
 	// ErrInvalidFinanceData is returned when finance data validation fails
 	ErrInvalidFinanceData = errors.New("invalid finance data")
 
@@ -61,9 +104,47 @@ var (
 	// ErrInvalidExpenseData is returned when expense data validation fails
 	ErrInvalidExpenseData = errors.New("invalid expense data")
 
+	// ErrInvalidExpenseTags is returned when an expense has too many tags or a malformed one
+	ErrInvalidExpenseTags = errors.New("invalid expense tags")
+
+	// ErrInvalidExpenseFilter is returned when expense search criteria are invalid
+	ErrInvalidExpenseFilter = errors.New("invalid expense filter")
+
+	// ErrInvalidCursor is returned when a pagination cursor can't be decoded
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+
 	// ErrInvalidLoanData is returned when loan data validation fails
 	ErrInvalidLoanData = errors.New("invalid loan data")
 
+	// ErrInvalidSavingsGoalData is returned when savings goal or contribution validation fails
+	ErrInvalidSavingsGoalData = errors.New("invalid savings goal data")
+
+	// ErrInvalidBudgetData is returned when budget validation fails
+	ErrInvalidBudgetData = errors.New("invalid budget data")
+
+	// ErrInvalidPurchaseData is returned when the purchase given for an affordability check is invalid
+	ErrInvalidPurchaseData = errors.New("invalid purchase data")
+
+	// ErrNoIncome is returned when a purchase is checked for a user with no monthly income
+	ErrNoIncome = errors.New("no monthly income to assess the purchase against")
+
+	// ErrInvalidFinancialThresholds is returned when configured health or affordability thresholds are inconsistent
+	ErrInvalidFinancialThresholds = errors.New("invalid financial thresholds")
+
+	// ErrUnsupportedCurrency is returned when no exchange rate is known between two currencies
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+	// ErrDuplicateRecord is returned when a new income or expense matches one the user recently added.
+	// It is wrapped in a DuplicateRecordError naming the existing record.
+	ErrDuplicateRecord = errors.New("duplicate record")
+
+	// ErrPaymentBelowInterest is returned when a loan payment would never repay the balance
+	// because it doesn't cover the interest that accrues each month
+	ErrPaymentBelowInterest = errors.New("payment does not cover interest")
+
+	// ErrInstallmentsComplete is returned when recording a payment on an installment expense that is already paid off
+	ErrInstallmentsComplete = errors.New("installments already paid")
+
 	// ErrUnauthorizedAccess is returned when user tries to access data they don't own
 	ErrUnauthorizedAccess = errors.New("unauthorized access")
 
@@ -75,4 +156,106 @@ var (
 
 	// ErrLoanNotOwnedByUser is returned when user tries to access loan that doesn't belong to them
 	ErrLoanNotOwnedByUser = errors.New("loan does not belong to user")
-)
\ No newline at end of file
+
+	// ErrSavingsGoalNotOwnedByUser is returned when user tries to access a savings goal that doesn't belong to them
+	ErrSavingsGoalNotOwnedByUser = errors.New("savings goal does not belong to user")
+
+	// ErrBudgetNotOwnedByUser is returned when user tries to access a budget that doesn't belong to them
+	ErrBudgetNotOwnedByUser = errors.New("budget does not belong to user")
+)
+
+// DuplicateRecordError reports that a new record matches an existing one, so the client can
+// decide whether to keep the existing record or add the new one anyway
+type DuplicateRecordError struct {
+	// Resource is the kind of record, an AuditResource* value such as "income" or "expense"
+	Resource string
+	// ExistingID is the ID of the record the new one duplicates
+	ExistingID string
+}
+
+func (e *DuplicateRecordError) Error() string {
+	return fmt.Sprintf("%s duplicates existing %s %s", e.Resource, e.Resource, e.ExistingID)
+}
+
+// Unwrap makes errors.Is(err, ErrDuplicateRecord) match
+func (e *DuplicateRecordError) Unwrap() error {
+	return ErrDuplicateRecord
+}
+
+// WeakPasswordError lists every password policy rule a new password breaks, so the client can
+// show them all at once
+type WeakPasswordError struct {
+	// Violations describe the broken rules without naming the field, e.g. "must contain a digit"
+	Violations []string
+}
+
+func (e *WeakPasswordError) Error() string {
+	return "password " + strings.Join(e.Violations, "; ")
+}
+
+// Unwrap makes errors.Is(err, ErrWeakPassword) match
+func (e *WeakPasswordError) Unwrap() error {
+	return ErrWeakPassword
+}
+
+// Webhook-related errors
+var (
+	// ErrWebhookNotFound is returned when a webhook cannot be found or belongs to another user
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrInvalidWebhookData is returned when webhook data validation fails
+	ErrInvalidWebhookData = errors.New("invalid webhook data")
+)
+
+// Health-related errors
+var (
+	// ErrInvalidRiskModel is returned when a health risk model fails validation
+	ErrInvalidRiskModel = errors.New("invalid risk model")
+
+	// ErrProfileAlreadyExists is returned when a user who has a self health profile creates another
+	ErrProfileAlreadyExists = errors.New("user already has a health profile")
+
+	// ErrPolicyNumberExists is returned when a user already has an insurance policy with the same number
+	ErrPolicyNumberExists = errors.New("policy number already exists")
+
+	// ErrMedicalConditionNotFound is returned when a medical condition cannot be found
+	ErrMedicalConditionNotFound = errors.New("medical condition not found")
+
+	// ErrInsurancePolicyNotFound is returned when an insurance policy cannot be found
+	ErrInsurancePolicyNotFound = errors.New("insurance policy not found")
+
+	// ErrMedicalExpenseNotFound is returned when a medical expense cannot be found or belongs to another user
+	ErrMedicalExpenseNotFound = errors.New("medical expense not found")
+
+	// ErrExpenseNotRecurring is returned when an occurrence is recorded for a one-time medical expense
+	ErrExpenseNotRecurring = errors.New("medical expense is not recurring")
+
+	// ErrAttachmentNotFound is returned when an expense attachment cannot be found or belongs to another user
+	ErrAttachmentNotFound = errors.New("attachment not found")
+
+	// ErrAttachmentTooLarge is returned when an uploaded attachment exceeds the configured size limit
+	ErrAttachmentTooLarge = errors.New("attachment too large")
+
+	// ErrUnsupportedAttachmentType is returned when an uploaded attachment isn't a PDF, JPEG or PNG
+	ErrUnsupportedAttachmentType = errors.New("unsupported attachment type")
+
+	// ErrConditionCatalogEntryNotFound is returned when a condition references a catalog entry that doesn't exist
+	ErrConditionCatalogEntryNotFound = errors.New("condition catalog entry not found")
+)
+
+// Demo data errors
+var (
+	// ErrAccountHasData is returned when demo data is seeded into an account that already has
+	// finance or health records
+	ErrAccountHasData = errors.New("account already has finance or health data")
+
+	// ErrDemoDataNotFound is returned when there is no seeded demo data to remove
+	ErrDemoDataNotFound = errors.New("no demo data to remove")
+)
+
+// Report errors
+var (
+	// ErrInvalidReportPeriod is returned when a report is requested for a month that doesn't exist
+	// or hasn't started yet
+	ErrInvalidReportPeriod = errors.New("invalid report period")
+)
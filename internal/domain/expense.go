@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/money"
 )
 
 // Expense represents a user's expense in the domain layer
@@ -13,13 +15,33 @@ type Expense struct {
 	Category  string
 	Name      string
 	Amount    float64
+	// Currency is an ISO 4217 code; empty means the service's base currency
+	Currency  string
 	Frequency string
 	IsFixed   bool
 	Priority  int
+	// InstallmentsTotal splits a one-time purchase of Amount into that many monthly
+	// payments; zero means a regular recurring expense
+	InstallmentsTotal int
+	InstallmentsPaid  int
+	// InstallmentStart is the date the first installment is due
+	InstallmentStart time.Time
+	// Tags are free-form labels in the form NormalizeExpenseTags produces
+	Tags      []string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// InstallmentSchedule describes where an installment expense stands
+type InstallmentSchedule struct {
+	ExpenseID            string
+	Name                 string
+	MonthlyAmount        float64
+	InstallmentsPaid     int
+	InstallmentsTotal    int
+	FinalInstallmentDate time.Time
+}
+
 // Expense category constants
 const (
 	CategoryHousing       = "housing"
@@ -44,6 +66,12 @@ const (
 	PriorityNiceToHave   = 3 // Nice-to-have expenses (entertainment, dining out)
 )
 
+// Installment plan limits
+const (
+	MinInstallments = 2
+	MaxInstallments = 60
+)
+
 // ValidCategories contains all valid category values for expenses
 var ValidCategories = []string{
 	CategoryHousing,
@@ -84,6 +112,10 @@ func (e *Expense) Validate() error {
 		errors = append(errors, "amount must be greater than 0")
 	}
 
+	if e.Currency != "" && !IsValidCurrency(e.Currency) {
+		errors = append(errors, "currency must be a three-letter ISO 4217 code")
+	}
+
 	if e.Frequency == "" {
 		errors = append(errors, "frequency is required")
 	} else if !isValidExpenseFrequency(e.Frequency) {
@@ -94,6 +126,26 @@ func (e *Expense) Validate() error {
 		errors = append(errors, "priority must be between 1 and 3")
 	}
 
+	if e.InstallmentsTotal != 0 {
+		if e.InstallmentsTotal < MinInstallments || e.InstallmentsTotal > MaxInstallments {
+			errors = append(errors, fmt.Sprintf("installments must be between %d and %d", MinInstallments, MaxInstallments))
+		}
+		if e.Frequency != ExpenseFrequencyMonthly {
+			errors = append(errors, "installment expenses must have monthly frequency")
+		}
+		if e.InstallmentStart.IsZero() {
+			errors = append(errors, "installment start date is required")
+		}
+	}
+
+	if e.InstallmentsPaid < 0 {
+		errors = append(errors, "installments paid cannot be negative")
+	} else if e.InstallmentsPaid > e.InstallmentsTotal {
+		errors = append(errors, "installments paid cannot exceed total installments")
+	}
+
+	errors = append(errors, expenseTagProblems(e.Tags)...)
+
 	if e.CreatedAt.IsZero() {
 		errors = append(errors, "created at is required")
 	}
@@ -109,25 +161,27 @@ func (e *Expense) Validate() error {
 	return nil
 }
 
-// NormalizeToMonthly converts expense amount to monthly equivalent based on frequency
+// NormalizeToMonthly converts expense amount to monthly equivalent based on frequency,
+// using the same factors as the finance summary.
+// An installment expense counts one installment while any remain and nothing after.
 func (e *Expense) NormalizeToMonthly() float64 {
 	if e.Amount <= 0 {
 		return 0.0
 	}
 
-	switch e.Frequency {
-	case ExpenseFrequencyMonthly:
-		return e.Amount
-	case ExpenseFrequencyWeekly:
-		// 52 weeks per year / 12 months = 4.3333...
-		return e.Amount * 52.0 / 12.0
-	case ExpenseFrequencyDaily:
-		// 365.25 days per year (accounting for leap years) / 12 months
-		return e.Amount * 365.25 / 12.0
-	default:
+	if e.IsInstallment() {
+		if e.RemainingInstallments() == 0 {
+			return 0.0
+		}
+		return e.InstallmentAmount()
+	}
+
+	monthly, err := money.NormalizeToMonthly(e.Amount, e.Frequency)
+	if err != nil {
 		// Invalid frequency
 		return 0.0
 	}
+	return monthly
 }
 
 // GetCategoryDisplayName returns a user-friendly display name for the category
@@ -183,6 +237,64 @@ func (e *Expense) IsEssential() bool {
 	return e.Priority == PriorityEssential
 }
 
+// IsInstallment returns true if the expense is a purchase paid off in installments
+func (e *Expense) IsInstallment() bool {
+	return e.InstallmentsTotal > 0
+}
+
+// InstallmentAmount returns the amount due each month of an installment plan
+func (e *Expense) InstallmentAmount() float64 {
+	if !e.IsInstallment() {
+		return 0.0
+	}
+	return e.Amount / float64(e.InstallmentsTotal)
+}
+
+// RemainingInstallments returns how many installments are still to be paid
+func (e *Expense) RemainingInstallments() int {
+	if !e.IsInstallment() || e.InstallmentsPaid >= e.InstallmentsTotal {
+		return 0
+	}
+	return e.InstallmentsTotal - e.InstallmentsPaid
+}
+
+// IsActive returns false once every installment of an installment expense is paid.
+// Regular expenses are always active.
+func (e *Expense) IsActive() bool {
+	return !e.IsInstallment() || e.RemainingInstallments() > 0
+}
+
+// FinalInstallmentDate returns the due date of the last installment, after which the
+// expense drops out of monthly totals. The zero time for regular expenses.
+func (e *Expense) FinalInstallmentDate() time.Time {
+	if !e.IsInstallment() || e.InstallmentStart.IsZero() {
+		return time.Time{}
+	}
+	return e.InstallmentStart.AddDate(0, e.InstallmentsTotal-1, 0)
+}
+
+// InstallmentDueBetween returns true if one of the expense's installments falls due from start
+// up to end. Always false for regular expenses and installment plans without a start date.
+func (e *Expense) InstallmentDueBetween(start, end time.Time) bool {
+	if !e.IsInstallment() || e.InstallmentStart.IsZero() {
+		return false
+	}
+	return e.InstallmentStart.Before(end) && !e.FinalInstallmentDate().Before(start)
+}
+
+// InstallmentSchedule returns the expense's installment progress, with MonthlyAmount in
+// the expense's own currency
+func (e *Expense) InstallmentSchedule() InstallmentSchedule {
+	return InstallmentSchedule{
+		ExpenseID:            e.ID,
+		Name:                 e.Name,
+		MonthlyAmount:        e.InstallmentAmount(),
+		InstallmentsPaid:     e.InstallmentsPaid,
+		InstallmentsTotal:    e.InstallmentsTotal,
+		FinalInstallmentDate: e.FinalInstallmentDate(),
+	}
+}
+
 // CalculateAnnualAmount calculates the annual equivalent of this expense
 func (e *Expense) CalculateAnnualAmount() float64 {
 	monthlyAmount := e.NormalizeToMonthly()
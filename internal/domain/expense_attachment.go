@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"path"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Content types accepted for expense attachments
+const (
+	AttachmentContentTypePDF  = "application/pdf"
+	AttachmentContentTypeJPEG = "image/jpeg"
+	AttachmentContentTypePNG  = "image/png"
+)
+
+// AllowedAttachmentContentTypes contains every content type an attachment can have
+var AllowedAttachmentContentTypes = []string{
+	AttachmentContentTypePDF,
+	AttachmentContentTypeJPEG,
+	AttachmentContentTypePNG,
+}
+
+const (
+	// MaxAttachmentFileNameLength bounds the length of an attachment's display name
+	MaxAttachmentFileNameLength = 255
+	// DefaultAttachmentFileName names attachments uploaded without a usable filename
+	DefaultAttachmentFileName = "attachment"
+)
+
+// ExpenseAttachment is a document, such as a receipt or an explanation of benefits,
+// attached to a medical expense. The file itself lives in blob storage under StorageKey,
+// a generated ID; FileName is the sanitized name the user uploaded it as and is only
+// used when the file is downloaded.
+type ExpenseAttachment struct {
+	ID          string
+	UserID      string
+	ExpenseID   string
+	FileName    string
+	ContentType string
+	Size        int64
+	// Checksum is the hex-encoded SHA-256 of the file contents
+	Checksum   string
+	StorageKey string
+	CreatedAt  time.Time
+}
+
+// IsAllowedAttachmentContentType reports whether contentType can be attached to an expense
+func IsAllowedAttachmentContentType(contentType string) bool {
+	for _, allowed := range AllowedAttachmentContentTypes {
+		if contentType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// SanitizeAttachmentFileName reduces a user-supplied filename to a safe display name.
+// Directory components are dropped whichever separator they use, so "../../etc/passwd"
+// becomes "passwd"; control characters, quotes and the remaining separators are removed
+// and the result is truncated to MaxAttachmentFileNameLength bytes. Names that end up
+// empty or as a relative directory fall back to DefaultAttachmentFileName.
+func SanitizeAttachmentFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' || r == '/' || r == '\\' {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(name)
+
+	if len(name) > MaxAttachmentFileNameLength {
+		name = strings.ToValidUTF8(name[:MaxAttachmentFileNameLength], "")
+	}
+	if name == "" || name == "." || name == ".." {
+		return DefaultAttachmentFileName
+	}
+	return name
+}
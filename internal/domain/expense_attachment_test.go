@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain name", "receipt.pdf", "receipt.pdf"},
+		{"unix traversal", "../../etc/passwd", "passwd"},
+		{"windows traversal", `..\..\windows\system32\eob.png`, "eob.png"},
+		{"absolute path", "/var/uploads/scan.jpg", "scan.jpg"},
+		{"header injection", "eob\r\nX-Injected: 1\".pdf", "eobX-Injected: 1.pdf"},
+		{"only dots", "..", DefaultAttachmentFileName},
+		{"trailing separator", "receipts/", "receipts"},
+		{"empty", "", DefaultAttachmentFileName},
+		{"whitespace", "   ", DefaultAttachmentFileName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeAttachmentFileName(tt.input))
+		})
+	}
+}
+
+func TestSanitizeAttachmentFileName_TruncatesLongNames(t *testing.T) {
+	name := SanitizeAttachmentFileName(strings.Repeat("é", MaxAttachmentFileNameLength) + ".pdf")
+
+	assert.LessOrEqual(t, len(name), MaxAttachmentFileNameLength)
+	assert.True(t, strings.HasPrefix(name, "é"))
+	assert.NotContains(t, name, "�")
+}
+
+func TestIsAllowedAttachmentContentType(t *testing.T) {
+	assert.True(t, IsAllowedAttachmentContentType(AttachmentContentTypePDF))
+	assert.True(t, IsAllowedAttachmentContentType(AttachmentContentTypeJPEG))
+	assert.True(t, IsAllowedAttachmentContentType(AttachmentContentTypePNG))
+	assert.False(t, IsAllowedAttachmentContentType("text/html; charset=utf-8"))
+	assert.False(t, IsAllowedAttachmentContentType("image/gif"))
+}
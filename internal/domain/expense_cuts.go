@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ExpenseCut is an expense that could be cut, with what cutting it would save each month
+type ExpenseCut struct {
+	Expense Expense
+	// MonthlySavings is the expense's monthly amount in the suggestion's currency
+	MonthlySavings float64
+}
+
+// ExpenseCutSuggestion lists the discretionary expenses to cut to free up a target amount each month.
+// All amounts are monthly and in Currency.
+type ExpenseCutSuggestion struct {
+	UserID                    string
+	Currency                  string
+	TargetSavings             float64
+	Cuts                      []ExpenseCut
+	TotalSavings              float64
+	DisposableIncome          float64
+	ResultingDisposableIncome float64
+	// TargetReachable is false when cutting every discretionary expense still falls short of
+	// TargetSavings; Cuts then lists all of them and Shortfall is what is still missing
+	TargetReachable bool
+	Shortfall       float64
+}
+
+// IsDiscretionary reports whether an expense may be suggested for cutting. Fixed and essential
+// expenses are never cut, nor are installment plans, which are owed whether or not they are cut.
+func (e *Expense) IsDiscretionary() bool {
+	return !e.IsFixed && e.Priority != PriorityEssential && !e.IsInstallment()
+}
+
+// SuggestExpenseCuts picks discretionary expenses from candidates until their savings reach target,
+// starting with the lowest priority and, within a priority, the largest saving so as few expenses
+// as possible are cut. Candidates that aren't discretionary or save nothing are skipped.
+// Returns an error wrapping ErrInvalidFinanceData if target isn't positive.
+func SuggestExpenseCuts(candidates []ExpenseCut, target, disposableIncome float64) (ExpenseCutSuggestion, error) {
+	if target <= 0 {
+		return ExpenseCutSuggestion{}, fmt.Errorf("%w: target savings must be greater than 0", ErrInvalidFinanceData)
+	}
+
+	var eligible []ExpenseCut
+	for _, candidate := range candidates {
+		if candidate.Expense.IsDiscretionary() && candidate.MonthlySavings > 0 {
+			eligible = append(eligible, candidate)
+		}
+	}
+	// Priority 3 (nice-to-have) is the lowest priority, so it sorts first
+	sort.SliceStable(eligible, func(i, j int) bool {
+		if eligible[i].Expense.Priority != eligible[j].Expense.Priority {
+			return eligible[i].Expense.Priority > eligible[j].Expense.Priority
+		}
+		return eligible[i].MonthlySavings > eligible[j].MonthlySavings
+	})
+
+	suggestion := ExpenseCutSuggestion{
+		TargetSavings:    target,
+		Cuts:             []ExpenseCut{},
+		DisposableIncome: disposableIncome,
+	}
+	for _, cut := range eligible {
+		if suggestion.TotalSavings >= target {
+			break
+		}
+		suggestion.Cuts = append(suggestion.Cuts, cut)
+		suggestion.TotalSavings += cut.MonthlySavings
+	}
+
+	suggestion.TotalSavings = roundToCents(suggestion.TotalSavings)
+	suggestion.ResultingDisposableIncome = roundToCents(disposableIncome + suggestion.TotalSavings)
+	suggestion.TargetReachable = suggestion.TotalSavings >= target
+	if !suggestion.TargetReachable {
+		suggestion.Shortfall = roundToCents(target - suggestion.TotalSavings)
+	}
+	return suggestion, nil
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func expenseCut(id string, priority int, fixed bool, savings float64) ExpenseCut {
+	return ExpenseCut{
+		Expense: Expense{
+			ID:        id,
+			UserID:    "user-1",
+			Name:      id,
+			Category:  CategoryEntertainment,
+			Amount:    savings,
+			Frequency: ExpenseFrequencyMonthly,
+			IsFixed:   fixed,
+			Priority:  priority,
+		},
+		MonthlySavings: savings,
+	}
+}
+
+func TestSuggestExpenseCuts(t *testing.T) {
+	candidates := []ExpenseCut{
+		expenseCut("rent", PriorityEssential, true, 1500),
+		expenseCut("groceries", PriorityEssential, false, 600),
+		expenseCut("gym", PriorityImportant, true, 50),
+		expenseCut("car-insurance", PriorityImportant, false, 120),
+		expenseCut("streaming", PriorityNiceToHave, false, 30),
+		expenseCut("dining", PriorityNiceToHave, false, 250),
+	}
+
+	tests := []struct {
+		name          string
+		target        float64
+		wantCuts      []string
+		wantTotal     float64
+		wantReachable bool
+		wantShortfall float64
+	}{
+		{
+			name:          "one_nice_to_have_covers_target",
+			target:        200,
+			wantCuts:      []string{"dining"},
+			wantTotal:     250,
+			wantReachable: true,
+		},
+		{
+			name:          "nice_to_have_before_important",
+			target:        300,
+			wantCuts:      []string{"dining", "streaming", "car-insurance"},
+			wantTotal:     400,
+			wantReachable: true,
+		},
+		{
+			name:          "target_beyond_discretionary_spending",
+			target:        1000,
+			wantCuts:      []string{"dining", "streaming", "car-insurance"},
+			wantTotal:     400,
+			wantReachable: false,
+			wantShortfall: 600,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, err := SuggestExpenseCuts(candidates, tt.target, 100)
+
+			require.NoError(t, err)
+			var cuts []string
+			for _, cut := range suggestion.Cuts {
+				cuts = append(cuts, cut.Expense.ID)
+			}
+			assert.Equal(t, tt.wantCuts, cuts)
+			assert.Equal(t, tt.wantTotal, suggestion.TotalSavings)
+			assert.Equal(t, 100+tt.wantTotal, suggestion.ResultingDisposableIncome)
+			assert.Equal(t, tt.wantReachable, suggestion.TargetReachable)
+			assert.Equal(t, tt.wantShortfall, suggestion.Shortfall)
+		})
+	}
+}
+
+func TestSuggestExpenseCuts_SkipsInstallmentsAndRejectsInvalidTarget(t *testing.T) {
+	installment := expenseCut("laptop", PriorityNiceToHave, false, 100)
+	installment.Expense.InstallmentsTotal = 12
+
+	suggestion, err := SuggestExpenseCuts([]ExpenseCut{installment}, 50, 0)
+	require.NoError(t, err)
+	assert.Empty(t, suggestion.Cuts)
+	assert.False(t, suggestion.TargetReachable)
+	assert.Equal(t, 50.0, suggestion.Shortfall)
+
+	_, err = SuggestExpenseCuts(nil, 0, 0)
+	assert.ErrorIs(t, err, ErrInvalidFinanceData)
+}
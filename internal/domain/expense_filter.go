@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MaxExpenseFilterQueryLength bounds the name search term
+const MaxExpenseFilterQueryLength = 255
+
+// ExpenseFilter holds search criteria for a user's expenses
+// Zero-valued fields don't filter, and all set fields must match
+type ExpenseFilter struct {
+	// Query matches expenses whose name contains it, case-insensitively
+	Query     string
+	Category  string
+	Frequency string
+	// Tag selects expenses carrying it, compared in normalized form
+	Tag string
+	// Priority is 1-3, or 0 for any priority
+	Priority int
+	// IsFixed selects fixed (true) or variable (false) expenses; nil selects both
+	IsFixed *bool
+	// MinAmount and MaxAmount bound the amount inclusively; 0 leaves that side unbounded
+	MinAmount float64
+	MaxAmount float64
+	// CreatedFrom is inclusive and CreatedBefore is exclusive; zero leaves that side unbounded
+	CreatedFrom   time.Time
+	CreatedBefore time.Time
+}
+
+// IsEmpty returns true if the filter matches every expense
+func (f ExpenseFilter) IsEmpty() bool {
+	return f == ExpenseFilter{}
+}
+
+// Validate checks the filter for values that can never match or contradict each other
+// Returns an error wrapping ErrInvalidExpenseFilter that describes every problem found
+func (f ExpenseFilter) Validate() error {
+	var errors []string
+
+	if len(f.Query) > MaxExpenseFilterQueryLength {
+		errors = append(errors, fmt.Sprintf("search query must be at most %d characters", MaxExpenseFilterQueryLength))
+	}
+
+	if f.Category != "" && !isValidCategory(f.Category) {
+		errors = append(errors, "category must be one of: housing, food, transport, entertainment, utilities, other")
+	}
+
+	if f.Tag != "" {
+		if problem := expenseTagProblem(f.Tag); problem != "" {
+			errors = append(errors, "tag "+problem)
+		}
+	}
+
+	if f.Frequency != "" && !isValidExpenseFrequency(f.Frequency) {
+		errors = append(errors, "frequency must be one of: monthly, weekly, daily")
+	}
+
+	if f.Priority < 0 || f.Priority > 3 {
+		errors = append(errors, "priority must be between 1 and 3")
+	}
+
+	if f.MinAmount < 0 || f.MaxAmount < 0 {
+		errors = append(errors, "amounts must not be negative")
+	} else if f.MaxAmount > 0 && f.MinAmount > f.MaxAmount {
+		errors = append(errors, "min amount must not be greater than max amount")
+	}
+
+	if !f.CreatedFrom.IsZero() && !f.CreatedBefore.IsZero() && !f.CreatedFrom.Before(f.CreatedBefore) {
+		errors = append(errors, "created from must be before created to")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidExpenseFilter, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpenseFilter_Validate(t *testing.T) {
+	fixed := true
+	day := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        ExpenseFilter
+		expectedError string
+	}{
+		{name: "empty filter", filter: ExpenseFilter{}},
+		{
+			name: "combined criteria",
+			filter: ExpenseFilter{
+				Query: "rent", Category: CategoryHousing, Frequency: ExpenseFrequencyMonthly, Priority: PriorityEssential,
+				IsFixed: &fixed, MinAmount: 100, MaxAmount: 2000, CreatedFrom: day, CreatedBefore: day.AddDate(0, 1, 0),
+			},
+		},
+		{name: "equal amount bounds", filter: ExpenseFilter{MinAmount: 50, MaxAmount: 50}},
+		{name: "min amount only", filter: ExpenseFilter{MinAmount: 50}},
+		{name: "min greater than max", filter: ExpenseFilter{MinAmount: 500, MaxAmount: 100}, expectedError: "min amount must not be greater than max amount"},
+		{name: "negative amount", filter: ExpenseFilter{MinAmount: -1}, expectedError: "amounts must not be negative"},
+		{name: "unknown category", filter: ExpenseFilter{Category: "travel"}, expectedError: "category must be one of"},
+		{name: "unknown frequency", filter: ExpenseFilter{Frequency: "yearly"}, expectedError: "frequency must be one of"},
+		{name: "priority out of range", filter: ExpenseFilter{Priority: 4}, expectedError: "priority must be between 1 and 3"},
+		{name: "empty date range", filter: ExpenseFilter{CreatedFrom: day, CreatedBefore: day}, expectedError: "created from must be before created to"},
+		{name: "query too long", filter: ExpenseFilter{Query: strings.Repeat("a", MaxExpenseFilterQueryLength+1)}, expectedError: "search query must be at most"},
+		{name: "tag", filter: ExpenseFilter{Tag: "summer vacation"}},
+		{name: "tag too long", filter: ExpenseFilter{Tag: strings.Repeat("a", MaxExpenseTagLength+1)}, expectedError: "tag must be at most"},
+		{name: "tag with punctuation", filter: ExpenseFilter{Tag: "vacation%"}, expectedError: "tag may only contain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidExpenseFilter)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestExpenseFilter_IsEmpty(t *testing.T) {
+	fixed := false
+
+	assert.True(t, ExpenseFilter{}.IsEmpty())
+	assert.False(t, ExpenseFilter{Query: "rent"}.IsEmpty())
+	assert.False(t, ExpenseFilter{Tag: "vacation"}.IsEmpty())
+	assert.False(t, ExpenseFilter{IsFixed: &fixed}.IsEmpty())
+	assert.False(t, ExpenseFilter{CreatedFrom: time.Now()}.IsEmpty())
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Expense tag limits
+const (
+	MaxExpenseTags      = 10
+	MaxExpenseTagLength = 32
+)
+
+// NormalizeExpenseTag lowercases a tag, trims it and collapses runs of whitespace to one space
+func NormalizeExpenseTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+}
+
+// NormalizeExpenseTags normalizes every tag, dropping blank ones and repeats while keeping the
+// order they were first given in. Returns nil if no tags remain.
+func NormalizeExpenseTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeExpenseTag(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// ValidateExpenseTags checks normalized tags against the tag limits
+// Returns an error wrapping ErrInvalidExpenseTags that describes every problem found
+func ValidateExpenseTags(tags []string) error {
+	if problems := expenseTagProblems(tags); len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidExpenseTags, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// expenseTagProblems describes each way tags break the tag limits
+func expenseTagProblems(tags []string) []string {
+	var problems []string
+
+	if len(tags) > MaxExpenseTags {
+		problems = append(problems, fmt.Sprintf("at most %d tags are allowed", MaxExpenseTags))
+	}
+
+	for _, tag := range tags {
+		if problem := expenseTagProblem(tag); problem != "" {
+			problems = append(problems, fmt.Sprintf("tag %q %s", tag, problem))
+		}
+	}
+
+	return problems
+}
+
+// expenseTagProblem describes why a single tag is malformed, or returns "" if it isn't.
+// Tags are limited to letters, digits, spaces, hyphens and underscores, which also keeps them
+// free of the characters JSON would escape when they are stored.
+func expenseTagProblem(tag string) string {
+	if tag == "" {
+		return "must not be empty"
+	}
+	if utf8.RuneCountInString(tag) > MaxExpenseTagLength {
+		return fmt.Sprintf("must be at most %d characters", MaxExpenseTagLength)
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "may only contain letters, digits, spaces, hyphens and underscores"
+		}
+	}
+	return ""
+}
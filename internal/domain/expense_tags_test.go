@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeExpenseTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		expected []string
+	}{
+		{name: "no tags", tags: nil, expected: nil},
+		{name: "already normalized", tags: []string{"vacation", "travel"}, expected: []string{"vacation", "travel"}},
+		{name: "lowercased and trimmed", tags: []string{"  Vacation ", "TRAVEL"}, expected: []string{"vacation", "travel"}},
+		{name: "inner whitespace collapsed", tags: []string{"summer \t  Vacation"}, expected: []string{"summer vacation"}},
+		{name: "duplicates dropped in first-seen order", tags: []string{"travel", "Vacation", "travel ", "VACATION"}, expected: []string{"travel", "vacation"}},
+		{name: "blank tags dropped", tags: []string{"", "   ", "\n"}, expected: nil},
+		{name: "non-ASCII letters kept", tags: []string{"Café", "ÉTÉ"}, expected: []string{"café", "été"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NormalizeExpenseTags(tt.tags))
+		})
+	}
+}
+
+func TestValidateExpenseTags(t *testing.T) {
+	tooMany := make([]string, MaxExpenseTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	tests := []struct {
+		name          string
+		tags          []string
+		expectedError string
+	}{
+		{name: "no tags", tags: nil},
+		{name: "letters, digits, spaces, hyphens and underscores", tags: []string{"trip-2025", "summer vacation", "work_travel"}},
+		{name: "longest tag", tags: []string{strings.Repeat("é", MaxExpenseTagLength)}},
+		{name: "most tags", tags: tooMany[:MaxExpenseTags]},
+		{name: "too many tags", tags: tooMany, expectedError: "at most 10 tags are allowed"},
+		{name: "tag too long", tags: []string{strings.Repeat("a", MaxExpenseTagLength+1)}, expectedError: "must be at most 32 characters"},
+		{name: "punctuation", tags: []string{"rent", `"quoted"`}, expectedError: `tag "\"quoted\"" may only contain letters, digits, spaces, hyphens and underscores`},
+		{name: "empty tag", tags: []string{""}, expectedError: `tag "" must not be empty`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExpenseTags(tt.tags)
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidExpenseTags)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestExpense_Validate_Tags(t *testing.T) {
+	expense := Expense{
+		UserID: "user-1", Name: "Flights", Category: CategoryTransport, Amount: 400,
+		Frequency: ExpenseFrequencyMonthly, Priority: PriorityNiceToHave,
+		Tags: []string{"vacation"}, CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	assert.NoError(t, expense.Validate())
+
+	expense.Tags = []string{"vacation!"}
+	err := expense.Validate()
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "may only contain letters")
+}
@@ -375,9 +375,9 @@ func TestExpense_NormalizeToMonthly_WeeklyFrequency_ReturnsMonthlyEquivalent(t *
 		weeklyAmount   float64
 		expectedMonthly float64
 	}{
-		{"groceries", 150.0, 650.00}, // 150 * 52 / 12 = 650
-		{"gas", 75.50, 327.17},
-		{"dining_out", 100.0, 433.33},
+		{"groceries", 150.0, 649.50}, // 150 * 4.33 = 649.50
+		{"gas", 75.50, 326.92},
+		{"dining_out", 100.0, 433.00},
 	}
 
 	for _, tt := range tests {
@@ -403,9 +403,9 @@ func TestExpense_NormalizeToMonthly_DailyFrequency_ReturnsMonthlyEquivalent(t *t
 		dailyAmount    float64
 		expectedMonthly float64
 	}{
-		{"coffee", 5.0, 152.1875},    // 5 * 365.25 / 12
-		{"parking", 10.0, 304.375}, // 10 * 365.25 / 12
-		{"lunch", 12.50, 380.46875},  // 12.50 * 365.25 / 12
+		{"coffee", 5.0, 150.0},    // 5 * 30
+		{"parking", 10.0, 300.0}, // 10 * 30
+		{"lunch", 12.50, 375.0},  // 12.50 * 30
 	}
 
 	for _, tt := range tests {
@@ -448,7 +448,7 @@ func TestExpense_NormalizeToMonthly_EdgeCases_HandlesCorrectly(t *testing.T) {
 		{"zero_amount_monthly", 0.0, "monthly", 0.0},
 		{"zero_amount_weekly", 0.0, "weekly", 0.0},
 		{"very_small_amount", 0.01, "daily", 0.30},
-		{"very_large_amount", 10000.0, "weekly", 43333.33},
+		{"very_large_amount", 10000.0, "weekly", 43300.00},
 		{"empty_frequency", 1000.0, "", 0.0},
 	}
 
@@ -522,4 +522,69 @@ func TestExpense_GetPriorityName_ReturnsCorrectPriorityNames(t *testing.T) {
 			assert.Equal(t, tt.expected, priorityName)
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestExpense_Validate_Installments(t *testing.T) {
+	valid := Expense{
+		UserID:            "user-123",
+		Category:          "other",
+		Name:              "Laptop",
+		Amount:            1200.00,
+		Frequency:         "monthly",
+		Priority:          2,
+		InstallmentsTotal: 12,
+		InstallmentsPaid:  3,
+		InstallmentStart:  time.Now(),
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name    string
+		modify  func(e *Expense)
+		message string
+	}{
+		{"too_few", func(e *Expense) { e.InstallmentsTotal = 1; e.InstallmentsPaid = 0 }, "installments must be between 2 and 60"},
+		{"too_many", func(e *Expense) { e.InstallmentsTotal = 61 }, "installments must be between 2 and 60"},
+		{"weekly", func(e *Expense) { e.Frequency = "weekly" }, "installment expenses must have monthly frequency"},
+		{"no_start", func(e *Expense) { e.InstallmentStart = time.Time{} }, "installment start date is required"},
+		{"overpaid", func(e *Expense) { e.InstallmentsPaid = 13 }, "installments paid cannot exceed total installments"},
+		{"paid_without_plan", func(e *Expense) { e.InstallmentsTotal = 0 }, "installments paid cannot exceed total installments"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expense := valid
+			tt.modify(&expense)
+
+			err := expense.Validate()
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestExpense_Installments_CountOnlyWhileRemaining(t *testing.T) {
+	expense := Expense{
+		Amount:            1200.00,
+		Frequency:         "monthly",
+		InstallmentsTotal: 12,
+		InstallmentsPaid:  11,
+		InstallmentStart:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	assert.Equal(t, 100.0, expense.NormalizeToMonthly())
+	assert.Equal(t, 1, expense.RemainingInstallments())
+	assert.True(t, expense.IsActive())
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), expense.FinalInstallmentDate())
+
+	expense.InstallmentsPaid = 12
+	assert.Equal(t, 0.0, expense.NormalizeToMonthly())
+	assert.False(t, expense.IsActive())
+
+	regular := Expense{Amount: 50.00, Frequency: "monthly"}
+	assert.True(t, regular.IsActive())
+	assert.True(t, regular.FinalInstallmentDate().IsZero())
+}
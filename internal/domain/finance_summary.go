@@ -9,6 +9,8 @@ import (
 // FinanceSummary represents an aggregated view of a user's financial situation
 type FinanceSummary struct {
 	UserID              string
+	// Currency is the ISO 4217 code every amount in the summary is expressed in
+	Currency            string
 	MonthlyIncome       float64
 	MonthlyExpenses     float64
 	MonthlyLoanPayments float64
@@ -17,7 +19,28 @@ type FinanceSummary struct {
 	SavingsRate         float64
 	FinancialHealth     string
 	BudgetRemaining     float64
-	UpdatedAt           time.Time
+	// GoalsMonthlyCommitment is the total monthly contribution the user's savings goals need
+	GoalsMonthlyCommitment float64
+	GoalProjections        []GoalProjection
+	// Installments lists the installment expenses still being paid off and when each drops
+	// out of MonthlyExpenses
+	Installments []InstallmentSchedule
+	// Budgets compares the month's spending in each budgeted category with its budget
+	Budgets []BudgetStatus
+	// OverspentCategories are the most overspent budgets, at most MaxOverspentCategories
+	OverspentCategories []BudgetStatus
+	// HealthScore is the 0-100 score FinancialHealth is derived from, and HealthComponents the
+	// components it is built from; see ScoreHealthWith
+	HealthScore      float64
+	HealthComponents []HealthScoreComponent
+	// SavingsBalance is the total saved toward savings goals, or nil if the user tracks none
+	SavingsBalance *float64
+	// LoanPrincipal is the total borrowed on the user's loans and LoanBalance what is still owed
+	LoanPrincipal float64
+	LoanBalance   float64
+	// UpdatedAt is when the newest record behind the summary was last changed, so recomputing the
+	// summary of unchanged data yields the same summary; it is zero when the user has no records
+	UpdatedAt time.Time
 }
 
 // Financial health constants
@@ -48,36 +71,13 @@ var ValidHealthValues = []string{
 
 // CalculateHealth calculates and returns the financial health rating based on various metrics
 func (fs *FinanceSummary) CalculateHealth() string {
-	// Poor health conditions (highest priority)
-	if fs.DebtToIncomeRatio > PoorDebtToIncomeRatio {
-		return HealthPoor
-	}
-	
-	if fs.DisposableIncome < 0 {
-		return HealthPoor // Overspending is poor
-	}
-
-	// Special case: zero income scenario should be poor
-	if fs.MonthlyIncome == 0 && fs.DebtToIncomeRatio == 0 && fs.SavingsRate == 0 && fs.DisposableIncome == 0 {
-		return HealthPoor
-	}
-
-	// Fair health conditions
-	if fs.DebtToIncomeRatio > HealthyDebtToIncomeRatio {
-		return HealthFair
-	}
-	
-	if fs.SavingsRate < FairSavingsRate {
-		return HealthFair // Low savings rate
-	}
-
-	// Excellent health conditions
-	if fs.DebtToIncomeRatio <= ExcellentDebtToIncomeRatio && fs.SavingsRate >= MinimumSavingsRate {
-		return HealthExcellent
-	}
+	return fs.CalculateHealthWith(DefaultFinancialThresholds())
+}
 
-	// Default to good health
-	return HealthGood
+// CalculateHealthWith rates the financial health using the given thresholds
+// The rating is the label of the health score; see ScoreHealthWith
+func (fs *FinanceSummary) CalculateHealthWith(t FinancialThresholds) string {
+	return fs.ScoreHealthWith(t).Label
 }
 
 // GetHealthScore returns a numerical score for the financial health (4=Excellent, 3=Good, 2=Fair, 1=Poor, 0=Unknown)
@@ -99,25 +99,17 @@ func (fs *FinanceSummary) GetHealthScore() int {
 // CalculateAffordability returns the maximum amount the user can afford for a purchase
 // based on their financial situation
 func (fs *FinanceSummary) CalculateAffordability() float64 {
+	return fs.CalculateAffordabilityWith(DefaultFinancialThresholds())
+}
+
+// CalculateAffordabilityWith returns the maximum affordable purchase: disposable income scaled
+// by the multiplier for the summary's debt-to-income band
+func (fs *FinanceSummary) CalculateAffordabilityWith(t FinancialThresholds) float64 {
 	if fs.DisposableIncome <= 0 {
 		return 0.0 // No affordability if overspending
 	}
 
-	// Base affordability on debt-to-income ratio and disposable income
-	switch {
-	case fs.DebtToIncomeRatio <= ExcellentDebtToIncomeRatio:
-		// Excellent DTI: 3x disposable income
-		return fs.DisposableIncome * 3.0
-	case fs.DebtToIncomeRatio <= HealthyDebtToIncomeRatio:
-		// Good DTI: 3x disposable income
-		return fs.DisposableIncome * 3.0
-	case fs.DebtToIncomeRatio <= PoorDebtToIncomeRatio:
-		// Fair DTI: 2x disposable income
-		return fs.DisposableIncome * 2.0
-	default:
-		// High DTI: 0.5x disposable income (conservative)
-		return fs.DisposableIncome * 0.5
-	}
+	return fs.DisposableIncome * t.AffordabilityMultiplier(fs.DebtToIncomeRatio)
 }
 
 // GetBudgetStatus returns a string describing the budget status
@@ -250,6 +242,35 @@ func (fs *FinanceSummary) GetSavingsRateLevel() string {
 	}
 }
 
+// GoalsAchievable returns true if every savings goal is projected to reach its target on time
+func (fs *FinanceSummary) GoalsAchievable() bool {
+	for _, projection := range fs.GoalProjections {
+		if !projection.IsAchievable() {
+			return false
+		}
+	}
+	return true
+}
+
+// GoalShortfallWarnings describes each savings goal that won't reach its target on time
+func (fs *FinanceSummary) GoalShortfallWarnings() []string {
+	var warnings []string
+	for _, projection := range fs.GoalProjections {
+		if projection.IsAchievable() {
+			continue
+		}
+		if projection.MonthsRemaining == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s: target date %s has passed with %.2f still to save",
+				projection.Name, projection.TargetDate.Format("2006-01-02"), projection.Shortfall))
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("%s: projected to fall %.2f short by %s; it needs %.2f a month but only %.2f is available",
+			projection.Name, projection.Shortfall, projection.TargetDate.Format("2006-01-02"),
+			projection.RequiredMonthly, projection.AllocatedMonthly))
+	}
+	return warnings
+}
+
 // isValidHealthValue checks if the provided health value is valid
 func isValidHealthValue(health string) bool {
 	for _, validHealth := range ValidHealthValues {
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// FinancialHealthExplanation is a financial health tier together with the metrics behind it
+// and plain-language reasons for each. Amounts are monthly and in Currency.
+type FinancialHealthExplanation struct {
+	UserID            string
+	Currency          string
+	Tier              string
+	DebtToIncomeRatio float64
+	SavingsRate       float64
+	DisposableIncome  float64
+	Reasons           []string
+}
+
+// ExplainHealthWith rates the financial health under t, as CalculateHealthWith does, and
+// describes how the debt-to-income ratio, disposable income and savings rate each measure up
+// against the thresholds. A summary without income gets a single reason saying so.
+func (fs *FinanceSummary) ExplainHealthWith(t FinancialThresholds) FinancialHealthExplanation {
+	explanation := FinancialHealthExplanation{
+		UserID:            fs.UserID,
+		Currency:          fs.Currency,
+		Tier:              fs.CalculateHealthWith(t),
+		DebtToIncomeRatio: fs.DebtToIncomeRatio,
+		SavingsRate:       fs.SavingsRate,
+		DisposableIncome:  roundToCents(fs.DisposableIncome),
+	}
+
+	if fs.MonthlyIncome <= 0 {
+		explanation.Reasons = []string{"no income is recorded, so debt and savings can't be weighed against it"}
+		return explanation
+	}
+
+	explanation.Reasons = []string{
+		fs.debtToIncomeReason(t),
+		fs.disposableIncomeReason(),
+		fs.savingsRateReason(t),
+	}
+	return explanation
+}
+
+// debtToIncomeReason describes the debt-to-income ratio against the thresholds
+func (fs *FinanceSummary) debtToIncomeReason(t FinancialThresholds) string {
+	dti := formatPercent(fs.DebtToIncomeRatio)
+	switch {
+	case fs.DebtToIncomeRatio > t.PoorDTI:
+		return fmt.Sprintf("DTI of %s is high; above %s is poor", dti, formatPercent(t.PoorDTI))
+	case fs.DebtToIncomeRatio > t.HealthyDTI:
+		return fmt.Sprintf("DTI of %s is above the healthy %s", dti, formatPercent(t.HealthyDTI))
+	case fs.DebtToIncomeRatio > t.ExcellentDTI:
+		return fmt.Sprintf("DTI of %s is healthy but above the %s needed for Excellent", dti, formatPercent(t.ExcellentDTI))
+	default:
+		return fmt.Sprintf("DTI of %s is healthy", dti)
+	}
+}
+
+// disposableIncomeReason describes what is left each month, or by how much the user overspends
+func (fs *FinanceSummary) disposableIncomeReason() string {
+	if fs.DisposableIncome < 0 {
+		return fmt.Sprintf("you are overspending: expenses and loan payments exceed income by %.2f %s a month",
+			roundToCents(-fs.DisposableIncome), fs.Currency)
+	}
+	return fmt.Sprintf("disposable income of %.2f %s a month", roundToCents(fs.DisposableIncome), fs.Currency)
+}
+
+// savingsRateReason describes the savings rate against the thresholds
+func (fs *FinanceSummary) savingsRateReason(t FinancialThresholds) string {
+	rate := formatPercent(fs.SavingsRate)
+	switch {
+	case fs.SavingsRate >= t.ExcellentSavingsRate:
+		return fmt.Sprintf("savings rate of %s is high, at or above the %s target", rate, formatPercent(t.ExcellentSavingsRate))
+	case fs.SavingsRate >= t.FairSavingsRate:
+		return fmt.Sprintf("savings rate of %s is below the %s target", rate, formatPercent(t.ExcellentSavingsRate))
+	default:
+		return fmt.Sprintf("savings rate of %s is below the %s minimum", rate, formatPercent(t.FairSavingsRate))
+	}
+}
+
+// formatPercent formats a ratio as a whole percentage, e.g. 0.2 as "20%"
+func formatPercent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", math.Round(ratio*100))
+}
@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFinanceSummary_ExplainHealthWith_Excellent(t *testing.T) {
+	summary := &FinanceSummary{
+		UserID:              "user-1",
+		Currency:            "USD",
+		MonthlyIncome:       5000,
+		MonthlyExpenses:     2500,
+		MonthlyLoanPayments: 1000,
+		DisposableIncome:    1500,
+		DebtToIncomeRatio:   0.2,
+		SavingsRate:         0.3,
+	}
+
+	explanation := summary.ExplainHealthWith(DefaultFinancialThresholds())
+
+	assert.Equal(t, HealthExcellent, explanation.Tier)
+	assert.Equal(t, "user-1", explanation.UserID)
+	assert.Equal(t, "USD", explanation.Currency)
+	assert.Equal(t, 0.2, explanation.DebtToIncomeRatio)
+	assert.Equal(t, 0.3, explanation.SavingsRate)
+	assert.Equal(t, 1500.0, explanation.DisposableIncome)
+	assert.Equal(t, []string{
+		"DTI of 20% is healthy",
+		"disposable income of 1500.00 USD a month",
+		"savings rate of 30% is high, at or above the 20% target",
+	}, explanation.Reasons)
+}
+
+func TestFinanceSummary_ExplainHealthWith_Poor(t *testing.T) {
+	tests := []struct {
+		name    string
+		summary FinanceSummary
+		reason  string
+	}{
+		{
+			name: "overspending",
+			summary: FinanceSummary{
+				Currency: "EUR", MonthlyIncome: 3000, MonthlyExpenses: 3200, MonthlyLoanPayments: 300,
+				DisposableIncome: -500, DebtToIncomeRatio: 0.1, SavingsRate: -500.0 / 3000.0,
+			},
+			reason: "you are overspending: expenses and loan payments exceed income by 500.00 EUR a month",
+		},
+		{
+			name: "high_dti",
+			summary: FinanceSummary{
+				Currency: "EUR", MonthlyIncome: 4000, MonthlyExpenses: 1000, MonthlyLoanPayments: 2400,
+				DisposableIncome: 600, DebtToIncomeRatio: 0.6, SavingsRate: 0.15,
+			},
+			reason: "DTI of 60% is high; above 50% is poor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explanation := tt.summary.ExplainHealthWith(DefaultFinancialThresholds())
+
+			assert.Equal(t, HealthPoor, explanation.Tier)
+			assert.Contains(t, explanation.Reasons, tt.reason)
+		})
+	}
+}
+
+func TestFinanceSummary_ExplainHealthWith_BelowTargets(t *testing.T) {
+	summary := &FinanceSummary{
+		Currency:            "USD",
+		MonthlyIncome:       5000,
+		MonthlyExpenses:     2750,
+		MonthlyLoanPayments: 1500,
+		DisposableIncome:    750,
+		DebtToIncomeRatio:   0.3,
+		SavingsRate:         0.08,
+	}
+
+	explanation := summary.ExplainHealthWith(DefaultFinancialThresholds())
+
+	assert.Equal(t, HealthFair, explanation.Tier)
+	assert.Equal(t, []string{
+		"DTI of 30% is healthy but above the 28% needed for Excellent",
+		"disposable income of 750.00 USD a month",
+		"savings rate of 8% is below the 10% minimum",
+	}, explanation.Reasons)
+}
+
+func TestFinanceSummary_ExplainHealthWith_NoIncome(t *testing.T) {
+	summary := &FinanceSummary{UserID: "user-1", Currency: "USD"}
+
+	explanation := summary.ExplainHealthWith(DefaultFinancialThresholds())
+
+	assert.Equal(t, HealthPoor, explanation.Tier)
+	require.Len(t, explanation.Reasons, 1)
+	assert.Contains(t, explanation.Reasons[0], "no income")
+}
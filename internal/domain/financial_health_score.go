@@ -0,0 +1,194 @@
+package domain
+
+import "math"
+
+// Financial health score components
+const (
+	HealthComponentDebtToIncome  = "debt_to_income"
+	HealthComponentSavingsRate   = "savings_rate"
+	HealthComponentExpenseRatio  = "expense_ratio"
+	HealthComponentEmergencyFund = "emergency_fund"
+	HealthComponentDebtTrend     = "debt_trend"
+)
+
+// HealthScoreWeights sets how much each component counts toward the financial health score.
+// Weights are relative: components without data are left out and the rest rescaled to add up to 1.
+type HealthScoreWeights struct {
+	DebtToIncome  float64
+	SavingsRate   float64
+	ExpenseRatio  float64
+	EmergencyFund float64
+	DebtTrend     float64
+}
+
+// HealthScoreComponent is one metric's part in the financial health score
+type HealthScoreComponent struct {
+	Name string
+	// Value is the metric itself: a fraction of income for the debt-to-income ratio, savings rate
+	// and expense ratio, months of outgoings for the emergency fund, and the share of borrowed
+	// principal already repaid for the debt trend
+	Value float64
+	// Score rates Value from 0 to 100
+	Score float64
+	// Weight is the component's share of the health score
+	Weight float64
+	// Contribution is Score times Weight; the contributions add up to the health score
+	Contribution float64
+}
+
+// FinancialHealthScore is a 0-100 composite of the health score components and the label it earns
+type FinancialHealthScore struct {
+	Score      float64
+	Label      string
+	Components []HealthScoreComponent
+}
+
+// scorePoint pins the score of one metric value; scores between points are interpolated
+type scorePoint struct {
+	value, score float64
+}
+
+// ScoreHealthWith rates the summary's financial health from 0 to 100 under t.
+//
+// Each component is scored from 0 to 100 so that a metric exactly at one of t's thresholds
+// scores that threshold's label boundary: a debt-to-income ratio of t.ExcellentDTI scores
+// t.ExcellentScore, one of t.HealthyDTI t.GoodScore and one of t.PoorDTI t.FairScore.
+// The savings rate, expense ratio, emergency fund and debt trend are scored the same way
+// against their own thresholds. The expense ratio is left out without income, and the
+// emergency fund when the user tracks no savings goals.
+//
+// The label is the one the score earns, capped by hard limits: the summary is Poor when it
+// overspends, has no income or a debt-to-income ratio above t.PoorDTI; at best Fair above
+// t.HealthyDTI or below t.FairSavingsRate; and at best Good unless the debt-to-income ratio is
+// at most t.ExcellentDTI and the savings rate at least t.ExcellentSavingsRate.
+func (fs *FinanceSummary) ScoreHealthWith(t FinancialThresholds) FinancialHealthScore {
+	type rated struct {
+		name          string
+		value, weight float64
+		points        []scorePoint
+	}
+	components := []rated{
+		{HealthComponentDebtToIncome, fs.DebtToIncomeRatio, t.ScoreWeights.DebtToIncome, []scorePoint{
+			{0, 100}, {t.ExcellentDTI, t.ExcellentScore}, {t.HealthyDTI, t.GoodScore}, {t.PoorDTI, t.FairScore}, {1, 0},
+		}},
+		{HealthComponentSavingsRate, fs.SavingsRate, t.ScoreWeights.SavingsRate, []scorePoint{
+			{-1, 0}, {0, t.FairScore}, {t.FairSavingsRate, t.GoodScore}, {t.GoodSavingsRate, (t.GoodScore + t.ExcellentScore) / 2},
+			{t.ExcellentSavingsRate, t.ExcellentScore}, {math.Min(3*t.ExcellentSavingsRate, 1), 100},
+		}},
+	}
+	if fs.MonthlyIncome > 0 {
+		components = append(components, rated{HealthComponentExpenseRatio, fs.MonthlyExpenses / fs.MonthlyIncome, t.ScoreWeights.ExpenseRatio, []scorePoint{
+			{0, 100}, {t.ExcellentExpenseRatio, t.ExcellentScore}, {t.PoorExpenseRatio, t.FairScore}, {1, 0},
+		}})
+	}
+	if fs.SavingsBalance != nil {
+		components = append(components, rated{HealthComponentEmergencyFund, fs.emergencyFundMonths(), t.ScoreWeights.EmergencyFund, []scorePoint{
+			{0, 0}, {t.EmergencyFundMonths, t.ExcellentScore}, {2 * t.EmergencyFundMonths, 100},
+		}})
+	}
+	components = append(components, rated{HealthComponentDebtTrend, fs.debtRepaidShare(), t.ScoreWeights.DebtTrend, []scorePoint{
+		{0, 0}, {1, 100},
+	}})
+
+	totalWeight := 0.0
+	for _, component := range components {
+		totalWeight += component.weight
+	}
+
+	result := FinancialHealthScore{Components: make([]HealthScoreComponent, 0, len(components))}
+	for _, component := range components {
+		scored := HealthScoreComponent{
+			Name:  component.name,
+			Value: component.value,
+			Score: interpolateScore(component.value, component.points),
+		}
+		if totalWeight > 0 {
+			scored.Weight = component.weight / totalWeight
+		}
+		scored.Contribution = scored.Score * scored.Weight
+		result.Score += scored.Contribution
+		result.Components = append(result.Components, scored)
+	}
+	result.Score = math.Round(result.Score*10) / 10
+	result.Label = lowerHealth(t.healthLabel(result.Score), fs.healthCeiling(t))
+	return result
+}
+
+// emergencyFundMonths is how many months of expenses and loan payments the savings balance covers
+func (fs *FinanceSummary) emergencyFundMonths() float64 {
+	outgoings := fs.MonthlyExpenses + fs.MonthlyLoanPayments
+	if outgoings <= 0 {
+		return math.Inf(1)
+	}
+	return *fs.SavingsBalance / outgoings
+}
+
+// debtRepaidShare is the share of borrowed principal already repaid; without loans it is 1
+func (fs *FinanceSummary) debtRepaidShare() float64 {
+	if fs.LoanPrincipal <= 0 {
+		return 1
+	}
+	return math.Max(0, 1-fs.LoanBalance/fs.LoanPrincipal)
+}
+
+// healthCeiling is the best label the summary can have whatever its score
+func (fs *FinanceSummary) healthCeiling(t FinancialThresholds) string {
+	noIncome := fs.MonthlyIncome == 0 && fs.DebtToIncomeRatio == 0 && fs.SavingsRate == 0 && fs.DisposableIncome == 0
+	switch {
+	case fs.DebtToIncomeRatio > t.PoorDTI, fs.DisposableIncome < 0, noIncome:
+		return HealthPoor
+	case fs.DebtToIncomeRatio > t.HealthyDTI, fs.SavingsRate < t.FairSavingsRate:
+		return HealthFair
+	case fs.DebtToIncomeRatio > t.ExcellentDTI, fs.SavingsRate < t.ExcellentSavingsRate:
+		return HealthGood
+	default:
+		return HealthExcellent
+	}
+}
+
+// healthLabel is the label a score earns on its own
+func (t FinancialThresholds) healthLabel(score float64) string {
+	switch {
+	case score >= t.ExcellentScore:
+		return HealthExcellent
+	case score >= t.GoodScore:
+		return HealthGood
+	case score >= t.FairScore:
+		return HealthFair
+	default:
+		return HealthPoor
+	}
+}
+
+// lowerHealth returns the worse of two health labels
+func lowerHealth(a, b string) string {
+	if healthRank(a) < healthRank(b) {
+		return a
+	}
+	return b
+}
+
+// healthRank orders the health labels from Poor (1) to Excellent (4)
+func healthRank(label string) int {
+	summary := FinanceSummary{FinancialHealth: label}
+	return summary.GetHealthScore()
+}
+
+// interpolateScore scores value on the line through points, which are ordered by value.
+// Values outside the points get the score of the nearest end.
+func interpolateScore(value float64, points []scorePoint) float64 {
+	if value <= points[0].value {
+		return points[0].score
+	}
+	for i := 1; i < len(points); i++ {
+		previous, next := points[i-1], points[i]
+		if value > next.value {
+			continue
+		}
+		if next.value == previous.value {
+			return next.score
+		}
+		return previous.score + (next.score-previous.score)*(value-previous.value)/(next.value-previous.value)
+	}
+	return points[len(points)-1].score
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scoredSummary builds a summary from monthly amounts the way the finance service does
+func scoredSummary(income, expenses, loanPayments, loanPrincipal, loanBalance float64) *FinanceSummary {
+	summary := &FinanceSummary{
+		MonthlyIncome:       income,
+		MonthlyExpenses:     expenses,
+		MonthlyLoanPayments: loanPayments,
+		DisposableIncome:    income - expenses - loanPayments,
+		LoanPrincipal:       loanPrincipal,
+		LoanBalance:         loanBalance,
+	}
+	if income > 0 {
+		summary.DebtToIncomeRatio = loanPayments / income
+		summary.SavingsRate = summary.DisposableIncome / income
+	}
+	return summary
+}
+
+func TestFinanceSummary_ScoreHealthWith_ProfilesKeepTheirLabels(t *testing.T) {
+	// Profiles used by the finance flow integration tests, with the labels those tests expect
+	tests := []struct {
+		name    string
+		summary *FinanceSummary
+		want    string
+	}{
+		{"low debt high savings", scoredSummary(6400, 1850, 900, 350000, 320000), HealthExcellent},
+		{"moderate metrics", scoredSummary(10000, 3600, 2390, 450000, 420000), HealthGood},
+		{"first summary", scoredSummary(10000, 3225, 2066, 450000, 420000), HealthGood},
+		{"high debt to income", scoredSummary(10000, 3600, 4000, 50000, 45000), HealthFair},
+		{"overspending", scoredSummary(5000, 4500, 1000, 20000, 18000), HealthPoor},
+		{"50/30/20 budget without loans", scoredSummary(6000, 3100, 0, 0, 0), HealthExcellent},
+		{"recent graduate", scoredSummary(10000, 4595, 905, 35000, 33000), HealthGood},
+		{"mid-career", scoredSummary(10000, 4145, 1390, 520000, 480000), HealthGood},
+		{"young professional with high expenses", scoredSummary(4500, 3200, 650, 65000, 58000), HealthFair},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := tt.summary.ScoreHealthWith(DefaultFinancialThresholds())
+			assert.Equal(t, tt.want, score.Label, "score %.1f", score.Score)
+			assert.Equal(t, tt.want, tt.summary.CalculateHealth())
+		})
+	}
+}
+
+func TestFinanceSummary_ScoreHealthWith_ThresholdBoundaries(t *testing.T) {
+	// Without loans or expenses only the debt-to-income ratio and savings rate limit the label
+	tests := []struct {
+		name string
+		dti  float64
+		sr   float64
+		want string
+	}{
+		{"DTI at excellent cutoff", ExcellentDebtToIncomeRatio, MinimumSavingsRate, HealthExcellent},
+		{"DTI just above excellent cutoff", ExcellentDebtToIncomeRatio + 0.001, MinimumSavingsRate, HealthGood},
+		{"DTI at healthy cutoff", HealthyDebtToIncomeRatio, FairSavingsRate, HealthGood},
+		{"DTI just above healthy cutoff", HealthyDebtToIncomeRatio + 0.001, FairSavingsRate, HealthFair},
+		{"DTI at poor cutoff", PoorDebtToIncomeRatio, FairSavingsRate, HealthFair},
+		{"DTI just above poor cutoff", PoorDebtToIncomeRatio + 0.001, FairSavingsRate, HealthPoor},
+		{"savings rate just below excellent cutoff", 0.10, MinimumSavingsRate - 0.001, HealthGood},
+		{"savings rate at fair cutoff", 0.10, FairSavingsRate, HealthGood},
+		{"savings rate just below fair cutoff", 0.10, FairSavingsRate - 0.001, HealthFair},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			summary := &FinanceSummary{
+				MonthlyIncome:     10000,
+				DebtToIncomeRatio: tt.dti,
+				SavingsRate:       tt.sr,
+				DisposableIncome:  10000 * tt.sr,
+			}
+			assert.Equal(t, tt.want, summary.ScoreHealthWith(DefaultFinancialThresholds()).Label)
+		})
+	}
+}
+
+func TestFinanceSummary_ScoreHealthWith_ComponentsAtThresholdsScoreTheirBoundary(t *testing.T) {
+	thresholds := DefaultFinancialThresholds()
+	tests := []struct {
+		name      string
+		component string
+		summary   *FinanceSummary
+		want      float64
+	}{
+		{"DTI at excellent cutoff", HealthComponentDebtToIncome, &FinanceSummary{DebtToIncomeRatio: thresholds.ExcellentDTI}, thresholds.ExcellentScore},
+		{"DTI at healthy cutoff", HealthComponentDebtToIncome, &FinanceSummary{DebtToIncomeRatio: thresholds.HealthyDTI}, thresholds.GoodScore},
+		{"DTI at poor cutoff", HealthComponentDebtToIncome, &FinanceSummary{DebtToIncomeRatio: thresholds.PoorDTI}, thresholds.FairScore},
+		{"savings rate at excellent cutoff", HealthComponentSavingsRate, &FinanceSummary{SavingsRate: thresholds.ExcellentSavingsRate}, thresholds.ExcellentScore},
+		{"savings rate at fair cutoff", HealthComponentSavingsRate, &FinanceSummary{SavingsRate: thresholds.FairSavingsRate}, thresholds.GoodScore},
+		{"expense ratio at excellent cutoff", HealthComponentExpenseRatio, &FinanceSummary{MonthlyIncome: 1000, MonthlyExpenses: 400}, thresholds.ExcellentScore},
+		{"expense ratio at poor cutoff", HealthComponentExpenseRatio, &FinanceSummary{MonthlyIncome: 1000, MonthlyExpenses: 700}, thresholds.FairScore},
+		{"emergency fund at target", HealthComponentEmergencyFund, &FinanceSummary{MonthlyExpenses: 500, MonthlyLoanPayments: 500, SavingsBalance: floatPtr(6000)}, thresholds.ExcellentScore},
+		{"half the principal repaid", HealthComponentDebtTrend, &FinanceSummary{LoanPrincipal: 10000, LoanBalance: 5000}, 50},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := findHealthComponent(t, tt.summary.ScoreHealthWith(thresholds), tt.component)
+			assert.InDelta(t, tt.want, component.Score, 1e-9)
+		})
+	}
+}
+
+func TestFinanceSummary_ScoreHealthWith_ScoreBandsSetTheLabel(t *testing.T) {
+	thresholds := DefaultFinancialThresholds()
+	assert.Equal(t, HealthExcellent, thresholds.healthLabel(80))
+	assert.Equal(t, HealthGood, thresholds.healthLabel(79.9))
+	assert.Equal(t, HealthGood, thresholds.healthLabel(60))
+	assert.Equal(t, HealthFair, thresholds.healthLabel(59.9))
+	assert.Equal(t, HealthFair, thresholds.healthLabel(40))
+	assert.Equal(t, HealthPoor, thresholds.healthLabel(39.9))
+
+	// Healthy ratios still earn no better than the score: high expenses and new loans pull it down
+	summary := scoredSummary(10000, 7000, 1500, 100000, 100000)
+	score := summary.ScoreHealthWith(thresholds)
+	assert.Less(t, score.Score, thresholds.GoodScore)
+	assert.Equal(t, HealthFair, score.Label)
+}
+
+func TestFinanceSummary_ScoreHealthWith_ContributionsAddUpToScore(t *testing.T) {
+	summary := scoredSummary(8000, 3000, 1200, 60000, 30000)
+	summary.SavingsBalance = floatPtr(12000)
+
+	score := summary.ScoreHealthWith(DefaultFinancialThresholds())
+
+	require.Len(t, score.Components, 5)
+	names := make([]string, 0, len(score.Components))
+	totalWeight, totalContribution := 0.0, 0.0
+	for _, component := range score.Components {
+		names = append(names, component.Name)
+		totalWeight += component.Weight
+		totalContribution += component.Contribution
+		assert.InDelta(t, component.Score*component.Weight, component.Contribution, 1e-9)
+	}
+	assert.Equal(t, []string{
+		HealthComponentDebtToIncome, HealthComponentSavingsRate, HealthComponentExpenseRatio,
+		HealthComponentEmergencyFund, HealthComponentDebtTrend,
+	}, names)
+	assert.InDelta(t, 1, totalWeight, 1e-9)
+	assert.InDelta(t, totalContribution, score.Score, 0.05)
+
+	assert.InDelta(t, 0.15, findHealthComponent(t, score, HealthComponentDebtToIncome).Value, 1e-9)
+	assert.InDelta(t, 0.375, findHealthComponent(t, score, HealthComponentExpenseRatio).Value, 1e-9)
+	assert.InDelta(t, 12000.0/4200.0, findHealthComponent(t, score, HealthComponentEmergencyFund).Value, 1e-9)
+	assert.InDelta(t, 0.5, findHealthComponent(t, score, HealthComponentDebtTrend).Value, 1e-9)
+}
+
+func TestFinanceSummary_ScoreHealthWith_ComponentsWithoutDataAreLeftOut(t *testing.T) {
+	// No income leaves out the expense ratio, and no savings goals the emergency fund
+	summary := &FinanceSummary{MonthlyExpenses: 500, DisposableIncome: -500}
+
+	score := summary.ScoreHealthWith(DefaultFinancialThresholds())
+
+	require.Len(t, score.Components, 3)
+	assert.Equal(t, HealthComponentDebtToIncome, score.Components[0].Name)
+	assert.Equal(t, HealthComponentSavingsRate, score.Components[1].Name)
+	assert.Equal(t, HealthComponentDebtTrend, score.Components[2].Name)
+	assert.InDelta(t, 0.25/0.65, score.Components[0].Weight, 1e-9)
+	assert.InDelta(t, 0.30/0.65, score.Components[1].Weight, 1e-9)
+	assert.InDelta(t, 0.10/0.65, score.Components[2].Weight, 1e-9)
+	assert.Equal(t, HealthPoor, score.Label)
+}
+
+func TestFinanceSummary_ScoreHealthWith_CustomWeights(t *testing.T) {
+	summary := scoredSummary(10000, 7000, 1500, 100000, 100000)
+
+	thresholds := DefaultFinancialThresholds()
+	thresholds.ScoreWeights = HealthScoreWeights{DebtToIncome: 1}
+	score := summary.ScoreHealthWith(thresholds)
+
+	assert.InDelta(t, findHealthComponent(t, score, HealthComponentDebtToIncome).Score, score.Score, 0.05)
+	assert.Zero(t, findHealthComponent(t, score, HealthComponentSavingsRate).Weight)
+	assert.Equal(t, HealthGood, score.Label)
+}
+
+func findHealthComponent(t *testing.T, score FinancialHealthScore, name string) HealthScoreComponent {
+	t.Helper()
+	for _, component := range score.Components {
+		if component.Name == name {
+			return component
+		}
+	}
+	require.FailNow(t, "missing health score component", name)
+	return HealthScoreComponent{}
+}
@@ -0,0 +1,122 @@
+package domain
+
+import "fmt"
+
+// FinancialThresholds tunes how a finance summary is classified and how much it can afford.
+// DefaultFinancialThresholds returns the standard values.
+type FinancialThresholds struct {
+	// Debt-to-income bands: at most ExcellentDTI can be Excellent, above HealthyDTI is at best
+	// Fair, and above PoorDTI is Poor
+	ExcellentDTI float64
+	HealthyDTI   float64
+	PoorDTI      float64
+
+	// Savings rate cutoffs: ExcellentSavingsRate is needed for Excellent, below FairSavingsRate is
+	// at best Fair, and GoodSavingsRate marks spending as efficient in budget analysis
+	ExcellentSavingsRate float64
+	GoodSavingsRate      float64
+	FairSavingsRate      float64
+
+	// AffordabilityMultipliers scale disposable income into the largest affordable purchase
+	AffordabilityMultipliers AffordabilityMultipliers
+
+	// Health score: ScoreWeights combine the component scores, and a score of at least
+	// ExcellentScore, GoodScore or FairScore earns that label; see FinanceSummary.ScoreHealthWith
+	ScoreWeights   HealthScoreWeights
+	ExcellentScore float64
+	GoodScore      float64
+	FairScore      float64
+
+	// Expenses, before loan payments, as a share of income: at most ExcellentExpenseRatio scores
+	// as Excellent and PoorExpenseRatio as the Poor boundary
+	ExcellentExpenseRatio float64
+	PoorExpenseRatio      float64
+
+	// EmergencyFundMonths is how many months of expenses and loan payments savings should cover
+	EmergencyFundMonths float64
+}
+
+// AffordabilityMultipliers holds the disposable income multiplier for each debt-to-income band:
+// Excellent up to ExcellentDTI, Good up to HealthyDTI, Fair up to PoorDTI and Poor above it
+type AffordabilityMultipliers struct {
+	Excellent float64
+	Good      float64
+	Fair      float64
+	Poor      float64
+}
+
+// DefaultFinancialThresholds returns the standard thresholds
+func DefaultFinancialThresholds() FinancialThresholds {
+	return FinancialThresholds{
+		ExcellentDTI:         ExcellentDebtToIncomeRatio,
+		HealthyDTI:           HealthyDebtToIncomeRatio,
+		PoorDTI:              PoorDebtToIncomeRatio,
+		ExcellentSavingsRate: MinimumSavingsRate,
+		GoodSavingsRate:      GoodSavingsRate,
+		FairSavingsRate:      FairSavingsRate,
+		AffordabilityMultipliers: AffordabilityMultipliers{
+			Excellent: 3.0,
+			Good:      3.0,
+			Fair:      2.0,
+			Poor:      0.5, // Conservative for high debt
+		},
+		ScoreWeights: HealthScoreWeights{
+			DebtToIncome:  0.25,
+			SavingsRate:   0.30,
+			ExpenseRatio:  0.25,
+			EmergencyFund: 0.10,
+			DebtTrend:     0.10,
+		},
+		ExcellentScore:        80,
+		GoodScore:             60,
+		FairScore:             40,
+		ExcellentExpenseRatio: 0.40,
+		PoorExpenseRatio:      0.70,
+		EmergencyFundMonths:   6,
+	}
+}
+
+// Validate checks that the bands are ordered and every value is in range
+func (t FinancialThresholds) Validate() error {
+	if t.ExcellentDTI < 0 || t.ExcellentDTI > t.HealthyDTI || t.HealthyDTI > t.PoorDTI || t.PoorDTI > 1 {
+		return fmt.Errorf("%w: debt-to-income ratios must satisfy 0 <= excellent <= healthy <= poor <= 1", ErrInvalidFinancialThresholds)
+	}
+	if t.FairSavingsRate < 0 || t.FairSavingsRate > t.GoodSavingsRate || t.GoodSavingsRate > t.ExcellentSavingsRate || t.ExcellentSavingsRate > 1 {
+		return fmt.Errorf("%w: savings rates must satisfy 0 <= fair <= good <= excellent <= 1", ErrInvalidFinancialThresholds)
+	}
+	m := t.AffordabilityMultipliers
+	if m.Excellent < 0 || m.Good < 0 || m.Fair < 0 || m.Poor < 0 {
+		return fmt.Errorf("%w: affordability multipliers cannot be negative", ErrInvalidFinancialThresholds)
+	}
+	w := t.ScoreWeights
+	if w.DebtToIncome < 0 || w.SavingsRate < 0 || w.ExpenseRatio < 0 || w.EmergencyFund < 0 || w.DebtTrend < 0 {
+		return fmt.Errorf("%w: health score weights cannot be negative", ErrInvalidFinancialThresholds)
+	}
+	if w.DebtToIncome+w.SavingsRate+w.ExpenseRatio+w.EmergencyFund+w.DebtTrend == 0 {
+		return fmt.Errorf("%w: at least one health score weight must be positive", ErrInvalidFinancialThresholds)
+	}
+	if t.FairScore < 0 || t.FairScore > t.GoodScore || t.GoodScore > t.ExcellentScore || t.ExcellentScore > 100 {
+		return fmt.Errorf("%w: health scores must satisfy 0 <= fair <= good <= excellent <= 100", ErrInvalidFinancialThresholds)
+	}
+	if t.ExcellentExpenseRatio < 0 || t.ExcellentExpenseRatio > t.PoorExpenseRatio || t.PoorExpenseRatio > 1 {
+		return fmt.Errorf("%w: expense ratios must satisfy 0 <= excellent <= poor <= 1", ErrInvalidFinancialThresholds)
+	}
+	if t.EmergencyFundMonths <= 0 {
+		return fmt.Errorf("%w: emergency fund months must be greater than 0", ErrInvalidFinancialThresholds)
+	}
+	return nil
+}
+
+// AffordabilityMultiplier returns the multiplier for a debt-to-income ratio
+func (t FinancialThresholds) AffordabilityMultiplier(debtToIncomeRatio float64) float64 {
+	switch {
+	case debtToIncomeRatio <= t.ExcellentDTI:
+		return t.AffordabilityMultipliers.Excellent
+	case debtToIncomeRatio <= t.HealthyDTI:
+		return t.AffordabilityMultipliers.Good
+	case debtToIncomeRatio <= t.PoorDTI:
+		return t.AffordabilityMultipliers.Fair
+	default:
+		return t.AffordabilityMultipliers.Poor
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFinancialThresholds_Validate(t *testing.T) {
+	assert.NoError(t, DefaultFinancialThresholds().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*FinancialThresholds)
+	}{
+		{"DTI bands out of order", func(th *FinancialThresholds) { th.ExcellentDTI = 0.40 }},
+		{"poor DTI above 1", func(th *FinancialThresholds) { th.PoorDTI = 1.5 }},
+		{"savings rates out of order", func(th *FinancialThresholds) { th.FairSavingsRate = 0.30 }},
+		{"negative savings rate", func(th *FinancialThresholds) { th.FairSavingsRate = -0.1 }},
+		{"negative multiplier", func(th *FinancialThresholds) { th.AffordabilityMultipliers.Poor = -1 }},
+		{"negative score weight", func(th *FinancialThresholds) { th.ScoreWeights.DebtTrend = -0.1 }},
+		{"no score weights", func(th *FinancialThresholds) { th.ScoreWeights = HealthScoreWeights{} }},
+		{"score bands out of order", func(th *FinancialThresholds) { th.FairScore = 70 }},
+		{"excellent score above 100", func(th *FinancialThresholds) { th.ExcellentScore = 120 }},
+		{"expense ratios out of order", func(th *FinancialThresholds) { th.ExcellentExpenseRatio = 0.80 }},
+		{"no emergency fund months", func(th *FinancialThresholds) { th.EmergencyFundMonths = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thresholds := DefaultFinancialThresholds()
+			tt.modify(&thresholds)
+			assert.True(t, errors.Is(thresholds.Validate(), ErrInvalidFinancialThresholds))
+		})
+	}
+}
+
+func TestFinanceSummary_CalculateHealthWith_CustomThresholds(t *testing.T) {
+	// A 0.30 DTI with a 0.18 savings rate is Good by default
+	summary := FinanceSummary{MonthlyIncome: 5000, DebtToIncomeRatio: 0.30, SavingsRate: 0.18, DisposableIncome: 900}
+	assert.Equal(t, HealthGood, summary.CalculateHealth())
+
+	lenient := DefaultFinancialThresholds()
+	lenient.ExcellentDTI = 0.32
+	lenient.ExcellentSavingsRate = 0.15
+	assert.Equal(t, HealthExcellent, summary.CalculateHealthWith(lenient))
+
+	strict := DefaultFinancialThresholds()
+	strict.ExcellentDTI = 0.20
+	strict.HealthyDTI = 0.25
+	assert.Equal(t, HealthFair, summary.CalculateHealthWith(strict))
+}
+
+func TestFinanceSummary_CalculateAffordabilityWith_CustomMultipliers(t *testing.T) {
+	summary := FinanceSummary{DebtToIncomeRatio: 0.40, DisposableIncome: 1000}
+	assert.Equal(t, 2000.0, summary.CalculateAffordability())
+
+	thresholds := DefaultFinancialThresholds()
+	thresholds.AffordabilityMultipliers.Fair = 1.5
+	assert.Equal(t, 1500.0, summary.CalculateAffordabilityWith(thresholds))
+
+	// Raising the poor band cutoff moves a 0.55 DTI from Poor to Fair
+	summary.DebtToIncomeRatio = 0.55
+	assert.Equal(t, 500.0, summary.CalculateAffordability())
+	thresholds.PoorDTI = 0.60
+	assert.Equal(t, 1500.0, summary.CalculateAffordabilityWith(thresholds))
+}
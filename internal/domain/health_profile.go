@@ -6,10 +6,21 @@ import (
 	"time"
 )
 
+// Relation to owner constants. Each account has one self profile and any number of dependents.
+const (
+	RelationSelf   = "self"
+	RelationSpouse = "spouse"
+	RelationChild  = "child"
+	RelationParent = "parent"
+	RelationOther  = "other"
+)
+
 // HealthProfile represents a user's health profile with BMI calculation and risk assessment
 type HealthProfile struct {
 	ID                   string    `json:"id"`
-	UserID               string    `json:"user_id"`
+	UserID               string    `json:"user_id"`           // owning account
+	Name                 string    `json:"name"`              // display name, required for dependents
+	RelationToOwner      string    `json:"relation_to_owner"` // "self", "spouse", "child", "parent", "other"
 	Age                  int       `json:"age"`
 	Gender               string    `json:"gender"`                 // "male", "female", "other"
 	Height               float64   `json:"height"`                 // in cm
@@ -84,9 +95,25 @@ func (h *HealthProfile) Validate() error {
 		return fmt.Errorf("family size must be at least 1")
 	}
 
+	switch h.RelationToOwner {
+	case "", RelationSelf:
+	case RelationSpouse, RelationChild, RelationParent, RelationOther:
+		if h.Name == "" {
+			return fmt.Errorf("name is required for dependent profiles")
+		}
+	default:
+		return fmt.Errorf("relation to owner must be one of: self, spouse, child, parent, other")
+	}
+
 	return nil
 }
 
+// IsSelf reports whether this is the account owner's own profile.
+// Profiles created before dependents were supported have no relation and are treated as self.
+func (h *HealthProfile) IsSelf() bool {
+	return h.RelationToOwner == "" || h.RelationToOwner == RelationSelf
+}
+
 // HasHighRisk determines if the person has high health risk
 // Factors: Age >= 65, BMI < 18.5 or BMI >= 30, or has chronic conditions
 func (h *HealthProfile) HasHighRisk() bool {
@@ -325,6 +325,52 @@ func TestHealthProfile_Validate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "user ID is required",
 		},
+		{
+			name: "named_dependent_valid",
+			profile: HealthProfile{
+				ID:              "profile-17",
+				UserID:          "user-17",
+				Name:            "Sam",
+				RelationToOwner: RelationChild,
+				Age:             8,
+				Gender:          "male",
+				Height:          128.0,
+				Weight:          26.0,
+				FamilySize:      1,
+			},
+			expectError: false,
+		},
+		{
+			name: "dependent_without_name_invalid",
+			profile: HealthProfile{
+				ID:              "profile-18",
+				UserID:          "user-18",
+				RelationToOwner: RelationSpouse,
+				Age:             40,
+				Gender:          "female",
+				Height:          165.0,
+				Weight:          60.0,
+				FamilySize:      1,
+			},
+			expectError: true,
+			errorMsg:    "name is required for dependent profiles",
+		},
+		{
+			name: "unknown_relation_invalid",
+			profile: HealthProfile{
+				ID:              "profile-19",
+				UserID:          "user-19",
+				Name:            "Pat",
+				RelationToOwner: "cousin",
+				Age:             30,
+				Gender:          "other",
+				Height:          170.0,
+				Weight:          65.0,
+				FamilySize:      1,
+			},
+			expectError: true,
+			errorMsg:    "relation to owner must be one of",
+		},
 	}
 
 	for _, tt := range tests {
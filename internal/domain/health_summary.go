@@ -4,19 +4,31 @@ import "time"
 
 // HealthSummary represents aggregated health and financial data
 type HealthSummary struct {
-	UserID                    string    `json:"user_id"`
-	HealthRiskScore           int       `json:"health_risk_score"`           // 0-100 (0=excellent, 100=critical)
-	HealthRiskLevel           string    `json:"health_risk_level"`           // "low", "moderate", "high", "critical"
-	MonthlyMedicalExpenses    float64   `json:"monthly_medical_expenses"`
-	MonthlyInsurancePremiums  float64   `json:"monthly_insurance_premiums"`
-	AnnualDeductibleRemaining float64   `json:"annual_deductible_remaining"`
-	OutOfPocketRemaining      float64   `json:"out_of_pocket_remaining"`
-	TotalHealthCosts          float64   `json:"total_health_costs"`          // premiums + out-of-pocket
-	CoverageGapRisk           float64   `json:"coverage_gap_risk"`           // uncovered potential expenses
-	RecommendedEmergencyFund  float64   `json:"recommended_emergency_fund"`  // based on health risks
-	FinancialVulnerability    string    `json:"financial_vulnerability"`     // "secure", "moderate", "vulnerable", "critical"
-	PriorityAdjustment        float64   `json:"priority_adjustment"`         // multiplier for purchase decisions
-	UpdatedAt                 time.Time `json:"updated_at"`
+	UserID                    string                    `json:"user_id"`
+	HealthRiskScore           int                       `json:"health_risk_score"` // 0-100 (0=excellent, 100=critical)
+	HealthRiskLevel           string                    `json:"health_risk_level"` // "low", "moderate", "high", "critical"
+	MonthlyMedicalExpenses    float64                   `json:"monthly_medical_expenses"`
+	MonthlyInsurancePremiums  float64                   `json:"monthly_insurance_premiums"`
+	AnnualDeductibleRemaining float64                   `json:"annual_deductible_remaining"`
+	OutOfPocketRemaining      float64                   `json:"out_of_pocket_remaining"`
+	TotalHealthCosts          float64                   `json:"total_health_costs"`           // premiums + out-of-pocket
+	CoverageGapRisk           float64                   `json:"coverage_gap_risk"`            // uncovered potential expenses
+	RecommendedEmergencyFund  float64                   `json:"recommended_emergency_fund"`   // based on health risks
+	EmergencyFundBalance      float64                   `json:"emergency_fund_balance"`       // health emergency fund recorded on the profile
+	FinancialVulnerability    string                    `json:"financial_vulnerability"`      // "secure", "moderate", "vulnerable", "critical"
+	PriorityAdjustment        float64                   `json:"priority_adjustment"`          // multiplier for purchase decisions
+	MemberExpenses            []MemberMedicalExpenses   `json:"member_expenses"`              // monthly medical expenses per family member, owner first
+	OutOfPocketStatuses       []OutOfPocketStatus       `json:"out_of_pocket_statuses"`       // one per active policy
+	RecurringVariance         *RecurringExpenseVariance `json:"recurring_variance,omitempty"` // recurring expenses' recorded vs projected cost this year; nil unless occurrences are enabled
+	UpdatedAt                 time.Time                 `json:"updated_at"`
+}
+
+// MemberMedicalExpenses is one family member's share of the monthly medical expenses in a health summary
+type MemberMedicalExpenses struct {
+	ProfileID              string  `json:"profile_id"`
+	Name                   string  `json:"name"`
+	RelationToOwner        string  `json:"relation_to_owner"`
+	MonthlyMedicalExpenses float64 `json:"monthly_medical_expenses"`
 }
 
 // DetermineHealthLevel determines the health risk level based on score
@@ -57,4 +69,4 @@ func (h *HealthSummary) GetRiskMultiplier() float64 {
 	default:
 		return 1.0
 	}
-}
\ No newline at end of file
+}
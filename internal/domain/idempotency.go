@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord stores the outcome of a request made with an Idempotency-Key header
+// so that retries of the same request replay the original response
+type IdempotencyRecord struct {
+	UserID       string    `json:"user_id"`
+	Key          string    `json:"key"`
+	RequestHash  string    `json:"request_hash"` // hash of method, path and body
+	StatusCode   int       `json:"status_code"`
+	ContentType  string    `json:"content_type"`
+	ResponseBody []byte    `json:"response_body"`
+	Completed    bool      `json:"completed"` // false while the original request is in flight
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// IsExpired checks if the record has passed its expiry time
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Matches checks if a request hash belongs to the same request that created the record
+func (r *IdempotencyRecord) Matches(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
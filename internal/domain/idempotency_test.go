@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyRecord_IsExpired(t *testing.T) {
+	now := time.Now()
+	record := &IdempotencyRecord{ExpiresAt: now}
+
+	assert.False(t, record.IsExpired(now.Add(-time.Second)))
+	assert.True(t, record.IsExpired(now))
+	assert.True(t, record.IsExpired(now.Add(time.Second)))
+}
+
+func TestIdempotencyRecord_Matches(t *testing.T) {
+	record := &IdempotencyRecord{RequestHash: "abc"}
+
+	assert.True(t, record.Matches("abc"))
+	assert.False(t, record.Matches("abd"))
+}
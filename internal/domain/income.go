@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/money"
 )
 
 // Income represents a user's income source in the domain layer
@@ -12,6 +14,8 @@ type Income struct {
 	UserID    string
 	Source    string
 	Amount    float64
+	// Currency is an ISO 4217 code; empty means the service's base currency
+	Currency  string
 	Frequency string
 	IsActive  bool
 	CreatedAt time.Time
@@ -51,6 +55,10 @@ func (i *Income) Validate() error {
 		errors = append(errors, "amount must be greater than 0")
 	}
 
+	if i.Currency != "" && !IsValidCurrency(i.Currency) {
+		errors = append(errors, "currency must be a three-letter ISO 4217 code")
+	}
+
 	if i.Frequency == "" {
 		errors = append(errors, "frequency is required")
 	} else if !isValidFrequency(i.Frequency) {
@@ -72,28 +80,19 @@ func (i *Income) Validate() error {
 	return nil
 }
 
-// NormalizeToMonthly converts income amount to monthly equivalent based on frequency
+// NormalizeToMonthly converts income amount to monthly equivalent based on frequency,
+// using the same factors as the finance summary. One-time income is spread over a year.
 func (i *Income) NormalizeToMonthly() float64 {
 	if i.Amount <= 0 {
 		return 0.0
 	}
 
-	switch i.Frequency {
-	case FrequencyMonthly:
-		return i.Amount
-	case FrequencyWeekly:
-		// 52 weeks per year / 12 months = 4.3333...
-		return i.Amount * 52.0 / 12.0
-	case FrequencyDaily:
-		// 365.25 days per year (accounting for leap years) / 12 months
-		return i.Amount * 365.25 / 12.0
-	case FrequencyOneTime:
-		// One-time income doesn't contribute to regular monthly calculations
-		return 0.0
-	default:
+	monthly, err := money.NormalizeToMonthly(i.Amount, i.Frequency)
+	if err != nil {
 		// Invalid frequency
 		return 0.0
 	}
+	return monthly
 }
 
 // isValidFrequency checks if the provided frequency is valid
@@ -135,4 +134,33 @@ func (i *Income) CalculateAnnualAmount() float64 {
 		return i.Amount
 	}
 	return monthlyAmount * 12.0
-}
\ No newline at end of file
+}
+
+// SingleSourceDependencyShare is the share of monthly income above which a user depends on one source
+const SingleSourceDependencyShare = 0.9
+
+// IncomeDiversification describes how concentrated a user's recurring income is, with amounts in Currency.
+// Incomes with the same source name, ignoring case, count as one source; one-time incomes aren't included.
+type IncomeDiversification struct {
+	UserID        string
+	Currency      string
+	MonthlyIncome float64
+	// Sources has each source's monthly income and share of the total, largest first
+	Sources []IncomeSourceShare
+	// LargestSourceShare is the largest source's share of MonthlyIncome, from 0 to 1
+	LargestSourceShare float64
+	// ConcentrationIndex is the Herfindahl-Hirschman index of the source shares, from 0 to 1:
+	// 1 for a single source and 1/n for n equal sources. It is 0 without income.
+	ConcentrationIndex float64
+	// SingleSourceDependent is set when the largest source is more than SingleSourceDependencyShare of the income
+	SingleSourceDependent bool
+}
+
+// IncomeSourceShare is one source's part of a user's monthly income
+type IncomeSourceShare struct {
+	Source        string
+	IncomeCount   int
+	MonthlyAmount float64
+	// Share is MonthlyAmount as a share of the total monthly income, from 0 to 1
+	Share float64
+}
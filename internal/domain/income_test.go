@@ -69,6 +69,28 @@ func TestIncome_Validate_NegativeAmount_ReturnsError(t *testing.T) {
 	assert.Contains(t, err.Error(), "amount must be greater than 0")
 }
 
+func TestIncome_Validate_InvalidCurrency_ReturnsError(t *testing.T) {
+	// Arrange
+	income := Income{
+		ID:        "income-123",
+		UserID:    "user-123",
+		Source:    "Salary",
+		Amount:    5000.50,
+		Currency:  "euro",
+		Frequency: "monthly",
+		IsActive:  true,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	// Act
+	err := income.Validate()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "currency must be a three-letter ISO 4217 code")
+}
+
 func TestIncome_Validate_EmptySource_ReturnsError(t *testing.T) {
 	// Arrange
 	income := Income{
@@ -248,9 +270,9 @@ func TestIncome_NormalizeToMonthly_WeeklyFrequency_ReturnsMonthlyEquivalent(t *t
 		weeklyAmount   float64
 		expectedMonthly float64
 	}{
-		{"round_number", 1000.0, 4333.333333333333},
-		{"with_decimals", 1250.75, 5419.916666666667},
-		{"small_amount", 100.0, 433.3333333333333},
+		{"round_number", 1000.0, 4330.0},
+		{"with_decimals", 1250.75, 5415.7475},
+		{"small_amount", 100.0, 433.0},
 	}
 
 	for _, tt := range tests {
@@ -264,7 +286,7 @@ func TestIncome_NormalizeToMonthly_WeeklyFrequency_ReturnsMonthlyEquivalent(t *t
 			monthlyAmount := income.NormalizeToMonthly()
 
 			// Assert
-			// Weekly to monthly: weekly * 52 weeks / 12 months = weekly * 4.3333
+			// Weekly to monthly: weekly * 4.33
 			assert.InDelta(t, tt.expectedMonthly, monthlyAmount, 0.01)
 		})
 	}
@@ -277,9 +299,9 @@ func TestIncome_NormalizeToMonthly_DailyFrequency_ReturnsMonthlyEquivalent(t *te
 		dailyAmount    float64
 		expectedMonthly float64
 	}{
-		{"round_number", 100.0, 3043.75}, // 100 * 365.25 / 12
-		{"with_decimals", 150.75, 4588.453125},
-		{"small_amount", 50.0, 1521.875},
+		{"round_number", 100.0, 3000.0}, // 100 * 30
+		{"with_decimals", 150.75, 4522.5},
+		{"small_amount", 50.0, 1500.0},
 	}
 
 	for _, tt := range tests {
@@ -293,13 +315,13 @@ func TestIncome_NormalizeToMonthly_DailyFrequency_ReturnsMonthlyEquivalent(t *te
 			monthlyAmount := income.NormalizeToMonthly()
 
 			// Assert
-			// Daily to monthly: daily * 365.25 days / 12 months = daily * 30.4375
+			// Daily to monthly: daily * 30
 			assert.InDelta(t, tt.expectedMonthly, monthlyAmount, 0.01)
 		})
 	}
 }
 
-func TestIncome_NormalizeToMonthly_OneTimeFrequency_ReturnsZero(t *testing.T) {
+func TestIncome_NormalizeToMonthly_OneTimeFrequency_SpreadsOverYear(t *testing.T) {
 	// Arrange
 	income := Income{
 		Amount:    1000.0,
@@ -310,8 +332,8 @@ func TestIncome_NormalizeToMonthly_OneTimeFrequency_ReturnsZero(t *testing.T) {
 	monthlyAmount := income.NormalizeToMonthly()
 
 	// Assert
-	// One-time income doesn't contribute to regular monthly calculations
-	assert.Equal(t, 0.0, monthlyAmount)
+	// One-time income is spread over twelve months
+	assert.InDelta(t, 83.33, monthlyAmount, 0.01)
 }
 
 func TestIncome_NormalizeToMonthly_InvalidFrequency_ReturnsZero(t *testing.T) {
@@ -338,7 +360,7 @@ func TestIncome_NormalizeToMonthly_EdgeCases_HandlesCorrectly(t *testing.T) {
 		{"zero_amount_monthly", 0.0, "monthly", 0.0},
 		{"zero_amount_weekly", 0.0, "weekly", 0.0},
 		{"very_small_amount", 0.01, "weekly", 0.04},
-		{"very_large_amount", 1000000.0, "daily", 30437500.0},
+		{"very_large_amount", 1000000.0, "daily", 30000000.0},
 		{"empty_frequency", 1000.0, "", 0.0},
 	}
 
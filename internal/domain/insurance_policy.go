@@ -2,9 +2,23 @@ package domain
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
+// OutOfPocketStatus describes how close a policy is to its out-of-pocket maximum.
+// Once the maximum is reached the policy pays for all further covered care.
+type OutOfPocketStatus struct {
+	PolicyID           string  `json:"policy_id"`
+	PolicyNumber       string  `json:"policy_number"`
+	OutOfPocketMax     float64 `json:"out_of_pocket_max"`
+	OutOfPocketCurrent float64 `json:"out_of_pocket_current"`
+	Remaining          float64 `json:"remaining"`
+	PercentReached     float64 `json:"percent_reached"` // 0-100
+	MaxReached         bool    `json:"max_reached"`
+	NearMax            bool    `json:"near_max"` // within the warning percent of the maximum, or at it
+}
+
 // InsurancePolicy represents an insurance policy with deductible tracking
 type InsurancePolicy struct {
 	ID                  string    `json:"id"`
@@ -126,6 +140,12 @@ func (i *InsurancePolicy) CalculateCoverage(expenseAmount float64) (float64, flo
 	return insuranceCoverage, outOfPocketForThisExpense, newDeductibleMet
 }
 
+// ProgressAfter returns the deductible met and out-of-pocket spending after the policyholder
+// pays amount more, each capped at its limit
+func (i *InsurancePolicy) ProgressAfter(amount float64) (deductibleMet, outOfPocketCurrent float64) {
+	return math.Min(i.DeductibleMet+amount, i.Deductible), math.Min(i.OutOfPocketCurrent+amount, i.OutOfPocketMax)
+}
+
 // GetRemainingDeductible returns the remaining deductible amount
 func (i *InsurancePolicy) GetRemainingDeductible() float64 {
 	remaining := i.Deductible - i.DeductibleMet
@@ -154,7 +174,51 @@ func (i *InsurancePolicy) IsOutOfPocketMaxReached() bool {
 	return i.OutOfPocketCurrent >= i.OutOfPocketMax
 }
 
+// OutOfPocketPercentReached returns the share of the out-of-pocket maximum already paid, from 0 to 100.
+// A policy without a positive maximum counts as fully reached.
+func (i *InsurancePolicy) OutOfPocketPercentReached() float64 {
+	if i.OutOfPocketMax <= 0 {
+		return 100
+	}
+	percent := i.OutOfPocketCurrent / i.OutOfPocketMax * 100
+	if percent > 100 {
+		return 100
+	}
+	if percent < 0 {
+		return 0
+	}
+	return percent
+}
+
+// CoversMedicalExpense returns true if the policy applies to a medical expense: it is an active
+// policy of the expense's profile whose period includes the day of the expense. Which categories
+// the policy's type pays for is up to the caller.
+func (i *InsurancePolicy) CoversMedicalExpense(expense MedicalExpense) bool {
+	if !i.IsActive || i.ProfileID != expense.ProfileID {
+		return false
+	}
+	day := truncateToDay(expense.Date)
+	return !day.Before(truncateToDay(i.StartDate)) && !day.After(truncateToDay(i.EndDate))
+}
+
 // GetAnnualPremium returns the annual premium amount
 func (i *InsurancePolicy) GetAnnualPremium() float64 {
 	return i.MonthlyPremium * 12
+}
+
+// DaysUntilExpiry returns the number of whole days until the policy's end date.
+// A negative value means the policy expired that many days ago.
+func (i *InsurancePolicy) DaysUntilExpiry(now time.Time) int {
+	end := truncateToDay(i.EndDate)
+	today := truncateToDay(now)
+	return int(end.Sub(today).Hours() / 24)
+}
+
+// IsExpiringWithin returns true if the policy is active and ends within the given number of days,
+// but hasn't ended yet
+func (i *InsurancePolicy) IsExpiringWithin(now time.Time, days int) bool {
+	if !i.IsActive || i.EndDate.Before(now) {
+		return false
+	}
+	return i.DaysUntilExpiry(now) <= days
 }
\ No newline at end of file
@@ -660,6 +660,36 @@ func TestInsurancePolicy_GetRemainingOutOfPocket(t *testing.T) {
 	}
 }
 
+func TestInsurancePolicy_ProgressAfter(t *testing.T) {
+	tests := []struct {
+		name                       string
+		deductibleMet              float64
+		outOfPocketCurrent         float64
+		amount                     float64
+		expectedDeductibleMet      float64
+		expectedOutOfPocketCurrent float64
+	}{
+		{name: "within_limits", deductibleMet: 200, outOfPocketCurrent: 200, amount: 300, expectedDeductibleMet: 500, expectedOutOfPocketCurrent: 500},
+		{name: "deductible_capped", deductibleMet: 800, outOfPocketCurrent: 800, amount: 500, expectedDeductibleMet: 1000, expectedOutOfPocketCurrent: 1300},
+		{name: "both_capped", deductibleMet: 1000, outOfPocketCurrent: 4800, amount: 500, expectedDeductibleMet: 1000, expectedOutOfPocketCurrent: 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := InsurancePolicy{
+				Deductible:         1000,
+				DeductibleMet:      tt.deductibleMet,
+				OutOfPocketMax:     5000,
+				OutOfPocketCurrent: tt.outOfPocketCurrent,
+			}
+
+			deductibleMet, outOfPocketCurrent := policy.ProgressAfter(tt.amount)
+			assert.Equal(t, tt.expectedDeductibleMet, deductibleMet)
+			assert.Equal(t, tt.expectedOutOfPocketCurrent, outOfPocketCurrent)
+		})
+	}
+}
+
 func TestInsurancePolicy_IsDeductibleMet(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -758,6 +788,32 @@ func TestInsurancePolicy_IsOutOfPocketMaxReached(t *testing.T) {
 	}
 }
 
+func TestInsurancePolicy_OutOfPocketPercentReached(t *testing.T) {
+	tests := []struct {
+		name               string
+		outOfPocketMax     float64
+		outOfPocketCurrent float64
+		expectedPercent    float64
+	}{
+		{name: "part_way", outOfPocketMax: 8000.0, outOfPocketCurrent: 2000.0, expectedPercent: 25.0},
+		{name: "nothing_spent", outOfPocketMax: 8000.0, outOfPocketCurrent: 0.0, expectedPercent: 0.0},
+		{name: "exceeded_is_capped", outOfPocketMax: 8000.0, outOfPocketCurrent: 9000.0, expectedPercent: 100.0},
+		{name: "very_low_maximum", outOfPocketMax: 1.0, outOfPocketCurrent: 0.5, expectedPercent: 50.0},
+		{name: "no_maximum_counts_as_reached", outOfPocketMax: 0.0, outOfPocketCurrent: 0.0, expectedPercent: 100.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := InsurancePolicy{
+				OutOfPocketMax:     tt.outOfPocketMax,
+				OutOfPocketCurrent: tt.outOfPocketCurrent,
+			}
+
+			assert.InDelta(t, tt.expectedPercent, policy.OutOfPocketPercentReached(), 0.0001)
+		})
+	}
+}
+
 func TestInsurancePolicy_GetAnnualPremium(t *testing.T) {
 	tests := []struct {
 		name                   string
@@ -796,4 +852,37 @@ func TestInsurancePolicy_GetAnnualPremium(t *testing.T) {
 			assert.InDelta(t, tt.expectedAnnualPremium, annualPremium, 0.01, "Annual premium calculation should be accurate")
 		})
 	}
-}
\ No newline at end of file
+}
+func TestInsurancePolicy_CoversMedicalExpense(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		policyType string
+		isActive   bool
+		date       time.Time
+		expected   bool
+	}{
+		{"health_policy_in_period", "health", true, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), true},
+		{"comprehensive_policy_on_start_day", "comprehensive", true, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"on_end_day", "health", true, time.Date(2025, 12, 31, 23, 0, 0, 0, time.UTC), true},
+		{"before_period", "health", true, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), false},
+		{"after_period", "health", true, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{"inactive_policy", "health", false, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := InsurancePolicy{ProfileID: "1", Type: tt.policyType, IsActive: tt.isActive, StartDate: start, EndDate: end}
+
+			assert.Equal(t, tt.expected, policy.CoversMedicalExpense(MedicalExpense{ProfileID: "1", Date: tt.date}))
+		})
+	}
+
+	t.Run("other_profile", func(t *testing.T) {
+		policy := InsurancePolicy{ProfileID: "1", Type: "health", IsActive: true, StartDate: start, EndDate: end}
+
+		assert.False(t, policy.CoversMedicalExpense(MedicalExpense{ProfileID: "2", Date: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)}))
+	})
+}
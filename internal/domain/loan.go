@@ -17,9 +17,14 @@ type Loan struct {
 	RemainingBalance float64
 	MonthlyPayment   float64
 	InterestRate     float64
-	EndDate          time.Time
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
+	// Currency is an ISO 4217 code for all of the loan's amounts; empty means the service's base currency
+	Currency string
+	// AutoAccrueInterest adds a month of interest to the balance each month; payments then
+	// cover the accrued interest before they repay principal
+	AutoAccrueInterest bool
+	EndDate            time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
 
 // Loan type constants
@@ -81,6 +86,10 @@ func (l *Loan) Validate() error {
 		errors = append(errors, "interest rate must be between 0 and 100")
 	}
 
+	if l.Currency != "" && !IsValidCurrency(l.Currency) {
+		errors = append(errors, "currency must be a three-letter ISO 4217 code")
+	}
+
 	// Remaining balance cannot exceed principal amount
 	if l.RemainingBalance > l.PrincipalAmount {
 		errors = append(errors, "remaining balance cannot exceed principal amount")
@@ -237,4 +246,25 @@ func isValidLoanType(loanType string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// DebtBreakdown is a user's debt grouped by loan type, with every amount in Currency
+type DebtBreakdown struct {
+	UserID              string
+	Currency            string
+	TotalBalance        float64
+	TotalMonthlyPayment float64
+	// Types has an entry for each loan type the user has, largest balance first; it is empty for users without loans
+	Types []LoanTypeDebt
+}
+
+// LoanTypeDebt totals the loans of one type
+type LoanTypeDebt struct {
+	Type             string
+	LoanCount        int
+	RemainingBalance float64
+	MonthlyPayment   float64
+	// SharePercent is RemainingBalance as a percentage of the total balance, rounded to 2 decimals.
+	// The shares add up to 100, or are all 0 once every loan is repaid.
+	SharePercent float64
+}
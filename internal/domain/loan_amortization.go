@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MaxAmortizationMonths caps how far a loan's repayment is projected, so a payment that
+// barely covers the interest can't run the projection for ever
+const MaxAmortizationMonths = 600
+
+// AmortizationPayment is one month of a loan's projected repayment
+type AmortizationPayment struct {
+	Month     int // 1 for the first payment
+	Date      time.Time
+	Payment   float64
+	Principal float64
+	Interest  float64
+	Balance   float64 // Remaining balance after the payment
+}
+
+// AmortizationSchedule is a loan's projected repayment, month by month
+type AmortizationSchedule struct {
+	Payments      []AmortizationPayment
+	TotalInterest float64
+	TotalPaid     float64 // Includes any one-time payment
+	// PaidOff is false when the payment doesn't cover the interest or the balance
+	// isn't repaid within MaxAmortizationMonths; Payments is then empty
+	PaidOff    bool
+	PayoffDate time.Time
+}
+
+// Months returns how many monthly payments it takes to repay the loan
+func (s AmortizationSchedule) Months() int {
+	return len(s.Payments)
+}
+
+// Amortize projects repayment of the remaining balance with interest compounded monthly.
+// oneTimePayment comes off the balance at start, then MonthlyPayment plus extraMonthly is
+// paid each month from the month after start; the last payment only covers what is left.
+func (l *Loan) Amortize(start time.Time, extraMonthly, oneTimePayment float64) AmortizationSchedule {
+	balance := l.RemainingBalance - oneTimePayment
+	schedule := AmortizationSchedule{TotalPaid: math.Min(oneTimePayment, l.RemainingBalance)}
+	if balance <= 0 {
+		schedule.PaidOff = true
+		schedule.PayoffDate = start
+		return schedule
+	}
+
+	monthlyRate := l.InterestRate / 100.0 / 12.0
+	payment := l.MonthlyPayment + extraMonthly
+	// The balance only shrinks if the payment is more than the first month's interest
+	if payment <= balance*monthlyRate {
+		return AmortizationSchedule{TotalPaid: schedule.TotalPaid}
+	}
+
+	for month := 1; month <= MaxAmortizationMonths; month++ {
+		interest := balance * monthlyRate
+		paid := math.Min(payment, balance+interest)
+		balance -= paid - interest
+		// Treat a remainder under a cent as repaid rather than scheduling one more payment
+		if balance < 0.005 {
+			balance = 0
+		}
+
+		date := start.AddDate(0, month, 0)
+		schedule.Payments = append(schedule.Payments, AmortizationPayment{
+			Month:     month,
+			Date:      date,
+			Payment:   paid,
+			Principal: paid - interest,
+			Interest:  interest,
+			Balance:   balance,
+		})
+		schedule.TotalInterest += interest
+		schedule.TotalPaid += paid
+
+		if balance == 0 {
+			schedule.PaidOff = true
+			schedule.PayoffDate = date
+			schedule.TotalInterest = roundToCents(schedule.TotalInterest)
+			schedule.TotalPaid = roundToCents(schedule.TotalPaid)
+			return schedule
+		}
+	}
+
+	// Not repaid within the cap
+	return AmortizationSchedule{TotalPaid: math.Min(oneTimePayment, l.RemainingBalance)}
+}
+
+// LoanPayoffSimulation compares a loan's current repayment with one that adds extra payments
+type LoanPayoffSimulation struct {
+	LoanID              string
+	ExtraMonthlyPayment float64
+	OneTimePayment      float64
+	Baseline            AmortizationSchedule
+	Simulated           AmortizationSchedule
+	// MonthsSaved and InterestSaved are 0 when the baseline is never paid off
+	MonthsSaved   int
+	InterestSaved float64
+
+	// Debt-to-income ratios as fractions of monthly income, filled in by the finance service:
+	// now, while also paying ExtraMonthlyPayment, and once the loan is repaid
+	DebtToIncomeRatio            float64
+	SimulatedDebtToIncomeRatio   float64
+	DebtToIncomeRatioAfterPayoff float64
+}
+
+// SimulateLoanPayoff projects the loan with and without the extra payments from start.
+// Returns an error wrapping ErrInvalidLoanData if the payments are negative or both zero,
+// or ErrPaymentBelowInterest if the loan still wouldn't be repaid with them.
+func SimulateLoanPayoff(loan Loan, extraMonthly, oneTimePayment float64, start time.Time) (LoanPayoffSimulation, error) {
+	switch {
+	case extraMonthly < 0 || oneTimePayment < 0:
+		return LoanPayoffSimulation{}, fmt.Errorf("%w: extra payments can't be negative", ErrInvalidLoanData)
+	case extraMonthly == 0 && oneTimePayment == 0:
+		return LoanPayoffSimulation{}, fmt.Errorf("%w: an extra monthly or one-time payment is required", ErrInvalidLoanData)
+	case oneTimePayment > loan.RemainingBalance:
+		return LoanPayoffSimulation{}, fmt.Errorf("%w: one-time payment exceeds the remaining balance of %.2f",
+			ErrInvalidLoanData, loan.RemainingBalance)
+	}
+
+	simulation := LoanPayoffSimulation{
+		LoanID:              loan.ID,
+		ExtraMonthlyPayment: extraMonthly,
+		OneTimePayment:      oneTimePayment,
+		Baseline:            loan.Amortize(start, 0, 0),
+		Simulated:           loan.Amortize(start, extraMonthly, oneTimePayment),
+	}
+
+	if !simulation.Simulated.PaidOff {
+		return LoanPayoffSimulation{}, fmt.Errorf("%w: a monthly payment of %.2f never repays the remaining balance of %.2f at %.2f%% within %d months",
+			ErrPaymentBelowInterest, loan.MonthlyPayment+extraMonthly, loan.RemainingBalance-oneTimePayment,
+			loan.InterestRate, MaxAmortizationMonths)
+	}
+
+	if simulation.Baseline.PaidOff {
+		simulation.MonthsSaved = simulation.Baseline.Months() - simulation.Simulated.Months()
+		simulation.InterestSaved = roundToCents(simulation.Baseline.TotalInterest - simulation.Simulated.TotalInterest)
+	}
+
+	return simulation, nil
+}
+
+// NearPayoffThreshold is the share of its principal a loan may have left and still count as near payoff
+const NearPayoffThreshold = 0.1
+
+// LoanExtraPaymentImpact compares a loan's current repayment with one that adds a fixed extra monthly payment
+type LoanExtraPaymentImpact struct {
+	LoanID              string
+	ExtraMonthlyPayment float64
+	Baseline            AmortizationSchedule
+	Accelerated         AmortizationSchedule
+	// MonthsSaved and InterestSaved are 0 when the baseline is never paid off
+	MonthsSaved   int
+	InterestSaved float64
+	// NearPayoff is set by the finance service when the loan has at most NearPayoffThreshold of
+	// its principal left, so an extra payment can only save a little
+	NearPayoff bool
+}
+
+// CalculateExtraPaymentImpact projects the loan with and without extraMonthly added to each payment from start.
+// Returns an error wrapping ErrInvalidLoanData if extraMonthly isn't positive, or ErrPaymentBelowInterest
+// if the loan still wouldn't be repaid with it.
+func CalculateExtraPaymentImpact(loan Loan, extraMonthly float64, start time.Time) (LoanExtraPaymentImpact, error) {
+	if extraMonthly <= 0 {
+		return LoanExtraPaymentImpact{}, fmt.Errorf("%w: extra monthly payment must be greater than 0", ErrInvalidLoanData)
+	}
+
+	simulation, err := SimulateLoanPayoff(loan, extraMonthly, 0, start)
+	if err != nil {
+		return LoanExtraPaymentImpact{}, err
+	}
+
+	return LoanExtraPaymentImpact{
+		LoanID:              loan.ID,
+		ExtraMonthlyPayment: extraMonthly,
+		Baseline:            simulation.Baseline,
+		Accelerated:         simulation.Simulated,
+		MonthsSaved:         simulation.MonthsSaved,
+		InterestSaved:       simulation.InterestSaved,
+	}, nil
+}
+
+// NearPayoffLoan is a loan whose remaining balance has fallen to its payoff threshold
+type NearPayoffLoan struct {
+	Loan Loan
+	// ThresholdBalance is the balance, in the loan's currency, at or below which the loan counts as near payoff
+	ThresholdBalance float64
+	// PercentRemaining is the remaining balance as a percentage of the principal
+	PercentRemaining float64
+}
@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAmortizationTestLoan(balance, payment, rate float64) Loan {
+	return Loan{
+		ID:               "loan-1",
+		UserID:           "user-1",
+		Lender:           "Bank",
+		Type:             "personal",
+		PrincipalAmount:  balance,
+		RemainingBalance: balance,
+		MonthlyPayment:   payment,
+		InterestRate:     rate,
+	}
+}
+
+func TestLoan_Amortize_RepaysBalance(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(1200, 100, 0)
+
+	schedule := loan.Amortize(start, 0, 0)
+
+	require.True(t, schedule.PaidOff)
+	assert.Equal(t, 12, schedule.Months())
+	assert.Equal(t, 0.0, schedule.TotalInterest)
+	assert.Equal(t, 1200.0, schedule.TotalPaid)
+	assert.Equal(t, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), schedule.PayoffDate)
+	assert.Equal(t, 0.0, schedule.Payments[11].Balance)
+}
+
+func TestLoan_Amortize_PaymentBelowInterest_NeverPaysOff(t *testing.T) {
+	// 12% a year is 100 a month on 10,000
+	loan := newAmortizationTestLoan(10000, 100, 12)
+
+	schedule := loan.Amortize(time.Now(), 0, 0)
+
+	assert.False(t, schedule.PaidOff)
+	assert.Empty(t, schedule.Payments)
+}
+
+func TestLoan_Amortize_CapsProjection(t *testing.T) {
+	// Repaid only after roughly 75 years
+	loan := newAmortizationTestLoan(100000, 510, 6)
+
+	schedule := loan.Amortize(time.Now(), 0, 0)
+
+	assert.False(t, schedule.PaidOff)
+	assert.Empty(t, schedule.Payments)
+}
+
+func TestSimulateLoanPayoff_ExtraMonthlyPayment_SavesTimeAndInterest(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(20000, 400, 5)
+
+	simulation, err := SimulateLoanPayoff(loan, 200, 0, start)
+
+	require.NoError(t, err)
+	assert.Equal(t, 57, simulation.Baseline.Months())
+	assert.Equal(t, 36, simulation.Simulated.Months())
+	assert.Equal(t, 21, simulation.MonthsSaved)
+	assert.Greater(t, simulation.InterestSaved, 0.0)
+	assert.InDelta(t, simulation.Baseline.TotalInterest-simulation.Simulated.TotalInterest, simulation.InterestSaved, 0.01)
+	assert.True(t, simulation.Simulated.PayoffDate.Before(simulation.Baseline.PayoffDate))
+	// The loan itself is unchanged
+	assert.Equal(t, 20000.0, loan.RemainingBalance)
+}
+
+func TestSimulateLoanPayoff_OneTimePaymentRepaysLoan(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(5000, 400, 5)
+
+	simulation, err := SimulateLoanPayoff(loan, 0, 5000, start)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, simulation.Simulated.Months())
+	assert.Equal(t, start, simulation.Simulated.PayoffDate)
+	assert.Equal(t, simulation.Baseline.Months(), simulation.MonthsSaved)
+	assert.Equal(t, simulation.Baseline.TotalInterest, simulation.InterestSaved)
+}
+
+func TestSimulateLoanPayoff_BaselineBelowInterest_ReportsNoSavings(t *testing.T) {
+	loan := newAmortizationTestLoan(10000, 100, 12)
+
+	simulation, err := SimulateLoanPayoff(loan, 200, 0, time.Now())
+
+	require.NoError(t, err)
+	assert.False(t, simulation.Baseline.PaidOff)
+	assert.True(t, simulation.Simulated.PaidOff)
+	assert.Equal(t, 0, simulation.MonthsSaved)
+	assert.Equal(t, 0.0, simulation.InterestSaved)
+}
+
+func TestSimulateLoanPayoff_RejectsInvalidPayments(t *testing.T) {
+	loan := newAmortizationTestLoan(10000, 90, 12)
+
+	tests := []struct {
+		name         string
+		extraMonthly float64
+		oneTime      float64
+		want         error
+	}{
+		{"no extra payment", 0, 0, ErrInvalidLoanData},
+		{"negative extra payment", -50, 0, ErrInvalidLoanData},
+		{"one-time payment above balance", 0, 20000, ErrInvalidLoanData},
+		{"still below interest", 5, 500, ErrPaymentBelowInterest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SimulateLoanPayoff(loan, tt.extraMonthly, tt.oneTime, time.Now())
+			assert.True(t, errors.Is(err, tt.want), "got %v", err)
+		})
+	}
+}
+
+func TestCalculateExtraPaymentImpact_Mortgage(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	// A 30-year 300,000 mortgage at 6%, repaid at 1798.66 a month
+	mortgage := newAmortizationTestLoan(300000, 1798.66, 6)
+
+	impact, err := CalculateExtraPaymentImpact(mortgage, 500, start)
+
+	require.NoError(t, err)
+	assert.Equal(t, 360, impact.Baseline.Months())
+	assert.InDelta(t, 347509.14, impact.Baseline.TotalInterest, 0.01)
+	assert.Equal(t, 212, impact.Accelerated.Months())
+	assert.Equal(t, 148, impact.MonthsSaved)
+	assert.InDelta(t, impact.Baseline.TotalInterest-impact.Accelerated.TotalInterest, impact.InterestSaved, 0.01)
+	assert.Greater(t, impact.InterestSaved, 100000.0)
+	assert.False(t, impact.NearPayoff)
+}
+
+func TestCalculateExtraPaymentImpact_RejectsNonPositiveExtra(t *testing.T) {
+	loan := newAmortizationTestLoan(10000, 300, 5)
+
+	for _, extra := range []float64{0, -100} {
+		_, err := CalculateExtraPaymentImpact(loan, extra, time.Now())
+		assert.True(t, errors.Is(err, ErrInvalidLoanData), "extra %.2f: got %v", extra, err)
+	}
+}
+
+func TestCalculateExtraPaymentImpact_ExtraCoversBalance(t *testing.T) {
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(250, 100, 6)
+
+	impact, err := CalculateExtraPaymentImpact(loan, 1000, start)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, impact.Baseline.Months())
+	assert.Equal(t, 1, impact.Accelerated.Months())
+	assert.Equal(t, 2, impact.MonthsSaved)
+	// The final payment only covers what is left
+	assert.InDelta(t, 251.25, impact.Accelerated.TotalPaid, 0.01)
+}
@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Loan balance entry types
+const (
+	// LoanBalancePayment is a payment, split into the interest it covers and the principal it repays
+	LoanBalancePayment = "payment"
+	// LoanBalanceAdjustment is a balance set by hand, such as a correction from a lender statement
+	LoanBalanceAdjustment = "adjustment"
+	// LoanBalanceInterestAccrual is a month of interest added to the balance of an auto-accruing loan
+	LoanBalanceInterestAccrual = "interest-accrual"
+)
+
+// MaxLoanBalanceNoteLength bounds the note on a loan balance entry
+const MaxLoanBalanceNoteLength = 255
+
+// LoanBalanceEntry records one change to a loan's remaining balance. Entries are never
+// updated, so a loan's entries, newest first, explain how its balance got where it is.
+type LoanBalanceEntry struct {
+	ID         string
+	LoanID     string
+	UserID     string
+	Type       string
+	OldBalance float64
+	NewBalance float64
+	// Amount is the payment for payments, the interest added for accruals and the change in
+	// balance for adjustments
+	Amount float64
+	// InterestPortion and PrincipalPortion split a payment; an accrual's interest is its InterestPortion
+	InterestPortion  float64
+	PrincipalPortion float64
+	Note             string
+	CreatedAt        time.Time
+}
+
+// Validate validates the LoanBalanceEntry struct
+// Returns an error wrapping ErrInvalidLoanData that describes every problem found
+func (e *LoanBalanceEntry) Validate() error {
+	var errors []string
+
+	if e.LoanID == "" {
+		errors = append(errors, "loan ID is required")
+	}
+
+	if e.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	switch e.Type {
+	case LoanBalancePayment, LoanBalanceAdjustment, LoanBalanceInterestAccrual:
+	default:
+		errors = append(errors, "entry type must be one of: payment, adjustment, interest-accrual")
+	}
+
+	if e.NewBalance < 0 {
+		errors = append(errors, "balance cannot be negative")
+	}
+
+	if len(e.Note) > MaxLoanBalanceNoteLength {
+		errors = append(errors, fmt.Sprintf("note must be at most %d characters", MaxLoanBalanceNoteLength))
+	}
+
+	if e.CreatedAt.IsZero() {
+		errors = append(errors, "created at is required")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidLoanData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// MonthlyInterest returns a month of interest on the remaining balance at the loan's rate, in cents
+func (l *Loan) MonthlyInterest() float64 {
+	if l.RemainingBalance <= 0 || l.InterestRate <= 0 {
+		return 0
+	}
+	return roundToCents(l.RemainingBalance * l.InterestRate / 100.0 / 12.0)
+}
+
+// AdjustBalance returns the entry that sets the loan's balance to newBalance by hand
+func (l *Loan) AdjustBalance(newBalance float64, note string, at time.Time) LoanBalanceEntry {
+	return LoanBalanceEntry{
+		LoanID:     l.ID,
+		UserID:     l.UserID,
+		Type:       LoanBalanceAdjustment,
+		OldBalance: l.RemainingBalance,
+		NewBalance: newBalance,
+		Amount:     roundToCents(newBalance - l.RemainingBalance),
+		Note:       note,
+		CreatedAt:  at,
+	}
+}
+
+// ApplyPayment returns the entry recording a payment of amount, split the way an amortizing loan
+// splits it: interest first, then principal. A loan that doesn't accrue interest on its own owes a
+// month of interest on its balance, and only the principal comes off the balance. An auto-accruing
+// loan already carries its interest in the balance, so the payment covers unpaidInterest, the
+// interest accrued and not yet paid, and the whole amount comes off the balance.
+// Returns an error wrapping ErrInvalidLoanData if amount isn't positive or is more than is owed.
+func (l *Loan) ApplyPayment(amount, unpaidInterest float64, note string, at time.Time) (LoanBalanceEntry, error) {
+	if amount <= 0 {
+		return LoanBalanceEntry{}, fmt.Errorf("%w: payment amount must be greater than 0", ErrInvalidLoanData)
+	}
+
+	entry := LoanBalanceEntry{
+		LoanID:     l.ID,
+		UserID:     l.UserID,
+		Type:       LoanBalancePayment,
+		OldBalance: l.RemainingBalance,
+		Amount:     amount,
+		Note:       note,
+		CreatedAt:  at,
+	}
+
+	owedInterest := l.MonthlyInterest()
+	if l.AutoAccrueInterest {
+		owedInterest = roundToCents(math.Max(unpaidInterest, 0))
+	}
+	entry.InterestPortion = math.Min(amount, owedInterest)
+	entry.PrincipalPortion = roundToCents(amount - entry.InterestPortion)
+
+	if l.AutoAccrueInterest {
+		entry.NewBalance = roundToCents(l.RemainingBalance - amount)
+	} else {
+		entry.NewBalance = roundToCents(l.RemainingBalance - entry.PrincipalPortion)
+	}
+	if entry.NewBalance < 0 {
+		return LoanBalanceEntry{}, fmt.Errorf("%w: payment of %.2f is more than the %.2f owed",
+			ErrInvalidLoanData, amount, roundToCents(amount+entry.NewBalance))
+	}
+
+	return entry, nil
+}
+
+// AccrueInterest returns the entry adding a month of interest to the balance, and false when
+// there is nothing to accrue because the loan is repaid or interest-free
+func (l *Loan) AccrueInterest(at time.Time) (LoanBalanceEntry, bool) {
+	interest := l.MonthlyInterest()
+	if interest <= 0 {
+		return LoanBalanceEntry{}, false
+	}
+
+	return LoanBalanceEntry{
+		LoanID:          l.ID,
+		UserID:          l.UserID,
+		Type:            LoanBalanceInterestAccrual,
+		OldBalance:      l.RemainingBalance,
+		NewBalance:      roundToCents(l.RemainingBalance + interest),
+		Amount:          interest,
+		InterestPortion: interest,
+		Note:            fmt.Sprintf("Interest for %s", at.Format("January 2006")),
+		CreatedAt:       at,
+	}, true
+}
+
+// BalanceAt returns what was owed on the loan at the moment at, given its balance entries newest
+// first: the balance before the first change made at or after at, or the current balance if
+// nothing has changed since
+func (l *Loan) BalanceAt(entries []LoanBalanceEntry, at time.Time) float64 {
+	balance := l.RemainingBalance
+	for _, entry := range entries {
+		if entry.CreatedAt.Before(at) {
+			break
+		}
+		balance = entry.OldBalance
+	}
+	return balance
+}
+
+// UnpaidInterest returns the interest accrued on a loan that its payments haven't covered yet
+func UnpaidInterest(entries []LoanBalanceEntry) float64 {
+	var unpaid float64
+	for _, entry := range entries {
+		switch entry.Type {
+		case LoanBalanceInterestAccrual:
+			unpaid += entry.InterestPortion
+		case LoanBalancePayment:
+			unpaid -= entry.InterestPortion
+		}
+	}
+	return roundToCents(math.Max(unpaid, 0))
+}
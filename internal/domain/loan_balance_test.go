@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoan_ApplyPayment_SplitMatchesAmortization(t *testing.T) {
+	// 30-year mortgage: 4.5% on 245,000 is 918.75 interest in the first month
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(245000, 1266.71, 4.5)
+	schedule := loan.Amortize(start, 0, 0)
+	require.True(t, schedule.PaidOff)
+
+	// Paying each scheduled month keeps the recorded split on the schedule
+	for _, scheduled := range schedule.Payments[:12] {
+		entry, err := loan.ApplyPayment(loan.MonthlyPayment, 0, "", scheduled.Date)
+		require.NoError(t, err)
+
+		assert.InDelta(t, scheduled.Interest, entry.InterestPortion, 0.01, "month %d interest", scheduled.Month)
+		assert.InDelta(t, scheduled.Principal, entry.PrincipalPortion, 0.01, "month %d principal", scheduled.Month)
+		assert.InDelta(t, scheduled.Balance, entry.NewBalance, 0.05, "month %d balance", scheduled.Month)
+		assert.Equal(t, loan.MonthlyPayment, entry.InterestPortion+entry.PrincipalPortion)
+		loan.RemainingBalance = entry.NewBalance
+	}
+
+	fresh := newAmortizationTestLoan(245000, 1266.71, 4.5)
+	first, err := fresh.ApplyPayment(1266.71, 0, "June payment", start)
+	require.NoError(t, err)
+	assert.Equal(t, LoanBalancePayment, first.Type)
+	assert.Equal(t, 918.75, first.InterestPortion)
+	assert.Equal(t, 347.96, first.PrincipalPortion)
+	assert.Equal(t, 245000.0, first.OldBalance)
+	assert.Equal(t, 244652.04, first.NewBalance)
+	assert.Equal(t, "June payment", first.Note)
+}
+
+func TestLoan_ApplyPayment_BelowInterest_IsAllInterest(t *testing.T) {
+	loan := newAmortizationTestLoan(10000, 50, 12)
+
+	entry, err := loan.ApplyPayment(50, 0, "", time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, entry.InterestPortion)
+	assert.Equal(t, 0.0, entry.PrincipalPortion)
+	assert.Equal(t, 10000.0, entry.NewBalance)
+}
+
+func TestLoan_ApplyPayment_AutoAccruing_CoversUnpaidInterestFirst(t *testing.T) {
+	// The balance already includes last month's 100.00 of accrued interest
+	loan := newAmortizationTestLoan(10100, 300, 12)
+	loan.AutoAccrueInterest = true
+
+	entry, err := loan.ApplyPayment(300, 100, "", time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, entry.InterestPortion)
+	assert.Equal(t, 200.0, entry.PrincipalPortion)
+	assert.Equal(t, 9800.0, entry.NewBalance, "the whole payment comes off an auto-accruing balance")
+}
+
+func TestLoan_ApplyPayment_RejectsInvalidAmounts(t *testing.T) {
+	loan := newAmortizationTestLoan(1000, 100, 0)
+
+	for _, amount := range []float64{0, -10, 1000.01} {
+		_, err := loan.ApplyPayment(amount, 0, "", time.Now())
+		assert.True(t, errors.Is(err, ErrInvalidLoanData), "amount %v", amount)
+	}
+
+	interestBearing := newAmortizationTestLoan(1000, 100, 12)
+	_, err := interestBearing.ApplyPayment(1020, 0, "", time.Now())
+	assert.EqualError(t, err, "invalid loan data: payment of 1020.00 is more than the 1010.00 owed",
+		"the month's interest is owed on top of the balance")
+
+	entry, err := loan.ApplyPayment(1000, 0, "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, entry.NewBalance, "the exact balance repays the loan")
+}
+
+func TestLoan_AccrueInterest(t *testing.T) {
+	at := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	loan := newAmortizationTestLoan(10000, 300, 4.5)
+
+	entry, ok := loan.AccrueInterest(at)
+
+	require.True(t, ok)
+	assert.Equal(t, LoanBalanceInterestAccrual, entry.Type)
+	assert.Equal(t, 37.5, entry.Amount)
+	assert.Equal(t, 37.5, entry.InterestPortion)
+	assert.Equal(t, 10037.5, entry.NewBalance)
+	assert.Equal(t, "Interest for March 2025", entry.Note)
+
+	interestFree := newAmortizationTestLoan(10000, 300, 0)
+	_, ok = interestFree.AccrueInterest(at)
+	assert.False(t, ok, "interest-free loans accrue nothing")
+}
+
+func TestUnpaidInterest(t *testing.T) {
+	entries := []LoanBalanceEntry{
+		{Type: LoanBalancePayment, InterestPortion: 30},
+		{Type: LoanBalanceInterestAccrual, InterestPortion: 37.5},
+		{Type: LoanBalanceAdjustment, Amount: -500},
+		{Type: LoanBalanceInterestAccrual, InterestPortion: 37.5},
+	}
+
+	assert.Equal(t, 45.0, UnpaidInterest(entries))
+	assert.Equal(t, 0.0, UnpaidInterest(entries[:1]), "overpaid interest isn't owed back")
+}
+
+func TestLoanBalanceEntry_Validate(t *testing.T) {
+	entry := LoanBalanceEntry{LoanID: "loan-1", UserID: "user-1", Type: "refund", NewBalance: -1}
+
+	err := entry.Validate()
+
+	require.True(t, errors.Is(err, ErrInvalidLoanData))
+	assert.Contains(t, err.Error(), "entry type must be one of")
+	assert.Contains(t, err.Error(), "balance cannot be negative")
+	assert.Contains(t, err.Error(), "created at is required")
+}
+
+func TestLoan_BalanceAt(t *testing.T) {
+	loan := newAmortizationTestLoan(9400, 300, 0)
+	on := func(month time.Month) time.Time { return time.Date(2025, month, 5, 0, 0, 0, 0, time.UTC) }
+	entries := []LoanBalanceEntry{
+		{Type: LoanBalancePayment, OldBalance: 9700, NewBalance: 9400, CreatedAt: on(time.March)},
+		{Type: LoanBalanceAdjustment, OldBalance: 10000, NewBalance: 9700, CreatedAt: on(time.February)},
+	}
+
+	assert.Equal(t, 9400.0, loan.BalanceAt(entries, on(time.April)), "nothing changed since")
+	assert.Equal(t, 9700.0, loan.BalanceAt(entries, on(time.March)), "a change made at the moment hasn't happened yet")
+	assert.Equal(t, 10000.0, loan.BalanceAt(entries, on(time.January)))
+	assert.Equal(t, 9400.0, loan.BalanceAt(nil, on(time.January)), "without entries the current balance is all there is")
+}
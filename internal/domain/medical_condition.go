@@ -7,19 +7,22 @@ import (
 
 // MedicalCondition represents a medical condition with severity and risk assessment
 type MedicalCondition struct {
-	ID                 string    `json:"id"`
-	UserID             string    `json:"user_id"`
-	ProfileID          string    `json:"profile_id"`
-	Name               string    `json:"name"`                  // standardized condition name
-	Category           string    `json:"category"`              // "chronic", "acute", "mental_health", "preventive"
-	Severity           string    `json:"severity"`              // "mild", "moderate", "severe", "critical"
-	DiagnosedDate      time.Time `json:"diagnosed_date"`
-	IsActive           bool      `json:"is_active"`
-	RequiresMedication bool      `json:"requires_medication"`
-	MonthlyMedCost     float64   `json:"monthly_med_cost"`      // estimated monthly medication cost
-	RiskFactor         float64   `json:"risk_factor"`           // 0.0 to 1.0 risk multiplier
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                 string     `json:"id"`
+	UserID             string     `json:"user_id"`
+	ProfileID          string     `json:"profile_id"`
+	Name               string     `json:"name"`     // standardized condition name
+	Category           string     `json:"category"` // "chronic", "acute", "mental_health", "preventive"
+	Severity           string     `json:"severity"` // "mild", "moderate", "severe", "critical"
+	DiagnosedDate      time.Time  `json:"diagnosed_date"`
+	ResolvedDate       *time.Time `json:"resolved_date,omitempty"` // set when the condition stops being active
+	IsActive           bool       `json:"is_active"`
+	RequiresMedication bool       `json:"requires_medication"`
+	MonthlyMedCost     float64    `json:"monthly_med_cost"`              // estimated monthly medication cost
+	RiskFactor         float64    `json:"risk_factor"`                   // 0.0 to 1.0 risk multiplier
+	CatalogID          string     `json:"catalog_id,omitempty"`          // condition catalog entry; empty for free text
+	CatalogRiskWeight  int        `json:"catalog_risk_weight,omitempty"` // scored in place of the severity points
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 // Validate validates the medical condition data
@@ -73,9 +76,50 @@ func (m *MedicalCondition) Validate() error {
 		return fmt.Errorf("diagnosed date cannot be in the future")
 	}
 
+	if m.ResolvedDate != nil && m.ResolvedDate.Before(m.DiagnosedDate) {
+		return fmt.Errorf("resolved date cannot be before diagnosed date")
+	}
+
 	return nil
 }
 
+// IsCatalogued returns true if the condition was added from the condition catalog
+func (m *MedicalCondition) IsCatalogued() bool {
+	return m.CatalogID != ""
+}
+
+// Resolve marks the condition as no longer active. An existing resolved date is kept.
+func (m *MedicalCondition) Resolve(at time.Time) {
+	m.IsActive = false
+	if m.ResolvedDate == nil {
+		resolved := at
+		m.ResolvedDate = &resolved
+	}
+}
+
+// Reactivate marks a resolved condition as active again and clears its resolved date
+func (m *MedicalCondition) Reactivate() {
+	m.IsActive = true
+	m.ResolvedDate = nil
+}
+
+// DaysSinceDiagnosis returns the whole days between diagnosis and now
+func (m *MedicalCondition) DaysSinceDiagnosis(now time.Time) int {
+	if now.Before(m.DiagnosedDate) {
+		return 0
+	}
+	return int(now.Sub(m.DiagnosedDate).Hours() / 24)
+}
+
+// DurationDays returns how many whole days the condition was active:
+// until the resolved date once resolved, otherwise until now
+func (m *MedicalCondition) DurationDays(now time.Time) int {
+	if m.ResolvedDate != nil {
+		return m.DaysSinceDiagnosis(*m.ResolvedDate)
+	}
+	return m.DaysSinceDiagnosis(now)
+}
+
 // CalculateRiskContribution calculates the risk contribution of this condition
 // Returns risk points based on category and severity, only if condition is active
 func (m *MedicalCondition) CalculateRiskContribution() float64 {
@@ -167,4 +211,4 @@ func (m *MedicalCondition) RequiresHighRiskManagement() bool {
 	}
 
 	return m.Severity == "severe" || m.Severity == "critical"
-}
\ No newline at end of file
+}
@@ -668,4 +668,27 @@ func TestMedicalCondition_RequiresHighRiskManagement(t *testing.T) {
 			assert.Equal(t, tt.expectedHighRiskMgmt, requiresHighRisk, "High risk management requirement should match expected value")
 		})
 	}
-}
\ No newline at end of file
+}
+func TestMedicalCondition_ResolveAndReactivate(t *testing.T) {
+	diagnosed := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	resolvedAt := diagnosed.AddDate(0, 0, 30)
+	now := diagnosed.AddDate(0, 0, 100)
+
+	condition := &MedicalCondition{DiagnosedDate: diagnosed, IsActive: true}
+	assert.Equal(t, 100, condition.DurationDays(now))
+
+	condition.Resolve(resolvedAt)
+	assert.False(t, condition.IsActive)
+	require.NotNil(t, condition.ResolvedDate)
+	assert.Equal(t, resolvedAt, *condition.ResolvedDate)
+	assert.Equal(t, 100, condition.DaysSinceDiagnosis(now))
+	assert.Equal(t, 30, condition.DurationDays(now))
+
+	// Resolving again keeps the original resolved date
+	condition.Resolve(now)
+	assert.Equal(t, resolvedAt, *condition.ResolvedDate)
+
+	condition.Reactivate()
+	assert.True(t, condition.IsActive)
+	assert.Nil(t, condition.ResolvedDate)
+}
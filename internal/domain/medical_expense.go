@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/money"
 )
 
 // MedicalExpense represents a medical expense with insurance tracking
@@ -14,7 +17,7 @@ type MedicalExpense struct {
 	Category         string    `json:"category"`                // "doctor_visit", "medication", "hospital", "lab_test", "therapy", "equipment"
 	Description      string    `json:"description"`
 	IsRecurring      bool      `json:"is_recurring"`
-	Frequency        string    `json:"frequency"`               // "monthly", "quarterly", "annually", "one_time"
+	Frequency        string    `json:"frequency"`               // one of MedicalExpenseFrequencies, or "one_time"
 	IsCovered        bool      `json:"is_covered"`              // covered by insurance
 	InsurancePayment float64   `json:"insurance_payment"`       // amount paid by insurance
 	OutOfPocket      float64   `json:"out_of_pocket"`           // actual user payment
@@ -55,16 +58,8 @@ func (m *MedicalExpense) Validate() error {
 			return fmt.Errorf("frequency is required for recurring expenses")
 		}
 
-		validFrequencies := []string{"monthly", "quarterly", "annually"}
-		isValidFrequency := false
-		for _, frequency := range validFrequencies {
-			if m.Frequency == frequency {
-				isValidFrequency = true
-				break
-			}
-		}
-		if !isValidFrequency {
-			return fmt.Errorf("frequency must be one of: monthly, quarterly, annually")
+		if _, err := MedicalMonthlyAmount(m.Amount, m.Frequency); err != nil {
+			return fmt.Errorf("frequency must be one of: %s", strings.Join(MedicalExpenseFrequencies, ", "))
 		}
 	}
 
@@ -117,16 +112,27 @@ func (m *MedicalExpense) GetCoveragePercentage() float64 {
 
 // GetFrequencyMultiplier returns the annual multiplier for the frequency
 func (m *MedicalExpense) GetFrequencyMultiplier() float64 {
-	switch m.Frequency {
-	case "monthly":
-		return 12.0
-	case "quarterly":
-		return 4.0
-	case "annually":
-		return 1.0
-	default:
+	monthly, err := MedicalMonthlyAmount(1, m.Frequency)
+	if err != nil {
 		return 1.0 // For "one_time" or unknown
 	}
+	return monthly * 12
+}
+
+// MedicalExpenseFrequencies are the frequencies a recurring medical expense can have: every
+// recurring frequency in the money package, with its aliases
+var MedicalExpenseFrequencies = []string{
+	"daily", "weekly", "biweekly", "bi-weekly", "monthly", "quarterly",
+	"semiannual", "semiannually", "semi-annually", "annual", "annually", "yearly",
+}
+
+// MedicalMonthlyAmount converts an amount paid at frequency to its monthly equivalent with
+// money.NormalizeToMonthly. Returns an error for a frequency that is not recurring.
+func MedicalMonthlyAmount(amount float64, frequency string) (float64, error) {
+	if canonical, ok := money.CanonicalFrequency(frequency); !ok || canonical == money.FrequencyOneTime {
+		return 0, fmt.Errorf("unsupported medical expense frequency: %q", frequency)
+	}
+	return money.NormalizeToMonthly(amount, frequency)
 }
 
 // IsHighCostExpense determines if this is a high-cost expense (>= $500)
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MedicalExpenseOccurrence records what a recurring medical expense actually cost on one date.
+// It inherits its category and coverage from the expense it belongs to, so only the amount
+// and date are given by the user.
+type MedicalExpenseOccurrence struct {
+	ID               string    `json:"id"`
+	ExpenseID        string    `json:"expense_id"`
+	UserID           string    `json:"user_id"`
+	ProfileID        string    `json:"profile_id"`
+	Amount           float64   `json:"amount"`
+	Date             time.Time `json:"date"`
+	Category         string    `json:"category"`
+	IsCovered        bool      `json:"is_covered"`
+	InsurancePayment float64   `json:"insurance_payment"`
+	OutOfPocket      float64   `json:"out_of_pocket"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// Validate validates the occurrence's amount and date
+func (o *MedicalExpenseOccurrence) Validate() error {
+	if o.Amount <= 0 {
+		return fmt.Errorf("amount must be positive")
+	}
+
+	if o.Date.IsZero() {
+		return fmt.Errorf("date is required")
+	}
+
+	if o.Date.After(time.Now()) {
+		return fmt.Errorf("date cannot be in the future")
+	}
+
+	return nil
+}
+
+// InheritFrom copies the expense's user, profile and category to the occurrence and splits its
+// amount between insurance and out of pocket in the same proportion as the expense's
+func (o *MedicalExpenseOccurrence) InheritFrom(expense *MedicalExpense) {
+	o.ExpenseID = expense.ID
+	o.UserID = expense.UserID
+	o.ProfileID = expense.ProfileID
+	o.Category = expense.Category
+	o.IsCovered = expense.IsCovered
+
+	o.InsurancePayment = 0
+	if expense.IsCovered && expense.Amount > 0 {
+		o.InsurancePayment = roundToCents(o.Amount * expense.InsurancePayment / expense.Amount)
+	}
+	o.OutOfPocket = roundToCents(o.Amount - o.InsurancePayment)
+}
+
+// RecurringExpenseVariance compares what recurring medical expenses were projected to cost this
+// year with what their recorded occurrences actually cost. Only months with at least one
+// occurrence recorded for an expense are compared; the expense's projection stands for the rest.
+type RecurringExpenseVariance struct {
+	// ProjectedYTD is the projected monthly amount for every compared month
+	ProjectedYTD float64 `json:"projected_ytd"`
+	// ActualYTD is what the occurrences in the compared months cost
+	ActualYTD float64 `json:"actual_ytd"`
+	// Variance is ActualYTD - ProjectedYTD; positive when recurring expenses cost more than projected
+	Variance float64 `json:"variance"`
+	// ReconciledMonths counts the compared expense months
+	ReconciledMonths int `json:"reconciled_months"`
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedicalExpenseOccurrence_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		occurrence MedicalExpenseOccurrence
+		errorMsg   string
+	}{
+		{"valid", MedicalExpenseOccurrence{Amount: 45, Date: time.Now().AddDate(0, 0, -1)}, ""},
+		{"zero_amount", MedicalExpenseOccurrence{Amount: 0, Date: time.Now()}, "amount must be positive"},
+		{"missing_date", MedicalExpenseOccurrence{Amount: 45}, "date is required"},
+		{"future_date", MedicalExpenseOccurrence{Amount: 45, Date: time.Now().AddDate(0, 0, 1)}, "date cannot be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.occurrence.Validate()
+
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestMedicalExpenseOccurrence_InheritFrom(t *testing.T) {
+	expense := &MedicalExpense{
+		ID:               "7",
+		UserID:           "user-1",
+		ProfileID:        "3",
+		Amount:           100,
+		Category:         "medication",
+		IsRecurring:      true,
+		Frequency:        "monthly",
+		IsCovered:        true,
+		InsurancePayment: 80,
+		OutOfPocket:      20,
+	}
+
+	occurrence := &MedicalExpenseOccurrence{Amount: 120}
+	occurrence.InheritFrom(expense)
+
+	assert.Equal(t, "7", occurrence.ExpenseID)
+	assert.Equal(t, "user-1", occurrence.UserID)
+	assert.Equal(t, "3", occurrence.ProfileID)
+	assert.Equal(t, "medication", occurrence.Category)
+	assert.True(t, occurrence.IsCovered)
+	assert.Equal(t, 96.0, occurrence.InsurancePayment)
+	assert.Equal(t, 24.0, occurrence.OutOfPocket)
+
+	// An uncovered expense leaves the whole occurrence out of pocket
+	expense.IsCovered = false
+	occurrence.InheritFrom(expense)
+	assert.False(t, occurrence.IsCovered)
+	assert.Zero(t, occurrence.InsurancePayment)
+	assert.Equal(t, 120.0, occurrence.OutOfPocket)
+}
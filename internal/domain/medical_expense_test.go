@@ -215,7 +215,7 @@ func TestMedicalExpense_Validate(t *testing.T) {
 				Date:        time.Now(),
 			},
 			expectError: true,
-			errorMsg:    "frequency must be one of: monthly, quarterly, annually",
+			errorMsg:    "frequency must be one of: daily, weekly, biweekly, bi-weekly, monthly, quarterly, semiannual, semiannually, semi-annually, annual, annually, yearly",
 		},
 		{
 			name: "non_recurring_with_frequency_valid",
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MedicationSchedule represents a medication taken for a condition and how often it is refilled
+type MedicationSchedule struct {
+	ID              string    `json:"id"`
+	UserID          string    `json:"user_id"`
+	ConditionID     string    `json:"condition_id"`
+	MedicationName  string    `json:"medication_name"`
+	RefillEveryDays int       `json:"refill_every_days"`
+	LastFilledDate  time.Time `json:"last_filled_date"`
+	IsActive        bool      `json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Validate validates the medication schedule data
+func (m *MedicationSchedule) Validate() error {
+	if m.UserID == "" {
+		return fmt.Errorf("user ID is required")
+	}
+
+	if m.ConditionID == "" {
+		return fmt.Errorf("condition ID is required")
+	}
+
+	if m.MedicationName == "" {
+		return fmt.Errorf("medication name is required")
+	}
+
+	if m.RefillEveryDays <= 0 {
+		return fmt.Errorf("refill interval must be positive")
+	}
+
+	if m.LastFilledDate.IsZero() {
+		return fmt.Errorf("last filled date is required")
+	}
+
+	if m.LastFilledDate.After(time.Now()) {
+		return fmt.Errorf("last filled date cannot be in the future")
+	}
+
+	return nil
+}
+
+// NextRefillDate returns the date the medication is due to be refilled
+func (m *MedicationSchedule) NextRefillDate() time.Time {
+	return m.LastFilledDate.AddDate(0, 0, m.RefillEveryDays)
+}
+
+// DaysUntilRefill returns the number of whole days until the next refill is due.
+// A negative value means the refill is overdue by that many days.
+func (m *MedicationSchedule) DaysUntilRefill(now time.Time) int {
+	due := truncateToDay(m.NextRefillDate())
+	today := truncateToDay(now)
+	return int(due.Sub(today).Hours() / 24)
+}
+
+// IsOverdue returns true if the refill due date has passed
+func (m *MedicationSchedule) IsOverdue(now time.Time) bool {
+	return m.DaysUntilRefill(now) < 0
+}
+
+// IsDueWithin returns true if the refill is overdue or due within the given number of days
+func (m *MedicationSchedule) IsDueWithin(now time.Time, days int) bool {
+	return m.DaysUntilRefill(now) <= days
+}
+
+// truncateToDay strips the time of day so day differences are not skewed by hours
+func truncateToDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
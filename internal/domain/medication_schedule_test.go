@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMedicationSchedule_Validate(t *testing.T) {
+	valid := func() MedicationSchedule {
+		return MedicationSchedule{
+			UserID:          "user-1",
+			ConditionID:     "1",
+			MedicationName:  "Metformin",
+			RefillEveryDays: 30,
+			LastFilledDate:  time.Now().AddDate(0, 0, -10),
+			IsActive:        true,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		modify   func(*MedicationSchedule)
+		errorMsg string
+	}{
+		{name: "valid_schedule", modify: func(m *MedicationSchedule) {}},
+		{name: "missing_medication_name", modify: func(m *MedicationSchedule) { m.MedicationName = "" }, errorMsg: "medication name is required"},
+		{name: "zero_refill_interval", modify: func(m *MedicationSchedule) { m.RefillEveryDays = 0 }, errorMsg: "refill interval must be positive"},
+		{name: "negative_refill_interval", modify: func(m *MedicationSchedule) { m.RefillEveryDays = -7 }, errorMsg: "refill interval must be positive"},
+		{name: "future_last_filled_date", modify: func(m *MedicationSchedule) { m.LastFilledDate = time.Now().AddDate(0, 0, 2) }, errorMsg: "last filled date cannot be in the future"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := valid()
+			tt.modify(&schedule)
+
+			err := schedule.Validate()
+
+			if tt.errorMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestMedicationSchedule_DaysUntilRefill(t *testing.T) {
+	now := time.Date(2024, 6, 15, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		lastFilled    time.Time
+		refillEvery   int
+		expectedDays  int
+		expectOverdue bool
+	}{
+		{name: "due_in_three_days", lastFilled: now.AddDate(0, 0, -27), refillEvery: 30, expectedDays: 3},
+		{name: "due_today", lastFilled: now.AddDate(0, 0, -30), refillEvery: 30, expectedDays: 0},
+		{name: "overdue_by_five_days", lastFilled: now.AddDate(0, 0, -35), refillEvery: 30, expectedDays: -5, expectOverdue: true},
+		{name: "filled_late_in_day_still_counts_whole_days", lastFilled: time.Date(2024, 6, 10, 23, 0, 0, 0, time.UTC), refillEvery: 7, expectedDays: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := MedicationSchedule{RefillEveryDays: tt.refillEvery, LastFilledDate: tt.lastFilled}
+
+			assert.Equal(t, tt.expectedDays, schedule.DaysUntilRefill(now))
+			assert.Equal(t, tt.expectOverdue, schedule.IsOverdue(now))
+		})
+	}
+}
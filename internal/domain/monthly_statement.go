@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MinStatementYear is the earliest year a monthly statement can be requested for
+const MinStatementYear = 2000
+
+// StatementMonth returns the first instant of the month and of the month after it, in UTC.
+// Returns an error wrapping ErrInvalidReportPeriod if month isn't 1-12, year is before
+// MinStatementYear or the month starts after now.
+func StatementMonth(year int, month time.Month, now time.Time) (start, end time.Time, err error) {
+	if month < time.January || month > time.December {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: month must be between 1 and 12", ErrInvalidReportPeriod)
+	}
+	if year < MinStatementYear {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: year must be %d or later", ErrInvalidReportPeriod, MinStatementYear)
+	}
+
+	start = time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if start.After(now) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s hasn't started yet", ErrInvalidReportPeriod, start.Format("January 2006"))
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// StatementLine is one line of a monthly statement: what an amount was for and how many
+// records it adds up
+type StatementLine struct {
+	Label  string
+	Amount float64
+	Count  int
+}
+
+// StatementLoanPayment is what was paid toward one loan in a month, split the way its balance
+// entries split it
+type StatementLoanPayment struct {
+	LoanID           string
+	Lender           string
+	Amount           float64
+	InterestPortion  float64
+	PrincipalPortion float64
+	Payments         int
+}
+
+// MonthlyFinanceActivity is the finance side of a monthly statement. Amounts are in Currency.
+type MonthlyFinanceActivity struct {
+	Currency string
+	// Income is the month's income by source, largest first
+	Income      []StatementLine
+	TotalIncome float64
+	// Expenses is the month's spending by category, largest first
+	Expenses      []StatementLine
+	TotalExpenses float64
+	// LoanPayments lists the payments recorded in the month by loan, largest first
+	LoanPayments      []StatementLoanPayment
+	TotalLoanPayments float64
+	// StartScore and EndScore are the financial health score at the start and end of the month;
+	// StartScore is nil when the user had no records yet, and EndScore when they still have none
+	StartScore *FinancialHealthScore
+	EndScore   *FinancialHealthScore
+	// RecordCount is how many records the activity is built from and LastUpdated the latest
+	// change to any of them
+	RecordCount int
+	LastUpdated time.Time
+}
+
+// MonthlyHealthSpend is the medical spending dated in a month
+type MonthlyHealthSpend struct {
+	TotalAmount       float64
+	CoveredAmount     float64
+	OutOfPocketAmount float64
+	ExpenseCount      int
+	LastUpdated       time.Time
+}
+
+// SummarizeHealthSpend totals the medical expenses dated from start up to end. What insurance
+// covered is the insurance payment applied to each expense; the user paid the rest.
+func SummarizeHealthSpend(expenses []MedicalExpense, start, end time.Time) MonthlyHealthSpend {
+	var spend MonthlyHealthSpend
+	for _, expense := range expenses {
+		if expense.Date.Before(start) || !expense.Date.Before(end) {
+			continue
+		}
+		spend.TotalAmount += expense.Amount
+		spend.CoveredAmount += expense.InsurancePayment
+		spend.OutOfPocketAmount += expense.CalculateOutOfPocket()
+		spend.ExpenseCount++
+		if expense.UpdatedAt.After(spend.LastUpdated) {
+			spend.LastUpdated = expense.UpdatedAt
+		}
+	}
+
+	spend.TotalAmount = roundToCents(spend.TotalAmount)
+	spend.CoveredAmount = roundToCents(spend.CoveredAmount)
+	spend.OutOfPocketAmount = roundToCents(spend.OutOfPocketAmount)
+	return spend
+}
+
+// MonthlyStatement is a user's finances and medical spending over one calendar month
+type MonthlyStatement struct {
+	UserID  string
+	Year    int
+	Month   time.Month
+	Finance MonthlyFinanceActivity
+	Health  MonthlyHealthSpend
+}
+
+// Period names the statement's month, such as "March 2025"
+func (s *MonthlyStatement) Period() string {
+	return fmt.Sprintf("%s %d", s.Month, s.Year)
+}
+
+// NetSavings is what was left of the month's income after expenses, loan payments and the
+// medical costs the user paid themselves
+func (s *MonthlyStatement) NetSavings() float64 {
+	return roundToCents(s.Finance.TotalIncome - s.Finance.TotalExpenses - s.Finance.TotalLoanPayments - s.Health.OutOfPocketAmount)
+}
+
+// ScoreChange is how far the financial health score moved over the month, and false when
+// there is no score to compare at either end
+func (s *MonthlyStatement) ScoreChange() (float64, bool) {
+	if s.Finance.StartScore == nil || s.Finance.EndScore == nil {
+		return 0, false
+	}
+	return s.Finance.EndScore.Score - s.Finance.StartScore.Score, true
+}
+
+// IsEmpty returns true if no finance or health record contributes to the statement
+func (s *MonthlyStatement) IsEmpty() bool {
+	return s.Finance.RecordCount == 0 && s.Health.ExpenseCount == 0
+}
+
+// RecordCount is how many records the statement is built from
+func (s *MonthlyStatement) RecordCount() int {
+	return s.Finance.RecordCount + s.Health.ExpenseCount
+}
+
+// LastUpdated is the latest change to any record the statement is built from, or the zero
+// time for an empty statement
+func (s *MonthlyStatement) LastUpdated() time.Time {
+	if s.Health.LastUpdated.After(s.Finance.LastUpdated) {
+		return s.Health.LastUpdated
+	}
+	return s.Finance.LastUpdated
+}
+
+// SortStatementLines orders lines largest amount first, then by label, rounding each amount to cents
+func SortStatementLines(lines []StatementLine) {
+	for i := range lines {
+		lines[i].Amount = roundToCents(lines[i].Amount)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].Amount != lines[j].Amount {
+			return lines[i].Amount > lines[j].Amount
+		}
+		return lines[i].Label < lines[j].Label
+	})
+}
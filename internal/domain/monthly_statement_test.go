@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementMonth(t *testing.T) {
+	now := time.Date(2025, time.March, 10, 9, 0, 0, 0, time.UTC)
+
+	start, end, err := StatementMonth(2025, time.March, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), end, "the current month can be requested")
+
+	start, end, err = StatementMonth(2024, time.December, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), end)
+
+	tests := []struct {
+		name    string
+		year    int
+		month   time.Month
+		message string
+	}{
+		{"month too low", 2025, 0, "month must be between 1 and 12"},
+		{"month too high", 2025, 13, "month must be between 1 and 12"},
+		{"year too early", 1999, time.December, "year must be 2000 or later"},
+		{"future month", 2025, time.April, "April 2025 hasn't started yet"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := StatementMonth(tt.year, tt.month, now)
+
+			require.True(t, errors.Is(err, ErrInvalidReportPeriod))
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestSummarizeHealthSpend(t *testing.T) {
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	expenses := []MedicalExpense{
+		{Amount: 200, InsurancePayment: 160, Date: start, UpdatedAt: start.AddDate(0, 0, 2)},
+		{Amount: 45.5, Date: time.Date(2025, time.March, 31, 23, 0, 0, 0, time.UTC), UpdatedAt: start.AddDate(0, 1, 1)},
+		{Amount: 900, InsurancePayment: 900, Date: end, UpdatedAt: end.AddDate(0, 0, 3)},
+		{Amount: 80, Date: start.Add(-time.Hour), UpdatedAt: start},
+	}
+
+	spend := SummarizeHealthSpend(expenses, start, end)
+
+	assert.Equal(t, MonthlyHealthSpend{
+		TotalAmount:       245.5,
+		CoveredAmount:     160,
+		OutOfPocketAmount: 85.5,
+		ExpenseCount:      2,
+		LastUpdated:       start.AddDate(0, 1, 1),
+	}, spend, "only expenses dated in the month count")
+	assert.Equal(t, MonthlyHealthSpend{}, SummarizeHealthSpend(nil, start, end))
+}
+
+func TestMonthlyStatement_Figures(t *testing.T) {
+	financeUpdated := time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)
+	healthUpdated := time.Date(2025, time.March, 25, 0, 0, 0, 0, time.UTC)
+	statement := MonthlyStatement{
+		Year:  2025,
+		Month: time.March,
+		Finance: MonthlyFinanceActivity{
+			TotalIncome:       5000,
+			TotalExpenses:     2100.25,
+			TotalLoanPayments: 400,
+			StartScore:        &FinancialHealthScore{Score: 62.5},
+			EndScore:          &FinancialHealthScore{Score: 70},
+			RecordCount:       6,
+			LastUpdated:       financeUpdated,
+		},
+		Health: MonthlyHealthSpend{OutOfPocketAmount: 85.5, ExpenseCount: 2, LastUpdated: healthUpdated},
+	}
+
+	assert.Equal(t, "March 2025", statement.Period())
+	assert.Equal(t, 2414.25, statement.NetSavings())
+	change, ok := statement.ScoreChange()
+	assert.True(t, ok)
+	assert.Equal(t, 7.5, change)
+	assert.False(t, statement.IsEmpty())
+	assert.Equal(t, 8, statement.RecordCount())
+	assert.Equal(t, healthUpdated, statement.LastUpdated())
+
+	statement.Finance.StartScore = nil
+	_, ok = statement.ScoreChange()
+	assert.False(t, ok, "no change without a score at the start of the month")
+
+	empty := MonthlyStatement{Year: 2025, Month: time.March}
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, 0, empty.RecordCount())
+	assert.True(t, empty.LastUpdated().IsZero())
+}
+
+func TestSortStatementLines(t *testing.T) {
+	lines := []StatementLine{
+		{Label: "food", Amount: 400.004},
+		{Label: "transport", Amount: 1500},
+		{Label: "housing", Amount: 1500},
+	}
+
+	SortStatementLines(lines)
+
+	assert.Equal(t, []StatementLine{
+		{Label: "housing", Amount: 1500},
+		{Label: "transport", Amount: 1500},
+		{Label: "food", Amount: 400},
+	}, lines)
+}
@@ -0,0 +1,148 @@
+package domain
+
+import "math"
+
+// Financial resilience grades, from most to least resilient
+const (
+	ResilienceGradeA = "A"
+	ResilienceGradeB = "B"
+	ResilienceGradeC = "C"
+	ResilienceGradeD = "D"
+	ResilienceGradeF = "F"
+)
+
+// Resilience score weights. Finance-only scores are scaled up to the same 0-100 range.
+const (
+	resilienceDisposableWeight = 40
+	resilienceDebtWeight       = 30
+	resilienceEmergencyWeight  = 30
+)
+
+// FinancialResilience combines the finance and health summaries into cross-domain figures
+type FinancialResilience struct {
+	// HealthAdjustedDisposableIncome is disposable income after monthly medical costs and premiums
+	HealthAdjustedDisposableIncome float64
+	// EmergencyFundCoverageMonths is how many months of expenses, loan payments and health costs
+	// the health emergency fund covers. Nil when there is no health summary or nothing to cover.
+	EmergencyFundCoverageMonths *float64
+	// Score is 0-100; Grade is A-F
+	Score int
+	Grade string
+	// IncludesHealth reports whether the health summary contributed to the score
+	IncludesHealth bool
+}
+
+// CalculateFinancialResilience derives the cross-domain figures for a finance summary and an
+// optional health summary. Without a health summary the score is based on finance alone.
+func CalculateFinancialResilience(finance FinanceSummary, health *HealthSummary) FinancialResilience {
+	resilience := FinancialResilience{
+		HealthAdjustedDisposableIncome: finance.DisposableIncome,
+		IncludesHealth:                 health != nil,
+	}
+
+	monthlyHealthCosts := 0.0
+	if health != nil {
+		monthlyHealthCosts = health.MonthlyMedicalExpenses + health.MonthlyInsurancePremiums
+		resilience.HealthAdjustedDisposableIncome = roundToCents(finance.DisposableIncome - monthlyHealthCosts)
+	}
+
+	score := disposableIncomePoints(resilience.HealthAdjustedDisposableIncome, finance.MonthlyIncome) +
+		debtToIncomePoints(finance.DebtToIncomeRatio)
+
+	if health == nil {
+		score = score * 100 / (resilienceDisposableWeight + resilienceDebtWeight)
+	} else {
+		monthlyOutgoings := finance.MonthlyExpenses + finance.MonthlyLoanPayments + monthlyHealthCosts
+		if monthlyOutgoings > 0 {
+			months := math.Round(health.EmergencyFundBalance/monthlyOutgoings*10) / 10
+			resilience.EmergencyFundCoverageMonths = &months
+			score += emergencyCoveragePoints(months)
+		}
+
+		// High health risk makes the same savings less of a cushion
+		switch health.HealthRiskLevel {
+		case "critical":
+			score -= 10
+		case "high":
+			score -= 5
+		}
+	}
+
+	resilience.Score = clampScore(score)
+	resilience.Grade = ResilienceGrade(resilience.Score)
+	return resilience
+}
+
+// ResilienceGrade converts a 0-100 resilience score to a letter grade
+func ResilienceGrade(score int) string {
+	switch {
+	case score >= 85:
+		return ResilienceGradeA
+	case score >= 70:
+		return ResilienceGradeB
+	case score >= 55:
+		return ResilienceGradeC
+	case score >= 40:
+		return ResilienceGradeD
+	default:
+		return ResilienceGradeF
+	}
+}
+
+// disposableIncomePoints scores the share of income left each month
+func disposableIncomePoints(disposable, income float64) int {
+	if income <= 0 || disposable <= 0 {
+		return 0
+	}
+
+	switch share := disposable / income; {
+	case share >= MinimumSavingsRate:
+		return resilienceDisposableWeight
+	case share >= FairSavingsRate:
+		return resilienceDisposableWeight * 3 / 4
+	default:
+		return resilienceDisposableWeight * 3 / 8
+	}
+}
+
+// debtToIncomePoints scores the debt-to-income ratio using the finance health thresholds
+func debtToIncomePoints(ratio float64) int {
+	switch {
+	case ratio <= ExcellentDebtToIncomeRatio:
+		return resilienceDebtWeight
+	case ratio <= HealthyDebtToIncomeRatio:
+		return resilienceDebtWeight * 2 / 3
+	case ratio <= PoorDebtToIncomeRatio:
+		return resilienceDebtWeight / 3
+	default:
+		return 0
+	}
+}
+
+// emergencyCoveragePoints scores emergency fund coverage against the usual 3-6 month guidance
+func emergencyCoveragePoints(months float64) int {
+	switch {
+	case months >= 6:
+		return resilienceEmergencyWeight
+	case months >= 3:
+		return resilienceEmergencyWeight * 2 / 3
+	case months >= 1:
+		return resilienceEmergencyWeight / 3
+	default:
+		return 0
+	}
+}
+
+func clampScore(score int) int {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+func roundToCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateFinancialResilience(t *testing.T) {
+	healthyFinance := FinanceSummary{
+		MonthlyIncome:       8000,
+		MonthlyExpenses:     4000,
+		MonthlyLoanPayments: 1600,
+		DisposableIncome:    2400,
+		DebtToIncomeRatio:   0.20,
+	}
+	strainedFinance := FinanceSummary{
+		MonthlyIncome:       5000,
+		MonthlyExpenses:     2400,
+		MonthlyLoanPayments: 2000,
+		DisposableIncome:    600,
+		DebtToIncomeRatio:   0.40,
+	}
+
+	tests := []struct {
+		name         string
+		finance      FinanceSummary
+		health       *HealthSummary
+		wantAdjusted float64
+		wantCoverage *float64
+		wantScore    int
+		wantGrade    string
+	}{
+		{
+			name:         "finance_only_scaled_to_full_range",
+			finance:      healthyFinance,
+			health:       nil,
+			wantAdjusted: 2400,
+			wantCoverage: nil,
+			wantScore:    100,
+			wantGrade:    ResilienceGradeA,
+		},
+		{
+			name:    "health_costs_and_emergency_fund",
+			finance: healthyFinance,
+			health: &HealthSummary{
+				HealthRiskLevel:          "low",
+				MonthlyMedicalExpenses:   300,
+				MonthlyInsurancePremiums: 200,
+				EmergencyFundBalance:     12200,
+			},
+			wantAdjusted: 1900,
+			wantCoverage: floatPtr(2.0),
+			wantScore:    80,
+			wantGrade:    ResilienceGradeB,
+		},
+		{
+			name:    "critical_risk_without_savings",
+			finance: strainedFinance,
+			health: &HealthSummary{
+				HealthRiskLevel:          "critical",
+				MonthlyMedicalExpenses:   400,
+				MonthlyInsurancePremiums: 300,
+			},
+			wantAdjusted: -100,
+			wantCoverage: floatPtr(0),
+			wantScore:    0,
+			wantGrade:    ResilienceGradeF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resilience := CalculateFinancialResilience(tt.finance, tt.health)
+
+			assert.Equal(t, tt.wantAdjusted, resilience.HealthAdjustedDisposableIncome)
+			if tt.wantCoverage == nil {
+				assert.Nil(t, resilience.EmergencyFundCoverageMonths)
+			} else {
+				require.NotNil(t, resilience.EmergencyFundCoverageMonths)
+				assert.Equal(t, *tt.wantCoverage, *resilience.EmergencyFundCoverageMonths)
+			}
+			assert.Equal(t, tt.wantScore, resilience.Score)
+			assert.Equal(t, tt.wantGrade, resilience.Grade)
+			assert.Equal(t, tt.health != nil, resilience.IncludesHealth)
+		})
+	}
+}
+
+func TestResilienceGrade(t *testing.T) {
+	tests := []struct {
+		score int
+		want  string
+	}{
+		{100, ResilienceGradeA},
+		{85, ResilienceGradeA},
+		{84, ResilienceGradeB},
+		{70, ResilienceGradeB},
+		{55, ResilienceGradeC},
+		{40, ResilienceGradeD},
+		{39, ResilienceGradeF},
+		{0, ResilienceGradeF},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ResilienceGrade(tt.score), "score %d", tt.score)
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// ProfileSnapshot records a health profile's body measurements at a point in time.
+// A snapshot of the previous values is written whenever a profile is updated.
+type ProfileSnapshot struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	ProfileID  string    `json:"profile_id"`
+	Age        int       `json:"age"`
+	Height     float64   `json:"height"` // in cm
+	Weight     float64   `json:"weight"` // in kg
+	BMI        float64   `json:"bmi"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// NewProfileSnapshot captures the profile's current measurements, computing BMI from height and weight
+func NewProfileSnapshot(profile *HealthProfile, recordedAt time.Time) *ProfileSnapshot {
+	snapshot := &ProfileSnapshot{
+		UserID:     profile.UserID,
+		ProfileID:  profile.ID,
+		Age:        profile.Age,
+		Height:     profile.Height,
+		Weight:     profile.Weight,
+		BMI:        profile.BMI,
+		RecordedAt: recordedAt,
+	}
+
+	if bmi, err := profile.CalculateBMI(); err == nil {
+		snapshot.BMI = bmi
+	}
+
+	return snapshot
+}
+
+// WeightChangeTo returns the weight change from this snapshot to a later one, rounded to 2 decimal places
+func (s *ProfileSnapshot) WeightChangeTo(later *ProfileSnapshot) float64 {
+	return math.Round((later.Weight-s.Weight)*100) / 100
+}
+
+// BMIChangeTo returns the BMI change from this snapshot to a later one, rounded to 2 decimal places
+func (s *ProfileSnapshot) BMIChangeTo(later *ProfileSnapshot) float64 {
+	return math.Round((later.BMI-s.BMI)*100) / 100
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProfileSnapshot_ComputesBMI(t *testing.T) {
+	recordedAt := time.Now()
+	profile := &HealthProfile{
+		ID: "7", UserID: "user-123", Age: 40, Height: 170, Weight: 85, BMI: 0,
+	}
+
+	snapshot := NewProfileSnapshot(profile, recordedAt)
+
+	assert.Equal(t, "user-123", snapshot.UserID)
+	assert.Equal(t, "7", snapshot.ProfileID)
+	assert.Equal(t, 85.0, snapshot.Weight)
+	assert.Equal(t, 29.41, snapshot.BMI)
+	assert.Equal(t, recordedAt, snapshot.RecordedAt)
+}
+
+func TestProfileSnapshot_ChangeTo(t *testing.T) {
+	earlier := &ProfileSnapshot{Weight: 90.4, BMI: 27.9}
+	later := &ProfileSnapshot{Weight: 86.1, BMI: 26.57}
+
+	assert.Equal(t, -4.3, earlier.WeightChangeTo(later))
+	assert.Equal(t, -1.33, earlier.BMIChangeTo(later))
+	assert.Equal(t, 4.3, later.WeightChangeTo(earlier))
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Ways to pay for a purchase
+const (
+	PaymentMethodCash         = "cash"
+	PaymentMethodInstallments = "installments"
+)
+
+// Purchase affordability verdicts
+const (
+	PurchaseAllowed        = "allowed"
+	PurchaseBorderline     = "borderline"
+	PurchaseNotRecommended = "not_recommended"
+)
+
+// Factors that can hold a purchase back from being allowed
+const (
+	LimitingFactorDisposableIncome = "disposable_income"
+	LimitingFactorDebtToIncome     = "debt_to_income"
+	LimitingFactorSavingsRate      = "savings_rate"
+)
+
+// MaxPurchaseMonths caps both the installment term and the cash saving horizon
+const MaxPurchaseMonths = MaxAmortizationMonths
+
+// PurchasePlan describes a purchase and how the user would pay for it. Price is in the base currency.
+type PurchasePlan struct {
+	Price         float64
+	PaymentMethod string
+	// Months is the number of monthly installments; only used for installments
+	Months int
+	// InterestRate is the annual rate charged on installments, as a percentage
+	InterestRate float64
+	// HorizonMonths is how many months of disposable income may be saved up to pay cash; 0 means 1
+	HorizonMonths int
+}
+
+// Validate checks the purchase plan. Returns an error wrapping ErrInvalidPurchaseData.
+func (p PurchasePlan) Validate() error {
+	if p.Price <= 0 {
+		return fmt.Errorf("%w: price must be greater than 0", ErrInvalidPurchaseData)
+	}
+
+	switch p.PaymentMethod {
+	case PaymentMethodCash:
+		if p.HorizonMonths < 0 || p.HorizonMonths > MaxPurchaseMonths {
+			return fmt.Errorf("%w: horizon_months must be between 0 and %d", ErrInvalidPurchaseData, MaxPurchaseMonths)
+		}
+	case PaymentMethodInstallments:
+		if p.Months <= 0 || p.Months > MaxPurchaseMonths {
+			return fmt.Errorf("%w: months must be between 1 and %d for installments", ErrInvalidPurchaseData, MaxPurchaseMonths)
+		}
+		if p.InterestRate < 0 || p.InterestRate > 100 {
+			return fmt.Errorf("%w: interest_rate must be between 0 and 100", ErrInvalidPurchaseData)
+		}
+	default:
+		return fmt.Errorf("%w: payment_method must be %q or %q", ErrInvalidPurchaseData, PaymentMethodCash, PaymentMethodInstallments)
+	}
+	return nil
+}
+
+// MonthlyInstallment returns the fixed monthly payment that repays principal over months at an
+// annual interest rate, as a percentage, compounded monthly
+func MonthlyInstallment(principal, interestRate float64, months int) float64 {
+	if principal <= 0 || months <= 0 {
+		return 0
+	}
+	if interestRate <= 0 {
+		return principal / float64(months)
+	}
+
+	monthlyRate := interestRate / 100.0 / 12.0
+	factor := math.Pow(1+monthlyRate, float64(months))
+	return principal * monthlyRate * factor / (factor - 1)
+}
+
+// PurchaseAffordability is the verdict on a planned purchase. Amounts are in the summary's currency
+// and ratios are fractions of monthly income.
+type PurchaseAffordability struct {
+	UserID   string
+	Currency string
+	Plan     PurchasePlan
+	Verdict  string
+	// LimitingFactor names what kept the purchase from being allowed; empty when it is allowed
+	LimitingFactor string
+
+	// Cash: the disposable income saved up over the horizon
+	AvailableCash float64
+
+	// Installments: the payment each month and what is repaid in total
+	MonthlyPayment float64
+	TotalCost      float64
+
+	// Ratios after the purchase. For cash the savings rate is averaged over the horizon and the
+	// debt-to-income ratio is unchanged.
+	DebtToIncomeRatio float64
+	SavingsRate       float64
+}
+
+// AssessPurchase decides whether the purchase fits the summary's finances under t.
+// A cash purchase is weighed against the disposable income saved over the horizon; an installment
+// purchase adds its monthly payment to the user's debt. The purchase is not recommended if it
+// spends more than the user has left or, on installments, pushes the debt-to-income ratio above
+// t.PoorDTI; it is borderline above t.HealthyDTI or when the savings rate drops below t.FairSavingsRate.
+// Returns an error wrapping ErrInvalidPurchaseData if the plan is invalid, or ErrNoIncome if the
+// summary has no monthly income to weigh the purchase against.
+func (fs *FinanceSummary) AssessPurchase(plan PurchasePlan, t FinancialThresholds) (PurchaseAffordability, error) {
+	if err := plan.Validate(); err != nil {
+		return PurchaseAffordability{}, err
+	}
+	if fs.MonthlyIncome <= 0 {
+		return PurchaseAffordability{}, ErrNoIncome
+	}
+
+	result := PurchaseAffordability{
+		UserID:            fs.UserID,
+		Currency:          fs.Currency,
+		Plan:              plan,
+		DebtToIncomeRatio: fs.DebtToIncomeRatio,
+	}
+
+	var remaining float64 // Disposable income left each month after the purchase
+	if plan.PaymentMethod == PaymentMethodCash {
+		if result.Plan.HorizonMonths == 0 {
+			result.Plan.HorizonMonths = 1
+		}
+		horizon := float64(result.Plan.HorizonMonths)
+		result.AvailableCash = roundToCents(math.Max(fs.DisposableIncome, 0) * horizon)
+		remaining = (fs.DisposableIncome*horizon - plan.Price) / horizon
+	} else {
+		payment := MonthlyInstallment(plan.Price, plan.InterestRate, plan.Months)
+		result.MonthlyPayment = roundToCents(payment)
+		result.TotalCost = roundToCents(payment * float64(plan.Months))
+		result.DebtToIncomeRatio = (fs.MonthlyLoanPayments + payment) / fs.MonthlyIncome
+		remaining = fs.DisposableIncome - payment
+	}
+	result.SavingsRate = remaining / fs.MonthlyIncome
+
+	// Paying cash adds no debt, so only installments are held back by the debt-to-income ratio
+	installments := plan.PaymentMethod == PaymentMethodInstallments
+	switch {
+	case installments && result.DebtToIncomeRatio > t.PoorDTI:
+		result.Verdict, result.LimitingFactor = PurchaseNotRecommended, LimitingFactorDebtToIncome
+	case remaining < 0:
+		result.Verdict, result.LimitingFactor = PurchaseNotRecommended, LimitingFactorDisposableIncome
+	case installments && result.DebtToIncomeRatio > t.HealthyDTI:
+		result.Verdict, result.LimitingFactor = PurchaseBorderline, LimitingFactorDebtToIncome
+	case result.SavingsRate < t.FairSavingsRate:
+		result.Verdict, result.LimitingFactor = PurchaseBorderline, LimitingFactorSavingsRate
+	default:
+		result.Verdict = PurchaseAllowed
+	}
+	return result, nil
+}
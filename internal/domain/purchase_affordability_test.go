@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func purchaseTestSummary() *FinanceSummary {
+	return &FinanceSummary{
+		UserID:              "user-1",
+		Currency:            "USD",
+		MonthlyIncome:       6000,
+		MonthlyExpenses:     2000,
+		MonthlyLoanPayments: 400,
+		DisposableIncome:    3600,
+		DebtToIncomeRatio:   400.0 / 6000.0,
+		SavingsRate:         0.6,
+	}
+}
+
+func TestFinanceSummary_AssessPurchase(t *testing.T) {
+	tests := []struct {
+		name           string
+		plan           PurchasePlan
+		verdict        string
+		limitingFactor string
+	}{
+		{"cash_within_month", PurchasePlan{Price: 2000, PaymentMethod: PaymentMethodCash}, PurchaseAllowed, ""},
+		{"cash_beyond_month", PurchasePlan{Price: 5000, PaymentMethod: PaymentMethodCash}, PurchaseNotRecommended, LimitingFactorDisposableIncome},
+		{"cash_saved_over_horizon", PurchasePlan{Price: 5000, PaymentMethod: PaymentMethodCash, HorizonMonths: 2}, PurchaseAllowed, ""},
+		{"cash_leaves_little_saved", PurchasePlan{Price: 6800, PaymentMethod: PaymentMethodCash, HorizonMonths: 2}, PurchaseBorderline, LimitingFactorSavingsRate},
+		{"installments_healthy", PurchasePlan{Price: 12000, PaymentMethod: PaymentMethodInstallments, Months: 12}, PurchaseAllowed, ""},
+		{"installments_above_healthy_dti", PurchasePlan{Price: 24000, PaymentMethod: PaymentMethodInstallments, Months: 12}, PurchaseBorderline, LimitingFactorDebtToIncome},
+		{"installments_above_poor_dti", PurchasePlan{Price: 36000, PaymentMethod: PaymentMethodInstallments, Months: 12}, PurchaseNotRecommended, LimitingFactorDebtToIncome},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := purchaseTestSummary().AssessPurchase(tt.plan, DefaultFinancialThresholds())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.verdict, result.Verdict)
+			assert.Equal(t, tt.limitingFactor, result.LimitingFactor)
+		})
+	}
+}
+
+func TestFinanceSummary_AssessPurchase_Installments(t *testing.T) {
+	plan := PurchasePlan{Price: 12000, PaymentMethod: PaymentMethodInstallments, Months: 12, InterestRate: 12}
+
+	result, err := purchaseTestSummary().AssessPurchase(plan, DefaultFinancialThresholds())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1066.19, result.MonthlyPayment)
+	assert.Equal(t, 12794.23, result.TotalCost)
+	assert.InDelta(t, (400+1066.19)/6000.0, result.DebtToIncomeRatio, 0.0001)
+	assert.InDelta(t, (3600-1066.19)/6000.0, result.SavingsRate, 0.0001)
+	assert.Zero(t, result.AvailableCash)
+}
+
+func TestFinanceSummary_AssessPurchase_CashDefaultsToOneMonth(t *testing.T) {
+	result, err := purchaseTestSummary().AssessPurchase(PurchasePlan{Price: 1000, PaymentMethod: PaymentMethodCash}, DefaultFinancialThresholds())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Plan.HorizonMonths)
+	assert.Equal(t, 3600.0, result.AvailableCash)
+	assert.Equal(t, 400.0/6000.0, result.DebtToIncomeRatio)
+}
+
+func TestFinanceSummary_AssessPurchase_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		plan PurchasePlan
+	}{
+		{"zero_price", PurchasePlan{Price: 0, PaymentMethod: PaymentMethodCash}},
+		{"unknown_method", PurchasePlan{Price: 100, PaymentMethod: "barter"}},
+		{"zero_months", PurchasePlan{Price: 100, PaymentMethod: PaymentMethodInstallments}},
+		{"negative_rate", PurchasePlan{Price: 100, PaymentMethod: PaymentMethodInstallments, Months: 6, InterestRate: -1}},
+		{"negative_horizon", PurchasePlan{Price: 100, PaymentMethod: PaymentMethodCash, HorizonMonths: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := purchaseTestSummary().AssessPurchase(tt.plan, DefaultFinancialThresholds())
+
+			assert.ErrorIs(t, err, ErrInvalidPurchaseData)
+		})
+	}
+}
+
+func TestFinanceSummary_AssessPurchase_NoIncome(t *testing.T) {
+	summary := &FinanceSummary{UserID: "user-1"}
+
+	_, err := summary.AssessPurchase(PurchasePlan{Price: 100, PaymentMethod: PaymentMethodCash}, DefaultFinancialThresholds())
+
+	assert.ErrorIs(t, err, ErrNoIncome)
+}
+
+func TestMonthlyInstallment(t *testing.T) {
+	assert.Equal(t, 500.0, MonthlyInstallment(6000, 0, 12))
+	assert.InDelta(t, 1066.19, MonthlyInstallment(12000, 12, 12), 0.005)
+	assert.Zero(t, MonthlyInstallment(6000, 5, 0))
+}
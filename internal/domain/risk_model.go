@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// MaxHealthRiskScore caps the health risk score
+const MaxHealthRiskScore = 100
+
+// Health risk levels, from lowest to highest
+const (
+	RiskLevelLow      = "low"
+	RiskLevelModerate = "moderate"
+	RiskLevelHigh     = "high"
+	RiskLevelCritical = "critical"
+)
+
+// Factors that add to a health risk score
+const (
+	RiskFactorAge        = "age"
+	RiskFactorBMI        = "bmi"
+	RiskFactorFamilySize = "family_size"
+	RiskFactorCondition  = "condition"
+)
+
+// RiskBand awards points to values from Min up to Max.
+// Min is inclusive and Max exclusive unless IncludeMax is set.
+// A Max of 0 leaves the band open-ended; only the last band of a list may be open.
+type RiskBand struct {
+	Min        float64
+	Max        float64
+	IncludeMax bool
+	Points     int
+}
+
+// IsOpenEnded returns true if the band has no upper bound
+func (b RiskBand) IsOpenEnded() bool {
+	return b.Max == 0
+}
+
+// coversUpTo returns true if value is not above the band's upper bound
+func (b RiskBand) coversUpTo(value float64) bool {
+	if b.IsOpenEnded() {
+		return true
+	}
+	return value < b.Max || (b.IncludeMax && value == b.Max)
+}
+
+// RiskLevelCutoffs holds the highest score of each risk level; scores above High are critical
+type RiskLevelCutoffs struct {
+	Low      int
+	Moderate int
+	High     int
+}
+
+// RiskModel holds the point values used to score health risk.
+// Conditions score SeverityPoints[severity] scaled by CategoryWeights[category];
+// an unrecognized severity scores as mild and an unweighted category has a weight of 1.
+type RiskModel struct {
+	AgeBands        []RiskBand
+	BMIBands        []RiskBand
+	FamilySizeBands []RiskBand
+	SeverityPoints  map[string]int
+	CategoryWeights map[string]float64
+	LevelCutoffs    RiskLevelCutoffs
+}
+
+// DefaultRiskModel returns the standard scoring model:
+// - Age: <30 (0pts), 30-40 (5pts), 40-50 (10pts), 50-60 (15pts), 60+ (20pts)
+// - BMI: 18.5-25 (0pts), 25-30 (8pts), <18.5 or >30 (15pts)
+// - Conditions: mild (2pts), moderate (5pts), severe (10pts), critical (15pts), any category
+// - Family size: 1-2 (0pts), 3-4 (5pts), 5+ (10pts)
+// - Levels: 0-25 (low), 26-50 (moderate), 51-75 (high), 76-100 (critical)
+func DefaultRiskModel() RiskModel {
+	return RiskModel{
+		AgeBands: []RiskBand{
+			{Min: 0, Max: 30, Points: 0},
+			{Min: 30, Max: 40, Points: 5},
+			{Min: 40, Max: 50, Points: 10},
+			{Min: 50, Max: 60, Points: 15},
+			{Min: 60, Points: 20},
+		},
+		BMIBands: []RiskBand{
+			{Min: 0, Max: 18.5, Points: 15},
+			{Min: 18.5, Max: 25, IncludeMax: true, Points: 0},
+			{Min: 25, Max: 30, IncludeMax: true, Points: 8},
+			{Min: 30, Points: 15},
+		},
+		FamilySizeBands: []RiskBand{
+			{Min: 0, Max: 3, Points: 0},
+			{Min: 3, Max: 5, Points: 5},
+			{Min: 5, Points: 10},
+		},
+		SeverityPoints: map[string]int{
+			"mild":     2,
+			"moderate": 5,
+			"severe":   10,
+			"critical": 15,
+		},
+		CategoryWeights: map[string]float64{
+			"chronic":       1,
+			"acute":         1,
+			"mental_health": 1,
+			"preventive":    1,
+		},
+		LevelCutoffs: RiskLevelCutoffs{Low: 25, Moderate: 50, High: 75},
+	}
+}
+
+// Validate checks that every band list is contiguous and covers all values from its first band up,
+// that every severity has points, and that the level cutoffs ascend below MaxHealthRiskScore
+func (m RiskModel) Validate() error {
+	bandLists := []struct {
+		name  string
+		bands []RiskBand
+	}{
+		{"age", m.AgeBands},
+		{"BMI", m.BMIBands},
+		{"family size", m.FamilySizeBands},
+	}
+	for _, list := range bandLists {
+		if err := validateRiskBands(list.bands); err != nil {
+			return fmt.Errorf("%w: %s bands: %v", ErrInvalidRiskModel, list.name, err)
+		}
+	}
+
+	for _, severity := range []string{"mild", "moderate", "severe", "critical"} {
+		points, ok := m.SeverityPoints[severity]
+		if !ok {
+			return fmt.Errorf("%w: severity %q has no points", ErrInvalidRiskModel, severity)
+		}
+		if points < 0 {
+			return fmt.Errorf("%w: severity %q points cannot be negative", ErrInvalidRiskModel, severity)
+		}
+	}
+
+	for category, weight := range m.CategoryWeights {
+		if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("%w: category %q weight must be a non-negative number", ErrInvalidRiskModel, category)
+		}
+	}
+
+	cutoffs := m.LevelCutoffs
+	if cutoffs.Low < 0 || cutoffs.Low >= cutoffs.Moderate || cutoffs.Moderate >= cutoffs.High || cutoffs.High >= MaxHealthRiskScore {
+		return fmt.Errorf("%w: level cutoffs must ascend from 0 and stay below %d, got %d, %d, %d",
+			ErrInvalidRiskModel, MaxHealthRiskScore, cutoffs.Low, cutoffs.Moderate, cutoffs.High)
+	}
+
+	return nil
+}
+
+// validateRiskBands checks a single band list
+func validateRiskBands(bands []RiskBand) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("at least one band is required")
+	}
+
+	for i, band := range bands {
+		if band.Points < 0 {
+			return fmt.Errorf("band %d points cannot be negative", i+1)
+		}
+		if band.Min < 0 {
+			return fmt.Errorf("band %d min cannot be negative", i+1)
+		}
+
+		last := i == len(bands)-1
+		if band.IsOpenEnded() != last {
+			return fmt.Errorf("only the last band must be open-ended (max 0)")
+		}
+		if !last && band.Max <= band.Min {
+			return fmt.Errorf("band %d max must be greater than its min", i+1)
+		}
+		if i > 0 && band.Min != bands[i-1].Max {
+			return fmt.Errorf("band %d starts at %g but the previous band ends at %g", i+1, band.Min, bands[i-1].Max)
+		}
+	}
+
+	return nil
+}
+
+// BandPoints returns the points of the band containing value.
+// Values below the first band score as the first band.
+func BandPoints(bands []RiskBand, value float64) int {
+	for _, band := range bands {
+		if band.coversUpTo(value) {
+			return band.Points
+		}
+	}
+	if len(bands) == 0 {
+		return 0
+	}
+	return bands[len(bands)-1].Points
+}
+
+// ConditionPoints returns the points an active condition adds to the risk score
+func (m RiskModel) ConditionPoints(severity, category string) int {
+	points, ok := m.SeverityPoints[severity]
+	if !ok {
+		points = m.SeverityPoints["mild"]
+	}
+
+	return m.weighCategory(points, category)
+}
+
+// CatalogConditionPoints returns the points an active condition added from the condition catalog
+// adds to the risk score: the entry's risk weight in place of the severity points
+func (m RiskModel) CatalogConditionPoints(riskWeight int, category string) int {
+	return m.weighCategory(riskWeight, category)
+}
+
+// weighCategory scales points by the category weight
+func (m RiskModel) weighCategory(points int, category string) int {
+	weight, ok := m.CategoryWeights[category]
+	if !ok {
+		weight = 1
+	}
+
+	return int(math.Round(float64(points) * weight))
+}
+
+// conditionPoints returns the points an active condition adds to the risk score
+func (m RiskModel) conditionPoints(condition MedicalCondition) int {
+	if condition.IsCatalogued() {
+		return m.CatalogConditionPoints(condition.CatalogRiskWeight, condition.Category)
+	}
+	return m.ConditionPoints(condition.Severity, condition.Category)
+}
+
+// RiskLevel converts a risk score to its risk level
+func (m RiskModel) RiskLevel(score int) string {
+	switch {
+	case score <= m.LevelCutoffs.Low:
+		return RiskLevelLow
+	case score <= m.LevelCutoffs.Moderate:
+		return RiskLevelModerate
+	case score <= m.LevelCutoffs.High:
+		return RiskLevelHigh
+	default:
+		return RiskLevelCritical
+	}
+}
+
+// RiskFactorPoints is what one factor adds to a risk score. A condition factor names the
+// condition it stands for.
+type RiskFactorPoints struct {
+	Factor      string
+	ConditionID string
+	Name        string
+	Points      int
+}
+
+// Breakdown returns the points each factor adds to the risk score of profile: its age, BMI and
+// family size, then each active condition in order. The score is their sum capped at
+// MaxHealthRiskScore. The profile's BMI is calculated from its height and weight if it isn't set.
+func (m RiskModel) Breakdown(profile *HealthProfile, conditions []MedicalCondition) []RiskFactorPoints {
+	bmi := profile.BMI
+	if bmi == 0 {
+		bmi, _ = profile.CalculateBMI()
+	}
+
+	factors := []RiskFactorPoints{
+		{Factor: RiskFactorAge, Points: BandPoints(m.AgeBands, float64(profile.Age))},
+		{Factor: RiskFactorBMI, Points: BandPoints(m.BMIBands, bmi)},
+		{Factor: RiskFactorFamilySize, Points: BandPoints(m.FamilySizeBands, float64(profile.FamilySize))},
+	}
+	for _, condition := range conditions {
+		if condition.IsActive {
+			factors = append(factors, RiskFactorPoints{
+				Factor:      RiskFactorCondition,
+				ConditionID: condition.ID,
+				Name:        condition.Name,
+				Points:      m.conditionPoints(condition),
+			})
+		}
+	}
+	return factors
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskModel_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(m *RiskModel)
+		wantErr bool
+	}{
+		{
+			name:    "default_model_is_valid",
+			modify:  func(m *RiskModel) {},
+			wantErr: false,
+		},
+		{
+			name: "gap_between_bands",
+			modify: func(m *RiskModel) {
+				m.AgeBands = []RiskBand{{Min: 0, Max: 30}, {Min: 35, Points: 10}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "overlapping_bands",
+			modify: func(m *RiskModel) {
+				m.AgeBands = []RiskBand{{Min: 0, Max: 30}, {Min: 25, Points: 10}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "open_ended_band_not_last",
+			modify: func(m *RiskModel) {
+				m.FamilySizeBands = []RiskBand{{Min: 0}, {Min: 3, Max: 5, Points: 5}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "last_band_not_open_ended",
+			modify: func(m *RiskModel) {
+				m.FamilySizeBands = []RiskBand{{Min: 0, Max: 3}, {Min: 3, Max: 5, Points: 5}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty_bands",
+			modify: func(m *RiskModel) {
+				m.BMIBands = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative_points",
+			modify: func(m *RiskModel) {
+				m.AgeBands[0].Points = -1
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing_severity",
+			modify: func(m *RiskModel) {
+				delete(m.SeverityPoints, "critical")
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative_category_weight",
+			modify: func(m *RiskModel) {
+				m.CategoryWeights["chronic"] = -0.5
+			},
+			wantErr: true,
+		},
+		{
+			name: "cutoffs_not_ascending",
+			modify: func(m *RiskModel) {
+				m.LevelCutoffs = RiskLevelCutoffs{Low: 50, Moderate: 25, High: 75}
+			},
+			wantErr: true,
+		},
+		{
+			name: "cutoff_at_maximum_score",
+			modify: func(m *RiskModel) {
+				m.LevelCutoffs = RiskLevelCutoffs{Low: 25, Moderate: 50, High: 100}
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := DefaultRiskModel()
+			tt.modify(&model)
+
+			err := model.Validate()
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRiskModel)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBandPoints_Boundaries(t *testing.T) {
+	model := DefaultRiskModel()
+
+	tests := []struct {
+		name     string
+		bands    []RiskBand
+		value    float64
+		expected int
+	}{
+		{"age_below_first_band", model.AgeBands, -1, 0},
+		{"age_29", model.AgeBands, 29, 0},
+		{"age_30_starts_next_band", model.AgeBands, 30, 5},
+		{"age_60_open_ended", model.AgeBands, 60, 20},
+		{"age_far_above", model.AgeBands, 200, 20},
+		{"bmi_underweight", model.BMIBands, 18.49, 15},
+		{"bmi_18_5_normal", model.BMIBands, 18.5, 0},
+		{"bmi_25_still_normal", model.BMIBands, 25, 0},
+		{"bmi_just_over_25", model.BMIBands, 25.01, 8},
+		{"bmi_30_still_overweight", model.BMIBands, 30, 8},
+		{"bmi_just_over_30", model.BMIBands, 30.01, 15},
+		{"family_2", model.FamilySizeBands, 2, 0},
+		{"family_3", model.FamilySizeBands, 3, 5},
+		{"family_5", model.FamilySizeBands, 5, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BandPoints(tt.bands, tt.value))
+		})
+	}
+}
+
+func TestRiskModel_ConditionPoints(t *testing.T) {
+	model := DefaultRiskModel()
+	model.CategoryWeights["mental_health"] = 1.5
+
+	assert.Equal(t, 10, model.ConditionPoints("severe", "chronic"))
+	assert.Equal(t, 8, model.ConditionPoints("moderate", "mental_health"))
+	assert.Equal(t, 2, model.ConditionPoints("unknown", "chronic"))
+	assert.Equal(t, 15, model.ConditionPoints("critical", "unknown"))
+}
+
+func TestRiskModel_CatalogConditionPoints(t *testing.T) {
+	model := DefaultRiskModel()
+	model.CategoryWeights["mental_health"] = 1.5
+
+	assert.Equal(t, 8, model.CatalogConditionPoints(8, "chronic"))
+	assert.Equal(t, 9, model.CatalogConditionPoints(6, "mental_health"))
+	assert.Equal(t, 0, model.CatalogConditionPoints(0, "preventive"))
+}
+
+func TestRiskModel_Breakdown_CataloguedConditionsScoreTheirRiskWeight(t *testing.T) {
+	profile := &HealthProfile{Age: 30, Height: 175, Weight: 70, FamilySize: 1}
+	conditions := []MedicalCondition{
+		// Severe would score 10 points; the catalog weight replaces it
+		{ID: "1", CatalogID: "E11", CatalogRiskWeight: 8, Name: "Type 2 Diabetes", Category: "chronic", Severity: "severe", IsActive: true},
+		{ID: "2", CatalogID: "Z00.00", Name: "Annual Checkup", Category: "preventive", Severity: "mild", IsActive: true},
+		{ID: "3", Name: "Back pain", Category: "chronic", Severity: "severe", IsActive: true},
+	}
+
+	factors := DefaultRiskModel().Breakdown(profile, conditions)
+
+	var conditionPoints []RiskFactorPoints
+	for _, factor := range factors {
+		if factor.Factor == RiskFactorCondition {
+			conditionPoints = append(conditionPoints, factor)
+		}
+	}
+	assert.Equal(t, []RiskFactorPoints{
+		{Factor: RiskFactorCondition, ConditionID: "1", Name: "Type 2 Diabetes", Points: 8},
+		{Factor: RiskFactorCondition, ConditionID: "2", Name: "Annual Checkup", Points: 0},
+		{Factor: RiskFactorCondition, ConditionID: "3", Name: "Back pain", Points: 10},
+	}, conditionPoints)
+}
+
+func TestRiskModel_Breakdown(t *testing.T) {
+	profile := &HealthProfile{Age: 45, Height: 170, Weight: 95, FamilySize: 3}
+	conditions := []MedicalCondition{
+		{ID: "1", Name: "Hypertension", Category: "chronic", Severity: "severe", IsActive: true},
+		{ID: "2", Name: "Fracture", Category: "acute", Severity: "moderate", IsActive: false},
+	}
+
+	factors := DefaultRiskModel().Breakdown(profile, conditions)
+
+	assert.Equal(t, []RiskFactorPoints{
+		{Factor: RiskFactorAge, Points: 10},
+		{Factor: RiskFactorBMI, Points: 15}, // Calculated from height and weight: 32.87
+		{Factor: RiskFactorFamilySize, Points: 5},
+		{Factor: RiskFactorCondition, ConditionID: "1", Name: "Hypertension", Points: 10},
+	}, factors)
+}
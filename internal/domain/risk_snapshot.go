@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// RiskSnapshot records a user's computed health risk score and level at a point in time
+type RiskSnapshot struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	RiskScore  int       `json:"risk_score"`
+	RiskLevel  string    `json:"risk_level"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
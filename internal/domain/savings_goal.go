@@ -0,0 +1,269 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SavingsGoal represents an amount a user is saving toward by a target date
+type SavingsGoal struct {
+	ID            string
+	UserID        string
+	Name          string
+	TargetAmount  float64
+	CurrentAmount float64
+	TargetDate    time.Time
+	// Priority is 1 (highest) to 3; higher priority goals are funded first
+	Priority  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Savings goal priority constants
+const (
+	GoalPriorityHigh   = 1
+	GoalPriorityMedium = 2
+	GoalPriorityLow    = 3
+)
+
+const (
+	// MaxSavingsGoalNameLength bounds the goal name
+	MaxSavingsGoalNameLength = 100
+	// MaxGoalContributionNoteLength bounds the note on a contribution
+	MaxGoalContributionNoteLength = 255
+	// goalAmountTolerance absorbs floating point error when comparing money amounts
+	goalAmountTolerance = 0.005
+)
+
+// Validate validates the SavingsGoal struct
+// Returns an error wrapping ErrInvalidSavingsGoalData that describes every problem found
+func (g *SavingsGoal) Validate() error {
+	var errors []string
+
+	if g.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	if strings.TrimSpace(g.Name) == "" {
+		errors = append(errors, "name is required")
+	} else if len(g.Name) > MaxSavingsGoalNameLength {
+		errors = append(errors, fmt.Sprintf("name must be at most %d characters", MaxSavingsGoalNameLength))
+	}
+
+	if g.TargetAmount <= 0 {
+		errors = append(errors, "target amount must be greater than 0")
+	}
+
+	if g.CurrentAmount < 0 {
+		errors = append(errors, "current amount cannot be negative")
+	}
+
+	if g.TargetDate.IsZero() {
+		errors = append(errors, "target date is required")
+	} else if !g.TargetDate.After(time.Now()) {
+		errors = append(errors, "target date must be in the future")
+	}
+
+	if g.Priority < GoalPriorityHigh || g.Priority > GoalPriorityLow {
+		errors = append(errors, "priority must be between 1 and 3")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSavingsGoalData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// RemainingAmount returns how much is still needed to reach the target
+func (g *SavingsGoal) RemainingAmount() float64 {
+	return math.Max(g.TargetAmount-g.CurrentAmount, 0)
+}
+
+// IsComplete returns true if the saved amount has reached the target
+func (g *SavingsGoal) IsComplete() bool {
+	return g.RemainingAmount() < goalAmountTolerance
+}
+
+// CalculateProgress returns the percentage of the target that has been saved, capped at 100
+func (g *SavingsGoal) CalculateProgress() float64 {
+	if g.TargetAmount <= 0 {
+		return 0.0
+	}
+	return math.Min(g.CurrentAmount/g.TargetAmount*100.0, 100.0)
+}
+
+// MonthsRemaining returns the number of whole months of contributions left before the target date
+// A target date less than a month away still leaves one month; a past target date leaves none
+func (g *SavingsGoal) MonthsRemaining(now time.Time) int {
+	if !g.TargetDate.After(now) {
+		return 0
+	}
+
+	months := (g.TargetDate.Year()-now.Year())*12 + int(g.TargetDate.Month()-now.Month())
+	if g.TargetDate.Day() < now.Day() {
+		months--
+	}
+	if months < 1 {
+		return 1
+	}
+	return months
+}
+
+// RequiredMonthlyContribution returns the monthly amount needed to reach the target on time
+// Once the target date has passed, the whole remaining amount is due
+func (g *SavingsGoal) RequiredMonthlyContribution(now time.Time) float64 {
+	remaining := g.RemainingAmount()
+	months := g.MonthsRemaining(now)
+	if months == 0 {
+		return remaining
+	}
+	return remaining / float64(months)
+}
+
+// GoalContribution records money added to a savings goal
+type GoalContribution struct {
+	ID            string
+	GoalID        string
+	UserID        string
+	Amount        float64
+	Note          string
+	ContributedAt time.Time
+	CreatedAt     time.Time
+}
+
+// Validate validates the GoalContribution struct
+// Returns an error wrapping ErrInvalidSavingsGoalData that describes every problem found
+func (c *GoalContribution) Validate() error {
+	var errors []string
+
+	if c.GoalID == "" {
+		errors = append(errors, "goal ID is required")
+	}
+
+	if c.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	if c.Amount <= 0 {
+		errors = append(errors, "contribution amount must be greater than 0")
+	}
+
+	if len(c.Note) > MaxGoalContributionNoteLength {
+		errors = append(errors, fmt.Sprintf("note must be at most %d characters", MaxGoalContributionNoteLength))
+	}
+
+	if c.ContributedAt.IsZero() {
+		errors = append(errors, "contribution date is required")
+	} else if c.ContributedAt.After(time.Now()) {
+		errors = append(errors, "contribution date cannot be in the future")
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidSavingsGoalData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// GoalProjection describes whether a savings goal will be reached at the current disposable income
+type GoalProjection struct {
+	GoalID     string
+	Name       string
+	Priority   int
+	TargetDate time.Time
+	// RequiredMonthly is the contribution needed each month to reach the target on time
+	RequiredMonthly float64
+	// AllocatedMonthly is the share of disposable income left for this goal
+	AllocatedMonthly float64
+	MonthsRemaining  int
+	// ProjectedAmount is the current amount plus the allocated contributions until the target date
+	ProjectedAmount float64
+	// Shortfall is how far ProjectedAmount falls below the target, or 0
+	Shortfall float64
+}
+
+// IsAchievable returns true if the goal is projected to reach its target on time
+func (p GoalProjection) IsAchievable() bool {
+	return p.Shortfall < goalAmountTolerance
+}
+
+// ProjectSavingsGoals projects every goal at the given disposable income.
+// Disposable income is allocated to goals in priority order, then by earliest target date,
+// and each goal receives at most its required monthly contribution.
+// A goal whose target date has passed can't receive further contributions in time.
+func ProjectSavingsGoals(goals []SavingsGoal, disposableIncome float64, now time.Time) []GoalProjection {
+	ordered := make([]SavingsGoal, len(goals))
+	copy(ordered, goals)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].Priority != ordered[j].Priority {
+			return ordered[i].Priority < ordered[j].Priority
+		}
+		return ordered[i].TargetDate.Before(ordered[j].TargetDate)
+	})
+
+	available := math.Max(disposableIncome, 0)
+	projections := make([]GoalProjection, len(ordered))
+	for i, goal := range ordered {
+		months := goal.MonthsRemaining(now)
+		required := goal.RequiredMonthlyContribution(now)
+
+		allocated := 0.0
+		if months > 0 {
+			allocated = math.Min(required, available)
+			available -= allocated
+		}
+
+		projected := goal.CurrentAmount + allocated*float64(months)
+		projections[i] = GoalProjection{
+			GoalID:           goal.ID,
+			Name:             goal.Name,
+			Priority:         goal.Priority,
+			TargetDate:       goal.TargetDate,
+			RequiredMonthly:  required,
+			AllocatedMonthly: allocated,
+			MonthsRemaining:  months,
+			ProjectedAmount:  projected,
+			Shortfall:        math.Max(goal.TargetAmount-projected, 0),
+		}
+	}
+
+	return projections
+}
+
+// GoalContributionMonth groups the contributions made in one calendar month
+type GoalContributionMonth struct {
+	// Month is the first instant of the month, in the contributions' time zone
+	Month         time.Time
+	Total         float64
+	Contributions []GoalContribution
+}
+
+// GroupContributionsByMonth returns one entry per calendar month from the month of since
+// through the month of now, oldest first, including months without contributions
+func GroupContributionsByMonth(contributions []GoalContribution, since, now time.Time) []GoalContributionMonth {
+	start := time.Date(since.Year(), since.Month(), 1, 0, 0, 0, 0, since.Location())
+	end := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, since.Location())
+
+	var months []GoalContributionMonth
+	index := make(map[time.Time]int)
+	for month := start; !month.After(end); month = month.AddDate(0, 1, 0) {
+		index[month] = len(months)
+		months = append(months, GoalContributionMonth{Month: month, Contributions: []GoalContribution{}})
+	}
+
+	for _, contribution := range contributions {
+		at := contribution.ContributedAt.In(since.Location())
+		i, ok := index[time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, since.Location())]
+		if !ok {
+			continue
+		}
+		months[i].Total += contribution.Amount
+		months[i].Contributions = append(months[i].Contributions, contribution)
+	}
+
+	return months
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validSavingsGoal() SavingsGoal {
+	return SavingsGoal{
+		ID:            "goal-1",
+		UserID:        "user-1",
+		Name:          "Emergency fund",
+		TargetAmount:  6000,
+		CurrentAmount: 1000,
+		TargetDate:    time.Now().AddDate(1, 0, 0),
+		Priority:      GoalPriorityHigh,
+	}
+}
+
+func TestSavingsGoal_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(*SavingsGoal)
+		expectedError string
+	}{
+		{name: "valid goal", modify: func(g *SavingsGoal) {}},
+		{name: "missing user", modify: func(g *SavingsGoal) { g.UserID = "" }, expectedError: "user ID is required"},
+		{name: "blank name", modify: func(g *SavingsGoal) { g.Name = "  " }, expectedError: "name is required"},
+		{name: "name too long", modify: func(g *SavingsGoal) { g.Name = strings.Repeat("a", MaxSavingsGoalNameLength+1) }, expectedError: "name must be at most"},
+		{name: "zero target", modify: func(g *SavingsGoal) { g.TargetAmount = 0 }, expectedError: "target amount must be greater than 0"},
+		{name: "negative current amount", modify: func(g *SavingsGoal) { g.CurrentAmount = -1 }, expectedError: "current amount cannot be negative"},
+		{name: "missing target date", modify: func(g *SavingsGoal) { g.TargetDate = time.Time{} }, expectedError: "target date is required"},
+		{name: "past target date", modify: func(g *SavingsGoal) { g.TargetDate = time.Now().AddDate(0, 0, -1) }, expectedError: "target date must be in the future"},
+		{name: "priority out of range", modify: func(g *SavingsGoal) { g.Priority = 4 }, expectedError: "priority must be between 1 and 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := validSavingsGoal()
+			tt.modify(&goal)
+
+			err := goal.Validate()
+
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidSavingsGoalData)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestSavingsGoal_Progress(t *testing.T) {
+	goal := validSavingsGoal()
+	assert.Equal(t, 5000.0, goal.RemainingAmount())
+	assert.InDelta(t, 16.67, goal.CalculateProgress(), 0.01)
+	assert.False(t, goal.IsComplete())
+
+	goal.CurrentAmount = 7000
+	assert.Equal(t, 0.0, goal.RemainingAmount())
+	assert.Equal(t, 100.0, goal.CalculateProgress())
+	assert.True(t, goal.IsComplete())
+}
+
+func TestSavingsGoal_MonthsRemaining(t *testing.T) {
+	now := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		targetDate time.Time
+		expected   int
+	}{
+		{name: "exactly a year", targetDate: time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC), expected: 12},
+		{name: "day before the monthly anniversary", targetDate: time.Date(2025, 6, 14, 0, 0, 0, 0, time.UTC), expected: 2},
+		{name: "less than a month", targetDate: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC), expected: 1},
+		{name: "past", targetDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			goal := SavingsGoal{TargetDate: tt.targetDate}
+			assert.Equal(t, tt.expected, goal.MonthsRemaining(now))
+		})
+	}
+}
+
+func TestSavingsGoal_RequiredMonthlyContribution(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	goal := SavingsGoal{TargetAmount: 6000, CurrentAmount: 1200, TargetDate: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)}
+	assert.InDelta(t, 480.0, goal.RequiredMonthlyContribution(now), 0.001)
+
+	// Overdue goals need the whole remainder now
+	goal.TargetDate = time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 4800.0, goal.RequiredMonthlyContribution(now))
+}
+
+func TestGoalContribution_Validate(t *testing.T) {
+	valid := GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 250, ContributedAt: time.Now().Add(-time.Hour)}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name          string
+		modify        func(*GoalContribution)
+		expectedError string
+	}{
+		{name: "missing goal", modify: func(c *GoalContribution) { c.GoalID = "" }, expectedError: "goal ID is required"},
+		{name: "zero amount", modify: func(c *GoalContribution) { c.Amount = 0 }, expectedError: "contribution amount must be greater than 0"},
+		{name: "future date", modify: func(c *GoalContribution) { c.ContributedAt = time.Now().Add(time.Hour) }, expectedError: "cannot be in the future"},
+		{name: "note too long", modify: func(c *GoalContribution) { c.Note = strings.Repeat("n", MaxGoalContributionNoteLength+1) }, expectedError: "note must be at most"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contribution := valid
+			tt.modify(&contribution)
+
+			err := contribution.Validate()
+
+			assert.ErrorIs(t, err, ErrInvalidSavingsGoalData)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func TestProjectSavingsGoals_AllocatesByPriorityThenTargetDate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	goals := []SavingsGoal{
+		{ID: "vacation", Name: "Vacation", TargetAmount: 2000, TargetDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), Priority: GoalPriorityLow},
+		{ID: "car", Name: "Car", TargetAmount: 6000, TargetDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Priority: GoalPriorityHigh},
+		{ID: "emergency", Name: "Emergency fund", TargetAmount: 3000, CurrentAmount: 600, TargetDate: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC), Priority: GoalPriorityHigh},
+	}
+
+	projections := ProjectSavingsGoals(goals, 1000, now)
+
+	require.Len(t, projections, 3)
+	assert.Equal(t, []string{"emergency", "car", "vacation"},
+		[]string{projections[0].GoalID, projections[1].GoalID, projections[2].GoalID})
+
+	// 2400 over 6 months, then 6000 over 12 months, leaving 100 of the 1000 for the vacation
+	assert.InDelta(t, 400.0, projections[0].AllocatedMonthly, 0.001)
+	assert.True(t, projections[0].IsAchievable())
+	assert.InDelta(t, 500.0, projections[1].AllocatedMonthly, 0.001)
+	assert.True(t, projections[1].IsAchievable())
+
+	vacation := projections[2]
+	assert.Equal(t, 4, vacation.MonthsRemaining)
+	assert.InDelta(t, 500.0, vacation.RequiredMonthly, 0.001)
+	assert.InDelta(t, 100.0, vacation.AllocatedMonthly, 0.001)
+	assert.InDelta(t, 400.0, vacation.ProjectedAmount, 0.001)
+	assert.InDelta(t, 1600.0, vacation.Shortfall, 0.001)
+	assert.False(t, vacation.IsAchievable())
+}
+
+func TestProjectSavingsGoals_NegativeDisposableIncomeFundsNothing(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	goals := []SavingsGoal{
+		{ID: "done", TargetAmount: 500, CurrentAmount: 500, TargetDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Priority: GoalPriorityHigh},
+		{ID: "open", TargetAmount: 500, TargetDate: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), Priority: GoalPriorityHigh},
+	}
+
+	projections := ProjectSavingsGoals(goals, -250, now)
+
+	assert.True(t, projections[0].IsAchievable())
+	assert.Equal(t, 0.0, projections[1].AllocatedMonthly)
+	assert.Equal(t, 500.0, projections[1].Shortfall)
+}
+
+func TestFinanceSummary_GoalShortfallWarnings(t *testing.T) {
+	summary := FinanceSummary{GoalProjections: []GoalProjection{
+		{Name: "Car", TargetDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), MonthsRemaining: 12},
+		{Name: "Vacation", TargetDate: time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), MonthsRemaining: 4, RequiredMonthly: 500, AllocatedMonthly: 100, Shortfall: 1600},
+		{Name: "Laptop", TargetDate: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), Shortfall: 300},
+	}}
+
+	warnings := summary.GoalShortfallWarnings()
+
+	assert.False(t, summary.GoalsAchievable())
+	require.Len(t, warnings, 2)
+	assert.Equal(t, "Vacation: projected to fall 1600.00 short by 2025-05-01; it needs 500.00 a month but only 100.00 is available", warnings[0])
+	assert.Equal(t, "Laptop: target date 2024-12-01 has passed with 300.00 still to save", warnings[1])
+	assert.True(t, (&FinanceSummary{}).GoalsAchievable())
+}
+
+func TestGroupContributionsByMonth(t *testing.T) {
+	since := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)
+	contributions := []GoalContribution{
+		{ID: "c1", Amount: 100, ContributedAt: time.Date(2025, 1, 31, 23, 0, 0, 0, time.UTC)},
+		{ID: "c2", Amount: 50, ContributedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "c3", Amount: 25, ContributedAt: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)},
+		{ID: "old", Amount: 999, ContributedAt: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	months := GroupContributionsByMonth(contributions, since, now)
+
+	require.Len(t, months, 3)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), months[0].Month)
+	assert.Equal(t, 100.0, months[0].Total)
+	assert.Empty(t, months[1].Contributions)
+	assert.NotNil(t, months[1].Contributions)
+	assert.Equal(t, 75.0, months[2].Total)
+	assert.Len(t, months[2].Contributions, 2)
+}
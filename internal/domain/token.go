@@ -17,6 +17,7 @@ type TokenPair struct {
 type TokenClaims struct {
 	UserID    string `json:"user_id"`
 	Email     string `json:"email"`
+	Role      string `json:"role"`
 	ExpiresAt int64  `json:"expires_at"` // Unix timestamp
 }
 
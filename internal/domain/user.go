@@ -14,8 +14,23 @@ type User struct {
 	Name         string    `json:"name"`
 	PasswordHash string    `json:"-"` // Never serialize password hash
 	IsActive     bool      `json:"is_active"`
+	Role         string    `json:"role"` // RoleUser or RoleAdmin; empty means RoleUser
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// LastLoginAt is nil until the user's first login; it is maintained by
+	// UserRepository.UpdateLastLogin and never written by Update
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// User roles
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// IsValidRole reports whether role is one of the known user roles
+func IsValidRole(role string) bool {
+	return role == RoleUser || role == RoleAdmin
 }
 
 // emailRegex is a regex pattern for validating email addresses
@@ -38,6 +53,11 @@ func (u User) Validate() error {
 		errors = append(errors, "name is required")
 	}
 
+	// Validate role; empty defaults to RoleUser
+	if u.Role != "" && !IsValidRole(u.Role) {
+		errors = append(errors, "role must be one of: user admin")
+	}
+
 	// Validate password hash
 	if u.PasswordHash == "" {
 		errors = append(errors, "password hash is required")
@@ -58,4 +78,23 @@ func (u User) Validate() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// EffectiveRole returns the user's role, treating an unset role as RoleUser
+func (u User) EffectiveRole() string {
+	if u.Role == "" {
+		return RoleUser
+	}
+	return u.Role
+}
+
+// IsAdmin returns true if the user has the admin role
+func (u User) IsAdmin() bool {
+	return u.EffectiveRole() == RoleAdmin
+}
+
+// AccountUpdate holds the changes a user makes to their own account; nil fields are left as they are
+type AccountUpdate struct {
+	Name  *string
+	Email *string
+}
@@ -219,4 +219,31 @@ func TestUser_Validate_MultipleErrors_ReturnsAllErrors(t *testing.T) {
 	assert.Contains(t, errorMsg, "password hash is required")
 	assert.Contains(t, errorMsg, "created at is required")
 	assert.Contains(t, errorMsg, "updated at is required")
-}
\ No newline at end of file
+}
+func TestUser_Validate_UnknownRole_ReturnsError(t *testing.T) {
+	// Arrange
+	user := User{
+		ID:           "user-123",
+		Email:        "test@example.com",
+		Name:         "Test User",
+		PasswordHash: "hashed_password",
+		IsActive:     true,
+		Role:         "superuser",
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	// Act
+	err := user.Validate()
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "role must be one of")
+}
+
+func TestUser_EffectiveRole_DefaultsToUser(t *testing.T) {
+	assert.Equal(t, RoleUser, User{}.EffectiveRole())
+	assert.False(t, User{}.IsAdmin())
+	assert.Equal(t, RoleAdmin, User{Role: RoleAdmin}.EffectiveRole())
+	assert.True(t, User{Role: RoleAdmin}.IsAdmin())
+}
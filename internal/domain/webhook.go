@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Event types published by the services and delivered to webhooks
+const (
+	// EventFinancialHealthChanged fires when a recalculated finance summary moves to a different health rating
+	EventFinancialHealthChanged = "financial_health.changed"
+	// EventDebtToIncomeExceeded fires when the debt-to-income ratio rises above HealthyDebtToIncomeRatio
+	EventDebtToIncomeExceeded = "debt_to_income.threshold_exceeded"
+	// EventBudgetExceeded fires when monthly expenses and loan payments start to exceed monthly income
+	EventBudgetExceeded = "budget.exceeded"
+	// EventDeductibleMet fires when deductible progress reaches an insurance policy's deductible
+	EventDeductibleMet = "insurance.deductible_met"
+	// EventHighRiskDetected fires when a user's health risk level rises to high or critical
+	EventHighRiskDetected = "health.high_risk_detected"
+)
+
+// ValidWebhookEventTypes contains all event types a webhook can subscribe to
+var ValidWebhookEventTypes = []string{
+	EventFinancialHealthChanged,
+	EventDebtToIncomeExceeded,
+	EventBudgetExceeded,
+	EventDeductibleMet,
+	EventHighRiskDetected,
+}
+
+const (
+	// MinWebhookSecretLength is the shortest secret accepted for signing deliveries
+	MinWebhookSecretLength = 16
+	// MaxWebhookURLLength bounds the length of a webhook URL
+	MaxWebhookURLLength = 2048
+)
+
+// Event is something significant that happened to a user's data
+// Data holds event-specific fields and is delivered to webhooks as JSON
+type Event struct {
+	ID         string
+	Type       string
+	UserID     string
+	OccurredAt time.Time
+	Data       map[string]interface{}
+}
+
+// Webhook is a user-registered URL that receives signed event deliveries
+type Webhook struct {
+	ID         string
+	UserID     string
+	URL        string
+	Secret     string
+	EventTypes []string
+	IsActive   bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// WebhookDelivery records one attempt to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID         uint
+	WebhookID  string
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int // 0 if no response was received
+	Error      string
+	Succeeded  bool
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+// Validate validates the Webhook struct
+// Returns an error wrapping ErrInvalidWebhookData that describes every problem found
+func (w *Webhook) Validate() error {
+	var errors []string
+
+	if w.UserID == "" {
+		errors = append(errors, "user ID is required")
+	}
+
+	if err := validateWebhookURL(w.URL); err != "" {
+		errors = append(errors, err)
+	}
+
+	if len(w.Secret) < MinWebhookSecretLength {
+		errors = append(errors, fmt.Sprintf("secret must be at least %d characters", MinWebhookSecretLength))
+	}
+
+	if len(w.EventTypes) == 0 {
+		errors = append(errors, "at least one event type is required")
+	}
+	seen := make(map[string]bool, len(w.EventTypes))
+	for _, eventType := range w.EventTypes {
+		if !isValidWebhookEventType(eventType) {
+			errors = append(errors, fmt.Sprintf("unknown event type %q", eventType))
+		} else if seen[eventType] {
+			errors = append(errors, fmt.Sprintf("duplicate event type %q", eventType))
+		}
+		seen[eventType] = true
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidWebhookData, strings.Join(errors, "; "))
+	}
+
+	return nil
+}
+
+// Subscribes returns true if the webhook is active and wants events of the given type
+func (w *Webhook) Subscribes(eventType string) bool {
+	if !w.IsActive {
+		return false
+	}
+	for _, t := range w.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWebhookURL returns a description of what's wrong with the URL, or "" if it's usable
+func validateWebhookURL(raw string) string {
+	if raw == "" {
+		return "URL is required"
+	}
+	if len(raw) > MaxWebhookURLLength {
+		return fmt.Sprintf("URL must be at most %d characters", MaxWebhookURLLength)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return "URL must be an absolute http or https URL"
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "URL must be an absolute http or https URL"
+	}
+	if parsed.User != nil {
+		return "URL must not contain credentials"
+	}
+
+	return ""
+}
+
+// isValidWebhookEventType checks if the event type is one webhooks can subscribe to
+func isValidWebhookEventType(eventType string) bool {
+	for _, valid := range ValidWebhookEventTypes {
+		if eventType == valid {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+/*
+Request CreateAPIKeyDTO dto
+Request to create an API key with the given scopes
+*/
+type CreateAPIKeyDTO struct {
+	Name   string   `json:"name" validate:"required,min=1,max=100" example:"Nightly export"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,oneof=finance:read finance:write health:read health:write" example:"finance:read"`
+}
+
+/*
+Response APIKeyResponseDTO dto
+API key details in API responses; the full key is only included when the key is created
+*/
+type APIKeyResponseDTO struct {
+	ID         string     `json:"id" example:"key-123"`
+	Name       string     `json:"name" example:"Nightly export"`
+	Key        string     `json:"key,omitempty" example:"bob_4f3c2a1b0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b"`
+	Prefix     string     `json:"prefix" example:"bob_4f3c2a1b"`
+	Scopes     []string   `json:"scopes" example:"finance:read"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2024-01-16T08:00:00Z"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" example:"2024-02-01T12:00:00Z"`
+	CreatedAt  time.Time  `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// ToDomain converts CreateAPIKeyDTO to domain.APIKey
+func (dto CreateAPIKeyDTO) ToDomain(userID string) domain.APIKey {
+	return domain.APIKey{
+		UserID: userID,
+		Name:   dto.Name,
+		Scopes: dto.Scopes,
+	}
+}
+
+// FromDomain converts domain.APIKey to APIKeyResponseDTO without the key itself
+func (dto *APIKeyResponseDTO) FromDomain(key domain.APIKey) {
+	dto.ID = key.ID
+	dto.Name = key.Name
+	dto.Prefix = key.Prefix
+	dto.Scopes = key.Scopes
+	dto.LastUsedAt = key.LastUsedAt
+	dto.RevokedAt = key.RevokedAt
+	dto.CreatedAt = key.CreatedAt
+}
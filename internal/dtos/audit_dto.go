@@ -0,0 +1,51 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+/*
+Response AuditEntryDTO dto
+One audit log entry. changes maps each changed field to its before and after values;
+sensitive values are shown as [REDACTED].
+*/
+type AuditEntryDTO struct {
+	ID           string                        `json:"id" example:"audit-2f6c1f5e-8f0b-4c1e-9d7a-3b1d2c4e5f60"`
+	UserID       string                        `json:"user_id" example:"user-123"`
+	Action       string                        `json:"action" example:"update"`
+	ResourceType string                        `json:"resource_type" example:"income"`
+	ResourceID   string                        `json:"resource_id,omitempty" example:"income-123"`
+	RequestID    string                        `json:"request_id,omitempty" example:"req-1705314600000000000"`
+	IPAddress    string                        `json:"ip_address,omitempty" example:"203.0.113.7"`
+	UserAgent    string                        `json:"user_agent,omitempty" example:"Mozilla/5.0"`
+	Changes      map[string]domain.AuditChange `json:"changes,omitempty"`
+	CreatedAt    time.Time                     `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Response AuditLogPageResponseDTO dto
+One page of the audit log, newest first. Pass next_cursor as cursor to get the next page;
+it is empty on the last page.
+*/
+type AuditLogPageResponseDTO struct {
+	Entries    []AuditEntryDTO `json:"entries"`
+	NextCursor string          `json:"next_cursor" example:"eyJjIjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpIjoiYXVkaXQtMTIzIn0"`
+	HasMore    bool            `json:"has_more" example:"true"`
+	Limit      int             `json:"limit" example:"50"`
+}
+
+// FromDomain converts domain.AuditEntry to AuditEntryDTO
+func (dto *AuditEntryDTO) FromDomain(entry domain.AuditEntry) {
+	dto.ID = entry.ID
+	dto.UserID = entry.UserID
+	dto.Action = entry.Action
+	dto.ResourceType = entry.ResourceType
+	dto.ResourceID = entry.ResourceID
+	dto.RequestID = entry.RequestID
+	dto.IPAddress = entry.IPAddress
+	dto.UserAgent = entry.UserAgent
+	dto.Changes = entry.Changes
+	dto.CreatedAt = entry.CreatedAt
+}
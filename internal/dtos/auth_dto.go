@@ -47,6 +47,14 @@ type RefreshTokenRequestDTO struct {
 	RefreshToken string `json:"refresh_token" validate:"required"`
 }
 
+/*
+Request DeleteAccountRequestDTO dto
+Account deletion request; the password confirms it's the account owner
+*/
+type DeleteAccountRequestDTO struct {
+	Password string `json:"password" validate:"required" example:"SecurePassword123"`
+}
+
 /*
 Response TokenResponseDTO dto
 Successful authentication response containing JWT token pair
@@ -64,4 +72,11 @@ func (dto *TokenResponseDTO) FromDomain(tokenPair *domain.TokenPair) {
 	dto.RefreshToken = tokenPair.RefreshToken
 	dto.ExpiresIn = tokenPair.ExpiresIn
 	dto.TokenType = "Bearer"
-}
\ No newline at end of file
+}
+/*
+Response TokenCleanupResponseDTO dto
+Result of a manually triggered expired refresh token cleanup
+*/
+type TokenCleanupResponseDTO struct {
+	PurgedTokens int64 `json:"purged_tokens"`
+}
@@ -5,37 +5,88 @@ Response ErrorResponseDTO dto
 Standard error response format for HTTP endpoints
 */
 type ErrorResponseDTO struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    int    `json:"code"`
+	Error     string    `json:"error"`
+	Message   string    `json:"message"`
+	Code      int       `json:"code"`
+	ErrorCode ErrorCode `json:"error_code" example:"FIN_INCOME_NOT_FOUND"`
 }
 
-// NewErrorResponse creates a new ErrorResponseDTO
+// NewErrorResponse creates a new ErrorResponseDTO with the generic error code for its status
 func NewErrorResponse(code int, error string, message string) *ErrorResponseDTO {
 	return &ErrorResponseDTO{
-		Code:    code,
-		Error:   error,
-		Message: message,
+		Code:      code,
+		Error:     error,
+		Message:   message,
+		ErrorCode: DefaultErrorCode(code),
 	}
 }
 
+// NewCodedErrorResponse creates a new ErrorResponseDTO with a specific error code
+func NewCodedErrorResponse(status int, errorCode ErrorCode, message string) *ErrorResponseDTO {
+	return &ErrorResponseDTO{
+		Code:      status,
+		Error:     errorNameForStatus(status),
+		Message:   message,
+		ErrorCode: errorCode,
+	}
+}
+
+/*
+Response SimpleErrorResponseDTO dto
+Error response used by the health endpoints, carrying only a message
+*/
+type SimpleErrorResponseDTO struct {
+	Error     string    `json:"error" example:"Health profile not found"`
+	ErrorCode ErrorCode `json:"error_code" example:"HEALTH_PROFILE_NOT_FOUND"`
+}
+
+// NewSimpleErrorResponse creates a new SimpleErrorResponseDTO
+func NewSimpleErrorResponse(errorCode ErrorCode, message string) SimpleErrorResponseDTO {
+	return SimpleErrorResponseDTO{
+		Error:     message,
+		ErrorCode: errorCode,
+	}
+}
+
+/*
+Response MessageResponseDTO dto
+Success response for endpoints that don't return a resource
+*/
+type MessageResponseDTO struct {
+	Message string `json:"message" example:"Income added successfully"`
+}
+
+/*
+Response ProbeResponseDTO dto
+Liveness and readiness probe result, with per-check status for readiness
+*/
+type ProbeResponseDTO struct {
+	Status  string            `json:"status" example:"ok"`
+	Message string            `json:"message,omitempty" example:"BuyOrBye API is running"`
+	Checks  map[string]string `json:"checks,omitempty"`
+	// Failed lists the readiness checks that failed, sorted; "shutdown" while the server drains
+	Failed []string `json:"failed,omitempty" example:"db"`
+}
+
 /*
 Response ValidationErrorResponseDTO dto
 Validation error response with field-specific error details
 */
 type ValidationErrorResponseDTO struct {
-	Error   string                 `json:"error"`
-	Message string                 `json:"message"`
-	Code    int                    `json:"code"`
-	Fields  map[string]any `json:"fields,omitempty"`
+	Error     string         `json:"error"`
+	Message   string         `json:"message"`
+	Code      int            `json:"code"`
+	ErrorCode ErrorCode      `json:"error_code" example:"VALIDATION_FAILED"`
+	Fields    map[string]any `json:"fields,omitempty"`
 }
 
 // NewValidationErrorResponse creates a new ValidationErrorResponseDTO
 func NewValidationErrorResponse(message string, fields map[string]any) *ValidationErrorResponseDTO {
 	return &ValidationErrorResponseDTO{
-		Code:    400,
-		Error:   "validation_error",
-		Message: message,
-		Fields:  fields,
+		Code:      400,
+		Error:     "validation_error",
+		ErrorCode: ErrorCodeValidationFailed,
+		Message:   message,
+		Fields:    fields,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,34 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+/*
+Response DemoDataBatchResponseDTO dto
+The demo data seeded into an account, counted by kind of record
+*/
+type DemoDataBatchResponseDTO struct {
+	BatchID   string         `json:"batch_id" example:"demo-5b0f6c1e-8d3a-4c47-9f2e-1a7b3c9d2e4f"`
+	Counts    map[string]int `json:"counts"`
+	Total     int            `json:"total" example:"22"`
+	CreatedAt time.Time      `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Response DemoDataRemovedResponseDTO dto
+How many seeded records were deleted when demo data was removed
+*/
+type DemoDataRemovedResponseDTO struct {
+	Removed int `json:"removed" example:"22"`
+}
+
+// FromDomain converts domain.DemoDataBatch to DemoDataBatchResponseDTO
+func (dto *DemoDataBatchResponseDTO) FromDomain(batch domain.DemoDataBatch) {
+	dto.BatchID = batch.ID
+	dto.Counts = batch.Counts()
+	dto.Total = len(batch.Records)
+	dto.CreatedAt = batch.CreatedAt
+}
@@ -0,0 +1,156 @@
+package dtos
+
+import "net/http"
+
+// StatusClientClosedRequest is the non-standard status reported when the client goes away
+// before its request finishes. The client never sees it, but it keeps cancelled requests
+// apart from server errors in logs and metrics.
+const StatusClientClosedRequest = 499
+
+// ErrorCode is a stable, machine-readable identifier carried in error responses.
+// Clients should branch on the code rather than on the English message, which may change.
+type ErrorCode string
+
+// Generic error codes, used when no more specific code applies
+const (
+	ErrorCodeBadRequest       ErrorCode = "BAD_REQUEST"
+	ErrorCodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	ErrorCodeAuthRequired     ErrorCode = "AUTH_REQUIRED"
+	ErrorCodeForbidden        ErrorCode = "FORBIDDEN"
+	ErrorCodeNotFound         ErrorCode = "NOT_FOUND"
+	ErrorCodeConflict         ErrorCode = "CONFLICT"
+	ErrorCodeUnprocessable    ErrorCode = "UNPROCESSABLE"
+	ErrorCodePayloadTooLarge  ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeTooManyRequests  ErrorCode = "TOO_MANY_REQUESTS"
+	ErrorCodeTimeout          ErrorCode = "TIMEOUT"
+	ErrorCodeRequestCanceled  ErrorCode = "REQUEST_CANCELED"
+	ErrorCodeInternal         ErrorCode = "INTERNAL_ERROR"
+)
+
+// Authentication error codes
+const (
+	ErrorCodeAuthInvalidCredentials ErrorCode = "AUTH_INVALID_CREDENTIALS"
+	ErrorCodeAuthAccountInactive    ErrorCode = "AUTH_ACCOUNT_INACTIVE"
+	ErrorCodeAuthInvalidToken       ErrorCode = "AUTH_INVALID_TOKEN"
+	ErrorCodeAuthTokenExpired       ErrorCode = "AUTH_TOKEN_EXPIRED"
+	ErrorCodeAuthTokenRevoked       ErrorCode = "AUTH_TOKEN_REVOKED"
+	ErrorCodeAuthUserExists         ErrorCode = "AUTH_USER_EXISTS"
+	ErrorCodeAuthInvalidUserData    ErrorCode = "AUTH_INVALID_USER_DATA"
+	ErrorCodeAuthInvalidAPIKey      ErrorCode = "AUTH_INVALID_API_KEY"
+	ErrorCodeAuthAPIKeyRevoked      ErrorCode = "AUTH_API_KEY_REVOKED"
+	ErrorCodeAuthAPIKeyNotFound     ErrorCode = "AUTH_API_KEY_NOT_FOUND"
+	ErrorCodeAuthInvalidAPIKeyData  ErrorCode = "AUTH_INVALID_API_KEY_DATA"
+	ErrorCodeAuthInsufficientScope  ErrorCode = "AUTH_INSUFFICIENT_SCOPE"
+	ErrorCodeAuthCSRFTokenInvalid   ErrorCode = "AUTH_CSRF_TOKEN_INVALID"
+)
+
+// Finance error codes
+const (
+	ErrorCodeFinIncomeNotFound       ErrorCode = "FIN_INCOME_NOT_FOUND"
+	ErrorCodeFinExpenseNotFound      ErrorCode = "FIN_EXPENSE_NOT_FOUND"
+	ErrorCodeFinLoanNotFound         ErrorCode = "FIN_LOAN_NOT_FOUND"
+	ErrorCodeFinSavingsGoalNotFound  ErrorCode = "FIN_SAVINGS_GOAL_NOT_FOUND"
+	ErrorCodeFinSummaryNotFound      ErrorCode = "FIN_SUMMARY_NOT_FOUND"
+	ErrorCodeFinIncomeNotOwned       ErrorCode = "FIN_INCOME_NOT_OWNED"
+	ErrorCodeFinExpenseNotOwned      ErrorCode = "FIN_EXPENSE_NOT_OWNED"
+	ErrorCodeFinLoanNotOwned         ErrorCode = "FIN_LOAN_NOT_OWNED"
+	ErrorCodeFinSavingsGoalNotOwned  ErrorCode = "FIN_SAVINGS_GOAL_NOT_OWNED"
+	ErrorCodeFinAccessDenied         ErrorCode = "FIN_ACCESS_DENIED"
+	ErrorCodeFinInvalidData          ErrorCode = "FIN_INVALID_DATA"
+	ErrorCodeFinInvalidIncome        ErrorCode = "FIN_INVALID_INCOME"
+	ErrorCodeFinInvalidExpense       ErrorCode = "FIN_INVALID_EXPENSE"
+	ErrorCodeFinInvalidLoan          ErrorCode = "FIN_INVALID_LOAN"
+	ErrorCodeFinInvalidSavingsGoal   ErrorCode = "FIN_INVALID_SAVINGS_GOAL"
+	ErrorCodeFinInvalidExpenseFilter ErrorCode = "FIN_INVALID_EXPENSE_FILTER"
+	ErrorCodeFinInvalidCursor        ErrorCode = "FIN_INVALID_CURSOR"
+	ErrorCodeFinUnsupportedCurrency  ErrorCode = "FIN_UNSUPPORTED_CURRENCY"
+	ErrorCodeFinDuplicateRecord      ErrorCode = "FIN_DUPLICATE_RECORD"
+	ErrorCodeFinInstallmentsComplete ErrorCode = "FIN_INSTALLMENTS_COMPLETE"
+	ErrorCodeFinPaymentBelowInterest ErrorCode = "FIN_PAYMENT_BELOW_INTEREST"
+	ErrorCodeFinInvalidPurchase      ErrorCode = "FIN_INVALID_PURCHASE"
+	ErrorCodeFinNoIncome             ErrorCode = "FIN_NO_INCOME"
+	ErrorCodeFinBudgetNotFound       ErrorCode = "FIN_BUDGET_NOT_FOUND"
+	ErrorCodeFinBudgetNotOwned       ErrorCode = "FIN_BUDGET_NOT_OWNED"
+	ErrorCodeFinBudgetExists         ErrorCode = "FIN_BUDGET_EXISTS"
+	ErrorCodeFinInvalidBudget        ErrorCode = "FIN_INVALID_BUDGET"
+)
+
+// Health error codes
+const (
+	ErrorCodeHealthProfileNotFound      ErrorCode = "HEALTH_PROFILE_NOT_FOUND"
+	ErrorCodeHealthFamilyMemberNotFound ErrorCode = "HEALTH_FAMILY_MEMBER_NOT_FOUND"
+	ErrorCodeHealthProfileExists        ErrorCode = "HEALTH_PROFILE_EXISTS"
+	ErrorCodeHealthProfileRequired      ErrorCode = "HEALTH_PROFILE_REQUIRED"
+	ErrorCodeHealthConditionNotFound    ErrorCode = "HEALTH_CONDITION_NOT_FOUND"
+	ErrorCodeHealthPolicyNotFound       ErrorCode = "HEALTH_POLICY_NOT_FOUND"
+	ErrorCodeHealthPolicyExists         ErrorCode = "HEALTH_POLICY_EXISTS"
+	ErrorCodeHealthNoPolicies           ErrorCode = "HEALTH_NO_POLICIES"
+	ErrorCodeHealthAccessDenied         ErrorCode = "HEALTH_ACCESS_DENIED"
+	ErrorCodeHealthInvalidData          ErrorCode = "HEALTH_INVALID_DATA"
+	ErrorCodeHealthExpenseNotFound      ErrorCode = "HEALTH_EXPENSE_NOT_FOUND"
+	ErrorCodeHealthExpenseNotRecurring  ErrorCode = "HEALTH_EXPENSE_NOT_RECURRING"
+	ErrorCodeHealthAttachmentNotFound   ErrorCode = "HEALTH_ATTACHMENT_NOT_FOUND"
+	ErrorCodeHealthAttachmentTooLarge   ErrorCode = "HEALTH_ATTACHMENT_TOO_LARGE"
+	ErrorCodeHealthUnsupportedMediaType ErrorCode = "HEALTH_UNSUPPORTED_MEDIA_TYPE"
+	ErrorCodeHealthCatalogEntryNotFound ErrorCode = "HEALTH_CATALOG_ENTRY_NOT_FOUND"
+)
+
+// Report error codes
+const (
+	ErrorCodeReportInvalidPeriod  ErrorCode = "REPORT_INVALID_PERIOD"
+	ErrorCodeReportPDFUnavailable ErrorCode = "REPORT_PDF_UNAVAILABLE"
+)
+
+// DefaultErrorCode returns the generic code for an HTTP status
+func DefaultErrorCode(status int) ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeAuthRequired
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusUnprocessableEntity:
+		return ErrorCodeUnprocessable
+	case http.StatusRequestEntityTooLarge:
+		return ErrorCodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	default:
+		return ErrorCodeInternal
+	}
+}
+
+// errorNameForStatus returns the short error name used in the error field for an HTTP status
+func errorNameForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "bad_request"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusNotAcceptable:
+		return "not_acceptable"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusUnprocessableEntity:
+		return "unprocessable_entity"
+	case http.StatusRequestEntityTooLarge:
+		return "payload_too_large"
+	case http.StatusTooManyRequests:
+		return "too_many_requests"
+	case StatusClientClosedRequest:
+		return "client_closed_request"
+	case http.StatusServiceUnavailable:
+		return "service_unavailable"
+	default:
+		return "internal_error"
+	}
+}
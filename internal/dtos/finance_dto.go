@@ -1,6 +1,7 @@
 package dtos
 
 import (
+	"strings"
 	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
@@ -13,9 +14,12 @@ Request AddIncomeDTO dto
 Request to add a new income source with amount and frequency
 */
 type AddIncomeDTO struct {
-	Source    string  `json:"source" validate:"required,min=2" example:"Software Engineer Salary"`
-	Amount    float64 `json:"amount" validate:"required,gt=0" example:"5000.00"`
-	Frequency string  `json:"frequency" validate:"required,oneof=monthly weekly daily one-time" example:"monthly"`
+	Source    string  `json:"source" validate:"required,min=2,max=100" example:"Software Engineer Salary"`
+	Amount    float64 `json:"amount" validate:"required,gt=0,money" example:"5000.00"`
+	Currency  string  `json:"currency,omitempty" validate:"omitempty,iso4217" example:"USD"`
+	Frequency string  `json:"frequency" validate:"required,frequency" example:"monthly"`
+	// Force adds the income even if it duplicates a recently added one
+	Force bool `json:"force,omitempty" example:"false"`
 }
 
 /*
@@ -23,9 +27,10 @@ Request UpdateIncomeDTO dto
 Request to update an existing income source with optional fields
 */
 type UpdateIncomeDTO struct {
-	Source    *string  `json:"source,omitempty" validate:"omitempty,min=2" example:"Senior Software Engineer"`
-	Amount    *float64 `json:"amount,omitempty" validate:"omitempty,gt=0" example:"5500.00"`
-	Frequency *string  `json:"frequency,omitempty" validate:"omitempty,oneof=monthly weekly daily one-time" example:"monthly"`
+	Source    *string  `json:"source,omitempty" validate:"omitempty,min=2,max=100" example:"Senior Software Engineer"`
+	Amount    *float64 `json:"amount,omitempty" validate:"omitempty,gt=0,money" example:"5500.00"`
+	Currency  *string  `json:"currency,omitempty" validate:"omitempty,iso4217" example:"EUR"`
+	Frequency *string  `json:"frequency,omitempty" validate:"omitempty,frequency" example:"monthly"`
 }
 
 /*
@@ -37,6 +42,7 @@ type IncomeResponseDTO struct {
 	UserID    string    `json:"user_id" example:"user-456"`
 	Source    string    `json:"source" example:"Software Engineer Salary"`
 	Amount    float64   `json:"amount" example:"5000.00"`
+	Currency  string    `json:"currency" example:"USD"`
 	Frequency string    `json:"frequency" example:"monthly"`
 	IsActive  bool      `json:"is_active" example:"true"`
 	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
@@ -51,11 +57,23 @@ Request to add a new expense with category, amount, and priority
 */
 type AddExpenseDTO struct {
 	Category  string  `json:"category" validate:"required,oneof=housing food transport entertainment utilities other" example:"housing"`
-	Name      string  `json:"name" validate:"required,min=2" example:"Monthly Rent"`
-	Amount    float64 `json:"amount" validate:"required,gt=0" example:"1200.00"`
-	Frequency string  `json:"frequency" validate:"required,oneof=monthly weekly daily" example:"monthly"`
+	Name      string  `json:"name" validate:"required,min=2,max=100" example:"Monthly Rent"`
+	Amount    float64 `json:"amount" validate:"required,gt=0,money" example:"1200.00"`
+	Currency  string  `json:"currency,omitempty" validate:"omitempty,iso4217" example:"USD"`
+	Frequency string  `json:"frequency" validate:"required,frequency=recurring" example:"monthly"`
 	IsFixed   bool    `json:"is_fixed" example:"true"`
 	Priority  int     `json:"priority" validate:"required,min=1,max=3" example:"1"`
+	// InstallmentsTotal splits a one-time purchase of Amount into monthly installments;
+	// only allowed with monthly frequency
+	InstallmentsTotal int `json:"installments_total,omitempty" validate:"omitempty,min=2,max=60,excluded_unless=Frequency monthly" example:"12"`
+	// InstallmentsPaid counts installments already paid when the purchase is recorded
+	InstallmentsPaid int `json:"installments_paid,omitempty" validate:"omitempty,gte=0,ltefield=InstallmentsTotal" example:"0"`
+	// InstallmentStart is when the first installment is due; defaults to now
+	InstallmentStart *time.Time `json:"installment_start,omitempty" example:"2024-02-01T00:00:00Z"`
+	// Tags are free-form labels; they are lowercased, trimmed and deduplicated before saving
+	Tags []string `json:"tags,omitempty" example:"vacation,travel"`
+	// Force adds the expense even if it duplicates a recently added one
+	Force bool `json:"force,omitempty" example:"false"`
 }
 
 /*
@@ -64,11 +82,58 @@ Request to update an existing expense with optional fields
 */
 type UpdateExpenseDTO struct {
 	Category  *string  `json:"category,omitempty" validate:"omitempty,oneof=housing food transport entertainment utilities other" example:"utilities"`
-	Name      *string  `json:"name,omitempty" validate:"omitempty,min=2" example:"Electricity Bill"`
-	Amount    *float64 `json:"amount,omitempty" validate:"omitempty,gt=0" example:"150.00"`
-	Frequency *string  `json:"frequency,omitempty" validate:"omitempty,oneof=monthly weekly daily" example:"monthly"`
+	Name      *string  `json:"name,omitempty" validate:"omitempty,min=2,max=100" example:"Electricity Bill"`
+	Amount    *float64 `json:"amount,omitempty" validate:"omitempty,gt=0,money" example:"150.00"`
+	Currency  *string  `json:"currency,omitempty" validate:"omitempty,iso4217" example:"EUR"`
+	Frequency *string  `json:"frequency,omitempty" validate:"omitempty,frequency=recurring" example:"monthly"`
 	IsFixed   *bool    `json:"is_fixed,omitempty" example:"false"`
 	Priority  *int     `json:"priority,omitempty" validate:"omitempty,min=1,max=3" example:"2"`
+	// Tags replaces every tag on the expense; an empty list removes them all
+	Tags *[]string `json:"tags,omitempty" example:"vacation"`
+}
+
+/*
+Request BulkDeleteExpensesDTO dto
+Request to delete several expenses at once; either all of them are deleted or none are
+*/
+type BulkDeleteExpensesDTO struct {
+	IDs []string `json:"ids" validate:"required,min=1,max=100,dive,required" example:"expense-123,expense-456"`
+}
+
+/*
+Response BulkDeleteExpensesResponseDTO dto
+Result of a bulk expense delete
+*/
+type BulkDeleteExpensesResponseDTO struct {
+	Message string `json:"message" example:"Expenses deleted successfully"`
+	Deleted int    `json:"deleted" example:"2"`
+}
+
+/*
+Response DuplicateRecordResponseDTO dto
+Conflict response when a new income or expense matches one added recently. Resend the request
+with force set to add it anyway.
+*/
+type DuplicateRecordResponseDTO struct {
+	ErrorResponseDTO
+	ExistingID string `json:"existing_id" example:"expense-123"`
+}
+
+/*
+Request ExpenseFilterDTO dto
+Query parameters for searching expenses; every parameter is optional and they combine with AND
+*/
+type ExpenseFilterDTO struct {
+	Query       string    `form:"q" example:"rent"`
+	Category    string    `form:"category" example:"housing"`
+	MinAmount   float64   `form:"min_amount" example:"100"`
+	MaxAmount   float64   `form:"max_amount" example:"1500"`
+	IsFixed     *bool     `form:"is_fixed" example:"true"`
+	Priority    int       `form:"priority" example:"1"`
+	Frequency   string    `form:"frequency" example:"monthly"`
+	Tag         string    `form:"tag" example:"vacation"`
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02" time_utc:"1" example:"2025-01-01"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02" time_utc:"1" example:"2025-12-31"`
 }
 
 /*
@@ -81,9 +146,18 @@ type ExpenseResponseDTO struct {
 	Category  string    `json:"category" example:"housing"`
 	Name      string    `json:"name" example:"Monthly Rent"`
 	Amount    float64   `json:"amount" example:"1200.00"`
+	Currency  string    `json:"currency" example:"USD"`
 	Frequency string    `json:"frequency" example:"monthly"`
 	IsFixed   bool      `json:"is_fixed" example:"true"`
 	Priority  int       `json:"priority" example:"1"`
+	// IsActive is false once every installment of an installment expense is paid
+	IsActive             bool       `json:"is_active" example:"true"`
+	InstallmentsTotal    int        `json:"installments_total" example:"12"`
+	InstallmentsPaid     int        `json:"installments_paid" example:"3"`
+	InstallmentAmount    float64    `json:"installment_amount,omitempty" example:"100.00"`
+	InstallmentStart     *time.Time `json:"installment_start,omitempty" example:"2024-02-01T00:00:00Z"`
+	FinalInstallmentDate *time.Time `json:"final_installment_date,omitempty" example:"2025-01-01T00:00:00Z"`
+	Tags                 []string   `json:"tags" example:"vacation,travel"`
 	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
 }
@@ -95,13 +169,16 @@ Request AddLoanDTO dto
 Request to add a new loan with lender, type, and payment details
 */
 type AddLoanDTO struct {
-	Lender           string    `json:"lender" validate:"required,min=2" example:"Chase Bank"`
+	Lender           string    `json:"lender" validate:"required,min=2,max=100" example:"Chase Bank"`
 	Type             string    `json:"type" validate:"required,oneof=mortgage auto personal student" example:"mortgage"`
-	PrincipalAmount  float64   `json:"principal_amount" validate:"required,gt=0" example:"250000.00"`
-	RemainingBalance float64   `json:"remaining_balance" validate:"required,gte=0" example:"245000.00"`
-	MonthlyPayment   float64   `json:"monthly_payment" validate:"required,gt=0" example:"1266.71"`
+	PrincipalAmount  float64   `json:"principal_amount" validate:"required,gt=0,money" example:"250000.00"`
+	RemainingBalance float64   `json:"remaining_balance" validate:"required,gte=0,money" example:"245000.00"`
+	MonthlyPayment   float64   `json:"monthly_payment" validate:"required,gt=0,money" example:"1266.71"`
 	InterestRate     float64   `json:"interest_rate" validate:"required,gte=0,lte=100" example:"4.5"`
+	Currency         string    `json:"currency,omitempty" validate:"omitempty,iso4217" example:"USD"`
 	EndDate          time.Time `json:"end_date" validate:"required" example:"2054-01-15T00:00:00Z"`
+	// AutoAccrueInterest adds a month of interest to the balance at the start of every month
+	AutoAccrueInterest bool `json:"auto_accrue_interest,omitempty" example:"false"`
 }
 
 /*
@@ -109,13 +186,15 @@ Request UpdateLoanDTO dto
 Request to update an existing loan with optional fields
 */
 type UpdateLoanDTO struct {
-	Lender           *string    `json:"lender,omitempty" validate:"omitempty,min=2" example:"Wells Fargo"`
-	Type             *string    `json:"type,omitempty" validate:"omitempty,oneof=mortgage auto personal student" example:"auto"`
-	PrincipalAmount  *float64   `json:"principal_amount,omitempty" validate:"omitempty,gt=0" example:"240000.00"`
-	RemainingBalance *float64   `json:"remaining_balance,omitempty" validate:"omitempty,gte=0" example:"235000.00"`
-	MonthlyPayment   *float64   `json:"monthly_payment,omitempty" validate:"omitempty,gt=0" example:"1200.00"`
-	InterestRate     *float64   `json:"interest_rate,omitempty" validate:"omitempty,gte=0,lte=100" example:"3.5"`
-	EndDate          *time.Time `json:"end_date,omitempty" validate:"omitempty" example:"2050-01-15T00:00:00Z"`
+	Lender             *string    `json:"lender,omitempty" validate:"omitempty,min=2,max=100" example:"Wells Fargo"`
+	Type               *string    `json:"type,omitempty" validate:"omitempty,oneof=mortgage auto personal student" example:"auto"`
+	PrincipalAmount    *float64   `json:"principal_amount,omitempty" validate:"omitempty,gt=0,money" example:"240000.00"`
+	RemainingBalance   *float64   `json:"remaining_balance,omitempty" validate:"omitempty,gte=0,money" example:"235000.00"`
+	MonthlyPayment     *float64   `json:"monthly_payment,omitempty" validate:"omitempty,gt=0,money" example:"1200.00"`
+	InterestRate       *float64   `json:"interest_rate,omitempty" validate:"omitempty,gte=0,lte=100" example:"3.5"`
+	Currency           *string    `json:"currency,omitempty" validate:"omitempty,iso4217" example:"EUR"`
+	EndDate            *time.Time `json:"end_date,omitempty" validate:"omitempty" example:"2050-01-15T00:00:00Z"`
+	AutoAccrueInterest *bool      `json:"auto_accrue_interest,omitempty" example:"true"`
 }
 
 /*
@@ -123,17 +202,345 @@ Response LoanResponseDTO dto
 Loan details in API responses with lender and payment information
 */
 type LoanResponseDTO struct {
-	ID               string    `json:"id" example:"loan-123"`
-	UserID           string    `json:"user_id" example:"user-456"`
-	Lender           string    `json:"lender" example:"Chase Bank"`
-	Type             string    `json:"type" example:"mortgage"`
-	PrincipalAmount  float64   `json:"principal_amount" example:"250000.00"`
-	RemainingBalance float64   `json:"remaining_balance" example:"245000.00"`
-	MonthlyPayment   float64   `json:"monthly_payment" example:"1266.71"`
-	InterestRate     float64   `json:"interest_rate" example:"4.5"`
-	EndDate          time.Time `json:"end_date" example:"2054-01-15T00:00:00Z"`
-	CreatedAt        time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
-	UpdatedAt        time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+	ID                 string    `json:"id" example:"loan-123"`
+	UserID             string    `json:"user_id" example:"user-456"`
+	Lender             string    `json:"lender" example:"Chase Bank"`
+	Type               string    `json:"type" example:"mortgage"`
+	PrincipalAmount    float64   `json:"principal_amount" example:"250000.00"`
+	RemainingBalance   float64   `json:"remaining_balance" example:"245000.00"`
+	MonthlyPayment     float64   `json:"monthly_payment" example:"1266.71"`
+	InterestRate       float64   `json:"interest_rate" example:"4.5"`
+	Currency           string    `json:"currency" example:"USD"`
+	EndDate            time.Time `json:"end_date" example:"2054-01-15T00:00:00Z"`
+	AutoAccrueInterest bool      `json:"auto_accrue_interest" example:"false"`
+	CreatedAt          time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt          time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Request LoanPaymentDTO dto
+A payment made on a loan, in the loan's currency
+*/
+type LoanPaymentDTO struct {
+	Amount float64 `json:"amount" validate:"required,gt=0,money" example:"1266.71"`
+	Note   string  `json:"note,omitempty" validate:"max=255" example:"June payment"`
+}
+
+/*
+Response LoanBalanceEntryResponseDTO dto
+One change to a loan's balance. type is payment, adjustment or interest-accrual. A payment's
+amount is split into interest_portion and principal_portion; an accrual's amount is the interest
+it added; an adjustment's amount is the change in balance.
+*/
+type LoanBalanceEntryResponseDTO struct {
+	ID               string    `json:"id" example:"loanbal-123"`
+	LoanID           string    `json:"loan_id" example:"loan-123"`
+	Type             string    `json:"type" example:"payment"`
+	OldBalance       float64   `json:"old_balance" example:"245000.00"`
+	NewBalance       float64   `json:"new_balance" example:"244652.04"`
+	Amount           float64   `json:"amount" example:"1266.71"`
+	InterestPortion  float64   `json:"interest_portion" example:"918.75"`
+	PrincipalPortion float64   `json:"principal_portion" example:"347.96"`
+	Note             string    `json:"note,omitempty" example:"June payment"`
+	CreatedAt        time.Time `json:"created_at" example:"2024-06-01T09:00:00Z"`
+}
+
+/*
+Request SimulateLoanPayoffDTO dto
+Extra payments to simulate on a loan, in the loan's currency; at least one must be given
+*/
+type SimulateLoanPayoffDTO struct {
+	ExtraMonthlyPayment float64 `json:"extra_monthly_payment,omitempty" validate:"gte=0,money" example:"200.00"`
+	OneTimePayment      float64 `json:"one_time_payment,omitempty" validate:"gte=0,money" example:"5000.00"`
+}
+
+/*
+Response LoanPayoffSimulationResponseDTO dto
+A loan's payoff with the simulated extra payments compared with its current schedule.
+baseline_payoff_date is omitted, and months_saved and interest_saved are 0, when the current
+payment never repays the loan. Debt-to-income ratios are fractions of monthly income in the base currency.
+*/
+type LoanPayoffSimulationResponseDTO struct {
+	LoanID                       string     `json:"loan_id" example:"loan-123"`
+	ExtraMonthlyPayment          float64    `json:"extra_monthly_payment" example:"200.00"`
+	OneTimePayment               float64    `json:"one_time_payment" example:"5000.00"`
+	BaselinePayoffDate           *time.Time `json:"baseline_payoff_date,omitempty" example:"2054-01-15T00:00:00Z"`
+	BaselineMonths               int        `json:"baseline_months" example:"348"`
+	BaselineTotalInterest        float64    `json:"baseline_total_interest" example:"195811.42"`
+	PayoffDate                   time.Time  `json:"payoff_date" example:"2046-06-15T00:00:00Z"`
+	Months                       int        `json:"months" example:"257"`
+	TotalInterest                float64    `json:"total_interest" example:"132504.87"`
+	MonthsSaved                  int        `json:"months_saved" example:"91"`
+	InterestSaved                float64    `json:"interest_saved" example:"63306.55"`
+	DebtToIncomeRatio            float64    `json:"debt_to_income_ratio" example:"0.253"`
+	SimulatedDebtToIncomeRatio   float64    `json:"simulated_debt_to_income_ratio" example:"0.293"`
+	DebtToIncomeRatioAfterPayoff float64    `json:"debt_to_income_ratio_after_payoff" example:"0.0"`
+}
+
+/*
+Request LoanExtraPaymentDTO dto
+An extra amount to add to every monthly payment of a loan, in the loan's currency
+*/
+type LoanExtraPaymentDTO struct {
+	ExtraMonthlyPayment float64 `json:"extra_monthly_payment" validate:"required,gt=0,money" example:"500.00"`
+}
+
+/*
+Response LoanExtraPaymentImpactResponseDTO dto
+How much sooner a loan is repaid, and how much interest is saved, with an extra monthly payment.
+baseline_payoff_date is omitted, and months_saved and interest_saved are 0, when the current payment
+never repays the loan. near_payoff is true when at most 10% of the principal is left.
+*/
+type LoanExtraPaymentImpactResponseDTO struct {
+	LoanID                string     `json:"loan_id" example:"loan-123"`
+	ExtraMonthlyPayment   float64    `json:"extra_monthly_payment" example:"500.00"`
+	BaselinePayoffDate    *time.Time `json:"baseline_payoff_date,omitempty" example:"2055-01-15T00:00:00Z"`
+	BaselineMonths        int        `json:"baseline_months" example:"360"`
+	BaselineTotalInterest float64    `json:"baseline_total_interest" example:"347509.14"`
+	PayoffDate            time.Time  `json:"payoff_date" example:"2042-09-15T00:00:00Z"`
+	Months                int        `json:"months" example:"212"`
+	TotalInterest         float64    `json:"total_interest" example:"187218.22"`
+	MonthsSaved           int        `json:"months_saved" example:"148"`
+	InterestSaved         float64    `json:"interest_saved" example:"160290.92"`
+	NearPayoff            bool       `json:"near_payoff" example:"false"`
+}
+
+/*
+Response NearPayoffLoanResponseDTO dto
+A loan close to being repaid, with the balance at or below which it counts as near payoff
+*/
+type NearPayoffLoanResponseDTO struct {
+	LoanResponseDTO
+	PayoffThresholdBalance float64 `json:"payoff_threshold_balance" example:"2500.00"`
+	PercentRemaining       float64 `json:"percent_remaining" example:"4.0"`
+}
+
+/*
+Response DebtBreakdownResponseDTO dto
+The user's debt grouped by loan type, largest balance first, with amounts in the base currency
+*/
+type DebtBreakdownResponseDTO struct {
+	Currency            string            `json:"currency" example:"USD"`
+	TotalBalance        float64           `json:"total_balance" example:"270000.00"`
+	TotalMonthlyPayment float64           `json:"total_monthly_payment" example:"2100.00"`
+	Types               []LoanTypeDebtDTO `json:"types"`
+}
+
+// LoanTypeDebtDTO totals the loans of one type
+type LoanTypeDebtDTO struct {
+	Type             string  `json:"type" example:"mortgage"`
+	LoanCount        int     `json:"loan_count" example:"1"`
+	RemainingBalance float64 `json:"remaining_balance" example:"240000.00"`
+	MonthlyPayment   float64 `json:"monthly_payment" example:"1800.00"`
+	SharePercent     float64 `json:"share_percent" example:"88.89"`
+}
+
+/*
+Response IncomeDiversificationResponseDTO dto
+How concentrated the user's recurring income is, with amounts in the base currency. Shares and the
+concentration index (Herfindahl-Hirschman) run from 0 to 1; single_source_dependent is set when one
+source is more than 90% of the income.
+*/
+type IncomeDiversificationResponseDTO struct {
+	Currency              string                 `json:"currency" example:"USD"`
+	MonthlyIncome         float64                `json:"monthly_income" example:"6000.00"`
+	SourceCount           int                    `json:"source_count" example:"3"`
+	LargestSourceShare    float64                `json:"largest_source_share" example:"0.5"`
+	ConcentrationIndex    float64                `json:"concentration_index" example:"0.375"`
+	SingleSourceDependent bool                   `json:"single_source_dependent" example:"false"`
+	Sources               []IncomeSourceShareDTO `json:"sources"`
+}
+
+// IncomeSourceShareDTO is one source's part of the monthly income
+type IncomeSourceShareDTO struct {
+	Source        string  `json:"source" example:"Salary"`
+	IncomeCount   int     `json:"income_count" example:"1"`
+	MonthlyAmount float64 `json:"monthly_amount" example:"3000.00"`
+	Share         float64 `json:"share" example:"0.5"`
+}
+
+// Savings Goal DTOs
+
+/*
+Request AddSavingsGoalDTO dto
+Request to add a savings goal with a target amount and date
+*/
+type AddSavingsGoalDTO struct {
+	Name          string    `json:"name" validate:"required,min=2,max=100" example:"Emergency Fund"`
+	TargetAmount  float64   `json:"target_amount" validate:"required,gt=0,money" example:"10000.00"`
+	CurrentAmount float64   `json:"current_amount" validate:"gte=0,money" example:"1500.00"`
+	TargetDate    time.Time `json:"target_date" validate:"required" example:"2026-12-31T00:00:00Z"`
+	Priority      int       `json:"priority" validate:"required,min=1,max=3" example:"1"`
+}
+
+/*
+Request UpdateSavingsGoalDTO dto
+Request to update an existing savings goal with optional fields
+*/
+type UpdateSavingsGoalDTO struct {
+	Name          *string    `json:"name,omitempty" validate:"omitempty,min=2,max=100" example:"Rainy Day Fund"`
+	TargetAmount  *float64   `json:"target_amount,omitempty" validate:"omitempty,gt=0,money" example:"12000.00"`
+	CurrentAmount *float64   `json:"current_amount,omitempty" validate:"omitempty,gte=0,money" example:"2000.00"`
+	TargetDate    *time.Time `json:"target_date,omitempty" validate:"omitempty" example:"2027-06-30T00:00:00Z"`
+	Priority      *int       `json:"priority,omitempty" validate:"omitempty,min=1,max=3" example:"2"`
+}
+
+/*
+Response SavingsGoalResponseDTO dto
+Savings goal details with progress toward the target
+*/
+type SavingsGoalResponseDTO struct {
+	ID              string    `json:"id" example:"goal-123"`
+	UserID          string    `json:"user_id" example:"user-456"`
+	Name            string    `json:"name" example:"Emergency Fund"`
+	TargetAmount    float64   `json:"target_amount" example:"10000.00"`
+	CurrentAmount   float64   `json:"current_amount" example:"1500.00"`
+	RemainingAmount float64   `json:"remaining_amount" example:"8500.00"`
+	ProgressPercent float64   `json:"progress_percent" example:"15"`
+	RequiredMonthly float64   `json:"required_monthly" example:"708.33"`
+	TargetDate      time.Time `json:"target_date" example:"2026-12-31T00:00:00Z"`
+	Priority        int       `json:"priority" example:"1"`
+	IsComplete      bool      `json:"is_complete" example:"false"`
+	CreatedAt       time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Request AddGoalContributionDTO dto
+Request to record money put toward a savings goal; contributed_at defaults to now
+*/
+type AddGoalContributionDTO struct {
+	Amount        float64    `json:"amount" validate:"required,gt=0,money" example:"250.00"`
+	Note          string     `json:"note,omitempty" validate:"max=255" example:"Bonus"`
+	ContributedAt *time.Time `json:"contributed_at,omitempty" example:"2025-01-31T09:00:00Z"`
+}
+
+/*
+Response GoalContributionResponseDTO dto
+A contribution recorded toward a savings goal
+*/
+type GoalContributionResponseDTO struct {
+	ID            string    `json:"id" example:"contrib-123"`
+	GoalID        string    `json:"goal_id" example:"goal-123"`
+	Amount        float64   `json:"amount" example:"250.00"`
+	Note          string    `json:"note,omitempty" example:"Bonus"`
+	ContributedAt time.Time `json:"contributed_at" example:"2025-01-31T09:00:00Z"`
+}
+
+/*
+Response GoalContributionMonthDTO dto
+Goal contributions made in one calendar month
+*/
+type GoalContributionMonthDTO struct {
+	Month         string                        `json:"month" example:"2025-01"`
+	Total         float64                       `json:"total" example:"250.00"`
+	Contributions []GoalContributionResponseDTO `json:"contributions"`
+}
+
+/*
+Response GoalProjectionDTO dto
+Projection of a savings goal at the user's current disposable income
+*/
+type GoalProjectionDTO struct {
+	GoalID           string    `json:"goal_id" example:"goal-123"`
+	Name             string    `json:"name" example:"Emergency Fund"`
+	Priority         int       `json:"priority" example:"1"`
+	TargetDate       time.Time `json:"target_date" example:"2026-12-31T00:00:00Z"`
+	MonthsRemaining  int       `json:"months_remaining" example:"12"`
+	RequiredMonthly  float64   `json:"required_monthly" example:"708.33"`
+	AllocatedMonthly float64   `json:"allocated_monthly" example:"533.29"`
+	ProjectedAmount  float64   `json:"projected_amount" example:"7899.48"`
+	Shortfall        float64   `json:"shortfall" example:"2100.52"`
+	Achievable       bool      `json:"achievable" example:"false"`
+}
+
+/*
+Response InstallmentScheduleDTO dto
+Progress of an installment expense still being paid off
+*/
+type InstallmentScheduleDTO struct {
+	ExpenseID            string    `json:"expense_id" example:"expense-123"`
+	Name                 string    `json:"name" example:"Laptop"`
+	MonthlyAmount        float64   `json:"monthly_amount" example:"100.00"`
+	InstallmentsPaid     int       `json:"installments_paid" example:"3"`
+	InstallmentsTotal    int       `json:"installments_total" example:"12"`
+	FinalInstallmentDate time.Time `json:"final_installment_date" example:"2025-01-01T00:00:00Z"`
+}
+
+// Budget DTOs
+
+/*
+Request AddBudgetDTO dto
+Request to cap monthly spending in an expense category
+*/
+type AddBudgetDTO struct {
+	Category     string  `json:"category" validate:"required,oneof=housing food transport entertainment utilities other" example:"food"`
+	MonthlyLimit float64 `json:"monthly_limit" validate:"required,gt=0,money" example:"600.00"`
+	Rollover     bool    `json:"rollover" example:"false"`
+}
+
+/*
+Request UpdateBudgetDTO dto
+Request to update an existing budget with optional fields
+*/
+type UpdateBudgetDTO struct {
+	Category     *string  `json:"category,omitempty" validate:"omitempty,oneof=housing food transport entertainment utilities other" example:"food"`
+	MonthlyLimit *float64 `json:"monthly_limit,omitempty" validate:"omitempty,gt=0,money" example:"650.00"`
+	Rollover     *bool    `json:"rollover,omitempty" example:"true"`
+}
+
+/*
+Response BudgetResponseDTO dto
+A monthly spending limit for an expense category
+*/
+type BudgetResponseDTO struct {
+	ID           string    `json:"id" example:"budget-123"`
+	UserID       string    `json:"user_id" example:"user-456"`
+	Category     string    `json:"category" example:"food"`
+	MonthlyLimit float64   `json:"monthly_limit" example:"600.00"`
+	Rollover     bool      `json:"rollover" example:"false"`
+	CreatedAt    time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Response HealthScoreComponentDTO dto
+One component of the financial health score. value is the metric: a fraction of income for
+debt_to_income, savings_rate and expense_ratio, months of outgoings for emergency_fund, and the
+share of borrowed principal repaid for debt_trend. score rates it from 0 to 100, weight is its share
+of the health score and contribution is score times weight; the contributions add up to the score.
+*/
+type HealthScoreComponentDTO struct {
+	Name         string  `json:"name" example:"debt_to_income"`
+	Value        float64 `json:"value" example:"0.253"`
+	Score        float64 `json:"score" example:"83.5"`
+	Weight       float64 `json:"weight" example:"0.278"`
+	Contribution float64 `json:"contribution" example:"23.19"`
+}
+
+// FromDomain converts domain.HealthScoreComponent to HealthScoreComponentDTO
+func (dto *HealthScoreComponentDTO) FromDomain(component domain.HealthScoreComponent) {
+	dto.Name = component.Name
+	dto.Value = component.Value
+	dto.Score = component.Score
+	dto.Weight = component.Weight
+	dto.Contribution = component.Contribution
+}
+
+/*
+Response BudgetStatusDTO dto
+This month's spending in a budget's category compared with its limit
+*/
+type BudgetStatusDTO struct {
+	BudgetID       string  `json:"budget_id" example:"budget-123"`
+	Category       string  `json:"category" example:"food"`
+	MonthlyLimit   float64 `json:"monthly_limit" example:"600.00"`
+	RolloverAmount float64 `json:"rollover_amount" example:"50.00"`
+	Limit          float64 `json:"limit" example:"650.00"`
+	Spent          float64 `json:"spent" example:"720.00"`
+	Remaining      float64 `json:"remaining" example:"-70.00"`
+	PercentUsed    float64 `json:"percent_used" example:"110.77"`
+	OverBudget     bool    `json:"over_budget" example:"true"`
 }
 
 /*
@@ -142,6 +549,7 @@ Financial summary with income, expenses, and debt analysis
 */
 type FinanceSummaryResponseDTO struct {
 	UserID              string    `json:"user_id" example:"user-456"`
+	Currency            string    `json:"currency" example:"USD"`
 	MonthlyIncome       float64   `json:"monthly_income" example:"5000.00"`
 	MonthlyExpenses     float64   `json:"monthly_expenses" example:"3200.00"`
 	MonthlyLoanPayments float64   `json:"monthly_loan_payments" example:"1266.71"`
@@ -151,6 +559,161 @@ type FinanceSummaryResponseDTO struct {
 	FinancialHealth     string    `json:"financial_health" example:"Good"`
 	BudgetRemaining     float64   `json:"budget_remaining" example:"533.29"`
 	UpdatedAt           time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+
+	// FinancialHealthScore is the 0-100 score financial_health is derived from, and
+	// FinancialHealthComponents what it is built from
+	FinancialHealthScore      float64                   `json:"financial_health_score" example:"71.4"`
+	FinancialHealthComponents []HealthScoreComponentDTO `json:"financial_health_components"`
+
+	GoalsMonthlyCommitment float64             `json:"goals_monthly_commitment" example:"708.33"`
+	GoalsAchievable        bool                `json:"goals_achievable" example:"false"`
+	GoalProjections        []GoalProjectionDTO `json:"goal_projections"`
+	GoalWarnings           []string            `json:"goal_warnings"`
+
+	// Installments lists the installment expenses still being paid off and when each drops
+	// out of monthly_expenses
+	Installments []InstallmentScheduleDTO `json:"installments"`
+
+	// Budgets compares each budget with this month's spending in its category;
+	// overspent_categories lists up to three over-budget categories, the most overspent first
+	Budgets             []BudgetStatusDTO `json:"budgets"`
+	OverspentCategories []BudgetStatusDTO `json:"overspent_categories"`
+}
+
+/*
+Response IncomePageResponseDTO dto
+One page of a cursor-paginated income list. Pass next_cursor as cursor to get the next page;
+it is empty on the last page.
+*/
+type IncomePageResponseDTO struct {
+	Incomes    []IncomeResponseDTO `json:"incomes"`
+	NextCursor string              `json:"next_cursor" example:"eyJjIjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpIjoiaW5jb21lLTEyMyJ9"`
+	HasMore    bool                `json:"has_more" example:"true"`
+	Limit      int                 `json:"limit" example:"20"`
+}
+
+/*
+Response LoanPageResponseDTO dto
+One page of a cursor-paginated loan list. Pass next_cursor as cursor to get the next page;
+it is empty on the last page.
+*/
+type LoanPageResponseDTO struct {
+	Loans      []LoanResponseDTO `json:"loans"`
+	NextCursor string            `json:"next_cursor" example:"eyJjIjoiMjAyNC0wMS0xNVQxMDozMDowMFoiLCJpIjoibG9hbi0xMjMifQ"`
+	HasMore    bool              `json:"has_more" example:"true"`
+	Limit      int               `json:"limit" example:"20"`
+}
+
+/*
+Response AffordabilityResponseDTO dto
+Maximum amount the user can afford to spend on a purchase
+*/
+type AffordabilityResponseDTO struct {
+	UserID              string  `json:"user_id" example:"user-456"`
+	MaxAffordableAmount float64 `json:"max_affordable_amount" example:"1599.87"`
+	Currency            string  `json:"currency" example:"USD"`
+	CalculationDate     string  `json:"calculation_date" example:"now"`
+}
+
+/*
+Request PurchaseAffordabilityCheckDTO dto
+A planned purchase, priced in the base currency, and how it would be paid for.
+months and interest_rate apply to installments; horizon_months is how many months of disposable
+income may be saved up to pay cash and defaults to 1.
+*/
+type PurchaseAffordabilityCheckDTO struct {
+	Price         float64 `json:"price" validate:"required,gt=0,money" example:"1200.00"`
+	PaymentMethod string  `json:"payment_method" validate:"required,oneof=cash installments" example:"installments"`
+	Months        int     `json:"months,omitempty" validate:"required_if=PaymentMethod installments,gte=0,lte=600" example:"12"`
+	InterestRate  float64 `json:"interest_rate,omitempty" validate:"gte=0,lte=100" example:"9.9"`
+	HorizonMonths int     `json:"horizon_months,omitempty" validate:"gte=0,lte=600" example:"3"`
+}
+
+// ToDomain converts PurchaseAffordabilityCheckDTO to domain.PurchasePlan
+func (dto PurchaseAffordabilityCheckDTO) ToDomain() domain.PurchasePlan {
+	return domain.PurchasePlan{
+		Price:         dto.Price,
+		PaymentMethod: dto.PaymentMethod,
+		Months:        dto.Months,
+		InterestRate:  dto.InterestRate,
+		HorizonMonths: dto.HorizonMonths,
+	}
+}
+
+/*
+Response PurchaseAffordabilityResponseDTO dto
+Whether a planned purchase is allowed, borderline or not recommended, and what limits it.
+limiting_factor is omitted when the purchase is allowed. available_cash is only set for cash and
+monthly_payment and total_cost only for installments. Ratios are fractions of monthly income after the purchase.
+*/
+type PurchaseAffordabilityResponseDTO struct {
+	UserID            string  `json:"user_id" example:"user-456"`
+	Currency          string  `json:"currency" example:"USD"`
+	Price             float64 `json:"price" example:"1200.00"`
+	PaymentMethod     string  `json:"payment_method" example:"installments"`
+	Months            int     `json:"months,omitempty" example:"12"`
+	InterestRate      float64 `json:"interest_rate,omitempty" example:"9.9"`
+	HorizonMonths     int     `json:"horizon_months,omitempty" example:"3"`
+	Verdict           string  `json:"verdict" example:"borderline"`
+	LimitingFactor    string  `json:"limiting_factor,omitempty" example:"debt_to_income"`
+	AvailableCash     float64 `json:"available_cash,omitempty" example:"2400.00"`
+	MonthlyPayment    float64 `json:"monthly_payment,omitempty" example:"105.40"`
+	TotalCost         float64 `json:"total_cost,omitempty" example:"1264.80"`
+	DebtToIncomeRatio float64 `json:"debt_to_income_ratio" example:"0.38"`
+	SavingsRate       float64 `json:"savings_rate" example:"0.12"`
+}
+
+/*
+Response FinancialHealthExplanationResponseDTO dto
+The financial health tier, the metrics that decide it and a plain-language reason for each.
+Ratios are fractions of monthly income; disposable_income is monthly and in currency.
+*/
+type FinancialHealthExplanationResponseDTO struct {
+	UserID            string   `json:"user_id" example:"user-456"`
+	Currency          string   `json:"currency" example:"USD"`
+	FinancialHealth   string   `json:"financial_health" example:"Fair"`
+	DebtToIncomeRatio float64  `json:"debt_to_income_ratio" example:"0.2"`
+	SavingsRate       float64  `json:"savings_rate" example:"0.08"`
+	DisposableIncome  float64  `json:"disposable_income" example:"400.00"`
+	Reasons           []string `json:"reasons" example:"DTI of 20% is healthy,savings rate of 8% is below the 10% minimum"`
+}
+
+/*
+Request SuggestExpenseCutsDTO dto
+Monthly amount, in the base currency, the user wants to free up by cutting expenses
+*/
+type SuggestExpenseCutsDTO struct {
+	TargetSavings float64 `json:"target_savings" validate:"required,gt=0,money" example:"400.00"`
+}
+
+/*
+Response ExpenseCutDTO dto
+An expense suggested for cutting and its monthly amount in the base currency
+*/
+type ExpenseCutDTO struct {
+	ExpenseID      string  `json:"expense_id" example:"expense-123"`
+	Name           string  `json:"name" example:"Dining out"`
+	Category       string  `json:"category" example:"entertainment"`
+	Priority       int     `json:"priority" example:"3"`
+	MonthlySavings float64 `json:"monthly_savings" example:"300.00"`
+}
+
+/*
+Response ExpenseCutSuggestionResponseDTO dto
+Discretionary expenses to cut, lowest priority first, to free up the target each month.
+target_reachable is false, and shortfall is what is still missing, when cutting every
+discretionary expense isn't enough; fixed and essential expenses are never suggested.
+*/
+type ExpenseCutSuggestionResponseDTO struct {
+	UserID                    string          `json:"user_id" example:"user-456"`
+	Currency                  string          `json:"currency" example:"USD"`
+	TargetSavings             float64         `json:"target_savings" example:"400.00"`
+	Cuts                      []ExpenseCutDTO `json:"cuts"`
+	TotalSavings              float64         `json:"total_savings" example:"450.00"`
+	DisposableIncome          float64         `json:"disposable_income" example:"850.00"`
+	ResultingDisposableIncome float64         `json:"resulting_disposable_income" example:"1300.00"`
+	TargetReachable           bool            `json:"target_reachable" example:"true"`
+	Shortfall                 float64         `json:"shortfall" example:"0"`
 }
 
 // Conversion Methods - DTO to Domain
@@ -161,6 +724,7 @@ func (dto AddIncomeDTO) ToDomain(userID string) domain.Income {
 		UserID:    userID,
 		Source:    dto.Source,
 		Amount:    dto.Amount,
+		Currency:  dto.Currency,
 		Frequency: dto.Frequency,
 		IsActive:  true,
 		CreatedAt: time.Now(),
@@ -170,32 +734,107 @@ func (dto AddIncomeDTO) ToDomain(userID string) domain.Income {
 
 // ToDomain converts AddExpenseDTO to domain.Expense
 func (dto AddExpenseDTO) ToDomain(userID string) domain.Expense {
-	return domain.Expense{
+	expense := domain.Expense{
 		UserID:    userID,
 		Category:  dto.Category,
 		Name:      dto.Name,
 		Amount:    dto.Amount,
+		Currency:  dto.Currency,
 		Frequency: dto.Frequency,
 		IsFixed:   dto.IsFixed,
 		Priority:  dto.Priority,
+		InstallmentsTotal: dto.InstallmentsTotal,
+		InstallmentsPaid:  dto.InstallmentsPaid,
+		Tags:      dto.Tags,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
+	if dto.InstallmentsTotal > 0 {
+		expense.InstallmentStart = expense.CreatedAt
+		if dto.InstallmentStart != nil {
+			expense.InstallmentStart = *dto.InstallmentStart
+		}
+	}
+	return expense
+}
+
+// ToDomain converts ExpenseFilterDTO to domain.ExpenseFilter
+// created_to is a whole day, so the exclusive upper bound is the following midnight
+func (dto ExpenseFilterDTO) ToDomain() domain.ExpenseFilter {
+	filter := domain.ExpenseFilter{
+		Query:       strings.TrimSpace(dto.Query),
+		Category:    dto.Category,
+		Frequency:   dto.Frequency,
+		Tag:         domain.NormalizeExpenseTag(dto.Tag),
+		Priority:    dto.Priority,
+		IsFixed:     dto.IsFixed,
+		MinAmount:   dto.MinAmount,
+		MaxAmount:   dto.MaxAmount,
+		CreatedFrom: dto.CreatedFrom,
+	}
+	if !dto.CreatedTo.IsZero() {
+		filter.CreatedBefore = dto.CreatedTo.AddDate(0, 0, 1)
+	}
+	return filter
 }
 
 // ToDomain converts AddLoanDTO to domain.Loan
 func (dto AddLoanDTO) ToDomain(userID string) domain.Loan {
 	return domain.Loan{
-		UserID:           userID,
-		Lender:           dto.Lender,
-		Type:             dto.Type,
-		PrincipalAmount:  dto.PrincipalAmount,
-		RemainingBalance: dto.RemainingBalance,
-		MonthlyPayment:   dto.MonthlyPayment,
-		InterestRate:     dto.InterestRate,
-		EndDate:          dto.EndDate,
-		CreatedAt:        time.Now(),
-		UpdatedAt:        time.Now(),
+		UserID:             userID,
+		Lender:             dto.Lender,
+		Type:               dto.Type,
+		PrincipalAmount:    dto.PrincipalAmount,
+		RemainingBalance:   dto.RemainingBalance,
+		MonthlyPayment:     dto.MonthlyPayment,
+		InterestRate:       dto.InterestRate,
+		Currency:           dto.Currency,
+		EndDate:            dto.EndDate,
+		AutoAccrueInterest: dto.AutoAccrueInterest,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+}
+
+// ToDomain converts AddSavingsGoalDTO to domain.SavingsGoal
+func (dto AddSavingsGoalDTO) ToDomain(userID string) domain.SavingsGoal {
+	return domain.SavingsGoal{
+		UserID:        userID,
+		Name:          strings.TrimSpace(dto.Name),
+		TargetAmount:  dto.TargetAmount,
+		CurrentAmount: dto.CurrentAmount,
+		TargetDate:    dto.TargetDate,
+		Priority:      dto.Priority,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// ToDomain converts AddBudgetDTO to domain.Budget
+func (dto AddBudgetDTO) ToDomain(userID string) domain.Budget {
+	return domain.Budget{
+		UserID:       userID,
+		Category:     dto.Category,
+		MonthlyLimit: dto.MonthlyLimit,
+		Rollover:     dto.Rollover,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// ToDomain converts AddGoalContributionDTO to domain.GoalContribution
+func (dto AddGoalContributionDTO) ToDomain(userID, goalID string) domain.GoalContribution {
+	contributedAt := time.Now()
+	if dto.ContributedAt != nil {
+		contributedAt = *dto.ContributedAt
+	}
+	return domain.GoalContribution{
+		GoalID:        goalID,
+		UserID:        userID,
+		Amount:        dto.Amount,
+		Note:          strings.TrimSpace(dto.Note),
+		ContributedAt: contributedAt,
+		CreatedAt:     time.Now(),
 	}
 }
 
@@ -207,6 +846,7 @@ func (dto *IncomeResponseDTO) FromDomain(income domain.Income) {
 	dto.UserID = income.UserID
 	dto.Source = income.Source
 	dto.Amount = income.Amount
+	dto.Currency = income.Currency
 	dto.Frequency = income.Frequency
 	dto.IsActive = income.IsActive
 	dto.CreatedAt = income.CreatedAt
@@ -220,9 +860,24 @@ func (dto *ExpenseResponseDTO) FromDomain(expense domain.Expense) {
 	dto.Category = expense.Category
 	dto.Name = expense.Name
 	dto.Amount = expense.Amount
+	dto.Currency = expense.Currency
 	dto.Frequency = expense.Frequency
 	dto.IsFixed = expense.IsFixed
 	dto.Priority = expense.Priority
+	dto.IsActive = expense.IsActive()
+	dto.InstallmentsTotal = expense.InstallmentsTotal
+	dto.InstallmentsPaid = expense.InstallmentsPaid
+	if expense.IsInstallment() {
+		dto.InstallmentAmount = expense.InstallmentAmount()
+		start := expense.InstallmentStart
+		final := expense.FinalInstallmentDate()
+		dto.InstallmentStart = &start
+		dto.FinalInstallmentDate = &final
+	}
+	dto.Tags = expense.Tags
+	if dto.Tags == nil {
+		dto.Tags = []string{}
+	}
 	dto.CreatedAt = expense.CreatedAt
 	dto.UpdatedAt = expense.UpdatedAt
 }
@@ -237,14 +892,167 @@ func (dto *LoanResponseDTO) FromDomain(loan domain.Loan) {
 	dto.RemainingBalance = loan.RemainingBalance
 	dto.MonthlyPayment = loan.MonthlyPayment
 	dto.InterestRate = loan.InterestRate
+	dto.Currency = loan.Currency
 	dto.EndDate = loan.EndDate
+	dto.AutoAccrueInterest = loan.AutoAccrueInterest
 	dto.CreatedAt = loan.CreatedAt
 	dto.UpdatedAt = loan.UpdatedAt
 }
 
+// FromDomain converts domain.LoanBalanceEntry to LoanBalanceEntryResponseDTO
+func (dto *LoanBalanceEntryResponseDTO) FromDomain(entry domain.LoanBalanceEntry) {
+	dto.ID = entry.ID
+	dto.LoanID = entry.LoanID
+	dto.Type = entry.Type
+	dto.OldBalance = entry.OldBalance
+	dto.NewBalance = entry.NewBalance
+	dto.Amount = entry.Amount
+	dto.InterestPortion = entry.InterestPortion
+	dto.PrincipalPortion = entry.PrincipalPortion
+	dto.Note = entry.Note
+	dto.CreatedAt = entry.CreatedAt
+}
+
+// FromDomain converts domain.PurchaseAffordability to PurchaseAffordabilityResponseDTO
+func (dto *PurchaseAffordabilityResponseDTO) FromDomain(result domain.PurchaseAffordability) {
+	dto.UserID = result.UserID
+	dto.Currency = result.Currency
+	dto.Price = result.Plan.Price
+	dto.PaymentMethod = result.Plan.PaymentMethod
+	if result.Plan.PaymentMethod == domain.PaymentMethodInstallments {
+		dto.Months = result.Plan.Months
+		dto.InterestRate = result.Plan.InterestRate
+	} else {
+		dto.HorizonMonths = result.Plan.HorizonMonths
+	}
+	dto.Verdict = result.Verdict
+	dto.LimitingFactor = result.LimitingFactor
+	dto.AvailableCash = result.AvailableCash
+	dto.MonthlyPayment = result.MonthlyPayment
+	dto.TotalCost = result.TotalCost
+	dto.DebtToIncomeRatio = result.DebtToIncomeRatio
+	dto.SavingsRate = result.SavingsRate
+}
+
+// FromDomain converts domain.FinancialHealthExplanation to FinancialHealthExplanationResponseDTO
+func (dto *FinancialHealthExplanationResponseDTO) FromDomain(explanation domain.FinancialHealthExplanation) {
+	dto.UserID = explanation.UserID
+	dto.Currency = explanation.Currency
+	dto.FinancialHealth = explanation.Tier
+	dto.DebtToIncomeRatio = explanation.DebtToIncomeRatio
+	dto.SavingsRate = explanation.SavingsRate
+	dto.DisposableIncome = explanation.DisposableIncome
+	dto.Reasons = explanation.Reasons
+}
+
+// FromDomain converts domain.LoanPayoffSimulation to LoanPayoffSimulationResponseDTO
+func (dto *LoanPayoffSimulationResponseDTO) FromDomain(simulation domain.LoanPayoffSimulation) {
+	dto.LoanID = simulation.LoanID
+	dto.ExtraMonthlyPayment = simulation.ExtraMonthlyPayment
+	dto.OneTimePayment = simulation.OneTimePayment
+	if simulation.Baseline.PaidOff {
+		payoff := simulation.Baseline.PayoffDate
+		dto.BaselinePayoffDate = &payoff
+		dto.BaselineMonths = simulation.Baseline.Months()
+		dto.BaselineTotalInterest = simulation.Baseline.TotalInterest
+	}
+	dto.PayoffDate = simulation.Simulated.PayoffDate
+	dto.Months = simulation.Simulated.Months()
+	dto.TotalInterest = simulation.Simulated.TotalInterest
+	dto.MonthsSaved = simulation.MonthsSaved
+	dto.InterestSaved = simulation.InterestSaved
+	dto.DebtToIncomeRatio = simulation.DebtToIncomeRatio
+	dto.SimulatedDebtToIncomeRatio = simulation.SimulatedDebtToIncomeRatio
+	dto.DebtToIncomeRatioAfterPayoff = simulation.DebtToIncomeRatioAfterPayoff
+}
+
+// FromDomain converts domain.LoanExtraPaymentImpact to LoanExtraPaymentImpactResponseDTO
+func (dto *LoanExtraPaymentImpactResponseDTO) FromDomain(impact domain.LoanExtraPaymentImpact) {
+	dto.LoanID = impact.LoanID
+	dto.ExtraMonthlyPayment = impact.ExtraMonthlyPayment
+	if impact.Baseline.PaidOff {
+		payoff := impact.Baseline.PayoffDate
+		dto.BaselinePayoffDate = &payoff
+		dto.BaselineMonths = impact.Baseline.Months()
+		dto.BaselineTotalInterest = impact.Baseline.TotalInterest
+	}
+	dto.PayoffDate = impact.Accelerated.PayoffDate
+	dto.Months = impact.Accelerated.Months()
+	dto.TotalInterest = impact.Accelerated.TotalInterest
+	dto.MonthsSaved = impact.MonthsSaved
+	dto.InterestSaved = impact.InterestSaved
+	dto.NearPayoff = impact.NearPayoff
+}
+
+// FromDomain converts domain.NearPayoffLoan to NearPayoffLoanResponseDTO
+func (dto *NearPayoffLoanResponseDTO) FromDomain(near domain.NearPayoffLoan) {
+	dto.LoanResponseDTO.FromDomain(near.Loan)
+	dto.PayoffThresholdBalance = near.ThresholdBalance
+	dto.PercentRemaining = near.PercentRemaining
+}
+
+// FromDomain converts domain.DebtBreakdown to DebtBreakdownResponseDTO
+func (dto *DebtBreakdownResponseDTO) FromDomain(breakdown domain.DebtBreakdown) {
+	dto.Currency = breakdown.Currency
+	dto.TotalBalance = breakdown.TotalBalance
+	dto.TotalMonthlyPayment = breakdown.TotalMonthlyPayment
+	dto.Types = make([]LoanTypeDebtDTO, len(breakdown.Types))
+	for i, typeDebt := range breakdown.Types {
+		dto.Types[i] = LoanTypeDebtDTO{
+			Type:             typeDebt.Type,
+			LoanCount:        typeDebt.LoanCount,
+			RemainingBalance: typeDebt.RemainingBalance,
+			MonthlyPayment:   typeDebt.MonthlyPayment,
+			SharePercent:     typeDebt.SharePercent,
+		}
+	}
+}
+
+// FromDomain converts domain.IncomeDiversification to IncomeDiversificationResponseDTO
+func (dto *IncomeDiversificationResponseDTO) FromDomain(diversification domain.IncomeDiversification) {
+	dto.Currency = diversification.Currency
+	dto.MonthlyIncome = diversification.MonthlyIncome
+	dto.SourceCount = len(diversification.Sources)
+	dto.LargestSourceShare = diversification.LargestSourceShare
+	dto.ConcentrationIndex = diversification.ConcentrationIndex
+	dto.SingleSourceDependent = diversification.SingleSourceDependent
+	dto.Sources = make([]IncomeSourceShareDTO, len(diversification.Sources))
+	for i, source := range diversification.Sources {
+		dto.Sources[i] = IncomeSourceShareDTO{
+			Source:        source.Source,
+			IncomeCount:   source.IncomeCount,
+			MonthlyAmount: source.MonthlyAmount,
+			Share:         source.Share,
+		}
+	}
+}
+
+// FromDomain converts domain.ExpenseCutSuggestion to ExpenseCutSuggestionResponseDTO
+func (dto *ExpenseCutSuggestionResponseDTO) FromDomain(suggestion domain.ExpenseCutSuggestion) {
+	dto.UserID = suggestion.UserID
+	dto.Currency = suggestion.Currency
+	dto.TargetSavings = suggestion.TargetSavings
+	dto.Cuts = make([]ExpenseCutDTO, len(suggestion.Cuts))
+	for i, cut := range suggestion.Cuts {
+		dto.Cuts[i] = ExpenseCutDTO{
+			ExpenseID:      cut.Expense.ID,
+			Name:           cut.Expense.Name,
+			Category:       cut.Expense.Category,
+			Priority:       cut.Expense.Priority,
+			MonthlySavings: cut.MonthlySavings,
+		}
+	}
+	dto.TotalSavings = suggestion.TotalSavings
+	dto.DisposableIncome = suggestion.DisposableIncome
+	dto.ResultingDisposableIncome = suggestion.ResultingDisposableIncome
+	dto.TargetReachable = suggestion.TargetReachable
+	dto.Shortfall = suggestion.Shortfall
+}
+
 // FromDomain converts domain.FinanceSummary to FinanceSummaryResponseDTO
 func (dto *FinanceSummaryResponseDTO) FromDomain(summary domain.FinanceSummary) {
 	dto.UserID = summary.UserID
+	dto.Currency = summary.Currency
 	dto.MonthlyIncome = summary.MonthlyIncome
 	dto.MonthlyExpenses = summary.MonthlyExpenses
 	dto.MonthlyLoanPayments = summary.MonthlyLoanPayments
@@ -254,6 +1062,117 @@ func (dto *FinanceSummaryResponseDTO) FromDomain(summary domain.FinanceSummary)
 	dto.FinancialHealth = summary.FinancialHealth
 	dto.BudgetRemaining = summary.BudgetRemaining
 	dto.UpdatedAt = summary.UpdatedAt
+	dto.FinancialHealthScore = summary.HealthScore
+	dto.FinancialHealthComponents = make([]HealthScoreComponentDTO, len(summary.HealthComponents))
+	for i, component := range summary.HealthComponents {
+		dto.FinancialHealthComponents[i].FromDomain(component)
+	}
+	dto.GoalsMonthlyCommitment = summary.GoalsMonthlyCommitment
+	dto.GoalsAchievable = summary.GoalsAchievable()
+	dto.GoalProjections = make([]GoalProjectionDTO, len(summary.GoalProjections))
+	for i, projection := range summary.GoalProjections {
+		dto.GoalProjections[i].FromDomain(projection)
+	}
+	dto.GoalWarnings = summary.GoalShortfallWarnings()
+	if dto.GoalWarnings == nil {
+		dto.GoalWarnings = []string{}
+	}
+	dto.Installments = make([]InstallmentScheduleDTO, len(summary.Installments))
+	for i, schedule := range summary.Installments {
+		dto.Installments[i].FromDomain(schedule)
+	}
+	dto.Budgets = make([]BudgetStatusDTO, len(summary.Budgets))
+	for i, status := range summary.Budgets {
+		dto.Budgets[i].FromDomain(status)
+	}
+	dto.OverspentCategories = make([]BudgetStatusDTO, len(summary.OverspentCategories))
+	for i, status := range summary.OverspentCategories {
+		dto.OverspentCategories[i].FromDomain(status)
+	}
+}
+
+// FromDomain converts domain.SavingsGoal to SavingsGoalResponseDTO
+func (dto *SavingsGoalResponseDTO) FromDomain(goal domain.SavingsGoal) {
+	dto.ID = goal.ID
+	dto.UserID = goal.UserID
+	dto.Name = goal.Name
+	dto.TargetAmount = goal.TargetAmount
+	dto.CurrentAmount = goal.CurrentAmount
+	dto.RemainingAmount = goal.RemainingAmount()
+	dto.ProgressPercent = goal.CalculateProgress()
+	dto.RequiredMonthly = goal.RequiredMonthlyContribution(time.Now())
+	dto.TargetDate = goal.TargetDate
+	dto.Priority = goal.Priority
+	dto.IsComplete = goal.IsComplete()
+	dto.CreatedAt = goal.CreatedAt
+	dto.UpdatedAt = goal.UpdatedAt
+}
+
+// FromDomain converts domain.GoalProjection to GoalProjectionDTO
+func (dto *GoalProjectionDTO) FromDomain(projection domain.GoalProjection) {
+	dto.GoalID = projection.GoalID
+	dto.Name = projection.Name
+	dto.Priority = projection.Priority
+	dto.TargetDate = projection.TargetDate
+	dto.MonthsRemaining = projection.MonthsRemaining
+	dto.RequiredMonthly = projection.RequiredMonthly
+	dto.AllocatedMonthly = projection.AllocatedMonthly
+	dto.ProjectedAmount = projection.ProjectedAmount
+	dto.Shortfall = projection.Shortfall
+	dto.Achievable = projection.IsAchievable()
+}
+
+// FromDomain converts domain.InstallmentSchedule to InstallmentScheduleDTO
+func (dto *InstallmentScheduleDTO) FromDomain(schedule domain.InstallmentSchedule) {
+	dto.ExpenseID = schedule.ExpenseID
+	dto.Name = schedule.Name
+	dto.MonthlyAmount = schedule.MonthlyAmount
+	dto.InstallmentsPaid = schedule.InstallmentsPaid
+	dto.InstallmentsTotal = schedule.InstallmentsTotal
+	dto.FinalInstallmentDate = schedule.FinalInstallmentDate
+}
+
+// FromDomain converts domain.Budget to BudgetResponseDTO
+func (dto *BudgetResponseDTO) FromDomain(budget domain.Budget) {
+	dto.ID = budget.ID
+	dto.UserID = budget.UserID
+	dto.Category = budget.Category
+	dto.MonthlyLimit = budget.MonthlyLimit
+	dto.Rollover = budget.Rollover
+	dto.CreatedAt = budget.CreatedAt
+	dto.UpdatedAt = budget.UpdatedAt
+}
+
+// FromDomain converts domain.BudgetStatus to BudgetStatusDTO
+func (dto *BudgetStatusDTO) FromDomain(status domain.BudgetStatus) {
+	dto.BudgetID = status.BudgetID
+	dto.Category = status.Category
+	dto.MonthlyLimit = status.MonthlyLimit
+	dto.RolloverAmount = status.RolloverAmount
+	dto.Limit = status.Limit
+	dto.Spent = status.Spent
+	dto.Remaining = status.Remaining
+	dto.PercentUsed = status.PercentUsed
+	dto.OverBudget = status.OverBudget
+}
+
+// FromDomain converts domain.GoalContribution to GoalContributionResponseDTO
+func (dto *GoalContributionResponseDTO) FromDomain(contribution domain.GoalContribution) {
+	dto.ID = contribution.ID
+	dto.GoalID = contribution.GoalID
+	dto.Amount = contribution.Amount
+	dto.Note = contribution.Note
+	dto.ContributedAt = contribution.ContributedAt
+}
+
+// FromDomain converts domain.GoalContributionMonth to GoalContributionMonthDTO
+func (dto *GoalContributionMonthDTO) FromDomain(month domain.GoalContributionMonth) {
+	dto.Month = month.Month.Format("2006-01")
+	dto.Total = month.Total
+	dto.Contributions = make([]GoalContributionResponseDTO, len(month.Contributions))
+	for i, contribution := range month.Contributions {
+		dto.Contributions[i].FromDomain(contribution)
+	}
 }
 
 // Update Methods - Apply Updates to Domain Structs
@@ -266,6 +1185,9 @@ func (dto UpdateIncomeDTO) ApplyUpdates(income *domain.Income) {
 	if dto.Amount != nil {
 		income.Amount = *dto.Amount
 	}
+	if dto.Currency != nil {
+		income.Currency = *dto.Currency
+	}
 	if dto.Frequency != nil {
 		income.Frequency = *dto.Frequency
 	}
@@ -283,6 +1205,9 @@ func (dto UpdateExpenseDTO) ApplyUpdates(expense *domain.Expense) {
 	if dto.Amount != nil {
 		expense.Amount = *dto.Amount
 	}
+	if dto.Currency != nil {
+		expense.Currency = *dto.Currency
+	}
 	if dto.Frequency != nil {
 		expense.Frequency = *dto.Frequency
 	}
@@ -292,6 +1217,9 @@ func (dto UpdateExpenseDTO) ApplyUpdates(expense *domain.Expense) {
 	if dto.Priority != nil {
 		expense.Priority = *dto.Priority
 	}
+	if dto.Tags != nil {
+		expense.Tags = *dto.Tags
+	}
 	expense.UpdatedAt = time.Now()
 }
 
@@ -312,11 +1240,51 @@ func (dto UpdateLoanDTO) ApplyUpdates(loan *domain.Loan) {
 	if dto.MonthlyPayment != nil {
 		loan.MonthlyPayment = *dto.MonthlyPayment
 	}
+	if dto.Currency != nil {
+		loan.Currency = *dto.Currency
+	}
 	if dto.InterestRate != nil {
 		loan.InterestRate = *dto.InterestRate
 	}
 	if dto.EndDate != nil {
 		loan.EndDate = *dto.EndDate
 	}
+	if dto.AutoAccrueInterest != nil {
+		loan.AutoAccrueInterest = *dto.AutoAccrueInterest
+	}
 	loan.UpdatedAt = time.Now()
-}
\ No newline at end of file
+}
+
+// ApplyUpdates applies UpdateSavingsGoalDTO fields to domain.SavingsGoal
+func (dto UpdateSavingsGoalDTO) ApplyUpdates(goal *domain.SavingsGoal) {
+	if dto.Name != nil {
+		goal.Name = strings.TrimSpace(*dto.Name)
+	}
+	if dto.TargetAmount != nil {
+		goal.TargetAmount = *dto.TargetAmount
+	}
+	if dto.CurrentAmount != nil {
+		goal.CurrentAmount = *dto.CurrentAmount
+	}
+	if dto.TargetDate != nil {
+		goal.TargetDate = *dto.TargetDate
+	}
+	if dto.Priority != nil {
+		goal.Priority = *dto.Priority
+	}
+	goal.UpdatedAt = time.Now()
+}
+
+// ApplyUpdates applies UpdateBudgetDTO fields to domain.Budget
+func (dto UpdateBudgetDTO) ApplyUpdates(budget *domain.Budget) {
+	if dto.Category != nil {
+		budget.Category = *dto.Category
+	}
+	if dto.MonthlyLimit != nil {
+		budget.MonthlyLimit = *dto.MonthlyLimit
+	}
+	if dto.Rollover != nil {
+		budget.Rollover = *dto.Rollover
+	}
+	budget.UpdatedAt = time.Now()
+}
@@ -0,0 +1,110 @@
+package dtos
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Finance export query values
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+
+	ExportTypeExpenses = "expenses"
+	ExportTypeIncomes  = "incomes"
+	ExportTypeLoans    = "loans"
+	ExportTypeAll      = "all"
+)
+
+/*
+Request FinanceExportQueryDTO dto
+What to export and in which format, plus the expense list filters.
+The date range also applies to incomes and loans; the other filters only apply to expenses.
+*/
+type FinanceExportQueryDTO struct {
+	ExpenseFilterDTO
+	Format string `form:"format" example:"csv"`
+	Type   string `form:"type" example:"expenses"`
+}
+
+// CSV column names, matching the JSON field names of the response DTOs
+
+// IncomeCSVHeader is the header row of an income CSV export
+var IncomeCSVHeader = []string{"id", "source", "amount", "currency", "frequency", "is_active", "created_at", "updated_at"}
+
+// ExpenseCSVHeader is the header row of an expense CSV export
+var ExpenseCSVHeader = []string{
+	"id", "category", "name", "amount", "currency", "frequency", "is_fixed", "priority", "is_active",
+	"installments_total", "installments_paid", "created_at", "updated_at", "tags",
+}
+
+// ExpenseCSVTagSeparator joins an expense's tags into its tags column; tags never contain it
+const ExpenseCSVTagSeparator = ";"
+
+// LoanCSVHeader is the header row of a loan CSV export
+var LoanCSVHeader = []string{
+	"id", "lender", "type", "principal_amount", "remaining_balance", "monthly_payment", "interest_rate",
+	"currency", "end_date", "created_at", "updated_at",
+}
+
+// CSVRecord returns the income's fields in IncomeCSVHeader order
+func (dto IncomeResponseDTO) CSVRecord() []string {
+	return []string{
+		dto.ID,
+		dto.Source,
+		csvFloat(dto.Amount),
+		dto.Currency,
+		dto.Frequency,
+		strconv.FormatBool(dto.IsActive),
+		csvTime(dto.CreatedAt),
+		csvTime(dto.UpdatedAt),
+	}
+}
+
+// CSVRecord returns the expense's fields in ExpenseCSVHeader order
+func (dto ExpenseResponseDTO) CSVRecord() []string {
+	return []string{
+		dto.ID,
+		dto.Category,
+		dto.Name,
+		csvFloat(dto.Amount),
+		dto.Currency,
+		dto.Frequency,
+		strconv.FormatBool(dto.IsFixed),
+		strconv.Itoa(dto.Priority),
+		strconv.FormatBool(dto.IsActive),
+		strconv.Itoa(dto.InstallmentsTotal),
+		strconv.Itoa(dto.InstallmentsPaid),
+		csvTime(dto.CreatedAt),
+		csvTime(dto.UpdatedAt),
+		strings.Join(dto.Tags, ExpenseCSVTagSeparator),
+	}
+}
+
+// CSVRecord returns the loan's fields in LoanCSVHeader order
+func (dto LoanResponseDTO) CSVRecord() []string {
+	return []string{
+		dto.ID,
+		dto.Lender,
+		dto.Type,
+		csvFloat(dto.PrincipalAmount),
+		csvFloat(dto.RemainingBalance),
+		csvFloat(dto.MonthlyPayment),
+		csvFloat(dto.InterestRate),
+		dto.Currency,
+		csvTime(dto.EndDate),
+		csvTime(dto.CreatedAt),
+		csvTime(dto.UpdatedAt),
+	}
+}
+
+// csvFloat formats an amount with as many digits as it needs and no exponent
+func csvFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// csvTime formats a timestamp as RFC 3339 in UTC
+func csvTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339)
+}
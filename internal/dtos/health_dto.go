@@ -17,7 +17,7 @@ type CreateHealthProfileRequestDTO struct {
 	Weight               float64 `json:"weight" binding:"required,gt=0"`
 	FamilySize           int     `json:"family_size" binding:"required,gte=1"`
 	HasChronicConditions bool    `json:"has_chronic_conditions"`
-	EmergencyFundHealth  float64 `json:"emergency_fund_health" binding:"gte=0"`
+	EmergencyFundHealth  float64 `json:"emergency_fund_health" binding:"gte=0,lte=1000000000"`
 }
 
 // ToDomain converts DTO to domain struct
@@ -42,13 +42,15 @@ type UpdateHealthProfileRequestDTO struct {
 	Weight               float64 `json:"weight" binding:"omitempty,gt=0"`
 	FamilySize           int     `json:"family_size" binding:"omitempty,gte=1"`
 	HasChronicConditions bool    `json:"has_chronic_conditions"`
-	EmergencyFundHealth  float64 `json:"emergency_fund_health" binding:"omitempty,gte=0"`
+	EmergencyFundHealth  float64 `json:"emergency_fund_health" binding:"omitempty,gte=0,lte=1000000000"`
 }
 
 // HealthProfileResponseDTO represents a health profile response
 type HealthProfileResponseDTO struct {
 	ID                   string    `json:"id"`
 	UserID               string    `json:"user_id"`
+	Name                 string    `json:"name,omitempty"`
+	RelationToOwner      string    `json:"relation_to_owner"`
 	Age                  int       `json:"age"`
 	Gender               string    `json:"gender"`
 	Height               float64   `json:"height"`
@@ -65,6 +67,8 @@ type HealthProfileResponseDTO struct {
 func (dto *HealthProfileResponseDTO) FromDomain(profile *domain.HealthProfile) {
 	dto.ID = profile.ID
 	dto.UserID = profile.UserID
+	dto.Name = profile.Name
+	dto.RelationToOwner = profile.RelationToOwner
 	dto.Age = profile.Age
 	dto.Gender = profile.Gender
 	dto.Height = profile.Height
@@ -77,18 +81,135 @@ func (dto *HealthProfileResponseDTO) FromDomain(profile *domain.HealthProfile) {
 	dto.UpdatedAt = profile.UpdatedAt
 }
 
+// CreateDependentProfileRequestDTO represents a request to add a family member's profile to the account
+type CreateDependentProfileRequestDTO struct {
+	Name            string  `json:"name" binding:"required,max=100"`
+	RelationToOwner string  `json:"relation_to_owner" binding:"required,oneof=spouse child parent other"`
+	Age             int     `json:"age" binding:"required,gte=0,lte=120"`
+	Gender          string  `json:"gender" binding:"required,oneof=male female other"`
+	Height          float64 `json:"height" binding:"required,gt=0"`
+	Weight          float64 `json:"weight" binding:"required,gt=0"`
+}
+
+// ToDomain converts DTO to domain struct for the given owner
+func (dto CreateDependentProfileRequestDTO) ToDomain(ownerUserID string) *domain.HealthProfile {
+	return &domain.HealthProfile{
+		UserID:          ownerUserID,
+		Name:            dto.Name,
+		RelationToOwner: dto.RelationToOwner,
+		Age:             dto.Age,
+		Gender:          dto.Gender,
+		Height:          dto.Height,
+		Weight:          dto.Weight,
+		FamilySize:      1,
+	}
+}
+
+// UpdateDependentProfileRequestDTO represents a request to update a family member's profile.
+// Fields left empty keep their current values.
+type UpdateDependentProfileRequestDTO struct {
+	Name            string  `json:"name" binding:"omitempty,max=100"`
+	RelationToOwner string  `json:"relation_to_owner" binding:"omitempty,oneof=spouse child parent other"`
+	Age             int     `json:"age" binding:"omitempty,gte=0,lte=120"`
+	Gender          string  `json:"gender" binding:"omitempty,oneof=male female other"`
+	Height          float64 `json:"height" binding:"omitempty,gt=0"`
+	Weight          float64 `json:"weight" binding:"omitempty,gt=0"`
+}
+
+// ApplyTo copies the provided fields onto an existing dependent profile
+func (dto UpdateDependentProfileRequestDTO) ApplyTo(profile *domain.HealthProfile) {
+	if dto.Name != "" {
+		profile.Name = dto.Name
+	}
+	if dto.RelationToOwner != "" {
+		profile.RelationToOwner = dto.RelationToOwner
+	}
+	if dto.Age != 0 {
+		profile.Age = dto.Age
+	}
+	if dto.Gender != "" {
+		profile.Gender = dto.Gender
+	}
+	if dto.Height != 0 {
+		profile.Height = dto.Height
+	}
+	if dto.Weight != 0 {
+		profile.Weight = dto.Weight
+	}
+}
+
+// memberProfileID returns the profile a condition or expense request refers to.
+// family_member_id takes precedence over profile_id; both may be empty to mean the owner.
+func memberProfileID(profileID, familyMemberID string) string {
+	if familyMemberID != "" {
+		return familyMemberID
+	}
+	return profileID
+}
+
+// FamilyMemberRollupDTO represents the risk and medical costs of one family member
+type FamilyMemberRollupDTO struct {
+	ProfileID           string  `json:"profile_id"`
+	Name                string  `json:"name,omitempty"`
+	RelationToOwner     string  `json:"relation_to_owner"`
+	HealthRiskScore     int     `json:"health_risk_score"`
+	HealthRiskLevel     string  `json:"health_risk_level"`
+	ActiveConditions    int     `json:"active_conditions"`
+	MonthlyMedicalCost  float64 `json:"monthly_medical_cost"`
+	ProjectedAnnualCost float64 `json:"projected_annual_cost"`
+}
+
+// FamilyRollupDTO represents risk and medical costs aggregated across the family
+type FamilyRollupDTO struct {
+	Members                  []FamilyMemberRollupDTO `json:"members"`
+	HighestRiskScore         int                     `json:"highest_risk_score"`
+	HighestRiskLevel         string                  `json:"highest_risk_level"`
+	AverageRiskScore         float64                 `json:"average_risk_score"`
+	TotalMonthlyMedicalCost  float64                 `json:"total_monthly_medical_cost"`
+	TotalProjectedAnnualCost float64                 `json:"total_projected_annual_cost"`
+}
+
+// FamilyProfilesResponseDTO represents every profile on an account, self profile first.
+// Rollup is only included when aggregation is requested.
+type FamilyProfilesResponseDTO struct {
+	Profiles []HealthProfileResponseDTO `json:"profiles"`
+	Total    int                        `json:"total"`
+	Rollup   *FamilyRollupDTO           `json:"rollup,omitempty"`
+}
+
+// ProfileSnapshotDTO represents a single point in a profile's measurement history
+type ProfileSnapshotDTO struct {
+	Age        int       `json:"age"`
+	Height     float64   `json:"height"`
+	Weight     float64   `json:"weight"`
+	BMI        float64   `json:"bmi"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// ProfileHistoryResponseDTO represents weight and BMI over a time window, oldest point first
+type ProfileHistoryResponseDTO struct {
+	Months       int                  `json:"months"`
+	Points       []ProfileSnapshotDTO `json:"points"`
+	WeightChange float64              `json:"weight_change"`
+	BMIChange    float64              `json:"bmi_change"`
+}
+
 // Medical Condition DTOs
 
-// CreateMedicalConditionRequestDTO represents a request to create a medical condition
+// CreateMedicalConditionRequestDTO represents a request to create a medical condition.
+// With a catalog_id the name and category come from the condition catalog and the severity
+// defaults to the entry's suggestion; without one they are required.
 type CreateMedicalConditionRequestDTO struct {
 	UserID             string    `json:"user_id" binding:"required"`
-	ProfileID          string    `json:"profile_id" binding:"required"`
-	Name               string    `json:"name" binding:"required"`
-	Category           string    `json:"category" binding:"required,oneof=chronic acute mental_health preventive"`
-	Severity           string    `json:"severity" binding:"required,oneof=mild moderate severe critical"`
+	ProfileID          string    `json:"profile_id"`       // optional, defaults to the owner's profile
+	FamilyMemberID     string    `json:"family_member_id"` // optional alias for profile_id naming a dependent
+	CatalogID          string    `json:"catalog_id" binding:"max=16"`
+	Name               string    `json:"name" binding:"required_without=CatalogID,max=100"`
+	Category           string    `json:"category" binding:"required_without=CatalogID,omitempty,oneof=chronic acute mental_health preventive"`
+	Severity           string    `json:"severity" binding:"required_without=CatalogID,omitempty,oneof=mild moderate severe critical"`
 	DiagnosedDate      time.Time `json:"diagnosed_date" binding:"required"`
 	RequiresMedication bool      `json:"requires_medication"`
-	MonthlyMedCost     float64   `json:"monthly_med_cost" binding:"gte=0"`
+	MonthlyMedCost     float64   `json:"monthly_med_cost" binding:"gte=0,lte=1000000000"`
 	RiskFactor         float64   `json:"risk_factor" binding:"gte=0,lte=1"`
 	IsActive           bool      `json:"is_active"`
 }
@@ -97,7 +218,8 @@ type CreateMedicalConditionRequestDTO struct {
 func (dto CreateMedicalConditionRequestDTO) ToDomain() *domain.MedicalCondition {
 	return &domain.MedicalCondition{
 		UserID:             dto.UserID,
-		ProfileID:          dto.ProfileID,
+		ProfileID:          memberProfileID(dto.ProfileID, dto.FamilyMemberID),
+		CatalogID:          dto.CatalogID,
 		Name:               dto.Name,
 		Category:           dto.Category,
 		Severity:           dto.Severity,
@@ -111,30 +233,33 @@ func (dto CreateMedicalConditionRequestDTO) ToDomain() *domain.MedicalCondition
 
 // UpdateMedicalConditionRequestDTO represents a request to update a medical condition
 type UpdateMedicalConditionRequestDTO struct {
-	Name               string  `json:"name"`
+	Name               string  `json:"name" binding:"max=100"`
 	Category           string  `json:"category" binding:"omitempty,oneof=chronic acute mental_health preventive"`
 	Severity           string  `json:"severity" binding:"omitempty,oneof=mild moderate severe critical"`
 	RequiresMedication bool    `json:"requires_medication"`
-	MonthlyMedCost     float64 `json:"monthly_med_cost" binding:"gte=0"`
+	MonthlyMedCost     float64 `json:"monthly_med_cost" binding:"gte=0,lte=1000000000"`
 	RiskFactor         float64 `json:"risk_factor" binding:"gte=0,lte=1"`
 	IsActive           bool    `json:"is_active"`
 }
 
 // MedicalConditionResponseDTO represents a medical condition response
 type MedicalConditionResponseDTO struct {
-	ID                 string    `json:"id"`
-	UserID             string    `json:"user_id"`
-	ProfileID          string    `json:"profile_id"`
-	Name               string    `json:"name"`
-	Category           string    `json:"category"`
-	Severity           string    `json:"severity"`
-	DiagnosedDate      time.Time `json:"diagnosed_date"`
-	RequiresMedication bool      `json:"requires_medication"`
-	MonthlyMedCost     float64   `json:"monthly_med_cost"`
-	RiskFactor         float64   `json:"risk_factor"`
-	IsActive           bool      `json:"is_active"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                 string     `json:"id"`
+	UserID             string     `json:"user_id"`
+	ProfileID          string     `json:"profile_id"`
+	Name               string     `json:"name"`
+	Category           string     `json:"category"`
+	Severity           string     `json:"severity"`
+	DiagnosedDate      time.Time  `json:"diagnosed_date"`
+	ResolvedDate       *time.Time `json:"resolved_date,omitempty"`
+	RequiresMedication bool       `json:"requires_medication"`
+	MonthlyMedCost     float64    `json:"monthly_med_cost"`
+	RiskFactor         float64    `json:"risk_factor"`
+	IsActive           bool       `json:"is_active"`
+	CatalogID          string     `json:"catalog_id,omitempty"`
+	Uncatalogued       bool       `json:"uncatalogued"` // true for a free-text condition not added from the catalog
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
 }
 
 // FromDomain converts domain struct to DTO
@@ -146,36 +271,96 @@ func (dto *MedicalConditionResponseDTO) FromDomain(condition *domain.MedicalCond
 	dto.Category = condition.Category
 	dto.Severity = condition.Severity
 	dto.DiagnosedDate = condition.DiagnosedDate
+	dto.ResolvedDate = condition.ResolvedDate
 	dto.RequiresMedication = condition.RequiresMedication
 	dto.MonthlyMedCost = condition.MonthlyMedCost
 	dto.RiskFactor = condition.RiskFactor
 	dto.IsActive = condition.IsActive
+	dto.CatalogID = condition.CatalogID
+	dto.Uncatalogued = !condition.IsCatalogued()
 	dto.CreatedAt = condition.CreatedAt
 	dto.UpdatedAt = condition.UpdatedAt
 }
 
+// MedicalConditionCreatedResponseDTO represents the response to adding a medical condition
+type MedicalConditionCreatedResponseDTO struct {
+	Message   string                      `json:"message" example:"Condition added successfully"`
+	Condition MedicalConditionResponseDTO `json:"condition"`
+}
+
+// ConditionCatalogEntryDTO represents a condition catalog entry suggested for a search
+type ConditionCatalogEntryDTO struct {
+	ID              string `json:"id" example:"E11"`
+	Name            string `json:"name" example:"Type 2 Diabetes"`
+	Category        string `json:"category" example:"chronic"`
+	DefaultSeverity string `json:"default_severity" example:"moderate"`
+	RiskWeight      int    `json:"risk_weight" example:"8"`
+	MatchedName     string `json:"matched_name" example:"diabetes type ii"` // the name or alias the query matched
+	Score           int    `json:"score" example:"80"`
+}
+
+// FromDomain converts domain struct to DTO
+func (dto *ConditionCatalogEntryDTO) FromDomain(match domain.ConditionCatalogMatch) {
+	dto.ID = match.Entry.ID
+	dto.Name = match.Entry.Name
+	dto.Category = match.Entry.Category
+	dto.DefaultSeverity = match.Entry.DefaultSeverity
+	dto.RiskWeight = match.Entry.RiskWeight
+	dto.MatchedName = match.MatchedName
+	dto.Score = match.Score
+}
+
+// ConditionCatalogSearchResponseDTO represents condition catalog suggestions, best match first
+type ConditionCatalogSearchResponseDTO struct {
+	Query       string                     `json:"query" example:"diab"`
+	Suggestions []ConditionCatalogEntryDTO `json:"suggestions"`
+	Total       int                        `json:"total" example:"3"`
+}
+
+// ConditionStatusChangeDTO represents a point in a condition's status history
+type ConditionStatusChangeDTO struct {
+	Status string    `json:"status"`
+	Date   time.Time `json:"date"`
+}
+
+// ConditionTimelineEntryDTO represents a condition in the user's medical chronology
+type ConditionTimelineEntryDTO struct {
+	Condition          MedicalConditionResponseDTO `json:"condition"`
+	Status             string                      `json:"status"`
+	DaysSinceDiagnosis int                         `json:"days_since_diagnosis"`
+	DurationDays       int                         `json:"duration_days"`
+	StatusHistory      []ConditionStatusChangeDTO  `json:"status_history"`
+}
+
+// ConditionTimelineResponseDTO represents active and resolved conditions, each ordered by diagnosis date
+type ConditionTimelineResponseDTO struct {
+	Active   []ConditionTimelineEntryDTO `json:"active"`
+	Resolved []ConditionTimelineEntryDTO `json:"resolved"`
+}
+
 // Medical Expense DTOs
 
 // CreateMedicalExpenseRequestDTO represents a request to create a medical expense
 type CreateMedicalExpenseRequestDTO struct {
 	UserID           string    `json:"user_id" binding:"required"`
-	ProfileID        string    `json:"profile_id" binding:"required"`
-	Amount           float64   `json:"amount" binding:"required,gt=0"`
+	ProfileID        string    `json:"profile_id"`       // optional, defaults to the owner's profile
+	FamilyMemberID   string    `json:"family_member_id"` // optional alias for profile_id naming a dependent
+	Amount           float64   `json:"amount" binding:"required,gt=0,lte=1000000000"`
 	Category         string    `json:"category" binding:"required,oneof=doctor_visit medication hospital lab_test therapy equipment"`
-	Description      string    `json:"description" binding:"required"`
+	Description      string    `json:"description" binding:"required,max=500"`
 	Date             time.Time `json:"date" binding:"required"`
 	IsCovered        bool      `json:"is_covered"`
-	InsurancePayment float64   `json:"insurance_payment" binding:"gte=0"`
-	OutOfPocket      float64   `json:"out_of_pocket" binding:"gte=0"`
+	InsurancePayment float64   `json:"insurance_payment" binding:"gte=0,lte=1000000000"`
+	OutOfPocket      float64   `json:"out_of_pocket" binding:"gte=0,lte=1000000000"`
 	IsRecurring      bool      `json:"is_recurring"`
-	Frequency        string    `json:"frequency" binding:"required,oneof=one_time monthly quarterly annually"`
+	Frequency        string    `json:"frequency" binding:"required,oneof=one_time daily weekly biweekly bi-weekly monthly quarterly semiannual semiannually semi-annually annual annually yearly"`
 }
 
 // ToDomain converts DTO to domain struct
 func (dto CreateMedicalExpenseRequestDTO) ToDomain() *domain.MedicalExpense {
 	return &domain.MedicalExpense{
 		UserID:           dto.UserID,
-		ProfileID:        dto.ProfileID,
+		ProfileID:        memberProfileID(dto.ProfileID, dto.FamilyMemberID),
 		Amount:           dto.Amount,
 		Category:         dto.Category,
 		Description:      dto.Description,
@@ -224,18 +409,134 @@ func (dto *MedicalExpenseResponseDTO) FromDomain(expense *domain.MedicalExpense)
 	dto.UpdatedAt = expense.UpdatedAt
 }
 
+// ExpenseAttachmentResponseDTO represents a document attached to a medical expense
+type ExpenseAttachmentResponseDTO struct {
+	ID          string    `json:"id" example:"12"`
+	ExpenseID   string    `json:"expense_id" example:"7"`
+	FileName    string    `json:"file_name" example:"eob-march.pdf"`
+	ContentType string    `json:"content_type" example:"application/pdf"`
+	Size        int64     `json:"size" example:"48213"`
+	Checksum    string    `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FromDomain converts domain struct to DTO
+func (dto *ExpenseAttachmentResponseDTO) FromDomain(attachment domain.ExpenseAttachment) {
+	dto.ID = attachment.ID
+	dto.ExpenseID = attachment.ExpenseID
+	dto.FileName = attachment.FileName
+	dto.ContentType = attachment.ContentType
+	dto.Size = attachment.Size
+	dto.Checksum = attachment.Checksum
+	dto.CreatedAt = attachment.CreatedAt
+}
+
+// CreateExpenseOccurrenceRequestDTO represents a request to record what one occurrence of a
+// recurring medical expense actually cost
+type CreateExpenseOccurrenceRequestDTO struct {
+	Amount float64   `json:"amount" binding:"required,gt=0,lte=1000000000" example:"112.50"`
+	Date   time.Time `json:"date" binding:"required"`
+}
+
+// ToDomain converts DTO to domain struct
+func (dto CreateExpenseOccurrenceRequestDTO) ToDomain() *domain.MedicalExpenseOccurrence {
+	return &domain.MedicalExpenseOccurrence{
+		Amount: dto.Amount,
+		Date:   dto.Date,
+	}
+}
+
+// ExpenseOccurrenceResponseDTO represents a recorded occurrence of a recurring medical expense,
+// with the category and coverage inherited from the expense
+type ExpenseOccurrenceResponseDTO struct {
+	ID               string    `json:"id" example:"31"`
+	ExpenseID        string    `json:"expense_id" example:"7"`
+	ProfileID        string    `json:"profile_id" example:"3"`
+	Amount           float64   `json:"amount" example:"112.50"`
+	Date             time.Time `json:"date"`
+	Category         string    `json:"category" example:"medication"`
+	IsCovered        bool      `json:"is_covered" example:"true"`
+	InsurancePayment float64   `json:"insurance_payment" example:"90"`
+	OutOfPocket      float64   `json:"out_of_pocket" example:"22.50"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// FromDomain converts domain struct to DTO
+func (dto *ExpenseOccurrenceResponseDTO) FromDomain(occurrence *domain.MedicalExpenseOccurrence) {
+	dto.ID = occurrence.ID
+	dto.ExpenseID = occurrence.ExpenseID
+	dto.ProfileID = occurrence.ProfileID
+	dto.Amount = occurrence.Amount
+	dto.Date = occurrence.Date
+	dto.Category = occurrence.Category
+	dto.IsCovered = occurrence.IsCovered
+	dto.InsurancePayment = occurrence.InsurancePayment
+	dto.OutOfPocket = occurrence.OutOfPocket
+	dto.CreatedAt = occurrence.CreatedAt
+}
+
+// RecurringExpenseVarianceDTO compares the projected and recorded costs of recurring medical
+// expenses this year, over the months that have recorded occurrences
+type RecurringExpenseVarianceDTO struct {
+	ProjectedYTD     float64 `json:"projected_ytd" example:"300"`
+	ActualYTD        float64 `json:"actual_ytd" example:"337.50"`
+	Variance         float64 `json:"variance" example:"37.50"`
+	ReconciledMonths int     `json:"reconciled_months" example:"3"`
+}
+
+// Medication Schedule DTOs
+
+// CreateMedicationScheduleRequestDTO represents a request to track a medication's refills
+type CreateMedicationScheduleRequestDTO struct {
+	ConditionID     string    `json:"condition_id" binding:"required"`
+	MedicationName  string    `json:"medication_name" binding:"required,max=100"`
+	RefillEveryDays int       `json:"refill_every_days" binding:"required,gt=0"`
+	LastFilledDate  time.Time `json:"last_filled_date" binding:"required"`
+}
+
+// ToDomain converts DTO to domain struct
+func (dto CreateMedicationScheduleRequestDTO) ToDomain(userID string) *domain.MedicationSchedule {
+	return &domain.MedicationSchedule{
+		UserID:          userID,
+		ConditionID:     dto.ConditionID,
+		MedicationName:  dto.MedicationName,
+		RefillEveryDays: dto.RefillEveryDays,
+		LastFilledDate:  dto.LastFilledDate,
+		IsActive:        true,
+	}
+}
+
+// MedicationRefillResponseDTO represents a medication due for refill
+type MedicationRefillResponseDTO struct {
+	ScheduleID      string    `json:"schedule_id"`
+	ConditionID     string    `json:"condition_id"`
+	MedicationName  string    `json:"medication_name"`
+	RefillEveryDays int       `json:"refill_every_days"`
+	LastFilledDate  time.Time `json:"last_filled_date"`
+	DueDate         time.Time `json:"due_date"`
+	DaysUntilDue    int       `json:"days_until_due"`
+	IsOverdue       bool      `json:"is_overdue"`
+}
+
+// MedicationRefillListResponseDTO represents medications due for refill within a window
+type MedicationRefillListResponseDTO struct {
+	Refills    []MedicationRefillResponseDTO `json:"refills"`
+	WithinDays int                           `json:"within_days"`
+	Total      int                           `json:"total"`
+}
+
 // Insurance Policy DTOs
 
 // CreateInsurancePolicyRequestDTO represents a request to create an insurance policy
 type CreateInsurancePolicyRequestDTO struct {
 	UserID             string    `json:"user_id" binding:"required"`
-	PolicyNumber       string    `json:"policy_number" binding:"required"`
-	Provider           string    `json:"provider" binding:"required"`
+	PolicyNumber       string    `json:"policy_number" binding:"required,max=50"`
+	Provider           string    `json:"provider" binding:"required,max=100"`
 	Type               string    `json:"type" binding:"required,oneof=health dental vision life disability"`
 	CoveragePercentage float64   `json:"coverage_percentage" binding:"required,gte=0,lte=100"`
-	Deductible         float64   `json:"deductible" binding:"required,gte=0"`
-	OutOfPocketMax     float64   `json:"out_of_pocket_max" binding:"required,gte=0"`
-	MonthlyPremium     float64   `json:"monthly_premium" binding:"required,gte=0"`
+	Deductible         float64   `json:"deductible" binding:"required,gte=0,lte=1000000000"`
+	OutOfPocketMax     float64   `json:"out_of_pocket_max" binding:"required,gte=0,lte=1000000000"`
+	MonthlyPremium     float64   `json:"monthly_premium" binding:"required,gte=0,lte=1000000000"`
 	StartDate          time.Time `json:"start_date" binding:"required"`
 	EndDate            time.Time `json:"end_date" binding:"required"`
 	IsActive           bool      `json:"is_active"`
@@ -261,9 +562,9 @@ func (dto CreateInsurancePolicyRequestDTO) ToDomain() *domain.InsurancePolicy {
 // UpdateInsurancePolicyRequestDTO represents a request to update an insurance policy
 type UpdateInsurancePolicyRequestDTO struct {
 	CoveragePercentage float64   `json:"coverage_percentage" binding:"gte=0,lte=100"`
-	Deductible         float64   `json:"deductible" binding:"gte=0"`
-	OutOfPocketMax     float64   `json:"out_of_pocket_max" binding:"gte=0"`
-	MonthlyPremium     float64   `json:"monthly_premium" binding:"gte=0"`
+	Deductible         float64   `json:"deductible" binding:"gte=0,lte=1000000000"`
+	OutOfPocketMax     float64   `json:"out_of_pocket_max" binding:"gte=0,lte=1000000000"`
+	MonthlyPremium     float64   `json:"monthly_premium" binding:"gte=0,lte=1000000000"`
 	EndDate            time.Time `json:"end_date"`
 	IsActive           bool      `json:"is_active"`
 }
@@ -310,26 +611,381 @@ func (dto *InsurancePolicyResponseDTO) FromDomain(policy *domain.InsurancePolicy
 
 // UpdateDeductibleRequestDTO represents a request to update deductible progress
 type UpdateDeductibleRequestDTO struct {
-	Amount float64 `json:"amount" binding:"required,gt=0"`
+	Amount float64 `json:"amount" binding:"required,gt=0,lte=1000000000"`
+}
+
+// PolicyCandidateDTO represents a plan to include in a policy comparison
+type PolicyCandidateDTO struct {
+	PolicyNumber       string  `json:"policy_number" binding:"required,max=50"`
+	Provider           string  `json:"provider" binding:"required,max=100"`
+	Type               string  `json:"type" binding:"required,oneof=health dental vision comprehensive"`
+	CoveragePercentage float64 `json:"coverage_percentage" binding:"gte=0,lte=100"`
+	Deductible         float64 `json:"deductible" binding:"gte=0,lte=1000000000"`
+	OutOfPocketMax     float64 `json:"out_of_pocket_max" binding:"required,gt=0,lte=1000000000"`
+	MonthlyPremium     float64 `json:"monthly_premium" binding:"gte=0,lte=1000000000"`
+}
+
+// ToDomain converts DTO to domain struct
+func (dto PolicyCandidateDTO) ToDomain() domain.InsurancePolicy {
+	return domain.InsurancePolicy{
+		PolicyNumber:       dto.PolicyNumber,
+		Provider:           dto.Provider,
+		Type:               dto.Type,
+		CoveragePercentage: dto.CoveragePercentage,
+		Deductible:         dto.Deductible,
+		OutOfPocketMax:     dto.OutOfPocketMax,
+		MonthlyPremium:     dto.MonthlyPremium,
+		IsActive:           true,
+	}
+}
+
+// ComparePoliciesRequestDTO represents a request to compare policies for an expected annual spend.
+// When no policies are supplied the user's active policies are compared.
+type ComparePoliciesRequestDTO struct {
+	ExpectedAnnualSpend float64              `json:"expected_annual_spend" binding:"gte=0,lte=1000000000"`
+	Policies            []PolicyCandidateDTO `json:"policies" binding:"omitempty,max=20,dive"`
+}
+
+// PolicyCostProjectionDTO represents the projected annual cost of a policy
+type PolicyCostProjectionDTO struct {
+	PolicyID             string  `json:"policy_id,omitempty"`
+	PolicyNumber         string  `json:"policy_number"`
+	Provider             string  `json:"provider"`
+	Type                 string  `json:"type"`
+	AnnualPremium        float64 `json:"annual_premium"`
+	InsuranceCoverage    float64 `json:"insurance_coverage"`
+	ProjectedOutOfPocket float64 `json:"projected_out_of_pocket"`
+	TotalProjectedCost   float64 `json:"total_projected_cost"`
+	Rank                 int     `json:"rank"`
+}
+
+// PolicyComparisonResponseDTO represents policies ranked by total projected cost
+type PolicyComparisonResponseDTO struct {
+	ExpectedAnnualSpend float64                   `json:"expected_annual_spend"`
+	Policies            []PolicyCostProjectionDTO `json:"policies"`
+	Cheapest            PolicyCostProjectionDTO   `json:"cheapest"`
+}
+
+// PolicyAdequacyDTO represents one policy's expected cost and benefit for a year of projected expenses
+type PolicyAdequacyDTO struct {
+	PolicyID                 string   `json:"policy_id"`
+	PolicyNumber             string   `json:"policy_number"`
+	Provider                 string   `json:"provider"`
+	Type                     string   `json:"type"`
+	AnnualPremium            float64  `json:"annual_premium"`
+	ExpectedBenefit          float64  `json:"expected_benefit"`
+	ExpectedOutOfPocket      float64  `json:"expected_out_of_pocket"`
+	PremiumToBenefitRatio    float64  `json:"premium_to_benefit_ratio"`
+	CoverageSufficient       bool     `json:"coverage_sufficient"`
+	OutOfPocketMaxSufficient bool     `json:"out_of_pocket_max_sufficient"`
+	Flags                    []string `json:"flags"`
+}
+
+// InsuranceEvaluationResponseDTO represents an assessment of whether the user's active policies
+// are adequate for their projected expenses and risk level
+type InsuranceEvaluationResponseDTO struct {
+	UserID                  string              `json:"user_id"`
+	RiskLevel               string              `json:"risk_level"`
+	ProjectedAnnualExpenses float64             `json:"projected_annual_expenses"`
+	Policies                []PolicyAdequacyDTO `json:"policies"`
+}
+
+// CoverageGapDTO represents a single coverage gap with a concrete description
+type CoverageGapDTO struct {
+	Type              string  `json:"type"`
+	Description       string  `json:"description"`
+	RiskLevel         string  `json:"risk_level"`
+	Recommendation    string  `json:"recommendation"`
+	EstimatedExposure float64 `json:"estimated_exposure"`
+}
+
+// UncoveredCategoryDTO represents an expense category no active policy covers
+type UncoveredCategoryDTO struct {
+	Category     string  `json:"category"`
+	ExpenseCount int     `json:"expense_count"`
+	TotalAmount  float64 `json:"total_amount"`
+}
+
+// CoverageLapseDTO represents a period with no policy in force
+type CoverageLapseDTO struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Days      int       `json:"days"`
+}
+
+// CoverageGapsResponseDTO represents a detailed coverage gap analysis
+type CoverageGapsResponseDTO struct {
+	UserID                    string                 `json:"user_id"`
+	UncoveredCategories       []UncoveredCategoryDTO `json:"uncovered_categories"`
+	RecurringMonthlyCost      float64                `json:"recurring_monthly_cost"`
+	UncoveredRecurringMonthly float64                `json:"uncovered_recurring_monthly"`
+	UncoveredRecurringShare   float64                `json:"uncovered_recurring_share"`
+	CoverageLapses            []CoverageLapseDTO     `json:"coverage_lapses"`
+	Gaps                      []CoverageGapDTO       `json:"gaps"`
+}
+
+// CostProjectionResponseDTO represents projected medical costs for the next 12 months
+type CostProjectionResponseDTO struct {
+	UserID                    string  `json:"user_id"`
+	ProjectedGrossCost        float64 `json:"projected_gross_cost"`
+	ExpectedInsurancePayments float64 `json:"expected_insurance_payments"`
+	NetOutOfPocket            float64 `json:"net_out_of_pocket"`
+	CoveredGrossCost          float64 `json:"covered_gross_cost"`
+	UncoveredGrossCost        float64 `json:"uncovered_gross_cost"`
+	AnnualPremiums            float64 `json:"annual_premiums"`
+	RecurringExpenses         int     `json:"recurring_expenses"`
+	ExcludedOneTimeExpenses   int     `json:"excluded_one_time_expenses"`
+}
+
+// ExpenseCategoryTotalDTO represents year-to-date spending in one medical expense category
+type ExpenseCategoryTotalDTO struct {
+	Category           string  `json:"category"`
+	TotalAmount        float64 `json:"total_amount"`
+	TotalInsurancePaid float64 `json:"total_insurance_paid"`
+	TotalOutOfPocket   float64 `json:"total_out_of_pocket"`
+	ExpenseCount       int64   `json:"expense_count"`
+}
+
+// DeductibleProgressDTO represents an active policy's progress toward its deductible and out-of-pocket maximum
+type DeductibleProgressDTO struct {
+	PolicyID                    string  `json:"policy_id"`
+	Provider                    string  `json:"provider"`
+	Type                        string  `json:"type"`
+	Deductible                  float64 `json:"deductible"`
+	DeductibleMet               float64 `json:"deductible_met"`
+	DeductibleRemaining         float64 `json:"deductible_remaining"`
+	OutOfPocketMax              float64 `json:"out_of_pocket_max"`
+	OutOfPocketMet              float64 `json:"out_of_pocket_met"`
+	OutOfPocketRemaining        float64 `json:"out_of_pocket_remaining"`
+	ProjectedDeductibleMetMonth string  `json:"projected_deductible_met_month,omitempty" example:"2025-09"`
+	WillMeetDeductibleThisYear  bool    `json:"will_meet_deductible_this_year"`
+}
+
+// ExpenseAnalyticsResponseDTO represents a year-to-date breakdown of medical spending
+type ExpenseAnalyticsResponseDTO struct {
+	UserID             string                    `json:"user_id"`
+	Year               int                       `json:"year"`
+	YearToDateTotal    float64                   `json:"year_to_date_total"`
+	OutOfPocketTotal   float64                   `json:"out_of_pocket_total"`
+	InsurancePaidTotal float64                   `json:"insurance_paid_total"`
+	ExpenseCount       int64                     `json:"expense_count"`
+	ByCategory         []ExpenseCategoryTotalDTO `json:"by_category"`
+	MonthlyRunRate     float64                   `json:"monthly_run_rate"`
+	Policies           []DeductibleProgressDTO   `json:"policies"`
+}
+
+// ExpenseBreakdownResponseDTO represents the user's recorded medical spending split by category
+type ExpenseBreakdownResponseDTO struct {
+	UserID            string                        `json:"user_id"`
+	TotalAmount       float64                       `json:"total_amount"`
+	CoveredAmount     float64                       `json:"covered_amount"`
+	OutOfPocketAmount float64                       `json:"out_of_pocket_amount"`
+	ExpenseCount      int                           `json:"expense_count"`
+	Categories        []ExpenseCategoryBreakdownDTO `json:"categories"`
+}
+
+// ExpenseCategoryBreakdownDTO represents the spending in one medical expense category and its
+// share of the total, from 0 to 1
+type ExpenseCategoryBreakdownDTO struct {
+	Category          string  `json:"category"`
+	TotalAmount       float64 `json:"total_amount"`
+	CoveredAmount     float64 `json:"covered_amount"`
+	OutOfPocketAmount float64 `json:"out_of_pocket_amount"`
+	ExpenseCount      int     `json:"expense_count"`
+	Share             float64 `json:"share"`
+}
+
+// HSARecommendationResponseDTO represents a suggested pre-tax HSA contribution
+type HSARecommendationResponseDTO struct {
+	UserID                         string  `json:"user_id"`
+	Eligible                       bool    `json:"eligible"`
+	CoverageTier                   string  `json:"coverage_tier"`
+	QualifyingPolicyID             string  `json:"qualifying_policy_id,omitempty"`
+	ProjectedAnnualExpenses        float64 `json:"projected_annual_expenses"`
+	AnnualContributionLimit        float64 `json:"annual_contribution_limit"`
+	RecommendedAnnualContribution  float64 `json:"recommended_annual_contribution"`
+	RecommendedMonthlyContribution float64 `json:"recommended_monthly_contribution"`
+	Explanation                    string  `json:"explanation"`
+}
+
+// RiskBandDTO represents one scoring band of the risk model.
+// Max is omitted for the open-ended last band.
+type RiskBandDTO struct {
+	Min        float64  `json:"min"`
+	Max        *float64 `json:"max,omitempty"`
+	IncludeMax bool     `json:"include_max"`
+	Points     int      `json:"points"`
+}
+
+// RiskLevelCutoffsDTO represents the highest score in each risk level below critical
+type RiskLevelCutoffsDTO struct {
+	Low      int `json:"low" example:"25"`
+	Moderate int `json:"moderate" example:"50"`
+	High     int `json:"high" example:"75"`
+	MaxScore int `json:"max_score" example:"100"`
+}
+
+// RiskModelResponseDTO represents the weights used to calculate health risk scores
+type RiskModelResponseDTO struct {
+	AgeBands        []RiskBandDTO       `json:"age_bands"`
+	BMIBands        []RiskBandDTO       `json:"bmi_bands"`
+	FamilySizeBands []RiskBandDTO       `json:"family_size_bands"`
+	SeverityPoints  map[string]int      `json:"severity_points"`
+	CategoryWeights map[string]float64  `json:"category_weights"`
+	LevelCutoffs    RiskLevelCutoffsDTO `json:"level_cutoffs"`
+}
+
+// FromDomain converts domain struct to DTO
+func (dto *RiskModelResponseDTO) FromDomain(model domain.RiskModel) {
+	dto.AgeBands = riskBandsFromDomain(model.AgeBands)
+	dto.BMIBands = riskBandsFromDomain(model.BMIBands)
+	dto.FamilySizeBands = riskBandsFromDomain(model.FamilySizeBands)
+	dto.SeverityPoints = make(map[string]int, len(model.SeverityPoints))
+	for severity, points := range model.SeverityPoints {
+		dto.SeverityPoints[severity] = points
+	}
+	dto.CategoryWeights = make(map[string]float64, len(model.CategoryWeights))
+	for category, weight := range model.CategoryWeights {
+		dto.CategoryWeights[category] = weight
+	}
+	dto.LevelCutoffs = RiskLevelCutoffsDTO{
+		Low:      model.LevelCutoffs.Low,
+		Moderate: model.LevelCutoffs.Moderate,
+		High:     model.LevelCutoffs.High,
+		MaxScore: domain.MaxHealthRiskScore,
+	}
+}
+
+func riskBandsFromDomain(bands []domain.RiskBand) []RiskBandDTO {
+	result := make([]RiskBandDTO, len(bands))
+	for i, band := range bands {
+		result[i] = RiskBandDTO{
+			Min:        band.Min,
+			IncludeMax: band.IncludeMax,
+			Points:     band.Points,
+		}
+		if !band.IsOpenEnded() {
+			max := band.Max
+			result[i].Max = &max
+		}
+	}
+	return result
+}
+
+// RiskSnapshotDTO represents the health risk score and level at a point in time
+type RiskSnapshotDTO struct {
+	RiskScore  int       `json:"risk_score" example:"35"`
+	RiskLevel  string    `json:"risk_level" example:"moderate"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// RiskLevelTransitionDTO represents a change of risk level between two consecutive snapshots
+type RiskLevelTransitionDTO struct {
+	From string    `json:"from" example:"low"`
+	To   string    `json:"to" example:"moderate"`
+	At   time.Time `json:"at"`
+}
+
+// RiskHistoryResponseDTO represents the health risk score over a time window, oldest entry first
+type RiskHistoryResponseDTO struct {
+	Months      int                      `json:"months"`
+	Entries     []RiskSnapshotDTO        `json:"entries"`
+	ScoreChange int                      `json:"score_change"`
+	Transitions []RiskLevelTransitionDTO `json:"transitions"`
+}
+
+// RiskRecalculationResponseDTO reports a batch recalculation of every user's risk score
+type RiskRecalculationResponseDTO struct {
+	Processed int `json:"processed" example:"120"`
+	Updated   int `json:"updated" example:"7"`
+}
+
+// RiskWhatIfRequestDTO represents hypothetical changes to score the user's risk against.
+// Omitted fields leave the profile as it is; condition IDs must be of the user's own conditions.
+type RiskWhatIfRequestDTO struct {
+	Weight             *float64                   `json:"weight,omitempty" binding:"omitempty,gt=0" example:"70"`
+	Age                *int                       `json:"age,omitempty" binding:"omitempty,gte=0,lte=120" example:"45"`
+	SeverityChanges    []ConditionSeverityDTO     `json:"severity_changes" binding:"omitempty,max=50,dive"`
+	AddConditions      []HypotheticalConditionDTO `json:"add_conditions" binding:"omitempty,max=20,dive"`
+	RemoveConditionIDs []string                   `json:"remove_condition_ids" binding:"omitempty,max=50,dive,required"`
+}
+
+// ConditionSeverityDTO sets the severity of one of the user's conditions
+type ConditionSeverityDTO struct {
+	ConditionID string `json:"condition_id" binding:"required" example:"12"`
+	Severity    string `json:"severity" binding:"required,oneof=mild moderate severe critical" example:"mild"`
+}
+
+// HypotheticalConditionDTO represents a condition the user doesn't have, to score as active
+type HypotheticalConditionDTO struct {
+	Name     string `json:"name" binding:"required,max=100" example:"Type 2 diabetes"`
+	Category string `json:"category" binding:"required,oneof=chronic acute mental_health preventive" example:"chronic"`
+	Severity string `json:"severity" binding:"required,oneof=mild moderate severe critical" example:"moderate"`
+}
+
+// RiskFactorChangeDTO compares what one factor adds to the current and hypothetical scores
+type RiskFactorChangeDTO struct {
+	Factor             string `json:"factor" example:"condition"`
+	ConditionID        string `json:"condition_id,omitempty" example:"12"`
+	Name               string `json:"name,omitempty" example:"Hypertension"`
+	CurrentPoints      int    `json:"current_points" example:"10"`
+	HypotheticalPoints int    `json:"hypothetical_points" example:"2"`
+	Change             int    `json:"change" example:"-8"`
+}
+
+// RiskWhatIfResponseDTO compares the user's current risk score with the hypothetical one
+type RiskWhatIfResponseDTO struct {
+	CurrentScore      int                   `json:"current_score" example:"37"`
+	CurrentLevel      string                `json:"current_level" example:"moderate"`
+	HypotheticalScore int                   `json:"hypothetical_score" example:"14"`
+	HypotheticalLevel string                `json:"hypothetical_level" example:"low"`
+	ScoreChange       int                   `json:"score_change" example:"-23"`
+	Factors           []RiskFactorChangeDTO `json:"factors"`
 }
 
 // Health Summary DTOs
 
 // HealthSummaryResponseDTO represents a health summary response
 type HealthSummaryResponseDTO struct {
-	UserID                    string    `json:"user_id"`
-	HealthRiskScore           int       `json:"health_risk_score"`
-	HealthRiskLevel           string    `json:"health_risk_level"`
-	MonthlyMedicalExpenses    float64   `json:"monthly_medical_expenses"`
-	MonthlyInsurancePremiums  float64   `json:"monthly_insurance_premiums"`
-	AnnualDeductibleRemaining float64   `json:"annual_deductible_remaining"`
-	OutOfPocketRemaining      float64   `json:"out_of_pocket_remaining"`
-	TotalHealthCosts          float64   `json:"total_health_costs"`
-	CoverageGapRisk           float64   `json:"coverage_gap_risk"`
-	RecommendedEmergencyFund  float64   `json:"recommended_emergency_fund"`
-	FinancialVulnerability    string    `json:"financial_vulnerability"`
-	PriorityAdjustment        float64   `json:"priority_adjustment"`
-	UpdatedAt                 time.Time `json:"updated_at"`
+	UserID                    string                     `json:"user_id"`
+	HealthRiskScore           int                        `json:"health_risk_score"`
+	HealthRiskLevel           string                     `json:"health_risk_level"`
+	MonthlyMedicalExpenses    float64                    `json:"monthly_medical_expenses"`
+	MonthlyInsurancePremiums  float64                    `json:"monthly_insurance_premiums"`
+	AnnualDeductibleRemaining float64                    `json:"annual_deductible_remaining"`
+	OutOfPocketRemaining      float64                    `json:"out_of_pocket_remaining"`
+	TotalHealthCosts          float64                    `json:"total_health_costs"`
+	CoverageGapRisk           float64                    `json:"coverage_gap_risk"`
+	RecommendedEmergencyFund  float64                    `json:"recommended_emergency_fund"`
+	EmergencyFundBalance      float64                    `json:"emergency_fund_balance"`
+	FinancialVulnerability    string                     `json:"financial_vulnerability"`
+	PriorityAdjustment        float64                    `json:"priority_adjustment"`
+	MemberExpenses            []MemberMedicalExpensesDTO `json:"member_expenses"`
+	OutOfPocketStatuses       []OutOfPocketStatusDTO     `json:"out_of_pocket_statuses"`
+	// RecurringVariance is present when actual costs of recurring expenses can be recorded
+	RecurringVariance *RecurringExpenseVarianceDTO `json:"recurring_variance,omitempty"`
+	UpdatedAt         time.Time                    `json:"updated_at"`
+}
+
+// OutOfPocketStatusDTO represents how close a policy is to its out-of-pocket maximum,
+// after which covered care costs nothing more
+type OutOfPocketStatusDTO struct {
+	PolicyID           string  `json:"policy_id"`
+	PolicyNumber       string  `json:"policy_number"`
+	OutOfPocketMax     float64 `json:"out_of_pocket_max"`
+	OutOfPocketCurrent float64 `json:"out_of_pocket_current"`
+	Remaining          float64 `json:"remaining"`
+	PercentReached     float64 `json:"percent_reached"`
+	MaxReached         bool    `json:"max_reached"`
+	NearMax            bool    `json:"near_max"`
+}
+
+// MemberMedicalExpensesDTO represents one family member's monthly medical expenses in a health summary
+type MemberMedicalExpensesDTO struct {
+	ProfileID              string  `json:"profile_id"`
+	Name                   string  `json:"name,omitempty"`
+	RelationToOwner        string  `json:"relation_to_owner"`
+	MonthlyMedicalExpenses float64 `json:"monthly_medical_expenses"`
 }
 
 // FromDomain converts domain struct to DTO
@@ -344,8 +1000,21 @@ func (dto *HealthSummaryResponseDTO) FromDomain(summary *domain.HealthSummary) {
 	dto.TotalHealthCosts = summary.TotalHealthCosts
 	dto.CoverageGapRisk = summary.CoverageGapRisk
 	dto.RecommendedEmergencyFund = summary.RecommendedEmergencyFund
+	dto.EmergencyFundBalance = summary.EmergencyFundBalance
 	dto.FinancialVulnerability = summary.FinancialVulnerability
 	dto.PriorityAdjustment = summary.PriorityAdjustment
+	dto.MemberExpenses = make([]MemberMedicalExpensesDTO, len(summary.MemberExpenses))
+	for i, member := range summary.MemberExpenses {
+		dto.MemberExpenses[i] = MemberMedicalExpensesDTO(member)
+	}
+	dto.OutOfPocketStatuses = make([]OutOfPocketStatusDTO, len(summary.OutOfPocketStatuses))
+	for i, status := range summary.OutOfPocketStatuses {
+		dto.OutOfPocketStatuses[i] = OutOfPocketStatusDTO(status)
+	}
+	if summary.RecurringVariance != nil {
+		variance := RecurringExpenseVarianceDTO(*summary.RecurringVariance)
+		dto.RecurringVariance = &variance
+	}
 	dto.UpdatedAt = summary.UpdatedAt
 }
 
@@ -363,8 +1032,33 @@ type MedicalExpenseListResponseDTO struct {
 	Total    int                         `json:"total"`
 }
 
+// ExpenseAttachmentListResponseDTO represents the documents attached to a medical expense
+type ExpenseAttachmentListResponseDTO struct {
+	Attachments []ExpenseAttachmentResponseDTO `json:"attachments"`
+	Total       int                            `json:"total"`
+}
+
+// ExpenseOccurrenceListResponseDTO represents the recorded occurrences of a recurring medical expense
+type ExpenseOccurrenceListResponseDTO struct {
+	Occurrences []ExpenseOccurrenceResponseDTO `json:"occurrences"`
+	Total       int                            `json:"total"`
+}
+
 // InsurancePolicyListResponseDTO represents a list of insurance policies
 type InsurancePolicyListResponseDTO struct {
 	Policies []InsurancePolicyResponseDTO `json:"policies"`
 	Total    int                          `json:"total"`
-}
\ No newline at end of file
+}
+
+// ExpiringPolicyResponseDTO represents an active insurance policy that ends soon
+type ExpiringPolicyResponseDTO struct {
+	InsurancePolicyResponseDTO
+	DaysUntilExpiry int `json:"days_until_expiry"`
+}
+
+// ExpiringPolicyListResponseDTO represents the policies ending within a window, soonest first
+type ExpiringPolicyListResponseDTO struct {
+	Policies   []ExpiringPolicyResponseDTO `json:"policies"`
+	WithinDays int                         `json:"within_days"`
+	Total      int                         `json:"total"`
+}
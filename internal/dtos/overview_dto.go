@@ -0,0 +1,39 @@
+package dtos
+
+import "github.com/DuckDHD/BuyOrBye/internal/domain"
+
+/*
+Response OverviewResponseDTO dto
+Finance and health summaries for the dashboard, with figures derived from both.
+A section that failed to load is null and has an entry in errors keyed by section name
+(finance, affordability or health). health is null without an error when the user has no health profile.
+*/
+type OverviewResponseDTO struct {
+	UserID        string                            `json:"user_id" example:"user-456"`
+	Finance       *FinanceSummaryResponseDTO        `json:"finance"`
+	Affordability *AffordabilityResponseDTO         `json:"affordability"`
+	Health        *HealthSummaryResponseDTO         `json:"health"`
+	Resilience    *FinancialResilienceDTO           `json:"resilience"`
+	Errors        map[string]SimpleErrorResponseDTO `json:"errors,omitempty"`
+}
+
+/*
+Response FinancialResilienceDTO dto
+Cross-domain figures combining the finance and health summaries
+*/
+type FinancialResilienceDTO struct {
+	HealthAdjustedDisposableIncome float64  `json:"health_adjusted_disposable_income" example:"283.29"`
+	EmergencyFundCoverageMonths    *float64 `json:"emergency_fund_coverage_months" example:"2.4"`
+	Score                          int      `json:"score" example:"62"`
+	Grade                          string   `json:"grade" example:"C"`
+	IncludesHealth                 bool     `json:"includes_health" example:"true"`
+}
+
+// FromDomain converts domain.FinancialResilience to FinancialResilienceDTO
+func (dto *FinancialResilienceDTO) FromDomain(resilience domain.FinancialResilience) {
+	dto.HealthAdjustedDisposableIncome = resilience.HealthAdjustedDisposableIncome
+	dto.EmergencyFundCoverageMonths = resilience.EmergencyFundCoverageMonths
+	dto.Score = resilience.Score
+	dto.Grade = resilience.Grade
+	dto.IncludesHealth = resilience.IncludesHealth
+}
@@ -0,0 +1,40 @@
+package dtos
+
+import "time"
+
+/*
+Response PersonalDataExportDTO dto
+Everything stored about the caller: their account, finance records and health records.
+The password hash is never included. Lists are empty, never null, when there are no records.
+*/
+type PersonalDataExportDTO struct {
+	SchemaVersion int                    `json:"schema_version" example:"1"`
+	GeneratedAt   time.Time              `json:"generated_at" example:"2025-03-01T09:00:00Z"`
+	User          UserProfileDTO         `json:"user"`
+	Finance       PersonalFinanceDataDTO `json:"finance"`
+	Health        PersonalHealthDataDTO  `json:"health"`
+}
+
+/*
+Response PersonalFinanceDataDTO dto
+All of the caller's finance records
+*/
+type PersonalFinanceDataDTO struct {
+	Incomes      []IncomeResponseDTO      `json:"incomes"`
+	Expenses     []ExpenseResponseDTO     `json:"expenses"`
+	Loans        []LoanResponseDTO        `json:"loans"`
+	SavingsGoals []SavingsGoalResponseDTO `json:"savings_goals"`
+	Budgets      []BudgetResponseDTO      `json:"budgets"`
+}
+
+/*
+Response PersonalHealthDataDTO dto
+All of the caller's health records. profiles holds the caller's own health profile and those
+of their dependents; policies includes inactive and expired policies.
+*/
+type PersonalHealthDataDTO struct {
+	Profiles   []HealthProfileResponseDTO    `json:"profiles"`
+	Conditions []MedicalConditionResponseDTO `json:"conditions"`
+	Expenses   []MedicalExpenseResponseDTO   `json:"medical_expenses"`
+	Policies   []InsurancePolicyResponseDTO  `json:"insurance_policies"`
+}
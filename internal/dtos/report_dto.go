@@ -0,0 +1,18 @@
+package dtos
+
+// Monthly report formats
+const (
+	ReportFormatHTML = "html"
+	ReportFormatPDF  = "pdf"
+)
+
+/*
+Request MonthlyReportQueryDTO dto
+The calendar month a statement covers and the format to render it in.
+An empty format follows the Accept header, defaulting to HTML.
+*/
+type MonthlyReportQueryDTO struct {
+	Year   int    `form:"year" binding:"required" example:"2025"`
+	Month  int    `form:"month" binding:"required" example:"3"`
+	Format string `form:"format" example:"pdf"`
+}
@@ -1,6 +1,7 @@
 package dtos
 
 import (
+	"strings"
 	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
@@ -14,6 +15,7 @@ type UserProfileDTO struct {
 	ID          string    `json:"id" example:"user-123"`
 	Email       string    `json:"email" example:"user@example.com"`
 	Name        string    `json:"name" example:"John Doe"`
+	Role        string    `json:"role" example:"user"`
 	IsActive    bool      `json:"is_active" example:"true"`
 	CreatedAt   time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
 	UpdatedAt   time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
@@ -25,18 +27,62 @@ Request UpdateUserProfileDTO dto
 User profile update request with optional fields
 */
 type UpdateUserProfileDTO struct {
-	Name  *string `json:"name,omitempty" validate:"omitempty,min=1" example:"John Smith"`
+	Name  *string `json:"name,omitempty" validate:"omitempty,min=1,max=255" example:"John Smith"`
 	Email *string `json:"email,omitempty" validate:"omitempty,email" example:"johnsmith@example.com"`
 }
 
+/*
+Request ChangePasswordDTO dto
+Password change request; the current password confirms it's the account owner
+*/
+type ChangePasswordDTO struct {
+	CurrentPassword string `json:"current_password" validate:"required" example:"OldPassword123"`
+	NewPassword     string `json:"new_password" validate:"required,min=8" example:"NewPassword456!"`
+}
+
+/*
+Request UpdateUserRoleDTO dto
+Admin request to change a user's role
+*/
+type UpdateUserRoleDTO struct {
+	Role string `json:"role" validate:"required,oneof=user admin" example:"admin"`
+}
+
+/*
+Response UserListResponseDTO dto
+One page of users for the admin user list
+*/
+type UserListResponseDTO struct {
+	Users    []UserProfileDTO `json:"users"`
+	Page     int              `json:"page" example:"1"`
+	PageSize int              `json:"page_size" example:"20"`
+	Total    int64            `json:"total" example:"42"`
+}
+
 // FromDomain converts domain.User to UserProfileDTO
 func (dto *UserProfileDTO) FromDomain(user domain.User) {
 	dto.ID = user.ID
 	dto.Email = user.Email
 	dto.Name = user.Name
+	dto.Role = user.EffectiveRole()
 	dto.IsActive = user.IsActive
 	dto.CreatedAt = user.CreatedAt
 	dto.UpdatedAt = user.UpdatedAt
+	dto.LastLoginAt = user.LastLoginAt
+}
+
+// ToDomain converts UpdateUserProfileDTO to domain.AccountUpdate with surrounding whitespace trimmed
+func (dto UpdateUserProfileDTO) ToDomain() domain.AccountUpdate {
+	var update domain.AccountUpdate
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		update.Name = &name
+	}
+	if dto.Email != nil {
+		email := strings.TrimSpace(*dto.Email)
+		update.Email = &email
+	}
+	return update
 }
 
 // ApplyUpdates applies UpdateUserProfileDTO fields to domain.User
@@ -0,0 +1,216 @@
+package dtos
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// MaxMoneyAmount caps every monetary amount in a request. Larger values are never genuine and
+// would lose cent precision in a float64, so they are rejected as validation errors. The health
+// DTOs, which are bound by gin, spell the same cap out as lte=1000000000.
+const MaxMoneyAmount = 1e9
+
+// NewValidator creates a validator for request DTOs.
+// Field names in errors are taken from the json tag so they match the request payload,
+// and the custom tags frequency, currency and money are registered.
+func NewValidator() *validator.Validate {
+	validate := validator.New()
+
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	// Registration only fails for empty tags or nil functions
+	_ = validate.RegisterValidation("frequency", validateFrequency)
+	_ = validate.RegisterValidation("currency", validateCurrency)
+	_ = validate.RegisterValidation("money", validateMoney)
+
+	return validate
+}
+
+// NewBindingValidator creates a validator like NewValidator for DTOs whose rules are declared in
+// binding tags, as the health DTOs are, so their errors also name the json fields
+func NewBindingValidator() *validator.Validate {
+	validate := NewValidator()
+	validate.SetTagName("binding")
+	return validate
+}
+
+// FieldError is a validation failure of a single request field found outside the validator,
+// such as the cross-field rules of the health validation middleware
+type FieldError struct {
+	// Field is the json name of the offending field
+	Field   string
+	Message string
+}
+
+// NewFieldError creates a FieldError for the json field named field
+func NewFieldError(field, message string) *FieldError {
+	return &FieldError{Field: field, Message: message}
+}
+
+// Error returns the failure message
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// NewValidationErrorResponseFromError converts a validator error, a FieldError or a JSON decoding
+// error into a ValidationErrorResponseDTO.
+// Every validation failure should be rendered through this function.
+func NewValidationErrorResponseFromError(err error) *ValidationErrorResponseDTO {
+	fields := make(map[string]any)
+
+	var validationErrors validator.ValidationErrors
+	var ruleErr *FieldError
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &validationErrors):
+		for _, fieldErr := range validationErrors {
+			fields[fieldErr.Field()] = validationMessage(fieldErr)
+		}
+	case errors.As(err, &ruleErr):
+		fields[ruleErr.Field] = ruleErr.Message
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		fields[typeErr.Field] = typeErr.Field + " must be a " + jsonTypeName(typeErr.Type.Kind())
+	case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return NewValidationErrorResponse("Invalid JSON format", nil)
+	}
+
+	return NewValidationErrorResponse("Validation failed", fields)
+}
+
+// jsonTypeName names the JSON type a Go value of kind decodes from
+func jsonTypeName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// validationMessage builds a human-readable message for a single field error
+func validationMessage(fieldErr validator.FieldError) string {
+	field := fieldErr.Field()
+	switch fieldErr.Tag() {
+	case "required":
+		return field + " is required"
+	case "email":
+		return field + " must be a valid email address"
+	case "min":
+		if fieldErr.Kind() == reflect.String {
+			return field + " must be at least " + fieldErr.Param() + " characters"
+		}
+		return field + " must be at least " + fieldErr.Param()
+	case "max":
+		if fieldErr.Kind() == reflect.String {
+			return field + " must be at most " + fieldErr.Param() + " characters"
+		}
+		return field + " must be at most " + fieldErr.Param()
+	case "gt":
+		return field + " must be greater than " + fieldErr.Param()
+	case "gte":
+		return field + " must be greater than or equal to " + fieldErr.Param()
+	case "lte":
+		return field + " must be less than or equal to " + fieldErr.Param()
+	case "ltefield":
+		return field + " must be less than or equal to " + fieldErr.Param()
+	case "excluded_unless":
+		if param := strings.Fields(fieldErr.Param()); len(param) == 2 {
+			return field + " is only allowed when " + param[0] + " is " + param[1]
+		}
+		return field + " is not allowed here"
+	case "oneof":
+		return field + " must be one of: " + fieldErr.Param()
+	case "frequency":
+		if fieldErr.Param() == "recurring" {
+			return field + " must be one of: daily weekly monthly"
+		}
+		return field + " must be one of: daily weekly monthly one-time"
+	case "currency":
+		return field + " must be a 3-letter ISO 4217 currency code"
+	case "money":
+		if amount, ok := fieldErr.Value().(float64); ok && math.Abs(amount) > MaxMoneyAmount {
+			return field + " must not exceed " + strconv.FormatFloat(MaxMoneyAmount, 'f', -1, 64)
+		}
+		return field + " must have at most two decimal places"
+	default:
+		return field + " is invalid"
+	}
+}
+
+// validateFrequency accepts the income frequencies; with the param "recurring"
+// one-time is rejected, matching the frequencies allowed for expenses
+func validateFrequency(fl validator.FieldLevel) bool {
+	switch fl.Field().String() {
+	case domain.FrequencyDaily, domain.FrequencyWeekly, domain.FrequencyMonthly:
+		return true
+	case domain.FrequencyOneTime:
+		return fl.Param() != "recurring"
+	default:
+		return false
+	}
+}
+
+// validateCurrency accepts upper-case three letter currency codes such as USD
+func validateCurrency(fl validator.FieldLevel) bool {
+	code := fl.Field().String()
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMoney accepts amounts with at most two decimal places and no larger than MaxMoneyAmount
+func validateMoney(fl validator.FieldLevel) bool {
+	field := fl.Field()
+	bitSize := 64
+	switch field.Kind() {
+	case reflect.Float64:
+	case reflect.Float32:
+		bitSize = 32
+	default:
+		return false
+	}
+
+	amount := field.Float()
+	if math.IsNaN(amount) || math.IsInf(amount, 0) || math.Abs(amount) > MaxMoneyAmount {
+		return false
+	}
+	// The shortest decimal form avoids float rounding noise, e.g. 19.99*100 = 1998.9999999999998
+	formatted := strconv.FormatFloat(amount, 'f', -1, bitSize)
+	if dot := strings.IndexByte(formatted, '.'); dot >= 0 {
+		return len(formatted)-dot-1 <= 2
+	}
+	return true
+}
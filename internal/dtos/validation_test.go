@@ -0,0 +1,119 @@
+package dtos
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidator_CustomTags(t *testing.T) {
+	validate := NewValidator()
+
+	tests := []struct {
+		name  string
+		value any
+		tag   string
+		valid bool
+	}{
+		{"monthly frequency", "monthly", "frequency", true},
+		{"one-time frequency", "one-time", "frequency", true},
+		{"unknown frequency", "yearly", "frequency", false},
+		{"capitalised frequency", "Monthly", "frequency", false},
+		{"recurring frequency", "weekly", "frequency=recurring", true},
+		{"one-time is not recurring", "one-time", "frequency=recurring", false},
+		{"currency code", "USD", "currency", true},
+		{"lower-case currency", "usd", "currency", false},
+		{"long currency", "USDT", "currency", false},
+		{"whole amount", 1200.0, "money", true},
+		{"two decimal amount", 19.99, "money", true},
+		{"one decimal amount", 0.5, "money", true},
+		{"three decimal amount", 10.005, "money", false},
+		{"amount at cap", MaxMoneyAmount, "money", true},
+		{"amount over cap", 1e300, "money", false},
+		{"negative amount over cap", -2e9, "money", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validate.Var(tt.value, tt.tag)
+			assert.Equal(t, tt.valid, err == nil, "%v with %q", tt.value, tt.tag)
+		})
+	}
+}
+
+func TestNewValidationErrorResponseFromError_UsesJSONFieldNames(t *testing.T) {
+	// Arrange
+	request := AddLoanDTO{
+		Lender:           "",
+		Type:             "boat",
+		PrincipalAmount:  1000.123,
+		RemainingBalance: 500,
+		MonthlyPayment:   100,
+		InterestRate:     5,
+	}
+
+	// Act
+	err := NewValidator().Struct(&request)
+	require.Error(t, err)
+	response := NewValidationErrorResponseFromError(err)
+
+	// Assert
+	assert.Equal(t, 400, response.Code)
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, "Validation failed", response.Message)
+	assert.Equal(t, map[string]any{
+		"lender":           "lender is required",
+		"type":             "type must be one of: mortgage auto personal student",
+		"principal_amount": "principal_amount must have at most two decimal places",
+		"end_date":         "end_date is required",
+	}, response.Fields)
+}
+
+func TestNewValidationErrorResponseFromError_FrequencyMessage(t *testing.T) {
+	request := AddExpenseDTO{
+		Category:  "housing",
+		Name:      "Rent",
+		Amount:    1200,
+		Frequency: "one-time",
+		Priority:  1,
+	}
+
+	err := NewValidator().Struct(&request)
+	require.Error(t, err)
+	response := NewValidationErrorResponseFromError(err)
+
+	assert.Equal(t, map[string]any{
+		"frequency": "frequency must be one of: daily weekly monthly",
+	}, response.Fields)
+}
+
+func TestNewValidationErrorResponseFromError_BindingTagsUseJSONFieldNames(t *testing.T) {
+	request := CreateHealthProfileRequestDTO{Age: 150, Gender: "male", Weight: 70, FamilySize: 1}
+
+	err := NewBindingValidator().Struct(&request)
+	require.Error(t, err)
+	response := NewValidationErrorResponseFromError(err)
+
+	assert.Contains(t, response.Fields, "age")
+	assert.Contains(t, response.Fields, "height")
+	assert.NotContains(t, response.Fields, "Age")
+}
+
+func TestNewValidationErrorResponseFromError_FieldAndDecodingErrors(t *testing.T) {
+	response := NewValidationErrorResponseFromError(NewFieldError("end_date", "policy end date cannot be before start date"))
+	assert.Equal(t, map[string]any{"end_date": "policy end date cannot be before start date"}, response.Fields)
+
+	var request CreateHealthProfileRequestDTO
+	err := json.Unmarshal([]byte(`{"age":"forty"}`), &request)
+	require.Error(t, err)
+	response = NewValidationErrorResponseFromError(err)
+	assert.Equal(t, map[string]any{"age": "age must be a number"}, response.Fields)
+
+	err = json.Unmarshal([]byte(`{"age":`), &request)
+	require.Error(t, err)
+	response = NewValidationErrorResponseFromError(err)
+	assert.Equal(t, "Invalid JSON format", response.Message)
+	assert.Empty(t, response.Fields)
+}
@@ -0,0 +1,105 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+/*
+Request CreateWebhookDTO dto
+Request to register a webhook; a secret is generated when none is given
+*/
+type CreateWebhookDTO struct {
+	URL        string   `json:"url" validate:"required,url,max=2048" example:"https://hooks.example.com/buyorbye"`
+	Secret     string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255" example:"0123456789abcdef0123456789abcdef"`
+	EventTypes []string `json:"event_types" validate:"required,min=1,dive,oneof=financial_health.changed debt_to_income.threshold_exceeded budget.exceeded insurance.deductible_met health.high_risk_detected" example:"financial_health.changed"`
+}
+
+/*
+Request UpdateWebhookDTO dto
+Request to update an existing webhook with optional fields
+*/
+type UpdateWebhookDTO struct {
+	URL        *string  `json:"url,omitempty" validate:"omitempty,url,max=2048" example:"https://hooks.example.com/buyorbye"`
+	Secret     *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=255" example:"0123456789abcdef0123456789abcdef"`
+	EventTypes []string `json:"event_types,omitempty" validate:"omitempty,min=1,dive,oneof=financial_health.changed debt_to_income.threshold_exceeded budget.exceeded insurance.deductible_met health.high_risk_detected" example:"insurance.deductible_met"`
+	IsActive   *bool    `json:"is_active,omitempty" example:"false"`
+}
+
+/*
+Response WebhookResponseDTO dto
+Webhook details in API responses; the secret is only included when the webhook is created
+*/
+type WebhookResponseDTO struct {
+	ID         string    `json:"id" example:"wh-123"`
+	URL        string    `json:"url" example:"https://hooks.example.com/buyorbye"`
+	Secret     string    `json:"secret,omitempty" example:"0123456789abcdef0123456789abcdef"`
+	EventTypes []string  `json:"event_types" example:"financial_health.changed"`
+	IsActive   bool      `json:"is_active" example:"true"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`
+}
+
+/*
+Response WebhookDeliveryDTO dto
+One attempt to deliver an event to a webhook
+*/
+type WebhookDeliveryDTO struct {
+	EventID    string    `json:"event_id" example:"2f6c1f5e-8f0b-4c1e-9d7a-3b1d2c4e5f60"`
+	EventType  string    `json:"event_type" example:"insurance.deductible_met"`
+	Attempt    int       `json:"attempt" example:"1"`
+	StatusCode int       `json:"status_code,omitempty" example:"200"`
+	Error      string    `json:"error,omitempty" example:"unexpected status 503"`
+	Succeeded  bool      `json:"succeeded" example:"true"`
+	DurationMs int64     `json:"duration_ms" example:"142"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`
+}
+
+// ToDomain converts CreateWebhookDTO to domain.Webhook
+func (dto CreateWebhookDTO) ToDomain(userID string) domain.Webhook {
+	return domain.Webhook{
+		UserID:     userID,
+		URL:        dto.URL,
+		Secret:     dto.Secret,
+		EventTypes: dto.EventTypes,
+	}
+}
+
+// ApplyUpdates applies UpdateWebhookDTO fields to domain.Webhook
+func (dto UpdateWebhookDTO) ApplyUpdates(webhook *domain.Webhook) {
+	if dto.URL != nil {
+		webhook.URL = *dto.URL
+	}
+	if dto.Secret != nil {
+		webhook.Secret = *dto.Secret
+	}
+	if dto.EventTypes != nil {
+		webhook.EventTypes = dto.EventTypes
+	}
+	if dto.IsActive != nil {
+		webhook.IsActive = *dto.IsActive
+	}
+}
+
+// FromDomain converts domain.Webhook to WebhookResponseDTO without the secret
+func (dto *WebhookResponseDTO) FromDomain(webhook domain.Webhook) {
+	dto.ID = webhook.ID
+	dto.URL = webhook.URL
+	dto.EventTypes = webhook.EventTypes
+	dto.IsActive = webhook.IsActive
+	dto.CreatedAt = webhook.CreatedAt
+	dto.UpdatedAt = webhook.UpdatedAt
+}
+
+// FromDomain converts domain.WebhookDelivery to WebhookDeliveryDTO
+func (dto *WebhookDeliveryDTO) FromDomain(delivery domain.WebhookDelivery) {
+	dto.EventID = delivery.EventID
+	dto.EventType = delivery.EventType
+	dto.Attempt = delivery.Attempt
+	dto.StatusCode = delivery.StatusCode
+	dto.Error = delivery.Error
+	dto.Succeeded = delivery.Succeeded
+	dto.DurationMs = delivery.Duration.Milliseconds()
+	dto.CreatedAt = delivery.CreatedAt
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// AccountHandler handles HTTP requests for the caller's own account
+// Routes must be registered behind authentication
+type AccountHandler struct {
+	accountService AccountService
+	validator      *validator.Validate
+}
+
+// NewAccountHandler creates a new account handler with dependency injection
+func NewAccountHandler(accountService AccountService) *AccountHandler {
+	return &AccountHandler{
+		accountService: accountService,
+		validator:      dtos.NewValidator(),
+	}
+}
+
+// GetMe handles GET /api/v1/account/me requests
+// Returns the caller's account details
+//
+//	@Summary	Get my account
+//	@Tags		account
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200			{object}	dtos.UserProfileDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	404			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/account/me	[get]
+func (h *AccountHandler) GetMe(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		h.unauthorized(c)
+		return
+	}
+
+	user, err := h.accountService.GetAccount(c.Request.Context(), userID)
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+
+	var response dtos.UserProfileDTO
+	response.FromDomain(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateMe handles PUT /api/v1/account/me requests
+// Changes the caller's name and/or email; changing the email signs out every session
+//
+//	@Summary	Update my account
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request		body		dtos.UpdateUserProfileDTO	true	"Fields to change"
+//	@Success	200			{object}	dtos.UserProfileDTO
+//	@Failure	400			{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	404			{object}	dtos.ErrorResponseDTO
+//	@Failure	409			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/account/me	[put]
+func (h *AccountHandler) UpdateMe(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		h.unauthorized(c)
+		return
+	}
+
+	var request dtos.UpdateUserProfileDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.badRequest(c, "Invalid JSON format")
+		return
+	}
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	user, err := h.accountService.UpdateAccount(c.Request.Context(), userID, request.ToDomain())
+	if err != nil {
+		h.handleAccountError(c, err)
+		return
+	}
+
+	var response dtos.UserProfileDTO
+	response.FromDomain(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// ChangePassword handles PUT /api/v1/account/password requests
+// Replaces the caller's password and signs out every session
+//
+//	@Summary	Change my password
+//	@Description	The new password must follow the password policy; each broken rule is listed under fields.new_password.
+//	@Description	Every refresh token is revoked, so all sessions have to log in again.
+//	@Tags		account
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request				body		dtos.ChangePasswordDTO	true	"Current and new password"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/account/password	[put]
+func (h *AccountHandler) ChangePassword(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		h.unauthorized(c)
+		return
+	}
+
+	var request dtos.ChangePasswordDTO
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.badRequest(c, "Invalid JSON format")
+		return
+	}
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	err := h.accountService.ChangePassword(c.Request.Context(), userID, request.CurrentPassword, request.NewPassword)
+	if err != nil {
+		if response, ok := weakPasswordResponse(err, "new_password"); ok {
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
+		h.handleAccountError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.MessageResponseDTO{Message: "Password changed; please log in again"})
+}
+
+func (h *AccountHandler) unauthorized(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+		http.StatusUnauthorized,
+		"unauthorized",
+		"Authentication required",
+	))
+}
+
+func (h *AccountHandler) badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+		http.StatusBadRequest,
+		"bad_request",
+		message,
+	))
+}
+
+// handleAccountError maps service errors to HTTP responses
+func (h *AccountHandler) handleAccountError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			http.StatusNotFound,
+			"not_found",
+			"User not found",
+		))
+	case errors.Is(err, domain.ErrUserAlreadyExists):
+		c.JSON(http.StatusConflict, dtos.NewErrorResponse(
+			http.StatusConflict,
+			"conflict",
+			"Email is already in use",
+		))
+	case errors.Is(err, domain.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Current password is incorrect",
+		))
+	case errors.Is(err, domain.ErrInvalidUserData):
+		h.badRequest(c, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Account request failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"An internal error occurred. Please try again later",
+		))
+	}
+}
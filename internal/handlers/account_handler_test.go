@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockAccountService is a mock implementation of AccountService for testing
+type MockAccountService struct {
+	mock.Mock
+}
+
+func (m *MockAccountService) GetAccount(ctx context.Context, userID string) (domain.User, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockAccountService) UpdateAccount(ctx context.Context, userID string, update domain.AccountUpdate) (domain.User, error) {
+	args := m.Called(ctx, userID, update)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockAccountService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	args := m.Called(ctx, userID, currentPassword, newPassword)
+	return args.Error(0)
+}
+
+// setupAccountTestRouter authenticates every request as userID; an empty userID leaves the request unauthenticated
+func setupAccountTestRouter(accountService AccountService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewAccountHandler(accountService)
+	r.GET("/account/me", handler.GetMe)
+	r.PUT("/account/me", handler.UpdateMe)
+	r.PUT("/account/password", handler.ChangePassword)
+	return r
+}
+
+func TestAccountHandler_GetMe(t *testing.T) {
+	lastLogin := time.Date(2024, 1, 15, 14, 30, 0, 0, time.UTC)
+	accountService := new(MockAccountService)
+	accountService.On("GetAccount", mock.Anything, "user-1").Return(domain.User{
+		ID:          "user-1",
+		Email:       "ada@example.com",
+		Name:        "Ada",
+		IsActive:    true,
+		CreatedAt:   lastLogin.AddDate(0, -1, 0),
+		LastLoginAt: &lastLogin,
+	}, nil)
+	router := setupAccountTestRouter(accountService, "user-1")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/account/me", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.UserProfileDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ada@example.com", response.Email)
+	assert.Equal(t, domain.RoleUser, response.Role)
+	assert.True(t, response.IsActive)
+	require.NotNil(t, response.LastLoginAt)
+	assert.True(t, response.LastLoginAt.Equal(lastLogin))
+	assert.NotContains(t, w.Body.String(), "password")
+}
+
+func TestAccountHandler_GetMe_Unauthenticated(t *testing.T) {
+	accountService := new(MockAccountService)
+	router := setupAccountTestRouter(accountService, "")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/account/me", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	accountService.AssertNotCalled(t, "GetAccount", mock.Anything, mock.Anything)
+}
+
+func TestAccountHandler_UpdateMe(t *testing.T) {
+	name, email := "Ada Lovelace", "ada@newmail.com"
+	accountService := new(MockAccountService)
+	accountService.On("UpdateAccount", mock.Anything, "user-1", domain.AccountUpdate{Name: &name, Email: &email}).
+		Return(domain.User{ID: "user-1", Email: email, Name: name, IsActive: true}, nil)
+	router := setupAccountTestRouter(accountService, "user-1")
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"name": " Ada Lovelace ", "email": "ada@newmail.com"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/account/me", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.UserProfileDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, email, response.Email)
+	assert.Equal(t, name, response.Name)
+	accountService.AssertExpectations(t)
+}
+
+func TestAccountHandler_UpdateMe_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"invalid email", `{"email": "not-an-email"}`, nil, http.StatusBadRequest},
+		{"empty name", `{"name": ""}`, nil, http.StatusBadRequest},
+		{"malformed JSON", `{"name":`, nil, http.StatusBadRequest},
+		{"email taken", `{"email": "grace@example.com"}`, domain.ErrUserAlreadyExists, http.StatusConflict},
+		{"blank name", `{"name": "   "}`, domain.ErrInvalidUserData, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountService := new(MockAccountService)
+			if tt.serviceErr != nil {
+				accountService.On("UpdateAccount", mock.Anything, "user-1", mock.Anything).Return(domain.User{}, tt.serviceErr)
+			}
+			router := setupAccountTestRouter(accountService, "user-1")
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/account/me", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.serviceErr == nil {
+				accountService.AssertNotCalled(t, "UpdateAccount", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_ChangePassword(t *testing.T) {
+	accountService := new(MockAccountService)
+	accountService.On("ChangePassword", mock.Anything, "user-1", "OldPassword123", "NewPassword456!").Return(nil)
+	router := setupAccountTestRouter(accountService, "user-1")
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"current_password": "OldPassword123", "new_password": "NewPassword456!"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/account/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	accountService.AssertExpectations(t)
+}
+
+func TestAccountHandler_ChangePassword_WeakPassword_NamesBrokenRules(t *testing.T) {
+	accountService := new(MockAccountService)
+	accountService.On("ChangePassword", mock.Anything, "user-1", "OldPassword123", "newpassword").
+		Return(&domain.WeakPasswordError{Violations: []string{"must contain a digit", "must contain an uppercase letter"}})
+	router := setupAccountTestRouter(accountService, "user-1")
+
+	w := httptest.NewRecorder()
+	body := []byte(`{"current_password": "OldPassword123", "new_password": "newpassword"}`)
+	req, _ := http.NewRequest(http.MethodPut, "/account/password", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeValidationFailed, response.ErrorCode)
+	assert.Equal(t, "new_password must contain a digit; must contain an uppercase letter", response.Fields["new_password"])
+}
+
+func TestAccountHandler_ChangePassword_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"missing current password", `{"new_password": "NewPassword456!"}`, nil, http.StatusBadRequest},
+		{"new password too short", `{"current_password": "OldPassword123", "new_password": "short"}`, nil, http.StatusBadRequest},
+		{"malformed JSON", `{"current_password":`, nil, http.StatusBadRequest},
+		{"wrong current password", `{"current_password": "Wrong", "new_password": "NewPassword456!"}`, domain.ErrInvalidCredentials, http.StatusUnauthorized},
+		{"user gone", `{"current_password": "OldPassword123", "new_password": "NewPassword456!"}`, domain.ErrUserNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountService := new(MockAccountService)
+			if tt.serviceErr != nil {
+				accountService.On("ChangePassword", mock.Anything, "user-1", mock.Anything, mock.Anything).Return(tt.serviceErr)
+			}
+			router := setupAccountTestRouter(accountService, "user-1")
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/account/password", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.serviceErr == nil {
+				accountService.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// AccountService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by AccountHandler in this package
+type AccountService interface {
+	// GetAccount returns the user's own account
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	GetAccount(ctx context.Context, userID string) (domain.User, error)
+
+	// UpdateAccount changes the user's name and email and returns the updated account
+	// Changing the email revokes all of the user's refresh tokens
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	// Returns domain.ErrUserAlreadyExists if the email belongs to another user
+	// Returns domain.ErrInvalidUserData if the result fails validation
+	UpdateAccount(ctx context.Context, userID string, update domain.AccountUpdate) (domain.User, error)
+
+	// ChangePassword replaces the user's password and revokes all of their refresh tokens
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	// Returns domain.ErrInvalidCredentials if currentPassword is wrong
+	// Returns a *domain.WeakPasswordError, matching domain.ErrWeakPassword, if newPassword breaks the password policy
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error
+}
@@ -0,0 +1,289 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+const (
+	// defaultHighDebtThreshold is the debt-to-income ratio used when the request doesn't give one
+	defaultHighDebtThreshold = 0.4
+	// defaultUserPageSize and maxUserPageSize bound the admin user list page size
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+// AdminHandler handles HTTP requests for admin-only endpoints
+// Routes must be registered behind middleware.RequireRole(domain.RoleAdmin)
+type AdminHandler struct {
+	adminService AdminService
+	validator    *validator.Validate
+}
+
+// NewAdminHandler creates a new admin handler with dependency injection
+func NewAdminHandler(adminService AdminService) *AdminHandler {
+	return &AdminHandler{
+		adminService: adminService,
+		validator:    dtos.NewValidator(),
+	}
+}
+
+// ListUsers handles GET /api/v1/admin/users requests
+// Supports page (default 1) and page_size (default 20, max 100) query parameters
+//
+//	@Summary	List users
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		page			query		int	false	"Page number, from 1"
+//	@Param		page_size		query		int	false	"Page size, 1-100"
+//	@Success	200				{object}	dtos.UserListResponseDTO
+//	@Failure	400				{object}	dtos.ErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	403				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/users	[get]
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		h.badRequest(c, "page must be a positive integer")
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("page_size", strconv.Itoa(defaultUserPageSize)))
+	if err != nil || pageSize < 1 || pageSize > maxUserPageSize {
+		h.badRequest(c, "page_size must be between 1 and "+strconv.Itoa(maxUserPageSize))
+		return
+	}
+
+	users, total, err := h.adminService.ListUsers(c.Request.Context(), page, pageSize)
+	if err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	response := dtos.UserListResponseDTO{
+		Users:    make([]dtos.UserProfileDTO, len(users)),
+		Page:     page,
+		PageSize: pageSize,
+		Total:    total,
+	}
+	for i, user := range users {
+		response.Users[i].FromDomain(user)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUser handles GET /api/v1/admin/users/:id requests
+// Returns any user's profile, including deactivated accounts
+//
+//	@Summary	Get a user
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string	true	"User ID"
+//	@Success	200					{object}	dtos.UserProfileDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/users/{id}	[get]
+func (h *AdminHandler) GetUser(c *gin.Context) {
+	user, err := h.adminService.GetUser(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	var response dtos.UserProfileDTO
+	response.FromDomain(user)
+	c.JSON(http.StatusOK, response)
+}
+
+// DeactivateUser handles POST /api/v1/admin/users/:id/deactivate requests
+// Deactivates the account and revokes its refresh tokens
+//
+//	@Summary	Deactivate a user
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id								path		string	true	"User ID"
+//	@Success	200								{object}	dtos.MessageResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	404								{object}	dtos.ErrorResponseDTO
+//	@Failure	409								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/users/{id}/deactivate	[post]
+func (h *AdminHandler) DeactivateUser(c *gin.Context) {
+	if err := h.adminService.DeactivateUser(c.Request.Context(), middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User deactivated successfully",
+	})
+}
+
+// UpdateUserRole handles PUT /api/v1/admin/users/:id/role requests
+// This is the only way to grant or revoke the admin role after startup
+//
+//	@Summary	Change a user's role
+//	@Tags		admin
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string					true	"User ID"
+//	@Param		request					body		dtos.UpdateUserRoleDTO	true	"New role"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	409						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/users/{id}/role	[put]
+func (h *AdminHandler) UpdateUserRole(c *gin.Context) {
+	var request dtos.UpdateUserRoleDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.badRequest(c, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	if err := h.adminService.SetUserRole(c.Request.Context(), middleware.GetUserID(c), c.Param("id"), request.Role); err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "User role updated successfully",
+	})
+}
+
+// GetHighDebtUsers handles GET /api/v1/admin/finance/high-debt requests
+// Supports a threshold query parameter, defaulting to 0.4
+//
+//	@Summary	List users with a high debt-to-income ratio
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		threshold					query		number	false	"Debt-to-income ratio, default 0.4"
+//	@Success	200							{array}		dtos.FinanceSummaryResponseDTO
+//	@Failure	400							{object}	dtos.ErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	403							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/finance/high-debt	[get]
+func (h *AdminHandler) GetHighDebtUsers(c *gin.Context) {
+	threshold := defaultHighDebtThreshold
+	if raw := c.Query("threshold"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			h.badRequest(c, "threshold must be a number")
+			return
+		}
+		threshold = parsed
+	}
+
+	summaries, err := h.adminService.GetUsersWithHighDebtRatio(c.Request.Context(), threshold)
+	if err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toFinanceSummaryDTOs(summaries))
+}
+
+// GetSummariesByHealth handles GET /api/v1/admin/finance/by-health requests
+// Requires a status query parameter (Excellent, Good, Fair or Poor)
+//
+//	@Summary	List finance summaries by financial health
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		status						query		string	true	"Excellent, Good, Fair or Poor"
+//	@Success	200							{array}		dtos.FinanceSummaryResponseDTO
+//	@Failure	400							{object}	dtos.ErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	403							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/finance/by-health	[get]
+func (h *AdminHandler) GetSummariesByHealth(c *gin.Context) {
+	status := c.Query("status")
+	if status == "" {
+		h.badRequest(c, "status is required")
+		return
+	}
+
+	summaries, err := h.adminService.GetFinanceSummariesByHealthStatus(c.Request.Context(), status)
+	if err != nil {
+		h.handleAdminError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toFinanceSummaryDTOs(summaries))
+}
+
+func toFinanceSummaryDTOs(summaries []domain.FinanceSummary) []dtos.FinanceSummaryResponseDTO {
+	response := make([]dtos.FinanceSummaryResponseDTO, len(summaries))
+	for i, summary := range summaries {
+		response[i].FromDomain(summary)
+	}
+	return response
+}
+
+func (h *AdminHandler) badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+		http.StatusBadRequest,
+		"bad_request",
+		message,
+	))
+}
+
+// handleAdminError maps service errors to HTTP responses
+func (h *AdminHandler) handleAdminError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			http.StatusNotFound,
+			"not_found",
+			"User not found",
+		))
+	case errors.Is(err, domain.ErrCannotModifySelf):
+		c.JSON(http.StatusConflict, dtos.NewErrorResponse(
+			http.StatusConflict,
+			"conflict",
+			"Admins cannot deactivate or change the role of their own account",
+		))
+	case errors.Is(err, domain.ErrInvalidUserData), errors.Is(err, domain.ErrInvalidFinanceData):
+		h.badRequest(c, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Admin request failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"An internal error occurred. Please try again later",
+		))
+	}
+}
@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// MockAdminService is a mock implementation of AdminService for testing
+type MockAdminService struct {
+	mock.Mock
+}
+
+func (m *MockAdminService) ListUsers(ctx context.Context, page, pageSize int) ([]domain.User, int64, error) {
+	args := m.Called(ctx, page, pageSize)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAdminService) GetUser(ctx context.Context, userID string) (domain.User, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.User), args.Error(1)
+}
+
+func (m *MockAdminService) DeactivateUser(ctx context.Context, actorID, userID string) error {
+	args := m.Called(ctx, actorID, userID)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) SetUserRole(ctx context.Context, actorID, userID, role string) error {
+	args := m.Called(ctx, actorID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockAdminService) GetUsersWithHighDebtRatio(ctx context.Context, threshold float64) ([]domain.FinanceSummary, error) {
+	args := m.Called(ctx, threshold)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FinanceSummary), args.Error(1)
+}
+
+func (m *MockAdminService) GetFinanceSummariesByHealthStatus(ctx context.Context, status string) ([]domain.FinanceSummary, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.FinanceSummary), args.Error(1)
+}
+
+// setupAdminTestRouter authenticates every request as the given user and role,
+// then applies the same RequireRole guard as the production admin group
+func setupAdminTestRouter(adminService AdminService, userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		claims := &domain.TokenClaims{UserID: userID, Email: userID + "@example.com", Role: role}
+		c.Set("userID", claims.UserID)
+		c.Set("userEmail", claims.Email)
+		c.Set("userRole", claims.Role)
+		c.Set("tokenClaims", claims)
+		c.Next()
+	})
+
+	handler := NewAdminHandler(adminService)
+	admin := r.Group("/admin", middleware.RequireRole(domain.RoleAdmin))
+	admin.GET("/users", handler.ListUsers)
+	admin.GET("/users/:id", handler.GetUser)
+	admin.POST("/users/:id/deactivate", handler.DeactivateUser)
+	admin.PUT("/users/:id/role", handler.UpdateUserRole)
+	admin.GET("/finance/high-debt", handler.GetHighDebtUsers)
+	admin.GET("/finance/by-health", handler.GetSummariesByHealth)
+
+	return r
+}
+
+func TestAdminRoutes_NormalUser_Forbidden(t *testing.T) {
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/admin/users"},
+		{http.MethodGet, "/admin/users/user-2"},
+		{http.MethodPost, "/admin/users/user-2/deactivate"},
+		{http.MethodPut, "/admin/users/user-2/role"},
+		{http.MethodGet, "/admin/finance/high-debt"},
+		{http.MethodGet, "/admin/finance/by-health?status=Poor"},
+	}
+
+	adminService := new(MockAdminService)
+	router := setupAdminTestRouter(adminService, "user-1", domain.RoleUser)
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(route.method, route.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+
+	adminService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything)
+	adminService.AssertNotCalled(t, "GetUser", mock.Anything, mock.Anything)
+	adminService.AssertNotCalled(t, "DeactivateUser", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminHandler_ListUsers(t *testing.T) {
+	adminService := new(MockAdminService)
+	adminService.On("ListUsers", mock.Anything, 2, 10).
+		Return([]domain.User{{ID: "user-11", Email: "user11@example.com", IsActive: true}}, int64(11), nil)
+	router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users?page=2&page_size=10", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.UserListResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, int64(11), response.Total)
+	assert.Equal(t, 2, response.Page)
+	require.Len(t, response.Users, 1)
+	assert.Equal(t, domain.RoleUser, response.Users[0].Role)
+	adminService.AssertExpectations(t)
+}
+
+func TestAdminHandler_ListUsers_InvalidPageSize(t *testing.T) {
+	adminService := new(MockAdminService)
+	router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users?page_size=1000", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	adminService.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminHandler_GetUser(t *testing.T) {
+	adminService := new(MockAdminService)
+	adminService.On("GetUser", mock.Anything, "user-2").
+		Return(domain.User{ID: "user-2", Email: "user2@example.com", Role: domain.RoleUser, IsActive: false}, nil)
+	adminService.On("GetUser", mock.Anything, "missing").Return(domain.User{}, domain.ErrUserNotFound)
+	router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/admin/users/user-2", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.UserProfileDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "user-2", response.ID)
+	assert.Equal(t, domain.RoleUser, response.Role)
+	assert.False(t, response.IsActive)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/admin/users/missing", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	adminService.AssertExpectations(t)
+}
+
+func TestAdminHandler_DeactivateUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{name: "deactivates user", expectedStatus: http.StatusOK},
+		{name: "user not found", err: domain.ErrUserNotFound, expectedStatus: http.StatusNotFound},
+		{name: "own account", err: domain.ErrCannotModifySelf, expectedStatus: http.StatusConflict},
+		{name: "repository failure", err: errors.New("database unavailable"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminService := new(MockAdminService)
+			adminService.On("DeactivateUser", mock.Anything, "admin-1", "user-2").Return(tt.err)
+			router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/users/user-2/deactivate", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			adminService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_UpdateUserRole(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectCall     bool
+		expectedStatus int
+	}{
+		{name: "promotes user", body: `{"role":"admin"}`, expectCall: true, expectedStatus: http.StatusOK},
+		{name: "unknown role", body: `{"role":"superuser"}`, expectedStatus: http.StatusBadRequest},
+		{name: "missing role", body: `{}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			adminService := new(MockAdminService)
+			if tt.expectCall {
+				adminService.On("SetUserRole", mock.Anything, "admin-1", "user-2", domain.RoleAdmin).Return(nil)
+			}
+			router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPut, "/admin/users/user-2/role", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			adminService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminHandler_FinanceAggregates(t *testing.T) {
+	summaries := []domain.FinanceSummary{{UserID: "user-1", DebtToIncomeRatio: 0.55, FinancialHealth: domain.HealthPoor}}
+	adminService := new(MockAdminService)
+	adminService.On("GetUsersWithHighDebtRatio", mock.Anything, 0.5).Return(summaries, nil)
+	adminService.On("GetFinanceSummariesByHealthStatus", mock.Anything, domain.HealthPoor).Return(summaries, nil)
+	router := setupAdminTestRouter(adminService, "admin-1", domain.RoleAdmin)
+
+	for _, path := range []string{"/admin/finance/high-debt?threshold=0.5", "/admin/finance/by-health?status=Poor"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, path)
+		var response []dtos.FinanceSummaryResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response, 1)
+		assert.Equal(t, "user-1", response[0].UserID)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/admin/finance/by-health", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	adminService.AssertExpectations(t)
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// AdminService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by AdminHandler in this package
+type AdminService interface {
+	// ListUsers returns one page of users and the total number of users
+	// page is 1-based; out of range values fall back to defaults
+	ListUsers(ctx context.Context, page, pageSize int) ([]domain.User, int64, error)
+
+	// GetUser returns a user by ID
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	GetUser(ctx context.Context, userID string) (domain.User, error)
+
+	// DeactivateUser marks a user inactive and revokes their refresh tokens
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	// Returns domain.ErrCannotModifySelf if actorID and userID are the same
+	DeactivateUser(ctx context.Context, actorID, userID string) error
+
+	// SetUserRole changes a user's role
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	// Returns domain.ErrInvalidUserData if the role is unknown
+	// Returns domain.ErrCannotModifySelf if actorID and userID are the same
+	SetUserRole(ctx context.Context, actorID, userID, role string) error
+
+	// GetUsersWithHighDebtRatio returns finance summaries with a debt-to-income ratio above threshold
+	// Returns domain.ErrInvalidFinanceData if the threshold is negative
+	GetUsersWithHighDebtRatio(ctx context.Context, threshold float64) ([]domain.FinanceSummary, error)
+
+	// GetFinanceSummariesByHealthStatus returns finance summaries with the given financial health
+	// Returns domain.ErrInvalidFinanceData if the status is unknown
+	GetFinanceSummariesByHealthStatus(ctx context.Context, status string) ([]domain.FinanceSummary, error)
+}
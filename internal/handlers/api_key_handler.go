@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// APIKeyHandler handles HTTP requests for API key management
+// Routes must be registered behind JWT authentication only, so a key can't be used to mint more keys
+type APIKeyHandler struct {
+	apiKeyService APIKeyService
+	validator     *validator.Validate
+}
+
+// NewAPIKeyHandler creates a new API key handler with dependency injection
+func NewAPIKeyHandler(apiKeyService APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+		validator:     dtos.NewValidator(),
+	}
+}
+
+// CreateAPIKey handles POST /api/v1/auth/api-keys requests
+// The response is the only time the key itself is returned
+//
+//	@Summary	Create an API key
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request			body		dtos.CreateAPIKeyDTO	true	"API key"
+//	@Success	201				{object}	dtos.APIKeyResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/api-keys	[post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	var request dtos.CreateAPIKeyDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	key := request.ToDomain(middleware.GetUserID(c))
+	rawKey, err := h.apiKeyService.CreateAPIKey(c.Request.Context(), &key)
+	if err != nil {
+		h.handleAPIKeyError(c, err, "Failed to create API key")
+		return
+	}
+
+	var response dtos.APIKeyResponseDTO
+	response.FromDomain(key)
+	response.Key = rawKey
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetAPIKeys handles GET /api/v1/auth/api-keys requests
+// Keys are identified by their prefix; revoked keys are listed with their revocation time
+//
+//	@Summary	List API keys
+//	@Tags		auth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200				{array}		dtos.APIKeyResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/api-keys	[get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	keys, err := h.apiKeyService.GetUserAPIKeys(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleAPIKeyError(c, err, "Failed to retrieve API keys")
+		return
+	}
+
+	response := make([]dtos.APIKeyResponseDTO, len(keys))
+	for i, key := range keys {
+		response[i].FromDomain(key)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RevokeAPIKey handles DELETE /api/v1/auth/api-keys/:id requests
+//
+//	@Summary	Revoke an API key
+//	@Tags		auth
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string	true	"API key ID"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/api-keys/{id}	[delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	if err := h.apiKeyService.RevokeAPIKey(c.Request.Context(), middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleAPIKeyError(c, err, "Failed to revoke API key")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "API key revoked successfully",
+	})
+}
+
+// handleAPIKeyError maps an API key service error to its status and error code and writes the response
+// Unexpected errors are logged and reported as a 500 with action as the message
+func (h *APIKeyHandler) handleAPIKeyError(c *gin.Context, err error, action string) {
+	status, code := mapDomainError(err)
+
+	var message string
+	switch code {
+	case dtos.ErrorCodeAuthAPIKeyNotFound:
+		message = "API key not found"
+	case dtos.ErrorCodeTimeout, dtos.ErrorCodeRequestCanceled:
+		message, _ = contextErrorMessage(err)
+	case dtos.ErrorCodeInternal:
+		logging.ContextLogger(c).Error("API key request failed", logging.WithError(err))
+		message = action
+	default:
+		message = err.Error()
+	}
+
+	c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockAPIKeyService is a mock implementation of APIKeyService for testing
+type MockAPIKeyService struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyService) CreateAPIKey(ctx context.Context, key *domain.APIKey) (string, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockAPIKeyService) GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	args := m.Called(ctx, userID, keyID)
+	return args.Error(0)
+}
+
+func setupAPIKeyTestRouter(apiKeyService APIKeyService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	})
+
+	handler := NewAPIKeyHandler(apiKeyService)
+	apiKeys := r.Group("/auth/api-keys")
+	apiKeys.POST("", handler.CreateAPIKey)
+	apiKeys.GET("", handler.GetAPIKeys)
+	apiKeys.DELETE("/:id", handler.RevokeAPIKey)
+
+	return r
+}
+
+func TestAPIKeyHandler_CreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	apiKeyService := new(MockAPIKeyService)
+	apiKeyService.On("CreateAPIKey", mock.Anything, mock.MatchedBy(func(k *domain.APIKey) bool {
+		return k.UserID == "user-1" && k.Name == "Nightly export"
+	})).Run(func(args mock.Arguments) {
+		key := args.Get(1).(*domain.APIKey)
+		key.ID = "key-1"
+		key.HashedKey = "stored-hash"
+		key.Prefix = "bob_0123abcd"
+	}).Return("bob_0123abcdef", nil)
+	apiKeyService.On("GetUserAPIKeys", mock.Anything, "user-1").Return([]domain.APIKey{
+		{ID: "key-1", UserID: "user-1", Name: "Nightly export", HashedKey: "stored-hash", Prefix: "bob_0123abcd", Scopes: []string{domain.APIKeyScopeFinanceRead}},
+	}, nil)
+	router := setupAPIKeyTestRouter(apiKeyService)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":   "Nightly export",
+		"scopes": []string{domain.APIKeyScopeFinanceRead},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/auth/api-keys", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var created dtos.APIKeyResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "key-1", created.ID)
+	assert.Equal(t, "bob_0123abcdef", created.Key)
+	assert.NotContains(t, w.Body.String(), "stored-hash")
+
+	// Listing shows the prefix, never the key or its hash
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/auth/api-keys", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), `"key"`)
+	assert.NotContains(t, w.Body.String(), "stored-hash")
+	assert.Contains(t, w.Body.String(), "bob_0123abcd")
+}
+
+func TestAPIKeyHandler_CreateAPIKey_RejectsUnknownScope(t *testing.T) {
+	apiKeyService := new(MockAPIKeyService)
+	router := setupAPIKeyTestRouter(apiKeyService)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":   "Admin script",
+		"scopes": []string{"admin"},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/auth/api-keys", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	apiKeyService.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyHandler_RevokeAPIKey(t *testing.T) {
+	apiKeyService := new(MockAPIKeyService)
+	apiKeyService.On("RevokeAPIKey", mock.Anything, "user-1", "key-1").Return(nil)
+	apiKeyService.On("RevokeAPIKey", mock.Anything, "user-1", "key-other").Return(domain.ErrAPIKeyNotFound)
+	router := setupAPIKeyTestRouter(apiKeyService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/auth/api-keys/key-1", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodDelete, "/auth/api-keys/key-other", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeAuthAPIKeyNotFound, response.ErrorCode)
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// APIKeyService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by APIKeyHandler in this package
+type APIKeyService interface {
+	// CreateAPIKey creates a key for the user and returns the key itself, which isn't stored
+	// Returns an error wrapping domain.ErrInvalidAPIKeyData if validation fails
+	CreateAPIKey(ctx context.Context, key *domain.APIKey) (string, error)
+
+	// GetUserAPIKeys retrieves all API keys created by a user, revoked ones included
+	GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error)
+
+	// RevokeAPIKey revokes one of the user's API keys
+	// Returns domain.ErrAPIKeyNotFound if it doesn't exist or belongs to another user
+	RevokeAPIKey(ctx context.Context, userID, keyID string) error
+}
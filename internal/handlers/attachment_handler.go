@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+const (
+	// attachmentFormField is the multipart form field an attachment is uploaded in
+	attachmentFormField = "file"
+	// multipartOverhead is allowed on top of the attachment size limit for the multipart
+	// boundaries and part headers around the file
+	multipartOverhead = 64 << 10
+)
+
+// AttachmentHandler handles HTTP requests for medical expense attachments
+// Routes must be registered behind authentication; every operation is scoped to the caller
+type AttachmentHandler struct {
+	attachmentService AttachmentService
+}
+
+// NewAttachmentHandler creates a new attachment handler with dependency injection
+func NewAttachmentHandler(attachmentService AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+	}
+}
+
+// MaxUploadSize returns the largest request body, in bytes, UploadAttachment accepts:
+// the attachment size limit plus room for the multipart framing around the file
+func (h *AttachmentHandler) MaxUploadSize() int64 {
+	return h.attachmentService.MaxAttachmentSize() + multipartOverhead
+}
+
+// UploadAttachment handles POST /api/v1/health/expenses/:id/attachments requests
+// The file's type is detected from its content; the uploaded filename is only kept for downloads
+//
+//	@Summary	Attach a document to a medical expense
+//	@Tags		health
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id									path		string	true	"Medical expense ID"
+//	@Param		file								formData	file	true	"Receipt or EOB: PDF, JPEG or PNG"
+//	@Success	201									{object}	dtos.ExpenseAttachmentResponseDTO
+//	@Failure	400									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	413									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	415									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/{id}/attachments	[post]
+func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.MaxUploadSize())
+
+	file, header, err := c.Request.FormFile(attachmentFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.handleAttachmentError(c, domain.ErrAttachmentTooLarge, "")
+			return
+		}
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed,
+			fmt.Sprintf("A file is required in the %q form field", attachmentFormField)))
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.attachmentService.MaxAttachmentSize() {
+		h.handleAttachmentError(c, domain.ErrAttachmentTooLarge, "")
+		return
+	}
+
+	attachment, err := h.attachmentService.AddAttachment(c.Request.Context(), middleware.GetUserID(c), c.Param("id"), header.Filename, file)
+	if err != nil {
+		h.handleAttachmentError(c, err, "Failed to upload attachment")
+		return
+	}
+
+	var response dtos.ExpenseAttachmentResponseDTO
+	response.FromDomain(attachment)
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetAttachments handles GET /api/v1/health/expenses/:id/attachments requests
+//
+//	@Summary	List a medical expense's attachments
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id									path		string	true	"Medical expense ID"
+//	@Success	200									{object}	dtos.ExpenseAttachmentListResponseDTO
+//	@Failure	401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/{id}/attachments	[get]
+func (h *AttachmentHandler) GetAttachments(c *gin.Context) {
+	attachments, err := h.attachmentService.GetAttachments(c.Request.Context(), middleware.GetUserID(c), c.Param("id"))
+	if err != nil {
+		h.handleAttachmentError(c, err, "Failed to get attachments")
+		return
+	}
+
+	response := dtos.ExpenseAttachmentListResponseDTO{
+		Attachments: make([]dtos.ExpenseAttachmentResponseDTO, len(attachments)),
+		Total:       len(attachments),
+	}
+	for i, attachment := range attachments {
+		response.Attachments[i].FromDomain(attachment)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DownloadAttachment handles GET /api/v1/health/expenses/:id/attachments/:attachmentId requests
+// The file is always served as a download so a browser never renders it inline
+//
+//	@Summary	Download a medical expense attachment
+//	@Tags		health
+//	@Produce	application/pdf,image/jpeg,image/png
+//	@Security	BearerAuth
+//	@Param		id													path		string	true	"Medical expense ID"
+//	@Param		attachmentId										path		string	true	"Attachment ID"
+//	@Success	200													{file}		binary
+//	@Failure	401													{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404													{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500													{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/{id}/attachments/{attachmentId}	[get]
+func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	attachment, content, err := h.attachmentService.OpenAttachment(c.Request.Context(), middleware.GetUserID(c), c.Param("id"), c.Param("attachmentId"))
+	if err != nil {
+		h.handleAttachmentError(c, err, "Failed to download attachment")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment handles DELETE /api/v1/health/expenses/:id/attachments/:attachmentId requests
+//
+//	@Summary	Delete a medical expense attachment
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id													path		string	true	"Medical expense ID"
+//	@Param		attachmentId										path		string	true	"Attachment ID"
+//	@Success	200													{object}	dtos.MessageResponseDTO
+//	@Failure	401													{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404													{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500													{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/{id}/attachments/{attachmentId}	[delete]
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	if err := h.attachmentService.DeleteAttachment(c.Request.Context(), middleware.GetUserID(c), c.Param("id"), c.Param("attachmentId")); err != nil {
+		h.handleAttachmentError(c, err, "Failed to delete attachment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// handleAttachmentError maps an attachment service error to its status and error code and writes the response
+// Unexpected errors are logged and reported as a 500 with action as the message
+func (h *AttachmentHandler) handleAttachmentError(c *gin.Context, err error, action string) {
+	status, code := mapDomainError(err)
+
+	var message string
+	switch code {
+	case dtos.ErrorCodeHealthExpenseNotFound:
+		message = "Medical expense not found"
+	case dtos.ErrorCodeHealthAttachmentNotFound:
+		message = "Attachment not found"
+	case dtos.ErrorCodeHealthAttachmentTooLarge:
+		message = "Attachments must be at most " + strconv.FormatInt(h.attachmentService.MaxAttachmentSize(), 10) + " bytes"
+	case dtos.ErrorCodeHealthUnsupportedMediaType:
+		message = "Attachments must be PDF, JPEG or PNG files"
+	case dtos.ErrorCodeTimeout, dtos.ErrorCodeRequestCanceled:
+		message, _ = contextErrorMessage(err)
+	case dtos.ErrorCodeInternal:
+		logging.ContextLogger(c).Error("Attachment request failed", logging.WithError(err))
+		message = action
+	default:
+		message = err.Error()
+	}
+
+	c.JSON(status, dtos.NewSimpleErrorResponse(code, message))
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockAttachmentService is a mock implementation of AttachmentService for testing
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) MaxAttachmentSize() int64 {
+	return int64(m.Called().Int(0))
+}
+
+func (m *MockAttachmentService) AddAttachment(ctx context.Context, userID, expenseID, fileName string, content io.Reader) (domain.ExpenseAttachment, error) {
+	args := m.Called(ctx, userID, expenseID, fileName, content)
+	return args.Get(0).(domain.ExpenseAttachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) GetAttachments(ctx context.Context, userID, expenseID string) ([]domain.ExpenseAttachment, error) {
+	args := m.Called(ctx, userID, expenseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ExpenseAttachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) OpenAttachment(ctx context.Context, userID, expenseID, attachmentID string) (domain.ExpenseAttachment, io.ReadCloser, error) {
+	args := m.Called(ctx, userID, expenseID, attachmentID)
+	if args.Get(1) == nil {
+		return args.Get(0).(domain.ExpenseAttachment), nil, args.Error(2)
+	}
+	return args.Get(0).(domain.ExpenseAttachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockAttachmentService) DeleteAttachment(ctx context.Context, userID, expenseID, attachmentID string) error {
+	args := m.Called(ctx, userID, expenseID, attachmentID)
+	return args.Error(0)
+}
+
+const testMaxAttachmentSize = 1024
+
+// setupAttachmentTestRouter authenticates every request as user-1 and registers the attachment routes
+func setupAttachmentTestRouter(attachmentService AttachmentService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	})
+
+	handler := NewAttachmentHandler(attachmentService)
+	r.POST("/health/expenses/:id/attachments", handler.UploadAttachment)
+	r.GET("/health/expenses/:id/attachments", handler.GetAttachments)
+	r.GET("/health/expenses/:id/attachments/:attachmentId", handler.DownloadAttachment)
+	r.DELETE("/health/expenses/:id/attachments/:attachmentId", handler.DeleteAttachment)
+	return r
+}
+
+// newUploadRequest builds a multipart upload of content under field
+func newUploadRequest(t *testing.T, field, fileName string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, fileName)
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, "/health/expenses/7/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func serveAttachmentRequest(router *gin.Engine, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAttachmentHandler_UploadAttachment_Success(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachmentService.On("MaxAttachmentSize").Return(testMaxAttachmentSize)
+	attachment := domain.ExpenseAttachment{
+		ID:          "12",
+		ExpenseID:   "7",
+		FileName:    "receipt.pdf",
+		ContentType: domain.AttachmentContentTypePDF,
+		Size:        9,
+		Checksum:    "abc123",
+		CreatedAt:   time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	attachmentService.On("AddAttachment", mock.Anything, "user-1", "7", "receipt.pdf", mock.Anything).Return(attachment, nil)
+	router := setupAttachmentTestRouter(attachmentService)
+
+	w := serveAttachmentRequest(router, newUploadRequest(t, "file", "receipt.pdf", []byte("%PDF-1.7\n")))
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var response dtos.ExpenseAttachmentResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "12", response.ID)
+	assert.Equal(t, "receipt.pdf", response.FileName)
+	assert.Equal(t, domain.AttachmentContentTypePDF, response.ContentType)
+	assert.Equal(t, "abc123", response.Checksum)
+	attachmentService.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_UploadAttachment_RejectsOversizedFile(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachmentService.On("MaxAttachmentSize").Return(testMaxAttachmentSize)
+	router := setupAttachmentTestRouter(attachmentService)
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"just over the limit", testMaxAttachmentSize + 1},
+		// Larger than the limit plus the multipart overhead, so the body itself is cut off
+		{"body over the limit", testMaxAttachmentSize + multipartOverhead + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAttachmentRequest(router, newUploadRequest(t, "file", "receipt.pdf", bytes.Repeat([]byte("a"), tt.size)))
+
+			assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+			var response dtos.SimpleErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, dtos.ErrorCodeHealthAttachmentTooLarge, response.ErrorCode)
+			assert.Contains(t, response.Error, "1024 bytes")
+		})
+	}
+
+	attachmentService.AssertNotCalled(t, "AddAttachment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentHandler_UploadAttachment_RejectsUnsupportedContentType(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachmentService.On("MaxAttachmentSize").Return(testMaxAttachmentSize)
+	attachmentService.On("AddAttachment", mock.Anything, "user-1", "7", "receipt.pdf", mock.Anything).
+		Return(domain.ExpenseAttachment{}, fmt.Errorf("%w: text/html; charset=utf-8", domain.ErrUnsupportedAttachmentType))
+	router := setupAttachmentTestRouter(attachmentService)
+
+	w := serveAttachmentRequest(router, newUploadRequest(t, "file", "receipt.pdf", []byte("<html></html>")))
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	var response dtos.SimpleErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeHealthUnsupportedMediaType, response.ErrorCode)
+	attachmentService.AssertExpectations(t)
+}
+
+func TestAttachmentHandler_UploadAttachment_RequiresFileField(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachmentService.On("MaxAttachmentSize").Return(testMaxAttachmentSize)
+	router := setupAttachmentTestRouter(attachmentService)
+
+	w := serveAttachmentRequest(router, newUploadRequest(t, "document", "receipt.pdf", []byte("%PDF-1.7\n")))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	attachmentService.AssertNotCalled(t, "AddAttachment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachmentHandler_DownloadAttachment_SetsHeaders(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachment := domain.ExpenseAttachment{
+		ID:          "12",
+		ExpenseID:   "7",
+		FileName:    `eob "march".pdf`,
+		ContentType: domain.AttachmentContentTypePDF,
+		Size:        9,
+	}
+	attachmentService.On("OpenAttachment", mock.Anything, "user-1", "7", "12").
+		Return(attachment, io.NopCloser(strings.NewReader("%PDF-1.7\n")), nil)
+	router := setupAttachmentTestRouter(attachmentService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/expenses/7/attachments/12", nil)
+	w := serveAttachmentRequest(router, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "%PDF-1.7\n", w.Body.String())
+	assert.Equal(t, domain.AttachmentContentTypePDF, w.Header().Get("Content-Type"))
+	assert.Equal(t, "9", w.Header().Get("Content-Length"))
+	assert.Equal(t, `attachment; filename="eob \"march\".pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+}
+
+func TestAttachmentHandler_NotFound(t *testing.T) {
+	attachmentService := new(MockAttachmentService)
+	attachmentService.On("OpenAttachment", mock.Anything, "user-1", "8", "12").
+		Return(domain.ExpenseAttachment{}, nil, domain.ErrMedicalExpenseNotFound)
+	attachmentService.On("DeleteAttachment", mock.Anything, "user-1", "7", "99").Return(domain.ErrAttachmentNotFound)
+	attachmentService.On("GetAttachments", mock.Anything, "user-1", "8").Return(nil, domain.ErrMedicalExpenseNotFound)
+	router := setupAttachmentTestRouter(attachmentService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/health/expenses/8/attachments/12", nil)
+	w := serveAttachmentRequest(router, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), string(dtos.ErrorCodeHealthExpenseNotFound))
+
+	req, _ = http.NewRequest(http.MethodDelete, "/health/expenses/7/attachments/99", nil)
+	w = serveAttachmentRequest(router, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), string(dtos.ErrorCodeHealthAttachmentNotFound))
+
+	req, _ = http.NewRequest(http.MethodGet, "/health/expenses/8/attachments", nil)
+	w = serveAttachmentRequest(router, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	attachmentService.AssertExpectations(t)
+}
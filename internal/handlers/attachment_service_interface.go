@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"context"
+	"io"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// AttachmentService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by AttachmentHandler in this package
+type AttachmentService interface {
+	// MaxAttachmentSize returns the largest attachment, in bytes, AddAttachment accepts
+	MaxAttachmentSize() int64
+
+	// AddAttachment stores content as an attachment of one of the user's medical expenses
+	// Returns domain.ErrMedicalExpenseNotFound if the expense doesn't exist or belongs to another user,
+	// or an error wrapping domain.ErrAttachmentTooLarge or domain.ErrUnsupportedAttachmentType
+	AddAttachment(ctx context.Context, userID, expenseID, fileName string, content io.Reader) (domain.ExpenseAttachment, error)
+
+	// GetAttachments retrieves the attachments of one of the user's medical expenses
+	// Returns domain.ErrMedicalExpenseNotFound if the expense doesn't exist or belongs to another user
+	GetAttachments(ctx context.Context, userID, expenseID string) ([]domain.ExpenseAttachment, error)
+
+	// OpenAttachment retrieves an attachment with its content, which the caller must close
+	// Returns domain.ErrMedicalExpenseNotFound or domain.ErrAttachmentNotFound
+	OpenAttachment(ctx context.Context, userID, expenseID, attachmentID string) (domain.ExpenseAttachment, io.ReadCloser, error)
+
+	// DeleteAttachment removes an attachment and its stored file
+	// Returns domain.ErrMedicalExpenseNotFound or domain.ErrAttachmentNotFound
+	DeleteAttachment(ctx context.Context, userID, expenseID, attachmentID string) error
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+const (
+	// defaultAuditPageSize and maxAuditPageSize bound the audit log page size
+	defaultAuditPageSize = 50
+	maxAuditPageSize     = 100
+)
+
+// AuditHandler handles HTTP requests for the audit log
+// GetMyAuditLog must be registered behind authentication and GetAuditLog behind
+// middleware.RequireRole(domain.RoleAdmin)
+type AuditHandler struct {
+	auditService AuditService
+}
+
+// NewAuditHandler creates a new audit handler with dependency injection
+func NewAuditHandler(auditService AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetMyAuditLog handles GET /api/v1/account/audit-log and GET /api/v1/auth/audit requests
+// Returns the caller's own audit entries, newest first
+//
+//	@Summary	List my audit log
+//	@Tags		account
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		cursor				query		string	false	"Cursor from a previous page"
+//	@Param		limit				query		int		false	"Page size, 1-100 (default 50)"
+//	@Success	200					{object}	dtos.AuditLogPageResponseDTO
+//	@Failure	400					{object}	dtos.ErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/account/audit-log	[get]
+//	@Router		/auth/audit			[get]
+func (h *AuditHandler) GetMyAuditLog(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	h.respondWithPage(c, userID)
+}
+
+// GetAuditLog handles GET /api/v1/admin/audit-log requests
+// Returns every user's audit entries, newest first, or one user's with the user_id query parameter
+//
+//	@Summary	List the audit log
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		user_id				query		string	false	"Only entries by this user"
+//	@Param		cursor				query		string	false	"Cursor from a previous page"
+//	@Param		limit				query		int		false	"Page size, 1-100 (default 50)"
+//	@Success	200					{object}	dtos.AuditLogPageResponseDTO
+//	@Failure	400					{object}	dtos.ErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/audit-log	[get]
+func (h *AuditHandler) GetAuditLog(c *gin.Context) {
+	h.respondWithPage(c, c.Query("user_id"))
+}
+
+// respondWithPage writes the page of userID's entries selected by the cursor and limit query parameters
+func (h *AuditHandler) respondWithPage(c *gin.Context, userID string) {
+	limit := defaultAuditPageSize
+	if raw, ok := c.GetQuery("limit"); ok {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxAuditPageSize {
+			h.badRequest(c, "limit must be between 1 and "+strconv.Itoa(maxAuditPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	entries, next, err := h.auditService.GetAuditLogPage(c.Request.Context(), userID, c.Query("cursor"), limit)
+	if err != nil {
+		h.handleAuditError(c, err)
+		return
+	}
+
+	response := dtos.AuditLogPageResponseDTO{
+		Entries:    make([]dtos.AuditEntryDTO, len(entries)),
+		NextCursor: next,
+		HasMore:    next != "",
+		Limit:      limit,
+	}
+	for i, entry := range entries {
+		response.Entries[i].FromDomain(entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuditHandler) badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+		http.StatusBadRequest,
+		"bad_request",
+		message,
+	))
+}
+
+// handleAuditError maps service errors to HTTP responses
+func (h *AuditHandler) handleAuditError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCursor):
+		h.badRequest(c, "Invalid pagination cursor")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Audit log request failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"An internal error occurred. Please try again later",
+		))
+	}
+}
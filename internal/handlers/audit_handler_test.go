@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// MockAuditService is a mock implementation of AuditService for testing
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) GetAuditLogPage(ctx context.Context, userID, cursor string, limit int) ([]domain.AuditEntry, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.AuditEntry), args.String(1), args.Error(2)
+}
+
+// setupAuditTestRouter authenticates every request as the given user and role and registers
+// the audit routes the way the production router does
+func setupAuditTestRouter(auditService AuditService, userID, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", userID)
+		c.Set("userRole", role)
+		c.Set("tokenClaims", &domain.TokenClaims{UserID: userID, Role: role})
+		c.Next()
+	})
+
+	handler := NewAuditHandler(auditService)
+	r.GET("/account/audit-log", handler.GetMyAuditLog)
+	r.GET("/auth/audit", handler.GetMyAuditLog)
+	r.GET("/admin/audit-log", middleware.RequireRole(domain.RoleAdmin), handler.GetAuditLog)
+	return r
+}
+
+func serveAuditRequest(router *gin.Engine, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuditHandler_GetMyAuditLog_ReturnsOwnEntries(t *testing.T) {
+	auditService := new(MockAuditService)
+	entries := []domain.AuditEntry{{
+		ID:           "audit-1",
+		UserID:       "user-1",
+		Action:       domain.AuditActionUpdate,
+		ResourceType: domain.AuditResourceIncome,
+		ResourceID:   "income-1",
+		Changes:      map[string]domain.AuditChange{"amount": {Before: 5000.0, After: 5500.0}},
+		CreatedAt:    time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+	}}
+	auditService.On("GetAuditLogPage", mock.Anything, "user-1", "abc", 10).Return(entries, "next-page", nil)
+	router := setupAuditTestRouter(auditService, "user-1", domain.RoleUser)
+
+	w := serveAuditRequest(router, "/account/audit-log?cursor=abc&limit=10&user_id=user-2")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.AuditLogPageResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Entries, 1)
+	assert.Equal(t, "income-1", response.Entries[0].ResourceID)
+	assert.Equal(t, 5500.0, response.Entries[0].Changes["amount"].After)
+	assert.Equal(t, "next-page", response.NextCursor)
+	assert.True(t, response.HasMore)
+	assert.Equal(t, 10, response.Limit)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetMyAuditLog_AuthAuditRoute(t *testing.T) {
+	auditService := new(MockAuditService)
+	auditService.On("GetAuditLogPage", mock.Anything, "user-1", "", defaultAuditPageSize).Return([]domain.AuditEntry{}, "", nil)
+	router := setupAuditTestRouter(auditService, "user-1", domain.RoleUser)
+
+	w := serveAuditRequest(router, "/auth/audit?user_id=user-2")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetMyAuditLog_InvalidRequests(t *testing.T) {
+	auditService := new(MockAuditService)
+	auditService.On("GetAuditLogPage", mock.Anything, "user-1", "bad", defaultAuditPageSize).
+		Return(nil, "", fmt.Errorf("bad cursor: %w", domain.ErrInvalidCursor))
+	router := setupAuditTestRouter(auditService, "user-1", domain.RoleUser)
+
+	assert.Equal(t, http.StatusBadRequest, serveAuditRequest(router, "/account/audit-log?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, serveAuditRequest(router, "/account/audit-log?limit=101").Code)
+	assert.Equal(t, http.StatusBadRequest, serveAuditRequest(router, "/account/audit-log?cursor=bad").Code)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetAuditLog_AdminFiltersByUser(t *testing.T) {
+	auditService := new(MockAuditService)
+	auditService.On("GetAuditLogPage", mock.Anything, "user-2", "", defaultAuditPageSize).Return([]domain.AuditEntry{}, "", nil)
+	auditService.On("GetAuditLogPage", mock.Anything, "", "", defaultAuditPageSize).Return([]domain.AuditEntry{}, "", nil)
+	router := setupAuditTestRouter(auditService, "admin-1", domain.RoleAdmin)
+
+	w := serveAuditRequest(router, "/admin/audit-log?user_id=user-2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.AuditLogPageResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Empty(t, response.Entries)
+	assert.False(t, response.HasMore)
+
+	assert.Equal(t, http.StatusOK, serveAuditRequest(router, "/admin/audit-log").Code)
+	auditService.AssertExpectations(t)
+}
+
+func TestAuditHandler_GetAuditLog_NormalUserForbidden(t *testing.T) {
+	auditService := new(MockAuditService)
+	router := setupAuditTestRouter(auditService, "user-1", domain.RoleUser)
+
+	w := serveAuditRequest(router, "/admin/audit-log?user_id=user-2")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	auditService.AssertNotCalled(t, "GetAuditLogPage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
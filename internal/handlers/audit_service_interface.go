@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// AuditService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by AuditHandler in this package
+type AuditService interface {
+	// GetAuditLogPage retrieves one page of audit entries, newest first, and the cursor for the next page
+	// userID limits the entries to one user; "" returns every user's entries
+	// Returns an error wrapping domain.ErrInvalidCursor if the cursor is malformed
+	GetAuditLogPage(ctx context.Context, userID, cursor string, limit int) ([]domain.AuditEntry, string, error)
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -10,6 +11,7 @@ import (
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
 )
 
 // AuthService interface is consumed by this handler and defined in this package
@@ -19,19 +21,47 @@ import (
 type AuthHandler struct {
 	authService AuthService
 	validator   *validator.Validate
+	// sessionCookies is nil unless cookie sessions are enabled
+	sessionCookies *middleware.SessionCookies
+}
+
+// AuthHandlerOption configures optional AuthHandler behaviour
+type AuthHandlerOption func(*AuthHandler)
+
+// WithSessionCookies enables cookie sessions: issued tokens are also set as cookies, refresh and
+// logout read the refresh token cookie, and logout and account deletion clear the cookies
+func WithSessionCookies(cookies *middleware.SessionCookies) AuthHandlerOption {
+	return func(h *AuthHandler) {
+		h.sessionCookies = cookies
+	}
 }
 
 // NewAuthHandler creates a new authentication handler with dependency injection
-func NewAuthHandler(authService AuthService) *AuthHandler {
-	return &AuthHandler{
+func NewAuthHandler(authService AuthService, opts ...AuthHandlerOption) *AuthHandler {
+	h := &AuthHandler{
 		authService: authService,
-		validator:   validator.New(),
+		validator:   dtos.NewValidator(),
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
 // Login handles POST /api/auth/login requests
 // Authenticates user with email and password
 // Returns JWT token pair on successful authentication
+//
+//	@Summary	Log in
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dtos.LoginRequestDTO	true	"Credentials"
+//	@Success	200		{object}	dtos.TokenResponseDTO
+//	@Failure	400		{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401		{object}	dtos.ErrorResponseDTO
+//	@Failure	500		{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	logger := logging.ContextLogger(c).With(logging.WithOperation("login"))
 	logger.Info("Login request started")
@@ -51,25 +81,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := make(map[string]interface{})
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			switch err.Tag() {
-			case "required":
-				validationErrors[field] = field + " is required"
-			case "email":
-				validationErrors[field] = field + " must be a valid email address"
-			case "min":
-				validationErrors[field] = field + " must be at least " + err.Param() + " characters"
-			default:
-				validationErrors[field] = field + " is invalid"
-			}
-		}
-
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -85,16 +97,23 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	// Convert domain response to DTO
-	var response dtos.TokenResponseDTO
-	response.FromDomain(tokenPair)
-
 	logger.Info("Login successful", logging.WithUserID(credentials.Email))
-	c.JSON(http.StatusOK, response)
+	h.respondWithTokens(c, http.StatusOK, tokenPair)
 }
 
 // Register handles POST /api/auth/register requests
 // Creates new user account and returns JWT token pair
+//
+//	@Summary	Register a new user
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dtos.RegisterRequestDTO	true	"New account"
+//	@Success	201		{object}	dtos.TokenResponseDTO
+//	@Failure	400		{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	409		{object}	dtos.ErrorResponseDTO
+//	@Failure	500		{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	logger := logging.ContextLogger(c).With(logging.WithOperation("register"))
 	logger.Info("Registration request started")
@@ -113,25 +132,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := make(map[string]interface{})
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			switch err.Tag() {
-			case "required":
-				validationErrors[field] = field + " is required"
-			case "email":
-				validationErrors[field] = field + " must be a valid email address"
-			case "min":
-				validationErrors[field] = field + " must be at least " + err.Param() + " characters"
-			default:
-				validationErrors[field] = field + " is invalid"
-			}
-		}
-
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -150,23 +151,112 @@ func (h *AuthHandler) Register(c *gin.Context) {
 			))
 			return
 		}
+		if response, ok := weakPasswordResponse(err, "password"); ok {
+			c.JSON(http.StatusBadRequest, response)
+			return
+		}
 		h.handleAuthError(c, err)
 		return
 	}
 
-	// Convert domain response to DTO
-	var response dtos.TokenResponseDTO
-	response.FromDomain(tokenPair)
-
-	c.JSON(http.StatusCreated, response)
+	h.respondWithTokens(c, http.StatusCreated, tokenPair)
 }
 
 // RefreshToken handles POST /api/auth/refresh requests
 // Generates new JWT token pair using valid refresh token
+// With cookie sessions enabled, a refresh token cookie is used instead of the request body
+//
+//	@Summary	Refresh tokens
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		dtos.RefreshTokenRequestDTO	true	"Refresh token"
+//	@Success	200		{object}	dtos.TokenResponseDTO
+//	@Failure	400		{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401		{object}	dtos.ErrorResponseDTO
+//	@Failure	500		{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
-	var request dtos.RefreshTokenRequestDTO
+	refreshToken, ok := h.requestRefreshToken(c)
+	if !ok {
+		return
+	}
 
-	// Parse and bind JSON request
+	// Call service layer
+	tokenPair, err := h.authService.RefreshToken(c.Request.Context(), refreshToken)
+	if err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	h.respondWithTokens(c, http.StatusOK, tokenPair)
+}
+
+// Logout handles POST /api/auth/logout requests
+// Revokes the provided refresh token
+// With cookie sessions enabled, a refresh token cookie is revoked instead and the cookies are cleared
+//
+//	@Summary	Log out
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request	body		dtos.RefreshTokenRequestDTO	true	"Refresh token to revoke"
+//	@Success	200		{object}	dtos.MessageResponseDTO
+//	@Failure	400		{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401		{object}	dtos.ErrorResponseDTO
+//	@Failure	500		{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	refreshToken, ok := h.requestRefreshToken(c)
+	if !ok {
+		return
+	}
+
+	// Call service layer
+	if err := h.authService.Logout(c.Request.Context(), refreshToken); err != nil {
+		h.handleAuthError(c, err)
+		return
+	}
+
+	if h.sessionCookies != nil {
+		h.sessionCookies.Clear(c)
+	}
+
+	// Return success response
+	c.JSON(http.StatusOK, gin.H{
+		"message": "logged out successfully",
+	})
+}
+
+// DeleteAccount handles DELETE /api/v1/auth/me requests
+// Permanently deletes the caller's account once their password is confirmed
+//
+//	@Summary	Delete my account
+//	@Description	Deletes the account with all of its finance and health records and revokes every refresh token.
+//	@Description	Access tokens already issued are rejected from then on.
+//	@Tags		auth
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request	body		dtos.DeleteAccountRequestDTO	true	"Password confirming the deletion"
+//	@Success	200		{object}	dtos.MessageResponseDTO
+//	@Failure	400		{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401		{object}	dtos.ErrorResponseDTO
+//	@Failure	500		{object}	dtos.ErrorResponseDTO
+//	@Router		/auth/me [delete]
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"User not authenticated",
+		))
+		return
+	}
+
+	var request dtos.DeleteAccountRequestDTO
 	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
 			http.StatusBadRequest,
@@ -175,43 +265,42 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		))
 		return
 	}
-
-	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := make(map[string]interface{})
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			if err.Tag() == "required" {
-				validationErrors[field] = field + " is required"
-			} else {
-				validationErrors[field] = field + " is invalid"
-			}
-		}
-
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
-	// Call service layer
-	tokenPair, err := h.authService.RefreshToken(c.Request.Context(), request.RefreshToken)
-	if err != nil {
+	if err := h.authService.DeleteAccount(c.Request.Context(), userID, request.Password); err != nil {
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, dtos.NewCodedErrorResponse(
+				http.StatusUnauthorized,
+				dtos.ErrorCodeAuthInvalidCredentials,
+				"Password is incorrect",
+			))
+			return
+		}
 		h.handleAuthError(c, err)
 		return
 	}
 
-	// Convert domain response to DTO
-	var response dtos.TokenResponseDTO
-	response.FromDomain(tokenPair)
+	if h.sessionCookies != nil {
+		h.sessionCookies.Clear(c)
+	}
 
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "account deleted",
+	})
 }
 
-// Logout handles POST /api/auth/logout requests
-// Revokes the provided refresh token
-func (h *AuthHandler) Logout(c *gin.Context) {
+// requestRefreshToken returns the refresh token cookie of a cookie session, or else the refresh
+// token in the JSON body. Writes a 400 response and returns false if the body is invalid.
+func (h *AuthHandler) requestRefreshToken(c *gin.Context) (string, bool) {
+	if h.sessionCookies != nil {
+		if token := h.sessionCookies.RefreshToken(c); token != "" {
+			return token, true
+		}
+	}
+
 	var request dtos.RefreshTokenRequestDTO
 
 	// Parse and bind JSON request
@@ -221,98 +310,79 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 			"bad_request",
 			"Invalid JSON format",
 		))
-		return
+		return "", false
 	}
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := make(map[string]interface{})
-		for _, err := range err.(validator.ValidationErrors) {
-			field := err.Field()
-			if err.Tag() == "required" {
-				validationErrors[field] = field + " is required"
-			} else {
-				validationErrors[field] = field + " is invalid"
-			}
-		}
-
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
-		return
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return "", false
 	}
 
-	// Call service layer
-	if err := h.authService.Logout(c.Request.Context(), request.RefreshToken); err != nil {
-		h.handleAuthError(c, err)
-		return
+	return request.RefreshToken, true
+}
+
+// respondWithTokens writes an issued token pair, also setting it as cookies when cookie sessions
+// are enabled. The body is the same either way, so clients that send an Authorization header
+// keep working.
+func (h *AuthHandler) respondWithTokens(c *gin.Context, status int, tokenPair *domain.TokenPair) {
+	if h.sessionCookies != nil {
+		if err := h.sessionCookies.Set(c, tokenPair); err != nil {
+			h.handleAuthError(c, err)
+			return
+		}
 	}
 
-	// Return success response
-	c.JSON(http.StatusOK, gin.H{
-		"message": "logged out successfully",
-	})
+	// Convert domain response to DTO
+	var response dtos.TokenResponseDTO
+	response.FromDomain(tokenPair)
+
+	c.JSON(status, response)
 }
 
 // handleAuthError handles authentication-specific errors and maps them to appropriate HTTP responses
 func (h *AuthHandler) handleAuthError(c *gin.Context, err error) {
+	status, code := mapDomainError(err)
+	c.JSON(status, dtos.NewCodedErrorResponse(status, code, authErrorMessage(err)))
+}
+
+// weakPasswordResponse renders a password policy failure as a validation error on field, the
+// JSON name of the password in the request, listing every rule the password breaks
+func weakPasswordResponse(err error, field string) (*dtos.ValidationErrorResponseDTO, bool) {
+	var weak *domain.WeakPasswordError
+	if !errors.As(err, &weak) {
+		return nil, false
+	}
+	return dtos.NewValidationErrorResponse("Password does not meet the password policy", map[string]any{
+		field: field + " " + strings.Join(weak.Violations, "; "),
+	}), true
+}
+
+// authErrorMessage returns the user-facing message for an authentication error
+func authErrorMessage(err error) string {
+	if message, ok := contextErrorMessage(err); ok {
+		return message
+	}
+
 	switch {
-	case errors.Is(err, domain.ErrInvalidCredentials):
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"Invalid email or password",
-		))
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrUserNotFound):
+		// User not found is reported as invalid credentials for security
+		return "Invalid email or password"
 	case errors.Is(err, domain.ErrAccountInactive):
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"Your account is inactive. Please contact support",
-		))
-	case errors.Is(err, domain.ErrInvalidToken):
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"Invalid or malformed token",
-		))
+		return "Your account is inactive. Please contact support"
+	case errors.Is(err, domain.ErrInvalidToken), errors.Is(err, domain.ErrTokenNotFound):
+		return "Invalid or malformed token"
 	case errors.Is(err, domain.ErrTokenExpired):
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"token has expired",
-		))
+		return "token has expired"
 	case errors.Is(err, domain.ErrTokenRevoked):
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"Token has been revoked",
-		))
-	case errors.Is(err, domain.ErrUserNotFound):
-		// Map user not found to invalid credentials for security
-		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
-			http.StatusUnauthorized,
-			"unauthorized",
-			"Invalid email or password",
-		))
+		return "Token has been revoked"
 	case errors.Is(err, domain.ErrUserAlreadyExists):
-		c.JSON(http.StatusConflict, dtos.NewErrorResponse(
-			http.StatusConflict,
-			"conflict",
-			"User with this email already exists",
-		))
+		return "User with this email already exists"
 	case errors.Is(err, domain.ErrInvalidUserData):
-		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
-			http.StatusBadRequest,
-			"bad_request",
-			"Invalid user data provided",
-		))
+		return "Invalid user data provided"
+	case errors.Is(err, domain.ErrWeakPassword):
+		return "Password does not meet the password policy"
 	default:
-		// Internal server error for unexpected errors
-		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
-			http.StatusInternalServerError,
-			"internal_error",
-			"An internal error occurred. Please try again later",
-		))
+		return "An internal error occurred. Please try again later"
 	}
-}
\ No newline at end of file
+}
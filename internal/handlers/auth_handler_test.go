@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +18,7 @@ import (
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
 )
 
 // MockAuthService is a mock implementation of AuthService for testing
@@ -53,7 +55,12 @@ func (m *MockAuthService) Logout(ctx context.Context, refreshToken string) error
 	return args.Error(0)
 }
 
-func setupTestRouter(authService AuthService) *gin.Engine {
+func (m *MockAuthService) DeleteAccount(ctx context.Context, userID, password string) error {
+	args := m.Called(ctx, userID, password)
+	return args.Error(0)
+}
+
+func setupTestRouter(authService AuthService, opts ...AuthHandlerOption) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	
 	// Initialize logging for tests
@@ -68,7 +75,7 @@ func setupTestRouter(authService AuthService) *gin.Engine {
 	// Add logging middleware for tests
 	r.Use(logging.HTTPLoggingMiddleware(logging.DefaultHTTPLoggingConfig()))
 	
-	handler := NewAuthHandler(authService)
+	handler := NewAuthHandler(authService, opts...)
 	
 	// Set up auth routes
 	auth := r.Group("/api/auth")
@@ -158,6 +165,7 @@ func TestAuthHandler_Login_InvalidCredentials_Returns401(t *testing.T) {
 	
 	assert.Equal(t, http.StatusUnauthorized, response.Code)
 	assert.Equal(t, "unauthorized", response.Error)
+	assert.Equal(t, dtos.ErrorCodeAuthInvalidCredentials, response.ErrorCode)
 	
 	mockAuthService.AssertExpectations(t)
 }
@@ -189,6 +197,7 @@ func TestAuthHandler_Login_InvalidEmailFormat_Returns400(t *testing.T) {
 	
 	assert.Equal(t, http.StatusBadRequest, response.Code)
 	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, dtos.ErrorCodeValidationFailed, response.ErrorCode)
 	
 	// Should not call service if validation fails
 	mockAuthService.AssertNotCalled(t, "Login")
@@ -326,6 +335,25 @@ func TestAuthHandler_Register_InvalidData_Returns400(t *testing.T) {
 	mockAuthService.AssertNotCalled(t, "Register")
 }
 
+func TestAuthHandler_Register_WeakPassword_Returns400WithBrokenRules(t *testing.T) {
+	mockAuthService := new(MockAuthService)
+	router := setupTestRouter(mockAuthService)
+	mockAuthService.On("Register", mock.Anything, mock.Anything, "password123").
+		Return(nil, &domain.WeakPasswordError{Violations: []string{"must contain a symbol"}})
+
+	requestBody, _ := json.Marshal(dtos.RegisterRequestDTO{Email: "newuser@example.com", Name: "New User", Password: "password123"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/auth/register", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, "password must contain a symbol", response.Fields["password"])
+}
+
 func TestAuthHandler_RefreshToken_ValidToken_Returns200AndNewTokens(t *testing.T) {
 	// Arrange
 	mockAuthService := new(MockAuthService)
@@ -693,8 +721,8 @@ func TestAuthHandler_Login_RequiredFieldsMissing_Returns400(t *testing.T) {
 	
 	assert.Equal(t, http.StatusBadRequest, response.Code)
 	assert.Equal(t, "validation_error", response.Error)
-	assert.Contains(t, response.Fields, "Email")
-	assert.Contains(t, response.Fields, "Password")
+	assert.Contains(t, response.Fields, "email")
+	assert.Contains(t, response.Fields, "password")
 	
 	// Should not call service if validation fails
 	mockAuthService.AssertNotCalled(t, "Login")
@@ -727,7 +755,7 @@ func TestAuthHandler_Login_PasswordTooShort_Returns400(t *testing.T) {
 	
 	assert.Equal(t, http.StatusBadRequest, response.Code)
 	assert.Equal(t, "validation_error", response.Error)
-	assert.Contains(t, response.Fields, "Password")
+	assert.Contains(t, response.Fields, "password")
 	
 	// Should not call service if validation fails
 	mockAuthService.AssertNotCalled(t, "Login")
@@ -768,4 +796,144 @@ func TestAuthHandler_Register_InternalServerError_Returns500(t *testing.T) {
 	assert.Equal(t, "internal_error", response.Error)
 	
 	mockAuthService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestAuthHandler_DeleteAccount(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		body        string
+		serviceErr  error
+		callsDelete bool
+		wantStatus  int
+		wantMessage string
+	}{
+		{"deleted", "user-123", `{"password":"password123"}`, nil, true, http.StatusOK, "account deleted"},
+		{"wrong password", "user-123", `{"password":"wrong"}`, domain.ErrInvalidCredentials, true, http.StatusUnauthorized, "Password is incorrect"},
+		{"missing password", "user-123", `{}`, nil, false, http.StatusBadRequest, ""},
+		{"malformed JSON", "user-123", `{"password":`, nil, false, http.StatusBadRequest, "Invalid JSON format"},
+		{"unauthenticated", "", `{"password":"password123"}`, nil, false, http.StatusUnauthorized, ""},
+		{"internal error", "user-123", `{"password":"password123"}`, fmt.Errorf("database connection failed"), true, http.StatusInternalServerError, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuthService := new(MockAuthService)
+			if tt.callsDelete {
+				mockAuthService.On("DeleteAccount", mock.Anything, tt.userID, mock.AnythingOfType("string")).Return(tt.serviceErr)
+			}
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.userID != "" {
+					c.Set("userID", tt.userID)
+				}
+				c.Next()
+			})
+			router.DELETE("/api/auth/me", NewAuthHandler(mockAuthService).DeleteAccount)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/api/auth/me", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantMessage != "" {
+				assert.Contains(t, w.Body.String(), tt.wantMessage)
+			}
+			assert.NotContains(t, w.Body.String(), "database connection failed")
+			mockAuthService.AssertExpectations(t)
+			if !tt.callsDelete {
+				mockAuthService.AssertNotCalled(t, "DeleteAccount", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAuthHandler_CookieSessions(t *testing.T) {
+	sessionCookies := func() AuthHandlerOption {
+		return WithSessionCookies(middleware.NewSessionCookies(middleware.SessionCookiesConfig{
+			RefreshPath:     "/api/auth",
+			RefreshTokenTTL: 24 * time.Hour,
+		}))
+	}
+	cookiesOf := func(w *httptest.ResponseRecorder) map[string]*http.Cookie {
+		cookies := make(map[string]*http.Cookie)
+		for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+			cookies[cookie.Name] = cookie
+		}
+		return cookies
+	}
+
+	t.Run("login sets the session cookies and still returns the tokens", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		router := setupTestRouter(mockAuthService, sessionCookies())
+		mockAuthService.On("Login", mock.Anything, mock.Anything).Return(createValidTokenPair(), nil)
+
+		requestBody, _ := json.Marshal(dtos.LoginRequestDTO{Email: "test@example.com", Password: "password123"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		cookies := cookiesOf(w)
+		assert.Equal(t, "valid_access_token", cookies[middleware.AccessTokenCookie].Value)
+		assert.Equal(t, "valid_refresh_token", cookies[middleware.RefreshTokenCookie].Value)
+		assert.NotEmpty(t, cookies[middleware.CSRFCookie].Value)
+
+		var response dtos.TokenResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "valid_access_token", response.AccessToken)
+	})
+
+	t.Run("refresh reads the refresh token cookie", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		router := setupTestRouter(mockAuthService, sessionCookies())
+		newTokens := &domain.TokenPair{AccessToken: "new_access_token", RefreshToken: "new_refresh_token", ExpiresIn: 900}
+		mockAuthService.On("RefreshToken", mock.Anything, "cookie_refresh_token").Return(newTokens, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: middleware.RefreshTokenCookie, Value: "cookie_refresh_token"})
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "new_refresh_token", cookiesOf(w)[middleware.RefreshTokenCookie].Value)
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("logout revokes the refresh token cookie and clears the cookies", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		router := setupTestRouter(mockAuthService, sessionCookies())
+		mockAuthService.On("Logout", mock.Anything, "cookie_refresh_token").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/logout", nil)
+		req.AddCookie(&http.Cookie{Name: middleware.RefreshTokenCookie, Value: "cookie_refresh_token"})
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		cookies := cookiesOf(w)
+		require.Len(t, cookies, 3)
+		for _, cookie := range cookies {
+			assert.Negative(t, cookie.MaxAge, cookie.Name)
+		}
+		mockAuthService.AssertExpectations(t)
+	})
+
+	t.Run("no cookies without cookie sessions", func(t *testing.T) {
+		mockAuthService := new(MockAuthService)
+		router := setupTestRouter(mockAuthService)
+		mockAuthService.On("Login", mock.Anything, mock.Anything).Return(createValidTokenPair(), nil)
+
+		requestBody, _ := json.Marshal(dtos.LoginRequestDTO{Email: "test@example.com", Password: "password123"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/auth/login", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Values("Set-Cookie"))
+	})
+}
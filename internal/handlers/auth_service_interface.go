@@ -18,6 +18,7 @@ type AuthService interface {
 	// Register creates a new user account and returns authentication tokens
 	// Returns domain.ErrUserAlreadyExists if user already exists
 	// Returns domain.ErrInvalidUserData if user data validation fails
+	// Returns a *domain.WeakPasswordError, matching domain.ErrWeakPassword, if the password breaks the password policy
 	Register(ctx context.Context, user *domain.User, password string) (*domain.TokenPair, error)
 
 	// RefreshToken generates a new token pair using a valid refresh token
@@ -29,4 +30,10 @@ type AuthService interface {
 	// Logout revokes a user's refresh token
 	// Returns domain.ErrInvalidToken if token is invalid
 	Logout(ctx context.Context, refreshToken string) error
+
+	// DeleteAccount permanently deletes the user's account and everything they own once
+	// password confirms it, revoking all of their refresh tokens
+	// Returns domain.ErrInvalidCredentials if the password is wrong
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	DeleteAccount(ctx context.Context, userID, password string) error
 }
\ No newline at end of file
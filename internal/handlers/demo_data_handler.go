@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// DemoDataHandler handles HTTP requests that seed and remove demo data in the caller's account
+// Routes must be registered behind authentication, and only when demo data is enabled
+type DemoDataHandler struct {
+	demoDataService DemoDataService
+}
+
+// NewDemoDataHandler creates a new demo data handler with dependency injection
+func NewDemoDataHandler(demoDataService DemoDataService) *DemoDataHandler {
+	return &DemoDataHandler{
+		demoDataService: demoDataService,
+	}
+}
+
+// SeedDemoData handles POST /api/v1/account/seed-demo-data requests
+// Fills the caller's empty account with random but realistic finance and health data
+//
+//	@Summary	Seed demo data into my account
+//	@Description	Creates 2-3 incomes, 8-12 expenses tagged "demo", 1-2 loans, a health profile with two conditions,
+//	@Description	an insurance policy and 3-5 medical expenses. Only available when demo data is enabled.
+//	@Description	Refused with 409 if the account already has finance or health data.
+//	@Tags		account
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	201						{object}	dtos.DemoDataBatchResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	409						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/account/seed-demo-data	[post]
+func (h *DemoDataHandler) SeedDemoData(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		h.unauthorized(c)
+		return
+	}
+
+	batch, err := h.demoDataService.Seed(c.Request.Context(), userID)
+	if err != nil {
+		h.handleDemoDataError(c, err)
+		return
+	}
+
+	var response dtos.DemoDataBatchResponseDTO
+	response.FromDomain(batch)
+	c.JSON(http.StatusCreated, response)
+}
+
+// RemoveDemoData handles DELETE /api/v1/account/demo-data requests
+// Deletes exactly the records seeded into the caller's account, keeping everything they added
+//
+//	@Summary	Remove the demo data from my account
+//	@Description	Seeded records already deleted are skipped. The seeded health profile is kept
+//	@Description	while health data the user added themselves still belongs to it.
+//	@Tags		account
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.DemoDataRemovedResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/account/demo-data	[delete]
+func (h *DemoDataHandler) RemoveDemoData(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		h.unauthorized(c)
+		return
+	}
+
+	removed, err := h.demoDataService.Remove(c.Request.Context(), userID)
+	if err != nil {
+		h.handleDemoDataError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.DemoDataRemovedResponseDTO{Removed: removed})
+}
+
+func (h *DemoDataHandler) unauthorized(c *gin.Context) {
+	c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+		http.StatusUnauthorized,
+		"unauthorized",
+		"Authentication required",
+	))
+}
+
+// handleDemoDataError maps service errors to HTTP responses
+func (h *DemoDataHandler) handleDemoDataError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountHasData):
+		c.JSON(http.StatusConflict, dtos.NewErrorResponse(
+			http.StatusConflict,
+			"conflict",
+			"Demo data can only be added to an account without finance or health data",
+		))
+	case errors.Is(err, domain.ErrDemoDataNotFound):
+		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			http.StatusNotFound,
+			"not_found",
+			"There is no demo data to remove",
+		))
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Demo data request failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"Failed to process demo data",
+		))
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockDemoDataService is a mock implementation of DemoDataService for testing
+type MockDemoDataService struct {
+	mock.Mock
+}
+
+func (m *MockDemoDataService) Seed(ctx context.Context, userID string) (domain.DemoDataBatch, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.DemoDataBatch), args.Error(1)
+}
+
+func (m *MockDemoDataService) Remove(ctx context.Context, userID string) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+// setupDemoDataTestRouter authenticates every request as userID; an empty userID leaves the request unauthenticated
+func setupDemoDataTestRouter(demoDataService DemoDataService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewDemoDataHandler(demoDataService)
+	r.POST("/account/seed-demo-data", handler.SeedDemoData)
+	r.DELETE("/account/demo-data", handler.RemoveDemoData)
+	return r
+}
+
+func TestDemoDataHandler_SeedDemoData(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	batch := domain.DemoDataBatch{
+		ID:     "demo-1",
+		UserID: "user-1",
+		Records: []domain.DemoDataRecord{
+			{Resource: domain.AuditResourceIncome, RecordID: "income-1"},
+			{Resource: domain.AuditResourceIncome, RecordID: "income-2"},
+			{Resource: domain.AuditResourceHealthProfile, RecordID: "1"},
+		},
+		CreatedAt: createdAt,
+	}
+	service := new(MockDemoDataService)
+	service.On("Seed", mock.Anything, "user-1").Return(batch, nil)
+	router := setupDemoDataTestRouter(service, "user-1")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/account/seed-demo-data", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var response dtos.DemoDataBatchResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "demo-1", response.BatchID)
+	assert.Equal(t, map[string]int{"income": 2, "health_profile": 1}, response.Counts)
+	assert.Equal(t, 3, response.Total)
+	assert.True(t, createdAt.Equal(response.CreatedAt))
+}
+
+func TestDemoDataHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		userID         string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"unauthenticated seed", http.MethodPost, "/account/seed-demo-data", "", nil, http.StatusUnauthorized},
+		{"account has data", http.MethodPost, "/account/seed-demo-data", "user-1", domain.ErrAccountHasData, http.StatusConflict},
+		{"seeding fails", http.MethodPost, "/account/seed-demo-data", "user-1", errors.New("database unavailable"), http.StatusInternalServerError},
+		{"unauthenticated remove", http.MethodDelete, "/account/demo-data", "", nil, http.StatusUnauthorized},
+		{"nothing to remove", http.MethodDelete, "/account/demo-data", "user-1", domain.ErrDemoDataNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := new(MockDemoDataService)
+			service.On("Seed", mock.Anything, tt.userID).Return(domain.DemoDataBatch{}, tt.serviceErr)
+			service.On("Remove", mock.Anything, tt.userID).Return(0, tt.serviceErr)
+			router := setupDemoDataTestRouter(service, tt.userID)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.userID == "" {
+				service.AssertNotCalled(t, "Seed", mock.Anything, mock.Anything)
+				service.AssertNotCalled(t, "Remove", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestDemoDataHandler_RemoveDemoData(t *testing.T) {
+	service := new(MockDemoDataService)
+	service.On("Remove", mock.Anything, "user-1").Return(17, nil)
+	router := setupDemoDataTestRouter(service, "user-1")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/account/demo-data", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dtos.DemoDataRemovedResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 17, response.Removed)
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// DemoDataService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by DemoDataHandler in this package
+type DemoDataService interface {
+	// Seed fills the user's empty account with demo finance and health data and returns what it created
+	// Returns domain.ErrAccountHasData if the user already has finance or health data
+	Seed(ctx context.Context, userID string) (domain.DemoDataBatch, error)
+
+	// Remove deletes the demo data seeded for the user and returns how many records were deleted
+	// Records the user has since deleted are skipped
+	// Returns domain.ErrDemoDataNotFound if the user has no demo data
+	Remove(ctx context.Context, userID string) (int, error)
+}
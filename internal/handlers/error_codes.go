@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// domainErrorMapping pairs a domain error with the status and code it is reported as
+type domainErrorMapping struct {
+	err    error
+	status int
+	code   dtos.ErrorCode
+}
+
+// domainErrorMappings is checked in order, so wrapped errors match their most specific entry first
+var domainErrorMappings = []domainErrorMapping{
+	// Request lifetime: a timed out or abandoned request isn't a server error
+	{context.DeadlineExceeded, http.StatusServiceUnavailable, dtos.ErrorCodeTimeout},
+	{context.Canceled, dtos.StatusClientClosedRequest, dtos.ErrorCodeRequestCanceled},
+
+	// Authentication
+	{domain.ErrInvalidCredentials, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidCredentials},
+	// A missing user is reported as invalid credentials so emails can't be enumerated
+	{domain.ErrUserNotFound, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidCredentials},
+	{domain.ErrAccountInactive, http.StatusUnauthorized, dtos.ErrorCodeAuthAccountInactive},
+	{domain.ErrInvalidToken, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidToken},
+	{domain.ErrTokenExpired, http.StatusUnauthorized, dtos.ErrorCodeAuthTokenExpired},
+	{domain.ErrTokenRevoked, http.StatusUnauthorized, dtos.ErrorCodeAuthTokenRevoked},
+	// A refresh token that was never issued, or was purged with its account, is just invalid
+	{domain.ErrTokenNotFound, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidToken},
+	{domain.ErrUserAlreadyExists, http.StatusConflict, dtos.ErrorCodeAuthUserExists},
+	{domain.ErrInvalidUserData, http.StatusBadRequest, dtos.ErrorCodeAuthInvalidUserData},
+	{domain.ErrWeakPassword, http.StatusBadRequest, dtos.ErrorCodeValidationFailed},
+	{domain.ErrInvalidAPIKey, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidAPIKey},
+	{domain.ErrAPIKeyRevoked, http.StatusUnauthorized, dtos.ErrorCodeAuthAPIKeyRevoked},
+	{domain.ErrAPIKeyNotFound, http.StatusNotFound, dtos.ErrorCodeAuthAPIKeyNotFound},
+	{domain.ErrInvalidAPIKeyData, http.StatusBadRequest, dtos.ErrorCodeAuthInvalidAPIKeyData},
+
+	// Finance
+	{domain.ErrIncomeNotFound, http.StatusNotFound, dtos.ErrorCodeFinIncomeNotFound},
+	{domain.ErrExpenseNotFound, http.StatusNotFound, dtos.ErrorCodeFinExpenseNotFound},
+	{domain.ErrLoanNotFound, http.StatusNotFound, dtos.ErrorCodeFinLoanNotFound},
+	{domain.ErrSavingsGoalNotFound, http.StatusNotFound, dtos.ErrorCodeFinSavingsGoalNotFound},
+	{domain.ErrFinanceSummaryNotFound, http.StatusNotFound, dtos.ErrorCodeFinSummaryNotFound},
+	{domain.ErrIncomeNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinIncomeNotOwned},
+	{domain.ErrExpenseNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinExpenseNotOwned},
+	{domain.ErrLoanNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinLoanNotOwned},
+	{domain.ErrSavingsGoalNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinSavingsGoalNotOwned},
+	{domain.ErrUnauthorizedAccess, http.StatusForbidden, dtos.ErrorCodeFinAccessDenied},
+	{domain.ErrInvalidIncomeData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidIncome},
+	{domain.ErrInvalidExpenseData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpense},
+	{domain.ErrInvalidExpenseTags, http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpense},
+	{domain.ErrInvalidLoanData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidLoan},
+	{domain.ErrInvalidSavingsGoalData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidSavingsGoal},
+	{domain.ErrInvalidExpenseFilter, http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpenseFilter},
+	{domain.ErrInvalidCursor, http.StatusBadRequest, dtos.ErrorCodeFinInvalidCursor},
+	{domain.ErrUnsupportedCurrency, http.StatusBadRequest, dtos.ErrorCodeFinUnsupportedCurrency},
+	{domain.ErrDuplicateRecord, http.StatusConflict, dtos.ErrorCodeFinDuplicateRecord},
+	{domain.ErrInstallmentsComplete, http.StatusConflict, dtos.ErrorCodeFinInstallmentsComplete},
+	{domain.ErrPaymentBelowInterest, http.StatusUnprocessableEntity, dtos.ErrorCodeFinPaymentBelowInterest},
+	{domain.ErrInvalidPurchaseData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidPurchase},
+	{domain.ErrNoIncome, http.StatusBadRequest, dtos.ErrorCodeFinNoIncome},
+	{domain.ErrBudgetNotFound, http.StatusNotFound, dtos.ErrorCodeFinBudgetNotFound},
+	{domain.ErrBudgetNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinBudgetNotOwned},
+	{domain.ErrBudgetExists, http.StatusConflict, dtos.ErrorCodeFinBudgetExists},
+	{domain.ErrInvalidBudgetData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidBudget},
+	{domain.ErrInvalidFinanceData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidData},
+
+	// Health
+	{domain.ErrProfileAlreadyExists, http.StatusConflict, dtos.ErrorCodeHealthProfileExists},
+	{domain.ErrPolicyNumberExists, http.StatusConflict, dtos.ErrorCodeHealthPolicyExists},
+	{domain.ErrMedicalConditionNotFound, http.StatusNotFound, dtos.ErrorCodeHealthConditionNotFound},
+	{domain.ErrInsurancePolicyNotFound, http.StatusNotFound, dtos.ErrorCodeHealthPolicyNotFound},
+	{domain.ErrMedicalExpenseNotFound, http.StatusNotFound, dtos.ErrorCodeHealthExpenseNotFound},
+	{domain.ErrExpenseNotRecurring, http.StatusUnprocessableEntity, dtos.ErrorCodeHealthExpenseNotRecurring},
+	{domain.ErrAttachmentNotFound, http.StatusNotFound, dtos.ErrorCodeHealthAttachmentNotFound},
+	{domain.ErrAttachmentTooLarge, http.StatusRequestEntityTooLarge, dtos.ErrorCodeHealthAttachmentTooLarge},
+	{domain.ErrUnsupportedAttachmentType, http.StatusUnsupportedMediaType, dtos.ErrorCodeHealthUnsupportedMediaType},
+	{domain.ErrConditionCatalogEntryNotFound, http.StatusBadRequest, dtos.ErrorCodeHealthCatalogEntryNotFound},
+
+	// Reports
+	{domain.ErrInvalidReportPeriod, http.StatusBadRequest, dtos.ErrorCodeReportInvalidPeriod},
+}
+
+// healthErrorMapping pairs fragments of a health service error message with the status and code it is reported as
+type healthErrorMapping struct {
+	fragments []string
+	status    int
+	code      dtos.ErrorCode
+}
+
+// healthErrorMappings classifies health service errors, which are still plain messages rather
+// than domain errors. A mapping matches when the message contains all of its fragments;
+// entries are checked in order, so more specific mappings come first.
+var healthErrorMappings = []healthErrorMapping{
+	{[]string{"not authorized"}, http.StatusForbidden, dtos.ErrorCodeHealthAccessDenied},
+	{[]string{"already has a health profile"}, http.StatusConflict, dtos.ErrorCodeHealthProfileExists},
+	{[]string{"already exists"}, http.StatusConflict, dtos.ErrorCodeHealthPolicyExists},
+	{[]string{"before adding dependents"}, http.StatusConflict, dtos.ErrorCodeHealthProfileRequired},
+	{[]string{"at least one policy"}, http.StatusUnprocessableEntity, dtos.ErrorCodeHealthNoPolicies},
+	{[]string{"validation failed"}, http.StatusBadRequest, dtos.ErrorCodeHealthInvalidData},
+	{[]string{"family member", "not found"}, http.StatusNotFound, dtos.ErrorCodeHealthFamilyMemberNotFound},
+	{[]string{"condition", "not found"}, http.StatusNotFound, dtos.ErrorCodeHealthConditionNotFound},
+	{[]string{"policy", "not found"}, http.StatusNotFound, dtos.ErrorCodeHealthPolicyNotFound},
+	{[]string{"not found"}, http.StatusNotFound, dtos.ErrorCodeHealthProfileNotFound},
+}
+
+// mapDomainError returns the HTTP status and error code for a domain error returned by a service.
+// Errors that aren't recognised map to 500 and dtos.ErrorCodeInternal.
+func mapDomainError(err error) (int, dtos.ErrorCode) {
+	for _, mapping := range domainErrorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping.status, mapping.code
+		}
+	}
+
+	return http.StatusInternalServerError, dtos.ErrorCodeInternal
+}
+
+// mapHealthError returns the HTTP status and error code for an error returned by the health service,
+// falling back to classifying its message when it isn't a domain error
+func mapHealthError(err error) (int, dtos.ErrorCode) {
+	if status, code := mapDomainError(err); code != dtos.ErrorCodeInternal {
+		return status, code
+	}
+
+	message := err.Error()
+	for _, mapping := range healthErrorMappings {
+		if containsAll(message, mapping.fragments) {
+			return mapping.status, mapping.code
+		}
+	}
+
+	return http.StatusInternalServerError, dtos.ErrorCodeInternal
+}
+
+// contextErrorMessage returns the user-facing message for a request that timed out or was
+// cancelled, and whether err is one
+func contextErrorMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "The request took too long. Please try again later", true
+	case errors.Is(err, context.Canceled):
+		return "The request was cancelled", true
+	default:
+		return "", false
+	}
+}
+
+func containsAll(s string, fragments []string) bool {
+	for _, fragment := range fragments {
+		if !strings.Contains(s, fragment) {
+			return false
+		}
+	}
+	return true
+}
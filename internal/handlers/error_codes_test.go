@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapDomainError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   dtos.ErrorCode
+	}{
+		{"invalid_credentials", domain.ErrInvalidCredentials, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidCredentials},
+		{"user_not_found_hidden_as_invalid_credentials", domain.ErrUserNotFound, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidCredentials},
+		{"token_expired", domain.ErrTokenExpired, http.StatusUnauthorized, dtos.ErrorCodeAuthTokenExpired},
+		{"api_key_revoked", domain.ErrAPIKeyRevoked, http.StatusUnauthorized, dtos.ErrorCodeAuthAPIKeyRevoked},
+		{"invalid_api_key_data", fmt.Errorf("%w: name is required", domain.ErrInvalidAPIKeyData), http.StatusBadRequest, dtos.ErrorCodeAuthInvalidAPIKeyData},
+		{"income_not_owned", domain.ErrIncomeNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinIncomeNotOwned},
+		{"loan_not_owned", domain.ErrLoanNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinLoanNotOwned},
+		{"wrapped_not_found", fmt.Errorf("failed to verify income ownership: %w", domain.ErrIncomeNotFound), http.StatusNotFound, dtos.ErrorCodeFinIncomeNotFound},
+		{"invalid_expense_data", domain.ErrInvalidExpenseData, http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpense},
+		{"invalid_expense_tags", fmt.Errorf("%w: at most 10 tags are allowed", domain.ErrInvalidExpenseTags), http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpense},
+		{"invalid_savings_goal", fmt.Errorf("%w: name is required", domain.ErrInvalidSavingsGoalData), http.StatusBadRequest, dtos.ErrorCodeFinInvalidSavingsGoal},
+		{"invalid_cursor", fmt.Errorf("%w: not a valid token", domain.ErrInvalidCursor), http.StatusBadRequest, dtos.ErrorCodeFinInvalidCursor},
+		{"unsupported_currency", fmt.Errorf("no exchange rate for JPY: %w", domain.ErrUnsupportedCurrency), http.StatusBadRequest, dtos.ErrorCodeFinUnsupportedCurrency},
+		{"duplicate_record", &domain.DuplicateRecordError{Resource: domain.AuditResourceExpense, ExistingID: "expense-1"}, http.StatusConflict, dtos.ErrorCodeFinDuplicateRecord},
+		{"installments_complete", domain.ErrInstallmentsComplete, http.StatusConflict, dtos.ErrorCodeFinInstallmentsComplete},
+		{"payment_below_interest", fmt.Errorf("%w: 10.00 a month", domain.ErrPaymentBelowInterest), http.StatusUnprocessableEntity, dtos.ErrorCodeFinPaymentBelowInterest},
+		{"no_income", domain.ErrNoIncome, http.StatusBadRequest, dtos.ErrorCodeFinNoIncome},
+		{"invalid_purchase", fmt.Errorf("%w: months must be between 1 and 600", domain.ErrInvalidPurchaseData), http.StatusBadRequest, dtos.ErrorCodeFinInvalidPurchase},
+		{"invalid_report_period", fmt.Errorf("%w: month must be between 1 and 12", domain.ErrInvalidReportPeriod), http.StatusBadRequest, dtos.ErrorCodeReportInvalidPeriod},
+		{"deadline_exceeded", fmt.Errorf("failed to get incomes: %w", context.DeadlineExceeded), http.StatusServiceUnavailable, dtos.ErrorCodeTimeout},
+		{"canceled", fmt.Errorf("failed to get incomes: %w", context.Canceled), dtos.StatusClientClosedRequest, dtos.ErrorCodeRequestCanceled},
+		{"unknown_error", errors.New("database connection failed"), http.StatusInternalServerError, dtos.ErrorCodeInternal},
+		// Only domain errors are mapped; messages are never classified here
+		{"plain_not_found_message", errors.New("record not found"), http.StatusInternalServerError, dtos.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := mapDomainError(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedCode, code)
+		})
+	}
+}
+
+func TestMapHealthError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   dtos.ErrorCode
+	}{
+		{"not_authorized", errors.New("not authorized to update this condition"), http.StatusForbidden, dtos.ErrorCodeHealthAccessDenied},
+		{"profile_exists", errors.New("user already has a health profile"), http.StatusConflict, dtos.ErrorCodeHealthProfileExists},
+		{"profile_exists_race", fmt.Errorf("health profile for user u1: %w", domain.ErrProfileAlreadyExists), http.StatusConflict, dtos.ErrorCodeHealthProfileExists},
+		{"policy_number_exists", fmt.Errorf("insurance policy POL-1: %w", domain.ErrPolicyNumberExists), http.StatusConflict, dtos.ErrorCodeHealthPolicyExists},
+		{"validation_failed", errors.New("condition validation failed: name is required"), http.StatusBadRequest, dtos.ErrorCodeHealthInvalidData},
+		{"family_member_not_found", errors.New("family member 7 not found"), http.StatusNotFound, dtos.ErrorCodeHealthFamilyMemberNotFound},
+		{"condition_not_found", errors.New("failed to get condition: medical condition with ID 7 not found"), http.StatusNotFound, dtos.ErrorCodeHealthConditionNotFound},
+		{"policy_not_found", errors.New("insurance policy with ID 3 not found"), http.StatusNotFound, dtos.ErrorCodeHealthPolicyNotFound},
+		{"profile_not_found", errors.New("failed to get user profile: health profile not found for user u1"), http.StatusNotFound, dtos.ErrorCodeHealthProfileNotFound},
+		{"no_policies", errors.New("at least one policy is required"), http.StatusUnprocessableEntity, dtos.ErrorCodeHealthNoPolicies},
+		{"expense_not_found", fmt.Errorf("medical expense with ID 7: %w", domain.ErrMedicalExpenseNotFound), http.StatusNotFound, dtos.ErrorCodeHealthExpenseNotFound},
+		{"expense_not_recurring", domain.ErrExpenseNotRecurring, http.StatusUnprocessableEntity, dtos.ErrorCodeHealthExpenseNotRecurring},
+		{"attachment_not_found", domain.ErrAttachmentNotFound, http.StatusNotFound, dtos.ErrorCodeHealthAttachmentNotFound},
+		{"attachment_too_large", fmt.Errorf("%w: limit is 10 bytes", domain.ErrAttachmentTooLarge), http.StatusRequestEntityTooLarge, dtos.ErrorCodeHealthAttachmentTooLarge},
+		{"unsupported_attachment_type", fmt.Errorf("%w: text/plain", domain.ErrUnsupportedAttachmentType), http.StatusUnsupportedMediaType, dtos.ErrorCodeHealthUnsupportedMediaType},
+		{"catalog_entry_not_found", fmt.Errorf("%w: Z99", domain.ErrConditionCatalogEntryNotFound), http.StatusBadRequest, dtos.ErrorCodeHealthCatalogEntryNotFound},
+		// A timed out lookup isn't reported as a missing record
+		{"deadline_exceeded", fmt.Errorf("health profile not found: %w", context.DeadlineExceeded), http.StatusServiceUnavailable, dtos.ErrorCodeTimeout},
+		{"unknown_error", errors.New("database connection failed"), http.StatusInternalServerError, dtos.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := mapHealthError(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedCode, code)
+		})
+	}
+}
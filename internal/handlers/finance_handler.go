@@ -1,21 +1,38 @@
 package handlers
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 	"github.com/DuckDHD/BuyOrBye/internal/middleware"
 )
 
 // FinanceService interface is consumed by this handler and defined in this package
 // Following the consumer-defined interface principle from CLAUDE.md
 
+// Bounds for the months query parameter of the savings goal history
+const (
+	defaultGoalHistoryMonths = 12
+	maxGoalHistoryMonths     = 24
+)
+
+// Bounds for the limit query parameter of cursor-paginated lists
+const (
+	defaultFinancePageSize = 20
+	maxFinancePageSize     = 100
+)
+
 // FinanceHandler handles HTTP requests for finance endpoints
 type FinanceHandler struct {
 	financeService FinanceService
@@ -26,7 +43,7 @@ type FinanceHandler struct {
 func NewFinanceHandler(financeService FinanceService) *FinanceHandler {
 	return &FinanceHandler{
 		financeService: financeService,
-		validator:      validator.New(),
+		validator:      dtos.NewValidator(),
 	}
 }
 
@@ -34,6 +51,22 @@ func NewFinanceHandler(financeService FinanceService) *FinanceHandler {
 
 // AddIncome handles POST /api/finance/income requests
 // Adds a new income source for the authenticated user
+//
+//	@Summary	Add an income source
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string				false	"Makes retries safe; see Idempotent Retries"
+//	@Param		force			query		bool				false	"Add the income even if it duplicates a recent one"
+//	@Param		request			body		dtos.AddIncomeDTO	true	"Income"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	409				{object}	dtos.DuplicateRecordResponseDTO
+//	@Failure	422				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/income	[post]
 func (h *FinanceHandler) AddIncome(c *gin.Context) {
 	var request dtos.AddIncomeDTO
 
@@ -49,11 +82,7 @@ func (h *FinanceHandler) AddIncome(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -68,11 +97,16 @@ func (h *FinanceHandler) AddIncome(c *gin.Context) {
 		return
 	}
 
+	force, ok := h.forceFlag(c, request.Force)
+	if !ok {
+		return
+	}
+
 	// Convert DTO to domain struct
 	income := request.ToDomain(userID)
 
 	// Call service layer
-	if err := h.financeService.AddIncome(c.Request.Context(), income); err != nil {
+	if err := h.financeService.AddIncome(c.Request.Context(), income, force); err != nil {
 		h.handleFinanceError(c, err)
 		return
 	}
@@ -83,7 +117,20 @@ func (h *FinanceHandler) AddIncome(c *gin.Context) {
 }
 
 // GetIncomes handles GET /api/finance/income requests
-// Retrieves all income records for the authenticated user
+// Retrieves all income records for the authenticated user, or one page of them, oldest first,
+// when the cursor or limit query parameter is given
+//
+//	@Summary	List incomes
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		cursor			query		string	false	"Cursor from a previous page; with cursor or limit the response is a dtos.IncomePageResponseDTO"
+//	@Param		limit			query		int		false	"Page size, 1-100 (default 20)"
+//	@Success	200				{array}		dtos.IncomeResponseDTO
+//	@Failure	400				{object}	dtos.ErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/income	[get]
 func (h *FinanceHandler) GetIncomes(c *gin.Context) {
 	// Extract user ID from authentication context
 	userID := middleware.GetUserID(c)
@@ -96,6 +143,30 @@ func (h *FinanceHandler) GetIncomes(c *gin.Context) {
 		return
 	}
 
+	cursor, limit, paginated, ok := h.pageQuery(c)
+	if !ok {
+		return
+	}
+	if paginated {
+		incomes, next, err := h.financeService.GetUserIncomesPage(c.Request.Context(), userID, cursor, limit)
+		if err != nil {
+			h.handleFinanceError(c, err)
+			return
+		}
+
+		response := dtos.IncomePageResponseDTO{
+			Incomes:    make([]dtos.IncomeResponseDTO, len(incomes)),
+			NextCursor: next,
+			HasMore:    next != "",
+			Limit:      limit,
+		}
+		for i, income := range incomes {
+			response.Incomes[i].FromDomain(income)
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
 	// Call service layer
 	incomes, err := h.financeService.GetUserIncomes(c.Request.Context(), userID)
 	if err != nil {
@@ -116,6 +187,21 @@ func (h *FinanceHandler) GetIncomes(c *gin.Context) {
 
 // UpdateIncome handles PUT /api/finance/income/:id requests
 // Updates an existing income record for the authenticated user
+//
+//	@Summary	Update an income
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string					true	"Income ID"
+//	@Param		request					body		dtos.UpdateIncomeDTO	true	"Fields to change"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/income/{id}	[put]
 func (h *FinanceHandler) UpdateIncome(c *gin.Context) {
 	var request dtos.UpdateIncomeDTO
 	incomeID := c.Param("id")
@@ -132,11 +218,7 @@ func (h *FinanceHandler) UpdateIncome(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -168,9 +250,9 @@ func (h *FinanceHandler) UpdateIncome(c *gin.Context) {
 	}
 
 	if income == nil {
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+		c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 			http.StatusNotFound,
-			"not_found",
+			dtos.ErrorCodeFinIncomeNotFound,
 			"Income not found or access denied",
 		))
 		return
@@ -182,17 +264,17 @@ func (h *FinanceHandler) UpdateIncome(c *gin.Context) {
 	// Call service layer
 	if err := h.financeService.UpdateIncome(c.Request.Context(), *income); err != nil {
 		if strings.Contains(err.Error(), "does not belong to user") {
-			c.JSON(http.StatusForbidden, dtos.NewErrorResponse(
+			c.JSON(http.StatusForbidden, dtos.NewCodedErrorResponse(
 				http.StatusForbidden,
-				"forbidden",
+				dtos.ErrorCodeFinIncomeNotOwned,
 				"Access denied: You can only update your own income records",
 			))
 			return
 		}
 		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 				http.StatusNotFound,
-				"not_found",
+				dtos.ErrorCodeFinIncomeNotFound,
 				"Income record not found",
 			))
 			return
@@ -208,6 +290,18 @@ func (h *FinanceHandler) UpdateIncome(c *gin.Context) {
 
 // DeleteIncome handles DELETE /api/finance/income/:id requests
 // Soft deletes an income record for the authenticated user
+//
+//	@Summary	Delete an income
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string	true	"Income ID"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/income/{id}	[delete]
 func (h *FinanceHandler) DeleteIncome(c *gin.Context) {
 	incomeID := c.Param("id")
 
@@ -225,9 +319,9 @@ func (h *FinanceHandler) DeleteIncome(c *gin.Context) {
 	// Call service layer
 	if err := h.financeService.DeleteIncome(c.Request.Context(), userID, incomeID); err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 				http.StatusNotFound,
-				"not_found",
+				dtos.ErrorCodeFinIncomeNotFound,
 				"Income record not found",
 			))
 			return
@@ -241,10 +335,65 @@ func (h *FinanceHandler) DeleteIncome(c *gin.Context) {
 	})
 }
 
+// RestoreIncome handles POST /api/finance/income/:id/restore requests
+// Restores a soft-deleted income record for the authenticated user
+//
+//	@Summary	Restore a deleted income
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id								path		string	true	"Income ID"
+//	@Success	200								{object}	dtos.MessageResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	404								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/income/{id}/restore	[post]
+func (h *FinanceHandler) RestoreIncome(c *gin.Context) {
+	incomeID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.RestoreIncome(c.Request.Context(), userID, incomeID); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Income restored successfully",
+	})
+}
+
 // ==================== EXPENSE ENDPOINTS ====================
 
 // AddExpense handles POST /api/finance/expense requests
 // Adds a new expense for the authenticated user
+//
+//	@Summary	Add an expense
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key		header		string				false	"Makes retries safe; see Idempotent Retries"
+//	@Param		force				query		bool				false	"Add the expense even if it duplicates a recent one"
+//	@Param		request				body		dtos.AddExpenseDTO	true	"Expense"
+//	@Success	201					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	409					{object}	dtos.DuplicateRecordResponseDTO
+//	@Failure	422					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expense	[post]
 func (h *FinanceHandler) AddExpense(c *gin.Context) {
 	var request dtos.AddExpenseDTO
 
@@ -271,11 +420,12 @@ func (h *FinanceHandler) AddExpense(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	force, ok := h.forceFlag(c, request.Force)
+	if !ok {
 		return
 	}
 
@@ -283,7 +433,7 @@ func (h *FinanceHandler) AddExpense(c *gin.Context) {
 	expense := request.ToDomain(userID)
 
 	// Call service layer
-	if err := h.financeService.AddExpense(c.Request.Context(), expense); err != nil {
+	if err := h.financeService.AddExpense(c.Request.Context(), expense, force); err != nil {
 		h.handleFinanceError(c, err)
 		return
 	}
@@ -294,7 +444,28 @@ func (h *FinanceHandler) AddExpense(c *gin.Context) {
 }
 
 // GetExpenses handles GET /api/finance/expenses requests
-// Retrieves expenses for the authenticated user, optionally filtered by category
+// Retrieves expenses for the authenticated user, optionally filtered by name search,
+// category, tag, amount range, fixed/variable, priority, frequency and creation date
+//
+//	@Summary	List and search expenses
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		q					query		string	false	"Case-insensitive name substring"
+//	@Param		category			query		string	false	"Only return expenses in this category"
+//	@Param		min_amount			query		number	false	"Minimum amount, inclusive"
+//	@Param		max_amount			query		number	false	"Maximum amount, inclusive"
+//	@Param		is_fixed			query		bool	false	"Fixed (true) or variable (false) expenses"
+//	@Param		priority			query		int		false	"Priority, 1-3"
+//	@Param		frequency			query		string	false	"monthly, weekly or daily"
+//	@Param		tag					query		string	false	"Only return expenses carrying this tag, matched case-insensitively"
+//	@Param		created_from		query		string	false	"Created on or after this date (YYYY-MM-DD)"
+//	@Param		created_to			query		string	false	"Created on or before this date (YYYY-MM-DD)"
+//	@Success	200					{array}		dtos.ExpenseResponseDTO
+//	@Failure	400					{object}	dtos.ErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expenses	[get]
 func (h *FinanceHandler) GetExpenses(c *gin.Context) {
 	// Extract user ID from authentication context
 	userID := middleware.GetUserID(c)
@@ -307,17 +478,25 @@ func (h *FinanceHandler) GetExpenses(c *gin.Context) {
 		return
 	}
 
-	// Check for category filter
-	category := c.Query("category")
+	var query dtos.ExpenseFilterDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid query parameters: amounts must be numbers, priority an integer, is_fixed a boolean and dates YYYY-MM-DD",
+		))
+		return
+	}
+	filter := query.ToDomain()
 
 	var expenses []domain.Expense
 	var err error
 
-	// Call appropriate service method based on filter
-	if category != "" {
-		expenses, err = h.financeService.GetUserExpensesByCategory(c.Request.Context(), userID, category)
-	} else {
+	// Unfiltered listing keeps the plain query; anything else is a search
+	if filter.IsEmpty() {
 		expenses, err = h.financeService.GetUserExpenses(c.Request.Context(), userID)
+	} else {
+		expenses, err = h.financeService.SearchUserExpenses(c.Request.Context(), userID, filter)
 	}
 
 	if err != nil {
@@ -338,6 +517,21 @@ func (h *FinanceHandler) GetExpenses(c *gin.Context) {
 
 // UpdateExpense handles PUT /api/finance/expense/:id requests
 // Updates an existing expense record for the authenticated user
+//
+//	@Summary	Update an expense
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string					true	"Expense ID"
+//	@Param		request					body		dtos.UpdateExpenseDTO	true	"Fields to change"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expense/{id}	[put]
 func (h *FinanceHandler) UpdateExpense(c *gin.Context) {
 	var request dtos.UpdateExpenseDTO
 	expenseID := c.Param("id")
@@ -354,11 +548,7 @@ func (h *FinanceHandler) UpdateExpense(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -390,9 +580,9 @@ func (h *FinanceHandler) UpdateExpense(c *gin.Context) {
 	}
 
 	if expense == nil {
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+		c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 			http.StatusNotFound,
-			"not_found",
+			dtos.ErrorCodeFinExpenseNotFound,
 			"Expense not found or access denied",
 		))
 		return
@@ -404,9 +594,9 @@ func (h *FinanceHandler) UpdateExpense(c *gin.Context) {
 	// Call service layer
 	if err := h.financeService.UpdateExpense(c.Request.Context(), *expense); err != nil {
 		if strings.Contains(err.Error(), "does not belong to user") {
-			c.JSON(http.StatusForbidden, dtos.NewErrorResponse(
+			c.JSON(http.StatusForbidden, dtos.NewCodedErrorResponse(
 				http.StatusForbidden,
-				"forbidden",
+				dtos.ErrorCodeFinExpenseNotOwned,
 				"Access denied: You can only update your own expense records",
 			))
 			return
@@ -422,6 +612,18 @@ func (h *FinanceHandler) UpdateExpense(c *gin.Context) {
 
 // DeleteExpense handles DELETE /api/finance/expense/:id requests
 // Soft deletes an expense record for the authenticated user
+//
+//	@Summary	Delete an expense
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string	true	"Expense ID"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expense/{id}	[delete]
 func (h *FinanceHandler) DeleteExpense(c *gin.Context) {
 	expenseID := c.Param("id")
 
@@ -439,9 +641,9 @@ func (h *FinanceHandler) DeleteExpense(c *gin.Context) {
 	// Call service layer
 	if err := h.financeService.DeleteExpense(c.Request.Context(), userID, expenseID); err != nil {
 		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 				http.StatusNotFound,
-				"not_found",
+				dtos.ErrorCodeFinExpenseNotFound,
 				"Expense record not found",
 			))
 			return
@@ -455,10 +657,166 @@ func (h *FinanceHandler) DeleteExpense(c *gin.Context) {
 	})
 }
 
+// BulkDeleteExpenses handles DELETE /api/finance/expenses requests
+// Soft deletes every listed expense, or none of them if any doesn't exist or belongs to another user
+//
+//	@Summary	Delete several expenses
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request				body		dtos.BulkDeleteExpensesDTO	true	"IDs of the expenses to delete, at most 100"
+//	@Success	200					{object}	dtos.BulkDeleteExpensesResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expenses	[delete]
+func (h *FinanceHandler) BulkDeleteExpenses(c *gin.Context) {
+	var request dtos.BulkDeleteExpensesDTO
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Call service layer
+	deleted, err := h.financeService.BulkDeleteExpenses(c.Request.Context(), userID, request.IDs)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.BulkDeleteExpensesResponseDTO{
+		Message: "Expenses deleted successfully",
+		Deleted: deleted,
+	})
+}
+
+// RestoreExpense handles POST /api/finance/expense/:id/restore requests
+// Restores a soft-deleted expense record for the authenticated user
+//
+//	@Summary	Restore a deleted expense
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id								path		string	true	"Expense ID"
+//	@Success	200								{object}	dtos.MessageResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	404								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expense/{id}/restore	[post]
+func (h *FinanceHandler) RestoreExpense(c *gin.Context) {
+	expenseID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.RestoreExpense(c.Request.Context(), userID, expenseID); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expense restored successfully",
+	})
+}
+
+// RecordInstallmentPaid handles POST /api/finance/expense/:id/installment-paid requests
+// Records the next installment of an installment expense as paid; paying the last one
+// deactivates the expense
+//
+//	@Summary	Record an installment payment
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id										path		string	true	"Expense ID"
+//	@Success	200										{object}	dtos.ExpenseResponseDTO
+//	@Failure	400										{object}	dtos.ErrorResponseDTO
+//	@Failure	401										{object}	dtos.ErrorResponseDTO
+//	@Failure	403										{object}	dtos.ErrorResponseDTO
+//	@Failure	404										{object}	dtos.ErrorResponseDTO
+//	@Failure	409										{object}	dtos.ErrorResponseDTO
+//	@Failure	500										{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/expense/{id}/installment-paid	[post]
+func (h *FinanceHandler) RecordInstallmentPaid(c *gin.Context) {
+	expenseID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	expense, err := h.financeService.RecordInstallmentPaid(c.Request.Context(), userID, expenseID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.ExpenseResponseDTO
+	response.FromDomain(expense)
+	c.JSON(http.StatusOK, response)
+}
+
 // ==================== LOAN ENDPOINTS ====================
 
 // AddLoan handles POST /api/finance/loan requests
 // Adds a new loan for the authenticated user
+//
+//	@Summary	Add a loan
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string			false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request			body		dtos.AddLoanDTO	true	"Loan"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	409				{object}	dtos.ErrorResponseDTO
+//	@Failure	422				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan	[post]
 func (h *FinanceHandler) AddLoan(c *gin.Context) {
 	var request dtos.AddLoanDTO
 
@@ -474,11 +832,7 @@ func (h *FinanceHandler) AddLoan(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -508,7 +862,23 @@ func (h *FinanceHandler) AddLoan(c *gin.Context) {
 }
 
 // GetLoans handles GET /api/finance/loans requests
-// Retrieves all loan records for the authenticated user
+// Retrieves all loan records for the authenticated user, or one page of them, oldest first,
+// when the cursor or limit query parameter is given. With min_rate or max_rate only the loans
+// within that interest rate range are returned, highest rate first.
+//
+//	@Summary	List loans
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		cursor			query		string	false	"Cursor from a previous page; with cursor or limit the response is a dtos.LoanPageResponseDTO"
+//	@Param		limit			query		int		false	"Page size, 1-100 (default 20)"
+//	@Param		min_rate		query		number	false	"Minimum interest rate in percent, inclusive (default 0); can't be combined with cursor or limit"
+//	@Param		max_rate		query		number	false	"Maximum interest rate in percent, inclusive (default 100); can't be combined with cursor or limit"
+//	@Success	200				{array}		dtos.LoanResponseDTO
+//	@Failure	400				{object}	dtos.ErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loans	[get]
 func (h *FinanceHandler) GetLoans(c *gin.Context) {
 	// Extract user ID from authentication context
 	userID := middleware.GetUserID(c)
@@ -521,6 +891,57 @@ func (h *FinanceHandler) GetLoans(c *gin.Context) {
 		return
 	}
 
+	cursor, limit, paginated, ok := h.pageQuery(c)
+	if !ok {
+		return
+	}
+	minRate, maxRate, rateFiltered, ok := h.loanRateQuery(c)
+	if !ok {
+		return
+	}
+	if rateFiltered {
+		if paginated {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"min_rate and max_rate can't be combined with cursor or limit",
+			))
+			return
+		}
+
+		loans, err := h.financeService.GetLoansByRateRange(c.Request.Context(), userID, minRate, maxRate)
+		if err != nil {
+			h.handleFinanceError(c, err)
+			return
+		}
+
+		response := make([]dtos.LoanResponseDTO, len(loans))
+		for i, loan := range loans {
+			response[i].FromDomain(loan)
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	if paginated {
+		loans, next, err := h.financeService.GetUserLoansPage(c.Request.Context(), userID, cursor, limit)
+		if err != nil {
+			h.handleFinanceError(c, err)
+			return
+		}
+
+		response := dtos.LoanPageResponseDTO{
+			Loans:      make([]dtos.LoanResponseDTO, len(loans)),
+			NextCursor: next,
+			HasMore:    next != "",
+			Limit:      limit,
+		}
+		for i, loan := range loans {
+			response.Loans[i].FromDomain(loan)
+		}
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
 	// Call service layer
 	loans, err := h.financeService.GetUserLoans(c.Request.Context(), userID)
 	if err != nil {
@@ -541,6 +962,21 @@ func (h *FinanceHandler) GetLoans(c *gin.Context) {
 
 // UpdateLoan handles PUT /api/finance/loan/:id requests
 // Updates an existing loan record for the authenticated user
+//
+//	@Summary	Update a loan
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string				true	"Loan ID"
+//	@Param		request				body		dtos.UpdateLoanDTO	true	"Fields to change"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan/{id}	[put]
 func (h *FinanceHandler) UpdateLoan(c *gin.Context) {
 	var request dtos.UpdateLoanDTO
 	loanID := c.Param("id")
@@ -557,11 +993,7 @@ func (h *FinanceHandler) UpdateLoan(c *gin.Context) {
 
 	// Validate request fields
 	if err := h.validator.Struct(&request); err != nil {
-		validationErrors := h.buildValidationErrors(err)
-		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse(
-			"Validation failed",
-			validationErrors,
-		))
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
 
@@ -593,9 +1025,9 @@ func (h *FinanceHandler) UpdateLoan(c *gin.Context) {
 	}
 
 	if loan == nil {
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+		c.JSON(http.StatusNotFound, dtos.NewCodedErrorResponse(
 			http.StatusNotFound,
-			"not_found",
+			dtos.ErrorCodeFinLoanNotFound,
 			"Loan not found or access denied",
 		))
 		return
@@ -607,9 +1039,9 @@ func (h *FinanceHandler) UpdateLoan(c *gin.Context) {
 	// Call service layer
 	if err := h.financeService.UpdateLoan(c.Request.Context(), *loan); err != nil {
 		if strings.Contains(err.Error(), "does not belong to user") {
-			c.JSON(http.StatusForbidden, dtos.NewErrorResponse(
+			c.JSON(http.StatusForbidden, dtos.NewCodedErrorResponse(
 				http.StatusForbidden,
-				"forbidden",
+				dtos.ErrorCodeFinLoanNotOwned,
 				"Access denied: You can only update your own loan records",
 			))
 			return
@@ -623,11 +1055,44 @@ func (h *FinanceHandler) UpdateLoan(c *gin.Context) {
 	})
 }
 
-// ==================== FINANCIAL ANALYSIS ENDPOINTS ====================
+// SimulateLoanPayoff handles POST /api/finance/loan/:id/simulate requests
+// Projects how extra monthly and/or one-time payments would change the loan's payoff; the loan isn't changed
+//
+//	@Summary	Simulate extra loan payments
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id							path		string						true	"Loan ID"
+//	@Param		request						body		dtos.SimulateLoanPayoffDTO	true	"Extra payments"
+//	@Success	200							{object}	dtos.LoanPayoffSimulationResponseDTO
+//	@Failure	400							{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	403							{object}	dtos.ErrorResponseDTO
+//	@Failure	404							{object}	dtos.ErrorResponseDTO
+//	@Failure	422							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan/{id}/simulate	[post]
+func (h *FinanceHandler) SimulateLoanPayoff(c *gin.Context) {
+	var request dtos.SimulateLoanPayoffDTO
+	loanID := c.Param("id")
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
 
-// GetFinanceSummary handles GET /api/finance/summary requests
-// Returns comprehensive financial overview for the authenticated user
-func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 	// Extract user ID from authentication context
 	userID := middleware.GetUserID(c)
 	if userID == "" {
@@ -640,22 +1105,65 @@ func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
 	}
 
 	// Call service layer
-	summary, err := h.financeService.CalculateFinanceSummary(c.Request.Context(), userID)
+	simulation, err := h.financeService.SimulateLoanPayoff(c.Request.Context(), userID, loanID,
+		request.ExtraMonthlyPayment, request.OneTimePayment)
 	if err != nil {
+		// Say which payment was rejected rather than the generic invalid data message
+		if errors.Is(err, domain.ErrInvalidLoanData) {
+			c.JSON(http.StatusBadRequest, dtos.NewCodedErrorResponse(
+				http.StatusBadRequest,
+				dtos.ErrorCodeFinInvalidLoan,
+				err.Error(),
+			))
+			return
+		}
 		h.handleFinanceError(c, err)
 		return
 	}
 
-	// Convert domain struct to DTO
-	var response dtos.FinanceSummaryResponseDTO
-	response.FromDomain(summary)
-
+	var response dtos.LoanPayoffSimulationResponseDTO
+	response.FromDomain(simulation)
 	c.JSON(http.StatusOK, response)
 }
 
-// GetAffordability handles GET /api/finance/affordability requests
-// Returns maximum affordable amount for purchases based on user's financial situation
-func (h *FinanceHandler) GetAffordability(c *gin.Context) {
+// CalculateExtraPaymentImpact handles POST /api/finance/loan/:id/extra-payment requests
+// Reports how much sooner the loan is repaid, and the interest saved, with an extra monthly payment
+//
+//	@Summary	Calculate the impact of an extra monthly loan payment
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id								path		string						true	"Loan ID"
+//	@Param		request							body		dtos.LoanExtraPaymentDTO	true	"Extra monthly payment"
+//	@Success	200								{object}	dtos.LoanExtraPaymentImpactResponseDTO
+//	@Failure	400								{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	404								{object}	dtos.ErrorResponseDTO
+//	@Failure	422								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan/{id}/extra-payment	[post]
+func (h *FinanceHandler) CalculateExtraPaymentImpact(c *gin.Context) {
+	var request dtos.LoanExtraPaymentDTO
+	loanID := c.Param("id")
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
 	// Extract user ID from authentication context
 	userID := middleware.GetUserID(c)
 	if userID == "" {
@@ -668,94 +1176,1481 @@ func (h *FinanceHandler) GetAffordability(c *gin.Context) {
 	}
 
 	// Call service layer
-	maxAffordable, err := h.financeService.GetMaxAffordableAmount(c.Request.Context(), userID)
+	impact, err := h.financeService.CalculateExtraPaymentImpact(c.Request.Context(), userID, loanID, request.ExtraMonthlyPayment)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLoanData) {
+			c.JSON(http.StatusBadRequest, dtos.NewCodedErrorResponse(
+				http.StatusBadRequest,
+				dtos.ErrorCodeFinInvalidLoan,
+				err.Error(),
+			))
+			return
+		}
 		h.handleFinanceError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"user_id":               userID,
-		"max_affordable_amount": maxAffordable,
-		"currency":              "USD",
-		"calculation_date":      "now", // Could be actual timestamp
-	})
+	var response dtos.LoanExtraPaymentImpactResponseDTO
+	response.FromDomain(impact)
+	c.JSON(http.StatusOK, response)
 }
 
-// ==================== HELPER METHODS ====================
-
-// buildValidationErrors constructs a map of validation errors from validator.ValidationErrors
-func (h *FinanceHandler) buildValidationErrors(err error) map[string]interface{} {
-	validationErrors := make(map[string]interface{})
-	for _, err := range err.(validator.ValidationErrors) {
-		field := err.Field()
-		switch err.Tag() {
-		case "required":
-			validationErrors[field] = field + " is required"
-		case "email":
-			validationErrors[field] = field + " must be a valid email address"
-		case "min":
-			validationErrors[field] = field + " must be at least " + err.Param() + " characters"
-		case "gt":
-			validationErrors[field] = field + " must be greater than " + err.Param()
-		case "gte":
-			validationErrors[field] = field + " must be greater than or equal to " + err.Param()
-		case "lte":
-			validationErrors[field] = field + " must be less than or equal to " + err.Param()
-		case "oneof":
-			validationErrors[field] = field + " must be one of: " + err.Param()
-		default:
-			validationErrors[field] = field + " is invalid"
-		}
-	}
-	return validationErrors
-}
+// RecordLoanPayment handles POST /api/finance/loan/:id/payment requests
+// Records a payment on the loan, splitting it into the interest it covers and the principal it repays
+//
+//	@Summary	Record a loan payment
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key				header		string				false	"Makes retries safe; see Idempotent Retries"
+//	@Param		id							path		string				true	"Loan ID"
+//	@Param		request						body		dtos.LoanPaymentDTO	true	"Payment"
+//	@Success	201							{object}	dtos.LoanBalanceEntryResponseDTO
+//	@Failure	400							{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	403							{object}	dtos.ErrorResponseDTO
+//	@Failure	404							{object}	dtos.ErrorResponseDTO
+//	@Failure	409							{object}	dtos.ErrorResponseDTO
+//	@Failure	422							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan/{id}/payment	[post]
+func (h *FinanceHandler) RecordLoanPayment(c *gin.Context) {
+	var request dtos.LoanPaymentDTO
+	loanID := c.Param("id")
 
-// handleFinanceError handles finance-specific errors and maps them to appropriate HTTP responses
-func (h *FinanceHandler) handleFinanceError(c *gin.Context, err error) {
-	switch {
-	case errors.Is(err, domain.ErrIncomeNotFound):
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
-			http.StatusNotFound,
-			"not_found",
-			"Income record not found",
-		))
-	case errors.Is(err, domain.ErrExpenseNotFound):
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
-			http.StatusNotFound,
-			"not_found",
-			"Expense record not found",
-		))
-	case errors.Is(err, domain.ErrLoanNotFound):
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
-			http.StatusNotFound,
-			"not_found",
-			"Loan record not found",
-		))
-	case errors.Is(err, domain.ErrFinanceSummaryNotFound):
-		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
-			http.StatusNotFound,
-			"not_found",
-			"Financial summary not found",
-		))
-	case errors.Is(err, domain.ErrUnauthorizedAccess):
-		c.JSON(http.StatusForbidden, dtos.NewErrorResponse(
-			http.StatusForbidden,
-			"forbidden",
-			"Access denied: You can only access your own financial records",
-		))
-	case errors.Is(err, domain.ErrInvalidFinanceData):
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
 		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
 			http.StatusBadRequest,
 			"bad_request",
-			"Invalid financial data provided",
+			"Invalid JSON format",
 		))
-	default:
-		// Internal server error for unexpected errors
-		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
-			http.StatusInternalServerError,
-			"internal_error",
-			"An internal error occurred. Please try again later",
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
 		))
+		return
 	}
-}
\ No newline at end of file
+
+	// Call service layer
+	entry, err := h.financeService.RecordLoanPayment(c.Request.Context(), userID, loanID, request.Amount, strings.TrimSpace(request.Note))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidLoanData) {
+			c.JSON(http.StatusBadRequest, dtos.NewCodedErrorResponse(
+				http.StatusBadRequest,
+				dtos.ErrorCodeFinInvalidLoan,
+				err.Error(),
+			))
+			return
+		}
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.LoanBalanceEntryResponseDTO
+	response.FromDomain(entry)
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetLoanBalanceHistory handles GET /api/finance/loan/:id/history requests
+// Lists every change to the loan's balance - payments, adjustments and interest accruals - newest first
+//
+//	@Summary	Loan balance history
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id							path		string	true	"Loan ID"
+//	@Success	200							{array}		dtos.LoanBalanceEntryResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	403							{object}	dtos.ErrorResponseDTO
+//	@Failure	404							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loan/{id}/history	[get]
+func (h *FinanceHandler) GetLoanBalanceHistory(c *gin.Context) {
+	loanID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	entries, err := h.financeService.GetLoanBalanceHistory(c.Request.Context(), userID, loanID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	response := make([]dtos.LoanBalanceEntryResponseDTO, len(entries))
+	for i, entry := range entries {
+		response[i].FromDomain(entry)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetNearPayoffLoans handles GET /api/finance/loans/near-payoff requests
+// Lists the loans with at most threshold_percent of their principal left to repay, smallest balance first
+//
+//	@Summary	List loans that are nearly paid off
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		threshold_percent			query		number	false	"Percentage of the principal still owed, above 0 and at most 100 (default 10)"
+//	@Success	200							{array}		dtos.NearPayoffLoanResponseDTO
+//	@Failure	400							{object}	dtos.ErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/loans/near-payoff	[get]
+func (h *FinanceHandler) GetNearPayoffLoans(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	thresholdPercent := domain.NearPayoffThreshold * 100
+	if raw := c.Query("threshold_percent"); raw != "" {
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil || parsed <= 0 || parsed > 100 {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"threshold_percent must be greater than 0 and at most 100",
+			))
+			return
+		}
+		thresholdPercent = parsed
+	}
+
+	loans, err := h.financeService.GetNearPayoffLoans(c.Request.Context(), userID, thresholdPercent)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	response := make([]dtos.NearPayoffLoanResponseDTO, len(loans))
+	for i, loan := range loans {
+		response[i].FromDomain(loan)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetDebtBreakdown handles GET /api/finance/debt-breakdown requests
+// Groups the user's loans by type with each type's share of the total debt
+//
+//	@Summary	Break debt down by loan type
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200						{object}	dtos.DebtBreakdownResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/debt-breakdown	[get]
+func (h *FinanceHandler) GetDebtBreakdown(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	breakdown, err := h.financeService.GetDebtBreakdownByType(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.DebtBreakdownResponseDTO
+	response.FromDomain(breakdown)
+	c.JSON(http.StatusOK, response)
+}
+
+// ==================== SAVINGS GOAL ENDPOINTS ====================
+
+// AddSavingsGoal handles POST /api/finance/goals requests
+// Adds a new savings goal for the authenticated user
+//
+//	@Summary	Add a savings goal
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string					false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request			body		dtos.AddSavingsGoalDTO	true	"Savings goal"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	409				{object}	dtos.ErrorResponseDTO
+//	@Failure	422				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals	[post]
+func (h *FinanceHandler) AddSavingsGoal(c *gin.Context) {
+	var request dtos.AddSavingsGoalDTO
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Convert DTO to domain struct
+	goal := request.ToDomain(userID)
+
+	// Call service layer
+	if err := h.financeService.AddSavingsGoal(c.Request.Context(), goal); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Savings goal added successfully",
+	})
+}
+
+// GetSavingsGoals handles GET /api/finance/goals requests
+// Retrieves all savings goals for the authenticated user, nearest target date first
+//
+//	@Summary	List savings goals
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200				{array}		dtos.SavingsGoalResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals	[get]
+func (h *FinanceHandler) GetSavingsGoals(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	goals, err := h.financeService.GetUserSavingsGoals(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	// Convert domain structs to DTOs
+	response := make([]dtos.SavingsGoalResponseDTO, len(goals))
+	for i, goal := range goals {
+		response[i].FromDomain(goal)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateSavingsGoal handles PUT /api/finance/goals/:id requests
+// Updates an existing savings goal for the authenticated user
+//
+//	@Summary	Update a savings goal
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string						true	"Savings goal ID"
+//	@Param		request				body		dtos.UpdateSavingsGoalDTO	true	"Fields to change"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals/{id}	[put]
+func (h *FinanceHandler) UpdateSavingsGoal(c *gin.Context) {
+	var request dtos.UpdateSavingsGoalDTO
+	goalID := c.Param("id")
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Get existing goals to find the one to update
+	goals, err := h.financeService.GetUserSavingsGoals(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	// Find the goal to update
+	var goal *domain.SavingsGoal
+	for i := range goals {
+		if goals[i].ID == goalID {
+			goal = &goals[i]
+			break
+		}
+	}
+
+	if goal == nil {
+		h.handleFinanceError(c, domain.ErrSavingsGoalNotFound)
+		return
+	}
+
+	// Apply updates
+	request.ApplyUpdates(goal)
+
+	// Call service layer
+	if err := h.financeService.UpdateSavingsGoal(c.Request.Context(), *goal); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Savings goal updated successfully",
+	})
+}
+
+// DeleteSavingsGoal handles DELETE /api/finance/goals/:id requests
+// Soft deletes a savings goal; contributions already made stay in the history
+//
+//	@Summary	Delete a savings goal
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string	true	"Savings goal ID"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	403					{object}	dtos.ErrorResponseDTO
+//	@Failure	404					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals/{id}	[delete]
+func (h *FinanceHandler) DeleteSavingsGoal(c *gin.Context) {
+	goalID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.DeleteSavingsGoal(c.Request.Context(), userID, goalID); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Savings goal deleted successfully",
+	})
+}
+
+// AddGoalContribution handles POST /api/finance/goals/:id/contributions requests
+// Records money put toward a savings goal and adds it to the goal's current amount
+//
+//	@Summary	Contribute to a savings goal
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key					header		string						false	"Makes retries safe; see Idempotent Retries"
+//	@Param		id								path		string						true	"Savings goal ID"
+//	@Param		request							body		dtos.AddGoalContributionDTO	true	"Contribution"
+//	@Success	201								{object}	dtos.MessageResponseDTO
+//	@Failure	400								{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	404								{object}	dtos.ErrorResponseDTO
+//	@Failure	409								{object}	dtos.ErrorResponseDTO
+//	@Failure	422								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals/{id}/contributions	[post]
+func (h *FinanceHandler) AddGoalContribution(c *gin.Context) {
+	var request dtos.AddGoalContributionDTO
+	goalID := c.Param("id")
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.AddGoalContribution(c.Request.Context(), request.ToDomain(userID, goalID)); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Contribution recorded successfully",
+	})
+}
+
+// GetGoalHistory handles GET /api/finance/goals/history requests
+// Returns goal contributions grouped by month, oldest first; supports a months query parameter (default 12, max 24)
+//
+//	@Summary	Monthly savings goal contribution history
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		months					query		int		false	"Number of months including the current one, 1-24"
+//	@Success	200						{array}		dtos.GoalContributionMonthDTO
+//	@Failure	400						{object}	dtos.ErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/goals/history	[get]
+func (h *FinanceHandler) GetGoalHistory(c *gin.Context) {
+	months := defaultGoalHistoryMonths
+	if raw := c.Query("months"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxGoalHistoryMonths {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"months must be between 1 and "+strconv.Itoa(maxGoalHistoryMonths),
+			))
+			return
+		}
+		months = parsed
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	history, err := h.financeService.GetGoalContributionHistory(c.Request.Context(), userID, months)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	response := make([]dtos.GoalContributionMonthDTO, len(history))
+	for i, month := range history {
+		response[i].FromDomain(month)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ==================== BUDGET ENDPOINTS ====================
+
+// AddBudget handles POST /api/finance/budgets requests
+// Adds a monthly spending limit for one of the authenticated user's expense categories
+//
+//	@Summary	Add a budget
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string				false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request			body		dtos.AddBudgetDTO	true	"Budget"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	409				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/budgets	[post]
+func (h *FinanceHandler) AddBudget(c *gin.Context) {
+	var request dtos.AddBudgetDTO
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.AddBudget(c.Request.Context(), request.ToDomain(userID)); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Budget added successfully",
+	})
+}
+
+// GetBudgets handles GET /api/finance/budgets requests
+// Retrieves all budgets for the authenticated user, ordered by category
+//
+//	@Summary	List budgets
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{array}		dtos.BudgetResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/budgets	[get]
+func (h *FinanceHandler) GetBudgets(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	budgets, err := h.financeService.GetUserBudgets(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	// Convert domain structs to DTOs
+	response := make([]dtos.BudgetResponseDTO, len(budgets))
+	for i, budget := range budgets {
+		response[i].FromDomain(budget)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateBudget handles PUT /api/finance/budgets/:id requests
+// Updates an existing budget for the authenticated user
+//
+//	@Summary	Update a budget
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string					true	"Budget ID"
+//	@Param		request					body		dtos.UpdateBudgetDTO	true	"Fields to change"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	409						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/budgets/{id}	[put]
+func (h *FinanceHandler) UpdateBudget(c *gin.Context) {
+	var request dtos.UpdateBudgetDTO
+	budgetID := c.Param("id")
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Get existing budgets to find the one to update
+	budgets, err := h.financeService.GetUserBudgets(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	// Find the budget to update
+	var budget *domain.Budget
+	for i := range budgets {
+		if budgets[i].ID == budgetID {
+			budget = &budgets[i]
+			break
+		}
+	}
+
+	if budget == nil {
+		h.handleFinanceError(c, domain.ErrBudgetNotFound)
+		return
+	}
+
+	// Apply updates
+	request.ApplyUpdates(budget)
+
+	// Call service layer
+	if err := h.financeService.UpdateBudget(c.Request.Context(), *budget); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget updated successfully",
+	})
+}
+
+// DeleteBudget handles DELETE /api/finance/budgets/:id requests
+// Removes a budget; its category no longer appears in the summary's budget comparison
+//
+//	@Summary	Delete a budget
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string	true	"Budget ID"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	403						{object}	dtos.ErrorResponseDTO
+//	@Failure	404						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/budgets/{id}	[delete]
+func (h *FinanceHandler) DeleteBudget(c *gin.Context) {
+	budgetID := c.Param("id")
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	if err := h.financeService.DeleteBudget(c.Request.Context(), userID, budgetID); err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Budget deleted successfully",
+	})
+}
+
+// ==================== FINANCIAL ANALYSIS ENDPOINTS ====================
+
+// GetFinanceSummary handles GET /api/finance/summary requests
+// Returns comprehensive financial overview for the authenticated user
+//
+//	@Summary	Get the finance summary
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.FinanceSummaryResponseDTO
+//	@Failure	401					{object}	dtos.ErrorResponseDTO
+//	@Failure	500					{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/summary	[get]
+func (h *FinanceHandler) GetFinanceSummary(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	summary, err := h.financeService.CalculateFinanceSummary(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	// Convert domain struct to DTO
+	var response dtos.FinanceSummaryResponseDTO
+	response.FromDomain(summary)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAffordability handles GET /api/finance/affordability requests
+// Returns maximum affordable amount for purchases based on user's financial situation
+//
+//	@Summary	Get the maximum affordable purchase
+//	@Tags		finance
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200						{object}	dtos.AffordabilityResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/affordability	[get]
+func (h *FinanceHandler) GetAffordability(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	maxAffordable, err := h.financeService.GetMaxAffordableAmount(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.AffordabilityResponseDTO{
+		UserID:              userID,
+		MaxAffordableAmount: maxAffordable,
+		Currency:            h.financeService.BaseCurrency(),
+		CalculationDate:     "now", // Could be actual timestamp
+	})
+}
+
+// GetHealthExplanation handles GET /api/finance/health-explanation requests
+// Returns the user's financial health tier with the metrics behind it and why each helps or hurts
+//
+//	@Summary		Explain the financial health rating
+//	@Description	Rates financial health as financial_health in the finance summary does, and explains how the
+//	@Description	debt-to-income ratio, disposable income and savings rate each measure up against the thresholds.
+//	@Tags			finance
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200							{object}	dtos.FinancialHealthExplanationResponseDTO
+//	@Failure		401							{object}	dtos.ErrorResponseDTO
+//	@Failure		500							{object}	dtos.ErrorResponseDTO
+//	@Router			/finance/health-explanation	[get]
+func (h *FinanceHandler) GetHealthExplanation(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	// Call service layer
+	explanation, err := h.financeService.ExplainFinancialHealth(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.FinancialHealthExplanationResponseDTO
+	response.FromDomain(explanation)
+	c.JSON(http.StatusOK, response)
+}
+
+// GetIncomeDiversification handles GET /api/finance/income-diversification requests
+// Returns how concentrated the user's recurring income is and whether they depend on a single source
+//
+//	@Summary		Measure income diversification
+//	@Description	Groups active recurring incomes by source and reports each source's share of the monthly income,
+//	@Description	the largest share and the Herfindahl-Hirschman concentration index. single_source_dependent is set
+//	@Description	when one source is more than 90% of the income.
+//	@Tags			finance
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200								{object}	dtos.IncomeDiversificationResponseDTO
+//	@Failure		401								{object}	dtos.ErrorResponseDTO
+//	@Failure		500								{object}	dtos.ErrorResponseDTO
+//	@Router			/finance/income-diversification	[get]
+func (h *FinanceHandler) GetIncomeDiversification(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	diversification, err := h.financeService.GetIncomeDiversification(c.Request.Context(), userID)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.IncomeDiversificationResponseDTO
+	response.FromDomain(diversification)
+	c.JSON(http.StatusOK, response)
+}
+
+// CheckPurchaseAffordability handles POST /api/finance/affordability/check requests
+// Weighs a specific purchase, paid in cash or in installments, against the user's finances
+//
+//	@Summary		Check whether a specific purchase is affordable
+//	@Description	Cash purchases are weighed against the disposable income saved over horizon_months.
+//	@Description	Installment purchases add the implied monthly payment to the debt-to-income ratio and take it off the savings rate.
+//	@Tags			finance
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request							body		dtos.PurchaseAffordabilityCheckDTO	true	"Purchase and payment method"
+//	@Success		200								{object}	dtos.PurchaseAffordabilityResponseDTO
+//	@Failure		400								{object}	dtos.ErrorResponseDTO
+//	@Failure		401								{object}	dtos.ErrorResponseDTO
+//	@Failure		500								{object}	dtos.ErrorResponseDTO
+//	@Router			/finance/affordability/check	[post]
+func (h *FinanceHandler) CheckPurchaseAffordability(c *gin.Context) {
+	var request dtos.PurchaseAffordabilityCheckDTO
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	result, err := h.financeService.CheckPurchaseAffordability(c.Request.Context(), userID, request.ToDomain())
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.PurchaseAffordabilityResponseDTO
+	response.FromDomain(result)
+	c.JSON(http.StatusOK, response)
+}
+
+// SuggestExpenseCuts handles POST /api/finance/suggest-cuts requests
+// Suggests the discretionary expenses to cut, lowest priority first, to free up a monthly target
+//
+//	@Summary	Suggest expenses to cut to reach a savings target
+//	@Tags		finance
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request					body		dtos.SuggestExpenseCutsDTO	true	"Monthly savings target"
+//	@Success	200						{object}	dtos.ExpenseCutSuggestionResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.ErrorResponseDTO
+//	@Failure	500						{object}	dtos.ErrorResponseDTO
+//	@Router		/finance/suggest-cuts	[post]
+func (h *FinanceHandler) SuggestExpenseCuts(c *gin.Context) {
+	var request dtos.SuggestExpenseCutsDTO
+
+	// Parse and bind JSON request
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid JSON format",
+		))
+		return
+	}
+
+	// Validate request fields
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	suggestion, err := h.financeService.SuggestExpenseCuts(c.Request.Context(), userID, request.TargetSavings)
+	if err != nil {
+		h.handleFinanceError(c, err)
+		return
+	}
+
+	var response dtos.ExpenseCutSuggestionResponseDTO
+	response.FromDomain(suggestion)
+	c.JSON(http.StatusOK, response)
+}
+
+// ==================== EXPORT ====================
+
+// exportFlushInterval is how many records the export writes between flushes to the client
+const exportFlushInterval = 100
+
+// ExportFinanceData handles GET /api/finance/export requests
+// Streams the authenticated user's expenses, incomes or loans as CSV or JSON, oldest first
+//
+//	@Summary		Export finance records
+//	@Description	Streams records as a file download named after the type and the current date.
+//	@Description	CSV exports one type per file with a header row; JSON is an array, or an object keyed by type for type=all.
+//	@Description	The date range applies to every type; the other filters only to expenses.
+//	@Tags			finance
+//	@Produce		json
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			format			query		string	false	"csv or json (default json)"
+//	@Param			type			query		string	false	"expenses, incomes, loans or all (default all, JSON only)"
+//	@Param			q				query		string	false	"Case-insensitive expense name substring"
+//	@Param			category		query		string	false	"Only export expenses in this category"
+//	@Param			min_amount		query		number	false	"Minimum expense amount, inclusive"
+//	@Param			max_amount		query		number	false	"Maximum expense amount, inclusive"
+//	@Param			is_fixed		query		bool	false	"Fixed (true) or variable (false) expenses"
+//	@Param			priority		query		int		false	"Expense priority, 1-3"
+//	@Param			frequency		query		string	false	"Expense frequency: monthly, weekly or daily"
+//	@Param			created_from	query		string	false	"Created on or after this date (YYYY-MM-DD)"
+//	@Param			created_to		query		string	false	"Created on or before this date (YYYY-MM-DD)"
+//	@Success		200				{array}		dtos.ExpenseResponseDTO
+//	@Failure		400				{object}	dtos.ErrorResponseDTO
+//	@Failure		401				{object}	dtos.ErrorResponseDTO
+//	@Failure		500				{object}	dtos.ErrorResponseDTO
+//	@Router			/finance/export	[get]
+func (h *FinanceHandler) ExportFinanceData(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	var query dtos.FinanceExportQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid query parameters: amounts must be numbers, priority an integer, is_fixed a boolean and dates YYYY-MM-DD",
+		))
+		return
+	}
+	if query.Format == "" {
+		query.Format = dtos.ExportFormatJSON
+	}
+	if query.Type == "" {
+		query.Type = dtos.ExportTypeAll
+	}
+
+	var message string
+	switch {
+	case query.Format != dtos.ExportFormatCSV && query.Format != dtos.ExportFormatJSON:
+		message = "format must be csv or json"
+	case query.Type != dtos.ExportTypeExpenses && query.Type != dtos.ExportTypeIncomes &&
+		query.Type != dtos.ExportTypeLoans && query.Type != dtos.ExportTypeAll:
+		message = "type must be expenses, incomes, loans or all"
+	case query.Format == dtos.ExportFormatCSV && query.Type == dtos.ExportTypeAll:
+		message = "CSV exports one type at a time: type must be expenses, incomes or loans"
+	}
+	if message != "" {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(http.StatusBadRequest, "bad_request", message))
+		return
+	}
+
+	stream := &exportStream{
+		c:        c,
+		format:   query.Format,
+		keyed:    query.Type == dtos.ExportTypeAll,
+		filename: "finance-" + query.Type + "-" + time.Now().UTC().Format("2006-01-02") + "." + query.Format,
+	}
+	err := h.exportRecords(c, userID, query.Type, query.ToDomain(), stream)
+	if err == nil {
+		err = stream.close()
+	}
+	if err == nil {
+		return
+	}
+
+	// Once the headers are out the status can't change, so the client is left with a truncated file
+	if stream.started {
+		logging.ContextLogger(c).Error("Finance export failed after streaming began",
+			logging.WithUserID(userID), logging.WithError(err))
+		return
+	}
+	h.handleFinanceError(c, err)
+}
+
+// exportRecords writes each requested type to the stream in its own section, expenses first so
+// an invalid filter is reported before anything is written
+func (h *FinanceHandler) exportRecords(c *gin.Context, userID, exportType string, filter domain.ExpenseFilter, stream *exportStream) error {
+	ctx := c.Request.Context()
+	all := exportType == dtos.ExportTypeAll
+
+	if all || exportType == dtos.ExportTypeExpenses {
+		stream.beginSection(dtos.ExportTypeExpenses, dtos.ExpenseCSVHeader)
+		err := h.financeService.ExportExpenses(ctx, userID, filter, func(expense domain.Expense) error {
+			var dto dtos.ExpenseResponseDTO
+			dto.FromDomain(expense)
+			return stream.write(dto, dto.CSVRecord())
+		})
+		if err != nil {
+			return err
+		}
+		stream.endSection()
+	}
+
+	if all || exportType == dtos.ExportTypeIncomes {
+		stream.beginSection(dtos.ExportTypeIncomes, dtos.IncomeCSVHeader)
+		err := h.financeService.ExportIncomes(ctx, userID, filter, func(income domain.Income) error {
+			var dto dtos.IncomeResponseDTO
+			dto.FromDomain(income)
+			return stream.write(dto, dto.CSVRecord())
+		})
+		if err != nil {
+			return err
+		}
+		stream.endSection()
+	}
+
+	if all || exportType == dtos.ExportTypeLoans {
+		stream.beginSection(dtos.ExportTypeLoans, dtos.LoanCSVHeader)
+		err := h.financeService.ExportLoans(ctx, userID, filter, func(loan domain.Loan) error {
+			var dto dtos.LoanResponseDTO
+			dto.FromDomain(loan)
+			return stream.write(dto, dto.CSVRecord())
+		})
+		if err != nil {
+			return err
+		}
+		stream.endSection()
+	}
+
+	return nil
+}
+
+// exportStream writes an export to the response one record at a time. Nothing, not even the
+// headers, is written until the first record or the end of the first section, so an error
+// before then can still be answered with a normal JSON error response.
+type exportStream struct {
+	c        *gin.Context
+	format   string
+	keyed    bool // JSON object keyed by section name rather than a single array
+	filename string
+
+	started  bool
+	csv      *csv.Writer
+	section  string
+	header   []string
+	opened   int // sections whose opening has been written
+	records  int // records written to the current section
+	writeErr error
+}
+
+// beginSection starts a new section; its opening is written with its first record
+func (s *exportStream) beginSection(name string, header []string) {
+	s.section = name
+	s.header = header
+	s.records = 0
+}
+
+// write appends one record to the current section, as value in JSON or as record in CSV
+func (s *exportStream) write(value any, record []string) error {
+	if s.records == 0 {
+		s.openSection()
+	}
+	s.records++
+
+	if s.format == dtos.ExportFormatCSV {
+		s.fail(s.csv.Write(record))
+	} else {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if s.records > 1 {
+			s.writeString(",")
+		}
+		s.writeBytes(encoded)
+	}
+
+	if s.records%exportFlushInterval == 0 {
+		s.flush()
+	}
+	return s.writeErr
+}
+
+// endSection closes the current section, writing its opening first if it had no records
+func (s *exportStream) endSection() {
+	if s.records == 0 {
+		s.openSection()
+	}
+	if s.format == dtos.ExportFormatJSON {
+		s.writeString("]")
+	}
+}
+
+// close finishes the document and flushes it to the client
+func (s *exportStream) close() error {
+	s.start()
+	if s.format == dtos.ExportFormatJSON && s.keyed {
+		s.writeString("}")
+	}
+	s.flush()
+	return s.writeErr
+}
+
+// start writes the response headers and, for a keyed JSON export, the opening brace
+func (s *exportStream) start() {
+	if s.started {
+		return
+	}
+	s.started = true
+
+	contentType := "application/json; charset=utf-8"
+	if s.format == dtos.ExportFormatCSV {
+		contentType = "text/csv; charset=utf-8"
+		s.csv = csv.NewWriter(s.c.Writer)
+	}
+	s.c.Header("Content-Type", contentType)
+	s.c.Header("Content-Disposition", `attachment; filename="`+s.filename+`"`)
+	s.c.Status(http.StatusOK)
+
+	if s.format == dtos.ExportFormatJSON && s.keyed {
+		s.writeString("{")
+	}
+}
+
+// openSection writes the CSV header row or the JSON array opening of the current section
+func (s *exportStream) openSection() {
+	s.start()
+	if s.format == dtos.ExportFormatCSV {
+		s.fail(s.csv.Write(s.header))
+	} else {
+		if s.keyed {
+			if s.opened > 0 {
+				s.writeString(",")
+			}
+			s.writeString(strconv.Quote(s.section) + ":")
+		}
+		s.writeString("[")
+	}
+	s.opened++
+}
+
+func (s *exportStream) flush() {
+	if s.csv != nil {
+		s.csv.Flush()
+		s.fail(s.csv.Error())
+	}
+	s.c.Writer.Flush()
+}
+
+func (s *exportStream) writeString(value string) {
+	s.writeBytes([]byte(value))
+}
+
+func (s *exportStream) writeBytes(value []byte) {
+	if s.writeErr != nil {
+		return
+	}
+	_, err := s.c.Writer.Write(value)
+	s.fail(err)
+}
+
+// fail records the first write error; later writes are skipped
+func (s *exportStream) fail(err error) {
+	if s.writeErr == nil {
+		s.writeErr = err
+	}
+}
+
+// ==================== HELPER METHODS ====================
+
+// pageQuery reads the cursor and limit query parameters of a cursor-paginated list.
+// paginated is false when neither is given, in which case the whole list is returned.
+// Writes a 400 response and returns ok false if limit isn't a number between 1 and maxFinancePageSize.
+func (h *FinanceHandler) pageQuery(c *gin.Context) (cursor string, limit int, paginated, ok bool) {
+	cursor = c.Query("cursor")
+	rawLimit, hasLimit := c.GetQuery("limit")
+	_, hasCursor := c.GetQuery("cursor")
+
+	limit = defaultFinancePageSize
+	if hasLimit {
+		parsed, err := strconv.Atoi(rawLimit)
+		if err != nil || parsed < 1 || parsed > maxFinancePageSize {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"limit must be between 1 and "+strconv.Itoa(maxFinancePageSize),
+			))
+			return "", 0, false, false
+		}
+		limit = parsed
+	}
+
+	return cursor, limit, hasCursor || hasLimit, true
+}
+
+// maxLoanInterestRate is the highest interest rate a loan can have, the default upper bound of a rate filter
+const maxLoanInterestRate = 100.0
+
+// loanRateQuery reads the min_rate and max_rate query parameters of the loan listing.
+// filtered is false when neither is given; a missing bound defaults to 0 or maxLoanInterestRate.
+// Writes a 400 response and returns ok false if a rate isn't a non-negative number or min_rate
+// is greater than max_rate.
+func (h *FinanceHandler) loanRateQuery(c *gin.Context) (minRate, maxRate float64, filtered, ok bool) {
+	rawMin, hasMin := c.GetQuery("min_rate")
+	rawMax, hasMax := c.GetQuery("max_rate")
+	if !hasMin && !hasMax {
+		return 0, 0, false, true
+	}
+
+	badRequest := func(message string) (float64, float64, bool, bool) {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(http.StatusBadRequest, "bad_request", message))
+		return 0, 0, false, false
+	}
+
+	minRate, maxRate = 0, maxLoanInterestRate
+	var err error
+	if hasMin {
+		if minRate, err = strconv.ParseFloat(rawMin, 64); err != nil || minRate < 0 {
+			return badRequest("min_rate must be a non-negative number")
+		}
+	}
+	if hasMax {
+		if maxRate, err = strconv.ParseFloat(rawMax, 64); err != nil || maxRate < 0 {
+			return badRequest("max_rate must be a non-negative number")
+		}
+	}
+	if minRate > maxRate {
+		return badRequest("min_rate must not be greater than max_rate")
+	}
+
+	return minRate, maxRate, true, true
+}
+
+// handleFinanceError handles finance-specific errors and maps them to appropriate HTTP responses
+func (h *FinanceHandler) handleFinanceError(c *gin.Context, err error) {
+	status, code := mapDomainError(err)
+	response := dtos.NewCodedErrorResponse(status, code, financeErrorMessage(err))
+
+	// Name the existing record so the client can choose between it and resending with force
+	var duplicate *domain.DuplicateRecordError
+	if errors.As(err, &duplicate) {
+		c.JSON(status, dtos.DuplicateRecordResponseDTO{ErrorResponseDTO: *response, ExistingID: duplicate.ExistingID})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// forceFlag reports whether an add request asked to skip duplicate detection, with force=true in
+// the query string or "force": true in the body. Writes a 400 response and returns false if the
+// query value isn't a boolean.
+func (h *FinanceHandler) forceFlag(c *gin.Context, bodyForce bool) (force bool, ok bool) {
+	raw := c.Query("force")
+	if raw == "" {
+		return bodyForce, true
+	}
+
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"force must be true or false",
+		))
+		return false, false
+	}
+	return parsed || bodyForce, true
+}
+
+// financeErrorMessage returns the user-facing message for a finance error
+func financeErrorMessage(err error) string {
+	if message, ok := contextErrorMessage(err); ok {
+		return message
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrIncomeNotFound):
+		return "Income record not found"
+	case errors.Is(err, domain.ErrExpenseNotFound):
+		return "Expense record not found"
+	case errors.Is(err, domain.ErrLoanNotFound):
+		return "Loan record not found"
+	case errors.Is(err, domain.ErrSavingsGoalNotFound):
+		return "Savings goal not found"
+	case errors.Is(err, domain.ErrFinanceSummaryNotFound):
+		return "Financial summary not found"
+	case errors.Is(err, domain.ErrSavingsGoalNotOwnedByUser):
+		return "Access denied: You can only access your own savings goals"
+	case errors.Is(err, domain.ErrBudgetNotFound):
+		return "Budget not found"
+	case errors.Is(err, domain.ErrBudgetNotOwnedByUser):
+		return "Access denied: You can only access your own budgets"
+	case errors.Is(err, domain.ErrBudgetExists):
+		return "A budget already exists for this category"
+	case errors.Is(err, domain.ErrInvalidBudgetData):
+		return err.Error()
+	case errors.Is(err, domain.ErrUnauthorizedAccess),
+		errors.Is(err, domain.ErrIncomeNotOwnedByUser),
+		errors.Is(err, domain.ErrExpenseNotOwnedByUser),
+		errors.Is(err, domain.ErrLoanNotOwnedByUser):
+		return "Access denied: You can only access your own financial records"
+	case errors.Is(err, domain.ErrInvalidSavingsGoalData), errors.Is(err, domain.ErrInvalidExpenseFilter),
+		errors.Is(err, domain.ErrInvalidExpenseTags):
+		return err.Error()
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return "Invalid pagination cursor"
+	case errors.Is(err, domain.ErrDuplicateRecord):
+		return "A matching record was added recently. Resend with force=true to add it anyway"
+	case errors.Is(err, domain.ErrInstallmentsComplete):
+		return "Every installment of this expense has already been paid"
+	case errors.Is(err, domain.ErrPaymentBelowInterest), errors.Is(err, domain.ErrInvalidPurchaseData):
+		return err.Error()
+	case errors.Is(err, domain.ErrNoIncome):
+		return "Add an income before checking whether a purchase is affordable"
+	case errors.Is(err, domain.ErrInvalidFinanceData),
+		errors.Is(err, domain.ErrInvalidIncomeData),
+		errors.Is(err, domain.ErrInvalidExpenseData),
+		errors.Is(err, domain.ErrInvalidLoanData):
+		return "Invalid financial data provided"
+	default:
+		return "An internal error occurred. Please try again later"
+	}
+}
@@ -3,10 +3,14 @@ package handlers
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +21,9 @@ import (
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories/memory"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
 // MockFinanceService is a mock implementation of FinanceService for testing
@@ -25,8 +32,8 @@ type MockFinanceService struct {
 }
 
 // Income operations
-func (m *MockFinanceService) AddIncome(ctx context.Context, income domain.Income) error {
-	args := m.Called(ctx, income)
+func (m *MockFinanceService) AddIncome(ctx context.Context, income domain.Income, allowDuplicate bool) error {
+	args := m.Called(ctx, income, allowDuplicate)
 	return args.Error(0)
 }
 
@@ -40,6 +47,11 @@ func (m *MockFinanceService) DeleteIncome(ctx context.Context, userID, incomeID
 	return args.Error(0)
 }
 
+func (m *MockFinanceService) RestoreIncome(ctx context.Context, userID, incomeID string) error {
+	args := m.Called(ctx, userID, incomeID)
+	return args.Error(0)
+}
+
 func (m *MockFinanceService) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -56,9 +68,17 @@ func (m *MockFinanceService) GetActiveUserIncomes(ctx context.Context, userID st
 	return args.Get(0).([]domain.Income), args.Error(1)
 }
 
+func (m *MockFinanceService) GetUserIncomesPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Income, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Income), args.String(1), args.Error(2)
+}
+
 // Expense operations
-func (m *MockFinanceService) AddExpense(ctx context.Context, expense domain.Expense) error {
-	args := m.Called(ctx, expense)
+func (m *MockFinanceService) AddExpense(ctx context.Context, expense domain.Expense, allowDuplicate bool) error {
+	args := m.Called(ctx, expense, allowDuplicate)
 	return args.Error(0)
 }
 
@@ -72,6 +92,21 @@ func (m *MockFinanceService) DeleteExpense(ctx context.Context, userID, expenseI
 	return args.Error(0)
 }
 
+func (m *MockFinanceService) RestoreExpense(ctx context.Context, userID, expenseID string) error {
+	args := m.Called(ctx, userID, expenseID)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) RecordInstallmentPaid(ctx context.Context, userID, expenseID string) (domain.Expense, error) {
+	args := m.Called(ctx, userID, expenseID)
+	return args.Get(0).(domain.Expense), args.Error(1)
+}
+
+func (m *MockFinanceService) BulkDeleteExpenses(ctx context.Context, userID string, ids []string) (int, error) {
+	args := m.Called(ctx, userID, ids)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockFinanceService) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
 	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
@@ -88,6 +123,51 @@ func (m *MockFinanceService) GetUserExpensesByCategory(ctx context.Context, user
 	return args.Get(0).([]domain.Expense), args.Error(1)
 }
 
+func (m *MockFinanceService) SearchUserExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	args := m.Called(ctx, userID, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Expense), args.Error(1)
+}
+
+// The export mocks pass the records given as the first return value to fn before returning the error
+func (m *MockFinanceService) ExportExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Expense) error) error {
+	args := m.Called(ctx, userID, filter)
+	if records, ok := args.Get(0).([]domain.Expense); ok {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockFinanceService) ExportIncomes(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Income) error) error {
+	args := m.Called(ctx, userID, filter)
+	if records, ok := args.Get(0).([]domain.Income); ok {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockFinanceService) ExportLoans(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Loan) error) error {
+	args := m.Called(ctx, userID, filter)
+	if records, ok := args.Get(0).([]domain.Loan); ok {
+		for _, record := range records {
+			if err := fn(record); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
 // Loan operations
 func (m *MockFinanceService) AddLoan(ctx context.Context, loan domain.Loan) error {
 	args := m.Called(ctx, loan)
@@ -107,11 +187,115 @@ func (m *MockFinanceService) GetUserLoans(ctx context.Context, userID string) ([
 	return args.Get(0).([]domain.Loan), args.Error(1)
 }
 
+func (m *MockFinanceService) GetUserLoansPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Loan, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]domain.Loan), args.String(1), args.Error(2)
+}
+
 func (m *MockFinanceService) UpdateLoanBalance(ctx context.Context, userID, loanID string, newBalance float64) error {
 	args := m.Called(ctx, userID, loanID, newBalance)
 	return args.Error(0)
 }
 
+func (m *MockFinanceService) RecordLoanPayment(ctx context.Context, userID, loanID string, amount float64, note string) (domain.LoanBalanceEntry, error) {
+	args := m.Called(ctx, userID, loanID, amount, note)
+	return args.Get(0).(domain.LoanBalanceEntry), args.Error(1)
+}
+
+func (m *MockFinanceService) GetLoanBalanceHistory(ctx context.Context, userID, loanID string) ([]domain.LoanBalanceEntry, error) {
+	args := m.Called(ctx, userID, loanID)
+	return args.Get(0).([]domain.LoanBalanceEntry), args.Error(1)
+}
+
+func (m *MockFinanceService) SimulateLoanPayoff(ctx context.Context, userID, loanID string, extraMonthly, oneTimePayment float64) (domain.LoanPayoffSimulation, error) {
+	args := m.Called(ctx, userID, loanID, extraMonthly, oneTimePayment)
+	return args.Get(0).(domain.LoanPayoffSimulation), args.Error(1)
+}
+
+func (m *MockFinanceService) CalculateExtraPaymentImpact(ctx context.Context, userID, loanID string, extraMonthly float64) (domain.LoanExtraPaymentImpact, error) {
+	args := m.Called(ctx, userID, loanID, extraMonthly)
+	return args.Get(0).(domain.LoanExtraPaymentImpact), args.Error(1)
+}
+
+func (m *MockFinanceService) GetLoansByRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error) {
+	args := m.Called(ctx, userID, minRate, maxRate)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
+func (m *MockFinanceService) GetNearPayoffLoans(ctx context.Context, userID string, thresholdPercent float64) ([]domain.NearPayoffLoan, error) {
+	args := m.Called(ctx, userID, thresholdPercent)
+	return args.Get(0).([]domain.NearPayoffLoan), args.Error(1)
+}
+
+func (m *MockFinanceService) GetDebtBreakdownByType(ctx context.Context, userID string) (domain.DebtBreakdown, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.DebtBreakdown), args.Error(1)
+}
+
+func (m *MockFinanceService) GetIncomeDiversification(ctx context.Context, userID string) (domain.IncomeDiversification, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.IncomeDiversification), args.Error(1)
+}
+
+func (m *MockFinanceService) SuggestExpenseCuts(ctx context.Context, userID string, targetSavings float64) (domain.ExpenseCutSuggestion, error) {
+	args := m.Called(ctx, userID, targetSavings)
+	return args.Get(0).(domain.ExpenseCutSuggestion), args.Error(1)
+}
+
+func (m *MockFinanceService) AddSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) UpdateSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) DeleteSavingsGoal(ctx context.Context, userID, goalID string) error {
+	args := m.Called(ctx, userID, goalID)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) GetUserSavingsGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.SavingsGoal), args.Error(1)
+}
+
+func (m *MockFinanceService) AddGoalContribution(ctx context.Context, contribution domain.GoalContribution) error {
+	args := m.Called(ctx, contribution)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) GetGoalContributionHistory(ctx context.Context, userID string, months int) ([]domain.GoalContributionMonth, error) {
+	args := m.Called(ctx, userID, months)
+	return args.Get(0).([]domain.GoalContributionMonth), args.Error(1)
+}
+
+// Budget operations
+func (m *MockFinanceService) AddBudget(ctx context.Context, budget domain.Budget) error {
+	args := m.Called(ctx, budget)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) UpdateBudget(ctx context.Context, budget domain.Budget) error {
+	args := m.Called(ctx, budget)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) DeleteBudget(ctx context.Context, userID, budgetID string) error {
+	args := m.Called(ctx, userID, budgetID)
+	return args.Error(0)
+}
+
+func (m *MockFinanceService) GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Budget), args.Error(1)
+}
+
 // Financial analysis
 func (m *MockFinanceService) CalculateFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error) {
 	args := m.Called(ctx, userID)
@@ -136,11 +320,26 @@ func (m *MockFinanceService) EvaluateFinancialHealth(ctx context.Context, userID
 	return args.Get(0).(string), args.Error(1)
 }
 
+func (m *MockFinanceService) ExplainFinancialHealth(ctx context.Context, userID string) (domain.FinancialHealthExplanation, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.FinancialHealthExplanation), args.Error(1)
+}
+
 func (m *MockFinanceService) GetMaxAffordableAmount(ctx context.Context, userID string) (float64, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(float64), args.Error(1)
 }
 
+func (m *MockFinanceService) CheckPurchaseAffordability(ctx context.Context, userID string, plan domain.PurchasePlan) (domain.PurchaseAffordability, error) {
+	args := m.Called(ctx, userID, plan)
+	return args.Get(0).(domain.PurchaseAffordability), args.Error(1)
+}
+
+// BaseCurrency always reports the default currency; no test varies it
+func (m *MockFinanceService) BaseCurrency() string {
+	return domain.DefaultCurrency
+}
+
 // Helper functions
 func (m *MockFinanceService) NormalizeToMonthly(amount float64, frequency string) (float64, error) {
 	args := m.Called(amount, frequency)
@@ -150,6 +349,7 @@ func (m *MockFinanceService) NormalizeToMonthly(amount float64, frequency string
 func setupFinanceTestRouter(financeService FinanceService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
+	r.Use(middleware.ValidateRequestLimits())
 
 	// Middleware to set authenticated user
 	r.Use(func(c *gin.Context) {
@@ -168,21 +368,52 @@ func setupFinanceTestRouter(financeService FinanceService) *gin.Engine {
 		finance.GET("/income", handler.GetIncomes)
 		finance.PUT("/income/:id", handler.UpdateIncome)
 		finance.DELETE("/income/:id", handler.DeleteIncome)
+		finance.POST("/income/:id/restore", handler.RestoreIncome)
 
 		// Expense routes
 		finance.POST("/expense", handler.AddExpense)
 		finance.GET("/expenses", handler.GetExpenses)
 		finance.PUT("/expense/:id", handler.UpdateExpense)
 		finance.DELETE("/expense/:id", handler.DeleteExpense)
+		finance.POST("/expense/:id/restore", handler.RestoreExpense)
+		finance.POST("/expense/:id/installment-paid", handler.RecordInstallmentPaid)
+		finance.DELETE("/expenses", handler.BulkDeleteExpenses)
 
 		// Loan routes
 		finance.POST("/loan", handler.AddLoan)
 		finance.GET("/loans", handler.GetLoans)
+		finance.GET("/loans/near-payoff", handler.GetNearPayoffLoans)
+		finance.GET("/debt-breakdown", handler.GetDebtBreakdown)
 		finance.PUT("/loan/:id", handler.UpdateLoan)
+		finance.POST("/loan/:id/simulate", handler.SimulateLoanPayoff)
+		finance.POST("/loan/:id/extra-payment", handler.CalculateExtraPaymentImpact)
+		finance.POST("/loan/:id/payment", handler.RecordLoanPayment)
+		finance.GET("/loan/:id/history", handler.GetLoanBalanceHistory)
+
+		// Savings goal routes
+		finance.POST("/goals", handler.AddSavingsGoal)
+		finance.GET("/goals", handler.GetSavingsGoals)
+		finance.GET("/goals/history", handler.GetGoalHistory)
+		finance.PUT("/goals/:id", handler.UpdateSavingsGoal)
+		finance.DELETE("/goals/:id", handler.DeleteSavingsGoal)
+		finance.POST("/goals/:id/contributions", handler.AddGoalContribution)
+
+		// Budget routes
+		finance.POST("/budgets", handler.AddBudget)
+		finance.GET("/budgets", handler.GetBudgets)
+		finance.PUT("/budgets/:id", handler.UpdateBudget)
+		finance.DELETE("/budgets/:id", handler.DeleteBudget)
 
 		// Financial analysis routes
 		finance.GET("/summary", handler.GetFinanceSummary)
 		finance.GET("/affordability", handler.GetAffordability)
+		finance.GET("/health-explanation", handler.GetHealthExplanation)
+		finance.GET("/income-diversification", handler.GetIncomeDiversification)
+		finance.POST("/affordability/check", handler.CheckPurchaseAffordability)
+		finance.POST("/suggest-cuts", handler.SuggestExpenseCuts)
+
+		// Export routes
+		finance.GET("/export", handler.ExportFinanceData)
 	}
 
 	return r
@@ -281,7 +512,7 @@ func TestFinanceHandler_AddIncome_Success(t *testing.T) {
 			income.Amount == addIncomeRequest.Amount &&
 			income.Frequency == addIncomeRequest.Frequency &&
 			income.IsActive == true
-	})).Return(nil)
+	}), false).Return(nil)
 
 	requestBody, _ := json.Marshal(addIncomeRequest)
 
@@ -331,14 +562,42 @@ func TestFinanceHandler_AddIncome_ValidationError(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, response.Code)
 	assert.Equal(t, "validation_error", response.Error)
-	assert.Contains(t, response.Fields, "Source")
-	assert.Contains(t, response.Fields, "Amount")
-	assert.Contains(t, response.Fields, "Frequency")
+	assert.Contains(t, response.Fields, "source")
+	assert.Contains(t, response.Fields, "amount")
+	assert.Contains(t, response.Fields, "frequency")
 
 	// Service should not be called
 	mockFinanceService.AssertNotCalled(t, "AddIncome")
 }
 
+func TestFinanceHandler_AddIncome_MoneyPrecisionError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	requestBody := []byte(`{"source":"Salary","amount":5000.125,"frequency":"monthly"}`)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ValidationErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, map[string]any{
+		"amount": "amount must have at most two decimal places",
+	}, response.Fields)
+
+	mockFinanceService.AssertNotCalled(t, "AddIncome")
+}
+
 func TestFinanceHandler_GetIncomes_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
@@ -368,6 +627,290 @@ func TestFinanceHandler_GetIncomes_Success(t *testing.T) {
 	mockFinanceService.AssertExpectations(t)
 }
 
+func TestFinanceHandler_GetIncomes_ContextErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   dtos.ErrorCode
+	}{
+		{"timeout", fmt.Errorf("failed to get incomes: %w", context.DeadlineExceeded), http.StatusServiceUnavailable, dtos.ErrorCodeTimeout},
+		{"canceled", fmt.Errorf("failed to get incomes: %w", context.Canceled), dtos.StatusClientClosedRequest, dtos.ErrorCodeRequestCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			// The service must be handed the request's context, which is already cancelled here
+			requestContext := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() != nil })
+			mockFinanceService.On("GetUserIncomes", requestContext, "test-user-123").Return(nil, tt.err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequestWithContext(ctx, "GET", "/api/finance/income", nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.ErrorCode)
+			assert.NotContains(t, response.Message, "context")
+
+			mockFinanceService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinanceHandler_GetIncomes_Paginated(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	incomes := []domain.Income{createTestIncome()}
+	mockFinanceService.On("GetUserIncomesPage", mock.Anything, "test-user-123", "abc", 1).
+		Return(incomes, "next-token", nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/income?cursor=abc&limit=1", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.IncomePageResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Incomes, 1)
+	assert.Equal(t, incomes[0].ID, response.Incomes[0].ID)
+	assert.Equal(t, "next-token", response.NextCursor)
+	assert.True(t, response.HasMore)
+	assert.Equal(t, 1, response.Limit)
+
+	mockFinanceService.AssertExpectations(t)
+	mockFinanceService.AssertNotCalled(t, "GetUserIncomes", mock.Anything, mock.Anything)
+}
+
+func TestFinanceHandler_GetLoans_Paginated_LastPage(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	loans := []domain.Loan{createTestLoan()}
+	mockFinanceService.On("GetUserLoansPage", mock.Anything, "test-user-123", "", 20).Return(loans, "", nil)
+
+	// Act: limit alone asks for the first page
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/loans?limit=20", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.LoanPageResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response.Loans, 1)
+	assert.Empty(t, response.NextCursor)
+	assert.False(t, response.HasMore)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_GetLoans_InvalidPagination_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantCode dtos.ErrorCode
+	}{
+		{"limit_not_a_number", "limit=abc", dtos.ErrorCodeBadRequest},
+		{"limit_zero", "limit=0", dtos.ErrorCodeBadRequest},
+		{"limit_too_large", "limit=101", dtos.ErrorCodeBadRequest},
+		{"invalid_cursor", "cursor=bogus", dtos.ErrorCodeFinInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+			mockFinanceService.On("GetUserLoansPage", mock.Anything, "test-user-123", "bogus", 20).
+				Return(nil, "", fmt.Errorf("%w: not a valid token", domain.ErrInvalidCursor)).Maybe()
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/finance/loans?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantCode, response.ErrorCode)
+		})
+	}
+}
+
+func TestFinanceHandler_GetLoans_RateRange(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	for _, rate := range []float64{4, 6, 9} {
+		postJSON(t, router, "/api/finance/loan", dtos.AddLoanDTO{
+			Lender: fmt.Sprintf("Bank %v%%", rate), Type: "personal", PrincipalAmount: 10000, RemainingBalance: 8000,
+			MonthlyPayment: 300, InterestRate: rate, EndDate: time.Now().AddDate(3, 0, 0),
+		})
+	}
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/loans?min_rate=5&max_rate=8", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var loans []dtos.LoanResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &loans))
+	require.Len(t, loans, 1)
+	assert.Equal(t, 6.0, loans[0].InterestRate)
+
+	// A range without loans is an empty list
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/finance/loans?min_rate=20", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, "[]", w.Body.String())
+}
+
+func TestFinanceHandler_GetLoans_InvalidRateRange_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"min_rate_not_a_number", "min_rate=abc"},
+		{"negative_min_rate", "min_rate=-1"},
+		{"negative_max_rate", "max_rate=-0.5"},
+		{"min_greater_than_max", "min_rate=8&max_rate=5"},
+		{"empty_min_rate", "min_rate=&max_rate=5"},
+		{"combined_with_pagination", "min_rate=5&limit=10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/finance/loans?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, dtos.ErrorCodeBadRequest, response.ErrorCode)
+			mockFinanceService.AssertNotCalled(t, "GetLoansByRateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFinanceHandler_GetDebtBreakdown(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	endDate := time.Now().AddDate(20, 0, 0)
+	postJSON(t, router, "/api/finance/loan", dtos.AddLoanDTO{
+		Lender: "Bank", Type: "mortgage", PrincipalAmount: 300000, RemainingBalance: 240000,
+		MonthlyPayment: 1800, InterestRate: 4, EndDate: endDate,
+	})
+	postJSON(t, router, "/api/finance/loan", dtos.AddLoanDTO{
+		Lender: "Servicer", Type: "student", PrincipalAmount: 40000, RemainingBalance: 30000,
+		MonthlyPayment: 300, InterestRate: 5, EndDate: endDate,
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/debt-breakdown", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response dtos.DebtBreakdownResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 270000.0, response.TotalBalance)
+	assert.Equal(t, 2100.0, response.TotalMonthlyPayment)
+	assert.Equal(t, []dtos.LoanTypeDebtDTO{
+		{Type: "mortgage", LoanCount: 1, RemainingBalance: 240000, MonthlyPayment: 1800, SharePercent: 88.89},
+		{Type: "student", LoanCount: 1, RemainingBalance: 30000, MonthlyPayment: 300, SharePercent: 11.11},
+	}, response.Types)
+	assert.InDelta(t, 100.0, response.Types[0].SharePercent+response.Types[1].SharePercent, 1e-9)
+}
+
+func TestFinanceHandler_GetDebtBreakdown_NoLoans(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/debt-breakdown", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{"currency":"USD","total_balance":0,"total_monthly_payment":0,"types":[]}`, w.Body.String())
+}
+
+func TestFinanceHandler_GetIncomeDiversification(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	postJSON(t, router, "/api/finance/income", dtos.AddIncomeDTO{Source: "Salary", Amount: 3000, Frequency: "monthly"})
+	postJSON(t, router, "/api/finance/income", dtos.AddIncomeDTO{Source: "Rental", Amount: 1500, Frequency: "monthly"})
+	postJSON(t, router, "/api/finance/income", dtos.AddIncomeDTO{Source: "Freelance", Amount: 1500, Frequency: "monthly"})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/income-diversification", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var response dtos.IncomeDiversificationResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 6000.0, response.MonthlyIncome)
+	assert.Equal(t, 3, response.SourceCount)
+	assert.Equal(t, 0.5, response.LargestSourceShare)
+	assert.InDelta(t, 0.375, response.ConcentrationIndex, 1e-9)
+	assert.False(t, response.SingleSourceDependent)
+	assert.Equal(t, dtos.IncomeSourceShareDTO{Source: "Salary", IncomeCount: 1, MonthlyAmount: 3000, Share: 0.5}, response.Sources[0])
+}
+
+func TestFinanceHandler_GetIncomeDiversification_SingleSource(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	postJSON(t, router, "/api/finance/income", dtos.AddIncomeDTO{Source: "Salary", Amount: 4000, Frequency: "monthly"})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/income-diversification", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.JSONEq(t, `{
+		"currency": "USD", "monthly_income": 4000, "source_count": 1,
+		"largest_source_share": 1, "concentration_index": 1, "single_source_dependent": true,
+		"sources": [{"source": "Salary", "income_count": 1, "monthly_amount": 4000, "share": 1}]
+	}`, w.Body.String())
+}
+
 func TestFinanceHandler_UpdateIncome_OnlyOwner(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
@@ -438,6 +981,7 @@ func TestFinanceHandler_UpdateIncome_ForbiddenAccess(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, response.Code)
 	assert.Equal(t, "not_found", response.Error)
+	assert.Equal(t, dtos.ErrorCodeFinIncomeNotFound, response.ErrorCode)
 
 	mockFinanceService.AssertExpectations(t)
 }
@@ -466,53 +1010,318 @@ func TestFinanceHandler_DeleteIncome_SoftDelete(t *testing.T) {
 	mockFinanceService.AssertExpectations(t)
 }
 
-// ==================== EXPENSE TESTS ====================
-
-func TestFinanceHandler_AddExpense_RequiresAuth(t *testing.T) {
+func TestFinanceHandler_RestoreIncome_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
-	router := setupUnauthenticatedTestRouter(mockFinanceService) // No auth middleware
-
-	addExpenseRequest := dtos.AddExpenseDTO{
-		Category:  "housing",
-		Name:      "Monthly Rent",
-		Amount:    1200.00,
-		Frequency: "monthly",
-		IsFixed:   true,
-		Priority:  1,
-	}
+	router := setupFinanceTestRouter(mockFinanceService)
 
-	requestBody, _ := json.Marshal(addExpenseRequest)
+	mockFinanceService.On("RestoreIncome", mock.Anything, "test-user-123", "income-123").Return(nil)
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("POST", "/api/finance/income/income-123/restore", nil)
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response dtos.ErrorResponseDTO
+	var response map[string]interface{}
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
 
-	assert.Equal(t, http.StatusUnauthorized, response.Code)
-	assert.Equal(t, "unauthorized", response.Error)
+	assert.Equal(t, "Income restored successfully", response["message"])
 
-	// Service should not be called
-	mockFinanceService.AssertNotCalled(t, "AddExpense")
+	mockFinanceService.AssertExpectations(t)
 }
 
-func TestFinanceHandler_AddExpense_Success(t *testing.T) {
-	// Arrange
-	mockFinanceService := new(MockFinanceService)
-	router := setupFinanceTestRouter(mockFinanceService)
+func TestFinanceHandler_RestoreIncome_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedErr  dtos.ErrorCode
+	}{
+		{"not_deleted_or_missing", domain.ErrIncomeNotFound, http.StatusNotFound, dtos.ErrorCodeFinIncomeNotFound},
+		{"other_users_income", domain.ErrIncomeNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinIncomeNotOwned},
+	}
 
-	addExpenseRequest := dtos.AddExpenseDTO{
-		Category:  "housing",
-		Name:      "Monthly Rent",
-		Amount:    1200.00,
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			mockFinanceService.On("RestoreIncome", mock.Anything, "test-user-123", "income-123").Return(tt.serviceErr)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/income/income-123/restore", nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedErr, response.ErrorCode)
+		})
+	}
+}
+
+func TestFinanceHandler_RestoreExpense_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("RestoreExpense", mock.Anything, "test-user-123", "expense-123").Return(nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense/expense-123/restore", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Expense restored successfully", response["message"])
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_RecordInstallmentPaid_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	start := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockFinanceService.On("RecordInstallmentPaid", mock.Anything, "test-user-123", "expense-123").Return(domain.Expense{
+		ID:                "expense-123",
+		UserID:            "test-user-123",
+		Name:              "Laptop",
+		Amount:            1200,
+		Frequency:         domain.ExpenseFrequencyMonthly,
+		InstallmentsTotal: 12,
+		InstallmentsPaid:  12,
+		InstallmentStart:  start,
+	}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense/expense-123/installment-paid", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ExpenseResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 12, response.InstallmentsPaid)
+	assert.Equal(t, 100.0, response.InstallmentAmount)
+	assert.False(t, response.IsActive)
+	require.NotNil(t, response.FinalInstallmentDate)
+	assert.True(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Equal(*response.FinalInstallmentDate))
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_RecordInstallmentPaid_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedErr  dtos.ErrorCode
+	}{
+		{"already_paid_off", domain.ErrInstallmentsComplete, http.StatusConflict, dtos.ErrorCodeFinInstallmentsComplete},
+		{"not_an_installment_expense", fmt.Errorf("not paid in installments: %w", domain.ErrInvalidExpenseData), http.StatusBadRequest, dtos.ErrorCodeFinInvalidExpense},
+		{"other_users_expense", domain.ErrExpenseNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinExpenseNotOwned},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			mockFinanceService.On("RecordInstallmentPaid", mock.Anything, "test-user-123", "expense-123").Return(domain.Expense{}, tt.serviceErr)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/expense/expense-123/installment-paid", nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedErr, response.ErrorCode)
+		})
+	}
+}
+
+func TestFinanceHandler_AddExpense_Installments(t *testing.T) {
+	t.Run("monthly_installments_accepted", func(t *testing.T) {
+		mockFinanceService := new(MockFinanceService)
+		router := setupFinanceTestRouter(mockFinanceService)
+		mockFinanceService.On("AddExpense", mock.Anything, mock.MatchedBy(func(expense domain.Expense) bool {
+			return expense.InstallmentsTotal == 12 && expense.InstallmentsPaid == 2 && !expense.InstallmentStart.IsZero()
+		}), false).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBufferString(
+			`{"category":"other","name":"Laptop","amount":1200,"frequency":"monthly","priority":2,"installments_total":12,"installments_paid":2}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockFinanceService.AssertExpectations(t)
+	})
+
+	invalid := map[string]string{
+		"weekly_frequency": `{"category":"other","name":"Laptop","amount":1200,"frequency":"weekly","priority":2,"installments_total":12}`,
+		"too_few":          `{"category":"other","name":"Laptop","amount":1200,"frequency":"monthly","priority":2,"installments_total":1}`,
+		"too_many":         `{"category":"other","name":"Laptop","amount":1200,"frequency":"monthly","priority":2,"installments_total":61}`,
+		"paid_over_total":  `{"category":"other","name":"Laptop","amount":1200,"frequency":"monthly","priority":2,"installments_total":12,"installments_paid":13}`,
+	}
+	for name, body := range invalid {
+		t.Run(name, func(t *testing.T) {
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockFinanceService.AssertNotCalled(t, "AddExpense", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFinanceHandler_BulkDeleteExpenses_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("BulkDeleteExpenses", mock.Anything, "test-user-123", []string{"expense-1", "expense-2"}).Return(2, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/finance/expenses", bytes.NewBufferString(`{"ids":["expense-1","expense-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.BulkDeleteExpensesResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Deleted)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_BulkDeleteExpenses_RejectsWholeBatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"expense owned by another user", fmt.Errorf("expense expense-2: %w", domain.ErrExpenseNotOwnedByUser), http.StatusForbidden},
+		{"missing expense", fmt.Errorf("expense expense-2: %w", domain.ErrExpenseNotFound), http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			mockFinanceService.On("BulkDeleteExpenses", mock.Anything, "test-user-123", []string{"expense-1", "expense-2"}).Return(0, tt.err)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("DELETE", "/api/finance/expenses", bytes.NewBufferString(`{"ids":["expense-1","expense-2"]}`))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			mockFinanceService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestFinanceHandler_BulkDeleteExpenses_EmptyIDs(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	for _, body := range []string{`{"ids":[]}`, `{}`, `{"ids":[""]}`} {
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api/finance/expenses", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	mockFinanceService.AssertNotCalled(t, "BulkDeleteExpenses", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// ==================== EXPENSE TESTS ====================
+
+func TestFinanceHandler_AddExpense_RequiresAuth(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupUnauthenticatedTestRouter(mockFinanceService) // No auth middleware
+
+	addExpenseRequest := dtos.AddExpenseDTO{
+		Category:  "housing",
+		Name:      "Monthly Rent",
+		Amount:    1200.00,
+		Frequency: "monthly",
+		IsFixed:   true,
+		Priority:  1,
+	}
+
+	requestBody, _ := json.Marshal(addExpenseRequest)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	assert.Equal(t, "unauthorized", response.Error)
+
+	// Service should not be called
+	mockFinanceService.AssertNotCalled(t, "AddExpense")
+}
+
+func TestFinanceHandler_AddExpense_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	addExpenseRequest := dtos.AddExpenseDTO{
+		Category:  "housing",
+		Name:      "Monthly Rent",
+		Amount:    1200.00,
 		Frequency: "monthly",
 		IsFixed:   true,
 		Priority:  1,
@@ -524,7 +1333,7 @@ func TestFinanceHandler_AddExpense_Success(t *testing.T) {
 			expense.Name == addExpenseRequest.Name &&
 			expense.Amount == addExpenseRequest.Amount &&
 			expense.Priority == addExpenseRequest.Priority
-	})).Return(nil)
+	}), false).Return(nil)
 
 	requestBody, _ := json.Marshal(addExpenseRequest)
 
@@ -582,7 +1391,7 @@ func TestFinanceHandler_GetExpenses_WithCategoryFilter(t *testing.T) {
 
 	expectedExpenses := []domain.Expense{createTestExpense()}
 
-	mockFinanceService.On("GetUserExpensesByCategory", mock.Anything, "test-user-123", "housing").
+	mockFinanceService.On("SearchUserExpenses", mock.Anything, "test-user-123", domain.ExpenseFilter{Category: "housing"}).
 		Return(expectedExpenses, nil)
 
 	// Act
@@ -603,6 +1412,69 @@ func TestFinanceHandler_GetExpenses_WithCategoryFilter(t *testing.T) {
 	mockFinanceService.AssertExpectations(t)
 }
 
+func TestFinanceHandler_GetExpenses_CombinedFilters(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	fixed := true
+	expectedFilter := domain.ExpenseFilter{
+		Query:         "rent",
+		Category:      "housing",
+		MinAmount:     500,
+		MaxAmount:     1500,
+		IsFixed:       &fixed,
+		Priority:      1,
+		Frequency:     "monthly",
+		CreatedFrom:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+	}
+	mockFinanceService.On("SearchUserExpenses", mock.Anything, "test-user-123", expectedFilter).
+		Return([]domain.Expense{createTestExpense()}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/expenses?q=rent&category=housing&min_amount=500&max_amount=1500"+
+		"&is_fixed=true&priority=1&frequency=monthly&created_from=2025-01-01&created_to=2025-01-31", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_GetExpenses_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		callErr error
+	}{
+		{name: "min greater than max", query: "min_amount=500&max_amount=100", callErr: fmt.Errorf("%w: min amount must not be greater than max amount", domain.ErrInvalidExpenseFilter)},
+		{name: "malformed amount", query: "min_amount=lots"},
+		{name: "malformed date", query: "created_from=01/02/2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+			if tt.callErr != nil {
+				mockFinanceService.On("SearchUserExpenses", mock.Anything, "test-user-123", mock.Anything).Return(nil, tt.callErr)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/finance/expenses?"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockFinanceService.AssertExpectations(t)
+		})
+	}
+}
+
 // ==================== LOAN TESTS ====================
 
 func TestFinanceHandler_AddLoan_Success(t *testing.T) {
@@ -683,227 +1555,443 @@ func TestFinanceHandler_AddLoan_ValidationError(t *testing.T) {
 	mockFinanceService.AssertNotCalled(t, "AddLoan")
 }
 
-// ==================== SUMMARY & AFFORDABILITY TESTS ====================
-
-func TestFinanceHandler_GetFinanceSummary_Success(t *testing.T) {
+func TestFinanceHandler_SimulateLoanPayoff_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	expectedSummary := createTestFinanceSummary()
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	simulation, err := domain.SimulateLoanPayoff(createTestLoan(), 500.0, 0, start)
+	require.NoError(t, err)
+	mockFinanceService.On("SimulateLoanPayoff", mock.Anything, "test-user-123", "loan-123", 500.0, 0.0).Return(simulation, nil)
 
-	mockFinanceService.On("CalculateFinanceSummary", mock.Anything, "test-user-123").
-		Return(expectedSummary, nil)
+	requestBody, _ := json.Marshal(dtos.SimulateLoanPayoffDTO{ExtraMonthlyPayment: 500.0})
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/finance/summary", nil)
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/simulate", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response dtos.FinanceSummaryResponseDTO
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, expectedSummary.UserID, response.UserID)
-	assert.Equal(t, expectedSummary.MonthlyIncome, response.MonthlyIncome)
-	assert.Equal(t, expectedSummary.MonthlyExpenses, response.MonthlyExpenses)
-	assert.Equal(t, expectedSummary.FinancialHealth, response.FinancialHealth)
-
+	var response dtos.LoanPayoffSimulationResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "loan-123", response.LoanID)
+	assert.Equal(t, simulation.MonthsSaved, response.MonthsSaved)
+	assert.Equal(t, simulation.InterestSaved, response.InterestSaved)
+	require.NotNil(t, response.BaselinePayoffDate)
+	assert.True(t, response.PayoffDate.Before(*response.BaselinePayoffDate))
 	mockFinanceService.AssertExpectations(t)
 }
 
-func TestFinanceHandler_GetAffordability_Success(t *testing.T) {
+func TestFinanceHandler_SimulateLoanPayoff_PaymentBelowInterest(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	expectedAffordability := 1500.00
+	mockFinanceService.On("SimulateLoanPayoff", mock.Anything, "test-user-123", "loan-123", 10.0, 0.0).
+		Return(domain.LoanPayoffSimulation{}, fmt.Errorf("%w: a monthly payment of 10.00 never repays the loan", domain.ErrPaymentBelowInterest))
 
-	mockFinanceService.On("GetMaxAffordableAmount", mock.Anything, "test-user-123").
-		Return(expectedAffordability, nil)
+	requestBody, _ := json.Marshal(dtos.SimulateLoanPayoffDTO{ExtraMonthlyPayment: 10.0})
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/finance/affordability", nil)
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/simulate", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
 
-	var response map[string]interface{}
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, "test-user-123", response["user_id"])
-	assert.Equal(t, expectedAffordability, response["max_affordable_amount"])
-
-	mockFinanceService.AssertExpectations(t)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinPaymentBelowInterest, response.ErrorCode)
 }
 
-func TestFinanceHandler_GetAffordability_ServiceError(t *testing.T) {
+func TestFinanceHandler_SimulateLoanPayoff_NegativePayment_ReturnsValidationError(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	mockFinanceService.On("GetMaxAffordableAmount", mock.Anything, "test-user-123").
-		Return(0.0, fmt.Errorf("failed to calculate affordability"))
+	requestBody, _ := json.Marshal(dtos.SimulateLoanPayoffDTO{OneTimePayment: -100.0})
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("GET", "/api/finance/affordability", nil)
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/simulate", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
-
-	var response dtos.ErrorResponseDTO
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, http.StatusInternalServerError, response.Code)
-	assert.Equal(t, "internal_error", response.Error)
-
-	mockFinanceService.AssertExpectations(t)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockFinanceService.AssertNotCalled(t, "SimulateLoanPayoff")
 }
 
-// ==================== MALFORMED JSON TESTS ====================
-
-func TestFinanceHandler_AddIncome_MalformedJSON_Returns400(t *testing.T) {
+func TestFinanceHandler_CalculateExtraPaymentImpact_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
+	start := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	impact, err := domain.CalculateExtraPaymentImpact(createTestLoan(), 500.0, start)
+	require.NoError(t, err)
+	mockFinanceService.On("CalculateExtraPaymentImpact", mock.Anything, "test-user-123", "loan-123", 500.0).Return(impact, nil)
+
+	requestBody, _ := json.Marshal(dtos.LoanExtraPaymentDTO{ExtraMonthlyPayment: 500.0})
+
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBufferString("{invalid json"))
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/extra-payment", bytes.NewBuffer(requestBody))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response dtos.ErrorResponseDTO
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
+	var response dtos.LoanExtraPaymentImpactResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "loan-123", response.LoanID)
+	assert.Equal(t, impact.MonthsSaved, response.MonthsSaved)
+	assert.Equal(t, impact.InterestSaved, response.InterestSaved)
+	assert.Equal(t, impact.Accelerated.Months(), response.Months)
+	require.NotNil(t, response.BaselinePayoffDate)
+	assert.True(t, response.PayoffDate.Before(*response.BaselinePayoffDate))
+	mockFinanceService.AssertExpectations(t)
+}
 
-	assert.Equal(t, http.StatusBadRequest, response.Code)
-	assert.Equal(t, "bad_request", response.Error)
+func TestFinanceHandler_CalculateExtraPaymentImpact_NonPositiveExtra_ReturnsValidationError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
 
-	mockFinanceService.AssertNotCalled(t, "AddIncome")
+	for _, body := range []string{`{}`, `{"extra_monthly_payment": 0}`, `{"extra_monthly_payment": -50}`} {
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/extra-payment", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	mockFinanceService.AssertNotCalled(t, "CalculateExtraPaymentImpact")
 }
 
-func TestFinanceHandler_AddExpense_MalformedJSON_Returns400(t *testing.T) {
+func TestFinanceHandler_CalculateExtraPaymentImpact_NotOwner(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
+	mockFinanceService.On("CalculateExtraPaymentImpact", mock.Anything, "test-user-123", "loan-123", 200.0).
+		Return(domain.LoanExtraPaymentImpact{}, domain.ErrLoanNotOwnedByUser)
+
+	requestBody, _ := json.Marshal(dtos.LoanExtraPaymentDTO{ExtraMonthlyPayment: 200.0})
+
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBufferString("{invalid json"))
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/extra-payment", bytes.NewBuffer(requestBody))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response dtos.ErrorResponseDTO
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	require.NoError(t, err)
-
-	assert.Equal(t, http.StatusBadRequest, response.Code)
-	assert.Equal(t, "bad_request", response.Error)
-
-	mockFinanceService.AssertNotCalled(t, "AddExpense")
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
 
-// ==================== NOT FOUND TESTS ====================
-
-func TestFinanceHandler_UpdateIncome_NotFound(t *testing.T) {
+func TestFinanceHandler_RecordLoanPayment_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	updateIncomeRequest := dtos.UpdateIncomeDTO{
-		Source: stringPtr("Updated Source"),
-		Amount: floatPtr(6000.00),
-	}
+	loan := createTestLoan()
+	entry, err := loan.ApplyPayment(1266.71, 0, "June payment", time.Now())
+	require.NoError(t, err)
+	entry.ID = "loanbal-123"
+	mockFinanceService.On("RecordLoanPayment", mock.Anything, "test-user-123", "loan-123", 1266.71, "June payment").Return(entry, nil)
 
-	// Mock GetUserIncomes to return empty slice so income won't be found
-	mockFinanceService.On("GetUserIncomes", mock.Anything, "test-user-123").
-		Return([]domain.Income{}, nil)
-
-	requestBody, _ := json.Marshal(updateIncomeRequest)
+	requestBody, _ := json.Marshal(dtos.LoanPaymentDTO{Amount: 1266.71, Note: " June payment "})
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("PUT", "/api/finance/income/nonexistent", bytes.NewBuffer(requestBody))
+	req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/payment", bytes.NewBuffer(requestBody))
 	req.Header.Set("Content-Type", "application/json")
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var response dtos.LoanBalanceEntryResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "loanbal-123", response.ID)
+	assert.Equal(t, domain.LoanBalancePayment, response.Type)
+	assert.Equal(t, 918.75, response.InterestPortion)
+	assert.Equal(t, 347.96, response.PrincipalPortion)
+	assert.Equal(t, 244652.04, response.NewBalance)
+	mockFinanceService.AssertExpectations(t)
+}
 
-	var response dtos.ErrorResponseDTO
-	err := json.Unmarshal(w.Body.Bytes(), &response)
+func TestFinanceHandler_RecordLoanPayment_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		serviceErr error
+		wantStatus int
+		wantCode   dtos.ErrorCode
+	}{
+		{"missing_amount", `{}`, nil, http.StatusBadRequest, dtos.ErrorCodeValidationFailed},
+		{"negative_amount", `{"amount": -5}`, nil, http.StatusBadRequest, dtos.ErrorCodeValidationFailed},
+		{"more_than_owed", `{"amount": 500}`, fmt.Errorf("%w: payment of 500.00 is more than the 400.00 owed", domain.ErrInvalidLoanData),
+			http.StatusBadRequest, dtos.ErrorCodeFinInvalidLoan},
+		{"not_owner", `{"amount": 500}`, domain.ErrLoanNotOwnedByUser, http.StatusForbidden, dtos.ErrorCodeFinLoanNotOwned},
+		{"not_found", `{"amount": 500}`, domain.ErrLoanNotFound, http.StatusNotFound, dtos.ErrorCodeFinLoanNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+			if tt.serviceErr != nil {
+				mockFinanceService.On("RecordLoanPayment", mock.Anything, "test-user-123", "loan-123", 500.0, "").
+					Return(domain.LoanBalanceEntry{}, tt.serviceErr)
+			}
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/loan/loan-123/payment", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantCode, response.ErrorCode)
+			if tt.name == "more_than_owed" {
+				assert.Contains(t, response.Message, "more than the 400.00 owed")
+			}
+		})
+	}
+}
+
+func TestFinanceHandler_LoanPayments_UpdateHistoryAndDebt(t *testing.T) {
+	// Arrange
+	store := memory.NewStore()
+	service := services.NewFinanceService(services.NewFinanceRepositories(
+		memory.NewIncomeRepository(store),
+		memory.NewExpenseRepository(store),
+		memory.NewLoanRepository(store),
+		nil,
+		nil,
+	))
+	router := setupFinanceTestRouter(service)
+	// 6% on 20,000 is 100.00 of interest a month
+	postJSON(t, router, "/api/finance/loan", dtos.AddLoanDTO{
+		Lender: "Bank", Type: "auto", PrincipalAmount: 25000, RemainingBalance: 20000,
+		MonthlyPayment: 400, InterestRate: 6, EndDate: time.Now().AddDate(5, 0, 0),
+	})
+	loans, err := service.GetUserLoans(context.Background(), "test-user-123")
 	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	loanID := loans[0].ID
 
-	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "not_found", response.Error)
+	// Act
+	postJSON(t, router, "/api/finance/loan/"+loanID+"/payment", dtos.LoanPaymentDTO{Amount: 400, Note: "March"})
+	postJSON(t, router, "/api/finance/loan/"+loanID+"/payment", dtos.LoanPaymentDTO{Amount: 400, Note: "April"})
+
+	// Assert
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/loan/"+loanID+"/history", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var history []dtos.LoanBalanceEntryResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &history))
+	require.Len(t, history, 2)
+	assert.Equal(t, "April", history[0].Note, "newest first")
+	assert.Equal(t, 19700.0, history[1].NewBalance)
+	assert.Equal(t, 98.5, history[0].InterestPortion, "interest is on the balance left after March")
+	assert.Equal(t, 301.5, history[0].PrincipalPortion)
+	assert.Equal(t, 19398.5, history[0].NewBalance)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/api/finance/debt-breakdown", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var debt dtos.DebtBreakdownResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &debt))
+	assert.Equal(t, 19398.5, debt.TotalBalance, "total debt is the latest entry's balance")
+}
+
+func TestFinanceHandler_GetNearPayoffLoans_DefaultThreshold(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	loan := createTestLoan()
+	loan.RemainingBalance = 12500.00
+	nearPayoff := []domain.NearPayoffLoan{{Loan: loan, ThresholdBalance: 25000.00, PercentRemaining: 5.0}}
+	mockFinanceService.On("GetNearPayoffLoans", mock.Anything, "test-user-123", 10.0).Return(nearPayoff, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/loans/near-payoff", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
 
+	var response []dtos.NearPayoffLoanResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "loan-123", response[0].ID)
+	assert.Equal(t, 12500.00, response[0].RemainingBalance)
+	assert.Equal(t, 25000.00, response[0].PayoffThresholdBalance)
+	assert.Equal(t, 5.0, response[0].PercentRemaining)
 	mockFinanceService.AssertExpectations(t)
 }
 
-func TestFinanceHandler_DeleteIncome_NotFound(t *testing.T) {
+func TestFinanceHandler_GetNearPayoffLoans_CustomThreshold_ReturnsEmptyArray(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	mockFinanceService.On("DeleteIncome", mock.Anything, "test-user-123", "nonexistent").
-		Return(fmt.Errorf("income not found"))
+	mockFinanceService.On("GetNearPayoffLoans", mock.Anything, "test-user-123", 2.5).Return([]domain.NearPayoffLoan{}, nil)
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/api/finance/income/nonexistent", nil)
+	req, _ := http.NewRequest("GET", "/api/finance/loans/near-payoff?threshold_percent=2.5", nil)
 	router.ServeHTTP(w, req)
 
 	// Assert
-	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `[]`, w.Body.String())
+	mockFinanceService.AssertExpectations(t)
+}
 
-	var response dtos.ErrorResponseDTO
+func TestFinanceHandler_GetNearPayoffLoans_InvalidThreshold_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	for _, threshold := range []string{"abc", "0", "-5", "150"} {
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/finance/loans/near-payoff?threshold_percent="+threshold, nil)
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, threshold)
+	}
+	mockFinanceService.AssertNotCalled(t, "GetNearPayoffLoans")
+}
+
+// ==================== SUMMARY & AFFORDABILITY TESTS ====================
+
+func TestFinanceHandler_GetFinanceSummary_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	expectedSummary := createTestFinanceSummary()
+
+	mockFinanceService.On("CalculateFinanceSummary", mock.Anything, "test-user-123").
+		Return(expectedSummary, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/summary", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.FinanceSummaryResponseDTO
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	require.NoError(t, err)
 
-	assert.Equal(t, http.StatusNotFound, response.Code)
-	assert.Equal(t, "not_found", response.Error)
+	assert.Equal(t, expectedSummary.UserID, response.UserID)
+	assert.Equal(t, expectedSummary.MonthlyIncome, response.MonthlyIncome)
+	assert.Equal(t, expectedSummary.MonthlyExpenses, response.MonthlyExpenses)
+	assert.Equal(t, expectedSummary.FinancialHealth, response.FinancialHealth)
 
 	mockFinanceService.AssertExpectations(t)
 }
 
-// ==================== SERVICE ERROR TESTS ====================
+func TestFinanceHandler_GetAffordability_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
 
-func TestFinanceHandler_AddIncome_ServiceError_Returns500(t *testing.T) {
+	expectedAffordability := 1500.00
+
+	mockFinanceService.On("GetMaxAffordableAmount", mock.Anything, "test-user-123").
+		Return(expectedAffordability, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/affordability", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, "test-user-123", response["user_id"])
+	assert.Equal(t, expectedAffordability, response["max_affordable_amount"])
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_GetHealthExplanation_Success(t *testing.T) {
 	// Arrange
 	mockFinanceService := new(MockFinanceService)
 	router := setupFinanceTestRouter(mockFinanceService)
 
-	addIncomeRequest := dtos.AddIncomeDTO{
-		Source:    "Test Income",
-		Amount:    1000.00,
-		Frequency: "monthly",
+	explanation := domain.FinancialHealthExplanation{
+		UserID:            "test-user-123",
+		Currency:          "USD",
+		Tier:              domain.HealthFair,
+		DebtToIncomeRatio: 0.2,
+		SavingsRate:       0.08,
+		DisposableIncome:  400,
+		Reasons:           []string{"DTI of 20% is healthy", "savings rate of 8% is below the 10% minimum"},
 	}
+	mockFinanceService.On("ExplainFinancialHealth", mock.Anything, "test-user-123").
+		Return(explanation, nil)
 
-	mockFinanceService.On("AddIncome", mock.Anything, mock.AnythingOfType("domain.Income")).
-		Return(fmt.Errorf("database connection failed"))
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/health-explanation", nil)
+	router.ServeHTTP(w, req)
 
-	requestBody, _ := json.Marshal(addIncomeRequest)
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.FinancialHealthExplanationResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "test-user-123", response.UserID)
+	assert.Equal(t, domain.HealthFair, response.FinancialHealth)
+	assert.Equal(t, 0.2, response.DebtToIncomeRatio)
+	assert.Equal(t, 0.08, response.SavingsRate)
+	assert.Equal(t, 400.0, response.DisposableIncome)
+	assert.Equal(t, explanation.Reasons, response.Reasons)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_GetAffordability_ServiceError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("GetMaxAffordableAmount", mock.Anything, "test-user-123").
+		Return(0.0, fmt.Errorf("failed to calculate affordability"))
 
 	// Act
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBuffer(requestBody))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/api/finance/affordability", nil)
 	router.ServeHTTP(w, req)
 
 	// Assert
@@ -919,13 +2007,1202 @@ func TestFinanceHandler_AddIncome_ServiceError_Returns500(t *testing.T) {
 	mockFinanceService.AssertExpectations(t)
 }
 
-// ==================== HELPER FUNCTIONS ====================
+// ==================== MALFORMED JSON TESTS ====================
 
-func stringPtr(s string) *string {
-	return &s
+func TestFinanceHandler_AddIncome_MalformedJSON_Returns400(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBufferString("{invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Equal(t, "bad_request", response.Error)
+
+	mockFinanceService.AssertNotCalled(t, "AddIncome")
 }
 
-func floatPtr(f float64) *float64 {
-	return &f
+func TestFinanceHandler_AddExpense_MalformedJSON_Returns400(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBufferString("{invalid json"))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Equal(t, "bad_request", response.Error)
+
+	mockFinanceService.AssertNotCalled(t, "AddExpense")
+}
+
+func TestFinanceHandler_CheckPurchaseAffordability_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	plan := domain.PurchasePlan{Price: 1200, PaymentMethod: domain.PaymentMethodInstallments, Months: 12, InterestRate: 9.9}
+	mockFinanceService.On("CheckPurchaseAffordability", mock.Anything, "test-user-123", plan).Return(domain.PurchaseAffordability{
+		UserID:            "test-user-123",
+		Currency:          "USD",
+		Plan:              plan,
+		Verdict:           domain.PurchaseBorderline,
+		LimitingFactor:    domain.LimitingFactorDebtToIncome,
+		MonthlyPayment:    105.40,
+		TotalCost:         1264.80,
+		DebtToIncomeRatio: 0.38,
+		SavingsRate:       0.12,
+	}, nil)
+
+	requestBody, _ := json.Marshal(dtos.PurchaseAffordabilityCheckDTO{
+		Price: 1200, PaymentMethod: "installments", Months: 12, InterestRate: 9.9,
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/affordability/check", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.PurchaseAffordabilityResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "borderline", response.Verdict)
+	assert.Equal(t, "debt_to_income", response.LimitingFactor)
+	assert.Equal(t, 105.40, response.MonthlyPayment)
+	assert.Equal(t, 12, response.Months)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_CheckPurchaseAffordability_InvalidPlan_ReturnsBadRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"installments_without_months", `{"price": 1200, "payment_method": "installments", "months": 0}`},
+		{"negative_interest_rate", `{"price": 1200, "payment_method": "installments", "months": 12, "interest_rate": -2}`},
+		{"unknown_payment_method", `{"price": 1200, "payment_method": "barter"}`},
+		{"missing_price", `{"payment_method": "cash"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/affordability/check", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockFinanceService.AssertNotCalled(t, "CheckPurchaseAffordability", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFinanceHandler_CheckPurchaseAffordability_NoIncome_ReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("CheckPurchaseAffordability", mock.Anything, "test-user-123", mock.Anything).
+		Return(domain.PurchaseAffordability{}, domain.ErrNoIncome)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/affordability/check",
+		bytes.NewBufferString(`{"price": 300, "payment_method": "cash", "horizon_months": 2}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinNoIncome, response.ErrorCode)
+}
+
+func TestFinanceHandler_SuggestExpenseCuts_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	suggestion := domain.ExpenseCutSuggestion{
+		UserID:        "test-user-123",
+		Currency:      "USD",
+		TargetSavings: 1000.0,
+		Cuts: []domain.ExpenseCut{{
+			Expense:        domain.Expense{ID: "expense-1", Name: "Dining out", Category: "entertainment", Priority: 3},
+			MonthlySavings: 300.0,
+		}},
+		TotalSavings:              300.0,
+		DisposableIncome:          500.0,
+		ResultingDisposableIncome: 800.0,
+		TargetReachable:           false,
+		Shortfall:                 700.0,
+	}
+	mockFinanceService.On("SuggestExpenseCuts", mock.Anything, "test-user-123", 1000.0).Return(suggestion, nil)
+
+	requestBody, _ := json.Marshal(dtos.SuggestExpenseCutsDTO{TargetSavings: 1000.0})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/suggest-cuts", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ExpenseCutSuggestionResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Cuts, 1)
+	assert.Equal(t, "expense-1", response.Cuts[0].ExpenseID)
+	assert.Equal(t, 300.0, response.Cuts[0].MonthlySavings)
+	assert.False(t, response.TargetReachable)
+	assert.Equal(t, 700.0, response.Shortfall)
+	assert.Equal(t, 800.0, response.ResultingDisposableIncome)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_SuggestExpenseCuts_InvalidTarget_ReturnsValidationError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	for _, body := range []string{`{}`, `{"target_savings": 0}`, `{"target_savings": -100}`, `{"target_savings": 10.555}`} {
+		// Act
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api/finance/suggest-cuts", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// Assert
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+	mockFinanceService.AssertNotCalled(t, "SuggestExpenseCuts")
 }
 
+func TestFinanceHandler_AddIncome_AmountOverCap_ReturnsFieldError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+	body := `{"source":"Salary","amount":1e300,"frequency":"monthly"}`
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "amount must not exceed 1000000000", response.Fields["amount"])
+	mockFinanceService.AssertNotCalled(t, "AddIncome")
+}
+
+func TestFinanceHandler_AddLoan_OversizedBody_Returns413(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+	body := `{"lender":"Bank","padding":"` + strings.Repeat("x", middleware.DefaultMaxRequestSize) + `"}`
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/loan", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "payload_too_large", response.Error)
+	mockFinanceService.AssertNotCalled(t, "AddLoan")
+}
+
+// ==================== NOT FOUND TESTS ====================
+
+func TestFinanceHandler_UpdateIncome_NotFound(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	updateIncomeRequest := dtos.UpdateIncomeDTO{
+		Source: stringPtr("Updated Source"),
+		Amount: floatPtr(6000.00),
+	}
+
+	// Mock GetUserIncomes to return empty slice so income won't be found
+	mockFinanceService.On("GetUserIncomes", mock.Anything, "test-user-123").
+		Return([]domain.Income{}, nil)
+
+	requestBody, _ := json.Marshal(updateIncomeRequest)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/finance/income/nonexistent", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "not_found", response.Error)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_DeleteIncome_NotFound(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("DeleteIncome", mock.Anything, "test-user-123", "nonexistent").
+		Return(fmt.Errorf("income not found"))
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/finance/income/nonexistent", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNotFound, response.Code)
+	assert.Equal(t, "not_found", response.Error)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+// ==================== SERVICE ERROR TESTS ====================
+
+func TestFinanceHandler_AddIncome_ServiceError_Returns500(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	addIncomeRequest := dtos.AddIncomeDTO{
+		Source:    "Test Income",
+		Amount:    1000.00,
+		Frequency: "monthly",
+	}
+
+	mockFinanceService.On("AddIncome", mock.Anything, mock.AnythingOfType("domain.Income"), false).
+		Return(fmt.Errorf("database connection failed"))
+
+	requestBody, _ := json.Marshal(addIncomeRequest)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "internal_error", response.Error)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+// ==================== HELPER FUNCTIONS ====================
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+// ==================== SAVINGS GOAL TESTS ====================
+
+func createTestSavingsGoal() domain.SavingsGoal {
+	return domain.SavingsGoal{
+		ID:            "goal-123",
+		UserID:        "test-user-123",
+		Name:          "Emergency Fund",
+		TargetAmount:  10000.00,
+		CurrentAmount: 2500.00,
+		TargetDate:    time.Now().AddDate(1, 0, 0),
+		Priority:      domain.GoalPriorityHigh,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+func TestFinanceHandler_AddSavingsGoal_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	targetDate := time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Second)
+	request := dtos.AddSavingsGoalDTO{
+		Name:         "Emergency Fund",
+		TargetAmount: 10000.00,
+		TargetDate:   targetDate,
+		Priority:     1,
+	}
+
+	mockFinanceService.On("AddSavingsGoal", mock.Anything, mock.MatchedBy(func(goal domain.SavingsGoal) bool {
+		return goal.UserID == "test-user-123" && goal.Name == "Emergency Fund" &&
+			goal.TargetAmount == 10000.00 && goal.CurrentAmount == 0 && goal.TargetDate.Equal(targetDate)
+	})).Return(nil)
+
+	requestBody, _ := json.Marshal(request)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/goals", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddSavingsGoal_ValidationError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	requestBody := []byte(`{"name":"Car","target_amount":0,"target_date":"2030-01-01T00:00:00Z","priority":4}`)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/goals", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Contains(t, response.Fields, "target_amount")
+	assert.Contains(t, response.Fields, "priority")
+	mockFinanceService.AssertNotCalled(t, "AddSavingsGoal")
+}
+
+func TestFinanceHandler_AddSavingsGoal_PastTargetDate_Returns400(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	requestBody := []byte(`{"name":"Car","target_amount":5000,"target_date":"2020-01-01T00:00:00Z","priority":2}`)
+	mockFinanceService.On("AddSavingsGoal", mock.Anything, mock.AnythingOfType("domain.SavingsGoal")).
+		Return(fmt.Errorf("%w: target date must be in the future", domain.ErrInvalidSavingsGoalData))
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/goals", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Contains(t, response.Message, "target date must be in the future")
+}
+
+func TestFinanceHandler_GetSavingsGoals_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("GetUserSavingsGoals", mock.Anything, "test-user-123").
+		Return([]domain.SavingsGoal{createTestSavingsGoal()}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/goals", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []dtos.SavingsGoalResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 1)
+	assert.Equal(t, "goal-123", response[0].ID)
+	assert.Equal(t, 7500.00, response[0].RemainingAmount)
+	assert.Equal(t, 25.0, response[0].ProgressPercent)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_UpdateSavingsGoal_AppliesPartialUpdate(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	existing := createTestSavingsGoal()
+	mockFinanceService.On("GetUserSavingsGoals", mock.Anything, "test-user-123").
+		Return([]domain.SavingsGoal{existing}, nil)
+	mockFinanceService.On("UpdateSavingsGoal", mock.Anything, mock.MatchedBy(func(goal domain.SavingsGoal) bool {
+		return goal.ID == "goal-123" && goal.TargetAmount == 12000.00 && goal.Name == existing.Name
+	})).Return(nil)
+
+	requestBody, _ := json.Marshal(dtos.UpdateSavingsGoalDTO{TargetAmount: floatPtr(12000.00)})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/finance/goals/goal-123", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_UpdateSavingsGoal_NotFound(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("GetUserSavingsGoals", mock.Anything, "test-user-123").
+		Return([]domain.SavingsGoal{}, nil)
+
+	requestBody, _ := json.Marshal(dtos.UpdateSavingsGoalDTO{TargetAmount: floatPtr(12000.00)})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/finance/goals/goal-missing", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockFinanceService.AssertNotCalled(t, "UpdateSavingsGoal")
+}
+
+func TestFinanceHandler_DeleteSavingsGoal_Forbidden(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("DeleteSavingsGoal", mock.Anything, "test-user-123", "goal-other").
+		Return(domain.ErrSavingsGoalNotOwnedByUser)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/finance/goals/goal-other", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinSavingsGoalNotOwned, response.ErrorCode)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddBudget_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddBudget", mock.Anything, mock.MatchedBy(func(budget domain.Budget) bool {
+		return budget.UserID == "test-user-123" && budget.Category == "food" && budget.MonthlyLimit == 600.00 && budget.Rollover
+	})).Return(nil)
+
+	requestBody, _ := json.Marshal(dtos.AddBudgetDTO{Category: "food", MonthlyLimit: 600.00, Rollover: true})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/budgets", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddBudget_CategoryTaken_Returns409(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddBudget", mock.Anything, mock.Anything).Return(domain.ErrBudgetExists)
+
+	requestBody := []byte(`{"category":"food","monthly_limit":600}`)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/budgets", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinBudgetExists, response.ErrorCode)
+}
+
+func TestFinanceHandler_AddBudget_ValidationError(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	requestBody := []byte(`{"category":"travel","monthly_limit":0}`)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/budgets", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Contains(t, response.Fields, "category")
+	assert.Contains(t, response.Fields, "monthly_limit")
+	mockFinanceService.AssertNotCalled(t, "AddBudget")
+}
+
+func TestFinanceHandler_UpdateBudget_AppliesPartialUpdate(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	existing := domain.Budget{ID: "budget-123", UserID: "test-user-123", Category: "food", MonthlyLimit: 600.00}
+	mockFinanceService.On("GetUserBudgets", mock.Anything, "test-user-123").
+		Return([]domain.Budget{existing}, nil)
+	mockFinanceService.On("UpdateBudget", mock.Anything, mock.MatchedBy(func(budget domain.Budget) bool {
+		return budget.ID == "budget-123" && budget.MonthlyLimit == 650.00 && budget.Category == "food"
+	})).Return(nil)
+
+	requestBody, _ := json.Marshal(dtos.UpdateBudgetDTO{MonthlyLimit: floatPtr(650.00)})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/finance/budgets/budget-123", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_DeleteIncome_NotOwned_Returns403WithCode(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("DeleteIncome", mock.Anything, "test-user-123", "income-other").
+		Return(domain.ErrIncomeNotOwnedByUser)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/api/finance/income/income-other", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusForbidden, response.Code)
+	assert.Equal(t, "forbidden", response.Error)
+	assert.Equal(t, dtos.ErrorCodeFinIncomeNotOwned, response.ErrorCode)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddIncome_InvalidData_ReturnsCode(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddIncome", mock.Anything, mock.AnythingOfType("domain.Income"), false).
+		Return(domain.ErrInvalidIncomeData)
+
+	requestBody, _ := json.Marshal(dtos.AddIncomeDTO{
+		Source:    "Software Engineer Salary",
+		Amount:    5000.00,
+		Frequency: "monthly",
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/income", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinInvalidIncome, response.ErrorCode)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddGoalContribution_Success(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddGoalContribution", mock.Anything, mock.MatchedBy(func(contribution domain.GoalContribution) bool {
+		return contribution.GoalID == "goal-123" && contribution.UserID == "test-user-123" &&
+			contribution.Amount == 250.00 && contribution.Note == "Bonus" && !contribution.ContributedAt.IsZero()
+	})).Return(nil)
+
+	requestBody, _ := json.Marshal(dtos.AddGoalContributionDTO{Amount: 250.00, Note: "Bonus"})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/goals/goal-123/contributions", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddGoalContribution_GoalNotFound(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddGoalContribution", mock.Anything, mock.AnythingOfType("domain.GoalContribution")).
+		Return(domain.ErrSavingsGoalNotFound)
+
+	requestBody, _ := json.Marshal(dtos.AddGoalContributionDTO{Amount: 250.00})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/goals/goal-missing/contributions", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestFinanceHandler_GetGoalHistory_DefaultsToTwelveMonths(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	history := []domain.GoalContributionMonth{
+		{Month: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Total: 250.00, Contributions: []domain.GoalContribution{
+			{ID: "contrib-1", GoalID: "goal-123", Amount: 250.00, ContributedAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)},
+		}},
+		{Month: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Contributions: []domain.GoalContribution{}},
+	}
+	mockFinanceService.On("GetGoalContributionHistory", mock.Anything, "test-user-123", 12).Return(history, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/goals/history", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []dtos.GoalContributionMonthDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response, 2)
+	assert.Equal(t, "2025-01", response[0].Month)
+	assert.Equal(t, 250.00, response[0].Total)
+	assert.Len(t, response[0].Contributions, 1)
+	assert.NotNil(t, response[1].Contributions)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_GetGoalHistory_InvalidMonths(t *testing.T) {
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	for _, months := range []string{"0", "25", "abc"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/finance/goals/history?months="+months, nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, "months=%s", months)
+	}
+	mockFinanceService.AssertNotCalled(t, "GetGoalContributionHistory")
+}
+
+func TestFinanceHandler_GetFinanceSummary_IncludesGoalWarnings(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	summary := createTestFinanceSummary()
+	summary.GoalsMonthlyCommitment = 500.00
+	summary.GoalProjections = []domain.GoalProjection{{
+		GoalID:           "goal-123",
+		Name:             "Car",
+		TargetDate:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		MonthsRemaining:  12,
+		RequiredMonthly:  500.00,
+		AllocatedMonthly: 200.00,
+		ProjectedAmount:  2400.00,
+		Shortfall:        3600.00,
+	}}
+	mockFinanceService.On("CalculateFinanceSummary", mock.Anything, "test-user-123").Return(summary, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/summary", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.FinanceSummaryResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 500.00, response.GoalsMonthlyCommitment)
+	assert.False(t, response.GoalsAchievable)
+	require.Len(t, response.GoalProjections, 1)
+	assert.False(t, response.GoalProjections[0].Achievable)
+	require.Len(t, response.GoalWarnings, 1)
+	assert.Contains(t, response.GoalWarnings[0], "Car: projected to fall 3600.00 short")
+}
+
+func TestFinanceHandler_AddExpense_Duplicate_Returns409WithExistingID(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("AddExpense", mock.Anything, mock.AnythingOfType("domain.Expense"), false).
+		Return(&domain.DuplicateRecordError{Resource: domain.AuditResourceExpense, ExistingID: "expense-123"})
+
+	requestBody, _ := json.Marshal(dtos.AddExpenseDTO{
+		Category:  "housing",
+		Name:      "Monthly Rent",
+		Amount:    1200.00,
+		Frequency: "monthly",
+		IsFixed:   true,
+		Priority:  1,
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusConflict, w.Code)
+
+	var response dtos.DuplicateRecordResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinDuplicateRecord, response.ErrorCode)
+	assert.Equal(t, "expense-123", response.ExistingID)
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_AddIncome_Force(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		bodyForce    bool
+		expectedCode int
+	}{
+		{"query parameter", "?force=true", false, http.StatusCreated},
+		{"body field", "", true, http.StatusCreated},
+		{"invalid query parameter", "?force=yes-please", false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+			mockFinanceService.On("AddIncome", mock.Anything, mock.AnythingOfType("domain.Income"), true).Return(nil)
+
+			requestBody, _ := json.Marshal(dtos.AddIncomeDTO{
+				Source:    "Software Engineer Salary",
+				Amount:    5000.00,
+				Frequency: "monthly",
+				Force:     tt.bodyForce,
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/api/finance/income"+tt.query, bytes.NewBuffer(requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusCreated {
+				mockFinanceService.AssertExpectations(t)
+			} else {
+				mockFinanceService.AssertNotCalled(t, "AddIncome", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestFinanceHandler_ExportFinanceData_CSVEscaping(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	expense := createTestExpense()
+	expense.Name = "Rent, \"downtown\"\nunit 4"
+	expense.CreatedAt = time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	mockFinanceService.On("ExportExpenses", mock.Anything, "test-user-123", domain.ExpenseFilter{Category: "housing"}).
+		Return([]domain.Expense{expense}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/export?format=csv&type=expenses&category=housing", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t,
+		`attachment; filename="finance-expenses-`+time.Now().UTC().Format("2006-01-02")+`.csv"`,
+		w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "\"Rent, \"\"downtown\"\"\nunit 4\"")
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, dtos.ExpenseCSVHeader, records[0])
+	assert.Equal(t, expense.Name, records[1][2])
+	assert.Equal(t, "2025-03-01T09:30:00Z", records[1][11])
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_ExportFinanceData_JSONAll(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	mockFinanceService.On("ExportExpenses", mock.Anything, "test-user-123", domain.ExpenseFilter{}).
+		Return([]domain.Expense{createTestExpense()}, nil)
+	mockFinanceService.On("ExportIncomes", mock.Anything, "test-user-123", domain.ExpenseFilter{}).
+		Return([]domain.Income{createTestIncome()}, nil)
+	mockFinanceService.On("ExportLoans", mock.Anything, "test-user-123", domain.ExpenseFilter{}).
+		Return(nil, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/export", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `filename="finance-all-`)
+
+	var response struct {
+		Expenses []dtos.ExpenseResponseDTO `json:"expenses"`
+		Incomes  []dtos.IncomeResponseDTO  `json:"incomes"`
+		Loans    []dtos.LoanResponseDTO    `json:"loans"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Expenses, 1)
+	assert.Equal(t, "expense-123", response.Expenses[0].ID)
+	require.Len(t, response.Incomes, 1)
+	assert.Equal(t, "income-123", response.Incomes[0].ID)
+	assert.NotNil(t, response.Loans)
+	assert.Empty(t, response.Loans)
+
+	mockFinanceService.AssertExpectations(t)
+}
+
+func TestFinanceHandler_ExportFinanceData_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "unknown format", query: "?format=xml&type=expenses"},
+		{name: "unknown type", query: "?type=goals"},
+		{name: "csv of every type", query: "?format=csv"},
+		{name: "malformed date", query: "?created_from=yesterday"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockFinanceService := new(MockFinanceService)
+			router := setupFinanceTestRouter(mockFinanceService)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/finance/export"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+			mockFinanceService.AssertNotCalled(t, "ExportExpenses", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestFinanceHandler_ExportFinanceData_InvalidFilter(t *testing.T) {
+	// Arrange
+	mockFinanceService := new(MockFinanceService)
+	router := setupFinanceTestRouter(mockFinanceService)
+
+	filterErr := fmt.Errorf("%w: min amount must not be greater than max amount", domain.ErrInvalidExpenseFilter)
+	mockFinanceService.On("ExportExpenses", mock.Anything, "test-user-123", mock.Anything).Return(nil, filterErr)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/export?type=expenses&min_amount=500&max_amount=100", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "min amount must not be greater than max amount")
+}
+
+// newMemoryFinanceRouter returns a test router backed by a real finance service over in-memory repositories
+func newMemoryFinanceRouter() *gin.Engine {
+	store := memory.NewStore()
+	repos := services.NewFinanceRepositories(
+		memory.NewIncomeRepository(store),
+		memory.NewExpenseRepository(store),
+		memory.NewLoanRepository(store),
+		nil,
+		nil,
+	)
+	return setupFinanceTestRouter(services.NewFinanceService(repos))
+}
+
+// exportCSV fetches a CSV export and returns its rows keyed by column name
+func exportCSV(t *testing.T, router *gin.Engine, exportType string) []map[string]string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/finance/export?format=csv&type="+exportType, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	records, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.NotEmpty(t, records)
+
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(record))
+		for i, column := range records[0] {
+			row[column] = record[i]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// postJSON sends body to path and requires a 201 response
+func postJSON(t *testing.T, router *gin.Engine, path string, body any) {
+	t.Helper()
+	encoded, err := json.Marshal(body)
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, bytes.NewBuffer(encoded))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+}
+
+// sumColumn parses and totals one numeric column of exported rows
+func sumColumn(t *testing.T, rows []map[string]string, column string) float64 {
+	t.Helper()
+	var total float64
+	for _, row := range rows {
+		value, err := strconv.ParseFloat(row[column], 64)
+		require.NoError(t, err)
+		total += value
+	}
+	return total
+}
+
+// There is no CSV import endpoint, so the round trip re-creates each exported row through the
+// add endpoints of a second, empty account and exports that account again
+func TestFinanceHandler_ExportFinanceData_CSVRoundTrip(t *testing.T) {
+	// Arrange
+	source := newMemoryFinanceRouter()
+	postJSON(t, source, "/api/finance/expense", dtos.AddExpenseDTO{
+		Category: "housing", Name: "Rent, \"downtown\"\nunit 4", Amount: 1200.5, Frequency: "monthly", IsFixed: true, Priority: 1,
+	})
+	postJSON(t, source, "/api/finance/expense", dtos.AddExpenseDTO{
+		Category: "food", Name: "Groceries", Amount: 99.99, Frequency: "weekly", Priority: 2,
+	})
+	postJSON(t, source, "/api/finance/income", dtos.AddIncomeDTO{Source: "Salary, net", Amount: 5000, Frequency: "monthly"})
+	postJSON(t, source, "/api/finance/loan", dtos.AddLoanDTO{
+		Lender: "Bank \"A\"", Type: "auto", PrincipalAmount: 20000, RemainingBalance: 15000.25,
+		MonthlyPayment: 450, InterestRate: 5.5, EndDate: time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC),
+	})
+	target := newMemoryFinanceRouter()
+
+	// Act
+	expenses := exportCSV(t, source, "expenses")
+	for _, row := range expenses {
+		amount, _ := strconv.ParseFloat(row["amount"], 64)
+		priority, _ := strconv.Atoi(row["priority"])
+		postJSON(t, target, "/api/finance/expense?force=true", dtos.AddExpenseDTO{
+			Category: row["category"], Name: row["name"], Amount: amount, Currency: row["currency"],
+			Frequency: row["frequency"], IsFixed: row["is_fixed"] == "true", Priority: priority,
+		})
+	}
+	incomes := exportCSV(t, source, "incomes")
+	for _, row := range incomes {
+		amount, _ := strconv.ParseFloat(row["amount"], 64)
+		postJSON(t, target, "/api/finance/income?force=true", dtos.AddIncomeDTO{
+			Source: row["source"], Amount: amount, Currency: row["currency"], Frequency: row["frequency"],
+		})
+	}
+	loans := exportCSV(t, source, "loans")
+	for _, row := range loans {
+		endDate, err := time.Parse(time.RFC3339, row["end_date"])
+		require.NoError(t, err)
+		loan := dtos.AddLoanDTO{Lender: row["lender"], Type: row["type"], Currency: row["currency"], EndDate: endDate}
+		loan.PrincipalAmount, _ = strconv.ParseFloat(row["principal_amount"], 64)
+		loan.RemainingBalance, _ = strconv.ParseFloat(row["remaining_balance"], 64)
+		loan.MonthlyPayment, _ = strconv.ParseFloat(row["monthly_payment"], 64)
+		loan.InterestRate, _ = strconv.ParseFloat(row["interest_rate"], 64)
+		postJSON(t, target, "/api/finance/loan", loan)
+	}
+
+	// Assert
+	require.Len(t, expenses, 2)
+	require.Len(t, incomes, 1)
+	require.Len(t, loans, 1)
+
+	reimported := exportCSV(t, target, "expenses")
+	require.Len(t, reimported, 2)
+	assert.InDelta(t, 1300.49, sumColumn(t, reimported, "amount"), 0.001)
+	assert.Equal(t, sumColumn(t, expenses, "amount"), sumColumn(t, reimported, "amount"))
+	assert.Equal(t, expenses[0]["name"], reimported[0]["name"])
+
+	reimported = exportCSV(t, target, "incomes")
+	assert.Equal(t, sumColumn(t, incomes, "amount"), sumColumn(t, reimported, "amount"))
+	assert.Equal(t, "Salary, net", reimported[0]["source"])
+
+	reimported = exportCSV(t, target, "loans")
+	assert.Equal(t, sumColumn(t, loans, "remaining_balance"), sumColumn(t, reimported, "remaining_balance"))
+	assert.Equal(t, loans[0]["end_date"], reimported[0]["end_date"])
+	assert.Equal(t, "Bank \"A\"", reimported[0]["lender"])
+}
+
+func TestFinanceHandler_ExpenseTags(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	postJSON(t, router, "/api/finance/expense", dtos.AddExpenseDTO{
+		Category: "transport", Name: "Flights", Amount: 400, Frequency: "monthly", Priority: 3,
+		Tags: []string{"  Vacation", "SUMMER   trip", "vacation"},
+	})
+	postJSON(t, router, "/api/finance/expense", dtos.AddExpenseDTO{
+		Category: "housing", Name: "Rent", Amount: 1200, Frequency: "monthly", Priority: 1,
+	})
+	listByTag := func(tag string) []dtos.ExpenseResponseDTO {
+		t.Helper()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/api/finance/expenses?tag="+url.QueryEscape(tag), nil)
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var expenses []dtos.ExpenseResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &expenses))
+		return expenses
+	}
+
+	// Act
+	tagged := listByTag(" VACATION ")
+	untagged := listByTag("rent")
+
+	// Assert
+	require.Len(t, tagged, 1)
+	assert.Equal(t, "Flights", tagged[0].Name)
+	assert.Equal(t, []string{"vacation", "summer trip"}, tagged[0].Tags)
+	assert.Empty(t, untagged)
+
+	// Updating the tags replaces them
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/api/finance/expense/"+tagged[0].ID, strings.NewReader(`{"tags":["Work"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Empty(t, listByTag("vacation"))
+	require.Len(t, listByTag("work"), 1)
+}
+
+func TestFinanceHandler_AddExpense_InvalidTags(t *testing.T) {
+	// Arrange
+	router := newMemoryFinanceRouter()
+	body := `{"category":"transport","name":"Flights","amount":400,"frequency":"monthly","priority":3,"tags":["vacation","trip #2"]}`
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/api/finance/expense", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeFinInvalidExpense, response.ErrorCode)
+	assert.Contains(t, response.Message, `tag "trip #2" may only contain`)
+}
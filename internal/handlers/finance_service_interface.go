@@ -10,31 +10,106 @@ import (
 // This interface is consumed by FinanceHandler in this package
 type FinanceService interface {
 	// Income operations
-	AddIncome(ctx context.Context, income domain.Income) error
+	// AddIncome returns a *domain.DuplicateRecordError if the income matches one added recently,
+	// unless allowDuplicate is set
+	AddIncome(ctx context.Context, income domain.Income, allowDuplicate bool) error
 	UpdateIncome(ctx context.Context, income domain.Income) error
 	DeleteIncome(ctx context.Context, userID, incomeID string) error
+	RestoreIncome(ctx context.Context, userID, incomeID string) error
 	GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error)
 	GetActiveUserIncomes(ctx context.Context, userID string) ([]domain.Income, error)
+	// GetUserIncomesPage returns one page of incomes and the cursor for the next page ("" on the last page)
+	// Returns domain.ErrInvalidCursor if the cursor is malformed
+	GetUserIncomesPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Income, string, error)
 
 	// Expense operations  
-	AddExpense(ctx context.Context, expense domain.Expense) error
+	// AddExpense returns a *domain.DuplicateRecordError if the expense matches one added recently,
+	// unless allowDuplicate is set
+	AddExpense(ctx context.Context, expense domain.Expense, allowDuplicate bool) error
 	UpdateExpense(ctx context.Context, expense domain.Expense) error
 	DeleteExpense(ctx context.Context, userID, expenseID string) error
+	RestoreExpense(ctx context.Context, userID, expenseID string) error
+	// RecordInstallmentPaid increments an installment expense's progress and returns it
+	// Returns domain.ErrInstallmentsComplete if every installment is already paid
+	RecordInstallmentPaid(ctx context.Context, userID, expenseID string) (domain.Expense, error)
+	// BulkDeleteExpenses deletes all of the expenses or none of them and returns the number deleted
+	// Returns an error wrapping domain.ErrExpenseNotFound or domain.ErrExpenseNotOwnedByUser if any can't be deleted
+	BulkDeleteExpenses(ctx context.Context, userID string, ids []string) (int, error)
 	GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
 	GetUserExpensesByCategory(ctx context.Context, userID, category string) ([]domain.Expense, error)
+	SearchUserExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error)
+	// ExportExpenses passes each expense matching the filter to fn, oldest first, without loading them all at once
+	// Returns an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+	ExportExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Expense) error) error
 
 	// Loan operations
 	AddLoan(ctx context.Context, loan domain.Loan) error
 	UpdateLoan(ctx context.Context, loan domain.Loan) error
 	GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error)
+	// GetUserLoansPage returns one page of loans and the cursor for the next page ("" on the last page)
+	// Returns domain.ErrInvalidCursor if the cursor is malformed
+	GetUserLoansPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Loan, string, error)
+	// GetLoansByRateRange returns the loans with an interest rate within [minRate, maxRate], highest rate first
+	// Returns an error wrapping domain.ErrInvalidLoanData if a rate is negative or minRate exceeds maxRate
+	GetLoansByRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error)
+	// ExportIncomes and ExportLoans pass each record created within the filter's date range to fn, oldest first
+	// Return an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+	ExportIncomes(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Income) error) error
+	ExportLoans(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Loan) error) error
 	UpdateLoanBalance(ctx context.Context, userID, loanID string, newBalance float64) error
+	// RecordLoanPayment records a payment, split into interest and principal, and returns its balance entry
+	// Returns an error wrapping domain.ErrInvalidLoanData if the amount isn't positive or is more than is owed
+	RecordLoanPayment(ctx context.Context, userID, loanID string, amount float64, note string) (domain.LoanBalanceEntry, error)
+	// GetLoanBalanceHistory returns every change to the loan's balance, newest first
+	GetLoanBalanceHistory(ctx context.Context, userID, loanID string) ([]domain.LoanBalanceEntry, error)
+	// SimulateLoanPayoff projects the loan's payoff with extra payments without changing it
+	// Returns an error wrapping domain.ErrInvalidLoanData if the payments are invalid, or
+	// domain.ErrPaymentBelowInterest if they still never repay the loan
+	SimulateLoanPayoff(ctx context.Context, userID, loanID string, extraMonthly, oneTimePayment float64) (domain.LoanPayoffSimulation, error)
+	// CalculateExtraPaymentImpact compares the loan's payoff with and without an extra monthly payment
+	// Returns an error wrapping domain.ErrInvalidLoanData if the extra payment isn't positive, or
+	// domain.ErrPaymentBelowInterest if the loan still would never be repaid
+	CalculateExtraPaymentImpact(ctx context.Context, userID, loanID string, extraMonthly float64) (domain.LoanExtraPaymentImpact, error)
+	// GetNearPayoffLoans returns the loans with at most thresholdPercent of their principal left to repay
+	// Returns an error wrapping domain.ErrInvalidLoanData if thresholdPercent is outside (0, 100]
+	GetNearPayoffLoans(ctx context.Context, userID string, thresholdPercent float64) ([]domain.NearPayoffLoan, error)
+	// GetDebtBreakdownByType groups the user's loans by type with each type's share of the total debt
+	GetDebtBreakdownByType(ctx context.Context, userID string) (domain.DebtBreakdown, error)
+
+	// Savings goal operations
+	AddSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error
+	UpdateSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error
+	DeleteSavingsGoal(ctx context.Context, userID, goalID string) error
+	GetUserSavingsGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error)
+	AddGoalContribution(ctx context.Context, contribution domain.GoalContribution) error
+	GetGoalContributionHistory(ctx context.Context, userID string, months int) ([]domain.GoalContributionMonth, error)
+
+	// Budget operations
+	AddBudget(ctx context.Context, budget domain.Budget) error
+	UpdateBudget(ctx context.Context, budget domain.Budget) error
+	DeleteBudget(ctx context.Context, userID, budgetID string) error
+	GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error)
 
 	// Financial analysis
 	CalculateFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error)
 	CalculateDisposableIncome(ctx context.Context, userID string) (float64, error)
 	CalculateDebtToIncomeRatio(ctx context.Context, userID string) (float64, error)
 	EvaluateFinancialHealth(ctx context.Context, userID string) (string, error)
+	// ExplainFinancialHealth returns the user's financial health tier with the metrics driving it
+	// and plain-language reasons
+	ExplainFinancialHealth(ctx context.Context, userID string) (domain.FinancialHealthExplanation, error)
+	// GetIncomeDiversification measures how concentrated the user's recurring income is across its sources
+	GetIncomeDiversification(ctx context.Context, userID string) (domain.IncomeDiversification, error)
 	GetMaxAffordableAmount(ctx context.Context, userID string) (float64, error)
+	// CheckPurchaseAffordability weighs a planned purchase against the user's finances
+	// Returns an error wrapping domain.ErrInvalidPurchaseData for an invalid plan, or domain.ErrNoIncome
+	// when the user has no income
+	CheckPurchaseAffordability(ctx context.Context, userID string, plan domain.PurchasePlan) (domain.PurchaseAffordability, error)
+	// SuggestExpenseCuts suggests discretionary expenses to cut to free up targetSavings a month
+	// Returns an error wrapping domain.ErrInvalidFinanceData if targetSavings isn't positive
+	SuggestExpenseCuts(ctx context.Context, userID string, targetSavings float64) (domain.ExpenseCutSuggestion, error)
+	// BaseCurrency returns the ISO 4217 code summaries and affordability amounts are expressed in
+	BaseCurrency() string
 
 	// Helper functions
 	NormalizeToMonthly(amount float64, frequency string) (float64, error)
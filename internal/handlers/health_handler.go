@@ -1,138 +1,217 @@
 package handlers
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"strings"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
 	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
 // HealthHandler handles health-related HTTP requests
 type HealthHandler struct {
 	healthService services.HealthService
+	validator     *validator.Validate
 }
 
 // NewHealthHandler creates a new health handler instance
 func NewHealthHandler(healthService services.HealthService) *HealthHandler {
 	return &HealthHandler{
 		healthService: healthService,
+		validator:     dtos.NewBindingValidator(),
 	}
 }
 
+// bindJSON decodes the JSON request body into request and checks its binding tags.
+// Unlike ShouldBindJSON, failures name the json fields, ready for NewValidationErrorResponseFromError.
+func (h *HealthHandler) bindJSON(c *gin.Context, request any) error {
+	if c.Request.Body == nil {
+		return io.EOF
+	}
+	if err := json.NewDecoder(c.Request.Body).Decode(request); err != nil {
+		return err
+	}
+	return h.validator.Struct(request)
+}
+
 // getUserFromContext extracts user ID from JWT context
 func (h *HealthHandler) getUserFromContext(c *gin.Context) (string, error) {
+	if userID := middleware.GetUserID(c); userID != "" {
+		return userID, nil
+	}
+
 	userID, exists := c.Get("user_id")
 	if !exists {
 		return "", fmt.Errorf("user not authenticated")
 	}
-	
+
 	userIDStr, ok := userID.(string)
 	if !ok {
 		return "", fmt.Errorf("invalid user ID format")
 	}
-	
+
 	return userIDStr, nil
 }
 
+// handleHealthError maps a health service error to its status and error code and writes the response.
+// Unexpected errors are reported as a 500 prefixed with action, e.g. "Failed to get profile".
+func (h *HealthHandler) handleHealthError(c *gin.Context, err error, action string) {
+	status, code := mapHealthError(err)
+
+	var message string
+	switch code {
+	case dtos.ErrorCodeHealthProfileNotFound:
+		message = "Health profile not found"
+	case dtos.ErrorCodeHealthProfileExists:
+		message = "User already has a health profile"
+	case dtos.ErrorCodeHealthConditionNotFound:
+		message = "Condition not found"
+	case dtos.ErrorCodeHealthPolicyNotFound:
+		message = "Policy not found"
+	case dtos.ErrorCodeHealthExpenseNotFound:
+		message = "Medical expense not found"
+	case dtos.ErrorCodeHealthExpenseNotRecurring:
+		message = "Occurrences can only be recorded for recurring expenses"
+	case dtos.ErrorCodeHealthNoPolicies:
+		message = "No policies to compare: supply candidate policies or add an active policy"
+	case dtos.ErrorCodeTimeout, dtos.ErrorCodeRequestCanceled:
+		message, _ = contextErrorMessage(err)
+	case dtos.ErrorCodeInternal:
+		message = action + ": " + err.Error()
+	default:
+		message = err.Error()
+	}
+
+	c.JSON(status, dtos.NewSimpleErrorResponse(code, message))
+}
+
 // CreateProfile creates a new health profile
+//
+//	@Summary	Create the health profile
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string								false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request			body		dtos.CreateHealthProfileRequestDTO	true	"Health profile"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	409				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/profile	[post]
 func (h *HealthHandler) CreateProfile(c *gin.Context) {
 	var requestDTO dtos.CreateHealthProfileRequestDTO
-	
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	// Get user from JWT context for authorization
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
+
 	// Ensure user can only create profile for themselves
 	if requestDTO.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot create profile for another user"})
+		c.JSON(http.StatusForbidden, dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthAccessDenied, "Cannot create profile for another user"))
 		return
 	}
-	
+
 	// Convert DTO to domain
 	profile := requestDTO.ToDomain()
-	
+
 	// Create profile
-	ctx := context.Background()
+	ctx := c.Request.Context()
 	if err := h.healthService.CreateProfile(ctx, profile); err != nil {
-		if strings.Contains(err.Error(), "already has a health profile") {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create profile: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to create profile")
 		return
 	}
-	
+
 	c.JSON(http.StatusCreated, gin.H{"message": "Profile created successfully"})
 }
 
 // GetProfile retrieves the user's health profile
+//
+//	@Summary	Get the health profile
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200				{object}	dtos.HealthProfileResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/profile	[get]
 func (h *HealthHandler) GetProfile(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	profile, err := h.healthService.GetProfile(ctx, userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Health profile not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get profile: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get profile")
 		return
 	}
-	
+
 	// Convert domain to DTO
 	var responseDTO dtos.HealthProfileResponseDTO
 	responseDTO.FromDomain(profile)
-	
+
 	c.JSON(http.StatusOK, responseDTO)
 }
 
 // UpdateProfile updates the user's health profile
+//
+//	@Summary	Update the health profile
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request			body		dtos.UpdateHealthProfileRequestDTO	true	"Fields to change"
+//	@Success	200				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/profile	[put]
 func (h *HealthHandler) UpdateProfile(c *gin.Context) {
 	var requestDTO dtos.UpdateHealthProfileRequestDTO
-	
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
-	
+
+	ctx := c.Request.Context()
+
 	// Get existing profile
 	existingProfile, err := h.healthService.GetProfile(ctx, userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Health profile not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get profile: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get profile")
 		return
 	}
-	
+
 	// Update fields that were provided
 	if requestDTO.Age != 0 {
 		existingProfile.Age = requestDTO.Age
@@ -153,105 +232,484 @@ func (h *HealthHandler) UpdateProfile(c *gin.Context) {
 	if requestDTO.EmergencyFundHealth >= 0 {
 		existingProfile.EmergencyFundHealth = requestDTO.EmergencyFundHealth
 	}
-	
+
 	// Update profile
 	if err := h.healthService.UpdateProfile(ctx, existingProfile); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update profile: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to update profile")
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{"message": "Profile updated successfully"})
 }
 
-// AddCondition adds a new medical condition
+// DeleteProfile deletes the user's health profile along with its conditions, medical expenses
+// and policies; dependent profiles are kept
+//
+//	@Summary	Delete the health profile
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200				{object}	dtos.MessageResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/profile	[delete]
+func (h *HealthHandler) DeleteProfile(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.healthService.DeleteProfile(ctx, userID); err != nil {
+		h.handleHealthError(c, err, "Failed to delete profile")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Profile deleted successfully"})
+}
+
+// GetProfileHistory retrieves the user's weight and BMI history for the last ?months= months
+//
+//	@Summary	Get weight and BMI history
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		months					query		int	false	"Months of history, 1-120"
+//	@Success	200						{object}	dtos.ProfileHistoryResponseDTO
+//	@Failure	400						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/profile/history	[get]
+func (h *HealthHandler) GetProfileHistory(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	months := 12
+	if raw := c.Query("months"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 120 {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "months must be a whole number between 1 and 120"))
+			return
+		}
+		months = parsed
+	}
+
+	ctx := c.Request.Context()
+	history, err := h.healthService.GetProfileHistory(ctx, userID, months)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get profile history")
+		return
+	}
+
+	points := make([]dtos.ProfileSnapshotDTO, len(history.Points))
+	for i, point := range history.Points {
+		points[i] = dtos.ProfileSnapshotDTO{
+			Age:        point.Age,
+			Height:     point.Height,
+			Weight:     point.Weight,
+			BMI:        point.BMI,
+			RecordedAt: point.RecordedAt,
+		}
+	}
+
+	c.JSON(http.StatusOK, dtos.ProfileHistoryResponseDTO{
+		Months:       history.Months,
+		Points:       points,
+		WeightChange: history.WeightChange,
+		BMIChange:    history.BMIChange,
+	})
+}
+
+// CreateDependentProfile adds a family member's health profile to the user's account
+//
+//	@Summary	Add a family member profile
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key	header		string									false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request			body		dtos.CreateDependentProfileRequestDTO	true	"Dependent profile"
+//	@Success	201				{object}	dtos.MessageResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	409				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/family	[post]
+func (h *HealthHandler) CreateDependentProfile(c *gin.Context) {
+	var requestDTO dtos.CreateDependentProfileRequestDTO
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.healthService.CreateDependentProfile(ctx, requestDTO.ToDomain(userID)); err != nil {
+		h.handleHealthError(c, err, "Failed to create dependent profile")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Dependent profile created successfully"})
+}
+
+// GetFamilyProfiles retrieves every profile on the user's account.
+// With ?aggregate=true the response includes risk and cost rolled up across the family.
+//
+//	@Summary	List family profiles
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		aggregate		query		bool	false	"Include the family risk and cost rollup"
+//	@Success	200				{object}	dtos.FamilyProfilesResponseDTO
+//	@Failure	400				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/family	[get]
+func (h *HealthHandler) GetFamilyProfiles(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	aggregate := false
+	if raw := c.Query("aggregate"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "aggregate must be true or false"))
+			return
+		}
+		aggregate = parsed
+	}
+
+	ctx := c.Request.Context()
+	profiles, err := h.healthService.GetFamilyProfiles(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get family profiles")
+		return
+	}
+
+	response := dtos.FamilyProfilesResponseDTO{
+		Profiles: make([]dtos.HealthProfileResponseDTO, len(profiles)),
+		Total:    len(profiles),
+	}
+	for i := range profiles {
+		response.Profiles[i].FromDomain(&profiles[i])
+	}
+
+	if aggregate && len(profiles) > 0 {
+		rollup, err := h.healthService.GetFamilyHealthRollup(ctx, userID)
+		if err != nil {
+			h.handleHealthError(c, err, "Failed to aggregate family health")
+			return
+		}
+		response.Rollup = toFamilyRollupResponse(rollup)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateDependentProfile updates a family member's profile. Fields left out of the request are unchanged.
+//
+//	@Summary	Update a family member profile
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string									true	"Family member profile ID"
+//	@Param		request				body		dtos.UpdateDependentProfileRequestDTO	true	"Fields to update"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/family/{id}	[put]
+func (h *HealthHandler) UpdateDependentProfile(c *gin.Context) {
+	profileID := c.Param("id")
+	if profileID == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Family member ID is required"))
+		return
+	}
+
+	var requestDTO dtos.UpdateDependentProfileRequestDTO
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	member, err := h.healthService.GetFamilyMember(ctx, userID, profileID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get family member")
+		return
+	}
+
+	requestDTO.ApplyTo(member)
+	if err := h.healthService.UpdateDependentProfile(ctx, member); err != nil {
+		h.handleHealthError(c, err, "Failed to update family member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Family member updated successfully"})
+}
+
+// DeleteDependentProfile removes a family member's profile along with their conditions, expenses and policies
+//
+//	@Summary	Remove a family member profile
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id					path		string	true	"Family member profile ID"
+//	@Success	200					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/family/{id}	[delete]
+func (h *HealthHandler) DeleteDependentProfile(c *gin.Context) {
+	profileID := c.Param("id")
+	if profileID == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Family member ID is required"))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.healthService.DeleteDependentProfile(ctx, userID, profileID); err != nil {
+		h.handleHealthError(c, err, "Failed to remove family member")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Family member removed successfully"})
+}
+
+// toFamilyRollupResponse converts a family health rollup to its response DTO
+func toFamilyRollupResponse(rollup *services.FamilyHealthRollup) *dtos.FamilyRollupDTO {
+	response := &dtos.FamilyRollupDTO{
+		Members:                  make([]dtos.FamilyMemberRollupDTO, len(rollup.Members)),
+		HighestRiskScore:         rollup.HighestRiskScore,
+		HighestRiskLevel:         rollup.HighestRiskLevel,
+		AverageRiskScore:         rollup.AverageRiskScore,
+		TotalMonthlyMedicalCost:  rollup.TotalMonthlyMedicalCost,
+		TotalProjectedAnnualCost: rollup.TotalProjectedAnnualCost,
+	}
+	for i, member := range rollup.Members {
+		response.Members[i] = dtos.FamilyMemberRollupDTO(member)
+	}
+	return response
+}
+
+// AddCondition adds a new medical condition, from the condition catalog when catalog_id is given
+//
+//	@Summary	Add a medical condition
+//	@Description	Give a catalog_id from the condition catalog to record the condition under its canonical name
+//	@Description	and category and score it by the catalog's risk weight. Free-text conditions are flagged uncatalogued.
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key		header		string									false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request				body		dtos.CreateMedicalConditionRequestDTO	true	"Condition"
+//	@Success	201					{object}	dtos.MedicalConditionCreatedResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions	[post]
 func (h *HealthHandler) AddCondition(c *gin.Context) {
 	var requestDTO dtos.CreateMedicalConditionRequestDTO
-	
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
+
 	// Ensure user can only add condition for themselves
 	if requestDTO.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot add condition for another user"})
+		c.JSON(http.StatusForbidden, dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthAccessDenied, "Cannot add condition for another user"))
 		return
 	}
-	
+
 	// Convert DTO to domain
 	condition := requestDTO.ToDomain()
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.AddCondition(ctx, condition); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add condition: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to add condition")
 		return
 	}
-	
-	c.JSON(http.StatusCreated, gin.H{"message": "Condition added successfully"})
+
+	response := dtos.MedicalConditionCreatedResponseDTO{Message: "Condition added successfully"}
+	response.Condition.FromDomain(condition)
+	c.JSON(http.StatusCreated, response)
 }
 
 // GetConditions retrieves all medical conditions for the user
+//
+//	@Summary	List medical conditions
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.MedicalConditionListResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions	[get]
 func (h *HealthHandler) GetConditions(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	conditions, err := h.healthService.GetConditions(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get conditions: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get conditions")
 		return
 	}
-	
+
 	// Convert domain to DTOs
 	conditionDTOs := make([]dtos.MedicalConditionResponseDTO, len(conditions))
 	for i, condition := range conditions {
 		conditionDTOs[i].FromDomain(&condition)
 	}
-	
+
 	response := dtos.MedicalConditionListResponseDTO{
 		Conditions: conditionDTOs,
 		Total:      len(conditionDTOs),
 	}
-	
+
+	c.JSON(http.StatusOK, response)
+}
+
+// SearchConditionCatalog suggests condition catalog entries for a partial condition name
+//
+//	@Summary	Search the condition catalog
+//	@Description	Matches the query against each entry's name and aliases, ignoring case and accents and
+//	@Description	tolerating small typos. Pass a suggestion's id as catalog_id when adding a condition.
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		q							query		string	true	"Part of a condition name"
+//	@Param		limit						query		int		false	"Most suggestions to return, 1-50; default 10"
+//	@Success	200							{object}	dtos.ConditionCatalogSearchResponseDTO
+//	@Failure	400							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions/catalog	[get]
+func (h *HealthHandler) SearchConditionCatalog(c *gin.Context) {
+	if _, err := h.getUserFromContext(c); err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	query := c.Query("q")
+	if domain.NormalizeConditionName(query) == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "q must contain at least one letter or digit"))
+		return
+	}
+
+	limit := domain.DefaultConditionCatalogMatches
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > domain.MaxConditionCatalogMatches {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed,
+				fmt.Sprintf("limit must be a whole number between 1 and %d", domain.MaxConditionCatalogMatches)))
+			return
+		}
+		limit = parsed
+	}
+
+	ctx := c.Request.Context()
+	matches, err := h.healthService.SearchConditionCatalog(ctx, query, limit)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to search condition catalog")
+		return
+	}
+
+	response := dtos.ConditionCatalogSearchResponseDTO{
+		Query:       query,
+		Suggestions: make([]dtos.ConditionCatalogEntryDTO, len(matches)),
+		Total:       len(matches),
+	}
+	for i, match := range matches {
+		response.Suggestions[i].FromDomain(match)
+	}
+
 	c.JSON(http.StatusOK, response)
 }
 
 // UpdateCondition updates a medical condition
+//
+//	@Summary	Update a medical condition
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string									true	"Condition ID"
+//	@Param		request					body		dtos.UpdateMedicalConditionRequestDTO	true	"Fields to change"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions/{id}	[put]
 func (h *HealthHandler) UpdateCondition(c *gin.Context) {
 	conditionID := c.Param("id")
 	if conditionID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Condition ID is required"})
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Condition ID is required"))
 		return
 	}
-	
+
 	var requestDTO dtos.UpdateMedicalConditionRequestDTO
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
+
 	// For now, we'll create a basic condition with the ID and user for update
 	// In a real implementation, you'd first retrieve the existing condition
 	condition := &domain.MedicalCondition{
 		ID:     conditionID,
 		UserID: userID,
 	}
-	
+
 	// Update fields that were provided
 	if requestDTO.Name != "" {
 		condition.Name = requestDTO.Name
@@ -270,267 +728,1192 @@ func (h *HealthHandler) UpdateCondition(c *gin.Context) {
 		condition.RiskFactor = requestDTO.RiskFactor
 	}
 	condition.IsActive = requestDTO.IsActive
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.UpdateCondition(ctx, condition); err != nil {
-		if strings.Contains(err.Error(), "not authorized") {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update condition: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to update condition")
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{"message": "Condition updated successfully"})
 }
 
-// RemoveCondition removes a medical condition
+// RemoveCondition marks a medical condition as resolved
+//
+//	@Summary	Resolve a medical condition
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id						path		string	true	"Condition ID"
+//	@Success	200						{object}	dtos.MessageResponseDTO
+//	@Failure	400						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions/{id}	[delete]
 func (h *HealthHandler) RemoveCondition(c *gin.Context) {
 	conditionID := c.Param("id")
 	if conditionID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Condition ID is required"})
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Condition ID is required"))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.RemoveCondition(ctx, userID, conditionID); err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Condition not found"})
-			return
-		}
-		if strings.Contains(err.Error(), "not authorized") {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to remove condition: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to remove condition")
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{"message": "Condition removed successfully"})
 }
 
+// GetConditionTimeline retrieves the user's conditions in diagnosis order, split into active and resolved
+//
+//	@Summary	Get the condition timeline
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200							{object}	dtos.ConditionTimelineResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/conditions/timeline	[get]
+func (h *HealthHandler) GetConditionTimeline(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	timeline, err := h.healthService.GetConditionTimeline(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get condition timeline")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.ConditionTimelineResponseDTO{
+		Active:   toConditionTimelineEntries(timeline.Active),
+		Resolved: toConditionTimelineEntries(timeline.Resolved),
+	})
+}
+
+// toConditionTimelineEntries converts condition timeline entries to their response DTOs
+func toConditionTimelineEntries(entries []services.ConditionTimelineEntry) []dtos.ConditionTimelineEntryDTO {
+	result := make([]dtos.ConditionTimelineEntryDTO, len(entries))
+	for i, entry := range entries {
+		result[i] = dtos.ConditionTimelineEntryDTO{
+			Status:             entry.Status,
+			DaysSinceDiagnosis: entry.DaysSinceDiagnosis,
+			DurationDays:       entry.DurationDays,
+			StatusHistory:      make([]dtos.ConditionStatusChangeDTO, len(entry.StatusHistory)),
+		}
+		result[i].Condition.FromDomain(&entry.Condition)
+		for j, change := range entry.StatusHistory {
+			result[i].StatusHistory[j] = dtos.ConditionStatusChangeDTO(change)
+		}
+	}
+	return result
+}
+
 // AddExpense adds a new medical expense
+//
+//	@Summary	Add a medical expense
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key		header		string								false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request				body		dtos.CreateMedicalExpenseRequestDTO	true	"Medical expense"
+//	@Success	201					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses	[post]
 func (h *HealthHandler) AddExpense(c *gin.Context) {
 	var requestDTO dtos.CreateMedicalExpenseRequestDTO
-	
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
+
 	// Ensure user can only add expense for themselves
 	if requestDTO.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot add expense for another user"})
+		c.JSON(http.StatusForbidden, dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthAccessDenied, "Cannot add expense for another user"))
 		return
 	}
-	
+
 	// Convert DTO to domain
 	expense := requestDTO.ToDomain()
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.AddExpense(ctx, expense); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add expense: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to add expense")
 		return
 	}
-	
+
 	c.JSON(http.StatusCreated, gin.H{"message": "Expense added successfully"})
 }
 
 // GetExpenses retrieves all medical expenses for the user
+//
+//	@Summary	List medical expenses
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.MedicalExpenseListResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses	[get]
 func (h *HealthHandler) GetExpenses(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	expenses, err := h.healthService.GetExpenses(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get expenses: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get expenses")
 		return
 	}
-	
+
 	// Convert domain to DTOs
 	expenseDTOs := make([]dtos.MedicalExpenseResponseDTO, len(expenses))
 	for i, expense := range expenses {
 		expenseDTOs[i].FromDomain(&expense)
 	}
-	
+
 	response := dtos.MedicalExpenseListResponseDTO{
 		Expenses: expenseDTOs,
 		Total:    len(expenseDTOs),
 	}
-	
+
 	c.JSON(http.StatusOK, response)
 }
 
 // GetRecurringExpenses retrieves recurring medical expenses for the user
+//
+//	@Summary	List recurring medical expenses
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200							{object}	dtos.MedicalExpenseListResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/recurring	[get]
 func (h *HealthHandler) GetRecurringExpenses(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	expenses, err := h.healthService.GetRecurringExpenses(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get recurring expenses: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get recurring expenses")
 		return
 	}
-	
+
 	// Convert domain to DTOs
 	expenseDTOs := make([]dtos.MedicalExpenseResponseDTO, len(expenses))
 	for i, expense := range expenses {
 		expenseDTOs[i].FromDomain(&expense)
 	}
-	
+
 	response := dtos.MedicalExpenseListResponseDTO{
 		Expenses: expenseDTOs,
 		Total:    len(expenseDTOs),
 	}
-	
+
 	c.JSON(http.StatusOK, response)
 }
 
+// AddExpenseOccurrence records what one occurrence of a recurring medical expense actually cost
+//
+//	@Summary		Record an occurrence of a recurring medical expense
+//	@Description	The occurrence inherits the expense's category and coverage.
+//	@Description	The health summary uses recorded occurrences in place of the expense's projected cost for the months that have them.
+//	@Tags			health
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			Idempotency-Key						header		string									false	"Makes retries safe; see Idempotent Retries"
+//	@Param			id									path		string									true	"Medical expense ID"
+//	@Param			request								body		dtos.CreateExpenseOccurrenceRequestDTO	true	"Actual amount and date"
+//	@Success		201									{object}	dtos.ExpenseOccurrenceResponseDTO
+//	@Failure		400									{object}	dtos.ValidationErrorResponseDTO
+//	@Failure		401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		422									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router			/health/expenses/{id}/occurrences	[post]
+func (h *HealthHandler) AddExpenseOccurrence(c *gin.Context) {
+	expenseID := c.Param("id")
+	if expenseID == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Expense ID is required"))
+		return
+	}
+
+	var requestDTO dtos.CreateExpenseOccurrenceRequestDTO
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	occurrence, err := h.healthService.AddExpenseOccurrence(ctx, userID, expenseID, requestDTO.ToDomain())
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to record expense occurrence")
+		return
+	}
+
+	var response dtos.ExpenseOccurrenceResponseDTO
+	response.FromDomain(occurrence)
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetExpenseOccurrences lists the recorded occurrences of a recurring medical expense
+//
+//	@Summary	List the occurrences of a recurring medical expense
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id									path		string	true	"Medical expense ID"
+//	@Success	200									{object}	dtos.ExpenseOccurrenceListResponseDTO
+//	@Failure	400									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/{id}/occurrences	[get]
+func (h *HealthHandler) GetExpenseOccurrences(c *gin.Context) {
+	expenseID := c.Param("id")
+	if expenseID == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Expense ID is required"))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	occurrences, err := h.healthService.GetExpenseOccurrences(ctx, userID, expenseID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get expense occurrences")
+		return
+	}
+
+	occurrenceDTOs := make([]dtos.ExpenseOccurrenceResponseDTO, len(occurrences))
+	for i := range occurrences {
+		occurrenceDTOs[i].FromDomain(&occurrences[i])
+	}
+
+	c.JSON(http.StatusOK, dtos.ExpenseOccurrenceListResponseDTO{
+		Occurrences: occurrenceDTOs,
+		Total:       len(occurrenceDTOs),
+	})
+}
+
+// AddMedicationSchedule starts tracking refills for a medication
+//
+//	@Summary	Track medication refills
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key		header		string									false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request				body		dtos.CreateMedicationScheduleRequestDTO	true	"Medication schedule"
+//	@Success	201					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/medications	[post]
+func (h *HealthHandler) AddMedicationSchedule(c *gin.Context) {
+	var requestDTO dtos.CreateMedicationScheduleRequestDTO
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	schedule := requestDTO.ToDomain(userID)
+
+	ctx := c.Request.Context()
+	if err := h.healthService.AddMedicationSchedule(ctx, schedule); err != nil {
+		h.handleHealthError(c, err, "Failed to add medication schedule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Medication schedule added successfully"})
+}
+
+// GetUpcomingRefills retrieves medications due for refill within the requested number of days
+//
+//	@Summary	List upcoming medication refills
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		within						query		int	false	"Days ahead to look, 0-365"
+//	@Success	200							{object}	dtos.MedicationRefillListResponseDTO
+//	@Failure	400							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/medications/refills	[get]
+func (h *HealthHandler) GetUpcomingRefills(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	withinDays := 7
+	if raw := c.Query("within"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "within must be a whole number of days between 0 and 365"))
+			return
+		}
+		withinDays = parsed
+	}
+
+	ctx := c.Request.Context()
+	refills, err := h.healthService.GetUpcomingRefills(ctx, userID, withinDays)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get upcoming refills")
+		return
+	}
+
+	refillDTOs := make([]dtos.MedicationRefillResponseDTO, len(refills))
+	for i, refill := range refills {
+		refillDTOs[i] = dtos.MedicationRefillResponseDTO{
+			ScheduleID:      refill.Schedule.ID,
+			ConditionID:     refill.Schedule.ConditionID,
+			MedicationName:  refill.Schedule.MedicationName,
+			RefillEveryDays: refill.Schedule.RefillEveryDays,
+			LastFilledDate:  refill.Schedule.LastFilledDate,
+			DueDate:         refill.DueDate,
+			DaysUntilDue:    refill.DaysUntilDue,
+			IsOverdue:       refill.IsOverdue,
+		}
+	}
+
+	c.JSON(http.StatusOK, dtos.MedicationRefillListResponseDTO{
+		Refills:    refillDTOs,
+		WithinDays: withinDays,
+		Total:      len(refillDTOs),
+	})
+}
+
 // AddInsurancePolicy adds a new insurance policy
+//
+//	@Summary	Add an insurance policy
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		Idempotency-Key		header		string									false	"Makes retries safe; see Idempotent Retries"
+//	@Param		request				body		dtos.CreateInsurancePolicyRequestDTO	true	"Insurance policy"
+//	@Success	201					{object}	dtos.MessageResponseDTO
+//	@Failure	400					{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	409					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance	[post]
 func (h *HealthHandler) AddInsurancePolicy(c *gin.Context) {
 	var requestDTO dtos.CreateInsurancePolicyRequestDTO
-	
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
+
 	// Ensure user can only add policy for themselves
 	if requestDTO.UserID != userID {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Cannot add policy for another user"})
+		c.JSON(http.StatusForbidden, dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthAccessDenied, "Cannot add policy for another user"))
 		return
 	}
-	
+
 	// Convert DTO to domain
 	policy := requestDTO.ToDomain()
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.AddInsurancePolicy(ctx, policy); err != nil {
-		if strings.Contains(err.Error(), "already exists") {
-			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to add policy: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to add policy")
 		return
 	}
-	
+
 	c.JSON(http.StatusCreated, gin.H{"message": "Insurance policy added successfully"})
 }
 
 // GetActivePolicies retrieves active insurance policies for the user
+//
+//	@Summary	List active insurance policies
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.InsurancePolicyListResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance	[get]
 func (h *HealthHandler) GetActivePolicies(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	policies, err := h.healthService.GetActivePolicies(ctx, userID)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get policies: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to get policies")
 		return
 	}
-	
+
 	// Convert domain to DTOs
 	policyDTOs := make([]dtos.InsurancePolicyResponseDTO, len(policies))
 	for i, policy := range policies {
 		policyDTOs[i].FromDomain(&policy)
 	}
-	
+
 	response := dtos.InsurancePolicyListResponseDTO{
 		Policies: policyDTOs,
 		Total:    len(policyDTOs),
 	}
-	
+
 	c.JSON(http.StatusOK, response)
 }
 
+// GetExpiringPolicies retrieves active insurance policies that end within the requested number of days
+//
+//	@Summary	List insurance policies due for renewal
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		within						query		int	false	"Days ahead to look, 0-365 (default 30)"
+//	@Success	200							{object}	dtos.ExpiringPolicyListResponseDTO
+//	@Failure	400							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance/expiring	[get]
+func (h *HealthHandler) GetExpiringPolicies(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	withinDays := 30
+	if raw := c.Query("within"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > 365 {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "within must be a whole number of days between 0 and 365"))
+			return
+		}
+		withinDays = parsed
+	}
+
+	ctx := c.Request.Context()
+	expiring, err := h.healthService.GetExpiringPolicies(ctx, userID, withinDays)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get expiring policies")
+		return
+	}
+
+	policyDTOs := make([]dtos.ExpiringPolicyResponseDTO, len(expiring))
+	for i, policy := range expiring {
+		policyDTOs[i].FromDomain(&policy.Policy)
+		policyDTOs[i].DaysUntilExpiry = policy.DaysUntilExpiry
+	}
+
+	c.JSON(http.StatusOK, dtos.ExpiringPolicyListResponseDTO{
+		Policies:   policyDTOs,
+		WithinDays: withinDays,
+		Total:      len(policyDTOs),
+	})
+}
+
 // UpdateDeductibleProgress updates deductible progress for a policy
+//
+//	@Summary	Update deductible progress
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id									path		string							true	"Policy ID"
+//	@Param		request								body		dtos.UpdateDeductibleRequestDTO	true	"Amount met"
+//	@Success	200									{object}	dtos.MessageResponseDTO
+//	@Failure	400									{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance/{id}/deductible	[put]
 func (h *HealthHandler) UpdateDeductibleProgress(c *gin.Context) {
 	policyID := c.Param("id")
 	if policyID == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Policy ID is required"})
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Policy ID is required"))
 		return
 	}
-	
+
 	var requestDTO dtos.UpdateDeductibleRequestDTO
-	if err := c.ShouldBindJSON(&requestDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request data: " + err.Error()})
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 		return
 	}
-	
+
 	_, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	if err := h.healthService.UpdateDeductibleProgress(ctx, policyID, requestDTO.Amount); err != nil {
-		if strings.Contains(err.Error(), "not authorized") {
-			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
-			return
+		h.handleHealthError(c, err, "Failed to update deductible progress")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Deductible progress updated successfully"})
+}
+
+// GetOutOfPocketStatus reports how close a policy is to its out-of-pocket maximum
+//
+//	@Summary	Get out-of-pocket maximum progress
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id									path		string						true	"Policy ID"
+//	@Success	200									{object}	dtos.OutOfPocketStatusDTO
+//	@Failure	400									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	403									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404									{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500									{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance/{id}/oop-status	[get]
+func (h *HealthHandler) GetOutOfPocketStatus(c *gin.Context) {
+	policyID := c.Param("id")
+	if policyID == "" {
+		c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Policy ID is required"))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	status, err := h.healthService.GetOutOfPocketStatus(ctx, userID, policyID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get out-of-pocket status")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.OutOfPocketStatusDTO(*status))
+}
+
+// ComparePolicies ranks insurance policies by projected annual cost for an expected spend
+//
+//	@Summary	Compare insurance policies
+//	@Tags		health
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request						body		dtos.ComparePoliciesRequestDTO	true	"Expected spend and optional candidate policies"
+//	@Success	200							{object}	dtos.PolicyComparisonResponseDTO
+//	@Failure	400							{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	422							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance/compare	[post]
+func (h *HealthHandler) ComparePolicies(c *gin.Context) {
+	var requestDTO dtos.ComparePoliciesRequestDTO
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	candidates := make([]domain.InsurancePolicy, len(requestDTO.Policies))
+	for i, candidate := range requestDTO.Policies {
+		candidates[i] = candidate.ToDomain()
+		candidates[i].UserID = userID
+	}
+
+	ctx := c.Request.Context()
+	comparison, err := h.healthService.ComparePolicies(ctx, userID, candidates, requestDTO.ExpectedAnnualSpend)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to compare policies")
+		return
+	}
+
+	c.JSON(http.StatusOK, toPolicyComparisonResponse(comparison))
+}
+
+// toPolicyComparisonResponse converts a policy comparison to its response DTO
+func toPolicyComparisonResponse(comparison *services.PolicyComparison) dtos.PolicyComparisonResponseDTO {
+	toDTO := func(p services.PolicyCostProjection) dtos.PolicyCostProjectionDTO {
+		return dtos.PolicyCostProjectionDTO{
+			PolicyID:             p.PolicyID,
+			PolicyNumber:         p.PolicyNumber,
+			Provider:             p.Provider,
+			Type:                 p.Type,
+			AnnualPremium:        p.AnnualPremium,
+			InsuranceCoverage:    p.InsuranceCoverage,
+			ProjectedOutOfPocket: p.ProjectedOutOfPocket,
+			TotalProjectedCost:   p.TotalProjectedCost,
+			Rank:                 p.Rank,
 		}
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Policy not found"})
+	}
+
+	response := dtos.PolicyComparisonResponseDTO{
+		ExpectedAnnualSpend: comparison.ExpectedAnnualSpend,
+		Policies:            make([]dtos.PolicyCostProjectionDTO, len(comparison.Policies)),
+		Cheapest:            toDTO(comparison.Cheapest),
+	}
+	for i, projection := range comparison.Policies {
+		response.Policies[i] = toDTO(projection)
+	}
+	return response
+}
+
+// GetCoverageGaps returns a detailed analysis of the user's coverage gaps
+//
+//	@Summary	Analyze coverage gaps
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200						{object}	dtos.CoverageGapsResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/coverage-gaps	[get]
+func (h *HealthHandler) GetCoverageGaps(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	analysis, err := h.healthService.GetCoverageGaps(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to analyze coverage gaps")
+		return
+	}
+
+	c.JSON(http.StatusOK, toCoverageGapsResponse(analysis))
+}
+
+// GetInsuranceEvaluation assesses whether the user's active policies are adequate for their
+// projected annual medical expenses and risk level
+//
+//	@Summary	Evaluate insurance adequacy
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200								{object}	dtos.InsuranceEvaluationResponseDTO
+//	@Failure	401								{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404								{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500								{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/insurance/evaluation	[get]
+func (h *HealthHandler) GetInsuranceEvaluation(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	adequacy, err := h.healthService.EvaluateInsuranceAdequacy(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to evaluate insurance")
+		return
+	}
+
+	response := dtos.InsuranceEvaluationResponseDTO{
+		UserID:                  adequacy.UserID,
+		RiskLevel:               adequacy.RiskLevel,
+		ProjectedAnnualExpenses: adequacy.ProjectedAnnualExpenses,
+		Policies:                make([]dtos.PolicyAdequacyDTO, len(adequacy.Policies)),
+	}
+	for i, policy := range adequacy.Policies {
+		response.Policies[i] = dtos.PolicyAdequacyDTO(policy)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetCostProjection retrieves projected medical costs and insurance payments for the next 12 months
+//
+//	@Summary	Project medical costs for 12 months
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200						{object}	dtos.CostProjectionResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/cost-projection	[get]
+func (h *HealthHandler) GetCostProjection(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	projection, err := h.healthService.GetCostProjection(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to project costs")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.CostProjectionResponseDTO{
+		UserID:                    projection.UserID,
+		ProjectedGrossCost:        projection.ProjectedGrossCost,
+		ExpectedInsurancePayments: projection.ExpectedInsurancePayments,
+		NetOutOfPocket:            projection.NetOutOfPocket,
+		CoveredGrossCost:          projection.CoveredGrossCost,
+		UncoveredGrossCost:        projection.UncoveredGrossCost,
+		AnnualPremiums:            projection.AnnualPremiums,
+		RecurringExpenses:         projection.RecurringExpenses,
+		ExcludedOneTimeExpenses:   projection.ExcludedOneTimeExpenses,
+	})
+}
+
+// GetHSARecommendation retrieves a suggested monthly HSA contribution based on projected medical costs
+//
+//	@Summary	Recommend an HSA contribution
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200							{object}	dtos.HSARecommendationResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/hsa-recommendation	[get]
+func (h *HealthHandler) GetHSARecommendation(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	recommendation, err := h.healthService.RecommendHSAContribution(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to recommend HSA contribution")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.HSARecommendationResponseDTO{
+		UserID:                         recommendation.UserID,
+		Eligible:                       recommendation.Eligible,
+		CoverageTier:                   recommendation.CoverageTier,
+		QualifyingPolicyID:             recommendation.QualifyingPolicyID,
+		ProjectedAnnualExpenses:        recommendation.ProjectedAnnualExpenses,
+		AnnualContributionLimit:        recommendation.AnnualContributionLimit,
+		RecommendedAnnualContribution:  recommendation.RecommendedAnnualContribution,
+		RecommendedMonthlyContribution: recommendation.RecommendedMonthlyContribution,
+		Explanation:                    recommendation.Explanation,
+	})
+}
+
+// GetRiskModel returns the weights used to calculate health risk scores so clients can explain a score
+//
+//	@Summary	Get the health risk scoring model
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200					{object}	dtos.RiskModelResponseDTO
+//	@Failure	401					{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/risk-model	[get]
+func (h *HealthHandler) GetRiskModel(c *gin.Context) {
+	if _, err := h.getUserFromContext(c); err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	var response dtos.RiskModelResponseDTO
+	response.FromDomain(h.healthService.GetRiskModel())
+	c.JSON(http.StatusOK, response)
+}
+
+// RecalculateAllRisk handles POST /api/v1/admin/health/recalculate-risk requests
+// Rescores every user's risk and snapshots the scores that changed; safe to run repeatedly
+//
+//	@Summary	Recalculate every user's health risk score
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200								{object}	dtos.RiskRecalculationResponseDTO
+//	@Failure	401								{object}	dtos.ErrorResponseDTO
+//	@Failure	403								{object}	dtos.ErrorResponseDTO
+//	@Failure	500								{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/admin/health/recalculate-risk	[post]
+func (h *HealthHandler) RecalculateAllRisk(c *gin.Context) {
+	result, err := h.healthService.RecalculateAllRisk(c.Request.Context())
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to recalculate risk scores")
+		return
+	}
+
+	c.JSON(http.StatusOK, dtos.RiskRecalculationResponseDTO{
+		Processed: result.Processed,
+		Updated:   result.Updated,
+	})
+}
+
+// GetRiskHistory retrieves the user's health risk score history for the last ?months= months
+//
+//	@Summary	Get health risk score history
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		months					query		int	false	"Months of history, 1-120"
+//	@Success	200						{object}	dtos.RiskHistoryResponseDTO
+//	@Failure	400						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/risk-history	[get]
+func (h *HealthHandler) GetRiskHistory(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	months := 12
+	if raw := c.Query("months"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 120 {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "months must be a whole number between 1 and 120"))
 			return
 		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update deductible progress: " + err.Error()})
+		months = parsed
+	}
+
+	ctx := c.Request.Context()
+	history, err := h.healthService.GetRiskHistory(ctx, userID, months)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to get risk history")
 		return
 	}
-	
-	c.JSON(http.StatusOK, gin.H{"message": "Deductible progress updated successfully"})
+
+	entries := make([]dtos.RiskSnapshotDTO, len(history.Entries))
+	for i, entry := range history.Entries {
+		entries[i] = dtos.RiskSnapshotDTO{
+			RiskScore:  entry.RiskScore,
+			RiskLevel:  entry.RiskLevel,
+			RecordedAt: entry.RecordedAt,
+		}
+	}
+	transitions := make([]dtos.RiskLevelTransitionDTO, len(history.Transitions))
+	for i, transition := range history.Transitions {
+		transitions[i] = dtos.RiskLevelTransitionDTO{
+			From: transition.From,
+			To:   transition.To,
+			At:   transition.At,
+		}
+	}
+
+	c.JSON(http.StatusOK, dtos.RiskHistoryResponseDTO{
+		Months:      history.Months,
+		Entries:     entries,
+		ScoreChange: history.ScoreChange,
+		Transitions: transitions,
+	})
+}
+
+// EvaluateRiskWhatIf scores the user's risk with hypothetical changes to their profile and
+// conditions, without saving anything
+//
+//	@Summary		Score a hypothetical health risk
+//	@Description	Scores the risk the user would have with the given weight, age and condition changes,
+//	@Description	and attributes the change from the current score to each factor.
+//	@Description	Condition IDs that aren't the user's own active conditions are not found.
+//	@Tags			health
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request					body		dtos.RiskWhatIfRequestDTO	true	"Hypothetical changes"
+//	@Success		200						{object}	dtos.RiskWhatIfResponseDTO
+//	@Failure		400						{object}	dtos.ValidationErrorResponseDTO
+//	@Failure		401						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		404						{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		500						{object}	dtos.SimpleErrorResponseDTO
+//	@Router			/health/risk/what-if	[post]
+func (h *HealthHandler) EvaluateRiskWhatIf(c *gin.Context) {
+	var requestDTO dtos.RiskWhatIfRequestDTO
+	if err := h.bindJSON(c, &requestDTO); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	result, err := h.healthService.EvaluateRiskWhatIf(ctx, userID, toRiskWhatIf(requestDTO))
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to evaluate risk what-if")
+		return
+	}
+
+	factors := make([]dtos.RiskFactorChangeDTO, len(result.Factors))
+	for i, factor := range result.Factors {
+		factors[i] = dtos.RiskFactorChangeDTO(factor)
+	}
+	c.JSON(http.StatusOK, dtos.RiskWhatIfResponseDTO{
+		CurrentScore:      result.CurrentScore,
+		CurrentLevel:      result.CurrentLevel,
+		HypotheticalScore: result.HypotheticalScore,
+		HypotheticalLevel: result.HypotheticalLevel,
+		ScoreChange:       result.ScoreChange,
+		Factors:           factors,
+	})
+}
+
+// toRiskWhatIf converts a what-if request to the service's changes
+func toRiskWhatIf(requestDTO dtos.RiskWhatIfRequestDTO) services.RiskWhatIf {
+	whatIf := services.RiskWhatIf{
+		Weight:             requestDTO.Weight,
+		Age:                requestDTO.Age,
+		RemoveConditionIDs: requestDTO.RemoveConditionIDs,
+	}
+	for _, change := range requestDTO.SeverityChanges {
+		whatIf.SeverityChanges = append(whatIf.SeverityChanges, services.ConditionSeverityChange{
+			ConditionID: change.ConditionID,
+			Severity:    change.Severity,
+		})
+	}
+	for _, condition := range requestDTO.AddConditions {
+		whatIf.AddConditions = append(whatIf.AddConditions, domain.MedicalCondition{
+			Name:     condition.Name,
+			Category: condition.Category,
+			Severity: condition.Severity,
+		})
+	}
+	return whatIf
+}
+
+// GetExpenseAnalytics retrieves a year-to-date breakdown of medical spending and deductible progress
+//
+//	@Summary	Analyze medical expenses year to date
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200							{object}	dtos.ExpenseAnalyticsResponseDTO
+//	@Failure	401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/expenses/analytics	[get]
+func (h *HealthHandler) GetExpenseAnalytics(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	analytics, err := h.healthService.GetExpenseAnalytics(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to analyze expenses")
+		return
+	}
+
+	c.JSON(http.StatusOK, toExpenseAnalyticsResponse(analytics))
+}
+
+// GetExpenseBreakdown splits all of the user's recorded medical expenses by category
+//
+//	@Summary		Break down medical expenses by category
+//	@Description	Totals every recorded medical expense by category, split into what insurance paid and what the user paid out of pocket, with each category's share of the total
+//	@Tags			health
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200							{object}	dtos.ExpenseBreakdownResponseDTO
+//	@Failure		401							{object}	dtos.SimpleErrorResponseDTO
+//	@Failure		500							{object}	dtos.SimpleErrorResponseDTO
+//	@Router			/health/expenses/breakdown	[get]
+func (h *HealthHandler) GetExpenseBreakdown(c *gin.Context) {
+	userID, err := h.getUserFromContext(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
+		return
+	}
+
+	ctx := c.Request.Context()
+	breakdown, err := h.healthService.GetExpenseBreakdown(ctx, userID)
+	if err != nil {
+		h.handleHealthError(c, err, "Failed to break down expenses")
+		return
+	}
+
+	response := dtos.ExpenseBreakdownResponseDTO{
+		UserID:            breakdown.UserID,
+		TotalAmount:       breakdown.TotalAmount,
+		CoveredAmount:     breakdown.CoveredAmount,
+		OutOfPocketAmount: breakdown.OutOfPocketAmount,
+		ExpenseCount:      breakdown.ExpenseCount,
+		Categories:        make([]dtos.ExpenseCategoryBreakdownDTO, len(breakdown.Categories)),
+	}
+	for i, category := range breakdown.Categories {
+		response.Categories[i] = dtos.ExpenseCategoryBreakdownDTO(category)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// toExpenseAnalyticsResponse converts medical expense analytics to its response DTO
+func toExpenseAnalyticsResponse(analytics *services.MedicalExpenseAnalytics) dtos.ExpenseAnalyticsResponseDTO {
+	response := dtos.ExpenseAnalyticsResponseDTO{
+		UserID:             analytics.UserID,
+		Year:               analytics.Year,
+		YearToDateTotal:    analytics.YearToDateTotal,
+		OutOfPocketTotal:   analytics.OutOfPocketTotal,
+		InsurancePaidTotal: analytics.InsurancePaidTotal,
+		ExpenseCount:       analytics.ExpenseCount,
+		ByCategory:         make([]dtos.ExpenseCategoryTotalDTO, len(analytics.ByCategory)),
+		MonthlyRunRate:     analytics.MonthlyRunRate,
+		Policies:           make([]dtos.DeductibleProgressDTO, len(analytics.Policies)),
+	}
+
+	for i, category := range analytics.ByCategory {
+		response.ByCategory[i] = dtos.ExpenseCategoryTotalDTO{
+			Category:           category.Category,
+			TotalAmount:        category.TotalAmount,
+			TotalInsurancePaid: category.TotalInsurancePaid,
+			TotalOutOfPocket:   category.TotalOutOfPocket,
+			ExpenseCount:       category.ExpenseCount,
+		}
+	}
+
+	for i, progress := range analytics.Policies {
+		response.Policies[i] = dtos.DeductibleProgressDTO{
+			PolicyID:                   progress.PolicyID,
+			Provider:                   progress.Provider,
+			Type:                       progress.Type,
+			Deductible:                 progress.Deductible,
+			DeductibleMet:              progress.DeductibleMet,
+			DeductibleRemaining:        progress.DeductibleRemaining,
+			OutOfPocketMax:             progress.OutOfPocketMax,
+			OutOfPocketMet:             progress.OutOfPocketMet,
+			OutOfPocketRemaining:       progress.OutOfPocketRemaining,
+			WillMeetDeductibleThisYear: progress.WillMeetDeductibleThisYear,
+		}
+		if progress.ProjectedDeductibleMetMonth != nil {
+			response.Policies[i].ProjectedDeductibleMetMonth = progress.ProjectedDeductibleMetMonth.Format("2006-01")
+		}
+	}
+
+	return response
+}
+
+// toCoverageGapsResponse converts a coverage gap analysis to its response DTO
+func toCoverageGapsResponse(analysis *services.CoverageGapAnalysis) dtos.CoverageGapsResponseDTO {
+	response := dtos.CoverageGapsResponseDTO{
+		UserID:                    analysis.UserID,
+		UncoveredCategories:       make([]dtos.UncoveredCategoryDTO, len(analysis.UncoveredCategories)),
+		RecurringMonthlyCost:      analysis.RecurringMonthlyCost,
+		UncoveredRecurringMonthly: analysis.UncoveredRecurringMonthly,
+		UncoveredRecurringShare:   analysis.UncoveredRecurringShare,
+		CoverageLapses:            make([]dtos.CoverageLapseDTO, len(analysis.CoverageLapses)),
+		Gaps:                      make([]dtos.CoverageGapDTO, len(analysis.Gaps)),
+	}
+	for i, category := range analysis.UncoveredCategories {
+		response.UncoveredCategories[i] = dtos.UncoveredCategoryDTO(category)
+	}
+	for i, lapse := range analysis.CoverageLapses {
+		response.CoverageLapses[i] = dtos.CoverageLapseDTO(lapse)
+	}
+	for i, gap := range analysis.Gaps {
+		response.Gaps[i] = dtos.CoverageGapDTO{
+			Type:              gap.Type,
+			Description:       gap.Description,
+			RiskLevel:         gap.RiskLevel,
+			Recommendation:    gap.Recommendation,
+			EstimatedExposure: gap.EstimatedExposure,
+		}
+	}
+	return response
 }
 
 // GetHealthSummary calculates and returns a comprehensive health summary
+//
+//	@Summary	Get the health summary
+//	@Tags		health
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200				{object}	dtos.HealthSummaryResponseDTO
+//	@Failure	401				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	404				{object}	dtos.SimpleErrorResponseDTO
+//	@Failure	500				{object}	dtos.SimpleErrorResponseDTO
+//	@Router		/health/summary	[get]
 func (h *HealthHandler) GetHealthSummary(c *gin.Context) {
 	userID, err := h.getUserFromContext(c)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 		return
 	}
-	
-	ctx := context.Background()
+
+	ctx := c.Request.Context()
 	summary, err := h.healthService.CalculateHealthSummary(ctx, userID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Health profile not found"})
-			return
-		}
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate health summary: " + err.Error()})
+		h.handleHealthError(c, err, "Failed to calculate health summary")
 		return
 	}
-	
+
 	// Convert domain to DTO
 	var responseDTO dtos.HealthSummaryResponseDTO
 	responseDTO.FromDomain(summary)
-	
+
 	c.JSON(http.StatusOK, responseDTO)
-}
\ No newline at end of file
+}
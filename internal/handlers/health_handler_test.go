@@ -15,9 +15,12 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
 // MockHealthService for testing
@@ -40,6 +43,53 @@ func (m *MockHealthService) UpdateProfile(ctx context.Context, profile *domain.H
 	return args.Error(0)
 }
 
+func (m *MockHealthService) GetProfileHistory(ctx context.Context, userID string, months int) (*services.ProfileHistory, error) {
+	args := m.Called(ctx, userID, months)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.ProfileHistory), args.Error(1)
+}
+
+func (m *MockHealthService) CreateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) GetFamilyProfiles(ctx context.Context, userID string) ([]domain.HealthProfile, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.HealthProfile), args.Error(1)
+}
+
+func (m *MockHealthService) GetFamilyMember(ctx context.Context, userID, profileID string) (*domain.HealthProfile, error) {
+	args := m.Called(ctx, userID, profileID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HealthProfile), args.Error(1)
+}
+
+func (m *MockHealthService) UpdateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	args := m.Called(ctx, profile)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) DeleteDependentProfile(ctx context.Context, userID, profileID string) error {
+	args := m.Called(ctx, userID, profileID)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) GetFamilyHealthRollup(ctx context.Context, userID string) (*services.FamilyHealthRollup, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.FamilyHealthRollup), args.Error(1)
+}
+
 func (m *MockHealthService) AddCondition(ctx context.Context, condition *domain.MedicalCondition) error {
 	args := m.Called(ctx, condition)
 	return args.Error(0)
@@ -60,6 +110,42 @@ func (m *MockHealthService) RemoveCondition(ctx context.Context, userID, conditi
 	return args.Error(0)
 }
 
+func (m *MockHealthService) DeleteCondition(ctx context.Context, userID, conditionID string) error {
+	args := m.Called(ctx, userID, conditionID)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) DeleteProfile(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) DeleteExpense(ctx context.Context, userID, expenseID string) error {
+	args := m.Called(ctx, userID, expenseID)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) DeleteInsurancePolicy(ctx context.Context, userID, policyID string) error {
+	args := m.Called(ctx, userID, policyID)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) GetConditionTimeline(ctx context.Context, userID string) (*services.ConditionTimeline, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.ConditionTimeline), args.Error(1)
+}
+
+func (m *MockHealthService) SearchConditionCatalog(ctx context.Context, query string, limit int) ([]domain.ConditionCatalogMatch, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ConditionCatalogMatch), args.Error(1)
+}
+
 func (m *MockHealthService) AddExpense(ctx context.Context, expense *domain.MedicalExpense) error {
 	args := m.Called(ctx, expense)
 	return args.Error(0)
@@ -75,6 +161,38 @@ func (m *MockHealthService) GetRecurringExpenses(ctx context.Context, userID str
 	return args.Get(0).([]domain.MedicalExpense), args.Error(1)
 }
 
+func (m *MockHealthService) GetExpenseBreakdown(ctx context.Context, userID string) (*services.MedicalExpenseBreakdown, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.MedicalExpenseBreakdown), args.Error(1)
+}
+
+func (m *MockHealthService) GetExpenseAnalytics(ctx context.Context, userID string) (*services.MedicalExpenseAnalytics, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.MedicalExpenseAnalytics), args.Error(1)
+}
+
+func (m *MockHealthService) AddExpenseOccurrence(ctx context.Context, userID, expenseID string, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error) {
+	args := m.Called(ctx, userID, expenseID, occurrence)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MedicalExpenseOccurrence), args.Error(1)
+}
+
+func (m *MockHealthService) GetExpenseOccurrences(ctx context.Context, userID, expenseID string) ([]domain.MedicalExpenseOccurrence, error) {
+	args := m.Called(ctx, userID, expenseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.MedicalExpenseOccurrence), args.Error(1)
+}
+
 func (m *MockHealthService) AddInsurancePolicy(ctx context.Context, policy *domain.InsurancePolicy) error {
 	args := m.Called(ctx, policy)
 	return args.Error(0)
@@ -85,11 +203,122 @@ func (m *MockHealthService) GetActivePolicies(ctx context.Context, userID string
 	return args.Get(0).([]domain.InsurancePolicy), args.Error(1)
 }
 
+func (m *MockHealthService) GetPolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.InsurancePolicy), args.Error(1)
+}
+
 func (m *MockHealthService) UpdateDeductibleProgress(ctx context.Context, policyID string, amount float64) error {
 	args := m.Called(ctx, policyID, amount)
 	return args.Error(0)
 }
 
+func (m *MockHealthService) ComparePolicies(ctx context.Context, userID string, candidates []domain.InsurancePolicy, expectedAnnualSpend float64) (*services.PolicyComparison, error) {
+	args := m.Called(ctx, userID, candidates, expectedAnnualSpend)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PolicyComparison), args.Error(1)
+}
+
+func (m *MockHealthService) GetCoverageGaps(ctx context.Context, userID string) (*services.CoverageGapAnalysis, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CoverageGapAnalysis), args.Error(1)
+}
+
+func (m *MockHealthService) EvaluateInsuranceAdequacy(ctx context.Context, userID string) (*services.InsuranceAdequacy, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.InsuranceAdequacy), args.Error(1)
+}
+
+func (m *MockHealthService) AddMedicationSchedule(ctx context.Context, schedule *domain.MedicationSchedule) error {
+	args := m.Called(ctx, schedule)
+	return args.Error(0)
+}
+
+func (m *MockHealthService) GetUpcomingRefills(ctx context.Context, userID string, withinDays int) ([]services.UpcomingRefill, error) {
+	args := m.Called(ctx, userID, withinDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.UpcomingRefill), args.Error(1)
+}
+
+func (m *MockHealthService) GetExpiringPolicies(ctx context.Context, userID string, withinDays int) ([]services.ExpiringPolicy, error) {
+	args := m.Called(ctx, userID, withinDays)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]services.ExpiringPolicy), args.Error(1)
+}
+
+func (m *MockHealthService) GetOutOfPocketStatus(ctx context.Context, userID, policyID string) (*domain.OutOfPocketStatus, error) {
+	args := m.Called(ctx, userID, policyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OutOfPocketStatus), args.Error(1)
+}
+
+func (m *MockHealthService) GetCostProjection(ctx context.Context, userID string) (*services.AnnualCostProjection, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AnnualCostProjection), args.Error(1)
+}
+
+func (m *MockHealthService) RecommendHSAContribution(ctx context.Context, userID string) (*services.HSARecommendation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.HSARecommendation), args.Error(1)
+}
+
+func (m *MockHealthService) GetRiskModel() domain.RiskModel {
+	args := m.Called()
+	return args.Get(0).(domain.RiskModel)
+}
+
+func (m *MockHealthService) SnapshotRisk(ctx context.Context, userID string) (*domain.RiskSnapshot, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RiskSnapshot), args.Error(1)
+}
+
+func (m *MockHealthService) GetRiskHistory(ctx context.Context, userID string, months int) (*services.RiskHistory, error) {
+	args := m.Called(ctx, userID, months)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.RiskHistory), args.Error(1)
+}
+
+func (m *MockHealthService) EvaluateRiskWhatIf(ctx context.Context, userID string, whatIf services.RiskWhatIf) (*services.RiskWhatIfResult, error) {
+	args := m.Called(ctx, userID, whatIf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.RiskWhatIfResult), args.Error(1)
+}
+
+func (m *MockHealthService) RecalculateAllRisk(ctx context.Context) (*services.RiskRecalculation, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.RiskRecalculation), args.Error(1)
+}
+
 func (m *MockHealthService) CalculateHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(*domain.HealthSummary), args.Error(1)
@@ -110,6 +339,7 @@ func createTestJWTToken(userID string) string {
 func setupHealthTestRouter(handler *HealthHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
+	router.Use(middleware.ValidateRequestLimits())
 	
 	// Add auth middleware that sets user context
 	router.Use(func(c *gin.Context) {
@@ -133,17 +363,41 @@ func setupHealthTestRouter(handler *HealthHandler) *gin.Engine {
 		health.POST("/profile", handler.CreateProfile)
 		health.GET("/profile", handler.GetProfile)
 		health.PUT("/profile", handler.UpdateProfile)
+		health.DELETE("/profile", handler.DeleteProfile)
+		health.GET("/profile/history", handler.GetProfileHistory)
+		health.POST("/family", handler.CreateDependentProfile)
+		health.GET("/family", handler.GetFamilyProfiles)
+		health.PUT("/family/:id", handler.UpdateDependentProfile)
+		health.DELETE("/family/:id", handler.DeleteDependentProfile)
 		health.POST("/conditions", handler.AddCondition)
 		health.GET("/conditions", handler.GetConditions)
+		health.GET("/conditions/timeline", handler.GetConditionTimeline)
+		health.GET("/conditions/catalog", handler.SearchConditionCatalog)
 		health.PUT("/conditions/:id", handler.UpdateCondition)
 		health.DELETE("/conditions/:id", handler.RemoveCondition)
 		health.POST("/expenses", handler.AddExpense)
 		health.GET("/expenses", handler.GetExpenses)
 		health.GET("/expenses/recurring", handler.GetRecurringExpenses)
+		health.GET("/expenses/analytics", handler.GetExpenseAnalytics)
+		health.GET("/expenses/breakdown", handler.GetExpenseBreakdown)
+		health.POST("/expenses/:id/occurrences", handler.AddExpenseOccurrence)
+		health.GET("/expenses/:id/occurrences", handler.GetExpenseOccurrences)
+		health.POST("/medications", handler.AddMedicationSchedule)
+		health.GET("/medications/refills", handler.GetUpcomingRefills)
 		health.POST("/policies", handler.AddInsurancePolicy)
 		health.GET("/policies", handler.GetActivePolicies)
 		health.PUT("/policies/:id/deductible", handler.UpdateDeductibleProgress)
+		health.POST("/policies/compare", handler.ComparePolicies)
 		health.GET("/summary", handler.GetHealthSummary)
+		health.GET("/coverage-gaps", handler.GetCoverageGaps)
+		health.GET("/insurance/evaluation", handler.GetInsuranceEvaluation)
+		health.GET("/insurance/expiring", handler.GetExpiringPolicies)
+		health.GET("/insurance/:id/oop-status", handler.GetOutOfPocketStatus)
+		health.GET("/cost-projection", handler.GetCostProjection)
+		health.GET("/hsa-recommendation", handler.GetHSARecommendation)
+		health.GET("/risk-model", handler.GetRiskModel)
+		health.GET("/risk-history", handler.GetRiskHistory)
+		health.POST("/risk/what-if", handler.EvaluateRiskWhatIf)
 	}
 	
 	return router
@@ -293,6 +547,151 @@ func TestAddCondition_ValidationError(t *testing.T) {
 	mockService.AssertNotCalled(t, "AddCondition")
 }
 
+func TestAddCondition_FromCatalog_NeedsNoName(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("AddCondition", mock.Anything, mock.MatchedBy(func(c *domain.MedicalCondition) bool {
+		return c.CatalogID == "E11" && c.Name == ""
+	})).Run(func(args mock.Arguments) {
+		domain.ConditionCatalogEntry{ID: "E11", Name: "Type 2 Diabetes", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 8}.
+			ApplyTo(args.Get(1).(*domain.MedicalCondition))
+	}).Return(nil)
+
+	reqBody := `{"user_id":"user123","catalog_id":"E11","diagnosed_date":"2024-01-15T00:00:00Z","is_active":true}`
+	req := httptest.NewRequest("POST", "/health/conditions", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var response dtos.MedicalConditionCreatedResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "E11", response.Condition.CatalogID)
+	assert.Equal(t, "Type 2 Diabetes", response.Condition.Name)
+	assert.False(t, response.Condition.Uncatalogued)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddCondition_FreeText_FlaggedUncatalogued(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("AddCondition", mock.Anything, mock.Anything).Return(nil)
+
+	reqBody := `{"user_id":"user123","name":"Back pain","category":"chronic","severity":"mild","diagnosed_date":"2024-01-15T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/health/conditions", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	condition := response["condition"].(map[string]interface{})
+	assert.Equal(t, true, condition["uncatalogued"])
+	assert.NotContains(t, condition, "catalog_id")
+}
+
+func TestAddCondition_UnknownCatalogEntry(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("AddCondition", mock.Anything, mock.Anything).Return(domain.ErrConditionCatalogEntryNotFound)
+
+	reqBody := `{"user_id":"user123","catalog_id":"Z99","diagnosed_date":"2024-01-15T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/health/conditions", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), string(dtos.ErrorCodeHealthCatalogEntryNotFound))
+}
+
+func TestSearchConditionCatalog_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("SearchConditionCatalog", mock.Anything, "diab", domain.DefaultConditionCatalogMatches).Return([]domain.ConditionCatalogMatch{
+		{
+			Entry:       domain.ConditionCatalogEntry{ID: "E11", Name: "Type 2 Diabetes", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 8},
+			MatchedName: "diabetes type ii",
+			Score:       90,
+		},
+	}, nil)
+
+	req := httptest.NewRequest("GET", "/health/conditions/catalog?q=diab", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var response dtos.ConditionCatalogSearchResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "diab", response.Query)
+	assert.Equal(t, 1, response.Total)
+	require.Len(t, response.Suggestions, 1)
+	assert.Equal(t, "E11", response.Suggestions[0].ID)
+	assert.Equal(t, "diabetes type ii", response.Suggestions[0].MatchedName)
+	assert.Equal(t, 8, response.Suggestions[0].RiskWeight)
+}
+
+func TestSearchConditionCatalog_NoMatches(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("SearchConditionCatalog", mock.Anything, "xyz", 5).Return(nil, nil)
+
+	req := httptest.NewRequest("GET", "/health/conditions/catalog?q=xyz&limit=5", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"query":"xyz","suggestions":[],"total":0}`, w.Body.String())
+}
+
+func TestSearchConditionCatalog_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"missing q", "/health/conditions/catalog"},
+		{"blank q", "/health/conditions/catalog?q=%20-"},
+		{"limit too high", "/health/conditions/catalog?q=diab&limit=51"},
+		{"limit not a number", "/health/conditions/catalog?q=diab&limit=ten"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHealthService)
+			router := setupHealthTestRouter(NewHealthHandler(mockService))
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockService.AssertNotCalled(t, "SearchConditionCatalog", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestAddExpense_RequiresAuth(t *testing.T) {
 	// Arrange
 	mockService := new(MockHealthService)
@@ -373,6 +772,39 @@ func TestGetHealthSummary_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetHealthSummary_Timeout_ReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	// The service must be handed the request's context rather than a fresh one
+	requestContext := mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() != nil })
+	mockService.On("CalculateHealthSummary", requestContext, "user123").
+		Return((*domain.HealthSummary)(nil), fmt.Errorf("failed to get user profile: %w", context.DeadlineExceeded))
+
+	req := httptest.NewRequest("GET", "/health/summary", nil).WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	// Act
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response dtos.SimpleErrorResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, dtos.ErrorCodeTimeout, response.ErrorCode)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestAddInsurancePolicy_UniqueNumber(t *testing.T) {
 	// Arrange
 	mockService := new(MockHealthService)
@@ -447,6 +879,7 @@ func TestUpdateDeductible_OnlyOwner(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &errorResponse)
 	assert.NoError(t, err)
 	assert.Contains(t, errorResponse, "error")
+	assert.Equal(t, string(dtos.ErrorCodeHealthAccessDenied), errorResponse["error_code"])
 	
 	mockService.AssertExpectations(t)
 }
@@ -495,6 +928,11 @@ func TestGetProfile_NotFound(t *testing.T) {
 	router.ServeHTTP(w, req)
 	
 	assert.Equal(t, http.StatusNotFound, w.Code)
+	
+	var response dtos.SimpleErrorResponseDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Health profile not found", response.Error)
+	assert.Equal(t, dtos.ErrorCodeHealthProfileNotFound, response.ErrorCode)
 	mockService.AssertExpectations(t)
 }
 
@@ -643,4 +1081,1380 @@ func TestGetActivePolicies_Success(t *testing.T) {
 	assert.Equal(t, 1, response.Total)
 	
 	mockService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestComparePolicies_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	requestBody := dtos.ComparePoliciesRequestDTO{
+		ExpectedAnnualSpend: 500,
+		Policies: []dtos.PolicyCandidateDTO{
+			{PolicyNumber: "LOW-DED", Provider: "Acme", Type: "health", CoveragePercentage: 90, Deductible: 250, OutOfPocketMax: 2000, MonthlyPremium: 500},
+			{PolicyNumber: "HIGH-DED", Provider: "Acme", Type: "health", CoveragePercentage: 70, Deductible: 5000, OutOfPocketMax: 7000, MonthlyPremium: 200},
+		},
+	}
+	
+	cheapest := services.PolicyCostProjection{PolicyNumber: "HIGH-DED", AnnualPremium: 2400, ProjectedOutOfPocket: 500, TotalProjectedCost: 2900, Rank: 1}
+	comparison := &services.PolicyComparison{
+		ExpectedAnnualSpend: 500,
+		Policies: []services.PolicyCostProjection{
+			cheapest,
+			{PolicyNumber: "LOW-DED", AnnualPremium: 6000, ProjectedOutOfPocket: 275, TotalProjectedCost: 6275, Rank: 2},
+		},
+		Cheapest: cheapest,
+	}
+	
+	mockService.On("ComparePolicies", mock.Anything, "user123", mock.MatchedBy(func(candidates []domain.InsurancePolicy) bool {
+		return len(candidates) == 2 && candidates[0].UserID == "user123"
+	}), 500.0).Return(comparison, nil)
+	
+	body, _ := json.Marshal(requestBody)
+	req := httptest.NewRequest("POST", "/health/policies/compare", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.PolicyComparisonResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "HIGH-DED", response.Cheapest.PolicyNumber)
+	assert.Len(t, response.Policies, 2)
+	assert.Equal(t, 2, response.Policies[1].Rank)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCoverageGaps_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	analysis := &services.CoverageGapAnalysis{
+		UserID:              "user123",
+		UncoveredCategories: []services.UncoveredCategory{{Category: "medication", ExpenseCount: 2, TotalAmount: 300}},
+		CoverageLapses:      []services.CoverageLapse{},
+		Gaps: []services.CoverageGap{
+			{Type: "uncovered_category", Description: "2 medication expense(s) totalling $300.00 are not covered by any active policy", RiskLevel: "low"},
+		},
+	}
+	
+	mockService.On("GetCoverageGaps", mock.Anything, "user123").Return(analysis, nil)
+	
+	req := httptest.NewRequest("GET", "/health/coverage-gaps", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.CoverageGapsResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.UncoveredCategories, 1)
+	assert.Equal(t, "medication", response.UncoveredCategories[0].Category)
+	assert.Len(t, response.Gaps, 1)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetInsuranceEvaluation_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	adequacy := &services.InsuranceAdequacy{
+		UserID:                  "user123",
+		RiskLevel:               "low",
+		ProjectedAnnualExpenses: 800,
+		Policies: []services.PolicyAdequacy{
+			{
+				PolicyID:                 "1",
+				AnnualPremium:            3600,
+				ExpectedOutOfPocket:      800,
+				CoverageSufficient:       true,
+				OutOfPocketMaxSufficient: true,
+				Flags:                    []string{"Your deductible of 2000.00 exceeds your projected annual expenses of 800.00, so this policy is unlikely to pay out; consider a lower-premium plan"},
+			},
+		},
+	}
+
+	mockService.On("EvaluateInsuranceAdequacy", mock.Anything, "user123").Return(adequacy, nil)
+
+	req := httptest.NewRequest("GET", "/health/insurance/evaluation", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.InsuranceEvaluationResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "low", response.RiskLevel)
+	if assert.Len(t, response.Policies, 1) {
+		assert.Equal(t, 800.0, response.Policies[0].ExpectedOutOfPocket)
+		assert.Len(t, response.Policies[0].Flags, 1)
+	}
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetInsuranceEvaluation_ProfileNotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("EvaluateInsuranceAdequacy", mock.Anything, "user123").
+		Return(nil, fmt.Errorf("failed to get user profile: %w", services.ErrProfileNotFound))
+
+	req := httptest.NewRequest("GET", "/health/insurance/evaluation", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGetUpcomingRefills_WithinQuery(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	refills := []services.UpcomingRefill{
+		{
+			Schedule:     domain.MedicationSchedule{ID: "1", ConditionID: "1", MedicationName: "Metformin", RefillEveryDays: 30},
+			DueDate:      time.Now().AddDate(0, 0, -2),
+			DaysUntilDue: -2,
+			IsOverdue:    true,
+		},
+	}
+	mockService.On("GetUpcomingRefills", mock.Anything, "user123", 14).Return(refills, nil)
+	
+	req := httptest.NewRequest("GET", "/health/medications/refills?within=14", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.MedicationRefillListResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 14, response.WithinDays)
+	assert.Equal(t, 1, response.Total)
+	assert.True(t, response.Refills[0].IsOverdue)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetUpcomingRefills_InvalidWithin(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	req := httptest.NewRequest("GET", "/health/medications/refills?within=-3", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetUpcomingRefills", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetExpiringPolicies_DefaultWindow(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	expiring := []services.ExpiringPolicy{
+		{
+			Policy:          domain.InsurancePolicy{ID: "1", UserID: "user123", PolicyNumber: "POL-1", EndDate: time.Now().AddDate(0, 0, 20), IsActive: true},
+			DaysUntilExpiry: 20,
+		},
+	}
+	mockService.On("GetExpiringPolicies", mock.Anything, "user123", 30).Return(expiring, nil)
+
+	req := httptest.NewRequest("GET", "/health/insurance/expiring", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ExpiringPolicyListResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 30, response.WithinDays)
+	require.Equal(t, 1, response.Total)
+	assert.Equal(t, "POL-1", response.Policies[0].PolicyNumber)
+	assert.Equal(t, 20, response.Policies[0].DaysUntilExpiry)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetExpiringPolicies_InvalidWithin(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	req := httptest.NewRequest("GET", "/health/insurance/expiring?within=soon", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetExpiringPolicies", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetOutOfPocketStatus_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	status := &domain.OutOfPocketStatus{
+		PolicyID:           "7",
+		PolicyNumber:       "POL-7",
+		OutOfPocketMax:     2000,
+		OutOfPocketCurrent: 2000,
+		PercentReached:     100,
+		MaxReached:         true,
+		NearMax:            true,
+	}
+	mockService.On("GetOutOfPocketStatus", mock.Anything, "user123", "7").Return(status, nil)
+
+	req := httptest.NewRequest("GET", "/health/insurance/7/oop-status", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.OutOfPocketStatusDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, response.PercentReached)
+	assert.True(t, response.MaxReached)
+	assert.True(t, response.NearMax)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetOutOfPocketStatus_PolicyNotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("GetOutOfPocketStatus", mock.Anything, "user123", "99").
+		Return(nil, fmt.Errorf("failed to get policy: insurance policy with ID 99 not found"))
+
+	req := httptest.NewRequest("GET", "/health/insurance/99/oop-status", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), string(dtos.ErrorCodeHealthPolicyNotFound))
+}
+
+// setupRiskRecalculationRouter authenticates every request with the given role and guards the
+// recalculation route with the same RequireRole middleware as the production admin group
+func setupRiskRecalculationRouter(handler *HealthHandler, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", "admin-1")
+		c.Set("userRole", role)
+		c.Set("tokenClaims", &domain.TokenClaims{UserID: "admin-1", Role: role})
+		c.Next()
+	})
+	router.POST("/admin/health/recalculate-risk", middleware.RequireRole(domain.RoleAdmin), handler.RecalculateAllRisk)
+	return router
+}
+
+func TestRecalculateAllRisk_Admin_ReturnsCounts(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupRiskRecalculationRouter(NewHealthHandler(mockService), domain.RoleAdmin)
+
+	mockService.On("RecalculateAllRisk", mock.Anything).Return(&services.RiskRecalculation{Processed: 5, Updated: 2}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/health/recalculate-risk", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.RiskRecalculationResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 5, response.Processed)
+	assert.Equal(t, 2, response.Updated)
+	mockService.AssertExpectations(t)
+}
+
+func TestRecalculateAllRisk_NormalUser_Forbidden(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupRiskRecalculationRouter(NewHealthHandler(mockService), domain.RoleUser)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/health/recalculate-risk", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockService.AssertNotCalled(t, "RecalculateAllRisk", mock.Anything)
+}
+
+func TestGetProfileHistory_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	now := time.Now()
+	history := &services.ProfileHistory{
+		UserID: "user123",
+		Months: 6,
+		Points: []domain.ProfileSnapshot{
+			{Age: 35, Height: 180, Weight: 81, BMI: 25.0, RecordedAt: now.AddDate(0, -3, 0)},
+			{Age: 35, Height: 180, Weight: 78, BMI: 24.07, RecordedAt: now},
+		},
+		WeightChange: -3,
+		BMIChange:    -0.93,
+	}
+	mockService.On("GetProfileHistory", mock.Anything, "user123", 6).Return(history, nil)
+	
+	req := httptest.NewRequest("GET", "/health/profile/history?months=6", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.ProfileHistoryResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, response.Months)
+	assert.Len(t, response.Points, 2)
+	assert.Equal(t, -3.0, response.WeightChange)
+	assert.Equal(t, -0.93, response.BMIChange)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetProfileHistory_InvalidMonths(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	req := httptest.NewRequest("GET", "/health/profile/history?months=abc", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetProfileHistory", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateDependentProfile_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("CreateDependentProfile", mock.Anything, mock.MatchedBy(func(profile *domain.HealthProfile) bool {
+		return profile.UserID == "user123" && profile.Name == "Sam" && profile.RelationToOwner == domain.RelationChild
+	})).Return(nil)
+	
+	body := `{"name":"Sam","relation_to_owner":"child","age":8,"gender":"male","height":128,"weight":26}`
+	req := httptest.NewRequest("POST", "/health/family", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateDependentProfile_RejectsSelfRelation(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	body := `{"name":"Me","relation_to_owner":"self","age":35,"gender":"male","height":180,"weight":75}`
+	req := httptest.NewRequest("POST", "/health/family", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "CreateDependentProfile", mock.Anything, mock.Anything)
+}
+
+func TestGetFamilyProfiles_WithAggregate(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	profiles := []domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf, Age: 40},
+		{ID: "2", UserID: "user123", Name: "Alex", RelationToOwner: domain.RelationSpouse, Age: 38},
+	}
+	rollup := &services.FamilyHealthRollup{
+		UserID: "user123",
+		Members: []services.FamilyMemberRollup{
+			{ProfileID: "1", RelationToOwner: domain.RelationSelf, HealthRiskScore: 10, MonthlyMedicalCost: 100},
+			{ProfileID: "2", Name: "Alex", RelationToOwner: domain.RelationSpouse, HealthRiskScore: 30, MonthlyMedicalCost: 50},
+		},
+		HighestRiskScore:        30,
+		AverageRiskScore:        20,
+		TotalMonthlyMedicalCost: 150,
+	}
+	mockService.On("GetFamilyProfiles", mock.Anything, "user123").Return(profiles, nil)
+	mockService.On("GetFamilyHealthRollup", mock.Anything, "user123").Return(rollup, nil)
+	
+	req := httptest.NewRequest("GET", "/health/family?aggregate=true", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.FamilyProfilesResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Total)
+	assert.Equal(t, "spouse", response.Profiles[1].RelationToOwner)
+	if assert.NotNil(t, response.Rollup) {
+		assert.Len(t, response.Rollup.Members, 2)
+		assert.Equal(t, 30, response.Rollup.HighestRiskScore)
+		assert.Equal(t, 150.0, response.Rollup.TotalMonthlyMedicalCost)
+	}
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetFamilyProfiles_WithoutAggregate(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	profiles := []domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf, Age: 40},
+	}
+	mockService.On("GetFamilyProfiles", mock.Anything, "user123").Return(profiles, nil)
+	
+	req := httptest.NewRequest("GET", "/health/family", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.FamilyProfilesResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Total)
+	assert.Nil(t, response.Rollup)
+	mockService.AssertNotCalled(t, "GetFamilyHealthRollup", mock.Anything, mock.Anything)
+}
+
+func TestUpdateDependentProfile_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	member := &domain.HealthProfile{
+		ID: "2", UserID: "user123", Name: "Sam", RelationToOwner: domain.RelationChild,
+		Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1,
+	}
+	mockService.On("GetFamilyMember", mock.Anything, "user123", "2").Return(member, nil)
+	mockService.On("UpdateDependentProfile", mock.Anything, mock.MatchedBy(func(profile *domain.HealthProfile) bool {
+		return profile.ID == "2" && profile.Age == 9 && profile.Weight == 28 && profile.Name == "Sam"
+	})).Return(nil)
+	
+	body := `{"age":9,"weight":28}`
+	req := httptest.NewRequest("PUT", "/health/family/2", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateDependentProfile_RejectsInvalidRelation(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	body := `{"relation_to_owner":"cousin"}`
+	req := httptest.NewRequest("PUT", "/health/family/2", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "UpdateDependentProfile", mock.Anything, mock.Anything)
+}
+
+func TestDeleteProfile_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("DeleteProfile", mock.Anything, "user123").Return(nil)
+	
+	req := httptest.NewRequest("DELETE", "/health/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteProfile_NotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("DeleteProfile", mock.Anything, "user123").Return(fmt.Errorf("health profile not found for user user123"))
+	
+	req := httptest.NewRequest("DELETE", "/health/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteDependentProfile_NotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("DeleteDependentProfile", mock.Anything, "user123", "7").Return(fmt.Errorf("family member 7 not found"))
+	
+	req := httptest.NewRequest("DELETE", "/health/family/7", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	
+	var response dtos.SimpleErrorResponseDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeHealthFamilyMemberNotFound, response.ErrorCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestDeleteDependentProfile_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("DeleteDependentProfile", mock.Anything, "user123", "2").Return(nil)
+	
+	req := httptest.NewRequest("DELETE", "/health/family/2", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddExpense_FamilyMemberID(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("AddExpense", mock.Anything, mock.MatchedBy(func(expense *domain.MedicalExpense) bool {
+		return expense.ProfileID == "2"
+	})).Return(nil)
+	
+	body := `{"user_id":"user123","family_member_id":"2","amount":40,"category":"doctor_visit","description":"Checkup","date":"2026-01-15T00:00:00Z","frequency":"one_time"}`
+	req := httptest.NewRequest("POST", "/health/expenses", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusCreated, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestAddExpense_AmountOverCap_ReturnsValidationError(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	body := `{"user_id":"user123","amount":1e300,"category":"doctor_visit","description":"Checkup","date":"2026-01-15T00:00:00Z","frequency":"one_time"}`
+	req := httptest.NewRequest("POST", "/health/expenses", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "amount must be less than or equal to 1000000000", response.Fields["amount"])
+	mockService.AssertNotCalled(t, "AddExpense")
+}
+
+func TestCreateProfile_InvalidFields_ReturnsValidationErrorWithJSONFieldNames(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupHealthTestRouter(NewHealthHandler(mockService))
+
+	body := `{"user_id":"user123","age":150,"gender":"male","weight":70,"family_size":1}`
+	req := httptest.NewRequest("POST", "/health/profile", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Contains(t, response.Fields, "age")
+	assert.Contains(t, response.Fields, "height")
+	assert.NotContains(t, w.Body.String(), "CreateHealthProfileRequestDTO")
+	mockService.AssertNotCalled(t, "CreateProfile")
+}
+
+func TestUpdateProfile_WrongJSONType_ReturnsValidationError(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupHealthTestRouter(NewHealthHandler(mockService))
+
+	req := httptest.NewRequest("PUT", "/health/profile", strings.NewReader(`{"height":"tall"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]any{"height": "height must be a number"}, response.Fields)
+	mockService.AssertNotCalled(t, "UpdateProfile")
+}
+
+func TestAddInsurancePolicy_OversizedBody_Returns413(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	body := `{"user_id":"user123","provider":"` + strings.Repeat("x", middleware.DefaultMaxRequestSize) + `"}`
+	req := httptest.NewRequest("POST", "/health/policies", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var response dtos.ErrorResponseDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "payload_too_large", response.Error)
+	mockService.AssertNotCalled(t, "AddInsurancePolicy")
+}
+
+func TestAddExpense_OtherUsersFamilyMember_Returns403(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("AddExpense", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("user is not authorized to record data for family member 7"))
+	
+	body := `{"user_id":"user123","family_member_id":"7","amount":40,"category":"doctor_visit","description":"Checkup","date":"2026-01-15T00:00:00Z","frequency":"one_time"}`
+	req := httptest.NewRequest("POST", "/health/expenses", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	
+	var response dtos.SimpleErrorResponseDTO
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeHealthAccessDenied, response.ErrorCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetConditionTimeline_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	now := time.Now()
+	resolvedAt := now.AddDate(0, -1, 0)
+	timeline := &services.ConditionTimeline{
+		UserID: "user123",
+		Active: []services.ConditionTimelineEntry{
+			{
+				Condition:          domain.MedicalCondition{ID: "1", Name: "Asthma", DiagnosedDate: now.AddDate(-1, 0, 0), IsActive: true},
+				Status:             services.ConditionStatusActive,
+				DaysSinceDiagnosis: 365,
+				DurationDays:       365,
+				StatusHistory:      []services.ConditionStatusChange{{Status: services.ConditionStatusDiagnosed, Date: now.AddDate(-1, 0, 0)}},
+			},
+		},
+		Resolved: []services.ConditionTimelineEntry{
+			{
+				Condition:          domain.MedicalCondition{ID: "2", Name: "Flu", DiagnosedDate: now.AddDate(0, -2, 0), ResolvedDate: &resolvedAt},
+				Status:             services.ConditionStatusResolved,
+				DaysSinceDiagnosis: 61,
+				DurationDays:       30,
+				StatusHistory: []services.ConditionStatusChange{
+					{Status: services.ConditionStatusDiagnosed, Date: now.AddDate(0, -2, 0)},
+					{Status: services.ConditionStatusResolved, Date: resolvedAt},
+				},
+			},
+		},
+	}
+	mockService.On("GetConditionTimeline", mock.Anything, "user123").Return(timeline, nil)
+	
+	req := httptest.NewRequest("GET", "/health/conditions/timeline", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.ConditionTimelineResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Active, 1)
+	if assert.Len(t, response.Resolved, 1) {
+		assert.Equal(t, "Flu", response.Resolved[0].Condition.Name)
+		assert.NotNil(t, response.Resolved[0].Condition.ResolvedDate)
+		assert.Equal(t, 30, response.Resolved[0].DurationDays)
+		assert.Len(t, response.Resolved[0].StatusHistory, 2)
+	}
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetCostProjection_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	projection := &services.AnnualCostProjection{
+		UserID:                    "user123",
+		ProjectedGrossCost:        2400,
+		ExpectedInsurancePayments: 1120,
+		NetOutOfPocket:            1280,
+		CoveredGrossCost:          1600,
+		UncoveredGrossCost:        800,
+		RecurringExpenses:         2,
+		ExcludedOneTimeExpenses:   1,
+	}
+	mockService.On("GetCostProjection", mock.Anything, "user123").Return(projection, nil)
+	
+	req := httptest.NewRequest("GET", "/health/cost-projection", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.CostProjectionResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 2400.0, response.ProjectedGrossCost)
+	assert.Equal(t, 1120.0, response.ExpectedInsurancePayments)
+	assert.Equal(t, 1280.0, response.NetOutOfPocket)
+	assert.Equal(t, 1, response.ExcludedOneTimeExpenses)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetExpenseAnalytics_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	projected := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	analytics := &services.MedicalExpenseAnalytics{
+		UserID:             "user123",
+		Year:               2025,
+		YearToDateTotal:    1800,
+		OutOfPocketTotal:   600,
+		InsurancePaidTotal: 1200,
+		ExpenseCount:       4,
+		ByCategory:         []services.CategoryTotals{{Category: "hospital", TotalAmount: 1500, ExpenseCount: 1}},
+		MonthlyRunRate:     150,
+		Policies: []services.DeductibleProgress{
+			{PolicyID: "10", Deductible: 2000, DeductibleMet: 1800, DeductibleRemaining: 200, ProjectedDeductibleMetMonth: &projected, WillMeetDeductibleThisYear: true},
+			{PolicyID: "11", Deductible: 5000, DeductibleMet: 100, DeductibleRemaining: 4900},
+		},
+	}
+	mockService.On("GetExpenseAnalytics", mock.Anything, "user123").Return(analytics, nil)
+
+	req := httptest.NewRequest("GET", "/health/expenses/analytics", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ExpenseAnalyticsResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1800.0, response.YearToDateTotal)
+	assert.Equal(t, 150.0, response.MonthlyRunRate)
+	assert.Len(t, response.ByCategory, 1)
+	assert.Len(t, response.Policies, 2)
+	assert.Equal(t, "2025-11", response.Policies[0].ProjectedDeductibleMetMonth)
+	assert.Empty(t, response.Policies[1].ProjectedDeductibleMetMonth)
+	assert.False(t, response.Policies[1].WillMeetDeductibleThisYear)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetExpenseBreakdown_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	breakdown := &services.MedicalExpenseBreakdown{
+		UserID:            "user123",
+		TotalAmount:       400,
+		CoveredAmount:     230,
+		OutOfPocketAmount: 170,
+		ExpenseCount:      2,
+		Categories: []services.ExpenseCategoryBreakdown{
+			{Category: "hospital", TotalAmount: 300, CoveredAmount: 150, OutOfPocketAmount: 150, ExpenseCount: 1, Share: 0.75},
+			{Category: "medication", TotalAmount: 100, CoveredAmount: 80, OutOfPocketAmount: 20, ExpenseCount: 1, Share: 0.25},
+		},
+	}
+	mockService.On("GetExpenseBreakdown", mock.Anything, "user123").Return(breakdown, nil)
+
+	req := httptest.NewRequest("GET", "/health/expenses/breakdown", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.ExpenseBreakdownResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 400.0, response.TotalAmount)
+	assert.Equal(t, 170.0, response.OutOfPocketAmount)
+	require.Len(t, response.Categories, 2)
+	assert.Equal(t, dtos.ExpenseCategoryBreakdownDTO{
+		Category: "hospital", TotalAmount: 300, CoveredAmount: 150, OutOfPocketAmount: 150, ExpenseCount: 1, Share: 0.75,
+	}, response.Categories[0])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetExpenseBreakdown_NoExpenses(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("GetExpenseBreakdown", mock.Anything, "user123").
+		Return(services.NewMedicalCostAnalyzer().BreakdownByCategory(nil), nil)
+
+	req := httptest.NewRequest("GET", "/health/expenses/breakdown", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"user_id": "", "total_amount": 0, "covered_amount": 0, "out_of_pocket_amount": 0, "expense_count": 0, "categories": []}`, w.Body.String())
+}
+
+func TestAddExpenseOccurrence_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	date := time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)
+	created := &domain.MedicalExpenseOccurrence{
+		ID: "31", ExpenseID: "7", UserID: "user123", ProfileID: "3", Amount: 112.5, Date: date,
+		Category: "medication", IsCovered: true, InsurancePayment: 90, OutOfPocket: 22.5,
+	}
+	mockService.On("AddExpenseOccurrence", mock.Anything, "user123", "7", mock.MatchedBy(func(o *domain.MedicalExpenseOccurrence) bool {
+		return o.Amount == 112.5 && o.Date.Equal(date)
+	})).Return(created, nil)
+
+	req := httptest.NewRequest("POST", "/health/expenses/7/occurrences", strings.NewReader(`{"amount":112.5,"date":"2025-03-03T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response dtos.ExpenseOccurrenceResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "31", response.ID)
+	assert.Equal(t, "medication", response.Category)
+	assert.Equal(t, 22.5, response.OutOfPocket)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestAddExpenseOccurrence_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		code   dtos.ErrorCode
+	}{
+		{"not_recurring", domain.ErrExpenseNotRecurring, http.StatusUnprocessableEntity, dtos.ErrorCodeHealthExpenseNotRecurring},
+		{"other_users_expense", domain.ErrMedicalExpenseNotFound, http.StatusNotFound, dtos.ErrorCodeHealthExpenseNotFound},
+		{"future_date", fmt.Errorf("occurrence validation failed: date cannot be in the future"), http.StatusBadRequest, dtos.ErrorCodeHealthInvalidData},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHealthService)
+			router := setupHealthTestRouter(NewHealthHandler(mockService))
+			mockService.On("AddExpenseOccurrence", mock.Anything, "user123", "7", mock.Anything).Return(nil, tt.err)
+
+			req := httptest.NewRequest("POST", "/health/expenses/7/occurrences", strings.NewReader(`{"amount":112.5,"date":"2025-03-03T00:00:00Z"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			var response dtos.SimpleErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.code, response.ErrorCode)
+		})
+	}
+}
+
+func TestAddExpenseOccurrence_InvalidAmount(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupHealthTestRouter(NewHealthHandler(mockService))
+
+	req := httptest.NewRequest("POST", "/health/expenses/7/occurrences", strings.NewReader(`{"amount":0,"date":"2025-03-03T00:00:00Z"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "AddExpenseOccurrence")
+}
+
+func TestGetExpenseOccurrences_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	router := setupHealthTestRouter(NewHealthHandler(mockService))
+
+	occurrences := []domain.MedicalExpenseOccurrence{
+		{ID: "31", ExpenseID: "7", Amount: 95, Date: time.Date(2025, time.January, 3, 0, 0, 0, 0, time.UTC)},
+		{ID: "32", ExpenseID: "7", Amount: 112.5, Date: time.Date(2025, time.February, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	mockService.On("GetExpenseOccurrences", mock.Anything, "user123", "7").Return(occurrences, nil)
+
+	req := httptest.NewRequest("GET", "/health/expenses/7/occurrences", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.ExpenseOccurrenceListResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 2, response.Total)
+	assert.Equal(t, "32", response.Occurrences[1].ID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetHSARecommendation_Eligible(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	recommendation := &services.HSARecommendation{
+		UserID:                         "user123",
+		Eligible:                       true,
+		CoverageTier:                   services.HSACoverageSelfOnly,
+		QualifyingPolicyID:             "10",
+		ProjectedAnnualExpenses:        6500,
+		AnnualContributionLimit:        4300,
+		RecommendedAnnualContribution:  4300,
+		RecommendedMonthlyContribution: 358.33,
+		Explanation:                    "capped at the annual contribution limit",
+	}
+	mockService.On("RecommendHSAContribution", mock.Anything, "user123").Return(recommendation, nil)
+	
+	req := httptest.NewRequest("GET", "/health/hsa-recommendation", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.HSARecommendationResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.True(t, response.Eligible)
+	assert.Equal(t, "10", response.QualifyingPolicyID)
+	assert.Equal(t, 358.33, response.RecommendedMonthlyContribution)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetHSARecommendation_ProfileNotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("RecommendHSAContribution", mock.Anything, "user123").Return(nil, fmt.Errorf("failed to get user profile: health profile not found"))
+	
+	req := httptest.NewRequest("GET", "/health/hsa-recommendation", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetRiskModel_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	mockService.On("GetRiskModel").Return(domain.DefaultRiskModel())
+	
+	req := httptest.NewRequest("GET", "/health/risk-model", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.RiskModelResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.AgeBands, 5)
+	assert.Nil(t, response.AgeBands[4].Max, "Open-ended band should have no max")
+	assert.Equal(t, 30.0, response.AgeBands[1].Min)
+	assert.Equal(t, 15, response.SeverityPoints["critical"])
+	assert.Equal(t, 25, response.LevelCutoffs.Low)
+	assert.Equal(t, 100, response.LevelCutoffs.MaxScore)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetRiskModel_Unauthorized(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	req := httptest.NewRequest("GET", "/health/risk-model", nil)
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockService.AssertNotCalled(t, "GetRiskModel")
+}
+
+func TestGetRiskHistory_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	now := time.Now()
+	history := &services.RiskHistory{
+		UserID: "user123",
+		Months: 12,
+		Entries: []domain.RiskSnapshot{
+			{UserID: "user123", RiskScore: 20, RiskLevel: "low", RecordedAt: now.AddDate(0, -2, 0)},
+			{UserID: "user123", RiskScore: 35, RiskLevel: "moderate", RecordedAt: now},
+		},
+		ScoreChange: 15,
+		Transitions: []services.RiskLevelTransition{{From: "low", To: "moderate", At: now}},
+	}
+	mockService.On("GetRiskHistory", mock.Anything, "user123", 12).Return(history, nil)
+	
+	req := httptest.NewRequest("GET", "/health/risk-history", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusOK, w.Code)
+	
+	var response dtos.RiskHistoryResponseDTO
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, response.Months)
+	require.Len(t, response.Entries, 2)
+	assert.Equal(t, 20, response.Entries[0].RiskScore)
+	assert.Equal(t, "moderate", response.Entries[1].RiskLevel)
+	assert.Equal(t, 15, response.ScoreChange)
+	require.Len(t, response.Transitions, 1)
+	assert.Equal(t, "low", response.Transitions[0].From)
+	assert.Equal(t, "moderate", response.Transitions[0].To)
+	
+	mockService.AssertExpectations(t)
+}
+
+func TestGetRiskHistory_InvalidMonths(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+	
+	req := httptest.NewRequest("GET", "/health/risk-history?months=0", nil)
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+	
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "GetRiskHistory", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEvaluateRiskWhatIf_Success(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	weight := 70.0
+	whatIf := services.RiskWhatIf{
+		Weight:             &weight,
+		SeverityChanges:    []services.ConditionSeverityChange{{ConditionID: "10", Severity: "mild"}},
+		RemoveConditionIDs: []string{"11"},
+	}
+	result := &services.RiskWhatIfResult{
+		UserID: "user123", CurrentScore: 37, CurrentLevel: "moderate", HypotheticalScore: 12, HypotheticalLevel: "low", ScoreChange: -25,
+		Factors: []services.RiskFactorChange{
+			{Factor: domain.RiskFactorBMI, CurrentPoints: 15, Change: -15},
+			{Factor: domain.RiskFactorCondition, ConditionID: "10", Name: "Hypertension", CurrentPoints: 10, HypotheticalPoints: 2, Change: -8},
+		},
+	}
+	mockService.On("EvaluateRiskWhatIf", mock.Anything, "user123", whatIf).Return(result, nil)
+
+	body := `{"weight": 70, "severity_changes": [{"condition_id": "10", "severity": "mild"}], "remove_condition_ids": ["11"]}`
+	req := httptest.NewRequest("POST", "/health/risk/what-if", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.RiskWhatIfResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 37, response.CurrentScore)
+	assert.Equal(t, "low", response.HypotheticalLevel)
+	assert.Equal(t, -25, response.ScoreChange)
+	require.Len(t, response.Factors, 2)
+	assert.Equal(t, dtos.RiskFactorChangeDTO{Factor: "condition", ConditionID: "10", Name: "Hypertension", CurrentPoints: 10, HypotheticalPoints: 2, Change: -8}, response.Factors[1])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestEvaluateRiskWhatIf_InvalidSeverity(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	body := `{"severity_changes": [{"condition_id": "10", "severity": "extreme"}]}`
+	req := httptest.NewRequest("POST", "/health/risk/what-if", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "EvaluateRiskWhatIf", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEvaluateRiskWhatIf_OtherUsersCondition(t *testing.T) {
+	mockService := new(MockHealthService)
+	handler := NewHealthHandler(mockService)
+	router := setupHealthTestRouter(handler)
+
+	mockService.On("EvaluateRiskWhatIf", mock.Anything, "user123", mock.Anything).
+		Return(nil, fmt.Errorf("condition 99 not found"))
+
+	req := httptest.NewRequest("POST", "/health/risk/what-if", strings.NewReader(`{"remove_condition_ids": ["99"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response dtos.SimpleErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeHealthConditionNotFound, response.ErrorCode)
+	assert.Equal(t, "Condition not found", response.Error)
+}
+
+// countingOwnerLookup is a services.HealthResourceOwnerLookup over fixed owners, keyed by
+// resource and ID, that counts its lookups
+type countingOwnerLookup struct {
+	owners  map[string]string
+	err     error
+	lookups int
+}
+
+func (l *countingOwnerLookup) GetResourceOwner(ctx context.Context, resource, id string) (string, error) {
+	l.lookups++
+	if l.err != nil {
+		return "", l.err
+	}
+	owner, ok := l.owners[resource+"/"+id]
+	if !ok {
+		return "", fmt.Errorf("medical condition with ID %s: %w", id, domain.ErrMedicalConditionNotFound)
+	}
+	return owner, nil
+}
+
+// setupHealthOwnershipTestRouter registers the condition routes behind the health ownership
+// middleware, the way the server does
+func setupHealthOwnershipTestRouter(handler *HealthHandler, owners services.HealthResourceOwnerLookup) *gin.Engine {
+	router := setupHealthTestRouter(handler)
+	ownership := middleware.NewHealthOwnershipMiddleware(owners)
+
+	owned := router.Group("/owned")
+	owned.PUT("/conditions/:id", ownership.ValidateHealthResourceOwnership("condition"), handler.UpdateCondition)
+	owned.DELETE("/conditions/:id", ownership.ValidateHealthResourceOwnership("condition"), handler.RemoveCondition)
+	return router
+}
+
+func TestUpdateCondition_OtherUsersCondition_NotFound(t *testing.T) {
+	mockService := new(MockHealthService)
+	lookup := &countingOwnerLookup{owners: map[string]string{"condition/7": "user-b"}}
+	router := setupHealthOwnershipTestRouter(NewHealthHandler(mockService), lookup)
+
+	req := httptest.NewRequest("PUT", "/owned/conditions/7", strings.NewReader(`{"name": "Asthma", "severity": "severe"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user-a"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response dtos.SimpleErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeHealthConditionNotFound, response.ErrorCode)
+	assert.Equal(t, "Condition not found", response.Error)
+	assert.Equal(t, 1, lookup.lookups)
+	mockService.AssertNotCalled(t, "UpdateCondition", mock.Anything, mock.Anything)
+}
+
+func TestUpdateCondition_OwnCondition_ChecksOwnershipOnce(t *testing.T) {
+	mockService := new(MockHealthService)
+	lookup := &countingOwnerLookup{owners: map[string]string{"condition/7": "user-a"}}
+	router := setupHealthOwnershipTestRouter(NewHealthHandler(mockService), lookup)
+
+	mockService.On("UpdateCondition", mock.Anything, mock.MatchedBy(func(condition *domain.MedicalCondition) bool {
+		return condition.ID == "7" && condition.UserID == "user-a"
+	})).Return(nil)
+
+	req := httptest.NewRequest("PUT", "/owned/conditions/7", strings.NewReader(`{"name": "Asthma", "severity": "severe"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user-a"))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 1, lookup.lookups, "ownership is checked exactly once per request")
+	mockService.AssertExpectations(t)
+}
+
+func TestRemoveCondition_OwnershipErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		conditionID    string
+		lookupErr      error
+		expectedStatus int
+		expectedCode   dtos.ErrorCode
+	}{
+		{"other_users", "7", nil, http.StatusNotFound, dtos.ErrorCodeHealthConditionNotFound},
+		{"missing", "99", nil, http.StatusNotFound, dtos.ErrorCodeHealthConditionNotFound},
+		{"lookup_failure", "7", fmt.Errorf("failed to get medical condition: connection refused"), http.StatusInternalServerError, dtos.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockHealthService)
+			lookup := &countingOwnerLookup{owners: map[string]string{"condition/7": "user-b"}, err: tt.lookupErr}
+			router := setupHealthOwnershipTestRouter(NewHealthHandler(mockService), lookup)
+
+			req := httptest.NewRequest("DELETE", "/owned/conditions/"+tt.conditionID, nil)
+			req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user-a"))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response dtos.SimpleErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.ErrorCode)
+			assert.Equal(t, 1, lookup.lookups)
+			mockService.AssertNotCalled(t, "RemoveCondition", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestValidateHealthResourceOwnership_UnknownResourcePanics(t *testing.T) {
+	ownership := middleware.NewHealthOwnershipMiddleware(&countingOwnerLookup{})
+
+	assert.Panics(t, func() { ownership.ValidateHealthResourceOwnership("conditon") })
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/DuckDHD/BuyOrBye/internal/database"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// racingProfileRepository holds every ExistsByUserID call until all the expected ones have been
+// made, so each concurrent request passes the existence check before any of them inserts
+type racingProfileRepository struct {
+	services.HealthProfileRepository
+	checked sync.WaitGroup
+}
+
+func (r *racingProfileRepository) ExistsByUserID(ctx context.Context, userID string) (bool, error) {
+	exists, err := r.HealthProfileRepository.ExistsByUserID(ctx, userID)
+	r.checked.Done()
+	r.checked.Wait()
+	return exists, err
+}
+
+// Simultaneous profile creations can all pass the service's existence check; the unique index on
+// self_user_id has to turn every insert but one into a conflict rather than a server error
+func TestCreateProfile_ConcurrentRequestsCreateOneProfile(t *testing.T) {
+	db, err := database.ConnectSQLite(filepath.Join(t.TempDir(), "health.db"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	require.NoError(t, database.RunAllMigrations(db))
+
+	const requests = 8
+	profileRepo := &racingProfileRepository{HealthProfileRepository: repositories.NewHealthProfileRepository(db)}
+	profileRepo.checked.Add(requests)
+	healthService := services.NewHealthService(
+		profileRepo,
+		repositories.NewMedicalConditionRepository(db),
+		repositories.NewMedicalExpenseRepository(db),
+		repositories.NewInsurancePolicyRepository(db),
+		nil,
+		services.NewRiskCalculator(domain.DefaultRiskModel()),
+		services.NewMedicalCostAnalyzer(),
+		services.NewInsuranceEvaluator(),
+	)
+	router := setupHealthTestRouter(NewHealthHandler(healthService))
+
+	body, _ := json.Marshal(dtos.CreateHealthProfileRequestDTO{
+		UserID: "user123", Age: 30, Gender: "female", Height: 165, Weight: 60, FamilySize: 1,
+	})
+	responses := make([]*httptest.ResponseRecorder, requests)
+	var wg sync.WaitGroup
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := httptest.NewRequest("POST", "/health/profile", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+createTestJWTToken("user123"))
+			responses[i] = httptest.NewRecorder()
+			router.ServeHTTP(responses[i], req)
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, w := range responses {
+		if w.Code == http.StatusCreated {
+			created++
+			continue
+		}
+		assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "already has a health profile")
+		assert.Contains(t, w.Body.String(), string(dtos.ErrorCodeHealthProfileExists))
+	}
+	assert.Equal(t, 1, created, "exactly one request creates the profile")
+
+	var count int64
+	require.NoError(t, db.Model(&models.HealthProfileModel{}).Where("user_id = ?", "user123").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MaintenanceHandler handles HTTP requests for operator maintenance endpoints
+type MaintenanceHandler struct {
+	tokenCleaner TokenCleaner
+}
+
+// NewMaintenanceHandler creates a new maintenance handler with dependency injection
+func NewMaintenanceHandler(tokenCleaner TokenCleaner) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		tokenCleaner: tokenCleaner,
+	}
+}
+
+// CleanupTokens handles POST /api/v1/admin/maintenance/cleanup-tokens requests
+// Purges expired refresh tokens immediately instead of waiting for the scheduled run
+// Returns 409 if a cleanup is already running
+//
+//	@Summary	Purge expired refresh tokens
+//	@Tags		admin
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200									{object}	dtos.TokenCleanupResponseDTO
+//	@Failure	401									{object}	dtos.ErrorResponseDTO
+//	@Failure	403									{object}	dtos.ErrorResponseDTO
+//	@Failure	409									{object}	dtos.ErrorResponseDTO
+//	@Failure	500									{object}	dtos.ErrorResponseDTO
+//	@Router		/admin/maintenance/cleanup-tokens	[post]
+func (h *MaintenanceHandler) CleanupTokens(c *gin.Context) {
+	logger := logging.ContextLogger(c).With(logging.WithOperation("cleanup_tokens"))
+
+	purged, err := h.tokenCleaner.RunOnce(c.Request.Context())
+	if err != nil {
+		if errors.Is(err, domain.ErrTokenCleanupInProgress) {
+			c.JSON(http.StatusConflict, dtos.NewErrorResponse(
+				http.StatusConflict,
+				"conflict",
+				"A token cleanup is already in progress",
+			))
+			return
+		}
+
+		logger.Error("Manual token cleanup failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"An internal error occurred. Please try again later",
+		))
+		return
+	}
+
+	logger.Info("Manual token cleanup completed", logging.WithRowsAffected(purged))
+	c.JSON(http.StatusOK, dtos.TokenCleanupResponseDTO{PurgedTokens: purged})
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockTokenCleaner is a mock implementation of TokenCleaner for testing
+type MockTokenCleaner struct {
+	mock.Mock
+}
+
+func (m *MockTokenCleaner) RunOnce(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func setupMaintenanceTestRouter(tokenCleaner TokenCleaner) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	handler := NewMaintenanceHandler(tokenCleaner)
+	r.POST("/admin/maintenance/cleanup-tokens", handler.CleanupTokens)
+
+	return r
+}
+
+func TestMaintenanceHandler_CleanupTokens(t *testing.T) {
+	tests := []struct {
+		name           string
+		purged         int64
+		err            error
+		expectedStatus int
+	}{
+		{name: "purges expired tokens", purged: 5, expectedStatus: http.StatusOK},
+		{name: "cleanup already running", err: domain.ErrTokenCleanupInProgress, expectedStatus: http.StatusConflict},
+		{name: "repository failure", err: errors.New("database unavailable"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tokenCleaner := new(MockTokenCleaner)
+			tokenCleaner.On("RunOnce", mock.Anything).Return(tt.purged, tt.err)
+			router := setupMaintenanceTestRouter(tokenCleaner)
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/admin/maintenance/cleanup-tokens", nil)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				var response dtos.TokenCleanupResponseDTO
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.purged, response.PurgedTokens)
+			}
+			tokenCleaner.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package handlers
+
+import "context"
+
+// TokenCleaner interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by MaintenanceHandler in this package
+type TokenCleaner interface {
+	// RunOnce purges expired refresh tokens and returns how many were removed
+	// Returns domain.ErrTokenCleanupInProgress if a cleanup is already running
+	RunOnce(ctx context.Context) (int64, error)
+}
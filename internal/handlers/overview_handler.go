@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// OverviewHandler handles HTTP requests for the combined finance and health overview
+type OverviewHandler struct {
+	overviewService OverviewService
+}
+
+// NewOverviewHandler creates a new overview handler with dependency injection
+func NewOverviewHandler(overviewService OverviewService) *OverviewHandler {
+	return &OverviewHandler{
+		overviewService: overviewService,
+	}
+}
+
+// GetOverview handles GET /api/v1/overview requests
+// Sections that fail to load are null and reported in errors; the response is still 200
+//
+//	@Summary	Get the combined finance and health overview
+//	@Tags		overview
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200			{object}	dtos.OverviewResponseDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/overview	[get]
+func (h *OverviewHandler) GetOverview(c *gin.Context) {
+	// Extract user ID from authentication context
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	overview, err := h.overviewService.GetOverview(c.Request.Context(), userID)
+	if err != nil {
+		if message, ok := contextErrorMessage(err); ok {
+			status, code := mapDomainError(err)
+			c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"Failed to load overview",
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, toOverviewResponse(overview))
+}
+
+// toOverviewResponse converts an overview to its response DTO, mapping section errors
+// to the same codes the finance and health endpoints use
+func toOverviewResponse(overview *services.Overview) dtos.OverviewResponseDTO {
+	response := dtos.OverviewResponseDTO{UserID: overview.UserID}
+
+	if overview.Finance != nil {
+		response.Finance = &dtos.FinanceSummaryResponseDTO{}
+		response.Finance.FromDomain(*overview.Finance)
+	}
+	if overview.MaxAffordable != nil {
+		// The affordable amount is derived from the same summary, so it shares its currency
+		currency := domain.DefaultCurrency
+		if overview.Finance != nil {
+			currency = overview.Finance.Currency
+		}
+		response.Affordability = &dtos.AffordabilityResponseDTO{
+			UserID:              overview.UserID,
+			MaxAffordableAmount: *overview.MaxAffordable,
+			Currency:            currency,
+			CalculationDate:     "now",
+		}
+	}
+	if overview.Health != nil {
+		response.Health = &dtos.HealthSummaryResponseDTO{}
+		response.Health.FromDomain(overview.Health)
+	}
+	if overview.Resilience != nil {
+		response.Resilience = &dtos.FinancialResilienceDTO{}
+		response.Resilience.FromDomain(*overview.Resilience)
+	}
+
+	if len(overview.Errors) > 0 {
+		response.Errors = make(map[string]dtos.SimpleErrorResponseDTO, len(overview.Errors))
+		for section, err := range overview.Errors {
+			var code dtos.ErrorCode
+			message := financeErrorMessage(err)
+			if section == services.OverviewSectionHealth {
+				_, code = mapHealthError(err)
+				message = "Health summary could not be loaded"
+			} else {
+				_, code = mapDomainError(err)
+			}
+			response.Errors[section] = dtos.NewSimpleErrorResponse(code, message)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// MockOverviewService is a mock implementation of OverviewService for testing
+type MockOverviewService struct {
+	mock.Mock
+}
+
+func (m *MockOverviewService) GetOverview(ctx context.Context, userID string) (*services.Overview, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.Overview), args.Error(1)
+}
+
+func setupOverviewTestRouter(overviewService OverviewService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	// Middleware to set authenticated user
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "test-user-123")
+		c.Next()
+	})
+
+	handler := NewOverviewHandler(overviewService)
+	r.GET("/api/v1/overview", handler.GetOverview)
+
+	return r
+}
+
+func TestOverviewHandler_GetOverview_Success(t *testing.T) {
+	// Arrange
+	mockOverviewService := new(MockOverviewService)
+	router := setupOverviewTestRouter(mockOverviewService)
+
+	finance := domain.FinanceSummary{UserID: "test-user-123", MonthlyIncome: 8000, DisposableIncome: 2400}
+	maxAffordable := 720.0
+	coverage := 2.0
+	mockOverviewService.On("GetOverview", mock.Anything, "test-user-123").Return(&services.Overview{
+		UserID:        "test-user-123",
+		Finance:       &finance,
+		MaxAffordable: &maxAffordable,
+		Health:        &domain.HealthSummary{UserID: "test-user-123", HealthRiskLevel: "low", EmergencyFundBalance: 12200},
+		Resilience: &domain.FinancialResilience{
+			HealthAdjustedDisposableIncome: 1900,
+			EmergencyFundCoverageMonths:    &coverage,
+			Score:                          80,
+			Grade:                          domain.ResilienceGradeB,
+			IncludesHealth:                 true,
+		},
+		Errors: map[string]error{},
+	}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/overview", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response dtos.OverviewResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Finance)
+	assert.Equal(t, 2400.0, response.Finance.DisposableIncome)
+	require.NotNil(t, response.Affordability)
+	assert.Equal(t, 720.0, response.Affordability.MaxAffordableAmount)
+	require.NotNil(t, response.Health)
+	assert.Equal(t, 12200.0, response.Health.EmergencyFundBalance)
+	require.NotNil(t, response.Resilience)
+	assert.Equal(t, "B", response.Resilience.Grade)
+	assert.Equal(t, 2.0, *response.Resilience.EmergencyFundCoverageMonths)
+	assert.Empty(t, response.Errors)
+	assert.NotContains(t, w.Body.String(), `"errors"`)
+}
+
+func TestOverviewHandler_GetOverview_PartialFailure(t *testing.T) {
+	// Arrange
+	mockOverviewService := new(MockOverviewService)
+	router := setupOverviewTestRouter(mockOverviewService)
+
+	maxAffordable := 720.0
+	mockOverviewService.On("GetOverview", mock.Anything, "test-user-123").Return(&services.Overview{
+		UserID:        "test-user-123",
+		MaxAffordable: &maxAffordable,
+		Errors: map[string]error{
+			services.OverviewSectionFinance: fmt.Errorf("failed to load summary: %w", domain.ErrFinanceSummaryNotFound),
+			services.OverviewSectionHealth:  errors.New("failed to get conditions: timeout"),
+		},
+	}, nil)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/overview", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.JSONEq(t, "null", string(body["finance"]))
+	assert.JSONEq(t, "null", string(body["health"]))
+	assert.JSONEq(t, "null", string(body["resilience"]))
+
+	var response dtos.OverviewResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.NotNil(t, response.Affordability)
+	assert.Len(t, response.Errors, 2)
+	assert.Equal(t, dtos.ErrorCodeFinSummaryNotFound, response.Errors["finance"].ErrorCode)
+	assert.Equal(t, dtos.ErrorCodeInternal, response.Errors["health"].ErrorCode)
+}
+
+func TestOverviewHandler_GetOverview_Cancelled(t *testing.T) {
+	// Arrange
+	mockOverviewService := new(MockOverviewService)
+	router := setupOverviewTestRouter(mockOverviewService)
+
+	mockOverviewService.On("GetOverview", mock.Anything, "test-user-123").Return(nil, context.Canceled)
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/overview", nil)
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, dtos.StatusClientClosedRequest, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeRequestCanceled, response.ErrorCode)
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// OverviewService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by OverviewHandler in this package
+type OverviewService interface {
+	// GetOverview returns the user's finance and health summaries with the figures derived from both
+	// Sections that fail to load are reported in Overview.Errors; an error is only returned
+	// when the request context is cancelled
+	GetOverview(ctx context.Context, userID string) (*services.Overview, error)
+}
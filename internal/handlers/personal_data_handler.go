@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// PersonalDataHandler handles HTTP requests for the caller's own personal data across all domains
+type PersonalDataHandler struct {
+	exportService PersonalDataExportService
+}
+
+// NewPersonalDataHandler creates a new personal data handler with dependency injection
+func NewPersonalDataHandler(exportService PersonalDataExportService) *PersonalDataHandler {
+	return &PersonalDataHandler{
+		exportService: exportService,
+	}
+}
+
+// ExportMyData handles GET /api/v1/me/export requests
+// Downloads everything stored about the caller as one JSON document
+//
+//	@Summary	Export all my personal data
+//	@Description	Returns the caller's account (without the password), all finance records and all health records:
+//	@Description	health profiles, conditions, medical expenses and insurance policies, including inactive ones.
+//	@Description	Unlike /finance/export this is a complete personal data download, not a report. Sent as an attachment.
+//	@Tags		account
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200			{object}	dtos.PersonalDataExportDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	404			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/me/export	[get]
+func (h *PersonalDataHandler) ExportMyData(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	export, err := h.exportService.ExportPersonalData(c.Request.Context(), userID)
+	if err != nil {
+		h.handleExportError(c, err)
+		return
+	}
+
+	// Health records aren't meant to be cached anywhere on the way to the user
+	c.Header("Cache-Control", "no-store")
+	c.Header("Content-Disposition", `attachment; filename="buyorbye-personal-data-`+export.GeneratedAt.Format("2006-01-02")+`.json"`)
+	c.JSON(http.StatusOK, toPersonalDataExportResponse(export))
+}
+
+// handleExportError maps service errors to HTTP responses
+func (h *PersonalDataHandler) handleExportError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			http.StatusNotFound,
+			"not_found",
+			"User not found",
+		))
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Personal data export failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"Failed to export personal data",
+		))
+	}
+}
+
+// toPersonalDataExportResponse converts a personal data export to its response DTO
+func toPersonalDataExportResponse(export *services.PersonalDataExport) dtos.PersonalDataExportDTO {
+	response := dtos.PersonalDataExportDTO{
+		SchemaVersion: export.SchemaVersion,
+		GeneratedAt:   export.GeneratedAt,
+	}
+	response.User.FromDomain(export.User)
+
+	finance := export.Finance
+	response.Finance.Incomes = make([]dtos.IncomeResponseDTO, len(finance.Incomes))
+	for i, income := range finance.Incomes {
+		response.Finance.Incomes[i].FromDomain(income)
+	}
+	response.Finance.Expenses = make([]dtos.ExpenseResponseDTO, len(finance.Expenses))
+	for i, expense := range finance.Expenses {
+		response.Finance.Expenses[i].FromDomain(expense)
+	}
+	response.Finance.Loans = make([]dtos.LoanResponseDTO, len(finance.Loans))
+	for i, loan := range finance.Loans {
+		response.Finance.Loans[i].FromDomain(loan)
+	}
+	response.Finance.SavingsGoals = make([]dtos.SavingsGoalResponseDTO, len(finance.SavingsGoals))
+	for i, goal := range finance.SavingsGoals {
+		response.Finance.SavingsGoals[i].FromDomain(goal)
+	}
+	response.Finance.Budgets = make([]dtos.BudgetResponseDTO, len(finance.Budgets))
+	for i, budget := range finance.Budgets {
+		response.Finance.Budgets[i].FromDomain(budget)
+	}
+
+	health := export.Health
+	response.Health.Profiles = make([]dtos.HealthProfileResponseDTO, len(health.Profiles))
+	for i := range health.Profiles {
+		response.Health.Profiles[i].FromDomain(&health.Profiles[i])
+	}
+	response.Health.Conditions = make([]dtos.MedicalConditionResponseDTO, len(health.Conditions))
+	for i := range health.Conditions {
+		response.Health.Conditions[i].FromDomain(&health.Conditions[i])
+	}
+	response.Health.Expenses = make([]dtos.MedicalExpenseResponseDTO, len(health.Expenses))
+	for i := range health.Expenses {
+		response.Health.Expenses[i].FromDomain(&health.Expenses[i])
+	}
+	response.Health.Policies = make([]dtos.InsurancePolicyResponseDTO, len(health.Policies))
+	for i := range health.Policies {
+		response.Health.Policies[i].FromDomain(&health.Policies[i])
+	}
+
+	return response
+}
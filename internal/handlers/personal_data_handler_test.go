@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories/memory"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// MockPersonalDataExportService is a mock implementation of PersonalDataExportService
+type MockPersonalDataExportService struct {
+	mock.Mock
+}
+
+func (m *MockPersonalDataExportService) ExportPersonalData(ctx context.Context, userID string) (*services.PersonalDataExport, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PersonalDataExport), args.Error(1)
+}
+
+// memorySavingsGoalRepository keeps savings goals in memory; contributions aren't supported
+type memorySavingsGoalRepository struct {
+	services.SavingsGoalRepository
+	goals []domain.SavingsGoal
+}
+
+func (r *memorySavingsGoalRepository) SaveGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	r.goals = append(r.goals, goal)
+	return nil
+}
+
+func (r *memorySavingsGoalRepository) GetUserGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	var goals []domain.SavingsGoal
+	for _, goal := range r.goals {
+		if goal.UserID == userID {
+			goals = append(goals, goal)
+		}
+	}
+	return goals, nil
+}
+
+func setupPersonalDataTestRouter(exportService PersonalDataExportService, userID string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if userID != "" {
+			c.Set("userID", userID)
+		}
+		c.Next()
+	})
+
+	handler := NewPersonalDataHandler(exportService)
+	r.GET("/api/v1/me/export", handler.ExportMyData)
+	return r
+}
+
+// Populates an account through the real finance and health services over in-memory
+// repositories, then exports it through the handler
+func TestPersonalDataHandler_ExportMyData_PopulatedUser(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewStore()
+	userRepo := memory.NewUserRepository(store)
+	user := &domain.User{
+		Email: "export@example.com", Name: "Export User", PasswordHash: "$2a$10$secret-hash", IsActive: true,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}
+	require.NoError(t, userRepo.Create(ctx, user))
+
+	financeService := services.NewFinanceService(services.NewFinanceRepositories(
+		memory.NewIncomeRepository(store),
+		memory.NewExpenseRepository(store),
+		memory.NewLoanRepository(store),
+		&memorySavingsGoalRepository{},
+		nil,
+	))
+	healthService := services.NewHealthService(
+		memory.NewHealthProfileRepository(store),
+		memory.NewMedicalConditionRepository(store),
+		memory.NewMedicalExpenseRepository(store),
+		memory.NewInsurancePolicyRepository(store),
+		nil,
+		services.NewRiskCalculator(domain.DefaultRiskModel()),
+		services.NewMedicalCostAnalyzer(),
+		services.NewInsuranceEvaluator(),
+	)
+
+	now := time.Now()
+	require.NoError(t, financeService.AddIncome(ctx, domain.Income{
+		UserID: user.ID, Source: "Salary", Amount: 5000, Frequency: "monthly", IsActive: true,
+		CreatedAt: now, UpdatedAt: now,
+	}, false))
+	require.NoError(t, financeService.AddExpense(ctx, domain.Expense{
+		UserID: user.ID, Category: "housing", Name: "Rent", Amount: 1500, Frequency: "monthly", IsFixed: true, Priority: 1,
+		CreatedAt: now, UpdatedAt: now,
+	}, false))
+	require.NoError(t, financeService.AddLoan(ctx, domain.Loan{
+		UserID: user.ID, Lender: "Bank", Type: "auto", PrincipalAmount: 20000, RemainingBalance: 15000,
+		MonthlyPayment: 400, InterestRate: 5, EndDate: now.AddDate(3, 0, 0),
+		CreatedAt: now, UpdatedAt: now,
+	}))
+	require.NoError(t, financeService.AddSavingsGoal(ctx, domain.SavingsGoal{
+		UserID: user.ID, Name: "Emergency fund", TargetAmount: 10000, TargetDate: now.AddDate(1, 0, 0), Priority: 1,
+		CreatedAt: now, UpdatedAt: now,
+	}))
+
+	profile := &domain.HealthProfile{UserID: user.ID, Age: 40, Gender: "female", Height: 165, Weight: 60, FamilySize: 1}
+	require.NoError(t, healthService.CreateProfile(ctx, profile))
+	profile, err := healthService.GetProfile(ctx, user.ID)
+	require.NoError(t, err)
+	require.NoError(t, healthService.AddCondition(ctx, &domain.MedicalCondition{
+		UserID: user.ID, Name: "Asthma", Category: "chronic", Severity: "mild",
+		DiagnosedDate: now.AddDate(-2, 0, 0), IsActive: true,
+	}))
+	require.NoError(t, healthService.AddExpense(ctx, &domain.MedicalExpense{
+		UserID: user.ID, ProfileID: profile.ID, Amount: 120, Category: "doctor_visit", Description: "Checkup",
+		Frequency: "one_time", OutOfPocket: 120, Date: now.AddDate(0, -1, 0),
+	}))
+	require.NoError(t, healthService.AddInsurancePolicy(ctx, &domain.InsurancePolicy{
+		UserID: user.ID, ProfileID: profile.ID, Provider: "Acme Health", PolicyNumber: "ACME-123", Type: "health",
+		CoveragePercentage: 80, Deductible: 1000, OutOfPocketMax: 5000, MonthlyPremium: 300,
+		StartDate: now.AddDate(-1, 0, 0), EndDate: now.AddDate(1, 0, 0), IsActive: true,
+	}))
+
+	router := setupPersonalDataTestRouter(services.NewPersonalDataExportService(userRepo, financeService, healthService), user.ID)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/me/export", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), `attachment; filename="buyorbye-personal-data-`)
+	assert.NotContains(t, w.Body.String(), "secret-hash")
+
+	var export map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	assert.JSONEq(t, "1", string(export["schema_version"]))
+	var generatedAt time.Time
+	require.NoError(t, json.Unmarshal(export["generated_at"], &generatedAt))
+	assert.WithinDuration(t, time.Now(), generatedAt, time.Minute)
+
+	var account map[string]any
+	require.NoError(t, json.Unmarshal(export["user"], &account))
+	assert.Equal(t, "export@example.com", account["email"])
+	assert.NotContains(t, account, "password")
+	assert.NotContains(t, account, "password_hash")
+
+	sections := map[string][]string{
+		"finance": {"incomes", "expenses", "loans", "savings_goals"},
+		"health":  {"profiles", "conditions", "medical_expenses", "insurance_policies"},
+	}
+	for section, lists := range sections {
+		var records map[string][]map[string]any
+		require.NoError(t, json.Unmarshal(export[section], &records), section)
+		for _, list := range lists {
+			assert.Len(t, records[list], 1, "%s.%s", section, list)
+		}
+	}
+
+	var finance map[string][]any
+	require.NoError(t, json.Unmarshal(export["finance"], &finance))
+	assert.NotNil(t, finance["budgets"], "empty sections are empty lists, not null")
+	assert.Empty(t, finance["budgets"])
+}
+
+func TestPersonalDataHandler_ExportMyData_EmptyAccountHasEmptyLists(t *testing.T) {
+	exportService := new(MockPersonalDataExportService)
+	exportService.On("ExportPersonalData", mock.Anything, "user-123").Return(&services.PersonalDataExport{
+		SchemaVersion: services.PersonalDataExportSchemaVersion,
+		GeneratedAt:   time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC),
+		User:          domain.User{ID: "user-123", Email: "user@example.com"},
+	}, nil)
+	router := setupPersonalDataTestRouter(exportService, "user-123")
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api/v1/me/export", nil)
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `attachment; filename="buyorbye-personal-data-2025-03-01.json"`, w.Header().Get("Content-Disposition"))
+	var export struct {
+		Finance map[string][]any `json:"finance"`
+		Health  map[string][]any `json:"health"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &export))
+	for _, section := range []map[string][]any{export.Finance, export.Health} {
+		for name, list := range section {
+			assert.NotNil(t, list, name)
+		}
+	}
+	assert.Len(t, export.Finance, 5)
+	assert.Len(t, export.Health, 4)
+}
+
+func TestPersonalDataHandler_ExportMyData_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		err        error
+		wantStatus int
+	}{
+		{"unauthenticated", "", nil, http.StatusUnauthorized},
+		{"user not found", "user-123", domain.ErrUserNotFound, http.StatusNotFound},
+		{"section failed", "user-123", errors.New("failed to export loans: database unavailable"), http.StatusInternalServerError},
+		{"timeout", "user-123", context.DeadlineExceeded, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exportService := new(MockPersonalDataExportService)
+			exportService.On("ExportPersonalData", mock.Anything, tt.userID).Return(nil, tt.err)
+			router := setupPersonalDataTestRouter(exportService, tt.userID)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/api/v1/me/export", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Empty(t, w.Header().Get("Content-Disposition"))
+			assert.NotContains(t, w.Body.String(), "database unavailable")
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// PersonalDataExportService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by PersonalDataHandler in this package
+type PersonalDataExportService interface {
+	// ExportPersonalData gathers the user's account, finance and health records into one export
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	// Fails as a whole if any section fails to load
+	ExportPersonalData(ctx context.Context, userID string) (*services.PersonalDataExport, error)
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/health"
+)
+
+// ReadinessChecker interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by ReadinessProbe in this package
+type ReadinessChecker interface {
+	// Run runs every readiness check and reports which ones failed
+	Run(ctx context.Context) health.Report
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/health"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+const (
+	dependencyUp   = "up"
+	dependencyDown = "down"
+)
+
+// LivenessProbe returns a handler for GET /healthz
+// It only reports that the process is serving requests and never touches dependencies,
+// so it stays cheap enough for frequent liveness probes
+func LivenessProbe() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, dtos.ProbeResponseDTO{
+			Status:  "ok",
+			Message: "BuyOrBye API is running",
+		})
+	}
+}
+
+// ReadinessProbe returns a handler for GET /readyz
+// It runs every registered readiness check and reports each one's status, responding 503 with
+// the failed checks listed if any fails. During graceful shutdown only the shutdown check is
+// reported, as failed.
+func ReadinessProbe(checker ReadinessChecker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := checker.Run(c.Request.Context())
+
+		response := dtos.ProbeResponseDTO{
+			Status: "ok",
+			Checks: make(map[string]string, len(report.Checks)),
+		}
+		for name, err := range report.Checks {
+			response.Checks[name] = dependencyUp
+			if err != nil {
+				response.Checks[name] = dependencyDown
+			}
+		}
+
+		if report.Ready() {
+			c.JSON(http.StatusOK, response)
+			return
+		}
+
+		response.Status = "unavailable"
+		response.Failed = report.Failed()
+		for _, name := range response.Failed {
+			if name == health.ShutdownCheck {
+				continue
+			}
+			logging.ContextLogger(c).Warn("Readiness check failed", logging.WithComponent(name), logging.WithError(report.Checks[name]))
+		}
+		c.JSON(http.StatusServiceUnavailable, response)
+	}
+}
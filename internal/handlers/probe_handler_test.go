@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/health"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+func setupProbeTestRouter(t *testing.T) (*gin.Engine, config.DatabaseService, *health.Registry) {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	dbService, err := config.NewDatabaseService(&config.DatabaseConfig{
+		Database:     ":memory:",
+		MaxIdleConns: 1,
+		MaxOpenConns: 1,
+	}, &config.LoggingConfig{Level: "silent"})
+	require.NoError(t, err)
+	t.Cleanup(func() { dbService.Close() })
+
+	readiness := health.NewRegistry()
+	readiness.Register("db", dbService.Ping)
+	readiness.Register("migrations", func(ctx context.Context) error { return nil })
+
+	r := gin.New()
+	r.GET("/healthz", LivenessProbe())
+	r.GET("/readyz", ReadinessProbe(readiness))
+
+	return r, dbService, readiness
+}
+
+func TestReadinessProbe_DatabaseUp_ReturnsOK(t *testing.T) {
+	router, _, _ := setupProbeTestRouter(t)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.ProbeResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "ok", response.Status)
+	assert.Equal(t, map[string]string{"db": "up", "migrations": "up"}, response.Checks)
+	assert.Empty(t, response.Failed)
+}
+
+func TestReadinessProbe_DatabaseClosed_ReturnsServiceUnavailable(t *testing.T) {
+	router, dbService, _ := setupProbeTestRouter(t)
+	require.NoError(t, dbService.Close())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var response dtos.ProbeResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "unavailable", response.Status)
+	assert.Equal(t, "down", response.Checks["db"])
+	assert.Equal(t, "up", response.Checks["migrations"])
+	assert.Equal(t, []string{"db"}, response.Failed)
+
+	// Liveness doesn't depend on the database
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadinessProbe_Draining_ReturnsServiceUnavailable(t *testing.T) {
+	router, _, readiness := setupProbeTestRouter(t)
+	readiness.Drain()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var response dtos.ProbeResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, []string{health.ShutdownCheck}, response.Failed)
+	assert.Equal(t, map[string]string{health.ShutdownCheck: "down"}, response.Checks)
+
+	// The process is still alive while it drains
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
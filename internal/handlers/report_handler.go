@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/reports"
+)
+
+// ReportHandler handles HTTP requests for the reports users download and archive
+type ReportHandler struct {
+	reportService ReportService
+	pdfRenderer   ReportPDFRenderer
+}
+
+// NewReportHandler creates a new report handler with dependency injection
+// A nil pdfRenderer turns PDF reports off; asking for one is answered with 406
+func NewReportHandler(reportService ReportService, pdfRenderer ReportPDFRenderer) *ReportHandler {
+	return &ReportHandler{
+		reportService: reportService,
+		pdfRenderer:   pdfRenderer,
+	}
+}
+
+// GetMonthlyReport handles GET /api/v1/reports/monthly requests
+// Renders the user's statement for a calendar month as HTML, or as PDF for format=pdf or Accept: application/pdf
+//
+//	@Summary		Get a monthly statement
+//	@Description	Income received, expenses by category, loan payments, net savings, health spending and how the
+//	@Description	financial health score moved over a calendar month, in UTC. A month without records renders an
+//	@Description	empty statement. The ETag changes whenever a record behind the statement changes; send it back
+//	@Description	in If-None-Match to get 304 Not Modified.
+//	@Tags			reports
+//	@Produce		html
+//	@Produce		application/pdf
+//	@Security		BearerAuth
+//	@Param			year			query		int		true	"Year, 2000 or later"
+//	@Param			month			query		int		true	"Month, 1-12"
+//	@Param			format			query		string	false	"html or pdf; defaults to the Accept header, then html"
+//	@Success		200				{string}	string	"The statement"
+//	@Success		304				{string}	string	"Not modified"
+//	@Failure		400				{object}	dtos.ErrorResponseDTO
+//	@Failure		401				{object}	dtos.ErrorResponseDTO
+//	@Failure		406				{object}	dtos.ErrorResponseDTO
+//	@Failure		500				{object}	dtos.ErrorResponseDTO
+//	@Router			/reports/monthly	[get]
+func (h *ReportHandler) GetMonthlyReport(c *gin.Context) {
+	userID := middleware.GetUserID(c)
+	if userID == "" {
+		c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			http.StatusUnauthorized,
+			"unauthorized",
+			"Authentication required",
+		))
+		return
+	}
+
+	var query dtos.MonthlyReportQueryDTO
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+			http.StatusBadRequest,
+			"bad_request",
+			"Invalid query parameters: year and month are required integers",
+		))
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		format = dtos.ReportFormatHTML
+		if acceptsPDF(c.GetHeader("Accept")) {
+			format = dtos.ReportFormatPDF
+		}
+	}
+	if format != dtos.ReportFormatHTML && format != dtos.ReportFormatPDF {
+		c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(http.StatusBadRequest, "bad_request", "format must be html or pdf"))
+		return
+	}
+	if format == dtos.ReportFormatPDF && h.pdfRenderer == nil {
+		c.JSON(http.StatusNotAcceptable, dtos.NewCodedErrorResponse(
+			http.StatusNotAcceptable,
+			dtos.ErrorCodeReportPDFUnavailable,
+			"PDF reports are not enabled on this server; request format=html",
+		))
+		return
+	}
+
+	ctx := c.Request.Context()
+	statement, err := h.reportService.GetMonthlyStatement(ctx, userID, query.Year, time.Month(query.Month))
+	if err != nil {
+		h.handleReportError(c, err, "Failed to load monthly statement")
+		return
+	}
+
+	// The tag is checked before rendering, so an unchanged statement costs only the queries
+	tag := monthlyReportETag(statement, format)
+	c.Header("ETag", tag)
+	c.Header("Vary", "Accept")
+	c.Header("Cache-Control", "private, no-cache")
+	if middleware.ETagMatches(c.GetHeader("If-None-Match"), tag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := reports.RenderMonthlyStatementHTML(&page, statement); err != nil {
+		h.handleReportError(c, err, "Failed to render monthly statement")
+		return
+	}
+
+	body, contentType := page.Bytes(), "text/html; charset=utf-8"
+	if format == dtos.ReportFormatPDF {
+		body, err = h.pdfRenderer.RenderPDF(ctx, body)
+		if err != nil {
+			h.handleReportError(c, err, "Failed to render monthly statement")
+			return
+		}
+		contentType = "application/pdf"
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="statement-%04d-%02d.%s"`, query.Year, query.Month, format))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// handleReportError writes the error response for a report that couldn't be built, with
+// fallback as the message for errors the client can't act on
+func (h *ReportHandler) handleReportError(c *gin.Context, err error, fallback string) {
+	status, code := mapDomainError(err)
+
+	message := fallback
+	if contextMessage, ok := contextErrorMessage(err); ok {
+		message = contextMessage
+	} else if code == dtos.ErrorCodeReportInvalidPeriod {
+		message = err.Error()
+	} else {
+		logging.ContextLogger(c).Error(fallback, logging.WithUserID(middleware.GetUserID(c)), logging.WithError(err))
+	}
+
+	c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+}
+
+// acceptsPDF reports whether an Accept header asks for application/pdf without refusing it with q=0
+func acceptsPDF(accept string) bool {
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil || mediaType != "application/pdf" {
+			continue
+		}
+		q, err := strconv.ParseFloat(params["q"], 64)
+		return err != nil || q > 0
+	}
+	return false
+}
+
+// monthlyReportETag tags a rendering of the statement. Any change to a record behind the
+// statement moves its latest update, and a deletion lowers its record count, so the tag
+// changes with the statement without rendering it. The tag is weak because the PDF
+// renderer may stamp each rendering differently.
+func monthlyReportETag(statement *domain.MonthlyStatement, format string) string {
+	version := fmt.Sprintf("%s|%04d-%02d|%s|%s|%d",
+		statement.UserID, statement.Year, statement.Month, format,
+		statement.LastUpdated().UTC().Format(time.RFC3339Nano), statement.RecordCount())
+	sum := sha256.Sum256([]byte(version))
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
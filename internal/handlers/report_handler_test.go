@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// MockReportService is a mock implementation of ReportService for testing
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) GetMonthlyStatement(ctx context.Context, userID string, year int, month time.Month) (*domain.MonthlyStatement, error) {
+	args := m.Called(ctx, userID, year, month)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MonthlyStatement), args.Error(1)
+}
+
+// fakePDFRenderer "renders" a PDF by prefixing the HTML with a PDF header
+type fakePDFRenderer struct {
+	calls int
+}
+
+func (r *fakePDFRenderer) RenderPDF(ctx context.Context, html []byte) ([]byte, error) {
+	r.calls++
+	return append([]byte("%PDF-fake\n"), html...), nil
+}
+
+func setupReportTestRouter(reportService ReportService, pdfRenderer ReportPDFRenderer) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	// Middleware to set authenticated user
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "test-user-123")
+		c.Next()
+	})
+
+	handler := NewReportHandler(reportService, pdfRenderer)
+	r.GET("/api/v1/reports/monthly", handler.GetMonthlyReport)
+
+	return r
+}
+
+func reportTestStatement() *domain.MonthlyStatement {
+	return &domain.MonthlyStatement{
+		UserID: "test-user-123",
+		Year:   2025,
+		Month:  time.March,
+		Finance: domain.MonthlyFinanceActivity{
+			Currency:    "USD",
+			Income:      []domain.StatementLine{{Label: "Salary", Amount: 4000, Count: 1}},
+			TotalIncome: 4000,
+			RecordCount: 1,
+			LastUpdated: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func getMonthlyReport(router *gin.Engine, query string, headers map[string]string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/reports/monthly?"+query, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestReportHandler_GetMonthlyReport_HTML(t *testing.T) {
+	reportService := new(MockReportService)
+	router := setupReportTestRouter(reportService, nil)
+	reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.March).Return(reportTestStatement(), nil)
+
+	w := getMonthlyReport(router, "year=2025&month=3", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename="statement-2025-03.html"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Accept", w.Header().Get("Vary"))
+	assert.Regexp(t, `^W/"[0-9a-f]{32}"$`, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "Monthly statement: March 2025")
+	assert.Contains(t, w.Body.String(), "4,000.00")
+}
+
+func TestReportHandler_GetMonthlyReport_EmptyMonth(t *testing.T) {
+	reportService := new(MockReportService)
+	router := setupReportTestRouter(reportService, nil)
+	reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2024, time.June).Return(&domain.MonthlyStatement{
+		UserID: "test-user-123",
+		Year:   2024,
+		Month:  time.June,
+	}, nil)
+
+	w := getMonthlyReport(router, "year=2024&month=6", nil)
+
+	assert.Equal(t, http.StatusOK, w.Code, "a month without records isn't missing")
+	assert.Contains(t, w.Body.String(), "Nothing was recorded for June 2024")
+}
+
+func TestReportHandler_GetMonthlyReport_PDF(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		headers map[string]string
+	}{
+		{"accept header", "year=2025&month=3", map[string]string{"Accept": "text/html;q=0.9, application/pdf"}},
+		{"format parameter", "year=2025&month=3&format=pdf", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportService := new(MockReportService)
+			renderer := &fakePDFRenderer{}
+			router := setupReportTestRouter(reportService, renderer)
+			reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.March).Return(reportTestStatement(), nil)
+
+			w := getMonthlyReport(router, tt.query, tt.headers)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+			assert.Equal(t, `inline; filename="statement-2025-03.pdf"`, w.Header().Get("Content-Disposition"))
+			assert.Contains(t, w.Body.String(), "%PDF-fake\n<!DOCTYPE html>")
+			assert.Equal(t, 1, renderer.calls)
+		})
+	}
+
+	t.Run("format parameter wins over the accept header", func(t *testing.T) {
+		reportService := new(MockReportService)
+		renderer := &fakePDFRenderer{}
+		router := setupReportTestRouter(reportService, renderer)
+		reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.March).Return(reportTestStatement(), nil)
+
+		w := getMonthlyReport(router, "year=2025&month=3&format=html", map[string]string{"Accept": "application/pdf"})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, 0, renderer.calls)
+	})
+}
+
+func TestReportHandler_GetMonthlyReport_PDFDisabled(t *testing.T) {
+	reportService := new(MockReportService)
+	router := setupReportTestRouter(reportService, nil)
+
+	w := getMonthlyReport(router, "year=2025&month=3", map[string]string{"Accept": "application/pdf"})
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeReportPDFUnavailable, response.ErrorCode)
+	reportService.AssertNotCalled(t, "GetMonthlyStatement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReportHandler_GetMonthlyReport_ETag(t *testing.T) {
+	reportService := new(MockReportService)
+	renderer := &fakePDFRenderer{}
+	router := setupReportTestRouter(reportService, renderer)
+	statement := reportTestStatement()
+	reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.March).Return(statement, nil)
+
+	first := getMonthlyReport(router, "year=2025&month=3&format=pdf", nil)
+	tag := first.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+
+	// Unchanged records: 304 without rendering the PDF again
+	cached := getMonthlyReport(router, "year=2025&month=3&format=pdf", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusNotModified, cached.Code)
+	assert.Empty(t, cached.Body.String())
+	assert.Equal(t, tag, cached.Header().Get("ETag"))
+	assert.Equal(t, 1, renderer.calls)
+
+	// The HTML rendering is tagged separately
+	html := getMonthlyReport(router, "year=2025&month=3", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusOK, html.Code)
+	assert.NotEqual(t, tag, html.Header().Get("ETag"))
+
+	// Updating a record changes the tag
+	statement.Finance.LastUpdated = statement.Finance.LastUpdated.Add(time.Minute)
+	updated := getMonthlyReport(router, "year=2025&month=3&format=pdf", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusOK, updated.Code)
+	assert.NotEqual(t, tag, updated.Header().Get("ETag"))
+
+	// So does deleting one
+	statement.Finance.LastUpdated = statement.Finance.LastUpdated.Add(-time.Minute)
+	statement.Finance.RecordCount = 0
+	deleted := getMonthlyReport(router, "year=2025&month=3&format=pdf", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusOK, deleted.Code)
+	assert.NotEqual(t, tag, deleted.Header().Get("ETag"))
+}
+
+func TestReportHandler_GetMonthlyReport_BadRequest(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"missing month", "year=2025"},
+		{"month not a number", "year=2025&month=march"},
+		{"unknown format", "year=2025&month=3&format=docx"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportService := new(MockReportService)
+			router := setupReportTestRouter(reportService, nil)
+
+			w := getMonthlyReport(router, tt.query, nil)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			reportService.AssertNotCalled(t, "GetMonthlyStatement", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestReportHandler_GetMonthlyReport_InvalidPeriod(t *testing.T) {
+	reportService := new(MockReportService)
+	router := setupReportTestRouter(reportService, nil)
+	reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.Month(13)).
+		Return(nil, fmt.Errorf("%w: month must be between 1 and 12", domain.ErrInvalidReportPeriod))
+
+	w := getMonthlyReport(router, "year=2025&month=13", nil)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeReportInvalidPeriod, response.ErrorCode)
+	assert.Equal(t, "invalid report period: month must be between 1 and 12", response.Message)
+}
+
+func TestReportHandler_GetMonthlyReport_ServiceError(t *testing.T) {
+	reportService := new(MockReportService)
+	router := setupReportTestRouter(reportService, nil)
+	reportService.On("GetMonthlyStatement", mock.Anything, "test-user-123", 2025, time.March).
+		Return(nil, errors.New("database unavailable"))
+
+	w := getMonthlyReport(router, "year=2025&month=3", nil)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "Failed to load monthly statement", response.Message)
+}
+
+func TestAcceptsPDF(t *testing.T) {
+	assert.True(t, acceptsPDF("application/pdf"))
+	assert.True(t, acceptsPDF("text/html;q=0.9, application/pdf;q=0.5"))
+	assert.False(t, acceptsPDF(""))
+	assert.False(t, acceptsPDF("*/*"), "a wildcard gets the default HTML")
+	assert.False(t, acceptsPDF("text/html, application/pdf;q=0"))
+}
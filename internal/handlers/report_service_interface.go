@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// ReportService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by ReportHandler in this package
+type ReportService interface {
+	// GetMonthlyStatement gathers the user's finances and medical spending for a calendar month
+	// Returns an error wrapping domain.ErrInvalidReportPeriod for a month that doesn't exist or hasn't started
+	GetMonthlyStatement(ctx context.Context, userID string, year int, month time.Month) (*domain.MonthlyStatement, error)
+}
+
+// ReportPDFRenderer converts a rendered HTML report to PDF
+type ReportPDFRenderer interface {
+	RenderPDF(ctx context.Context, html []byte) ([]byte, error)
+}
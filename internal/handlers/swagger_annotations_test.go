@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// swaggerSpecPath is the spec generated by `make swagger`
+const swaggerSpecPath = "../../docs/swagger.json"
+
+// swaggerRoute is one operation declared by an @Router annotation
+type swaggerRoute struct {
+	handler string
+	path    string
+	method  string
+}
+
+// annotatedRoutes parses the handlers in this package and returns the @Router
+// annotations of every Gin handler method, plus the handlers that have none
+func annotatedRoutes(t *testing.T) ([]swaggerRoute, []string) {
+	t.Helper()
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, parser.ParseComments)
+	require.NoError(t, err)
+
+	var routes []swaggerRoute
+	var missing []string
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || !isGinHandlerMethod(fn) {
+					continue
+				}
+
+				name := receiverName(fn) + "." + fn.Name.Name
+				found := false
+				if fn.Doc != nil {
+					for _, comment := range fn.Doc.List {
+						fields := strings.Fields(strings.TrimPrefix(comment.Text, "//"))
+						if len(fields) != 3 || fields[0] != "@Router" {
+							continue
+						}
+						found = true
+						routes = append(routes, swaggerRoute{
+							handler: name,
+							path:    fields[1],
+							method:  strings.Trim(fields[2], "[]"),
+						})
+					}
+				}
+				if !found {
+					missing = append(missing, name)
+				}
+			}
+		}
+	}
+
+	return routes, missing
+}
+
+// isGinHandlerMethod reports whether fn is an exported method on a *XxxHandler
+// that takes only a *gin.Context, i.e. something that can be registered as a route
+func isGinHandlerMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || !fn.Name.IsExported() || !strings.HasSuffix(receiverName(fn), "Handler") {
+		return false
+	}
+	params := fn.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) > 1 {
+		return false
+	}
+	star, ok := params[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := star.X.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	return ok && pkg.Name == "gin" && sel.Sel.Name == "Context"
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func TestSwagger_AllHandlersAreAnnotated(t *testing.T) {
+	routes, missing := annotatedRoutes(t)
+
+	require.NotEmpty(t, routes)
+	for _, name := range missing {
+		t.Errorf("%s has no @Router annotation; document it and run `make swagger`", name)
+	}
+}
+
+func TestSwagger_SpecMatchesAnnotations(t *testing.T) {
+	raw, err := os.ReadFile(swaggerSpecPath)
+	require.NoError(t, err, "generated spec not found; run `make swagger`")
+
+	var spec struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &spec))
+
+	routes, _ := annotatedRoutes(t)
+	annotated := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		annotated[route.method+" "+route.path] = true
+		if _, ok := spec.Paths[route.path][route.method]; !ok {
+			t.Errorf("%s documents %s %s but the generated spec doesn't; run `make swagger`",
+				route.handler, strings.ToUpper(route.method), route.path)
+		}
+	}
+
+	for path, operations := range spec.Paths {
+		for method := range operations {
+			if !annotated[method+" "+path] {
+				t.Errorf("generated spec has %s %s but no handler documents it; run `make swagger`",
+					strings.ToUpper(method), path)
+			}
+		}
+	}
+}
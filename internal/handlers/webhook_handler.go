@@ -0,0 +1,264 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// maxWebhookDeliveryLimit bounds the limit query parameter of the delivery log
+const maxWebhookDeliveryLimit = 200
+
+// WebhookHandler handles HTTP requests for webhook endpoints
+// Routes must be registered behind authentication; every operation is scoped to the caller
+type WebhookHandler struct {
+	webhookService WebhookService
+	validator      *validator.Validate
+}
+
+// NewWebhookHandler creates a new webhook handler with dependency injection
+func NewWebhookHandler(webhookService WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validator:      dtos.NewValidator(),
+	}
+}
+
+// CreateWebhook handles POST /api/v1/webhooks requests
+// The response is the only time the signing secret is returned
+//
+//	@Summary	Register a webhook
+//	@Tags		webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		request		body		dtos.CreateWebhookDTO	true	"Webhook"
+//	@Success	201			{object}	dtos.WebhookResponseDTO
+//	@Failure	400			{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks	[post]
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var request dtos.CreateWebhookDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.badRequest(c, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	webhook := request.ToDomain(middleware.GetUserID(c))
+	if err := h.webhookService.CreateWebhook(c.Request.Context(), &webhook); err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	var response dtos.WebhookResponseDTO
+	response.FromDomain(webhook)
+	response.Secret = webhook.Secret
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetWebhooks handles GET /api/v1/webhooks requests
+//
+//	@Summary	List webhooks
+//	@Tags		webhooks
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Success	200			{array}		dtos.WebhookResponseDTO
+//	@Failure	401			{object}	dtos.ErrorResponseDTO
+//	@Failure	500			{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks	[get]
+func (h *WebhookHandler) GetWebhooks(c *gin.Context) {
+	webhooks, err := h.webhookService.GetUserWebhooks(c.Request.Context(), middleware.GetUserID(c))
+	if err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	response := make([]dtos.WebhookResponseDTO, len(webhooks))
+	for i, webhook := range webhooks {
+		response[i].FromDomain(webhook)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetWebhook handles GET /api/v1/webhooks/:id requests
+//
+//	@Summary	Get a webhook
+//	@Tags		webhooks
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id				path		string	true	"Webhook ID"
+//	@Success	200				{object}	dtos.WebhookResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	404				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks/{id}	[get]
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), middleware.GetUserID(c), c.Param("id"))
+	if err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	var response dtos.WebhookResponseDTO
+	response.FromDomain(webhook)
+	c.JSON(http.StatusOK, response)
+}
+
+// UpdateWebhook handles PUT /api/v1/webhooks/:id requests
+// Only the fields present in the request are changed
+//
+//	@Summary	Update a webhook
+//	@Tags		webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id				path		string					true	"Webhook ID"
+//	@Param		request			body		dtos.UpdateWebhookDTO	true	"Fields to change"
+//	@Success	200				{object}	dtos.WebhookResponseDTO
+//	@Failure	400				{object}	dtos.ValidationErrorResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	404				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks/{id}	[put]
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var request dtos.UpdateWebhookDTO
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		h.badRequest(c, "Invalid JSON format")
+		return
+	}
+
+	if err := h.validator.Struct(&request); err != nil {
+		c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(c.Request.Context(), middleware.GetUserID(c), c.Param("id"))
+	if err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	request.ApplyUpdates(&webhook)
+	if err := h.webhookService.UpdateWebhook(c.Request.Context(), &webhook); err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	var response dtos.WebhookResponseDTO
+	response.FromDomain(webhook)
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /api/v1/webhooks/:id requests
+//
+//	@Summary	Delete a webhook
+//	@Tags		webhooks
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id				path		string	true	"Webhook ID"
+//	@Success	200				{object}	dtos.MessageResponseDTO
+//	@Failure	401				{object}	dtos.ErrorResponseDTO
+//	@Failure	404				{object}	dtos.ErrorResponseDTO
+//	@Failure	500				{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks/{id}	[delete]
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), middleware.GetUserID(c), c.Param("id")); err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Webhook deleted successfully",
+	})
+}
+
+// GetDeliveries handles GET /api/v1/webhooks/:id/deliveries requests
+// Returns the most recent delivery attempts, newest first; supports a limit query parameter (default 50, max 200)
+//
+//	@Summary	List a webhook's delivery attempts
+//	@Tags		webhooks
+//	@Produce	json
+//	@Security	BearerAuth
+//	@Param		id							path		string	true	"Webhook ID"
+//	@Param		limit						query		int		false	"Number of attempts, 1-200"
+//	@Success	200							{array}		dtos.WebhookDeliveryDTO
+//	@Failure	400							{object}	dtos.ErrorResponseDTO
+//	@Failure	401							{object}	dtos.ErrorResponseDTO
+//	@Failure	404							{object}	dtos.ErrorResponseDTO
+//	@Failure	500							{object}	dtos.ErrorResponseDTO
+//	@Router		/webhooks/{id}/deliveries	[get]
+func (h *WebhookHandler) GetDeliveries(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxWebhookDeliveryLimit {
+			h.badRequest(c, "limit must be between 1 and "+strconv.Itoa(maxWebhookDeliveryLimit))
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(c.Request.Context(), middleware.GetUserID(c), c.Param("id"), limit)
+	if err != nil {
+		h.handleWebhookError(c, err)
+		return
+	}
+
+	response := make([]dtos.WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		response[i].FromDomain(delivery)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *WebhookHandler) badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+		http.StatusBadRequest,
+		"bad_request",
+		message,
+	))
+}
+
+// handleWebhookError maps service errors to HTTP responses
+func (h *WebhookHandler) handleWebhookError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		c.JSON(http.StatusNotFound, dtos.NewErrorResponse(
+			http.StatusNotFound,
+			"not_found",
+			"Webhook not found",
+		))
+	case errors.Is(err, domain.ErrInvalidWebhookData):
+		h.badRequest(c, err.Error())
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		status, code := mapDomainError(err)
+		message, _ := contextErrorMessage(err)
+		c.JSON(status, dtos.NewCodedErrorResponse(status, code, message))
+	default:
+		logging.ContextLogger(c).Error("Webhook request failed", logging.WithError(err))
+		c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+			http.StatusInternalServerError,
+			"internal_error",
+			"An internal error occurred. Please try again later",
+		))
+	}
+}
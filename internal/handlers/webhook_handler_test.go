@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// MockWebhookService is a mock implementation of WebhookService for testing
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) GetWebhook(ctx context.Context, userID, webhookID string) (domain.Webhook, error) {
+	args := m.Called(ctx, userID, webhookID)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookService) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	args := m.Called(ctx, userID, webhookID)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) GetDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, userID, webhookID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func setupWebhookTestRouter(webhookService WebhookService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	logging.InitLogger(logging.LogConfig{Environment: "test", Level: "debug"})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", "user-1")
+		c.Next()
+	})
+
+	handler := NewWebhookHandler(webhookService)
+	webhooks := r.Group("/webhooks")
+	webhooks.POST("", handler.CreateWebhook)
+	webhooks.GET("", handler.GetWebhooks)
+	webhooks.GET("/:id", handler.GetWebhook)
+	webhooks.PUT("/:id", handler.UpdateWebhook)
+	webhooks.DELETE("/:id", handler.DeleteWebhook)
+	webhooks.GET("/:id/deliveries", handler.GetDeliveries)
+
+	return r
+}
+
+func testHandlerWebhook() domain.Webhook {
+	return domain.Webhook{
+		ID:         "wh-1",
+		UserID:     "user-1",
+		URL:        "https://hooks.example.com/buyorbye",
+		Secret:     "0123456789abcdef0123456789abcdef",
+		EventTypes: []string{domain.EventDeductibleMet},
+		IsActive:   true,
+	}
+}
+
+func TestWebhookHandler_CreateWebhook_ReturnsSecretOnce(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("CreateWebhook", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+		return w.UserID == "user-1" && w.URL == "https://hooks.example.com/buyorbye"
+	})).Run(func(args mock.Arguments) {
+		webhook := args.Get(1).(*domain.Webhook)
+		webhook.ID = "wh-1"
+		webhook.Secret = "generated-secret-0123456789"
+		webhook.IsActive = true
+	}).Return(nil)
+	webhookService.On("GetWebhook", mock.Anything, "user-1", "wh-1").Return(testHandlerWebhook(), nil)
+	router := setupWebhookTestRouter(webhookService)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"url":         "https://hooks.example.com/buyorbye",
+		"event_types": []string{domain.EventDeductibleMet},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var created dtos.WebhookResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, "wh-1", created.ID)
+	assert.Equal(t, "generated-secret-0123456789", created.Secret)
+	assert.True(t, created.IsActive)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/webhooks/wh-1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "secret")
+	webhookService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_CreateWebhook_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		body map[string]interface{}
+	}{
+		{name: "missing url", body: map[string]interface{}{"event_types": []string{domain.EventDeductibleMet}}},
+		{name: "unknown event type", body: map[string]interface{}{"url": "https://hooks.example.com", "event_types": []string{"user.created"}}},
+		{name: "no event types", body: map[string]interface{}{"url": "https://hooks.example.com", "event_types": []string{}}},
+		{name: "short secret", body: map[string]interface{}{"url": "https://hooks.example.com", "secret": "short", "event_types": []string{domain.EventDeductibleMet}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookService := new(MockWebhookService)
+			router := setupWebhookTestRouter(webhookService)
+
+			body, _ := json.Marshal(tt.body)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			webhookService.AssertNotCalled(t, "CreateWebhook", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestWebhookHandler_CreateWebhook_InvalidWebhookData(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("CreateWebhook", mock.Anything, mock.Anything).
+		Return(fmt.Errorf("%w: url must not point at a private address", domain.ErrInvalidWebhookData))
+	router := setupWebhookTestRouter(webhookService)
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"url":         "https://hooks.example.com/buyorbye",
+		"event_types": []string{domain.EventDeductibleMet},
+	})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "private address")
+}
+
+func TestWebhookHandler_GetWebhooks_ServiceError(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("GetUserWebhooks", mock.Anything, "user-1").Return(nil, errors.New("database error"))
+	router := setupWebhookTestRouter(webhookService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.NotContains(t, w.Body.String(), "database error")
+}
+
+func TestWebhookHandler_GetWebhook_NotFound(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("GetWebhook", mock.Anything, "user-1", "wh-other").
+		Return(domain.Webhook{}, domain.ErrWebhookNotFound)
+	router := setupWebhookTestRouter(webhookService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/webhooks/wh-other", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "not_found", response.Error)
+}
+
+func TestWebhookHandler_UpdateWebhook_PartialUpdate(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("GetWebhook", mock.Anything, "user-1", "wh-1").Return(testHandlerWebhook(), nil)
+	webhookService.On("UpdateWebhook", mock.Anything, mock.MatchedBy(func(w *domain.Webhook) bool {
+		return !w.IsActive && w.URL == "https://hooks.example.com/buyorbye" &&
+			len(w.EventTypes) == 1 && w.EventTypes[0] == domain.EventDeductibleMet
+	})).Return(nil)
+	router := setupWebhookTestRouter(webhookService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/webhooks/wh-1", bytes.NewBufferString(`{"is_active": false}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dtos.WebhookResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.False(t, response.IsActive)
+	assert.Empty(t, response.Secret)
+	webhookService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_DeleteWebhook(t *testing.T) {
+	webhookService := new(MockWebhookService)
+	webhookService.On("DeleteWebhook", mock.Anything, "user-1", "wh-1").Return(nil)
+	router := setupWebhookTestRouter(webhookService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/webhooks/wh-1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	webhookService.AssertExpectations(t)
+}
+
+func TestWebhookHandler_GetDeliveries(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedStatus int
+	}{
+		{name: "default limit", query: "", expectedLimit: 0, expectedStatus: http.StatusOK},
+		{name: "explicit limit", query: "?limit=10", expectedLimit: 10, expectedStatus: http.StatusOK},
+		{name: "limit too large", query: "?limit=500", expectedStatus: http.StatusBadRequest},
+		{name: "limit not a number", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhookService := new(MockWebhookService)
+			webhookService.On("GetDeliveries", mock.Anything, "user-1", "wh-1", tt.expectedLimit).
+				Return([]domain.WebhookDelivery{{WebhookID: "wh-1", EventType: domain.EventDeductibleMet, Attempt: 1, StatusCode: 200, Succeeded: true}}, nil)
+			router := setupWebhookTestRouter(webhookService)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/webhooks/wh-1/deliveries"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				webhookService.AssertNotCalled(t, "GetDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			var response []dtos.WebhookDeliveryDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			require.Len(t, response, 1)
+			assert.True(t, response[0].Succeeded)
+		})
+	}
+}
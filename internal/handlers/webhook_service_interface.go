@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// WebhookService interface is defined in handlers package following consumer-defined principle
+// This interface is consumed by WebhookHandler in this package
+type WebhookService interface {
+	// CreateWebhook registers an active webhook, generating a secret when none is set
+	// Returns an error wrapping domain.ErrInvalidWebhookData if validation fails
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) error
+
+	// GetUserWebhooks retrieves all webhooks registered by a user
+	GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error)
+
+	// GetWebhook retrieves one of the user's webhooks
+	// Returns domain.ErrWebhookNotFound if it doesn't exist or belongs to another user
+	GetWebhook(ctx context.Context, userID, webhookID string) (domain.Webhook, error)
+
+	// UpdateWebhook saves changes to one of the user's webhooks
+	// Returns domain.ErrWebhookNotFound or an error wrapping domain.ErrInvalidWebhookData
+	UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error
+
+	// DeleteWebhook removes one of the user's webhooks
+	// Returns domain.ErrWebhookNotFound if it doesn't exist or belongs to another user
+	DeleteWebhook(ctx context.Context, userID, webhookID string) error
+
+	// GetDeliveries retrieves the most recent delivery attempts of one of the user's webhooks
+	// Returns domain.ErrWebhookNotFound if it doesn't exist or belongs to another user
+	GetDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]domain.WebhookDelivery, error)
+}
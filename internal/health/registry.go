@@ -0,0 +1,157 @@
+// Package health runs the readiness checks behind the readiness probe. Components register a
+// check with a Registry, which runs every check on each probe and reports the ones that failed.
+// Once the registry starts draining at shutdown every probe fails without running the checks,
+// so load balancers stop routing to the instance before its listener closes.
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTimeout bounds each check so a hung dependency can't hang the probe
+const DefaultTimeout = 2 * time.Second
+
+// ShutdownCheck is the check reported as failed while the registry is draining
+const ShutdownCheck = "shutdown"
+
+// ErrDraining is the error reported for ShutdownCheck while the registry is draining
+var ErrDraining = errors.New("shutting down")
+
+// Check returns an error if the dependency it checks can't be used. It should give up once
+// ctx is done.
+type Check func(ctx context.Context) error
+
+// Registry holds the readiness checks. It is safe for concurrent use.
+type Registry struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	checks map[string]Check
+
+	draining atomic.Bool
+}
+
+// Option customizes a Registry created by NewRegistry
+type Option func(*Registry)
+
+// WithTimeout bounds each check; a non-positive timeout uses DefaultTimeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Registry) {
+		if timeout > 0 {
+			r.timeout = timeout
+		}
+	}
+}
+
+// NewRegistry creates a registry without checks
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		timeout: DefaultTimeout,
+		checks:  make(map[string]Check),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Register adds a check under name, replacing any check registered under it before
+func (r *Registry) Register(name string, check Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check
+}
+
+// Drain makes every later run fail with ShutdownCheck; it can't be undone
+func (r *Registry) Drain() {
+	r.draining.Store(true)
+}
+
+// Draining reports whether Drain has been called
+func (r *Registry) Draining() bool {
+	return r.draining.Load()
+}
+
+// Report is the outcome of running the checks
+type Report struct {
+	// Checks maps each check's name to its error, nil if it passed
+	Checks map[string]error
+}
+
+// Ready reports whether every check passed
+func (r Report) Ready() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the names of the checks that failed, sorted
+func (r Report) Failed() []string {
+	var failed []string
+	for name, err := range r.Checks {
+		if err != nil {
+			failed = append(failed, name)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// Run runs every check at once, each bounded by the registry's timeout, and waits for all of
+// them. While draining no check runs and the report holds only a failed ShutdownCheck.
+func (r *Registry) Run(ctx context.Context) Report {
+	if r.Draining() {
+		return Report{Checks: map[string]error{ShutdownCheck: ErrDraining}}
+	}
+
+	r.mu.RLock()
+	checks := make(map[string]Check, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	report := Report{Checks: make(map[string]error, len(checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := r.runCheck(ctx, check)
+			mu.Lock()
+			report.Checks[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return report
+}
+
+// runCheck runs check with the registry's timeout. A check that ignores its context is
+// reported as failed once the timeout expires; it is left to finish in the background.
+func (r *Registry) runCheck(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("check panicked: %v", recovered)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check did not finish within %s: %w", r.timeout, ctx.Err())
+	}
+}
@@ -0,0 +1,82 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Run_ReportsFailedChecks(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("db", func(ctx context.Context) error { return errors.New("connection refused") })
+	registry.Register("migrations", func(ctx context.Context) error { return nil })
+	registry.Register("cache", func(ctx context.Context) error { panic("boom") })
+
+	report := registry.Run(context.Background())
+
+	assert.False(t, report.Ready())
+	assert.Equal(t, []string{"cache", "db"}, report.Failed())
+	assert.NoError(t, report.Checks["migrations"])
+	assert.ErrorContains(t, report.Checks["cache"], "panicked")
+}
+
+func TestRegistry_Run_AllPassing(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("db", func(ctx context.Context) error { return nil })
+
+	report := registry.Run(context.Background())
+
+	assert.True(t, report.Ready())
+	assert.Empty(t, report.Failed())
+	assert.True(t, NewRegistry().Run(context.Background()).Ready(), "a registry without checks is ready")
+}
+
+func TestRegistry_Run_TimesOutHungCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	registry := NewRegistry(WithTimeout(20 * time.Millisecond))
+	registry.Register("respects context", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	registry.Register("ignores context", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	start := time.Now()
+	report := registry.Run(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, []string{"ignores context", "respects context"}, report.Failed())
+	assert.ErrorIs(t, report.Checks["ignores context"], context.DeadlineExceeded)
+}
+
+func TestRegistry_Drain_FailsWithoutRunningChecks(t *testing.T) {
+	registry := NewRegistry()
+	ran := false
+	registry.Register("db", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+
+	registry.Drain()
+	report := registry.Run(context.Background())
+
+	require.True(t, registry.Draining())
+	assert.False(t, ran)
+	assert.Equal(t, []string{ShutdownCheck}, report.Failed())
+	assert.ErrorIs(t, report.Checks[ShutdownCheck], ErrDraining)
+}
+
+func TestRegistry_Register_ReplacesCheck(t *testing.T) {
+	registry := NewRegistry()
+	registry.Register("db", func(ctx context.Context) error { return errors.New("down") })
+	registry.Register("db", func(ctx context.Context) error { return nil })
+
+	assert.True(t, registry.Run(context.Background()).Ready())
+}
@@ -0,0 +1,169 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// SensitiveTables are the tables whose bound parameter values are kept out of query logs
+var SensitiveTables = []string{"users", "insurance_policies"}
+
+// GormLoggerConfig configures the GORM logger adapter
+type GormLoggerConfig struct {
+	// LogLevel is the GORM log level; see GormLogLevel
+	LogLevel gormlogger.LogLevel
+	// SlowThreshold is how long a query may take before it is logged as slow; 0 disables it
+	SlowThreshold time.Duration
+	// RedactedTables are tables whose queries are logged with ? in place of their parameter values
+	RedactedTables []string
+}
+
+// GormLogLevel maps an application log level to the GORM log level that shows every query
+// at "debug", slow queries and errors at "info", and only errors at "warn" or "error".
+// Any other level silences GORM.
+func GormLogLevel(level string) gormlogger.LogLevel {
+	switch level {
+	case "debug":
+		return gormlogger.Info
+	case "info":
+		return gormlogger.Warn
+	case "warn", "error":
+		return gormlogger.Error
+	default:
+		return gormlogger.Silent
+	}
+}
+
+// gormLogger writes GORM's logs to zap. It also implements gorm.ParamsFilter to redact parameters.
+type gormLogger struct {
+	zap           *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+	redacted      *regexp.Regexp
+}
+
+// NewGormLogger returns a GORM logger that writes to base, or discards everything if base is nil.
+// Queries are logged at debug with their sql, rows, elapsed_ms, caller and request_id fields,
+// queries slower than config.SlowThreshold at warn, and failed queries at error; a missing record
+// is not treated as a failure.
+func NewGormLogger(base *zap.Logger, config GormLoggerConfig) gormlogger.Interface {
+	if base == nil {
+		base = zap.NewNop()
+	}
+
+	l := &gormLogger{
+		// The query's caller is logged as a field, so zap's own caller would only point here
+		zap:           base.WithOptions(zap.WithCaller(false)),
+		level:         config.LogLevel,
+		slowThreshold: config.SlowThreshold,
+	}
+	if len(config.RedactedTables) > 0 {
+		tables := make([]string, len(config.RedactedTables))
+		for i, table := range config.RedactedTables {
+			tables[i] = regexp.QuoteMeta(table)
+		}
+		l.redacted = regexp.MustCompile(`\b(` + strings.Join(tables, "|") + `)\b`)
+	}
+	return l
+}
+
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.zap.Info(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.zap.Warn(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.zap.Error(fmt.Sprintf(msg, data...), l.contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && l.level >= gormlogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		l.zap.Error("Database query failed", append(l.queryFields(ctx, elapsed, fc), WithError(err))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.zap.Warn("Slow database query", append(l.queryFields(ctx, elapsed, fc),
+			zap.Float64("slow_threshold_ms", milliseconds(l.slowThreshold)))...)
+	case l.level >= gormlogger.Info:
+		l.zap.Debug("Database query", l.queryFields(ctx, elapsed, fc)...)
+	}
+}
+
+// ParamsFilter leaves the parameter values out of queries that touch a redacted table,
+// so they are logged with their ? placeholders
+func (l *gormLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.redacted != nil && l.redacted.MatchString(sql) {
+		return sql, nil
+	}
+	return sql, params
+}
+
+// queryFields describes a finished query
+func (l *gormLogger) queryFields(ctx context.Context, elapsed time.Duration, fc func() (string, int64)) []zap.Field {
+	sql, rows := fc()
+	fields := []zap.Field{
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Float64("elapsed_ms", milliseconds(elapsed)),
+		zap.String("caller", queryCaller()),
+	}
+	return append(fields, l.contextFields(ctx)...)
+}
+
+// contextFields returns the request ID stored in ctx, if any
+func (l *gormLogger) contextFields(ctx context.Context) []zap.Field {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		return []zap.Field{WithRequestID(requestID)}
+	}
+	return nil
+}
+
+// queryCaller returns the file and line of the code that ran the query: the first frame outside
+// GORM and this logger
+func queryCaller() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "gorm.io/") && !strings.Contains(frame.Function, "(*gormLogger)") {
+			return zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true).TrimmedPath()
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// milliseconds returns d in fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
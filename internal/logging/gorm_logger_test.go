@@ -0,0 +1,121 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/DuckDHD/BuyOrBye/internal/database"
+)
+
+// setupGormLoggerTestDB opens an in-memory database with users and expenses tables whose
+// queries are logged to the returned observer
+func setupGormLoggerTestDB(t *testing.T, config GormLoggerConfig) (*gorm.DB, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	db, err := database.ConnectSQLite(database.SQLiteMemory, &gorm.Config{Logger: gormlogger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	require.NoError(t, db.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT)").Error)
+	require.NoError(t, db.Exec("CREATE TABLE expenses (id INTEGER PRIMARY KEY, name TEXT)").Error)
+
+	db.Logger = NewGormLogger(zap.New(core), config)
+	return db, logs
+}
+
+func TestGormLogger_Query_LogsFieldsAtDebug(t *testing.T) {
+	db, logs := setupGormLoggerTestDB(t, GormLoggerConfig{LogLevel: gormlogger.Info, SlowThreshold: time.Hour})
+	ctx := ContextWithRequestID(context.Background(), "req-123")
+
+	require.NoError(t, db.WithContext(ctx).Exec("INSERT INTO expenses (name) VALUES (?)", "Rent").Error)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "INSERT INTO expenses (name) VALUES (\"Rent\")", fields["sql"])
+	assert.Equal(t, int64(1), fields["rows"])
+	assert.Contains(t, fields, "elapsed_ms")
+	assert.True(t, strings.HasPrefix(fields["caller"].(string), "logging/gorm_logger_test.go:"), fields["caller"])
+	assert.Equal(t, "req-123", fields["request_id"])
+}
+
+func TestGormLogger_SensitiveTable_RedactsParameters(t *testing.T) {
+	db, logs := setupGormLoggerTestDB(t, GormLoggerConfig{LogLevel: gormlogger.Info, RedactedTables: SensitiveTables})
+
+	require.NoError(t, db.Exec("INSERT INTO users (email) VALUES (?)", "jane@example.com").Error)
+	require.NoError(t, db.Exec("INSERT INTO expenses (name) VALUES (?)", "Rent").Error)
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "INSERT INTO users (email) VALUES (?)", entries[0].ContextMap()["sql"])
+	assert.Equal(t, "INSERT INTO expenses (name) VALUES (\"Rent\")", entries[1].ContextMap()["sql"])
+}
+
+func TestGormLogger_SlowQuery_LogsWarn(t *testing.T) {
+	db, logs := setupGormLoggerTestDB(t, GormLoggerConfig{LogLevel: gormlogger.Warn, SlowThreshold: time.Nanosecond})
+
+	require.NoError(t, db.Exec("INSERT INTO expenses (name) VALUES (?)", "Rent").Error)
+
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "Slow database query", entries[0].Message)
+	assert.Contains(t, entries[0].ContextMap(), "sql")
+}
+
+func TestGormLogger_WarnLevel_SkipsFastQueries(t *testing.T) {
+	db, logs := setupGormLoggerTestDB(t, GormLoggerConfig{LogLevel: gormlogger.Warn, SlowThreshold: time.Hour})
+
+	require.NoError(t, db.Exec("INSERT INTO expenses (name) VALUES (?)", "Rent").Error)
+
+	assert.Zero(t, logs.Len())
+}
+
+func TestGormLogger_FailedQuery_LogsError(t *testing.T) {
+	db, logs := setupGormLoggerTestDB(t, GormLoggerConfig{LogLevel: gormlogger.Error})
+
+	require.Error(t, db.Exec("SELECT * FROM missing_table").Error)
+	var user struct{ ID int }
+	err := db.Table("users").Where("id = ?", 42).First(&user).Error
+	require.True(t, errors.Is(err, gorm.ErrRecordNotFound))
+
+	entries := logs.All()
+	require.Len(t, entries, 1, "a missing record is not logged as a failure")
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, "SELECT * FROM missing_table", entries[0].ContextMap()["sql"])
+	assert.Contains(t, entries[0].ContextMap(), "error")
+}
+
+func TestGormLogLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected gormlogger.LogLevel
+	}{
+		{"debug", gormlogger.Info},
+		{"info", gormlogger.Warn},
+		{"warn", gormlogger.Error},
+		{"error", gormlogger.Error},
+		{"", gormlogger.Silent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			assert.Equal(t, tt.expected, GormLogLevel(tt.level))
+		})
+	}
+}
@@ -3,14 +3,19 @@ package logging
 import (
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 var (
-	logger *zap.Logger
-	once   sync.Once
+	// logger is the global logger, nil until InitLogger or SetTestLogger sets it
+	logger atomic.Pointer[zap.Logger]
+	// initMu makes InitLogger and ResetLogger take turns, so only one logger is ever created
+	initMu sync.Mutex
+	// nopLogger is handed out by GetLogger until the global logger is set
+	nopLogger = zap.NewNop()
 )
 
 // LogConfig holds the configuration for the logger
@@ -20,41 +25,64 @@ type LogConfig struct {
 }
 
 // InitLogger initializes the global logger instance
-// This should be called once at application startup
+// This should be called once at application startup; later calls, including concurrent ones,
+// keep the logger the first call created until ResetLogger is called
 func InitLogger(config LogConfig) error {
-	var err error
-	once.Do(func() {
-		logger, err = createLogger(config)
-	})
-	return err
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if logger.Load() != nil {
+		return nil
+	}
+	created, err := createLogger(config)
+	if err != nil {
+		return err
+	}
+	logger.Store(created)
+	return nil
+}
+
+// ResetLogger drops the global logger so the next InitLogger creates a new one
+// This should only be used in tests
+func ResetLogger() {
+	initMu.Lock()
+	defer initMu.Unlock()
+
+	if current := logger.Swap(nil); current != nil {
+		_ = current.Sync()
+	}
 }
 
 // GetLogger returns the global logger instance
-// Returns nil if InitLogger has not been called (for graceful degradation)
+// Returns a no-op logger if InitLogger has not been called (for graceful degradation)
 func GetLogger() *zap.Logger {
-	return logger
+	if current := logger.Load(); current != nil {
+		return current
+	}
+	return nopLogger
 }
 
 // MustGetLogger returns the global logger instance
 // Panics if InitLogger has not been called (use for critical paths)
 func MustGetLogger() *zap.Logger {
-	if logger == nil {
+	current := logger.Load()
+	if current == nil {
 		panic("logger not initialized - call InitLogger first")
 	}
-	return logger
+	return current
 }
 
 // SetTestLogger sets the global logger for testing purposes
-// This bypasses the sync.Once initialization and should only be used in tests
+// Later InitLogger calls keep it; it should only be used in tests
 func SetTestLogger(testLogger *zap.Logger) {
-	logger = testLogger
+	logger.Store(testLogger)
 }
 
 // Sync flushes any buffered log entries
 // Should be called before application shutdown
 func Sync() error {
-	if logger != nil {
-		return logger.Sync()
+	if current := logger.Load(); current != nil {
+		return current.Sync()
 	}
 	return nil
 }
@@ -233,40 +261,25 @@ func WithRowsAffected(count int64) zap.Field {
 
 // HandlerLogger returns a logger pre-configured for handler layer
 func HandlerLogger() *zap.Logger {
-	if base := GetLogger(); base != nil {
-		return base.With(WithComponent("handler"))
-	}
-	return nil
+	return GetLogger().With(WithComponent("handler"))
 }
 
 // ServiceLogger returns a logger pre-configured for service layer
 func ServiceLogger() *zap.Logger {
-	if base := GetLogger(); base != nil {
-		return base.With(WithComponent("service"))
-	}
-	return nil
+	return GetLogger().With(WithComponent("service"))
 }
 
 // RepositoryLogger returns a logger pre-configured for repository layer
 func RepositoryLogger() *zap.Logger {
-	if base := GetLogger(); base != nil {
-		return base.With(WithComponent("repository"))
-	}
-	return nil
+	return GetLogger().With(WithComponent("repository"))
 }
 
 // MiddlewareLogger returns a logger pre-configured for middleware
 func MiddlewareLogger() *zap.Logger {
-	if base := GetLogger(); base != nil {
-		return base.With(WithComponent("middleware"))
-	}
-	return nil
+	return GetLogger().With(WithComponent("middleware"))
 }
 
 // DatabaseLogger returns a logger pre-configured for database operations
 func DatabaseLogger() *zap.Logger {
-	if base := GetLogger(); base != nil {
-		return base.With(WithComponent("database"))
-	}
-	return nil
+	return GetLogger().With(WithComponent("database"))
 }
\ No newline at end of file
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInitLogger_ConcurrentCallsShareOneLogger(t *testing.T) {
+	ResetLogger()
+	t.Cleanup(ResetLogger)
+
+	const callers = 16
+	var wg sync.WaitGroup
+	errs := make([]error, callers)
+	loggers := make([]*zap.Logger, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = InitLogger(LogConfig{Environment: "test", Level: "error"})
+			loggers[i] = GetLogger()
+			loggers[i].Debug("logged while other callers initialize")
+		}(i)
+	}
+	wg.Wait()
+
+	first := MustGetLogger()
+	for i := 0; i < callers; i++ {
+		require.NoError(t, errs[i])
+		assert.Same(t, first, loggers[i])
+	}
+	assert.NotPanics(t, func() { ServiceLogger().Info("usable after concurrent init") })
+}
+
+func TestGetLogger_ReturnsNoopBeforeInit(t *testing.T) {
+	ResetLogger()
+	t.Cleanup(ResetLogger)
+
+	require.NotNil(t, GetLogger())
+	assert.NotPanics(t, func() {
+		GetLogger().Info("dropped")
+		HandlerLogger().Warn("dropped")
+	})
+	assert.Panics(t, func() { MustGetLogger() })
+	assert.NoError(t, Sync())
+}
+
+func TestResetLogger_AllowsReinitialization(t *testing.T) {
+	ResetLogger()
+	t.Cleanup(ResetLogger)
+
+	require.NoError(t, InitLogger(LogConfig{Environment: "test", Level: "error"}))
+	first := GetLogger()
+	require.NoError(t, InitLogger(LogConfig{Environment: "test", Level: "debug"}))
+	assert.Same(t, first, GetLogger(), "a second InitLogger keeps the first logger")
+
+	ResetLogger()
+	require.NoError(t, InitLogger(LogConfig{Environment: "test", Level: "debug"}))
+	assert.NotSame(t, first, GetLogger())
+}
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"strconv"
 	"time"
@@ -20,6 +21,9 @@ type HTTPLoggingConfig struct {
 	LogResponseBody bool
 	// MaxBodySize limits the size of body to log (in bytes)
 	MaxBodySize int64
+	// RedactedFields are JSON fields whose values are logged as "[REDACTED]" in request and
+	// response bodies, whatever the route; see SensitiveBodyFields
+	RedactedFields []string
 }
 
 // DefaultHTTPLoggingConfig returns a sensible default configuration
@@ -27,6 +31,8 @@ func DefaultHTTPLoggingConfig() HTTPLoggingConfig {
 	return HTTPLoggingConfig{
 		SkipPaths: []string{
 			"/health",
+			"/health/live",
+			"/health/ready",
 			"/healthz",
 			"/ping",
 			"/metrics",
@@ -34,12 +40,14 @@ func DefaultHTTPLoggingConfig() HTTPLoggingConfig {
 		LogRequestBody:  false, // Disabled by default for security
 		LogResponseBody: false, // Disabled by default for performance
 		MaxBodySize:     1024,  // 1KB limit
+		RedactedFields:  SensitiveBodyFields,
 	}
 }
 
 // HTTPLoggingMiddleware returns a Gin middleware for structured HTTP request logging
 func HTTPLoggingMiddleware(config HTTPLoggingConfig) gin.HandlerFunc {
 	logger := MiddlewareLogger()
+	redactor := newBodyRedactor(config.RedactedFields)
 	skipMap := make(map[string]bool, len(config.SkipPaths))
 	for _, path := range config.SkipPaths {
 		skipMap[path] = true
@@ -65,7 +73,7 @@ func HTTPLoggingMiddleware(config HTTPLoggingConfig) gin.HandlerFunc {
 		if config.LogRequestBody && c.Request.Body != nil {
 			bodyBytes, err := io.ReadAll(io.LimitReader(c.Request.Body, config.MaxBodySize))
 			if err == nil {
-				requestBody = string(bodyBytes)
+				requestBody = redactor.Redact(string(bodyBytes))
 				// Restore the body for the actual handler
 				c.Request.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 			}
@@ -83,7 +91,7 @@ func HTTPLoggingMiddleware(config HTTPLoggingConfig) gin.HandlerFunc {
 			WithRequestID(requestID),
 			WithMethod(c.Request.Method),
 			WithPath(path),
-			WithIP(c.ClientIP()),
+			WithIP(GetClientIP(c)),
 			zap.String("user_agent", c.Request.UserAgent()),
 		}
 
@@ -108,7 +116,7 @@ func HTTPLoggingMiddleware(config HTTPLoggingConfig) gin.HandlerFunc {
 			WithRequestID(requestID),
 			WithMethod(c.Request.Method),
 			WithPath(path),
-			WithIP(c.ClientIP()),
+			WithIP(GetClientIP(c)),
 			WithHTTPStatus(c.Writer.Status()),
 			WithLatency(latency),
 			zap.Int("response_size", c.Writer.Size()),
@@ -117,7 +125,10 @@ func HTTPLoggingMiddleware(config HTTPLoggingConfig) gin.HandlerFunc {
 		// Add response body if captured
 		if config.LogResponseBody {
 			if blw, ok := c.Writer.(*bodyLogWriter); ok {
-				responseBody = blw.body.String()
+				responseBody = redactor.Redact(blw.body.String())
+				if encoding := c.Writer.Header().Get("Content-Encoding"); encoding != "" {
+					responseBody = "(" + encoding + " compressed body)"
+				}
 				if len(responseBody) > int(config.MaxBodySize) {
 					responseBody = responseBody[:config.MaxBodySize] + "... (truncated)"
 				}
@@ -162,7 +173,8 @@ func generateRequestID() string {
 	return strconv.FormatInt(time.Now().UnixNano(), 36)
 }
 
-// RequestIDMiddleware adds request ID to context
+// RequestIDMiddleware adds request ID to the Gin context and to the request's context,
+// so database queries run with that context can be traced back to the request
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
@@ -170,11 +182,29 @@ func RequestIDMiddleware() gin.HandlerFunc {
 			requestID = generateRequestID()
 		}
 		c.Set("request_id", requestID)
+		c.Request = c.Request.WithContext(ContextWithRequestID(c.Request.Context(), requestID))
 		c.Header("X-Request-ID", requestID)
 		c.Next()
 	}
 }
 
+// requestIDKey is the context key under which the request ID is stored
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying requestID
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext retrieves the request ID from ctx, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
 // GetRequestID retrieves request ID from Gin context
 func GetRequestID(c *gin.Context) string {
 	if requestID, exists := c.Get("request_id"); exists {
@@ -185,6 +215,28 @@ func GetRequestID(c *gin.Context) string {
 	return ""
 }
 
+// ClientIPMiddleware resolves the client IP once and stores it in the context, so the logs,
+// rate limits and audit log all attribute the request to the same address. gin only believes
+// X-Forwarded-For from the engine's trusted proxies (see gin.Engine.SetTrustedProxies); anything
+// else resolves to the TCP peer address. It must run before the other middleware.
+func ClientIPMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("client_ip", c.ClientIP())
+		c.Next()
+	}
+}
+
+// GetClientIP returns the client IP resolved by ClientIPMiddleware, falling back to
+// c.ClientIP() when the middleware didn't run
+func GetClientIP(c *gin.Context) string {
+	if clientIP, exists := c.Get("client_ip"); exists {
+		if ip, ok := clientIP.(string); ok {
+			return ip
+		}
+	}
+	return c.ClientIP()
+}
+
 // ErrorLoggingMiddleware logs panics and errors
 func ErrorLoggingMiddleware() gin.HandlerFunc {
 	logger := MiddlewareLogger()
@@ -196,7 +248,7 @@ func ErrorLoggingMiddleware() gin.HandlerFunc {
 			WithRequestID(requestID),
 			WithMethod(c.Request.Method),
 			WithPath(c.Request.URL.Path),
-			WithIP(c.ClientIP()),
+			WithIP(GetClientIP(c)),
 			zap.Any("panic", recovered),
 		}
 
@@ -209,10 +261,6 @@ func ErrorLoggingMiddleware() gin.HandlerFunc {
 // ContextLogger returns a logger with request context
 func ContextLogger(c *gin.Context) *zap.Logger {
 	base := GetLogger()
-	if base == nil {
-		return nil // Return nil if logger not initialized
-	}
-	
 	requestID := GetRequestID(c)
 	if requestID != "" {
 		return base.With(WithRequestID(requestID))
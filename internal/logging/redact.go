@@ -0,0 +1,153 @@
+package logging
+
+import "strings"
+
+// RedactedValue replaces the value of a redacted field in a logged body
+const RedactedValue = `"[REDACTED]"`
+
+// SensitiveBodyFields are the JSON fields whose values are kept out of logged request and
+// response bodies. Condition names are sent under the generic "name" field, so names are
+// redacted on every route; new API keys are returned once under "key".
+var SensitiveBodyFields = []string{
+	"password",
+	"current_password",
+	"new_password",
+	"access_token",
+	"refresh_token",
+	"csrf_token",
+	"key",
+	"secret",
+	"policy_number",
+	"name",
+	"medication_name",
+	"condition",
+	"conditions",
+	"active_conditions",
+	"add_conditions",
+}
+
+// bodyRedactor replaces the values of named fields in JSON bodies with RedactedValue
+type bodyRedactor struct {
+	fields map[string]bool
+}
+
+// newBodyRedactor returns a redactor for fields, or nil if there are none. Field names match
+// JSON keys regardless of case, underscores and hyphens, so "policyNumber" matches "policy_number".
+func newBodyRedactor(fields []string) *bodyRedactor {
+	if len(fields) == 0 {
+		return nil
+	}
+	r := &bodyRedactor{fields: make(map[string]bool, len(fields))}
+	for _, field := range fields {
+		r.fields[normalizeFieldName(field)] = true
+	}
+	return r
+}
+
+// Redact returns body with the value of every matching field, at any depth, replaced by
+// RedactedValue. Object and array values are replaced whole. The body may be cut short,
+// as bodies are when they are longer than the logged size; a field whose value was cut off
+// is still redacted. Text that isn't JSON passes through unchanged.
+func (r *bodyRedactor) Redact(body string) string {
+	if r == nil || body == "" {
+		return body
+	}
+
+	var out strings.Builder
+	out.Grow(len(body))
+	for i := 0; i < len(body); {
+		if body[i] != '"' {
+			out.WriteByte(body[i])
+			i++
+			continue
+		}
+
+		end := skipJSONString(body, i)
+		out.WriteString(body[i:end])
+		colon := skipJSONSpace(body, end)
+		// A string followed by a colon is an object key
+		if colon >= len(body) || body[colon] != ':' || !r.fields[normalizeFieldName(unquoteKey(body[i:end]))] {
+			i = end
+			continue
+		}
+		value := skipJSONSpace(body, colon+1)
+		out.WriteString(body[end:value])
+		out.WriteString(RedactedValue)
+		i = skipJSONValue(body, value)
+	}
+	return out.String()
+}
+
+// normalizeFieldName lowercases name and drops its underscores and hyphens
+func normalizeFieldName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// unquoteKey strips the quotes from a scanned key, which may be missing its closing quote
+func unquoteKey(key string) string {
+	key = strings.TrimPrefix(key, `"`)
+	return strings.TrimSuffix(key, `"`)
+}
+
+// skipJSONString returns the index just past the string starting at s[start], or len(s) if it
+// isn't closed
+func skipJSONString(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// skipJSONSpace returns the index of the first non-whitespace byte at or after start
+func skipJSONSpace(s string, start int) int {
+	for start < len(s) && strings.IndexByte(" \t\r\n", s[start]) >= 0 {
+		start++
+	}
+	return start
+}
+
+// skipJSONValue returns the index just past the value starting at s[start], or len(s) if the
+// value runs to the end of s
+func skipJSONValue(s string, start int) int {
+	if start >= len(s) {
+		return start
+	}
+	switch s[start] {
+	case '"':
+		return skipJSONString(s, start)
+	case '{', '[':
+		depth := 0
+		for i := start; i < len(s); i++ {
+			switch s[i] {
+			case '"':
+				i = skipJSONString(s, i) - 1
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return len(s)
+	default:
+		// Numbers, booleans and null run until the next delimiter
+		for i := start; i < len(s); i++ {
+			if strings.IndexByte(",}] \t\r\n", s[i]) >= 0 {
+				return i
+			}
+		}
+		return len(s)
+	}
+}
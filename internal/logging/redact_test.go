@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestBodyRedactor_Redact(t *testing.T) {
+	redactor := newBodyRedactor([]string{"password", "policyNumber", "conditions"})
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			"string_value",
+			`{"email":"a@example.com","password":"hunter22"}`,
+			`{"email":"a@example.com","password":"[REDACTED]"}`,
+		},
+		{
+			"matches_across_naming_styles",
+			`{"policy_number": "PN-1", "provider": "Acme"}`,
+			`{"policy_number": "[REDACTED]", "provider": "Acme"}`,
+		},
+		{
+			"case_insensitive",
+			`{"Password":"x"}`,
+			`{"Password":"[REDACTED]"}`,
+		},
+		{
+			"nested_and_in_arrays",
+			`{"user":{"password":"x"},"items":[{"password":123}]}`,
+			`{"user":{"password":"[REDACTED]"},"items":[{"password":"[REDACTED]"}]}`,
+		},
+		{
+			"array_value_replaced_whole",
+			`{"conditions":[{"name":"Asthma","note":"]"}],"age":40}`,
+			`{"conditions":"[REDACTED]","age":40}`,
+		},
+		{
+			"escaped_quotes_in_value",
+			`{"password":"a\"b","note":"password"}`,
+			`{"password":"[REDACTED]","note":"password"}`,
+		},
+		{
+			"value_cut_off",
+			`{"email":"a@example.com","password":"hunt`,
+			`{"email":"a@example.com","password":"[REDACTED]"`,
+		},
+		{
+			"not_json",
+			`password=hunter22`,
+			`password=hunter22`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, redactor.Redact(tt.body))
+		})
+	}
+}
+
+func TestBodyRedactor_NoFields(t *testing.T) {
+	redactor := newBodyRedactor(nil)
+
+	assert.Equal(t, `{"password":"x"}`, redactor.Redact(`{"password":"x"}`))
+}
+
+func TestHTTPLoggingMiddleware_RedactsBodies(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	SetTestLogger(zap.New(core))
+	t.Cleanup(ResetLogger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(HTTPLoggingMiddleware(HTTPLoggingConfig{
+		LogRequestBody:  true,
+		LogResponseBody: true,
+		MaxBodySize:     1024,
+		RedactedFields:  SensitiveBodyFields,
+	}))
+	var handlerBody string
+	router.POST("/api/v1/auth/register", func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		handlerBody = string(body)
+		c.JSON(http.StatusCreated, gin.H{"email": "jane@example.com", "access_token": "token-abc", "csrf_token": "csrf-xyz"})
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register",
+		bytes.NewBufferString(`{"email":"jane@example.com","password":"S3cret-Passw0rd"}`))
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, handlerBody, "S3cret-Passw0rd", "the handler still gets the real body")
+
+	entries := logs.All()
+	require.Len(t, entries, 2)
+	var output strings.Builder
+	for _, entry := range entries {
+		for _, value := range entry.ContextMap() {
+			if s, ok := value.(string); ok {
+				output.WriteString(s)
+			}
+		}
+	}
+	assert.NotContains(t, output.String(), "S3cret-Passw0rd")
+	assert.NotContains(t, output.String(), "token-abc")
+	assert.NotContains(t, output.String(), "csrf-xyz")
+	assert.Contains(t, output.String(), `"password":"[REDACTED]"`)
+	assert.Contains(t, output.String(), "jane@example.com")
+}
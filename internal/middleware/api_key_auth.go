@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// APIKeyHeader is the request header scripts send their API key in
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthMiddleware provides API key authentication middleware for Gin
+type APIKeyAuthMiddleware struct {
+	authenticator services.APIKeyAuthenticator
+}
+
+// NewAPIKeyAuthMiddleware creates a new API key authentication middleware
+func NewAPIKeyAuthMiddleware(authenticator services.APIKeyAuthenticator) *APIKeyAuthMiddleware {
+	return &APIKeyAuthMiddleware{
+		authenticator: authenticator,
+	}
+}
+
+// APIKeyAuth is a Gin middleware that authenticates requests carrying an X-API-Key header.
+// It must run before RequireAuth: requests without the header are passed on for RequireAuth
+// to check their bearer token, and requests authenticated here are let through by it.
+// A valid key adds its owner to the context the same way RequireAuth does, always with the
+// user role, so API keys can't reach admin routes. Returns 401 for unknown or revoked keys.
+func (m *APIKeyAuthMiddleware) APIKeyAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rawKey := c.GetHeader(APIKeyHeader)
+		if rawKey == "" {
+			c.Next()
+			return
+		}
+
+		key, user, err := m.authenticator.AuthenticateAPIKey(c.Request.Context(), rawKey)
+		if err != nil {
+			statusCode := http.StatusUnauthorized
+			errorCode := dtos.ErrorCodeAuthInvalidAPIKey
+			message := "Invalid API key"
+
+			switch {
+			case errors.Is(err, domain.ErrAPIKeyRevoked):
+				errorCode = dtos.ErrorCodeAuthAPIKeyRevoked
+				message = "API key has been revoked"
+			case errors.Is(err, domain.ErrAccountInactive):
+				errorCode = dtos.ErrorCodeAuthAccountInactive
+				message = "Account is inactive"
+			case !errors.Is(err, domain.ErrInvalidAPIKey):
+				logging.ContextLogger(c).Error("API key authentication failed", logging.WithError(err))
+				statusCode = http.StatusInternalServerError
+				errorCode = dtos.ErrorCodeInternal
+				message = "An internal error occurred. Please try again later"
+			}
+
+			c.JSON(statusCode, dtos.NewCodedErrorResponse(
+				statusCode,
+				errorCode,
+				message,
+			))
+			c.Abort()
+			return
+		}
+
+		// Store the key's owner in Gin context like RequireAuth does for token claims
+		claims := &domain.TokenClaims{
+			UserID: user.ID,
+			Email:  user.Email,
+			Role:   domain.RoleUser,
+		}
+		c.Set("userID", claims.UserID)
+		c.Set("userEmail", claims.Email)
+		c.Set("userRole", claims.Role)
+		c.Set("tokenClaims", claims)
+		c.Set("apiKey", &key)
+
+		// The services read the acting user for the audit log from the request context
+		c.Request = c.Request.WithContext(services.WithRequestUser(c.Request.Context(), claims.UserID))
+
+		c.Next()
+	}
+}
+
+// GetAPIKey returns the API key the request was authenticated with
+// Returns nil for requests authenticated with a JWT or not at all
+func GetAPIKey(c *gin.Context) *domain.APIKey {
+	value, exists := c.Get("apiKey")
+	if !exists {
+		return nil
+	}
+
+	key, ok := value.(*domain.APIKey)
+	if !ok {
+		return nil
+	}
+
+	return key
+}
+
+// RequireScope is a Gin middleware that limits which routes an API key can reach.
+// Requests authenticated with an API key need readScope for GET and HEAD requests
+// and writeScope for every other method; returns 403 when the key lacks it.
+// Requests authenticated with a JWT carry every scope and are always let through.
+func RequireScope(readScope, writeScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := GetAPIKey(c)
+		if key == nil {
+			c.Next()
+			return
+		}
+
+		scope := writeScope
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = readScope
+		}
+
+		if !key.HasScope(scope) {
+			c.JSON(http.StatusForbidden, dtos.NewCodedErrorResponse(
+				http.StatusForbidden,
+				dtos.ErrorCodeAuthInsufficientScope,
+				"API key is missing the "+scope+" scope",
+			))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,169 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// MockAPIKeyAuthenticator is a mock implementation of APIKeyAuthenticator for testing
+type MockAPIKeyAuthenticator struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, rawKey string) (domain.APIKey, *domain.User, error) {
+	args := m.Called(ctx, rawKey)
+	if args.Get(1) == nil {
+		return args.Get(0).(domain.APIKey), nil, args.Error(2)
+	}
+	return args.Get(0).(domain.APIKey), args.Get(1).(*domain.User), args.Error(2)
+}
+
+// setupAPIKeyTestRouter mirrors the route setup in main: API keys are checked before bearer
+// tokens, and the finance routes need the finance scopes
+func setupAPIKeyTestRouter(authenticator *MockAPIKeyAuthenticator, jwtService *MockJWTService) *gin.Engine {
+	setupTestLogger()
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	finance := r.Group("/finance")
+	finance.Use(NewAPIKeyAuthMiddleware(authenticator).APIKeyAuth())
+	finance.Use(NewJWTAuthMiddleware(jwtService).RequireAuth())
+	finance.Use(RequireScope(domain.APIKeyScopeFinanceRead, domain.APIKeyScopeFinanceWrite))
+
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user_id": GetUserID(c), "role": GetUserRole(c)})
+	}
+	finance.GET("/summary", handler)
+	finance.POST("/income", handler)
+
+	return r
+}
+
+func TestAPIKeyAuth_ValidKeyAuthenticatesAsOwner(t *testing.T) {
+	authenticator := &MockAPIKeyAuthenticator{}
+	jwtService := &MockJWTService{}
+	router := setupAPIKeyTestRouter(authenticator, jwtService)
+
+	key := domain.APIKey{ID: "key-1", UserID: "user-123", Scopes: []string{domain.APIKeyScopeFinanceRead}}
+	// Admins' keys still only act with the user role
+	owner := &domain.User{ID: "user-123", Email: "admin@example.com", Role: domain.RoleAdmin, IsActive: true}
+	authenticator.On("AuthenticateAPIKey", mock.Anything, "bob_valid").Return(key, owner, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/finance/summary", nil)
+	req.Header.Set(APIKeyHeader, "bob_valid")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "user-123", body["user_id"])
+	assert.Equal(t, domain.RoleUser, body["role"])
+	jwtService.AssertNotCalled(t, "ValidateAccessToken", mock.Anything)
+}
+
+func TestAPIKeyAuth_RejectedKeys(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   dtos.ErrorCode
+	}{
+		{"unknown key", domain.ErrInvalidAPIKey, http.StatusUnauthorized, dtos.ErrorCodeAuthInvalidAPIKey},
+		{"revoked key", domain.ErrAPIKeyRevoked, http.StatusUnauthorized, dtos.ErrorCodeAuthAPIKeyRevoked},
+		{"deactivated owner", domain.ErrAccountInactive, http.StatusUnauthorized, dtos.ErrorCodeAuthAccountInactive},
+		{"lookup failure", errors.New("database unavailable"), http.StatusInternalServerError, dtos.ErrorCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authenticator := &MockAPIKeyAuthenticator{}
+			router := setupAPIKeyTestRouter(authenticator, &MockJWTService{})
+			authenticator.On("AuthenticateAPIKey", mock.Anything, "bob_key").Return(domain.APIKey{}, nil, tt.err)
+
+			req := httptest.NewRequest(http.MethodGet, "/finance/summary", nil)
+			req.Header.Set(APIKeyHeader, "bob_key")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			var response dtos.ErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedCode, response.ErrorCode)
+		})
+	}
+}
+
+func TestRequireScope_GatesAPIKeysByMethod(t *testing.T) {
+	authenticator := &MockAPIKeyAuthenticator{}
+	router := setupAPIKeyTestRouter(authenticator, &MockJWTService{})
+
+	owner := &domain.User{ID: "user-123", IsActive: true}
+	readOnly := domain.APIKey{ID: "key-1", UserID: "user-123", Scopes: []string{domain.APIKeyScopeFinanceRead}}
+	healthOnly := domain.APIKey{ID: "key-2", UserID: "user-123", Scopes: []string{domain.APIKeyScopeHealthRead, domain.APIKeyScopeHealthWrite}}
+	authenticator.On("AuthenticateAPIKey", mock.Anything, "bob_read").Return(readOnly, owner, nil)
+	authenticator.On("AuthenticateAPIKey", mock.Anything, "bob_health").Return(healthOnly, owner, nil)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		key            string
+		expectedStatus int
+	}{
+		{"read scope allows GET", http.MethodGet, "/finance/summary", "bob_read", http.StatusOK},
+		{"read scope doesn't allow POST", http.MethodPost, "/finance/income", "bob_read", http.StatusForbidden},
+		{"other resource's scopes don't apply", http.MethodGet, "/finance/summary", "bob_health", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(APIKeyHeader, tt.key)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				var response dtos.ErrorResponseDTO
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, dtos.ErrorCodeAuthInsufficientScope, response.ErrorCode)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_BearerTokensStillWork(t *testing.T) {
+	authenticator := &MockAPIKeyAuthenticator{}
+	jwtService := &MockJWTService{}
+	router := setupAPIKeyTestRouter(authenticator, jwtService)
+	jwtService.On("ValidateAccessToken", "valid-token").Return(createValidTokenClaims(), nil)
+
+	// JWTs carry every scope
+	req := httptest.NewRequest(http.MethodPost, "/finance/income", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	authenticator.AssertNotCalled(t, "AuthenticateAPIKey", mock.Anything, mock.Anything)
+
+	// Without either credential RequireAuth still rejects the request
+	req = httptest.NewRequest(http.MethodGet, "/finance/summary", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -8,29 +9,79 @@ import (
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
 // JWTAuthMiddleware provides JWT authentication middleware for Gin
 type JWTAuthMiddleware struct {
 	jwtService services.JWTService
+	// cookieAuth accepts the access token cookie from requests without an Authorization header
+	cookieAuth bool
+	// userChecker, when set, rejects tokens whose user has been deleted, deactivated or given another role
+	userChecker services.TokenUserChecker
+}
+
+// JWTAuthOption configures optional JWTAuthMiddleware behaviour
+type JWTAuthOption func(*JWTAuthMiddleware)
+
+// WithAccessTokenCookie accepts the access token from the access_token cookie of a cookie
+// session when a request has no Authorization header
+func WithAccessTokenCookie() JWTAuthOption {
+	return func(j *JWTAuthMiddleware) {
+		j.cookieAuth = true
+	}
+}
+
+// WithTokenUserCheck looks up the user of every valid access token through checker, so tokens
+// issued before the account was deleted, deactivated or given another role stop working before they expire
+func WithTokenUserCheck(checker services.TokenUserChecker) JWTAuthOption {
+	return func(j *JWTAuthMiddleware) {
+		j.userChecker = checker
+	}
 }
 
 // NewJWTAuthMiddleware creates a new JWT authentication middleware
-func NewJWTAuthMiddleware(jwtService services.JWTService) *JWTAuthMiddleware {
-	return &JWTAuthMiddleware{
+func NewJWTAuthMiddleware(jwtService services.JWTService, opts ...JWTAuthOption) *JWTAuthMiddleware {
+	j := &JWTAuthMiddleware{
 		jwtService: jwtService,
 	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// authorizationHeader returns the request's Authorization header or, with cookie sessions
+// enabled and no header, the access token cookie in the same Bearer form. fromCookie reports
+// which one it is.
+func (j *JWTAuthMiddleware) authorizationHeader(c *gin.Context) (header string, fromCookie bool) {
+	header = c.GetHeader("Authorization")
+	if header != "" || !j.cookieAuth {
+		return header, false
+	}
+	if token, err := c.Cookie(AccessTokenCookie); err == nil && token != "" {
+		return "Bearer " + token, true
+	}
+	return "", false
 }
 
 // RequireAuth is a Gin middleware that validates JWT tokens
-// Extracts JWT from Authorization header (Bearer token format)
+// Extracts JWT from Authorization header (Bearer token format), or the access token cookie
+// when cookie sessions are accepted
 // Validates token using JWTService and adds user claims to context
-// Returns 401 for invalid, expired, or missing tokens
+// Returns 401 for invalid, expired, or missing tokens, and with WithTokenUserCheck for tokens
+// of deleted or deactivated users
+// Requests already authenticated by APIKeyAuth are let through without a token
 func (j *JWTAuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Extract token from Authorization header
-		authHeader := c.GetHeader("Authorization")
+		if GetAPIKey(c) != nil {
+			c.Next()
+			return
+		}
+
+		// Extract token from Authorization header, or the access token cookie
+		authHeader, fromCookie := j.authorizationHeader(c)
 		if authHeader == "" {
 			c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
 				http.StatusUnauthorized,
@@ -70,10 +121,11 @@ func (j *JWTAuthMiddleware) RequireAuth() gin.HandlerFunc {
 		if err != nil {
 			// Determine specific error type for appropriate response
 			statusCode := http.StatusUnauthorized
-			errorCode := "unauthorized"
+			errorCode := dtos.ErrorCodeAuthInvalidToken
 			message := "Invalid access token"
 
 			if strings.Contains(err.Error(), "expired") {
+				errorCode = dtos.ErrorCodeAuthTokenExpired
 				message = "Access token has expired"
 			} else if strings.Contains(err.Error(), "signature") {
 				message = "Invalid token signature"
@@ -81,7 +133,7 @@ func (j *JWTAuthMiddleware) RequireAuth() gin.HandlerFunc {
 				message = "Malformed access token"
 			}
 
-			c.JSON(statusCode, dtos.NewErrorResponse(
+			c.JSON(statusCode, dtos.NewCodedErrorResponse(
 				statusCode,
 				errorCode,
 				message,
@@ -92,19 +144,53 @@ func (j *JWTAuthMiddleware) RequireAuth() gin.HandlerFunc {
 
 		// Additional validation: Check if token has expired using domain logic
 		if claims.IsExpired() {
-			c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+			c.JSON(http.StatusUnauthorized, dtos.NewCodedErrorResponse(
 				http.StatusUnauthorized,
-				"unauthorized",
+				dtos.ErrorCodeAuthTokenExpired,
 				"Access token has expired",
 			))
 			c.Abort()
 			return
 		}
 
+		if err := j.checkTokenUser(c, claims); err != nil {
+			statusCode := http.StatusUnauthorized
+			errorCode := dtos.ErrorCodeAuthInvalidToken
+			message := "Access token no longer belongs to an account"
+
+			switch {
+			case errors.Is(err, domain.ErrAccountInactive):
+				errorCode = dtos.ErrorCodeAuthAccountInactive
+				message = "Account is inactive"
+			case errors.Is(err, domain.ErrTokenRoleChanged):
+				message = "Access token is out of date; refresh it"
+			case !errors.Is(err, domain.ErrInvalidToken):
+				logging.ContextLogger(c).Error("Access token user check failed", logging.WithError(err))
+				statusCode = http.StatusInternalServerError
+				errorCode = dtos.ErrorCodeInternal
+				message = "An internal error occurred. Please try again later"
+			}
+
+			c.JSON(statusCode, dtos.NewCodedErrorResponse(
+				statusCode,
+				errorCode,
+				message,
+			))
+			c.Abort()
+			return
+		}
+
 		// Store user claims in Gin context for use by handlers
 		c.Set("userID", claims.UserID)
 		c.Set("userEmail", claims.Email)
+		c.Set("userRole", claims.Role)
 		c.Set("tokenClaims", claims)
+		if !fromCookie {
+			c.Set("bearerAuth", true)
+		}
+
+		// The services read the acting user for the audit log from the request context
+		c.Request = c.Request.WithContext(services.WithRequestUser(c.Request.Context(), claims.UserID))
 
 		// Continue to the next middleware/handler
 		c.Next()
@@ -117,8 +203,8 @@ func (j *JWTAuthMiddleware) RequireAuth() gin.HandlerFunc {
 // If no token is present or token is invalid, it continues without claims
 func (j *JWTAuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Extract token from Authorization header
-		authHeader := c.GetHeader("Authorization")
+		// Extract token from Authorization header, or the access token cookie
+		authHeader, fromCookie := j.authorizationHeader(c)
 		if authHeader == "" {
 			// No token provided, continue without authentication
 			c.Next()
@@ -143,8 +229,8 @@ func (j *JWTAuthMiddleware) OptionalAuth() gin.HandlerFunc {
 
 		// Validate the access token using JWTService
 		claims, err := j.jwtService.ValidateAccessToken(tokenString)
-		if err != nil || claims.IsExpired() {
-			// Invalid or expired token, continue without authentication
+		if err != nil || claims.IsExpired() || j.checkTokenUser(c, claims) != nil {
+			// Invalid or expired token, or one without an active user, continue without authentication
 			c.Next()
 			return
 		}
@@ -152,13 +238,32 @@ func (j *JWTAuthMiddleware) OptionalAuth() gin.HandlerFunc {
 		// Store user claims in Gin context for use by handlers
 		c.Set("userID", claims.UserID)
 		c.Set("userEmail", claims.Email)
+		c.Set("userRole", claims.Role)
 		c.Set("tokenClaims", claims)
+		if !fromCookie {
+			c.Set("bearerAuth", true)
+		}
 
 		// Continue to the next middleware/handler
 		c.Next()
 	}
 }
 
+// checkTokenUser confirms the token's user still has an active account with the token's role
+// when WithTokenUserCheck is set
+func (j *JWTAuthMiddleware) checkTokenUser(c *gin.Context, claims *domain.TokenClaims) error {
+	if j.userChecker == nil {
+		return nil
+	}
+	return j.userChecker.CheckTokenUser(c.Request.Context(), claims.UserID, claims.Role)
+}
+
+// AuthenticatedByBearer reports whether the request was authenticated by a valid token in its
+// Authorization header, as opposed to the access token cookie, an API key or not at all
+func AuthenticatedByBearer(c *gin.Context) bool {
+	return c.GetBool("bearerAuth")
+}
+
 // GetUserClaims extracts user claims from Gin context
 // Returns nil if no authenticated user is found
 func GetUserClaims(c *gin.Context) *domain.TokenClaims {
@@ -191,6 +296,19 @@ func GetUserID(c *gin.Context) string {
 	return id
 }
 
+// authenticatedUserID returns the authenticated user ID set by the finance or health auth context
+func authenticatedUserID(c *gin.Context) string {
+	if userID := GetUserID(c); userID != "" {
+		return userID
+	}
+	if userID, ok := c.Get("user_id"); ok {
+		if userIDStr, ok := userID.(string); ok {
+			return userIDStr
+		}
+	}
+	return ""
+}
+
 // GetUserEmail extracts user email from Gin context
 // Returns empty string if no authenticated user is found
 func GetUserEmail(c *gin.Context) string {
@@ -207,8 +325,50 @@ func GetUserEmail(c *gin.Context) string {
 	return userEmail
 }
 
+// GetUserRole extracts the user's role from Gin context
+// Returns empty string if no authenticated user is found
+func GetUserRole(c *gin.Context) string {
+	claims := GetUserClaims(c)
+	if claims == nil {
+		return ""
+	}
+	if claims.Role == "" {
+		return domain.RoleUser
+	}
+	return claims.Role
+}
+
 // IsAuthenticated checks if the current request has valid authentication
 // Returns true if user claims are present in context
 func IsAuthenticated(c *gin.Context) bool {
 	return GetUserClaims(c) != nil
-}
\ No newline at end of file
+}
+
+// RequireRole is a Gin middleware that only lets users with the given role through.
+// It must run after RequireAuth. Returns 401 without an authenticated user
+// and 403 when the role claim does not match.
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !IsAuthenticated(c) {
+			c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+				http.StatusUnauthorized,
+				"unauthorized",
+				"Authentication required",
+			))
+			c.Abort()
+			return
+		}
+
+		if GetUserRole(c) != role {
+			c.JSON(http.StatusForbidden, dtos.NewErrorResponse(
+				http.StatusForbidden,
+				"forbidden",
+				"You do not have permission to access this resource",
+			))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +17,7 @@ import (
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 )
 
 // MockJWTService is a mock implementation of JWTService for testing
@@ -22,8 +25,8 @@ type MockJWTService struct {
 	mock.Mock
 }
 
-func (m *MockJWTService) GenerateTokenPair(userID, email string) (*domain.TokenPair, error) {
-	args := m.Called(userID, email)
+func (m *MockJWTService) GenerateTokenPair(userID, email, role string) (*domain.TokenPair, error) {
+	args := m.Called(userID, email, role)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -46,6 +49,10 @@ func (m *MockJWTService) ValidateRefreshToken(tokenString string) (*domain.Token
 	return args.Get(0).(*domain.TokenClaims), args.Error(1)
 }
 
+func (m *MockJWTService) ActiveKeyID() string {
+	return m.Called().String(0)
+}
+
 func setupTestRouter(middleware gin.HandlerFunc) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
@@ -502,4 +509,193 @@ func TestNewJWTAuthMiddleware_CreatesInstance(t *testing.T) {
 	// Assert
 	assert.NotNil(t, middleware)
 	assert.Equal(t, mockJWTService, middleware.jwtService)
-}
\ No newline at end of file
+}
+func TestRequireRole_Admin(t *testing.T) {
+	tests := []struct {
+		name           string
+		claims         *domain.TokenClaims
+		expectedStatus int
+	}{
+		{name: "admin", claims: &domain.TokenClaims{UserID: "1", Email: "admin@example.com", Role: domain.RoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "regular user", claims: &domain.TokenClaims{UserID: "2", Email: "test@example.com", Role: domain.RoleUser}, expectedStatus: http.StatusForbidden},
+		{name: "token without role", claims: &domain.TokenClaims{UserID: "3", Email: "old@example.com"}, expectedStatus: http.StatusForbidden},
+		{name: "unauthenticated", claims: nil, expectedStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockJWTService := new(MockJWTService)
+			if tt.claims != nil {
+				claims := *tt.claims
+				claims.ExpiresAt = time.Now().Add(15 * time.Minute).Unix()
+				mockJWTService.On("ValidateAccessToken", "token").Return(&claims, nil)
+			}
+			auth := NewJWTAuthMiddleware(mockJWTService)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.GET("/admin", auth.OptionalAuth(), RequireRole(domain.RoleAdmin), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "admin resource accessed"})
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/admin", nil)
+			if tt.claims != nil {
+				req.Header.Set("Authorization", "Bearer token")
+			}
+			r.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.expectedStatus, w.Code)
+		})
+	}
+}
+
+func TestJWTAuthMiddleware_RequireAuth_AccessTokenCookie(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []JWTAuthOption
+		authHeader     string
+		expectedToken  string
+		expectedStatus int
+	}{
+		{
+			name:           "cookie accepted with cookie sessions",
+			opts:           []JWTAuthOption{WithAccessTokenCookie()},
+			expectedToken:  "cookie_token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "authorization header wins over the cookie",
+			opts:           []JWTAuthOption{WithAccessTokenCookie()},
+			authHeader:     "Bearer header_token",
+			expectedToken:  "header_token",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "cookie ignored without cookie sessions",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWTService := new(MockJWTService)
+			router := setupTestRouter(NewJWTAuthMiddleware(mockJWTService, tt.opts...).RequireAuth())
+			if tt.expectedToken != "" {
+				mockJWTService.On("ValidateAccessToken", tt.expectedToken).Return(createValidTokenClaims(), nil)
+			}
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/protected", nil)
+			req.AddCookie(&http.Cookie{Name: AccessTokenCookie, Value: "cookie_token"})
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			mockJWTService.AssertExpectations(t)
+		})
+	}
+}
+
+// tokenUserCheckerFunc adapts a function to services.TokenUserChecker
+type tokenUserCheckerFunc func(ctx context.Context, userID, role string) error
+
+func (f tokenUserCheckerFunc) CheckTokenUser(ctx context.Context, userID, role string) error {
+	return f(ctx, userID, role)
+}
+
+func TestJWTAuthMiddleware_TokenUserCheck(t *testing.T) {
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}))
+
+	tests := []struct {
+		name              string
+		checkErr          error
+		expectedStatus    int
+		expectedErrorCode dtos.ErrorCode
+		expectedOptional  string
+	}{
+		{
+			name:             "active user",
+			expectedStatus:   http.StatusOK,
+			expectedOptional: "user-123",
+		},
+		{
+			name:              "deleted user",
+			checkErr:          domain.ErrInvalidToken,
+			expectedStatus:    http.StatusUnauthorized,
+			expectedErrorCode: dtos.ErrorCodeAuthInvalidToken,
+		},
+		{
+			name:              "deactivated user",
+			checkErr:          domain.ErrAccountInactive,
+			expectedStatus:    http.StatusUnauthorized,
+			expectedErrorCode: dtos.ErrorCodeAuthAccountInactive,
+		},
+		{
+			name:              "role changed",
+			checkErr:          domain.ErrTokenRoleChanged,
+			expectedStatus:    http.StatusUnauthorized,
+			expectedErrorCode: dtos.ErrorCodeAuthInvalidToken,
+		},
+		{
+			name:              "lookup failure",
+			checkErr:          fmt.Errorf("failed to get user: %w", errors.New("connection refused")),
+			expectedStatus:    http.StatusInternalServerError,
+			expectedErrorCode: dtos.ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJWTService := new(MockJWTService)
+			claims := createValidTokenClaims()
+			claims.Role = domain.RoleAdmin
+			mockJWTService.On("ValidateAccessToken", "valid_token").Return(claims, nil)
+			var checkedUser, checkedRole string
+			checker := tokenUserCheckerFunc(func(ctx context.Context, userID, role string) error {
+				checkedUser, checkedRole = userID, role
+				return tt.checkErr
+			})
+			jwtAuth := NewJWTAuthMiddleware(mockJWTService, WithTokenUserCheck(checker))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/protected", jwtAuth.RequireAuth(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"message": "protected resource accessed"})
+			})
+			router.GET("/optional", jwtAuth.OptionalAuth(), func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"user_id": GetUserID(c)})
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/protected", nil)
+			req.Header.Set("Authorization", "Bearer valid_token")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, "user-123", checkedUser)
+			assert.Equal(t, domain.RoleAdmin, checkedRole)
+			if tt.expectedErrorCode != "" {
+				var response dtos.ErrorResponseDTO
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, tt.expectedErrorCode, response.ErrorCode)
+			}
+
+			// OptionalAuth treats the request as anonymous instead of rejecting it
+			w = httptest.NewRecorder()
+			req, _ = http.NewRequest("GET", "/optional", nil)
+			req.Header.Set("Authorization", "Bearer valid_token")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var body map[string]string
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tt.expectedOptional, body["user_id"])
+		})
+	}
+}
@@ -0,0 +1,279 @@
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionConfig holds configuration for the response compression middleware
+type CompressionConfig struct {
+	// Enabled turns compression on; when false the middleware passes responses through untouched
+	Enabled bool
+	// MinSize is the smallest response body, in bytes, that is compressed. Smaller bodies
+	// aren't worth the CPU and gzip framing overhead.
+	MinSize int
+}
+
+// DefaultCompressionConfig returns a sensible default configuration
+func DefaultCompressionConfig() CompressionConfig {
+	return CompressionConfig{
+		Enabled: true,
+		MinSize: 1024,
+	}
+}
+
+// Supported content codings, in order of preference when the client accepts both equally
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// incompressibleTypes are content types whose bodies are already compressed
+var incompressibleTypes = map[string]bool{
+	"application/gzip":             true,
+	"application/x-gzip":           true,
+	"application/zip":              true,
+	"application/x-7z-compressed":  true,
+	"application/x-rar-compressed": true,
+	"application/x-bzip2":          true,
+	"application/zstd":             true,
+	"application/pdf":              true,
+	"application/octet-stream":     true,
+	"font/woff":                    true,
+	"font/woff2":                   true,
+}
+
+// Compression gzips or deflates response bodies of at least config.MinSize bytes when the
+// client's Accept-Encoding allows it. Responses that already carry a Content-Encoding, and
+// content types that are already compressed, are passed through unchanged.
+func Compression(config CompressionConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !config.Enabled || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &compressionWriter{
+			ResponseWriter: original,
+			minSize:        config.MinSize,
+			encoding:       negotiateEncoding(c.GetHeader("Accept-Encoding")),
+		}
+		c.Writer = writer
+
+		defer func() {
+			writer.finish()
+			c.Writer = original
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, honouring q-values.
+// It returns "" when the client accepts neither.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		coding, quality := parseCoding(part)
+		if coding != "" {
+			qualities[coding] = quality
+		}
+	}
+
+	best, bestQuality := "", 0.0
+	for _, coding := range []string{encodingGzip, encodingDeflate} {
+		quality, ok := qualities[coding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+		if ok && quality > bestQuality {
+			best, bestQuality = coding, quality
+		}
+	}
+	return best
+}
+
+// parseCoding splits one Accept-Encoding entry such as "gzip;q=0.8" into its coding and quality
+func parseCoding(part string) (string, float64) {
+	fields := strings.Split(part, ";")
+	coding := strings.ToLower(strings.TrimSpace(fields[0]))
+	quality := 1.0
+	for _, param := range fields[1:] {
+		param = strings.TrimSpace(param)
+		if value, ok := strings.CutPrefix(param, "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return "", 0
+			}
+			quality = parsed
+		}
+	}
+	return coding, quality
+}
+
+// isCompressibleType reports whether a Content-Type is worth compressing
+func isCompressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(contentType))
+	}
+
+	switch {
+	case incompressibleTypes[mediaType]:
+		return false
+	case mediaType == "image/svg+xml":
+		return true
+	case strings.HasPrefix(mediaType, "image/"),
+		strings.HasPrefix(mediaType, "video/"),
+		strings.HasPrefix(mediaType, "audio/"):
+		return false
+	default:
+		return true
+	}
+}
+
+// compressionWriter buffers the start of a response until it knows whether the body reaches
+// the size threshold, then either streams it through a compressor or writes it unchanged
+type compressionWriter struct {
+	gin.ResponseWriter
+	minSize  int
+	encoding string // negotiated coding, "" when the client accepts none
+
+	buffer     bytes.Buffer
+	decided    bool
+	compressor io.WriteCloser
+}
+
+func (w *compressionWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		return w.write(data)
+	}
+
+	w.buffer.Write(data)
+	if w.buffer.Len() >= w.minSize {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
+
+func (w *compressionWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends the headers immediately, so a body written afterwards can't be compressed
+func (w *compressionWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush decides on compression with whatever has been buffered so far and flushes it to the client
+func (w *compressionWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(w.buffer.Len() >= w.minSize)
+	}
+	if flusher, ok := w.compressor.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressionWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	return w.ResponseWriter.Hijack()
+}
+
+// decide fixes whether the response is compressed and writes out anything buffered.
+// largeEnough reports whether the body reached the size threshold.
+func (w *compressionWriter) decide(largeEnough bool) error {
+	w.decided = true
+
+	header := w.Header()
+	eligible := largeEnough &&
+		header.Get("Content-Encoding") == "" &&
+		bodyAllowed(w.Status()) &&
+		w.Status() != http.StatusPartialContent &&
+		isCompressibleType(header.Get("Content-Type"))
+
+	if eligible {
+		// The representation now depends on Accept-Encoding, whether or not this client gets it compressed
+		addVary(header, "Accept-Encoding")
+	}
+
+	if eligible && w.encoding != "" {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+		switch w.encoding {
+		case encodingGzip:
+			w.compressor = gzip.NewWriter(w.ResponseWriter)
+		case encodingDeflate:
+			w.compressor = zlib.NewWriter(w.ResponseWriter)
+		}
+	}
+
+	if w.buffer.Len() == 0 {
+		return nil
+	}
+	buffered := w.buffer.Bytes()
+	w.buffer = bytes.Buffer{}
+	_, err := w.write(buffered)
+	return err
+}
+
+func (w *compressionWriter) write(data []byte) (int, error) {
+	if w.compressor != nil {
+		return w.compressor.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// finish writes out a response that never reached the threshold and closes the compressor
+func (w *compressionWriter) finish() {
+	if !w.decided {
+		if w.buffer.Len() == 0 {
+			return
+		}
+		_ = w.decide(false)
+	}
+	if w.compressor != nil {
+		_ = w.compressor.Close()
+	}
+}
+
+// bodyAllowed reports whether a response with the given status may carry a body
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
+
+// addVary adds a field to the Vary header unless it's already listed
+func addVary(header http.Header, field string) {
+	for _, value := range header.Values("Vary") {
+		for _, existing := range strings.Split(value, ",") {
+			existing = strings.TrimSpace(existing)
+			if existing == "*" || strings.EqualFold(existing, field) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", field)
+}
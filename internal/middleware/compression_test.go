@@ -0,0 +1,195 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// largeHealthSummary builds a summary whose JSON is well above the default threshold
+func largeHealthSummary() dtos.HealthSummaryResponseDTO {
+	summary := dtos.HealthSummaryResponseDTO{
+		UserID:                 "user123",
+		HealthRiskScore:        42,
+		HealthRiskLevel:        "moderate",
+		MonthlyMedicalExpenses: 850,
+		UpdatedAt:              time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	for i := 0; i < 50; i++ {
+		summary.MemberExpenses = append(summary.MemberExpenses, dtos.MemberMedicalExpensesDTO{
+			ProfileID:              fmt.Sprintf("%d", i+1),
+			Name:                   fmt.Sprintf("Member %d", i+1),
+			RelationToOwner:        "child",
+			MonthlyMedicalExpenses: float64(i) * 10,
+		})
+	}
+	return summary
+}
+
+func setupCompressionTestRouter(config CompressionConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(Compression(config))
+	r.GET("/summary", func(c *gin.Context) {
+		c.JSON(http.StatusOK, largeHealthSummary())
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.GET("/image", func(c *gin.Context) {
+		c.Data(http.StatusOK, "image/png", bytes.Repeat([]byte{0x89}, 4096))
+	})
+	r.GET("/precompressed", func(c *gin.Context) {
+		var body bytes.Buffer
+		gz := gzip.NewWriter(&body)
+		_, _ = gz.Write(bytes.Repeat([]byte("a"), 4096))
+		_ = gz.Close()
+		c.Header("Content-Encoding", "gzip")
+		c.Data(http.StatusOK, "text/plain", body.Bytes())
+	})
+	r.GET("/empty", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	return r
+}
+
+func serveCompressionRequest(router *gin.Engine, path, acceptEncoding string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestCompression_LargeSummaryWithAndWithoutGzip(t *testing.T) {
+	router := setupCompressionTestRouter(DefaultCompressionConfig())
+	expected, err := json.Marshal(largeHealthSummary())
+	require.NoError(t, err)
+	require.Greater(t, len(expected), DefaultCompressionConfig().MinSize)
+
+	// With gzip the body is compressed and decompresses to the same summary
+	w := serveCompressionRequest(router, "/summary", "gzip, deflate, br")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	assert.Less(t, w.Body.Len(), len(expected))
+
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(decompressed))
+
+	var summary dtos.HealthSummaryResponseDTO
+	require.NoError(t, json.Unmarshal(decompressed, &summary))
+	assert.Len(t, summary.MemberExpenses, 50)
+
+	// Without Accept-Encoding the same response is sent as plain JSON
+	w = serveCompressionRequest(router, "/summary", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"), "caches must still key on Accept-Encoding")
+	assert.JSONEq(t, string(expected), w.Body.String())
+}
+
+func TestCompression_Deflate(t *testing.T) {
+	router := setupCompressionTestRouter(DefaultCompressionConfig())
+
+	w := serveCompressionRequest(router, "/summary", "deflate")
+	assert.Equal(t, "deflate", w.Header().Get("Content-Encoding"))
+
+	reader, err := zlib.NewReader(w.Body)
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	expected, _ := json.Marshal(largeHealthSummary())
+	assert.JSONEq(t, string(expected), string(decompressed))
+}
+
+func TestCompression_PassesThrough(t *testing.T) {
+	router := setupCompressionTestRouter(DefaultCompressionConfig())
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantEncoding   string
+		wantStatus     int
+	}{
+		{"below_threshold", "/small", "gzip", "", http.StatusOK},
+		{"already_compressed_type", "/image", "gzip", "", http.StatusOK},
+		{"already_encoded", "/precompressed", "gzip", "gzip", http.StatusOK},
+		{"gzip_refused", "/summary", "gzip;q=0, identity", "", http.StatusOK},
+		{"no_body", "/empty", "gzip", "", http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveCompressionRequest(router, tt.path, tt.acceptEncoding)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantEncoding, w.Header().Get("Content-Encoding"))
+		})
+	}
+
+	// The pre-compressed body must not be compressed a second time
+	w := serveCompressionRequest(router, "/precompressed", "gzip")
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	decompressed, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte("a"), 4096), decompressed)
+}
+
+func TestCompression_Disabled(t *testing.T) {
+	router := setupCompressionTestRouter(CompressionConfig{Enabled: false, MinSize: 1024})
+
+	w := serveCompressionRequest(router, "/summary", "gzip")
+
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Header().Get("Vary"))
+	expected, _ := json.Marshal(largeHealthSummary())
+	assert.JSONEq(t, string(expected), w.Body.String())
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"br", ""},
+		{"deflate, gzip", "gzip"},
+		{"gzip;q=0.5, deflate", "deflate"},
+		{"gzip;q=0", ""},
+		{"*", "gzip"},
+		{"*;q=0, deflate", "deflate"},
+		{"GZIP", "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, negotiateEncoding(tt.header))
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSConfig holds the cross-origin policy applied by the CORS middleware
+type CORSConfig struct {
+	// AllowedOrigins lists the origins browsers may call the API from. Entries are exact origins
+	// such as "https://app.example.com", subdomain patterns such as "https://*.example.com",
+	// or "*" for any origin.
+	AllowedOrigins []string
+	AllowedMethods []string
+	// AllowedHeaders lists the request headers a preflight may ask for; "*" allows any request
+	// header except Authorization, which browsers require to be listed by name
+	AllowedHeaders []string
+	// ExposedHeaders lists the response headers scripts on an allowed origin may read
+	ExposedHeaders []string
+	// AllowCredentials lets browsers send cookies and HTTP authentication cross-origin.
+	// It is ignored when "*" is among the origins.
+	AllowCredentials bool
+	// MaxAge is how long browsers may cache a preflight response; 0 leaves it to the browser
+	MaxAge time.Duration
+}
+
+// DefaultCORSConfig returns a permissive configuration that allows any origin without credentials
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions,
+		},
+		AllowedHeaders: []string{
+			"Authorization", "Content-Type", "Idempotency-Key", "If-None-Match", "X-API-Key", "X-CSRF-Token", "X-Request-ID",
+		},
+		ExposedHeaders: []string{"X-Request-ID", "ETag"},
+		MaxAge:         10 * time.Minute,
+	}
+}
+
+// originMatcher decides which origins the CORS policy applies to
+type originMatcher struct {
+	allowAll bool
+	exact    map[string]bool
+	patterns []originPattern
+}
+
+// originPattern matches the origins of every subdomain of a host, e.g. "https://*.example.com"
+type originPattern struct {
+	prefix string // "https://"
+	suffix string // ".example.com", with the port if the pattern has one
+}
+
+// matches reports whether origin is a subdomain origin covered by the pattern
+func (p originPattern) matches(origin string) bool {
+	if len(origin) <= len(p.prefix)+len(p.suffix) ||
+		!strings.HasPrefix(origin, p.prefix) || !strings.HasSuffix(origin, p.suffix) {
+		return false
+	}
+	// Only host labels may stand in for the wildcard, so a port, path or userinfo can't be smuggled in
+	subdomain := origin[len(p.prefix) : len(origin)-len(p.suffix)]
+	for _, r := range subdomain {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '.') {
+			return false
+		}
+	}
+	return !strings.HasPrefix(subdomain, ".") && !strings.HasSuffix(subdomain, ".")
+}
+
+func newOriginMatcher(origins []string) originMatcher {
+	matcher := originMatcher{exact: make(map[string]bool)}
+	for _, origin := range origins {
+		origin = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
+		switch {
+		case origin == "*":
+			matcher.allowAll = true
+		case strings.Contains(origin, "://*."):
+			scheme, host, _ := strings.Cut(origin, "://*")
+			matcher.patterns = append(matcher.patterns, originPattern{prefix: scheme + "://", suffix: host})
+		case origin != "":
+			matcher.exact[origin] = true
+		}
+	}
+	return matcher
+}
+
+// allows reports whether responses to origin may be shared with its scripts
+func (m originMatcher) allows(origin string) bool {
+	if m.allowAll {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if m.exact[origin] {
+		return true
+	}
+	for _, pattern := range m.patterns {
+		if pattern.matches(origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS applies the cross-origin policy in config. It must be registered before any
+// authentication middleware: preflight requests are answered here with 204 and never
+// reach the routes. Requests from origins that aren't allowed still run, but get no CORS
+// headers, so browsers withhold the response from the calling page.
+func CORS(config CORSConfig) gin.HandlerFunc {
+	origins := newOriginMatcher(config.AllowedOrigins)
+
+	corsConfig := cors.Config{
+		AllowMethods:  config.AllowedMethods,
+		AllowHeaders:  config.AllowedHeaders,
+		ExposeHeaders: config.ExposedHeaders,
+		// Never combined with "*", which would let every site act as the user
+		AllowCredentials: config.AllowCredentials && !origins.allowAll,
+		MaxAge:           config.MaxAge,
+	}
+	if origins.allowAll {
+		corsConfig.AllowAllOrigins = true
+	} else {
+		// Origins are checked before the handler runs, so it only has to write the headers
+		corsConfig.AllowOriginFunc = func(string) bool { return true }
+	}
+	// cors.New refuses disallowed origins with a 403, so it only sees allowed ones
+	handler := cors.New(corsConfig)
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" || origins.allows(origin) {
+			handler(c)
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
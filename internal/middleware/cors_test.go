@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupCORSTestRouter registers a route behind an auth check that rejects requests without an
+// Authorization header, so a preflight only succeeds if CORS answers it first
+func setupCORSTestRouter(config CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(CORS(config))
+	api := r.Group("/api/v1")
+	api.Use(func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+			return
+		}
+		c.Next()
+	})
+	api.GET("/finance/summary", func(c *gin.Context) {
+		c.Header("X-Request-ID", "req-1")
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	return r
+}
+
+func strictCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins:   []string{"https://app.example.com", "https://*.partner.example.org"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           2 * time.Hour,
+	}
+}
+
+func TestCORS_PreflightIsAnsweredBeforeAuthAndCached(t *testing.T) {
+	router := setupCORSTestRouter(strictCORSConfig())
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/finance/summary", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	req.Header.Set("Access-Control-Request-Headers", "authorization")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Equal(t, "GET,POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization,Content-Type", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "7200", w.Header().Get("Access-Control-Max-Age"))
+	assert.Contains(t, w.Header().Values("Vary"), "Origin")
+}
+
+func TestCORS_CredentialedRequestFromAllowedOrigin(t *testing.T) {
+	router := setupCORSTestRouter(strictCORSConfig())
+
+	tests := []struct {
+		name   string
+		origin string
+	}{
+		{"exact origin", "https://app.example.com"},
+		{"subdomain pattern", "https://eu.billing.partner.example.org"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/finance/summary", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Cookie", "_gorilla_csrf=abc")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.origin, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, "X-Request-Id", w.Header().Get("Access-Control-Expose-Headers"))
+			assert.Contains(t, w.Header().Values("Vary"), "Origin")
+		})
+	}
+}
+
+func TestCORS_DisallowedOriginGetsNoCORSHeaders(t *testing.T) {
+	router := setupCORSTestRouter(strictCORSConfig())
+
+	origins := []string{
+		"https://evil.example.com",
+		"http://app.example.com",      // Scheme must match
+		"https://partner.example.org", // The pattern only covers subdomains
+		"https://evil.com#.partner.example.org",
+		"https://app.example.com.evil.com",
+	}
+
+	for _, origin := range origins {
+		t.Run(origin, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/finance/summary", nil)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// The request itself isn't refused; the browser withholds the response
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+
+			req = httptest.NewRequest(http.MethodOptions, "/api/v1/finance/summary", nil)
+			req.Header.Set("Origin", origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Empty(t, w.Body.String())
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Methods"))
+		})
+	}
+}
+
+func TestCORS_WildcardOriginNeverAllowsCredentials(t *testing.T) {
+	config := DefaultCORSConfig()
+	config.AllowCredentials = true
+	router := setupCORSTestRouter(config)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/v1/finance/summary", nil)
+	req.Header.Set("Origin", "https://anywhere.example.net")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPut)
+	req.Header.Set("Access-Control-Request-Headers", "authorization, idempotency-key")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, w.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// DoubleSubmitCSRF protects cookie sessions from cross-site request forgery. A state-changing
+// request that carries a session cookie must repeat the csrf_token cookie in the X-CSRF-Token
+// header; another site can make the browser send the cookie but can't read it to set the header.
+//
+// Requests authenticated by an API key or by a valid token in the Authorization header are
+// exempt, as are requests without a session cookie: they aren't authenticated by cookie, so
+// there is no session to ride on. Merely sending one of those headers doesn't exempt a request,
+// since routes that don't check it still authenticate by cookie. Register it after APIKeyAuth
+// and RequireAuth when cookie sessions are enabled.
+func DoubleSubmitCSRF() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+			c.Next()
+			return
+		}
+
+		if GetAPIKey(c) != nil || AuthenticatedByBearer(c) || !hasSessionCookie(c) {
+			c.Next()
+			return
+		}
+
+		cookie, _ := c.Cookie(CSRFCookie)
+		header := c.GetHeader(CSRFHeader)
+		switch {
+		case cookie == "" || header == "":
+			rejectCSRF(c, "CSRF token missing: send the csrf_token cookie's value in the X-CSRF-Token header")
+			return
+		case subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) != 1:
+			rejectCSRF(c, "CSRF token does not match the csrf_token cookie")
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// hasSessionCookie reports whether the request carries either session token cookie
+func hasSessionCookie(c *gin.Context) bool {
+	for _, name := range []string{AccessTokenCookie, RefreshTokenCookie} {
+		if value, err := c.Cookie(name); err == nil && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func rejectCSRF(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, dtos.NewCodedErrorResponse(
+		http.StatusForbidden,
+		dtos.ErrorCodeAuthCSRFTokenInvalid,
+		message,
+	))
+}
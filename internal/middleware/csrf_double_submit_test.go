@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// setupDoubleSubmitCSRFRouter checks CSRF tokens after authentication, like the API routes:
+// /keyed accepts API keys, /resource only tokens. Every token but "forged" is valid.
+func setupDoubleSubmitCSRFRouter() *gin.Engine {
+	setupTestLogger()
+	gin.SetMode(gin.TestMode)
+
+	jwtService := &MockJWTService{}
+	jwtService.On("ValidateAccessToken", "forged").Return(nil, errors.New("invalid token"))
+	jwtService.On("ValidateAccessToken", mock.Anything).Return(createValidTokenClaims(), nil)
+	authenticator := &MockAPIKeyAuthenticator{}
+	authenticator.On("AuthenticateAPIKey", mock.Anything, "bob_valid").
+		Return(domain.APIKey{ID: "key-1", UserID: "user-123"}, &domain.User{ID: "user-123", IsActive: true}, nil)
+	jwtAuth := NewJWTAuthMiddleware(jwtService, WithAccessTokenCookie())
+
+	handler := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"message": "ok"})
+	}
+	r := gin.New()
+	resource := r.Group("/resource", jwtAuth.OptionalAuth(), DoubleSubmitCSRF())
+	resource.GET("", handler)
+	resource.POST("", handler)
+	resource.DELETE("", handler)
+	keyed := r.Group("/keyed", NewAPIKeyAuthMiddleware(authenticator).APIKeyAuth(), jwtAuth.OptionalAuth(), DoubleSubmitCSRF())
+	keyed.POST("", handler)
+	return r
+}
+
+func TestDoubleSubmitCSRF(t *testing.T) {
+	sessionCookie := &http.Cookie{Name: AccessTokenCookie, Value: "access"}
+	csrfCookie := &http.Cookie{Name: CSRFCookie, Value: "csrf-token-value"}
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		cookies         []*http.Cookie
+		headers         map[string]string
+		expectedStatus  int
+		expectedMessage string
+	}{
+		{
+			name:           "matching token passes",
+			method:         http.MethodPost,
+			cookies:        []*http.Cookie{sessionCookie, csrfCookie},
+			headers:        map[string]string{CSRFHeader: "csrf-token-value"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "missing header is rejected",
+			method:          http.MethodPost,
+			cookies:         []*http.Cookie{sessionCookie, csrfCookie},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "CSRF token missing",
+		},
+		{
+			name:            "missing cookie is rejected",
+			method:          http.MethodDelete,
+			cookies:         []*http.Cookie{sessionCookie},
+			headers:         map[string]string{CSRFHeader: "csrf-token-value"},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "CSRF token missing",
+		},
+		{
+			name:            "mismatched token is rejected",
+			method:          http.MethodPost,
+			cookies:         []*http.Cookie{{Name: RefreshTokenCookie, Value: "refresh"}, csrfCookie},
+			headers:         map[string]string{CSRFHeader: "forged"},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "does not match",
+		},
+		{
+			name:           "valid bearer token is exempt",
+			method:         http.MethodPost,
+			cookies:        []*http.Cookie{sessionCookie, csrfCookie},
+			headers:        map[string]string{"Authorization": "Bearer header_token"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "invalid bearer token is not exempt",
+			method:          http.MethodPost,
+			cookies:         []*http.Cookie{sessionCookie, csrfCookie},
+			headers:         map[string]string{"Authorization": "Bearer forged"},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "CSRF token missing",
+		},
+		{
+			name:           "authenticated API key is exempt",
+			method:         http.MethodPost,
+			path:           "/keyed",
+			cookies:        []*http.Cookie{sessionCookie},
+			headers:        map[string]string{APIKeyHeader: "bob_valid"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			// Routes without APIKeyAuth ignore the key, so the cookie still authenticates
+			name:            "unchecked API key header is not exempt",
+			method:          http.MethodPost,
+			cookies:         []*http.Cookie{sessionCookie, csrfCookie},
+			headers:         map[string]string{APIKeyHeader: "bogus"},
+			expectedStatus:  http.StatusForbidden,
+			expectedMessage: "CSRF token missing",
+		},
+		{
+			name:           "request without a session cookie is exempt",
+			method:         http.MethodPost,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "safe method passes",
+			method:         http.MethodGet,
+			cookies:        []*http.Cookie{sessionCookie},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	router := setupDoubleSubmitCSRFRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = "/resource"
+			}
+			req := httptest.NewRequest(tt.method, path, nil)
+			for _, cookie := range tt.cookies {
+				req.AddCookie(cookie)
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusForbidden {
+				var response dtos.ErrorResponseDTO
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				assert.Equal(t, dtos.ErrorCodeAuthCSRFTokenInvalid, response.ErrorCode)
+				assert.Contains(t, response.Message, tt.expectedMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ETag tags successful GET responses with a hash of their body and answers 304 Not Modified
+// when the request's If-None-Match already holds that tag. The handler still runs on every
+// request; what's saved is sending the body again.
+//
+// The tag is weak (W/"...") because Compression may change the bytes on the wire without
+// changing the response. Register it on individual GET routes, after authentication.
+func ETag() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet {
+			c.Next()
+			return
+		}
+
+		original := c.Writer
+		writer := &etagWriter{ResponseWriter: original}
+		c.Writer = writer
+		defer func() {
+			c.Writer = original
+		}()
+
+		c.Next()
+
+		if writer.passthrough {
+			return
+		}
+
+		body := writer.buffer.Bytes()
+		if writer.Status() != http.StatusOK || len(body) == 0 {
+			if len(body) > 0 {
+				_, _ = original.Write(body)
+			}
+			return
+		}
+
+		tag := responseETag(body)
+		original.Header().Set("ETag", tag)
+
+		if ETagMatches(c.GetHeader("If-None-Match"), tag) {
+			original.Header().Del("Content-Length")
+			original.WriteHeader(http.StatusNotModified)
+			original.WriteHeaderNow()
+			return
+		}
+
+		_, _ = original.Write(body)
+	}
+}
+
+// responseETag returns the weak entity tag for a response body
+func responseETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-None-Match header matches tag, using the weak comparison
+// RFC 9110 requires for If-None-Match. Handlers that derive a tag without rendering the response
+// use it to answer 304 before doing the work.
+func ETagMatches(header, tag string) bool {
+	if header == "" {
+		return false
+	}
+
+	opaque := strings.TrimPrefix(tag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == opaque {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWriter holds back the response body so it can be hashed before anything is sent.
+// If the handler sends headers or flushes early, the body is passed through untagged.
+type etagWriter struct {
+	gin.ResponseWriter
+	buffer      bytes.Buffer
+	passthrough bool
+}
+
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buffer.Write(data)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *etagWriter) WriteHeaderNow() {
+	w.release()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *etagWriter) Flush() {
+	w.release()
+	w.ResponseWriter.Flush()
+}
+
+// release gives up on tagging the response and writes out anything buffered
+func (w *etagWriter) release() {
+	if w.passthrough {
+		return
+	}
+	w.passthrough = true
+	if w.buffer.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.buffer.Bytes())
+		w.buffer.Reset()
+	}
+}
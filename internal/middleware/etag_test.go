@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// setupETagTestRouter serves a finance summary that tests can change between requests
+func setupETagTestRouter(summary *dtos.FinanceSummaryResponseDTO, middlewares ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(middlewares...)
+	r.GET("/summary", ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, summary)
+	})
+	r.GET("/missing", ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, dtos.NewSimpleErrorResponse(dtos.ErrorCodeNotFound, "not found"))
+	})
+	r.GET("/aborted", ETag(), func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusForbidden)
+	})
+	return r
+}
+
+func serveETagRequest(router *gin.Engine, path, ifNoneMatch string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestETag_ConditionalGet(t *testing.T) {
+	summary := &dtos.FinanceSummaryResponseDTO{
+		UserID:           "user-123",
+		MonthlyIncome:    5000,
+		DisposableIncome: 1200,
+		UpdatedAt:        time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	router := setupETagTestRouter(summary)
+
+	// First request returns the body and a tag
+	w := serveETagRequest(router, "/summary", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	tag := w.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	assert.Contains(t, w.Body.String(), `"monthly_income":5000`)
+
+	// Identical data gives the same tag
+	assert.Equal(t, tag, serveETagRequest(router, "/summary", "").Header().Get("ETag"))
+
+	// Re-requesting with the tag is answered with 304 and no body
+	w = serveETagRequest(router, "/summary", tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, tag, w.Header().Get("ETag"))
+
+	// Once the data changes the old tag no longer matches
+	summary.MonthlyIncome = 5500
+	summary.DisposableIncome = 1700
+	w = serveETagRequest(router, "/summary", tag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"monthly_income":5500`)
+	assert.NotEqual(t, tag, w.Header().Get("ETag"))
+}
+
+func TestETag_IfNoneMatchForms(t *testing.T) {
+	router := setupETagTestRouter(&dtos.FinanceSummaryResponseDTO{UserID: "user-123"})
+	tag := serveETagRequest(router, "/summary", "").Header().Get("ETag")
+	strong := tag[len("W/"):]
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{"exact", tag, http.StatusNotModified},
+		{"strong_form", strong, http.StatusNotModified},
+		{"in_list", `"other", ` + tag, http.StatusNotModified},
+		{"wildcard", "*", http.StatusNotModified},
+		{"stale", `W/"0123456789abcdef"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantStatus, serveETagRequest(router, "/summary", tt.ifNoneMatch).Code)
+		})
+	}
+}
+
+func TestETag_OnlyTagsSuccessfulResponses(t *testing.T) {
+	router := setupETagTestRouter(&dtos.FinanceSummaryResponseDTO{})
+
+	w := serveETagRequest(router, "/missing", "*")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), "NOT_FOUND")
+
+	w = serveETagRequest(router, "/aborted", "*")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+}
+
+func TestETag_WithCompression(t *testing.T) {
+	summary := largeHealthSummary()
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Compression(DefaultCompressionConfig()))
+	router.GET("/summary", ETag(), func(c *gin.Context) {
+		c.JSON(http.StatusOK, summary)
+	})
+
+	request := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/summary", nil)
+		req.Header.Set("Accept-Encoding", "gzip")
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := request("")
+	require.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	tag := w.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+	reader, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.NoError(t, err)
+
+	w = request(tag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Zero(t, w.Body.Len())
+}
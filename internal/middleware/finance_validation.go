@@ -1,6 +1,12 @@
 package middleware
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"strings"
 
@@ -32,6 +38,26 @@ func ValidateOwnership() gin.HandlerFunc {
 	}
 }
 
+// Request body size limits. The route groups apply the limit that suits their payloads;
+// bodies over the limit are rejected with a 413 before any JSON is decoded.
+const (
+	// AuthMaxRequestSize limits the auth endpoints, which only carry credentials and tokens (16KB)
+	AuthMaxRequestSize = 16 << 10
+	// DefaultMaxRequestSize limits the JSON API endpoints (1MB)
+	DefaultMaxRequestSize = 1 << 20
+	// ImportMaxRequestSize limits bulk imports such as CSV uploads (10MB)
+	ImportMaxRequestSize = 10 << 20
+)
+
+const (
+	// maxRequestSize is the largest financial payload ValidateFinancialData inspects
+	maxRequestSize = DefaultMaxRequestSize
+	// maxFinancialValue caps the magnitude of any number in a financial payload
+	maxFinancialValue = 1e12
+)
+
+var errRequestTooLarge = errors.New("request body too large")
+
 // ValidatePositiveAmount middleware validates that financial amounts are positive
 // This middleware reads JSON body and validates amount fields
 func ValidatePositiveAmount(field string) gin.HandlerFunc {
@@ -43,29 +69,39 @@ func ValidatePositiveAmount(field string) gin.HandlerFunc {
 	}
 }
 
-// NormalizeFrequency middleware ensures frequency values are valid and normalized
+// NormalizeFrequency middleware rewrites the frequency field of JSON bodies to its canonical value
+// (e.g. "Month" becomes "monthly") so the handler's DTO validation sees a standard frequency
 func NormalizeFrequency() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get the request body for processing
+		if !isJSONRequest(c) {
+			c.Next()
+			return
+		}
+
+		raw, err := peekRequestBody(c, maxRequestSize)
+		if err != nil {
+			// Oversized or unreadable bodies are rejected by ValidateFinancialData
+			c.Next()
+			return
+		}
+
 		var body map[string]interface{}
-		
-		// Only process if there's a JSON body
-		if c.Request.ContentLength > 0 && strings.Contains(c.GetHeader("Content-Type"), "application/json") {
-			if err := c.ShouldBindJSON(&body); err != nil {
-				// If we can't parse JSON, let the handler deal with it
-				c.Next()
-				return
-			}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			// If we can't parse JSON, let the handler deal with it
+			c.Next()
+			return
+		}
 
-			// Normalize frequency field if present
-			if freq, exists := body["frequency"]; exists {
-				if freqStr, ok := freq.(string); ok {
-					normalizedFreq := normalizeFrequencyValue(freqStr)
-					body["frequency"] = normalizedFreq
-					
-					// Store normalized body in context for handler use
-					c.Set("normalizedBody", body)
+		// Normalize frequency field if present
+		if freq, exists := body["frequency"]; exists {
+			if freqStr, ok := freq.(string); ok {
+				body["frequency"] = normalizeFrequencyValue(freqStr)
+				if normalized, err := json.Marshal(body); err == nil {
+					replaceRequestBody(c, normalized)
 				}
+
+				// Store normalized body in context for handler use
+				c.Set("normalizedBody", body)
 			}
 		}
 
@@ -92,61 +128,49 @@ func normalizeFrequencyValue(freq string) string {
 	}
 }
 
-// ValidateFinancialData middleware performs comprehensive validation on financial data
+// ValidateFinancialData middleware enforces cross-cutting limits on financial payloads:
+// the maximum body size and a sanity cap on every top-level number.
+// Field rules (required, positive amounts, frequencies, ...) are enforced by DTO validation
+// in the handlers, so a request only ever gets validation errors from one place.
 func ValidateFinancialData() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var body map[string]interface{}
-		
-		// Only validate JSON requests
-		if c.Request.ContentLength > 0 && strings.Contains(c.GetHeader("Content-Type"), "application/json") {
-			if err := c.ShouldBindJSON(&body); err != nil {
-				c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
-					http.StatusBadRequest,
-					"bad_request",
-					"Invalid JSON format",
-				))
-				c.Abort()
-				return
-			}
+		if !isJSONRequest(c) {
+			c.Next()
+			return
+		}
 
-			// Validate amount fields are positive
-			amountFields := []string{"amount", "balance", "monthly_payment", "interest_rate"}
-			for _, field := range amountFields {
-				if value, exists := body[field]; exists {
-					if amount, ok := value.(float64); ok {
-						if amount < 0 {
-							c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
-								http.StatusBadRequest,
-								"validation_error",
-								field+" must be positive",
-							))
-							c.Abort()
-							return
-						}
-					}
-				}
-			}
+		raw, err := peekRequestBody(c, maxRequestSize)
+		if errors.Is(err, errRequestTooLarge) {
+			abortPayloadTooLarge(c)
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"Unable to read request body",
+			))
+			c.Abort()
+			return
+		}
 
-			// Validate required string fields are not empty
-			stringFields := []string{"name", "description", "category", "source"}
-			for _, field := range stringFields {
-				if value, exists := body[field]; exists {
-					if str, ok := value.(string); ok {
-						if strings.TrimSpace(str) == "" {
-							c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
-								http.StatusBadRequest,
-								"validation_error",
-								field+" cannot be empty",
-							))
-							c.Abort()
-							return
-						}
-					}
-				}
-			}
+		var body map[string]interface{}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			// Malformed JSON is reported by the handler when it binds the DTO
+			c.Next()
+			return
+		}
 
-			// Store validated body for handler use
-			c.Set("validatedBody", body)
+		fields := make(map[string]any)
+		for field, value := range body {
+			if number, ok := value.(float64); ok && math.Abs(number) > maxFinancialValue {
+				fields[field] = fmt.Sprintf("%s must not exceed %.0f in magnitude", field, maxFinancialValue)
+			}
+		}
+		if len(fields) > 0 {
+			c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponse("Validation failed", fields))
+			c.Abort()
+			return
 		}
 
 		c.Next()
@@ -188,34 +212,60 @@ func ValidateUserOwnership(resourceType string) gin.HandlerFunc {
 	}
 }
 
-// ValidateRequestLimits validates request size and content limits
+// ValidateRequestLimits applies DefaultMaxRequestSize to every request body, whatever its Content-Type.
+// Routes that accept larger bodies, such as file uploads, are registered outside it with their own LimitRequestBody.
 func ValidateRequestLimits() gin.HandlerFunc {
+	return LimitRequestBody(DefaultMaxRequestSize)
+}
+
+// LimitRequestBody rejects bodies whose Content-Length is over limit bytes with a 413 and caps the rest
+// with http.MaxBytesReader, so a body can never be read past limit. Route groups use it to tighten the limit.
+func LimitRequestBody(limit int64) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Limit request size (1MB)
-		const maxRequestSize = 1 << 20 // 1MB
-		
-		if c.Request.ContentLength > maxRequestSize {
-			c.JSON(http.StatusRequestEntityTooLarge, dtos.NewErrorResponse(
-				http.StatusRequestEntityTooLarge,
-				"payload_too_large",
-				"Request payload too large",
-			))
-			c.Abort()
+		if c.Request.ContentLength > limit {
+			abortPayloadTooLarge(c)
+			return
+		}
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
 			return
 		}
 
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+		// A chunked body's size is only known once it is read; read it here so an oversized
+		// body is a 413 rather than a bind error in the handler
+		if c.Request.ContentLength <= 0 {
+			raw, err := io.ReadAll(c.Request.Body)
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abortPayloadTooLarge(c)
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+					http.StatusBadRequest,
+					"bad_request",
+					"Unable to read request body",
+				))
+				c.Abort()
+				return
+			}
+			replaceRequestBody(c, raw)
+		}
+
 		c.Next()
 	}
 }
 
-// GetValidatedBody retrieves the validated request body from context
-func GetValidatedBody(c *gin.Context) (map[string]interface{}, bool) {
-	if body, exists := c.Get("validatedBody"); exists {
-		if validBody, ok := body.(map[string]interface{}); ok {
-			return validBody, true
-		}
-	}
-	return nil, false
+// abortPayloadTooLarge writes the 413 response for an oversized request body
+func abortPayloadTooLarge(c *gin.Context) {
+	c.JSON(http.StatusRequestEntityTooLarge, dtos.NewErrorResponse(
+		http.StatusRequestEntityTooLarge,
+		"payload_too_large",
+		"Request payload too large",
+	))
+	c.Abort()
 }
 
 // GetNormalizedBody retrieves the normalized request body from context
@@ -249,4 +299,39 @@ func GetResourceInfo(c *gin.Context) (userID, resourceID, resourceType string) {
 	}
 	
 	return userID, resourceID, resourceType
-}
\ No newline at end of file
+}
+// isJSONRequest reports whether the request carries a JSON body
+func isJSONRequest(c *gin.Context) bool {
+	return c.Request.Body != nil &&
+		c.Request.ContentLength != 0 &&
+		strings.Contains(c.GetHeader("Content-Type"), "application/json")
+}
+
+// peekRequestBody reads up to limit bytes of the request body and puts them back
+// so handlers can still bind it. Bodies larger than limit return errRequestTooLarge.
+func peekRequestBody(c *gin.Context, limit int64) ([]byte, error) {
+	if c.Request.ContentLength > limit {
+		return nil, errRequestTooLarge
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, limit+1))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return nil, errRequestTooLarge
+	}
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, errRequestTooLarge
+	}
+
+	replaceRequestBody(c, raw)
+	return raw, nil
+}
+
+// replaceRequestBody swaps the request body for the given bytes
+func replaceRequestBody(c *gin.Context, raw []byte) {
+	c.Request.Body = io.NopCloser(bytes.NewReader(raw))
+	c.Request.ContentLength = int64(len(raw))
+}
@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+func setupFinanceValidationTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	// Echo the body the handler receives so tests can check it was not consumed
+	r.POST("/income", ValidateFinancialData(), NormalizeFrequency(), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	})
+
+	return r
+}
+
+func postJSON(router *gin.Engine, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/income", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestValidateFinancialData_LeavesFieldValidationToHandler(t *testing.T) {
+	// Arrange - negative amounts and empty strings are DTO concerns now
+	router := setupFinanceValidationTestRouter()
+	body := `{"source":"","amount":-100,"frequency":"monthly"}`
+
+	// Act
+	w := postJSON(router, body)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, body, w.Body.String())
+}
+
+func TestValidateFinancialData_NumericSanityCap_ReturnsValidationError(t *testing.T) {
+	// Arrange
+	router := setupFinanceValidationTestRouter()
+
+	// Act
+	w := postJSON(router, `{"source":"Salary","amount":1e15,"frequency":"monthly"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	assert.Contains(t, response.Fields, "amount")
+	assert.NotContains(t, response.Fields, "source")
+}
+
+func TestValidateFinancialData_OversizedBody_Returns413(t *testing.T) {
+	// Arrange
+	router := setupFinanceValidationTestRouter()
+	body := `{"source":"` + strings.Repeat("a", maxRequestSize) + `"}`
+
+	// Act
+	w := postJSON(router, body)
+
+	// Assert
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "payload_too_large", response.Error)
+}
+
+func TestValidateFinancialData_MalformedJSON_PassesToHandler(t *testing.T) {
+	router := setupFinanceValidationTestRouter()
+
+	w := postJSON(router, `{"source":`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `{"source":`, w.Body.String())
+}
+
+func TestNormalizeFrequency_RewritesBodyForHandler(t *testing.T) {
+	// Arrange
+	router := setupFinanceValidationTestRouter()
+
+	// Act
+	w := postJSON(router, `{"source":"Salary","amount":5000,"frequency":" Month "}`)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"source":"Salary","amount":5000,"frequency":"monthly"}`, w.Body.String())
+}
+
+func TestValidateRequestLimits_MultipartContentTypeDoesNotBypassLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(ValidateRequestLimits())
+	r.POST("/income", func(c *gin.Context) {
+		_, _ = io.ReadAll(c.Request.Body)
+		c.Status(http.StatusNoContent)
+	})
+	body := strings.Repeat("a", maxRequestSize+1)
+
+	for _, contentType := range []string{"application/json", "multipart/form-data; boundary=xyz"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/income", strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, contentType)
+	}
+}
+
+func setupLimitTestRouter(limit int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.POST("/login", LimitRequestBody(limit), func(c *gin.Context) {
+		body, _ := io.ReadAll(c.Request.Body)
+		c.Data(http.StatusOK, "application/json", body)
+	})
+	return r
+}
+
+func TestLimitRequestBody_RejectsBodyOverLimit(t *testing.T) {
+	router := setupLimitTestRouter(AuthMaxRequestSize)
+	body := `{"email":"user@example.com","password":"` + strings.Repeat("a", AuthMaxRequestSize) + `"}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "payload_too_large", response.Error)
+}
+
+func TestLimitRequestBody_RejectsChunkedBodyOverLimit(t *testing.T) {
+	router := setupLimitTestRouter(64)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/login", strings.NewReader(strings.Repeat("a", 65)))
+	req.ContentLength = -1 // Sent chunked, so the size is unknown until the body is read
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestLimitRequestBody_PassesBodyWithinLimit(t *testing.T) {
+	router := setupLimitTestRouter(64)
+	body := `{"email":"user@example.com"}`
+
+	for _, length := range []int64{int64(len(body)), -1} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", strings.NewReader(body))
+		req.ContentLength = length
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, body, w.Body.String())
+	}
+}
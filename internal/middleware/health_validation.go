@@ -1,60 +1,92 @@
 package middleware
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
-// ValidateHealthOwnership ensures users can only access their own health data
-func ValidateHealthOwnership() gin.HandlerFunc {
+// healthResourceNotFound is the response for each health resource that doesn't exist or
+// belongs to another user, matching what the health handlers return for it
+var healthResourceNotFound = map[string]dtos.SimpleErrorResponseDTO{
+	services.HealthResourceCondition: dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthConditionNotFound, "Condition not found"),
+	services.HealthResourceExpense:   dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthExpenseNotFound, "Medical expense not found"),
+	services.HealthResourcePolicy:    dtos.NewSimpleErrorResponse(dtos.ErrorCodeHealthPolicyNotFound, "Policy not found"),
+}
+
+// HealthOwnershipMiddleware ensures users can only access their own health records
+type HealthOwnershipMiddleware struct {
+	owners services.HealthResourceOwnerLookup
+}
+
+// NewHealthOwnershipMiddleware creates a health ownership middleware that looks owners up through owners
+func NewHealthOwnershipMiddleware(owners services.HealthResourceOwnerLookup) *HealthOwnershipMiddleware {
+	return &HealthOwnershipMiddleware{owners: owners}
+}
+
+// ValidateHealthResourceOwnership returns a Gin middleware for routes whose :id path parameter
+// names a health resource, such as services.HealthResourceCondition. It looks the resource's
+// owner up once and aborts with 404 unless it's the authenticated user, so another user's
+// records can't be told apart from records that don't exist.
+// It panics if resource isn't a health resource, so a mistyped route fails at startup.
+func (m *HealthOwnershipMiddleware) ValidateHealthResourceOwnership(resource string) gin.HandlerFunc {
+	notFound, ok := healthResourceNotFound[resource]
+	if !ok {
+		panic(fmt.Sprintf("middleware: unknown health resource %q", resource))
+	}
+
 	return func(c *gin.Context) {
-		// Get user ID from JWT context
-		userID, exists := c.Get("user_id")
-		if !exists {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		userID := authenticatedUserID(c)
+		if userID == "" {
+			c.JSON(http.StatusUnauthorized, dtos.NewSimpleErrorResponse(dtos.ErrorCodeAuthRequired, "Authentication required"))
 			c.Abort()
 			return
 		}
 
-		userIDStr, ok := userID.(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID format"})
+		resourceID := c.Param("id")
+		if resourceID == "" {
+			c.JSON(http.StatusBadRequest, dtos.NewSimpleErrorResponse(dtos.ErrorCodeValidationFailed, "Resource ID is required"))
 			c.Abort()
 			return
 		}
 
-		// For JSON requests, validate user_id in body matches authenticated user
-		if c.Request.Method == "POST" || c.Request.Method == "PUT" {
-			var requestBody map[string]interface{}
-			if err := c.ShouldBindJSON(&requestBody); err == nil {
-				if bodyUserID, exists := requestBody["user_id"]; exists {
-					if bodyUserIDStr, ok := bodyUserID.(string); ok {
-						if bodyUserIDStr != userIDStr {
-							c.JSON(http.StatusForbidden, gin.H{
-								"error": "Cannot perform action on another user's health data",
-							})
-							c.Abort()
-							return
-						}
-					}
-				}
-				
-				// Re-bind the JSON for the handler to use
-				c.Set("validated_request_body", requestBody)
-			}
+		ownerID, err := m.owners.GetResourceOwner(c.Request.Context(), resource, resourceID)
+		if err != nil && !isHealthResourceNotFound(err) {
+			c.JSON(http.StatusInternalServerError, dtos.NewSimpleErrorResponse(dtos.ErrorCodeInternal, "Failed to check resource ownership"))
+			c.Abort()
+			return
+		}
+		if err != nil || ownerID != userID {
+			c.JSON(http.StatusNotFound, notFound)
+			c.Abort()
+			return
 		}
 
-		// Store validated user ID for handler use
-		c.Set("validated_user_id", userIDStr)
+		// Store both IDs in context for handler use
+		c.Set("authenticatedUserID", userID)
+		c.Set("resourceID", resourceID)
+		c.Set("resourceType", resource)
 		c.Next()
 	}
 }
 
+// isHealthResourceNotFound reports whether err means a health resource doesn't exist
+func isHealthResourceNotFound(err error) bool {
+	return errors.Is(err, domain.ErrMedicalConditionNotFound) ||
+		errors.Is(err, domain.ErrMedicalExpenseNotFound) ||
+		errors.Is(err, domain.ErrInsurancePolicyNotFound)
+}
+
 // SanitizeSensitiveData removes sensitive health information from logs
 func SanitizeSensitiveData() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -70,8 +102,8 @@ func SanitizeSensitiveData() gin.HandlerFunc {
 			logger = zap.L()
 		}
 
-		// Sanitize logged data based on health endpoints
-		if strings.Contains(originalPath, "/health/") {
+		// Sanitize logged data based on health endpoints, and the personal data export that includes health data
+		if strings.Contains(originalPath, "/health/") || strings.HasSuffix(originalPath, "/me/export") {
 			// Remove sensitive fields from logging context
 			sanitizedFields := []zap.Field{
 				zap.String("path", originalPath),
@@ -80,8 +112,8 @@ func SanitizeSensitiveData() gin.HandlerFunc {
 			}
 
 			// Add user ID for audit purposes (but not sensitive health details)
-			if userID, exists := c.Get("validated_user_id"); exists {
-				sanitizedFields = append(sanitizedFields, zap.String("user_id", userID.(string)))
+			if userID := authenticatedUserID(c); userID != "" {
+				sanitizedFields = append(sanitizedFields, zap.String("user_id", userID))
 			}
 
 			// Log with sanitized fields only
@@ -104,35 +136,38 @@ func ValidateInsuranceDates() gin.HandlerFunc {
 		}
 
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" {
-			var requestBody map[string]interface{}
-			if err := c.ShouldBindJSON(&requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format"})
+			requestBody, err := peekJSONObject(c)
+			if errors.Is(err, errRequestTooLarge) {
+				abortPayloadTooLarge(c)
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
 			// Validate insurance policy dates
 			if err := validatePolicyDates(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
 			// Validate coverage percentage
 			if err := validateCoveragePercentage(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
 			// Validate deductible and out-of-pocket maximums
 			if err := validateInsuranceLimits(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
-			// Re-bind the validated JSON for the handler
 			c.Set("validated_request_body", requestBody)
 		}
 
@@ -140,6 +175,21 @@ func ValidateInsuranceDates() gin.HandlerFunc {
 	}
 }
 
+// peekJSONObject decodes the JSON object in the request body, leaving the body in place
+// for the handler to bind
+func peekJSONObject(c *gin.Context) (map[string]interface{}, error) {
+	raw, err := peekRequestBody(c, maxRequestSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var requestBody map[string]interface{}
+	if err := json.Unmarshal(raw, &requestBody); err != nil {
+		return nil, err
+	}
+	return requestBody, nil
+}
+
 // validatePolicyDates checks that start and end dates are logical
 func validatePolicyDates(requestBody map[string]interface{}) error {
 	startDateStr, hasStart := requestBody["start_date"]
@@ -168,19 +218,19 @@ func validatePolicyDates(requestBody map[string]interface{}) error {
 
 	// Validate date logic
 	if endDate.Before(startDate) {
-		return errors.New("policy end date cannot be before start date")
+		return dtos.NewFieldError("end_date", "policy end date cannot be before start date")
 	}
 
 	// Validate reasonable policy duration (not more than 10 years)
 	maxDuration := time.Hour * 24 * 365 * 10 // 10 years
 	if endDate.Sub(startDate) > maxDuration {
-		return errors.New("policy duration cannot exceed 10 years")
+		return dtos.NewFieldError("end_date", "policy duration cannot exceed 10 years")
 	}
 
 	// Validate policy doesn't start too far in the past (more than 5 years)
 	fiveYearsAgo := time.Now().AddDate(-5, 0, 0)
 	if startDate.Before(fiveYearsAgo) {
-		return errors.New("policy start date cannot be more than 5 years in the past")
+		return dtos.NewFieldError("start_date", "policy start date cannot be more than 5 years in the past")
 	}
 
 	return nil
@@ -199,7 +249,7 @@ func validateCoveragePercentage(requestBody map[string]interface{}) error {
 	}
 
 	if coverage < 0 || coverage > 100 {
-		return errors.New("coverage percentage must be between 0 and 100")
+		return dtos.NewFieldError("coverage_percentage", "coverage percentage must be between 0 and 100")
 	}
 
 	// Warn about unusually low coverage (less than 50%)
@@ -224,16 +274,16 @@ func validateInsuranceLimits(requestBody map[string]interface{}) error {
 		if deductibleOk && outOfPocketOk {
 			// Deductible should not exceed out-of-pocket maximum
 			if deductible > outOfPocketMax {
-				return errors.New("deductible cannot exceed out-of-pocket maximum")
+				return dtos.NewFieldError("deductible", "deductible cannot exceed out-of-pocket maximum")
 			}
 
 			// Validate reasonable limits (not more than $50,000 annually)
 			maxReasonableAmount := 50000.0
 			if deductible > maxReasonableAmount {
-				return errors.New("deductible amount seems unreasonably high")
+				return dtos.NewFieldError("deductible", "deductible amount seems unreasonably high")
 			}
 			if outOfPocketMax > maxReasonableAmount {
-				return errors.New("out-of-pocket maximum seems unreasonably high")
+				return dtos.NewFieldError("out_of_pocket_max", "out-of-pocket maximum seems unreasonably high")
 			}
 		}
 	}
@@ -244,7 +294,7 @@ func validateInsuranceLimits(requestBody map[string]interface{}) error {
 		premium, ok := premiumVal.(float64)
 		if ok {
 			if premium < 0 {
-				return errors.New("monthly premium cannot be negative")
+				return dtos.NewFieldError("monthly_premium", "monthly premium cannot be negative")
 			}
 			if premium > 2000 { // Reasonable upper limit for individual coverage
 				zap.L().Warn("High monthly premium detected",
@@ -266,9 +316,13 @@ func ValidateHealthProfileData() gin.HandlerFunc {
 		}
 
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" {
-			var requestBody map[string]interface{}
-			if err := c.ShouldBindJSON(&requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format"})
+			requestBody, err := peekJSONObject(c)
+			if errors.Is(err, errRequestTooLarge) {
+				abortPayloadTooLarge(c)
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
@@ -277,9 +331,8 @@ func ValidateHealthProfileData() gin.HandlerFunc {
 			if familySizeVal, exists := requestBody["family_size"]; exists {
 				if familySize, ok := familySizeVal.(float64); ok {
 					if familySize < 1 || familySize > 20 { // MAX_FAMILY_SIZE from env
-						c.JSON(http.StatusBadRequest, gin.H{
-							"error": "Family size must be between 1 and 20",
-						})
+						c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(
+							dtos.NewFieldError("family_size", "family size must be between 1 and 20")))
 						c.Abort()
 						return
 					}
@@ -288,7 +341,7 @@ func ValidateHealthProfileData() gin.HandlerFunc {
 
 			// Validate BMI if height and weight are provided
 			if err := validateBMIConsistency(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
@@ -297,9 +350,8 @@ func ValidateHealthProfileData() gin.HandlerFunc {
 			if fundVal, exists := requestBody["emergency_fund_health"]; exists {
 				if fund, ok := fundVal.(float64); ok {
 					if fund < 0 {
-						c.JSON(http.StatusBadRequest, gin.H{
-							"error": "Emergency fund cannot be negative",
-						})
+						c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(
+							dtos.NewFieldError("emergency_fund_health", "emergency fund cannot be negative")))
 						c.Abort()
 						return
 					}
@@ -333,7 +385,7 @@ func validateBMIConsistency(requestBody map[string]interface{}) error {
 
 			// Validate BMI is in reasonable range (10-100)
 			if bmi < 10 || bmi > 100 {
-				return errors.New("height and weight combination results in unrealistic BMI")
+				return dtos.NewFieldError("height", "height and weight combination results in unrealistic BMI")
 			}
 
 			// Log extreme BMI values for review
@@ -374,23 +426,27 @@ func ValidateExpenseData() gin.HandlerFunc {
 		}
 
 		if c.Request.Method == "POST" || c.Request.Method == "PUT" {
-			var requestBody map[string]interface{}
-			if err := c.ShouldBindJSON(&requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON format"})
+			requestBody, err := peekJSONObject(c)
+			if errors.Is(err, errRequestTooLarge) {
+				abortPayloadTooLarge(c)
+				return
+			}
+			if err != nil {
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
 			// Validate expense amounts are consistent
 			if err := validateExpenseAmounts(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
 
 			// Validate expense date is not in future
 			if err := validateExpenseDate(requestBody); err != nil {
-				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				c.JSON(http.StatusBadRequest, dtos.NewValidationErrorResponseFromError(err))
 				c.Abort()
 				return
 			}
@@ -414,10 +470,10 @@ func validateExpenseAmounts(requestBody map[string]interface{}) error {
 
 		if amountOk && insuranceOk {
 			if insurance > amount {
-				return errors.New("insurance payment cannot exceed total expense amount")
+				return dtos.NewFieldError("insurance_payment", "insurance payment cannot exceed total expense amount")
 			}
 			if insurance < 0 {
-				return errors.New("insurance payment cannot be negative")
+				return dtos.NewFieldError("insurance_payment", "insurance payment cannot be negative")
 			}
 		}
 	}
@@ -428,10 +484,10 @@ func validateExpenseAmounts(requestBody map[string]interface{}) error {
 
 		if amountOk && outOfPocketOk {
 			if outOfPocket > amount {
-				return errors.New("out-of-pocket amount cannot exceed total expense amount")
+				return dtos.NewFieldError("out_of_pocket", "out-of-pocket amount cannot exceed total expense amount")
 			}
 			if outOfPocket < 0 {
-				return errors.New("out-of-pocket amount cannot be negative")
+				return dtos.NewFieldError("out_of_pocket", "out-of-pocket amount cannot be negative")
 			}
 		}
 	}
@@ -457,7 +513,7 @@ func validateExpenseDate(requestBody map[string]interface{}) error {
 	}
 
 	if expenseDate.After(time.Now()) {
-		return errors.New("expense date cannot be in the future")
+		return dtos.NewFieldError("date", "expense date cannot be in the future")
 	}
 
 	// Warn about very old expenses (more than 5 years)
@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+func TestHealthValidation_LeavesBodyForHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		middleware gin.HandlerFunc
+		body       string
+	}{
+		{
+			name:       "profile",
+			path:       "/health/profile",
+			middleware: ValidateHealthProfileData(),
+			body:       `{"age":35,"gender":"male","height":175,"weight":80,"family_size":2}`,
+		},
+		{
+			name:       "expense",
+			path:       "/health/expenses",
+			middleware: ValidateExpenseData(),
+			body:       `{"amount":100,"insurance_payment":80,"date":"2024-01-15T00:00:00Z"}`,
+		},
+		{
+			name:       "insurance",
+			path:       "/health/insurance",
+			middleware: ValidateInsuranceDates(),
+			body:       `{"start_date":"2024-01-01T00:00:00Z","end_date":"2025-01-01T00:00:00Z","coverage_percentage":80}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange - echo the body the handler receives
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST(tt.path, tt.middleware, func(c *gin.Context) {
+				body, _ := io.ReadAll(c.Request.Body)
+				c.Data(http.StatusOK, "application/json", body)
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.JSONEq(t, tt.body, w.Body.String())
+		})
+	}
+}
+
+func TestHealthValidation_RejectsMalformedJSON(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/health/expenses", ValidateExpenseData(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	// Act
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/health/expenses", strings.NewReader(`{"amount": 100`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	var response dtos.ValidationErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "validation_error", response.Error)
+	assert.Equal(t, "Invalid JSON format", response.Message)
+}
+
+func TestHealthValidation_RuleFailuresNameTheField(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		middleware gin.HandlerFunc
+		body       string
+		field      string
+	}{
+		{
+			name:       "unrealistic BMI",
+			path:       "/health/profile",
+			middleware: ValidateHealthProfileData(),
+			body:       `{"age":35,"height":50,"weight":300}`,
+			field:      "height",
+		},
+		{
+			name:       "family size",
+			path:       "/health/profile",
+			middleware: ValidateHealthProfileData(),
+			body:       `{"age":35,"family_size":25}`,
+			field:      "family_size",
+		},
+		{
+			name:       "insurance payment over amount",
+			path:       "/health/expenses",
+			middleware: ValidateExpenseData(),
+			body:       `{"amount":100,"insurance_payment":150}`,
+			field:      "insurance_payment",
+		},
+		{
+			name:       "end before start",
+			path:       "/health/insurance",
+			middleware: ValidateInsuranceDates(),
+			body:       `{"start_date":"2025-01-01T00:00:00Z","end_date":"2024-01-01T00:00:00Z"}`,
+			field:      "end_date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST(tt.path, tt.middleware, func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			var response dtos.ValidationErrorResponseDTO
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, "validation_error", response.Error)
+			assert.Equal(t, []string{tt.field}, slices.Collect(maps.Keys(response.Fields)))
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header clients use to make retries safe
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayHeader is set on responses replayed from a stored result
+	IdempotentReplayHeader = "Idempotent-Replayed"
+	// DefaultIdempotencyTTL is how long a stored response is replayed for
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	maxIdempotencyKeyLength  = 255
+	idempotencyCleanupPeriod = time.Hour
+)
+
+// IdempotencyMiddleware replays the stored response for requests repeated with the same
+// Idempotency-Key header instead of executing them again
+type IdempotencyMiddleware struct {
+	store services.IdempotencyStore
+	ttl   time.Duration
+
+	// Cleanup ticker
+	cleanupTicker *time.Ticker
+	stopCleanup   chan bool
+}
+
+// NewIdempotencyMiddleware creates a new idempotency middleware and starts removing expired keys
+func NewIdempotencyMiddleware(store services.IdempotencyStore, ttl time.Duration) *IdempotencyMiddleware {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	m := &IdempotencyMiddleware{
+		store:       store,
+		ttl:         ttl,
+		stopCleanup: make(chan bool),
+	}
+
+	m.cleanupTicker = time.NewTicker(idempotencyCleanupPeriod)
+	go m.cleanup()
+
+	return m
+}
+
+// Idempotency returns a Gin middleware that makes a route safe to retry.
+// Requests without the header are passed through. For a key seen before:
+//   - the same request gets the original status and body replayed
+//   - a different request body gets 422
+//   - a request still being processed gets 409
+//
+// Responses with a 5xx status are not stored, so the client can retry with the same key.
+func (m *IdempotencyMiddleware) Idempotency() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimSpace(c.GetHeader(IdempotencyKeyHeader))
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"Idempotency-Key must be at most 255 characters",
+			))
+			c.Abort()
+			return
+		}
+
+		userID := authenticatedUserID(c)
+		if userID == "" {
+			c.JSON(http.StatusUnauthorized, dtos.NewErrorResponse(
+				http.StatusUnauthorized,
+				"unauthorized",
+				"Authentication required",
+			))
+			c.Abort()
+			return
+		}
+
+		raw, err := peekRequestBody(c, maxRequestSize)
+		if errors.Is(err, errRequestTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, dtos.NewErrorResponse(
+				http.StatusRequestEntityTooLarge,
+				"payload_too_large",
+				"Request payload too large",
+			))
+			c.Abort()
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusBadRequest, dtos.NewErrorResponse(
+				http.StatusBadRequest,
+				"bad_request",
+				"Unable to read request body",
+			))
+			c.Abort()
+			return
+		}
+
+		requestHash := hashIdempotentRequest(c, raw)
+		now := time.Now()
+		existing, reserved, err := m.store.Reserve(c.Request.Context(), &domain.IdempotencyRecord{
+			UserID:      userID,
+			Key:         key,
+			RequestHash: requestHash,
+			ExpiresAt:   now.Add(m.ttl),
+			CreatedAt:   now,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, dtos.NewErrorResponse(
+				http.StatusInternalServerError,
+				"internal_error",
+				"An internal error occurred. Please try again later",
+			))
+			c.Abort()
+			return
+		}
+
+		if !reserved {
+			m.respondWithExisting(c, existing, requestHash)
+			return
+		}
+
+		recorder := &idempotencyResponseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// The request context may already be cancelled once the handler has returned
+		ctx := context.Background()
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			_ = m.store.Release(ctx, userID, key)
+			return
+		}
+		if err := m.store.Complete(ctx, userID, key, status, recorder.Header().Get("Content-Type"), recorder.body.Bytes()); err != nil {
+			// Without a stored response the key must not block retries forever
+			_ = m.store.Release(ctx, userID, key)
+		}
+	}
+}
+
+// Stop stops the cleanup goroutine
+func (m *IdempotencyMiddleware) Stop() {
+	close(m.stopCleanup)
+}
+
+// respondWithExisting answers a request whose key is already held by another request
+func (m *IdempotencyMiddleware) respondWithExisting(c *gin.Context, existing *domain.IdempotencyRecord, requestHash string) {
+	switch {
+	case !existing.Matches(requestHash):
+		c.JSON(http.StatusUnprocessableEntity, dtos.NewErrorResponse(
+			http.StatusUnprocessableEntity,
+			"idempotency_key_reused",
+			"Idempotency-Key has already been used with a different request",
+		))
+	case !existing.Completed:
+		c.JSON(http.StatusConflict, dtos.NewErrorResponse(
+			http.StatusConflict,
+			"conflict",
+			"A request with this Idempotency-Key is still being processed",
+		))
+	default:
+		c.Header(IdempotentReplayHeader, "true")
+		c.Data(existing.StatusCode, existing.ContentType, existing.ResponseBody)
+	}
+	c.Abort()
+}
+
+// cleanup periodically removes expired idempotency keys
+func (m *IdempotencyMiddleware) cleanup() {
+	for {
+		select {
+		case <-m.cleanupTicker.C:
+			_, _ = m.store.DeleteExpired(context.Background(), time.Now())
+		case <-m.stopCleanup:
+			m.cleanupTicker.Stop()
+			return
+		}
+	}
+}
+
+// hashIdempotentRequest fingerprints the method, path and body of a request
+func hashIdempotentRequest(c *gin.Context, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Request.Method))
+	hash.Write([]byte(" "))
+	hash.Write([]byte(c.Request.URL.Path))
+	hash.Write([]byte("\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// idempotencyResponseRecorder copies the response body while it is written to the client
+type idempotencyResponseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *idempotencyResponseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *idempotencyResponseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,250 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories"
+)
+
+// idempotencyTestRow stands in for the rows a create endpoint persists
+type idempotencyTestRow struct {
+	ID   uint `gorm:"primarykey"`
+	Name string
+}
+
+func setupIdempotencyTestRouter(t *testing.T, handlerDelay time.Duration) (*gin.Engine, *gorm.DB) {
+	return setupIdempotencyTestRouterWithTTL(t, handlerDelay, time.Hour)
+}
+
+func openIdempotencyTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+	require.NoError(t, db.AutoMigrate(&models.IdempotencyKeyModel{}, &idempotencyTestRow{}))
+	return db
+}
+
+func setupIdempotencyTestRouterWithTTL(t *testing.T, handlerDelay, ttl time.Duration) (*gin.Engine, *gorm.DB) {
+	db := openIdempotencyTestDB(t)
+
+	idempotency := NewIdempotencyMiddleware(repositories.NewIdempotencyRepository(db), ttl)
+	t.Cleanup(idempotency.Stop)
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	r.POST("/expense", idempotency.Idempotency(), func(c *gin.Context) {
+		var body struct {
+			Name string `json:"name"`
+		}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
+			return
+		}
+		time.Sleep(handlerDelay)
+		row := idempotencyTestRow{Name: body.Name}
+		if err := db.Create(&row).Error; err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"id": row.ID, "name": row.Name})
+	})
+	r.POST("/fail", idempotency.Idempotency(), func(c *gin.Context) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "boom"})
+	})
+
+	return r, db
+}
+
+func postWithKey(router *gin.Engine, path, user, key, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Test-User", user)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func countIdempotencyTestRows(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&idempotencyTestRow{}).Count(&count).Error)
+	return count
+}
+
+func TestIdempotency_RetryReplaysOriginalResponse(t *testing.T) {
+	// Arrange
+	router, db := setupIdempotencyTestRouter(t, 0)
+	body := `{"name":"Rent"}`
+
+	// Act
+	first := postWithKey(router, "/expense", "user-1", "key-1", body)
+	second := postWithKey(router, "/expense", "user-1", "key-1", body)
+
+	// Assert
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Empty(t, first.Header().Get(IdempotentReplayHeader))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayHeader))
+	assert.Equal(t, int64(1), countIdempotencyTestRows(t, db))
+}
+
+func TestIdempotency_ExpiredKey_ExecutesAgain(t *testing.T) {
+	// Arrange
+	router, db := setupIdempotencyTestRouterWithTTL(t, 0, 20*time.Millisecond)
+	body := `{"name":"Rent"}`
+
+	// Act
+	first := postWithKey(router, "/expense", "user-1", "key-1", body)
+	time.Sleep(40 * time.Millisecond)
+	second := postWithKey(router, "/expense", "user-1", "key-1", body)
+
+	// Assert - once the window has passed the key is free to be used again
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotentReplayHeader))
+	assert.Equal(t, int64(2), countIdempotencyTestRows(t, db))
+}
+
+func TestIdempotency_ConcurrentDuplicates_CreateOneRow(t *testing.T) {
+	// Arrange - the handler is slow enough that both requests are in flight together
+	router, db := setupIdempotencyTestRouter(t, 50*time.Millisecond)
+	body := `{"name":"Rent"}`
+
+	var wg sync.WaitGroup
+	responses := make([]*httptest.ResponseRecorder, 2)
+	start := make(chan struct{})
+	for i := range responses {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			responses[i] = postWithKey(router, "/expense", "user-1", "key-1", body)
+		}(i)
+	}
+
+	// Act
+	close(start)
+	wg.Wait()
+
+	// Assert - one request ran the handler; the other was told it is in progress or got the replay
+	assert.Equal(t, int64(1), countIdempotencyTestRows(t, db))
+	created := 0
+	for _, w := range responses {
+		switch w.Code {
+		case http.StatusCreated:
+			created++
+		case http.StatusConflict:
+		default:
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+	}
+	assert.GreaterOrEqual(t, created, 1)
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody_Returns422(t *testing.T) {
+	router, db := setupIdempotencyTestRouter(t, 0)
+
+	first := postWithKey(router, "/expense", "user-1", "key-1", `{"name":"Rent"}`)
+	second := postWithKey(router, "/expense", "user-1", "key-1", `{"name":"Groceries"}`)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, second.Code)
+
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(second.Body.Bytes(), &response))
+	assert.Equal(t, "idempotency_key_reused", response.Error)
+	assert.Equal(t, int64(1), countIdempotencyTestRows(t, db))
+}
+
+func TestIdempotency_KeysAreScopedPerUser(t *testing.T) {
+	router, db := setupIdempotencyTestRouter(t, 0)
+	body := `{"name":"Rent"}`
+
+	first := postWithKey(router, "/expense", "user-1", "key-1", body)
+	second := postWithKey(router, "/expense", "user-2", "key-1", body)
+
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotentReplayHeader))
+	assert.Equal(t, int64(2), countIdempotencyTestRows(t, db))
+}
+
+func TestIdempotency_WithoutKey_IsNotDeduplicated(t *testing.T) {
+	router, db := setupIdempotencyTestRouter(t, 0)
+
+	for i := 0; i < 2; i++ {
+		w := postWithKey(router, "/expense", "user-1", "", fmt.Sprintf(`{"name":"Rent %d"}`, i))
+		assert.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	assert.Equal(t, int64(2), countIdempotencyTestRows(t, db))
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	router, _ := setupIdempotencyTestRouter(t, 0)
+
+	first := postWithKey(router, "/fail", "user-1", "key-1", `{}`)
+	second := postWithKey(router, "/fail", "user-1", "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusInternalServerError, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotentReplayHeader))
+}
+
+func TestIdempotency_TimedOutRequestReleasesKey(t *testing.T) {
+	// Arrange
+	gin.SetMode(gin.TestMode)
+	// The timeout is logged through the global logger
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}))
+	db := openIdempotencyTestDB(t)
+	idempotency := NewIdempotencyMiddleware(repositories.NewIdempotencyRepository(db), time.Hour)
+	t.Cleanup(idempotency.Stop)
+
+	handlerDelay := 50 * time.Millisecond
+	r := gin.New()
+	r.Use(Timeout(20*time.Millisecond), func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-Test-User"))
+		c.Next()
+	})
+	r.POST("/expense", idempotency.Idempotency(), func(c *gin.Context) {
+		// The handler finishes after the deadline without watching the context
+		time.Sleep(handlerDelay)
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+	})
+
+	// Act
+	first := postWithKey(r, "/expense", "user-1", "key-1", `{"name":"Rent"}`)
+	handlerDelay = 0
+	second := postWithKey(r, "/expense", "user-1", "key-1", `{"name":"Rent"}`)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Empty(t, second.Header().Get(IdempotentReplayHeader))
+}
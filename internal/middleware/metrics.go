@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	metricsNamespace = "buyorbye"
+	// unmatchedRoute labels requests that didn't match a route, so unknown URLs
+	// can't blow up the number of series
+	unmatchedRoute = "unmatched"
+)
+
+// MetricsConfig holds configuration for the Prometheus metrics middleware
+type MetricsConfig struct {
+	// SkipPaths contains request paths that aren't recorded (e.g., health checks, /metrics itself)
+	SkipPaths []string
+}
+
+// DefaultMetricsConfig returns a sensible default configuration
+func DefaultMetricsConfig() MetricsConfig {
+	return MetricsConfig{
+		SkipPaths: []string{
+			"/health",
+			"/health/live",
+			"/health/ready",
+			"/metrics",
+		},
+	}
+}
+
+// HTTPMetrics records Prometheus metrics for HTTP requests
+// Each instance has its own registry so metrics never leak between routers or tests
+type HTTPMetrics struct {
+	registry *prometheus.Registry
+	skip     map[string]bool
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+}
+
+// NewHTTPMetrics creates the request metrics and registers them, along with the
+// Go runtime and process collectors, on a new registry
+func NewHTTPMetrics(config MetricsConfig) *HTTPMetrics {
+	m := &HTTPMetrics{
+		registry: prometheus.NewRegistry(),
+		skip:     make(map[string]bool, len(config.SkipPaths)),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_total",
+			Help:      "Total number of HTTP requests by method, route and status.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		inFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "http_requests_in_flight",
+			Help:      "Number of HTTP requests currently being served.",
+		}),
+	}
+
+	for _, path := range config.SkipPaths {
+		m.skip[path] = true
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.inFlight,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Metrics returns a Gin middleware that records request count, duration and
+// in-flight requests. Requests are labeled by route template (e.g. /api/v1/finance/income/:id)
+// rather than the raw URL path.
+func (m *HTTPMetrics) Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m.skip[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		start := time.Now()
+		m.inFlight.Inc()
+		defer m.inFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.duration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Handler returns a Gin handler that serves the collected metrics in the Prometheus text format
+func (m *HTTPMetrics) Handler() gin.HandlerFunc {
+	h := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	return gin.WrapH(h)
+}
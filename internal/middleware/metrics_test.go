@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMetricsTestRouter() (*gin.Engine, *HTTPMetrics) {
+	gin.SetMode(gin.TestMode)
+	metrics := NewHTTPMetrics(DefaultMetricsConfig())
+
+	r := gin.New()
+	r.Use(metrics.Metrics())
+	r.GET("/metrics", metrics.Handler())
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.GET("/income/:id", func(c *gin.Context) {
+		if c.Param("id") == "missing" {
+			c.JSON(http.StatusNotFound, gin.H{"error": "not found"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+
+	return r, metrics
+}
+
+func TestMetrics_CountsRequestsByRouteAndStatus(t *testing.T) {
+	router, metrics := setupMetricsTestRouter()
+
+	for _, path := range []string{"/income/1", "/income/2", "/income/missing", "/unknown", "/health"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(w, req)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.requests.WithLabelValues("GET", "/income/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("GET", "/income/:id", "404")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.requests.WithLabelValues("GET", unmatchedRoute, "404")))
+	// /health is a skip path, and raw URLs never become labels
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.requests))
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.duration))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.inFlight))
+}
+
+func TestMetrics_HandlerExposesPrometheusFormat(t *testing.T) {
+	router, _ := setupMetricsTestRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/income/1", nil)
+	router.ServeHTTP(w, req)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/metrics", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	assert.Contains(t, w.Body.String(), `buyorbye_http_requests_total{method="GET",route="/income/:id",status="200"} 1`)
+	assert.Contains(t, w.Body.String(), "buyorbye_http_request_duration_seconds_bucket")
+	assert.Contains(t, w.Body.String(), "buyorbye_http_requests_in_flight 0")
+	// Scraping /metrics isn't recorded itself
+	assert.NotContains(t, w.Body.String(), `route="/metrics"`)
+}
@@ -1,21 +1,11 @@
 package middleware
 
 import (
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	
 	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
-// CORS returns a CORS middleware with default configuration
-func CORS() gin.HandlerFunc {
-	config := cors.DefaultConfig()
-	config.AllowAllOrigins = true
-	config.AllowHeaders = []string{"*"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	return cors.New(config)
-}
-
 // Logger returns Gin's default logger middleware
 func Logger() gin.HandlerFunc {
 	return gin.Logger()
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 )
 
 // RateLimitConfig holds configuration for rate limiting
@@ -49,7 +50,7 @@ func DefaultRateLimitConfig() RateLimitConfig {
 		Window:   15 * time.Minute,
 		KeyFunc: func(c *gin.Context) string {
 			// Use client IP as the key
-			return c.ClientIP()
+			return logging.GetClientIP(c)
 		},
 		Skip: nil, // No skip function by default
 	}
@@ -229,7 +230,7 @@ func NewLoginRateLimiter() *InMemoryRateLimiter {
 	config.KeyFunc = func(c *gin.Context) string {
 		// For login attempts, we might want to combine IP and email for more granular limiting
 		// But for simplicity, we'll stick with IP-based limiting
-		return c.ClientIP()
+		return logging.GetClientIP(c)
 	}
 	
 	return NewInMemoryRateLimiter(config)
@@ -242,11 +243,12 @@ func NewAPIRateLimiter() *InMemoryRateLimiter {
 		Requests: 100,
 		Window:   1 * time.Minute,
 		KeyFunc: func(c *gin.Context) string {
-			return c.ClientIP()
+			return logging.GetClientIP(c)
 		},
 		Skip: func(c *gin.Context) bool {
 			// Skip rate limiting for health check endpoints
-			return c.Request.URL.Path == "/health" || c.Request.URL.Path == "/ping"
+			path := c.Request.URL.Path
+			return path == "/health" || path == "/health/live" || path == "/health/ready" || path == "/ping"
 		},
 	}
 	
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
 )
 
 func setupRateLimitTestRouter(rateLimiter *InMemoryRateLimiter) *gin.Engine {
@@ -177,6 +178,56 @@ func TestInMemoryRateLimiter_DifferentIPs_IndependentLimits(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w2.Code)
 }
 
+func TestLoginRateLimiter_KeysByResolvedClientIP(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   []string
+		wantLimited    bool
+	}{
+		// Rotating a spoofed X-Forwarded-For doesn't get an untrusted client a fresh limit
+		{"spoofed header from untrusted peer", nil, "203.0.113.7:52100", []string{"198.51.100.1", "198.51.100.2"}, true},
+		// Behind the load balancer every client has its own limit
+		{"clients through trusted proxy", []string{"10.0.0.0/8"}, "10.0.0.2:41000", []string{"198.51.100.1, 10.0.0.9", "198.51.100.2, 10.0.0.9"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			gin.SetMode(gin.TestMode)
+			config := DefaultRateLimitConfig()
+			config.Requests = 1
+			rateLimiter := NewInMemoryRateLimiter(config)
+			defer rateLimiter.Close()
+
+			r := gin.New()
+			require.NoError(t, r.SetTrustedProxies(tt.trustedProxies))
+			r.Use(logging.ClientIPMiddleware(), rateLimiter.RateLimit())
+			r.POST("/login", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+			// Act
+			var codes []int
+			for _, forwardedFor := range tt.forwardedFor {
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("POST", "/login", nil)
+				req.RemoteAddr = tt.remoteAddr
+				req.Header.Set("X-Forwarded-For", forwardedFor)
+				r.ServeHTTP(w, req)
+				codes = append(codes, w.Code)
+			}
+
+			// Assert
+			assert.Equal(t, http.StatusOK, codes[0])
+			if tt.wantLimited {
+				assert.Equal(t, http.StatusTooManyRequests, codes[1])
+			} else {
+				assert.Equal(t, http.StatusOK, codes[1])
+			}
+		})
+	}
+}
+
 func TestInMemoryRateLimiter_WindowReset_AllowsNewRequests(t *testing.T) {
 	// Arrange
 	config := RateLimitConfig{
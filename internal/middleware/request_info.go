@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// RequestInfo stores the request ID, client IP and user agent in the request context so
+// services can attribute audit log entries. It must run after logging.RequestIDMiddleware and
+// logging.ClientIPMiddleware; RequireAuth adds the authenticated user.
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		info := services.RequestInfo{
+			RequestID: logging.GetRequestID(c),
+			IPAddress: logging.GetClientIP(c),
+			UserAgent: c.Request.UserAgent(),
+		}
+		c.Request = c.Request.WithContext(services.WithRequestInfo(c.Request.Context(), info))
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+func TestRequestInfo_StoresRequestAndUserInContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	jwtService := &MockJWTService{}
+	claims := &domain.TokenClaims{UserID: "user-1", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	jwtService.On("ValidateAccessToken", "valid-token").Return(claims, nil)
+
+	var info services.RequestInfo
+	r := gin.New()
+	r.Use(logging.RequestIDMiddleware(), RequestInfo())
+	r.GET("/protected", NewJWTAuthMiddleware(jwtService).RequireAuth(), func(c *gin.Context) {
+		info = services.RequestInfoFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer valid-token")
+	req.Header.Set("X-Request-ID", "req-1")
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.RemoteAddr = "203.0.113.7:52100"
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, services.RequestInfo{
+		RequestID: "req-1",
+		UserID:    "user-1",
+		IPAddress: "203.0.113.7",
+		UserAgent: "curl/8.0",
+	}, info)
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// Cookie session names. The token cookies are HttpOnly; the CSRF cookie is readable by scripts,
+// which copy it into the CSRF header.
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CSRFCookie         = "csrf_token"
+	CSRFHeader         = "X-CSRF-Token"
+)
+
+// SessionCookiesConfig holds the attributes of the cookie session cookies
+type SessionCookiesConfig struct {
+	Secure   bool
+	Domain   string
+	SameSite http.SameSite
+	// RefreshPath limits the refresh token cookie to the auth routes, the only ones that read it
+	RefreshPath     string
+	RefreshTokenTTL time.Duration
+}
+
+// SessionCookies sets and clears the cookies of a cookie session: the access token, the refresh
+// token and the CSRF token that DoubleSubmitCSRF checks state-changing requests against
+type SessionCookies struct {
+	config SessionCookiesConfig
+}
+
+// NewSessionCookies creates the cookie session helper. SameSite defaults to strict and
+// RefreshPath to "/".
+func NewSessionCookies(config SessionCookiesConfig) *SessionCookies {
+	if config.SameSite == 0 || config.SameSite == http.SameSiteDefaultMode {
+		config.SameSite = http.SameSiteStrictMode
+	}
+	if config.RefreshPath == "" {
+		config.RefreshPath = "/"
+	}
+	return &SessionCookies{config: config}
+}
+
+// Set starts or renews a cookie session with tokens. The access token cookie expires with the
+// token, and each call issues a new CSRF token.
+func (s *SessionCookies) Set(c *gin.Context, tokens *domain.TokenPair) error {
+	csrfToken, err := newCSRFToken()
+	if err != nil {
+		return err
+	}
+
+	refreshMaxAge := int(s.config.RefreshTokenTTL / time.Second)
+	s.write(c, AccessTokenCookie, tokens.AccessToken, "/", int(tokens.ExpiresIn), true)
+	s.write(c, RefreshTokenCookie, tokens.RefreshToken, s.config.RefreshPath, refreshMaxAge, true)
+	s.write(c, CSRFCookie, csrfToken, "/", refreshMaxAge, false)
+	return nil
+}
+
+// Clear ends the cookie session by expiring its cookies
+func (s *SessionCookies) Clear(c *gin.Context) {
+	s.write(c, AccessTokenCookie, "", "/", -1, true)
+	s.write(c, RefreshTokenCookie, "", s.config.RefreshPath, -1, true)
+	s.write(c, CSRFCookie, "", "/", -1, false)
+}
+
+// RefreshToken returns the refresh token cookie sent with the request, or "" without one
+func (s *SessionCookies) RefreshToken(c *gin.Context) string {
+	token, err := c.Cookie(RefreshTokenCookie)
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+func (s *SessionCookies) write(c *gin.Context, name, value, path string, maxAge int, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   s.config.Domain,
+		MaxAge:   maxAge,
+		Secure:   s.config.Secure,
+		HttpOnly: httpOnly,
+		SameSite: s.config.SameSite,
+	})
+}
+
+// newCSRFToken returns 32 random bytes, base64url encoded
+func newCSRFToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func responseCookies(w *httptest.ResponseRecorder) map[string]*http.Cookie {
+	cookies := make(map[string]*http.Cookie)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func TestSessionCookies_Set(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sessionCookies := NewSessionCookies(SessionCookiesConfig{
+		Secure:          true,
+		RefreshPath:     "/api/v1/auth",
+		RefreshTokenTTL: 7 * 24 * time.Hour,
+	})
+	tokens := &domain.TokenPair{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	require.NoError(t, sessionCookies.Set(c, tokens))
+
+	cookies := responseCookies(w)
+	require.Len(t, cookies, 3)
+
+	access := cookies[AccessTokenCookie]
+	assert.Equal(t, "access", access.Value)
+	assert.Equal(t, "/", access.Path)
+	assert.Equal(t, 900, access.MaxAge)
+	assert.True(t, access.HttpOnly)
+
+	refresh := cookies[RefreshTokenCookie]
+	assert.Equal(t, "refresh", refresh.Value)
+	assert.Equal(t, "/api/v1/auth", refresh.Path)
+	assert.Equal(t, 7*24*60*60, refresh.MaxAge)
+	assert.True(t, refresh.HttpOnly)
+
+	// Scripts must read the CSRF token to echo it in the header
+	csrf := cookies[CSRFCookie]
+	assert.NotEmpty(t, csrf.Value)
+	assert.False(t, csrf.HttpOnly)
+
+	for _, cookie := range cookies {
+		assert.True(t, cookie.Secure, cookie.Name)
+		assert.Equal(t, http.SameSiteStrictMode, cookie.SameSite, cookie.Name)
+	}
+
+	// Each session gets a new CSRF token
+	w2 := httptest.NewRecorder()
+	c2, _ := gin.CreateTestContext(w2)
+	require.NoError(t, sessionCookies.Set(c2, tokens))
+	assert.NotEqual(t, csrf.Value, responseCookies(w2)[CSRFCookie].Value)
+}
+
+func TestSessionCookies_Clear(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sessionCookies := NewSessionCookies(SessionCookiesConfig{RefreshPath: "/api/v1/auth"})
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	sessionCookies.Clear(c)
+
+	cookies := responseCookies(w)
+	require.Len(t, cookies, 3)
+	for _, cookie := range cookies {
+		assert.Empty(t, cookie.Value, cookie.Name)
+		assert.Negative(t, cookie.MaxAge, cookie.Name)
+	}
+	assert.Equal(t, "/api/v1/auth", cookies[RefreshTokenCookie].Path)
+}
@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// Timeout gives each request a deadline d from now, so services and repositories using the
+// request context abort once it passes, and responds 503 at the deadline if the handler is
+// still running. The handler runs on its own goroutine and writes into a buffer that is sent
+// once it returns, so the 503 goes out on time even when the handler ignores its context, and
+// nothing it writes after the deadline, such as an error from a cancelled query, reaches the
+// client. A handler that flushes has started its response, which is then left as is.
+// Timeout still waits for the handler to return before returning itself, as gin reuses the
+// context afterwards. A non-positive d disables it.
+func Timeout(d time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if d <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), d)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		logger := logging.ContextLogger(c)
+
+		original := c.Writer
+		writer := newTimeoutWriter(original)
+		c.Writer = writer
+
+		done := make(chan struct{})
+		var panicked any
+		go func() {
+			defer func() {
+				panicked = recover()
+				close(done)
+			}()
+			c.Next()
+		}()
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && writer.timeOut() {
+			logger.Warn("Request timed out",
+				logging.WithComponent("middleware"),
+				zap.Duration("timeout", d))
+			writeTimeoutResponse(original)
+		}
+
+		<-done
+		c.Writer = original
+		if panicked != nil {
+			panic(panicked)
+		}
+		writer.commit()
+	}
+}
+
+// writeTimeoutResponse sends the 503 with its length and flushes it, so the client has the
+// whole response while the handler is still running
+func writeTimeoutResponse(w gin.ResponseWriter) {
+	body, _ := json.Marshal(dtos.NewCodedErrorResponse(
+		http.StatusServiceUnavailable,
+		dtos.ErrorCodeTimeout,
+		"The request took too long to process; please try again",
+	))
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write(body)
+	w.Flush()
+}
+
+// timeoutWriter buffers the handler's response until the handler returns or flushes. Once
+// timed out it drops whatever the handler writes and reports the 503 Timeout sent, so
+// wrapping middleware such as Idempotency never records the status the handler set instead.
+type timeoutWriter struct {
+	gin.ResponseWriter
+
+	mu        sync.Mutex
+	header    http.Header
+	status    int
+	wroteNow  bool // WriteHeaderNow was called
+	body      bytes.Buffer
+	committed bool // the response was flushed; writes now go straight through
+	timedOut  bool
+}
+
+func newTimeoutWriter(w gin.ResponseWriter) *timeoutWriter {
+	return &timeoutWriter{ResponseWriter: w, header: w.Header().Clone(), status: http.StatusOK}
+}
+
+// timeOut marks the writer timed out unless the response was already committed, and reports
+// whether it did
+func (w *timeoutWriter) timeOut() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.committed {
+		return false
+	}
+	w.timedOut = true
+	return true
+}
+
+// commit sends the buffered response, unless the request timed out or it was already sent
+func (w *timeoutWriter) commit() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.commitLocked()
+}
+
+func (w *timeoutWriter) commitLocked() {
+	if w.committed || w.timedOut {
+		return
+	}
+	w.committed = true
+
+	header := w.ResponseWriter.Header()
+	for key := range header {
+		if _, ok := w.header[key]; !ok {
+			header.Del(key)
+		}
+	}
+	for key, values := range w.header {
+		header[key] = values
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.wroteNow {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	if w.body.Len() > 0 {
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+		w.body.Reset()
+	}
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.committed {
+		return w.ResponseWriter.Header()
+	}
+	return w.header
+}
+
+func (w *timeoutWriter) Status() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.timedOut:
+		return http.StatusServiceUnavailable
+	case w.committed:
+		return w.ResponseWriter.Status()
+	}
+	return w.status
+}
+
+func (w *timeoutWriter) Size() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.committed {
+		return w.ResponseWriter.Size()
+	}
+	if !w.wroteNow && w.body.Len() == 0 {
+		return -1
+	}
+	return w.body.Len()
+}
+
+func (w *timeoutWriter) Written() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.committed {
+		return w.ResponseWriter.Written()
+	}
+	return w.timedOut || w.wroteNow || w.body.Len() > 0
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.timedOut:
+	case w.committed:
+		w.ResponseWriter.WriteHeader(code)
+	case !w.wroteNow && w.body.Len() == 0:
+		w.status = code
+	}
+}
+
+func (w *timeoutWriter) WriteHeaderNow() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.timedOut:
+	case w.committed:
+		w.ResponseWriter.WriteHeaderNow()
+	default:
+		w.wroteNow = true
+	}
+}
+
+func (w *timeoutWriter) Write(data []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.timedOut:
+		return 0, http.ErrHandlerTimeout
+	case w.committed:
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *timeoutWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush sends what has been written so far and lets the rest of the response through as it is
+// written, as streamed exports expect
+func (w *timeoutWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return
+	}
+	w.commitLocked()
+	w.ResponseWriter.Flush()
+}
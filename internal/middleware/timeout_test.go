@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+func TestTimeout_SlowHandler_Returns503AndCancelsContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// The timeout is logged through the global logger
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}))
+	var handlerErr error
+	r := gin.New()
+	r.Use(logging.RequestIDMiddleware(), Timeout(20*time.Millisecond))
+	r.GET("/slow", func(c *gin.Context) {
+		// Stands in for a repository call that aborts when its context is cancelled
+		select {
+		case <-c.Request.Context().Done():
+			handlerErr = c.Request.Context().Err()
+		case <-time.After(time.Second):
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "query cancelled"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/slow", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	r.ServeHTTP(w, req)
+
+	assert.ErrorIs(t, handlerErr, context.DeadlineExceeded)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "req-1", w.Header().Get("X-Request-ID"))
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, dtos.ErrorCodeTimeout, response.ErrorCode)
+}
+
+func TestTimeout_FastHandler_PassesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var deadline time.Time
+	r := gin.New()
+	r.Use(Timeout(time.Second))
+	r.GET("/fast", func(c *gin.Context) {
+		deadline, _ = c.Request.Context().Deadline()
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/fast", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestTimeout_HandlerIgnoringContext_Gets503AtDeadline(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}))
+	r := gin.New()
+	r.Use(Timeout(50 * time.Millisecond))
+	r.GET("/stuck", func(c *gin.Context) {
+		// Stands in for a call that never looks at the request context
+		time.Sleep(time.Second)
+		c.JSON(http.StatusOK, gin.H{"status": "too late"})
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	start := time.Now()
+	resp, err := http.Get(server.URL + "/stuck")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Less(t, elapsed, 500*time.Millisecond, "the 503 is sent at the deadline, not when the handler returns")
+	var response dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(body, &response))
+	assert.Equal(t, dtos.ErrorCodeTimeout, response.ErrorCode)
+}
+
+func TestTimeout_WritesBeforeDeadlineAreDiscarded(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}))
+	r := gin.New()
+	r.Use(Timeout(20 * time.Millisecond))
+	r.GET("/slow", func(c *gin.Context) {
+		c.Header("X-Partial", "yes")
+		c.String(http.StatusOK, "partial")
+		time.Sleep(50 * time.Millisecond)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/slow", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.NotContains(t, w.Body.String(), "partial")
+	assert.Empty(t, w.Header().Get("X-Partial"))
+}
+
+func TestTimeout_HandlerPanic_ReachesRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, recovered any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}), Timeout(time.Second))
+	r.GET("/panic", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/panic", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestTimeout_ResponseFlushedBeforeDeadline_IsKept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Timeout(20 * time.Millisecond))
+	r.GET("/stream", func(c *gin.Context) {
+		c.String(http.StatusOK, "partial")
+		c.Writer.Flush()
+		<-c.Request.Context().Done()
+		c.Writer.WriteString(" rest")
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/stream", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "partial rest", w.Body.String())
+}
+
+func TestTimeout_Disabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hasDeadline := true
+	r := gin.New()
+	r.Use(Timeout(0))
+	r.GET("/", func(c *gin.Context) {
+		_, hasDeadline = c.Request.Context().Deadline()
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.False(t, hasDeadline)
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyModel represents the api_keys table structure in the database
+// Scopes is stored as a comma-separated list; revoked keys are kept so they can be listed
+type APIKeyModel struct {
+	ID         string     `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID     string     `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	Name       string     `gorm:"not null;type:varchar(100)" json:"name"`
+	HashedKey  string     `gorm:"not null;uniqueIndex;type:varchar(64)" json:"-"`
+	Prefix     string     `gorm:"not null;type:varchar(16)" json:"prefix"`
+	Scopes     string     `gorm:"not null;type:varchar(255)" json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"not null" json:"created_at"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+// BeforeCreate sets the ID if not provided
+func (k *APIKeyModel) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = "key-" + uuid.New().String()
+	}
+	if k.CreatedAt.IsZero() {
+		k.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ToDomain converts APIKeyModel to domain.APIKey
+func (k APIKeyModel) ToDomain() domain.APIKey {
+	var scopes []string
+	if k.Scopes != "" {
+		scopes = strings.Split(k.Scopes, ",")
+	}
+
+	return domain.APIKey{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		HashedKey:  k.HashedKey,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
+
+// FromDomain creates APIKeyModel from domain.APIKey
+func (k *APIKeyModel) FromDomain(key domain.APIKey) {
+	k.ID = key.ID
+	k.UserID = key.UserID
+	k.Name = key.Name
+	k.HashedKey = key.HashedKey
+	k.Prefix = key.Prefix
+	k.Scopes = strings.Join(key.Scopes, ",")
+	k.LastUsedAt = key.LastUsedAt
+	k.RevokedAt = key.RevokedAt
+	k.CreatedAt = key.CreatedAt
+}
+
+// NewAPIKeyModelFromDomain creates a new APIKeyModel from domain.APIKey
+func NewAPIKeyModelFromDomain(key domain.APIKey) *APIKeyModel {
+	model := &APIKeyModel{}
+	model.FromDomain(key)
+	return model
+}
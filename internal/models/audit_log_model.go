@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AuditLogModel represents the audit_logs table structure in the database.
+// Rows are only ever inserted; there is no UpdatedAt or DeletedAt, and no foreign key to
+// users so that entries outlive the account and failed logins for unknown emails can be kept.
+// Changes is stored as a JSON object.
+type AuditLogModel struct {
+	ID           string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID       string    `gorm:"not null;default:'';type:varchar(36);index:idx_audit_logs_user_created,priority:1" json:"user_id"`
+	Action       string    `gorm:"not null;type:varchar(32)" json:"action"`
+	ResourceType string    `gorm:"not null;type:varchar(32)" json:"resource_type"`
+	ResourceID   string    `gorm:"not null;default:'';type:varchar(64)" json:"resource_id"`
+	RequestID    string    `gorm:"not null;default:'';type:varchar(64)" json:"request_id"`
+	IPAddress    string    `gorm:"not null;default:'';type:varchar(45)" json:"ip_address"`
+	UserAgent    string    `gorm:"not null;default:'';type:varchar(512)" json:"user_agent"`
+	Changes      string    `gorm:"type:text" json:"changes"`
+	CreatedAt    time.Time `gorm:"not null;index;index:idx_audit_logs_user_created,priority:2" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (AuditLogModel) TableName() string {
+	return "audit_logs"
+}
+
+// BeforeCreate sets the ID and timestamp if not provided
+func (a *AuditLogModel) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = "audit-" + uuid.New().String()
+	}
+	if a.CreatedAt.IsZero() {
+		a.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ToDomain converts AuditLogModel to domain.AuditEntry
+// Changes that can't be decoded are left empty rather than failing the whole listing
+func (a AuditLogModel) ToDomain() domain.AuditEntry {
+	var changes map[string]domain.AuditChange
+	if a.Changes != "" {
+		_ = json.Unmarshal([]byte(a.Changes), &changes)
+	}
+
+	return domain.AuditEntry{
+		ID:           a.ID,
+		UserID:       a.UserID,
+		Action:       a.Action,
+		ResourceType: a.ResourceType,
+		ResourceID:   a.ResourceID,
+		RequestID:    a.RequestID,
+		IPAddress:    a.IPAddress,
+		UserAgent:    a.UserAgent,
+		Changes:      changes,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+// FromDomain creates AuditLogModel from domain.AuditEntry
+// User agents longer than the column are truncated
+func (a *AuditLogModel) FromDomain(entry domain.AuditEntry) error {
+	a.ID = entry.ID
+	a.UserID = entry.UserID
+	a.Action = entry.Action
+	a.ResourceType = entry.ResourceType
+	a.ResourceID = entry.ResourceID
+	a.RequestID = entry.RequestID
+	a.IPAddress = entry.IPAddress
+	a.UserAgent = entry.UserAgent
+	if len(a.UserAgent) > 512 {
+		a.UserAgent = a.UserAgent[:512]
+	}
+	a.CreatedAt = entry.CreatedAt
+
+	a.Changes = ""
+	if len(entry.Changes) > 0 {
+		changes, err := json.Marshal(entry.Changes)
+		if err != nil {
+			return err
+		}
+		a.Changes = string(changes)
+	}
+	return nil
+}
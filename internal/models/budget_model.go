@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BudgetModel represents the budgets table structure in the database
+// Budgets are deleted outright, so the unique index leaves the category free for a new budget
+type BudgetModel struct {
+	ID           string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID       string    `gorm:"not null;type:varchar(36);uniqueIndex:idx_budgets_user_category,priority:1" json:"user_id"`
+	Category     string    `gorm:"not null;type:varchar(50);uniqueIndex:idx_budgets_user_category,priority:2" json:"category"`
+	MonthlyLimit float64   `gorm:"not null;type:decimal(12,2)" json:"monthly_limit"`
+	Rollover     bool      `gorm:"not null;default:false" json:"rollover"`
+	CreatedAt    time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt    time.Time `gorm:"not null" json:"updated_at"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (BudgetModel) TableName() string {
+	return "budgets"
+}
+
+// BeforeCreate sets the ID if not provided
+func (b *BudgetModel) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == "" {
+		b.ID = "budget-" + uuid.New().String()
+	}
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+	if b.UpdatedAt.IsZero() {
+		b.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate updates the UpdatedAt timestamp
+func (b *BudgetModel) BeforeUpdate(tx *gorm.DB) error {
+	b.UpdatedAt = time.Now()
+	return nil
+}
+
+// ToDomain converts BudgetModel to domain.Budget
+func (b BudgetModel) ToDomain() domain.Budget {
+	return domain.Budget{
+		ID:           b.ID,
+		UserID:       b.UserID,
+		Category:     b.Category,
+		MonthlyLimit: b.MonthlyLimit,
+		Rollover:     b.Rollover,
+		CreatedAt:    b.CreatedAt,
+		UpdatedAt:    b.UpdatedAt,
+	}
+}
+
+// NewBudgetModelFromDomain creates a new BudgetModel from domain.Budget
+func NewBudgetModelFromDomain(budget domain.Budget) *BudgetModel {
+	return &BudgetModel{
+		ID:           budget.ID,
+		UserID:       budget.UserID,
+		Category:     budget.Category,
+		MonthlyLimit: budget.MonthlyLimit,
+		Rollover:     budget.Rollover,
+		CreatedAt:    budget.CreatedAt,
+		UpdatedAt:    budget.UpdatedAt,
+	}
+}
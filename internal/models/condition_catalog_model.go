@@ -0,0 +1,46 @@
+package models
+
+import (
+	"slices"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// ConditionCatalogModel represents the condition_catalog table structure in the database
+// The table is seeded by the migrations from an embedded file and isn't written by the API
+type ConditionCatalogModel struct {
+	ID              string `gorm:"primaryKey;type:varchar(16)" json:"id"`
+	Name            string `gorm:"not null;size:100" json:"name"`
+	Category        string `gorm:"not null;size:20;check:category IN ('chronic','acute','mental_health','preventive')" json:"category"`
+	DefaultSeverity string `gorm:"not null;size:10;check:default_severity IN ('mild','moderate','severe','critical')" json:"default_severity"`
+	RiskWeight      int    `gorm:"not null;check:risk_weight >= 0" json:"risk_weight"`
+	// Aliases are stored as a JSON array; NULL when the condition has none
+	Aliases []string `gorm:"serializer:json;type:text" json:"aliases,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (ConditionCatalogModel) TableName() string {
+	return "condition_catalog"
+}
+
+// ToDomain converts ConditionCatalogModel to domain.ConditionCatalogEntry
+func (m ConditionCatalogModel) ToDomain() domain.ConditionCatalogEntry {
+	return domain.ConditionCatalogEntry{
+		ID:              m.ID,
+		Name:            m.Name,
+		Category:        m.Category,
+		DefaultSeverity: m.DefaultSeverity,
+		RiskWeight:      m.RiskWeight,
+		Aliases:         slices.Clone(m.Aliases),
+	}
+}
+
+// FromDomain creates ConditionCatalogModel from domain.ConditionCatalogEntry
+func (m *ConditionCatalogModel) FromDomain(entry domain.ConditionCatalogEntry) {
+	m.ID = entry.ID
+	m.Name = entry.Name
+	m.Category = entry.Category
+	m.DefaultSeverity = entry.DefaultSeverity
+	m.RiskWeight = entry.RiskWeight
+	m.Aliases = slices.Clone(entry.Aliases)
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DemoDataRecordModel represents the demo_data_records table structure in the database
+// Each row names one record created by seeding demo data; the records themselves are unmarked
+type DemoDataRecordModel struct {
+	ID        string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID    string    `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	BatchID   string    `gorm:"not null;type:varchar(64)" json:"batch_id"`
+	Resource  string    `gorm:"not null;type:varchar(50)" json:"resource"`
+	RecordID  string    `gorm:"not null;type:varchar(256)" json:"record_id"`
+	CreatedAt time.Time `gorm:"not null" json:"created_at"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (DemoDataRecordModel) TableName() string {
+	return "demo_data_records"
+}
+
+// BeforeCreate sets the ID if not provided
+func (m *DemoDataRecordModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = "demo-record-" + uuid.New().String()
+	}
+	if m.CreatedAt.IsZero() {
+		m.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ToDomain converts DemoDataRecordModel to domain.DemoDataRecord
+func (m DemoDataRecordModel) ToDomain() domain.DemoDataRecord {
+	return domain.DemoDataRecord{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		BatchID:   m.BatchID,
+		Resource:  m.Resource,
+		RecordID:  m.RecordID,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+// FromDomain creates DemoDataRecordModel from domain.DemoDataRecord
+func (m *DemoDataRecordModel) FromDomain(record domain.DemoDataRecord) {
+	m.ID = record.ID
+	m.UserID = record.UserID
+	m.BatchID = record.BatchID
+	m.Resource = record.Resource
+	m.RecordID = record.RecordID
+	m.CreatedAt = record.CreatedAt
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// ExpenseAttachmentModel represents the metadata of a document attached to a medical expense.
+// The file itself is kept in blob storage under StorageKey. Rows are hard deleted together
+// with their file, so it only carries a creation timestamp.
+type ExpenseAttachmentModel struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// Foreign Keys
+	UserID    string `gorm:"not null;size:36;index" json:"user_id"`
+	ExpenseID uint   `gorm:"not null;index:idx_expense_attachments" json:"expense_id"`
+
+	// File Details
+	FileName    string `gorm:"not null;size:255" json:"file_name"`
+	ContentType string `gorm:"not null;size:100" json:"content_type"`
+	Size        int64  `gorm:"not null" json:"size"`
+	Checksum    string `gorm:"not null;size:64" json:"checksum"`
+	StorageKey  string `gorm:"not null;size:64;uniqueIndex" json:"-"`
+
+	// Relationship
+	Expense MedicalExpenseModel `gorm:"foreignKey:ExpenseID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the table name used by ExpenseAttachmentModel to `expense_attachments`
+func (ExpenseAttachmentModel) TableName() string {
+	return "expense_attachments"
+}
+
+// ToDomain converts ExpenseAttachmentModel to domain.ExpenseAttachment
+func (a *ExpenseAttachmentModel) ToDomain() domain.ExpenseAttachment {
+	return domain.ExpenseAttachment{
+		ID:          fmt.Sprintf("%d", a.ID),
+		UserID:      a.UserID,
+		ExpenseID:   fmt.Sprintf("%d", a.ExpenseID),
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Checksum:    a.Checksum,
+		StorageKey:  a.StorageKey,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+// FromDomain creates ExpenseAttachmentModel from domain.ExpenseAttachment
+func (a *ExpenseAttachmentModel) FromDomain(attachment domain.ExpenseAttachment, expenseID uint) {
+	a.UserID = attachment.UserID
+	a.ExpenseID = expenseID
+	a.FileName = attachment.FileName
+	a.ContentType = attachment.ContentType
+	a.Size = attachment.Size
+	a.Checksum = attachment.Checksum
+	a.StorageKey = attachment.StorageKey
+	a.CreatedAt = attachment.CreatedAt
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"slices"
 	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
@@ -10,14 +11,21 @@ import (
 
 // ExpenseModel represents the expense table structure in the database
 type ExpenseModel struct {
-	ID        string         `gorm:"primaryKey;type:varchar(256)" json:"id"`
-	UserID    string         `gorm:"not null;index;type:varchar(256)" json:"user_id"`
-	Category  string         `gorm:"not null;type:varchar(50)" json:"category"`
-	Name      string         `gorm:"not null;type:varchar(255)" json:"name"`
-	Amount    float64        `gorm:"not null;type:decimal(10,2)" json:"amount"`
-	Frequency string         `gorm:"not null;type:varchar(20)" json:"frequency"`
-	IsFixed   bool           `gorm:"not null;default:false" json:"is_fixed"`
-	Priority  int            `gorm:"not null;type:tinyint" json:"priority"`
+	ID        string  `gorm:"primaryKey;type:varchar(256)" json:"id"`
+	UserID    string  `gorm:"not null;index;type:varchar(256)" json:"user_id"`
+	Category  string  `gorm:"not null;type:varchar(50)" json:"category"`
+	Name      string  `gorm:"not null;type:varchar(255)" json:"name"`
+	Amount    float64 `gorm:"not null;type:decimal(10,2)" json:"amount"`
+	Currency  string  `gorm:"not null;default:'USD';type:varchar(3)" json:"currency"`
+	Frequency string  `gorm:"not null;type:varchar(20)" json:"frequency"`
+	IsFixed   bool    `gorm:"not null;default:false" json:"is_fixed"`
+	Priority  int     `gorm:"not null;type:tinyint" json:"priority"`
+	// Installment plan; zero total for regular expenses
+	InstallmentsTotal    int        `gorm:"not null;default:0;type:tinyint" json:"installments_total"`
+	InstallmentsPaid     int        `gorm:"not null;default:0;type:tinyint" json:"installments_paid"`
+	InstallmentStartDate *time.Time `gorm:"type:date" json:"installment_start_date,omitempty"`
+	// Tags are stored as a JSON array; NULL when the expense has none
+	Tags      []string       `gorm:"serializer:json;type:text" json:"tags,omitempty"`
 	CreatedAt time.Time      `gorm:"not null" json:"created_at"`
 	UpdatedAt time.Time      `gorm:"not null" json:"updated_at"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
@@ -53,18 +61,26 @@ func (e *ExpenseModel) BeforeUpdate(tx *gorm.DB) error {
 
 // ToDomain converts ExpenseModel to domain.Expense
 func (e ExpenseModel) ToDomain() domain.Expense {
-	return domain.Expense{
-		ID:        e.ID,
-		UserID:    e.UserID,
-		Category:  e.Category,
-		Name:      e.Name,
-		Amount:    e.Amount,
-		Frequency: e.Frequency,
-		IsFixed:   e.IsFixed,
-		Priority:  e.Priority,
-		CreatedAt: e.CreatedAt,
-		UpdatedAt: e.UpdatedAt,
+	expense := domain.Expense{
+		ID:                e.ID,
+		UserID:            e.UserID,
+		Category:          e.Category,
+		Name:              e.Name,
+		Amount:            e.Amount,
+		Currency:          e.Currency,
+		Frequency:         e.Frequency,
+		IsFixed:           e.IsFixed,
+		Priority:          e.Priority,
+		InstallmentsTotal: e.InstallmentsTotal,
+		InstallmentsPaid:  e.InstallmentsPaid,
+		Tags:              slices.Clone(e.Tags),
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
 	}
+	if e.InstallmentStartDate != nil {
+		expense.InstallmentStart = *e.InstallmentStartDate
+	}
+	return expense
 }
 
 // FromDomain creates ExpenseModel from domain.Expense
@@ -74,9 +90,18 @@ func (e *ExpenseModel) FromDomain(expense domain.Expense) {
 	e.Category = expense.Category
 	e.Name = expense.Name
 	e.Amount = expense.Amount
+	e.Currency = expense.Currency
 	e.Frequency = expense.Frequency
 	e.IsFixed = expense.IsFixed
 	e.Priority = expense.Priority
+	e.InstallmentsTotal = expense.InstallmentsTotal
+	e.InstallmentsPaid = expense.InstallmentsPaid
+	e.InstallmentStartDate = nil
+	if !expense.InstallmentStart.IsZero() {
+		start := expense.InstallmentStart
+		e.InstallmentStartDate = &start
+	}
+	e.Tags = slices.Clone(expense.Tags)
 	e.CreatedAt = expense.CreatedAt
 	e.UpdatedAt = expense.UpdatedAt
 }
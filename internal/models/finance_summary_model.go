@@ -10,6 +10,7 @@ import (
 // FinanceSummaryModel represents the finance_summaries table structure in the database
 type FinanceSummaryModel struct {
 	UserID              string         `gorm:"primaryKey;type:varchar(36)" json:"user_id"`
+	Currency            string         `gorm:"not null;default:'USD';type:varchar(3)" json:"currency"`
 	MonthlyIncome       float64        `gorm:"not null;type:decimal(10,2)" json:"monthly_income"`
 	MonthlyExpenses     float64        `gorm:"not null;type:decimal(10,2)" json:"monthly_expenses"`
 	MonthlyLoanPayments float64        `gorm:"not null;type:decimal(10,2)" json:"monthly_loan_payments"`
@@ -48,6 +49,7 @@ func (f *FinanceSummaryModel) BeforeUpdate(tx *gorm.DB) error {
 func (f FinanceSummaryModel) ToDomain() domain.FinanceSummary {
 	return domain.FinanceSummary{
 		UserID:              f.UserID,
+		Currency:            f.Currency,
 		MonthlyIncome:       f.MonthlyIncome,
 		MonthlyExpenses:     f.MonthlyExpenses,
 		MonthlyLoanPayments: f.MonthlyLoanPayments,
@@ -63,6 +65,7 @@ func (f FinanceSummaryModel) ToDomain() domain.FinanceSummary {
 // FromDomain creates FinanceSummaryModel from domain.FinanceSummary
 func (f *FinanceSummaryModel) FromDomain(summary domain.FinanceSummary) {
 	f.UserID = summary.UserID
+	f.Currency = summary.Currency
 	f.MonthlyIncome = summary.MonthlyIncome
 	f.MonthlyExpenses = summary.MonthlyExpenses
 	f.MonthlyLoanPayments = summary.MonthlyLoanPayments
@@ -12,13 +12,16 @@ type HealthProfileModel struct {
 	gorm.Model
 	
 	// Basic Information
-	UserID     string  `gorm:"uniqueIndex;not null;size:36" json:"user_id"`         // One profile per user
-	Age        int     `gorm:"not null;check:age >= 0 AND age <= 150" json:"age"`
-	Gender     string  `gorm:"not null;size:10;check:gender IN ('male','female','other')" json:"gender"`
-	Height     float64 `gorm:"not null;check:height > 0" json:"height"`             // in cm
-	Weight     float64 `gorm:"not null;check:weight > 0" json:"weight"`             // in kg  
-	BMI        float64 `gorm:"not null" json:"bmi"`                                 // calculated BMI
-	FamilySize int     `gorm:"not null;check:family_size >= 1 AND family_size <= 20" json:"family_size"`
+	UserID          string  `gorm:"index;not null;size:36" json:"user_id"` // Owning account
+	Name            string  `gorm:"size:100" json:"name"`
+	RelationToOwner string  `gorm:"not null;size:20;default:'self'" json:"relation_to_owner"`
+	SelfUserID      *string `gorm:"uniqueIndex;size:36" json:"-"` // Set only on the self profile: one self profile per user
+	Age             int     `gorm:"not null;check:age >= 0 AND age <= 150" json:"age"`
+	Gender          string  `gorm:"not null;size:10;check:gender IN ('male','female','other')" json:"gender"`
+	Height          float64 `gorm:"not null;check:height > 0" json:"height"` // in cm
+	Weight          float64 `gorm:"not null;check:weight > 0" json:"weight"` // in kg
+	BMI             float64 `gorm:"not null" json:"bmi"`                     // calculated BMI
+	FamilySize      int     `gorm:"not null;check:family_size >= 1 AND family_size <= 20" json:"family_size"`
 	
 	// Relationships - One profile has many conditions, expenses, and policies
 	Conditions []MedicalConditionModel `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"conditions,omitempty"`
@@ -54,16 +57,18 @@ func (h *HealthProfileModel) calculateBMI() {
 // ToDomain converts HealthProfileModel to domain.HealthProfile
 func (h *HealthProfileModel) ToDomain() *domain.HealthProfile {
 	return &domain.HealthProfile{
-		ID:         fmt.Sprintf("%d", h.ID), // Convert uint to string
-		UserID:     h.UserID,
-		Age:        h.Age,
-		Gender:     h.Gender,
-		Height:     h.Height,
-		Weight:     h.Weight,
-		BMI:        h.BMI,
-		FamilySize: h.FamilySize,
-		CreatedAt:  h.CreatedAt,
-		UpdatedAt:  h.UpdatedAt,
+		ID:              fmt.Sprintf("%d", h.ID), // Convert uint to string
+		UserID:          h.UserID,
+		Name:            h.Name,
+		RelationToOwner: h.RelationToOwner,
+		Age:             h.Age,
+		Gender:          h.Gender,
+		Height:          h.Height,
+		Weight:          h.Weight,
+		BMI:             h.BMI,
+		FamilySize:      h.FamilySize,
+		CreatedAt:       h.CreatedAt,
+		UpdatedAt:       h.UpdatedAt,
 	}
 }
 
@@ -71,6 +76,14 @@ func (h *HealthProfileModel) ToDomain() *domain.HealthProfile {
 func (h *HealthProfileModel) FromDomain(profile *domain.HealthProfile) {
 	// Note: We don't set ID since it's auto-generated by GORM
 	h.UserID = profile.UserID
+	h.Name = profile.Name
+	h.RelationToOwner = profile.RelationToOwner
+	h.SelfUserID = nil
+	if profile.IsSelf() {
+		h.RelationToOwner = domain.RelationSelf
+		userID := profile.UserID
+		h.SelfUserID = &userID
+	}
 	h.Age = profile.Age
 	h.Gender = profile.Gender
 	h.Height = profile.Height
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// IdempotencyKeyModel represents the idempotency_keys table structure in the database.
+// Rows are hard-deleted when they expire so that keys can be reused.
+type IdempotencyKeyModel struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	UserID         string    `gorm:"not null;size:36;uniqueIndex:idx_idempotency_user_key,priority:1" json:"user_id"`
+	IdempotencyKey string    `gorm:"not null;size:255;uniqueIndex:idx_idempotency_user_key,priority:2" json:"idempotency_key"`
+	RequestHash    string    `gorm:"not null;size:64" json:"request_hash"`
+	StatusCode     int       `gorm:"not null;default:0" json:"status_code"`
+	ContentType    string    `gorm:"size:100" json:"content_type"`
+	ResponseBody   string    `gorm:"type:text" json:"response_body"`
+	Completed      bool      `gorm:"not null;default:false" json:"completed"`
+	ExpiresAt      time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// TableName returns the table name for GORM
+func (IdempotencyKeyModel) TableName() string {
+	return "idempotency_keys"
+}
+
+// ToDomain converts IdempotencyKeyModel to domain.IdempotencyRecord
+func (m *IdempotencyKeyModel) ToDomain() *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		UserID:       m.UserID,
+		Key:          m.IdempotencyKey,
+		RequestHash:  m.RequestHash,
+		StatusCode:   m.StatusCode,
+		ContentType:  m.ContentType,
+		ResponseBody: []byte(m.ResponseBody),
+		Completed:    m.Completed,
+		ExpiresAt:    m.ExpiresAt,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+// FromDomain creates IdempotencyKeyModel from domain.IdempotencyRecord
+func (m *IdempotencyKeyModel) FromDomain(record *domain.IdempotencyRecord) {
+	m.UserID = record.UserID
+	m.IdempotencyKey = record.Key
+	m.RequestHash = record.RequestHash
+	m.StatusCode = record.StatusCode
+	m.ContentType = record.ContentType
+	m.ResponseBody = string(record.ResponseBody)
+	m.Completed = record.Completed
+	m.ExpiresAt = record.ExpiresAt
+}
@@ -14,6 +14,7 @@ type IncomeModel struct {
 	UserID    string         `gorm:"not null;index;type:varchar(36)" json:"user_id"`
 	Source    string         `gorm:"not null;type:varchar(255)" json:"source"`
 	Amount    float64        `gorm:"not null;type:decimal(10,2)" json:"amount"`
+	Currency  string         `gorm:"not null;default:'USD';type:varchar(3)" json:"currency"`
 	Frequency string         `gorm:"not null;type:varchar(20)" json:"frequency"`
 	IsActive  bool           `gorm:"not null" json:"is_active"`
 	CreatedAt time.Time      `gorm:"not null" json:"created_at"`
@@ -61,6 +62,7 @@ func (i IncomeModel) ToDomain() domain.Income {
 		UserID:    i.UserID,
 		Source:    i.Source,
 		Amount:    i.Amount,
+		Currency:  i.Currency,
 		Frequency: i.Frequency,
 		IsActive:  i.IsActive,
 		CreatedAt: i.CreatedAt,
@@ -74,6 +76,7 @@ func (i *IncomeModel) FromDomain(income domain.Income) {
 	i.UserID = income.UserID
 	i.Source = income.Source
 	i.Amount = income.Amount
+	i.Currency = income.Currency
 	i.Frequency = income.Frequency
 	i.IsActive = income.IsActive
 	i.CreatedAt = income.CreatedAt
@@ -13,12 +13,12 @@ type InsurancePolicyModel struct {
 	gorm.Model
 	
 	// Foreign Keys
-	UserID    string `gorm:"not null;size:36;index:idx_user_policies" json:"user_id"`
+	UserID    string `gorm:"not null;size:36;index:idx_user_policies;uniqueIndex:idx_insurance_policies_user_number,priority:1" json:"user_id"`
 	ProfileID uint   `gorm:"not null;index:idx_profile_policies" json:"profile_id"`
 	
 	// Policy Details
 	Provider           string    `gorm:"not null;size:100" json:"provider"`
-	PolicyNumber       string    `gorm:"uniqueIndex;not null;size:50" json:"policy_number"` // Unique across all policies
+	PolicyNumber       string    `gorm:"not null;size:50;uniqueIndex:idx_insurance_policies_user_number,priority:2" json:"policy_number"` // Unique per user
 	Type               string    `gorm:"not null;size:10;check:type IN ('health','dental','vision')" json:"type"`
 	MonthlyPremium     float64   `gorm:"not null;check:monthly_premium > 0" json:"monthly_premium"`
 	AnnualDeductible   float64   `gorm:"not null;check:annual_deductible >= 0" json:"annual_deductible"`
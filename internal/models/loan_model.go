@@ -10,18 +10,20 @@ import (
 
 // LoanModel represents the loan table structure in the database
 type LoanModel struct {
-	ID               string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
-	UserID           string         `gorm:"not null;index;type:varchar(36)" json:"user_id"`
-	Lender           string         `gorm:"not null;type:varchar(255)" json:"lender"`
-	Type             string         `gorm:"not null;type:varchar(50)" json:"type"`
-	PrincipalAmount  float64        `gorm:"not null;type:decimal(12,2)" json:"principal_amount"`
-	RemainingBalance float64        `gorm:"not null;type:decimal(12,2)" json:"remaining_balance"`
-	MonthlyPayment   float64        `gorm:"not null;type:decimal(10,2)" json:"monthly_payment"`
-	InterestRate     float64        `gorm:"not null;type:decimal(5,3)" json:"interest_rate"`
-	EndDate          time.Time      `gorm:"not null" json:"end_date"`
-	CreatedAt        time.Time      `gorm:"not null" json:"created_at"`
-	UpdatedAt        time.Time      `gorm:"not null" json:"updated_at"`
-	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID                 string         `gorm:"primaryKey;type:varchar(36)" json:"id"`
+	UserID             string         `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	Lender             string         `gorm:"not null;type:varchar(255)" json:"lender"`
+	Type               string         `gorm:"not null;type:varchar(50)" json:"type"`
+	PrincipalAmount    float64        `gorm:"not null;type:decimal(12,2)" json:"principal_amount"`
+	RemainingBalance   float64        `gorm:"not null;type:decimal(12,2)" json:"remaining_balance"`
+	MonthlyPayment     float64        `gorm:"not null;type:decimal(10,2)" json:"monthly_payment"`
+	InterestRate       float64        `gorm:"not null;type:decimal(5,3)" json:"interest_rate"`
+	Currency           string         `gorm:"not null;default:'USD';type:varchar(3)" json:"currency"`
+	AutoAccrueInterest bool           `gorm:"not null;default:false" json:"auto_accrue_interest"`
+	EndDate            time.Time      `gorm:"not null" json:"end_date"`
+	CreatedAt          time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt          time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 
 	// Foreign key relationship
 	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
@@ -55,17 +57,19 @@ func (l *LoanModel) BeforeUpdate(tx *gorm.DB) error {
 // ToDomain converts LoanModel to domain.Loan
 func (l LoanModel) ToDomain() domain.Loan {
 	return domain.Loan{
-		ID:               l.ID,
-		UserID:           l.UserID,
-		Lender:           l.Lender,
-		Type:             l.Type,
-		PrincipalAmount:  l.PrincipalAmount,
-		RemainingBalance: l.RemainingBalance,
-		MonthlyPayment:   l.MonthlyPayment,
-		InterestRate:     l.InterestRate,
-		EndDate:          l.EndDate,
-		CreatedAt:        l.CreatedAt,
-		UpdatedAt:        l.UpdatedAt,
+		ID:                 l.ID,
+		UserID:             l.UserID,
+		Lender:             l.Lender,
+		Type:               l.Type,
+		PrincipalAmount:    l.PrincipalAmount,
+		RemainingBalance:   l.RemainingBalance,
+		MonthlyPayment:     l.MonthlyPayment,
+		InterestRate:       l.InterestRate,
+		Currency:           l.Currency,
+		AutoAccrueInterest: l.AutoAccrueInterest,
+		EndDate:            l.EndDate,
+		CreatedAt:          l.CreatedAt,
+		UpdatedAt:          l.UpdatedAt,
 	}
 }
 
@@ -79,6 +83,8 @@ func (l *LoanModel) FromDomain(loan domain.Loan) {
 	l.RemainingBalance = loan.RemainingBalance
 	l.MonthlyPayment = loan.MonthlyPayment
 	l.InterestRate = loan.InterestRate
+	l.Currency = loan.Currency
+	l.AutoAccrueInterest = loan.AutoAccrueInterest
 	l.EndDate = loan.EndDate
 	l.CreatedAt = loan.CreatedAt
 	l.UpdatedAt = loan.UpdatedAt
@@ -89,4 +95,70 @@ func NewLoanModelFromDomain(loan domain.Loan) *LoanModel {
 	model := &LoanModel{}
 	model.FromDomain(loan)
 	return model
-}
\ No newline at end of file
+}
+
+// LoanBalanceEntryModel represents the loan_balance_entries table structure in the database
+// Entries are only ever inserted; they are kept when their loan is soft deleted
+type LoanBalanceEntryModel struct {
+	ID               string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	LoanID           string    `gorm:"not null;type:varchar(36);index:idx_loan_balance_entries_loan_created,priority:1" json:"loan_id"`
+	UserID           string    `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	Type             string    `gorm:"not null;type:varchar(20)" json:"type"`
+	OldBalance       float64   `gorm:"not null;type:decimal(12,2)" json:"old_balance"`
+	NewBalance       float64   `gorm:"not null;type:decimal(12,2)" json:"new_balance"`
+	Amount           float64   `gorm:"not null;type:decimal(12,2)" json:"amount"`
+	InterestPortion  float64   `gorm:"not null;type:decimal(12,2)" json:"interest_portion"`
+	PrincipalPortion float64   `gorm:"not null;type:decimal(12,2)" json:"principal_portion"`
+	Note             string    `gorm:"type:varchar(255)" json:"note"`
+	CreatedAt        time.Time `gorm:"not null;index:idx_loan_balance_entries_loan_created,priority:2" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (LoanBalanceEntryModel) TableName() string {
+	return "loan_balance_entries"
+}
+
+// BeforeCreate sets the ID if not provided
+func (e *LoanBalanceEntryModel) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = "loanbal-" + uuid.New().String()
+	}
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ToDomain converts LoanBalanceEntryModel to domain.LoanBalanceEntry
+func (e LoanBalanceEntryModel) ToDomain() domain.LoanBalanceEntry {
+	return domain.LoanBalanceEntry{
+		ID:               e.ID,
+		LoanID:           e.LoanID,
+		UserID:           e.UserID,
+		Type:             e.Type,
+		OldBalance:       e.OldBalance,
+		NewBalance:       e.NewBalance,
+		Amount:           e.Amount,
+		InterestPortion:  e.InterestPortion,
+		PrincipalPortion: e.PrincipalPortion,
+		Note:             e.Note,
+		CreatedAt:        e.CreatedAt,
+	}
+}
+
+// NewLoanBalanceEntryModelFromDomain creates a new LoanBalanceEntryModel from domain.LoanBalanceEntry
+func NewLoanBalanceEntryModelFromDomain(entry domain.LoanBalanceEntry) *LoanBalanceEntryModel {
+	return &LoanBalanceEntryModel{
+		ID:               entry.ID,
+		LoanID:           entry.LoanID,
+		UserID:           entry.UserID,
+		Type:             entry.Type,
+		OldBalance:       entry.OldBalance,
+		NewBalance:       entry.NewBalance,
+		Amount:           entry.Amount,
+		InterestPortion:  entry.InterestPortion,
+		PrincipalPortion: entry.PrincipalPortion,
+		Note:             entry.Note,
+		CreatedAt:        entry.CreatedAt,
+	}
+}
@@ -11,21 +11,27 @@ import (
 // MedicalConditionModel represents the medical condition database model
 type MedicalConditionModel struct {
 	gorm.Model
-	
+
 	// Foreign Keys
 	UserID    string `gorm:"not null;size:36;index:idx_user_conditions" json:"user_id"`
 	ProfileID uint   `gorm:"not null;index:idx_profile_conditions" json:"profile_id"`
-	
+
 	// Condition Details
-	Name               string    `gorm:"not null;size:100;index:idx_condition_name" json:"name"`
-	Category           string    `gorm:"not null;size:20;index:idx_condition_category;check:category IN ('chronic','acute','mental_health','preventive')" json:"category"`
-	Severity           string    `gorm:"not null;size:10;check:severity IN ('mild','moderate','severe','critical')" json:"severity"`
-	DiagnosedDate      time.Time `gorm:"not null" json:"diagnosed_date"`
-	IsActive           bool      `gorm:"not null;default:true;index:idx_active_conditions" json:"is_active"`
-	RequiresMedication bool      `gorm:"not null;default:false" json:"requires_medication"`
-	MonthlyMedCost     float64   `gorm:"not null;default:0;check:monthly_med_cost >= 0" json:"monthly_med_cost"`
-	RiskFactor         float64   `gorm:"not null;default:0.1;check:risk_factor >= 0 AND risk_factor <= 1" json:"risk_factor"`
-	
+	Name               string     `gorm:"not null;size:100;index:idx_condition_name" json:"name"`
+	Category           string     `gorm:"not null;size:20;index:idx_condition_category;check:category IN ('chronic','acute','mental_health','preventive')" json:"category"`
+	Severity           string     `gorm:"not null;size:10;check:severity IN ('mild','moderate','severe','critical')" json:"severity"`
+	DiagnosedDate      time.Time  `gorm:"not null" json:"diagnosed_date"`
+	ResolvedDate       *time.Time `json:"resolved_date,omitempty"` // set when the condition is resolved instead of deleting it
+	IsActive           bool       `gorm:"not null;default:true;index:idx_active_conditions" json:"is_active"`
+	RequiresMedication bool       `gorm:"not null;default:false" json:"requires_medication"`
+	MonthlyMedCost     float64    `gorm:"not null;default:0;check:monthly_med_cost >= 0" json:"monthly_med_cost"`
+	RiskFactor         float64    `gorm:"not null;default:0.1;check:risk_factor >= 0 AND risk_factor <= 1" json:"risk_factor"`
+
+	// Condition catalog reference: empty for a free-text condition. The catalog entry's risk weight
+	// is copied so the condition scores the same if the catalog is reseeded.
+	CatalogID         string `gorm:"size:16;index:idx_condition_catalog" json:"catalog_id,omitempty"`
+	CatalogRiskWeight int    `gorm:"not null;default:0" json:"catalog_risk_weight,omitempty"`
+
 	// Relationship
 	Profile HealthProfileModel `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"-"`
 }
@@ -53,10 +59,13 @@ func (m *MedicalConditionModel) ToDomain() *domain.MedicalCondition {
 		Category:           m.Category,
 		Severity:           m.Severity,
 		DiagnosedDate:      m.DiagnosedDate,
+		ResolvedDate:       m.ResolvedDate,
 		IsActive:           m.IsActive,
 		RequiresMedication: m.RequiresMedication,
 		MonthlyMedCost:     m.MonthlyMedCost,
 		RiskFactor:         m.RiskFactor,
+		CatalogID:          m.CatalogID,
+		CatalogRiskWeight:  m.CatalogRiskWeight,
 		CreatedAt:          m.CreatedAt,
 		UpdatedAt:          m.UpdatedAt,
 	}
@@ -71,10 +80,13 @@ func (m *MedicalConditionModel) FromDomain(condition *domain.MedicalCondition, p
 	m.Category = condition.Category
 	m.Severity = condition.Severity
 	m.DiagnosedDate = condition.DiagnosedDate
+	m.ResolvedDate = condition.ResolvedDate
 	m.IsActive = condition.IsActive
 	m.RequiresMedication = condition.RequiresMedication
 	m.MonthlyMedCost = condition.MonthlyMedCost
 	m.RiskFactor = condition.RiskFactor
+	m.CatalogID = condition.CatalogID
+	m.CatalogRiskWeight = condition.CatalogRiskWeight
 	m.CreatedAt = condition.CreatedAt
 	m.UpdatedAt = condition.UpdatedAt
-}
\ No newline at end of file
+}
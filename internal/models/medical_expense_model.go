@@ -21,7 +21,7 @@ type MedicalExpenseModel struct {
 	Category         string    `gorm:"not null;size:20;index:idx_expense_category;check:category IN ('doctor_visit','medication','hospital','lab_test','therapy','equipment')" json:"category"`
 	Description      string    `gorm:"not null;size:200" json:"description"`
 	IsRecurring      bool      `gorm:"not null;default:false;index:idx_recurring_expenses" json:"is_recurring"`
-	Frequency        string    `gorm:"size:20;check:frequency IN ('daily','weekly','bi-weekly','monthly','quarterly','semi-annually','annually','one_time')" json:"frequency"`
+	Frequency        string    `gorm:"size:20;check:chk_medical_expenses_frequencies,frequency IN ('daily','weekly','biweekly','bi-weekly','monthly','quarterly','semiannual','semiannually','semi-annually','annual','annually','yearly','one_time')" json:"frequency"`
 	IsCovered        bool      `gorm:"not null;default:false" json:"is_covered"`
 	InsurancePayment float64   `gorm:"not null;default:0;check:insurance_payment >= 0" json:"insurance_payment"`
 	OutOfPocket      float64   `gorm:"not null;check:out_of_pocket >= 0" json:"out_of_pocket"`
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// MedicalExpenseOccurrenceModel represents the actual cost of one occurrence of a recurring
+// medical expense. Occurrences are hard deleted together with their expense, so it only
+// carries a creation timestamp.
+type MedicalExpenseOccurrenceModel struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// Foreign Keys
+	UserID    string `gorm:"not null;size:36;index:idx_user_expense_occurrences,priority:1" json:"user_id"`
+	ProfileID uint   `gorm:"not null" json:"profile_id"`
+	ExpenseID uint   `gorm:"not null;index:idx_expense_occurrences" json:"expense_id"`
+
+	// Occurrence Details, with the category and coverage inherited from the expense
+	Amount           float64   `gorm:"not null;check:chk_expense_occurrences_amount,amount > 0" json:"amount"`
+	Date             time.Time `gorm:"not null;index:idx_user_expense_occurrences,priority:2" json:"date"`
+	Category         string    `gorm:"not null;size:20" json:"category"`
+	IsCovered        bool      `gorm:"not null;default:false" json:"is_covered"`
+	InsurancePayment float64   `gorm:"not null;default:0" json:"insurance_payment"`
+	OutOfPocket      float64   `gorm:"not null;default:0" json:"out_of_pocket"`
+
+	// Relationship
+	Expense MedicalExpenseModel `gorm:"foreignKey:ExpenseID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the table name used by MedicalExpenseOccurrenceModel to `medical_expense_occurrences`
+func (MedicalExpenseOccurrenceModel) TableName() string {
+	return "medical_expense_occurrences"
+}
+
+// ToDomain converts MedicalExpenseOccurrenceModel to domain.MedicalExpenseOccurrence
+func (o *MedicalExpenseOccurrenceModel) ToDomain() *domain.MedicalExpenseOccurrence {
+	return &domain.MedicalExpenseOccurrence{
+		ID:               fmt.Sprintf("%d", o.ID),
+		ExpenseID:        fmt.Sprintf("%d", o.ExpenseID),
+		UserID:           o.UserID,
+		ProfileID:        fmt.Sprintf("%d", o.ProfileID),
+		Amount:           o.Amount,
+		Date:             o.Date,
+		Category:         o.Category,
+		IsCovered:        o.IsCovered,
+		InsurancePayment: o.InsurancePayment,
+		OutOfPocket:      o.OutOfPocket,
+		CreatedAt:        o.CreatedAt,
+	}
+}
+
+// FromDomain creates MedicalExpenseOccurrenceModel from domain.MedicalExpenseOccurrence
+func (o *MedicalExpenseOccurrenceModel) FromDomain(occurrence *domain.MedicalExpenseOccurrence, expenseID, profileID uint) {
+	o.UserID = occurrence.UserID
+	o.ProfileID = profileID
+	o.ExpenseID = expenseID
+	o.Amount = occurrence.Amount
+	o.Date = occurrence.Date
+	o.Category = occurrence.Category
+	o.IsCovered = occurrence.IsCovered
+	o.InsurancePayment = occurrence.InsurancePayment
+	o.OutOfPocket = occurrence.OutOfPocket
+}
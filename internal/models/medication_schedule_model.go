@@ -0,0 +1,60 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"gorm.io/gorm"
+)
+
+// MedicationScheduleModel represents the medication schedule database model
+type MedicationScheduleModel struct {
+	gorm.Model
+
+	// Foreign Keys
+	UserID      string `gorm:"not null;size:36;index:idx_user_medications" json:"user_id"`
+	ConditionID uint   `gorm:"not null;index:idx_condition_medications" json:"condition_id"`
+
+	// Schedule Details
+	MedicationName  string    `gorm:"not null;size:100" json:"medication_name"`
+	RefillEveryDays int       `gorm:"not null;check:refill_every_days > 0" json:"refill_every_days"`
+	LastFilledDate  time.Time `gorm:"not null" json:"last_filled_date"`
+	IsActive        bool      `gorm:"not null;default:true;index:idx_active_medications" json:"is_active"`
+
+	// Relationship
+	Condition MedicalConditionModel `gorm:"foreignKey:ConditionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the table name used by MedicationScheduleModel to `medication_schedules`
+func (MedicationScheduleModel) TableName() string {
+	return "medication_schedules"
+}
+
+// ToDomain converts MedicationScheduleModel to domain.MedicationSchedule
+func (m *MedicationScheduleModel) ToDomain() *domain.MedicationSchedule {
+	return &domain.MedicationSchedule{
+		ID:              fmt.Sprintf("%d", m.ID),
+		UserID:          m.UserID,
+		ConditionID:     fmt.Sprintf("%d", m.ConditionID),
+		MedicationName:  m.MedicationName,
+		RefillEveryDays: m.RefillEveryDays,
+		LastFilledDate:  m.LastFilledDate,
+		IsActive:        m.IsActive,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// FromDomain creates MedicationScheduleModel from domain.MedicationSchedule
+func (m *MedicationScheduleModel) FromDomain(schedule *domain.MedicationSchedule, conditionID uint) {
+	// Note: We don't set ID since it's auto-generated
+	m.UserID = schedule.UserID
+	m.ConditionID = conditionID
+	m.MedicationName = schedule.MedicationName
+	m.RefillEveryDays = schedule.RefillEveryDays
+	m.LastFilledDate = schedule.LastFilledDate
+	m.IsActive = schedule.IsActive
+	m.CreatedAt = schedule.CreatedAt
+	m.UpdatedAt = schedule.UpdatedAt
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// ProfileSnapshotModel represents a historical health profile measurement.
+// Snapshots are append-only, so it only carries a creation timestamp.
+type ProfileSnapshotModel struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// Foreign Keys
+	UserID    string `gorm:"not null;size:36;index:idx_user_snapshots,priority:1" json:"user_id"`
+	ProfileID uint   `gorm:"not null;index" json:"profile_id"`
+
+	// Measurements
+	Age        int       `gorm:"not null" json:"age"`
+	Height     float64   `gorm:"not null" json:"height"` // in cm
+	Weight     float64   `gorm:"not null" json:"weight"` // in kg
+	BMI        float64   `gorm:"not null" json:"bmi"`
+	RecordedAt time.Time `gorm:"not null;index:idx_user_snapshots,priority:2" json:"recorded_at"`
+
+	// Relationship
+	Profile HealthProfileModel `gorm:"foreignKey:ProfileID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TableName overrides the table name used by ProfileSnapshotModel to `profile_snapshots`
+func (ProfileSnapshotModel) TableName() string {
+	return "profile_snapshots"
+}
+
+// ToDomain converts ProfileSnapshotModel to domain.ProfileSnapshot
+func (p *ProfileSnapshotModel) ToDomain() *domain.ProfileSnapshot {
+	return &domain.ProfileSnapshot{
+		ID:         fmt.Sprintf("%d", p.ID),
+		UserID:     p.UserID,
+		ProfileID:  fmt.Sprintf("%d", p.ProfileID),
+		Age:        p.Age,
+		Height:     p.Height,
+		Weight:     p.Weight,
+		BMI:        p.BMI,
+		RecordedAt: p.RecordedAt,
+	}
+}
+
+// FromDomain creates ProfileSnapshotModel from domain.ProfileSnapshot
+func (p *ProfileSnapshotModel) FromDomain(snapshot *domain.ProfileSnapshot, profileID uint) {
+	p.UserID = snapshot.UserID
+	p.ProfileID = profileID
+	p.Age = snapshot.Age
+	p.Height = snapshot.Height
+	p.Weight = snapshot.Weight
+	p.BMI = snapshot.BMI
+	p.RecordedAt = snapshot.RecordedAt
+}
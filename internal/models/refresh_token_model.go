@@ -1,6 +1,8 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strconv"
 	"time"
 
@@ -8,11 +10,12 @@ import (
 )
 
 // RefreshTokenModel represents the GORM model for refresh tokens table
-// This struct defines the database schema and should only be used in the repository layer
+// This struct defines the database schema and should only be used in the repository layer.
+// Only the SHA-256 hash of a token is stored; tokens are looked up by hashing them again.
 type RefreshTokenModel struct {
 	gorm.Model
 	UserID    uint      `gorm:"not null;index"`
-	Token     string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	TokenHash string    `gorm:"type:char(64);uniqueIndex;not null"`
 	ExpiresAt time.Time `gorm:"not null"`
 	IsRevoked bool      `gorm:"default:false"`
 	RevokedAt *time.Time
@@ -39,9 +42,16 @@ func (r RefreshTokenModel) ToUserID() string {
 	return strconv.FormatUint(uint64(r.UserID), 10)
 }
 
+// HashRefreshToken returns the hex encoded SHA-256 hash refresh tokens are stored and looked up by.
+// Tokens are signed JWTs with a random ID, so a fast unsalted hash is safe, unlike passwords.
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // RefreshTokenFromDomain creates a RefreshTokenModel from domain data
 // userID should be a string representation of the user ID
-// token is the actual refresh token string
+// token is the actual refresh token string, which is stored hashed
 // expiresAt is when the token expires
 func RefreshTokenFromDomain(userID, token string, expiresAt time.Time) RefreshTokenModel {
 	var uid uint
@@ -51,7 +61,7 @@ func RefreshTokenFromDomain(userID, token string, expiresAt time.Time) RefreshTo
 
 	return RefreshTokenModel{
 		UserID:    uid,
-		Token:     token,
+		TokenHash: HashRefreshToken(token),
 		ExpiresAt: expiresAt,
 		IsRevoked: false,
 	}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// RiskSnapshotModel represents a user's health risk score at a point in time.
+// Snapshots are append-only, so it only carries a creation timestamp.
+type RiskSnapshotModel struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	UserID     string    `gorm:"not null;size:36;index:idx_user_risk_snapshots,priority:1" json:"user_id"`
+	RiskScore  int       `gorm:"not null" json:"risk_score"`
+	RiskLevel  string    `gorm:"not null;size:20" json:"risk_level"`
+	RecordedAt time.Time `gorm:"not null;index:idx_user_risk_snapshots,priority:2" json:"recorded_at"`
+}
+
+// TableName overrides the table name used by RiskSnapshotModel to `health_risk_snapshots`
+func (RiskSnapshotModel) TableName() string {
+	return "health_risk_snapshots"
+}
+
+// ToDomain converts RiskSnapshotModel to domain.RiskSnapshot
+func (r *RiskSnapshotModel) ToDomain() *domain.RiskSnapshot {
+	return &domain.RiskSnapshot{
+		ID:         fmt.Sprintf("%d", r.ID),
+		UserID:     r.UserID,
+		RiskScore:  r.RiskScore,
+		RiskLevel:  r.RiskLevel,
+		RecordedAt: r.RecordedAt,
+	}
+}
+
+// FromDomain creates RiskSnapshotModel from domain.RiskSnapshot
+func (r *RiskSnapshotModel) FromDomain(snapshot *domain.RiskSnapshot) {
+	r.UserID = snapshot.UserID
+	r.RiskScore = snapshot.RiskScore
+	r.RiskLevel = snapshot.RiskLevel
+	r.RecordedAt = snapshot.RecordedAt
+}
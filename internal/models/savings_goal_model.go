@@ -0,0 +1,140 @@
+package models
+
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SavingsGoalModel represents the savings_goals table structure in the database
+type SavingsGoalModel struct {
+	ID            string         `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID        string         `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	Name          string         `gorm:"not null;type:varchar(100)" json:"name"`
+	TargetAmount  float64        `gorm:"not null;type:decimal(12,2)" json:"target_amount"`
+	CurrentAmount float64        `gorm:"not null;type:decimal(12,2)" json:"current_amount"`
+	TargetDate    time.Time      `gorm:"not null" json:"target_date"`
+	Priority      int            `gorm:"not null" json:"priority"`
+	CreatedAt     time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (SavingsGoalModel) TableName() string {
+	return "savings_goals"
+}
+
+// BeforeCreate sets the ID if not provided
+func (g *SavingsGoalModel) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == "" {
+		g.ID = "goal-" + uuid.New().String()
+	}
+	if g.CreatedAt.IsZero() {
+		g.CreatedAt = time.Now()
+	}
+	if g.UpdatedAt.IsZero() {
+		g.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate updates the UpdatedAt timestamp
+func (g *SavingsGoalModel) BeforeUpdate(tx *gorm.DB) error {
+	g.UpdatedAt = time.Now()
+	return nil
+}
+
+// ToDomain converts SavingsGoalModel to domain.SavingsGoal
+func (g SavingsGoalModel) ToDomain() domain.SavingsGoal {
+	return domain.SavingsGoal{
+		ID:            g.ID,
+		UserID:        g.UserID,
+		Name:          g.Name,
+		TargetAmount:  g.TargetAmount,
+		CurrentAmount: g.CurrentAmount,
+		TargetDate:    g.TargetDate,
+		Priority:      g.Priority,
+		CreatedAt:     g.CreatedAt,
+		UpdatedAt:     g.UpdatedAt,
+	}
+}
+
+// FromDomain creates SavingsGoalModel from domain.SavingsGoal
+func (g *SavingsGoalModel) FromDomain(goal domain.SavingsGoal) {
+	g.ID = goal.ID
+	g.UserID = goal.UserID
+	g.Name = goal.Name
+	g.TargetAmount = goal.TargetAmount
+	g.CurrentAmount = goal.CurrentAmount
+	g.TargetDate = goal.TargetDate
+	g.Priority = goal.Priority
+	g.CreatedAt = goal.CreatedAt
+	g.UpdatedAt = goal.UpdatedAt
+}
+
+// NewSavingsGoalModelFromDomain creates a new SavingsGoalModel from domain.SavingsGoal
+func NewSavingsGoalModelFromDomain(goal domain.SavingsGoal) *SavingsGoalModel {
+	model := &SavingsGoalModel{}
+	model.FromDomain(goal)
+	return model
+}
+
+// GoalContributionModel represents the goal_contributions table structure in the database
+// Contributions are kept when their goal is deleted so the monthly history stays complete
+type GoalContributionModel struct {
+	ID            string    `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	GoalID        string    `gorm:"not null;index;type:varchar(64)" json:"goal_id"`
+	UserID        string    `gorm:"not null;type:varchar(36);index:idx_goal_contributions_user_date,priority:1" json:"user_id"`
+	Amount        float64   `gorm:"not null;type:decimal(12,2)" json:"amount"`
+	Note          string    `gorm:"type:varchar(255)" json:"note"`
+	ContributedAt time.Time `gorm:"not null;index:idx_goal_contributions_user_date,priority:2" json:"contributed_at"`
+	CreatedAt     time.Time `gorm:"not null" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (GoalContributionModel) TableName() string {
+	return "goal_contributions"
+}
+
+// BeforeCreate sets the ID if not provided
+func (c *GoalContributionModel) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = "contrib-" + uuid.New().String()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now()
+	}
+	return nil
+}
+
+// ToDomain converts GoalContributionModel to domain.GoalContribution
+func (c GoalContributionModel) ToDomain() domain.GoalContribution {
+	return domain.GoalContribution{
+		ID:            c.ID,
+		GoalID:        c.GoalID,
+		UserID:        c.UserID,
+		Amount:        c.Amount,
+		Note:          c.Note,
+		ContributedAt: c.ContributedAt,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+// NewGoalContributionModelFromDomain creates a new GoalContributionModel from domain.GoalContribution
+func NewGoalContributionModelFromDomain(contribution domain.GoalContribution) *GoalContributionModel {
+	return &GoalContributionModel{
+		ID:            contribution.ID,
+		GoalID:        contribution.GoalID,
+		UserID:        contribution.UserID,
+		Amount:        contribution.Amount,
+		Note:          contribution.Note,
+		ContributedAt: contribution.ContributedAt,
+		CreatedAt:     contribution.CreatedAt,
+	}
+}
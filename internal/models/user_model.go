@@ -16,6 +16,7 @@ type UserModel struct {
 	Name         string     `gorm:"type:varchar(255);not null"`
 	PasswordHash string     `gorm:"type:varchar(255);not null"`
 	IsActive     bool       `gorm:"default:true"`
+	Role         string     `gorm:"type:varchar(20);not null;default:'user';index"`
 	LastLoginAt  *time.Time `gorm:"default:null"`
 }
 
@@ -33,8 +34,10 @@ func (m UserModel) ToDomain() domain.User {
 		Name:         m.Name,
 		PasswordHash: m.PasswordHash,
 		IsActive:     m.IsActive,
+		Role:         m.Role,
 		CreatedAt:    m.CreatedAt,
 		UpdatedAt:    m.UpdatedAt,
+		LastLoginAt:  m.LastLoginAt,
 	}
 }
 
@@ -53,6 +56,7 @@ func UserFromDomain(d domain.User) UserModel {
 		Name:         d.Name,
 		PasswordHash: d.PasswordHash,
 		IsActive:     d.IsActive,
+		Role:         d.EffectiveRole(),
 	}
 
 	// Set ID if it exists (for updates)
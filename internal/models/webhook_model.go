@@ -0,0 +1,144 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookModel represents the webhooks table structure in the database
+// EventTypes is stored as a comma-separated list
+type WebhookModel struct {
+	ID         string         `gorm:"primaryKey;type:varchar(64)" json:"id"`
+	UserID     string         `gorm:"not null;index;type:varchar(36)" json:"user_id"`
+	URL        string         `gorm:"not null;type:varchar(2048)" json:"url"`
+	Secret     string         `gorm:"not null;type:varchar(255)" json:"-"`
+	EventTypes string         `gorm:"not null;type:varchar(512)" json:"event_types"`
+	IsActive   bool           `gorm:"not null" json:"is_active"`
+	CreatedAt  time.Time      `gorm:"not null" json:"created_at"`
+	UpdatedAt  time.Time      `gorm:"not null" json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+
+	// Foreign key relationship
+	User UserModel `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (WebhookModel) TableName() string {
+	return "webhooks"
+}
+
+// BeforeCreate sets the ID if not provided
+func (w *WebhookModel) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == "" {
+		w.ID = "wh-" + uuid.New().String()
+	}
+	if w.CreatedAt.IsZero() {
+		w.CreatedAt = time.Now()
+	}
+	if w.UpdatedAt.IsZero() {
+		w.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// BeforeUpdate updates the UpdatedAt timestamp
+func (w *WebhookModel) BeforeUpdate(tx *gorm.DB) error {
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
+// ToDomain converts WebhookModel to domain.Webhook
+func (w WebhookModel) ToDomain() domain.Webhook {
+	var eventTypes []string
+	if w.EventTypes != "" {
+		eventTypes = strings.Split(w.EventTypes, ",")
+	}
+
+	return domain.Webhook{
+		ID:         w.ID,
+		UserID:     w.UserID,
+		URL:        w.URL,
+		Secret:     w.Secret,
+		EventTypes: eventTypes,
+		IsActive:   w.IsActive,
+		CreatedAt:  w.CreatedAt,
+		UpdatedAt:  w.UpdatedAt,
+	}
+}
+
+// FromDomain creates WebhookModel from domain.Webhook
+func (w *WebhookModel) FromDomain(webhook domain.Webhook) {
+	w.ID = webhook.ID
+	w.UserID = webhook.UserID
+	w.URL = webhook.URL
+	w.Secret = webhook.Secret
+	w.EventTypes = strings.Join(webhook.EventTypes, ",")
+	w.IsActive = webhook.IsActive
+	w.CreatedAt = webhook.CreatedAt
+	w.UpdatedAt = webhook.UpdatedAt
+}
+
+// NewWebhookModelFromDomain creates a new WebhookModel from domain.Webhook
+func NewWebhookModelFromDomain(webhook domain.Webhook) *WebhookModel {
+	model := &WebhookModel{}
+	model.FromDomain(webhook)
+	return model
+}
+
+// WebhookDeliveryModel represents the webhook_deliveries table structure in the database.
+// Each row is one delivery attempt, so a retried event has several rows.
+type WebhookDeliveryModel struct {
+	ID         uint      `gorm:"primarykey"`
+	WebhookID  string    `gorm:"not null;type:varchar(64);index:idx_webhook_deliveries_webhook_created,priority:1" json:"webhook_id"`
+	EventID    string    `gorm:"not null;type:varchar(36);index" json:"event_id"`
+	EventType  string    `gorm:"not null;type:varchar(64)" json:"event_type"`
+	Attempt    int       `gorm:"not null" json:"attempt"`
+	StatusCode int       `gorm:"not null;default:0" json:"status_code"`
+	Error      string    `gorm:"type:varchar(1024)" json:"error"`
+	Succeeded  bool      `gorm:"not null;default:false" json:"succeeded"`
+	DurationMs int64     `gorm:"not null;default:0" json:"duration_ms"`
+	CreatedAt  time.Time `gorm:"not null;index:idx_webhook_deliveries_webhook_created,priority:2" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (WebhookDeliveryModel) TableName() string {
+	return "webhook_deliveries"
+}
+
+// ToDomain converts WebhookDeliveryModel to domain.WebhookDelivery
+func (d WebhookDeliveryModel) ToDomain() domain.WebhookDelivery {
+	return domain.WebhookDelivery{
+		ID:         d.ID,
+		WebhookID:  d.WebhookID,
+		EventID:    d.EventID,
+		EventType:  d.EventType,
+		Attempt:    d.Attempt,
+		StatusCode: d.StatusCode,
+		Error:      d.Error,
+		Succeeded:  d.Succeeded,
+		Duration:   time.Duration(d.DurationMs) * time.Millisecond,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+// FromDomain creates WebhookDeliveryModel from domain.WebhookDelivery
+// Errors longer than the column are truncated
+func (d *WebhookDeliveryModel) FromDomain(delivery domain.WebhookDelivery) {
+	d.ID = delivery.ID
+	d.WebhookID = delivery.WebhookID
+	d.EventID = delivery.EventID
+	d.EventType = delivery.EventType
+	d.Attempt = delivery.Attempt
+	d.StatusCode = delivery.StatusCode
+	d.Error = delivery.Error
+	if len(d.Error) > 1024 {
+		d.Error = d.Error[:1024]
+	}
+	d.Succeeded = delivery.Succeeded
+	d.DurationMs = delivery.Duration.Milliseconds()
+	d.CreatedAt = delivery.CreatedAt
+}
@@ -0,0 +1,95 @@
+// Package money holds the frequency arithmetic shared by the finance and health domains, so an
+// amount paid weekly or semiannually is converted to a monthly figure the same way everywhere.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Canonical frequencies
+const (
+	FrequencyDaily      = "daily"
+	FrequencyWeekly     = "weekly"
+	FrequencyBiweekly   = "biweekly"
+	FrequencyMonthly    = "monthly"
+	FrequencyQuarterly  = "quarterly"
+	FrequencySemiannual = "semiannual"
+	FrequencyAnnual     = "annual"
+	FrequencyOneTime    = "one-time"
+)
+
+// MonthsPerYear is the factor between monthly and annual amounts
+const MonthsPerYear = 12
+
+// ErrUnsupportedFrequency is returned for a frequency that is neither canonical nor an alias
+var ErrUnsupportedFrequency = errors.New("unsupported frequency")
+
+// Frequencies are the canonical frequencies, from the most to the least frequent
+var Frequencies = []string{
+	FrequencyDaily, FrequencyWeekly, FrequencyBiweekly, FrequencyMonthly,
+	FrequencyQuarterly, FrequencySemiannual, FrequencyAnnual, FrequencyOneTime,
+}
+
+// Aliases maps the other accepted spellings to their canonical frequency
+var Aliases = map[string]string{
+	"bi-weekly":     FrequencyBiweekly,
+	"semiannually":  FrequencySemiannual,
+	"semi-annually": FrequencySemiannual,
+	"annually":      FrequencyAnnual,
+	"yearly":        FrequencyAnnual,
+}
+
+// canonical lists every canonical frequency for CanonicalFrequency
+var canonical = func() map[string]bool {
+	set := make(map[string]bool, len(Frequencies))
+	for _, frequency := range Frequencies {
+		set[frequency] = true
+	}
+	return set
+}()
+
+// CanonicalFrequency returns the canonical spelling of frequency, ignoring case and surrounding
+// spaces. The second result is false if the frequency is not supported.
+func CanonicalFrequency(frequency string) (string, bool) {
+	frequency = strings.ToLower(strings.TrimSpace(frequency))
+	if alias, ok := Aliases[frequency]; ok {
+		return alias, true
+	}
+	if canonical[frequency] {
+		return frequency, true
+	}
+	return "", false
+}
+
+// NormalizeToMonthly converts an amount paid at frequency to its monthly equivalent.
+// Returns an error wrapping ErrUnsupportedFrequency if the frequency is not supported.
+func NormalizeToMonthly(amount float64, frequency string) (float64, error) {
+	normalized, ok := CanonicalFrequency(frequency)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupportedFrequency, frequency)
+	}
+
+	switch normalized {
+	case FrequencyDaily:
+		return amount * 30, nil // 30 days per month
+	case FrequencyWeekly:
+		return amount * 4.33, nil // Average weeks per month (52/12)
+	case FrequencyBiweekly:
+		return amount * 2.17, nil // Every two weeks (26 periods / 12 months)
+	case FrequencyQuarterly:
+		return amount / 3, nil
+	case FrequencySemiannual:
+		return amount / 6, nil
+	case FrequencyAnnual, FrequencyOneTime:
+		return amount / MonthsPerYear, nil // One-time amounts are spread over a year
+	default:
+		return amount, nil
+	}
+}
+
+// AnnualizeFromMonthly converts a monthly amount to its annual equivalent
+func AnnualizeFromMonthly(monthly float64) float64 {
+	return monthly * MonthsPerYear
+}
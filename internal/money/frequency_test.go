@@ -0,0 +1,68 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeToMonthly(t *testing.T) {
+	tests := []struct {
+		frequency string
+		amount    float64
+		expected  float64
+	}{
+		{"daily", 10, 300},
+		{"weekly", 100, 433},
+		{"biweekly", 100, 217},
+		{"bi-weekly", 100, 217},
+		{"monthly", 250, 250},
+		{"quarterly", 300, 100},
+		{"semiannual", 600, 100},
+		{"semiannually", 600, 100},
+		{"semi-annually", 600, 100},
+		{"annual", 1200, 100},
+		{"annually", 1200, 100},
+		{"yearly", 1200, 100},
+		{"one-time", 1200, 100},
+		{" Monthly ", 250, 250},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frequency, func(t *testing.T) {
+			monthly, err := NormalizeToMonthly(tt.amount, tt.frequency)
+
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, monthly, 0.001)
+		})
+	}
+}
+
+func TestNormalizeToMonthly_UnsupportedFrequency(t *testing.T) {
+	_, err := NormalizeToMonthly(100, "fortnightly")
+
+	assert.ErrorIs(t, err, ErrUnsupportedFrequency)
+	assert.EqualError(t, err, "unsupported frequency: fortnightly")
+}
+
+func TestCanonicalFrequency(t *testing.T) {
+	for _, frequency := range Frequencies {
+		canonical, ok := CanonicalFrequency(frequency)
+		assert.True(t, ok, frequency)
+		assert.Equal(t, frequency, canonical)
+	}
+	for alias, expected := range Aliases {
+		canonical, ok := CanonicalFrequency(alias)
+		assert.True(t, ok, alias)
+		assert.Equal(t, expected, canonical)
+	}
+
+	_, ok := CanonicalFrequency("one_time")
+	assert.False(t, ok)
+}
+
+func TestAnnualizeFromMonthly(t *testing.T) {
+	assert.Equal(t, 1200.0, AnnualizeFromMonthly(100))
+	assert.Zero(t, AnnualizeFromMonthly(0))
+}
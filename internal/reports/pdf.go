@@ -0,0 +1,50 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// PDFRenderer converts a standalone HTML document to PDF
+type PDFRenderer interface {
+	RenderPDF(ctx context.Context, html []byte) ([]byte, error)
+}
+
+// WKHTMLToPDFRenderer renders PDFs by running wkhtmltopdf, reading the HTML from its stdin and
+// the PDF from its stdout
+type WKHTMLToPDFRenderer struct {
+	path string
+}
+
+// NewWKHTMLToPDFRenderer creates a renderer that runs command, looked up on the PATH if it
+// isn't a path. Returns an error if the executable can't be found.
+func NewWKHTMLToPDFRenderer(command string) (*WKHTMLToPDFRenderer, error) {
+	path, err := exec.LookPath(command)
+	if err != nil {
+		return nil, fmt.Errorf("wkhtmltopdf not found: %w", err)
+	}
+	return &WKHTMLToPDFRenderer{path: path}, nil
+}
+
+// RenderPDF runs wkhtmltopdf on html. The process is killed if ctx is done first.
+func (r *WKHTMLToPDFRenderer) RenderPDF(ctx context.Context, html []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.path, "--quiet", "--encoding", "utf-8", "-", "-")
+	cmd.Stdin = bytes.NewReader(html)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to render PDF: %w", ctx.Err())
+		}
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("failed to render PDF: %w: %s", err, message)
+		}
+		return nil, fmt.Errorf("failed to render PDF: %w", err)
+	}
+	return stdout.Bytes(), nil
+}
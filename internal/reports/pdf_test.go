@@ -0,0 +1,48 @@
+package reports
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFakeCommand writes an executable shell script standing in for wkhtmltopdf
+func writeFakeCommand(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wkhtmltopdf")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755))
+	return path
+}
+
+func TestWKHTMLToPDFRenderer_RenderPDF(t *testing.T) {
+	// The fake reads the HTML from stdin and writes a "PDF" to stdout
+	renderer, err := NewWKHTMLToPDFRenderer(writeFakeCommand(t, `printf '%%PDF-1.4 '; cat`))
+	require.NoError(t, err)
+
+	pdf, err := renderer.RenderPDF(context.Background(), []byte("<p>statement</p>"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4 <p>statement</p>", string(pdf))
+}
+
+func TestWKHTMLToPDFRenderer_RenderPDF_Failure(t *testing.T) {
+	renderer, err := NewWKHTMLToPDFRenderer(writeFakeCommand(t, "echo 'cannot load page' >&2\nexit 1"))
+	require.NoError(t, err)
+
+	pdf, err := renderer.RenderPDF(context.Background(), []byte("<p>statement</p>"))
+
+	assert.Nil(t, pdf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to render PDF")
+	assert.Contains(t, err.Error(), "cannot load page")
+}
+
+func TestNewWKHTMLToPDFRenderer_MissingCommand(t *testing.T) {
+	_, err := NewWKHTMLToPDFRenderer(filepath.Join(t.TempDir(), "missing"))
+
+	assert.ErrorContains(t, err, "wkhtmltopdf not found")
+}
@@ -0,0 +1,88 @@
+// Package reports renders the documents users download and archive. Monthly statements are
+// rendered to HTML from an embedded html/template; a PDFRenderer converts that HTML to PDF.
+package reports
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+//go:embed templates/monthly_statement.html
+var monthlyStatementSource string
+
+var monthlyStatementTemplate = template.Must(template.New("monthly_statement").Funcs(template.FuncMap{
+	"money":  formatMoney,
+	"label":  formatLabel,
+	"score":  formatScore,
+	"signed": formatSigned,
+}).Parse(monthlyStatementSource))
+
+// statementView adds the figures the template can't compute itself to the statement
+type statementView struct {
+	*domain.MonthlyStatement
+	NetSavings     float64
+	ScoreChange    float64
+	HasScoreChange bool
+}
+
+// RenderMonthlyStatementHTML writes the statement as a standalone HTML page. A statement without
+// any records renders an explicit empty state. The page depends only on the statement, so the
+// same statement always renders the same bytes.
+func RenderMonthlyStatementHTML(w io.Writer, statement *domain.MonthlyStatement) error {
+	view := statementView{
+		MonthlyStatement: statement,
+		NetSavings:       statement.NetSavings(),
+	}
+	view.ScoreChange, view.HasScoreChange = statement.ScoreChange()
+
+	if err := monthlyStatementTemplate.Execute(w, view); err != nil {
+		return fmt.Errorf("failed to render monthly statement: %w", err)
+	}
+	return nil
+}
+
+// formatMoney formats an amount with two decimals and thousands separators, e.g. -1,234.50
+func formatMoney(amount float64) string {
+	formatted := fmt.Sprintf("%.2f", amount)
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign, formatted = "-", formatted[1:]
+	}
+	if formatted == "0.00" {
+		sign = ""
+	}
+
+	whole, cents := formatted[:len(formatted)-3], formatted[len(formatted)-3:]
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return sign + grouped.String() + cents
+}
+
+// formatLabel turns a stored value such as "doctor_visit" into "Doctor visit"
+func formatLabel(value string) string {
+	label := strings.ReplaceAll(value, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// formatScore formats a 0-100 score with one decimal
+func formatScore(score float64) string {
+	return fmt.Sprintf("%.1f", score)
+}
+
+// formatSigned formats a score change with its sign, e.g. +2.5 or -1.0
+func formatSigned(change float64) string {
+	return fmt.Sprintf("%+.1f", change)
+}
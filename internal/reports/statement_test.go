@@ -0,0 +1,96 @@
+package reports
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func reportTestStatement() *domain.MonthlyStatement {
+	return &domain.MonthlyStatement{
+		UserID: "user-123",
+		Year:   2025,
+		Month:  time.March,
+		Finance: domain.MonthlyFinanceActivity{
+			Currency:    "USD",
+			Income:      []domain.StatementLine{{Label: "Salary", Amount: 4000, Count: 1}},
+			TotalIncome: 4000,
+			Expenses: []domain.StatementLine{
+				{Label: "housing", Amount: 1500, Count: 1},
+				{Label: "food", Amount: 420.5, Count: 2},
+			},
+			TotalExpenses:     1920.5,
+			LoanPayments:      []domain.StatementLoanPayment{{LoanID: "loan-1", Lender: "Acme <Bank>", Amount: 400, InterestPortion: 92, PrincipalPortion: 308, Payments: 1}},
+			TotalLoanPayments: 400,
+			StartScore:        &domain.FinancialHealthScore{Score: 61.25, Label: "fair"},
+			EndScore:          &domain.FinancialHealthScore{Score: 66, Label: "good"},
+			RecordCount:       5,
+			LastUpdated:       time.Date(2025, time.March, 28, 14, 30, 0, 0, time.UTC),
+		},
+		Health: domain.MonthlyHealthSpend{TotalAmount: 250, CoveredAmount: 200, OutOfPocketAmount: 50, ExpenseCount: 1},
+	}
+}
+
+func TestRenderMonthlyStatementHTML(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := RenderMonthlyStatementHTML(&buf, reportTestStatement())
+
+	require.NoError(t, err)
+	page := buf.String()
+	assert.Contains(t, page, "<title>Monthly statement: March 2025</title>")
+	assert.Contains(t, page, "Amounts in USD")
+	assert.Contains(t, page, "<td>Salary</td><td class=\"amount\">4,000.00</td>")
+	assert.Contains(t, page, "<td>Housing</td>")
+	assert.Contains(t, page, "<td class=\"amount\">420.50</td>")
+	assert.Contains(t, page, "<td>Acme &lt;Bank&gt;</td>", "record values are escaped")
+	assert.Contains(t, page, "<td class=\"amount\">1,629.50</td>", "net savings")
+	assert.Contains(t, page, "61.2 (fair)")
+	assert.Contains(t, page, "66.0 (good)")
+	assert.Contains(t, page, "&#43;4.8", "html/template escapes the plus sign")
+	assert.Contains(t, page, "Records last changed 28 March 2025 14:30 UTC")
+	assert.NotContains(t, page, "Nothing was recorded")
+
+	var again bytes.Buffer
+	require.NoError(t, RenderMonthlyStatementHTML(&again, reportTestStatement()))
+	assert.Equal(t, page, again.String(), "the same statement renders the same page")
+}
+
+func TestRenderMonthlyStatementHTML_EmptyState(t *testing.T) {
+	var buf bytes.Buffer
+	statement := &domain.MonthlyStatement{UserID: "user-123", Year: 2024, Month: time.June, Finance: domain.MonthlyFinanceActivity{Currency: "USD"}}
+
+	err := RenderMonthlyStatementHTML(&buf, statement)
+
+	require.NoError(t, err)
+	page := buf.String()
+	assert.Contains(t, page, "Nothing was recorded for June 2024")
+	assert.NotContains(t, page, "Net savings")
+	assert.NotContains(t, page, "Records last changed")
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := map[float64]string{
+		0:           "0.00",
+		-0.001:      "0.00",
+		12.5:        "12.50",
+		999.999:     "1,000.00",
+		1234.5:      "1,234.50",
+		-1234.5:     "-1,234.50",
+		1234567.891: "1,234,567.89",
+	}
+	for amount, want := range tests {
+		assert.Equal(t, want, formatMoney(amount), "formatMoney(%v)", amount)
+	}
+}
+
+func TestFormatLabel(t *testing.T) {
+	assert.Equal(t, "Doctor visit", formatLabel("doctor_visit"))
+	assert.Equal(t, "Housing", formatLabel("housing"))
+	assert.Equal(t, "", formatLabel(""))
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// apiKeyRepository implements services.APIKeyRepository using GORM
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *gorm.DB) services.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// Create saves a new API key and copies the generated ID and creation time back to it
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	model := models.NewAPIKeyModelFromDomain(*key)
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	key.ID = model.ID
+	key.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// GetByID retrieves an API key by its ID
+func (r *apiKeyRepository) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+// GetByHashedKey retrieves the API key with the given SHA-256 hash
+func (r *apiKeyRepository) GetByHashedKey(ctx context.Context, hashedKey string) (domain.APIKey, error) {
+	return r.first(ctx, "hashed_key = ?", hashedKey)
+}
+
+func (r *apiKeyRepository) first(ctx context.Context, query string, arg string) (domain.APIKey, error) {
+	var model models.APIKeyModel
+
+	err := dbFromContext(ctx, r.db).First(&model, query, arg).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.APIKey{}, domain.ErrAPIKeyNotFound
+		}
+		return domain.APIKey{}, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// GetUserAPIKeys retrieves all API keys for a user, oldest first
+func (r *apiKeyRepository) GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	var keyModels []models.APIKeyModel
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&keyModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user API keys: %w", err)
+	}
+
+	keys := make([]domain.APIKey, len(keyModels))
+	for i, model := range keyModels {
+		keys[i] = model.ToDomain()
+	}
+
+	return keys, nil
+}
+
+// Revoke marks an API key as revoked; revoking an already revoked key keeps the original time
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	key, err := r.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if key.IsRevoked() {
+		return nil
+	}
+
+	err = dbFromContext(ctx, r.db).Model(&models.APIKeyModel{}).
+		Where("id = ?", id).
+		Update("revoked_at", revokedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateLastUsed records when an API key last authenticated a request
+func (r *apiKeyRepository) UpdateLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	err := dbFromContext(ctx, r.db).Model(&models.APIKeyModel{}).
+		Where("id = ?", id).
+		Update("last_used_at", usedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to update API key last used time: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAPIKeyTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.APIKeyModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestAPIKey(userID, hashedKey string) *domain.APIKey {
+	return &domain.APIKey{
+		UserID:    userID,
+		Name:      "Export script",
+		HashedKey: hashedKey,
+		Prefix:    "bob_0123abcd",
+		Scopes:    []string{domain.APIKeyScopeFinanceRead, domain.APIKeyScopeHealthRead},
+	}
+}
+
+func TestAPIKeyRepository_CreateAndGet(t *testing.T) {
+	repo := NewAPIKeyRepository(setupAPIKeyTestDB(t))
+	ctx := context.Background()
+
+	key := createTestAPIKey("user-1", "hash-1")
+	require.NoError(t, repo.Create(ctx, key))
+	assert.NotEmpty(t, key.ID)
+	assert.False(t, key.CreatedAt.IsZero())
+
+	found, err := repo.GetByHashedKey(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, key.ID, found.ID)
+	assert.Equal(t, "user-1", found.UserID)
+	assert.Equal(t, []string{domain.APIKeyScopeFinanceRead, domain.APIKeyScopeHealthRead}, found.Scopes)
+	assert.Nil(t, found.LastUsedAt)
+	assert.Nil(t, found.RevokedAt)
+
+	_, err = repo.GetByHashedKey(ctx, "hash-missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	_, err = repo.GetByID(ctx, "key-missing")
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+
+	// Hashes are unique
+	assert.Error(t, repo.Create(ctx, createTestAPIKey("user-2", "hash-1")))
+}
+
+func TestAPIKeyRepository_RevokeAndUpdateLastUsed(t *testing.T) {
+	repo := NewAPIKeyRepository(setupAPIKeyTestDB(t))
+	ctx := context.Background()
+
+	key := createTestAPIKey("user-1", "hash-1")
+	require.NoError(t, repo.Create(ctx, key))
+
+	usedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+	require.NoError(t, repo.UpdateLastUsed(ctx, key.ID, usedAt))
+
+	revokedAt := time.Now().Truncate(time.Second)
+	require.NoError(t, repo.Revoke(ctx, key.ID, revokedAt))
+	// A second revocation keeps the first time
+	require.NoError(t, repo.Revoke(ctx, key.ID, revokedAt.Add(time.Hour)))
+
+	found, err := repo.GetByID(ctx, key.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found.LastUsedAt)
+	assert.True(t, usedAt.Equal(*found.LastUsedAt))
+	require.NotNil(t, found.RevokedAt)
+	assert.True(t, revokedAt.Equal(*found.RevokedAt))
+
+	assert.ErrorIs(t, repo.Revoke(ctx, "key-missing", revokedAt), domain.ErrAPIKeyNotFound)
+}
+
+func TestAPIKeyRepository_GetUserAPIKeys(t *testing.T) {
+	repo := NewAPIKeyRepository(setupAPIKeyTestDB(t))
+	ctx := context.Background()
+
+	first := createTestAPIKey("user-1", "hash-1")
+	first.CreatedAt = time.Now().Add(-time.Hour)
+	require.NoError(t, repo.Create(ctx, first))
+	second := createTestAPIKey("user-1", "hash-2")
+	require.NoError(t, repo.Create(ctx, second))
+	require.NoError(t, repo.Create(ctx, createTestAPIKey("user-2", "hash-3")))
+	require.NoError(t, repo.Revoke(ctx, second.ID, time.Now()))
+
+	keys, err := repo.GetUserAPIKeys(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, first.ID, keys[0].ID)
+	// Revoked keys are still listed
+	assert.Equal(t, second.ID, keys[1].ID)
+	assert.True(t, keys[1].IsRevoked())
+}
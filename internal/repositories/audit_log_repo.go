@@ -0,0 +1,69 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// auditLogRepository implements services.AuditLogRepository using GORM
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB) services.AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+// SaveEntries inserts audit entries in a single statement
+func (r *auditLogRepository) SaveEntries(ctx context.Context, entries []domain.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	auditModels := make([]models.AuditLogModel, len(entries))
+	for i, entry := range entries {
+		if err := auditModels[i].FromDomain(entry); err != nil {
+			return fmt.Errorf("failed to encode audit entry changes: %w", err)
+		}
+	}
+
+	if err := dbFromContext(ctx, r.db).Create(&auditModels).Error; err != nil {
+		return fmt.Errorf("failed to save audit entries: %w", err)
+	}
+
+	return nil
+}
+
+// GetEntriesBefore retrieves up to limit entries that come before cursor, newest first,
+// ordered by creation time and then ID so pages stay stable while new entries are added
+func (r *auditLogRepository) GetEntriesBefore(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.AuditEntry, error) {
+	var auditModels []models.AuditLogModel
+
+	query := dbFromContext(ctx, r.db)
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if !cursor.IsZero() {
+		query = query.Where("created_at < ? OR (created_at = ? AND id < ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	result := query.Order("created_at DESC, id DESC").Limit(limit).Find(&auditModels)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", result.Error)
+	}
+
+	entries := make([]domain.AuditEntry, len(auditModels))
+	for i, model := range auditModels {
+		entries[i] = model.ToDomain()
+	}
+
+	return entries, nil
+}
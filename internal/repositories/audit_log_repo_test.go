@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupAuditLogTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.AuditLogModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestAuditLogRepository_SaveEntries_RoundTrip(t *testing.T) {
+	repo := NewAuditLogRepository(setupAuditLogTestDB(t))
+	ctx := context.Background()
+
+	entry := domain.AuditEntry{
+		UserID:       "user-1",
+		Action:       domain.AuditActionUpdate,
+		ResourceType: domain.AuditResourceIncome,
+		ResourceID:   "income-1",
+		RequestID:    "req-1",
+		IPAddress:    "203.0.113.7",
+		UserAgent:    "curl/8.0",
+		Changes:      map[string]domain.AuditChange{"amount": {Before: 5000.0, After: 5500.0}},
+		CreatedAt:    time.Date(2026, 1, 15, 10, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, repo.SaveEntries(ctx, []domain.AuditEntry{entry}))
+	require.NoError(t, repo.SaveEntries(ctx, nil))
+
+	entries, err := repo.GetEntriesBefore(ctx, "user-1", domain.Cursor{}, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	saved := entries[0]
+	assert.NotEmpty(t, saved.ID)
+	assert.Equal(t, "income-1", saved.ResourceID)
+	assert.Equal(t, "req-1", saved.RequestID)
+	assert.Equal(t, "203.0.113.7", saved.IPAddress)
+	assert.Equal(t, "curl/8.0", saved.UserAgent)
+	assert.True(t, entry.CreatedAt.Equal(saved.CreatedAt))
+	assert.Equal(t, map[string]domain.AuditChange{"amount": {Before: 5000.0, After: 5500.0}}, saved.Changes)
+}
+
+func TestAuditLogRepository_GetEntriesBefore_WalksNewestFirst(t *testing.T) {
+	repo := NewAuditLogRepository(setupAuditLogTestDB(t))
+	ctx := context.Background()
+
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	var entries []domain.AuditEntry
+	for i := 0; i < 7; i++ {
+		entries = append(entries, domain.AuditEntry{
+			ID:           fmt.Sprintf("audit-%d", i),
+			UserID:       "user-1",
+			Action:       domain.AuditActionCreate,
+			ResourceType: domain.AuditResourceExpense,
+			// Several entries share a creation time, so the ID tie-break decides their order
+			CreatedAt: base.Add(time.Duration(i/2) * time.Minute),
+		})
+	}
+	entries = append(entries, domain.AuditEntry{ID: "audit-other", UserID: "user-2", Action: domain.AuditActionLogin, ResourceType: domain.AuditResourceUser, CreatedAt: base})
+	require.NoError(t, repo.SaveEntries(ctx, entries))
+
+	var got []string
+	cursor := domain.Cursor{}
+	for page := 0; page < 10; page++ {
+		batch, err := repo.GetEntriesBefore(ctx, "user-1", cursor, 3)
+		require.NoError(t, err)
+		if len(batch) == 0 {
+			break
+		}
+		for _, entry := range batch {
+			got = append(got, entry.ID)
+		}
+		last := batch[len(batch)-1]
+		cursor = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	assert.Equal(t, []string{"audit-6", "audit-5", "audit-4", "audit-3", "audit-2", "audit-1", "audit-0"}, got)
+
+	// Without a user filter every user's entries are returned
+	all, err := repo.GetEntriesBefore(ctx, "", domain.Cursor{}, 100)
+	require.NoError(t, err)
+	assert.Len(t, all, 8)
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// budgetRepository implements services.BudgetRepository using GORM
+type budgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new budget repository instance
+func NewBudgetRepository(db *gorm.DB) services.BudgetRepository {
+	return &budgetRepository{
+		db: db,
+	}
+}
+
+// SaveBudget creates a new budget record
+func (r *budgetRepository) SaveBudget(ctx context.Context, budget domain.Budget) error {
+	model := models.NewBudgetModelFromDomain(budget)
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return domain.ErrBudgetExists
+		}
+		return fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return nil
+}
+
+// GetBudgetByID retrieves a budget by its ID
+func (r *budgetRepository) GetBudgetByID(ctx context.Context, id string) (domain.Budget, error) {
+	var model models.BudgetModel
+
+	result := dbFromContext(ctx, r.db).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.Budget{}, domain.ErrBudgetNotFound
+		}
+		return domain.Budget{}, fmt.Errorf("failed to get budget by ID: %w", result.Error)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// UpdateBudget updates an existing budget record
+func (r *budgetRepository) UpdateBudget(ctx context.Context, budget domain.Budget) error {
+	model := models.NewBudgetModelFromDomain(budget)
+
+	// Select the editable columns so zero values (e.g. turning rollover off) are saved
+	result := dbFromContext(ctx, r.db).Model(&models.BudgetModel{}).
+		Where("id = ?", budget.ID).
+		Select("category", "monthly_limit", "rollover", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		if isDuplicateKeyError(result.Error) {
+			return domain.ErrBudgetExists
+		}
+		return fmt.Errorf("failed to update budget: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+// DeleteBudget permanently deletes a budget record
+func (r *budgetRepository) DeleteBudget(ctx context.Context, id string) error {
+	result := dbFromContext(ctx, r.db).Delete(&models.BudgetModel{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete budget: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+// GetUserBudgets retrieves all budgets for a specific user, ordered by category
+func (r *budgetRepository) GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error) {
+	var models []models.BudgetModel
+
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Order("category ASC").Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user budgets: %w", result.Error)
+	}
+
+	budgets := make([]domain.Budget, len(models))
+	for i, model := range models {
+		budgets[i] = model.ToDomain()
+	}
+
+	return budgets, nil
+}
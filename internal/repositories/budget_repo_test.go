@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupBudgetTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&models.BudgetModel{}))
+	return db
+}
+
+func createTestBudget(id, userID, category string, limit float64) domain.Budget {
+	return domain.Budget{
+		ID:           id,
+		UserID:       userID,
+		Category:     category,
+		MonthlyLimit: limit,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+func TestBudgetRepository_CRUD(t *testing.T) {
+	repo := NewBudgetRepository(setupBudgetTestDB(t))
+	ctx := context.Background()
+
+	budget := createTestBudget("budget-1", "user-1", domain.CategoryFood, 600)
+	budget.Rollover = true
+	require.NoError(t, repo.SaveBudget(ctx, budget))
+
+	saved, err := repo.GetBudgetByID(ctx, "budget-1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CategoryFood, saved.Category)
+	assert.Equal(t, 600.0, saved.MonthlyLimit)
+	assert.True(t, saved.Rollover)
+
+	// Zero values must be written, not skipped
+	saved.MonthlyLimit = 450
+	saved.Rollover = false
+	require.NoError(t, repo.UpdateBudget(ctx, saved))
+
+	updated, err := repo.GetBudgetByID(ctx, "budget-1")
+	require.NoError(t, err)
+	assert.Equal(t, 450.0, updated.MonthlyLimit)
+	assert.False(t, updated.Rollover)
+
+	require.NoError(t, repo.DeleteBudget(ctx, "budget-1"))
+	_, err = repo.GetBudgetByID(ctx, "budget-1")
+	assert.ErrorIs(t, err, domain.ErrBudgetNotFound)
+	assert.ErrorIs(t, repo.DeleteBudget(ctx, "budget-1"), domain.ErrBudgetNotFound)
+	assert.ErrorIs(t, repo.UpdateBudget(ctx, saved), domain.ErrBudgetNotFound)
+}
+
+func TestBudgetRepository_OneBudgetPerCategory(t *testing.T) {
+	repo := NewBudgetRepository(setupBudgetTestDB(t))
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-1", "user-1", domain.CategoryFood, 600)))
+	err := repo.SaveBudget(ctx, createTestBudget("budget-2", "user-1", domain.CategoryFood, 300))
+	assert.ErrorIs(t, err, domain.ErrBudgetExists)
+
+	// Another user, or a deleted budget's category, is free
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-3", "user-2", domain.CategoryFood, 300)))
+	require.NoError(t, repo.DeleteBudget(ctx, "budget-1"))
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-4", "user-1", domain.CategoryFood, 500)))
+}
+
+func TestBudgetRepository_GetUserBudgets_OrderedByCategory(t *testing.T) {
+	repo := NewBudgetRepository(setupBudgetTestDB(t))
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-t", "user-1", domain.CategoryTransport, 200)))
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-f", "user-1", domain.CategoryFood, 600)))
+	require.NoError(t, repo.SaveBudget(ctx, createTestBudget("budget-o", "user-2", domain.CategoryOther, 100)))
+
+	budgets, err := repo.GetUserBudgets(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, budgets, 2)
+	assert.Equal(t, "budget-f", budgets[0].ID)
+	assert.Equal(t, "budget-t", budgets[1].ID)
+}
@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// conditionCatalogRepository implements services.ConditionCatalogRepository using GORM
+type conditionCatalogRepository struct {
+	db *gorm.DB
+}
+
+// NewConditionCatalogRepository creates a new condition catalog repository instance
+func NewConditionCatalogRepository(db *gorm.DB) services.ConditionCatalogRepository {
+	return &conditionCatalogRepository{
+		db: db,
+	}
+}
+
+// List retrieves every catalog entry in name order
+func (r *conditionCatalogRepository) List(ctx context.Context) ([]domain.ConditionCatalogEntry, error) {
+	var entryModels []models.ConditionCatalogModel
+
+	if err := dbFromContext(ctx, r.db).Order("name ASC").Find(&entryModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list condition catalog: %w", err)
+	}
+
+	entries := make([]domain.ConditionCatalogEntry, len(entryModels))
+	for i, model := range entryModels {
+		entries[i] = model.ToDomain()
+	}
+	return entries, nil
+}
+
+// GetByID retrieves a catalog entry by its ID
+func (r *conditionCatalogRepository) GetByID(ctx context.Context, id string) (domain.ConditionCatalogEntry, error) {
+	var model models.ConditionCatalogModel
+
+	if err := dbFromContext(ctx, r.db).Where("id = ?", id).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ConditionCatalogEntry{}, fmt.Errorf("%w: %s", domain.ErrConditionCatalogEntryNotFound, id)
+		}
+		return domain.ConditionCatalogEntry{}, fmt.Errorf("failed to get condition catalog entry: %w", err)
+	}
+	return model.ToDomain(), nil
+}
@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+func setupConditionCatalogTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.ConditionCatalogModel{}))
+
+	entries := []models.ConditionCatalogModel{
+		{ID: "I10", Name: "Hypertension", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 6, Aliases: []string{"high blood pressure"}},
+		{ID: "E11", Name: "Type 2 Diabetes", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 8, Aliases: []string{"diabetes type ii"}},
+	}
+	require.NoError(t, db.Create(&entries).Error)
+	return db
+}
+
+func TestConditionCatalogRepository_List_OrdersByName(t *testing.T) {
+	repo := NewConditionCatalogRepository(setupConditionCatalogTestDB(t))
+
+	entries, err := repo.List(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "I10", entries[0].ID)
+	assert.Equal(t, "E11", entries[1].ID)
+	assert.Equal(t, []string{"diabetes type ii"}, entries[1].Aliases)
+}
+
+func TestConditionCatalogRepository_GetByID(t *testing.T) {
+	repo := NewConditionCatalogRepository(setupConditionCatalogTestDB(t))
+
+	entry, err := repo.GetByID(context.Background(), "E11")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ConditionCatalogEntry{
+		ID:              "E11",
+		Name:            "Type 2 Diabetes",
+		Category:        "chronic",
+		DefaultSeverity: "moderate",
+		RiskWeight:      8,
+		Aliases:         []string{"diabetes type ii"},
+	}, entry)
+
+	_, err = repo.GetByID(context.Background(), "Z99")
+	assert.ErrorIs(t, err, domain.ErrConditionCatalogEntryNotFound)
+	assert.Contains(t, err.Error(), "Z99")
+}
@@ -0,0 +1,60 @@
+package repositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(
+			&models.UserModel{},
+			&models.RefreshTokenModel{},
+			&models.IncomeModel{},
+			&models.ExpenseModel{},
+			&models.LoanModel{},
+			&models.LoanBalanceEntryModel{},
+			&models.HealthProfileModel{},
+			&models.ProfileSnapshotModel{},
+			&models.MedicalConditionModel{},
+			&models.MedicalExpenseModel{},
+			&models.MedicalExpenseOccurrenceModel{},
+			&models.InsurancePolicyModel{},
+			&models.RiskSnapshotModel{},
+			// Deleting a user purges every table with records they own
+			&models.APIKeyModel{},
+			&models.WebhookModel{},
+			&models.WebhookDeliveryModel{},
+			&models.IdempotencyKeyModel{},
+			&models.DemoDataRecordModel{},
+			&models.SavingsGoalModel{},
+			&models.GoalContributionModel{},
+			&models.BudgetModel{},
+			&models.FinanceSummaryModel{},
+			&models.ExpenseAttachmentModel{},
+			&models.MedicationScheduleModel{},
+		))
+
+		return repotest.Repositories{
+			User:                     NewUserRepository(db),
+			Token:                    NewTokenRepository(db),
+			Income:                   NewIncomeRepository(db),
+			Expense:                  NewExpenseRepository(db),
+			Loan:                     NewLoanRepository(db),
+			HealthProfile:            NewHealthProfileRepository(db),
+			MedicalCondition:         NewMedicalConditionRepository(db),
+			MedicalExpense:           NewMedicalExpenseRepository(db),
+			MedicalExpenseOccurrence: NewMedicalExpenseOccurrenceRepository(db),
+			InsurancePolicy:          NewInsurancePolicyRepository(db),
+			HealthRiskSnapshot:       NewHealthRiskSnapshotRepository(db),
+		}
+	})
+}
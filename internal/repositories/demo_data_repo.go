@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// demoDataRepository implements services.DemoDataRepository using GORM
+type demoDataRepository struct {
+	db *gorm.DB
+}
+
+// NewDemoDataRepository creates a new demo data repository instance
+func NewDemoDataRepository(db *gorm.DB) services.DemoDataRepository {
+	return &demoDataRepository{
+		db: db,
+	}
+}
+
+// SaveRecords saves the records of a seeding in a single statement
+func (r *demoDataRepository) SaveRecords(ctx context.Context, records []domain.DemoDataRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	recordModels := make([]models.DemoDataRecordModel, len(records))
+	for i, record := range records {
+		recordModels[i].FromDomain(record)
+	}
+
+	if err := dbFromContext(ctx, r.db).Create(&recordModels).Error; err != nil {
+		return fmt.Errorf("failed to save demo data records: %w", err)
+	}
+	return nil
+}
+
+// GetUserRecords retrieves every demo data record of a user, oldest first
+func (r *demoDataRepository) GetUserRecords(ctx context.Context, userID string) ([]domain.DemoDataRecord, error) {
+	var recordModels []models.DemoDataRecordModel
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&recordModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get demo data records: %w", err)
+	}
+
+	records := make([]domain.DemoDataRecord, len(recordModels))
+	for i, model := range recordModels {
+		records[i] = model.ToDomain()
+	}
+	return records, nil
+}
+
+// DeleteUserRecords deletes every demo data record of a user; the seeded records are untouched
+func (r *demoDataRepository) DeleteUserRecords(ctx context.Context, userID string) error {
+	err := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Delete(&models.DemoDataRecordModel{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete demo data records: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupDemoDataTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.DemoDataRecordModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestDemoDataRepository_SaveGetAndDelete(t *testing.T) {
+	repo := NewDemoDataRepository(setupDemoDataTestDB(t))
+	ctx := context.Background()
+	seededAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	require.NoError(t, repo.SaveRecords(ctx, []domain.DemoDataRecord{
+		{UserID: "user-1", BatchID: "demo-1", Resource: domain.AuditResourceIncome, RecordID: "income-1", CreatedAt: seededAt},
+		{UserID: "user-1", BatchID: "demo-1", Resource: domain.AuditResourceHealthProfile, RecordID: "1", CreatedAt: seededAt},
+		{UserID: "user-2", BatchID: "demo-2", Resource: domain.AuditResourceLoan, RecordID: "loan-1", CreatedAt: seededAt},
+	}))
+	require.NoError(t, repo.SaveRecords(ctx, nil), "saving no records is a no-op")
+
+	records, err := repo.GetUserRecords(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.ElementsMatch(t, []string{"income-1", "1"}, []string{records[0].RecordID, records[1].RecordID})
+	for _, record := range records {
+		assert.NotEmpty(t, record.ID)
+		assert.Equal(t, "demo-1", record.BatchID)
+		assert.True(t, seededAt.Equal(record.CreatedAt))
+	}
+
+	require.NoError(t, repo.DeleteUserRecords(ctx, "user-1"))
+
+	records, err = repo.GetUserRecords(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	records, err = repo.GetUserRecords(ctx, "user-2")
+	require.NoError(t, err)
+	assert.Len(t, records, 1, "other users' records are kept")
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// expenseAttachmentRepository implements services.ExpenseAttachmentRepository
+type expenseAttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewExpenseAttachmentRepository creates a new expense attachment repository
+func NewExpenseAttachmentRepository(db *gorm.DB) services.ExpenseAttachmentRepository {
+	return &expenseAttachmentRepository{db: db}
+}
+
+// Create saves an attachment's metadata and copies the generated ID and timestamp back to it
+func (r *expenseAttachmentRepository) Create(ctx context.Context, attachment *domain.ExpenseAttachment) error {
+	expenseID, err := strconv.ParseUint(attachment.ExpenseID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid expense ID %q: %w", attachment.ExpenseID, domain.ErrMedicalExpenseNotFound)
+	}
+
+	model := &models.ExpenseAttachmentModel{}
+	model.FromDomain(*attachment, uint(expenseID))
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create expense attachment: %w", err)
+	}
+
+	attachment.ID = fmt.Sprintf("%d", model.ID)
+	attachment.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// GetByID retrieves an attachment's metadata by its ID
+func (r *expenseAttachmentRepository) GetByID(ctx context.Context, id string) (domain.ExpenseAttachment, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return domain.ExpenseAttachment{}, domain.ErrAttachmentNotFound
+	}
+
+	var model models.ExpenseAttachmentModel
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.ExpenseAttachment{}, domain.ErrAttachmentNotFound
+		}
+		return domain.ExpenseAttachment{}, fmt.Errorf("failed to get expense attachment: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// GetByExpenseID retrieves the metadata of an expense's attachments, oldest first
+func (r *expenseAttachmentRepository) GetByExpenseID(ctx context.Context, expenseID string) ([]domain.ExpenseAttachment, error) {
+	expenseIDUint, err := strconv.ParseUint(expenseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID %q: %w", expenseID, domain.ErrMedicalExpenseNotFound)
+	}
+
+	var rows []models.ExpenseAttachmentModel
+	if err := dbFromContext(ctx, r.db).
+		Where("expense_id = ?", uint(expenseIDUint)).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expense attachments: %w", err)
+	}
+
+	attachments := make([]domain.ExpenseAttachment, len(rows))
+	for i := range rows {
+		attachments[i] = rows[i].ToDomain()
+	}
+	return attachments, nil
+}
+
+// GetStorageKeysByUserID retrieves the storage keys of all of a user's attachments
+func (r *expenseAttachmentRepository) GetStorageKeysByUserID(ctx context.Context, userID string) ([]string, error) {
+	var keys []string
+	if err := dbFromContext(ctx, r.db).Model(&models.ExpenseAttachmentModel{}).
+		Where("user_id = ?", userID).
+		Pluck("storage_key", &keys).Error; err != nil {
+		return nil, fmt.Errorf("failed to get attachment storage keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Delete removes an attachment's metadata
+func (r *expenseAttachmentRepository) Delete(ctx context.Context, id string) error {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return domain.ErrAttachmentNotFound
+	}
+
+	result := dbFromContext(ctx, r.db).Delete(&models.ExpenseAttachmentModel{}, uint(idUint))
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete expense attachment: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+	return nil
+}
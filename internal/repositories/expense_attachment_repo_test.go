@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+func setupExpenseAttachmentTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AutoMigrate(&models.ExpenseAttachmentModel{}))
+	return db
+}
+
+func createTestAttachment(expenseID, storageKey string) *domain.ExpenseAttachment {
+	return &domain.ExpenseAttachment{
+		UserID:      "user-1",
+		ExpenseID:   expenseID,
+		FileName:    "receipt.pdf",
+		ContentType: domain.AttachmentContentTypePDF,
+		Size:        1024,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StorageKey:  storageKey,
+	}
+}
+
+func TestExpenseAttachmentRepository_CreateGetAndList(t *testing.T) {
+	repo := NewExpenseAttachmentRepository(setupExpenseAttachmentTestDB(t))
+	ctx := context.Background()
+
+	first := createTestAttachment("7", "key-1")
+	require.NoError(t, repo.Create(ctx, first))
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+	require.NoError(t, repo.Create(ctx, createTestAttachment("7", "key-2")))
+	require.NoError(t, repo.Create(ctx, createTestAttachment("8", "key-3")))
+
+	found, err := repo.GetByID(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "7", found.ExpenseID)
+	assert.Equal(t, "receipt.pdf", found.FileName)
+	assert.Equal(t, "key-1", found.StorageKey)
+	assert.Equal(t, int64(1024), found.Size)
+
+	attachments, err := repo.GetByExpenseID(ctx, "7")
+	require.NoError(t, err)
+	require.Len(t, attachments, 2)
+	assert.Equal(t, "key-1", attachments[0].StorageKey)
+	assert.Equal(t, "key-2", attachments[1].StorageKey)
+}
+
+func TestExpenseAttachmentRepository_Delete(t *testing.T) {
+	repo := NewExpenseAttachmentRepository(setupExpenseAttachmentTestDB(t))
+	ctx := context.Background()
+
+	attachment := createTestAttachment("7", "key-1")
+	require.NoError(t, repo.Create(ctx, attachment))
+
+	require.NoError(t, repo.Delete(ctx, attachment.ID))
+
+	_, err := repo.GetByID(ctx, attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, attachment.ID), domain.ErrAttachmentNotFound)
+	_, err = repo.GetByID(ctx, "not-a-number")
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+}
+
+func TestExpenseAttachmentRepository_GetStorageKeysByUserID(t *testing.T) {
+	repo := NewExpenseAttachmentRepository(setupExpenseAttachmentTestDB(t))
+	ctx := context.Background()
+
+	require.NoError(t, repo.Create(ctx, createTestAttachment("7", "key-1")))
+	require.NoError(t, repo.Create(ctx, createTestAttachment("8", "key-2")))
+	other := createTestAttachment("9", "key-3")
+	other.UserID = "user-2"
+	require.NoError(t, repo.Create(ctx, other))
+
+	keys, err := repo.GetStorageKeysByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"key-1", "key-2"}, keys)
+
+	keys, err = repo.GetStorageKeysByUserID(ctx, "user-3")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
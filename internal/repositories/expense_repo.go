@@ -3,6 +3,8 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
@@ -28,18 +30,18 @@ func (r *expenseRepository) SaveExpense(ctx context.Context, expense domain.Expe
 	
 	// First try to find existing record
 	var existing models.ExpenseModel
-	result := r.db.WithContext(ctx).First(&existing, "id = ?", expense.ID)
+	result := dbFromContext(ctx, r.db).First(&existing, "id = ?", expense.ID)
 	
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			// Create new record with explicit column selection to override defaults
-			result = r.db.WithContext(ctx).Select("*").Create(model)
+			result = dbFromContext(ctx, r.db).Select("*").Create(model)
 		} else {
 			return fmt.Errorf("failed to check existing expense: %w", result.Error)
 		}
 	} else {
 		// Update existing record with explicit selection to handle zero values
-		result = r.db.WithContext(ctx).Model(&existing).Select("*").Updates(model)
+		result = dbFromContext(ctx, r.db).Model(&existing).Select("*").Updates(model)
 	}
 	
 	if result.Error != nil {
@@ -53,7 +55,7 @@ func (r *expenseRepository) SaveExpense(ctx context.Context, expense domain.Expe
 func (r *expenseRepository) GetExpenseByID(ctx context.Context, id string) (domain.Expense, error) {
 	var model models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	result := dbFromContext(ctx, r.db).First(&model, "id = ?", id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return domain.Expense{}, fmt.Errorf("expense with ID %s not found", id)
@@ -69,7 +71,7 @@ func (r *expenseRepository) UpdateExpense(ctx context.Context, expense domain.Ex
 	model := models.NewExpenseModelFromDomain(expense)
 	
 	// Use Select to explicitly update all fields including zero values
-	result := r.db.WithContext(ctx).Model(&models.ExpenseModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.ExpenseModel{}).
 		Where("id = ?", expense.ID).
 		Select("*").
 		Updates(model)
@@ -87,7 +89,7 @@ func (r *expenseRepository) UpdateExpense(ctx context.Context, expense domain.Ex
 
 // DeleteExpense soft deletes an expense record
 func (r *expenseRepository) DeleteExpense(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&models.ExpenseModel{}, "id = ?", id)
+	result := dbFromContext(ctx, r.db).Delete(&models.ExpenseModel{}, "id = ?", id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete expense: %w", result.Error)
 	}
@@ -99,11 +101,56 @@ func (r *expenseRepository) DeleteExpense(ctx context.Context, id string) error
 	return nil
 }
 
+// DeleteExpenses soft-deletes the expenses with the given IDs in a single statement
+func (r *expenseRepository) DeleteExpenses(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result := dbFromContext(ctx, r.db).Where("id IN ?", ids).Delete(&models.ExpenseModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expenses: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// GetDeletedExpenseByID retrieves a soft-deleted expense by its ID
+func (r *expenseRepository) GetDeletedExpenseByID(ctx context.Context, id string) (domain.Expense, error) {
+	var model models.ExpenseModel
+
+	result := dbFromContext(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&model, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return domain.Expense{}, fmt.Errorf("deleted expense with ID %s not found", id)
+		}
+		return domain.Expense{}, fmt.Errorf("failed to get deleted expense by ID: %w", result.Error)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// RestoreExpense undoes the soft delete of an expense record
+func (r *expenseRepository) RestoreExpense(ctx context.Context, id string) error {
+	result := dbFromContext(ctx, r.db).Unscoped().Model(&models.ExpenseModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore expense: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted expense with ID %s not found", id)
+	}
+
+	return nil
+}
+
 // GetUserExpenses retrieves all expenses for a specific user
 func (r *expenseRepository) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get user expenses: %w", result.Error)
 	}
@@ -120,7 +167,7 @@ func (r *expenseRepository) GetUserExpenses(ctx context.Context, userID string)
 func (r *expenseRepository) GetExpensesByCategory(ctx context.Context, userID string, category string) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND category = ?", userID, category).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND category = ?", userID, category).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get expenses by category: %w", result.Error)
 	}
@@ -137,7 +184,7 @@ func (r *expenseRepository) GetExpensesByCategory(ctx context.Context, userID st
 func (r *expenseRepository) GetExpensesByFrequency(ctx context.Context, userID string, frequency string) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND frequency = ?", userID, frequency).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND frequency = ?", userID, frequency).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get expenses by frequency: %w", result.Error)
 	}
@@ -154,7 +201,7 @@ func (r *expenseRepository) GetExpensesByFrequency(ctx context.Context, userID s
 func (r *expenseRepository) GetExpensesByPriority(ctx context.Context, userID string, priority int) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND priority = ?", userID, priority).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND priority = ?", userID, priority).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get expenses by priority: %w", result.Error)
 	}
@@ -171,7 +218,7 @@ func (r *expenseRepository) GetExpensesByPriority(ctx context.Context, userID st
 func (r *expenseRepository) GetFixedExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND is_fixed = ?", userID, true).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND is_fixed = ?", userID, true).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get fixed expenses: %w", result.Error)
 	}
@@ -188,7 +235,7 @@ func (r *expenseRepository) GetFixedExpenses(ctx context.Context, userID string)
 func (r *expenseRepository) GetVariableExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
 	var models []models.ExpenseModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND is_fixed = ?", userID, false).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND is_fixed = ?", userID, false).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get variable expenses: %w", result.Error)
 	}
@@ -205,7 +252,7 @@ func (r *expenseRepository) GetVariableExpenses(ctx context.Context, userID stri
 func (r *expenseRepository) CalculateUserTotalExpenses(ctx context.Context, userID string) (float64, error) {
 	var total float64
 	
-	result := r.db.WithContext(ctx).Model(&models.ExpenseModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.ExpenseModel{}).
 		Select("COALESCE(SUM(amount), 0)").
 		Where("user_id = ?", userID).
 		Scan(&total)
@@ -221,7 +268,7 @@ func (r *expenseRepository) CalculateUserTotalExpenses(ctx context.Context, user
 func (r *expenseRepository) CalculateTotalByCategory(ctx context.Context, userID string, category string) (float64, error) {
 	var total float64
 	
-	result := r.db.WithContext(ctx).Model(&models.ExpenseModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.ExpenseModel{}).
 		Select("COALESCE(SUM(amount), 0)").
 		Where("user_id = ? AND category = ?", userID, category).
 		Scan(&total)
@@ -231,4 +278,111 @@ func (r *expenseRepository) CalculateTotalByCategory(ctx context.Context, userID
 	}
 	
 	return total, nil
-}
\ No newline at end of file
+}
+// FindExpenses retrieves a user's expenses matching every criterion set on the filter
+// The filter is translated into a single query; all values are passed as bound parameters
+func (r *expenseRepository) FindExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	var models []models.ExpenseModel
+
+	query := applyExpenseFilter(dbFromContext(ctx, r.db).Where("user_id = ?", userID), filter)
+
+	result := query.Order("created_at DESC").Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find expenses: %w", result.Error)
+	}
+
+	expenses := make([]domain.Expense, len(models))
+	for i, model := range models {
+		expenses[i] = model.ToDomain()
+	}
+
+	return expenses, nil
+}
+
+// FindExpensesAfter retrieves up to limit of a user's expenses matching filter that come after
+// cursor, ordered by creation time and then ID so an export can walk them page by page
+func (r *expenseRepository) FindExpensesAfter(ctx context.Context, userID string, filter domain.ExpenseFilter, cursor domain.Cursor, limit int) ([]domain.Expense, error) {
+	var models []models.ExpenseModel
+
+	query := applyExpenseFilter(dbFromContext(ctx, r.db).Where("user_id = ?", userID), filter)
+	if !cursor.IsZero() {
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	result := query.Order("created_at ASC, id ASC").Limit(limit).Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to find expenses page: %w", result.Error)
+	}
+
+	expenses := make([]domain.Expense, len(models))
+	for i, model := range models {
+		expenses[i] = model.ToDomain()
+	}
+
+	return expenses, nil
+}
+
+// applyExpenseFilter adds a condition to query for every criterion set on filter
+func applyExpenseFilter(query *gorm.DB, filter domain.ExpenseFilter) *gorm.DB {
+	if filter.Query != "" {
+		query = query.Where("LOWER(name) LIKE ? ESCAPE '!'", "%"+escapeLikePattern(strings.ToLower(filter.Query))+"%")
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Frequency != "" {
+		query = query.Where("frequency = ?", filter.Frequency)
+	}
+	if filter.Tag != "" {
+		// Tags are a JSON array of strings that JSON never escapes, so the quoted tag only
+		// matches a whole element
+		query = query.Where("tags LIKE ? ESCAPE '!'", `%"`+escapeLikePattern(filter.Tag)+`"%`)
+	}
+	if filter.Priority != 0 {
+		query = query.Where("priority = ?", filter.Priority)
+	}
+	if filter.IsFixed != nil {
+		query = query.Where("is_fixed = ?", *filter.IsFixed)
+	}
+	if filter.MinAmount > 0 {
+		query = query.Where("amount >= ?", filter.MinAmount)
+	}
+	if filter.MaxAmount > 0 {
+		query = query.Where("amount <= ?", filter.MaxAmount)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedBefore.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedBefore)
+	}
+	return query
+}
+
+// FindDuplicateExpenseID returns the ID of the newest expense matching the user, name, amount and
+// frequency of expense that was created at or after since, or "" if there is none. The lookup is
+// served by the idx_expenses_duplicate_check index.
+func (r *expenseRepository) FindDuplicateExpenseID(ctx context.Context, expense domain.Expense, since time.Time) (string, error) {
+	var ids []string
+
+	result := dbFromContext(ctx, r.db).Model(&models.ExpenseModel{}).
+		Where("user_id = ? AND name = ? AND frequency = ? AND amount = ? AND created_at >= ?",
+			expense.UserID, expense.Name, expense.Frequency, expense.Amount, since).
+		Order("created_at DESC").
+		Limit(1).
+		Pluck("id", &ids)
+	if result.Error != nil {
+		return "", fmt.Errorf("failed to check for duplicate expense: %w", result.Error)
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
+// escapeLikePattern escapes LIKE wildcards so user input is matched literally
+// The escape character is '!', as declared by the ESCAPE clauses in applyExpenseFilter
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
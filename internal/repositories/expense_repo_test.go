@@ -291,6 +291,37 @@ func TestExpenseRepository_UpdateExpense_Success_UpdatesExistingRecord(t *testin
 	assert.Equal(t, 2, updated.Priority)
 }
 
+func TestExpenseRepository_UpdateExpense_InstallmentProgress_RoundTrips(t *testing.T) {
+	// Arrange
+	db := setupExpenseTestDB(t)
+	repo := NewExpenseRepository(db)
+	ctx := context.Background()
+
+	expense := createTestExpense("user-123", "other", "Laptop", 1200.00, "monthly", true, 2)
+	expense.InstallmentsTotal = 12
+	expense.InstallmentStart = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.SaveExpense(ctx, expense))
+
+	// Act
+	expense.InstallmentsPaid = 4
+	require.NoError(t, repo.UpdateExpense(ctx, expense))
+	got, err := repo.GetExpenseByID(ctx, expense.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 12, got.InstallmentsTotal)
+	assert.Equal(t, 4, got.InstallmentsPaid)
+	assert.True(t, expense.InstallmentStart.Equal(got.InstallmentStart))
+
+	// Regular expenses have no start date
+	rent := createTestExpense("user-123", "housing", "Rent", 1000.00, "monthly", true, 1)
+	require.NoError(t, repo.SaveExpense(ctx, rent))
+	got, err = repo.GetExpenseByID(ctx, rent.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsInstallment())
+	assert.True(t, got.InstallmentStart.IsZero())
+}
+
 func TestExpenseRepository_UpdateExpense_NonexistentRecord_ReturnsError(t *testing.T) {
 	// Arrange
 	db := setupExpenseTestDB(t)
@@ -348,6 +379,77 @@ func TestExpenseRepository_DeleteExpense_NonexistentRecord_ReturnsError(t *testi
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestExpenseRepository_RestoreExpense_DeleteHidesAndRestoreBringsBack(t *testing.T) {
+	// Arrange
+	db := setupExpenseTestDB(t)
+	repo := NewExpenseRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	rent := createTestExpense(userID, "housing", "Rent", 1200.00, "monthly", true, 1)
+	groceries := createTestExpense(userID, "food", "Groceries", 400.00, "monthly", false, 1)
+	require.NoError(t, repo.SaveExpense(ctx, rent))
+	require.NoError(t, repo.SaveExpense(ctx, groceries))
+
+	// Delete hides the row from lookups, filters and totals
+	require.NoError(t, repo.DeleteExpense(ctx, groceries.ID))
+
+	_, err := repo.GetExpenseByID(ctx, groceries.ID)
+	assert.Error(t, err)
+	food, err := repo.GetExpensesByCategory(ctx, userID, "food")
+	require.NoError(t, err)
+	assert.Empty(t, food)
+	total, err := repo.CalculateUserTotalExpenses(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1200.00, total)
+
+	// Act
+	err = repo.RestoreExpense(ctx, groceries.ID)
+
+	// Assert
+	require.NoError(t, err)
+
+	restored, err := repo.GetExpenseByID(ctx, groceries.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Groceries", restored.Name)
+	total, err = repo.CalculateUserTotalExpenses(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1600.00, total)
+
+	err = repo.RestoreExpense(ctx, groceries.ID)
+	assert.Error(t, err, "a live expense cannot be restored again")
+}
+
+func TestExpenseRepository_DeleteExpenses_DeletesOnlyListedExpenses(t *testing.T) {
+	// Arrange
+	db := setupExpenseTestDB(t)
+	repo := NewExpenseRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	rent := createTestExpense(userID, "housing", "Rent", 1200.00, "monthly", true, 1)
+	groceries := createTestExpense(userID, "food", "Groceries", 400.00, "monthly", false, 1)
+	cinema := createTestExpense(userID, "entertainment", "Cinema", 30.00, "monthly", false, 3)
+	for _, expense := range []domain.Expense{rent, groceries, cinema} {
+		require.NoError(t, repo.SaveExpense(ctx, expense))
+	}
+
+	// Act
+	deleted, err := repo.DeleteExpenses(ctx, []string{groceries.ID, cinema.ID, "missing"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	remaining, err := repo.GetUserExpenses(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+	assert.Equal(t, rent.ID, remaining[0].ID)
+
+	// Deleted expenses can still be restored one by one
+	require.NoError(t, repo.RestoreExpense(ctx, cinema.ID))
+}
+
 func TestExpenseRepository_GetExpenseByID_Success_ReturnsCorrectExpense(t *testing.T) {
 	// Arrange
 	db := setupExpenseTestDB(t)
@@ -471,3 +573,108 @@ func TestExpenseRepository_GetExpensesByFrequency_FiltersCorrectly(t *testing.T)
 		assert.Equal(t, userID, expense.UserID)
 	}
 }
+
+func TestExpenseRepository_FindExpenses_CombinesFilters(t *testing.T) {
+	// Arrange
+	db := setupExpenseTestDB(t)
+	repo := NewExpenseRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	jan := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	rent := createTestExpense(userID, "housing", "Rent", 1200.00, "monthly", true, 1)
+	rent.CreatedAt = jan
+	garage := createTestExpense(userID, "housing", "Garage Rental", 150.00, "monthly", true, 2)
+	garage.CreatedAt = mar
+	movies := createTestExpense(userID, "entertainment", "Movie rentals", 30.00, "weekly", false, 3)
+	movies.CreatedAt = mar
+	discount := createTestExpense(userID, "other", "100% discount", 10.00, "monthly", false, 3)
+	otherRent := createTestExpense("user-456", "housing", "Other Rent", 900.00, "monthly", true, 1)
+
+	for _, expense := range []domain.Expense{rent, garage, movies, discount, otherRent} {
+		require.NoError(t, repo.SaveExpense(ctx, expense))
+	}
+
+	fixed := true
+	tests := []struct {
+		name     string
+		filter   domain.ExpenseFilter
+		expected []string
+	}{
+		{name: "empty filter", filter: domain.ExpenseFilter{}, expected: []string{"Rent", "Garage Rental", "Movie rentals", "100% discount"}},
+		{name: "case-insensitive name search", filter: domain.ExpenseFilter{Query: "RENT"}, expected: []string{"Rent", "Garage Rental", "Movie rentals"}},
+		{name: "search with category", filter: domain.ExpenseFilter{Query: "rent", Category: "housing"}, expected: []string{"Rent", "Garage Rental"}},
+		{name: "search with category and amount range", filter: domain.ExpenseFilter{Query: "rent", Category: "housing", MinAmount: 100, MaxAmount: 500}, expected: []string{"Garage Rental"}},
+		{name: "fixed and priority", filter: domain.ExpenseFilter{IsFixed: &fixed, Priority: 1}, expected: []string{"Rent"}},
+		{name: "frequency", filter: domain.ExpenseFilter{Frequency: "weekly"}, expected: []string{"Movie rentals"}},
+		{name: "created date range", filter: domain.ExpenseFilter{CreatedFrom: jan.AddDate(0, 0, -1), CreatedBefore: jan.AddDate(0, 0, 1)}, expected: []string{"Rent"}},
+		{name: "wildcards match literally", filter: domain.ExpenseFilter{Query: "%"}, expected: []string{"100% discount"}},
+		{name: "underscore matches literally", filter: domain.ExpenseFilter{Query: "_"}, expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			expenses, err := repo.FindExpenses(ctx, userID, tt.filter)
+
+			// Assert
+			require.NoError(t, err)
+			names := make([]string, len(expenses))
+			for i, expense := range expenses {
+				assert.Equal(t, userID, expense.UserID)
+				names[i] = expense.Name
+			}
+			assert.ElementsMatch(t, tt.expected, names)
+		})
+	}
+}
+
+func TestExpenseRepository_FindDuplicateExpenseID(t *testing.T) {
+	// Arrange
+	db := setupExpenseTestDB(t)
+	repo := NewExpenseRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	older := createTestExpense("user-123", "housing", "Monthly Rent", 1200.00, "monthly", true, 1)
+	older.ID = "expense-rent-older"
+	older.CreatedAt = now.Add(-2 * time.Hour)
+	require.NoError(t, repo.SaveExpense(ctx, older))
+	existing := createTestExpense("user-123", "housing", "Monthly Rent", 1200.00, "monthly", true, 1)
+	existing.ID = "expense-rent-newer"
+	existing.CreatedAt = now.Add(-time.Hour)
+	require.NoError(t, repo.SaveExpense(ctx, existing))
+	deleted := createTestExpense("user-123", "utilities", "Internet", 60.00, "monthly", true, 2)
+	require.NoError(t, repo.SaveExpense(ctx, deleted))
+	require.NoError(t, repo.DeleteExpense(ctx, deleted.ID))
+
+	dayAgo := now.Add(-24 * time.Hour)
+	tests := []struct {
+		name     string
+		expense  domain.Expense
+		since    time.Time
+		expected string
+	}{
+		{"same name, amount and frequency returns the newest", createTestExpense("user-123", "housing", "Monthly Rent", 1200.00, "monthly", true, 1), dayAgo, "expense-rent-newer"},
+		{"category and priority are ignored", createTestExpense("user-123", "other", "Monthly Rent", 1200.00, "monthly", false, 3), dayAgo, "expense-rent-newer"},
+		{"same name, different amount", createTestExpense("user-123", "housing", "Monthly Rent", 1250.00, "monthly", true, 1), dayAgo, ""},
+		{"same amount, different frequency", createTestExpense("user-123", "housing", "Monthly Rent", 1200.00, "weekly", true, 1), dayAgo, ""},
+		{"different name", createTestExpense("user-123", "housing", "Parking", 1200.00, "monthly", true, 1), dayAgo, ""},
+		{"another user", createTestExpense("user-456", "housing", "Monthly Rent", 1200.00, "monthly", true, 1), dayAgo, ""},
+		{"outside the window", createTestExpense("user-123", "housing", "Monthly Rent", 1200.00, "monthly", true, 1), now.Add(-30 * time.Minute), ""},
+		{"deleted expense", createTestExpense("user-123", "utilities", "Internet", 60.00, "monthly", true, 2), dayAgo, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			id, err := repo.FindDuplicateExpenseID(ctx, tt.expense, tt.since)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -12,6 +13,9 @@ import (
 	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
+// maxProfileSnapshotsPerProfile caps how many historical snapshots are kept per profile
+const maxProfileSnapshotsPerProfile = 500
+
 // healthProfileRepository implements services.HealthProfileRepository
 type healthProfileRepository struct {
 	db *gorm.DB
@@ -27,9 +31,9 @@ func (r *healthProfileRepository) Create(ctx context.Context, profile *domain.He
 	model := &models.HealthProfileModel{}
 	model.FromDomain(profile)
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
 		if isHealthProfileDuplicateKeyError(err) {
-			return nil, fmt.Errorf("health profile already exists for user %s: unique constraint violation", profile.UserID)
+			return nil, fmt.Errorf("health profile for user %s: %w", profile.UserID, domain.ErrProfileAlreadyExists)
 		}
 		return nil, fmt.Errorf("failed to create health profile: %w", err)
 	}
@@ -41,7 +45,7 @@ func (r *healthProfileRepository) Create(ctx context.Context, profile *domain.He
 func (r *healthProfileRepository) GetByID(ctx context.Context, id uint) (*domain.HealthProfile, error) {
 	var model models.HealthProfileModel
 	
-	if err := r.db.WithContext(ctx).First(&model, id).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("health profile with ID %d not found", id)
 		}
@@ -51,11 +55,11 @@ func (r *healthProfileRepository) GetByID(ctx context.Context, id uint) (*domain
 	return model.ToDomain(), nil
 }
 
-// GetByUserID retrieves a health profile by user ID
+// GetByUserID retrieves the user's own (self) health profile
 func (r *healthProfileRepository) GetByUserID(ctx context.Context, userID string) (*domain.HealthProfile, error) {
 	var model models.HealthProfileModel
 	
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("user_id = ? AND relation_to_owner = ?", userID, domain.RelationSelf).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("health profile not found for user %s", userID)
 		}
@@ -73,47 +77,153 @@ func (r *healthProfileRepository) Update(ctx context.Context, profile *domain.He
 	}
 
 	var model models.HealthProfileModel
-	if err := r.db.WithContext(ctx).First(&model, uint(id)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(id)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("health profile with ID %s not found", profile.ID)
 		}
 		return nil, fmt.Errorf("failed to find health profile for update: %w", err)
 	}
 
+	// Keep the previous measurements before they are overwritten, dated from when they took effect
+	snapshot := &models.ProfileSnapshotModel{}
+	snapshot.FromDomain(domain.NewProfileSnapshot(model.ToDomain(), model.UpdatedAt), model.ID)
+
+	// An update without a relation keeps the stored one, so a dependent never becomes a self profile
+	if profile.RelationToOwner == "" {
+		updated := *profile
+		updated.RelationToOwner = model.RelationToOwner
+		profile = &updated
+	}
+
 	// Update fields from domain
 	model.FromDomain(profile)
 	model.ID = uint(id) // Preserve ID
 
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
-		return nil, fmt.Errorf("failed to update health profile: %w", err)
+	err = dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(snapshot).Error; err != nil {
+			return fmt.Errorf("failed to record profile snapshot: %w", err)
+		}
+		if err := pruneProfileSnapshots(tx, model.ID, maxProfileSnapshotsPerProfile); err != nil {
+			return err
+		}
+		if err := tx.Save(&model).Error; err != nil {
+			if isHealthProfileDuplicateKeyError(err) {
+				return fmt.Errorf("health profile for user %s: %w", model.UserID, domain.ErrProfileAlreadyExists)
+			}
+			return fmt.Errorf("failed to update health profile: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return model.ToDomain(), nil
 }
 
-// Delete deletes a health profile (and cascades to related records)
-func (r *healthProfileRepository) Delete(ctx context.Context, id uint) error {
-	result := r.db.WithContext(ctx).Delete(&models.HealthProfileModel{}, id)
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete health profile: %w", result.Error)
+// GetSnapshots retrieves up to limit of the most recent snapshots of the user's self profile recorded
+// since the given time, ordered oldest first
+func (r *healthProfileRepository) GetSnapshots(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.ProfileSnapshot, error) {
+	var snapshotModels []models.ProfileSnapshotModel
+
+	selfProfile := dbFromContext(ctx, r.db).Model(&models.HealthProfileModel{}).
+		Select("id").
+		Where("user_id = ? AND relation_to_owner = ?", userID, domain.RelationSelf)
+
+	if err := dbFromContext(ctx, r.db).
+		Where("profile_id IN (?) AND recorded_at >= ?", selfProfile, since).
+		Order("recorded_at DESC, id DESC").
+		Limit(limit).
+		Find(&snapshotModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get profile snapshots: %w", err)
 	}
-	
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("health profile with ID %d not found", id)
+
+	// Reverse into chronological order
+	snapshots := make([]*domain.ProfileSnapshot, len(snapshotModels))
+	for i, model := range snapshotModels {
+		snapshots[len(snapshotModels)-1-i] = model.ToDomain()
+	}
+
+	return snapshots, nil
+}
+
+// pruneProfileSnapshots deletes all but the newest keep snapshots for a profile
+func pruneProfileSnapshots(tx *gorm.DB, profileID uint, keep int) error {
+	var cutoff models.ProfileSnapshotModel
+	err := tx.Where("profile_id = ?", profileID).
+		Order("recorded_at DESC, id DESC").
+		Offset(keep).
+		Limit(1).
+		Find(&cutoff).Error
+	if err != nil {
+		return fmt.Errorf("failed to find profile snapshots to prune: %w", err)
+	}
+	if cutoff.ID == 0 {
+		return nil
+	}
+
+	if err := tx.Where("profile_id = ? AND (recorded_at < ? OR (recorded_at = ? AND id <= ?))",
+		profileID, cutoff.RecordedAt, cutoff.RecordedAt, cutoff.ID).
+		Delete(&models.ProfileSnapshotModel{}).Error; err != nil {
+		return fmt.Errorf("failed to prune profile snapshots: %w", err)
 	}
 
 	return nil
 }
 
-// GetWithRelations retrieves a health profile with all related entities preloaded
+// Delete deletes a health profile and its conditions, expenses and policies. Deleting a self
+// profile also deletes the owner's dependent profiles and their records.
+// Profiles are soft deleted, so the database ON DELETE CASCADE never fires and related
+// records are deleted here in the same transaction.
+func (r *healthProfileRepository) Delete(ctx context.Context, id uint) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var model models.HealthProfileModel
+		if err := tx.First(&model, id).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("health profile with ID %d not found", id)
+			}
+			return fmt.Errorf("failed to get health profile: %w", err)
+		}
+
+		profileIDs := []uint{model.ID}
+		if model.RelationToOwner == domain.RelationSelf {
+			if err := tx.Model(&models.HealthProfileModel{}).
+				Where("user_id = ?", model.UserID).
+				Pluck("id", &profileIDs).Error; err != nil {
+				return fmt.Errorf("failed to get dependent profiles: %w", err)
+			}
+		}
+
+		if err := tx.Where("profile_id IN ?", profileIDs).Delete(&models.MedicalConditionModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete profile conditions: %w", err)
+		}
+		if err := tx.Where("profile_id IN ?", profileIDs).Delete(&models.MedicalExpenseOccurrenceModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete profile expense occurrences: %w", err)
+		}
+		if err := tx.Where("profile_id IN ?", profileIDs).Delete(&models.MedicalExpenseModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete profile expenses: %w", err)
+		}
+		if err := tx.Where("profile_id IN ?", profileIDs).Delete(&models.InsurancePolicyModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete profile policies: %w", err)
+		}
+
+		if err := tx.Delete(&models.HealthProfileModel{}, profileIDs).Error; err != nil {
+			return fmt.Errorf("failed to delete health profile: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetWithRelations retrieves the user's self health profile with all related entities preloaded
 func (r *healthProfileRepository) GetWithRelations(ctx context.Context, userID string) (*domain.HealthProfile, error) {
 	var model models.HealthProfileModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Preload("Conditions").
 		Preload("Expenses").
 		Preload("Policies").
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND relation_to_owner = ?", userID, domain.RelationSelf).
 		First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("health profile not found for user %s", userID)
@@ -124,13 +234,13 @@ func (r *healthProfileRepository) GetWithRelations(ctx context.Context, userID s
 	return model.ToDomain(), nil
 }
 
-// ExistsByUserID checks if a health profile exists for the given user ID
+// ExistsByUserID checks if a self health profile exists for the given user ID
 func (r *healthProfileRepository) ExistsByUserID(ctx context.Context, userID string) (bool, error) {
 	var count int64
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Model(&models.HealthProfileModel{}).
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND relation_to_owner = ?", userID, domain.RelationSelf).
 		Count(&count).Error; err != nil {
 		return false, fmt.Errorf("failed to check health profile existence: %w", err)
 	}
@@ -138,6 +248,45 @@ func (r *healthProfileRepository) ExistsByUserID(ctx context.Context, userID str
 	return count > 0, nil
 }
 
+// GetFamilyByUserID retrieves every profile on the user's account, self profile first
+func (r *healthProfileRepository) GetFamilyByUserID(ctx context.Context, userID string) ([]*domain.HealthProfile, error) {
+	var profileModels []models.HealthProfileModel
+
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("self_user_id IS NULL, id ASC").
+		Find(&profileModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get family health profiles: %w", err)
+	}
+
+	profiles := make([]*domain.HealthProfile, len(profileModels))
+	for i := range profileModels {
+		profiles[i] = profileModels[i].ToDomain()
+	}
+
+	return profiles, nil
+}
+
+// ListSelfProfiles retrieves up to limit self profiles with an ID above afterID, in ID order
+func (r *healthProfileRepository) ListSelfProfiles(ctx context.Context, afterID uint, limit int) ([]*domain.HealthProfile, error) {
+	var profileModels []models.HealthProfileModel
+
+	if err := dbFromContext(ctx, r.db).
+		Where("relation_to_owner = ? AND id > ?", domain.RelationSelf, afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&profileModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to list health profiles: %w", err)
+	}
+
+	profiles := make([]*domain.HealthProfile, len(profileModels))
+	for i := range profileModels {
+		profiles[i] = profileModels[i].ToDomain()
+	}
+
+	return profiles, nil
+}
+
 // isHealthProfileDuplicateKeyError checks if the error is a duplicate key/unique constraint violation
 func isHealthProfileDuplicateKeyError(err error) bool {
 	if err == nil {
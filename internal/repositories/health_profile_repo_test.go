@@ -13,6 +13,7 @@ import (
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
 )
 
 func setupHealthProfileTestDB(t *testing.T) *gorm.DB {
@@ -24,7 +25,9 @@ func setupHealthProfileTestDB(t *testing.T) *gorm.DB {
 		&models.HealthProfileModel{},
 		&models.MedicalConditionModel{},
 		&models.MedicalExpenseModel{},
+		&models.MedicalExpenseOccurrenceModel{},
 		&models.InsurancePolicyModel{},
+		&models.ProfileSnapshotModel{},
 	)
 	require.NoError(t, err)
 
@@ -88,8 +91,7 @@ func TestHealthProfileRepository_CreateProfile_DuplicateUserFails(t *testing.T)
 
 	// Second creation with same user ID should fail
 	_, err = repo.Create(ctx, profile2)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "unique constraint")
+	assert.ErrorIs(t, err, domain.ErrProfileAlreadyExists)
 }
 
 func TestHealthProfileRepository_GetProfile_WithRelations(t *testing.T) {
@@ -309,3 +311,263 @@ func TestHealthProfileRepository_GetByUserID_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestHealthProfileRepository_Update_RecordsSnapshotOfPreviousValues(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	createdProfile, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID:     "test-user-123",
+		Age:        30,
+		Gender:     "male",
+		Height:     180.0,
+		Weight:     81.0,
+		FamilySize: 2,
+	})
+	require.NoError(t, err)
+
+	createdProfile.Weight = 77.0
+	_, err = repo.Update(ctx, createdProfile)
+	require.NoError(t, err)
+
+	snapshots, err := repo.GetSnapshots(ctx, "test-user-123", time.Now().AddDate(0, -1, 0), 10)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, createdProfile.ID, snapshots[0].ProfileID)
+	assert.Equal(t, 81.0, snapshots[0].Weight)
+	assert.Equal(t, 25.0, snapshots[0].BMI)
+}
+
+func TestHealthProfileRepository_GetSnapshots_WindowLimitAndOrder(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	createdProfile, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 30, Gender: "male", Height: 180.0, Weight: 75.0, FamilySize: 2,
+	})
+	require.NoError(t, err)
+	profileID, err := strconv.ParseUint(createdProfile.ID, 10, 32)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for i, monthsAgo := range []int{14, 6, 4, 2} {
+		require.NoError(t, db.Create(&models.ProfileSnapshotModel{
+			UserID:     "test-user-123",
+			ProfileID:  uint(profileID),
+			Age:        30,
+			Height:     180.0,
+			Weight:     70.0 + float64(i),
+			BMI:        21.6,
+			RecordedAt: now.AddDate(0, -monthsAgo, 0),
+		}).Error)
+	}
+
+	snapshots, err := repo.GetSnapshots(ctx, "test-user-123", now.AddDate(0, -12, 0), 2)
+	require.NoError(t, err)
+
+	// The 14 month old snapshot is outside the window; of the rest only the newest two are returned, oldest first
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 72.0, snapshots[0].Weight)
+	assert.Equal(t, 73.0, snapshots[1].Weight)
+}
+
+// Each history point is dated from when its measurements took effect: a snapshot carries the time
+// the replaced values were saved, and the current profile the time of the last update
+func TestHealthProfileRepository_ProfileHistory_DatesPointsWhenMeasured(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	clock := now
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{NowFunc: func() time.Time { return clock }})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&models.HealthProfileModel{}, &models.ProfileSnapshotModel{}))
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+	healthService := services.NewHealthService(repo, nil, nil, nil, nil, nil, nil, nil)
+
+	createdAt, firstUpdate, secondUpdate := now.AddDate(0, -13, 0), now.AddDate(0, -5, 0), now.AddDate(0, -1, 0)
+
+	clock = createdAt
+	profile, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 30, Gender: "male", Height: 180.0, Weight: 90.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+
+	clock = firstUpdate
+	profile.Weight = 85.0
+	profile, err = repo.Update(ctx, profile)
+	require.NoError(t, err)
+
+	clock = secondUpdate
+	profile.Weight = 81.0
+	_, err = repo.Update(ctx, profile)
+	require.NoError(t, err)
+
+	// The 90kg measured 13 months ago was replaced 5 months ago, so it is outside a 12 month window
+	history, err := healthService.GetProfileHistory(ctx, "test-user-123", 12)
+	require.NoError(t, err)
+	require.Len(t, history.Points, 2)
+	assert.Equal(t, 85.0, history.Points[0].Weight)
+	assert.True(t, firstUpdate.Equal(history.Points[0].RecordedAt), history.Points[0].RecordedAt)
+	assert.Equal(t, 81.0, history.Points[1].Weight)
+	assert.True(t, secondUpdate.Equal(history.Points[1].RecordedAt), history.Points[1].RecordedAt)
+	assert.Equal(t, -4.0, history.WeightChange)
+	assert.Equal(t, -1.23, history.BMIChange)
+
+	history, err = healthService.GetProfileHistory(ctx, "test-user-123", 24)
+	require.NoError(t, err)
+	require.Len(t, history.Points, 3)
+	assert.Equal(t, 90.0, history.Points[0].Weight)
+	assert.True(t, createdAt.Equal(history.Points[0].RecordedAt), history.Points[0].RecordedAt)
+	assert.Equal(t, -9.0, history.WeightChange)
+}
+
+func TestPruneProfileSnapshots_KeepsNewest(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	createdProfile, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 30, Gender: "male", Height: 180.0, Weight: 75.0, FamilySize: 2,
+	})
+	require.NoError(t, err)
+	profileID, err := strconv.ParseUint(createdProfile.ID, 10, 32)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for day := 5; day >= 1; day-- {
+		require.NoError(t, db.Create(&models.ProfileSnapshotModel{
+			UserID: "test-user-123", ProfileID: uint(profileID), Age: 30, Height: 180.0,
+			Weight: 80.0 - float64(day), BMI: 24.0, RecordedAt: now.AddDate(0, 0, -day),
+		}).Error)
+	}
+
+	require.NoError(t, pruneProfileSnapshots(db, uint(profileID), 3))
+
+	var remaining []models.ProfileSnapshotModel
+	require.NoError(t, db.Order("recorded_at ASC").Find(&remaining).Error)
+	require.Len(t, remaining, 3)
+	assert.Equal(t, 77.0, remaining[0].Weight)
+	assert.Equal(t, 79.0, remaining[2].Weight)
+}
+
+func TestHealthProfileRepository_FamilyProfiles_OneSelfPlusDependents(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	// Dependents created before the self profile still sort after it
+	_, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Name: "Alex", RelationToOwner: domain.RelationSpouse,
+		Age: 42, Gender: "male", Height: 182.0, Weight: 90.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+	self, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 40, Gender: "female", Height: 168.0, Weight: 62.0, FamilySize: 3,
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Name: "Sam", RelationToOwner: domain.RelationChild,
+		Age: 8, Gender: "male", Height: 128.0, Weight: 26.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+
+	// A second self profile violates the unique constraint
+	_, err = repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", RelationToOwner: domain.RelationSelf, Age: 41, Gender: "female", Height: 168.0, Weight: 63.0, FamilySize: 3,
+	})
+	assert.ErrorIs(t, err, domain.ErrProfileAlreadyExists)
+
+	family, err := repo.GetFamilyByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	require.Len(t, family, 3)
+	assert.Equal(t, self.ID, family[0].ID)
+	assert.Equal(t, domain.RelationSelf, family[0].RelationToOwner)
+	assert.Equal(t, "Alex", family[1].Name)
+	assert.Equal(t, "Sam", family[2].Name)
+
+	// Single-profile lookups keep returning the self profile
+	found, err := repo.GetByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	assert.Equal(t, self.ID, found.ID)
+
+	// Updating a dependent without a relation keeps it a dependent and out of the self history
+	family[1].RelationToOwner = ""
+	family[1].Weight = 88.0
+	updated, err := repo.Update(ctx, family[1])
+	require.NoError(t, err)
+	assert.Equal(t, domain.RelationSpouse, updated.RelationToOwner)
+
+	snapshots, err := repo.GetSnapshots(ctx, "test-user-123", time.Now().AddDate(0, -1, 0), 10)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func TestHealthProfileRepository_Delete_SelfProfileCascadesToDependents(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	self, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 40, Gender: "female", Height: 168.0, Weight: 62.0, FamilySize: 2,
+	})
+	require.NoError(t, err)
+	child, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Name: "Sam", RelationToOwner: domain.RelationChild,
+		Age: 8, Gender: "male", Height: 128.0, Weight: 26.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+	other, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "other-user", Age: 35, Gender: "male", Height: 175.0, Weight: 70.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+
+	childID, err := strconv.ParseUint(child.ID, 10, 32)
+	require.NoError(t, err)
+	require.NoError(t, db.Create(&models.MedicalExpenseModel{
+		UserID: "test-user-123", ProfileID: uint(childID), Amount: 40.0, Category: "doctor_visit",
+		Description: "Checkup", Date: time.Now(),
+	}).Error)
+
+	selfID, err := strconv.ParseUint(self.ID, 10, 32)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, uint(selfID)))
+
+	family, err := repo.GetFamilyByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	assert.Empty(t, family, "dependents are deleted with the self profile")
+
+	var expenseCount int64
+	require.NoError(t, db.Model(&models.MedicalExpenseModel{}).Where("profile_id = ?", uint(childID)).Count(&expenseCount).Error)
+	assert.Equal(t, int64(0), expenseCount)
+
+	otherID, err := strconv.ParseUint(other.ID, 10, 32)
+	require.NoError(t, err)
+	_, err = repo.GetByID(ctx, uint(otherID))
+	assert.NoError(t, err, "other users' profiles are untouched")
+}
+
+func TestHealthProfileRepository_Delete_DependentKeepsSelfProfile(t *testing.T) {
+	db := setupHealthProfileTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	self, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 40, Gender: "female", Height: 168.0, Weight: 62.0, FamilySize: 2,
+	})
+	require.NoError(t, err)
+	spouse, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Name: "Alex", RelationToOwner: domain.RelationSpouse,
+		Age: 42, Gender: "male", Height: 182.0, Weight: 90.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+
+	spouseID, err := strconv.ParseUint(spouse.ID, 10, 32)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, uint(spouseID)))
+
+	family, err := repo.GetFamilyByUserID(ctx, "test-user-123")
+	require.NoError(t, err)
+	require.Len(t, family, 1)
+	assert.Equal(t, self.ID, family[0].ID)
+}
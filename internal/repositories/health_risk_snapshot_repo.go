@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// maxRiskSnapshotsPerUser caps how many historical risk snapshots are kept per user
+const maxRiskSnapshotsPerUser = 500
+
+// healthRiskSnapshotRepository implements services.HealthRiskSnapshotRepository
+type healthRiskSnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewHealthRiskSnapshotRepository creates a new health risk snapshot repository
+func NewHealthRiskSnapshotRepository(db *gorm.DB) services.HealthRiskSnapshotRepository {
+	return &healthRiskSnapshotRepository{db: db}
+}
+
+// Create records a risk snapshot, pruning the user's oldest snapshots beyond maxRiskSnapshotsPerUser
+func (r *healthRiskSnapshotRepository) Create(ctx context.Context, snapshot *domain.RiskSnapshot) (*domain.RiskSnapshot, error) {
+	model := &models.RiskSnapshotModel{}
+	model.FromDomain(snapshot)
+
+	err := dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return fmt.Errorf("failed to create risk snapshot: %w", err)
+		}
+		return pruneRiskSnapshots(tx, model.UserID, maxRiskSnapshotsPerUser)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDomain(), nil
+}
+
+// GetByUserID retrieves up to limit of the user's most recent snapshots recorded since the given
+// time, ordered oldest first
+func (r *healthRiskSnapshotRepository) GetByUserID(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.RiskSnapshot, error) {
+	var snapshotModels []models.RiskSnapshotModel
+
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND recorded_at >= ?", userID, since).
+		Order("recorded_at DESC, id DESC").
+		Limit(limit).
+		Find(&snapshotModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get risk snapshots: %w", err)
+	}
+
+	// Reverse into chronological order
+	snapshots := make([]*domain.RiskSnapshot, len(snapshotModels))
+	for i, model := range snapshotModels {
+		snapshots[len(snapshotModels)-1-i] = model.ToDomain()
+	}
+
+	return snapshots, nil
+}
+
+// pruneRiskSnapshots deletes all but the newest keep risk snapshots for a user
+func pruneRiskSnapshots(tx *gorm.DB, userID string, keep int) error {
+	var cutoff models.RiskSnapshotModel
+	err := tx.Where("user_id = ?", userID).
+		Order("recorded_at DESC, id DESC").
+		Offset(keep).
+		Limit(1).
+		Find(&cutoff).Error
+	if err != nil {
+		return fmt.Errorf("failed to find risk snapshots to prune: %w", err)
+	}
+	if cutoff.ID == 0 {
+		return nil
+	}
+
+	if err := tx.Where("user_id = ? AND (recorded_at < ? OR (recorded_at = ? AND id <= ?))",
+		userID, cutoff.RecordedAt, cutoff.RecordedAt, cutoff.ID).
+		Delete(&models.RiskSnapshotModel{}).Error; err != nil {
+		return fmt.Errorf("failed to prune risk snapshots: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// idempotencyRepository implements services.IdempotencyStore
+type idempotencyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(db *gorm.DB) services.IdempotencyStore {
+	return &idempotencyRepository{db: db}
+}
+
+// Reserve claims a (user, key) pair using the unique index, so that concurrent requests
+// with the same key cannot both reserve it. An expired holder is replaced.
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	model := &models.IdempotencyKeyModel{}
+	model.FromDomain(record)
+
+	err := dbFromContext(ctx, r.db).Create(model).Error
+	if err == nil {
+		return nil, true, nil
+	}
+	if !isDuplicateKeyError(err) {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	existing, err := r.find(ctx, record.UserID, record.Key)
+	if err != nil {
+		return nil, false, err
+	}
+	if !existing.IsExpired(time.Now()) {
+		return existing, false, nil
+	}
+
+	// The key has expired; delete it and try once more. If another request took it first,
+	// the retry fails on the unique index and that request's record is returned.
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND idempotency_key = ? AND expires_at <= ?", record.UserID, record.Key, time.Now()).
+		Delete(&models.IdempotencyKeyModel{}).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+	}
+
+	model = &models.IdempotencyKeyModel{}
+	model.FromDomain(record)
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if !isDuplicateKeyError(err) {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		existing, err := r.find(ctx, record.UserID, record.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	return nil, true, nil
+}
+
+// Complete stores the response produced for a reserved key
+func (r *idempotencyRepository) Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error {
+	result := dbFromContext(ctx, r.db).
+		Model(&models.IdempotencyKeyModel{}).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		Updates(map[string]interface{}{
+			"status_code":   statusCode,
+			"content_type":  contentType,
+			"response_body": string(body),
+			"completed":     true,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("idempotency key %s not found", key)
+	}
+
+	return nil
+}
+
+// Release deletes a reserved key so the request can be retried
+func (r *idempotencyRepository) Release(ctx context.Context, userID, key string) error {
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		Delete(&models.IdempotencyKeyModel{}).Error; err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired removes all keys that expired before now
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Where("expires_at <= ?", now).
+		Delete(&models.IdempotencyKeyModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// find retrieves the record for a (user, key) pair
+func (r *idempotencyRepository) find(ctx context.Context, userID, key string) (*domain.IdempotencyRecord, error) {
+	var model models.IdempotencyKeyModel
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND idempotency_key = ?", userID, key).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("idempotency key %s not found", key)
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+func setupIdempotencyTestDB(t *testing.T) *gorm.DB {
+	// A shared-cache database with a single connection lets goroutines see the same tables
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(&models.IdempotencyKeyModel{}))
+	return db
+}
+
+func newIdempotencyRecord(key, hash string, expiresAt time.Time) *domain.IdempotencyRecord {
+	return &domain.IdempotencyRecord{
+		UserID:      "user-1",
+		Key:         key,
+		RequestHash: hash,
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func TestIdempotencyRepository_ReserveCompleteAndReplay(t *testing.T) {
+	db := setupIdempotencyTestDB(t)
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+
+	existing, reserved, err := repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-a", expiresAt))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Nil(t, existing)
+
+	// In flight
+	existing, reserved, err = repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-a", expiresAt))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.False(t, existing.Completed)
+
+	require.NoError(t, repo.Complete(ctx, "user-1", "key-1", 201, "application/json", []byte(`{"id":"1"}`)))
+
+	existing, reserved, err = repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-a", expiresAt))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, `{"id":"1"}`, string(existing.ResponseBody))
+	assert.True(t, existing.Matches("hash-a"))
+}
+
+func TestIdempotencyRepository_KeysAreScopedPerUser(t *testing.T) {
+	db := setupIdempotencyTestDB(t)
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	record := newIdempotencyRecord("shared-key", "hash-a", time.Now().Add(time.Hour))
+	_, reserved, err := repo.Reserve(ctx, record)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	other := newIdempotencyRecord("shared-key", "hash-b", time.Now().Add(time.Hour))
+	other.UserID = "user-2"
+	_, reserved, err = repo.Reserve(ctx, other)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyRepository_ConcurrentReserve_OnlyOneWins(t *testing.T) {
+	db := setupIdempotencyTestDB(t)
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	const attempts = 10
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	reservedCount := 0
+	start := make(chan struct{})
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, reserved, err := repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-a", time.Now().Add(time.Hour)))
+			assert.NoError(t, err)
+			if reserved {
+				mu.Lock()
+				reservedCount++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	assert.Equal(t, 1, reservedCount)
+
+	var rows int64
+	require.NoError(t, db.Model(&models.IdempotencyKeyModel{}).Count(&rows).Error)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestIdempotencyRepository_ExpiredKeyCanBeReservedAgain(t *testing.T) {
+	db := setupIdempotencyTestDB(t)
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	_, reserved, err := repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-a", time.Now().Add(-time.Minute)))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	_, reserved, err = repo.Reserve(ctx, newIdempotencyRecord("key-1", "hash-b", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
+
+func TestIdempotencyRepository_ReleaseAndDeleteExpired(t *testing.T) {
+	db := setupIdempotencyTestDB(t)
+	repo := NewIdempotencyRepository(db)
+	ctx := context.Background()
+
+	_, _, err := repo.Reserve(ctx, newIdempotencyRecord("released", "hash-a", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	_, _, err = repo.Reserve(ctx, newIdempotencyRecord("expired", "hash-a", time.Now().Add(-time.Hour)))
+	require.NoError(t, err)
+	_, _, err = repo.Reserve(ctx, newIdempotencyRecord("live", "hash-a", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Release(ctx, "user-1", "released"))
+
+	deleted, err := repo.DeleteExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	var keys []string
+	require.NoError(t, db.Model(&models.IdempotencyKeyModel{}).Pluck("idempotency_key", &keys).Error)
+	assert.Equal(t, []string{"live"}, keys)
+}
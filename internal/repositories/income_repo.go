@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
@@ -28,18 +29,18 @@ func (r *incomeRepository) SaveIncome(ctx context.Context, income domain.Income)
 	
 	// First try to find existing record
 	var existing models.IncomeModel
-	result := r.db.WithContext(ctx).First(&existing, "id = ?", income.ID)
+	result := dbFromContext(ctx, r.db).First(&existing, "id = ?", income.ID)
 	
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			// Create new record with explicit column selection to override defaults
-			result = r.db.WithContext(ctx).Select("*").Create(model)
+			result = dbFromContext(ctx, r.db).Select("*").Create(model)
 		} else {
 			return fmt.Errorf("failed to check existing income: %w", result.Error)
 		}
 	} else {
 		// Update existing record with explicit selection to handle zero values
-		result = r.db.WithContext(ctx).Model(&existing).Select("*").Updates(model)
+		result = dbFromContext(ctx, r.db).Model(&existing).Select("*").Updates(model)
 	}
 	
 	if result.Error != nil {
@@ -53,7 +54,7 @@ func (r *incomeRepository) SaveIncome(ctx context.Context, income domain.Income)
 func (r *incomeRepository) GetIncomeByID(ctx context.Context, id string) (domain.Income, error) {
 	var model models.IncomeModel
 	
-	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	result := dbFromContext(ctx, r.db).First(&model, "id = ?", id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return domain.Income{}, fmt.Errorf("income with ID %s not found", id)
@@ -69,7 +70,7 @@ func (r *incomeRepository) UpdateIncome(ctx context.Context, income domain.Incom
 	model := models.NewIncomeModelFromDomain(income)
 	
 	// Use Select to explicitly update all fields including zero values
-	result := r.db.WithContext(ctx).Model(&models.IncomeModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.IncomeModel{}).
 		Where("id = ?", income.ID).
 		Select("*").
 		Updates(model)
@@ -87,7 +88,7 @@ func (r *incomeRepository) UpdateIncome(ctx context.Context, income domain.Incom
 
 // DeleteIncome soft deletes an income record
 func (r *incomeRepository) DeleteIncome(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&models.IncomeModel{}, "id = ?", id)
+	result := dbFromContext(ctx, r.db).Delete(&models.IncomeModel{}, "id = ?", id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete income: %w", result.Error)
 	}
@@ -99,11 +100,42 @@ func (r *incomeRepository) DeleteIncome(ctx context.Context, id string) error {
 	return nil
 }
 
+// GetDeletedIncomeByID retrieves a soft-deleted income by its ID
+func (r *incomeRepository) GetDeletedIncomeByID(ctx context.Context, id string) (domain.Income, error) {
+	var model models.IncomeModel
+
+	result := dbFromContext(ctx, r.db).Unscoped().Where("deleted_at IS NOT NULL").First(&model, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return domain.Income{}, fmt.Errorf("deleted income with ID %s not found", id)
+		}
+		return domain.Income{}, fmt.Errorf("failed to get deleted income by ID: %w", result.Error)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// RestoreIncome undoes the soft delete of an income record
+func (r *incomeRepository) RestoreIncome(ctx context.Context, id string) error {
+	result := dbFromContext(ctx, r.db).Unscoped().Model(&models.IncomeModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return fmt.Errorf("failed to restore income: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("deleted income with ID %s not found", id)
+	}
+
+	return nil
+}
+
 // GetUserIncomes retrieves all incomes for a specific user
 func (r *incomeRepository) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
 	var models []models.IncomeModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get user incomes: %w", result.Error)
 	}
@@ -116,11 +148,56 @@ func (r *incomeRepository) GetUserIncomes(ctx context.Context, userID string) ([
 	return incomes, nil
 }
 
+// GetUserIncomesAfter retrieves up to limit incomes for a user that come after cursor,
+// ordered by creation time and then ID so pages stay stable while new incomes are added
+func (r *incomeRepository) GetUserIncomesAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Income, error) {
+	var models []models.IncomeModel
+
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
+	if !cursor.IsZero() {
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	result := query.Order("created_at ASC, id ASC").Limit(limit).Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user incomes page: %w", result.Error)
+	}
+
+	incomes := make([]domain.Income, len(models))
+	for i, model := range models {
+		incomes[i] = model.ToDomain()
+	}
+
+	return incomes, nil
+}
+
+// FindDuplicateIncomeID returns the ID of the newest active income matching the user, source,
+// amount and frequency of income that was created at or after since, or "" if there is none.
+// The lookup is served by the idx_incomes_duplicate_check index.
+func (r *incomeRepository) FindDuplicateIncomeID(ctx context.Context, income domain.Income, since time.Time) (string, error) {
+	var ids []string
+
+	result := dbFromContext(ctx, r.db).Model(&models.IncomeModel{}).
+		Where("user_id = ? AND source = ? AND frequency = ? AND amount = ? AND is_active = ? AND created_at >= ?",
+			income.UserID, income.Source, income.Frequency, income.Amount, true, since).
+		Order("created_at DESC").
+		Limit(1).
+		Pluck("id", &ids)
+	if result.Error != nil {
+		return "", fmt.Errorf("failed to check for duplicate income: %w", result.Error)
+	}
+
+	if len(ids) == 0 {
+		return "", nil
+	}
+	return ids[0], nil
+}
+
 // GetActiveIncomes retrieves only active incomes for a specific user
 func (r *incomeRepository) GetActiveIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
 	var models []models.IncomeModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND is_active = ?", userID, true).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND is_active = ?", userID, true).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get active incomes: %w", result.Error)
 	}
@@ -137,7 +214,7 @@ func (r *incomeRepository) GetActiveIncomes(ctx context.Context, userID string)
 func (r *incomeRepository) GetUserIncomesByFrequency(ctx context.Context, userID string, frequency string) ([]domain.Income, error) {
 	var models []models.IncomeModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND frequency = ?", userID, frequency).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND frequency = ?", userID, frequency).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get incomes by frequency: %w", result.Error)
 	}
@@ -154,7 +231,7 @@ func (r *incomeRepository) GetUserIncomesByFrequency(ctx context.Context, userID
 func (r *incomeRepository) CalculateUserTotalIncome(ctx context.Context, userID string, activeOnly bool) (float64, error) {
 	var total float64
 	
-	query := r.db.WithContext(ctx).Model(&models.IncomeModel{}).
+	query := dbFromContext(ctx, r.db).Model(&models.IncomeModel{}).
 		Select("COALESCE(SUM(amount), 0)").
 		Where("user_id = ?", userID)
 	
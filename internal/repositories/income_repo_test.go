@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -243,6 +244,72 @@ func TestIncomeRepository_DeleteIncome_NonexistentRecord_ReturnsError(t *testing
 	assert.Contains(t, err.Error(), "not found")
 }
 
+func TestIncomeRepository_RestoreIncome_DeleteHidesAndRestoreBringsBack(t *testing.T) {
+	// Arrange
+	db := setupIncomeTestDB(t)
+	repo := NewIncomeRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	salary := createTestIncome(userID, "Salary", 5000.00, "monthly")
+	freelance := createTestIncome(userID, "Freelance", 1500.00, "monthly")
+	require.NoError(t, repo.SaveIncome(ctx, salary))
+	require.NoError(t, repo.SaveIncome(ctx, freelance))
+
+	// Delete hides the row from lookups, listings and totals
+	require.NoError(t, repo.DeleteIncome(ctx, freelance.ID))
+
+	_, err := repo.GetIncomeByID(ctx, freelance.ID)
+	assert.Error(t, err)
+	active, err := repo.GetActiveIncomes(ctx, userID)
+	require.NoError(t, err)
+	assert.Len(t, active, 1)
+	total, err := repo.CalculateUserTotalIncome(ctx, userID, true)
+	require.NoError(t, err)
+	assert.Equal(t, 5000.00, total)
+
+	deleted, err := repo.GetDeletedIncomeByID(ctx, freelance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, deleted.UserID)
+
+	// Act
+	err = repo.RestoreIncome(ctx, freelance.ID)
+
+	// Assert
+	require.NoError(t, err)
+
+	restored, err := repo.GetIncomeByID(ctx, freelance.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Freelance", restored.Source)
+	total, err = repo.CalculateUserTotalIncome(ctx, userID, true)
+	require.NoError(t, err)
+	assert.Equal(t, 6500.00, total)
+
+	_, err = repo.GetDeletedIncomeByID(ctx, freelance.ID)
+	assert.Error(t, err)
+}
+
+func TestIncomeRepository_RestoreIncome_NotDeleted_ReturnsError(t *testing.T) {
+	// Arrange
+	db := setupIncomeTestDB(t)
+	repo := NewIncomeRepository(db)
+	ctx := context.Background()
+
+	income := createTestIncome("user-123", "Salary", 5000.00, "monthly")
+	require.NoError(t, repo.SaveIncome(ctx, income))
+
+	// Act
+	err := repo.RestoreIncome(ctx, income.ID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+
+	err = repo.RestoreIncome(ctx, "nonexistent-id")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
+
 func TestIncomeRepository_GetActiveIncomes_FiltersInactive_ReturnsOnlyActive(t *testing.T) {
 	// Arrange
 	db := setupIncomeTestDB(t)
@@ -398,4 +465,94 @@ func TestIncomeRepository_CalculateUserTotalIncome_IncludeInactive_ReturnsAllInc
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, 8000.00, total) // 5000 + 3000, including inactive
-}
\ No newline at end of file
+}
+func TestIncomeRepository_GetUserIncomesAfter_WalksAllPagesWithoutGapsOrDuplicates(t *testing.T) {
+	// Arrange
+	db := setupIncomeTestDB(t)
+	repo := NewIncomeRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Several incomes share a creation time, so the ID tie-break decides their order
+	var want []string
+	for i := 0; i < 8; i++ {
+		income := createTestIncome(userID, fmt.Sprintf("Source%d", i), 100, "monthly")
+		income.CreatedAt = base.Add(time.Duration(i/3) * time.Minute)
+		require.NoError(t, repo.SaveIncome(ctx, income))
+		want = append(want, income.ID)
+	}
+	require.NoError(t, repo.SaveIncome(ctx, createTestIncome("other-user", "Other", 100, "monthly")))
+
+	// Act: walk the list three at a time, adding an income once the walk has started
+	var got []string
+	cursor := domain.Cursor{}
+	for page := 0; page < 10; page++ {
+		incomes, err := repo.GetUserIncomesAfter(ctx, userID, cursor, 3)
+		require.NoError(t, err)
+		if len(incomes) == 0 {
+			break
+		}
+		for _, income := range incomes {
+			got = append(got, income.ID)
+		}
+		last := incomes[len(incomes)-1]
+		cursor = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+
+		if page == 0 {
+			late := createTestIncome(userID, "Late", 100, "monthly")
+			late.CreatedAt = base.Add(time.Hour)
+			require.NoError(t, repo.SaveIncome(ctx, late))
+			want = append(want, late.ID)
+		}
+	}
+
+	// Assert
+	assert.Len(t, got, 9)
+	assert.ElementsMatch(t, want, got)
+	for i := 1; i < len(got); i++ {
+		assert.NotEqual(t, got[i-1], got[i])
+	}
+	assert.Equal(t, "income-Late-123", got[len(got)-1], "incomes added during the walk come last")
+}
+
+func TestIncomeRepository_FindDuplicateIncomeID(t *testing.T) {
+	// Arrange
+	db := setupIncomeTestDB(t)
+	repo := NewIncomeRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	existing := createTestIncome("user-123", "Salary", 5000.00, "monthly")
+	existing.CreatedAt = now.Add(-time.Hour)
+	require.NoError(t, repo.SaveIncome(ctx, existing))
+	inactive := createTestIncomeWithActiveStatus("user-123", "Consulting", 800.00, "monthly", false)
+	require.NoError(t, repo.SaveIncome(ctx, inactive))
+
+	dayAgo := now.Add(-24 * time.Hour)
+	tests := []struct {
+		name     string
+		income   domain.Income
+		since    time.Time
+		expected string
+	}{
+		{"same source, amount and frequency", createTestIncome("user-123", "Salary", 5000.00, "monthly"), dayAgo, existing.ID},
+		{"same source, different amount", createTestIncome("user-123", "Salary", 5200.00, "monthly"), dayAgo, ""},
+		{"same amount, different frequency", createTestIncome("user-123", "Salary", 5000.00, "annual"), dayAgo, ""},
+		{"another user", createTestIncome("user-456", "Salary", 5000.00, "monthly"), dayAgo, ""},
+		{"outside the window", createTestIncome("user-123", "Salary", 5000.00, "monthly"), now.Add(-30 * time.Minute), ""},
+		{"inactive income", createTestIncome("user-123", "Consulting", 800.00, "monthly"), dayAgo, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			id, err := repo.FindDuplicateIncomeID(ctx, tt.income, tt.since)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
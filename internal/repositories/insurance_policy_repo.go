@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
@@ -34,11 +35,9 @@ func (r *insurancePolicyRepository) Create(ctx context.Context, policy *domain.I
 	model := &models.InsurancePolicyModel{}
 	model.FromDomain(policy, uint(profileID))
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
-		if containsStr(err.Error(), "UNIQUE constraint failed") ||
-			containsStr(err.Error(), "Duplicate entry") ||
-			containsStr(err.Error(), "unique constraint") {
-			return nil, fmt.Errorf("insurance policy with number %s already exists: unique constraint violation", policy.PolicyNumber)
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("insurance policy %s: %w", policy.PolicyNumber, domain.ErrPolicyNumberExists)
 		}
 		return nil, fmt.Errorf("failed to create insurance policy: %w", err)
 	}
@@ -48,16 +47,28 @@ func (r *insurancePolicyRepository) Create(ctx context.Context, policy *domain.I
 
 // GetByID retrieves an insurance policy by ID
 func (r *insurancePolicyRepository) GetByID(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	return r.getByID(dbFromContext(ctx, r.db), id)
+}
+
+// GetByIDForUpdate retrieves an insurance policy by ID with SELECT ... FOR UPDATE, locking its
+// row until the transaction in ctx ends. SQLite has no row locks and ignores the clause; it
+// serializes writing transactions instead.
+func (r *insurancePolicyRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	return r.getByID(dbFromContext(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
+
+// getByID retrieves an insurance policy by ID through db
+func (r *insurancePolicyRepository) getByID(db *gorm.DB, id string) (*domain.InsurancePolicy, error) {
 	idUint, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("invalid policy ID: %w", err)
+		return nil, fmt.Errorf("invalid policy ID %q: %w", id, domain.ErrInsurancePolicyNotFound)
 	}
 
 	var model models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := db.First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("insurance policy with ID %s not found", id)
+			return nil, fmt.Errorf("insurance policy with ID %s: %w", id, domain.ErrInsurancePolicyNotFound)
 		}
 		return nil, fmt.Errorf("failed to get insurance policy: %w", err)
 	}
@@ -78,7 +89,7 @@ func (r *insurancePolicyRepository) Update(ctx context.Context, policy *domain.I
 	}
 
 	var model models.InsurancePolicyModel
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("insurance policy with ID %s not found", policy.ID)
 		}
@@ -89,7 +100,10 @@ func (r *insurancePolicyRepository) Update(ctx context.Context, policy *domain.I
 	model.FromDomain(policy, uint(profileID))
 	model.ID = uint(idUint) // Preserve ID
 
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(&model).Error; err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, fmt.Errorf("insurance policy %s: %w", policy.PolicyNumber, domain.ErrPolicyNumberExists)
+		}
 		return nil, fmt.Errorf("failed to update insurance policy: %w", err)
 	}
 
@@ -103,7 +117,7 @@ func (r *insurancePolicyRepository) Delete(ctx context.Context, id string) error
 		return fmt.Errorf("invalid policy ID: %w", err)
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.InsurancePolicyModel{}, uint(idUint))
+	result := dbFromContext(ctx, r.db).Delete(&models.InsurancePolicyModel{}, uint(idUint))
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete insurance policy: %w", result.Error)
 	}
@@ -119,7 +133,7 @@ func (r *insurancePolicyRepository) Delete(ctx context.Context, id string) error
 func (r *insurancePolicyRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.InsurancePolicy, error) {
 	var models []models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ?", userID).
 		Order("created_at DESC").
 		Find(&models).Error; err != nil {
@@ -138,7 +152,7 @@ func (r *insurancePolicyRepository) GetByUserID(ctx context.Context, userID stri
 func (r *insurancePolicyRepository) GetByType(ctx context.Context, userID string, policyType string) ([]*domain.InsurancePolicy, error) {
 	var models []models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND type = ?", userID, policyType).
 		Order("created_at DESC").
 		Find(&models).Error; err != nil {
@@ -153,12 +167,12 @@ func (r *insurancePolicyRepository) GetByType(ctx context.Context, userID string
 	return policies, nil
 }
 
-// GetByPolicyNumber retrieves an insurance policy by policy number
-func (r *insurancePolicyRepository) GetByPolicyNumber(ctx context.Context, policyNumber string) (*domain.InsurancePolicy, error) {
+// GetByPolicyNumber retrieves the user's insurance policy with the policy number
+func (r *insurancePolicyRepository) GetByPolicyNumber(ctx context.Context, userID, policyNumber string) (*domain.InsurancePolicy, error) {
 	var model models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).
-		Where("policy_number = ?", policyNumber).
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND policy_number = ?", userID, policyNumber).
 		First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("insurance policy with number %s not found", policyNumber)
@@ -174,7 +188,7 @@ func (r *insurancePolicyRepository) GetActivePolicies(ctx context.Context, userI
 	var models []models.InsurancePolicyModel
 	now := time.Now()
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND is_active = ? AND start_date <= ? AND end_date >= ?", userID, true, now, now).
 		Order("created_at DESC").
 		Find(&models).Error; err != nil {
@@ -198,7 +212,7 @@ func (r *insurancePolicyRepository) GetByProfileID(ctx context.Context, profileI
 
 	var models []models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("profile_id = ?", uint(profileIDUint)).
 		Order("created_at DESC").
 		Find(&models).Error; err != nil {
@@ -220,21 +234,27 @@ func (r *insurancePolicyRepository) UpdateDeductibleProgress(ctx context.Context
 		return nil, fmt.Errorf("invalid policy ID: %w", err)
 	}
 
+	// The row is locked so the limits capped against can't change before the save
 	var model models.InsurancePolicyModel
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("insurance policy with ID %s not found", policyID)
+	err = dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, uint(idUint)).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("insurance policy with ID %s not found", policyID)
+			}
+			return fmt.Errorf("failed to find insurance policy: %w", err)
 		}
-		return nil, fmt.Errorf("failed to find insurance policy: %w", err)
-	}
 
-	// Update progress amounts with capping
-	model.DeductibleMet = deductibleMet
-	model.OutOfPocketCurrent = outOfPocketCurrent
-	
-	// Cap values at their maximums (this will be handled by the model's BeforeUpdate hook)
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
-		return nil, fmt.Errorf("failed to update deductible progress: %w", err)
+		model.DeductibleMet = deductibleMet
+		model.OutOfPocketCurrent = outOfPocketCurrent
+
+		// The model's BeforeUpdate hook caps both at the policy limits
+		if err := tx.Save(&model).Error; err != nil {
+			return fmt.Errorf("failed to update deductible progress: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return model.ToDomain(), nil
@@ -244,13 +264,13 @@ func (r *insurancePolicyRepository) UpdateDeductibleProgress(ctx context.Context
 func (r *insurancePolicyRepository) CalculateCoverageForExpense(ctx context.Context, policyID string, expenseAmount float64) (*services.CoverageCalculation, error) {
 	idUint, err := strconv.ParseUint(policyID, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("invalid policy ID: %w", err)
+		return nil, fmt.Errorf("invalid policy ID %q: %w", policyID, domain.ErrInsurancePolicyNotFound)
 	}
 
 	var model models.InsurancePolicyModel
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("insurance policy with ID %s not found", policyID)
+			return nil, fmt.Errorf("insurance policy with ID %s: %w", policyID, domain.ErrInsurancePolicyNotFound)
 		}
 		return nil, fmt.Errorf("failed to find insurance policy: %w", err)
 	}
@@ -281,7 +301,7 @@ func (r *insurancePolicyRepository) CalculateCoverageForExpense(ctx context.Cont
 func (r *insurancePolicyRepository) GetPoliciesByProvider(ctx context.Context, userID string, provider string) ([]*domain.InsurancePolicy, error) {
 	var models []models.InsurancePolicyModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND provider = ?", userID, provider).
 		Order("created_at DESC").
 		Find(&models).Error; err != nil {
@@ -81,10 +81,15 @@ func TestInsurancePolicyRepository_AddPolicy_UniqueNumber(t *testing.T) {
 	assert.NotEmpty(t, result1.ID)
 	assert.Equal(t, "HC-12345", result1.PolicyNumber)
 
-	// Second policy with same number should fail
+	// Policy numbers are unique per user, so another user can hold the same number
 	_, err = repo.Create(ctx, policy2)
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "unique constraint")
+	assert.NoError(t, err)
+
+	// The same user can't add the number twice
+	duplicate := *policy1
+	duplicate.Type = "dental"
+	_, err = repo.Create(ctx, &duplicate)
+	assert.ErrorIs(t, err, domain.ErrPolicyNumberExists)
 }
 
 func TestInsurancePolicyRepository_GetActivePolicies_FiltersByDate(t *testing.T) {
@@ -324,7 +329,7 @@ func TestInsurancePolicyRepository_GetPolicyByNumber(t *testing.T) {
 	require.NoError(t, err)
 
 	// Find policy by number
-	foundPolicy, err := repo.GetByPolicyNumber(ctx, "HC-12345")
+	foundPolicy, err := repo.GetByPolicyNumber(ctx, "test-user-123", "HC-12345")
 
 	assert.NoError(t, err)
 	assert.Equal(t, "HC-12345", foundPolicy.PolicyNumber)
@@ -332,7 +337,7 @@ func TestInsurancePolicyRepository_GetPolicyByNumber(t *testing.T) {
 	assert.Equal(t, "test-user-123", foundPolicy.UserID)
 
 	// Search for non-existent policy
-	_, err = repo.GetByPolicyNumber(ctx, "NON-EXISTENT")
+	_, err = repo.GetByPolicyNumber(ctx, "test-user-123", "NON-EXISTENT")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
@@ -3,11 +3,13 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/models"
 	"github.com/DuckDHD/BuyOrBye/internal/services"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // loanRepository implements services.LoanRepository using GORM
@@ -28,18 +30,18 @@ func (r *loanRepository) SaveLoan(ctx context.Context, loan domain.Loan) error {
 	
 	// First try to find existing record
 	var existing models.LoanModel
-	result := r.db.WithContext(ctx).First(&existing, "id = ?", loan.ID)
+	result := dbFromContext(ctx, r.db).First(&existing, "id = ?", loan.ID)
 	
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			// Create new record with explicit column selection to override defaults
-			result = r.db.WithContext(ctx).Select("*").Create(model)
+			result = dbFromContext(ctx, r.db).Select("*").Create(model)
 		} else {
 			return fmt.Errorf("failed to check existing loan: %w", result.Error)
 		}
 	} else {
 		// Update existing record with explicit selection to handle zero values
-		result = r.db.WithContext(ctx).Model(&existing).Select("*").Updates(model)
+		result = dbFromContext(ctx, r.db).Model(&existing).Select("*").Updates(model)
 	}
 	
 	if result.Error != nil {
@@ -53,7 +55,7 @@ func (r *loanRepository) SaveLoan(ctx context.Context, loan domain.Loan) error {
 func (r *loanRepository) GetLoanByID(ctx context.Context, id string) (domain.Loan, error) {
 	var model models.LoanModel
 	
-	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	result := dbFromContext(ctx, r.db).First(&model, "id = ?", id)
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
 			return domain.Loan{}, fmt.Errorf("loan with ID %s not found", id)
@@ -69,7 +71,7 @@ func (r *loanRepository) UpdateLoan(ctx context.Context, loan domain.Loan) error
 	model := models.NewLoanModelFromDomain(loan)
 	
 	// Use Select to explicitly update all fields including zero values
-	result := r.db.WithContext(ctx).Model(&models.LoanModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.LoanModel{}).
 		Where("id = ?", loan.ID).
 		Select("*").
 		Updates(model)
@@ -87,7 +89,7 @@ func (r *loanRepository) UpdateLoan(ctx context.Context, loan domain.Loan) error
 
 // DeleteLoan soft deletes a loan record
 func (r *loanRepository) DeleteLoan(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&models.LoanModel{}, "id = ?", id)
+	result := dbFromContext(ctx, r.db).Delete(&models.LoanModel{}, "id = ?", id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete loan: %w", result.Error)
 	}
@@ -103,7 +105,7 @@ func (r *loanRepository) DeleteLoan(ctx context.Context, id string) error {
 func (r *loanRepository) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
 	var models []models.LoanModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get user loans: %w", result.Error)
 	}
@@ -116,11 +118,34 @@ func (r *loanRepository) GetUserLoans(ctx context.Context, userID string) ([]dom
 	return loans, nil
 }
 
+// GetUserLoansAfter retrieves up to limit loans for a user that come after cursor,
+// ordered by creation time and then ID so pages stay stable while new loans are added
+func (r *loanRepository) GetUserLoansAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Loan, error) {
+	var models []models.LoanModel
+
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
+	if !cursor.IsZero() {
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)", cursor.CreatedAt, cursor.CreatedAt, cursor.ID)
+	}
+
+	result := query.Order("created_at ASC, id ASC").Limit(limit).Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user loans page: %w", result.Error)
+	}
+
+	loans := make([]domain.Loan, len(models))
+	for i, model := range models {
+		loans[i] = model.ToDomain()
+	}
+
+	return loans, nil
+}
+
 // GetLoansByType retrieves loans for a user filtered by loan type
 func (r *loanRepository) GetLoansByType(ctx context.Context, userID string, loanType string) ([]domain.Loan, error) {
 	var models []models.LoanModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND type = ?", userID, loanType).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND type = ?", userID, loanType).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get loans by type: %w", result.Error)
 	}
@@ -137,7 +162,7 @@ func (r *loanRepository) GetLoansByType(ctx context.Context, userID string, loan
 func (r *loanRepository) GetLoansByInterestRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error) {
 	var models []models.LoanModel
 	
-	result := r.db.WithContext(ctx).Where("user_id = ? AND interest_rate >= ? AND interest_rate <= ?", userID, minRate, maxRate).Find(&models)
+	result := dbFromContext(ctx, r.db).Where("user_id = ? AND interest_rate >= ? AND interest_rate <= ?", userID, minRate, maxRate).Find(&models)
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to get loans by interest rate range: %w", result.Error)
 	}
@@ -152,7 +177,7 @@ func (r *loanRepository) GetLoansByInterestRateRange(ctx context.Context, userID
 
 // UpdateLoanBalance updates the remaining balance for a specific loan
 func (r *loanRepository) UpdateLoanBalance(ctx context.Context, loanID string, newBalance float64) error {
-	result := r.db.WithContext(ctx).Model(&models.LoanModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.LoanModel{}).
 		Where("id = ?", loanID).
 		Update("remaining_balance", newBalance)
 	
@@ -172,7 +197,7 @@ func (r *loanRepository) GetNearPayoffLoans(ctx context.Context, userID string,
 	var models []models.LoanModel
 	
 	// Find loans where remaining_balance / principal_amount <= threshold
-	result := r.db.WithContext(ctx).
+	result := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND (CAST(remaining_balance AS REAL) / CAST(principal_amount AS REAL)) <= ?", userID, threshold).
 		Find(&models)
 	
@@ -192,7 +217,7 @@ func (r *loanRepository) GetNearPayoffLoans(ctx context.Context, userID string,
 func (r *loanRepository) CalculateUserTotalDebt(ctx context.Context, userID string) (float64, error) {
 	var total float64
 	
-	result := r.db.WithContext(ctx).Model(&models.LoanModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.LoanModel{}).
 		Select("COALESCE(SUM(remaining_balance), 0)").
 		Where("user_id = ?", userID).
 		Scan(&total)
@@ -208,7 +233,7 @@ func (r *loanRepository) CalculateUserTotalDebt(ctx context.Context, userID stri
 func (r *loanRepository) CalculateUserMonthlyPayments(ctx context.Context, userID string) (float64, error) {
 	var total float64
 	
-	result := r.db.WithContext(ctx).Model(&models.LoanModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.LoanModel{}).
 		Select("COALESCE(SUM(monthly_payment), 0)").
 		Where("user_id = ?", userID).
 		Scan(&total)
@@ -218,4 +243,92 @@ func (r *loanRepository) CalculateUserMonthlyPayments(ctx context.Context, userI
 	}
 	
 	return total, nil
-}
\ No newline at end of file
+}
+
+// GetLoanByIDForUpdate retrieves a loan by ID with SELECT ... FOR UPDATE, locking its row until
+// the transaction in ctx commits or rolls back
+func (r *loanRepository) GetLoanByIDForUpdate(ctx context.Context, id string) (domain.Loan, error) {
+	var model models.LoanModel
+
+	result := dbFromContext(ctx, r.db).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return domain.Loan{}, fmt.Errorf("loan with ID %s not found", id)
+		}
+		return domain.Loan{}, fmt.Errorf("failed to get loan by ID: %w", result.Error)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// RecordBalanceChange saves a balance entry and sets the loan's remaining balance to the entry's
+// new balance in one transaction
+func (r *loanRepository) RecordBalanceChange(ctx context.Context, entry domain.LoanBalanceEntry) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.LoanModel{}).
+			Where("id = ?", entry.LoanID).
+			Updates(map[string]interface{}{
+				"remaining_balance": entry.NewBalance,
+				"updated_at":        time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update loan balance: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("loan with ID %s not found", entry.LoanID)
+		}
+
+		if err := tx.Create(models.NewLoanBalanceEntryModelFromDomain(entry)).Error; err != nil {
+			return fmt.Errorf("failed to save loan balance entry: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetBalanceEntries retrieves a loan's balance entries, newest first
+func (r *loanRepository) GetBalanceEntries(ctx context.Context, loanID string) ([]domain.LoanBalanceEntry, error) {
+	var models []models.LoanBalanceEntryModel
+
+	result := dbFromContext(ctx, r.db).
+		Where("loan_id = ?", loanID).
+		Order("created_at DESC, id DESC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get loan balance entries: %w", result.Error)
+	}
+
+	entries := make([]domain.LoanBalanceEntry, len(models))
+	for i, model := range models {
+		entries[i] = model.ToDomain()
+	}
+
+	return entries, nil
+}
+
+// GetLoansDueForAccrual retrieves the auto-accruing loans with a balance left that have no
+// interest accrual entry at or after since
+func (r *loanRepository) GetLoansDueForAccrual(ctx context.Context, since time.Time) ([]domain.Loan, error) {
+	accrued := dbFromContext(ctx, r.db).Model(&models.LoanBalanceEntryModel{}).
+		Select("1").
+		Where("loan_balance_entries.loan_id = loans.id AND loan_balance_entries.type = ? AND loan_balance_entries.created_at >= ?",
+			domain.LoanBalanceInterestAccrual, since)
+
+	var models []models.LoanModel
+
+	result := dbFromContext(ctx, r.db).
+		Where("auto_accrue_interest = ? AND remaining_balance > 0", true).
+		Where("NOT EXISTS (?)", accrued).
+		Order("id ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get loans due for interest accrual: %w", result.Error)
+	}
+
+	loans := make([]domain.Loan, len(models))
+	for i, model := range models {
+		loans[i] = model.ToDomain()
+	}
+
+	return loans, nil
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -474,4 +475,43 @@ func TestLoanRepository_GetNearPayoffLoans_ReturnsLoansNearCompletion(t *testing
 	// Verify the loan is indeed near payoff (1000/25000 = 4% < 5%)
 	payoffPercentage := nearPayoffLoans[0].RemainingBalance / nearPayoffLoans[0].PrincipalAmount
 	assert.True(t, payoffPercentage < 0.05)
-}
\ No newline at end of file
+}
+func TestLoanRepository_GetUserLoansAfter_WalksAllPagesWithoutGapsOrDuplicates(t *testing.T) {
+	// Arrange
+	db := setupLoanTestDB(t)
+	repo := NewLoanRepository(db)
+	ctx := context.Background()
+
+	userID := "user-123"
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	var want []string
+	for i := 0; i < 7; i++ {
+		loan := createTestLoan(userID, fmt.Sprintf("Bank%d", i), "personal", 10000, 5000, 200, 5)
+		// Save newest first so insertion order doesn't match the sort order
+		loan.CreatedAt = base.Add(time.Duration(6-i) * time.Minute)
+		require.NoError(t, repo.SaveLoan(ctx, loan))
+		want = append([]string{loan.ID}, want...)
+	}
+	require.NoError(t, repo.SaveLoan(ctx, createTestLoan("other-user", "Other", "personal", 10000, 5000, 200, 5)))
+
+	// Act
+	var got []string
+	cursor := domain.Cursor{}
+	for page := 0; page < 10; page++ {
+		loans, err := repo.GetUserLoansAfter(ctx, userID, cursor, 2)
+		require.NoError(t, err)
+		if len(loans) == 0 {
+			break
+		}
+		assert.LessOrEqual(t, len(loans), 2)
+		for _, loan := range loans {
+			got = append(got, loan.ID)
+		}
+		last := loans[len(loans)-1]
+		cursor = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+
+	// Assert: every loan exactly once, oldest first
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func TestLocalBlobStorage_PutGetDelete(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "attachments")
+	storage, err := NewLocalBlobStorage(root)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, storage.Put(ctx, "blob-1", strings.NewReader("%PDF-1.7 receipt")))
+
+	reader, err := storage.Get(ctx, "blob-1")
+	require.NoError(t, err)
+	content, err := io.ReadAll(reader)
+	require.NoError(t, reader.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.7 receipt", string(content))
+
+	// Only the stored file is left behind, under its key
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "blob-1", entries[0].Name())
+
+	require.NoError(t, storage.Delete(ctx, "blob-1"))
+	_, err = storage.Get(ctx, "blob-1")
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	assert.NoError(t, storage.Delete(ctx, "blob-1"), "deleting a missing blob is not an error")
+}
+
+func TestLocalBlobStorage_RejectsKeysOutsideRoot(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewLocalBlobStorage(filepath.Join(dir, "attachments"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	for _, key := range []string{"", "..", "../escape", `..\escape`, "nested/key", ".hidden"} {
+		assert.Error(t, storage.Put(ctx, key, strings.NewReader("data")), "key %q", key)
+		_, err := storage.Get(ctx, key)
+		assert.Error(t, err, "key %q", key)
+		assert.Error(t, storage.Delete(ctx, key), "key %q", key)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "escape"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -33,7 +33,7 @@ func (r *medicalConditionRepository) Create(ctx context.Context, condition *doma
 	model := &models.MedicalConditionModel{}
 	model.FromDomain(condition, uint(profileID))
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
 		return nil, fmt.Errorf("failed to create medical condition: %w", err)
 	}
 
@@ -44,14 +44,14 @@ func (r *medicalConditionRepository) Create(ctx context.Context, condition *doma
 func (r *medicalConditionRepository) GetByID(ctx context.Context, id string) (*domain.MedicalCondition, error) {
 	idUint, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("invalid condition ID: %w", err)
+		return nil, fmt.Errorf("invalid condition ID %q: %w", id, domain.ErrMedicalConditionNotFound)
 	}
 
 	var model models.MedicalConditionModel
 	
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("medical condition with ID %s not found", id)
+			return nil, fmt.Errorf("medical condition with ID %s: %w", id, domain.ErrMedicalConditionNotFound)
 		}
 		return nil, fmt.Errorf("failed to get medical condition: %w", err)
 	}
@@ -72,7 +72,7 @@ func (r *medicalConditionRepository) Update(ctx context.Context, condition *doma
 	}
 
 	var model models.MedicalConditionModel
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("medical condition with ID %s not found", condition.ID)
 		}
@@ -83,7 +83,7 @@ func (r *medicalConditionRepository) Update(ctx context.Context, condition *doma
 	model.FromDomain(condition, uint(profileID))
 	model.ID = uint(idUint) // Preserve ID
 
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(&model).Error; err != nil {
 		return nil, fmt.Errorf("failed to update medical condition: %w", err)
 	}
 
@@ -97,7 +97,7 @@ func (r *medicalConditionRepository) Delete(ctx context.Context, id string) erro
 		return fmt.Errorf("invalid condition ID: %w", err)
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.MedicalConditionModel{}, uint(idUint))
+	result := dbFromContext(ctx, r.db).Delete(&models.MedicalConditionModel{}, uint(idUint))
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete medical condition: %w", result.Error)
 	}
@@ -113,7 +113,7 @@ func (r *medicalConditionRepository) Delete(ctx context.Context, id string) erro
 func (r *medicalConditionRepository) GetByUserID(ctx context.Context, userID string, activeOnly bool) ([]*domain.MedicalCondition, error) {
 	var models []models.MedicalConditionModel
 	
-	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	query := dbFromContext(ctx, r.db).Where("user_id = ?", userID)
 	if activeOnly {
 		query = query.Where("is_active = ?", true)
 	}
@@ -134,7 +134,7 @@ func (r *medicalConditionRepository) GetByUserID(ctx context.Context, userID str
 func (r *medicalConditionRepository) GetByCategory(ctx context.Context, userID string, category string) ([]*domain.MedicalCondition, error) {
 	var models []models.MedicalConditionModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND category = ?", userID, category).
 		Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to get medical conditions by category: %w", err)
@@ -152,7 +152,7 @@ func (r *medicalConditionRepository) GetByCategory(ctx context.Context, userID s
 func (r *medicalConditionRepository) GetBySeverity(ctx context.Context, userID string, severity string) ([]*domain.MedicalCondition, error) {
 	var models []models.MedicalConditionModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND severity = ?", userID, severity).
 		Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to get medical conditions by severity: %w", err)
@@ -175,7 +175,7 @@ func (r *medicalConditionRepository) GetByProfileID(ctx context.Context, profile
 
 	var models []models.MedicalConditionModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("profile_id = ?", uint(profileIDUint)).
 		Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to get medical conditions by profile: %w", err)
@@ -193,7 +193,7 @@ func (r *medicalConditionRepository) GetByProfileID(ctx context.Context, profile
 func (r *medicalConditionRepository) GetActiveConditionCount(ctx context.Context, userID string) (int64, error) {
 	var count int64
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Model(&models.MedicalConditionModel{}).
 		Where("user_id = ? AND is_active = ?", userID, true).
 		Count(&count).Error; err != nil {
@@ -209,7 +209,7 @@ func (r *medicalConditionRepository) CalculateTotalRiskFactor(ctx context.Contex
 		TotalRisk float64
 	}
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Model(&models.MedicalConditionModel{}).
 		Select("COALESCE(SUM(risk_factor), 0) as total_risk").
 		Where("user_id = ? AND is_active = ?", userID, true).
@@ -224,7 +224,7 @@ func (r *medicalConditionRepository) CalculateTotalRiskFactor(ctx context.Contex
 func (r *medicalConditionRepository) GetMedicationRequiringConditions(ctx context.Context, userID string) ([]*domain.MedicalCondition, error) {
 	var models []models.MedicalConditionModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND requires_medication = ? AND is_active = ?", userID, true, true).
 		Find(&models).Error; err != nil {
 		return nil, fmt.Errorf("failed to get medication-requiring conditions: %w", err)
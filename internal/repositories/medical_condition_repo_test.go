@@ -220,6 +220,40 @@ func TestMedicalConditionRepository_UpdateCondition_ChangesSeverity(t *testing.T
 	assert.True(t, updatedCondition.UpdatedAt.After(updatedCondition.CreatedAt))
 }
 
+func TestMedicalConditionRepository_UpdateCondition_PersistsResolvedDate(t *testing.T) {
+	db := setupConditionTestDB(t)
+	repo := NewMedicalConditionRepository(db)
+	ctx := context.Background()
+
+	createdCondition, err := repo.Create(ctx, &domain.MedicalCondition{
+		UserID:        "test-user-123",
+		ProfileID:     "1",
+		Name:          "Sprained ankle",
+		Category:      "acute",
+		Severity:      "mild",
+		DiagnosedDate: time.Now().AddDate(0, -1, 0),
+		IsActive:      true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, createdCondition.ResolvedDate)
+
+	createdCondition.Resolve(time.Now())
+	_, err = repo.Update(ctx, createdCondition)
+	require.NoError(t, err)
+
+	// Resolved conditions are kept and returned when inactive conditions are included
+	all, err := repo.GetByUserID(ctx, "test-user-123", false)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.False(t, all[0].IsActive)
+	require.NotNil(t, all[0].ResolvedDate)
+	assert.WithinDuration(t, *createdCondition.ResolvedDate, *all[0].ResolvedDate, time.Second)
+
+	active, err := repo.GetByUserID(ctx, "test-user-123", true)
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
 func TestMedicalConditionRepository_DeleteCondition_SoftDelete(t *testing.T) {
 	db := setupConditionTestDB(t)
 	repo := NewMedicalConditionRepository(db)
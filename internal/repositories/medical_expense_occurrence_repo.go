@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// medicalExpenseOccurrenceRepository implements services.MedicalExpenseOccurrenceRepository
+type medicalExpenseOccurrenceRepository struct {
+	db *gorm.DB
+}
+
+// NewMedicalExpenseOccurrenceRepository creates a new medical expense occurrence repository
+func NewMedicalExpenseOccurrenceRepository(db *gorm.DB) services.MedicalExpenseOccurrenceRepository {
+	return &medicalExpenseOccurrenceRepository{db: db}
+}
+
+// Create records an occurrence of a medical expense
+func (r *medicalExpenseOccurrenceRepository) Create(ctx context.Context, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error) {
+	expenseID, err := strconv.ParseUint(occurrence.ExpenseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID: %w", err)
+	}
+	profileID, err := strconv.ParseUint(occurrence.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	model := &models.MedicalExpenseOccurrenceModel{}
+	model.FromDomain(occurrence, uint(expenseID), uint(profileID))
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return nil, fmt.Errorf("failed to create expense occurrence: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// GetByExpenseID retrieves an expense's occurrences ordered by date, oldest first
+func (r *medicalExpenseOccurrenceRepository) GetByExpenseID(ctx context.Context, expenseID string) ([]*domain.MedicalExpenseOccurrence, error) {
+	id, err := strconv.ParseUint(expenseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID %q: %w", expenseID, domain.ErrMedicalExpenseNotFound)
+	}
+
+	var occurrenceModels []models.MedicalExpenseOccurrenceModel
+	if err := dbFromContext(ctx, r.db).
+		Where("expense_id = ?", uint(id)).
+		Order("date ASC, id ASC").
+		Find(&occurrenceModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expense occurrences: %w", err)
+	}
+
+	return occurrencesToDomain(occurrenceModels), nil
+}
+
+// GetByUserID retrieves the user's occurrences dated on or after since, oldest first
+func (r *medicalExpenseOccurrenceRepository) GetByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.MedicalExpenseOccurrence, error) {
+	var occurrenceModels []models.MedicalExpenseOccurrenceModel
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND date >= ?", userID, since).
+		Order("date ASC, id ASC").
+		Find(&occurrenceModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get expense occurrences: %w", err)
+	}
+
+	return occurrencesToDomain(occurrenceModels), nil
+}
+
+// occurrencesToDomain converts occurrence models to domain occurrences
+func occurrencesToDomain(occurrenceModels []models.MedicalExpenseOccurrenceModel) []*domain.MedicalExpenseOccurrence {
+	occurrences := make([]*domain.MedicalExpenseOccurrence, len(occurrenceModels))
+	for i := range occurrenceModels {
+		occurrences[i] = occurrenceModels[i].ToDomain()
+	}
+	return occurrences
+}
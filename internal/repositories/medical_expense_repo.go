@@ -34,7 +34,7 @@ func (r *medicalExpenseRepository) Create(ctx context.Context, expense *domain.M
 	model := &models.MedicalExpenseModel{}
 	model.FromDomain(expense, uint(profileID))
 
-	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
 		return nil, fmt.Errorf("failed to create medical expense: %w", err)
 	}
 
@@ -45,14 +45,14 @@ func (r *medicalExpenseRepository) Create(ctx context.Context, expense *domain.M
 func (r *medicalExpenseRepository) GetByID(ctx context.Context, id string) (*domain.MedicalExpense, error) {
 	idUint, err := strconv.ParseUint(id, 10, 32)
 	if err != nil {
-		return nil, fmt.Errorf("invalid expense ID: %w", err)
+		return nil, fmt.Errorf("invalid expense ID %q: %w", id, domain.ErrMedicalExpenseNotFound)
 	}
 
 	var model models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("medical expense with ID %s not found", id)
+			return nil, fmt.Errorf("medical expense with ID %s: %w", id, domain.ErrMedicalExpenseNotFound)
 		}
 		return nil, fmt.Errorf("failed to get medical expense: %w", err)
 	}
@@ -73,7 +73,7 @@ func (r *medicalExpenseRepository) Update(ctx context.Context, expense *domain.M
 	}
 
 	var model models.MedicalExpenseModel
-	if err := r.db.WithContext(ctx).First(&model, uint(idUint)).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("medical expense with ID %s not found", expense.ID)
 		}
@@ -84,7 +84,7 @@ func (r *medicalExpenseRepository) Update(ctx context.Context, expense *domain.M
 	model.FromDomain(expense, uint(profileID))
 	model.ID = uint(idUint) // Preserve ID
 
-	if err := r.db.WithContext(ctx).Save(&model).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Save(&model).Error; err != nil {
 		return nil, fmt.Errorf("failed to update medical expense: %w", err)
 	}
 
@@ -98,23 +98,30 @@ func (r *medicalExpenseRepository) Delete(ctx context.Context, id string) error
 		return fmt.Errorf("invalid expense ID: %w", err)
 	}
 
-	result := r.db.WithContext(ctx).Delete(&models.MedicalExpenseModel{}, uint(idUint))
-	if result.Error != nil {
-		return fmt.Errorf("failed to delete medical expense: %w", result.Error)
-	}
-	
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("medical expense with ID %s not found", id)
-	}
+	// The expense is soft deleted, so its occurrences are deleted here rather than by the foreign key
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Delete(&models.MedicalExpenseModel{}, uint(idUint))
+		if result.Error != nil {
+			return fmt.Errorf("failed to delete medical expense: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("medical expense with ID %s not found", id)
+		}
+
+		if err := tx.Where("expense_id = ?", uint(idUint)).Delete(&models.MedicalExpenseOccurrenceModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete expense occurrences: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetByUserID retrieves medical expenses by user ID
 func (r *medicalExpenseRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.MedicalExpense, error) {
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ?", userID).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -133,7 +140,7 @@ func (r *medicalExpenseRepository) GetByUserID(ctx context.Context, userID strin
 func (r *medicalExpenseRepository) GetByDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.MedicalExpense, error) {
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -152,7 +159,7 @@ func (r *medicalExpenseRepository) GetByDateRange(ctx context.Context, userID st
 func (r *medicalExpenseRepository) GetByCategory(ctx context.Context, userID string, category string) ([]*domain.MedicalExpense, error) {
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND category = ?", userID, category).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -171,7 +178,7 @@ func (r *medicalExpenseRepository) GetByCategory(ctx context.Context, userID str
 func (r *medicalExpenseRepository) GetByFrequency(ctx context.Context, userID string, frequency string) ([]*domain.MedicalExpense, error) {
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND frequency = ?", userID, frequency).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -190,7 +197,7 @@ func (r *medicalExpenseRepository) GetByFrequency(ctx context.Context, userID st
 func (r *medicalExpenseRepository) GetRecurring(ctx context.Context, userID string) ([]*domain.MedicalExpense, error) {
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("user_id = ? AND is_recurring = ?", userID, true).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -214,7 +221,7 @@ func (r *medicalExpenseRepository) GetByProfileID(ctx context.Context, profileID
 
 	var models []models.MedicalExpenseModel
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Where("profile_id = ?", uint(profileIDUint)).
 		Order("date DESC").
 		Find(&models).Error; err != nil {
@@ -238,7 +245,7 @@ func (r *medicalExpenseRepository) CalculateTotals(ctx context.Context, userID s
 		ExpenseCount       int64
 	}
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Model(&models.MedicalExpenseModel{}).
 		Select(`
 			COALESCE(SUM(amount), 0) as total_amount,
@@ -259,20 +266,66 @@ func (r *medicalExpenseRepository) CalculateTotals(ctx context.Context, userID s
 	}, nil
 }
 
+// GetCategoryTotals aggregates a user's expenses per category within a date range
+// Categories are ordered by total amount, highest first
+func (r *medicalExpenseRepository) GetCategoryTotals(ctx context.Context, userID string, startDate, endDate time.Time) ([]services.CategoryTotals, error) {
+	var results []struct {
+		Category           string
+		TotalAmount        float64
+		TotalInsurancePaid float64
+		TotalOutOfPocket   float64
+		ExpenseCount       int64
+	}
+
+	if err := dbFromContext(ctx, r.db).
+		Model(&models.MedicalExpenseModel{}).
+		Select(`
+			category,
+			COALESCE(SUM(amount), 0) as total_amount,
+			COALESCE(SUM(insurance_payment), 0) as total_insurance_paid,
+			COALESCE(SUM(out_of_pocket), 0) as total_out_of_pocket,
+			COUNT(*) as expense_count
+		`).
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Group("category").
+		Order("total_amount DESC").
+		Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to calculate category totals: %w", err)
+	}
+
+	totals := make([]services.CategoryTotals, len(results))
+	for i, result := range results {
+		totals[i] = services.CategoryTotals{
+			Category:           result.Category,
+			TotalAmount:        result.TotalAmount,
+			TotalInsurancePaid: result.TotalInsurancePaid,
+			TotalOutOfPocket:   result.TotalOutOfPocket,
+			ExpenseCount:       result.ExpenseCount,
+		}
+	}
+
+	return totals, nil
+}
+
 // GetMonthlyRecurringTotal calculates total monthly recurring expenses
 func (r *medicalExpenseRepository) GetMonthlyRecurringTotal(ctx context.Context, userID string) (float64, error) {
-	// Get all recurring expenses and convert to monthly
-	var expenses []models.MedicalExpenseModel
-	if err := r.db.WithContext(ctx).
+	// Sum recurring expenses per frequency in the database, then convert each sum to monthly
+	var totals []struct {
+		Frequency string
+		Total     float64
+	}
+	if err := dbFromContext(ctx, r.db).
+		Model(&models.MedicalExpenseModel{}).
+		Select("frequency, COALESCE(SUM(amount), 0) as total").
 		Where("user_id = ? AND is_recurring = ?", userID, true).
-		Find(&expenses).Error; err != nil {
+		Group("frequency").
+		Scan(&totals).Error; err != nil {
 		return 0, fmt.Errorf("failed to get recurring expenses: %w", err)
 	}
 
 	monthlyTotal := 0.0
-	for _, expense := range expenses {
-		monthlyAmount := convertToMonthlyAmount(expense.Amount, expense.Frequency)
-		monthlyTotal += monthlyAmount
+	for _, total := range totals {
+		monthlyTotal += convertToMonthlyAmount(total.Total, total.Frequency)
 	}
 
 	return monthlyTotal, nil
@@ -296,7 +349,7 @@ func (r *medicalExpenseRepository) GetAnnualProjectedExpenses(ctx context.Contex
 		Total float64
 	}
 	
-	if err := r.db.WithContext(ctx).
+	if err := dbFromContext(ctx, r.db).
 		Model(&models.MedicalExpenseModel{}).
 		Select("COALESCE(SUM(amount), 0) as total").
 		Where("user_id = ? AND is_recurring = ? AND date >= ? AND date <= ?", userID, false, oneYear, now).
@@ -309,22 +362,9 @@ func (r *medicalExpenseRepository) GetAnnualProjectedExpenses(ctx context.Contex
 
 // convertToMonthlyAmount converts expense amount to monthly based on frequency
 func convertToMonthlyAmount(amount float64, frequency string) float64 {
-	switch frequency {
-	case "daily":
-		return amount * 30 // Approximate month
-	case "weekly":
-		return amount * 4.33 // Approximate weeks per month
-	case "bi-weekly":
-		return amount * 2.17 // Approximate bi-weeks per month
-	case "monthly":
-		return amount
-	case "quarterly":
-		return amount / 3
-	case "semi-annually":
-		return amount / 6
-	case "annually":
-		return amount / 12
-	default: // "one_time" and others
+	monthly, err := domain.MedicalMonthlyAmount(amount, frequency)
+	if err != nil {
 		return 0 // One-time expenses don't contribute to monthly recurring
 	}
+	return monthly
 }
\ No newline at end of file
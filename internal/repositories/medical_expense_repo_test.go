@@ -21,6 +21,7 @@ func setupMedicalExpenseTestDB(t *testing.T) *gorm.DB {
 	err = db.AutoMigrate(
 		&models.HealthProfileModel{},
 		&models.MedicalExpenseModel{},
+		&models.MedicalExpenseOccurrenceModel{},
 	)
 	require.NoError(t, err)
 
@@ -386,3 +387,60 @@ func TestMedicalExpenseRepository_GetExpensesByFrequency(t *testing.T) {
 	assert.Len(t, annualExpenses, 1)
 	assert.Equal(t, "Annual checkup", annualExpenses[0].Description)
 }
+
+func TestMedicalExpenseRepository_GetCategoryTotals_GroupsWithinDateRange(t *testing.T) {
+	db := setupMedicalExpenseTestDB(t)
+	repo := NewMedicalExpenseRepository(db)
+	ctx := context.Background()
+
+	now := time.Now()
+	lastYear := now.AddDate(-1, 0, 0)
+	expenses := []*domain.MedicalExpense{
+		{UserID: "test-user-123", ProfileID: "1", Amount: 200.0, Category: "doctor_visit", Description: "Visit 1", InsurancePayment: 160.0, Date: now},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 100.0, Category: "doctor_visit", Description: "Visit 2", InsurancePayment: 80.0, Date: now},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 500.0, Category: "hospital", Description: "ER", InsurancePayment: 400.0, Date: now},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 999.0, Category: "hospital", Description: "Old stay", Date: lastYear},
+		{UserID: "other-user", ProfileID: "2", Amount: 50.0, Category: "medication", Description: "Other", Date: now},
+	}
+	for _, expense := range expenses {
+		_, err := repo.Create(ctx, expense)
+		require.NoError(t, err)
+	}
+
+	totals, err := repo.GetCategoryTotals(ctx, "test-user-123", now.AddDate(0, 0, -1), now.AddDate(0, 0, 1))
+
+	require.NoError(t, err)
+	require.Len(t, totals, 2)
+	assert.Equal(t, "hospital", totals[0].Category)
+	assert.Equal(t, 500.0, totals[0].TotalAmount)
+	assert.Equal(t, 100.0, totals[0].TotalOutOfPocket)
+	assert.Equal(t, int64(1), totals[0].ExpenseCount)
+	assert.Equal(t, "doctor_visit", totals[1].Category)
+	assert.Equal(t, 300.0, totals[1].TotalAmount)
+	assert.Equal(t, 240.0, totals[1].TotalInsurancePaid)
+	assert.Equal(t, 60.0, totals[1].TotalOutOfPocket)
+	assert.Equal(t, int64(2), totals[1].ExpenseCount)
+}
+
+func TestMedicalExpenseRepository_GetMonthlyRecurringTotal_ConvertsEachFrequency(t *testing.T) {
+	db := setupMedicalExpenseTestDB(t)
+	repo := NewMedicalExpenseRepository(db)
+	ctx := context.Background()
+
+	expenses := []*domain.MedicalExpense{
+		{UserID: "test-user-123", ProfileID: "1", Amount: 40.0, Category: "medication", Description: "Monthly 1", IsRecurring: true, Frequency: "monthly", Date: time.Now()},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 60.0, Category: "medication", Description: "Monthly 2", IsRecurring: true, Frequency: "monthly", Date: time.Now()},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 300.0, Category: "therapy", Description: "Quarterly", IsRecurring: true, Frequency: "quarterly", Date: time.Now()},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 1200.0, Category: "lab_test", Description: "Annual", IsRecurring: true, Frequency: "annually", Date: time.Now()},
+		{UserID: "test-user-123", ProfileID: "1", Amount: 800.0, Category: "hospital", Description: "One-off", Date: time.Now()},
+	}
+	for _, expense := range expenses {
+		_, err := repo.Create(ctx, expense)
+		require.NoError(t, err)
+	}
+
+	total, err := repo.GetMonthlyRecurringTotal(ctx, "test-user-123")
+
+	require.NoError(t, err)
+	assert.InDelta(t, 300.0, total, 0.001) // 40 + 60 + 300/3 + 1200/12
+}
@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// medicationScheduleRepository implements services.MedicationScheduleRepository
+type medicationScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewMedicationScheduleRepository creates a new medication schedule repository
+func NewMedicationScheduleRepository(db *gorm.DB) services.MedicationScheduleRepository {
+	return &medicationScheduleRepository{db: db}
+}
+
+// Create creates a new medication schedule
+func (r *medicationScheduleRepository) Create(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error) {
+	conditionID, err := strconv.ParseUint(schedule.ConditionID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition ID: %w", err)
+	}
+
+	model := &models.MedicationScheduleModel{}
+	model.FromDomain(schedule, uint(conditionID))
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return nil, fmt.Errorf("failed to create medication schedule: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// GetByID retrieves a medication schedule by ID
+func (r *medicationScheduleRepository) GetByID(ctx context.Context, id string) (*domain.MedicationSchedule, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid medication schedule ID: %w", err)
+	}
+
+	var model models.MedicationScheduleModel
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("medication schedule with ID %s not found", id)
+		}
+		return nil, fmt.Errorf("failed to get medication schedule: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// Update updates a medication schedule
+func (r *medicationScheduleRepository) Update(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error) {
+	idUint, err := strconv.ParseUint(schedule.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid medication schedule ID: %w", err)
+	}
+
+	conditionID, err := strconv.ParseUint(schedule.ConditionID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition ID: %w", err)
+	}
+
+	var model models.MedicationScheduleModel
+	if err := dbFromContext(ctx, r.db).First(&model, uint(idUint)).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, fmt.Errorf("medication schedule with ID %s not found", schedule.ID)
+		}
+		return nil, fmt.Errorf("failed to find medication schedule for update: %w", err)
+	}
+
+	model.FromDomain(schedule, uint(conditionID))
+	model.ID = uint(idUint) // Preserve ID
+
+	if err := dbFromContext(ctx, r.db).Save(&model).Error; err != nil {
+		return nil, fmt.Errorf("failed to update medication schedule: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// Delete performs soft delete on a medication schedule
+func (r *medicationScheduleRepository) Delete(ctx context.Context, id string) error {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid medication schedule ID: %w", err)
+	}
+
+	result := dbFromContext(ctx, r.db).Delete(&models.MedicationScheduleModel{}, uint(idUint))
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete medication schedule: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("medication schedule with ID %s not found", id)
+	}
+
+	return nil
+}
+
+// GetActiveByUserID retrieves active medication schedules for a user
+func (r *medicationScheduleRepository) GetActiveByUserID(ctx context.Context, userID string) ([]*domain.MedicationSchedule, error) {
+	var scheduleModels []models.MedicationScheduleModel
+
+	if err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Order("last_filled_date ASC").
+		Find(&scheduleModels).Error; err != nil {
+		return nil, fmt.Errorf("failed to get medication schedules: %w", err)
+	}
+
+	schedules := make([]*domain.MedicationSchedule, len(scheduleModels))
+	for i, model := range scheduleModels {
+		schedules[i] = model.ToDomain()
+	}
+
+	return schedules, nil
+}
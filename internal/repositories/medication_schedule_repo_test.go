@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+func setupMedicationTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(
+		&models.HealthProfileModel{},
+		&models.MedicalConditionModel{},
+		&models.MedicationScheduleModel{},
+	)
+	require.NoError(t, err)
+
+	profile := &models.HealthProfileModel{
+		UserID:     "test-user-123",
+		Age:        30,
+		Gender:     "male",
+		Height:     180.0,
+		Weight:     75.0,
+		FamilySize: 2,
+	}
+	require.NoError(t, db.Create(profile).Error)
+
+	condition := &models.MedicalConditionModel{
+		UserID:             "test-user-123",
+		ProfileID:          profile.ID,
+		Name:               "Hypertension",
+		Category:           "chronic",
+		Severity:           "moderate",
+		DiagnosedDate:      time.Now().AddDate(-1, 0, 0),
+		IsActive:           true,
+		RequiresMedication: true,
+	}
+	require.NoError(t, db.Create(condition).Error)
+
+	return db
+}
+
+func TestMedicationScheduleRepository_GetActiveByUserID_FiltersInactive(t *testing.T) {
+	db := setupMedicationTestDB(t)
+	repo := NewMedicationScheduleRepository(db)
+	ctx := context.Background()
+
+	active := &domain.MedicationSchedule{
+		UserID:          "test-user-123",
+		ConditionID:     "1",
+		MedicationName:  "Lisinopril",
+		RefillEveryDays: 30,
+		LastFilledDate:  time.Now().AddDate(0, 0, -20),
+		IsActive:        true,
+	}
+	inactive := &domain.MedicationSchedule{
+		UserID:          "test-user-123",
+		ConditionID:     "1",
+		MedicationName:  "Amlodipine",
+		RefillEveryDays: 90,
+		LastFilledDate:  time.Now().AddDate(0, 0, -5),
+		IsActive:        true,
+	}
+
+	created, err := repo.Create(ctx, active)
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, "1", created.ConditionID)
+
+	stopped, err := repo.Create(ctx, inactive)
+	require.NoError(t, err)
+	stopped.IsActive = false
+	_, err = repo.Update(ctx, stopped)
+	require.NoError(t, err)
+
+	schedules, err := repo.GetActiveByUserID(ctx, "test-user-123")
+
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, "Lisinopril", schedules[0].MedicationName)
+	assert.Equal(t, 30, schedules[0].RefillEveryDays)
+}
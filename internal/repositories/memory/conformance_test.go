@@ -0,0 +1,27 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/DuckDHD/BuyOrBye/internal/repositories/memory"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories/repotest"
+)
+
+func TestConformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repositories {
+		store := memory.NewStore()
+		return repotest.Repositories{
+			User:                     memory.NewUserRepository(store),
+			Token:                    memory.NewTokenRepository(store),
+			Income:                   memory.NewIncomeRepository(store),
+			Expense:                  memory.NewExpenseRepository(store),
+			Loan:                     memory.NewLoanRepository(store),
+			HealthProfile:            memory.NewHealthProfileRepository(store),
+			MedicalCondition:         memory.NewMedicalConditionRepository(store),
+			MedicalExpense:           memory.NewMedicalExpenseRepository(store),
+			MedicalExpenseOccurrence: memory.NewMedicalExpenseOccurrenceRepository(store),
+			InsurancePolicy:          memory.NewInsurancePolicyRepository(store),
+			HealthRiskSnapshot:       memory.NewHealthRiskSnapshotRepository(store),
+		}
+	})
+}
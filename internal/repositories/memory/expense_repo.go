@@ -0,0 +1,269 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// expenseRepository implements services.ExpenseRepository in memory
+type expenseRepository struct {
+	store *Store
+}
+
+// NewExpenseRepository creates an expense repository backed by store
+func NewExpenseRepository(store *Store) services.ExpenseRepository {
+	return &expenseRepository{store: store}
+}
+
+// SaveExpense saves a new expense or replaces the existing one with the same ID
+func (r *expenseRepository) SaveExpense(ctx context.Context, expense domain.Expense) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := models.NewExpenseModelFromDomain(expense)
+	if existing := r.store.findExpense(expense.ID, false); existing != nil {
+		model.BeforeUpdate(nil)
+		*existing = *model
+		return nil
+	}
+	if r.store.findExpense(expense.ID, true) != nil {
+		return fmt.Errorf("failed to save expense: UNIQUE constraint failed: expenses.id")
+	}
+
+	model.BeforeCreate(nil)
+	r.store.expenses = append(r.store.expenses, model)
+	return nil
+}
+
+// GetExpenseByID retrieves an expense by its ID
+func (r *expenseRepository) GetExpenseByID(ctx context.Context, id string) (domain.Expense, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findExpense(id, false)
+	if model == nil {
+		return domain.Expense{}, fmt.Errorf("expense with ID %s not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// UpdateExpense replaces every field of an existing expense
+func (r *expenseRepository) UpdateExpense(ctx context.Context, expense domain.Expense) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing := r.store.findExpense(expense.ID, false)
+	if existing == nil {
+		return fmt.Errorf("expense with ID %s not found", expense.ID)
+	}
+
+	model := models.NewExpenseModelFromDomain(expense)
+	model.BeforeUpdate(nil)
+	*existing = *model
+	return nil
+}
+
+// DeleteExpense soft deletes an expense
+func (r *expenseRepository) DeleteExpense(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findExpense(id, false)
+	if model == nil {
+		return fmt.Errorf("expense with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	return nil
+}
+
+// DeleteExpenses soft-deletes the expenses with the given IDs and returns how many were deleted
+func (r *expenseRepository) DeleteExpenses(ctx context.Context, ids []string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var deleted int64
+	for _, id := range ids {
+		if model := r.store.findExpense(id, false); model != nil {
+			softDelete(&model.DeletedAt)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// GetDeletedExpenseByID retrieves a soft-deleted expense by its ID
+func (r *expenseRepository) GetDeletedExpenseByID(ctx context.Context, id string) (domain.Expense, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findExpense(id, true)
+	if model == nil {
+		return domain.Expense{}, fmt.Errorf("deleted expense with ID %s not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// RestoreExpense undoes the soft delete of an expense
+func (r *expenseRepository) RestoreExpense(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findExpense(id, true)
+	if model == nil {
+		return fmt.Errorf("deleted expense with ID %s not found", id)
+	}
+	model.DeletedAt.Valid = false
+	return nil
+}
+
+// GetUserExpenses retrieves all expenses for a specific user
+func (r *expenseRepository) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+// GetExpensesByCategory retrieves expenses for a user filtered by category
+func (r *expenseRepository) GetExpensesByCategory(ctx context.Context, userID string, category string) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && m.Category == category
+	}), nil
+}
+
+// GetExpensesByFrequency retrieves expenses for a user filtered by frequency
+func (r *expenseRepository) GetExpensesByFrequency(ctx context.Context, userID string, frequency string) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && m.Frequency == frequency
+	}), nil
+}
+
+// GetExpensesByPriority retrieves expenses for a user filtered by priority
+func (r *expenseRepository) GetExpensesByPriority(ctx context.Context, userID string, priority int) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && m.Priority == priority
+	}), nil
+}
+
+// GetFixedExpenses retrieves only fixed expenses for a specific user
+func (r *expenseRepository) GetFixedExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && m.IsFixed
+	}), nil
+}
+
+// GetVariableExpenses retrieves only variable expenses for a specific user
+func (r *expenseRepository) GetVariableExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
+	return r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && !m.IsFixed
+	}), nil
+}
+
+// FindExpenses retrieves a user's expenses matching every criterion set on the filter, newest first
+func (r *expenseRepository) FindExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	expenses := r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && matchesExpenseFilter(m, filter)
+	})
+
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].CreatedAt.After(expenses[j].CreatedAt)
+	})
+	return expenses, nil
+}
+
+// FindExpensesAfter retrieves up to limit of a user's expenses matching filter that come after
+// cursor, ordered by creation time and then ID
+func (r *expenseRepository) FindExpensesAfter(ctx context.Context, userID string, filter domain.ExpenseFilter, cursor domain.Cursor, limit int) ([]domain.Expense, error) {
+	expenses := r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == userID && matchesExpenseFilter(m, filter) && afterCursor(m.CreatedAt, m.ID, cursor)
+	})
+	sortByCreation(expenses, func(e domain.Expense) (time.Time, string) { return e.CreatedAt, e.ID })
+
+	start, end := page(len(expenses), 0, limit)
+	return expenses[start:end], nil
+}
+
+// matchesExpenseFilter reports whether an expense meets every criterion set on filter
+func matchesExpenseFilter(m *models.ExpenseModel, filter domain.ExpenseFilter) bool {
+	return strings.Contains(strings.ToLower(m.Name), strings.ToLower(filter.Query)) &&
+		(filter.Category == "" || m.Category == filter.Category) &&
+		(filter.Frequency == "" || m.Frequency == filter.Frequency) &&
+		(filter.Tag == "" || slices.Contains(m.Tags, filter.Tag)) &&
+		(filter.Priority == 0 || m.Priority == filter.Priority) &&
+		(filter.IsFixed == nil || m.IsFixed == *filter.IsFixed) &&
+		(filter.MinAmount <= 0 || m.Amount >= filter.MinAmount) &&
+		(filter.MaxAmount <= 0 || m.Amount <= filter.MaxAmount) &&
+		(filter.CreatedFrom.IsZero() || !m.CreatedAt.Before(filter.CreatedFrom)) &&
+		(filter.CreatedBefore.IsZero() || m.CreatedAt.Before(filter.CreatedBefore))
+}
+
+// FindDuplicateExpenseID returns the ID of the newest expense matching the user, name, amount and
+// frequency of expense that was created at or after since, or "" if there is none
+func (r *expenseRepository) FindDuplicateExpenseID(ctx context.Context, expense domain.Expense, since time.Time) (string, error) {
+	matches := r.filter(func(m *models.ExpenseModel) bool {
+		return m.UserID == expense.UserID && m.Name == expense.Name && m.Frequency == expense.Frequency &&
+			m.Amount == expense.Amount && !m.CreatedAt.Before(since)
+	})
+
+	newest := ""
+	var newestAt time.Time
+	for _, match := range matches {
+		if newest == "" || match.CreatedAt.After(newestAt) {
+			newest, newestAt = match.ID, match.CreatedAt
+		}
+	}
+	return newest, nil
+}
+
+// CalculateUserTotalExpenses calculates the total expenses for a user
+func (r *expenseRepository) CalculateUserTotalExpenses(ctx context.Context, userID string) (float64, error) {
+	expenses, _ := r.GetUserExpenses(ctx, userID)
+	return sumExpenses(expenses), nil
+}
+
+// CalculateTotalByCategory calculates the total expenses for a user in a specific category
+func (r *expenseRepository) CalculateTotalByCategory(ctx context.Context, userID string, category string) (float64, error) {
+	expenses, _ := r.GetExpensesByCategory(ctx, userID, category)
+	return sumExpenses(expenses), nil
+}
+
+// filter returns the expenses that aren't deleted and match keep, in insertion order
+func (r *expenseRepository) filter(keep func(*models.ExpenseModel) bool) []domain.Expense {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	expenses := make([]domain.Expense, 0)
+	for _, model := range r.store.expenses {
+		if !model.DeletedAt.Valid && keep(model) {
+			expenses = append(expenses, model.ToDomain())
+		}
+	}
+	return expenses
+}
+
+// sumExpenses adds up the amounts of expenses
+func sumExpenses(expenses []domain.Expense) float64 {
+	var total float64
+	for _, expense := range expenses {
+		total += expense.Amount
+	}
+	return total
+}
+
+// findExpense returns the expense with the given ID that is deleted or not, or nil;
+// the caller must hold the lock
+func (s *Store) findExpense(id string, deleted bool) *models.ExpenseModel {
+	for _, model := range s.expenses {
+		if model.ID == id && model.DeletedAt.Valid == deleted {
+			return model
+		}
+	}
+	return nil
+}
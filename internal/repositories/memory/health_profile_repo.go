@@ -0,0 +1,328 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// maxProfileSnapshotsPerProfile caps how many historical snapshots are kept per profile
+const maxProfileSnapshotsPerProfile = 500
+
+// healthProfileRepository implements services.HealthProfileRepository in memory
+type healthProfileRepository struct {
+	store *Store
+}
+
+// NewHealthProfileRepository creates a health profile repository backed by store
+func NewHealthProfileRepository(store *Store) services.HealthProfileRepository {
+	return &healthProfileRepository{store: store}
+}
+
+// Create creates a new health profile; a user can only have one self profile
+func (r *healthProfileRepository) Create(ctx context.Context, profile *domain.HealthProfile) (*domain.HealthProfile, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := &models.HealthProfileModel{}
+	model.FromDomain(profile)
+	if r.store.selfProfileTaken(model, 0) {
+		return nil, fmt.Errorf("health profile for user %s: %w", profile.UserID, domain.ErrProfileAlreadyExists)
+	}
+
+	model.BeforeCreate(nil)
+	now := time.Now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = now
+	}
+	model.ID = r.store.nextID("health_profiles")
+	r.store.profiles = append(r.store.profiles, model)
+
+	return model.ToDomain(), nil
+}
+
+// GetByID retrieves a health profile by ID
+func (r *healthProfileRepository) GetByID(ctx context.Context, id uint) (*domain.HealthProfile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findProfile(id)
+	if model == nil {
+		return nil, fmt.Errorf("health profile with ID %d not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// GetByUserID retrieves the user's own (self) health profile
+func (r *healthProfileRepository) GetByUserID(ctx context.Context, userID string) (*domain.HealthProfile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findSelfProfile(userID)
+	if model == nil {
+		return nil, fmt.Errorf("health profile not found for user %s", userID)
+	}
+	return model.ToDomain(), nil
+}
+
+// Update updates a health profile, recording a snapshot of its previous measurements
+func (r *healthProfileRepository) Update(ctx context.Context, profile *domain.HealthProfile) (*domain.HealthProfile, error) {
+	id, err := strconv.ParseUint(profile.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing := r.store.findProfile(uint(id))
+	if existing == nil {
+		return nil, fmt.Errorf("health profile with ID %s not found", profile.ID)
+	}
+
+	// An update without a relation keeps the stored one, so a dependent never becomes a self profile
+	if profile.RelationToOwner == "" {
+		updated := *profile
+		updated.RelationToOwner = existing.RelationToOwner
+		profile = &updated
+	}
+
+	model := &models.HealthProfileModel{}
+	model.FromDomain(profile)
+	model.ID = existing.ID
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = existing.CreatedAt
+	}
+	model.UpdatedAt = time.Now()
+	model.BeforeUpdate(nil)
+	if r.store.selfProfileTaken(model, model.ID) {
+		return nil, fmt.Errorf("health profile for user %s: %w", model.UserID, domain.ErrProfileAlreadyExists)
+	}
+
+	// Keep the previous measurements before they are overwritten, dated from when they took effect
+	snapshot := &models.ProfileSnapshotModel{}
+	snapshot.FromDomain(domain.NewProfileSnapshot(existing.ToDomain(), existing.UpdatedAt), existing.ID)
+	snapshot.ID = r.store.nextID("profile_snapshots")
+	snapshot.CreatedAt = time.Now()
+	r.store.snapshots = append(r.store.snapshots, snapshot)
+	r.store.pruneProfileSnapshots(existing.ID, maxProfileSnapshotsPerProfile)
+
+	*existing = *model
+	return existing.ToDomain(), nil
+}
+
+// GetSnapshots retrieves up to limit of the most recent snapshots of the user's self profile recorded
+// since the given time, ordered oldest first
+func (r *healthProfileRepository) GetSnapshots(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.ProfileSnapshot, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	snapshots := make([]*domain.ProfileSnapshot, 0)
+	self := r.store.findSelfProfile(userID)
+	if self == nil {
+		return snapshots, nil
+	}
+
+	matches := r.store.profileSnapshots(self.ID)
+	start := 0
+	for start < len(matches) && matches[start].RecordedAt.Before(since) {
+		start++
+	}
+	matches = matches[start:]
+
+	// Keep the newest limit snapshots; a negative limit keeps them all
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+	for _, model := range matches {
+		snapshots = append(snapshots, model.ToDomain())
+	}
+	return snapshots, nil
+}
+
+// Delete deletes a health profile and its conditions, expenses and policies. Deleting a self
+// profile also deletes the owner's dependent profiles and their records.
+func (r *healthProfileRepository) Delete(ctx context.Context, id uint) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findProfile(id)
+	if model == nil {
+		return fmt.Errorf("health profile with ID %d not found", id)
+	}
+
+	deleted := map[uint]bool{model.ID: true}
+	if model.RelationToOwner == domain.RelationSelf {
+		for _, profile := range r.store.profiles {
+			if profile.UserID == model.UserID && !profile.DeletedAt.Valid {
+				deleted[profile.ID] = true
+			}
+		}
+	}
+
+	for _, condition := range r.store.conditions {
+		if deleted[condition.ProfileID] && !condition.DeletedAt.Valid {
+			softDelete(&condition.DeletedAt)
+		}
+	}
+	r.store.deleteOccurrences(func(occurrence *models.MedicalExpenseOccurrenceModel) bool {
+		return deleted[occurrence.ProfileID]
+	})
+	for _, expense := range r.store.medicalExpenses {
+		if deleted[expense.ProfileID] && !expense.DeletedAt.Valid {
+			softDelete(&expense.DeletedAt)
+		}
+	}
+	for _, policy := range r.store.policies {
+		if deleted[policy.ProfileID] && !policy.DeletedAt.Valid {
+			softDelete(&policy.DeletedAt)
+		}
+	}
+	for _, profile := range r.store.profiles {
+		if deleted[profile.ID] && !profile.DeletedAt.Valid {
+			softDelete(&profile.DeletedAt)
+		}
+	}
+	return nil
+}
+
+// GetWithRelations retrieves the user's self health profile
+func (r *healthProfileRepository) GetWithRelations(ctx context.Context, userID string) (*domain.HealthProfile, error) {
+	return r.GetByUserID(ctx, userID)
+}
+
+// ExistsByUserID checks if a self health profile exists for the given user ID
+func (r *healthProfileRepository) ExistsByUserID(ctx context.Context, userID string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.findSelfProfile(userID) != nil, nil
+}
+
+// GetFamilyByUserID retrieves every profile on the user's account, self profile first
+func (r *healthProfileRepository) GetFamilyByUserID(ctx context.Context, userID string) ([]*domain.HealthProfile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var family []*models.HealthProfileModel
+	for _, model := range r.store.profiles {
+		if model.UserID == userID && !model.DeletedAt.Valid {
+			family = append(family, model)
+		}
+	}
+	sort.SliceStable(family, func(i, j int) bool {
+		return family[i].SelfUserID != nil && family[j].SelfUserID == nil
+	})
+
+	profiles := make([]*domain.HealthProfile, len(family))
+	for i, model := range family {
+		profiles[i] = model.ToDomain()
+	}
+	return profiles, nil
+}
+
+// ListSelfProfiles retrieves up to limit self profiles with an ID above afterID, in ID order
+func (r *healthProfileRepository) ListSelfProfiles(ctx context.Context, afterID uint, limit int) ([]*domain.HealthProfile, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matches []*models.HealthProfileModel
+	for _, model := range r.store.profiles {
+		if model.ID > afterID && model.RelationToOwner == domain.RelationSelf && !model.DeletedAt.Valid {
+			matches = append(matches, model)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].ID < matches[j].ID })
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+
+	profiles := make([]*domain.HealthProfile, len(matches))
+	for i, model := range matches {
+		profiles[i] = model.ToDomain()
+	}
+	return profiles, nil
+}
+
+// findProfile returns the profile with the given ID that isn't deleted, or nil; the caller must hold the lock
+func (s *Store) findProfile(id uint) *models.HealthProfileModel {
+	for _, model := range s.profiles {
+		if model.ID == id && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
+
+// findSelfProfile returns the user's self profile, or nil; the caller must hold the lock
+func (s *Store) findSelfProfile(userID string) *models.HealthProfileModel {
+	for _, model := range s.profiles {
+		if model.UserID == userID && model.RelationToOwner == domain.RelationSelf && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
+
+// selfProfileTaken reports whether model is a self profile and another profile, deleted or not,
+// is already the user's self profile. Like the unique index on self_user_id, deleted profiles count.
+// The caller must hold the lock.
+func (s *Store) selfProfileTaken(model *models.HealthProfileModel, exceptID uint) bool {
+	if model.SelfUserID == nil {
+		return false
+	}
+	for _, profile := range s.profiles {
+		if profile.ID != exceptID && profile.SelfUserID != nil && *profile.SelfUserID == *model.SelfUserID {
+			return true
+		}
+	}
+	return false
+}
+
+// profileSnapshots returns a profile's snapshots ordered by recording time and then ID, oldest first;
+// the caller must hold the lock
+func (s *Store) profileSnapshots(profileID uint) []*models.ProfileSnapshotModel {
+	var snapshots []*models.ProfileSnapshotModel
+	for _, snapshot := range s.snapshots {
+		if snapshot.ProfileID == profileID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if !snapshots[i].RecordedAt.Equal(snapshots[j].RecordedAt) {
+			return snapshots[i].RecordedAt.Before(snapshots[j].RecordedAt)
+		}
+		return snapshots[i].ID < snapshots[j].ID
+	})
+	return snapshots
+}
+
+// pruneProfileSnapshots deletes all but the newest keep snapshots for a profile; the caller must hold the lock
+func (s *Store) pruneProfileSnapshots(profileID uint, keep int) {
+	snapshots := s.profileSnapshots(profileID)
+	if len(snapshots) <= keep {
+		return
+	}
+
+	pruned := make(map[*models.ProfileSnapshotModel]bool)
+	for _, snapshot := range snapshots[:len(snapshots)-keep] {
+		pruned[snapshot] = true
+	}
+
+	kept := s.snapshots[:0]
+	for _, snapshot := range s.snapshots {
+		if !pruned[snapshot] {
+			kept = append(kept, snapshot)
+		}
+	}
+	s.snapshots = kept
+}
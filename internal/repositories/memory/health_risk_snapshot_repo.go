@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// maxRiskSnapshotsPerUser caps how many historical risk snapshots are kept per user
+const maxRiskSnapshotsPerUser = 500
+
+// healthRiskSnapshotRepository implements services.HealthRiskSnapshotRepository in memory
+type healthRiskSnapshotRepository struct {
+	store *Store
+}
+
+// NewHealthRiskSnapshotRepository creates a health risk snapshot repository backed by store
+func NewHealthRiskSnapshotRepository(store *Store) services.HealthRiskSnapshotRepository {
+	return &healthRiskSnapshotRepository{store: store}
+}
+
+// Create records a risk snapshot, pruning the user's oldest snapshots beyond maxRiskSnapshotsPerUser
+func (r *healthRiskSnapshotRepository) Create(ctx context.Context, snapshot *domain.RiskSnapshot) (*domain.RiskSnapshot, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := &models.RiskSnapshotModel{}
+	model.FromDomain(snapshot)
+	model.CreatedAt = time.Now()
+	model.ID = r.store.nextID("health_risk_snapshots")
+	r.store.riskSnapshots = append(r.store.riskSnapshots, model)
+	r.store.pruneRiskSnapshots(model.UserID, maxRiskSnapshotsPerUser)
+
+	return model.ToDomain(), nil
+}
+
+// GetByUserID retrieves up to limit of the user's most recent snapshots recorded since the given
+// time, ordered oldest first
+func (r *healthRiskSnapshotRepository) GetByUserID(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.RiskSnapshot, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	matches := r.store.userRiskSnapshots(userID)
+	start := 0
+	for start < len(matches) && matches[start].RecordedAt.Before(since) {
+		start++
+	}
+	matches = matches[start:]
+
+	// Keep the newest limit snapshots; a negative limit keeps them all
+	if limit >= 0 && len(matches) > limit {
+		matches = matches[len(matches)-limit:]
+	}
+	snapshots := make([]*domain.RiskSnapshot, 0, len(matches))
+	for _, model := range matches {
+		snapshots = append(snapshots, model.ToDomain())
+	}
+	return snapshots, nil
+}
+
+// userRiskSnapshots returns a user's risk snapshots ordered by recording time and then ID, oldest
+// first; the caller must hold the lock
+func (s *Store) userRiskSnapshots(userID string) []*models.RiskSnapshotModel {
+	var snapshots []*models.RiskSnapshotModel
+	for _, snapshot := range s.riskSnapshots {
+		if snapshot.UserID == userID {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if !snapshots[i].RecordedAt.Equal(snapshots[j].RecordedAt) {
+			return snapshots[i].RecordedAt.Before(snapshots[j].RecordedAt)
+		}
+		return snapshots[i].ID < snapshots[j].ID
+	})
+	return snapshots
+}
+
+// pruneRiskSnapshots deletes all but the newest keep risk snapshots for a user; the caller must hold the lock
+func (s *Store) pruneRiskSnapshots(userID string, keep int) {
+	snapshots := s.userRiskSnapshots(userID)
+	if len(snapshots) <= keep {
+		return
+	}
+
+	pruned := make(map[*models.RiskSnapshotModel]bool)
+	for _, snapshot := range snapshots[:len(snapshots)-keep] {
+		pruned[snapshot] = true
+	}
+
+	kept := s.riskSnapshots[:0]
+	for _, snapshot := range s.riskSnapshots {
+		if !pruned[snapshot] {
+			kept = append(kept, snapshot)
+		}
+	}
+	s.riskSnapshots = kept
+}
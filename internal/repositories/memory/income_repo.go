@@ -0,0 +1,196 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// incomeRepository implements services.IncomeRepository in memory
+type incomeRepository struct {
+	store *Store
+}
+
+// NewIncomeRepository creates an income repository backed by store
+func NewIncomeRepository(store *Store) services.IncomeRepository {
+	return &incomeRepository{store: store}
+}
+
+// SaveIncome saves a new income or replaces the existing one with the same ID
+func (r *incomeRepository) SaveIncome(ctx context.Context, income domain.Income) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := models.NewIncomeModelFromDomain(income)
+	if existing := r.store.findIncome(income.ID, false); existing != nil {
+		model.BeforeUpdate(nil)
+		*existing = *model
+		return nil
+	}
+	if r.store.findIncome(income.ID, true) != nil {
+		return fmt.Errorf("failed to save income: UNIQUE constraint failed: incomes.id")
+	}
+
+	model.BeforeCreate(nil)
+	r.store.incomes = append(r.store.incomes, model)
+	return nil
+}
+
+// GetIncomeByID retrieves an income by its ID
+func (r *incomeRepository) GetIncomeByID(ctx context.Context, id string) (domain.Income, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findIncome(id, false)
+	if model == nil {
+		return domain.Income{}, fmt.Errorf("income with ID %s not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// UpdateIncome replaces every field of an existing income
+func (r *incomeRepository) UpdateIncome(ctx context.Context, income domain.Income) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing := r.store.findIncome(income.ID, false)
+	if existing == nil {
+		return fmt.Errorf("income with ID %s not found", income.ID)
+	}
+
+	model := models.NewIncomeModelFromDomain(income)
+	model.BeforeUpdate(nil)
+	*existing = *model
+	return nil
+}
+
+// DeleteIncome soft deletes an income
+func (r *incomeRepository) DeleteIncome(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findIncome(id, false)
+	if model == nil {
+		return fmt.Errorf("income with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	return nil
+}
+
+// GetDeletedIncomeByID retrieves a soft-deleted income by its ID
+func (r *incomeRepository) GetDeletedIncomeByID(ctx context.Context, id string) (domain.Income, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findIncome(id, true)
+	if model == nil {
+		return domain.Income{}, fmt.Errorf("deleted income with ID %s not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// RestoreIncome undoes the soft delete of an income
+func (r *incomeRepository) RestoreIncome(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findIncome(id, true)
+	if model == nil {
+		return fmt.Errorf("deleted income with ID %s not found", id)
+	}
+	model.DeletedAt.Valid = false
+	return nil
+}
+
+// GetUserIncomes retrieves all incomes for a specific user
+func (r *incomeRepository) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
+	return r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+// GetActiveIncomes retrieves only active incomes for a specific user
+func (r *incomeRepository) GetActiveIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
+	return r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == userID && m.IsActive
+	}), nil
+}
+
+// GetUserIncomesByFrequency retrieves user incomes filtered by frequency
+func (r *incomeRepository) GetUserIncomesByFrequency(ctx context.Context, userID string, frequency string) ([]domain.Income, error) {
+	return r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == userID && m.Frequency == frequency
+	}), nil
+}
+
+// GetUserIncomesAfter retrieves up to limit incomes for a user that come after cursor,
+// ordered by creation time and then ID
+func (r *incomeRepository) GetUserIncomesAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Income, error) {
+	incomes := r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == userID && afterCursor(m.CreatedAt, m.ID, cursor)
+	})
+	sortByCreation(incomes, func(i domain.Income) (time.Time, string) { return i.CreatedAt, i.ID })
+
+	start, end := page(len(incomes), 0, limit)
+	return incomes[start:end], nil
+}
+
+// FindDuplicateIncomeID returns the ID of the newest active income matching the user, source,
+// amount and frequency of income that was created at or after since, or "" if there is none
+func (r *incomeRepository) FindDuplicateIncomeID(ctx context.Context, income domain.Income, since time.Time) (string, error) {
+	matches := r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == income.UserID && m.Source == income.Source && m.Frequency == income.Frequency &&
+			m.Amount == income.Amount && m.IsActive && !m.CreatedAt.Before(since)
+	})
+
+	newest := ""
+	var newestAt time.Time
+	for _, match := range matches {
+		if newest == "" || match.CreatedAt.After(newestAt) {
+			newest, newestAt = match.ID, match.CreatedAt
+		}
+	}
+	return newest, nil
+}
+
+// CalculateUserTotalIncome calculates the total income for a user
+func (r *incomeRepository) CalculateUserTotalIncome(ctx context.Context, userID string, activeOnly bool) (float64, error) {
+	incomes := r.filter(func(m *models.IncomeModel) bool {
+		return m.UserID == userID && (m.IsActive || !activeOnly)
+	})
+
+	var total float64
+	for _, income := range incomes {
+		total += income.Amount
+	}
+	return total, nil
+}
+
+// filter returns the incomes that aren't deleted and match keep, in insertion order
+func (r *incomeRepository) filter(keep func(*models.IncomeModel) bool) []domain.Income {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	incomes := make([]domain.Income, 0)
+	for _, model := range r.store.incomes {
+		if !model.DeletedAt.Valid && keep(model) {
+			incomes = append(incomes, model.ToDomain())
+		}
+	}
+	return incomes
+}
+
+// findIncome returns the income with the given ID that is deleted or not, or nil;
+// the caller must hold the lock
+func (s *Store) findIncome(id string, deleted bool) *models.IncomeModel {
+	for _, model := range s.incomes {
+		if model.ID == id && model.DeletedAt.Valid == deleted {
+			return model
+		}
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// insurancePolicyRepository implements services.InsurancePolicyRepository in memory
+type insurancePolicyRepository struct {
+	store *Store
+}
+
+// NewInsurancePolicyRepository creates an insurance policy repository backed by store
+func NewInsurancePolicyRepository(store *Store) services.InsurancePolicyRepository {
+	return &insurancePolicyRepository{store: store}
+}
+
+// Create creates a new insurance policy; policy numbers are unique per user
+func (r *insurancePolicyRepository) Create(ctx context.Context, policy *domain.InsurancePolicy) (*domain.InsurancePolicy, error) {
+	profileID, err := strconv.ParseUint(policy.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Like the unique index on (user_id, policy_number), deleted policies keep their number
+	for _, existing := range r.store.policies {
+		if existing.UserID == policy.UserID && existing.PolicyNumber == policy.PolicyNumber {
+			return nil, fmt.Errorf("insurance policy %s: %w", policy.PolicyNumber, domain.ErrPolicyNumberExists)
+		}
+	}
+
+	model := &models.InsurancePolicyModel{}
+	model.FromDomain(policy, uint(profileID))
+	model.BeforeCreate(nil)
+	now := time.Now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = now
+	}
+	model.ID = r.store.nextID("insurance_policies")
+	r.store.policies = append(r.store.policies, model)
+
+	return model.ToDomain(), nil
+}
+
+// GetByID retrieves an insurance policy by ID
+func (r *insurancePolicyRepository) GetByID(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	model, err := r.get(id)
+	if err != nil {
+		return nil, err
+	}
+	return model.ToDomain(), nil
+}
+
+// GetByIDForUpdate retrieves an insurance policy by ID. The store has no transactions to hold
+// a lock for, so it doesn't lock anything.
+func (r *insurancePolicyRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	return r.GetByID(ctx, id)
+}
+
+// Update updates an insurance policy
+func (r *insurancePolicyRepository) Update(ctx context.Context, policy *domain.InsurancePolicy) (*domain.InsurancePolicy, error) {
+	idUint, err := strconv.ParseUint(policy.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy ID: %w", err)
+	}
+	profileID, err := strconv.ParseUint(policy.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findPolicy(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("insurance policy with ID %s not found", policy.ID)
+	}
+	for _, existing := range r.store.policies {
+		if existing != model && existing.UserID == policy.UserID && existing.PolicyNumber == policy.PolicyNumber {
+			return nil, fmt.Errorf("insurance policy %s: %w", policy.PolicyNumber, domain.ErrPolicyNumberExists)
+		}
+	}
+
+	createdAt := model.CreatedAt
+	model.FromDomain(policy, uint(profileID))
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = createdAt
+	}
+	model.UpdatedAt = time.Now()
+	model.BeforeUpdate(nil)
+
+	return model.ToDomain(), nil
+}
+
+// Delete performs soft delete on an insurance policy
+func (r *insurancePolicyRepository) Delete(ctx context.Context, id string) error {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid policy ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findPolicy(uint(idUint))
+	if model == nil {
+		return fmt.Errorf("insurance policy with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	return nil
+}
+
+// GetByUserID retrieves insurance policies by user ID, newest first
+func (r *insurancePolicyRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.InsurancePolicy, error) {
+	return r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+// GetByType retrieves insurance policies by type, newest first
+func (r *insurancePolicyRepository) GetByType(ctx context.Context, userID string, policyType string) ([]*domain.InsurancePolicy, error) {
+	return r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.UserID == userID && m.Type == policyType
+	}), nil
+}
+
+// GetByPolicyNumber retrieves the user's insurance policy with the policy number
+func (r *insurancePolicyRepository) GetByPolicyNumber(ctx context.Context, userID, policyNumber string) (*domain.InsurancePolicy, error) {
+	policies := r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.UserID == userID && m.PolicyNumber == policyNumber
+	})
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("insurance policy with number %s not found", policyNumber)
+	}
+	return policies[0], nil
+}
+
+// GetActivePolicies retrieves the user's active policies whose coverage period includes today, newest first
+func (r *insurancePolicyRepository) GetActivePolicies(ctx context.Context, userID string) ([]*domain.InsurancePolicy, error) {
+	now := time.Now()
+	return r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.UserID == userID && m.IsActive && !m.StartDate.After(now) && !m.EndDate.Before(now)
+	}), nil
+}
+
+// GetByProfileID retrieves insurance policies by profile ID, newest first
+func (r *insurancePolicyRepository) GetByProfileID(ctx context.Context, profileID string) ([]*domain.InsurancePolicy, error) {
+	profileIDUint, err := strconv.ParseUint(profileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	return r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.ProfileID == uint(profileIDUint)
+	}), nil
+}
+
+// UpdateDeductibleProgress updates deductible and out-of-pocket progress, capped at the policy limits
+func (r *insurancePolicyRepository) UpdateDeductibleProgress(ctx context.Context, policyID string, deductibleMet, outOfPocketCurrent float64) (*domain.InsurancePolicy, error) {
+	idUint, err := strconv.ParseUint(policyID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findPolicy(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("insurance policy with ID %s not found", policyID)
+	}
+
+	model.DeductibleMet = deductibleMet
+	model.OutOfPocketCurrent = outOfPocketCurrent
+	model.UpdatedAt = time.Now()
+	model.BeforeUpdate(nil)
+
+	return model.ToDomain(), nil
+}
+
+// CalculateCoverageForExpense calculates insurance coverage for an expense
+func (r *insurancePolicyRepository) CalculateCoverageForExpense(ctx context.Context, policyID string, expenseAmount float64) (*services.CoverageCalculation, error) {
+	model, err := r.get(policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := model.ToDomain()
+	insuranceCoverage, outOfPocketAmount, newDeductibleMet := policy.CalculateCoverage(expenseAmount)
+
+	return &services.CoverageCalculation{
+		InsurancePays:        insuranceCoverage,
+		PatientPays:          outOfPocketAmount,
+		NewDeductibleMet:     newDeductibleMet,
+		NewOutOfPocketUsed:   policy.OutOfPocketCurrent + outOfPocketAmount,
+		IsDeductibleMet:      newDeductibleMet >= policy.Deductible,
+		IsOutOfPocketMaxMet:  policy.OutOfPocketCurrent+outOfPocketAmount >= policy.OutOfPocketMax,
+		RemainingDeductible:  policy.GetRemainingDeductible(),
+		RemainingOutOfPocket: policy.GetRemainingOutOfPocket(),
+	}, nil
+}
+
+// GetPoliciesByProvider retrieves insurance policies by provider, newest first
+func (r *insurancePolicyRepository) GetPoliciesByProvider(ctx context.Context, userID string, provider string) ([]*domain.InsurancePolicy, error) {
+	return r.filter(func(m *models.InsurancePolicyModel) bool {
+		return m.UserID == userID && m.Provider == provider
+	}), nil
+}
+
+// get returns a copy of the policy with the given ID
+func (r *insurancePolicyRepository) get(id string) (models.InsurancePolicyModel, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return models.InsurancePolicyModel{}, fmt.Errorf("invalid policy ID %q: %w", id, domain.ErrInsurancePolicyNotFound)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findPolicy(uint(idUint))
+	if model == nil {
+		return models.InsurancePolicyModel{}, fmt.Errorf("insurance policy with ID %s: %w", id, domain.ErrInsurancePolicyNotFound)
+	}
+	return *model, nil
+}
+
+// filter returns the policies that aren't deleted and match keep, newest first
+func (r *insurancePolicyRepository) filter(keep func(*models.InsurancePolicyModel) bool) []*domain.InsurancePolicy {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	policies := make([]*domain.InsurancePolicy, 0)
+	for _, model := range r.store.policies {
+		if !model.DeletedAt.Valid && keep(model) {
+			policies = append(policies, model.ToDomain())
+		}
+	}
+
+	sort.SliceStable(policies, func(i, j int) bool {
+		return policies[i].CreatedAt.After(policies[j].CreatedAt)
+	})
+	return policies
+}
+
+// findPolicy returns the policy with the given ID that isn't deleted, or nil; the caller must hold the lock
+func (s *Store) findPolicy(id uint) *models.InsurancePolicyModel {
+	for _, model := range s.policies {
+		if model.ID == id && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
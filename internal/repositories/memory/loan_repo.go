@@ -0,0 +1,249 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// loanRepository implements services.LoanRepository in memory
+type loanRepository struct {
+	store *Store
+}
+
+// NewLoanRepository creates a loan repository backed by store
+func NewLoanRepository(store *Store) services.LoanRepository {
+	return &loanRepository{store: store}
+}
+
+// SaveLoan saves a new loan or replaces the existing one with the same ID
+func (r *loanRepository) SaveLoan(ctx context.Context, loan domain.Loan) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := models.NewLoanModelFromDomain(loan)
+	if existing := r.store.findLoan(loan.ID); existing != nil {
+		model.BeforeUpdate(nil)
+		*existing = *model
+		return nil
+	}
+	for _, existing := range r.store.loans {
+		if existing.ID == loan.ID {
+			return fmt.Errorf("failed to save loan: UNIQUE constraint failed: loans.id")
+		}
+	}
+
+	model.BeforeCreate(nil)
+	r.store.loans = append(r.store.loans, model)
+	return nil
+}
+
+// GetLoanByID retrieves a loan by its ID
+func (r *loanRepository) GetLoanByID(ctx context.Context, id string) (domain.Loan, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findLoan(id)
+	if model == nil {
+		return domain.Loan{}, fmt.Errorf("loan with ID %s not found", id)
+	}
+	return model.ToDomain(), nil
+}
+
+// UpdateLoan replaces every field of an existing loan
+func (r *loanRepository) UpdateLoan(ctx context.Context, loan domain.Loan) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing := r.store.findLoan(loan.ID)
+	if existing == nil {
+		return fmt.Errorf("loan with ID %s not found", loan.ID)
+	}
+
+	model := models.NewLoanModelFromDomain(loan)
+	model.BeforeUpdate(nil)
+	*existing = *model
+	return nil
+}
+
+// DeleteLoan soft deletes a loan
+func (r *loanRepository) DeleteLoan(ctx context.Context, id string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findLoan(id)
+	if model == nil {
+		return fmt.Errorf("loan with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	return nil
+}
+
+// GetUserLoans retrieves all loans for a specific user
+func (r *loanRepository) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
+	return r.filter(func(m *models.LoanModel) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+// GetUserLoansAfter retrieves up to limit loans for a user that come after cursor,
+// ordered by creation time and then ID
+func (r *loanRepository) GetUserLoansAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Loan, error) {
+	loans := r.filter(func(m *models.LoanModel) bool {
+		return m.UserID == userID && afterCursor(m.CreatedAt, m.ID, cursor)
+	})
+	sortByCreation(loans, func(l domain.Loan) (time.Time, string) { return l.CreatedAt, l.ID })
+
+	start, end := page(len(loans), 0, limit)
+	return loans[start:end], nil
+}
+
+// GetLoansByType retrieves loans for a user filtered by loan type
+func (r *loanRepository) GetLoansByType(ctx context.Context, userID string, loanType string) ([]domain.Loan, error) {
+	return r.filter(func(m *models.LoanModel) bool {
+		return m.UserID == userID && m.Type == loanType
+	}), nil
+}
+
+// GetLoansByInterestRateRange retrieves loans for a user within a specific interest rate range
+func (r *loanRepository) GetLoansByInterestRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error) {
+	return r.filter(func(m *models.LoanModel) bool {
+		return m.UserID == userID && m.InterestRate >= minRate && m.InterestRate <= maxRate
+	}), nil
+}
+
+// UpdateLoanBalance updates the remaining balance for a specific loan
+func (r *loanRepository) UpdateLoanBalance(ctx context.Context, loanID string, newBalance float64) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findLoan(loanID)
+	if model == nil {
+		return fmt.Errorf("loan with ID %s not found", loanID)
+	}
+	model.RemainingBalance = newBalance
+	model.BeforeUpdate(nil)
+	return nil
+}
+
+// GetNearPayoffLoans retrieves loans whose remaining balance is at most threshold of the principal
+func (r *loanRepository) GetNearPayoffLoans(ctx context.Context, userID string, threshold float64) ([]domain.Loan, error) {
+	return r.filter(func(m *models.LoanModel) bool {
+		return m.UserID == userID && m.PrincipalAmount != 0 && m.RemainingBalance/m.PrincipalAmount <= threshold
+	}), nil
+}
+
+// CalculateUserTotalDebt calculates the total remaining debt for a user
+func (r *loanRepository) CalculateUserTotalDebt(ctx context.Context, userID string) (float64, error) {
+	var total float64
+	for _, loan := range r.filter(func(m *models.LoanModel) bool { return m.UserID == userID }) {
+		total += loan.RemainingBalance
+	}
+	return total, nil
+}
+
+// CalculateUserMonthlyPayments calculates the total monthly loan payments for a user
+func (r *loanRepository) CalculateUserMonthlyPayments(ctx context.Context, userID string) (float64, error) {
+	var total float64
+	for _, loan := range r.filter(func(m *models.LoanModel) bool { return m.UserID == userID }) {
+		total += loan.MonthlyPayment
+	}
+	return total, nil
+}
+
+// GetLoanByIDForUpdate retrieves a loan by its ID; the store's lock already serializes writes,
+// so there is no row lock to take
+func (r *loanRepository) GetLoanByIDForUpdate(ctx context.Context, id string) (domain.Loan, error) {
+	return r.GetLoanByID(ctx, id)
+}
+
+// RecordBalanceChange saves a balance entry and sets the loan's remaining balance to the entry's new balance
+func (r *loanRepository) RecordBalanceChange(ctx context.Context, entry domain.LoanBalanceEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	loan := r.store.findLoan(entry.LoanID)
+	if loan == nil {
+		return fmt.Errorf("loan with ID %s not found", entry.LoanID)
+	}
+	for _, existing := range r.store.loanBalances {
+		if entry.ID != "" && existing.ID == entry.ID {
+			return fmt.Errorf("failed to save loan balance entry: UNIQUE constraint failed: loan_balance_entries.id")
+		}
+	}
+
+	model := models.NewLoanBalanceEntryModelFromDomain(entry)
+	model.BeforeCreate(nil)
+	r.store.loanBalances = append(r.store.loanBalances, model)
+	loan.RemainingBalance = entry.NewBalance
+	loan.BeforeUpdate(nil)
+	return nil
+}
+
+// GetBalanceEntries retrieves a loan's balance entries, newest first
+func (r *loanRepository) GetBalanceEntries(ctx context.Context, loanID string) ([]domain.LoanBalanceEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	entries := make([]domain.LoanBalanceEntry, 0)
+	for _, model := range r.store.loanBalances {
+		if model.LoanID == loanID {
+			entries = append(entries, model.ToDomain())
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// GetLoansDueForAccrual retrieves the auto-accruing loans with a balance left that have no
+// interest accrual entry at or after since, ordered by ID
+func (r *loanRepository) GetLoansDueForAccrual(ctx context.Context, since time.Time) ([]domain.Loan, error) {
+	r.store.mu.RLock()
+	accrued := make(map[string]bool)
+	for _, model := range r.store.loanBalances {
+		if model.Type == domain.LoanBalanceInterestAccrual && !model.CreatedAt.Before(since) {
+			accrued[model.LoanID] = true
+		}
+	}
+	r.store.mu.RUnlock()
+
+	loans := r.filter(func(m *models.LoanModel) bool {
+		return m.AutoAccrueInterest && m.RemainingBalance > 0 && !accrued[m.ID]
+	})
+	sort.Slice(loans, func(i, j int) bool { return loans[i].ID < loans[j].ID })
+	return loans, nil
+}
+
+// filter returns the loans that aren't deleted and match keep, in insertion order
+func (r *loanRepository) filter(keep func(*models.LoanModel) bool) []domain.Loan {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	loans := make([]domain.Loan, 0)
+	for _, model := range r.store.loans {
+		if !model.DeletedAt.Valid && keep(model) {
+			loans = append(loans, model.ToDomain())
+		}
+	}
+	return loans
+}
+
+// findLoan returns the loan with the given ID that isn't deleted, or nil; the caller must hold the lock
+func (s *Store) findLoan(id string) *models.LoanModel {
+	for _, model := range s.loans {
+		if model.ID == id && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
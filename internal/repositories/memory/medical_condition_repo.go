@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// medicalConditionRepository implements services.MedicalConditionRepository in memory
+type medicalConditionRepository struct {
+	store *Store
+}
+
+// NewMedicalConditionRepository creates a medical condition repository backed by store
+func NewMedicalConditionRepository(store *Store) services.MedicalConditionRepository {
+	return &medicalConditionRepository{store: store}
+}
+
+// Create creates a new medical condition
+func (r *medicalConditionRepository) Create(ctx context.Context, condition *domain.MedicalCondition) (*domain.MedicalCondition, error) {
+	profileID, err := strconv.ParseUint(condition.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := &models.MedicalConditionModel{}
+	model.FromDomain(condition, uint(profileID))
+	model.ResolvedDate = copyTime(condition.ResolvedDate)
+	model.BeforeCreate(nil)
+	now := time.Now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = now
+	}
+	model.ID = r.store.nextID("medical_conditions")
+	r.store.conditions = append(r.store.conditions, model)
+
+	return conditionToDomain(model), nil
+}
+
+// GetByID retrieves a medical condition by ID
+func (r *medicalConditionRepository) GetByID(ctx context.Context, id string) (*domain.MedicalCondition, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition ID %q: %w", id, domain.ErrMedicalConditionNotFound)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findCondition(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("medical condition with ID %s: %w", id, domain.ErrMedicalConditionNotFound)
+	}
+	return conditionToDomain(model), nil
+}
+
+// Update updates a medical condition
+func (r *medicalConditionRepository) Update(ctx context.Context, condition *domain.MedicalCondition) (*domain.MedicalCondition, error) {
+	idUint, err := strconv.ParseUint(condition.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition ID: %w", err)
+	}
+	profileID, err := strconv.ParseUint(condition.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findCondition(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("medical condition with ID %s not found", condition.ID)
+	}
+
+	createdAt := model.CreatedAt
+	model.FromDomain(condition, uint(profileID))
+	model.ResolvedDate = copyTime(condition.ResolvedDate)
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = createdAt
+	}
+	model.UpdatedAt = time.Now()
+
+	return conditionToDomain(model), nil
+}
+
+// Delete performs soft delete on a medical condition
+func (r *medicalConditionRepository) Delete(ctx context.Context, id string) error {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid condition ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findCondition(uint(idUint))
+	if model == nil {
+		return fmt.Errorf("medical condition with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	return nil
+}
+
+// GetByUserID retrieves medical conditions by user ID with optional active filter
+func (r *medicalConditionRepository) GetByUserID(ctx context.Context, userID string, activeOnly bool) ([]*domain.MedicalCondition, error) {
+	return r.filter(func(m *models.MedicalConditionModel) bool {
+		return m.UserID == userID && (m.IsActive || !activeOnly)
+	}), nil
+}
+
+// GetByCategory retrieves medical conditions by category
+func (r *medicalConditionRepository) GetByCategory(ctx context.Context, userID string, category string) ([]*domain.MedicalCondition, error) {
+	return r.filter(func(m *models.MedicalConditionModel) bool {
+		return m.UserID == userID && m.Category == category
+	}), nil
+}
+
+// GetBySeverity retrieves medical conditions by severity
+func (r *medicalConditionRepository) GetBySeverity(ctx context.Context, userID string, severity string) ([]*domain.MedicalCondition, error) {
+	return r.filter(func(m *models.MedicalConditionModel) bool {
+		return m.UserID == userID && m.Severity == severity
+	}), nil
+}
+
+// GetByProfileID retrieves medical conditions by profile ID
+func (r *medicalConditionRepository) GetByProfileID(ctx context.Context, profileID string) ([]*domain.MedicalCondition, error) {
+	profileIDUint, err := strconv.ParseUint(profileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	return r.filter(func(m *models.MedicalConditionModel) bool {
+		return m.ProfileID == uint(profileIDUint)
+	}), nil
+}
+
+// GetActiveConditionCount returns count of active conditions for a user
+func (r *medicalConditionRepository) GetActiveConditionCount(ctx context.Context, userID string) (int64, error) {
+	conditions, _ := r.GetByUserID(ctx, userID, true)
+	return int64(len(conditions)), nil
+}
+
+// CalculateTotalRiskFactor calculates sum of risk factors for active conditions
+func (r *medicalConditionRepository) CalculateTotalRiskFactor(ctx context.Context, userID string) (float64, error) {
+	conditions, _ := r.GetByUserID(ctx, userID, true)
+
+	var total float64
+	for _, condition := range conditions {
+		total += condition.RiskFactor
+	}
+	return total, nil
+}
+
+// GetMedicationRequiringConditions returns active conditions that require medication
+func (r *medicalConditionRepository) GetMedicationRequiringConditions(ctx context.Context, userID string) ([]*domain.MedicalCondition, error) {
+	return r.filter(func(m *models.MedicalConditionModel) bool {
+		return m.UserID == userID && m.RequiresMedication && m.IsActive
+	}), nil
+}
+
+// filter returns the conditions that aren't deleted and match keep, in insertion order
+func (r *medicalConditionRepository) filter(keep func(*models.MedicalConditionModel) bool) []*domain.MedicalCondition {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	conditions := make([]*domain.MedicalCondition, 0)
+	for _, model := range r.store.conditions {
+		if !model.DeletedAt.Valid && keep(model) {
+			conditions = append(conditions, conditionToDomain(model))
+		}
+	}
+	return conditions
+}
+
+// conditionToDomain converts a stored condition without sharing its resolved date with the caller
+func conditionToDomain(model *models.MedicalConditionModel) *domain.MedicalCondition {
+	condition := model.ToDomain()
+	condition.ResolvedDate = copyTime(model.ResolvedDate)
+	return condition
+}
+
+// findCondition returns the condition with the given ID that isn't deleted, or nil; the caller must hold the lock
+func (s *Store) findCondition(id uint) *models.MedicalConditionModel {
+	for _, model := range s.conditions {
+		if model.ID == id && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// medicalExpenseOccurrenceRepository implements services.MedicalExpenseOccurrenceRepository in memory
+type medicalExpenseOccurrenceRepository struct {
+	store *Store
+}
+
+// NewMedicalExpenseOccurrenceRepository creates a medical expense occurrence repository backed by store
+func NewMedicalExpenseOccurrenceRepository(store *Store) services.MedicalExpenseOccurrenceRepository {
+	return &medicalExpenseOccurrenceRepository{store: store}
+}
+
+// Create records an occurrence of a medical expense
+func (r *medicalExpenseOccurrenceRepository) Create(ctx context.Context, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error) {
+	expenseID, err := strconv.ParseUint(occurrence.ExpenseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID: %w", err)
+	}
+	profileID, err := strconv.ParseUint(occurrence.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := &models.MedicalExpenseOccurrenceModel{}
+	model.FromDomain(occurrence, uint(expenseID), uint(profileID))
+	model.CreatedAt = time.Now()
+	model.ID = r.store.nextID("medical_expense_occurrences")
+	r.store.occurrences = append(r.store.occurrences, model)
+
+	return model.ToDomain(), nil
+}
+
+// GetByExpenseID retrieves an expense's occurrences ordered by date, oldest first
+func (r *medicalExpenseOccurrenceRepository) GetByExpenseID(ctx context.Context, expenseID string) ([]*domain.MedicalExpenseOccurrence, error) {
+	id, err := strconv.ParseUint(expenseID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID %q: %w", expenseID, domain.ErrMedicalExpenseNotFound)
+	}
+
+	return r.filter(func(m *models.MedicalExpenseOccurrenceModel) bool {
+		return m.ExpenseID == uint(id)
+	}), nil
+}
+
+// GetByUserID retrieves the user's occurrences dated on or after since, oldest first
+func (r *medicalExpenseOccurrenceRepository) GetByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.MedicalExpenseOccurrence, error) {
+	return r.filter(func(m *models.MedicalExpenseOccurrenceModel) bool {
+		return m.UserID == userID && !m.Date.Before(since)
+	}), nil
+}
+
+// filter returns the occurrences matching keep, ordered by date and then ID, oldest first
+func (r *medicalExpenseOccurrenceRepository) filter(keep func(*models.MedicalExpenseOccurrenceModel) bool) []*domain.MedicalExpenseOccurrence {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var matches []*models.MedicalExpenseOccurrenceModel
+	for _, model := range r.store.occurrences {
+		if keep(model) {
+			matches = append(matches, model)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if !matches[i].Date.Equal(matches[j].Date) {
+			return matches[i].Date.Before(matches[j].Date)
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	occurrences := make([]*domain.MedicalExpenseOccurrence, len(matches))
+	for i, model := range matches {
+		occurrences[i] = model.ToDomain()
+	}
+	return occurrences
+}
+
+// deleteOccurrences deletes the occurrences matching remove; the caller must hold the write lock
+func (s *Store) deleteOccurrences(remove func(*models.MedicalExpenseOccurrenceModel) bool) {
+	kept := s.occurrences[:0]
+	for _, occurrence := range s.occurrences {
+		if !remove(occurrence) {
+			kept = append(kept, occurrence)
+		}
+	}
+	s.occurrences = kept
+}
@@ -0,0 +1,286 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// medicalExpenseRepository implements services.MedicalExpenseRepository in memory
+type medicalExpenseRepository struct {
+	store *Store
+}
+
+// NewMedicalExpenseRepository creates a medical expense repository backed by store
+func NewMedicalExpenseRepository(store *Store) services.MedicalExpenseRepository {
+	return &medicalExpenseRepository{store: store}
+}
+
+// Create creates a new medical expense
+func (r *medicalExpenseRepository) Create(ctx context.Context, expense *domain.MedicalExpense) (*domain.MedicalExpense, error) {
+	profileID, err := strconv.ParseUint(expense.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := &models.MedicalExpenseModel{}
+	model.FromDomain(expense, uint(profileID))
+	model.BeforeCreate(nil)
+	now := time.Now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = now
+	}
+	model.ID = r.store.nextID("medical_expenses")
+	r.store.medicalExpenses = append(r.store.medicalExpenses, model)
+
+	return model.ToDomain(), nil
+}
+
+// GetByID retrieves a medical expense by ID
+// Returns an error wrapping domain.ErrMedicalExpenseNotFound if the expense doesn't exist
+func (r *medicalExpenseRepository) GetByID(ctx context.Context, id string) (*domain.MedicalExpense, error) {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID %q: %w", id, domain.ErrMedicalExpenseNotFound)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findMedicalExpense(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("medical expense with ID %s: %w", id, domain.ErrMedicalExpenseNotFound)
+	}
+	return model.ToDomain(), nil
+}
+
+// Update updates a medical expense, recalculating its out-of-pocket amount
+func (r *medicalExpenseRepository) Update(ctx context.Context, expense *domain.MedicalExpense) (*domain.MedicalExpense, error) {
+	idUint, err := strconv.ParseUint(expense.ID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expense ID: %w", err)
+	}
+	profileID, err := strconv.ParseUint(expense.ProfileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findMedicalExpense(uint(idUint))
+	if model == nil {
+		return nil, fmt.Errorf("medical expense with ID %s not found", expense.ID)
+	}
+
+	createdAt := model.CreatedAt
+	model.FromDomain(expense, uint(profileID))
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = createdAt
+	}
+	model.UpdatedAt = time.Now()
+	model.BeforeUpdate(nil)
+
+	return model.ToDomain(), nil
+}
+
+// Delete performs soft delete on a medical expense
+func (r *medicalExpenseRepository) Delete(ctx context.Context, id string) error {
+	idUint, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid expense ID: %w", err)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findMedicalExpense(uint(idUint))
+	if model == nil {
+		return fmt.Errorf("medical expense with ID %s not found", id)
+	}
+	softDelete(&model.DeletedAt)
+	r.store.deleteOccurrences(func(occurrence *models.MedicalExpenseOccurrenceModel) bool {
+		return occurrence.ExpenseID == model.ID
+	})
+	return nil
+}
+
+// GetByUserID retrieves medical expenses by user ID, newest first
+func (r *medicalExpenseRepository) GetByUserID(ctx context.Context, userID string) ([]*domain.MedicalExpense, error) {
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID
+	}), nil
+}
+
+// GetByDateRange retrieves medical expenses within a date range, newest first
+func (r *medicalExpenseRepository) GetByDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]*domain.MedicalExpense, error) {
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID && inDateRange(m.Date, startDate, endDate)
+	}), nil
+}
+
+// GetByCategory retrieves medical expenses by category, newest first
+func (r *medicalExpenseRepository) GetByCategory(ctx context.Context, userID string, category string) ([]*domain.MedicalExpense, error) {
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID && m.Category == category
+	}), nil
+}
+
+// GetByFrequency retrieves medical expenses by frequency, newest first
+func (r *medicalExpenseRepository) GetByFrequency(ctx context.Context, userID string, frequency string) ([]*domain.MedicalExpense, error) {
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID && m.Frequency == frequency
+	}), nil
+}
+
+// GetRecurring retrieves recurring medical expenses, newest first
+func (r *medicalExpenseRepository) GetRecurring(ctx context.Context, userID string) ([]*domain.MedicalExpense, error) {
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID && m.IsRecurring
+	}), nil
+}
+
+// GetByProfileID retrieves medical expenses by profile ID, newest first
+func (r *medicalExpenseRepository) GetByProfileID(ctx context.Context, profileID string) ([]*domain.MedicalExpense, error) {
+	profileIDUint, err := strconv.ParseUint(profileID, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile ID: %w", err)
+	}
+
+	return r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.ProfileID == uint(profileIDUint)
+	}), nil
+}
+
+// CalculateTotals calculates expense totals within a date range
+func (r *medicalExpenseRepository) CalculateTotals(ctx context.Context, userID string, startDate, endDate time.Time) (*services.ExpenseTotals, error) {
+	expenses, _ := r.GetByDateRange(ctx, userID, startDate, endDate)
+
+	totals := &services.ExpenseTotals{}
+	for _, expense := range expenses {
+		totals.TotalAmount += expense.Amount
+		totals.TotalInsurancePaid += expense.InsurancePayment
+		totals.TotalOutOfPocket += expense.OutOfPocket
+		totals.ExpenseCount++
+	}
+	return totals, nil
+}
+
+// GetCategoryTotals aggregates a user's expenses per category within a date range
+// Categories are ordered by total amount, highest first
+func (r *medicalExpenseRepository) GetCategoryTotals(ctx context.Context, userID string, startDate, endDate time.Time) ([]services.CategoryTotals, error) {
+	expenses, _ := r.GetByDateRange(ctx, userID, startDate, endDate)
+
+	totals := make([]services.CategoryTotals, 0)
+	index := make(map[string]int)
+	for _, expense := range expenses {
+		i, ok := index[expense.Category]
+		if !ok {
+			i = len(totals)
+			index[expense.Category] = i
+			totals = append(totals, services.CategoryTotals{Category: expense.Category})
+		}
+		totals[i].TotalAmount += expense.Amount
+		totals[i].TotalInsurancePaid += expense.InsurancePayment
+		totals[i].TotalOutOfPocket += expense.OutOfPocket
+		totals[i].ExpenseCount++
+	}
+
+	sort.SliceStable(totals, func(i, j int) bool {
+		return totals[i].TotalAmount > totals[j].TotalAmount
+	})
+	return totals, nil
+}
+
+// GetMonthlyRecurringTotal calculates total monthly recurring expenses
+func (r *medicalExpenseRepository) GetMonthlyRecurringTotal(ctx context.Context, userID string) (float64, error) {
+	expenses, _ := r.GetRecurring(ctx, userID)
+
+	// Sum per frequency before converting, as the database query does
+	totals := make(map[string]float64)
+	for _, expense := range expenses {
+		totals[expense.Frequency] += expense.Amount
+	}
+
+	monthlyTotal := 0.0
+	for frequency, total := range totals {
+		monthlyTotal += convertToMonthlyAmount(total, frequency)
+	}
+	return monthlyTotal, nil
+}
+
+// GetAnnualProjectedExpenses projects a year of recurring expenses plus the one-time expenses
+// of the last 12 months
+func (r *medicalExpenseRepository) GetAnnualProjectedExpenses(ctx context.Context, userID string) (float64, error) {
+	monthlyTotal, err := r.GetMonthlyRecurringTotal(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	oneYear := now.AddDate(-1, 0, 0)
+	oneTime := r.filter(func(m *models.MedicalExpenseModel) bool {
+		return m.UserID == userID && !m.IsRecurring && inDateRange(m.Date, oneYear, now)
+	})
+
+	oneTimeTotal := 0.0
+	for _, expense := range oneTime {
+		oneTimeTotal += expense.Amount
+	}
+	return monthlyTotal*12 + oneTimeTotal, nil
+}
+
+// filter returns the expenses that aren't deleted and match keep, newest first
+func (r *medicalExpenseRepository) filter(keep func(*models.MedicalExpenseModel) bool) []*domain.MedicalExpense {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	expenses := make([]*domain.MedicalExpense, 0)
+	for _, model := range r.store.medicalExpenses {
+		if !model.DeletedAt.Valid && keep(model) {
+			expenses = append(expenses, model.ToDomain())
+		}
+	}
+
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].Date.After(expenses[j].Date)
+	})
+	return expenses
+}
+
+// inDateRange reports whether date is within start and end, inclusive
+func inDateRange(date, start, end time.Time) bool {
+	return !date.Before(start) && !date.After(end)
+}
+
+// convertToMonthlyAmount converts expense amount to monthly based on frequency
+func convertToMonthlyAmount(amount float64, frequency string) float64 {
+	monthly, err := domain.MedicalMonthlyAmount(amount, frequency)
+	if err != nil {
+		return 0 // One-time expenses don't contribute to monthly recurring
+	}
+	return monthly
+}
+
+// findMedicalExpense returns the expense with the given ID that isn't deleted, or nil;
+// the caller must hold the lock
+func (s *Store) findMedicalExpense(id uint) *models.MedicalExpenseModel {
+	for _, model := range s.medicalExpenses {
+		if model.ID == id && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// Package memory provides in-memory implementations of the repository interfaces.
+// They keep the error semantics of the GORM repositories in the repositories package, so
+// service tests can exercise real behavior without a database and reserve mocks for
+// injecting failures. The repotest conformance suite runs against both implementations.
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+// Store holds the records behind the in-memory repositories. Records are the same models the
+// GORM repositories persist, so their hooks and domain conversions apply unchanged, and they
+// are kept in insertion order, which is the order SQLite returns rows in when a query doesn't
+// sort them. Repositories created from the same store share its records the way the GORM
+// repositories share a database: tokens can only be saved for users that exist, deleting a
+// health profile deletes its conditions, expenses and policies, deleting a medical expense
+// deletes its occurrences, and deleting a user deletes every record they own.
+type Store struct {
+	mu sync.RWMutex
+
+	users  []*models.UserModel
+	tokens []*models.RefreshTokenModel
+
+	incomes  []*models.IncomeModel
+	expenses []*models.ExpenseModel
+	loans    []*models.LoanModel
+	// loanBalances holds the loans' balance entries, which outlive a soft-deleted loan
+	loanBalances []*models.LoanBalanceEntryModel
+
+	profiles        []*models.HealthProfileModel
+	snapshots       []*models.ProfileSnapshotModel
+	conditions      []*models.MedicalConditionModel
+	medicalExpenses []*models.MedicalExpenseModel
+	occurrences     []*models.MedicalExpenseOccurrenceModel
+	policies        []*models.InsurancePolicyModel
+	riskSnapshots   []*models.RiskSnapshotModel
+
+	// lastID is the last auto-increment ID assigned in each table
+	lastID map[string]uint
+}
+
+// NewStore creates an empty store
+func NewStore() *Store {
+	return &Store{lastID: make(map[string]uint)}
+}
+
+// nextID returns the next auto-increment ID for table; the caller must hold the write lock
+func (s *Store) nextID(table string) uint {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// page applies a query offset and limit to n results the way GORM does: a negative limit
+// returns every result and a limit of 0 returns none
+func page(n, offset, limit int) (start, end int) {
+	start = min(max(offset, 0), n)
+	end = n
+	if limit >= 0 {
+		end = min(start+limit, n)
+	}
+	return start, end
+}
+
+// copyTime returns a copy of t so stored records don't share a pointer with the caller
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+// deleteWhere returns records without those matching remove, reusing the slice's backing array
+func deleteWhere[T any](records []*T, remove func(*T) bool) []*T {
+	kept := records[:0]
+	for _, record := range records {
+		if !remove(record) {
+			kept = append(kept, record)
+		}
+	}
+	clear(records[len(kept):])
+	return kept
+}
+
+// softDelete marks a record as deleted now
+func softDelete(deletedAt *gorm.DeletedAt) {
+	*deletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+// afterCursor reports whether a record created at createdAt with the given ID comes after
+// cursor in creation order; every record comes after the zero cursor
+func afterCursor(createdAt time.Time, id string, cursor domain.Cursor) bool {
+	if cursor.IsZero() {
+		return true
+	}
+	return createdAt.After(cursor.CreatedAt) || (createdAt.Equal(cursor.CreatedAt) && id > cursor.ID)
+}
+
+// sortByCreation orders records by creation time and then ID, oldest first
+func sortByCreation[T any](records []T, key func(T) (time.Time, string)) {
+	sort.SliceStable(records, func(i, j int) bool {
+		createdI, idI := key(records[i])
+		createdJ, idJ := key(records[j])
+		if !createdI.Equal(createdJ) {
+			return createdI.Before(createdJ)
+		}
+		return idI < idJ
+	})
+}
@@ -0,0 +1,156 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// tokenRepository implements services.TokenRepository in memory
+type tokenRepository struct {
+	store *Store
+}
+
+// NewTokenRepository creates a refresh token repository backed by store
+func NewTokenRepository(store *Store) services.TokenRepository {
+	return &tokenRepository{store: store}
+}
+
+// SaveRefreshToken revokes the user's active tokens and stores the new one
+// Returns domain.ErrUserNotFound if the user isn't in the store
+func (r *tokenRepository) SaveRefreshToken(ctx context.Context, userID, token string, expiresAt time.Time) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+	if token == "" {
+		return fmt.Errorf("token cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.findUser(userID) == nil {
+		return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+	// The token_hash column is unique, so a token can't be saved twice
+	if r.store.findToken(token) != nil {
+		return fmt.Errorf("failed to create refresh token: %w", domain.ErrTokenAlreadyExists)
+	}
+
+	model := models.RefreshTokenFromDomain(userID, token, expiresAt)
+	now := time.Now()
+	for _, existing := range r.store.tokens {
+		if existing.ToUserID() == userID && !existing.IsRevoked && !existing.DeletedAt.Valid {
+			existing.IsRevoked = true
+			existing.RevokedAt = &now
+			existing.UpdatedAt = now
+		}
+	}
+
+	model.ID = r.store.nextID("refresh_tokens")
+	model.CreatedAt = now
+	model.UpdatedAt = now
+	r.store.tokens = append(r.store.tokens, &model)
+	return nil
+}
+
+// GetRefreshToken returns the ID of the user a token was issued to
+// Returns domain.ErrTokenNotFound, domain.ErrTokenRevoked or domain.ErrTokenExpired if it can't be used
+func (r *tokenRepository) GetRefreshToken(ctx context.Context, token string) (userID string, err error) {
+	if token == "" {
+		return "", fmt.Errorf("token cannot be empty: %w", domain.ErrInvalidToken)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findToken(token)
+	if model == nil {
+		return "", fmt.Errorf("token not found: %w", domain.ErrTokenNotFound)
+	}
+	if model.IsRevoked {
+		return "", fmt.Errorf("token has been revoked: %w", domain.ErrTokenRevoked)
+	}
+	if model.IsExpired() {
+		return "", fmt.Errorf("token has expired: %w", domain.ErrTokenExpired)
+	}
+
+	return model.ToUserID(), nil
+}
+
+// RevokeToken marks a refresh token as revoked
+// Returns domain.ErrTokenNotFound if the token doesn't exist
+func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("token cannot be empty: %w", domain.ErrInvalidToken)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findToken(token)
+	if model == nil {
+		return fmt.Errorf("token not found: %w", domain.ErrTokenNotFound)
+	}
+
+	now := time.Now()
+	model.IsRevoked = true
+	model.RevokedAt = &now
+	model.UpdatedAt = now
+	return nil
+}
+
+// RevokeAllUserTokens marks all refresh tokens for a user as revoked
+// A user without active tokens is not an error
+func (r *tokenRepository) RevokeAllUserTokens(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	for _, model := range r.store.tokens {
+		if model.ToUserID() == userID && !model.IsRevoked && !model.DeletedAt.Valid {
+			model.IsRevoked = true
+			model.RevokedAt = &now
+			model.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+// CleanupExpiredTokens permanently removes expired tokens and returns how many were purged
+func (r *tokenRepository) CleanupExpiredTokens(ctx context.Context) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	now := time.Now()
+	kept := r.store.tokens[:0]
+	var purged int64
+	for _, model := range r.store.tokens {
+		if model.ExpiresAt.Before(now) {
+			purged++
+			continue
+		}
+		kept = append(kept, model)
+	}
+	r.store.tokens = kept
+	return purged, nil
+}
+
+// findToken returns the refresh token with the given value, or nil; the caller must hold the lock
+func (s *Store) findToken(token string) *models.RefreshTokenModel {
+	hash := models.HashRefreshToken(token)
+	for _, model := range s.tokens {
+		if model.TokenHash == hash && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
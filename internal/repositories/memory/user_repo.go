@@ -0,0 +1,242 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// userRepository implements services.UserRepository in memory
+type userRepository struct {
+	store *Store
+}
+
+// NewUserRepository creates a user repository backed by store
+func NewUserRepository(store *Store) services.UserRepository {
+	return &userRepository{store: store}
+}
+
+// Create saves a new user and assigns its ID
+// Returns domain.ErrUserAlreadyExists if another user has the same email
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil: %w", domain.ErrInvalidUserData)
+	}
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("user validation failed: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if r.store.findUserByEmail(user.Email) != nil {
+		return fmt.Errorf("user with email %s already exists: %w", user.Email, domain.ErrUserAlreadyExists)
+	}
+
+	model := models.UserFromDomain(*user)
+	now := time.Now()
+	if model.CreatedAt.IsZero() {
+		model.CreatedAt = now
+	}
+	if model.UpdatedAt.IsZero() {
+		model.UpdatedAt = now
+	}
+	model.ID = r.store.nextID("users")
+	r.store.users = append(r.store.users, &model)
+
+	*user = model.ToDomain()
+	return nil
+}
+
+// GetByEmail retrieves a user by their email address
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	if email == "" {
+		return nil, fmt.Errorf("email cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findUserByEmail(email)
+	if model == nil {
+		return nil, fmt.Errorf("user with email %s not found: %w", email, domain.ErrUserNotFound)
+	}
+
+	user := model.ToDomain()
+	return &user, nil
+}
+
+// GetByID retrieves a user by their ID
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) GetByID(ctx context.Context, userID string) (*domain.User, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	model := r.store.findUser(userID)
+	if model == nil {
+		return nil, fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+
+	user := model.ToDomain()
+	return &user, nil
+}
+
+// Update modifies the user's email, name, password hash and active flag
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
+	if user == nil {
+		return fmt.Errorf("user cannot be nil: %w", domain.ErrInvalidUserData)
+	}
+	if user.ID == "" {
+		return fmt.Errorf("user ID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("user validation failed: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findUser(user.ID)
+	if model == nil {
+		return fmt.Errorf("user with ID %s not found: %w", user.ID, domain.ErrUserNotFound)
+	}
+
+	if existing := r.store.findUserByEmail(user.Email); existing != nil && existing != model {
+		return fmt.Errorf("user with email %s already exists: %w", user.Email, domain.ErrUserAlreadyExists)
+	}
+
+	model.Email = user.Email
+	model.Name = user.Name
+	model.PasswordHash = user.PasswordHash
+	model.IsActive = user.IsActive
+	model.UpdatedAt = time.Now()
+
+	user.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// UpdateLastLogin updates the last login timestamp for a user
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string, loginTime time.Time) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findUser(userID)
+	if model == nil {
+		return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+
+	model.LastLoginAt = &loginTime
+	model.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateRole changes a user's role
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+	if !domain.IsValidRole(role) {
+		return fmt.Errorf("invalid role %q: %w", role, domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findUser(userID)
+	if model == nil {
+		return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+
+	model.Role = role
+	model.UpdatedAt = time.Now()
+	return nil
+}
+
+// List returns a page of users ordered by ID along with the total number of users
+func (r *userRepository) List(ctx context.Context, offset, limit int) ([]domain.User, int64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	users := make([]domain.User, 0, len(r.store.users))
+	for _, model := range r.store.users {
+		if !model.DeletedAt.Valid {
+			users = append(users, model.ToDomain())
+		}
+	}
+
+	start, end := page(len(users), offset, limit)
+	return users[start:end], int64(len(users)), nil
+}
+
+// Delete permanently removes a user and every record they own, including soft-deleted ones
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	model := r.store.findUser(userID)
+	if model == nil {
+		return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+
+	s := r.store
+	s.tokens = deleteWhere(s.tokens, func(m *models.RefreshTokenModel) bool { return m.ToUserID() == userID })
+	s.incomes = deleteWhere(s.incomes, func(m *models.IncomeModel) bool { return m.UserID == userID })
+	s.expenses = deleteWhere(s.expenses, func(m *models.ExpenseModel) bool { return m.UserID == userID })
+	s.loans = deleteWhere(s.loans, func(m *models.LoanModel) bool { return m.UserID == userID })
+	s.loanBalances = deleteWhere(s.loanBalances, func(m *models.LoanBalanceEntryModel) bool { return m.UserID == userID })
+	s.occurrences = deleteWhere(s.occurrences, func(m *models.MedicalExpenseOccurrenceModel) bool { return m.UserID == userID })
+	s.conditions = deleteWhere(s.conditions, func(m *models.MedicalConditionModel) bool { return m.UserID == userID })
+	s.medicalExpenses = deleteWhere(s.medicalExpenses, func(m *models.MedicalExpenseModel) bool { return m.UserID == userID })
+	s.policies = deleteWhere(s.policies, func(m *models.InsurancePolicyModel) bool { return m.UserID == userID })
+	s.snapshots = deleteWhere(s.snapshots, func(m *models.ProfileSnapshotModel) bool { return m.UserID == userID })
+	s.riskSnapshots = deleteWhere(s.riskSnapshots, func(m *models.RiskSnapshotModel) bool { return m.UserID == userID })
+	s.profiles = deleteWhere(s.profiles, func(m *models.HealthProfileModel) bool { return m.UserID == userID })
+	s.users = deleteWhere(s.users, func(m *models.UserModel) bool { return m == model })
+	return nil
+}
+
+// findUser returns the user with the given ID, or nil; the caller must hold the lock
+func (s *Store) findUser(userID string) *models.UserModel {
+	id, err := strconv.ParseUint(userID, 10, 32)
+	if err != nil {
+		return nil
+	}
+	for _, model := range s.users {
+		if model.ID == uint(id) && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
+
+// findUserByEmail returns the user with the given email, or nil; the caller must hold the lock
+func (s *Store) findUserByEmail(email string) *models.UserModel {
+	for _, model := range s.users {
+		if model.Email == email && !model.DeletedAt.Valid {
+			return model
+		}
+	}
+	return nil
+}
@@ -0,0 +1,469 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+var financeTests = []conformanceTest{
+	{"Income/SaveAndGet", testIncomeSaveAndGet},
+	{"Income/DeleteAndRestore", testIncomeDeleteAndRestore},
+	{"Income/PagesAfterCursor", testIncomePagesAfterCursor},
+	{"Income/Totals", testIncomeTotals},
+	{"Income/FindDuplicate", testIncomeFindDuplicate},
+	{"Expense/DeleteMany", testExpenseDeleteMany},
+	{"Expense/Find", testExpenseFind},
+	{"Expense/FindAfterCursor", testExpenseFindAfterCursor},
+	{"Expense/FindByTag", testExpenseFindByTag},
+	{"Loan/Balance", testLoanBalance},
+	{"Loan/NotFound", testLoanNotFound},
+	{"Loan/InterestRateRange", testLoanInterestRateRange},
+	{"Loan/BalanceHistory", testLoanBalanceHistory},
+	{"Loan/DueForAccrual", testLoanDueForAccrual},
+}
+
+func newIncome(id, userID string, amount float64, createdAt time.Time) domain.Income {
+	return domain.Income{
+		ID:        id,
+		UserID:    userID,
+		Source:    "Salary",
+		Amount:    amount,
+		Currency:  "USD",
+		Frequency: domain.FrequencyMonthly,
+		IsActive:  true,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func newExpense(id, userID, name string, amount float64, createdAt time.Time) domain.Expense {
+	return domain.Expense{
+		ID:        id,
+		UserID:    userID,
+		Category:  "housing",
+		Name:      name,
+		Amount:    amount,
+		Currency:  "USD",
+		Frequency: "monthly",
+		IsFixed:   true,
+		Priority:  1,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func newLoan(id, userID string, principal, remaining, payment float64) domain.Loan {
+	return domain.Loan{
+		ID:               id,
+		UserID:           userID,
+		Lender:           "Bank",
+		Type:             domain.LoanTypeMortgage,
+		PrincipalAmount:  principal,
+		RemainingBalance: remaining,
+		MonthlyPayment:   payment,
+		InterestRate:     4.5,
+		Currency:         "USD",
+		EndDate:          baseTime.AddDate(10, 0, 0),
+		CreatedAt:        baseTime,
+		UpdatedAt:        baseTime,
+	}
+}
+
+func testIncomeSaveAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	income := newIncome("income-1", "user-1", 5000, baseTime)
+	require.NoError(t, repos.Income.SaveIncome(ctx, income))
+
+	stored, err := repos.Income.GetIncomeByID(ctx, "income-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Salary", stored.Source)
+	assert.Equal(t, 5000.0, stored.Amount)
+	assert.True(t, stored.IsActive)
+
+	// Saving an existing ID replaces the income, zero values included
+	income.Amount = 5500
+	income.IsActive = false
+	require.NoError(t, repos.Income.SaveIncome(ctx, income))
+	stored, err = repos.Income.GetIncomeByID(ctx, "income-1")
+	require.NoError(t, err)
+	assert.Equal(t, 5500.0, stored.Amount)
+	assert.False(t, stored.IsActive)
+
+	income.Source = "Bonus"
+	require.NoError(t, repos.Income.UpdateIncome(ctx, income))
+	stored, err = repos.Income.GetIncomeByID(ctx, "income-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Bonus", stored.Source)
+
+	_, err = repos.Income.GetIncomeByID(ctx, "income-missing")
+	assert.EqualError(t, err, "income with ID income-missing not found")
+	err = repos.Income.UpdateIncome(ctx, newIncome("income-missing", "user-1", 1, baseTime))
+	assert.EqualError(t, err, "income with ID income-missing not found")
+}
+
+func testIncomeDeleteAndRestore(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-1", "user-1", 5000, baseTime)))
+
+	_, err := repos.Income.GetDeletedIncomeByID(ctx, "income-1")
+	assert.EqualError(t, err, "deleted income with ID income-1 not found")
+
+	require.NoError(t, repos.Income.DeleteIncome(ctx, "income-1"))
+	_, err = repos.Income.GetIncomeByID(ctx, "income-1")
+	assert.EqualError(t, err, "income with ID income-1 not found")
+	assert.EqualError(t, repos.Income.DeleteIncome(ctx, "income-1"), "income with ID income-1 not found")
+
+	incomes, err := repos.Income.GetUserIncomes(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, incomes)
+
+	deleted, err := repos.Income.GetDeletedIncomeByID(ctx, "income-1")
+	require.NoError(t, err)
+	assert.Equal(t, 5000.0, deleted.Amount)
+
+	require.NoError(t, repos.Income.RestoreIncome(ctx, "income-1"))
+	_, err = repos.Income.GetIncomeByID(ctx, "income-1")
+	assert.NoError(t, err)
+	assert.EqualError(t, repos.Income.RestoreIncome(ctx, "income-1"), "deleted income with ID income-1 not found")
+}
+
+func testIncomePagesAfterCursor(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	// Saved out of order; pages follow creation time
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-c", "user-1", 300, baseTime.Add(2*time.Minute))))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-a", "user-1", 100, baseTime)))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-b", "user-1", 200, baseTime.Add(time.Minute))))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-other", "user-2", 400, baseTime)))
+
+	page, err := repos.Income.GetUserIncomesAfter(ctx, "user-1", domain.Cursor{}, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "income-a", page[0].ID)
+	assert.Equal(t, "income-b", page[1].ID)
+
+	cursor := domain.Cursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+	page, err = repos.Income.GetUserIncomesAfter(ctx, "user-1", cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "income-c", page[0].ID)
+}
+
+func testIncomeTotals(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	inactive := newIncome("income-2", "user-1", 1000, baseTime)
+	inactive.IsActive = false
+	inactive.Frequency = domain.FrequencyWeekly
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-1", "user-1", 5000, baseTime)))
+	require.NoError(t, repos.Income.SaveIncome(ctx, inactive))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-3", "user-2", 7000, baseTime)))
+
+	total, err := repos.Income.CalculateUserTotalIncome(ctx, "user-1", false)
+	require.NoError(t, err)
+	assert.Equal(t, 6000.0, total)
+
+	total, err = repos.Income.CalculateUserTotalIncome(ctx, "user-1", true)
+	require.NoError(t, err)
+	assert.Equal(t, 5000.0, total)
+
+	active, err := repos.Income.GetActiveIncomes(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, "income-1", active[0].ID)
+
+	weekly, err := repos.Income.GetUserIncomesByFrequency(ctx, "user-1", domain.FrequencyWeekly)
+	require.NoError(t, err)
+	require.Len(t, weekly, 1)
+	assert.Equal(t, "income-2", weekly[0].ID)
+}
+
+func testIncomeFindDuplicate(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-old", "user-1", 5000, baseTime.Add(-time.Hour))))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-1", "user-1", 5000, baseTime)))
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-2", "user-1", 5000, baseTime.Add(time.Minute))))
+
+	id, err := repos.Income.FindDuplicateIncomeID(ctx, newIncome("", "user-1", 5000, time.Time{}), baseTime)
+	require.NoError(t, err)
+	assert.Equal(t, "income-2", id)
+
+	id, err = repos.Income.FindDuplicateIncomeID(ctx, newIncome("", "user-1", 4999, time.Time{}), baseTime)
+	require.NoError(t, err)
+	assert.Empty(t, id)
+}
+
+func testExpenseDeleteMany(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Expense.SaveExpense(ctx, newExpense("expense-1", "user-1", "Rent", 1200, baseTime)))
+	require.NoError(t, repos.Expense.SaveExpense(ctx, newExpense("expense-2", "user-1", "Parking", 100, baseTime)))
+	require.NoError(t, repos.Expense.SaveExpense(ctx, newExpense("expense-3", "user-1", "Storage", 50, baseTime)))
+
+	deleted, err := repos.Expense.DeleteExpenses(ctx, []string{"expense-1", "expense-2", "expense-missing"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	total, err := repos.Expense.CalculateUserTotalExpenses(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, total)
+
+	_, err = repos.Expense.GetExpenseByID(ctx, "expense-1")
+	assert.EqualError(t, err, "expense with ID expense-1 not found")
+
+	require.NoError(t, repos.Expense.RestoreExpense(ctx, "expense-1"))
+	total, err = repos.Expense.CalculateTotalByCategory(ctx, "user-1", "housing")
+	require.NoError(t, err)
+	assert.Equal(t, 1250.0, total)
+}
+
+func testExpenseFind(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	rent := newExpense("expense-1", "user-1", "Monthly Rent", 1200, baseTime)
+	food := newExpense("expense-2", "user-1", "Groceries", 400, baseTime.Add(time.Hour))
+	food.Category = "food"
+	food.IsFixed = false
+	storage := newExpense("expense-3", "user-1", "Storage rental", 60, baseTime.Add(2*time.Hour))
+	for _, expense := range []domain.Expense{rent, food, storage, newExpense("expense-4", "user-2", "Rent", 900, baseTime)} {
+		require.NoError(t, repos.Expense.SaveExpense(ctx, expense))
+	}
+
+	// Name search is case-insensitive and results are newest first
+	found, err := repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Query: "RENT"})
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	assert.Equal(t, "expense-3", found[0].ID)
+	assert.Equal(t, "expense-1", found[1].ID)
+
+	fixed := true
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{IsFixed: &fixed, MinAmount: 100})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "expense-1", found[0].ID)
+
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{CreatedFrom: baseTime.Add(time.Hour), CreatedBefore: baseTime.Add(2 * time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "expense-2", found[0].ID)
+
+	variable, err := repos.Expense.GetVariableExpenses(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, variable, 1)
+	assert.Equal(t, "expense-2", variable[0].ID)
+}
+
+func testExpenseFindAfterCursor(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	food := newExpense("expense-food", "user-1", "Groceries", 400, baseTime)
+	food.Category = "food"
+	for _, expense := range []domain.Expense{
+		newExpense("expense-c", "user-1", "Storage", 60, baseTime.Add(2*time.Minute)),
+		newExpense("expense-a", "user-1", "Rent", 1200, baseTime),
+		newExpense("expense-b", "user-1", "Parking", 100, baseTime.Add(time.Minute)),
+		food,
+		newExpense("expense-other", "user-2", "Rent", 900, baseTime),
+	} {
+		require.NoError(t, repos.Expense.SaveExpense(ctx, expense))
+	}
+
+	filter := domain.ExpenseFilter{Category: "housing"}
+	page, err := repos.Expense.FindExpensesAfter(ctx, "user-1", filter, domain.Cursor{}, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "expense-a", page[0].ID)
+	assert.Equal(t, "expense-b", page[1].ID)
+
+	cursor := domain.Cursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+	page, err = repos.Expense.FindExpensesAfter(ctx, "user-1", filter, cursor, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, "expense-c", page[0].ID)
+}
+
+func testExpenseFindByTag(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	flights := newExpense("expense-1", "user-1", "Flights", 400, baseTime)
+	flights.Tags = []string{"vacation", "work_trip"}
+	hotel := newExpense("expense-2", "user-1", "Hotel", 300, baseTime.Add(time.Hour))
+	hotel.Tags = []string{"summer vacation"}
+	rent := newExpense("expense-3", "user-1", "Rent", 1200, baseTime)
+	other := newExpense("expense-4", "user-2", "Flights", 500, baseTime)
+	other.Tags = []string{"vacation"}
+	for _, expense := range []domain.Expense{flights, hotel, rent, other} {
+		require.NoError(t, repos.Expense.SaveExpense(ctx, expense))
+	}
+
+	saved, err := repos.Expense.GetExpenseByID(ctx, "expense-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vacation", "work_trip"}, saved.Tags)
+	saved, err = repos.Expense.GetExpenseByID(ctx, "expense-3")
+	require.NoError(t, err)
+	assert.Empty(t, saved.Tags)
+
+	// A tag only matches whole tags, not tags that contain it
+	found, err := repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Tag: "vacation"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "expense-1", found[0].ID)
+
+	// Underscores are matched literally rather than as wildcards
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Tag: "work_trip"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Tag: "workxtrip"})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Tag: "summer vacation", Category: "housing"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "expense-2", found[0].ID)
+
+	// Updating replaces the tags
+	flights.Tags = nil
+	require.NoError(t, repos.Expense.UpdateExpense(ctx, flights))
+	found, err = repos.Expense.FindExpenses(ctx, "user-1", domain.ExpenseFilter{Tag: "vacation"})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func testLoanBalance(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Loan.SaveLoan(ctx, newLoan("loan-1", "user-1", 10000, 9000, 300)))
+	require.NoError(t, repos.Loan.SaveLoan(ctx, newLoan("loan-2", "user-1", 20000, 2000, 500)))
+	require.NoError(t, repos.Loan.SaveLoan(ctx, newLoan("loan-3", "user-2", 5000, 100, 100)))
+
+	require.NoError(t, repos.Loan.UpdateLoanBalance(ctx, "loan-1", 1000))
+	loan, err := repos.Loan.GetLoanByID(ctx, "loan-1")
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, loan.RemainingBalance)
+
+	nearPayoff, err := repos.Loan.GetNearPayoffLoans(ctx, "user-1", 0.1)
+	require.NoError(t, err)
+	require.Len(t, nearPayoff, 2)
+	assert.Equal(t, "loan-1", nearPayoff[0].ID)
+	assert.Equal(t, "loan-2", nearPayoff[1].ID)
+
+	debt, err := repos.Loan.CalculateUserTotalDebt(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3000.0, debt)
+
+	payments, err := repos.Loan.CalculateUserMonthlyPayments(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 800.0, payments)
+}
+
+func testLoanNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Loan.SaveLoan(ctx, newLoan("loan-1", "user-1", 10000, 9000, 300)))
+	require.NoError(t, repos.Loan.DeleteLoan(ctx, "loan-1"))
+
+	_, err := repos.Loan.GetLoanByID(ctx, "loan-1")
+	assert.EqualError(t, err, "loan with ID loan-1 not found")
+	assert.EqualError(t, repos.Loan.DeleteLoan(ctx, "loan-1"), "loan with ID loan-1 not found")
+	assert.EqualError(t, repos.Loan.UpdateLoanBalance(ctx, "loan-1", 0), "loan with ID loan-1 not found")
+	assert.EqualError(t, repos.Loan.UpdateLoan(ctx, newLoan("loan-1", "user-1", 1, 1, 1)), "loan with ID loan-1 not found")
+
+	loans, err := repos.Loan.GetUserLoans(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, loans)
+}
+
+func testLoanInterestRateRange(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	for i, rate := range []float64{4, 5, 6, 8, 9} {
+		loan := newLoan(fmt.Sprintf("loan-%d", i+1), "user-1", 10000, 5000, 300)
+		loan.InterestRate = rate
+		require.NoError(t, repos.Loan.SaveLoan(ctx, loan))
+	}
+	other := newLoan("loan-6", "user-2", 10000, 5000, 300)
+	other.InterestRate = 6
+	require.NoError(t, repos.Loan.SaveLoan(ctx, other))
+
+	loans, err := repos.Loan.GetLoansByInterestRateRange(ctx, "user-1", 5, 8)
+	require.NoError(t, err)
+	var rates []float64
+	for _, loan := range loans {
+		rates = append(rates, loan.InterestRate)
+	}
+	assert.ElementsMatch(t, []float64{5, 6, 8}, rates, "both bounds are inclusive")
+}
+
+func testLoanBalanceHistory(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	require.NoError(t, repos.Loan.SaveLoan(ctx, newLoan("loan-1", "user-1", 10000, 9000, 300)))
+
+	payment := domain.LoanBalanceEntry{
+		ID: "loanbal-1", LoanID: "loan-1", UserID: "user-1", Type: domain.LoanBalancePayment,
+		OldBalance: 9000, NewBalance: 8733.75, Amount: 300, InterestPortion: 33.75, PrincipalPortion: 266.25,
+		Note: "June payment", CreatedAt: baseTime.Add(time.Hour),
+	}
+	adjustment := domain.LoanBalanceEntry{
+		ID: "loanbal-2", LoanID: "loan-1", UserID: "user-1", Type: domain.LoanBalanceAdjustment,
+		OldBalance: 8733.75, NewBalance: 8700, Amount: -33.75, CreatedAt: baseTime.Add(2 * time.Hour),
+	}
+	require.NoError(t, repos.Loan.RecordBalanceChange(ctx, payment))
+	require.NoError(t, repos.Loan.RecordBalanceChange(ctx, adjustment))
+
+	loan, err := repos.Loan.GetLoanByIDForUpdate(ctx, "loan-1")
+	require.NoError(t, err)
+	assert.Equal(t, 8700.0, loan.RemainingBalance, "the loan carries the latest entry's balance")
+	debt, err := repos.Loan.CalculateUserTotalDebt(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 8700.0, debt)
+
+	entries, err := repos.Loan.GetBalanceEntries(ctx, "loan-1")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "loanbal-2", entries[0].ID, "newest first")
+	assert.Equal(t, payment.Note, entries[1].Note)
+	assert.Equal(t, payment.InterestPortion, entries[1].InterestPortion)
+	assert.Equal(t, payment.PrincipalPortion, entries[1].PrincipalPortion)
+	assert.True(t, payment.CreatedAt.Equal(entries[1].CreatedAt))
+
+	err = repos.Loan.RecordBalanceChange(ctx, domain.LoanBalanceEntry{
+		LoanID: "loan-2", UserID: "user-1", Type: domain.LoanBalanceAdjustment, NewBalance: 1, CreatedAt: baseTime,
+	})
+	assert.EqualError(t, err, "loan with ID loan-2 not found")
+	entries, err = repos.Loan.GetBalanceEntries(ctx, "loan-2")
+	require.NoError(t, err)
+	assert.Empty(t, entries, "a failed change records no entry")
+}
+
+func testLoanDueForAccrual(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	monthStart := time.Date(baseTime.Year(), baseTime.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for _, loan := range []domain.Loan{
+		newLoan("loan-1", "user-1", 10000, 9000, 300),
+		newLoan("loan-2", "user-2", 10000, 9000, 300),
+		newLoan("loan-3", "user-1", 10000, 9000, 300),
+		newLoan("loan-4", "user-1", 10000, 0, 300),
+		newLoan("loan-5", "user-1", 10000, 9000, 300),
+	} {
+		loan.AutoAccrueInterest = loan.ID != "loan-5"
+		require.NoError(t, repos.Loan.SaveLoan(ctx, loan))
+	}
+
+	// loan-1 accrued last month and is due again; loan-3 already accrued this month
+	require.NoError(t, repos.Loan.RecordBalanceChange(ctx, domain.LoanBalanceEntry{
+		LoanID: "loan-1", UserID: "user-1", Type: domain.LoanBalanceInterestAccrual,
+		OldBalance: 8966.25, NewBalance: 9000, Amount: 33.75, InterestPortion: 33.75, CreatedAt: monthStart.AddDate(0, -1, 0),
+	}))
+	require.NoError(t, repos.Loan.RecordBalanceChange(ctx, domain.LoanBalanceEntry{
+		LoanID: "loan-3", UserID: "user-1", Type: domain.LoanBalanceInterestAccrual,
+		OldBalance: 8966.25, NewBalance: 9000, Amount: 33.75, InterestPortion: 33.75, CreatedAt: monthStart,
+	}))
+
+	loans, err := repos.Loan.GetLoansDueForAccrual(ctx, monthStart)
+	require.NoError(t, err)
+	var ids []string
+	for _, loan := range loans {
+		ids = append(ids, loan.ID)
+		assert.True(t, loan.AutoAccrueInterest)
+	}
+	assert.Equal(t, []string{"loan-1", "loan-2"}, ids, "repaid and manual loans aren't due")
+}
@@ -0,0 +1,544 @@
+package repotest
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+var healthTests = []conformanceTest{
+	{"HealthProfile/OneSelfProfile", testHealthProfileOneSelfProfile},
+	{"HealthProfile/Family", testHealthProfileFamily},
+	{"HealthProfile/ListSelfProfiles", testHealthProfileListSelfProfiles},
+	{"HealthProfile/Snapshots", testHealthProfileSnapshots},
+	{"HealthProfile/DeleteCascades", testHealthProfileDeleteCascades},
+	{"MedicalCondition/Risk", testMedicalConditionRisk},
+	{"MedicalExpense/OutOfPocket", testMedicalExpenseOutOfPocket},
+	{"MedicalExpense/Recurring", testMedicalExpenseRecurring},
+	{"MedicalExpenseOccurrence/DeleteCascades", testMedicalExpenseOccurrenceDeleteCascades},
+	{"InsurancePolicy/PolicyNumber", testInsurancePolicyNumber},
+	{"InsurancePolicy/DeductibleProgress", testInsurancePolicyDeductibleProgress},
+	{"HealthRiskSnapshot/History", testHealthRiskSnapshotHistory},
+}
+
+func newHealthProfile(userID, name, relation string) *domain.HealthProfile {
+	return &domain.HealthProfile{
+		UserID:          userID,
+		Name:            name,
+		RelationToOwner: relation,
+		Age:             35,
+		Gender:          "female",
+		Height:          170,
+		Weight:          65,
+		FamilySize:      3,
+	}
+}
+
+func newCondition(userID, profileID, name string) *domain.MedicalCondition {
+	return &domain.MedicalCondition{
+		UserID:        userID,
+		ProfileID:     profileID,
+		Name:          name,
+		Category:      "chronic",
+		Severity:      "moderate",
+		DiagnosedDate: baseTime,
+		IsActive:      true,
+	}
+}
+
+func newMedicalExpense(userID, profileID string, amount float64, date time.Time) *domain.MedicalExpense {
+	return &domain.MedicalExpense{
+		UserID:      userID,
+		ProfileID:   profileID,
+		Amount:      amount,
+		Category:    "doctor_visit",
+		Description: "Checkup",
+		Date:        date,
+	}
+}
+
+func newPolicy(userID, profileID, number string) *domain.InsurancePolicy {
+	now := time.Now()
+	return &domain.InsurancePolicy{
+		UserID:             userID,
+		ProfileID:          profileID,
+		Provider:           "Acme Health",
+		PolicyNumber:       number,
+		Type:               "health",
+		MonthlyPremium:     300,
+		Deductible:         1000,
+		OutOfPocketMax:     5000,
+		CoveragePercentage: 80,
+		StartDate:          now.AddDate(0, -1, 0),
+		EndDate:            now.AddDate(1, 0, 0),
+		IsActive:           true,
+	}
+}
+
+// createProfile stores a health profile and returns it with its assigned ID
+func createProfile(t *testing.T, repos Repositories, profile *domain.HealthProfile) *domain.HealthProfile {
+	t.Helper()
+
+	created, err := repos.HealthProfile.Create(context.Background(), profile)
+	require.NoError(t, err)
+	require.NotEmpty(t, created.ID)
+	return created
+}
+
+// profileID parses a profile ID for the methods that take one as a number
+func profileID(t *testing.T, profile *domain.HealthProfile) uint {
+	t.Helper()
+
+	id, err := strconv.ParseUint(profile.ID, 10, 32)
+	require.NoError(t, err)
+	return uint(id)
+}
+
+func testHealthProfileOneSelfProfile(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	assert.InDelta(t, 22.49, self.BMI, 0.01)
+
+	_, err := repos.HealthProfile.Create(ctx, newHealthProfile("user-1", "", domain.RelationSelf))
+	assert.ErrorIs(t, err, domain.ErrProfileAlreadyExists)
+
+	// Dependents don't count against the self profile
+	createProfile(t, repos, newHealthProfile("user-1", "Sam", domain.RelationChild))
+	createProfile(t, repos, newHealthProfile("user-2", "", domain.RelationSelf))
+
+	stored, err := repos.HealthProfile.GetByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, self.ID, stored.ID)
+
+	exists, err := repos.HealthProfile.ExistsByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repos.HealthProfile.ExistsByUserID(ctx, "user-3")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = repos.HealthProfile.GetByUserID(ctx, "user-3")
+	assert.EqualError(t, err, "health profile not found for user user-3")
+	_, err = repos.HealthProfile.GetByID(ctx, 999)
+	assert.EqualError(t, err, "health profile with ID 999 not found")
+}
+
+func testHealthProfileFamily(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	// The self profile is listed first even when a dependent was created before it
+	child := createProfile(t, repos, newHealthProfile("user-1", "Sam", domain.RelationChild))
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	spouse := createProfile(t, repos, newHealthProfile("user-1", "Alex", domain.RelationSpouse))
+	createProfile(t, repos, newHealthProfile("user-2", "", domain.RelationSelf))
+
+	family, err := repos.HealthProfile.GetFamilyByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, family, 3)
+	assert.Equal(t, self.ID, family[0].ID)
+	assert.Equal(t, child.ID, family[1].ID)
+	assert.Equal(t, spouse.ID, family[2].ID)
+
+	family, err = repos.HealthProfile.GetFamilyByUserID(ctx, "user-3")
+	require.NoError(t, err)
+	assert.Empty(t, family)
+}
+
+func testHealthProfileListSelfProfiles(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	createProfile(t, repos, newHealthProfile("user-1", "Sam", domain.RelationChild))
+	second := createProfile(t, repos, newHealthProfile("user-2", "", domain.RelationSelf))
+	third := createProfile(t, repos, newHealthProfile("user-3", "", domain.RelationSelf))
+	deleted := createProfile(t, repos, newHealthProfile("user-4", "", domain.RelationSelf))
+	require.NoError(t, repos.HealthProfile.Delete(ctx, profileID(t, deleted)))
+
+	// Pages continue after the last ID seen and skip dependents and deleted profiles
+	page, err := repos.HealthProfile.ListSelfProfiles(ctx, 0, 2)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, first.ID, page[0].ID)
+	assert.Equal(t, second.ID, page[1].ID)
+
+	page, err = repos.HealthProfile.ListSelfProfiles(ctx, profileID(t, page[1]), 2)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, third.ID, page[0].ID)
+
+	page, err = repos.HealthProfile.ListSelfProfiles(ctx, profileID(t, third), 2)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func testHealthProfileSnapshots(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	since := time.Now().Add(-time.Minute)
+
+	self.Weight = 70
+	_, err := repos.HealthProfile.Update(ctx, self)
+	require.NoError(t, err)
+	self.Weight = 75
+	updated, err := repos.HealthProfile.Update(ctx, self)
+	require.NoError(t, err)
+	assert.Equal(t, 75.0, updated.Weight)
+	assert.InDelta(t, 25.95, updated.BMI, 0.01)
+
+	// Each update records the measurements it replaced
+	snapshots, err := repos.HealthProfile.GetSnapshots(ctx, "user-1", since, 10)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 65.0, snapshots[0].Weight)
+	assert.Equal(t, 70.0, snapshots[1].Weight)
+	assert.Equal(t, self.ID, snapshots[1].ProfileID)
+
+	// A limit keeps the newest snapshots
+	snapshots, err = repos.HealthProfile.GetSnapshots(ctx, "user-1", since, 1)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, 70.0, snapshots[0].Weight)
+
+	snapshots, err = repos.HealthProfile.GetSnapshots(ctx, "user-1", time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
+
+func testHealthProfileDeleteCascades(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	child := createProfile(t, repos, newHealthProfile("user-1", "Sam", domain.RelationChild))
+	other := createProfile(t, repos, newHealthProfile("user-2", "", domain.RelationSelf))
+
+	for _, profile := range []*domain.HealthProfile{self, child, other} {
+		_, err := repos.MedicalCondition.Create(ctx, newCondition(profile.UserID, profile.ID, "Asthma"))
+		require.NoError(t, err)
+		_, err = repos.MedicalExpense.Create(ctx, newMedicalExpense(profile.UserID, profile.ID, 100, baseTime))
+		require.NoError(t, err)
+		_, err = repos.InsurancePolicy.Create(ctx, newPolicy(profile.UserID, profile.ID, "POL-"+profile.ID))
+		require.NoError(t, err)
+	}
+
+	// Deleting a dependent leaves the rest of the family alone
+	require.NoError(t, repos.HealthProfile.Delete(ctx, profileID(t, child)))
+	conditions, err := repos.MedicalCondition.GetByUserID(ctx, "user-1", false)
+	require.NoError(t, err)
+	require.Len(t, conditions, 1)
+	assert.Equal(t, self.ID, conditions[0].ProfileID)
+
+	// Deleting the self profile deletes everything the user has
+	require.NoError(t, repos.HealthProfile.Delete(ctx, profileID(t, self)))
+	_, err = repos.HealthProfile.GetByUserID(ctx, "user-1")
+	assert.Error(t, err)
+
+	conditions, err = repos.MedicalCondition.GetByUserID(ctx, "user-1", false)
+	require.NoError(t, err)
+	assert.Empty(t, conditions)
+	expenses, err := repos.MedicalExpense.GetByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, expenses)
+	policies, err := repos.InsurancePolicy.GetByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	// Other users keep their records
+	conditions, err = repos.MedicalCondition.GetByUserID(ctx, "user-2", false)
+	require.NoError(t, err)
+	assert.Len(t, conditions, 1)
+
+	assert.EqualError(t, repos.HealthProfile.Delete(ctx, profileID(t, self)),
+		"health profile with ID "+self.ID+" not found")
+}
+
+func testMedicalConditionRisk(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+
+	defaulted, err := repos.MedicalCondition.Create(ctx, newCondition("user-1", self.ID, "Asthma"))
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, defaulted.RiskFactor)
+
+	diabetes := newCondition("user-1", self.ID, "Diabetes")
+	diabetes.RiskFactor = 0.4
+	diabetes.RequiresMedication = true
+	_, err = repos.MedicalCondition.Create(ctx, diabetes)
+	require.NoError(t, err)
+
+	fracture := newCondition("user-1", self.ID, "Fracture")
+	fracture.Category = "acute"
+	fracture.RiskFactor = 0.3
+	resolved, err := repos.MedicalCondition.Create(ctx, fracture)
+	require.NoError(t, err)
+	resolvedDate := baseTime.AddDate(0, 2, 0)
+	resolved.IsActive = false
+	resolved.ResolvedDate = &resolvedDate
+	_, err = repos.MedicalCondition.Update(ctx, resolved)
+	require.NoError(t, err)
+
+	active, err := repos.MedicalCondition.GetByUserID(ctx, "user-1", true)
+	require.NoError(t, err)
+	assert.Len(t, active, 2)
+
+	count, err := repos.MedicalCondition.GetActiveConditionCount(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	risk, err := repos.MedicalCondition.CalculateTotalRiskFactor(ctx, "user-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, risk, 0.0001)
+
+	medicated, err := repos.MedicalCondition.GetMedicationRequiringConditions(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, medicated, 1)
+	assert.Equal(t, "Diabetes", medicated[0].Name)
+
+	acute, err := repos.MedicalCondition.GetByCategory(ctx, "user-1", "acute")
+	require.NoError(t, err)
+	require.Len(t, acute, 1)
+	assert.Equal(t, "Fracture", acute[0].Name)
+
+	_, err = repos.MedicalCondition.GetByID(ctx, "999")
+	assert.ErrorIs(t, err, domain.ErrMedicalConditionNotFound)
+	assert.EqualError(t, err, "medical condition with ID 999: medical condition not found")
+}
+
+func testMedicalExpenseOutOfPocket(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+
+	covered := newMedicalExpense("user-1", self.ID, 200, baseTime)
+	covered.IsCovered = true
+	covered.InsurancePayment = 150
+	created, err := repos.MedicalExpense.Create(ctx, covered)
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, created.OutOfPocket)
+	assert.Equal(t, "one_time", created.Frequency)
+
+	// Insurance never pays more than the expense
+	created.InsurancePayment = 500
+	updated, err := repos.MedicalExpense.Update(ctx, created)
+	require.NoError(t, err)
+	assert.Equal(t, 200.0, updated.InsurancePayment)
+	assert.Equal(t, 0.0, updated.OutOfPocket)
+
+	_, err = repos.MedicalExpense.Create(ctx, newMedicalExpense("user-1", self.ID, 80, baseTime.AddDate(0, 1, 0)))
+	require.NoError(t, err)
+
+	expenses, err := repos.MedicalExpense.GetByUserID(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, expenses, 2)
+	assert.Equal(t, 80.0, expenses[0].Amount)
+	assert.Equal(t, 200.0, expenses[1].Amount)
+
+	totals, err := repos.MedicalExpense.CalculateTotals(ctx, "user-1", baseTime, baseTime.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, 280.0, totals.TotalAmount)
+	assert.Equal(t, 200.0, totals.TotalInsurancePaid)
+	assert.Equal(t, 80.0, totals.TotalOutOfPocket)
+	assert.Equal(t, int64(2), totals.ExpenseCount)
+
+	_, err = repos.MedicalExpense.GetByID(ctx, "999")
+	assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+}
+
+func testMedicalExpenseRecurring(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+
+	monthly := newMedicalExpense("user-1", self.ID, 100, baseTime)
+	monthly.IsRecurring = true
+	quarterly := newMedicalExpense("user-1", self.ID, 300, baseTime)
+	quarterly.IsRecurring = true
+	quarterly.Frequency = "quarterly"
+	for _, expense := range []*domain.MedicalExpense{monthly, quarterly, newMedicalExpense("user-1", self.ID, 500, baseTime)} {
+		_, err := repos.MedicalExpense.Create(ctx, expense)
+		require.NoError(t, err)
+	}
+
+	recurring, err := repos.MedicalExpense.GetRecurring(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, recurring, 2)
+
+	byFrequency, err := repos.MedicalExpense.GetByFrequency(ctx, "user-1", "monthly")
+	require.NoError(t, err)
+	require.Len(t, byFrequency, 1)
+	assert.Equal(t, 100.0, byFrequency[0].Amount)
+
+	total, err := repos.MedicalExpense.GetMonthlyRecurringTotal(ctx, "user-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 200.0, total, 0.0001)
+}
+
+func testMedicalExpenseOccurrenceDeleteCascades(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+	child := createProfile(t, repos, newHealthProfile("user-1", "Sam", domain.RelationChild))
+
+	record := func(expense *domain.MedicalExpense, amount float64, date time.Time) {
+		t.Helper()
+		created, err := repos.MedicalExpenseOccurrence.Create(ctx, &domain.MedicalExpenseOccurrence{
+			ExpenseID: expense.ID, UserID: expense.UserID, ProfileID: expense.ProfileID,
+			Amount: amount, Date: date, Category: expense.Category, OutOfPocket: amount,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+	}
+
+	expenses := make([]*domain.MedicalExpense, 0, 3)
+	for _, profile := range []*domain.HealthProfile{self, self, child} {
+		expense := newMedicalExpense("user-1", profile.ID, 100, baseTime)
+		expense.IsRecurring = true
+		created, err := repos.MedicalExpense.Create(ctx, expense)
+		require.NoError(t, err)
+		expenses = append(expenses, created)
+	}
+
+	// Recorded out of order; occurrences come back oldest first
+	record(expenses[0], 110, baseTime.AddDate(0, 1, 0))
+	record(expenses[0], 95, baseTime)
+	record(expenses[1], 120, baseTime.AddDate(0, 1, 0))
+	record(expenses[2], 80, baseTime)
+
+	occurrences, err := repos.MedicalExpenseOccurrence.GetByExpenseID(ctx, expenses[0].ID)
+	require.NoError(t, err)
+	require.Len(t, occurrences, 2)
+	assert.Equal(t, []float64{95, 110}, []float64{occurrences[0].Amount, occurrences[1].Amount})
+	assert.Equal(t, "doctor_visit", occurrences[0].Category)
+	assert.True(t, occurrences[0].Date.Equal(baseTime))
+
+	// since excludes older occurrences
+	occurrences, err = repos.MedicalExpenseOccurrence.GetByUserID(ctx, "user-1", baseTime.Add(time.Second))
+	require.NoError(t, err)
+	assert.Len(t, occurrences, 2)
+
+	// Deleting an expense deletes its occurrences
+	require.NoError(t, repos.MedicalExpense.Delete(ctx, expenses[0].ID))
+	occurrences, err = repos.MedicalExpenseOccurrence.GetByExpenseID(ctx, expenses[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, occurrences)
+	occurrences, err = repos.MedicalExpenseOccurrence.GetByUserID(ctx, "user-1", baseTime)
+	require.NoError(t, err)
+	assert.Len(t, occurrences, 2)
+
+	// Deleting the self profile deletes the family's occurrences
+	require.NoError(t, repos.HealthProfile.Delete(ctx, profileID(t, self)))
+	occurrences, err = repos.MedicalExpenseOccurrence.GetByUserID(ctx, "user-1", baseTime)
+	require.NoError(t, err)
+	assert.Empty(t, occurrences)
+}
+
+func testInsurancePolicyNumber(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+
+	created, err := repos.InsurancePolicy.Create(ctx, newPolicy("user-1", self.ID, "POL-1"))
+	require.NoError(t, err)
+
+	_, err = repos.InsurancePolicy.Create(ctx, newPolicy("user-1", self.ID, "POL-1"))
+	assert.ErrorIs(t, err, domain.ErrPolicyNumberExists)
+
+	// Numbers are unique per user, so another user's policy can have the same one
+	other := createProfile(t, repos, newHealthProfile("user-2", "", domain.RelationSelf))
+	othersPolicy, err := repos.InsurancePolicy.Create(ctx, newPolicy("user-2", other.ID, "POL-1"))
+	require.NoError(t, err)
+	found, err := repos.InsurancePolicy.GetByPolicyNumber(ctx, "user-2", "POL-1")
+	require.NoError(t, err)
+	assert.Equal(t, othersPolicy.ID, found.ID)
+
+	// Renumbering a policy to a number the user already has fails too
+	second, err := repos.InsurancePolicy.Create(ctx, newPolicy("user-1", self.ID, "POL-2"))
+	require.NoError(t, err)
+	second.PolicyNumber = "POL-1"
+	_, err = repos.InsurancePolicy.Update(ctx, second)
+	assert.ErrorIs(t, err, domain.ErrPolicyNumberExists)
+
+	// Policy numbers stay taken after the policy is deleted
+	require.NoError(t, repos.InsurancePolicy.Delete(ctx, created.ID))
+	_, err = repos.InsurancePolicy.Create(ctx, newPolicy("user-1", self.ID, "POL-1"))
+	assert.ErrorIs(t, err, domain.ErrPolicyNumberExists)
+
+	_, err = repos.InsurancePolicy.GetByID(ctx, created.ID)
+	assert.ErrorIs(t, err, domain.ErrInsurancePolicyNotFound)
+}
+
+func testInsurancePolicyDeductibleProgress(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	self := createProfile(t, repos, newHealthProfile("user-1", "", domain.RelationSelf))
+
+	created, err := repos.InsurancePolicy.Create(ctx, newPolicy("user-1", self.ID, "POL-1"))
+	require.NoError(t, err)
+	expired := newPolicy("user-1", self.ID, "POL-2")
+	expired.StartDate = baseTime.AddDate(-2, 0, 0)
+	expired.EndDate = baseTime.AddDate(-1, 0, 0)
+	_, err = repos.InsurancePolicy.Create(ctx, expired)
+	require.NoError(t, err)
+
+	active, err := repos.InsurancePolicy.GetActivePolicies(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, active, 1)
+	assert.Equal(t, created.ID, active[0].ID)
+
+	// Progress is capped at the policy limits
+	updated, err := repos.InsurancePolicy.UpdateDeductibleProgress(ctx, created.ID, 1500, 6000)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, updated.DeductibleMet)
+	assert.Equal(t, 5000.0, updated.OutOfPocketCurrent)
+
+	stored, err := repos.InsurancePolicy.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, stored.DeductibleMet)
+
+	_, err = repos.InsurancePolicy.UpdateDeductibleProgress(ctx, "999", 0, 0)
+	assert.EqualError(t, err, "insurance policy with ID 999 not found")
+
+	locked, err := repos.InsurancePolicy.GetByIDForUpdate(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, locked.DeductibleMet)
+	assert.Equal(t, 5000.0, locked.OutOfPocketCurrent)
+
+	_, err = repos.InsurancePolicy.GetByIDForUpdate(ctx, "999")
+	assert.EqualError(t, err, "insurance policy with ID 999: insurance policy not found")
+}
+
+func testHealthRiskSnapshotHistory(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	record := func(userID string, score int, level string, at time.Time) {
+		t.Helper()
+		created, err := repos.HealthRiskSnapshot.Create(ctx, &domain.RiskSnapshot{
+			UserID: userID, RiskScore: score, RiskLevel: level, RecordedAt: at,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, created.ID)
+	}
+
+	// Recorded out of order; history comes back oldest first
+	record("user-1", 40, "moderate", baseTime.AddDate(0, 2, 0))
+	record("user-1", 10, "low", baseTime)
+	record("user-1", 25, "low", baseTime.AddDate(0, 1, 0))
+	record("user-2", 90, "critical", baseTime.AddDate(0, 1, 0))
+
+	snapshots, err := repos.HealthRiskSnapshot.GetByUserID(ctx, "user-1", baseTime, 10)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+	assert.Equal(t, []int{10, 25, 40}, []int{snapshots[0].RiskScore, snapshots[1].RiskScore, snapshots[2].RiskScore})
+	assert.Equal(t, "moderate", snapshots[2].RiskLevel)
+	assert.True(t, snapshots[0].RecordedAt.Equal(baseTime))
+
+	// since excludes older snapshots and a limit keeps the newest
+	snapshots, err = repos.HealthRiskSnapshot.GetByUserID(ctx, "user-1", baseTime.Add(time.Second), 10)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 2)
+	snapshots, err = repos.HealthRiskSnapshot.GetByUserID(ctx, "user-1", baseTime, 1)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, 40, snapshots[0].RiskScore)
+
+	snapshots, err = repos.HealthRiskSnapshot.GetByUserID(ctx, "user-3", baseTime, 10)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
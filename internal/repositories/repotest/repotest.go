@@ -0,0 +1,76 @@
+// Package repotest provides a conformance suite for implementations of the repository
+// interfaces. It checks the behavior callers rely on, such as which errors are returned
+// for missing records, how results are ordered and which records are deleted together,
+// so the GORM and in-memory repositories can be held to the same contract.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// Repositories holds the implementations under test. They must share one store, as
+// repositories sharing a database do, since some behavior spans them: tokens can only be
+// saved for existing users and deleting a health profile deletes its related records.
+type Repositories struct {
+	User                     services.UserRepository
+	Token                    services.TokenRepository
+	Income                   services.IncomeRepository
+	Expense                  services.ExpenseRepository
+	Loan                     services.LoanRepository
+	HealthProfile            services.HealthProfileRepository
+	MedicalCondition         services.MedicalConditionRepository
+	MedicalExpense           services.MedicalExpenseRepository
+	MedicalExpenseOccurrence services.MedicalExpenseOccurrenceRepository
+	InsurancePolicy          services.InsurancePolicyRepository
+	HealthRiskSnapshot       services.HealthRiskSnapshotRepository
+}
+
+// conformanceTest is one behavior checked against fresh repositories
+type conformanceTest struct {
+	name string
+	run  func(t *testing.T, repos Repositories)
+}
+
+// Run runs the conformance suite as subtests of t. newRepositories is called for every
+// subtest and must return repositories backed by a new, empty store.
+func Run(t *testing.T, newRepositories func(t *testing.T) Repositories) {
+	var tests []conformanceTest
+	tests = append(tests, userTests...)
+	tests = append(tests, tokenTests...)
+	tests = append(tests, financeTests...)
+	tests = append(tests, healthTests...)
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.run(t, newRepositories(t))
+		})
+	}
+}
+
+// baseTime is a fixed, whole-second time used for records that need explicit timestamps
+var baseTime = time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+// createUser stores a user with the given email and returns it with its assigned ID
+func createUser(t *testing.T, repos Repositories, email string) *domain.User {
+	t.Helper()
+
+	now := time.Now()
+	user := &domain.User{
+		Email:        email,
+		Name:         "Test User",
+		PasswordHash: "hashed-password",
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	require.NoError(t, repos.User.Create(context.Background(), user))
+	require.NotEmpty(t, user.ID)
+	return user
+}
@@ -0,0 +1,279 @@
+package repotest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+var userTests = []conformanceTest{
+	{"User/CreateAndGet", testUserCreateAndGet},
+	{"User/DuplicateEmail", testUserDuplicateEmail},
+	{"User/NotFound", testUserNotFound},
+	{"User/Update", testUserUpdate},
+	{"User/ConcurrentEmailClaim", testUserConcurrentEmailClaim},
+	{"User/List", testUserList},
+	{"User/Delete", testUserDelete},
+}
+
+var tokenTests = []conformanceTest{
+	{"Token/SaveAndGet", testTokenSaveAndGet},
+	{"Token/UnknownUser", testTokenUnknownUser},
+	{"Token/Revoke", testTokenRevoke},
+	{"Token/CleanupExpired", testTokenCleanupExpired},
+}
+
+func testUserCreateAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+
+	byID, err := repos.User.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", byID.Email)
+	assert.Equal(t, domain.RoleUser, byID.Role)
+	assert.True(t, byID.IsActive)
+
+	byEmail, err := repos.User.GetByEmail(ctx, "ada@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, byEmail.ID)
+}
+
+func testUserDuplicateEmail(t *testing.T, repos Repositories) {
+	createUser(t, repos, "ada@example.com")
+
+	now := time.Now()
+	duplicate := &domain.User{
+		Email:        "ada@example.com",
+		Name:         "Someone Else",
+		PasswordHash: "hashed-password",
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	err := repos.User.Create(context.Background(), duplicate)
+	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+}
+
+func testUserNotFound(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+
+	_, err := repos.User.GetByID(ctx, "999")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	_, err = repos.User.GetByEmail(ctx, "nobody@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	err = repos.User.UpdateLastLogin(ctx, "999", time.Now())
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+
+	err = repos.User.UpdateRole(ctx, "999", domain.RoleAdmin)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func testUserUpdate(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+	other := createUser(t, repos, "grace@example.com")
+
+	user.Name = "Ada Lovelace"
+	user.IsActive = false
+	require.NoError(t, repos.User.Update(ctx, user))
+	require.NoError(t, repos.User.UpdateRole(ctx, user.ID, domain.RoleAdmin))
+
+	updated, err := repos.User.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", updated.Name)
+	assert.False(t, updated.IsActive)
+	assert.Equal(t, domain.RoleAdmin, updated.Role)
+
+	// Emails stay unique
+	other.Email = "ada@example.com"
+	assert.ErrorIs(t, repos.User.Update(ctx, other), domain.ErrUserAlreadyExists)
+
+	missing := *user
+	missing.ID = "999"
+	assert.ErrorIs(t, repos.User.Update(ctx, &missing), domain.ErrUserNotFound)
+}
+
+// testUserDelete deletes a user with finance and health records, some already soft deleted
+func testUserDelete(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+	other := createUser(t, repos, "grace@example.com")
+
+	for _, owner := range []*domain.User{user, other} {
+		require.NoError(t, repos.Token.SaveRefreshToken(ctx, owner.ID, "token-"+owner.ID, time.Now().Add(time.Hour)))
+		require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-"+owner.ID, owner.ID, 5000, baseTime)))
+		profile := createProfile(t, repos, newHealthProfile(owner.ID, "", domain.RelationSelf))
+		_, err := repos.MedicalCondition.Create(ctx, newCondition(owner.ID, profile.ID, "Asthma"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, repos.Income.SaveIncome(ctx, newIncome("income-deleted", user.ID, 100, baseTime)))
+	require.NoError(t, repos.Income.DeleteIncome(ctx, "income-deleted"))
+
+	require.NoError(t, repos.User.Delete(ctx, user.ID))
+
+	_, err := repos.User.GetByID(ctx, user.ID)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	_, err = repos.Token.GetRefreshToken(ctx, "token-"+user.ID)
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+	incomes, err := repos.Income.GetUserIncomes(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, incomes)
+	_, err = repos.Income.GetDeletedIncomeByID(ctx, "income-deleted")
+	assert.Error(t, err, "soft-deleted records are purged")
+	exists, err := repos.HealthProfile.ExistsByUserID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.False(t, exists)
+	conditions, err := repos.MedicalCondition.GetByUserID(ctx, user.ID, true)
+	require.NoError(t, err)
+	assert.Empty(t, conditions)
+
+	// The email can be registered again
+	createUser(t, repos, "ada@example.com")
+
+	// Other users keep their records
+	_, err = repos.Token.GetRefreshToken(ctx, "token-"+other.ID)
+	assert.NoError(t, err)
+	incomes, err = repos.Income.GetUserIncomes(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Len(t, incomes, 1)
+	conditions, err = repos.MedicalCondition.GetByUserID(ctx, other.ID, true)
+	require.NoError(t, err)
+	assert.Len(t, conditions, 1)
+
+	assert.ErrorIs(t, repos.User.Delete(ctx, user.ID), domain.ErrUserNotFound)
+}
+
+// testUserConcurrentEmailClaim has two users change to the same email at once.
+// Exactly one must win; the other gets domain.ErrUserAlreadyExists.
+func testUserConcurrentEmailClaim(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	claimants := []*domain.User{
+		createUser(t, repos, "ada@example.com"),
+		createUser(t, repos, "grace@example.com"),
+	}
+
+	start := make(chan struct{})
+	errs := make([]error, len(claimants))
+	var wg sync.WaitGroup
+	for i, user := range claimants {
+		wg.Add(1)
+		go func(i int, user *domain.User) {
+			defer wg.Done()
+			<-start
+			user.Email = "shared@example.com"
+			errs[i] = repos.User.Update(ctx, user)
+		}(i, user)
+	}
+	close(start)
+	wg.Wait()
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else {
+			assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+		}
+	}
+	assert.Equal(t, 1, succeeded, "exactly one user should get the email")
+
+	owner, err := repos.User.GetByEmail(ctx, "shared@example.com")
+	require.NoError(t, err)
+	assert.Contains(t, []string{claimants[0].ID, claimants[1].ID}, owner.ID)
+}
+
+func testUserList(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	first := createUser(t, repos, "a@example.com")
+	second := createUser(t, repos, "b@example.com")
+	third := createUser(t, repos, "c@example.com")
+
+	users, total, err := repos.User.List(ctx, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, users, 2)
+	assert.Equal(t, first.ID, users[0].ID)
+	assert.Equal(t, second.ID, users[1].ID)
+
+	users, total, err = repos.User.List(ctx, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, users, 1)
+	assert.Equal(t, third.ID, users[0].ID)
+}
+
+func testTokenSaveAndGet(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+	expiresAt := time.Now().Add(time.Hour)
+
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, user.ID, "token-1", expiresAt))
+	userID, err := repos.Token.GetRefreshToken(ctx, "token-1")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userID)
+
+	// Saving a new token revokes the user's previous ones
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, user.ID, "token-2", expiresAt))
+	_, err = repos.Token.GetRefreshToken(ctx, "token-1")
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+	_, err = repos.Token.GetRefreshToken(ctx, "token-2")
+	assert.NoError(t, err)
+
+	// A stored token can't be saved again, and the failed save leaves it usable
+	err = repos.Token.SaveRefreshToken(ctx, user.ID, "token-2", expiresAt)
+	assert.ErrorIs(t, err, domain.ErrTokenAlreadyExists)
+	_, err = repos.Token.GetRefreshToken(ctx, "token-2")
+	assert.NoError(t, err)
+
+	_, err = repos.Token.GetRefreshToken(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+}
+
+func testTokenUnknownUser(t *testing.T, repos Repositories) {
+	err := repos.Token.SaveRefreshToken(context.Background(), "999", "token-1", time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func testTokenRevoke(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, user.ID, "token-1", time.Now().Add(time.Hour)))
+
+	require.NoError(t, repos.Token.RevokeToken(ctx, "token-1"))
+	_, err := repos.Token.GetRefreshToken(ctx, "token-1")
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+
+	assert.ErrorIs(t, repos.Token.RevokeToken(ctx, "unknown"), domain.ErrTokenNotFound)
+
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, user.ID, "token-2", time.Now().Add(time.Hour)))
+	require.NoError(t, repos.Token.RevokeAllUserTokens(ctx, user.ID))
+	_, err = repos.Token.GetRefreshToken(ctx, "token-2")
+	assert.ErrorIs(t, err, domain.ErrTokenRevoked)
+}
+
+func testTokenCleanupExpired(t *testing.T, repos Repositories) {
+	ctx := context.Background()
+	user := createUser(t, repos, "ada@example.com")
+	other := createUser(t, repos, "grace@example.com")
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, user.ID, "expired", time.Now().Add(-time.Hour)))
+	require.NoError(t, repos.Token.SaveRefreshToken(ctx, other.ID, "valid", time.Now().Add(time.Hour)))
+
+	_, err := repos.Token.GetRefreshToken(ctx, "expired")
+	assert.ErrorIs(t, err, domain.ErrTokenExpired)
+
+	purged, err := repos.Token.CleanupExpiredTokens(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = repos.Token.GetRefreshToken(ctx, "expired")
+	assert.ErrorIs(t, err, domain.ErrTokenNotFound)
+	_, err = repos.Token.GetRefreshToken(ctx, "valid")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// savingsGoalRepository implements services.SavingsGoalRepository using GORM
+type savingsGoalRepository struct {
+	db *gorm.DB
+}
+
+// NewSavingsGoalRepository creates a new savings goal repository instance
+func NewSavingsGoalRepository(db *gorm.DB) services.SavingsGoalRepository {
+	return &savingsGoalRepository{
+		db: db,
+	}
+}
+
+// SaveGoal creates a new savings goal record
+func (r *savingsGoalRepository) SaveGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	model := models.NewSavingsGoalModelFromDomain(goal)
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to save savings goal: %w", err)
+	}
+
+	return nil
+}
+
+// GetGoalByID retrieves a savings goal by its ID
+func (r *savingsGoalRepository) GetGoalByID(ctx context.Context, id string) (domain.SavingsGoal, error) {
+	var model models.SavingsGoalModel
+
+	result := dbFromContext(ctx, r.db).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return domain.SavingsGoal{}, domain.ErrSavingsGoalNotFound
+		}
+		return domain.SavingsGoal{}, fmt.Errorf("failed to get savings goal by ID: %w", result.Error)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// UpdateGoal updates an existing savings goal record
+func (r *savingsGoalRepository) UpdateGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	model := models.NewSavingsGoalModelFromDomain(goal)
+
+	// Select the editable columns so zero values (e.g. a current amount of 0) are saved
+	result := dbFromContext(ctx, r.db).Model(&models.SavingsGoalModel{}).
+		Where("id = ?", goal.ID).
+		Select("name", "target_amount", "current_amount", "target_date", "priority", "updated_at").
+		Updates(model)
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update savings goal: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrSavingsGoalNotFound
+	}
+
+	return nil
+}
+
+// DeleteGoal soft deletes a savings goal record; its contributions are kept
+func (r *savingsGoalRepository) DeleteGoal(ctx context.Context, id string) error {
+	result := dbFromContext(ctx, r.db).Delete(&models.SavingsGoalModel{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete savings goal: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrSavingsGoalNotFound
+	}
+
+	return nil
+}
+
+// GetUserGoals retrieves all savings goals for a specific user, nearest target date first
+func (r *savingsGoalRepository) GetUserGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	var models []models.SavingsGoalModel
+
+	result := dbFromContext(ctx, r.db).Where("user_id = ?", userID).Order("target_date ASC").Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get user savings goals: %w", result.Error)
+	}
+
+	goals := make([]domain.SavingsGoal, len(models))
+	for i, model := range models {
+		goals[i] = model.ToDomain()
+	}
+
+	return goals, nil
+}
+
+// AddContribution records a contribution and adds its amount to the goal's current amount
+// The increment is done in SQL so concurrent contributions can't overwrite each other
+func (r *savingsGoalRepository) AddContribution(ctx context.Context, contribution domain.GoalContribution) error {
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.SavingsGoalModel{}).
+			Where("id = ?", contribution.GoalID).
+			Updates(map[string]interface{}{
+				"current_amount": gorm.Expr("current_amount + ?", contribution.Amount),
+				"updated_at":     time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to update savings goal amount: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return domain.ErrSavingsGoalNotFound
+		}
+
+		if err := tx.Create(models.NewGoalContributionModelFromDomain(contribution)).Error; err != nil {
+			return fmt.Errorf("failed to save goal contribution: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetUserContributions retrieves a user's contributions made at or after since, oldest first
+func (r *savingsGoalRepository) GetUserContributions(ctx context.Context, userID string, since time.Time) ([]domain.GoalContribution, error) {
+	var models []models.GoalContributionModel
+
+	result := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND contributed_at >= ?", userID, since).
+		Order("contributed_at ASC, id ASC").
+		Find(&models)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get goal contributions: %w", result.Error)
+	}
+
+	contributions := make([]domain.GoalContribution, len(models))
+	for i, model := range models {
+		contributions[i] = model.ToDomain()
+	}
+
+	return contributions, nil
+}
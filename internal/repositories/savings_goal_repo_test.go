@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupSavingsGoalTestDB(t *testing.T) *gorm.DB {
+	// A shared cache lets the concurrency test's connections see the same in-memory database
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.SavingsGoalModel{}, &models.GoalContributionModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestSavingsGoal(id, userID, name string, target, current float64) domain.SavingsGoal {
+	return domain.SavingsGoal{
+		ID:            id,
+		UserID:        userID,
+		Name:          name,
+		TargetAmount:  target,
+		CurrentAmount: current,
+		TargetDate:    time.Date(2050, 6, 1, 0, 0, 0, 0, time.UTC),
+		Priority:      domain.GoalPriorityMedium,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
+func TestSavingsGoalRepository_CRUD(t *testing.T) {
+	db := setupSavingsGoalTestDB(t)
+	repo := NewSavingsGoalRepository(db)
+	ctx := context.Background()
+
+	goal := createTestSavingsGoal("goal-1", "user-1", "House deposit", 50000, 5000)
+	require.NoError(t, repo.SaveGoal(ctx, goal))
+
+	saved, err := repo.GetGoalByID(ctx, "goal-1")
+	require.NoError(t, err)
+	assert.Equal(t, "House deposit", saved.Name)
+	assert.Equal(t, 5000.0, saved.CurrentAmount)
+
+	// Zero values must be written, not skipped
+	saved.Name = "Flat deposit"
+	saved.CurrentAmount = 0
+	saved.Priority = domain.GoalPriorityHigh
+	require.NoError(t, repo.UpdateGoal(ctx, saved))
+
+	updated, err := repo.GetGoalByID(ctx, "goal-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Flat deposit", updated.Name)
+	assert.Equal(t, 0.0, updated.CurrentAmount)
+	assert.Equal(t, domain.GoalPriorityHigh, updated.Priority)
+
+	require.NoError(t, repo.DeleteGoal(ctx, "goal-1"))
+	_, err = repo.GetGoalByID(ctx, "goal-1")
+	assert.ErrorIs(t, err, domain.ErrSavingsGoalNotFound)
+	assert.ErrorIs(t, repo.DeleteGoal(ctx, "goal-1"), domain.ErrSavingsGoalNotFound)
+	assert.ErrorIs(t, repo.UpdateGoal(ctx, saved), domain.ErrSavingsGoalNotFound)
+}
+
+func TestSavingsGoalRepository_GetUserGoals_OrderedByTargetDate(t *testing.T) {
+	db := setupSavingsGoalTestDB(t)
+	repo := NewSavingsGoalRepository(db)
+	ctx := context.Background()
+
+	later := createTestSavingsGoal("goal-later", "user-1", "Car", 10000, 0)
+	sooner := createTestSavingsGoal("goal-sooner", "user-1", "Vacation", 2000, 0)
+	sooner.TargetDate = time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC)
+	other := createTestSavingsGoal("goal-other", "user-2", "Laptop", 1500, 0)
+	for _, goal := range []domain.SavingsGoal{later, sooner, other} {
+		require.NoError(t, repo.SaveGoal(ctx, goal))
+	}
+
+	goals, err := repo.GetUserGoals(ctx, "user-1")
+
+	require.NoError(t, err)
+	require.Len(t, goals, 2)
+	assert.Equal(t, "goal-sooner", goals[0].ID)
+	assert.Equal(t, "goal-later", goals[1].ID)
+}
+
+func TestSavingsGoalRepository_AddContribution(t *testing.T) {
+	db := setupSavingsGoalTestDB(t)
+	repo := NewSavingsGoalRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveGoal(ctx, createTestSavingsGoal("goal-1", "user-1", "Car", 10000, 1000)))
+
+	jan := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.AddContribution(ctx, domain.GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 250.50, ContributedAt: feb}))
+	require.NoError(t, repo.AddContribution(ctx, domain.GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 100, Note: "bonus", ContributedAt: jan}))
+
+	goal, err := repo.GetGoalByID(ctx, "goal-1")
+	require.NoError(t, err)
+	assert.InDelta(t, 1350.50, goal.CurrentAmount, 0.001)
+
+	contributions, err := repo.GetUserContributions(ctx, "user-1", jan)
+	require.NoError(t, err)
+	require.Len(t, contributions, 2)
+	assert.Equal(t, "bonus", contributions[0].Note)
+	assert.NotEmpty(t, contributions[0].ID)
+	assert.Equal(t, 250.50, contributions[1].Amount)
+
+	contributions, err = repo.GetUserContributions(ctx, "user-1", feb)
+	require.NoError(t, err)
+	assert.Len(t, contributions, 1)
+}
+
+func TestSavingsGoalRepository_AddContribution_MissingGoalRecordsNothing(t *testing.T) {
+	db := setupSavingsGoalTestDB(t)
+	repo := NewSavingsGoalRepository(db)
+	ctx := context.Background()
+
+	err := repo.AddContribution(ctx, domain.GoalContribution{GoalID: "goal-missing", UserID: "user-1", Amount: 50, ContributedAt: time.Now()})
+
+	assert.ErrorIs(t, err, domain.ErrSavingsGoalNotFound)
+	var count int64
+	require.NoError(t, db.Model(&models.GoalContributionModel{}).Count(&count).Error)
+	assert.Zero(t, count)
+}
+
+func TestSavingsGoalRepository_AddContribution_ConcurrentIncrements(t *testing.T) {
+	db := setupSavingsGoalTestDB(t)
+	repo := NewSavingsGoalRepository(db)
+	ctx := context.Background()
+
+	require.NoError(t, repo.SaveGoal(ctx, createTestSavingsGoal("goal-1", "user-1", "Car", 10000, 0)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// SQLite may report the table as locked under contention; retry until it goes through
+			for repo.AddContribution(ctx, domain.GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 10, ContributedAt: time.Now()}) != nil {
+				time.Sleep(time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	goal, err := repo.GetGoalByID(ctx, "goal-1")
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, goal.CurrentAmount)
+}
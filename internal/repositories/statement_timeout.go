@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Names of the statement timeout callbacks, and the statement setting they share
+const (
+	statementTimeoutStart  = "repositories:statement_timeout_start"
+	statementTimeoutFinish = "repositories:statement_timeout_finish"
+	statementTimeoutKey    = "repositories:statement_timeout"
+)
+
+// statementTimeoutState is what a statement's timeout needs to clean up after itself
+type statementTimeoutState struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// RegisterStatementTimeout bounds every statement run through db to timeout, on top of any deadline
+// the caller's context already has, so a slow query can't hold a request open indefinitely.
+// A timed out statement fails with an error wrapping context.DeadlineExceeded.
+// A timeout of 0 or less leaves statements unbounded.
+//
+// Register it after migrations, which can legitimately run for longer.
+func RegisterStatementTimeout(db *gorm.DB, timeout time.Duration) error {
+	if timeout <= 0 {
+		return nil
+	}
+
+	start := func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, cancel := context.WithTimeout(parent, timeout)
+		db.Statement.Context = ctx
+		db.InstanceSet(statementTimeoutKey, statementTimeoutState{parent: parent, cancel: cancel})
+	}
+
+	// finish releases the timeout and restores the original context, so a *gorm.DB reused
+	// for a second statement doesn't inherit a finished one's context
+	finish := func(release bool) func(db *gorm.DB) {
+		return func(db *gorm.DB) {
+			value, ok := db.InstanceGet(statementTimeoutKey)
+			if !ok {
+				return
+			}
+			state := value.(statementTimeoutState)
+			if release {
+				state.cancel()
+			}
+			db.Statement.Context = state.parent
+		}
+	}
+
+	callbacks := db.Callback()
+	err := errors.Join(
+		callbacks.Create().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Create().After("*").Register(statementTimeoutFinish, finish(true)),
+		callbacks.Query().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Query().After("*").Register(statementTimeoutFinish, finish(true)),
+		callbacks.Update().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Update().After("*").Register(statementTimeoutFinish, finish(true)),
+		callbacks.Delete().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Delete().After("*").Register(statementTimeoutFinish, finish(true)),
+		callbacks.Raw().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Raw().After("*").Register(statementTimeoutFinish, finish(true)),
+		// Rows from Row, Rows and Scan are read after the callbacks return, so their timeout
+		// can't be released early; it expires on its own
+		callbacks.Row().Before("*").Register(statementTimeoutStart, start),
+		callbacks.Row().After("*").Register(statementTimeoutFinish, finish(false)),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register statement timeout: %w", err)
+	}
+
+	return nil
+}
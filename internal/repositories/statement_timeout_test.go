@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// endlessQuery never finishes on its own
+const endlessQuery = "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x+1 FROM c) SELECT count(*) FROM c"
+
+func setupStatementTimeoutTestDB(t *testing.T, timeout time.Duration) *gorm.DB {
+	db := setupExpenseTestDB(t)
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	require.NoError(t, RegisterStatementTimeout(db, timeout))
+	return db
+}
+
+// assertConnectionsReleased checks nothing is left holding a pooled connection
+func assertConnectionsReleased(t *testing.T, db *gorm.DB) {
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	assert.Zero(t, sqlDB.Stats().InUse)
+}
+
+func TestRegisterStatementTimeout_SlowQuery_FailsFastAndReleasesConnection(t *testing.T) {
+	db := setupStatementTimeoutTestDB(t, 50*time.Millisecond)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func(tx *gorm.DB) error
+	}{
+		{"find", func(tx *gorm.DB) error {
+			var counts []int64
+			return tx.Raw(endlessQuery).Find(&counts).Error
+		}},
+		{"scan", func(tx *gorm.DB) error {
+			var count int64
+			return tx.Raw(endlessQuery).Scan(&count).Error
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			err := tt.run(dbFromContext(ctx, db))
+
+			assert.True(t, errors.Is(err, context.DeadlineExceeded), "got %v", err)
+			assert.Less(t, time.Since(start), time.Second)
+			assertConnectionsReleased(t, db)
+		})
+	}
+
+	// The pool is still usable, including through a repository
+	repo := NewExpenseRepository(db)
+	expense := createTestExpense("user-1", "food", "groceries", 100, "monthly", false, 2)
+	require.NoError(t, repo.SaveExpense(ctx, expense))
+	expenses, err := repo.GetUserExpenses(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, expenses, 1)
+	assertConnectionsReleased(t, db)
+}
+
+func TestRegisterStatementTimeout_ReusedChain_GetsAFreshTimeout(t *testing.T) {
+	db := setupStatementTimeoutTestDB(t, 200*time.Millisecond)
+	tx := dbFromContext(context.Background(), db)
+
+	for i := 0; i < 3; i++ {
+		var count int64
+		require.NoError(t, tx.Table("expenses").Count(&count).Error)
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestRegisterStatementTimeout_CanceledContext_FailsWithoutRunning(t *testing.T) {
+	db := setupStatementTimeoutTestDB(t, time.Second)
+	repo := NewExpenseRepository(db)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.GetUserExpenses(ctx, "user-1")
+
+	assert.True(t, errors.Is(err, context.Canceled), "got %v", err)
+	assertConnectionsReleased(t, db)
+}
+
+func TestRegisterStatementTimeout_ZeroTimeout_LeavesStatementsUnbounded(t *testing.T) {
+	db := setupExpenseTestDB(t)
+	require.NoError(t, RegisterStatementTimeout(db, 0))
+
+	assert.Nil(t, db.Callback().Query().Get(statementTimeoutStart))
+	_, err := NewExpenseRepository(db).GetUserExpenses(context.Background(), "user-1")
+	assert.NoError(t, err)
+}
@@ -36,42 +36,36 @@ func (r *tokenRepository) SaveRefreshToken(ctx context.Context, userID, token st
 
 	// Verify user exists
 	var userModel models.UserModel
-	if err := r.db.WithContext(ctx).Where("id = ?", userID).First(&userModel).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", userID).First(&userModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
 		}
 		return fmt.Errorf("failed to verify user existence: %w", err)
 	}
 
-	// Start transaction to ensure atomicity
-	tx := r.db.WithContext(ctx).Begin()
-	if tx.Error != nil {
-		return fmt.Errorf("failed to start transaction: %w", tx.Error)
-	}
-	defer tx.Rollback()
-
-	// Revoke all existing tokens for this user
-	if err := tx.Model(&models.RefreshTokenModel{}).
-		Where("user_id = ? AND is_revoked = false", userID).
-		Updates(map[string]interface{}{
-			"is_revoked": true,
-			"revoked_at": time.Now(),
-		}).Error; err != nil {
-		return fmt.Errorf("failed to revoke existing tokens: %w", err)
-	}
-
-	// Create new token
-	tokenModel := models.RefreshTokenFromDomain(userID, token, expiresAt)
-	if err := tx.Create(&tokenModel).Error; err != nil {
-		return fmt.Errorf("failed to create refresh token: %w", err)
-	}
+	// Revoke and replace in one transaction; nested in the caller's transaction when there is one
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Revoke all existing tokens for this user
+		if err := tx.Model(&models.RefreshTokenModel{}).
+			Where("user_id = ? AND is_revoked = false", userID).
+			Updates(map[string]interface{}{
+				"is_revoked": true,
+				"revoked_at": time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("failed to revoke existing tokens: %w", err)
+		}
 
-	// Commit transaction
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
+		// Create new token
+		tokenModel := models.RefreshTokenFromDomain(userID, token, expiresAt)
+		if err := tx.Create(&tokenModel).Error; err != nil {
+			if isDuplicateKeyError(err) {
+				return fmt.Errorf("failed to create refresh token: %w", domain.ErrTokenAlreadyExists)
+			}
+			return fmt.Errorf("failed to create refresh token: %w", err)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetRefreshToken retrieves a refresh token by the token string
@@ -84,7 +78,7 @@ func (r *tokenRepository) GetRefreshToken(ctx context.Context, token string) (us
 	}
 
 	var tokenModel models.RefreshTokenModel
-	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&tokenModel).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("token_hash = ?", models.HashRefreshToken(token)).First(&tokenModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return "", fmt.Errorf("token not found: %w", domain.ErrTokenNotFound)
 		}
@@ -112,8 +106,8 @@ func (r *tokenRepository) RevokeToken(ctx context.Context, token string) error {
 	}
 
 	now := time.Now()
-	result := r.db.WithContext(ctx).Model(&models.RefreshTokenModel{}).
-		Where("token = ?", token).
+	result := dbFromContext(ctx, r.db).Model(&models.RefreshTokenModel{}).
+		Where("token_hash = ?", models.HashRefreshToken(token)).
 		Updates(map[string]interface{}{
 			"is_revoked": true,
 			"revoked_at": now,
@@ -138,7 +132,7 @@ func (r *tokenRepository) RevokeAllUserTokens(ctx context.Context, userID string
 	}
 
 	now := time.Now()
-	result := r.db.WithContext(ctx).Model(&models.RefreshTokenModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.RefreshTokenModel{}).
 		Where("user_id = ? AND is_revoked = false", userID).
 		Updates(map[string]interface{}{
 			"is_revoked": true,
@@ -155,16 +149,19 @@ func (r *tokenRepository) RevokeAllUserTokens(ctx context.Context, userID string
 	return nil
 }
 
-// CleanupExpiredTokens removes expired tokens from the database
+// CleanupExpiredTokens permanently removes expired tokens from the database
+// and returns how many rows were purged. Expired tokens can never be used again,
+// so they are hard-deleted rather than soft-deleted.
 // This method should be called periodically for maintenance
-func (r *tokenRepository) CleanupExpiredTokens(ctx context.Context) error {
-	result := r.db.WithContext(ctx).
+func (r *tokenRepository) CleanupExpiredTokens(ctx context.Context) (int64, error) {
+	result := dbFromContext(ctx, r.db).
+		Unscoped().
 		Where("expires_at < ?", time.Now()).
 		Delete(&models.RefreshTokenModel{})
 
 	if result.Error != nil {
-		return fmt.Errorf("failed to cleanup expired tokens: %w", result.Error)
+		return 0, fmt.Errorf("failed to cleanup expired tokens: %w", result.Error)
 	}
 
-	return nil
-}
\ No newline at end of file
+	return result.RowsAffected, nil
+}
@@ -54,9 +54,9 @@ func TestTokenRepository_SaveRefreshToken_Success(t *testing.T) {
 
 	// Verify in database
 	var model models.RefreshTokenModel
-	err = db.Where("token = ?", token).First(&model).Error
+	err = db.Where("token_hash = ?", models.HashRefreshToken(token)).First(&model).Error
 	assert.NoError(t, err)
-	assert.Equal(t, token, model.Token)
+	assert.NotContains(t, model.TokenHash, token, "only the hash of the token is stored")
 	assert.Equal(t, user.ID, model.ToUserID())
 	assert.Equal(t, expiresAt.Unix(), model.ExpiresAt.Unix())
 	assert.False(t, model.IsRevoked)
@@ -91,9 +91,9 @@ func TestTokenRepository_SaveRefreshToken_ReplaceExisting_Success(t *testing.T)
 
 	// Verify the new token is in database
 	var model models.RefreshTokenModel
-	err = db.Where("token = ?", newToken).First(&model).Error
+	err = db.Where("token_hash = ?", models.HashRefreshToken(newToken)).First(&model).Error
 	assert.NoError(t, err)
-	assert.Equal(t, newToken, model.Token)
+	assert.Equal(t, user.ID, model.ToUserID())
 }
 
 func TestTokenRepository_SaveRefreshToken_InvalidUserID_ReturnsError(t *testing.T) {
@@ -224,7 +224,7 @@ func TestTokenRepository_RevokeToken_Success(t *testing.T) {
 
 	// Verify token is revoked in database
 	var model models.RefreshTokenModel
-	err = db.Where("token = ?", token).First(&model).Error
+	err = db.Where("token_hash = ?", models.HashRefreshToken(token)).First(&model).Error
 	require.NoError(t, err)
 	assert.True(t, model.IsRevoked)
 	assert.NotNil(t, model.RevokedAt)
@@ -331,15 +331,21 @@ func TestTokenRepository_CleanupExpiredTokens_Success(t *testing.T) {
 	assert.Equal(t, int64(4), initialCount) // 2 expired (revoked) + 2 valid
 
 	// Act
-	err = repo.CleanupExpiredTokens(ctx)
+	purged, err := repo.CleanupExpiredTokens(ctx)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, int64(2), purged)
 
 	// Verify only non-expired tokens remain
 	var remainingCount int64
 	db.Model(&models.RefreshTokenModel{}).Count(&remainingCount)
 	assert.Equal(t, int64(2), remainingCount) // Should only have the 2 valid tokens
+
+	// Expired tokens are purged, not soft-deleted
+	var totalCount int64
+	db.Unscoped().Model(&models.RefreshTokenModel{}).Count(&totalCount)
+	assert.Equal(t, int64(2), totalCount)
 }
 
 func TestTokenRepository_CleanupExpiredTokens_NoExpiredTokens_Success(t *testing.T) {
@@ -357,10 +363,11 @@ func TestTokenRepository_CleanupExpiredTokens_NoExpiredTokens_Success(t *testing
 	require.NoError(t, err)
 
 	// Act
-	err = repo.CleanupExpiredTokens(ctx)
+	purged, err := repo.CleanupExpiredTokens(ctx)
 
 	// Assert
 	assert.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
 
 	// Verify token is still there
 	var count int64
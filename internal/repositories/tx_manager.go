@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// txContextKey is the context key under which the active transaction is stored
+type txContextKey struct{}
+
+// txManager implements services.TxManager
+type txManager struct {
+	db *gorm.DB
+}
+
+// NewTxManager creates a transaction manager whose transactions are used by every repository
+func NewTxManager(db *gorm.DB) services.TxManager {
+	return &txManager{db: db}
+}
+
+// WithTx runs fn in a database transaction carried by the context passed to fn.
+// The transaction is rolled back if fn returns an error or panics.
+// Calls made inside an existing transaction join it instead of starting a new one.
+func (m *txManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// dbFromContext returns the transaction started by WithTx if ctx carries one, otherwise db
+func dbFromContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txContextKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
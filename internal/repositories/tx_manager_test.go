@@ -0,0 +1,186 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+var errInjected = errors.New("injected failure")
+
+// setupTxTestDB uses a single connection so a repository that ignores the transaction
+// in its context would block instead of silently writing outside it
+func setupTxTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
+	require.NoError(t, db.AutoMigrate(
+		&models.UserModel{},
+		&models.RefreshTokenModel{},
+		&models.HealthProfileModel{},
+		&models.MedicalConditionModel{},
+		&models.MedicalExpenseModel{},
+		&models.MedicalExpenseOccurrenceModel{},
+		&models.InsurancePolicyModel{},
+	))
+
+	return db
+}
+
+// failingTokenRepository fails SaveRefreshToken after the wrapped repository has done its other work
+type failingTokenRepository struct {
+	services.TokenRepository
+}
+
+func (r *failingTokenRepository) SaveRefreshToken(ctx context.Context, userID, token string, expiresAt time.Time) error {
+	return errInjected
+}
+
+// authFlows is the part of the auth service whose writes must be atomic
+type authFlows interface {
+	Register(ctx context.Context, user *domain.User, password string) (*domain.TokenPair, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*domain.TokenPair, error)
+}
+
+func newTxTestAuthService(t *testing.T, db *gorm.DB, tokenRepo services.TokenRepository) authFlows {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "test-secret-for-transaction-tests")
+
+	jwtService, err := services.NewJWTService()
+	require.NoError(t, err)
+
+	return services.NewAuthService(
+		NewUserRepository(db),
+		tokenRepo,
+		services.NewPasswordService(),
+		jwtService,
+		NewTxManager(db),
+	)
+}
+
+func TestTxManager_WithTx_RollsBackEarlierWritesOnError(t *testing.T) {
+	db := setupTxTestDB(t)
+	txManager := NewTxManager(db)
+	userRepo := NewUserRepository(db)
+	ctx := context.Background()
+
+	err := txManager.WithTx(ctx, func(ctx context.Context) error {
+		require.NoError(t, userRepo.Create(ctx, createTestUser()))
+		return errInjected
+	})
+
+	assert.ErrorIs(t, err, errInjected)
+	_, err = userRepo.GetByEmail(ctx, "test@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestTxManager_WithTx_NestedCallsJoinOuterTransaction(t *testing.T) {
+	db := setupTxTestDB(t)
+	txManager := NewTxManager(db)
+	userRepo := NewUserRepository(db)
+	ctx := context.Background()
+
+	err := txManager.WithTx(ctx, func(ctx context.Context) error {
+		innerErr := txManager.WithTx(ctx, func(ctx context.Context) error {
+			return userRepo.Create(ctx, createTestUser())
+		})
+		require.NoError(t, innerErr)
+		return errInjected
+	})
+
+	// The inner call committed nothing on its own
+	assert.ErrorIs(t, err, errInjected)
+	_, err = userRepo.GetByEmail(ctx, "test@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestAuthService_Register_RollsBackUserWhenTokenSaveFails(t *testing.T) {
+	setupTestLogger()
+	db := setupTxTestDB(t)
+	authService := newTxTestAuthService(t, db, &failingTokenRepository{TokenRepository: NewTokenRepository(db)})
+	ctx := context.Background()
+
+	user := &domain.User{Email: "new@example.com", Name: "New User"}
+	_, err := authService.Register(ctx, user, "Password123!")
+
+	assert.ErrorIs(t, err, errInjected)
+	_, err = NewUserRepository(db).GetByEmail(ctx, "new@example.com")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound, "the user must not exist without a refresh token")
+}
+
+func TestAuthService_RefreshToken_KeepsOldTokenWhenReplacementFails(t *testing.T) {
+	setupTestLogger()
+	db := setupTxTestDB(t)
+	tokenRepo := NewTokenRepository(db)
+	ctx := context.Background()
+
+	tokens, err := newTxTestAuthService(t, db, tokenRepo).Register(ctx, &domain.User{Email: "rotate@example.com", Name: "Rotate"}, "Password123!")
+	require.NoError(t, err)
+
+	failingService := newTxTestAuthService(t, db, &failingTokenRepository{TokenRepository: tokenRepo})
+	_, err = failingService.RefreshToken(ctx, tokens.RefreshToken)
+	assert.ErrorIs(t, err, errInjected)
+
+	// The revocation was rolled back, so the user is still logged in
+	userID, err := tokenRepo.GetRefreshToken(ctx, tokens.RefreshToken)
+	require.NoError(t, err)
+	assert.NotEmpty(t, userID)
+}
+
+func TestHealthProfileRepository_Delete_RollsBackWhenCascadeFails(t *testing.T) {
+	db := setupTxTestDB(t)
+	repo := NewHealthProfileRepository(db)
+	ctx := context.Background()
+
+	profile, err := repo.Create(ctx, &domain.HealthProfile{
+		UserID: "test-user-123", Age: 30, Gender: "male", Height: 180.0, Weight: 75.0, FamilySize: 1,
+	})
+	require.NoError(t, err)
+	profileID, err := strconv.ParseUint(profile.ID, 10, 32)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Create(&models.MedicalConditionModel{
+		UserID: "test-user-123", ProfileID: uint(profileID), Name: "Asthma", Category: "chronic",
+		Severity: "mild", DiagnosedDate: time.Now(), IsActive: true,
+	}).Error)
+	require.NoError(t, db.Create(&models.MedicalExpenseModel{
+		UserID: "test-user-123", ProfileID: uint(profileID), Amount: 100.0, Category: "medication",
+		Description: "Inhaler", Date: time.Now(),
+	}).Error)
+
+	// Fail the third step of the cascade, after conditions and expenses have been deleted
+	require.NoError(t, db.Callback().Delete().Before("gorm:delete").Register("test:fail_policy_delete", func(tx *gorm.DB) {
+		if tx.Statement.Table == "insurance_policies" {
+			_ = tx.AddError(errInjected)
+		}
+	}))
+
+	err = repo.Delete(ctx, uint(profileID))
+	assert.ErrorIs(t, err, errInjected)
+
+	var conditionCount, expenseCount int64
+	require.NoError(t, db.Model(&models.MedicalConditionModel{}).Where("profile_id = ?", profileID).Count(&conditionCount).Error)
+	require.NoError(t, db.Model(&models.MedicalExpenseModel{}).Where("profile_id = ?", profileID).Count(&expenseCount).Error)
+	assert.Equal(t, int64(1), conditionCount)
+	assert.Equal(t, int64(1), expenseCount)
+
+	_, err = repo.GetByID(ctx, uint(profileID))
+	assert.NoError(t, err, "the profile must survive a failed cascade")
+}
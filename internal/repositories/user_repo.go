@@ -56,7 +56,7 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	}
 
 	// Create user in database
-	if err := r.db.WithContext(ctx).Create(&userModel).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Create(&userModel).Error; err != nil {
 		// Check for unique constraint violation (duplicate email)
 		if isDuplicateKeyError(err) {
 			if logger != nil {
@@ -85,7 +85,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 	}
 
 	var userModel models.UserModel
-	if err := r.db.WithContext(ctx).Where("email = ?", email).First(&userModel).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("email = ?", email).First(&userModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user with email %s not found: %w", email, domain.ErrUserNotFound)
 		}
@@ -104,7 +104,7 @@ func (r *userRepository) GetByID(ctx context.Context, userID string) (*domain.Us
 	}
 
 	var userModel models.UserModel
-	if err := r.db.WithContext(ctx).Where("id = ?", userID).First(&userModel).Error; err != nil {
+	if err := dbFromContext(ctx, r.db).Where("id = ?", userID).First(&userModel).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
 		}
@@ -146,7 +146,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 		"is_active":     userModel.IsActive,
 		"updated_at":    userModel.UpdatedAt,
 	}
-	result := r.db.WithContext(ctx).Model(&userModel).Where("id = ?", user.ID).Updates(updates)
+	result := dbFromContext(ctx, r.db).Model(&userModel).Where("id = ?", user.ID).Updates(updates)
 	if result.Error != nil {
 		// Check for unique constraint violation (duplicate email)
 		if isDuplicateKeyError(result.Error) {
@@ -172,7 +172,7 @@ func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string, log
 		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
 	}
 
-	result := r.db.WithContext(ctx).Model(&models.UserModel{}).
+	result := dbFromContext(ctx, r.db).Model(&models.UserModel{}).
 		Where("id = ?", userID).
 		Updates(map[string]interface{}{
 			"last_login_at": loginTime,
@@ -190,6 +190,126 @@ func (r *userRepository) UpdateLastLogin(ctx context.Context, userID string, log
 	return nil
 }
 
+// UpdateRole changes a user's role
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+	if !domain.IsValidRole(role) {
+		return fmt.Errorf("invalid role %q: %w", role, domain.ErrInvalidUserData)
+	}
+
+	result := dbFromContext(ctx, r.db).Model(&models.UserModel{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"role":       role,
+			"updated_at": time.Now(),
+		})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update user role: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+	}
+
+	return nil
+}
+
+// List returns a page of users ordered by ID along with the total number of users
+func (r *userRepository) List(ctx context.Context, offset, limit int) ([]domain.User, int64, error) {
+	var total int64
+	if err := dbFromContext(ctx, r.db).Model(&models.UserModel{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	var userModels []models.UserModel
+	if err := dbFromContext(ctx, r.db).
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&userModels).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]domain.User, len(userModels))
+	for i, model := range userModels {
+		users[i] = model.ToDomain()
+	}
+
+	return users, total, nil
+}
+
+// Delete permanently removes a user and every record they own, in one transaction. Records that
+// were soft deleted, such as those under a deleted health profile, are purged too; records are
+// removed before the ones they reference so foreign keys hold. The audit log is kept.
+// Returns domain.ErrUserNotFound if the user doesn't exist
+func (r *userRepository) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("userID cannot be empty: %w", domain.ErrInvalidUserData)
+	}
+
+	return dbFromContext(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		var model models.UserModel
+		if err := tx.Where("id = ?", userID).First(&model).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("user with ID %s not found: %w", userID, domain.ErrUserNotFound)
+			}
+			return fmt.Errorf("failed to get user by ID: %w", err)
+		}
+
+		var webhookIDs []string
+		if err := tx.Model(&models.WebhookModel{}).Where("user_id = ?", userID).Pluck("id", &webhookIDs).Error; err != nil {
+			return fmt.Errorf("failed to get user webhooks: %w", err)
+		}
+		if len(webhookIDs) > 0 {
+			if err := tx.Unscoped().Where("webhook_id IN ?", webhookIDs).Delete(&models.WebhookDeliveryModel{}).Error; err != nil {
+				return fmt.Errorf("failed to delete user webhook deliveries: %w", err)
+			}
+		}
+
+		owned := []struct {
+			name  string
+			model interface{}
+		}{
+			{"refresh tokens", &models.RefreshTokenModel{}},
+			{"API keys", &models.APIKeyModel{}},
+			{"webhooks", &models.WebhookModel{}},
+			{"idempotency keys", &models.IdempotencyKeyModel{}},
+			{"demo data records", &models.DemoDataRecordModel{}},
+			{"incomes", &models.IncomeModel{}},
+			{"expenses", &models.ExpenseModel{}},
+			{"loan balance entries", &models.LoanBalanceEntryModel{}},
+			{"loans", &models.LoanModel{}},
+			{"goal contributions", &models.GoalContributionModel{}},
+			{"savings goals", &models.SavingsGoalModel{}},
+			{"budgets", &models.BudgetModel{}},
+			{"finance summary", &models.FinanceSummaryModel{}},
+			{"expense attachments", &models.ExpenseAttachmentModel{}},
+			{"medication schedules", &models.MedicationScheduleModel{}},
+			{"medical expense occurrences", &models.MedicalExpenseOccurrenceModel{}},
+			{"medical conditions", &models.MedicalConditionModel{}},
+			{"medical expenses", &models.MedicalExpenseModel{}},
+			{"insurance policies", &models.InsurancePolicyModel{}},
+			{"profile snapshots", &models.ProfileSnapshotModel{}},
+			{"health risk snapshots", &models.RiskSnapshotModel{}},
+			{"health profiles", &models.HealthProfileModel{}},
+		}
+		for _, records := range owned {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(records.model).Error; err != nil {
+				return fmt.Errorf("failed to delete user %s: %w", records.name, err)
+			}
+		}
+
+		if err := tx.Unscoped().Delete(&model).Error; err != nil {
+			return fmt.Errorf("failed to delete user: %w", err)
+		}
+		return nil
+	})
+}
+
 // isDuplicateKeyError checks if the error is a duplicate key constraint violation
 // This helper function checks for common database-specific error patterns
 func isDuplicateKeyError(err error) bool {
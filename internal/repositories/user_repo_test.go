@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -400,4 +401,68 @@ func TestUserRepository_Update_DuplicateEmail_ReturnsError(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
-}
\ No newline at end of file
+}
+func TestUserRepository_Create_DefaultsToUserRole(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := createTestUser()
+
+	// Act
+	require.NoError(t, repo.Create(ctx, user))
+	stored, err := repo.GetByID(ctx, user.ID)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, stored.Role)
+}
+
+func TestUserRepository_UpdateRole_PromotesUser(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	user := createTestUser()
+	require.NoError(t, repo.Create(ctx, user))
+
+	// Act
+	err := repo.UpdateRole(ctx, user.ID, domain.RoleAdmin)
+
+	// Assert
+	require.NoError(t, err)
+	stored, err := repo.GetByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleAdmin, stored.Role)
+
+	assert.ErrorIs(t, repo.UpdateRole(ctx, user.ID, "superuser"), domain.ErrInvalidUserData)
+	assert.ErrorIs(t, repo.UpdateRole(ctx, "999", domain.RoleAdmin), domain.ErrUserNotFound)
+}
+
+func TestUserRepository_List_PagesByID(t *testing.T) {
+	// Arrange
+	db := setupTestDB(t)
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		user := createTestUser()
+		user.Email = fmt.Sprintf("user%d@example.com", i)
+		require.NoError(t, repo.Create(ctx, user))
+	}
+
+	// Act
+	firstPage, total, err := repo.List(ctx, 0, 2)
+	require.NoError(t, err)
+	secondPage, _, err := repo.List(ctx, 2, 2)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(3), total)
+	require.Len(t, firstPage, 2)
+	require.Len(t, secondPage, 1)
+	assert.Equal(t, "user0@example.com", firstPage[0].Email)
+	assert.Equal(t, "user2@example.com", secondPage[0].Email)
+}
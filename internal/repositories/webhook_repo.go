@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"gorm.io/gorm"
+)
+
+// webhookRepository implements services.WebhookRepository using GORM
+type webhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository instance
+func NewWebhookRepository(db *gorm.DB) services.WebhookRepository {
+	return &webhookRepository{
+		db: db,
+	}
+}
+
+// Create saves a new webhook and copies the generated ID and timestamps back to it
+func (r *webhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	model := models.NewWebhookModelFromDomain(*webhook)
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	webhook.ID = model.ID
+	webhook.CreatedAt = model.CreatedAt
+	webhook.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// GetByID retrieves a webhook by its ID
+func (r *webhookRepository) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	var model models.WebhookModel
+
+	err := dbFromContext(ctx, r.db).First(&model, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Webhook{}, domain.ErrWebhookNotFound
+		}
+		return domain.Webhook{}, fmt.Errorf("failed to get webhook by ID: %w", err)
+	}
+
+	return model.ToDomain(), nil
+}
+
+// Update saves every field of an existing webhook, including zero values
+func (r *webhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	model := models.NewWebhookModelFromDomain(*webhook)
+	model.UpdatedAt = time.Now()
+
+	result := dbFromContext(ctx, r.db).Model(&models.WebhookModel{}).
+		Where("id = ?", webhook.ID).
+		Select("url", "secret", "event_types", "is_active", "updated_at").
+		Updates(model)
+	if result.Error != nil {
+		return fmt.Errorf("failed to update webhook: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	webhook.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Delete soft deletes a webhook; its delivery log is kept
+func (r *webhookRepository) Delete(ctx context.Context, id string) error {
+	result := dbFromContext(ctx, r.db).Delete(&models.WebhookModel{}, "id = ?", id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// GetUserWebhooks retrieves all webhooks for a user, oldest first
+func (r *webhookRepository) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	var webhookModels []models.WebhookModel
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at ASC").
+		Find(&webhookModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user webhooks: %w", err)
+	}
+
+	webhooks := make([]domain.Webhook, len(webhookModels))
+	for i, model := range webhookModels {
+		webhooks[i] = model.ToDomain()
+	}
+
+	return webhooks, nil
+}
+
+// GetSubscribers retrieves the user's active webhooks that subscribe to eventType
+// Users have few webhooks, so the event types are matched after loading them
+func (r *webhookRepository) GetSubscribers(ctx context.Context, userID, eventType string) ([]domain.Webhook, error) {
+	var webhookModels []models.WebhookModel
+
+	err := dbFromContext(ctx, r.db).
+		Where("user_id = ? AND is_active = ?", userID, true).
+		Order("created_at ASC").
+		Find(&webhookModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscribers: %w", err)
+	}
+
+	var webhooks []domain.Webhook
+	for _, model := range webhookModels {
+		webhook := model.ToDomain()
+		if webhook.Subscribes(eventType) {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+
+	return webhooks, nil
+}
+
+// RecordDelivery appends a delivery attempt to the log
+func (r *webhookRepository) RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	model := &models.WebhookDeliveryModel{}
+	model.FromDomain(delivery)
+
+	if err := dbFromContext(ctx, r.db).Create(model).Error; err != nil {
+		return fmt.Errorf("failed to record webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveries retrieves the most recent delivery attempts for a webhook, newest first
+func (r *webhookRepository) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveryModels []models.WebhookDeliveryModel
+
+	err := dbFromContext(ctx, r.db).
+		Where("webhook_id = ?", webhookID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&deliveryModels).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]domain.WebhookDelivery, len(deliveryModels))
+	for i, model := range deliveryModels {
+		deliveries[i] = model.ToDomain()
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupWebhookTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	err = db.AutoMigrate(&models.WebhookModel{}, &models.WebhookDeliveryModel{})
+	require.NoError(t, err)
+
+	return db
+}
+
+func createTestWebhook(userID string, isActive bool, eventTypes ...string) *domain.Webhook {
+	return &domain.Webhook{
+		UserID:     userID,
+		URL:        "https://hooks.example.com/" + userID,
+		Secret:     "0123456789abcdef",
+		EventTypes: eventTypes,
+		IsActive:   isActive,
+	}
+}
+
+func TestWebhookRepository_CreateAndGetByID(t *testing.T) {
+	repo := NewWebhookRepository(setupWebhookTestDB(t))
+	ctx := context.Background()
+
+	webhook := createTestWebhook("user-1", false, domain.EventDeductibleMet, domain.EventHighRiskDetected)
+	require.NoError(t, repo.Create(ctx, webhook))
+	assert.NotEmpty(t, webhook.ID)
+	assert.False(t, webhook.CreatedAt.IsZero())
+
+	found, err := repo.GetByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", found.UserID)
+	assert.Equal(t, webhook.URL, found.URL)
+	assert.Equal(t, webhook.Secret, found.Secret)
+	assert.Equal(t, []string{domain.EventDeductibleMet, domain.EventHighRiskDetected}, found.EventTypes)
+	// Inactive webhooks must not pick up the active default
+	assert.False(t, found.IsActive)
+
+	_, err = repo.GetByID(ctx, "wh-missing")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookRepository_UpdateAndDelete(t *testing.T) {
+	repo := NewWebhookRepository(setupWebhookTestDB(t))
+	ctx := context.Background()
+
+	webhook := createTestWebhook("user-1", true, domain.EventDeductibleMet)
+	require.NoError(t, repo.Create(ctx, webhook))
+
+	webhook.URL = "https://hooks.example.com/updated"
+	webhook.EventTypes = []string{domain.EventFinancialHealthChanged}
+	webhook.IsActive = false
+	require.NoError(t, repo.Update(ctx, webhook))
+
+	found, err := repo.GetByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.example.com/updated", found.URL)
+	assert.Equal(t, []string{domain.EventFinancialHealthChanged}, found.EventTypes)
+	assert.False(t, found.IsActive)
+
+	require.NoError(t, repo.Delete(ctx, webhook.ID))
+	_, err = repo.GetByID(ctx, webhook.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+	assert.ErrorIs(t, repo.Delete(ctx, webhook.ID), domain.ErrWebhookNotFound)
+	assert.ErrorIs(t, repo.Update(ctx, webhook), domain.ErrWebhookNotFound)
+}
+
+func TestWebhookRepository_GetSubscribers(t *testing.T) {
+	repo := NewWebhookRepository(setupWebhookTestDB(t))
+	ctx := context.Background()
+
+	subscribed := createTestWebhook("user-1", true, domain.EventFinancialHealthChanged, domain.EventDeductibleMet)
+	otherEvent := createTestWebhook("user-1", true, domain.EventHighRiskDetected)
+	inactive := createTestWebhook("user-1", false, domain.EventDeductibleMet)
+	otherUser := createTestWebhook("user-2", true, domain.EventDeductibleMet)
+	for _, webhook := range []*domain.Webhook{subscribed, otherEvent, inactive, otherUser} {
+		require.NoError(t, repo.Create(ctx, webhook))
+	}
+
+	subscribers, err := repo.GetSubscribers(ctx, "user-1", domain.EventDeductibleMet)
+	require.NoError(t, err)
+	require.Len(t, subscribers, 1)
+	assert.Equal(t, subscribed.ID, subscribers[0].ID)
+
+	all, err := repo.GetUserWebhooks(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
+
+func TestWebhookRepository_RecordAndGetDeliveries(t *testing.T) {
+	repo := NewWebhookRepository(setupWebhookTestDB(t))
+	ctx := context.Background()
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		require.NoError(t, repo.RecordDelivery(ctx, domain.WebhookDelivery{
+			WebhookID:  "wh-1",
+			EventID:    "evt-1",
+			EventType:  domain.EventDeductibleMet,
+			Attempt:    attempt,
+			StatusCode: 500,
+			Error:      "unexpected status 500",
+			Duration:   150 * time.Millisecond,
+			CreatedAt:  start.Add(time.Duration(attempt) * time.Second),
+		}))
+	}
+	require.NoError(t, repo.RecordDelivery(ctx, domain.WebhookDelivery{
+		WebhookID: "wh-2", EventID: "evt-1", EventType: domain.EventDeductibleMet, Attempt: 1, Succeeded: true, CreatedAt: start,
+	}))
+
+	deliveries, err := repo.GetDeliveries(ctx, "wh-1", 2)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, 3, deliveries[0].Attempt)
+	assert.Equal(t, 2, deliveries[1].Attempt)
+	assert.Equal(t, 500, deliveries[0].StatusCode)
+	assert.Equal(t, 150*time.Millisecond, deliveries[0].Duration)
+	assert.False(t, deliveries[0].Succeeded)
+}
@@ -0,0 +1,204 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+const accountDeletionPassword = "Sup3r-Secret-Passw0rd!"
+
+// serveJSON sends a JSON request to router, authenticated with accessToken when it isn't empty
+func serveJSON(router *gin.Engine, method, path, accessToken string, body any) *httptest.ResponseRecorder {
+	payload, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// registerAccount registers a user through the API and returns their ID and tokens
+func registerAccount(t *testing.T, router *gin.Engine, db *gorm.DB, email string) (string, dtos.TokenResponseDTO) {
+	t.Helper()
+
+	w := serveJSON(router, http.MethodPost, "/api/v1/auth/register", "", dtos.RegisterRequestDTO{
+		Email: email, Name: "Account Owner", Password: accountDeletionPassword,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var tokens dtos.TokenResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+
+	var user models.UserModel
+	require.NoError(t, db.Where("email = ?", email).First(&user).Error)
+	return user.ToDomain().ID, tokens
+}
+
+// populateAccount gives the user finance and health records, and a receipt attached to their
+// medical expense, through the services
+func populateAccount(t *testing.T, deps *Deps, userID string) {
+	t.Helper()
+
+	ctx := context.Background()
+	now := time.Now()
+	require.NoError(t, deps.FinanceService.AddIncome(ctx, domain.Income{
+		UserID: userID, Source: "Salary", Amount: 5000, Frequency: "monthly", IsActive: true,
+		CreatedAt: now, UpdatedAt: now,
+	}, false))
+	require.NoError(t, deps.FinanceService.AddExpense(ctx, domain.Expense{
+		UserID: userID, Category: "housing", Name: "Rent", Amount: 1500, Frequency: "monthly", IsFixed: true, Priority: 1,
+		CreatedAt: now, UpdatedAt: now,
+	}, false))
+	require.NoError(t, deps.FinanceService.AddLoan(ctx, domain.Loan{
+		UserID: userID, Lender: "Bank", Type: "auto", PrincipalAmount: 20000, RemainingBalance: 15000,
+		MonthlyPayment: 400, InterestRate: 5, EndDate: now.AddDate(3, 0, 0),
+		CreatedAt: now, UpdatedAt: now,
+	}))
+	require.NoError(t, deps.FinanceService.AddSavingsGoal(ctx, domain.SavingsGoal{
+		UserID: userID, Name: "Emergency fund", TargetAmount: 10000, TargetDate: now.AddDate(1, 0, 0), Priority: 1,
+		CreatedAt: now, UpdatedAt: now,
+	}))
+
+	require.NoError(t, deps.HealthService.CreateProfile(ctx, &domain.HealthProfile{
+		UserID: userID, Age: 40, Gender: "female", Height: 165, Weight: 60, FamilySize: 2,
+	}))
+	profile, err := deps.HealthService.GetProfile(ctx, userID)
+	require.NoError(t, err)
+	require.NoError(t, deps.HealthService.AddCondition(ctx, &domain.MedicalCondition{
+		UserID: userID, Name: "Asthma", Category: "chronic", Severity: "mild",
+		DiagnosedDate: now.AddDate(-2, 0, 0), IsActive: true,
+	}))
+	require.NoError(t, deps.HealthService.AddExpense(ctx, &domain.MedicalExpense{
+		UserID: userID, ProfileID: profile.ID, Amount: 120, Category: "doctor_visit", Description: "Checkup",
+		Frequency: "one_time", OutOfPocket: 120, Date: now.AddDate(0, -1, 0),
+	}))
+	expenses, err := deps.HealthService.GetExpenses(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, expenses, 1)
+	_, err = deps.AttachmentService.AddAttachment(ctx, userID, expenses[0].ID, "receipt.pdf", bytes.NewReader([]byte("%PDF-1.7\n")))
+	require.NoError(t, err)
+	require.NoError(t, deps.HealthService.AddInsurancePolicy(ctx, &domain.InsurancePolicy{
+		UserID: userID, ProfileID: profile.ID, Provider: "Acme Health", PolicyNumber: "ACME-" + userID, Type: "health",
+		CoveragePercentage: 80, Deductible: 1000, OutOfPocketMax: 5000, MonthlyPremium: 300,
+		StartDate: now.AddDate(-1, 0, 0), EndDate: now.AddDate(1, 0, 0), IsActive: true,
+	}))
+}
+
+// accountRows counts the rows each table holds for the user, soft-deleted ones included
+func accountRows(t *testing.T, db *gorm.DB, userID string) map[string]int64 {
+	t.Helper()
+
+	owned := map[string]any{
+		"refresh_tokens":        &models.RefreshTokenModel{},
+		"incomes":               &models.IncomeModel{},
+		"expenses":              &models.ExpenseModel{},
+		"loans":                 &models.LoanModel{},
+		"savings_goals":         &models.SavingsGoalModel{},
+		"health_profiles":       &models.HealthProfileModel{},
+		"medical_conditions":    &models.MedicalConditionModel{},
+		"medical_expenses":      &models.MedicalExpenseModel{},
+		"insurance_policies":    &models.InsurancePolicyModel{},
+		"health_risk_snapshots": &models.RiskSnapshotModel{},
+		"expense_attachments":   &models.ExpenseAttachmentModel{},
+	}
+	rows := make(map[string]int64, len(owned)+1)
+	for table, model := range owned {
+		var count int64
+		require.NoError(t, db.Unscoped().Model(model).Where("user_id = ?", userID).Count(&count).Error)
+		rows[table] = count
+	}
+	var users int64
+	require.NoError(t, db.Unscoped().Model(&models.UserModel{}).Where("id = ?", userID).Count(&users).Error)
+	rows["users"] = users
+	return rows
+}
+
+// attachmentFiles returns the paths of the files stored for the user's attachments
+func attachmentFiles(t *testing.T, deps *Deps, db *gorm.DB, userID string) []string {
+	t.Helper()
+
+	var keys []string
+	require.NoError(t, db.Model(&models.ExpenseAttachmentModel{}).Where("user_id = ?", userID).Pluck("storage_key", &keys).Error)
+	files := make([]string, len(keys))
+	for i, key := range keys {
+		files[i] = filepath.Join(deps.Config.Health.Attachments.StoragePath, key)
+	}
+	return files
+}
+
+func TestDeleteAccount_RemovesAllRecordsAndRevokesTokens(t *testing.T) {
+	deps, db := setupTestDepsWithDB(t)
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+
+	userID, tokens := registerAccount(t, router, db, "leaving@example.com")
+	otherID, _ := registerAccount(t, router, db, "staying@example.com")
+	populateAccount(t, deps, userID)
+	populateAccount(t, deps, otherID)
+
+	before := accountRows(t, db, userID)
+	for _, table := range []string{"users", "refresh_tokens", "incomes", "expenses", "loans", "savings_goals",
+		"health_profiles", "medical_conditions", "medical_expenses", "insurance_policies", "expense_attachments"} {
+		assert.NotZero(t, before[table], "%s should be populated before the deletion", table)
+	}
+	files := attachmentFiles(t, deps, db, userID)
+	otherFiles := attachmentFiles(t, deps, db, otherID)
+	for _, file := range append(files, otherFiles...) {
+		require.FileExists(t, file)
+	}
+
+	// The password has to be confirmed
+	w := serveJSON(router, http.MethodDelete, "/api/v1/auth/me", tokens.AccessToken, dtos.DeleteAccountRequestDTO{Password: "wrong-password"})
+	require.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	assert.Equal(t, before, accountRows(t, db, userID))
+
+	w = serveJSON(router, http.MethodDelete, "/api/v1/auth/me", tokens.AccessToken, dtos.DeleteAccountRequestDTO{Password: accountDeletionPassword})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	for table, count := range accountRows(t, db, userID) {
+		assert.Zero(t, count, "%s still has rows for the deleted user", table)
+	}
+	for table, count := range accountRows(t, db, otherID) {
+		assert.Equal(t, before[table], count, "%s lost rows of another user", table)
+	}
+
+	// The attachment files are removed from storage with the account
+	for _, file := range files {
+		assert.NoFileExists(t, file)
+	}
+	for _, file := range otherFiles {
+		assert.FileExists(t, file)
+	}
+
+	// The refresh token no longer gets new tokens and the credentials no longer log in
+	w = serveJSON(router, http.MethodPost, "/api/v1/auth/refresh", "", dtos.RefreshTokenRequestDTO{RefreshToken: tokens.RefreshToken})
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	w = serveJSON(router, http.MethodPost, "/api/v1/auth/login", "", dtos.LoginRequestDTO{
+		Email: "leaving@example.com", Password: accountDeletionPassword,
+	})
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+	// The access token is rejected although it hasn't expired
+	w = serveJSON(router, http.MethodGet, "/api/v1/account/me", tokens.AccessToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+	var errResp dtos.ErrorResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &errResp))
+	assert.Equal(t, dtos.ErrorCodeAuthInvalidToken, errResp.ErrorCode)
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// TestBuildRouter_RequestBodyLimits checks the API-wide body cap can't be dodged with a multipart
+// Content-Type, while the attachment upload route takes bodies up to the attachment size
+func TestBuildRouter_RequestBodyLimits(t *testing.T) {
+	deps, db := setupTestDepsWithDB(t)
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+	_, tokens := registerAccount(t, router, db, "limits@example.com")
+
+	serve := func(path, contentType string, size int) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodPost, path, strings.NewReader(strings.Repeat("a", size)))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	oversized := middleware.DefaultMaxRequestSize + 1
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge,
+		serve("/api/v1/finance/income", "application/json", oversized).Code)
+	assert.Equal(t, http.StatusRequestEntityTooLarge,
+		serve("/api/v1/finance/income", "multipart/form-data; boundary=xyz", oversized).Code)
+
+	// Past the body limit, the upload reaches the ownership check and the unknown expense is a 404
+	assert.Equal(t, http.StatusNotFound,
+		serve("/api/v1/health/expenses/missing/attachments", "multipart/form-data; boundary=xyz", oversized).Code)
+}
@@ -0,0 +1,69 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+func TestBuildRouter_ResolvesClientIPFromTrustedProxiesOnly(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		wantIP         string
+	}{
+		{"no proxies trusted", nil, "203.0.113.7:52100", "198.51.100.1", "203.0.113.7"},
+		{"spoofed header from untrusted peer", []string{"10.0.0.0/8"}, "203.0.113.7:52100", "198.51.100.1", "203.0.113.7"},
+		{"chain through trusted proxies", []string{"10.0.0.0/8"}, "10.0.0.2:41000", "198.51.100.1, 10.0.0.9", "198.51.100.1"},
+		// Only the hops appended by trusted proxies are believed; the client's own entry isn't
+		{"spoofed entry ahead of real client", []string{"10.0.0.0/8"}, "10.0.0.2:41000", "192.0.2.66, 198.51.100.1", "198.51.100.1"},
+		{"single trusted proxy IP", []string{"10.0.0.2"}, "10.0.0.2:41000", "198.51.100.1", "198.51.100.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			deps := setupTestDeps(t)
+			deps.Config.Server.TrustedProxies = tt.trustedProxies
+			router, err := BuildRouter(deps)
+			require.NoError(t, err)
+
+			var resolvedIP, auditIP string
+			router.GET("/client-ip", func(c *gin.Context) {
+				resolvedIP = logging.GetClientIP(c)
+				auditIP = services.RequestInfoFromContext(c.Request.Context()).IPAddress
+				c.Status(http.StatusNoContent)
+			})
+
+			// Act
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, "/client-ip", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			router.ServeHTTP(w, req)
+
+			// Assert
+			require.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, tt.wantIP, resolvedIP)
+			assert.Equal(t, tt.wantIP, auditIP, "the audit log sees the same address")
+		})
+	}
+}
+
+func TestBuildRouter_InvalidTrustedProxy(t *testing.T) {
+	deps := setupTestDeps(t)
+	deps.Config.Server.TrustedProxies = []string{"not-an-ip"}
+
+	_, err := BuildRouter(deps)
+
+	assert.ErrorContains(t, err, "invalid trusted proxies")
+}
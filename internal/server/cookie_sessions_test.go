@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// TestBuildRouter_CookieSessions walks a browser session through the API with cookie sessions on:
+// state-changing requests authenticated by cookie need the CSRF token, bearer-authenticated ones don't
+func TestBuildRouter_CookieSessions(t *testing.T) {
+	deps, _ := setupTestDepsWithDB(t)
+	deps.Config.Auth.Cookies.Enabled = true
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+
+	// Registering carries no session cookie yet, so it needs no CSRF token
+	w := serveJSON(router, http.MethodPost, "/api/v1/auth/register", "", dtos.RegisterRequestDTO{
+		Email: "browser@example.com", Name: "Browser User", Password: accountDeletionPassword,
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	cookies := (&http.Response{Header: w.Header()}).Cookies()
+	require.Len(t, cookies, 3)
+
+	var accessToken, csrfToken string
+	for _, cookie := range cookies {
+		switch cookie.Name {
+		case middleware.AccessTokenCookie:
+			accessToken = cookie.Value
+		case middleware.CSRFCookie:
+			csrfToken = cookie.Value
+		}
+	}
+	require.NotEmpty(t, accessToken)
+	require.NotEmpty(t, csrfToken)
+
+	serve := func(method, path string, headers map[string]string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Content-Type", "application/json")
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Reads are authenticated by the access token cookie alone
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/finance/summary", nil).Code)
+
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/finance/income", nil).Code)
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "/api/v1/finance/income",
+		map[string]string{middleware.CSRFHeader: "forged"}).Code)
+
+	// JSON API clients sending the Authorization header are exempt; the empty income fails validation
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/api/v1/finance/income",
+		map[string]string{"Authorization": "Bearer " + accessToken}).Code)
+
+	// The account routes don't take API keys, so a key header doesn't stand in for the CSRF token
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPut, "/api/v1/account/me",
+		map[string]string{middleware.APIKeyHeader: "bogus"}).Code)
+
+	w = serve(http.MethodPost, "/api/v1/auth/logout", map[string]string{middleware.CSRFHeader: csrfToken})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		assert.Negative(t, cookie.MaxAge, cookie.Name)
+	}
+}
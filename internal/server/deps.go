@@ -0,0 +1,290 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/app"
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/database"
+	"github.com/DuckDHD/BuyOrBye/internal/handlers"
+	"github.com/DuckDHD/BuyOrBye/internal/health"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/reports"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// Deps carries the services and middleware the router is built from, along with the
+// background components that run beside the server
+type Deps struct {
+	Config *config.Config
+	// Readiness holds the checks behind the readiness probe: the database, pending migrations
+	// and the JWT signing key. Background components may register their own.
+	Readiness *health.Registry
+
+	JWTService    services.JWTService
+	APIKeyService handlers.APIKeyService
+	// APIKeyAuthenticator is usually the same service as APIKeyService
+	APIKeyAuthenticator services.APIKeyAuthenticator
+	// TokenUserChecker is usually the same service as AuthService
+	TokenUserChecker    services.TokenUserChecker
+	AuthService         handlers.AuthService
+	FinanceService      handlers.FinanceService
+	HealthService       services.HealthService
+	AttachmentService   handlers.AttachmentService
+	AdminService        handlers.AdminService
+	AccountService      handlers.AccountService
+	WebhookService      handlers.WebhookService
+	OverviewService     handlers.OverviewService
+	PersonalDataService handlers.PersonalDataExportService
+	ReportService       handlers.ReportService
+	// PDFRenderer is nil unless reports.pdf_command is set, and PDF reports are refused
+	PDFRenderer handlers.ReportPDFRenderer
+	// DemoDataService is only routed when the server enables demo data
+	DemoDataService handlers.DemoDataService
+
+	// Background components: Start runs them and RegisterShutdown stops them
+	AuditService      *services.AuditService
+	WebhookDispatcher *services.WebhookDispatcher
+	TokenCleanupJob   *services.TokenCleanupJob
+	// BackgroundRunner is where periodic jobs such as snapshotters register
+	BackgroundRunner *services.BackgroundRunner
+
+	Idempotency     *middleware.IdempotencyMiddleware
+	HealthOwnership *middleware.HealthOwnershipMiddleware
+	Metrics         *middleware.HTTPMetrics
+}
+
+// NewDeps builds the repositories, services and middleware for cfg on dbService's database,
+// which must already be migrated. The configured admin emails are promoted; accounts that
+// don't exist yet are promoted on a later start.
+func NewDeps(cfg *config.Config, dbService config.DatabaseService) (*Deps, error) {
+	if cfg == nil {
+		return nil, fmt.Errorf("configuration cannot be nil")
+	}
+
+	db := dbService.GetDB()
+	if err := repositories.RegisterStatementTimeout(db, cfg.Database.StatementTimeout); err != nil {
+		return nil, fmt.Errorf("failed to configure statement timeout: %w", err)
+	}
+
+	// Initialize core services with config
+	passwordService := services.NewPasswordService()
+	passwordPolicy := services.NewPasswordPolicyFromConfig(cfg.Auth.PasswordPolicy)
+	jwtService, err := services.NewJWTServiceFromConfig(&cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize JWT service: %w", err)
+	}
+
+	// Initialize repositories
+	userRepo := repositories.NewUserRepository(db)
+	tokenRepo := repositories.NewTokenRepository(db)
+	txManager := repositories.NewTxManager(db)
+
+	// Initialize finance repositories
+	incomeRepo := repositories.NewIncomeRepository(db)
+	expenseRepo := repositories.NewExpenseRepository(db)
+	loanRepo := repositories.NewLoanRepository(db)
+	savingsGoalRepo := repositories.NewSavingsGoalRepository(db)
+	financeSummaryRepo := repositories.NewFinanceSummaryRepository()
+
+	// Create finance repositories aggregate
+	financeRepos := services.NewFinanceRepositories(incomeRepo, expenseRepo, loanRepo, savingsGoalRepo, financeSummaryRepo)
+
+	// Initialize health repositories
+	healthProfileRepo := repositories.NewHealthProfileRepository(db)
+	conditionRepo := repositories.NewMedicalConditionRepository(db)
+	medicalExpenseRepo := repositories.NewMedicalExpenseRepository(db)
+	policyRepo := repositories.NewInsurancePolicyRepository(db)
+	medicationRepo := repositories.NewMedicationScheduleRepository(db)
+	riskSnapshotRepo := repositories.NewHealthRiskSnapshotRepository(db)
+	attachmentRepo := repositories.NewExpenseAttachmentRepository(db)
+
+	// Receipts and EOB documents attached to medical expenses, stored on local disk
+	attachmentStorage, err := repositories.NewLocalBlobStorage(cfg.Health.Attachments.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize attachment storage: %w", err)
+	}
+
+	// Initialize health analysis services
+	riskCalculator := services.NewRiskCalculator(cfg.Health.RiskModel.ToDomain())
+	costAnalyzer := services.NewMedicalCostAnalyzer()
+	insuranceEvaluator := services.NewInsuranceEvaluator(
+		services.WithOutOfPocketWarningPercent(cfg.Health.OOPWarningPercent),
+	)
+
+	// Webhook delivery of finance and health events; the services publish to the dispatcher
+	webhookRepo := repositories.NewWebhookRepository(db)
+	webhookDispatcher := services.NewWebhookDispatcher(webhookRepo, services.WebhookDispatcherConfig{
+		Workers:              cfg.Webhooks.Workers,
+		QueueSize:            cfg.Webhooks.QueueSize,
+		MaxAttempts:          cfg.Webhooks.MaxAttempts,
+		InitialBackoff:       cfg.Webhooks.InitialBackoff,
+		MaxBackoff:           cfg.Webhooks.MaxBackoff,
+		RequestTimeout:       cfg.Webhooks.RequestTimeout,
+		AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+	})
+
+	// Audit trail of logins, token revocations and health and finance changes, written in the background
+	auditService := services.NewAuditService(repositories.NewAuditLogRepository(db), services.AuditServiceConfig{
+		QueueSize:    cfg.Audit.QueueSize,
+		BatchSize:    cfg.Audit.BatchSize,
+		WriteTimeout: cfg.Audit.WriteTimeout,
+	})
+
+	// Initialize services
+	authService := services.NewAuthService(userRepo, tokenRepo, passwordService, jwtService, txManager,
+		services.WithAuthAuditRecorder(auditService),
+		services.WithPasswordPolicy(passwordPolicy),
+		services.WithAuthHealthProfileRepository(healthProfileRepo),
+		services.WithAuthAttachmentStorage(attachmentRepo, attachmentStorage))
+	financeService := services.NewFinanceService(financeRepos,
+		services.WithSummaryCacheTTL(cfg.Finance.SummaryCacheTTL),
+		services.WithDuplicateWindow(cfg.Finance.DuplicateWindow),
+		services.WithFinanceEventPublisher(webhookDispatcher),
+		services.WithFinanceAuditRecorder(auditService),
+		services.WithFinanceTxManager(txManager),
+		services.WithBaseCurrency(cfg.Finance.BaseCurrency),
+		services.WithExchangeRateProvider(services.NewStaticExchangeRateProvider(cfg.Finance.BaseCurrency, cfg.Finance.ExchangeRates)),
+		services.WithFinancialThresholds(cfg.Finance.Thresholds()),
+		services.WithBudgetRepository(repositories.NewBudgetRepository(db)))
+	adminService := services.NewAdminService(userRepo, tokenRepo, financeSummaryRepo,
+		services.WithAdminAuditRecorder(auditService))
+	accountService := services.NewAccountService(userRepo, tokenRepo,
+		services.WithAccountAuditRecorder(auditService),
+		services.WithAccountPasswordService(passwordService),
+		services.WithAccountPasswordPolicy(passwordPolicy))
+
+	// Initialize health service
+	healthService := services.NewHealthService(
+		healthProfileRepo,
+		conditionRepo,
+		medicalExpenseRepo,
+		policyRepo,
+		medicationRepo,
+		riskCalculator,
+		costAnalyzer,
+		insuranceEvaluator,
+		services.WithHSALimits(services.HSALimits{
+			SelfOnlyContributionLimit: cfg.Health.HSASelfOnlyContributionLimit,
+			FamilyContributionLimit:   cfg.Health.HSAFamilyContributionLimit,
+			SelfOnlyMinDeductible:     cfg.Health.HDHPSelfOnlyMinDeductible,
+			FamilyMinDeductible:       cfg.Health.HDHPFamilyMinDeductible,
+		}),
+		services.WithHealthEventPublisher(webhookDispatcher),
+		services.WithHealthAuditRecorder(auditService),
+		services.WithRiskSnapshotRepository(riskSnapshotRepo),
+		services.WithExpenseOccurrenceRepository(repositories.NewMedicalExpenseOccurrenceRepository(db)),
+		services.WithHealthTxManager(txManager),
+		services.WithConditionCatalog(repositories.NewConditionCatalogRepository(db)),
+		services.WithHealthSummaryCache(cfg.Health.SummaryCacheEnabled),
+	)
+
+	apiKeyService := services.NewAPIKeyService(repositories.NewAPIKeyRepository(db), userRepo)
+
+	// Monthly statements render to PDF through wkhtmltopdf when it's configured
+	var pdfRenderer handlers.ReportPDFRenderer
+	if cfg.Reports.PDFCommand != "" {
+		renderer, err := reports.NewWKHTMLToPDFRenderer(cfg.Reports.PDFCommand)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize PDF reports: %w", err)
+		}
+		pdfRenderer = renderer
+	}
+
+	if err := adminService.PromoteAdmins(context.Background(), cfg.Auth.AdminEmails); err != nil {
+		logging.GetLogger().Error("Failed to promote configured admins", logging.WithComponent("server"), logging.WithError(err))
+	}
+
+	backgroundRunner := services.NewBackgroundRunner()
+	if interval := cfg.Maintenance.InterestAccrualInterval; interval > 0 {
+		backgroundRunner.Register(services.Periodic(interval, func(ctx context.Context) {
+			accrued, err := financeService.AccrueMonthlyInterest(ctx, time.Now())
+			if err != nil {
+				logging.GetLogger().Error("Scheduled interest accrual failed", logging.WithComponent("server"), logging.WithError(err))
+			}
+			if accrued > 0 {
+				logging.GetLogger().Info("Monthly interest accrued on loans", logging.WithComponent("server"), logging.WithRowsAffected(int64(accrued)))
+			}
+		}))
+	}
+
+	return &Deps{
+		Config:              cfg,
+		Readiness:           newReadinessRegistry(dbService, jwtService),
+		JWTService:          jwtService,
+		APIKeyService:       apiKeyService,
+		APIKeyAuthenticator: apiKeyService,
+		TokenUserChecker:    authService,
+		AuthService:         authService,
+		FinanceService:      financeService,
+		HealthService:       healthService,
+		AttachmentService: services.NewAttachmentService(
+			attachmentRepo,
+			medicalExpenseRepo,
+			attachmentStorage,
+			cfg.Health.Attachments.MaxSize,
+		),
+		AdminService:        adminService,
+		AccountService:      accountService,
+		WebhookService:      services.NewWebhookService(webhookRepo),
+		OverviewService:     services.NewOverviewService(financeService, healthService),
+		PersonalDataService: services.NewPersonalDataExportService(userRepo, financeService, healthService),
+		ReportService:       services.NewReportService(financeService, healthService),
+		PDFRenderer:         pdfRenderer,
+		DemoDataService:     services.NewDemoDataService(financeService, healthService, repositories.NewDemoDataRepository(db)),
+		AuditService:        auditService,
+		WebhookDispatcher:   webhookDispatcher,
+		TokenCleanupJob:     services.NewTokenCleanupJob(tokenRepo, cfg.Maintenance.TokenCleanupInterval),
+		BackgroundRunner:    backgroundRunner,
+		Idempotency: middleware.NewIdempotencyMiddleware(
+			repositories.NewIdempotencyRepository(db),
+			cfg.Server.IdempotencyTTL,
+		),
+		HealthOwnership: middleware.NewHealthOwnershipMiddleware(
+			services.NewHealthResourceOwners(conditionRepo, medicalExpenseRepo, policyRepo),
+		),
+		Metrics: middleware.NewHTTPMetrics(middleware.MetricsConfig{SkipPaths: cfg.Server.MetricsSkipPaths}),
+	}, nil
+}
+
+// newReadinessRegistry registers the checks every instance needs before it takes traffic
+func newReadinessRegistry(dbService config.DatabaseService, jwtService services.JWTService) *health.Registry {
+	readiness := health.NewRegistry()
+	readiness.Register("db", dbService.Ping)
+	readiness.Register("migrations", func(ctx context.Context) error {
+		return database.CheckMigrations(ctx, dbService.GetDB())
+	})
+	readiness.Register("jwt", func(ctx context.Context) error {
+		// Signing and verifying a throwaway token proves the signing key loaded
+		tokens, err := jwtService.GenerateTokenPair("readiness-probe", "readiness-probe@localhost", "")
+		if err != nil {
+			return err
+		}
+		_, err = jwtService.ValidateAccessToken(tokens.AccessToken)
+		return err
+	})
+	return readiness
+}
+
+// Start starts the background maintenance jobs, webhook delivery and the audit log writer
+func (d *Deps) Start() {
+	d.TokenCleanupJob.Start()
+	d.BackgroundRunner.Start()
+	d.WebhookDispatcher.Start()
+	d.AuditService.Start()
+}
+
+// RegisterShutdown registers the background components with lifecycle, each bounded by
+// timeout. Background jobs stop first, since a finishing job may still queue webhooks or
+// audit entries, and the audit log writer last so it writes out what is still queued.
+func (d *Deps) RegisterShutdown(lifecycle *app.Lifecycle, timeout time.Duration) {
+	lifecycle.Register("token cleanup job", timeout, app.StopFunc(d.TokenCleanupJob.Stop))
+	lifecycle.Register("background tasks", timeout, d.BackgroundRunner.Shutdown)
+	lifecycle.Register("idempotency key cleanup", timeout, app.StopFunc(d.Idempotency.Stop))
+	lifecycle.Register("webhook dispatcher", timeout, app.StopFunc(d.WebhookDispatcher.Stop))
+	lifecycle.Register("audit log writer", timeout, app.StopFunc(d.AuditService.Stop))
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+)
+
+// TestFinanceSummary_ETagStableAcrossRecomputation requests the summary twice with the summary
+// cache disabled, so it is recomputed each time: unchanged data must keep the same ETag
+func TestFinanceSummary_ETagStableAcrossRecomputation(t *testing.T) {
+	deps, db := setupTestDepsWithDB(t)
+	require.Zero(t, deps.Config.Finance.SummaryCacheTTL)
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+	_, tokens := registerAccount(t, router, db, "etag@example.com")
+
+	w := serveJSON(router, http.MethodPost, "/api/v1/finance/income", tokens.AccessToken, dtos.AddIncomeDTO{
+		Source: "Salary", Amount: 5000, Frequency: "monthly",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+	getSummary := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, "/api/v1/finance/summary", nil)
+		req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := getSummary("")
+	require.Equal(t, http.StatusOK, first.Code, first.Body.String())
+	tag := first.Header().Get("ETag")
+	require.NotEmpty(t, tag)
+
+	time.Sleep(10 * time.Millisecond)
+	second := getSummary("")
+	require.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, tag, second.Header().Get("ETag"))
+	assert.Equal(t, first.Body.String(), second.Body.String())
+
+	assert.Equal(t, http.StatusNotModified, getSummary(tag).Code)
+
+	// A change to the data changes the tag
+	w = serveJSON(router, http.MethodPost, "/api/v1/finance/income", tokens.AccessToken, dtos.AddIncomeDTO{
+		Source: "Freelance", Amount: 800, Frequency: "monthly",
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	assert.Equal(t, http.StatusOK, getSummary(tag).Code)
+}
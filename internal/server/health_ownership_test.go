@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+	"github.com/DuckDHD/BuyOrBye/internal/repositories"
+	"github.com/DuckDHD/BuyOrBye/internal/services"
+)
+
+// countingOwners counts the ownership lookups the health routes make
+type countingOwners struct {
+	next    services.HealthResourceOwnerLookup
+	lookups int
+}
+
+func (o *countingOwners) GetResourceOwner(ctx context.Context, resource, id string) (string, error) {
+	o.lookups++
+	return o.next.GetResourceOwner(ctx, resource, id)
+}
+
+func TestHealthRoutes_ResourceOwnership(t *testing.T) {
+	deps, db := setupTestDepsWithDB(t)
+	owners := &countingOwners{next: services.NewHealthResourceOwners(
+		repositories.NewMedicalConditionRepository(db),
+		repositories.NewMedicalExpenseRepository(db),
+		repositories.NewInsurancePolicyRepository(db),
+	)}
+	deps.HealthOwnership = middleware.NewHealthOwnershipMiddleware(owners)
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+
+	_, intruder := registerAccount(t, router, db, "intruder@example.com")
+	ownerID, owner := registerAccount(t, router, db, "owner@example.com")
+	populateAccount(t, deps, ownerID)
+
+	ctx := context.Background()
+	conditions, err := deps.HealthService.GetConditions(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, conditions, 1)
+	expenses, err := deps.HealthService.GetExpenses(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, expenses, 1)
+	policies, err := deps.HealthService.GetActivePolicies(ctx, ownerID)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+
+	update := dtos.UpdateMedicalConditionRequestDTO{Name: "Asthma", Category: "chronic", Severity: "severe", IsActive: true}
+	requests := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+	}{
+		{"update condition", http.MethodPut, "/api/v1/health/conditions/" + conditions[0].ID, update},
+		{"remove condition", http.MethodDelete, "/api/v1/health/conditions/" + conditions[0].ID, nil},
+		{"expense occurrences", http.MethodGet, "/api/v1/health/expenses/" + expenses[0].ID + "/occurrences", nil},
+		{"expense attachments", http.MethodGet, "/api/v1/health/expenses/" + expenses[0].ID + "/attachments", nil},
+		{"deductible progress", http.MethodPut, "/api/v1/health/insurance/" + policies[0].ID + "/deductible", map[string]float64{"amount": 100}},
+		{"out-of-pocket status", http.MethodGet, "/api/v1/health/insurance/" + policies[0].ID + "/oop-status", nil},
+	}
+	for _, req := range requests {
+		owners.lookups = 0
+		w := serveJSON(router, req.method, req.path, intruder.AccessToken, req.body)
+		assert.Equal(t, http.StatusNotFound, w.Code, "%s: %s", req.name, w.Body.String())
+		assert.Equal(t, 1, owners.lookups, "%s checks ownership exactly once", req.name)
+	}
+
+	// The owner's own condition is checked once and then updated
+	owners.lookups = 0
+	w := serveJSON(router, http.MethodPut, "/api/v1/health/conditions/"+conditions[0].ID, owner.AccessToken, update)
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, 1, owners.lookups)
+
+	conditions, err = deps.HealthService.GetConditions(ctx, ownerID)
+	require.NoError(t, err)
+	assert.Equal(t, "severe", conditions[0].Severity)
+}
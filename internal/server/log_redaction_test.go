@@ -0,0 +1,51 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// TestBuildRouter_NewAPIKeyIsNotLogged creates an API key with response bodies logged, as they
+// are in development, and checks the key only reaches the client
+func TestBuildRouter_NewAPIKeyIsNotLogged(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logging.SetTestLogger(zap.New(core))
+	t.Cleanup(logging.ResetLogger)
+
+	deps, db := setupTestDepsWithDB(t)
+	deps.Config.Server.Environment = "development"
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+	_, tokens := registerAccount(t, router, db, "keys@example.com")
+
+	w := serveJSON(router, http.MethodPost, "/api/v1/auth/api-keys", tokens.AccessToken, dtos.CreateAPIKeyDTO{
+		Name: "Nightly export", Scopes: []string{"finance:read"},
+	})
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created dtos.APIKeyResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	require.NotEmpty(t, created.Key)
+
+	var output strings.Builder
+	for _, entry := range logs.All() {
+		output.WriteString(entry.Message)
+		for _, value := range entry.ContextMap() {
+			if s, ok := value.(string); ok {
+				output.WriteString(s)
+			}
+		}
+	}
+	assert.Contains(t, output.String(), `"key":"[REDACTED]"`, "the response body is logged")
+	assert.NotContains(t, output.String(), created.Key)
+}
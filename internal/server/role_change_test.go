@@ -0,0 +1,59 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/internal/models"
+)
+
+// TestBuildRouter_DemotedAdminTokenRejected demotes an admin and checks the access token issued
+// while they were an admin stops opening admin routes, while a refreshed token carries the new role
+func TestBuildRouter_DemotedAdminTokenRejected(t *testing.T) {
+	deps, db := setupTestDepsWithDB(t)
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+
+	adminID, _ := registerAccount(t, router, db, "admin@example.com")
+	demotedID, _ := registerAccount(t, router, db, "demoted@example.com")
+	require.NoError(t, db.Model(&models.UserModel{}).Where("id IN ?", []string{adminID, demotedID}).
+		Update("role", domain.RoleAdmin).Error)
+
+	login := func(email string) dtos.TokenResponseDTO {
+		w := serveJSON(router, http.MethodPost, "/api/v1/auth/login", "", dtos.LoginRequestDTO{
+			Email: email, Password: accountDeletionPassword,
+		})
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var tokens dtos.TokenResponseDTO
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+		return tokens
+	}
+	adminTokens := login("admin@example.com")
+	demotedTokens := login("demoted@example.com")
+
+	w := serveJSON(router, http.MethodGet, "/api/v1/admin/users", demotedTokens.AccessToken, nil)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveJSON(router, http.MethodPut, "/api/v1/admin/users/"+demotedID+"/role", adminTokens.AccessToken,
+		dtos.UpdateUserRoleDTO{Role: domain.RoleUser})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	w = serveJSON(router, http.MethodGet, "/api/v1/admin/users", demotedTokens.AccessToken, nil)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serveJSON(router, http.MethodPost, "/api/v1/auth/refresh", "", dtos.RefreshTokenRequestDTO{RefreshToken: demotedTokens.RefreshToken})
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var refreshed dtos.TokenResponseDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &refreshed))
+
+	w = serveJSON(router, http.MethodGet, "/api/v1/admin/users", refreshed.AccessToken, nil)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	w = serveJSON(router, http.MethodGet, "/api/v1/finance/summary", refreshed.AccessToken, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,496 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	// Registers the Swagger 2.0 document served under /swagger
+	_ "github.com/DuckDHD/BuyOrBye/docs"
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	apidocs "github.com/DuckDHD/BuyOrBye/internal/docs"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/handlers"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+	"github.com/DuckDHD/BuyOrBye/internal/middleware"
+)
+
+// BuildRouter registers the global middleware and every route on a new Gin engine.
+// It is the only place routes are registered, so both binaries serve the same API.
+func BuildRouter(deps *Deps) (*gin.Engine, error) {
+	cfg := deps.Config
+	router := gin.Default()
+
+	// X-Forwarded-For is only believed from the configured proxies; the client IP is resolved
+	// once, before any middleware logs or limits by it
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+	router.Use(logging.ClientIPMiddleware())
+
+	// Global middleware with config; CORS comes first so preflights are answered before anything else runs
+	router.Use(middleware.CORS(corsConfig(cfg.Server)))
+
+	// Configure logging middleware based on environment
+	middlewareConfig := config.GetMiddlewareConfig(cfg.Server.Environment)
+	loggingConfig := logging.HTTPLoggingConfig{
+		SkipPaths:       middlewareConfig.SkipPaths,
+		LogRequestBody:  middlewareConfig.LogRequestBody,
+		LogResponseBody: middlewareConfig.LogResponseBody,
+		MaxBodySize:     middlewareConfig.MaxBodySize,
+		RedactedFields:  logging.SensitiveBodyFields,
+	}
+	router.Use(logging.HTTPLoggingMiddleware(loggingConfig))
+	router.Use(logging.ErrorLoggingMiddleware())
+	router.Use(middleware.Compression(middleware.CompressionConfig{
+		Enabled: middlewareConfig.CompressionEnabled,
+		MinSize: middlewareConfig.CompressionMinSize,
+	}))
+	router.Use(logging.RequestIDMiddleware())
+	router.Use(middleware.RequestInfo())
+	// Runs after the request ID is set, so a timed-out request is logged with it
+	router.Use(middleware.Timeout(middlewareConfig.RequestTimeout))
+
+	// Prometheus request metrics, scraped from /metrics
+	router.Use(deps.Metrics.Metrics())
+	router.GET("/metrics", deps.Metrics.Handler())
+
+	// Probes: liveness never touches dependencies, readiness runs the registered checks and
+	// fails while the server drains. /health, /health/live and /health/ready are the older paths.
+	liveness := handlers.LivenessProbe()
+	readiness := handlers.ReadinessProbe(deps.Readiness)
+	router.GET("/healthz", liveness)
+	router.GET("/readyz", readiness)
+	router.GET("/health", readiness)
+	router.GET("/health/live", liveness)
+	router.GET("/health/ready", readiness)
+
+	// API documentation, served from the spec generated into ./docs (make swagger):
+	// the OpenAPI 3 spec and its Swagger UI, plus swag's Swagger 2.0 document under /swagger
+	if cfg.Server.EnableSwagger {
+		apiDocs, err := apidocs.NewHandler("/api/v1/openapi.json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to build OpenAPI spec: %w", err)
+		}
+		router.GET("/api/v1/openapi.json", apiDocs.Spec)
+		router.GET("/docs/*any", apiDocs.UI())
+		router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	}
+
+	registerAPIRoutes(router, deps)
+	return router, nil
+}
+
+// registerAPIRoutes registers the /api/v1 routes
+func registerAPIRoutes(router *gin.Engine, deps *Deps) {
+	var authOpts []handlers.AuthHandlerOption
+	jwtOpts := []middleware.JWTAuthOption{middleware.WithTokenUserCheck(deps.TokenUserChecker)}
+	cookieSessions := deps.Config.Auth.Cookies.Enabled
+	if cookieSessions {
+		authOpts = append(authOpts, handlers.WithSessionCookies(sessionCookies(deps.Config.Auth)))
+		jwtOpts = append(jwtOpts, middleware.WithAccessTokenCookie())
+	}
+
+	authHandler := handlers.NewAuthHandler(deps.AuthService, authOpts...)
+	financeHandler := handlers.NewFinanceHandler(deps.FinanceService)
+	healthHandler := handlers.NewHealthHandler(deps.HealthService)
+	attachmentHandler := handlers.NewAttachmentHandler(deps.AttachmentService)
+	maintenanceHandler := handlers.NewMaintenanceHandler(deps.TokenCleanupJob)
+	adminHandler := handlers.NewAdminHandler(deps.AdminService)
+	webhookHandler := handlers.NewWebhookHandler(deps.WebhookService)
+	overviewHandler := handlers.NewOverviewHandler(deps.OverviewService)
+	auditHandler := handlers.NewAuditHandler(deps.AuditService)
+	accountHandler := handlers.NewAccountHandler(deps.AccountService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(deps.APIKeyService)
+	personalDataHandler := handlers.NewPersonalDataHandler(deps.PersonalDataService)
+	reportHandler := handlers.NewReportHandler(deps.ReportService, deps.PDFRenderer)
+
+	jwtAuth := middleware.NewJWTAuthMiddleware(deps.JWTService, jwtOpts...)
+	apiKeyAuth := middleware.NewAPIKeyAuthMiddleware(deps.APIKeyAuthenticator)
+
+	// Browsers holding session cookies must prove every state-changing request with the CSRF
+	// token. It runs after authentication, which decides whether the cookie did the authenticating.
+	csrf := func(c *gin.Context) { c.Next() }
+	if cookieSessions {
+		csrf = middleware.DoubleSubmitCSRF()
+	}
+
+	// Every API request body is capped at DefaultMaxRequestSize. Attachment uploads are the only
+	// larger bodies; their route is registered on uploads, outside the cap, with its own limit.
+	api := router.Group("/api/v1", middleware.ValidateRequestLimits())
+	uploads := router.Group("/api/v1")
+
+	// Auth routes (public)
+	auth := api.Group("/auth")
+	auth.Use(middleware.LimitRequestBody(middleware.AuthMaxRequestSize))
+	{
+		auth.POST("/register", csrf, authHandler.Register)
+		auth.POST("/login", csrf, authHandler.Login)
+		auth.POST("/refresh", csrf, authHandler.RefreshToken)
+
+		// Protected auth routes
+		protected := auth.Group("")
+		protected.Use(jwtAuth.RequireAuth())
+		protected.Use(csrf)
+		{
+			protected.POST("/logout", authHandler.Logout)
+			protected.DELETE("/me", authHandler.DeleteAccount)
+			protected.GET("/audit", auditHandler.GetMyAuditLog)
+
+			// API keys are managed with a JWT only, so a key can't create or revoke keys
+			protected.POST("/api-keys", apiKeyHandler.CreateAPIKey)
+			protected.GET("/api-keys", apiKeyHandler.GetAPIKeys)
+			protected.DELETE("/api-keys/:id", apiKeyHandler.RevokeAPIKey)
+		}
+	}
+
+	// Finance routes (all require auth; API keys need the finance scopes)
+	finance := api.Group("/finance")
+	finance.Use(apiKeyAuth.APIKeyAuth())
+	finance.Use(jwtAuth.RequireAuth())
+	finance.Use(csrf)
+	finance.Use(middleware.RequireScope(domain.APIKeyScopeFinanceRead, domain.APIKeyScopeFinanceWrite))
+	finance.Use(middleware.ValidateOwnership())
+	{
+		// Income endpoints
+		finance.POST("/income",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			middleware.NormalizeFrequency(),
+			financeHandler.AddIncome)
+		finance.GET("/income", financeHandler.GetIncomes)
+		finance.PUT("/income/:id",
+			middleware.ValidateUserOwnership("income"),
+			middleware.ValidateFinancialData(),
+			middleware.NormalizeFrequency(),
+			financeHandler.UpdateIncome)
+		finance.DELETE("/income/:id",
+			middleware.ValidateUserOwnership("income"),
+			financeHandler.DeleteIncome)
+		finance.POST("/income/:id/restore",
+			middleware.ValidateUserOwnership("income"),
+			financeHandler.RestoreIncome)
+
+		// Expense endpoints
+		finance.POST("/expense",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			middleware.NormalizeFrequency(),
+			financeHandler.AddExpense)
+		finance.GET("/expenses", financeHandler.GetExpenses)
+		finance.DELETE("/expenses", financeHandler.BulkDeleteExpenses)
+		finance.PUT("/expense/:id",
+			middleware.ValidateUserOwnership("expense"),
+			middleware.ValidateFinancialData(),
+			middleware.NormalizeFrequency(),
+			financeHandler.UpdateExpense)
+		finance.DELETE("/expense/:id",
+			middleware.ValidateUserOwnership("expense"),
+			financeHandler.DeleteExpense)
+		finance.POST("/expense/:id/restore",
+			middleware.ValidateUserOwnership("expense"),
+			financeHandler.RestoreExpense)
+		finance.POST("/expense/:id/installment-paid",
+			middleware.ValidateUserOwnership("expense"),
+			financeHandler.RecordInstallmentPaid)
+
+		// Loan endpoints
+		finance.POST("/loan",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			financeHandler.AddLoan)
+		finance.GET("/loans", financeHandler.GetLoans)
+		finance.GET("/loans/near-payoff", financeHandler.GetNearPayoffLoans)
+		finance.GET("/debt-breakdown", financeHandler.GetDebtBreakdown)
+		finance.PUT("/loan/:id",
+			middleware.ValidateUserOwnership("loan"),
+			middleware.ValidateFinancialData(),
+			financeHandler.UpdateLoan)
+		finance.POST("/loan/:id/simulate",
+			middleware.ValidateUserOwnership("loan"),
+			financeHandler.SimulateLoanPayoff)
+		finance.POST("/loan/:id/extra-payment",
+			middleware.ValidateUserOwnership("loan"),
+			financeHandler.CalculateExtraPaymentImpact)
+		finance.POST("/loan/:id/payment",
+			middleware.ValidateUserOwnership("loan"),
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			financeHandler.RecordLoanPayment)
+		finance.GET("/loan/:id/history",
+			middleware.ValidateUserOwnership("loan"),
+			financeHandler.GetLoanBalanceHistory)
+
+		// Savings goal endpoints
+		finance.POST("/goals",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			financeHandler.AddSavingsGoal)
+		finance.GET("/goals", financeHandler.GetSavingsGoals)
+		finance.GET("/goals/history", financeHandler.GetGoalHistory)
+		finance.PUT("/goals/:id",
+			middleware.ValidateUserOwnership("goal"),
+			middleware.ValidateFinancialData(),
+			financeHandler.UpdateSavingsGoal)
+		finance.DELETE("/goals/:id",
+			middleware.ValidateUserOwnership("goal"),
+			financeHandler.DeleteSavingsGoal)
+		finance.POST("/goals/:id/contributions",
+			middleware.ValidateUserOwnership("goal"),
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			financeHandler.AddGoalContribution)
+
+		// Budget endpoints
+		finance.POST("/budgets",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateFinancialData(),
+			financeHandler.AddBudget)
+		finance.GET("/budgets", financeHandler.GetBudgets)
+		finance.PUT("/budgets/:id",
+			middleware.ValidateUserOwnership("budget"),
+			middleware.ValidateFinancialData(),
+			financeHandler.UpdateBudget)
+		finance.DELETE("/budgets/:id",
+			middleware.ValidateUserOwnership("budget"),
+			financeHandler.DeleteBudget)
+
+		// Analysis endpoints
+		finance.GET("/summary", middleware.ETag(), financeHandler.GetFinanceSummary)
+		finance.GET("/affordability", financeHandler.GetAffordability)
+		finance.GET("/health-explanation", financeHandler.GetHealthExplanation)
+		finance.GET("/income-diversification", financeHandler.GetIncomeDiversification)
+		finance.POST("/affordability/check", financeHandler.CheckPurchaseAffordability)
+		finance.POST("/suggest-cuts", financeHandler.SuggestExpenseCuts)
+
+		// Export endpoint
+		finance.GET("/export", financeHandler.ExportFinanceData)
+
+		// Add spending insights endpoint when implemented
+		// finance.GET("/insights", financeHandler.GetSpendingInsights)
+	}
+
+	// Health routes (all require auth; API keys need the health scopes)
+	healthAccess := []gin.HandlerFunc{
+		apiKeyAuth.APIKeyAuth(),
+		jwtAuth.RequireAuth(),
+		csrf,
+		middleware.RequireScope(domain.APIKeyScopeHealthRead, domain.APIKeyScopeHealthWrite),
+		middleware.SanitizeSensitiveData(),
+	}
+	health := api.Group("/health", healthAccess...)
+	{
+		// Profile endpoints
+		health.POST("/profile",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateHealthProfileData(),
+			healthHandler.CreateProfile)
+		health.GET("/profile", healthHandler.GetProfile)
+		health.PUT("/profile",
+			middleware.ValidateHealthProfileData(),
+			healthHandler.UpdateProfile)
+		health.DELETE("/profile", healthHandler.DeleteProfile)
+		health.GET("/profile/history", healthHandler.GetProfileHistory)
+
+		// Family profile endpoints
+		health.POST("/family",
+			deps.Idempotency.Idempotency(),
+			healthHandler.CreateDependentProfile)
+		health.GET("/family", healthHandler.GetFamilyProfiles)
+		health.PUT("/family/:id", healthHandler.UpdateDependentProfile)
+		health.DELETE("/family/:id", healthHandler.DeleteDependentProfile)
+
+		// Condition endpoints
+		health.POST("/conditions",
+			deps.Idempotency.Idempotency(),
+			healthHandler.AddCondition)
+		health.GET("/conditions", healthHandler.GetConditions)
+		health.GET("/conditions/timeline", healthHandler.GetConditionTimeline)
+		health.GET("/conditions/catalog", healthHandler.SearchConditionCatalog)
+		health.PUT("/conditions/:id",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("condition"),
+			healthHandler.UpdateCondition)
+		health.DELETE("/conditions/:id",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("condition"),
+			healthHandler.RemoveCondition)
+
+		// Expense endpoints
+		health.POST("/expenses",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateExpenseData(),
+			healthHandler.AddExpense)
+		health.GET("/expenses", healthHandler.GetExpenses)
+		health.GET("/expenses/recurring", healthHandler.GetRecurringExpenses)
+		health.GET("/expenses/analytics", healthHandler.GetExpenseAnalytics)
+		health.GET("/expenses/breakdown", healthHandler.GetExpenseBreakdown)
+		health.POST("/expenses/:id/occurrences",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			deps.Idempotency.Idempotency(),
+			healthHandler.AddExpenseOccurrence)
+		health.GET("/expenses/:id/occurrences",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			healthHandler.GetExpenseOccurrences)
+		health.GET("/expenses/:id/attachments",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			attachmentHandler.GetAttachments)
+		health.GET("/expenses/:id/attachments/:attachmentId",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			attachmentHandler.DownloadAttachment)
+		health.DELETE("/expenses/:id/attachments/:attachmentId",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			attachmentHandler.DeleteAttachment)
+
+		// Attachment uploads are limited to the attachment size rather than the API-wide cap
+		healthUploads := uploads.Group("/health", healthAccess...)
+		healthUploads.POST("/expenses/:id/attachments",
+			middleware.LimitRequestBody(attachmentHandler.MaxUploadSize()),
+			deps.HealthOwnership.ValidateHealthResourceOwnership("expense"),
+			attachmentHandler.UploadAttachment)
+
+		// Medication endpoints
+		health.POST("/medications",
+			deps.Idempotency.Idempotency(),
+			healthHandler.AddMedicationSchedule)
+		health.GET("/medications/refills", healthHandler.GetUpcomingRefills)
+
+		// Insurance endpoints
+		health.POST("/insurance",
+			deps.Idempotency.Idempotency(),
+			middleware.ValidateInsuranceDates(),
+			healthHandler.AddInsurancePolicy)
+		health.GET("/insurance", healthHandler.GetActivePolicies)
+		health.PUT("/insurance/:id/deductible",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("policy"),
+			healthHandler.UpdateDeductibleProgress)
+		health.POST("/insurance/compare", healthHandler.ComparePolicies)
+		health.GET("/insurance/evaluation", healthHandler.GetInsuranceEvaluation)
+		health.GET("/insurance/expiring", healthHandler.GetExpiringPolicies)
+		health.GET("/insurance/:id/oop-status",
+			deps.HealthOwnership.ValidateHealthResourceOwnership("policy"),
+			healthHandler.GetOutOfPocketStatus)
+
+		// Analysis endpoints
+		health.GET("/summary", middleware.ETag(), healthHandler.GetHealthSummary)
+		health.GET("/coverage-gaps", healthHandler.GetCoverageGaps)
+		health.GET("/cost-projection", healthHandler.GetCostProjection)
+		health.GET("/hsa-recommendation", healthHandler.GetHSARecommendation)
+		health.GET("/risk-model", healthHandler.GetRiskModel)
+		health.GET("/risk-history", healthHandler.GetRiskHistory)
+		health.POST("/risk/what-if", healthHandler.EvaluateRiskWhatIf)
+
+		// Future endpoints for health context integration
+		// health.GET("/risk-score", healthHandler.GetRiskScore)
+		// health.GET("/context", healthHandler.GetHealthContext)
+	}
+
+	// Combined finance and health overview for the dashboard
+	api.GET("/overview",
+		apiKeyAuth.APIKeyAuth(),
+		jwtAuth.RequireAuth(),
+		middleware.RequireScope(domain.APIKeyScopeFinanceRead, domain.APIKeyScopeFinanceWrite),
+		middleware.RequireScope(domain.APIKeyScopeHealthRead, domain.APIKeyScopeHealthWrite),
+		overviewHandler.GetOverview)
+
+	// Monthly statements draw on both finance and health records
+	api.GET("/reports/monthly",
+		apiKeyAuth.APIKeyAuth(),
+		jwtAuth.RequireAuth(),
+		middleware.RequireScope(domain.APIKeyScopeFinanceRead, domain.APIKeyScopeFinanceWrite),
+		middleware.RequireScope(domain.APIKeyScopeHealthRead, domain.APIKeyScopeHealthWrite),
+		reportHandler.GetMonthlyReport)
+
+	// Account routes (all require auth)
+	account := api.Group("/account")
+	account.Use(jwtAuth.RequireAuth())
+	account.Use(csrf)
+	{
+		account.GET("/me", accountHandler.GetMe)
+		account.PUT("/me", accountHandler.UpdateMe)
+		account.PUT("/password", accountHandler.ChangePassword)
+		account.GET("/audit-log", auditHandler.GetMyAuditLog)
+	}
+	if deps.Config.Server.EnableDemoData {
+		demoDataHandler := handlers.NewDemoDataHandler(deps.DemoDataService)
+		account.POST("/seed-demo-data", demoDataHandler.SeedDemoData)
+		account.DELETE("/demo-data", demoDataHandler.RemoveDemoData)
+	}
+
+	// Personal data download covering every domain; JWT only, since no API key scope grants it all.
+	// Health records are in the response, so it is logged like the health routes.
+	me := api.Group("/me")
+	me.Use(jwtAuth.RequireAuth())
+	me.Use(csrf)
+	me.Use(middleware.SanitizeSensitiveData())
+	{
+		me.GET("/export", personalDataHandler.ExportMyData)
+	}
+
+	// Webhook routes (all require auth)
+	webhooks := api.Group("/webhooks")
+	webhooks.Use(jwtAuth.RequireAuth())
+	webhooks.Use(csrf)
+	{
+		webhooks.POST("", webhookHandler.CreateWebhook)
+		webhooks.GET("", webhookHandler.GetWebhooks)
+		webhooks.GET("/:id", webhookHandler.GetWebhook)
+		webhooks.PUT("/:id", webhookHandler.UpdateWebhook)
+		webhooks.DELETE("/:id", webhookHandler.DeleteWebhook)
+		webhooks.GET("/:id/deliveries", webhookHandler.GetDeliveries)
+	}
+
+	// Admin routes (require auth and the admin role)
+	admin := api.Group("/admin")
+	admin.Use(jwtAuth.RequireAuth())
+	admin.Use(csrf)
+	admin.Use(middleware.RequireRole(domain.RoleAdmin))
+	{
+		admin.POST("/maintenance/cleanup-tokens", maintenanceHandler.CleanupTokens)
+
+		// User management
+		admin.GET("/users", adminHandler.ListUsers)
+		admin.GET("/users/:id", adminHandler.GetUser)
+		admin.POST("/users/:id/deactivate", adminHandler.DeactivateUser)
+		admin.PUT("/users/:id/role", adminHandler.UpdateUserRole)
+		admin.GET("/audit-log", auditHandler.GetAuditLog)
+
+		// Cross-user finance reporting
+		admin.GET("/finance/high-debt", adminHandler.GetHighDebtUsers)
+		admin.GET("/finance/by-health", adminHandler.GetSummariesByHealth)
+
+		// Health maintenance
+		admin.POST("/health/recalculate-risk", healthHandler.RecalculateAllRisk)
+	}
+}
+
+// sessionCookies returns the cookie session helper for the configured cookie attributes. The
+// refresh token cookie is only sent to the auth routes.
+func sessionCookies(auth config.AuthConfig) *middleware.SessionCookies {
+	sameSite := http.SameSiteStrictMode
+	if auth.Cookies.SameSite == "lax" {
+		sameSite = http.SameSiteLaxMode
+	}
+	return middleware.NewSessionCookies(middleware.SessionCookiesConfig{
+		Secure:          auth.Cookies.Secure,
+		Domain:          auth.Cookies.Domain,
+		SameSite:        sameSite,
+		RefreshPath:     "/api/v1/auth",
+		RefreshTokenTTL: auth.RefreshTokenTTL,
+	})
+}
+
+// corsConfig returns the configured cross-origin policy, with the environment's defaults for
+// anything left unset
+func corsConfig(server config.ServerConfig) middleware.CORSConfig {
+	cors := server.CORS.WithDefaults(server.Environment)
+	return middleware.CORSConfig{
+		AllowedOrigins:   cors.AllowedOrigins,
+		AllowedMethods:   cors.AllowedMethods,
+		AllowedHeaders:   cors.AllowedHeaders,
+		ExposedHeaders:   cors.ExposedHeaders,
+		AllowCredentials: cors.AllowCredentials,
+		MaxAge:           cors.MaxAge,
+	}
+}
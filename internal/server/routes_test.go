@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/database"
+)
+
+// setupTestDeps builds the dependencies on a migrated in-memory database
+func setupTestDeps(t *testing.T) *Deps {
+	deps, _ := setupTestDepsWithDB(t)
+	return deps
+}
+
+// setupTestDepsWithDB builds the dependencies like setupTestDeps and also returns their database
+func setupTestDepsWithDB(t *testing.T) (*Deps, *gorm.DB) {
+	gin.SetMode(gin.TestMode)
+
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Environment: "test"},
+		Database: config.DatabaseConfig{Driver: config.DriverSQLite, Database: database.SQLiteMemory},
+		Auth:     config.AuthConfig{JWTSecret: strings.Repeat("s", 32), AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour},
+		Logging:  config.LoggingConfig{Level: "error"},
+	}
+	cfg.Health.Attachments.StoragePath = t.TempDir()
+
+	dbService, err := config.NewDatabaseService(&cfg.Database, &cfg.Logging)
+	require.NoError(t, err)
+	t.Cleanup(func() { dbService.Close() })
+	require.NoError(t, database.RunAllMigrations(dbService.GetDB()))
+
+	deps, err := NewDeps(cfg, dbService)
+	require.NoError(t, err)
+	t.Cleanup(deps.Idempotency.Stop)
+	return deps, dbService.GetDB()
+}
+
+// TestBuildRouter_RouteTable snapshots every registered route, so adding or removing one has
+// to be done on purpose: update this list along with the API documentation
+func TestBuildRouter_RouteTable(t *testing.T) {
+	router, err := BuildRouter(setupTestDeps(t))
+	require.NoError(t, err)
+
+	var routes []string
+	for _, route := range router.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	sort.Strings(routes)
+
+	assert.Equal(t, []string{
+		"DELETE /api/v1/auth/api-keys/:id",
+		"DELETE /api/v1/auth/me",
+		"DELETE /api/v1/finance/budgets/:id",
+		"DELETE /api/v1/finance/expense/:id",
+		"DELETE /api/v1/finance/expenses",
+		"DELETE /api/v1/finance/goals/:id",
+		"DELETE /api/v1/finance/income/:id",
+		"DELETE /api/v1/health/conditions/:id",
+		"DELETE /api/v1/health/expenses/:id/attachments/:attachmentId",
+		"DELETE /api/v1/health/family/:id",
+		"DELETE /api/v1/health/profile",
+		"DELETE /api/v1/webhooks/:id",
+		"GET /api/v1/account/audit-log",
+		"GET /api/v1/account/me",
+		"GET /api/v1/admin/audit-log",
+		"GET /api/v1/admin/finance/by-health",
+		"GET /api/v1/admin/finance/high-debt",
+		"GET /api/v1/admin/users",
+		"GET /api/v1/admin/users/:id",
+		"GET /api/v1/auth/api-keys",
+		"GET /api/v1/auth/audit",
+		"GET /api/v1/finance/affordability",
+		"GET /api/v1/finance/budgets",
+		"GET /api/v1/finance/debt-breakdown",
+		"GET /api/v1/finance/expenses",
+		"GET /api/v1/finance/export",
+		"GET /api/v1/finance/goals",
+		"GET /api/v1/finance/goals/history",
+		"GET /api/v1/finance/health-explanation",
+		"GET /api/v1/finance/income",
+		"GET /api/v1/finance/income-diversification",
+		"GET /api/v1/finance/loan/:id/history",
+		"GET /api/v1/finance/loans",
+		"GET /api/v1/finance/loans/near-payoff",
+		"GET /api/v1/finance/summary",
+		"GET /api/v1/health/conditions",
+		"GET /api/v1/health/conditions/catalog",
+		"GET /api/v1/health/conditions/timeline",
+		"GET /api/v1/health/cost-projection",
+		"GET /api/v1/health/coverage-gaps",
+		"GET /api/v1/health/expenses",
+		"GET /api/v1/health/expenses/:id/attachments",
+		"GET /api/v1/health/expenses/:id/attachments/:attachmentId",
+		"GET /api/v1/health/expenses/:id/occurrences",
+		"GET /api/v1/health/expenses/analytics",
+		"GET /api/v1/health/expenses/breakdown",
+		"GET /api/v1/health/expenses/recurring",
+		"GET /api/v1/health/family",
+		"GET /api/v1/health/hsa-recommendation",
+		"GET /api/v1/health/insurance",
+		"GET /api/v1/health/insurance/:id/oop-status",
+		"GET /api/v1/health/insurance/evaluation",
+		"GET /api/v1/health/insurance/expiring",
+		"GET /api/v1/health/medications/refills",
+		"GET /api/v1/health/profile",
+		"GET /api/v1/health/profile/history",
+		"GET /api/v1/health/risk-history",
+		"GET /api/v1/health/risk-model",
+		"GET /api/v1/health/summary",
+		"GET /api/v1/me/export",
+		"GET /api/v1/overview",
+		"GET /api/v1/reports/monthly",
+		"GET /api/v1/webhooks",
+		"GET /api/v1/webhooks/:id",
+		"GET /api/v1/webhooks/:id/deliveries",
+		"GET /health",
+		"GET /health/live",
+		"GET /health/ready",
+		"GET /healthz",
+		"GET /metrics",
+		"GET /readyz",
+		"POST /api/v1/admin/health/recalculate-risk",
+		"POST /api/v1/admin/maintenance/cleanup-tokens",
+		"POST /api/v1/admin/users/:id/deactivate",
+		"POST /api/v1/auth/api-keys",
+		"POST /api/v1/auth/login",
+		"POST /api/v1/auth/logout",
+		"POST /api/v1/auth/refresh",
+		"POST /api/v1/auth/register",
+		"POST /api/v1/finance/affordability/check",
+		"POST /api/v1/finance/budgets",
+		"POST /api/v1/finance/expense",
+		"POST /api/v1/finance/expense/:id/installment-paid",
+		"POST /api/v1/finance/expense/:id/restore",
+		"POST /api/v1/finance/goals",
+		"POST /api/v1/finance/goals/:id/contributions",
+		"POST /api/v1/finance/income",
+		"POST /api/v1/finance/income/:id/restore",
+		"POST /api/v1/finance/loan",
+		"POST /api/v1/finance/loan/:id/extra-payment",
+		"POST /api/v1/finance/loan/:id/payment",
+		"POST /api/v1/finance/loan/:id/simulate",
+		"POST /api/v1/finance/suggest-cuts",
+		"POST /api/v1/health/conditions",
+		"POST /api/v1/health/expenses",
+		"POST /api/v1/health/expenses/:id/attachments",
+		"POST /api/v1/health/expenses/:id/occurrences",
+		"POST /api/v1/health/family",
+		"POST /api/v1/health/insurance",
+		"POST /api/v1/health/insurance/compare",
+		"POST /api/v1/health/medications",
+		"POST /api/v1/health/profile",
+		"POST /api/v1/health/risk/what-if",
+		"POST /api/v1/webhooks",
+		"PUT /api/v1/account/me",
+		"PUT /api/v1/account/password",
+		"PUT /api/v1/admin/users/:id/role",
+		"PUT /api/v1/finance/budgets/:id",
+		"PUT /api/v1/finance/expense/:id",
+		"PUT /api/v1/finance/goals/:id",
+		"PUT /api/v1/finance/income/:id",
+		"PUT /api/v1/finance/loan/:id",
+		"PUT /api/v1/health/conditions/:id",
+		"PUT /api/v1/health/family/:id",
+		"PUT /api/v1/health/insurance/:id/deductible",
+		"PUT /api/v1/health/profile",
+		"PUT /api/v1/webhooks/:id",
+	}, routes)
+}
+
+func TestBuildRouter_EnableSwagger_RegistersDocs(t *testing.T) {
+	deps := setupTestDeps(t)
+	deps.Config.Server.EnableSwagger = true
+
+	router, err := BuildRouter(deps)
+	require.NoError(t, err)
+
+	var routes []string
+	for _, route := range router.Routes() {
+		routes = append(routes, route.Method+" "+route.Path)
+	}
+	assert.Subset(t, routes, []string{"GET /api/v1/openapi.json", "GET /docs/*any", "GET /swagger/*any"})
+}
+
+func TestBuildRouter_EnableDemoData_RegistersSeedRoutes(t *testing.T) {
+	demoRoutes := []string{"POST /api/v1/account/seed-demo-data", "DELETE /api/v1/account/demo-data"}
+	deps := setupTestDeps(t)
+
+	for _, enabled := range []bool{false, true} {
+		deps.Config.Server.EnableDemoData = enabled
+		router, err := BuildRouter(deps)
+		require.NoError(t, err)
+
+		var routes []string
+		for _, route := range router.Routes() {
+			routes = append(routes, route.Method+" "+route.Path)
+		}
+		if enabled {
+			assert.Subset(t, routes, demoRoutes)
+		} else {
+			assert.NotContains(t, routes, demoRoutes[0])
+			assert.NotContains(t, routes, demoRoutes[1])
+		}
+	}
+}
+
+func TestNewDeps_ReadinessChecks(t *testing.T) {
+	deps := setupTestDeps(t)
+
+	report := deps.Readiness.Run(context.Background())
+
+	assert.True(t, report.Ready(), "failed checks: %v", report.Failed())
+	assert.ElementsMatch(t, []string{"db", "jwt", "migrations"}, slices.Collect(maps.Keys(report.Checks)))
+}
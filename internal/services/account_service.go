@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// accountService implements the AccountService interface defined in handlers package
+type accountService struct {
+	userRepo  UserRepository
+	tokenRepo TokenRepository
+	audit     AuditRecorder
+	// passwordService and passwordPolicy are used to change passwords
+	passwordService PasswordService
+	passwordPolicy  PasswordPolicy
+}
+
+// AccountServiceOption customizes an account service created by NewAccountService
+type AccountServiceOption func(*accountService)
+
+// WithAccountAuditRecorder records account changes and the session revocations they cause in the audit log
+func WithAccountAuditRecorder(recorder AuditRecorder) AccountServiceOption {
+	return func(s *accountService) {
+		s.audit = recorder
+	}
+}
+
+// WithAccountPasswordService hashes and checks passwords with passwordService instead of a
+// default one with bcrypt cost 14
+func WithAccountPasswordService(passwordService PasswordService) AccountServiceOption {
+	return func(s *accountService) {
+		s.passwordService = passwordService
+	}
+}
+
+// WithAccountPasswordPolicy sets the rules a new password must follow; the default is DefaultPasswordPolicy
+func WithAccountPasswordPolicy(policy PasswordPolicy) AccountServiceOption {
+	return func(s *accountService) {
+		s.passwordPolicy = policy
+	}
+}
+
+// NewAccountService creates a new account service instance
+// Returns concrete type that implements AccountService interface defined in handlers package
+func NewAccountService(userRepo UserRepository, tokenRepo TokenRepository, opts ...AccountServiceOption) *accountService {
+	s := &accountService{
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		audit:     nopAuditRecorder{},
+
+		passwordService: NewPasswordService(),
+		passwordPolicy:  DefaultPasswordPolicy(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// GetAccount returns the user's own account
+func (s *accountService) GetAccount(ctx context.Context, userID string) (domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return *user, nil
+}
+
+// UpdateAccount changes the user's name and email. The email must not belong to another
+// user; the unique index on users.email settles two users claiming the same email at once.
+// Changing the email revokes all of the user's refresh tokens so every session has to
+// log in again with the new address. Access tokens already issued stay valid until they expire.
+func (s *accountService) UpdateAccount(ctx context.Context, userID string, update domain.AccountUpdate) (domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to get account: %w", err)
+	}
+	before := *user
+
+	if update.Name != nil {
+		user.Name = *update.Name
+	}
+	emailChanged := update.Email != nil && *update.Email != user.Email
+	if emailChanged {
+		// Checked up front for a clear error; the repository still rejects a concurrent claim
+		existing, err := s.userRepo.GetByEmail(ctx, *update.Email)
+		if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+			return domain.User{}, fmt.Errorf("failed to check email: %w", err)
+		}
+		if existing != nil {
+			return domain.User{}, domain.ErrUserAlreadyExists
+		}
+		user.Email = *update.Email
+	}
+
+	if err := user.Validate(); err != nil {
+		return domain.User{}, fmt.Errorf("%v: %w", err, domain.ErrInvalidUserData)
+	}
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return domain.User{}, fmt.Errorf("failed to update account: %w", err)
+	}
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceUser, userID, before, *user)
+
+	if emailChanged {
+		if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+			return domain.User{}, fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		s.audit.Record(ctx, domain.AuditActionTokenRevoke, domain.AuditResourceRefreshToken, "",
+			nil, map[string]interface{}{"reason": "email_changed", "user_id": userID})
+		logging.ServiceLogger().Info("Account email changed, sessions revoked",
+			logging.WithOperation("update_account"), logging.WithUserID(userID))
+	}
+
+	return *user, nil
+}
+
+// ChangePassword replaces the user's password once the current one is confirmed. The new
+// password must follow the password policy and differ from the current one. All of the user's
+// refresh tokens are revoked so every session, including this one, has to log in again.
+func (s *accountService) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	before := *user
+
+	if err := s.passwordService.CheckPassword(user.PasswordHash, currentPassword); err != nil {
+		return domain.ErrInvalidCredentials
+	}
+	if err := s.passwordPolicy.Check(newPassword); err != nil {
+		return err
+	}
+	if newPassword == currentPassword {
+		return &domain.WeakPasswordError{Violations: []string{"must differ from the current password"}}
+	}
+
+	hash, err := s.passwordService.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = hash
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+	s.audit.Record(ctx, domain.AuditActionPasswordChange, domain.AuditResourceUser, userID, before, *user)
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	s.audit.Record(ctx, domain.AuditActionTokenRevoke, domain.AuditResourceRefreshToken, "",
+		nil, map[string]interface{}{"reason": "password_changed", "user_id": userID})
+	logging.ServiceLogger().Info("Password changed, sessions revoked",
+		logging.WithOperation("change_password"), logging.WithUserID(userID))
+
+	return nil
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// setupPasswordChange returns an account service whose user's current password is "OldPassword123"
+func setupPasswordChange(opts ...AccountServiceOption) (*accountService, *MockUserRepository, *MockTokenRepository, *MockPasswordService) {
+	setupTestLogger()
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockTokenRepository)
+	passwordService := new(MockPasswordService)
+	passwordService.On("CheckPassword", "hashed-password", "OldPassword123").Return(nil).Maybe()
+	passwordService.On("CheckPassword", "hashed-password", mock.Anything).Return(errors.New("mismatched hash and password")).Maybe()
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+	opts = append([]AccountServiceOption{WithAccountPasswordService(passwordService)}, opts...)
+	return NewAccountService(userRepo, tokenRepo, opts...), userRepo, tokenRepo, passwordService
+}
+
+func setupAccountService() (*accountService, *MockUserRepository, *MockTokenRepository) {
+	setupTestLogger()
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockTokenRepository)
+	return NewAccountService(userRepo, tokenRepo), userRepo, tokenRepo
+}
+
+func accountUser() *domain.User {
+	now := time.Now()
+	return &domain.User{
+		ID:           "user-1",
+		Email:        "ada@example.com",
+		Name:         "Ada",
+		PasswordHash: "hashed-password",
+		IsActive:     true,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestAccountService_GetAccount(t *testing.T) {
+	service, userRepo, _ := setupAccountService()
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+	userRepo.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrUserNotFound)
+
+	user, err := service.GetAccount(context.Background(), "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ada@example.com", user.Email)
+
+	_, err = service.GetAccount(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestAccountService_UpdateAccount_NameOnlyKeepsSessions(t *testing.T) {
+	service, userRepo, tokenRepo := setupAccountService()
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+	userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Name == "Ada Lovelace" && u.Email == "ada@example.com"
+	})).Return(nil)
+
+	// Sending the current email back is not a change
+	user, err := service.UpdateAccount(context.Background(), "user-1", domain.AccountUpdate{
+		Name:  stringPtr("Ada Lovelace"),
+		Email: stringPtr("ada@example.com"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", user.Name)
+	userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	tokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_UpdateAccount_EmailChangeRevokesSessions(t *testing.T) {
+	service, userRepo, tokenRepo := setupAccountService()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+	userRepo.On("GetByEmail", mock.Anything, "ada@newmail.com").Return(nil, domain.ErrUserNotFound)
+	userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.Email == "ada@newmail.com"
+	})).Return(nil)
+	tokenRepo.On("RevokeAllUserTokens", mock.Anything, "user-1").Return(nil)
+
+	user, err := service.UpdateAccount(context.Background(), "user-1", domain.AccountUpdate{Email: stringPtr("ada@newmail.com")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "ada@newmail.com", user.Email)
+	assert.Equal(t, "Ada", user.Name)
+	tokenRepo.AssertExpectations(t)
+	require.Len(t, recorder.records, 2)
+	assert.Equal(t, domain.AuditChange{Before: "ada@example.com", After: "ada@newmail.com"}, recorder.records[0].Changes["email"])
+	assert.Equal(t, domain.AuditActionTokenRevoke, recorder.records[1].Action)
+}
+
+func TestAccountService_UpdateAccount_EmailTaken(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(userRepo *MockUserRepository)
+	}{
+		{
+			name: "another user already has the email",
+			setupMock: func(userRepo *MockUserRepository) {
+				userRepo.On("GetByEmail", mock.Anything, "grace@example.com").Return(&domain.User{ID: "user-2"}, nil)
+			},
+		},
+		{
+			name: "another user claims the email concurrently",
+			setupMock: func(userRepo *MockUserRepository) {
+				userRepo.On("GetByEmail", mock.Anything, "grace@example.com").Return(nil, domain.ErrUserNotFound)
+				userRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrUserAlreadyExists)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, userRepo, tokenRepo := setupAccountService()
+			userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+			tt.setupMock(userRepo)
+
+			_, err := service.UpdateAccount(context.Background(), "user-1", domain.AccountUpdate{Email: stringPtr("grace@example.com")})
+
+			assert.ErrorIs(t, err, domain.ErrUserAlreadyExists)
+			tokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAccountService_UpdateAccount_InvalidData(t *testing.T) {
+	service, userRepo, _ := setupAccountService()
+	userRepo.On("GetByID", mock.Anything, "user-1").Return(accountUser(), nil)
+
+	_, err := service.UpdateAccount(context.Background(), "user-1", domain.AccountUpdate{Name: stringPtr("  ")})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidUserData)
+	userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestAccountService_ChangePassword_RevokesSessions(t *testing.T) {
+	service, userRepo, tokenRepo, passwordService := setupPasswordChange()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	passwordService.On("HashPassword", "NewPassword456!").Return("new-hash", nil)
+	userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.PasswordHash == "new-hash" && u.Email == "ada@example.com"
+	})).Return(nil)
+	tokenRepo.On("RevokeAllUserTokens", mock.Anything, "user-1").Return(nil)
+
+	err := service.ChangePassword(context.Background(), "user-1", "OldPassword123", "NewPassword456!")
+
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+	require.Len(t, recorder.records, 2)
+	assert.Equal(t, domain.AuditActionPasswordChange, recorder.records[0].Action)
+	assert.Contains(t, recorder.records[0].Changes, "password_hash")
+	assert.NotContains(t, recorder.records[0].Changes["password_hash"].After, "new-hash", "the hash is redacted")
+	assert.Equal(t, domain.AuditActionTokenRevoke, recorder.records[1].Action)
+}
+
+func TestAccountService_ChangePassword_Rejected(t *testing.T) {
+	tests := []struct {
+		name               string
+		policy             PasswordPolicy
+		currentPassword    string
+		newPassword        string
+		expectedErr        error
+		expectedViolations []string
+	}{
+		{
+			name:            "wrong current password",
+			policy:          DefaultPasswordPolicy(),
+			currentPassword: "WrongPassword",
+			newPassword:     "NewPassword456!",
+			expectedErr:     domain.ErrInvalidCredentials,
+		},
+		{
+			name:               "new password breaks the policy",
+			policy:             PasswordPolicy{MinLength: 16, MaxLength: 72, RequireSymbol: true},
+			currentPassword:    "OldPassword123",
+			newPassword:        "NewPassword456",
+			expectedErr:        domain.ErrWeakPassword,
+			expectedViolations: []string{"must be at least 16 characters", "must contain a symbol"},
+		},
+		{
+			name:               "new password is the current one",
+			policy:             DefaultPasswordPolicy(),
+			currentPassword:    "OldPassword123",
+			newPassword:        "OldPassword123",
+			expectedErr:        domain.ErrWeakPassword,
+			expectedViolations: []string{"must differ from the current password"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, userRepo, tokenRepo, passwordService := setupPasswordChange(WithAccountPasswordPolicy(tt.policy))
+
+			err := service.ChangePassword(context.Background(), "user-1", tt.currentPassword, tt.newPassword)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedViolations != nil {
+				var weak *domain.WeakPasswordError
+				require.ErrorAs(t, err, &weak)
+				assert.Equal(t, tt.expectedViolations, weak.Violations)
+			}
+			passwordService.AssertNotCalled(t, "HashPassword", mock.Anything)
+			userRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			tokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+const (
+	// DefaultAdminPageSize is used when a list request doesn't specify a page size
+	DefaultAdminPageSize = 20
+	// MaxAdminPageSize caps the page size of admin list requests
+	MaxAdminPageSize = 100
+)
+
+// adminService implements the AdminService interface defined in handlers package
+type adminService struct {
+	userRepo    UserRepository
+	tokenRepo   TokenRepository
+	summaryRepo FinanceSummaryRepository
+	audit       AuditRecorder
+}
+
+// AdminServiceOption customizes an admin service created by NewAdminService
+type AdminServiceOption func(*adminService)
+
+// WithAdminAuditRecorder records deactivations, token revocations and role changes in the audit log
+func WithAdminAuditRecorder(recorder AuditRecorder) AdminServiceOption {
+	return func(s *adminService) {
+		s.audit = recorder
+	}
+}
+
+// NewAdminService creates a new admin service instance
+// Returns concrete type that implements AdminService interface defined in handlers package
+func NewAdminService(
+	userRepo UserRepository,
+	tokenRepo TokenRepository,
+	summaryRepo FinanceSummaryRepository,
+	opts ...AdminServiceOption,
+) *adminService {
+	s := &adminService{
+		userRepo:    userRepo,
+		tokenRepo:   tokenRepo,
+		summaryRepo: summaryRepo,
+		audit:       nopAuditRecorder{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ListUsers returns one page of users and the total number of users
+// page is 1-based; out of range values fall back to the first page and the default page size
+func (s *adminService) ListUsers(ctx context.Context, page, pageSize int) ([]domain.User, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultAdminPageSize
+	}
+	if pageSize > MaxAdminPageSize {
+		pageSize = MaxAdminPageSize
+	}
+
+	users, total, err := s.userRepo.List(ctx, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// GetUser returns a user by ID, whether or not the account is active
+func (s *adminService) GetUser(ctx context.Context, userID string) (domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return *user, nil
+}
+
+// DeactivateUser marks a user inactive and revokes all of their refresh tokens
+// Access tokens already issued are rejected from then on by the JWT middleware's user check
+func (s *adminService) DeactivateUser(ctx context.Context, actorID, userID string) error {
+	if actorID == userID {
+		return domain.ErrCannotModifySelf
+	}
+
+	logger := logging.ServiceLogger().With(logging.WithOperation("deactivate_user"), logging.WithUserID(userID))
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user.IsActive {
+		user.IsActive = false
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to deactivate user: %w", err)
+		}
+		s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceUser, userID,
+			map[string]interface{}{"is_active": true}, map[string]interface{}{"is_active": false})
+	}
+
+	if err := s.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke user tokens: %w", err)
+	}
+	s.audit.Record(ctx, domain.AuditActionTokenRevoke, domain.AuditResourceRefreshToken, "",
+		nil, map[string]interface{}{"reason": "deactivated", "user_id": userID})
+
+	logger.Info("User deactivated", zap.String("actor_id", actorID))
+	return nil
+}
+
+// SetUserRole changes a user's role
+// Admins can't change their own role so the last admin can't lock everyone out. Access tokens
+// issued with the old role are rejected by the JWT middleware's user check from then on.
+func (s *adminService) SetUserRole(ctx context.Context, actorID, userID, role string) error {
+	if actorID == userID {
+		return domain.ErrCannotModifySelf
+	}
+	if !domain.IsValidRole(role) {
+		return fmt.Errorf("invalid role %q: %w", role, domain.ErrInvalidUserData)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if err := s.userRepo.UpdateRole(ctx, userID, role); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceUser, userID,
+		map[string]interface{}{"role": user.EffectiveRole()}, map[string]interface{}{"role": role})
+
+	logging.ServiceLogger().Info("User role changed",
+		logging.WithOperation("set_user_role"),
+		logging.WithUserID(userID),
+		zap.String("actor_id", actorID),
+		zap.String("role", role))
+	return nil
+}
+
+// PromoteAdmins grants the admin role to the users with the given emails
+// Used at startup to seed admins from configuration; emails without an account are skipped
+func (s *adminService) PromoteAdmins(ctx context.Context, emails []string) error {
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+
+		user, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get user %s: %w", email, err)
+		}
+
+		if user.IsAdmin() {
+			continue
+		}
+
+		if err := s.userRepo.UpdateRole(ctx, user.ID, domain.RoleAdmin); err != nil {
+			return fmt.Errorf("failed to promote user %s: %w", email, err)
+		}
+	}
+
+	return nil
+}
+
+// GetUsersWithHighDebtRatio returns the finance summaries whose debt-to-income ratio is above threshold
+func (s *adminService) GetUsersWithHighDebtRatio(ctx context.Context, threshold float64) ([]domain.FinanceSummary, error) {
+	if threshold < 0 {
+		return nil, fmt.Errorf("threshold cannot be negative: %w", domain.ErrInvalidFinanceData)
+	}
+
+	summaries, err := s.summaryRepo.GetUsersWithHighDebtRatio(ctx, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users with high debt ratio: %w", err)
+	}
+
+	return summaries, nil
+}
+
+// GetFinanceSummariesByHealthStatus returns the finance summaries with the given financial health
+func (s *adminService) GetFinanceSummariesByHealthStatus(ctx context.Context, status string) ([]domain.FinanceSummary, error) {
+	switch status {
+	case domain.HealthExcellent, domain.HealthGood, domain.HealthFair, domain.HealthPoor:
+	default:
+		return nil, fmt.Errorf("unknown financial health status %q: %w", status, domain.ErrInvalidFinanceData)
+	}
+
+	summaries, err := s.summaryRepo.GetFinanceSummariesByHealthStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finance summaries by health status: %w", err)
+	}
+
+	return summaries, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func setupAdminService() (*adminService, *MockUserRepository, *MockTokenRepository, *MockFinanceSummaryRepository) {
+	setupTestLogger()
+	userRepo := new(MockUserRepository)
+	tokenRepo := new(MockTokenRepository)
+	summaryRepo := new(MockFinanceSummaryRepository)
+	return NewAdminService(userRepo, tokenRepo, summaryRepo), userRepo, tokenRepo, summaryRepo
+}
+
+func TestAdminService_ListUsers_Pagination(t *testing.T) {
+	tests := []struct {
+		name           string
+		page           int
+		pageSize       int
+		expectedOffset int
+		expectedLimit  int
+	}{
+		{name: "second page", page: 2, pageSize: 10, expectedOffset: 10, expectedLimit: 10},
+		{name: "defaults for zero values", page: 0, pageSize: 0, expectedOffset: 0, expectedLimit: DefaultAdminPageSize},
+		{name: "page size is capped", page: 1, pageSize: 1000, expectedOffset: 0, expectedLimit: MaxAdminPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, userRepo, _, _ := setupAdminService()
+			userRepo.On("List", mock.Anything, tt.expectedOffset, tt.expectedLimit).
+				Return([]domain.User{{ID: "user-1"}}, int64(1), nil)
+
+			users, total, err := service.ListUsers(context.Background(), tt.page, tt.pageSize)
+
+			assert.NoError(t, err)
+			assert.Len(t, users, 1)
+			assert.Equal(t, int64(1), total)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAdminService_GetUser(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+	userRepo.On("GetByID", mock.Anything, "user-2").Return(&domain.User{ID: "user-2", Role: domain.RoleAdmin}, nil)
+	userRepo.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrUserNotFound)
+
+	user, err := service.GetUser(context.Background(), "user-2")
+	require.NoError(t, err)
+	assert.Equal(t, "user-2", user.ID)
+	assert.Equal(t, domain.RoleAdmin, user.Role)
+
+	_, err = service.GetUser(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestAdminService_DeactivateUser_RevokesTokens(t *testing.T) {
+	service, userRepo, tokenRepo, _ := setupAdminService()
+	user := &domain.User{ID: "user-2", Email: "user@example.com", Name: "User", IsActive: true}
+	userRepo.On("GetByID", mock.Anything, "user-2").Return(user, nil)
+	userRepo.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.User) bool {
+		return u.ID == "user-2" && !u.IsActive
+	})).Return(nil)
+	tokenRepo.On("RevokeAllUserTokens", mock.Anything, "user-2").Return(nil)
+
+	err := service.DeactivateUser(context.Background(), "admin-1", "user-2")
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestAdminService_DeactivateUser_Self(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+
+	err := service.DeactivateUser(context.Background(), "admin-1", "admin-1")
+
+	assert.ErrorIs(t, err, domain.ErrCannotModifySelf)
+	userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestAdminService_DeactivateUser_NotFound(t *testing.T) {
+	service, userRepo, tokenRepo, _ := setupAdminService()
+	userRepo.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrUserNotFound)
+
+	err := service.DeactivateUser(context.Background(), "admin-1", "missing")
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	tokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+}
+
+func TestAdminService_SetUserRole(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+	recorder := &recordingAuditRecorder{}
+	WithAdminAuditRecorder(recorder)(service)
+	userRepo.On("GetByID", mock.Anything, "user-2").Return(&domain.User{ID: "user-2", Role: domain.RoleUser}, nil)
+	userRepo.On("UpdateRole", mock.Anything, "user-2", domain.RoleAdmin).Return(nil)
+
+	assert.NoError(t, service.SetUserRole(context.Background(), "admin-1", "user-2", domain.RoleAdmin))
+	assert.ErrorIs(t, service.SetUserRole(context.Background(), "admin-1", "user-2", "superuser"), domain.ErrInvalidUserData)
+	assert.ErrorIs(t, service.SetUserRole(context.Background(), "admin-1", "admin-1", domain.RoleUser), domain.ErrCannotModifySelf)
+	userRepo.AssertNumberOfCalls(t, "UpdateRole", 1)
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, map[string]domain.AuditChange{
+		"role": {Before: domain.RoleUser, After: domain.RoleAdmin},
+	}, recorder.records[0].Changes)
+}
+
+func TestAdminService_SetUserRole_UnknownUser(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+	userRepo.On("GetByID", mock.Anything, "missing").Return(nil, domain.ErrUserNotFound)
+
+	err := service.SetUserRole(context.Background(), "admin-1", "missing", domain.RoleAdmin)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+	userRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAdminService_PromoteAdmins_SkipsUnknownAndExistingAdmins(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+	userRepo.On("GetByEmail", mock.Anything, "new-admin@example.com").
+		Return(&domain.User{ID: "user-1", Role: domain.RoleUser}, nil)
+	userRepo.On("GetByEmail", mock.Anything, "admin@example.com").
+		Return(&domain.User{ID: "user-2", Role: domain.RoleAdmin}, nil)
+	userRepo.On("GetByEmail", mock.Anything, "nobody@example.com").
+		Return(nil, domain.ErrUserNotFound)
+	userRepo.On("UpdateRole", mock.Anything, "user-1", domain.RoleAdmin).Return(nil).Once()
+
+	err := service.PromoteAdmins(context.Background(), []string{
+		"new-admin@example.com", "admin@example.com", "nobody@example.com", " ",
+	})
+
+	assert.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	userRepo.AssertNumberOfCalls(t, "UpdateRole", 1)
+}
+
+func TestAdminService_PromoteAdmins_RepositoryError(t *testing.T) {
+	service, userRepo, _, _ := setupAdminService()
+	userRepo.On("GetByEmail", mock.Anything, "admin@example.com").Return(nil, errors.New("database unavailable"))
+
+	err := service.PromoteAdmins(context.Background(), []string{"admin@example.com"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database unavailable")
+}
+
+func TestAdminService_FinanceAggregates(t *testing.T) {
+	service, _, _, summaryRepo := setupAdminService()
+	summaries := []domain.FinanceSummary{{UserID: "user-1", DebtToIncomeRatio: 0.6, FinancialHealth: domain.HealthPoor}}
+	summaryRepo.On("GetUsersWithHighDebtRatio", mock.Anything, 0.4).Return(summaries, nil)
+	summaryRepo.On("GetFinanceSummariesByHealthStatus", mock.Anything, domain.HealthPoor).Return(summaries, nil)
+
+	highDebt, err := service.GetUsersWithHighDebtRatio(context.Background(), 0.4)
+	assert.NoError(t, err)
+	assert.Equal(t, summaries, highDebt)
+
+	poor, err := service.GetFinanceSummariesByHealthStatus(context.Background(), domain.HealthPoor)
+	assert.NoError(t, err)
+	assert.Equal(t, summaries, poor)
+
+	_, err = service.GetUsersWithHighDebtRatio(context.Background(), -1)
+	assert.ErrorIs(t, err, domain.ErrInvalidFinanceData)
+	_, err = service.GetFinanceSummariesByHealthStatus(context.Background(), "Terrible")
+	assert.ErrorIs(t, err, domain.ErrInvalidFinanceData)
+	summaryRepo.AssertExpectations(t)
+}
@@ -23,6 +23,9 @@ type BudgetAnalyzer interface {
 
 	// IdentifyUnnecessaryExpenses finds expenses that can be optimized
 	IdentifyUnnecessaryExpenses(ctx context.Context, userID string) ([]ExpenseOptimization, error)
+
+	// AssessAffordability rates financial health and the largest affordable purchase using the analyzer's thresholds
+	AssessAffordability(ctx context.Context, userID string) (AffordabilityAssessment, error)
 }
 
 // DebtCalculator defines debt analysis and calculation operations
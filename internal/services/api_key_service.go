@@ -0,0 +1,136 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// apiKeyService implements the APIKeyService interface defined in handlers package
+// and the APIKeyAuthenticator interface consumed by the API key middleware
+type apiKeyService struct {
+	repo     APIKeyRepository
+	userRepo UserRepository
+}
+
+// NewAPIKeyService creates a new API key service instance
+// Returns concrete type that implements APIKeyService interface defined in handlers package
+func NewAPIKeyService(repo APIKeyRepository, userRepo UserRepository) *apiKeyService {
+	return &apiKeyService{
+		repo:     repo,
+		userRepo: userRepo,
+	}
+}
+
+// CreateAPIKey generates a key for the user, stores its hash and returns the key itself.
+// The key can't be recovered afterwards; only its prefix is kept to identify it.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, key *domain.APIKey) (string, error) {
+	rawKey, err := generateAPIKey()
+	if err != nil {
+		return "", err
+	}
+
+	key.Name = strings.TrimSpace(key.Name)
+	key.HashedKey = hashAPIKey(rawKey)
+	key.Prefix = rawKey[:domain.APIKeyDisplayPrefixLength]
+	key.LastUsedAt = nil
+	key.RevokedAt = nil
+
+	if err := key.Validate(); err != nil {
+		return "", err
+	}
+
+	if err := s.repo.Create(ctx, key); err != nil {
+		return "", err
+	}
+
+	return rawKey, nil
+}
+
+// GetUserAPIKeys retrieves all API keys created by a user, revoked ones included
+func (s *apiKeyService) GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	return s.repo.GetUserAPIKeys(ctx, userID)
+}
+
+// RevokeAPIKey revokes one of the user's API keys; requests made with it are rejected from then on
+// Returns domain.ErrAPIKeyNotFound if it doesn't exist or belongs to another user
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, userID, keyID string) error {
+	key, err := s.repo.GetByID(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	// Other users' keys are reported as missing so IDs can't be probed
+	if key.UserID != userID {
+		return domain.ErrAPIKeyNotFound
+	}
+
+	return s.repo.Revoke(ctx, keyID, time.Now())
+}
+
+// AuthenticateAPIKey looks up the key presented with a request and returns it with its owner
+// Returns domain.ErrInvalidAPIKey for unknown keys, domain.ErrAPIKeyRevoked for revoked keys
+// and domain.ErrAccountInactive if the owner's account has been deactivated
+func (s *apiKeyService) AuthenticateAPIKey(ctx context.Context, rawKey string) (domain.APIKey, *domain.User, error) {
+	if !strings.HasPrefix(rawKey, domain.APIKeyPrefix) {
+		return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetByHashedKey(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+		}
+		return domain.APIKey{}, nil, err
+	}
+
+	if key.IsRevoked() {
+		return domain.APIKey{}, nil, domain.ErrAPIKeyRevoked
+	}
+
+	user, err := s.userRepo.GetByID(ctx, key.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.APIKey{}, nil, domain.ErrInvalidAPIKey
+		}
+		return domain.APIKey{}, nil, fmt.Errorf("failed to get API key owner: %w", err)
+	}
+	if !user.IsActive {
+		return domain.APIKey{}, nil, domain.ErrAccountInactive
+	}
+
+	now := time.Now()
+	if err := s.repo.UpdateLastUsed(ctx, key.ID, now); err != nil {
+		// Log error but don't fail the request
+		logging.ServiceLogger().Warn("Failed to update API key last used time",
+			logging.WithOperation("authenticate_api_key"), logging.WithError(err))
+	} else {
+		key.LastUsedAt = &now
+	}
+
+	return key, user, nil
+}
+
+// generateAPIKey returns APIKeyPrefix followed by 32 random bytes, hex encoded
+func generateAPIKey() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	return domain.APIKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+// hashAPIKey returns the hex encoded SHA-256 hash keys are stored and looked up by.
+// Keys are random enough that a fast unsalted hash is safe, unlike passwords.
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByID(ctx context.Context, id string) (domain.APIKey, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetByHashedKey(ctx context.Context, hashedKey string) (domain.APIKey, error) {
+	args := m.Called(ctx, hashedKey)
+	return args.Get(0).(domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, id string, revokedAt time.Time) error {
+	args := m.Called(ctx, id, revokedAt)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) UpdateLastUsed(ctx context.Context, id string, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func TestAPIKeyService_CreateAPIKey_StoresOnlyTheHash(t *testing.T) {
+	mockRepo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(mockRepo, &MockUserRepository{})
+	ctx := context.Background()
+
+	key := &domain.APIKey{
+		UserID: "user-1",
+		Name:   " Nightly export ",
+		Scopes: []string{domain.APIKeyScopeFinanceRead},
+	}
+	mockRepo.On("Create", ctx, key).Return(nil)
+
+	rawKey, err := service.CreateAPIKey(ctx, key)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(rawKey, domain.APIKeyPrefix))
+	assert.Len(t, rawKey, len(domain.APIKeyPrefix)+64)
+	assert.Equal(t, hashAPIKey(rawKey), key.HashedKey)
+	assert.NotContains(t, key.HashedKey, rawKey[len(domain.APIKeyPrefix):])
+	assert.Equal(t, rawKey[:domain.APIKeyDisplayPrefixLength], key.Prefix)
+	assert.Equal(t, "Nightly export", key.Name)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestAPIKeyService_CreateAPIKey_RejectsInvalidScopes(t *testing.T) {
+	mockRepo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(mockRepo, &MockUserRepository{})
+
+	_, err := service.CreateAPIKey(context.Background(), &domain.APIKey{
+		UserID: "user-1",
+		Name:   "Script",
+		Scopes: []string{"admin"},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKeyData)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_RevokeAPIKey_OtherUsersKeyIsNotFound(t *testing.T) {
+	mockRepo := &MockAPIKeyRepository{}
+	service := NewAPIKeyService(mockRepo, &MockUserRepository{})
+	ctx := context.Background()
+
+	mockRepo.On("GetByID", ctx, "key-1").Return(domain.APIKey{ID: "key-1", UserID: "user-2"}, nil)
+
+	err := service.RevokeAPIKey(ctx, "user-1", "key-1")
+
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	mockRepo.AssertNotCalled(t, "Revoke", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAPIKeyService_AuthenticateAPIKey(t *testing.T) {
+	rawKey := domain.APIKeyPrefix + strings.Repeat("ab", 32)
+	activeUser := &domain.User{ID: "user-1", Email: "user@example.com", IsActive: true}
+	revokedAt := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name        string
+		rawKey      string
+		stored      domain.APIKey
+		lookupErr   error
+		user        *domain.User
+		expectedErr error
+	}{
+		{
+			name:   "valid key",
+			rawKey: rawKey,
+			stored: domain.APIKey{ID: "key-1", UserID: "user-1", Scopes: []string{domain.APIKeyScopeHealthRead}},
+			user:   activeUser,
+		},
+		{
+			name:        "unknown key",
+			rawKey:      rawKey,
+			lookupErr:   domain.ErrAPIKeyNotFound,
+			expectedErr: domain.ErrInvalidAPIKey,
+		},
+		{
+			name:        "key without the prefix is never looked up",
+			rawKey:      strings.Repeat("ab", 32),
+			expectedErr: domain.ErrInvalidAPIKey,
+		},
+		{
+			name:        "revoked key",
+			rawKey:      rawKey,
+			stored:      domain.APIKey{ID: "key-1", UserID: "user-1", RevokedAt: &revokedAt},
+			expectedErr: domain.ErrAPIKeyRevoked,
+		},
+		{
+			name:        "deactivated owner",
+			rawKey:      rawKey,
+			stored:      domain.APIKey{ID: "key-1", UserID: "user-1"},
+			user:        &domain.User{ID: "user-1", IsActive: false},
+			expectedErr: domain.ErrAccountInactive,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockAPIKeyRepository{}
+			mockUserRepo := &MockUserRepository{}
+			service := NewAPIKeyService(mockRepo, mockUserRepo)
+			ctx := context.Background()
+
+			mockRepo.On("GetByHashedKey", ctx, hashAPIKey(tt.rawKey)).Return(tt.stored, tt.lookupErr).Maybe()
+			if tt.user != nil {
+				mockUserRepo.On("GetByID", ctx, "user-1").Return(tt.user, nil)
+			}
+			mockRepo.On("UpdateLastUsed", ctx, "key-1", mock.AnythingOfType("time.Time")).Return(nil).Maybe()
+
+			key, user, err := service.AuthenticateAPIKey(ctx, tt.rawKey)
+
+			if tt.expectedErr != nil {
+				assert.True(t, errors.Is(err, tt.expectedErr), "got %v", err)
+				mockRepo.AssertNotCalled(t, "UpdateLastUsed", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "key-1", key.ID)
+			assert.Equal(t, activeUser, user)
+			assert.NotNil(t, key.LastUsedAt)
+			mockRepo.AssertCalled(t, "UpdateLastUsed", ctx, "key-1", mock.AnythingOfType("time.Time"))
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// DefaultMaxAttachmentSize is the largest attachment accepted when no limit is configured (10MB)
+const DefaultMaxAttachmentSize int64 = 10 << 20
+
+// sniffLength is how much of an upload http.DetectContentType looks at
+const sniffLength = 512
+
+// attachmentService implements the AttachmentService interface defined in handlers package
+type attachmentService struct {
+	repo     ExpenseAttachmentRepository
+	expenses MedicalExpenseRepository
+	storage  BlobStorage
+	maxSize  int64
+}
+
+// NewAttachmentService creates a new attachment service instance
+// A non-positive maxSize uses DefaultMaxAttachmentSize
+// Returns concrete type that implements AttachmentService interface defined in handlers package
+func NewAttachmentService(repo ExpenseAttachmentRepository, expenses MedicalExpenseRepository, storage BlobStorage, maxSize int64) *attachmentService {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxAttachmentSize
+	}
+	return &attachmentService{
+		repo:     repo,
+		expenses: expenses,
+		storage:  storage,
+		maxSize:  maxSize,
+	}
+}
+
+// MaxAttachmentSize returns the largest attachment, in bytes, AddAttachment accepts
+func (s *attachmentService) MaxAttachmentSize() int64 {
+	return s.maxSize
+}
+
+// AddAttachment stores content as an attachment of one of the user's medical expenses.
+// The content type is detected from the content itself rather than trusted from the
+// client, and the file is stored under a generated key; fileName is only sanitized and
+// kept for downloads.
+func (s *attachmentService) AddAttachment(ctx context.Context, userID, expenseID, fileName string, content io.Reader) (domain.ExpenseAttachment, error) {
+	if _, err := s.getOwnedExpense(ctx, userID, expenseID); err != nil {
+		return domain.ExpenseAttachment{}, err
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return domain.ExpenseAttachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !domain.IsAllowedAttachmentContentType(contentType) {
+		return domain.ExpenseAttachment{}, fmt.Errorf("%w: %s", domain.ErrUnsupportedAttachmentType, contentType)
+	}
+
+	hash := sha256.New()
+	body := &limitedAttachmentReader{
+		reader:    io.TeeReader(io.MultiReader(bytes.NewReader(head), content), hash),
+		remaining: s.maxSize,
+	}
+
+	key := uuid.New().String()
+	if err := s.storage.Put(ctx, key, body); err != nil {
+		return domain.ExpenseAttachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	attachment := domain.ExpenseAttachment{
+		UserID:      userID,
+		ExpenseID:   expenseID,
+		FileName:    domain.SanitizeAttachmentFileName(fileName),
+		ContentType: contentType,
+		Size:        body.read,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		StorageKey:  key,
+	}
+	if err := s.repo.Create(ctx, &attachment); err != nil {
+		s.deleteBlob(ctx, key)
+		return domain.ExpenseAttachment{}, fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachments retrieves the attachments of one of the user's medical expenses
+func (s *attachmentService) GetAttachments(ctx context.Context, userID, expenseID string) ([]domain.ExpenseAttachment, error) {
+	if _, err := s.getOwnedExpense(ctx, userID, expenseID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByExpenseID(ctx, expenseID)
+}
+
+// OpenAttachment retrieves an attachment of one of the user's medical expenses together
+// with its content, which the caller must close
+func (s *attachmentService) OpenAttachment(ctx context.Context, userID, expenseID, attachmentID string) (domain.ExpenseAttachment, io.ReadCloser, error) {
+	attachment, err := s.getOwnedAttachment(ctx, userID, expenseID, attachmentID)
+	if err != nil {
+		return domain.ExpenseAttachment{}, nil, err
+	}
+
+	content, err := s.storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return domain.ExpenseAttachment{}, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment of one of the user's medical expenses.
+// The metadata is removed first so the attachment disappears even if deleting the file fails.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, userID, expenseID, attachmentID string) error {
+	attachment, err := s.getOwnedAttachment(ctx, userID, expenseID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, attachment.ID); err != nil {
+		return err
+	}
+
+	s.deleteBlob(ctx, attachment.StorageKey)
+	return nil
+}
+
+// getOwnedExpense retrieves a medical expense, reporting other users' expenses as missing so IDs can't be probed
+func (s *attachmentService) getOwnedExpense(ctx context.Context, userID, expenseID string) (*domain.MedicalExpense, error) {
+	expense, err := s.expenses.GetByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense.UserID != userID {
+		return nil, domain.ErrMedicalExpenseNotFound
+	}
+	return expense, nil
+}
+
+// getOwnedAttachment retrieves an attachment of one of the user's medical expenses
+func (s *attachmentService) getOwnedAttachment(ctx context.Context, userID, expenseID, attachmentID string) (domain.ExpenseAttachment, error) {
+	if _, err := s.getOwnedExpense(ctx, userID, expenseID); err != nil {
+		return domain.ExpenseAttachment{}, err
+	}
+
+	attachment, err := s.repo.GetByID(ctx, attachmentID)
+	if err != nil {
+		return domain.ExpenseAttachment{}, err
+	}
+	if attachment.ExpenseID != expenseID || attachment.UserID != userID {
+		return domain.ExpenseAttachment{}, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// deleteBlob removes a stored file on a best-effort basis; a file left behind only costs disk space
+func (s *attachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.storage.Delete(ctx, key); err != nil {
+		if logger := logging.ServiceLogger(); logger != nil {
+			logger.Warn("Failed to delete attachment file",
+				logging.WithOperation("delete_attachment"),
+				zap.String("storage_key", key),
+				logging.WithError(err))
+		}
+	}
+}
+
+// limitedAttachmentReader fails with domain.ErrAttachmentTooLarge once more than remaining
+// bytes are read, so oversized uploads are rejected while they are being stored
+type limitedAttachmentReader struct {
+	reader    io.Reader
+	remaining int64
+	read      int64
+}
+
+func (r *limitedAttachmentReader) Read(p []byte) (int, error) {
+	// Read one byte past the limit to tell an upload of exactly the limit from a larger one
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.read += int64(n)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, domain.ErrAttachmentTooLarge
+	}
+	return n, err
+}
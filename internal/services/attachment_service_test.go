@@ -0,0 +1,236 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// memoryAttachmentRepository keeps attachment metadata in memory for attachment service tests
+type memoryAttachmentRepository struct {
+	mu          sync.Mutex
+	attachments map[string]domain.ExpenseAttachment
+	nextID      int
+}
+
+func newMemoryAttachmentRepository() *memoryAttachmentRepository {
+	return &memoryAttachmentRepository{attachments: map[string]domain.ExpenseAttachment{}}
+}
+
+func (r *memoryAttachmentRepository) Create(ctx context.Context, attachment *domain.ExpenseAttachment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	attachment.ID = fmt.Sprintf("%d", r.nextID)
+	attachment.CreatedAt = time.Now()
+	r.attachments[attachment.ID] = *attachment
+	return nil
+}
+
+func (r *memoryAttachmentRepository) GetByID(ctx context.Context, id string) (domain.ExpenseAttachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	attachment, ok := r.attachments[id]
+	if !ok {
+		return domain.ExpenseAttachment{}, domain.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+func (r *memoryAttachmentRepository) GetByExpenseID(ctx context.Context, expenseID string) ([]domain.ExpenseAttachment, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attachments []domain.ExpenseAttachment
+	for _, attachment := range r.attachments {
+		if attachment.ExpenseID == expenseID {
+			attachments = append(attachments, attachment)
+		}
+	}
+	return attachments, nil
+}
+
+func (r *memoryAttachmentRepository) GetStorageKeysByUserID(ctx context.Context, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var keys []string
+	for _, attachment := range r.attachments {
+		if attachment.UserID == userID {
+			keys = append(keys, attachment.StorageKey)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAttachmentRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.attachments[id]; !ok {
+		return domain.ErrAttachmentNotFound
+	}
+	delete(r.attachments, id)
+	return nil
+}
+
+// memoryBlobStorage keeps attachment files in memory for attachment service tests
+type memoryBlobStorage struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func newMemoryBlobStorage() *memoryBlobStorage {
+	return &memoryBlobStorage{blobs: map[string][]byte{}}
+}
+
+func (s *memoryBlobStorage) Put(ctx context.Context, key string, content io.Reader) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.blobs[key] = data
+	return nil
+}
+
+func (s *memoryBlobStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.blobs[key]
+	if !ok {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *memoryBlobStorage) Delete(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.blobs, key)
+	return nil
+}
+
+func (s *memoryBlobStorage) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.blobs)
+}
+
+// testPDF returns size bytes that are detected as a PDF
+func testPDF(size int) []byte {
+	content := []byte("%PDF-1.7\n")
+	return append(content, bytes.Repeat([]byte("0"), size-len(content))...)
+}
+
+func setupAttachmentService(maxSize int64) (*attachmentService, *MockMedicalExpenseRepository, *memoryAttachmentRepository, *memoryBlobStorage) {
+	expenses := new(MockMedicalExpenseRepository)
+	expenses.On("GetByID", mock.Anything, "7").Return(&domain.MedicalExpense{ID: "7", UserID: "user-1"}, nil)
+	expenses.On("GetByID", mock.Anything, "8").Return(&domain.MedicalExpense{ID: "8", UserID: "user-2"}, nil)
+	repo := newMemoryAttachmentRepository()
+	storage := newMemoryBlobStorage()
+	return NewAttachmentService(repo, expenses, storage, maxSize), expenses, repo, storage
+}
+
+func TestAttachmentService_AddAttachment_StoresFileUnderGeneratedKey(t *testing.T) {
+	service, _, repo, storage := setupAttachmentService(1024)
+	content := testPDF(1024)
+
+	attachment, err := service.AddAttachment(context.Background(), "user-1", "7", "../../etc/receipt.pdf", bytes.NewReader(content))
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, attachment.ID)
+	assert.Equal(t, "receipt.pdf", attachment.FileName)
+	assert.Equal(t, domain.AttachmentContentTypePDF, attachment.ContentType)
+	assert.Equal(t, int64(1024), attachment.Size)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), attachment.Checksum)
+	assert.NotContains(t, attachment.StorageKey, "receipt")
+
+	saved, err := repo.GetByID(context.Background(), attachment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, attachment, saved)
+	assert.Equal(t, content, storage.blobs[attachment.StorageKey])
+}
+
+func TestAttachmentService_AddAttachment_RejectsOversizedUpload(t *testing.T) {
+	service, _, repo, storage := setupAttachmentService(1024)
+
+	_, err := service.AddAttachment(context.Background(), "user-1", "7", "receipt.pdf", bytes.NewReader(testPDF(1025)))
+
+	assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+	assert.Empty(t, repo.attachments)
+	assert.Zero(t, storage.count())
+}
+
+func TestAttachmentService_AddAttachment_RejectsUnsupportedContentType(t *testing.T) {
+	service, _, repo, storage := setupAttachmentService(1024)
+
+	// The claimed filename doesn't matter; the content is sniffed
+	_, err := service.AddAttachment(context.Background(), "user-1", "7", "receipt.pdf", strings.NewReader("<html><body>not a receipt</body></html>"))
+
+	assert.ErrorIs(t, err, domain.ErrUnsupportedAttachmentType)
+	assert.Empty(t, repo.attachments)
+	assert.Zero(t, storage.count())
+}
+
+func TestAttachmentService_OtherUsersExpensesAndAttachmentsAreHidden(t *testing.T) {
+	service, _, _, _ := setupAttachmentService(1024)
+	ctx := context.Background()
+
+	_, err := service.AddAttachment(ctx, "user-1", "8", "receipt.pdf", bytes.NewReader(testPDF(100)))
+	assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+
+	attachment, err := service.AddAttachment(ctx, "user-1", "7", "receipt.pdf", bytes.NewReader(testPDF(100)))
+	require.NoError(t, err)
+
+	// Another user can't reach it, and it can't be reached through another expense
+	_, _, err = service.OpenAttachment(ctx, "user-2", "7", attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+	_, _, err = service.OpenAttachment(ctx, "user-2", "8", attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+	assert.ErrorIs(t, service.DeleteAttachment(ctx, "user-2", "8", attachment.ID), domain.ErrAttachmentNotFound)
+}
+
+func TestAttachmentService_OpenAndDeleteAttachment(t *testing.T) {
+	service, _, _, storage := setupAttachmentService(1024)
+	ctx := context.Background()
+	content := testPDF(100)
+
+	attachment, err := service.AddAttachment(ctx, "user-1", "7", "receipt.pdf", bytes.NewReader(content))
+	require.NoError(t, err)
+
+	opened, reader, err := service.OpenAttachment(ctx, "user-1", "7", attachment.ID)
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, reader.Close())
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+	assert.Equal(t, attachment.ID, opened.ID)
+
+	attachments, err := service.GetAttachments(ctx, "user-1", "7")
+	require.NoError(t, err)
+	assert.Len(t, attachments, 1)
+
+	require.NoError(t, service.DeleteAttachment(ctx, "user-1", "7", attachment.ID))
+	assert.Zero(t, storage.count())
+	_, _, err = service.OpenAttachment(ctx, "user-1", "7", attachment.ID)
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+}
+
+func TestNewAttachmentService_DefaultsMaxSize(t *testing.T) {
+	service := NewAttachmentService(newMemoryAttachmentRepository(), new(MockMedicalExpenseRepository), newMemoryBlobStorage(), 0)
+
+	assert.Equal(t, DefaultMaxAttachmentSize, service.MaxAttachmentSize())
+}
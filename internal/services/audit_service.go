@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+const (
+	// DefaultAuditPageSize is used when an audit log request doesn't specify a limit
+	DefaultAuditPageSize = 50
+	// MaxAuditPageSize caps the limit of audit log requests
+	MaxAuditPageSize = 100
+)
+
+// RequestInfo describes the request an action was made in, for the audit log.
+// The HTTP middleware stores it in the request context; see WithRequestInfo.
+type RequestInfo struct {
+	RequestID string
+	UserID    string
+	IPAddress string
+	UserAgent string
+}
+
+// requestInfoKey is the context key under which RequestInfo is stored
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of ctx carrying info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// WithRequestUser returns a copy of ctx whose RequestInfo names userID as the acting user,
+// keeping the rest of any RequestInfo already in ctx
+func WithRequestUser(ctx context.Context, userID string) context.Context {
+	info := RequestInfoFromContext(ctx)
+	info.UserID = userID
+	return WithRequestInfo(ctx, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo stored in ctx, or the zero value if there is none
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info
+}
+
+// nopAuditRecorder discards entries; services use it until an AuditRecorder is configured
+type nopAuditRecorder struct{}
+
+func (nopAuditRecorder) Record(context.Context, string, string, string, interface{}, interface{}) {}
+
+// AuditServiceConfig holds the queue settings of an AuditService.
+// Zero values fall back to DefaultAuditServiceConfig.
+type AuditServiceConfig struct {
+	QueueSize int
+	// BatchSize is the most entries written in one insert
+	BatchSize    int
+	WriteTimeout time.Duration
+}
+
+// DefaultAuditServiceConfig returns the default queue settings
+func DefaultAuditServiceConfig() AuditServiceConfig {
+	return AuditServiceConfig{
+		QueueSize:    1024,
+		BatchSize:    100,
+		WriteTimeout: 5 * time.Second,
+	}
+}
+
+// AuditService keeps the audit trail of sensitive actions. Record queues entries and a
+// background worker writes them in batches, so auditing never blocks the request that
+// made the change. Stop writes out everything still queued.
+type AuditService struct {
+	repo   AuditLogRepository
+	config AuditServiceConfig
+	queue  chan domain.AuditEntry
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	worker    sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+// NewAuditService creates an audit service; call Start to begin writing entries
+func NewAuditService(repo AuditLogRepository, config AuditServiceConfig) *AuditService {
+	defaults := DefaultAuditServiceConfig()
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = defaults.WriteTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AuditService{
+		repo:   repo,
+		config: config,
+		queue:  make(chan domain.AuditEntry, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Start launches the background writer
+func (s *AuditService) Start() {
+	s.startOnce.Do(func() {
+		s.worker.Add(1)
+		go s.work()
+	})
+}
+
+// Stop writes out the entries still queued and stops the writer.
+// Call it after the HTTP server has shut down so no more entries arrive.
+func (s *AuditService) Stop() {
+	s.stopOnce.Do(func() {
+		s.cancel()
+		s.worker.Wait()
+	})
+}
+
+// Record queues an audit entry for action on a resource without blocking.
+// before and after are snapshots of the resource, either of which may be nil; only the
+// fields that differ are kept, with sensitive values redacted. The acting user, request
+// ID, IP address and user agent are taken from the RequestInfo in ctx.
+// The entry is dropped, and a warning logged, if the queue is full.
+func (s *AuditService) Record(ctx context.Context, action, resourceType, resourceID string, before, after interface{}) {
+	info := RequestInfoFromContext(ctx)
+	entry := domain.AuditEntry{
+		UserID:       info.UserID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		RequestID:    info.RequestID,
+		IPAddress:    info.IPAddress,
+		UserAgent:    info.UserAgent,
+		Changes:      domain.AuditDiff(before, after),
+		CreatedAt:    time.Now().UTC(),
+	}
+
+	select {
+	case s.queue <- entry:
+	default:
+		s.logger().Warn("Audit queue full, entry dropped",
+			zap.String("action", action),
+			zap.String("resource_type", resourceType),
+			zap.String("resource_id", resourceID),
+			logging.WithUserID(info.UserID))
+	}
+}
+
+// GetAuditLogPage retrieves one page of audit entries, newest first, and the cursor for the next page
+// userID limits the entries to one user; "" returns every user's entries
+// cursor is a token returned by a previous page, or "" for the first page; next is "" on the last page
+// Returns an error wrapping domain.ErrInvalidCursor if the cursor is malformed
+func (s *AuditService) GetAuditLogPage(ctx context.Context, userID, cursor string, limit int) ([]domain.AuditEntry, string, error) {
+	before, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = auditPageSize(limit)
+
+	// Fetch one extra row to learn whether another page follows
+	entries, err := s.repo.GetEntriesBefore(ctx, userID, before, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(entries) <= limit {
+		return entries, "", nil
+	}
+
+	entries = entries[:limit]
+	last := entries[limit-1]
+	return entries, domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode(), nil
+}
+
+// auditPageSize applies the default and maximum to a requested page size
+func auditPageSize(limit int) int {
+	if limit < 1 {
+		return DefaultAuditPageSize
+	}
+	if limit > MaxAuditPageSize {
+		return MaxAuditPageSize
+	}
+	return limit
+}
+
+func (s *AuditService) work() {
+	defer s.worker.Done()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			s.drain()
+			return
+		case entry := <-s.queue:
+			s.write(s.collect(entry))
+		}
+	}
+}
+
+// collect gathers entries that are already queued behind first, up to BatchSize
+func (s *AuditService) collect(first domain.AuditEntry) []domain.AuditEntry {
+	batch := []domain.AuditEntry{first}
+	for len(batch) < s.config.BatchSize {
+		select {
+		case entry := <-s.queue:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+// drain writes out everything left in the queue while stopping
+func (s *AuditService) drain() {
+	for {
+		select {
+		case entry := <-s.queue:
+			s.write(s.collect(entry))
+		default:
+			return
+		}
+	}
+}
+
+// write saves a batch; failures are only logged since the audited actions already happened
+func (s *AuditService) write(batch []domain.AuditEntry) {
+	// Not derived from s.ctx so entries are still written while stopping
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.WriteTimeout)
+	defer cancel()
+
+	if err := s.repo.SaveEntries(ctx, batch); err != nil {
+		s.logger().Error("Failed to write audit entries",
+			zap.Int("entries", len(batch)),
+			logging.WithError(err))
+	}
+}
+
+func (s *AuditService) logger() *zap.Logger {
+	if logger := logging.ServiceLogger(); logger != nil {
+		return logger.With(logging.WithOperation("audit"))
+	}
+	return zap.NewNop()
+}
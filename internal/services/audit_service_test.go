@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditLogRepository keeps audit entries in memory for audit service tests
+type memoryAuditLogRepository struct {
+	mu      sync.Mutex
+	entries []domain.AuditEntry
+	batches int
+}
+
+func (r *memoryAuditLogRepository) SaveEntries(ctx context.Context, entries []domain.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, entry := range entries {
+		if entry.ID == "" {
+			entry.ID = "audit-" + entry.CreatedAt.Format(time.RFC3339Nano)
+		}
+		r.entries = append(r.entries, entry)
+	}
+	r.batches++
+	return nil
+}
+
+func (r *memoryAuditLogRepository) GetEntriesBefore(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.AuditEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []domain.AuditEntry
+	for _, entry := range r.entries {
+		if userID != "" && entry.UserID != userID {
+			continue
+		}
+		if !cursor.IsZero() && !entry.CreatedAt.Before(cursor.CreatedAt) &&
+			!(entry.CreatedAt.Equal(cursor.CreatedAt) && entry.ID < cursor.ID) {
+			continue
+		}
+		matched = append(matched, entry)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+func (r *memoryAuditLogRepository) saved() []domain.AuditEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]domain.AuditEntry(nil), r.entries...)
+}
+
+// recordedAudit is one call to recordingAuditRecorder.Record
+type recordedAudit struct {
+	UserID       string
+	Action       string
+	ResourceType string
+	ResourceID   string
+	Changes      map[string]domain.AuditChange
+}
+
+// recordingAuditRecorder keeps the entries services record so tests can check them
+type recordingAuditRecorder struct {
+	mu      sync.Mutex
+	records []recordedAudit
+}
+
+func (r *recordingAuditRecorder) Record(ctx context.Context, action, resourceType, resourceID string, before, after interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, recordedAudit{
+		UserID:       RequestInfoFromContext(ctx).UserID,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Changes:      domain.AuditDiff(before, after),
+	})
+}
+
+func TestAuditService_Record_CapturesRequestInfoAndFlushesOnStop(t *testing.T) {
+	repo := &memoryAuditLogRepository{}
+	service := NewAuditService(repo, AuditServiceConfig{})
+	service.Start()
+
+	ctx := WithRequestInfo(context.Background(), RequestInfo{RequestID: "req-1", IPAddress: "203.0.113.7", UserAgent: "curl/8.0"})
+	ctx = WithRequestUser(ctx, "user-1")
+
+	before := domain.Income{ID: "income-1", UserID: "user-1", Source: "Salary", Amount: 5000}
+	after := before
+	after.Amount = 5500
+	service.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceIncome, "income-1", before, after)
+	service.Stop()
+
+	entries := repo.saved()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	assert.Equal(t, "user-1", entry.UserID)
+	assert.Equal(t, "req-1", entry.RequestID)
+	assert.Equal(t, "203.0.113.7", entry.IPAddress)
+	assert.Equal(t, "curl/8.0", entry.UserAgent)
+	assert.Equal(t, domain.AuditActionUpdate, entry.Action)
+	assert.Equal(t, map[string]domain.AuditChange{"amount": {Before: 5000.0, After: 5500.0}}, entry.Changes)
+	assert.False(t, entry.CreatedAt.IsZero())
+}
+
+func TestAuditService_Record_DropsEntriesWhenQueueIsFull(t *testing.T) {
+	repo := &memoryAuditLogRepository{}
+	// Not started, so nothing drains the queue
+	service := NewAuditService(repo, AuditServiceConfig{QueueSize: 2})
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			service.Record(context.Background(), domain.AuditActionLogin, domain.AuditResourceUser, "user-1", nil, nil)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Record blocked on a full queue")
+	}
+
+	// Stop still writes out what was queued
+	service.Start()
+	service.Stop()
+	assert.Len(t, repo.saved(), 2)
+}
+
+func TestAuditService_Stop_WritesQueuedEntriesInBatches(t *testing.T) {
+	repo := &memoryAuditLogRepository{}
+	service := NewAuditService(repo, AuditServiceConfig{QueueSize: 10, BatchSize: 4})
+
+	for i := 0; i < 10; i++ {
+		service.Record(context.Background(), domain.AuditActionCreate, domain.AuditResourceExpense, "", nil, nil)
+	}
+	service.Start()
+	service.Stop()
+	service.Stop()
+
+	assert.Len(t, repo.saved(), 10)
+	assert.GreaterOrEqual(t, repo.batches, 3)
+}
+
+func TestAuditService_GetAuditLogPage(t *testing.T) {
+	repo := &memoryAuditLogRepository{}
+	base := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		repo.entries = append(repo.entries, domain.AuditEntry{
+			ID:        "audit-" + string(rune('a'+i)),
+			UserID:    "user-1",
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+	repo.entries = append(repo.entries, domain.AuditEntry{ID: "audit-z", UserID: "user-2", CreatedAt: base})
+	service := NewAuditService(repo, AuditServiceConfig{})
+	ctx := context.Background()
+
+	first, next, err := service.GetAuditLogPage(ctx, "user-1", "", 3)
+	require.NoError(t, err)
+	require.Len(t, first, 3)
+	assert.Equal(t, "audit-e", first[0].ID)
+	require.NotEmpty(t, next)
+
+	second, next, err := service.GetAuditLogPage(ctx, "user-1", next, 3)
+	require.NoError(t, err)
+	require.Len(t, second, 2)
+	assert.Equal(t, "audit-b", second[0].ID)
+	assert.Empty(t, next)
+
+	all, _, err := service.GetAuditLogPage(ctx, "", "", 0)
+	require.NoError(t, err)
+	assert.Len(t, all, 6)
+
+	_, _, err = service.GetAuditLogPage(ctx, "user-1", "not-a-cursor", 3)
+	assert.True(t, errors.Is(err, domain.ErrInvalidCursor))
+}
+
+func TestAuditPageSize(t *testing.T) {
+	assert.Equal(t, DefaultAuditPageSize, auditPageSize(0))
+	assert.Equal(t, 10, auditPageSize(10))
+	assert.Equal(t, MaxAuditPageSize, auditPageSize(MaxAuditPageSize+1))
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
 )
 
+// dummyPasswordHash is a bcrypt hash, at the password service's cost, that a login for an
+// unknown email is checked against so it takes as long as one with a wrong password
+const dummyPasswordHash = "$2a$14$Ghq4MzojkbTmMOCkAwrRPufjKOVU35S7DWjwj7gahJFk2DDLYeQLm"
+
 // authService implements the AuthService interface defined in handlers package
 // Following the consumer-defined interface principle
 
@@ -19,6 +26,49 @@ type authService struct {
 	tokenRepo       TokenRepository
 	passwordService PasswordService
 	jwtService      JWTService
+	txManager       TxManager
+	audit           AuditRecorder
+	passwordPolicy  PasswordPolicy
+	// healthProfiles deletes the user's health profiles when their account is deleted
+	healthProfiles HealthProfileRepository
+	// attachments and attachmentStorage find and remove the user's attachment files when their
+	// account is deleted
+	attachments       ExpenseAttachmentRepository
+	attachmentStorage BlobStorage
+}
+
+// AuthServiceOption customizes an auth service created by NewAuthService
+type AuthServiceOption func(*authService)
+
+// WithAuthAuditRecorder records registrations, logins, failed logins and logouts in the audit log
+func WithAuthAuditRecorder(recorder AuditRecorder) AuthServiceOption {
+	return func(a *authService) {
+		a.audit = recorder
+	}
+}
+
+// WithPasswordPolicy sets the rules a password must follow at registration; the default is DefaultPasswordPolicy
+func WithPasswordPolicy(policy PasswordPolicy) AuthServiceOption {
+	return func(a *authService) {
+		a.passwordPolicy = policy
+	}
+}
+
+// WithAuthHealthProfileRepository deletes the user's health profiles through repo when their
+// account is deleted, cascading to the records under them the way deleting a profile does
+func WithAuthHealthProfileRepository(repo HealthProfileRepository) AuthServiceOption {
+	return func(a *authService) {
+		a.healthProfiles = repo
+	}
+}
+
+// WithAuthAttachmentStorage removes the files of the user's expense attachments from storage
+// once their account has been deleted
+func WithAuthAttachmentStorage(repo ExpenseAttachmentRepository, storage BlobStorage) AuthServiceOption {
+	return func(a *authService) {
+		a.attachments = repo
+		a.attachmentStorage = storage
+	}
 }
 
 // NewAuthService creates a new authentication service instance
@@ -28,13 +78,22 @@ func NewAuthService(
 	tokenRepo TokenRepository,
 	passwordService PasswordService,
 	jwtService JWTService,
+	txManager TxManager,
+	opts ...AuthServiceOption,
 ) *authService {
-	return &authService{
+	a := &authService{
 		userRepo:        userRepo,
 		tokenRepo:       tokenRepo,
 		passwordService: passwordService,
 		jwtService:      jwtService,
+		txManager:       txManager,
+		audit:           nopAuditRecorder{},
+		passwordPolicy:  DefaultPasswordPolicy(),
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 // Login authenticates a user with email and password
@@ -51,25 +110,27 @@ func (a *authService) Login(ctx context.Context, credentials domain.Credentials)
 	user, err := a.userRepo.GetByEmail(ctx, credentials.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			return nil, domain.ErrInvalidCredentials
+			// Hash anyway so the response time doesn't reveal that the email isn't registered
+			_ = a.passwordService.CheckPassword(dummyPasswordHash, credentials.Password)
+			return nil, a.rejectLogin(ctx, logger, "", credentials.Email, "unknown_email")
 		}
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
 	// Check if account is active
 	if !user.IsActive {
+		a.recordFailedLogin(ctx, user.ID, credentials.Email, "account_inactive")
 		return nil, domain.ErrAccountInactive
 	}
 
 	// Verify password
 	logger.Debug("Verifying user password")
 	if err := a.passwordService.CheckPassword(user.PasswordHash, credentials.Password); err != nil {
-		logger.Warn("Password verification failed")
-		return nil, domain.ErrInvalidCredentials
+		return nil, a.rejectLogin(ctx, logger, user.ID, credentials.Email, "invalid_password")
 	}
 
 	// Generate token pair
-	tokenPair, err := a.jwtService.GenerateTokenPair(user.ID, user.Email)
+	tokenPair, err := a.jwtService.GenerateTokenPair(user.ID, user.Email, user.EffectiveRole())
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate tokens: %w", err)
 	}
@@ -87,9 +148,26 @@ func (a *authService) Login(ctx context.Context, credentials domain.Credentials)
 	}
 
 	logger.Info("User authentication successful")
+	a.audit.Record(WithRequestUser(ctx, user.ID), domain.AuditActionLogin, domain.AuditResourceUser, user.ID, nil, nil)
 	return tokenPair, nil
 }
 
+// rejectLogin logs and audits a login refused for bad credentials and returns
+// domain.ErrInvalidCredentials. The warning is the same for an unknown email and a wrong
+// password; only the debug entry says which it was.
+func (a *authService) rejectLogin(ctx context.Context, logger *zap.Logger, userID, email, reason string) error {
+	logger.Debug("Login rejected", zap.String("reason", reason))
+	logger.Warn("Login failed: invalid credentials")
+	a.recordFailedLogin(ctx, userID, email, reason)
+	return domain.ErrInvalidCredentials
+}
+
+// recordFailedLogin audits a rejected login; userID is "" when no account has the email
+func (a *authService) recordFailedLogin(ctx context.Context, userID, email, reason string) {
+	a.audit.Record(WithRequestUser(ctx, userID), domain.AuditActionLoginFailed, domain.AuditResourceUser, userID,
+		nil, map[string]interface{}{"email": email, "reason": reason})
+}
+
 // Register creates a new user account and returns authentication tokens
 func (a *authService) Register(ctx context.Context, user *domain.User, password string) (*domain.TokenPair, error) {
 	// Validate input parameters
@@ -110,6 +188,9 @@ func (a *authService) Register(ctx context.Context, user *domain.User, password
 	if err := credentials.Validate(); err != nil {
 		return nil, domain.ErrInvalidUserData
 	}
+	if err := a.passwordPolicy.Check(password); err != nil {
+		return nil, err
+	}
 
 	// Check if user already exists
 	existingUser, err := a.userRepo.GetByEmail(ctx, user.Email)
@@ -130,26 +211,40 @@ func (a *authService) Register(ctx context.Context, user *domain.User, password
 	now := time.Now()
 	user.PasswordHash = hashedPassword
 	user.IsActive = true
+	// Roles are never chosen at registration; admins are promoted separately
+	user.Role = domain.RoleUser
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	// Create user
-	if err := a.userRepo.Create(ctx, user); err != nil {
-		return nil, fmt.Errorf("failed to create user: %w", err)
-	}
+	// The user and their first refresh token are saved together so a failed token
+	// save does not leave behind an account that cannot be registered again
+	var tokenPair *domain.TokenPair
+	err = a.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// Create user
+		if err := a.userRepo.Create(ctx, user); err != nil {
+			return fmt.Errorf("failed to create user: %w", err)
+		}
 
-	// Generate token pair
-	tokenPair, err := a.jwtService.GenerateTokenPair(user.ID, user.Email)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate tokens: %w", err)
-	}
+		// Generate token pair
+		var err error
+		tokenPair, err = a.jwtService.GenerateTokenPair(user.ID, user.Email, user.EffectiveRole())
+		if err != nil {
+			return fmt.Errorf("failed to generate tokens: %w", err)
+		}
 
-	// Save refresh token
-	refreshExpiry := time.Now().Add(7 * 24 * time.Hour) // 7 days
-	if err := a.tokenRepo.SaveRefreshToken(ctx, user.ID, tokenPair.RefreshToken, refreshExpiry); err != nil {
-		return nil, fmt.Errorf("failed to save refresh token: %w", err)
+		// Save refresh token
+		refreshExpiry := time.Now().Add(7 * 24 * time.Hour) // 7 days
+		if err := a.tokenRepo.SaveRefreshToken(ctx, user.ID, tokenPair.RefreshToken, refreshExpiry); err != nil {
+			return fmt.Errorf("failed to save refresh token: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	a.audit.Record(WithRequestUser(ctx, user.ID), domain.AuditActionCreate, domain.AuditResourceUser, user.ID, nil, user)
 	return tokenPair, nil
 }
 
@@ -184,21 +279,32 @@ func (a *authService) RefreshToken(ctx context.Context, refreshToken string) (*d
 		return nil, domain.ErrAccountInactive
 	}
 
-	// Revoke old refresh token
-	if err := a.tokenRepo.RevokeToken(ctx, refreshToken); err != nil {
-		return nil, fmt.Errorf("failed to revoke old token: %w", err)
-	}
+	// Rotate the token atomically: if the replacement cannot be saved the old token stays valid
+	// rather than logging the user out
+	var newTokenPair *domain.TokenPair
+	err = a.txManager.WithTx(ctx, func(ctx context.Context) error {
+		// Revoke old refresh token
+		if err := a.tokenRepo.RevokeToken(ctx, refreshToken); err != nil {
+			return fmt.Errorf("failed to revoke old token: %w", err)
+		}
 
-	// Generate new token pair
-	newTokenPair, err := a.jwtService.GenerateTokenPair(user.ID, user.Email)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate new tokens: %w", err)
-	}
+		// Generate new token pair
+		var err error
+		newTokenPair, err = a.jwtService.GenerateTokenPair(user.ID, user.Email, user.EffectiveRole())
+		if err != nil {
+			return fmt.Errorf("failed to generate new tokens: %w", err)
+		}
 
-	// Save new refresh token
-	refreshExpiry := time.Now().Add(7 * 24 * time.Hour) // 7 days
-	if err := a.tokenRepo.SaveRefreshToken(ctx, user.ID, newTokenPair.RefreshToken, refreshExpiry); err != nil {
-		return nil, fmt.Errorf("failed to save new refresh token: %w", err)
+		// Save new refresh token
+		refreshExpiry := time.Now().Add(7 * 24 * time.Hour) // 7 days
+		if err := a.tokenRepo.SaveRefreshToken(ctx, user.ID, newTokenPair.RefreshToken, refreshExpiry); err != nil {
+			return fmt.Errorf("failed to save new refresh token: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return newTokenPair, nil
@@ -211,7 +317,7 @@ func (a *authService) Logout(ctx context.Context, refreshToken string) error {
 	}
 
 	// Validate refresh token format
-	_, err := a.jwtService.ValidateRefreshToken(refreshToken)
+	claims, err := a.jwtService.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return err // Pass through the specific error (expired, invalid, etc.)
 	}
@@ -221,5 +327,119 @@ func (a *authService) Logout(ctx context.Context, refreshToken string) error {
 		return fmt.Errorf("failed to revoke token: %w", err)
 	}
 
+	// Logout is unauthenticated, so the user comes from the token rather than the request
+	if claims != nil {
+		ctx = WithRequestUser(ctx, claims.UserID)
+	}
+	a.audit.Record(ctx, domain.AuditActionTokenRevoke, domain.AuditResourceRefreshToken, "",
+		nil, map[string]interface{}{"reason": "logout"})
+	return nil
+}
+
+// CheckTokenUser confirms that userID still belongs to an active account with the given role, so
+// access tokens of deleted or deactivated users, or issued before a role change, are rejected
+// before they expire
+func (a *authService) CheckTokenUser(ctx context.Context, userID, role string) error {
+	user, err := a.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidToken
+		}
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if !user.IsActive {
+		return domain.ErrAccountInactive
+	}
+	if user.EffectiveRole() != role {
+		return domain.ErrTokenRoleChanged
+	}
+	return nil
+}
+
+// DeleteAccount permanently deletes the user's account once their password is confirmed. In one
+// transaction the user's health profiles are deleted with everything under them, all of their
+// refresh tokens are revoked, and the user is deleted with the rest of the records they own.
+// The files of their attachments are removed from storage once the transaction has committed.
+// Access tokens already issued are rejected from then on by the JWT middleware's user check.
+func (a *authService) DeleteAccount(ctx context.Context, userID, password string) error {
+	user, err := a.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	if err := a.passwordService.CheckPassword(user.PasswordHash, password); err != nil {
+		return domain.ErrInvalidCredentials
+	}
+
+	var storageKeys []string
+	err = a.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if a.attachments != nil {
+			keys, err := a.attachments.GetStorageKeysByUserID(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("failed to get attachment files: %w", err)
+			}
+			storageKeys = keys
+		}
+		if err := a.deleteHealthProfiles(ctx, userID); err != nil {
+			return err
+		}
+		if err := a.tokenRepo.RevokeAllUserTokens(ctx, userID); err != nil {
+			return fmt.Errorf("failed to revoke sessions: %w", err)
+		}
+		if err := a.userRepo.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("failed to delete account: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	a.deleteAttachmentFiles(ctx, storageKeys)
+
+	a.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceUser, userID, *user, nil)
+	logging.ServiceLogger().Info("Account deleted",
+		logging.WithOperation("delete_account"), logging.WithUserID(userID))
+	return nil
+}
+
+// deleteAttachmentFiles removes the files of a deleted account's attachments on a best-effort
+// basis; a file left behind only costs disk space
+func (a *authService) deleteAttachmentFiles(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := a.attachmentStorage.Delete(ctx, key); err != nil {
+			logging.ServiceLogger().Warn("Failed to delete attachment file",
+				logging.WithOperation("delete_account"),
+				zap.String("storage_key", key),
+				logging.WithError(err))
+		}
+	}
+}
+
+// deleteHealthProfiles deletes the user's health profiles. Deleting the self profile deletes the
+// dependents with it, so the others are only deleted one by one when there is no self profile.
+func (a *authService) deleteHealthProfiles(ctx context.Context, userID string) error {
+	if a.healthProfiles == nil {
+		return nil
+	}
+
+	family, err := a.healthProfiles.GetFamilyByUserID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get health profiles: %w", err)
+	}
+	for _, profile := range family {
+		if profile.RelationToOwner == domain.RelationSelf {
+			family = []*domain.HealthProfile{profile}
+			break
+		}
+	}
+
+	for _, profile := range family {
+		id, err := strconv.ParseUint(profile.ID, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid health profile ID %q: %w", profile.ID, err)
+		}
+		if err := a.healthProfiles.Delete(ctx, uint(id)); err != nil {
+			return fmt.Errorf("failed to delete health profile: %w", err)
+		}
+	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
@@ -8,7 +9,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
@@ -57,6 +60,24 @@ func (m *MockUserRepository) UpdateLastLogin(ctx context.Context, userID string,
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdateRole(ctx context.Context, userID, role string) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, offset, limit int) ([]domain.User, int64, error) {
+	args := m.Called(ctx, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]domain.User), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, userID string) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 // MockTokenRepository is a mock implementation of TokenRepository
 type MockTokenRepository struct {
 	mock.Mock
@@ -82,9 +103,9 @@ func (m *MockTokenRepository) RevokeAllUserTokens(ctx context.Context, userID st
 	return args.Error(0)
 }
 
-func (m *MockTokenRepository) CleanupExpiredTokens(ctx context.Context) error {
+func (m *MockTokenRepository) CleanupExpiredTokens(ctx context.Context) (int64, error) {
 	args := m.Called(ctx)
-	return args.Error(0)
+	return args.Get(0).(int64), args.Error(1)
 }
 
 // MockPasswordService is a mock implementation of PasswordService
@@ -107,8 +128,8 @@ type MockJWTService struct {
 	mock.Mock
 }
 
-func (m *MockJWTService) GenerateTokenPair(userID, email string) (*domain.TokenPair, error) {
-	args := m.Called(userID, email)
+func (m *MockJWTService) GenerateTokenPair(userID, email, role string) (*domain.TokenPair, error) {
+	args := m.Called(userID, email, role)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -131,6 +152,17 @@ func (m *MockJWTService) ValidateRefreshToken(tokenString string) (*domain.Token
 	return args.Get(0).(*domain.TokenClaims), args.Error(1)
 }
 
+func (m *MockJWTService) ActiveKeyID() string {
+	return m.Called().String(0)
+}
+
+// passthroughTxManager runs the function without a transaction; mocks have no state to roll back
+type passthroughTxManager struct{}
+
+func (passthroughTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 // Helper functions for test setup
 func createValidUser() *domain.User {
 	return &domain.User{
@@ -174,7 +206,7 @@ func TestAuthService_Login_ValidCredentials_ReturnsTokens(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -187,7 +219,7 @@ func TestAuthService_Login_ValidCredentials_ReturnsTokens(t *testing.T) {
 
 	userRepo.On("GetByEmail", ctx, credentials.Email).Return(user, nil)
 	passwordService.On("CheckPassword", user.PasswordHash, credentials.Password).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(tokenPair, nil)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(tokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, user.ID, tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil)
 	userRepo.On("UpdateLastLogin", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
 
@@ -208,7 +240,7 @@ func TestAuthService_Login_InvalidEmail_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -217,6 +249,7 @@ func TestAuthService_Login_InvalidEmail_ReturnsError(t *testing.T) {
 	}
 
 	userRepo.On("GetByEmail", ctx, credentials.Email).Return(nil, domain.ErrUserNotFound)
+	passwordService.On("CheckPassword", dummyPasswordHash, credentials.Password).Return(errors.New("password mismatch"))
 
 	// Act
 	result, err := service.Login(ctx, credentials)
@@ -226,8 +259,8 @@ func TestAuthService_Login_InvalidEmail_ReturnsError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, domain.ErrInvalidCredentials, err)
 	userRepo.AssertExpectations(t)
+	passwordService.AssertExpectations(t)
 	tokenRepo.AssertNotCalled(t, "SaveRefreshToken")
-	passwordService.AssertNotCalled(t, "CheckPassword")
 	jwtService.AssertNotCalled(t, "GenerateTokenPair")
 }
 
@@ -236,7 +269,7 @@ func TestAuthService_Login_WrongPassword_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -262,12 +295,110 @@ func TestAuthService_Login_WrongPassword_ReturnsError(t *testing.T) {
 	jwtService.AssertNotCalled(t, "GenerateTokenPair")
 }
 
+// countingPasswordService counts password checks, so tests can tell a path did the hashing work
+type countingPasswordService struct {
+	PasswordService
+	checks int
+}
+
+func (c *countingPasswordService) CheckPassword(hash, password string) error {
+	c.checks++
+	return c.PasswordService.CheckPassword(hash, password)
+}
+
+// Test Login hashes and fails the same way for an unknown email and a wrong password,
+// so neither the error nor the work done reveals whether the email is registered
+func TestAuthService_Login_UnknownEmailMatchesWrongPassword(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, _, jwtService := setupAuthServiceMocks()
+	passwords := &countingPasswordService{PasswordService: &passwordService{cost: bcrypt.MinCost}}
+	service := NewAuthService(userRepo, tokenRepo, passwords, jwtService, passthroughTxManager{})
+	ctx := context.Background()
+
+	user := createValidUser()
+	hash, err := passwords.HashPassword("password123")
+	require.NoError(t, err)
+	user.PasswordHash = hash
+
+	userRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, domain.ErrUserNotFound)
+	userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+
+	// Act
+	_, unknownErr := service.Login(ctx, domain.Credentials{Email: "unknown@example.com", Password: "password123"})
+	unknownChecks := passwords.checks
+	_, wrongErr := service.Login(ctx, domain.Credentials{Email: user.Email, Password: "wrongpassword"})
+	wrongChecks := passwords.checks - unknownChecks
+
+	// Assert
+	assert.Equal(t, 1, unknownChecks)
+	assert.Equal(t, 1, wrongChecks)
+	assert.Equal(t, domain.ErrInvalidCredentials, unknownErr)
+	assert.Equal(t, wrongErr, unknownErr)
+	assert.Equal(t, wrongErr.Error(), unknownErr.Error())
+	jwtService.AssertNotCalled(t, "GenerateTokenPair")
+}
+
+// Test the unknown-email check runs against a real bcrypt hash at the production cost
+func TestDummyPasswordHash_MatchesPasswordServiceCost(t *testing.T) {
+	cost, err := bcrypt.Cost([]byte(dummyPasswordHash))
+	require.NoError(t, err)
+
+	assert.Equal(t, NewPasswordService().(*passwordService).cost, cost)
+}
+
+// Test Login records failed and successful attempts in the audit log
+func TestAuthService_Login_RecordsAudit(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	recorder := &recordingAuditRecorder{}
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{}, WithAuthAuditRecorder(recorder))
+	ctx := context.Background()
+
+	user := createValidUser()
+	tokenPair := createValidTokenPair()
+
+	userRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, domain.ErrUserNotFound)
+	userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	passwordService.On("CheckPassword", dummyPasswordHash, "password123").Return(errors.New("password mismatch"))
+	passwordService.On("CheckPassword", user.PasswordHash, "wrongpassword").Return(errors.New("password mismatch"))
+	passwordService.On("CheckPassword", user.PasswordHash, "password123").Return(nil)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(tokenPair, nil)
+	tokenRepo.On("SaveRefreshToken", ctx, user.ID, tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil)
+	userRepo.On("UpdateLastLogin", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Act
+	_, err := service.Login(ctx, domain.Credentials{Email: "unknown@example.com", Password: "password123"})
+	assert.Equal(t, domain.ErrInvalidCredentials, err)
+	_, err = service.Login(ctx, domain.Credentials{Email: user.Email, Password: "wrongpassword"})
+	assert.Equal(t, domain.ErrInvalidCredentials, err)
+	_, err = service.Login(ctx, domain.Credentials{Email: user.Email, Password: "password123"})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, recorder.records, 3)
+	assert.Equal(t, recordedAudit{
+		Action:       domain.AuditActionLoginFailed,
+		ResourceType: domain.AuditResourceUser,
+		Changes: map[string]domain.AuditChange{
+			"email":  {After: "unknown@example.com"},
+			"reason": {After: "unknown_email"},
+		},
+	}, recorder.records[0])
+	assert.Equal(t, domain.AuditActionLoginFailed, recorder.records[1].Action)
+	assert.Equal(t, user.ID, recorder.records[1].UserID)
+	assert.Equal(t, domain.AuditChange{After: "invalid_password"}, recorder.records[1].Changes["reason"])
+	assert.Equal(t, domain.AuditActionLogin, recorder.records[2].Action)
+	assert.Equal(t, user.ID, recorder.records[2].UserID)
+}
+
 // Test Login with inactive account returns error
 func TestAuthService_Login_InactiveAccount_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -298,7 +429,7 @@ func TestAuthService_Register_ValidData_CreatesUserAndReturnsTokens(t *testing.T
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -316,7 +447,7 @@ func TestAuthService_Register_ValidData_CreatesUserAndReturnsTokens(t *testing.T
 		user := args.Get(1).(*domain.User)
 		user.ID = "2" // Simulate ID assignment
 	})
-	jwtService.On("GenerateTokenPair", "2", user.Email).Return(tokenPair, nil)
+	jwtService.On("GenerateTokenPair", "2", user.Email, domain.RoleUser).Return(tokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, "2", tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil)
 
 	// Act
@@ -331,12 +462,63 @@ func TestAuthService_Register_ValidData_CreatesUserAndReturnsTokens(t *testing.T
 	tokenRepo.AssertExpectations(t)
 }
 
+// Test Register rejects a password that breaks the configured policy before touching the repository
+func TestAuthService_Register_WeakPassword_ReturnsViolations(t *testing.T) {
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	policy := DefaultPasswordPolicy()
+	policy.RequireDigit = true
+	policy.RequireUpper = true
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{},
+		WithPasswordPolicy(policy))
+
+	_, err := service.Register(context.Background(), &domain.User{Email: "newuser@example.com", Name: "New User"}, "password123")
+
+	var weak *domain.WeakPasswordError
+	require.ErrorAs(t, err, &weak)
+	assert.Equal(t, []string{"must contain an uppercase letter"}, weak.Violations)
+	userRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	passwordService.AssertNotCalled(t, "HashPassword", mock.Anything)
+}
+
+// Test Register never lets the caller choose a role
+func TestAuthService_Register_RequestedAdminRole_IsIgnored(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
+	ctx := context.Background()
+
+	user := &domain.User{
+		Email: "sneaky@example.com",
+		Name:  "Sneaky User",
+		Role:  domain.RoleAdmin,
+	}
+	tokenPair := createValidTokenPair()
+
+	userRepo.On("GetByEmail", ctx, user.Email).Return(nil, domain.ErrUserNotFound)
+	passwordService.On("HashPassword", "password123").Return("hashed_password", nil)
+	userRepo.On("Create", ctx, mock.AnythingOfType("*domain.User")).Return(nil).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.User).ID = "3"
+	})
+	jwtService.On("GenerateTokenPair", "3", user.Email, domain.RoleUser).Return(tokenPair, nil)
+	tokenRepo.On("SaveRefreshToken", ctx, "3", tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil)
+
+	// Act
+	_, err := service.Register(ctx, user, "password123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, user.Role)
+	jwtService.AssertExpectations(t)
+}
+
 // Test Register with duplicate email returns error
 func TestAuthService_Register_DuplicateEmail_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -369,7 +551,7 @@ func TestAuthService_Register_PasswordHashingFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -399,7 +581,7 @@ func TestAuthService_RefreshToken_ValidToken_ReturnsNewTokenPair(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -411,7 +593,7 @@ func TestAuthService_RefreshToken_ValidToken_ReturnsNewTokenPair(t *testing.T) {
 	tokenRepo.On("GetRefreshToken", ctx, refreshToken).Return(claims.UserID, nil)
 	userRepo.On("GetByID", ctx, claims.UserID).Return(user, nil)
 	tokenRepo.On("RevokeToken", ctx, refreshToken).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(newTokenPair, nil)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(newTokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, user.ID, newTokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil)
 
 	// Act
@@ -425,12 +607,51 @@ func TestAuthService_RefreshToken_ValidToken_ReturnsNewTokenPair(t *testing.T) {
 	userRepo.AssertExpectations(t)
 }
 
+func TestAuthService_CheckTokenUser(t *testing.T) {
+	inactive := createValidUser()
+	inactive.IsActive = false
+	admin := createValidUser()
+	admin.Role = domain.RoleAdmin
+
+	tests := []struct {
+		name        string
+		user        *domain.User
+		role        string
+		repoErr     error
+		expectedErr error
+	}{
+		{name: "active user", user: createValidUser(), role: domain.RoleUser},
+		{name: "active admin", user: admin, role: domain.RoleAdmin},
+		{name: "deleted user", role: domain.RoleUser, repoErr: domain.ErrUserNotFound, expectedErr: domain.ErrInvalidToken},
+		{name: "deactivated user", user: inactive, role: domain.RoleUser, expectedErr: domain.ErrAccountInactive},
+		{name: "demoted admin", user: createValidUser(), role: domain.RoleAdmin, expectedErr: domain.ErrTokenRoleChanged},
+		{name: "promoted user", user: admin, role: domain.RoleUser, expectedErr: domain.ErrTokenRoleChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
+			ctx := context.Background()
+			userRepo.On("GetByID", ctx, "user-123").Return(tt.user, tt.repoErr)
+
+			// Act
+			err := service.CheckTokenUser(ctx, "user-123", tt.role)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expectedErr)
+			userRepo.AssertExpectations(t)
+		})
+	}
+}
+
 // Test RefreshToken with invalid token returns error
 func TestAuthService_RefreshToken_InvalidToken_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "invalid_refresh_token"
@@ -454,7 +675,7 @@ func TestAuthService_RefreshToken_ExpiredToken_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "expired_refresh_token"
@@ -477,7 +698,7 @@ func TestAuthService_RefreshToken_RevokedToken_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_but_revoked_token"
@@ -503,7 +724,7 @@ func TestAuthService_Logout_ValidToken_RevokesTokensSuccessfully(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -521,12 +742,34 @@ func TestAuthService_Logout_ValidToken_RevokesTokensSuccessfully(t *testing.T) {
 	tokenRepo.AssertExpectations(t)
 }
 
+// Test Logout records the token revocation for the token's user
+func TestAuthService_Logout_RecordsTokenRevocation(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	recorder := &recordingAuditRecorder{}
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{}, WithAuthAuditRecorder(recorder))
+	ctx := context.Background()
+
+	jwtService.On("ValidateRefreshToken", "valid_refresh_token").Return(createValidTokenClaims(), nil)
+	tokenRepo.On("RevokeToken", ctx, "valid_refresh_token").Return(nil)
+
+	// Act
+	require.NoError(t, service.Logout(ctx, "valid_refresh_token"))
+
+	// Assert
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, "1", recorder.records[0].UserID)
+	assert.Equal(t, domain.AuditActionTokenRevoke, recorder.records[0].Action)
+	assert.Equal(t, domain.AuditResourceRefreshToken, recorder.records[0].ResourceType)
+}
+
 // Test Logout with invalid token handles gracefully
 func TestAuthService_Logout_InvalidToken_HandlesGracefully(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "invalid_refresh_token"
@@ -590,7 +833,7 @@ func TestAuthService_Login_ValidationScenarios(t *testing.T) {
 			// Arrange
 			setupTestLogger()
 			userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 			ctx := context.Background()
 
 			// Act
@@ -668,7 +911,7 @@ func TestAuthService_Register_ValidationScenarios(t *testing.T) {
 			// Arrange
 			setupTestLogger()
 			userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 			ctx := context.Background()
 
 			// For validation tests, we expect early validation failures
@@ -695,7 +938,7 @@ func TestAuthService_Login_DatabaseError_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -720,7 +963,7 @@ func TestAuthService_Login_ConcurrentAttempts_HandledCorrectly(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -734,7 +977,7 @@ func TestAuthService_Login_ConcurrentAttempts_HandledCorrectly(t *testing.T) {
 	// Setup expectations for multiple calls
 	userRepo.On("GetByEmail", ctx, credentials.Email).Return(user, nil).Times(3)
 	passwordService.On("CheckPassword", user.PasswordHash, credentials.Password).Return(nil).Times(3)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(tokenPair, nil).Times(3)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(tokenPair, nil).Times(3)
 	tokenRepo.On("SaveRefreshToken", ctx, user.ID, tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(nil).Times(3)
 	userRepo.On("UpdateLastLogin", ctx, user.ID, mock.AnythingOfType("time.Time")).Return(nil).Times(3)
 
@@ -766,7 +1009,7 @@ func TestAuthService_Register_UserCreationFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -799,7 +1042,7 @@ func TestAuthService_Register_TokenGenerationFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -816,7 +1059,7 @@ func TestAuthService_Register_TokenGenerationFails_ReturnsError(t *testing.T) {
 		user := args.Get(1).(*domain.User)
 		user.ID = "2" // Simulate ID assignment
 	})
-	jwtService.On("GenerateTokenPair", "2", user.Email).Return(nil, errors.New("jwt generation failed"))
+	jwtService.On("GenerateTokenPair", "2", user.Email, domain.RoleUser).Return(nil, errors.New("jwt generation failed"))
 
 	// Act
 	result, err := service.Register(ctx, user, password)
@@ -836,7 +1079,7 @@ func TestAuthService_Register_SaveRefreshTokenFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	user := &domain.User{
@@ -854,7 +1097,7 @@ func TestAuthService_Register_SaveRefreshTokenFails_ReturnsError(t *testing.T) {
 		user := args.Get(1).(*domain.User)
 		user.ID = "2" // Simulate ID assignment
 	})
-	jwtService.On("GenerateTokenPair", "2", user.Email).Return(tokenPair, nil)
+	jwtService.On("GenerateTokenPair", "2", user.Email, domain.RoleUser).Return(tokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, "2", tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(errors.New("save token failed"))
 
 	// Act
@@ -875,7 +1118,7 @@ func TestAuthService_Login_JWTGenerationFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -887,7 +1130,7 @@ func TestAuthService_Login_JWTGenerationFails_ReturnsError(t *testing.T) {
 
 	userRepo.On("GetByEmail", ctx, credentials.Email).Return(user, nil)
 	passwordService.On("CheckPassword", user.PasswordHash, credentials.Password).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(nil, errors.New("jwt generation failed"))
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(nil, errors.New("jwt generation failed"))
 
 	// Act
 	result, err := service.Login(ctx, credentials)
@@ -907,7 +1150,7 @@ func TestAuthService_Login_SaveRefreshTokenFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	credentials := domain.Credentials{
@@ -920,7 +1163,7 @@ func TestAuthService_Login_SaveRefreshTokenFails_ReturnsError(t *testing.T) {
 
 	userRepo.On("GetByEmail", ctx, credentials.Email).Return(user, nil)
 	passwordService.On("CheckPassword", user.PasswordHash, credentials.Password).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(tokenPair, nil)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(tokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, user.ID, tokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(errors.New("save failed"))
 
 	// Act
@@ -941,7 +1184,7 @@ func TestAuthService_RefreshToken_UserNotFound_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -968,7 +1211,7 @@ func TestAuthService_RefreshToken_UserLookupFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -995,7 +1238,7 @@ func TestAuthService_RefreshToken_InactiveUser_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -1024,7 +1267,7 @@ func TestAuthService_RefreshToken_RevokeOldTokenFails_ReturnsError(t *testing.T)
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -1053,7 +1296,7 @@ func TestAuthService_RefreshToken_NewTokenGenerationFails_ReturnsError(t *testin
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -1064,7 +1307,7 @@ func TestAuthService_RefreshToken_NewTokenGenerationFails_ReturnsError(t *testin
 	tokenRepo.On("GetRefreshToken", ctx, refreshToken).Return(claims.UserID, nil)
 	userRepo.On("GetByID", ctx, claims.UserID).Return(user, nil)
 	tokenRepo.On("RevokeToken", ctx, refreshToken).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(nil, errors.New("token generation failed"))
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(nil, errors.New("token generation failed"))
 
 	// Act
 	result, err := service.RefreshToken(ctx, refreshToken)
@@ -1083,7 +1326,7 @@ func TestAuthService_RefreshToken_SaveNewTokenFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -1095,7 +1338,7 @@ func TestAuthService_RefreshToken_SaveNewTokenFails_ReturnsError(t *testing.T) {
 	tokenRepo.On("GetRefreshToken", ctx, refreshToken).Return(claims.UserID, nil)
 	userRepo.On("GetByID", ctx, claims.UserID).Return(user, nil)
 	tokenRepo.On("RevokeToken", ctx, refreshToken).Return(nil)
-	jwtService.On("GenerateTokenPair", user.ID, user.Email).Return(newTokenPair, nil)
+	jwtService.On("GenerateTokenPair", user.ID, user.Email, domain.RoleUser).Return(newTokenPair, nil)
 	tokenRepo.On("SaveRefreshToken", ctx, user.ID, newTokenPair.RefreshToken, mock.AnythingOfType("time.Time")).Return(errors.New("save failed"))
 
 	// Act
@@ -1115,7 +1358,7 @@ func TestAuthService_Logout_RevokeTokenFails_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	refreshToken := "valid_refresh_token"
@@ -1139,7 +1382,7 @@ func TestAuthService_Register_NilUser_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	// Act
@@ -1156,7 +1399,7 @@ func TestAuthService_RefreshToken_EmptyToken_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	// Act
@@ -1173,7 +1416,7 @@ func TestAuthService_Logout_EmptyToken_ReturnsError(t *testing.T) {
 	// Arrange
 	setupTestLogger()
 	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
-	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
 	ctx := context.Background()
 
 	// Act
@@ -1182,4 +1425,152 @@ func TestAuthService_Logout_EmptyToken_ReturnsError(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrInvalidToken, err)
-}
\ No newline at end of file
+}
+// Test DeleteAccount deletes the self profile, revokes the tokens and deletes the user
+func TestAuthService_DeleteAccount_DeletesProfilesTokensAndUser(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	profileRepo := &MockHealthProfileRepository{}
+	recorder := &recordingAuditRecorder{}
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{},
+		WithAuthAuditRecorder(recorder), WithAuthHealthProfileRepository(profileRepo))
+	ctx := WithRequestUser(context.Background(), "1")
+	user := createValidUser()
+
+	userRepo.On("GetByID", ctx, "1").Return(user, nil)
+	passwordService.On("CheckPassword", user.PasswordHash, "password123").Return(nil)
+	profileRepo.On("GetFamilyByUserID", ctx, "1").Return([]*domain.HealthProfile{
+		{ID: "7", UserID: "1", RelationToOwner: domain.RelationSelf},
+		{ID: "8", UserID: "1", RelationToOwner: domain.RelationChild},
+	}, nil)
+	profileRepo.On("Delete", ctx, uint(7)).Return(nil)
+	tokenRepo.On("RevokeAllUserTokens", ctx, "1").Return(nil)
+	userRepo.On("Delete", ctx, "1").Return(nil)
+
+	// Act
+	err := service.DeleteAccount(ctx, "1", "password123")
+
+	// Assert
+	require.NoError(t, err)
+	userRepo.AssertExpectations(t)
+	tokenRepo.AssertExpectations(t)
+	profileRepo.AssertExpectations(t)
+	profileRepo.AssertNotCalled(t, "Delete", ctx, uint(8))
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.AuditActionDelete, recorder.records[0].Action)
+	assert.Equal(t, domain.AuditResourceUser, recorder.records[0].ResourceType)
+	assert.Equal(t, "1", recorder.records[0].ResourceID)
+}
+
+// Test DeleteAccount removes the user's attachment files only once the deletion has succeeded
+func TestAuthService_DeleteAccount_RemovesAttachmentFiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		deleteErr     error
+		expectedBlobs []string
+	}{
+		{name: "deletion succeeds", expectedBlobs: []string{"key-other"}},
+		{name: "deletion fails", deleteErr: errors.New("database unavailable"), expectedBlobs: []string{"key-other", "key-own"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			setupTestLogger()
+			userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+			attachments := newMemoryAttachmentRepository()
+			storage := newMemoryBlobStorage()
+			for userID, key := range map[string]string{"1": "key-own", "2": "key-other"} {
+				require.NoError(t, attachments.Create(context.Background(), &domain.ExpenseAttachment{UserID: userID, StorageKey: key}))
+				require.NoError(t, storage.Put(context.Background(), key, bytes.NewReader(testPDF(16))))
+			}
+			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{},
+				WithAuthAttachmentStorage(attachments, storage))
+			ctx := context.Background()
+			user := createValidUser()
+
+			userRepo.On("GetByID", ctx, "1").Return(user, nil)
+			passwordService.On("CheckPassword", user.PasswordHash, "password123").Return(nil)
+			tokenRepo.On("RevokeAllUserTokens", ctx, "1").Return(nil)
+			userRepo.On("Delete", ctx, "1").Return(tt.deleteErr)
+
+			// Act
+			err := service.DeleteAccount(ctx, "1", "password123")
+
+			// Assert
+			if tt.deleteErr != nil {
+				assert.ErrorIs(t, err, tt.deleteErr)
+			} else {
+				require.NoError(t, err)
+			}
+			var blobs []string
+			for key := range storage.blobs {
+				blobs = append(blobs, key)
+			}
+			assert.ElementsMatch(t, tt.expectedBlobs, blobs)
+		})
+	}
+}
+
+// Test DeleteAccount with a wrong password deletes nothing
+func TestAuthService_DeleteAccount_WrongPassword_ReturnsInvalidCredentials(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+	service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
+	ctx := context.Background()
+	user := createValidUser()
+
+	userRepo.On("GetByID", ctx, "1").Return(user, nil)
+	passwordService.On("CheckPassword", user.PasswordHash, "wrong").Return(errors.New("password mismatch"))
+
+	// Act
+	err := service.DeleteAccount(ctx, "1", "wrong")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	userRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	tokenRepo.AssertNotCalled(t, "RevokeAllUserTokens", mock.Anything, mock.Anything)
+}
+
+// Test DeleteAccount returns the error of a failed step
+func TestAuthService_DeleteAccount_Failures(t *testing.T) {
+	tests := []struct {
+		name    string
+		arrange func(userRepo *MockUserRepository, tokenRepo *MockTokenRepository)
+		wantErr error
+	}{
+		{
+			name: "user not found",
+			arrange: func(userRepo *MockUserRepository, tokenRepo *MockTokenRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(nil, domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name: "delete fails",
+			arrange: func(userRepo *MockUserRepository, tokenRepo *MockTokenRepository) {
+				userRepo.On("GetByID", mock.Anything, "1").Return(createValidUser(), nil)
+				tokenRepo.On("RevokeAllUserTokens", mock.Anything, "1").Return(nil)
+				userRepo.On("Delete", mock.Anything, "1").Return(errors.New("database unavailable"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestLogger()
+			userRepo, tokenRepo, passwordService, jwtService := setupAuthServiceMocks()
+			passwordService.On("CheckPassword", mock.Anything, "password123").Return(nil)
+			tt.arrange(userRepo, tokenRepo)
+			service := NewAuthService(userRepo, tokenRepo, passwordService, jwtService, passthroughTxManager{})
+
+			err := service.DeleteAccount(context.Background(), "1", "password123")
+
+			require.Error(t, err)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
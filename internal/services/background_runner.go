@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BackgroundRunner runs long-lived background tasks, such as periodic snapshots, and stops them
+// on shutdown. Each task gets a context that is cancelled when shutdown begins; Shutdown then
+// waits for the tasks to return.
+type BackgroundRunner struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	pending  []func(ctx context.Context)
+	started  bool
+	stopped  bool
+	tasks    sync.WaitGroup
+	stopOnce sync.Once
+}
+
+// NewBackgroundRunner creates a runner. Tasks registered before Start wait for it.
+func NewBackgroundRunner() *BackgroundRunner {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &BackgroundRunner{ctx: ctx, cancel: cancel}
+}
+
+// Register adds a task, running it right away if the runner has started. The task should
+// return soon after its context is cancelled. Tasks registered after Shutdown never run.
+func (r *BackgroundRunner) Register(task func(ctx context.Context)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case r.stopped:
+	case r.started:
+		r.launch(task)
+	default:
+		r.pending = append(r.pending, task)
+	}
+}
+
+// Start runs the tasks registered so far
+func (r *BackgroundRunner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.started || r.stopped {
+		return
+	}
+	r.started = true
+	for _, task := range r.pending {
+		r.launch(task)
+	}
+	r.pending = nil
+}
+
+// launch runs task in its own goroutine; r.mu must be held
+func (r *BackgroundRunner) launch(task func(ctx context.Context)) {
+	r.tasks.Add(1)
+	go func() {
+		defer r.tasks.Done()
+		task(r.ctx)
+	}()
+}
+
+// Shutdown cancels the tasks' context and waits for them to return until ctx is done.
+// Returns an error if a task was still running when ctx ended. Its signature matches
+// app.Lifecycle.Register.
+func (r *BackgroundRunner) Shutdown(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		r.mu.Lock()
+		r.stopped = true
+		r.pending = nil
+		r.mu.Unlock()
+		r.cancel()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		r.tasks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("background tasks still running: %w", ctx.Err())
+	}
+}
+
+// Periodic adapts run to a task for Register that calls it every interval until the task's
+// context is cancelled. A run in progress gets the same context, so it is cancelled too.
+func Periodic(interval time.Duration, run func(ctx context.Context)) func(ctx context.Context) {
+	return func(ctx context.Context) {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				run(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackgroundRunner_Shutdown_WaitsForSlowTask(t *testing.T) {
+	runner := NewBackgroundRunner()
+	finished := make(chan struct{})
+	runner.Register(func(ctx context.Context) {
+		<-ctx.Done()
+		// Winds down after being cancelled, e.g. writing out a snapshot
+		time.Sleep(100 * time.Millisecond)
+		close(finished)
+	})
+	runner.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := runner.Shutdown(ctx)
+
+	require.NoError(t, err)
+	select {
+	case <-finished:
+	default:
+		t.Fatal("Shutdown returned before the task finished")
+	}
+}
+
+func TestBackgroundRunner_Shutdown_GivesUpAtDeadline(t *testing.T) {
+	runner := NewBackgroundRunner()
+	cancelled := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	runner.Register(func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+		<-release // Ignores the cancellation
+	})
+	runner.Start()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := runner.Shutdown(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	select {
+	case <-cancelled:
+	default:
+		t.Fatal("the task's context was not cancelled")
+	}
+}
+
+func TestBackgroundRunner_RegisterAfterStartRunsImmediately(t *testing.T) {
+	runner := NewBackgroundRunner()
+	runner.Start()
+
+	ran := make(chan struct{})
+	runner.Register(func(ctx context.Context) { close(ran) })
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("task registered after Start did not run")
+	}
+	require.NoError(t, runner.Shutdown(context.Background()))
+}
+
+func TestBackgroundRunner_RegisterAfterShutdownNeverRuns(t *testing.T) {
+	runner := NewBackgroundRunner()
+	runner.Start()
+	require.NoError(t, runner.Shutdown(context.Background()))
+
+	var ran atomic.Bool
+	runner.Register(func(ctx context.Context) { ran.Store(true) })
+
+	require.NoError(t, runner.Shutdown(context.Background()))
+	assert.False(t, ran.Load())
+}
+
+func TestPeriodic_RunsUntilCancelled(t *testing.T) {
+	var runs atomic.Int32
+	runner := NewBackgroundRunner()
+	runner.Register(Periodic(10*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+	}))
+	runner.Start()
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, runner.Shutdown(context.Background()))
+	stoppedAt := runs.Load()
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, stoppedAt, runs.Load())
+}
@@ -9,18 +9,34 @@ import (
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 )
 
-
 // budgetAnalyzer implements the BudgetAnalyzer interface
 type budgetAnalyzer struct {
 	financeService FinanceService
+	thresholds     domain.FinancialThresholds
+}
+
+// BudgetAnalyzerOption customizes a budget analyzer created by NewBudgetAnalyzer
+type BudgetAnalyzerOption func(*budgetAnalyzer)
+
+// WithBudgetThresholds sets the debt-to-income, savings rate and affordability thresholds
+// the analyzer scores budgets with; the default is domain.DefaultFinancialThresholds
+func WithBudgetThresholds(thresholds domain.FinancialThresholds) BudgetAnalyzerOption {
+	return func(ba *budgetAnalyzer) {
+		ba.thresholds = thresholds
+	}
 }
 
 // NewBudgetAnalyzer creates a new BudgetAnalyzer instance
 // Returns concrete type that implements BudgetAnalyzer interface
-func NewBudgetAnalyzer(financeService FinanceService) *budgetAnalyzer {
-	return &budgetAnalyzer{
+func NewBudgetAnalyzer(financeService FinanceService, opts ...BudgetAnalyzerOption) *budgetAnalyzer {
+	ba := &budgetAnalyzer{
 		financeService: financeService,
+		thresholds:     domain.DefaultFinancialThresholds(),
+	}
+	for _, opt := range opts {
+		opt(ba)
 	}
+	return ba
 }
 
 // AnalyzeBudget identifies overspending categories and budget issues
@@ -117,7 +133,7 @@ func (ba *budgetAnalyzer) AnalyzeBudget(ctx context.Context, userID string) (Bud
 	}
 
 	// Calculate budget health score (1-10)
-	healthScore := calculateBudgetHealthScore(summary, len(overspendingCategories))
+	healthScore := calculateBudgetHealthScore(summary, len(overspendingCategories), ba.thresholds)
 
 	analysis := BudgetAnalysis{
 		UserID:                 userID,
@@ -134,6 +150,23 @@ func (ba *budgetAnalyzer) AnalyzeBudget(ctx context.Context, userID string) (Bud
 	return analysis, nil
 }
 
+// AssessAffordability rates the user's financial health and largest affordable purchase
+// under the analyzer's thresholds
+func (ba *budgetAnalyzer) AssessAffordability(ctx context.Context, userID string) (AffordabilityAssessment, error) {
+	summary, err := ba.financeService.CalculateFinanceSummary(ctx, userID)
+	if err != nil {
+		return AffordabilityAssessment{}, fmt.Errorf("failed to get financial summary: %w", err)
+	}
+
+	return AffordabilityAssessment{
+		UserID:              userID,
+		FinancialHealth:     summary.CalculateHealthWith(ba.thresholds),
+		DebtToIncomeRatio:   summary.DebtToIncomeRatio,
+		Multiplier:          ba.thresholds.AffordabilityMultiplier(summary.DebtToIncomeRatio),
+		MaxAffordableAmount: summary.CalculateAffordabilityWith(ba.thresholds),
+	}, nil
+}
+
 // GetSpendingInsights provides category-wise spending analysis
 func (ba *budgetAnalyzer) GetSpendingInsights(ctx context.Context, userID string) (SpendingInsights, error) {
 	// Get expenses and income data
@@ -152,7 +185,7 @@ func (ba *budgetAnalyzer) GetSpendingInsights(ctx context.Context, userID string
 
 	for _, expense := range expenses {
 		// Normalize expense to monthly
-		monthlyAmount, err := ba.financeService.NormalizeToMonthly(expense.Amount, expense.Frequency)
+		monthlyAmount, err := ba.monthlyExpenseAmount(expense)
 		if err != nil {
 			continue // Skip invalid frequencies
 		}
@@ -217,7 +250,7 @@ func (ba *budgetAnalyzer) GetSpendingInsights(ctx context.Context, userID string
 	}
 
 	// Determine spending efficiency
-	spendingEfficiency := determineSpendingEfficiency(summary, variableVsFixedRatio)
+	spendingEfficiency := determineSpendingEfficiency(summary, variableVsFixedRatio, ba.thresholds)
 
 	insights := SpendingInsights{
 		UserID:               userID,
@@ -227,6 +260,8 @@ func (ba *budgetAnalyzer) GetSpendingInsights(ctx context.Context, userID string
 		LowestCategory:       lowest,
 		VariableVsFixedRatio: variableVsFixedRatio,
 		SpendingEfficiency:   spendingEfficiency,
+		Budgets:              summary.Budgets,
+		OverspentCategories:  summary.OverspentCategories,
 	}
 
 	return insights, nil
@@ -267,7 +302,7 @@ func (ba *budgetAnalyzer) RecommendSavings(ctx context.Context, userID string) (
 
 	var currentNeeds, currentWants float64
 	for _, expense := range expenses {
-		monthlyAmount, err := ba.financeService.NormalizeToMonthly(expense.Amount, expense.Frequency)
+		monthlyAmount, err := ba.monthlyExpenseAmount(expense)
 		if err != nil {
 			continue
 		}
@@ -366,7 +401,7 @@ func (ba *budgetAnalyzer) IdentifyUnnecessaryExpenses(ctx context.Context, userI
 
 	// Define optimization rules
 	for _, expense := range expenses {
-		monthlyAmount, err := ba.financeService.NormalizeToMonthly(expense.Amount, expense.Frequency)
+		monthlyAmount, err := ba.monthlyExpenseAmount(expense)
 		if err != nil {
 			continue
 		}
@@ -463,7 +498,7 @@ func (ba *budgetAnalyzer) IdentifyUnnecessaryExpenses(ctx context.Context, userI
 
 // Helper functions
 
-func calculateBudgetHealthScore(summary domain.FinanceSummary, overspendingCategories int) int {
+func calculateBudgetHealthScore(summary domain.FinanceSummary, overspendingCategories int, thresholds domain.FinancialThresholds) int {
 	score := 10
 
 	// Deduct points for negative disposable income
@@ -474,9 +509,9 @@ func calculateBudgetHealthScore(summary domain.FinanceSummary, overspendingCateg
 	}
 
 	// Deduct points for high debt-to-income ratio
-	if summary.DebtToIncomeRatio > 0.50 {
+	if summary.DebtToIncomeRatio > thresholds.PoorDTI {
 		score -= 3
-	} else if summary.DebtToIncomeRatio > 0.36 {
+	} else if summary.DebtToIncomeRatio > thresholds.HealthyDTI {
 		score -= 1
 	}
 
@@ -493,9 +528,9 @@ func calculateBudgetHealthScore(summary domain.FinanceSummary, overspendingCateg
 	return score
 }
 
-func determineSpendingEfficiency(summary domain.FinanceSummary, variableFixedRatio float64) string {
+func determineSpendingEfficiency(summary domain.FinanceSummary, variableFixedRatio float64, thresholds domain.FinancialThresholds) string {
 	// Good efficiency: Low variable expenses relative to fixed, good savings rate
-	if summary.SavingsRate >= 0.15 && variableFixedRatio < 0.5 {
+	if summary.SavingsRate >= thresholds.GoodSavingsRate && variableFixedRatio < 0.5 {
 		return "Efficient"
 	}
 	
@@ -554,4 +589,13 @@ func getCategorySpending(categories []CategorySpending, categoryName string) *Ca
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// monthlyExpenseAmount converts an expense to its monthly amount, counting an installment
+// expense only while installments remain
+func (ba *budgetAnalyzer) monthlyExpenseAmount(expense domain.Expense) (float64, error) {
+	if expense.IsInstallment() {
+		return expense.NormalizeToMonthly(), nil
+	}
+	return ba.financeService.NormalizeToMonthly(expense.Amount, expense.Frequency)
+}
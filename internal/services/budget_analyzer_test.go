@@ -9,6 +9,7 @@ import (
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock for BudgetAnalyzer dependencies
@@ -56,9 +57,7 @@ func (m *MockBudgetAnalyzerFinanceService) GetMaxAffordableAmount(ctx context.Co
 
 func setupBudgetAnalyzer() (*budgetAnalyzer, *MockBudgetAnalyzerFinanceService) {
 	mockFinanceService := &MockBudgetAnalyzerFinanceService{}
-	analyzer := &budgetAnalyzer{
-		financeService: mockFinanceService,
-	}
+	analyzer := NewBudgetAnalyzer(mockFinanceService)
 	return analyzer, mockFinanceService
 }
 
@@ -408,4 +407,58 @@ func TestBudgetAnalyzer_IdentifyUnnecessaryExpenses_IncomeError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to get user incomes")
 	mockFinanceService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestBudgetAnalyzer_AssessAffordability(t *testing.T) {
+	ctx := context.Background()
+	// DTI 0.30 sits between the default healthy (0.36) and excellent (0.28) bands
+	summary := createTestFinanceSummary("user1", 5000, 2000, 1500, 1500)
+
+	tests := []struct {
+		name               string
+		opts               []BudgetAnalyzerOption
+		expectedHealth     string
+		expectedMultiplier float64
+		expectedAmount     float64
+	}{
+		{
+			name:               "default thresholds",
+			expectedHealth:     domain.HealthGood,
+			expectedMultiplier: 3.0,
+			expectedAmount:     4500.0,
+		},
+		{
+			name: "stricter thresholds",
+			opts: []BudgetAnalyzerOption{WithBudgetThresholds(domain.FinancialThresholds{
+				ExcellentDTI:             0.15,
+				HealthyDTI:               0.25,
+				PoorDTI:                  0.40,
+				ExcellentSavingsRate:     0.30,
+				GoodSavingsRate:          0.20,
+				FairSavingsRate:          0.10,
+				AffordabilityMultipliers: domain.AffordabilityMultipliers{Excellent: 4, Good: 3, Fair: 1, Poor: 0},
+			})},
+			expectedHealth:     domain.HealthFair,
+			expectedMultiplier: 1.0,
+			expectedAmount:     1500.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockFinanceService := &MockBudgetAnalyzerFinanceService{}
+			mockFinanceService.On("CalculateFinanceSummary", ctx, "user1").Return(summary, nil)
+			analyzer := NewBudgetAnalyzer(mockFinanceService, tt.opts...)
+
+			assessment, err := analyzer.AssessAffordability(ctx, "user1")
+
+			require.NoError(t, err)
+			assert.Equal(t, "user1", assessment.UserID)
+			assert.Equal(t, tt.expectedHealth, assessment.FinancialHealth)
+			assert.InDelta(t, 0.30, assessment.DebtToIncomeRatio, 0.0001)
+			assert.Equal(t, tt.expectedMultiplier, assessment.Multiplier)
+			assert.InDelta(t, tt.expectedAmount, assessment.MaxAffordableAmount, 0.0001)
+			mockFinanceService.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,583 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// DemoDataTag is the tag every seeded expense carries so demo expenses are easy to filter
+const DemoDataTag = "demo"
+
+// DemoDataFinanceService is the part of the finance service demo data is seeded and removed through
+type DemoDataFinanceService interface {
+	AddIncome(ctx context.Context, income domain.Income, allowDuplicate bool) error
+	GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error)
+	DeleteIncome(ctx context.Context, userID, incomeID string) error
+
+	AddExpense(ctx context.Context, expense domain.Expense, allowDuplicate bool) error
+	GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
+	DeleteExpense(ctx context.Context, userID, expenseID string) error
+
+	AddLoan(ctx context.Context, loan domain.Loan) error
+	GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error)
+	DeleteLoan(ctx context.Context, userID, loanID string) error
+}
+
+// demoDataService implements the DemoDataService interface defined in handlers package
+type demoDataService struct {
+	finance DemoDataFinanceService
+	health  HealthService
+	repo    DemoDataRepository
+	// newRand returns the random source for one seeding
+	newRand func() *rand.Rand
+}
+
+// DemoDataServiceOption customizes a demo data service created by NewDemoDataService
+type DemoDataServiceOption func(*demoDataService)
+
+// WithDemoDataSeed makes every seeding draw the same values from seed, for tests
+func WithDemoDataSeed(seed uint64) DemoDataServiceOption {
+	return func(s *demoDataService) {
+		s.newRand = func() *rand.Rand {
+			return rand.New(rand.NewPCG(seed, seed))
+		}
+	}
+}
+
+// NewDemoDataService creates a new demo data service instance
+// Returns concrete type that implements DemoDataService interface defined in handlers package
+func NewDemoDataService(finance DemoDataFinanceService, health HealthService, repo DemoDataRepository, opts ...DemoDataServiceOption) *demoDataService {
+	s := &demoDataService{
+		finance: finance,
+		health:  health,
+		repo:    repo,
+		newRand: func() *rand.Rand {
+			return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Seed fills an empty account with a random but realistic set of incomes, expenses, loans and
+// health records, created through the finance and health services so they are validated and
+// summarized like any other. Every record created is remembered under one batch so Remove can
+// delete exactly those records later.
+// Returns domain.ErrAccountHasData if the user already has finance or health data, or demo data
+// that hasn't been removed. If seeding fails part way, the records already created are deleted.
+func (s *demoDataService) Seed(ctx context.Context, userID string) (domain.DemoDataBatch, error) {
+	if err := s.checkAccountEmpty(ctx, userID); err != nil {
+		return domain.DemoDataBatch{}, err
+	}
+
+	seeder := &demoDataSeeder{
+		finance: s.finance,
+		health:  s.health,
+		rng:     s.newRand(),
+		now:     time.Now(),
+		batch: domain.DemoDataBatch{
+			ID:     newResourceID("demo"),
+			UserID: userID,
+		},
+	}
+	seeder.batch.CreatedAt = seeder.now
+
+	if err := seeder.seed(ctx); err != nil {
+		s.rollback(ctx, seeder.batch)
+		return domain.DemoDataBatch{}, fmt.Errorf("failed to seed demo data: %w", err)
+	}
+	if err := s.repo.SaveRecords(ctx, seeder.batch.Records); err != nil {
+		s.rollback(ctx, seeder.batch)
+		return domain.DemoDataBatch{}, err
+	}
+
+	logging.ServiceLogger().Info("Demo data seeded",
+		logging.WithOperation("seed_demo_data"), logging.WithUserID(userID))
+	return seeder.batch, nil
+}
+
+// Remove deletes the records seeded for the user and returns how many were deleted. Seeded
+// records the user has since deleted are skipped. The seeded health profile is only deleted
+// when nothing the user recorded themselves depends on it, since deleting it would delete
+// their conditions, medical expenses and policies too.
+// Returns domain.ErrDemoDataNotFound if the user has no demo data.
+func (s *demoDataService) Remove(ctx context.Context, userID string) (int, error) {
+	records, err := s.repo.GetUserRecords(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, domain.ErrDemoDataNotFound
+	}
+
+	removed, err := s.deleteRecords(ctx, userID, records, true)
+	if err != nil {
+		return removed, fmt.Errorf("failed to remove demo data: %w", err)
+	}
+	if err := s.repo.DeleteUserRecords(ctx, userID); err != nil {
+		return removed, err
+	}
+
+	logging.ServiceLogger().Info("Demo data removed",
+		logging.WithOperation("remove_demo_data"), logging.WithUserID(userID))
+	return removed, nil
+}
+
+// checkAccountEmpty returns domain.ErrAccountHasData unless the user has no finance or health
+// data and no demo data records
+func (s *demoDataService) checkAccountEmpty(ctx context.Context, userID string) error {
+	records, err := s.repo.GetUserRecords(ctx, userID)
+	if err != nil {
+		return err
+	}
+	incomes, err := s.finance.GetUserIncomes(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get incomes: %w", err)
+	}
+	expenses, err := s.finance.GetUserExpenses(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get expenses: %w", err)
+	}
+	loans, err := s.finance.GetUserLoans(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get loans: %w", err)
+	}
+	// Every condition, medical expense and policy belongs to a profile
+	profiles, err := s.health.GetFamilyProfiles(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get health profiles: %w", err)
+	}
+
+	if len(records)+len(incomes)+len(expenses)+len(loans)+len(profiles) > 0 {
+		return domain.ErrAccountHasData
+	}
+	return nil
+}
+
+// rollback deletes the records of a seeding that failed. The account was empty, so the health
+// profile is deleted along with any health records created before their IDs were read back.
+// It is best effort: the seeding's error is what the caller reports, so failures here are only logged.
+func (s *demoDataService) rollback(ctx context.Context, batch domain.DemoDataBatch) {
+	if _, err := s.deleteRecords(ctx, batch.UserID, batch.Records, false); err != nil {
+		logging.ServiceLogger().Error("Failed to roll back demo data",
+			logging.WithOperation("seed_demo_data"), logging.WithUserID(batch.UserID), logging.WithError(err))
+	}
+}
+
+// deleteRecords deletes the given seeded records, the health profile last, and returns how many
+// were deleted. With keepUsedProfile the profile is kept while other health data remains.
+func (s *demoDataService) deleteRecords(ctx context.Context, userID string, records []domain.DemoDataRecord, keepUsedProfile bool) (int, error) {
+	removed := 0
+	var profile *domain.DemoDataRecord
+	for _, record := range records {
+		var err error
+		switch record.Resource {
+		case domain.AuditResourceIncome:
+			err = s.finance.DeleteIncome(ctx, userID, record.RecordID)
+			if errors.Is(err, domain.ErrIncomeNotFound) {
+				continue
+			}
+		case domain.AuditResourceExpense:
+			err = s.finance.DeleteExpense(ctx, userID, record.RecordID)
+			if errors.Is(err, domain.ErrExpenseNotFound) {
+				continue
+			}
+		case domain.AuditResourceLoan:
+			err = s.finance.DeleteLoan(ctx, userID, record.RecordID)
+			if errors.Is(err, domain.ErrLoanNotFound) {
+				continue
+			}
+		case domain.AuditResourceMedicalExpense:
+			err = s.health.DeleteExpense(ctx, userID, record.RecordID)
+		case domain.AuditResourceMedicalCondition:
+			err = s.health.DeleteCondition(ctx, userID, record.RecordID)
+		case domain.AuditResourceInsurancePolicy:
+			err = s.health.DeleteInsurancePolicy(ctx, userID, record.RecordID)
+		case domain.AuditResourceHealthProfile:
+			profile = &record
+			continue
+		default:
+			continue
+		}
+		if err != nil {
+			return removed, fmt.Errorf("failed to delete %s %s: %w", record.Resource, record.RecordID, err)
+		}
+		removed++
+	}
+
+	if profile == nil {
+		return removed, nil
+	}
+	if keepUsedProfile {
+		inUse, err := s.healthProfileInUse(ctx, userID)
+		if err != nil || inUse {
+			return removed, err
+		}
+	}
+	if err := s.health.DeleteProfile(ctx, userID); err != nil {
+		return removed, fmt.Errorf("failed to delete %s %s: %w", profile.Resource, profile.RecordID, err)
+	}
+	return removed + 1, nil
+}
+
+// healthProfileInUse reports whether the user has health data left besides their own profile
+func (s *demoDataService) healthProfileInUse(ctx context.Context, userID string) (bool, error) {
+	profiles, err := s.health.GetFamilyProfiles(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	conditions, err := s.health.GetConditions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	expenses, err := s.health.GetExpenses(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	policies, err := s.health.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return len(profiles) > 1 || len(conditions)+len(expenses)+len(policies) > 0, nil
+}
+
+// demoDataSeeder creates the records of one seeding and collects them into batch
+type demoDataSeeder struct {
+	finance DemoDataFinanceService
+	health  HealthService
+	rng     *rand.Rand
+	now     time.Time
+	batch   domain.DemoDataBatch
+}
+
+// demoIncome, demoExpense, demoLoan, demoCondition and demoMedicalExpense describe the records
+// a seeding picks from; amounts are drawn between min and max
+type demoIncome struct {
+	source    string
+	frequency string
+	min, max  float64
+}
+
+type demoExpense struct {
+	category  string
+	name      string
+	frequency string
+	isFixed   bool
+	priority  int
+	min, max  float64
+}
+
+type demoLoan struct {
+	lender   string
+	loanType string
+	min, max float64
+	rate     float64
+}
+
+type demoCondition struct {
+	name               string
+	category           string
+	severity           string
+	requiresMedication bool
+	monthlyMedCost     float64
+}
+
+type demoMedicalExpense struct {
+	category    string
+	description string
+	frequency   string
+	min, max    float64
+}
+
+// Every seeding gets a salary and one or two of the other incomes
+var (
+	demoSalary = demoIncome{"Salary", domain.FrequencyMonthly, 3500, 6500}
+
+	demoOtherIncomes = []demoIncome{
+		{"Freelance design", domain.FrequencyWeekly, 150, 450},
+		{"Apartment rental", domain.FrequencyMonthly, 600, 1200},
+		{"Annual bonus", domain.FrequencyOneTime, 1500, 5000},
+	}
+)
+
+var demoExpenses = []demoExpense{
+	{domain.CategoryHousing, "Rent", domain.ExpenseFrequencyMonthly, true, domain.PriorityEssential, 900, 1800},
+	{domain.CategoryUtilities, "Electricity", domain.ExpenseFrequencyMonthly, true, domain.PriorityEssential, 60, 140},
+	{domain.CategoryUtilities, "Internet", domain.ExpenseFrequencyMonthly, true, domain.PriorityImportant, 40, 80},
+	{domain.CategoryUtilities, "Phone plan", domain.ExpenseFrequencyMonthly, true, domain.PriorityImportant, 25, 60},
+	{domain.CategoryFood, "Groceries", domain.ExpenseFrequencyWeekly, false, domain.PriorityEssential, 60, 150},
+	{domain.CategoryFood, "Coffee", domain.ExpenseFrequencyDaily, false, domain.PriorityNiceToHave, 3, 6},
+	{domain.CategoryFood, "Dining out", domain.ExpenseFrequencyWeekly, false, domain.PriorityNiceToHave, 30, 90},
+	{domain.CategoryTransport, "Fuel", domain.ExpenseFrequencyWeekly, false, domain.PriorityImportant, 30, 70},
+	{domain.CategoryTransport, "Transit pass", domain.ExpenseFrequencyMonthly, true, domain.PriorityImportant, 50, 120},
+	{domain.CategoryEntertainment, "Streaming subscriptions", domain.ExpenseFrequencyMonthly, true, domain.PriorityNiceToHave, 15, 40},
+	{domain.CategoryEntertainment, "Gym membership", domain.ExpenseFrequencyMonthly, true, domain.PriorityNiceToHave, 25, 70},
+	{domain.CategoryOther, "Pet care", domain.ExpenseFrequencyMonthly, false, domain.PriorityImportant, 40, 120},
+	{domain.CategoryOther, "Donations", domain.ExpenseFrequencyMonthly, false, domain.PriorityNiceToHave, 20, 80},
+}
+
+var demoLoans = []demoLoan{
+	{"Metro Auto Finance", domain.LoanTypeAuto, 12000, 30000, 6.5},
+	{"Federal Student Aid", domain.LoanTypeStudent, 15000, 40000, 5.0},
+	{"Harbor Credit Union", domain.LoanTypePersonal, 3000, 10000, 11.0},
+}
+
+var demoConditions = []demoCondition{
+	{"Seasonal allergies", "chronic", "mild", true, 15},
+	{"Hypertension", "chronic", "moderate", true, 35},
+	{"Asthma", "chronic", "mild", true, 40},
+	{"Generalized anxiety", "mental_health", "mild", false, 0},
+	{"Lower back strain", "acute", "mild", false, 0},
+}
+
+var demoMedicalExpenses = []demoMedicalExpense{
+	{"doctor_visit", "Annual checkup", "", 120, 250},
+	{"lab_test", "Blood panel", "", 80, 200},
+	{"medication", "Prescription refill", "monthly", 20, 60},
+	{"therapy", "Physical therapy session", "", 90, 160},
+	{"equipment", "Blood pressure monitor", "", 40, 90},
+}
+
+// seed creates the whole dataset, stopping at the first error
+func (d *demoDataSeeder) seed(ctx context.Context) error {
+	steps := []func(context.Context) error{
+		d.seedIncomes,
+		d.seedExpenses,
+		d.seedLoans,
+		d.seedHealth,
+	}
+	for _, step := range steps {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedIncomes creates a salary and one or two other incomes
+func (d *demoDataSeeder) seedIncomes(ctx context.Context) error {
+	incomes := append([]demoIncome{demoSalary}, pickDemo(d.rng, demoOtherIncomes, 1+d.rng.IntN(2))...)
+	for _, template := range incomes {
+		income := domain.Income{
+			ID:        newResourceID("income"),
+			UserID:    d.batch.UserID,
+			Source:    template.source,
+			Amount:    d.amount(template.min, template.max),
+			Frequency: template.frequency,
+			IsActive:  true,
+			CreatedAt: d.now,
+			UpdatedAt: d.now,
+		}
+		if err := d.finance.AddIncome(ctx, income, true); err != nil {
+			return err
+		}
+		d.record(domain.AuditResourceIncome, income.ID)
+	}
+	return nil
+}
+
+// seedExpenses creates 8 to 12 expenses across the categories
+func (d *demoDataSeeder) seedExpenses(ctx context.Context) error {
+	for _, template := range pickDemo(d.rng, demoExpenses, 8+d.rng.IntN(5)) {
+		expense := domain.Expense{
+			ID:        newResourceID("expense"),
+			UserID:    d.batch.UserID,
+			Category:  template.category,
+			Name:      template.name,
+			Amount:    d.amount(template.min, template.max),
+			Frequency: template.frequency,
+			IsFixed:   template.isFixed,
+			Priority:  template.priority,
+			Tags:      []string{DemoDataTag},
+			CreatedAt: d.now,
+			UpdatedAt: d.now,
+		}
+		if err := d.finance.AddExpense(ctx, expense, true); err != nil {
+			return err
+		}
+		d.record(domain.AuditResourceExpense, expense.ID)
+	}
+	return nil
+}
+
+// seedLoans creates one or two loans, each partly paid off
+func (d *demoDataSeeder) seedLoans(ctx context.Context) error {
+	for _, template := range pickDemo(d.rng, demoLoans, 1+d.rng.IntN(2)) {
+		principal := math.Round(d.amount(template.min, template.max))
+		remaining := math.Round(principal * (0.4 + 0.5*d.rng.Float64()))
+		months := 24 + d.rng.IntN(49)
+		loan := domain.Loan{
+			ID:               newResourceID("loan"),
+			UserID:           d.batch.UserID,
+			Lender:           template.lender,
+			Type:             template.loanType,
+			PrincipalAmount:  principal,
+			RemainingBalance: remaining,
+			MonthlyPayment:   math.Round(remaining/float64(months)*(1+template.rate/200)*100) / 100,
+			InterestRate:     template.rate,
+			EndDate:          d.now.AddDate(0, months, 0),
+			CreatedAt:        d.now,
+			UpdatedAt:        d.now,
+		}
+		if err := d.finance.AddLoan(ctx, loan); err != nil {
+			return err
+		}
+		d.record(domain.AuditResourceLoan, loan.ID)
+	}
+	return nil
+}
+
+// seedHealth creates a health profile with two conditions, a policy that started three months
+// ago, and three to five medical expenses from the months since. The health service doesn't
+// return the IDs it assigns, so they are read back once everything is created; the account was
+// empty, so every record found belongs to this seeding.
+func (d *demoDataSeeder) seedHealth(ctx context.Context) error {
+	userID := d.batch.UserID
+	genders := []string{"male", "female", "other"}
+	profile := &domain.HealthProfile{
+		UserID:              userID,
+		RelationToOwner:     domain.RelationSelf,
+		Age:                 25 + d.rng.IntN(36),
+		Gender:              genders[d.rng.IntN(len(genders))],
+		Height:              float64(155 + d.rng.IntN(36)),
+		Weight:              float64(55 + d.rng.IntN(41)),
+		FamilySize:          1 + d.rng.IntN(4),
+		EmergencyFundHealth: math.Round(d.amount(1000, 5000)),
+		CreatedAt:           d.now,
+		UpdatedAt:           d.now,
+	}
+	if err := d.health.CreateProfile(ctx, profile); err != nil {
+		return err
+	}
+	created, err := d.health.GetProfile(ctx, userID)
+	if err != nil {
+		return err
+	}
+	d.record(domain.AuditResourceHealthProfile, created.ID)
+
+	for _, template := range pickDemo(d.rng, demoConditions, 2) {
+		condition := &domain.MedicalCondition{
+			UserID:             userID,
+			ProfileID:          created.ID,
+			Name:               template.name,
+			Category:           template.category,
+			Severity:           template.severity,
+			DiagnosedDate:      d.now.AddDate(-1-d.rng.IntN(8), 0, 0),
+			IsActive:           true,
+			RequiresMedication: template.requiresMedication,
+			MonthlyMedCost:     template.monthlyMedCost,
+			CreatedAt:          d.now,
+			UpdatedAt:          d.now,
+		}
+		if err := d.health.AddCondition(ctx, condition); err != nil {
+			return err
+		}
+	}
+
+	start := d.now.AddDate(0, -3, 0)
+	policy := &domain.InsurancePolicy{
+		UserID:             userID,
+		ProfileID:          created.ID,
+		Provider:           "Blue Meadow Health",
+		PolicyNumber:       "DEMO-" + strings.ToUpper(strings.TrimPrefix(d.batch.ID, "demo-")[:8]),
+		Type:               "health",
+		MonthlyPremium:     math.Round(d.amount(250, 450)),
+		Deductible:         float64(1000 + 500*d.rng.IntN(5)),
+		OutOfPocketMax:     float64(4000 + 500*d.rng.IntN(7)),
+		CoveragePercentage: 80,
+		StartDate:          start,
+		EndDate:            start.AddDate(1, 0, 0),
+		IsActive:           true,
+		CreatedAt:          d.now,
+		UpdatedAt:          d.now,
+	}
+	if err := d.health.AddInsurancePolicy(ctx, policy); err != nil {
+		return err
+	}
+
+	for _, template := range pickDemo(d.rng, demoMedicalExpenses, 3+d.rng.IntN(3)) {
+		expense := &domain.MedicalExpense{
+			UserID:      userID,
+			ProfileID:   created.ID,
+			Amount:      d.amount(template.min, template.max),
+			Category:    template.category,
+			Description: template.description,
+			IsRecurring: template.frequency != "",
+			Frequency:   template.frequency,
+			IsCovered:   true,
+			Date:        d.now.AddDate(0, 0, -1-d.rng.IntN(80)),
+			CreatedAt:   d.now,
+			UpdatedAt:   d.now,
+		}
+		if err := d.health.AddExpense(ctx, expense); err != nil {
+			return err
+		}
+	}
+
+	return d.recordHealthIDs(ctx)
+}
+
+// recordHealthIDs records the conditions, policies and medical expenses on the account
+func (d *demoDataSeeder) recordHealthIDs(ctx context.Context) error {
+	userID := d.batch.UserID
+	conditions, err := d.health.GetConditions(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, condition := range conditions {
+		d.record(domain.AuditResourceMedicalCondition, condition.ID)
+	}
+
+	policies, err := d.health.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		d.record(domain.AuditResourceInsurancePolicy, policy.ID)
+	}
+
+	expenses, err := d.health.GetExpenses(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, expense := range expenses {
+		d.record(domain.AuditResourceMedicalExpense, expense.ID)
+	}
+	return nil
+}
+
+// record adds a created record to the batch
+func (d *demoDataSeeder) record(resource, recordID string) {
+	d.batch.Records = append(d.batch.Records, domain.DemoDataRecord{
+		UserID:    d.batch.UserID,
+		BatchID:   d.batch.ID,
+		Resource:  resource,
+		RecordID:  recordID,
+		CreatedAt: d.now,
+	})
+}
+
+// amount draws an amount in cents between low and high
+func (d *demoDataSeeder) amount(low, high float64) float64 {
+	return math.Round((low+(high-low)*d.rng.Float64())*100) / 100
+}
+
+// pickDemo returns n templates in random order, without repeats
+func pickDemo[T any](rng *rand.Rand, templates []T, n int) []T {
+	picked := make([]T, len(templates))
+	copy(picked, templates)
+	rng.Shuffle(len(picked), func(i, j int) {
+		picked[i], picked[j] = picked[j], picked[i]
+	})
+	return picked[:min(n, len(picked))]
+}
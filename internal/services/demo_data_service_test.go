@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// fakeDemoFinanceService keeps finance records in memory
+type fakeDemoFinanceService struct {
+	incomes  []domain.Income
+	expenses []domain.Expense
+	loans    []domain.Loan
+}
+
+func (f *fakeDemoFinanceService) AddIncome(ctx context.Context, income domain.Income, allowDuplicate bool) error {
+	if err := income.Validate(); err != nil {
+		return err
+	}
+	f.incomes = append(f.incomes, income)
+	return nil
+}
+
+func (f *fakeDemoFinanceService) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
+	return f.incomes, nil
+}
+
+func (f *fakeDemoFinanceService) DeleteIncome(ctx context.Context, userID, incomeID string) error {
+	i := slices.IndexFunc(f.incomes, func(income domain.Income) bool { return income.ID == incomeID })
+	if i < 0 {
+		return domain.ErrIncomeNotFound
+	}
+	f.incomes = slices.Delete(f.incomes, i, i+1)
+	return nil
+}
+
+func (f *fakeDemoFinanceService) AddExpense(ctx context.Context, expense domain.Expense, allowDuplicate bool) error {
+	if err := expense.Validate(); err != nil {
+		return err
+	}
+	f.expenses = append(f.expenses, expense)
+	return nil
+}
+
+func (f *fakeDemoFinanceService) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
+	return f.expenses, nil
+}
+
+func (f *fakeDemoFinanceService) DeleteExpense(ctx context.Context, userID, expenseID string) error {
+	i := slices.IndexFunc(f.expenses, func(expense domain.Expense) bool { return expense.ID == expenseID })
+	if i < 0 {
+		return domain.ErrExpenseNotFound
+	}
+	f.expenses = slices.Delete(f.expenses, i, i+1)
+	return nil
+}
+
+func (f *fakeDemoFinanceService) AddLoan(ctx context.Context, loan domain.Loan) error {
+	if err := loan.Validate(); err != nil {
+		return err
+	}
+	f.loans = append(f.loans, loan)
+	return nil
+}
+
+func (f *fakeDemoFinanceService) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
+	return f.loans, nil
+}
+
+func (f *fakeDemoFinanceService) DeleteLoan(ctx context.Context, userID, loanID string) error {
+	i := slices.IndexFunc(f.loans, func(loan domain.Loan) bool { return loan.ID == loanID })
+	if i < 0 {
+		return domain.ErrLoanNotFound
+	}
+	f.loans = slices.Delete(f.loans, i, i+1)
+	return nil
+}
+
+// fakeDemoHealthService keeps one user's health records in memory and assigns IDs the way the
+// repositories do. With failExpenses set, adding a medical expense fails.
+// The embedded interface is nil; calling any other method panics.
+type fakeDemoHealthService struct {
+	HealthService
+	profile      *domain.HealthProfile
+	conditions   []domain.MedicalCondition
+	policies     []domain.InsurancePolicy
+	expenses     []domain.MedicalExpense
+	lastID       int
+	failExpenses bool
+}
+
+func (f *fakeDemoHealthService) nextID() string {
+	f.lastID++
+	return strconv.Itoa(f.lastID)
+}
+
+func (f *fakeDemoHealthService) CreateProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	stored := *profile
+	stored.ID = f.nextID()
+	f.profile = &stored
+	return nil
+}
+
+func (f *fakeDemoHealthService) GetProfile(ctx context.Context, userID string) (*domain.HealthProfile, error) {
+	if f.profile == nil {
+		return nil, ErrProfileNotFound
+	}
+	return f.profile, nil
+}
+
+func (f *fakeDemoHealthService) GetFamilyProfiles(ctx context.Context, userID string) ([]domain.HealthProfile, error) {
+	if f.profile == nil {
+		return []domain.HealthProfile{}, nil
+	}
+	return []domain.HealthProfile{*f.profile}, nil
+}
+
+func (f *fakeDemoHealthService) DeleteProfile(ctx context.Context, userID string) error {
+	*f = fakeDemoHealthService{lastID: f.lastID}
+	return nil
+}
+
+func (f *fakeDemoHealthService) AddCondition(ctx context.Context, condition *domain.MedicalCondition) error {
+	if err := condition.Validate(); err != nil {
+		return err
+	}
+	stored := *condition
+	stored.ID = f.nextID()
+	f.conditions = append(f.conditions, stored)
+	return nil
+}
+
+func (f *fakeDemoHealthService) GetConditions(ctx context.Context, userID string) ([]domain.MedicalCondition, error) {
+	return f.conditions, nil
+}
+
+func (f *fakeDemoHealthService) DeleteCondition(ctx context.Context, userID, conditionID string) error {
+	f.conditions = slices.DeleteFunc(f.conditions, func(c domain.MedicalCondition) bool { return c.ID == conditionID })
+	return nil
+}
+
+func (f *fakeDemoHealthService) AddInsurancePolicy(ctx context.Context, policy *domain.InsurancePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	stored := *policy
+	stored.ID = f.nextID()
+	f.policies = append(f.policies, stored)
+	return nil
+}
+
+func (f *fakeDemoHealthService) GetActivePolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
+	return f.policies, nil
+}
+
+func (f *fakeDemoHealthService) DeleteInsurancePolicy(ctx context.Context, userID, policyID string) error {
+	f.policies = slices.DeleteFunc(f.policies, func(p domain.InsurancePolicy) bool { return p.ID == policyID })
+	return nil
+}
+
+func (f *fakeDemoHealthService) AddExpense(ctx context.Context, expense *domain.MedicalExpense) error {
+	if f.failExpenses {
+		return errors.New("database unavailable")
+	}
+	if err := expense.Validate(); err != nil {
+		return err
+	}
+	stored := *expense
+	stored.ID = f.nextID()
+	f.expenses = append(f.expenses, stored)
+	return nil
+}
+
+func (f *fakeDemoHealthService) GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
+	return f.expenses, nil
+}
+
+func (f *fakeDemoHealthService) DeleteExpense(ctx context.Context, userID, expenseID string) error {
+	f.expenses = slices.DeleteFunc(f.expenses, func(e domain.MedicalExpense) bool { return e.ID == expenseID })
+	return nil
+}
+
+// memoryDemoDataRepository keeps demo data records in memory
+type memoryDemoDataRepository struct {
+	records []domain.DemoDataRecord
+}
+
+func (r *memoryDemoDataRepository) SaveRecords(ctx context.Context, records []domain.DemoDataRecord) error {
+	r.records = append(r.records, records...)
+	return nil
+}
+
+func (r *memoryDemoDataRepository) GetUserRecords(ctx context.Context, userID string) ([]domain.DemoDataRecord, error) {
+	var records []domain.DemoDataRecord
+	for _, record := range r.records {
+		if record.UserID == userID {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (r *memoryDemoDataRepository) DeleteUserRecords(ctx context.Context, userID string) error {
+	r.records = slices.DeleteFunc(r.records, func(record domain.DemoDataRecord) bool { return record.UserID == userID })
+	return nil
+}
+
+func setupDemoDataService(opts ...DemoDataServiceOption) (*demoDataService, *fakeDemoFinanceService, *fakeDemoHealthService, *memoryDemoDataRepository) {
+	setupTestLogger()
+	finance := &fakeDemoFinanceService{}
+	health := &fakeDemoHealthService{}
+	repo := &memoryDemoDataRepository{}
+	return NewDemoDataService(finance, health, repo, opts...), finance, health, repo
+}
+
+func TestDemoDataService_Seed_CreatesBoundedDataset(t *testing.T) {
+	for seed := uint64(0); seed < 20; seed++ {
+		service, finance, health, repo := setupDemoDataService(WithDemoDataSeed(seed))
+
+		batch, err := service.Seed(context.Background(), "user-1")
+
+		require.NoError(t, err, "seed %d", seed)
+		assert.True(t, len(finance.incomes) >= 2 && len(finance.incomes) <= 3, "seed %d: %d incomes", seed, len(finance.incomes))
+		assert.True(t, len(finance.expenses) >= 8 && len(finance.expenses) <= 12, "seed %d: %d expenses", seed, len(finance.expenses))
+		assert.True(t, len(finance.loans) >= 1 && len(finance.loans) <= 2, "seed %d: %d loans", seed, len(finance.loans))
+		require.NotNil(t, health.profile)
+		assert.Len(t, health.conditions, 2)
+		assert.Len(t, health.policies, 1)
+		assert.True(t, len(health.expenses) >= 3 && len(health.expenses) <= 5, "seed %d: %d medical expenses", seed, len(health.expenses))
+
+		counts := batch.Counts()
+		assert.Equal(t, len(finance.incomes), counts[domain.AuditResourceIncome])
+		assert.Equal(t, len(finance.expenses), counts[domain.AuditResourceExpense])
+		assert.Equal(t, len(finance.loans), counts[domain.AuditResourceLoan])
+		assert.Equal(t, 1, counts[domain.AuditResourceHealthProfile])
+		assert.Equal(t, len(health.expenses), counts[domain.AuditResourceMedicalExpense])
+		assert.Equal(t, batch.Records, repo.records)
+		for _, expense := range finance.expenses {
+			assert.Equal(t, []string{DemoDataTag}, expense.Tags)
+		}
+	}
+}
+
+func TestDemoDataService_Seed_RefusesAccountWithData(t *testing.T) {
+	service, finance, _, repo := setupDemoDataService()
+	finance.incomes = []domain.Income{{ID: "income-1", UserID: "user-1"}}
+
+	_, err := service.Seed(context.Background(), "user-1")
+
+	assert.ErrorIs(t, err, domain.ErrAccountHasData)
+	assert.Len(t, finance.incomes, 1)
+	assert.Empty(t, repo.records)
+}
+
+func TestDemoDataService_Seed_RollsBackOnFailure(t *testing.T) {
+	service, finance, health, repo := setupDemoDataService()
+	// Medical expenses are seeded last, after the conditions and policy whose IDs aren't known yet
+	health.failExpenses = true
+
+	_, err := service.Seed(context.Background(), "user-1")
+
+	require.Error(t, err)
+
+	assert.Empty(t, finance.incomes)
+	assert.Empty(t, finance.expenses)
+	assert.Empty(t, finance.loans)
+	assert.Nil(t, health.profile)
+	assert.Empty(t, health.conditions)
+	assert.Empty(t, health.policies)
+	assert.Empty(t, repo.records)
+}
+
+func TestDemoDataService_Remove_DeletesOnlySeededRecords(t *testing.T) {
+	service, finance, health, repo := setupDemoDataService(WithDemoDataSeed(3))
+	ctx := context.Background()
+	batch, err := service.Seed(ctx, "user-1")
+	require.NoError(t, err)
+
+	// The user deletes one seeded income and adds records of their own
+	require.NoError(t, finance.DeleteIncome(ctx, "user-1", finance.incomes[0].ID))
+	own := domain.Expense{ID: "expense-own", UserID: "user-1", Name: "Car insurance"}
+	finance.expenses = append(finance.expenses, own)
+	health.conditions = append(health.conditions, domain.MedicalCondition{ID: "own", UserID: "user-1", Name: "Flu"})
+
+	removed, err := service.Remove(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, len(batch.Records)-2, removed, "the deleted income and the profile in use aren't counted")
+	assert.Empty(t, finance.incomes)
+	assert.Equal(t, []domain.Expense{own}, finance.expenses)
+	assert.Empty(t, finance.loans)
+	assert.NotNil(t, health.profile, "the profile is kept while the user's own condition needs it")
+	assert.Len(t, health.conditions, 1)
+	assert.Empty(t, health.policies)
+	assert.Empty(t, health.expenses)
+	assert.Empty(t, repo.records)
+
+	_, err = service.Remove(ctx, "user-1")
+	assert.ErrorIs(t, err, domain.ErrDemoDataNotFound)
+}
+
+func TestDemoDataService_Remove_DeletesSeededProfile(t *testing.T) {
+	service, finance, health, _ := setupDemoDataService()
+	ctx := context.Background()
+	batch, err := service.Seed(ctx, "user-1")
+	require.NoError(t, err)
+
+	removed, err := service.Remove(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, len(batch.Records), removed)
+	assert.Nil(t, health.profile)
+	assert.Empty(t, finance.expenses)
+
+	_, err = service.Seed(ctx, "user-1")
+	assert.NoError(t, err, "an account emptied of demo data can be seeded again")
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// StaticExchangeRateProvider serves fixed exchange rates quoted against one base currency.
+// Rates between two non-base currencies are crossed through the base.
+type StaticExchangeRateProvider struct {
+	base  string
+	rates map[string]float64
+}
+
+// NewStaticExchangeRateProvider creates a provider from rates, which maps a currency code to
+// how many units of it one unit of base buys. Codes are normalized, so config keys that
+// were lower-cased on load still match; non-positive rates are ignored. An empty base is
+// domain.DefaultCurrency.
+func NewStaticExchangeRateProvider(base string, rates map[string]float64) *StaticExchangeRateProvider {
+	base = domain.NormalizeCurrency(base)
+	if base == "" {
+		base = domain.DefaultCurrency
+	}
+	p := &StaticExchangeRateProvider{
+		base:  base,
+		rates: map[string]float64{base: 1},
+	}
+	for code, rate := range rates {
+		if rate > 0 {
+			p.rates[domain.NormalizeCurrency(code)] = rate
+		}
+	}
+	p.rates[base] = 1
+	return p
+}
+
+// Rate returns how many units of to one unit of from buys
+func (p *StaticExchangeRateProvider) Rate(ctx context.Context, from, to string) (float64, error) {
+	from, to = domain.NormalizeCurrency(from), domain.NormalizeCurrency(to)
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := p.rates[from]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s: %w", from, domain.ErrUnsupportedCurrency)
+	}
+	toRate, ok := p.rates[to]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %s: %w", to, domain.ErrUnsupportedCurrency)
+	}
+	return toRate / fromRate, nil
+}
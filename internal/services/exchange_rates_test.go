@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func TestStaticExchangeRateProvider_Rate(t *testing.T) {
+	// Keys arrive lower-cased from the config file
+	provider := NewStaticExchangeRateProvider("usd", map[string]float64{"eur": 0.5, "GBP": 0.8, "XXX": 0})
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		from, to string
+		expected float64
+	}{
+		{"same currency", "EUR", "EUR", 1},
+		{"base to quoted", "USD", "EUR", 0.5},
+		{"quoted to base", "EUR", "USD", 2},
+		{"crossed through base", "EUR", "GBP", 1.6},
+		{"lower-case codes", "eur", "usd", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := provider.Rate(ctx, tt.from, tt.to)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.expected, rate, 1e-9)
+		})
+	}
+
+	_, err := provider.Rate(ctx, "JPY", "USD")
+	assert.True(t, errors.Is(err, domain.ErrUnsupportedCurrency))
+	_, err = provider.Rate(ctx, "USD", "XXX")
+	assert.True(t, errors.Is(err, domain.ErrUnsupportedCurrency), "non-positive rates are ignored")
+}
@@ -3,32 +3,222 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/money"
 )
 
+const (
+	// DefaultFinancePageSize is used when a paginated list request doesn't specify a limit
+	DefaultFinancePageSize = 20
+	// MaxFinancePageSize caps the limit of paginated list requests
+	MaxFinancePageSize = 100
+	// MaxBulkDeleteExpenses caps the number of expenses removed by one BulkDeleteExpenses call
+	MaxBulkDeleteExpenses = 100
+	// DefaultDuplicateWindow is how far back AddIncome and AddExpense look for a matching record
+	DefaultDuplicateWindow = 24 * time.Hour
+	// exportBatchSize is how many records the export methods read from a repository at a time
+	exportBatchSize = 500
+)
 
 // financeService implements the FinanceService interface
 type financeService struct {
-	repos *FinanceRepositories
+	repos        *FinanceRepositories
+	summaryCache *financeSummaryCache
+	events       EventPublisher
+	audit        AuditRecorder
+	txManager    TxManager
+	baseCurrency string
+	rates        ExchangeRateProvider
+	thresholds   domain.FinancialThresholds
+	// duplicateWindow is how recent a matching record must be to reject a new one; 0 disables the check
+	duplicateWindow time.Duration
+	budgets         BudgetRepository
+}
+
+// FinanceServiceOption customizes a finance service created by NewFinanceService
+type FinanceServiceOption func(*financeService)
+
+// WithSummaryCacheTTL sets how long a user's finance summary is cached.
+// A ttl of 0 disables the cache so every call reads the repositories.
+func WithSummaryCacheTTL(ttl time.Duration) FinanceServiceOption {
+	return func(s *financeService) {
+		if ttl < 0 {
+			ttl = 0
+		}
+		s.summaryCache = newFinanceSummaryCache(ttl)
+	}
+}
+
+// WithFinanceEventPublisher publishes financial health, debt-to-income and budget events
+// when a recalculated summary crosses into a new state
+func WithFinanceEventPublisher(publisher EventPublisher) FinanceServiceOption {
+	return func(s *financeService) {
+		s.events = publisher
+	}
+}
+
+// WithFinanceAuditRecorder records every income, expense, loan and savings goal change in the audit log
+func WithFinanceAuditRecorder(recorder AuditRecorder) FinanceServiceOption {
+	return func(s *financeService) {
+		s.audit = recorder
+	}
+}
+
+// WithFinanceTxManager runs multi-record operations such as BulkDeleteExpenses in a transaction.
+// Without it they run statement by statement.
+func WithFinanceTxManager(txManager TxManager) FinanceServiceOption {
+	return func(s *financeService) {
+		s.txManager = txManager
+	}
+}
+
+// WithBaseCurrency sets the ISO 4217 currency summaries are computed in and new incomes,
+// expenses and loans default to. An empty or malformed code leaves the default, USD.
+func WithBaseCurrency(code string) FinanceServiceOption {
+	return func(s *financeService) {
+		if code = domain.NormalizeCurrency(code); domain.IsValidCurrency(code) {
+			s.baseCurrency = code
+		}
+	}
+}
+
+// WithExchangeRateProvider converts amounts in other currencies to the base currency.
+// Without it only records in the base currency are accepted.
+func WithExchangeRateProvider(provider ExchangeRateProvider) FinanceServiceOption {
+	return func(s *financeService) {
+		s.rates = provider
+	}
+}
+
+// WithFinancialThresholds sets the thresholds summaries are rated and affordability is
+// computed with; the default is domain.DefaultFinancialThresholds
+func WithFinancialThresholds(thresholds domain.FinancialThresholds) FinanceServiceOption {
+	return func(s *financeService) {
+		s.thresholds = thresholds
+	}
+}
+
+// WithDuplicateWindow sets how far back AddIncome and AddExpense look for a record the new one
+// duplicates. A window of 0 disables duplicate detection.
+func WithDuplicateWindow(window time.Duration) FinanceServiceOption {
+	return func(s *financeService) {
+		if window < 0 {
+			window = 0
+		}
+		s.duplicateWindow = window
+	}
+}
+
+// WithBudgetRepository enables per-category budgets. Once set, the finance summary compares
+// the month's spending in each budgeted category with its budget.
+func WithBudgetRepository(repo BudgetRepository) FinanceServiceOption {
+	return func(s *financeService) {
+		s.budgets = repo
+	}
+}
+
+// directTxManager runs fn without a transaction; it is the finance service default
+type directTxManager struct{}
+
+func (directTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
 }
 
 // NewFinanceService creates a new FinanceService instance
 // Returns concrete type that implements FinanceService interface defined in handlers package
-func NewFinanceService(repos *FinanceRepositories) *financeService {
-	return &financeService{
-		repos: repos,
+func NewFinanceService(repos *FinanceRepositories, opts ...FinanceServiceOption) *financeService {
+	s := &financeService{
+		repos:           repos,
+		summaryCache:    newFinanceSummaryCache(DefaultFinanceSummaryCacheTTL),
+		audit:           nopAuditRecorder{},
+		txManager:       directTxManager{},
+		baseCurrency:    domain.DefaultCurrency,
+		thresholds:      domain.DefaultFinancialThresholds(),
+		duplicateWindow: DefaultDuplicateWindow,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rates == nil {
+		s.rates = NewStaticExchangeRateProvider(s.baseCurrency, nil)
+	}
+	return s
+}
+
+// BaseCurrency returns the ISO 4217 code finance summaries are computed in
+func (s *financeService) BaseCurrency() string {
+	return s.baseCurrency
+}
+
+// resolveCurrency returns the currency a record is stored in: code, or the base currency
+// when code is empty. Returns an error wrapping domain.ErrUnsupportedCurrency if code has
+// no rate to the base currency, since the record could not be counted in the summary.
+func (s *financeService) resolveCurrency(ctx context.Context, code string) (string, error) {
+	if code == "" {
+		return s.baseCurrency, nil
+	}
+	if _, err := s.rates.Rate(ctx, code, s.baseCurrency); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// toBaseCurrency converts amount from currency to the base currency
+func (s *financeService) toBaseCurrency(ctx context.Context, amount float64, currency string) (float64, error) {
+	if currency == "" || currency == s.baseCurrency {
+		return amount, nil
 	}
+	rate, err := s.rates.Rate(ctx, currency, s.baseCurrency)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
 }
 
-// AddIncome validates and adds a new income record
-func (s *financeService) AddIncome(ctx context.Context, income domain.Income) error {
+// AddIncome validates and adds a new income record. Unless allowDuplicate is set, returns a
+// *domain.DuplicateRecordError if the user added an active income with the same source, amount
+// and frequency within the duplicate window.
+func (s *financeService) AddIncome(ctx context.Context, income domain.Income, allowDuplicate bool) error {
 	if err := income.Validate(); err != nil {
 		return domain.ErrInvalidIncomeData
 	}
 
-	return s.repos.Income.SaveIncome(ctx, income)
+	currency, err := s.resolveCurrency(ctx, income.Currency)
+	if err != nil {
+		return err
+	}
+	income.Currency = currency
+
+	if !allowDuplicate && s.duplicateWindow > 0 {
+		existingID, err := s.repos.Income.FindDuplicateIncomeID(ctx, income, time.Now().Add(-s.duplicateWindow))
+		if err != nil {
+			return err
+		}
+		if existingID != "" {
+			return &domain.DuplicateRecordError{Resource: domain.AuditResourceIncome, ExistingID: existingID}
+		}
+	}
+
+	// Assigned here rather than by the repository so the audit entry can name the income
+	if income.ID == "" {
+		income.ID = newResourceID("income")
+	}
+
+	err = s.repos.Income.SaveIncome(ctx, income)
+	s.summaryCache.invalidate(income.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceIncome, income.ID, nil, income)
+	return nil
 }
 
 // UpdateIncome validates and updates an existing income record
@@ -47,7 +237,21 @@ func (s *financeService) UpdateIncome(ctx context.Context, income domain.Income)
 		return domain.ErrIncomeNotOwnedByUser
 	}
 
-	return s.repos.Income.UpdateIncome(ctx, income)
+	if income.Currency == "" {
+		income.Currency = existing.Currency
+	}
+	if income.Currency, err = s.resolveCurrency(ctx, income.Currency); err != nil {
+		return err
+	}
+
+	err = s.repos.Income.UpdateIncome(ctx, income)
+	s.summaryCache.invalidate(income.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceIncome, income.ID, existing, income)
+	return nil
 }
 
 // DeleteIncome removes an income record after verifying ownership
@@ -62,7 +266,36 @@ func (s *financeService) DeleteIncome(ctx context.Context, userID, incomeID stri
 		return domain.ErrIncomeNotOwnedByUser
 	}
 
-	return s.repos.Income.DeleteIncome(ctx, incomeID)
+	err = s.repos.Income.DeleteIncome(ctx, incomeID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceIncome, incomeID, existing, nil)
+	return nil
+}
+
+// RestoreIncome brings back a soft-deleted income record after verifying ownership
+func (s *financeService) RestoreIncome(ctx context.Context, userID, incomeID string) error {
+	// Verify ownership
+	existing, err := s.repos.Income.GetDeletedIncomeByID(ctx, incomeID)
+	if err != nil {
+		return domain.ErrIncomeNotFound
+	}
+
+	if existing.UserID != userID {
+		return domain.ErrIncomeNotOwnedByUser
+	}
+
+	err = s.repos.Income.RestoreIncome(ctx, incomeID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionRestore, domain.AuditResourceIncome, incomeID, nil, existing)
+	return nil
 }
 
 // GetUserIncomes retrieves all income records for a user
@@ -70,22 +303,84 @@ func (s *financeService) GetUserIncomes(ctx context.Context, userID string) ([]d
 	return s.repos.Income.GetUserIncomes(ctx, userID)
 }
 
+// GetUserIncomesPage retrieves one page of a user's incomes, oldest first, and the cursor for the next page
+// cursor is a token returned by a previous page, or "" for the first page; next is "" on the last page
+// Returns an error wrapping domain.ErrInvalidCursor if the cursor is malformed
+func (s *financeService) GetUserIncomesPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Income, string, error) {
+	after, err := domain.DecodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = financePageSize(limit)
+
+	// Fetch one extra row to learn whether another page follows
+	incomes, err := s.repos.Income.GetUserIncomesAfter(ctx, userID, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(incomes) <= limit {
+		return incomes, "", nil
+	}
+
+	incomes = incomes[:limit]
+	last := incomes[limit-1]
+	return incomes, domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode(), nil
+}
+
 // GetActiveUserIncomes retrieves only active income records for a user
 func (s *financeService) GetActiveUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
 	return s.repos.Income.GetActiveIncomes(ctx, userID)
 }
 
-// AddExpense validates and adds a new expense record
-func (s *financeService) AddExpense(ctx context.Context, expense domain.Expense) error {
+// AddExpense validates and adds a new expense record. Unless allowDuplicate is set, returns a
+// *domain.DuplicateRecordError if the user added an expense with the same name, amount and
+// frequency within the duplicate window.
+func (s *financeService) AddExpense(ctx context.Context, expense domain.Expense, allowDuplicate bool) error {
+	expense.Tags = domain.NormalizeExpenseTags(expense.Tags)
+	if err := domain.ValidateExpenseTags(expense.Tags); err != nil {
+		return err
+	}
 	if err := expense.Validate(); err != nil {
 		return domain.ErrInvalidExpenseData
 	}
 
-	return s.repos.Expense.SaveExpense(ctx, expense)
+	currency, err := s.resolveCurrency(ctx, expense.Currency)
+	if err != nil {
+		return err
+	}
+	expense.Currency = currency
+
+	if !allowDuplicate && s.duplicateWindow > 0 {
+		existingID, err := s.repos.Expense.FindDuplicateExpenseID(ctx, expense, time.Now().Add(-s.duplicateWindow))
+		if err != nil {
+			return err
+		}
+		if existingID != "" {
+			return &domain.DuplicateRecordError{Resource: domain.AuditResourceExpense, ExistingID: existingID}
+		}
+	}
+
+	// Assigned here rather than by the repository so the audit entry can name the expense
+	if expense.ID == "" {
+		expense.ID = newResourceID("expense")
+	}
+
+	err = s.repos.Expense.SaveExpense(ctx, expense)
+	s.summaryCache.invalidate(expense.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceExpense, expense.ID, nil, expense)
+	return nil
 }
 
 // UpdateExpense validates and updates an existing expense record
 func (s *financeService) UpdateExpense(ctx context.Context, expense domain.Expense) error {
+	expense.Tags = domain.NormalizeExpenseTags(expense.Tags)
+	if err := domain.ValidateExpenseTags(expense.Tags); err != nil {
+		return err
+	}
 	if err := expense.Validate(); err != nil {
 		return domain.ErrInvalidExpenseData
 	}
@@ -100,7 +395,21 @@ func (s *financeService) UpdateExpense(ctx context.Context, expense domain.Expen
 		return domain.ErrExpenseNotOwnedByUser
 	}
 
-	return s.repos.Expense.UpdateExpense(ctx, expense)
+	if expense.Currency == "" {
+		expense.Currency = existing.Currency
+	}
+	if expense.Currency, err = s.resolveCurrency(ctx, expense.Currency); err != nil {
+		return err
+	}
+
+	err = s.repos.Expense.UpdateExpense(ctx, expense)
+	s.summaryCache.invalidate(expense.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceExpense, expense.ID, existing, expense)
+	return nil
 }
 
 // DeleteExpense removes an expense record after verifying ownership
@@ -115,7 +424,133 @@ func (s *financeService) DeleteExpense(ctx context.Context, userID, expenseID st
 		return domain.ErrExpenseNotOwnedByUser
 	}
 
-	return s.repos.Expense.DeleteExpense(ctx, expenseID)
+	err = s.repos.Expense.DeleteExpense(ctx, expenseID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceExpense, expenseID, existing, nil)
+	return nil
+}
+
+// BulkDeleteExpenses deletes several of the user's expenses at once and returns how many were deleted.
+// Every expense is checked before any is deleted, so if one doesn't exist or belongs to another user
+// nothing is deleted. Duplicate IDs are deleted once.
+func (s *financeService) BulkDeleteExpenses(ctx context.Context, userID string, ids []string) (int, error) {
+	ids = uniqueIDs(ids)
+	if len(ids) == 0 {
+		return 0, fmt.Errorf("no expense IDs given: %w", domain.ErrInvalidExpenseData)
+	}
+	if len(ids) > MaxBulkDeleteExpenses {
+		return 0, fmt.Errorf("at most %d expenses can be deleted at once: %w", MaxBulkDeleteExpenses, domain.ErrInvalidExpenseData)
+	}
+
+	var deleted []domain.Expense
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		existing := make([]domain.Expense, 0, len(ids))
+		for _, id := range ids {
+			expense, err := s.repos.Expense.GetExpenseByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("expense %s: %w", id, domain.ErrExpenseNotFound)
+			}
+			if expense.UserID != userID {
+				return fmt.Errorf("expense %s: %w", id, domain.ErrExpenseNotOwnedByUser)
+			}
+			existing = append(existing, expense)
+		}
+
+		count, err := s.repos.Expense.DeleteExpenses(ctx, ids)
+		if err != nil {
+			return err
+		}
+		// Another request deleted one of them since the check; roll back rather than report a partial delete
+		if count != int64(len(ids)) {
+			return fmt.Errorf("deleted %d of %d expenses: %w", count, len(ids), domain.ErrExpenseNotFound)
+		}
+
+		deleted = existing
+		return nil
+	})
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, expense := range deleted {
+		s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceExpense, expense.ID, expense, nil)
+	}
+	return len(deleted), nil
+}
+
+// uniqueIDs returns ids without blanks and repeats, keeping their order
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// RestoreExpense brings back a soft-deleted expense record after verifying ownership
+func (s *financeService) RestoreExpense(ctx context.Context, userID, expenseID string) error {
+	// Verify ownership
+	existing, err := s.repos.Expense.GetDeletedExpenseByID(ctx, expenseID)
+	if err != nil {
+		return domain.ErrExpenseNotFound
+	}
+
+	if existing.UserID != userID {
+		return domain.ErrExpenseNotOwnedByUser
+	}
+
+	err = s.repos.Expense.RestoreExpense(ctx, expenseID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionRestore, domain.AuditResourceExpense, expenseID, nil, existing)
+	return nil
+}
+
+// RecordInstallmentPaid marks the next installment of an installment expense as paid and
+// returns the updated expense. Paying the last installment makes the expense inactive, so it
+// no longer counts towards monthly expenses.
+func (s *financeService) RecordInstallmentPaid(ctx context.Context, userID, expenseID string) (domain.Expense, error) {
+	existing, err := s.repos.Expense.GetExpenseByID(ctx, expenseID)
+	if err != nil {
+		return domain.Expense{}, domain.ErrExpenseNotFound
+	}
+
+	if existing.UserID != userID {
+		return domain.Expense{}, domain.ErrExpenseNotOwnedByUser
+	}
+
+	if !existing.IsInstallment() {
+		return domain.Expense{}, fmt.Errorf("expense %s is not paid in installments: %w", expenseID, domain.ErrInvalidExpenseData)
+	}
+	if !existing.IsActive() {
+		return domain.Expense{}, domain.ErrInstallmentsComplete
+	}
+
+	expense := existing
+	expense.InstallmentsPaid++
+	expense.UpdatedAt = time.Now()
+
+	err = s.repos.Expense.UpdateExpense(ctx, expense)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return domain.Expense{}, err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceExpense, expense.ID, existing, expense)
+	return expense, nil
 }
 
 // GetUserExpenses retrieves all expense records for a user
@@ -128,16 +563,84 @@ func (s *financeService) GetUserExpensesByCategory(ctx context.Context, userID,
 	return s.repos.Expense.GetExpensesByCategory(ctx, userID, category)
 }
 
+// GetUserExpensesByTag retrieves a user's expense records carrying the tag, newest first. The tag
+// is normalized the way tags are when they are saved, so "  Vacation" finds "vacation".
+// Returns an error wrapping domain.ErrInvalidExpenseFilter if the tag is blank or malformed
+func (s *financeService) GetUserExpensesByTag(ctx context.Context, userID, tag string) ([]domain.Expense, error) {
+	tag = domain.NormalizeExpenseTag(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("%w: tag is required", domain.ErrInvalidExpenseFilter)
+	}
+
+	return s.SearchUserExpenses(ctx, userID, domain.ExpenseFilter{Tag: tag})
+}
+
+// SearchUserExpenses retrieves a user's expense records matching the filter
+// Returns an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+func (s *financeService) SearchUserExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repos.Expense.FindExpenses(ctx, userID, filter)
+}
+
+// ExportExpenses passes each of a user's expenses matching the filter to fn, oldest first. Records
+// are read in batches so the whole history is never held in memory. Stops at the first error fn returns.
+// Returns an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+func (s *financeService) ExportExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Expense) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	var after domain.Cursor
+	for {
+		expenses, err := s.repos.Expense.FindExpensesAfter(ctx, userID, filter, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, expense := range expenses {
+			if err := fn(expense); err != nil {
+				return err
+			}
+		}
+		if len(expenses) < exportBatchSize {
+			return nil
+		}
+		last := expenses[len(expenses)-1]
+		after = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+}
+
 // AddLoan validates and adds a new loan record
 func (s *financeService) AddLoan(ctx context.Context, loan domain.Loan) error {
 	if err := loan.Validate(); err != nil {
 		return domain.ErrInvalidLoanData
 	}
 
-	return s.repos.Loan.SaveLoan(ctx, loan)
+	currency, err := s.resolveCurrency(ctx, loan.Currency)
+	if err != nil {
+		return err
+	}
+	loan.Currency = currency
+
+	// Assigned here rather than by the repository so the audit entry can name the loan
+	if loan.ID == "" {
+		loan.ID = newResourceID("loan")
+	}
+
+	err = s.repos.Loan.SaveLoan(ctx, loan)
+	s.summaryCache.invalidate(loan.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceLoan, loan.ID, nil, loan)
+	return nil
 }
 
-// UpdateLoan validates and updates an existing loan record
+// UpdateLoan validates and updates an existing loan record, recording a change to its balance
+// as an adjustment in the loan's balance history
 func (s *financeService) UpdateLoan(ctx context.Context, loan domain.Loan) error {
 	if err := loan.Validate(); err != nil {
 		return domain.ErrInvalidLoanData
@@ -153,16 +656,35 @@ func (s *financeService) UpdateLoan(ctx context.Context, loan domain.Loan) error
 		return domain.ErrLoanNotOwnedByUser
 	}
 
-	return s.repos.Loan.UpdateLoan(ctx, loan)
-}
+	if loan.Currency == "" {
+		loan.Currency = existing.Currency
+	}
+	if loan.Currency, err = s.resolveCurrency(ctx, loan.Currency); err != nil {
+		return err
+	}
 
-// GetUserLoans retrieves all loan records for a user
-func (s *financeService) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
-	return s.repos.Loan.GetUserLoans(ctx, userID)
+	err = s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repos.Loan.UpdateLoan(ctx, loan); err != nil {
+			return err
+		}
+		if loan.RemainingBalance == existing.RemainingBalance {
+			return nil
+		}
+		// Keep the balance history complete when an edit changes the balance
+		entry := existing.AdjustBalance(loan.RemainingBalance, "", time.Now())
+		return s.recordBalanceChange(ctx, &entry)
+	})
+	s.summaryCache.invalidate(loan.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceLoan, loan.ID, existing, loan)
+	return nil
 }
 
-// UpdateLoanBalance updates the remaining balance for a loan after verifying ownership
-func (s *financeService) UpdateLoanBalance(ctx context.Context, userID, loanID string, newBalance float64) error {
+// DeleteLoan deletes a loan record after verifying ownership
+func (s *financeService) DeleteLoan(ctx context.Context, userID, loanID string) error {
 	// Verify ownership
 	existing, err := s.repos.Loan.GetLoanByID(ctx, loanID)
 	if err != nil {
@@ -173,67 +695,815 @@ func (s *financeService) UpdateLoanBalance(ctx context.Context, userID, loanID s
 		return domain.ErrLoanNotOwnedByUser
 	}
 
-	if newBalance < 0 {
-		return fmt.Errorf("loan balance cannot be negative")
+	err = s.repos.Loan.DeleteLoan(ctx, loanID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
 	}
 
-	return s.repos.Loan.UpdateLoanBalance(ctx, loanID, newBalance)
+	s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceLoan, loanID, existing, nil)
+	return nil
 }
 
-// CalculateFinanceSummary aggregates all financial data for a user
-func (s *financeService) CalculateFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error) {
-	// Get all active incomes
-	incomes, err := s.repos.Income.GetActiveIncomes(ctx, userID)
+// GetUserLoans retrieves all loan records for a user
+func (s *financeService) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
+	return s.repos.Loan.GetUserLoans(ctx, userID)
+}
+
+// GetUserLoansPage retrieves one page of a user's loans, oldest first, and the cursor for the next page
+// cursor is a token returned by a previous page, or "" for the first page; next is "" on the last page
+// Returns an error wrapping domain.ErrInvalidCursor if the cursor is malformed
+func (s *financeService) GetUserLoansPage(ctx context.Context, userID, cursor string, limit int) ([]domain.Loan, string, error) {
+	after, err := domain.DecodeCursor(cursor)
 	if err != nil {
-		return domain.FinanceSummary{}, fmt.Errorf("failed to get user incomes: %w", err)
+		return nil, "", err
 	}
+	limit = financePageSize(limit)
 
-	// Get all expenses
-	expenses, err := s.repos.Expense.GetUserExpenses(ctx, userID)
+	// Fetch one extra row to learn whether another page follows
+	loans, err := s.repos.Loan.GetUserLoansAfter(ctx, userID, after, limit+1)
 	if err != nil {
-		return domain.FinanceSummary{}, fmt.Errorf("failed to get user expenses: %w", err)
+		return nil, "", err
+	}
+	if len(loans) <= limit {
+		return loans, "", nil
 	}
 
-	// Get all loans
-	loans, err := s.repos.Loan.GetUserLoans(ctx, userID)
-	if err != nil {
-		return domain.FinanceSummary{}, fmt.Errorf("failed to get user loans: %w", err)
+	loans = loans[:limit]
+	last := loans[limit-1]
+	return loans, domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode(), nil
+}
+
+// ExportIncomes passes each of a user's incomes created within the filter's date range to fn,
+// oldest first, reading them in batches. The filter's other criteria only apply to expenses.
+// Returns an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+func (s *financeService) ExportIncomes(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Income) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
 	}
 
-	// Calculate monthly totals
-	monthlyIncome := 0.0
-	for _, income := range incomes {
-		normalized, err := s.NormalizeToMonthly(income.Amount, income.Frequency)
+	// An empty cursor ID sorts before every real ID, so records created exactly at CreatedFrom are kept
+	after := domain.Cursor{CreatedAt: filter.CreatedFrom}
+	for {
+		incomes, err := s.repos.Income.GetUserIncomesAfter(ctx, userID, after, exportBatchSize)
 		if err != nil {
-			continue // Skip invalid frequencies
+			return err
 		}
-		monthlyIncome += normalized
+		for _, income := range incomes {
+			if beyondExportRange(income.CreatedAt, filter) {
+				return nil
+			}
+			if err := fn(income); err != nil {
+				return err
+			}
+		}
+		if len(incomes) < exportBatchSize {
+			return nil
+		}
+		last := incomes[len(incomes)-1]
+		after = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
 	}
+}
 
-	monthlyExpenses := 0.0
-	for _, expense := range expenses {
-		normalized, err := s.NormalizeToMonthly(expense.Amount, expense.Frequency)
+// ExportLoans passes each of a user's loans created within the filter's date range to fn,
+// oldest first, reading them in batches. The filter's other criteria only apply to expenses.
+// Returns an error wrapping domain.ErrInvalidExpenseFilter if the filter is invalid
+func (s *financeService) ExportLoans(ctx context.Context, userID string, filter domain.ExpenseFilter, fn func(domain.Loan) error) error {
+	if err := filter.Validate(); err != nil {
+		return err
+	}
+
+	after := domain.Cursor{CreatedAt: filter.CreatedFrom}
+	for {
+		loans, err := s.repos.Loan.GetUserLoansAfter(ctx, userID, after, exportBatchSize)
 		if err != nil {
-			continue // Skip invalid frequencies
+			return err
 		}
-		monthlyExpenses += normalized
+		for _, loan := range loans {
+			if beyondExportRange(loan.CreatedAt, filter) {
+				return nil
+			}
+			if err := fn(loan); err != nil {
+				return err
+			}
+		}
+		if len(loans) < exportBatchSize {
+			return nil
+		}
+		last := loans[len(loans)-1]
+		after = domain.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
 	}
+}
 
-	monthlyLoanPayments := 0.0
-	for _, loan := range loans {
-		monthlyLoanPayments += loan.MonthlyPayment
-	}
+// beyondExportRange reports whether a record created at createdAt falls after the filter's date range
+func beyondExportRange(createdAt time.Time, filter domain.ExpenseFilter) bool {
+	return !filter.CreatedBefore.IsZero() && !createdAt.Before(filter.CreatedBefore)
+}
 
-	// Calculate derived metrics
-	disposableIncome := monthlyIncome - monthlyExpenses - monthlyLoanPayments
-	budgetRemaining := disposableIncome
+// newResourceID returns an ID in the format the models generate, prefix-uuid
+func newResourceID(prefix string) string {
+	return prefix + "-" + uuid.New().String()
+}
 
-	var debtToIncomeRatio float64
-	if monthlyIncome > 0 {
-		debtToIncomeRatio = monthlyLoanPayments / monthlyIncome
+// financePageSize applies the default and maximum to a requested page size
+func financePageSize(limit int) int {
+	if limit < 1 {
+		return DefaultFinancePageSize
+	}
+	if limit > MaxFinancePageSize {
+		return MaxFinancePageSize
 	}
+	return limit
+}
 
-	var savingsRate float64
+// UpdateLoanBalance sets the remaining balance for a loan after verifying ownership and records
+// the change as an adjustment in the loan's balance history
+func (s *financeService) UpdateLoanBalance(ctx context.Context, userID, loanID string, newBalance float64) error {
+	if newBalance < 0 {
+		return fmt.Errorf("loan balance cannot be negative")
+	}
+
+	var existing domain.Loan
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		loan, err := s.getOwnedLoanForUpdate(ctx, userID, loanID)
+		if err != nil {
+			return err
+		}
+		existing = loan
+
+		entry := loan.AdjustBalance(newBalance, "", time.Now())
+		return s.recordBalanceChange(ctx, &entry)
+	})
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceLoan, loanID,
+		map[string]interface{}{"remaining_balance": existing.RemainingBalance},
+		map[string]interface{}{"remaining_balance": newBalance})
+	return nil
+}
+
+// RecordLoanPayment records a payment against one of the user's loans, splitting it into the
+// interest it covers and the principal it repays, and returns the balance entry it recorded
+func (s *financeService) RecordLoanPayment(ctx context.Context, userID, loanID string, amount float64, note string) (domain.LoanBalanceEntry, error) {
+	var entry domain.LoanBalanceEntry
+	err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+		loan, err := s.getOwnedLoanForUpdate(ctx, userID, loanID)
+		if err != nil {
+			return err
+		}
+
+		var unpaidInterest float64
+		if loan.AutoAccrueInterest {
+			history, err := s.repos.Loan.GetBalanceEntries(ctx, loanID)
+			if err != nil {
+				return err
+			}
+			unpaidInterest = domain.UnpaidInterest(history)
+		}
+
+		entry, err = loan.ApplyPayment(amount, unpaidInterest, note, time.Now())
+		if err != nil {
+			return err
+		}
+		return s.recordBalanceChange(ctx, &entry)
+	})
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return domain.LoanBalanceEntry{}, err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceLoanBalanceEntry, entry.ID, nil, entry)
+	return entry, nil
+}
+
+// GetLoanBalanceHistory returns every change to one of the user's loan balances, newest first
+func (s *financeService) GetLoanBalanceHistory(ctx context.Context, userID, loanID string) ([]domain.LoanBalanceEntry, error) {
+	loan, err := s.repos.Loan.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return nil, domain.ErrLoanNotFound
+	}
+
+	if loan.UserID != userID {
+		return nil, domain.ErrLoanNotOwnedByUser
+	}
+
+	return s.repos.Loan.GetBalanceEntries(ctx, loanID)
+}
+
+// AccrueMonthlyInterest adds a month of interest to the balance of every auto-accruing loan that
+// hasn't accrued interest yet in the month of now, and returns how many loans it accrued. It is
+// safe to run repeatedly: a loan accrues at most once a month. A loan that fails is skipped and
+// retried on the next run; the first failure is returned after the rest have been tried.
+func (s *financeService) AccrueMonthlyInterest(ctx context.Context, now time.Time) (int, error) {
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	loans, err := s.repos.Loan.GetLoansDueForAccrual(ctx, monthStart)
+	if err != nil {
+		return 0, err
+	}
+
+	var accrued int
+	var firstErr error
+	for _, due := range loans {
+		var entry domain.LoanBalanceEntry
+		var ok bool
+		err := s.txManager.WithTx(ctx, func(ctx context.Context) error {
+			// Re-read under lock so a payment made since the query above is included
+			loan, err := s.repos.Loan.GetLoanByIDForUpdate(ctx, due.ID)
+			if err != nil {
+				return err
+			}
+			if entry, ok = loan.AccrueInterest(now); !ok {
+				return nil
+			}
+			return s.recordBalanceChange(ctx, &entry)
+		})
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to accrue interest on loan %s: %w", due.ID, err)
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+
+		accrued++
+		s.summaryCache.invalidate(due.UserID)
+		s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceLoanBalanceEntry, entry.ID, nil, entry)
+	}
+
+	return accrued, firstErr
+}
+
+// getOwnedLoanForUpdate loads a loan, locking it until the transaction in ctx ends, and checks
+// that it belongs to the user
+func (s *financeService) getOwnedLoanForUpdate(ctx context.Context, userID, loanID string) (domain.Loan, error) {
+	loan, err := s.repos.Loan.GetLoanByIDForUpdate(ctx, loanID)
+	if err != nil {
+		return domain.Loan{}, domain.ErrLoanNotFound
+	}
+
+	if loan.UserID != userID {
+		return domain.Loan{}, domain.ErrLoanNotOwnedByUser
+	}
+
+	return loan, nil
+}
+
+// recordBalanceChange validates entry, names it and records it, moving the loan to its new balance
+func (s *financeService) recordBalanceChange(ctx context.Context, entry *domain.LoanBalanceEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	if entry.ID == "" {
+		entry.ID = newResourceID("loanbal")
+	}
+	return s.repos.Loan.RecordBalanceChange(ctx, *entry)
+}
+
+// SimulateLoanPayoff projects how extra payments would change a loan's payoff after verifying ownership.
+// The payments are in the loan's currency; the debt-to-income ratios are worked out in the base
+// currency from the user's finance summary. The stored loan is left unchanged.
+func (s *financeService) SimulateLoanPayoff(ctx context.Context, userID, loanID string, extraMonthly, oneTimePayment float64) (domain.LoanPayoffSimulation, error) {
+	loan, err := s.repos.Loan.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return domain.LoanPayoffSimulation{}, domain.ErrLoanNotFound
+	}
+
+	if loan.UserID != userID {
+		return domain.LoanPayoffSimulation{}, domain.ErrLoanNotOwnedByUser
+	}
+
+	simulation, err := domain.SimulateLoanPayoff(loan, extraMonthly, oneTimePayment, time.Now())
+	if err != nil {
+		return domain.LoanPayoffSimulation{}, err
+	}
+
+	summary, err := s.CalculateFinanceSummary(ctx, userID)
+	if err != nil {
+		return domain.LoanPayoffSimulation{}, fmt.Errorf("failed to calculate finance summary: %w", err)
+	}
+	if summary.MonthlyIncome <= 0 {
+		return simulation, nil
+	}
+
+	extra, err := s.toBaseCurrency(ctx, extraMonthly, loan.Currency)
+	if err != nil {
+		return domain.LoanPayoffSimulation{}, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+	}
+	payment, err := s.toBaseCurrency(ctx, loan.MonthlyPayment, loan.Currency)
+	if err != nil {
+		return domain.LoanPayoffSimulation{}, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+	}
+
+	simulation.DebtToIncomeRatio = summary.DebtToIncomeRatio
+	simulation.SimulatedDebtToIncomeRatio = (summary.MonthlyLoanPayments + extra) / summary.MonthlyIncome
+	simulation.DebtToIncomeRatioAfterPayoff = math.Max(summary.MonthlyLoanPayments-payment, 0) / summary.MonthlyIncome
+	return simulation, nil
+}
+
+// CalculateExtraPaymentImpact works out how much sooner a loan is repaid, and how much interest is
+// saved, by adding extraMonthly to each payment after verifying ownership. The extra payment is in
+// the loan's currency. Loans with at most domain.NearPayoffThreshold of their principal left are
+// flagged as near payoff. The stored loan is left unchanged.
+func (s *financeService) CalculateExtraPaymentImpact(ctx context.Context, userID, loanID string, extraMonthly float64) (domain.LoanExtraPaymentImpact, error) {
+	loan, err := s.repos.Loan.GetLoanByID(ctx, loanID)
+	if err != nil {
+		return domain.LoanExtraPaymentImpact{}, domain.ErrLoanNotFound
+	}
+
+	if loan.UserID != userID {
+		return domain.LoanExtraPaymentImpact{}, domain.ErrLoanNotOwnedByUser
+	}
+
+	impact, err := domain.CalculateExtraPaymentImpact(loan, extraMonthly, time.Now())
+	if err != nil {
+		return domain.LoanExtraPaymentImpact{}, err
+	}
+
+	nearPayoff, err := s.repos.Loan.GetNearPayoffLoans(ctx, userID, domain.NearPayoffThreshold)
+	if err != nil {
+		return domain.LoanExtraPaymentImpact{}, fmt.Errorf("failed to get near payoff loans: %w", err)
+	}
+	for _, near := range nearPayoff {
+		if near.ID == loan.ID {
+			impact.NearPayoff = true
+			break
+		}
+	}
+
+	return impact, nil
+}
+
+// GetNearPayoffLoans returns the user's loans with at most thresholdPercent of their principal left to
+// repay, smallest remaining balance first. Loans that are already repaid aren't included.
+// Returns an error wrapping domain.ErrInvalidLoanData if thresholdPercent isn't greater than 0 and at most 100.
+func (s *financeService) GetNearPayoffLoans(ctx context.Context, userID string, thresholdPercent float64) ([]domain.NearPayoffLoan, error) {
+	if thresholdPercent <= 0 || thresholdPercent > 100 {
+		return nil, fmt.Errorf("%w: threshold percent must be greater than 0 and at most 100", domain.ErrInvalidLoanData)
+	}
+
+	loans, err := s.repos.Loan.GetNearPayoffLoans(ctx, userID, thresholdPercent/100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get near payoff loans: %w", err)
+	}
+
+	nearPayoff := make([]domain.NearPayoffLoan, 0, len(loans))
+	for _, loan := range loans {
+		if loan.RemainingBalance <= 0 || loan.PrincipalAmount <= 0 {
+			continue
+		}
+		// The repository compares ratios; the absolute threshold is what the user sees
+		threshold := math.Round(loan.PrincipalAmount*thresholdPercent) / 100
+		if loan.RemainingBalance > threshold {
+			continue
+		}
+		nearPayoff = append(nearPayoff, domain.NearPayoffLoan{
+			Loan:             loan,
+			ThresholdBalance: threshold,
+			PercentRemaining: math.Round(loan.RemainingBalance/loan.PrincipalAmount*10000) / 100,
+		})
+	}
+
+	sort.SliceStable(nearPayoff, func(i, j int) bool {
+		return nearPayoff[i].Loan.RemainingBalance < nearPayoff[j].Loan.RemainingBalance
+	})
+	return nearPayoff, nil
+}
+
+// GetDebtBreakdownByType groups the user's loans by type, totalling the remaining balance and monthly
+// payment of each in the base currency, and works out each type's share of the total balance.
+// Users without loans get an empty breakdown.
+func (s *financeService) GetDebtBreakdownByType(ctx context.Context, userID string) (domain.DebtBreakdown, error) {
+	loans, err := s.repos.Loan.GetUserLoans(ctx, userID)
+	if err != nil {
+		return domain.DebtBreakdown{}, fmt.Errorf("failed to get user loans: %w", err)
+	}
+
+	breakdown := domain.DebtBreakdown{UserID: userID, Currency: s.baseCurrency, Types: []domain.LoanTypeDebt{}}
+	byType := make(map[string]*domain.LoanTypeDebt)
+	for _, loan := range loans {
+		// What one unit of the loan's currency is worth in the base currency
+		rate, err := s.toBaseCurrency(ctx, 1, loan.Currency)
+		if err != nil {
+			return domain.DebtBreakdown{}, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+		}
+
+		typeDebt, ok := byType[loan.Type]
+		if !ok {
+			typeDebt = &domain.LoanTypeDebt{Type: loan.Type}
+			byType[loan.Type] = typeDebt
+		}
+		typeDebt.LoanCount++
+		typeDebt.RemainingBalance += loan.RemainingBalance * rate
+		typeDebt.MonthlyPayment += loan.MonthlyPayment * rate
+		breakdown.TotalBalance += loan.RemainingBalance * rate
+		breakdown.TotalMonthlyPayment += loan.MonthlyPayment * rate
+	}
+
+	for _, typeDebt := range byType {
+		breakdown.Types = append(breakdown.Types, *typeDebt)
+	}
+	sort.Slice(breakdown.Types, func(i, j int) bool {
+		if breakdown.Types[i].RemainingBalance != breakdown.Types[j].RemainingBalance {
+			return breakdown.Types[i].RemainingBalance > breakdown.Types[j].RemainingBalance
+		}
+		return breakdown.Types[i].Type < breakdown.Types[j].Type
+	})
+
+	if breakdown.TotalBalance > 0 {
+		var sum float64
+		for i := range breakdown.Types {
+			breakdown.Types[i].SharePercent = math.Round(breakdown.Types[i].RemainingBalance/breakdown.TotalBalance*10000) / 100
+			sum += breakdown.Types[i].SharePercent
+		}
+		// Rounding can leave the shares a cent of a percent off 100; the largest type absorbs it
+		breakdown.Types[0].SharePercent = math.Round((breakdown.Types[0].SharePercent+100-sum)*100) / 100
+	}
+
+	return breakdown, nil
+}
+
+// GetLoansByRateRange returns the user's loans with an interest rate between minRate and maxRate,
+// both inclusive, highest rate first so the most expensive debt comes up first.
+// Returns an error wrapping domain.ErrInvalidLoanData if a rate is negative or minRate is greater than maxRate.
+func (s *financeService) GetLoansByRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error) {
+	if minRate < 0 || maxRate < 0 {
+		return nil, fmt.Errorf("%w: interest rates must not be negative", domain.ErrInvalidLoanData)
+	}
+	if minRate > maxRate {
+		return nil, fmt.Errorf("%w: min rate must not be greater than max rate", domain.ErrInvalidLoanData)
+	}
+
+	loans, err := s.repos.Loan.GetLoansByInterestRateRange(ctx, userID, minRate, maxRate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans by interest rate: %w", err)
+	}
+
+	sort.SliceStable(loans, func(i, j int) bool {
+		return loans[i].InterestRate > loans[j].InterestRate
+	})
+	return loans, nil
+}
+
+// AddSavingsGoal validates and adds a new savings goal
+func (s *financeService) AddSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	if err := goal.Validate(); err != nil {
+		return err
+	}
+
+	// Assigned here rather than by the repository so the audit entry can name the goal
+	if goal.ID == "" {
+		goal.ID = newResourceID("goal")
+	}
+
+	err := s.repos.SavingsGoal.SaveGoal(ctx, goal)
+	s.summaryCache.invalidate(goal.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceSavingsGoal, goal.ID, nil, goal)
+	return nil
+}
+
+// UpdateSavingsGoal validates and updates an existing savings goal
+func (s *financeService) UpdateSavingsGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	if err := goal.Validate(); err != nil {
+		return err
+	}
+
+	// Verify ownership
+	existing, err := s.getOwnedSavingsGoal(ctx, goal.UserID, goal.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repos.SavingsGoal.UpdateGoal(ctx, goal)
+	s.summaryCache.invalidate(goal.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceSavingsGoal, goal.ID, existing, goal)
+	return nil
+}
+
+// DeleteSavingsGoal removes a savings goal after verifying ownership
+func (s *financeService) DeleteSavingsGoal(ctx context.Context, userID, goalID string) error {
+	existing, err := s.getOwnedSavingsGoal(ctx, userID, goalID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repos.SavingsGoal.DeleteGoal(ctx, goalID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceSavingsGoal, goalID, existing, nil)
+	return nil
+}
+
+// GetUserSavingsGoals retrieves all savings goals for a user
+func (s *financeService) GetUserSavingsGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	return s.repos.SavingsGoal.GetUserGoals(ctx, userID)
+}
+
+// AddGoalContribution records a contribution toward one of the user's savings goals
+func (s *financeService) AddGoalContribution(ctx context.Context, contribution domain.GoalContribution) error {
+	if err := contribution.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.getOwnedSavingsGoal(ctx, contribution.UserID, contribution.GoalID); err != nil {
+		return err
+	}
+
+	// Assigned here rather than by the repository so the audit entry can name the contribution
+	if contribution.ID == "" {
+		contribution.ID = newResourceID("contrib")
+	}
+
+	err := s.repos.SavingsGoal.AddContribution(ctx, contribution)
+	s.summaryCache.invalidate(contribution.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceGoalContribution, contribution.ID, nil, contribution)
+	return nil
+}
+
+// GetGoalContributionHistory returns the user's goal contributions grouped by calendar month,
+// covering the current month and the months-1 before it
+func (s *financeService) GetGoalContributionHistory(ctx context.Context, userID string, months int) ([]domain.GoalContributionMonth, error) {
+	if months < 1 {
+		return nil, fmt.Errorf("%w: months must be at least 1", domain.ErrInvalidSavingsGoalData)
+	}
+
+	now := time.Now()
+	since := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location()).AddDate(0, -(months - 1), 0)
+
+	contributions, err := s.repos.SavingsGoal.GetUserContributions(ctx, userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.GroupContributionsByMonth(contributions, since, now), nil
+}
+
+// getOwnedSavingsGoal loads a savings goal and checks that it belongs to the user
+func (s *financeService) getOwnedSavingsGoal(ctx context.Context, userID, goalID string) (domain.SavingsGoal, error) {
+	existing, err := s.repos.SavingsGoal.GetGoalByID(ctx, goalID)
+	if err != nil {
+		return domain.SavingsGoal{}, err
+	}
+
+	if existing.UserID != userID {
+		return domain.SavingsGoal{}, domain.ErrSavingsGoalNotOwnedByUser
+	}
+
+	return existing, nil
+}
+
+// AddBudget validates and adds a budget; a user has at most one budget per category
+func (s *financeService) AddBudget(ctx context.Context, budget domain.Budget) error {
+	if s.budgets == nil {
+		return fmt.Errorf("budgets are not enabled")
+	}
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkBudgetCategoryFree(ctx, budget); err != nil {
+		return err
+	}
+
+	// Assigned here rather than by the repository so the audit entry can name the budget
+	if budget.ID == "" {
+		budget.ID = newResourceID("budget")
+	}
+
+	err := s.budgets.SaveBudget(ctx, budget)
+	s.summaryCache.invalidate(budget.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionCreate, domain.AuditResourceBudget, budget.ID, nil, budget)
+	return nil
+}
+
+// UpdateBudget validates and updates an existing budget
+func (s *financeService) UpdateBudget(ctx context.Context, budget domain.Budget) error {
+	if s.budgets == nil {
+		return fmt.Errorf("budgets are not enabled")
+	}
+	if err := budget.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.getOwnedBudget(ctx, budget.UserID, budget.ID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkBudgetCategoryFree(ctx, budget); err != nil {
+		return err
+	}
+
+	err = s.budgets.UpdateBudget(ctx, budget)
+	s.summaryCache.invalidate(budget.UserID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionUpdate, domain.AuditResourceBudget, budget.ID, existing, budget)
+	return nil
+}
+
+// DeleteBudget removes a budget after verifying ownership; its category drops out of the
+// summary's budget comparison right away
+func (s *financeService) DeleteBudget(ctx context.Context, userID, budgetID string) error {
+	if s.budgets == nil {
+		return fmt.Errorf("budgets are not enabled")
+	}
+
+	existing, err := s.getOwnedBudget(ctx, userID, budgetID)
+	if err != nil {
+		return err
+	}
+
+	err = s.budgets.DeleteBudget(ctx, budgetID)
+	s.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+
+	s.audit.Record(ctx, domain.AuditActionDelete, domain.AuditResourceBudget, budgetID, existing, nil)
+	return nil
+}
+
+// GetUserBudgets retrieves all budgets for a user, ordered by category
+func (s *financeService) GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error) {
+	if s.budgets == nil {
+		return []domain.Budget{}, nil
+	}
+	return s.budgets.GetUserBudgets(ctx, userID)
+}
+
+// checkBudgetCategoryFree returns domain.ErrBudgetExists if another of the user's budgets
+// covers the budget's category
+func (s *financeService) checkBudgetCategoryFree(ctx context.Context, budget domain.Budget) error {
+	budgets, err := s.budgets.GetUserBudgets(ctx, budget.UserID)
+	if err != nil {
+		return err
+	}
+	for _, other := range budgets {
+		if other.Category == budget.Category && other.ID != budget.ID {
+			return domain.ErrBudgetExists
+		}
+	}
+	return nil
+}
+
+// getOwnedBudget loads a budget and checks that it belongs to the user
+func (s *financeService) getOwnedBudget(ctx context.Context, userID, budgetID string) (domain.Budget, error) {
+	existing, err := s.budgets.GetBudgetByID(ctx, budgetID)
+	if err != nil {
+		return domain.Budget{}, err
+	}
+
+	if existing.UserID != userID {
+		return domain.Budget{}, domain.ErrBudgetNotOwnedByUser
+	}
+
+	return existing, nil
+}
+
+// CalculateFinanceSummary aggregates all financial data for a user
+// Summaries are cached per user until the TTL expires or the user's data changes
+func (s *financeService) CalculateFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error) {
+	// The version is captured before loading any data so that a write racing
+	// with this computation prevents the result from being cached
+	cached, version, ok := s.summaryCache.get(userID)
+	if ok {
+		return cached, nil
+	}
+
+	summary, err := s.computeFinanceSummary(ctx, userID)
+	if err != nil {
+		return domain.FinanceSummary{}, err
+	}
+
+	s.summaryCache.put(userID, version, summary)
+	return summary, nil
+}
+
+// computeFinanceSummary reads the user's incomes, expenses and loans and builds a fresh summary
+func (s *financeService) computeFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error) {
+	// Get all active incomes
+	incomes, err := s.repos.Income.GetActiveIncomes(ctx, userID)
+	if err != nil {
+		return domain.FinanceSummary{}, fmt.Errorf("failed to get user incomes: %w", err)
+	}
+
+	// Get all expenses
+	expenses, err := s.repos.Expense.GetUserExpenses(ctx, userID)
+	if err != nil {
+		return domain.FinanceSummary{}, fmt.Errorf("failed to get user expenses: %w", err)
+	}
+
+	// Get all loans
+	loans, err := s.repos.Loan.GetUserLoans(ctx, userID)
+	if err != nil {
+		return domain.FinanceSummary{}, fmt.Errorf("failed to get user loans: %w", err)
+	}
+
+	// Get all savings goals
+	goals, err := s.repos.SavingsGoal.GetUserGoals(ctx, userID)
+	if err != nil {
+		return domain.FinanceSummary{}, fmt.Errorf("failed to get user savings goals: %w", err)
+	}
+
+	var budgets []domain.Budget
+	if s.budgets != nil {
+		budgets, err = s.budgets.GetUserBudgets(ctx, userID)
+		if err != nil {
+			return domain.FinanceSummary{}, fmt.Errorf("failed to get user budgets: %w", err)
+		}
+	}
+
+	// Calculate monthly totals, converting each amount to the base currency before summing
+	monthlyIncome := 0.0
+	for _, income := range incomes {
+		normalized, err := s.NormalizeToMonthly(income.Amount, income.Frequency)
+		if err != nil {
+			continue // Skip invalid frequencies
+		}
+		converted, err := s.toBaseCurrency(ctx, normalized, income.Currency)
+		if err != nil {
+			return domain.FinanceSummary{}, fmt.Errorf("failed to convert income %s: %w", income.ID, err)
+		}
+		monthlyIncome += converted
+	}
+
+	// Category spending for the budget comparison: this month's, and last month's for the
+	// expenses that already existed then
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	categorySpent := make(map[string]float64)
+	previousCategorySpent := make(map[string]float64)
+
+	monthlyExpenses := 0.0
+	var installments []domain.InstallmentSchedule
+	for _, expense := range expenses {
+		normalized, err := s.monthlyExpenseAmount(expense)
+		if err != nil {
+			continue // Skip invalid frequencies
+		}
+		converted, err := s.toBaseCurrency(ctx, normalized, expense.Currency)
+		if err != nil {
+			return domain.FinanceSummary{}, fmt.Errorf("failed to convert expense %s: %w", expense.ID, err)
+		}
+		monthlyExpenses += converted
+		categorySpent[expense.Category] += converted
+		if expense.CreatedAt.Before(monthStart) {
+			previousCategorySpent[expense.Category] += converted
+		}
+
+		if expense.IsInstallment() && expense.IsActive() {
+			schedule := expense.InstallmentSchedule()
+			schedule.MonthlyAmount = converted
+			installments = append(installments, schedule)
+		}
+	}
+
+	monthlyLoanPayments, loanPrincipal, loanBalance := 0.0, 0.0, 0.0
+	for _, loan := range loans {
+		// What one unit of the loan's currency is worth in the base currency
+		rate, err := s.toBaseCurrency(ctx, 1, loan.Currency)
+		if err != nil {
+			return domain.FinanceSummary{}, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+		}
+		monthlyLoanPayments += loan.MonthlyPayment * rate
+		loanPrincipal += loan.PrincipalAmount * rate
+		loanBalance += loan.RemainingBalance * rate
+	}
+
+	// Calculate derived metrics
+	disposableIncome := monthlyIncome - monthlyExpenses - monthlyLoanPayments
+	budgetRemaining := disposableIncome
+
+	var debtToIncomeRatio float64
+	if monthlyIncome > 0 {
+		debtToIncomeRatio = monthlyLoanPayments / monthlyIncome
+	}
+
+	var savingsRate float64
 	if monthlyIncome > 0 {
 		savingsRate = disposableIncome / monthlyIncome
 		if savingsRate < 0 {
@@ -243,6 +1513,7 @@ func (s *financeService) CalculateFinanceSummary(ctx context.Context, userID str
 
 	summary := domain.FinanceSummary{
 		UserID:              userID,
+		Currency:            s.baseCurrency,
 		MonthlyIncome:       monthlyIncome,
 		MonthlyExpenses:     monthlyExpenses,
 		MonthlyLoanPayments: monthlyLoanPayments,
@@ -250,15 +1521,130 @@ func (s *financeService) CalculateFinanceSummary(ctx context.Context, userID str
 		DebtToIncomeRatio:   debtToIncomeRatio,
 		SavingsRate:         savingsRate,
 		BudgetRemaining:     budgetRemaining,
-		UpdatedAt:          time.Now(),
+		Installments:        installments,
+		LoanPrincipal:       loanPrincipal,
+		LoanBalance:         loanBalance,
+		UpdatedAt:           latestFinanceUpdate(incomes, expenses, loans, goals, budgets),
+	}
+	if len(goals) > 0 {
+		saved := 0.0
+		for _, goal := range goals {
+			saved += goal.CurrentAmount
+		}
+		summary.SavingsBalance = &saved
 	}
 
 	// Calculate financial health
-	summary.FinancialHealth = summary.CalculateHealth()
+	health := summary.ScoreHealthWith(s.thresholds)
+	summary.FinancialHealth = health.Label
+	summary.HealthScore = health.Score
+	summary.HealthComponents = health.Components
+
+	// Project savings goals against what is left over each month
+	summary.GoalProjections = domain.ProjectSavingsGoals(goals, disposableIncome, now)
+	for _, projection := range summary.GoalProjections {
+		summary.GoalsMonthlyCommitment += projection.RequiredMonthly
+	}
+
+	// Compare the month's spending with the budgeted categories
+	summary.Budgets = domain.CompareBudgets(budgets, categorySpent, previousCategorySpent, now)
+	summary.OverspentCategories = domain.MostOverspent(summary.Budgets, domain.MaxOverspentCategories)
+
+	// Compare with the previously saved summary before it is replaced
+	if s.events != nil {
+		s.publishSummaryEvents(ctx, summary)
+	}
+
+	// Keep the latest summary for the admin reporting queries; reporting is
+	// best effort, so a failed save must not fail the user's request
+	_ = s.repos.FinanceSummary.SaveFinanceSummary(ctx, summary)
 
 	return summary, nil
 }
 
+// latestFinanceUpdate returns when the newest of the user's finance records was last changed
+func latestFinanceUpdate(incomes []domain.Income, expenses []domain.Expense, loans []domain.Loan, goals []domain.SavingsGoal, budgets []domain.Budget) time.Time {
+	var latest time.Time
+	track := func(updatedAt time.Time) {
+		if updatedAt.After(latest) {
+			latest = updatedAt
+		}
+	}
+	for _, income := range incomes {
+		track(income.UpdatedAt)
+	}
+	for _, expense := range expenses {
+		track(expense.UpdatedAt)
+	}
+	for _, loan := range loans {
+		track(loan.UpdatedAt)
+	}
+	for _, goal := range goals {
+		track(goal.UpdatedAt)
+	}
+	for _, budget := range budgets {
+		track(budget.UpdatedAt)
+	}
+	return latest
+}
+
+// publishSummaryEvents publishes the events for changes since the user's last saved summary.
+// Health changes need a previous summary; without one the debt-to-income ratio and remaining
+// budget are treated as having been within their limits, so a user's first summary past
+// either still notifies.
+func (s *financeService) publishSummaryEvents(ctx context.Context, summary domain.FinanceSummary) {
+	previous, err := s.repos.FinanceSummary.GetFinanceSummaryByUserID(ctx, summary.UserID)
+	hasPrevious := err == nil
+
+	if hasPrevious && previous.FinancialHealth != summary.FinancialHealth {
+		s.events.Publish(domain.Event{
+			Type:   domain.EventFinancialHealthChanged,
+			UserID: summary.UserID,
+			Data: map[string]interface{}{
+				"previous_health":      previous.FinancialHealth,
+				"financial_health":     summary.FinancialHealth,
+				"debt_to_income_ratio": summary.DebtToIncomeRatio,
+				"savings_rate":         summary.SavingsRate,
+			},
+		})
+	}
+
+	previousRatio := 0.0
+	if hasPrevious {
+		previousRatio = previous.DebtToIncomeRatio
+	}
+	if previousRatio <= domain.HealthyDebtToIncomeRatio && summary.DebtToIncomeRatio > domain.HealthyDebtToIncomeRatio {
+		s.events.Publish(domain.Event{
+			Type:   domain.EventDebtToIncomeExceeded,
+			UserID: summary.UserID,
+			Data: map[string]interface{}{
+				"debt_to_income_ratio":  summary.DebtToIncomeRatio,
+				"threshold":             domain.HealthyDebtToIncomeRatio,
+				"monthly_income":        summary.MonthlyIncome,
+				"monthly_loan_payments": summary.MonthlyLoanPayments,
+			},
+		})
+	}
+
+	previousRemaining := 0.0
+	if hasPrevious {
+		previousRemaining = previous.BudgetRemaining
+	}
+	if previousRemaining >= 0 && summary.BudgetRemaining < 0 {
+		s.events.Publish(domain.Event{
+			Type:   domain.EventBudgetExceeded,
+			UserID: summary.UserID,
+			Data: map[string]interface{}{
+				"budget_remaining":      summary.BudgetRemaining,
+				"monthly_income":        summary.MonthlyIncome,
+				"monthly_expenses":      summary.MonthlyExpenses,
+				"monthly_loan_payments": summary.MonthlyLoanPayments,
+				"currency":              summary.Currency,
+			},
+		})
+	}
+}
+
 // CalculateDisposableIncome calculates disposable income by normalizing all frequencies
 func (s *financeService) CalculateDisposableIncome(ctx context.Context, userID string) (float64, error) {
 	summary, err := s.CalculateFinanceSummary(ctx, userID)
@@ -269,6 +1655,51 @@ func (s *financeService) CalculateDisposableIncome(ctx context.Context, userID s
 	return summary.DisposableIncome, nil
 }
 
+// SuggestExpenseCuts suggests which of the user's discretionary expenses to cut to free up
+// targetSavings a month, in the base currency, lowest priority first. Fixed, essential and
+// installment expenses are never suggested. When cutting every discretionary expense still
+// falls short, the suggestion says so and lists them all.
+// Returns an error wrapping domain.ErrInvalidFinanceData if targetSavings isn't positive.
+func (s *financeService) SuggestExpenseCuts(ctx context.Context, userID string, targetSavings float64) (domain.ExpenseCutSuggestion, error) {
+	if targetSavings <= 0 {
+		return domain.ExpenseCutSuggestion{}, fmt.Errorf("%w: target savings must be greater than 0", domain.ErrInvalidFinanceData)
+	}
+
+	summary, err := s.CalculateFinanceSummary(ctx, userID)
+	if err != nil {
+		return domain.ExpenseCutSuggestion{}, fmt.Errorf("failed to calculate finance summary: %w", err)
+	}
+
+	expenses, err := s.repos.Expense.GetUserExpenses(ctx, userID)
+	if err != nil {
+		return domain.ExpenseCutSuggestion{}, fmt.Errorf("failed to get user expenses: %w", err)
+	}
+
+	candidates := make([]domain.ExpenseCut, 0, len(expenses))
+	for _, expense := range expenses {
+		if !expense.IsDiscretionary() {
+			continue
+		}
+		normalized, err := s.monthlyExpenseAmount(expense)
+		if err != nil {
+			continue // Skip invalid frequencies, as the summary does
+		}
+		converted, err := s.toBaseCurrency(ctx, normalized, expense.Currency)
+		if err != nil {
+			return domain.ExpenseCutSuggestion{}, fmt.Errorf("failed to convert expense %s: %w", expense.ID, err)
+		}
+		candidates = append(candidates, domain.ExpenseCut{Expense: expense, MonthlySavings: converted})
+	}
+
+	suggestion, err := domain.SuggestExpenseCuts(candidates, targetSavings, summary.DisposableIncome)
+	if err != nil {
+		return domain.ExpenseCutSuggestion{}, err
+	}
+	suggestion.UserID = userID
+	suggestion.Currency = summary.Currency
+	return suggestion, nil
+}
+
 // CalculateDebtToIncomeRatio calculates the debt-to-income ratio as a percentage
 func (s *financeService) CalculateDebtToIncomeRatio(ctx context.Context, userID string) (float64, error) {
 	summary, err := s.CalculateFinanceSummary(ctx, userID)
@@ -289,6 +1720,319 @@ func (s *financeService) EvaluateFinancialHealth(ctx context.Context, userID str
 	return summary.FinancialHealth, nil
 }
 
+// ExplainFinancialHealth rates the user's financial health and explains the metrics behind the
+// rating under the service's thresholds. See domain.FinanceSummary.ExplainHealthWith.
+func (s *financeService) ExplainFinancialHealth(ctx context.Context, userID string) (domain.FinancialHealthExplanation, error) {
+	summary, err := s.CalculateFinanceSummary(ctx, userID)
+	if err != nil {
+		return domain.FinancialHealthExplanation{}, fmt.Errorf("failed to calculate finance summary: %w", err)
+	}
+
+	return summary.ExplainHealthWith(s.thresholds), nil
+}
+
+// GetIncomeDiversification measures how concentrated the user's active recurring income is: each
+// source's share of the monthly income in the base currency, the largest share and the
+// Herfindahl-Hirschman index of the shares. Incomes are grouped by source name, ignoring case.
+// Users without recurring income get an empty result that isn't flagged.
+func (s *financeService) GetIncomeDiversification(ctx context.Context, userID string) (domain.IncomeDiversification, error) {
+	incomes, err := s.repos.Income.GetActiveIncomes(ctx, userID)
+	if err != nil {
+		return domain.IncomeDiversification{}, fmt.Errorf("failed to get user incomes: %w", err)
+	}
+
+	diversification := domain.IncomeDiversification{UserID: userID, Currency: s.baseCurrency, Sources: []domain.IncomeSourceShare{}}
+	bySource := make(map[string]*domain.IncomeSourceShare)
+	var order []string
+	for _, income := range incomes {
+		if !income.IsRecurring() {
+			continue // One-time incomes aren't a recurring source
+		}
+		monthly, err := s.NormalizeToMonthly(income.Amount, income.Frequency)
+		if err != nil || monthly <= 0 {
+			continue // Skip invalid frequencies
+		}
+		converted, err := s.toBaseCurrency(ctx, monthly, income.Currency)
+		if err != nil {
+			return domain.IncomeDiversification{}, fmt.Errorf("failed to convert income %s: %w", income.ID, err)
+		}
+
+		key := strings.ToLower(strings.TrimSpace(income.Source))
+		source, ok := bySource[key]
+		if !ok {
+			source = &domain.IncomeSourceShare{Source: strings.TrimSpace(income.Source)}
+			bySource[key] = source
+			order = append(order, key)
+		}
+		source.IncomeCount++
+		source.MonthlyAmount += converted
+		diversification.MonthlyIncome += converted
+	}
+	if diversification.MonthlyIncome <= 0 {
+		return diversification, nil
+	}
+
+	for _, key := range order {
+		source := bySource[key]
+		source.Share = source.MonthlyAmount / diversification.MonthlyIncome
+		diversification.ConcentrationIndex += source.Share * source.Share
+		diversification.Sources = append(diversification.Sources, *source)
+	}
+	sort.SliceStable(diversification.Sources, func(i, j int) bool {
+		return diversification.Sources[i].MonthlyAmount > diversification.Sources[j].MonthlyAmount
+	})
+	diversification.LargestSourceShare = diversification.Sources[0].Share
+	diversification.SingleSourceDependent = diversification.LargestSourceShare > domain.SingleSourceDependencyShare
+
+	return diversification, nil
+}
+
+// GetMonthlyFinanceActivity gathers the user's finances over the month from start up to end, in
+// the base currency: recurring income as its monthly amount plus one-time income received in the
+// month, each expense's monthly amount or the installment due in the month, and the loan payments
+// recorded in the month. The financial health score is scored at both ends of the month from the
+// records that existed then, each on the month before it; savings goals are left out of those
+// scores since their past balances aren't kept. Inactive incomes are left out throughout, since
+// when they stopped isn't recorded.
+func (s *financeService) GetMonthlyFinanceActivity(ctx context.Context, userID string, start, end time.Time) (domain.MonthlyFinanceActivity, error) {
+	incomes, err := s.repos.Income.GetActiveIncomes(ctx, userID)
+	if err != nil {
+		return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to get user incomes: %w", err)
+	}
+	expenses, err := s.repos.Expense.GetUserExpenses(ctx, userID)
+	if err != nil {
+		return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to get user expenses: %w", err)
+	}
+	loans, err := s.repos.Loan.GetUserLoans(ctx, userID)
+	if err != nil {
+		return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to get user loans: %w", err)
+	}
+	balanceEntries := make(map[string][]domain.LoanBalanceEntry, len(loans))
+	for _, loan := range loans {
+		if !loan.CreatedAt.Before(end) {
+			continue
+		}
+		entries, err := s.repos.Loan.GetBalanceEntries(ctx, loan.ID)
+		if err != nil {
+			return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to get balance history of loan %s: %w", loan.ID, err)
+		}
+		balanceEntries[loan.ID] = entries
+	}
+
+	activity := domain.MonthlyFinanceActivity{
+		Currency:     s.baseCurrency,
+		Income:       []domain.StatementLine{},
+		Expenses:     []domain.StatementLine{},
+		LoanPayments: []domain.StatementLoanPayment{},
+	}
+	track := func(updatedAt time.Time) {
+		activity.RecordCount++
+		if updatedAt.After(activity.LastUpdated) {
+			activity.LastUpdated = updatedAt
+		}
+	}
+
+	incomeLines := make(map[string]int)
+	for _, income := range incomes {
+		if !income.CreatedAt.Before(end) {
+			continue
+		}
+		amount, err := s.NormalizeToMonthly(income.Amount, income.Frequency)
+		if err != nil {
+			continue // Skip invalid frequencies
+		}
+		if income.Frequency == domain.FrequencyOneTime {
+			// A one-time income only counts in the month it came in
+			amount = 0
+			if !income.CreatedAt.Before(start) {
+				amount = income.Amount
+			}
+		}
+		if amount <= 0 {
+			continue
+		}
+		converted, err := s.toBaseCurrency(ctx, amount, income.Currency)
+		if err != nil {
+			return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to convert income %s: %w", income.ID, err)
+		}
+
+		source := strings.TrimSpace(income.Source)
+		key := strings.ToLower(source)
+		i, ok := incomeLines[key]
+		if !ok {
+			i = len(activity.Income)
+			incomeLines[key] = i
+			activity.Income = append(activity.Income, domain.StatementLine{Label: source})
+		}
+		activity.Income[i].Amount += converted
+		activity.Income[i].Count++
+		activity.TotalIncome += converted
+		track(income.UpdatedAt)
+	}
+
+	expenseLines := make(map[string]int)
+	for _, expense := range expenses {
+		if !expense.CreatedAt.Before(end) {
+			continue
+		}
+		amount, err := s.expenseAmountBetween(expense, start, end)
+		if err != nil || amount <= 0 {
+			continue // Skip invalid frequencies and installment plans with nothing due
+		}
+		converted, err := s.toBaseCurrency(ctx, amount, expense.Currency)
+		if err != nil {
+			return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to convert expense %s: %w", expense.ID, err)
+		}
+
+		i, ok := expenseLines[expense.Category]
+		if !ok {
+			i = len(activity.Expenses)
+			expenseLines[expense.Category] = i
+			activity.Expenses = append(activity.Expenses, domain.StatementLine{Label: expense.Category})
+		}
+		activity.Expenses[i].Amount += converted
+		activity.Expenses[i].Count++
+		activity.TotalExpenses += converted
+		track(expense.UpdatedAt)
+	}
+
+	for _, loan := range loans {
+		entries, ok := balanceEntries[loan.ID]
+		if !ok {
+			continue
+		}
+		// The loan's balance counts toward the health scores even in months without a payment
+		track(loan.UpdatedAt)
+
+		payment := domain.StatementLoanPayment{LoanID: loan.ID, Lender: loan.Lender}
+		for _, entry := range entries {
+			if entry.Type != domain.LoanBalancePayment || entry.CreatedAt.Before(start) || !entry.CreatedAt.Before(end) {
+				continue
+			}
+			payment.Amount += entry.Amount
+			payment.InterestPortion += entry.InterestPortion
+			payment.PrincipalPortion += entry.PrincipalPortion
+			payment.Payments++
+			track(entry.CreatedAt)
+		}
+		if payment.Payments == 0 {
+			continue
+		}
+
+		rate, err := s.toBaseCurrency(ctx, 1, loan.Currency)
+		if err != nil {
+			return domain.MonthlyFinanceActivity{}, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+		}
+		payment.Amount = roundCents(payment.Amount * rate)
+		payment.InterestPortion = roundCents(payment.InterestPortion * rate)
+		payment.PrincipalPortion = roundCents(payment.PrincipalPortion * rate)
+		activity.LoanPayments = append(activity.LoanPayments, payment)
+		activity.TotalLoanPayments += payment.Amount
+	}
+
+	domain.SortStatementLines(activity.Income)
+	domain.SortStatementLines(activity.Expenses)
+	sort.SliceStable(activity.LoanPayments, func(i, j int) bool {
+		return activity.LoanPayments[i].Amount > activity.LoanPayments[j].Amount
+	})
+	activity.TotalIncome = roundCents(activity.TotalIncome)
+	activity.TotalExpenses = roundCents(activity.TotalExpenses)
+	activity.TotalLoanPayments = roundCents(activity.TotalLoanPayments)
+
+	if activity.StartScore, err = s.scoreHealthAt(ctx, incomes, expenses, loans, balanceEntries, start); err != nil {
+		return domain.MonthlyFinanceActivity{}, err
+	}
+	if activity.EndScore, err = s.scoreHealthAt(ctx, incomes, expenses, loans, balanceEntries, end); err != nil {
+		return domain.MonthlyFinanceActivity{}, err
+	}
+
+	return activity, nil
+}
+
+// scoreHealthAt scores the financial health of the records that existed at the moment at, on
+// the month before it, the way the finance summary scores the current month. Returns nil when
+// there were no records yet.
+func (s *financeService) scoreHealthAt(ctx context.Context, incomes []domain.Income, expenses []domain.Expense, loans []domain.Loan,
+	balanceEntries map[string][]domain.LoanBalanceEntry, at time.Time) (*domain.FinancialHealthScore, error) {
+	from := at.AddDate(0, -1, 0)
+	summary := domain.FinanceSummary{Currency: s.baseCurrency}
+	records := 0
+
+	for _, income := range incomes {
+		if !income.CreatedAt.Before(at) {
+			continue
+		}
+		records++
+		normalized, err := s.NormalizeToMonthly(income.Amount, income.Frequency)
+		if err != nil {
+			continue // Skip invalid frequencies
+		}
+		converted, err := s.toBaseCurrency(ctx, normalized, income.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert income %s: %w", income.ID, err)
+		}
+		summary.MonthlyIncome += converted
+	}
+
+	for _, expense := range expenses {
+		if !expense.CreatedAt.Before(at) {
+			continue
+		}
+		records++
+		amount, err := s.expenseAmountBetween(expense, from, at)
+		if err != nil {
+			continue
+		}
+		converted, err := s.toBaseCurrency(ctx, amount, expense.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert expense %s: %w", expense.ID, err)
+		}
+		summary.MonthlyExpenses += converted
+	}
+
+	for _, loan := range loans {
+		if !loan.CreatedAt.Before(at) {
+			continue
+		}
+		records++
+		rate, err := s.toBaseCurrency(ctx, 1, loan.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert loan %s: %w", loan.ID, err)
+		}
+		balance := loan.BalanceAt(balanceEntries[loan.ID], at)
+		if balance > 0 {
+			summary.MonthlyLoanPayments += loan.MonthlyPayment * rate
+		}
+		summary.LoanPrincipal += loan.PrincipalAmount * rate
+		summary.LoanBalance += balance * rate
+	}
+
+	if records == 0 {
+		return nil, nil
+	}
+
+	summary.DisposableIncome = summary.MonthlyIncome - summary.MonthlyExpenses - summary.MonthlyLoanPayments
+	if summary.MonthlyIncome > 0 {
+		summary.DebtToIncomeRatio = summary.MonthlyLoanPayments / summary.MonthlyIncome
+		summary.SavingsRate = math.Max(summary.DisposableIncome/summary.MonthlyIncome, 0)
+	}
+
+	score := summary.ScoreHealthWith(s.thresholds)
+	return &score, nil
+}
+
+// expenseAmountBetween returns what an expense costs over the month from start up to end: its
+// monthly amount, or for an installment plan with a start date the installment if one falls due
+func (s *financeService) expenseAmountBetween(expense domain.Expense, start, end time.Time) (float64, error) {
+	if expense.IsInstallment() && !expense.InstallmentStart.IsZero() {
+		if !expense.InstallmentDueBetween(start, end) {
+			return 0, nil
+		}
+		return expense.InstallmentAmount(), nil
+	}
+	return s.monthlyExpenseAmount(expense)
+}
+
 // GetMaxAffordableAmount calculates the maximum affordable purchase amount
 func (s *financeService) GetMaxAffordableAmount(ctx context.Context, userID string) (float64, error) {
 	summary, err := s.CalculateFinanceSummary(ctx, userID)
@@ -296,29 +2040,34 @@ func (s *financeService) GetMaxAffordableAmount(ctx context.Context, userID stri
 		return 0, fmt.Errorf("failed to calculate finance summary: %w", err)
 	}
 
-	return summary.CalculateAffordability(), nil
+	return summary.CalculateAffordabilityWith(s.thresholds), nil
 }
 
-// NormalizeToMonthly converts different frequencies to monthly amounts
+// CheckPurchaseAffordability weighs a planned purchase, priced in the base currency, against the
+// user's finance summary under the service's thresholds. See domain.FinanceSummary.AssessPurchase.
+func (s *financeService) CheckPurchaseAffordability(ctx context.Context, userID string, plan domain.PurchasePlan) (domain.PurchaseAffordability, error) {
+	if err := plan.Validate(); err != nil {
+		return domain.PurchaseAffordability{}, err
+	}
+
+	summary, err := s.CalculateFinanceSummary(ctx, userID)
+	if err != nil {
+		return domain.PurchaseAffordability{}, fmt.Errorf("failed to calculate finance summary: %w", err)
+	}
+
+	return summary.AssessPurchase(plan, s.thresholds)
+}
+
+// monthlyExpenseAmount converts an expense to its monthly amount. An installment expense
+// counts one installment while any remain and nothing once it is paid off.
+func (s *financeService) monthlyExpenseAmount(expense domain.Expense) (float64, error) {
+	if expense.IsInstallment() {
+		return expense.NormalizeToMonthly(), nil
+	}
+	return s.NormalizeToMonthly(expense.Amount, expense.Frequency)
+}
+
+// NormalizeToMonthly converts different frequencies to monthly amounts; see money.NormalizeToMonthly
 func (s *financeService) NormalizeToMonthly(amount float64, frequency string) (float64, error) {
-	switch frequency {
-	case "daily":
-		return amount * 30, nil // 30 days per month
-	case "weekly":
-		return amount * 4.33, nil // Average weeks per month (52/12)
-	case "biweekly":
-		return amount * 2.17, nil // Every two weeks (26 pay periods / 12 months)
-	case "monthly":
-		return amount, nil
-	case "quarterly":
-		return amount / 3, nil // 4 quarters / 12 months
-	case "semiannual":
-		return amount / 6, nil // 2 periods / 12 months
-	case "annual", "yearly":
-		return amount / 12, nil
-	case "one-time":
-		return amount / 12, nil // Spread one-time over 12 months
-	default:
-		return 0, fmt.Errorf("unsupported frequency: %s", frequency)
-	}
-}
\ No newline at end of file
+	return money.NormalizeToMonthly(amount, frequency)
+}
@@ -3,12 +3,15 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // Mock repositories for testing
@@ -36,6 +39,16 @@ func (m *MockIncomeRepository) DeleteIncome(ctx context.Context, id string) erro
 	return args.Error(0)
 }
 
+func (m *MockIncomeRepository) GetDeletedIncomeByID(ctx context.Context, id string) (domain.Income, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Income), args.Error(1)
+}
+
+func (m *MockIncomeRepository) RestoreIncome(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 func (m *MockIncomeRepository) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).([]domain.Income), args.Error(1)
@@ -51,6 +64,16 @@ func (m *MockIncomeRepository) GetUserIncomesByFrequency(ctx context.Context, us
 	return args.Get(0).([]domain.Income), args.Error(1)
 }
 
+func (m *MockIncomeRepository) GetUserIncomesAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Income, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return args.Get(0).([]domain.Income), args.Error(1)
+}
+
+func (m *MockIncomeRepository) FindDuplicateIncomeID(ctx context.Context, income domain.Income, since time.Time) (string, error) {
+	args := m.Called(ctx, income, since)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockIncomeRepository) CalculateUserTotalIncome(ctx context.Context, userID string, activeOnly bool) (float64, error) {
 	args := m.Called(ctx, userID, activeOnly)
 	return args.Get(0).(float64), args.Error(1)
@@ -80,6 +103,21 @@ func (m *MockExpenseRepository) DeleteExpense(ctx context.Context, id string) er
 	return args.Error(0)
 }
 
+func (m *MockExpenseRepository) GetDeletedExpenseByID(ctx context.Context, id string) (domain.Expense, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Expense), args.Error(1)
+}
+
+func (m *MockExpenseRepository) RestoreExpense(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockExpenseRepository) DeleteExpenses(ctx context.Context, ids []string) (int64, error) {
+	args := m.Called(ctx, ids)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockExpenseRepository) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).([]domain.Expense), args.Error(1)
@@ -110,6 +148,21 @@ func (m *MockExpenseRepository) GetVariableExpenses(ctx context.Context, userID
 	return args.Get(0).([]domain.Expense), args.Error(1)
 }
 
+func (m *MockExpenseRepository) FindExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error) {
+	args := m.Called(ctx, userID, filter)
+	return args.Get(0).([]domain.Expense), args.Error(1)
+}
+
+func (m *MockExpenseRepository) FindExpensesAfter(ctx context.Context, userID string, filter domain.ExpenseFilter, cursor domain.Cursor, limit int) ([]domain.Expense, error) {
+	args := m.Called(ctx, userID, filter, cursor, limit)
+	return args.Get(0).([]domain.Expense), args.Error(1)
+}
+
+func (m *MockExpenseRepository) FindDuplicateExpenseID(ctx context.Context, expense domain.Expense, since time.Time) (string, error) {
+	args := m.Called(ctx, expense, since)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockExpenseRepository) CalculateUserTotalExpenses(ctx context.Context, userID string) (float64, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(float64), args.Error(1)
@@ -159,11 +212,36 @@ func (m *MockLoanRepository) GetLoansByInterestRateRange(ctx context.Context, us
 	return args.Get(0).([]domain.Loan), args.Error(1)
 }
 
+func (m *MockLoanRepository) GetUserLoansAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Loan, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
 func (m *MockLoanRepository) UpdateLoanBalance(ctx context.Context, loanID string, newBalance float64) error {
 	args := m.Called(ctx, loanID, newBalance)
 	return args.Error(0)
 }
 
+func (m *MockLoanRepository) GetLoanByIDForUpdate(ctx context.Context, id string) (domain.Loan, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Loan), args.Error(1)
+}
+
+func (m *MockLoanRepository) RecordBalanceChange(ctx context.Context, entry domain.LoanBalanceEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockLoanRepository) GetBalanceEntries(ctx context.Context, loanID string) ([]domain.LoanBalanceEntry, error) {
+	args := m.Called(ctx, loanID)
+	return args.Get(0).([]domain.LoanBalanceEntry), args.Error(1)
+}
+
+func (m *MockLoanRepository) GetLoansDueForAccrual(ctx context.Context, since time.Time) ([]domain.Loan, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).([]domain.Loan), args.Error(1)
+}
+
 func (m *MockLoanRepository) GetNearPayoffLoans(ctx context.Context, userID string, threshold float64) ([]domain.Loan, error) {
 	args := m.Called(ctx, userID, threshold)
 	return args.Get(0).([]domain.Loan), args.Error(1)
@@ -179,6 +257,74 @@ func (m *MockLoanRepository) CalculateUserMonthlyPayments(ctx context.Context, u
 	return args.Get(0).(float64), args.Error(1)
 }
 
+type MockSavingsGoalRepository struct {
+	mock.Mock
+}
+
+func (m *MockSavingsGoalRepository) SaveGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalRepository) GetGoalByID(ctx context.Context, id string) (domain.SavingsGoal, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.SavingsGoal), args.Error(1)
+}
+
+func (m *MockSavingsGoalRepository) UpdateGoal(ctx context.Context, goal domain.SavingsGoal) error {
+	args := m.Called(ctx, goal)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalRepository) DeleteGoal(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalRepository) GetUserGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.SavingsGoal), args.Error(1)
+}
+
+func (m *MockSavingsGoalRepository) AddContribution(ctx context.Context, contribution domain.GoalContribution) error {
+	args := m.Called(ctx, contribution)
+	return args.Error(0)
+}
+
+func (m *MockSavingsGoalRepository) GetUserContributions(ctx context.Context, userID string, since time.Time) ([]domain.GoalContribution, error) {
+	args := m.Called(ctx, userID, since)
+	return args.Get(0).([]domain.GoalContribution), args.Error(1)
+}
+
+type MockBudgetRepository struct {
+	mock.Mock
+}
+
+func (m *MockBudgetRepository) SaveBudget(ctx context.Context, budget domain.Budget) error {
+	args := m.Called(ctx, budget)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) GetBudgetByID(ctx context.Context, id string) (domain.Budget, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Budget), args.Error(1)
+}
+
+func (m *MockBudgetRepository) UpdateBudget(ctx context.Context, budget domain.Budget) error {
+	args := m.Called(ctx, budget)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) DeleteBudget(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBudgetRepository) GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Budget), args.Error(1)
+}
+
 type MockFinanceSummaryRepository struct {
 	mock.Mock
 }
@@ -225,6 +371,7 @@ func createTestIncome(id, userID, source string, amount float64, frequency strin
 		UserID:    userID,
 		Source:    source,
 		Amount:    amount,
+		Currency:  domain.DefaultCurrency,
 		Frequency: frequency,
 		IsActive:  isActive,
 		CreatedAt: time.Now(),
@@ -239,6 +386,7 @@ func createTestExpense(id, userID, category, name string, amount float64, freque
 		Category:  category,
 		Name:      name,
 		Amount:    amount,
+		Currency:  domain.DefaultCurrency,
 		Frequency: frequency,
 		IsFixed:   isFixed,
 		Priority:  priority,
@@ -257,6 +405,7 @@ func createTestLoan(id, userID, lender, loanType string, principal, remaining, p
 		RemainingBalance: remaining,
 		MonthlyPayment:   payment,
 		InterestRate:     rate,
+		Currency:         domain.DefaultCurrency,
 		EndDate:          time.Now().AddDate(5, 0, 0),
 		CreatedAt:        time.Now(),
 		UpdatedAt:        time.Now(),
@@ -268,15 +417,22 @@ func setupFinanceService() (*financeService, *MockIncomeRepository, *MockExpense
 	mockExpenseRepo := &MockExpenseRepository{}
 	mockLoanRepo := &MockLoanRepository{}
 	mockFinanceSummaryRepo := &MockFinanceSummaryRepository{}
+	// Summaries are saved for reporting as a side effect of every calculation
+	mockFinanceSummaryRepo.On("SaveFinanceSummary", mock.Anything, mock.Anything).Return(nil).Maybe()
+	// Goals are read by every summary calculation; goal tests swap in their own mock
+	mockSavingsGoalRepo := &MockSavingsGoalRepository{}
+	mockSavingsGoalRepo.On("GetUserGoals", mock.Anything, mock.Anything).Return([]domain.SavingsGoal{}, nil).Maybe()
 
 	repos := &FinanceRepositories{
 		Income:         mockIncomeRepo,
 		Expense:        mockExpenseRepo,
 		Loan:           mockLoanRepo,
+		SavingsGoal:    mockSavingsGoalRepo,
 		FinanceSummary: mockFinanceSummaryRepo,
 	}
 
-	service := &financeService{repos: repos}
+	// Caching is covered separately; these tests expect every call to read the repositories
+	service := NewFinanceService(repos, WithSummaryCacheTTL(0))
 	return service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, mockFinanceSummaryRepo
 }
 
@@ -286,9 +442,10 @@ func TestFinanceService_AddIncome_Success(t *testing.T) {
 	ctx := context.Background()
 
 	income := createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true)
+	mockIncomeRepo.On("FindDuplicateIncomeID", ctx, income, mock.Anything).Return("", nil)
 	mockIncomeRepo.On("SaveIncome", ctx, income).Return(nil)
 
-	err := service.AddIncome(ctx, income)
+	err := service.AddIncome(ctx, income, false)
 
 	assert.NoError(t, err)
 	mockIncomeRepo.AssertExpectations(t)
@@ -300,14 +457,14 @@ func TestFinanceService_AddIncome_InvalidData_ReturnsError(t *testing.T) {
 
 	invalidIncome := domain.Income{} // Invalid - missing required fields
 
-	err := service.AddIncome(ctx, invalidIncome)
+	err := service.AddIncome(ctx, invalidIncome, false)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid income data")
 }
 
 func TestFinanceService_CalculateFinanceSummary_Success(t *testing.T) {
-	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, mockFinanceSummaryRepo := setupFinanceService()
 	ctx := context.Background()
 
 	incomes := []domain.Income{
@@ -338,10 +495,16 @@ func TestFinanceService_CalculateFinanceSummary_Success(t *testing.T) {
 	assert.Equal(t, 3100.0, summary.DisposableIncome)
 	assert.InDelta(t, 0.0667, summary.DebtToIncomeRatio, 0.001)
 	assert.Equal(t, domain.HealthExcellent, summary.FinancialHealth)
+	assert.Equal(t, 25000.0, summary.LoanPrincipal)
+	assert.Equal(t, 20000.0, summary.LoanBalance)
+	assert.GreaterOrEqual(t, summary.HealthScore, 80.0)
+	// Without savings goals there is no emergency fund component
+	assert.Len(t, summary.HealthComponents, 4)
 	
 	mockIncomeRepo.AssertExpectations(t)
 	mockExpenseRepo.AssertExpectations(t)
 	mockLoanRepo.AssertExpectations(t)
+	mockFinanceSummaryRepo.AssertCalled(t, "SaveFinanceSummary", ctx, summary)
 }
 
 func TestFinanceService_CalculateFinanceSummary_RepositoryError(t *testing.T) {
@@ -372,6 +535,9 @@ func TestFinanceService_NormalizeToMonthly_AllFrequencies(t *testing.T) {
 		{"Annual", 60000.0, "annual", 5000.0},
 		{"OneTime", 1200.0, "one-time", 100.0},
 		{"Quarterly", 3000.0, "quarterly", 1000.0},
+		{"Semiannual", 6000.0, "semiannual", 1000.0},
+		{"Semiannually", 6000.0, "semiannually", 1000.0},
+		{"Annually", 60000.0, "annually", 5000.0},
 	}
 
 	for _, tt := range tests {
@@ -423,6 +589,59 @@ func TestFinanceService_EvaluateFinancialHealth_PoorHealth(t *testing.T) {
 	mockLoanRepo.AssertExpectations(t)
 }
 
+func TestFinanceService_ExplainFinancialHealth_Excellent(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	incomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
+	}
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}
+	loans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 600.0, 5.0),
+	}
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+
+	explanation, err := service.ExplainFinancialHealth(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.HealthExcellent, explanation.Tier)
+	assert.InDelta(t, 0.1, explanation.DebtToIncomeRatio, 0.001)
+	assert.InDelta(t, 3400.0, explanation.DisposableIncome, 0.01)
+	assert.Contains(t, explanation.Reasons, "savings rate of 57% is high, at or above the 20% target")
+}
+
+func TestFinanceService_ExplainFinancialHealth_Poor(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	incomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 3000.0, "monthly", true),
+	}
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2500.0, "monthly", true, 1),
+	}
+	loans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 1000.0, 8.0),
+	}
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+
+	explanation, err := service.ExplainFinancialHealth(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.HealthPoor, explanation.Tier)
+	assert.Contains(t, explanation.Reasons,
+		"you are overspending: expenses and loan payments exceed income by 500.00 "+explanation.Currency+" a month")
+}
+
 func TestFinanceService_GetMaxAffordableAmount_Success(t *testing.T) {
 	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
 	ctx := context.Background()
@@ -455,6 +674,43 @@ func TestFinanceService_GetMaxAffordableAmount_Success(t *testing.T) {
 	mockLoanRepo.AssertExpectations(t)
 }
 
+func TestFinanceService_CheckPurchaseAffordability_Installments(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+	}, nil)
+
+	plan := domain.PurchasePlan{Price: 24000, PaymentMethod: domain.PaymentMethodInstallments, Months: 12}
+	result, err := service.CheckPurchaseAffordability(ctx, "user-1", plan)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2000.0, result.MonthlyPayment)
+	assert.InDelta(t, 0.4, result.DebtToIncomeRatio, 0.0001)
+	assert.Equal(t, domain.PurchaseBorderline, result.Verdict)
+	assert.Equal(t, domain.LimitingFactorDebtToIncome, result.LimitingFactor)
+}
+
+func TestFinanceService_CheckPurchaseAffordability_NoIncome_ReturnsError(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	_, err := service.CheckPurchaseAffordability(ctx, "user-1", domain.PurchasePlan{Price: 500, PaymentMethod: domain.PaymentMethodCash})
+
+	assert.ErrorIs(t, err, domain.ErrNoIncome)
+}
+
 func TestFinanceService_UpdateIncome_WrongOwner_ReturnsError(t *testing.T) {
 	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
@@ -475,14 +731,11 @@ func TestFinanceService_UpdateLoanBalance_NegativeBalance_ReturnsError(t *testin
 	service, _, _, mockLoanRepo, _ := setupFinanceService()
 	ctx := context.Background()
 
-	existingLoan := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
-	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existingLoan, nil)
-
 	err := service.UpdateLoanBalance(ctx, "user-1", "loan-1", -1000.0)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "cannot be negative")
-	mockLoanRepo.AssertExpectations(t)
+	mockLoanRepo.AssertNotCalled(t, "RecordBalanceChange", mock.Anything, mock.Anything)
 }
 
 // Additional comprehensive tests
@@ -496,7 +749,7 @@ func TestFinanceService_AddIncome_ValidationError(t *testing.T) {
 		Amount: -100, // Invalid - negative amount
 	}
 
-	err := service.AddIncome(ctx, invalidIncome)
+	err := service.AddIncome(ctx, invalidIncome, false)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid income data")
@@ -508,9 +761,10 @@ func TestFinanceService_AddExpense_Success(t *testing.T) {
 	ctx := context.Background()
 
 	expense := createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1)
+	mockExpenseRepo.On("FindDuplicateExpenseID", ctx, expense, mock.Anything).Return("", nil)
 	mockExpenseRepo.On("SaveExpense", ctx, expense).Return(nil)
 
-	err := service.AddExpense(ctx, expense)
+	err := service.AddExpense(ctx, expense, false)
 
 	assert.NoError(t, err)
 	mockExpenseRepo.AssertExpectations(t)
@@ -526,13 +780,43 @@ func TestFinanceService_AddExpense_ValidationError(t *testing.T) {
 		Amount: -100, // Invalid - negative amount
 	}
 
-	err := service.AddExpense(ctx, invalidExpense)
+	err := service.AddExpense(ctx, invalidExpense, false)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid expense data")
 	mockExpenseRepo.AssertNotCalled(t, "SaveExpense")
 }
 
+func TestFinanceService_AddExpense_NormalizesTags(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expense := createTestExpense("exp-1", "user-1", "transport", "Flights", 400.0, "monthly", false, 3)
+	expense.Tags = []string{" Vacation", "summer   TRIP", "vacation", ""}
+	mockExpenseRepo.On("FindDuplicateExpenseID", ctx, mock.Anything, mock.Anything).Return("", nil)
+	mockExpenseRepo.On("SaveExpense", ctx, mock.MatchedBy(func(saved domain.Expense) bool {
+		return assert.ObjectsAreEqual([]string{"vacation", "summer trip"}, saved.Tags)
+	})).Return(nil)
+
+	err := service.AddExpense(ctx, expense, false)
+
+	assert.NoError(t, err)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_AddExpense_InvalidTags(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+
+	expense := createTestExpense("exp-1", "user-1", "transport", "Flights", 400.0, "monthly", false, 3)
+	expense.Tags = []string{"vacation", "trip #2"}
+
+	err := service.AddExpense(context.Background(), expense, false)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidExpenseTags)
+	assert.Contains(t, err.Error(), `tag "trip #2" may only contain`)
+	mockExpenseRepo.AssertNotCalled(t, "SaveExpense", mock.Anything, mock.Anything)
+}
+
 func TestFinanceService_AddLoan_Success(t *testing.T) {
 	service, _, _, mockLoanRepo, _ := setupFinanceService()
 	ctx := context.Background()
@@ -579,6 +863,43 @@ func TestFinanceService_UpdateIncome_Success(t *testing.T) {
 	mockIncomeRepo.AssertExpectations(t)
 }
 
+func TestFinanceService_UpdateIncome_RecordsAuditDiff(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	ctx := WithRequestUser(context.Background(), "user-1")
+
+	income := createTestIncome("income-1", "user-1", "Salary", 5500.0, "monthly", true)
+	existingIncome := createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true)
+	income.CreatedAt = existingIncome.CreatedAt
+
+	mockIncomeRepo.On("GetIncomeByID", ctx, "income-1").Return(existingIncome, nil)
+	mockIncomeRepo.On("UpdateIncome", ctx, income).Return(nil)
+
+	require.NoError(t, service.UpdateIncome(ctx, income))
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, recordedAudit{
+		UserID:       "user-1",
+		Action:       domain.AuditActionUpdate,
+		ResourceType: domain.AuditResourceIncome,
+		ResourceID:   "income-1",
+		Changes:      map[string]domain.AuditChange{"amount": {Before: 5000.0, After: 5500.0}},
+	}, recorder.records[0])
+}
+
+func TestFinanceService_DeleteIncome_FailureIsNotAudited(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetIncomeByID", ctx, "income-1").Return(domain.Income{}, errors.New("not found"))
+
+	assert.Error(t, service.DeleteIncome(ctx, "user-1", "income-1"))
+	assert.Empty(t, recorder.records)
+}
+
 func TestFinanceService_UpdateIncome_OwnershipMismatch(t *testing.T) {
 	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
@@ -651,6 +972,9 @@ func TestFinanceService_UpdateLoan_Success(t *testing.T) {
 	
 	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
 	mockLoanRepo.On("UpdateLoan", ctx, loan).Return(nil)
+	mockLoanRepo.On("RecordBalanceChange", ctx, mock.MatchedBy(func(entry domain.LoanBalanceEntry) bool {
+		return entry.Type == domain.LoanBalanceAdjustment && entry.OldBalance == 20000.0 && entry.NewBalance == 18000.0
+	})).Return(nil)
 
 	err := service.UpdateLoan(ctx, loan)
 
@@ -674,6 +998,37 @@ func TestFinanceService_UpdateLoan_OwnershipMismatch(t *testing.T) {
 	mockLoanRepo.AssertNotCalled(t, "UpdateLoan")
 }
 
+func TestFinanceService_DeleteLoan_Success(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+	mockLoanRepo.On("DeleteLoan", ctx, "loan-1").Return(nil)
+
+	err := service.DeleteLoan(ctx, "user-1", "loan-1")
+
+	assert.NoError(t, err)
+	mockLoanRepo.AssertExpectations(t)
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, domain.AuditActionDelete, recorder.records[0].Action)
+}
+
+func TestFinanceService_DeleteLoan_OwnershipMismatch(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+
+	err := service.DeleteLoan(ctx, "user-1", "loan-1")
+
+	assert.ErrorIs(t, err, domain.ErrLoanNotOwnedByUser)
+	mockLoanRepo.AssertNotCalled(t, "DeleteLoan")
+}
+
 func TestFinanceService_DeleteIncome_Success(t *testing.T) {
 	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
@@ -743,199 +1098,1806 @@ func TestFinanceService_DeleteExpense_OwnershipMismatch(t *testing.T) {
 	mockExpenseRepo.AssertNotCalled(t, "DeleteExpense")
 }
 
-func TestFinanceService_GetUserIncomes_Success(t *testing.T) {
+func TestFinanceService_RestoreIncome_Success(t *testing.T) {
 	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
 
-	expectedIncomes := []domain.Income{
-		createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true),
-		createTestIncome("income-2", "user-1", "Freelance", 1000.0, "monthly", true),
-	}
-	
-	mockIncomeRepo.On("GetUserIncomes", ctx, "user-1").Return(expectedIncomes, nil)
+	deleted := createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true)
+	mockIncomeRepo.On("GetDeletedIncomeByID", ctx, "income-1").Return(deleted, nil)
+	mockIncomeRepo.On("RestoreIncome", ctx, "income-1").Return(nil)
 
-	incomes, err := service.GetUserIncomes(ctx, "user-1")
+	err := service.RestoreIncome(ctx, "user-1", "income-1")
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedIncomes, incomes)
 	mockIncomeRepo.AssertExpectations(t)
 }
 
-func TestFinanceService_GetActiveUserIncomes_Success(t *testing.T) {
+func TestFinanceService_RestoreIncome_NotDeleted(t *testing.T) {
 	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
 
-	expectedIncomes := []domain.Income{
-		createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true),
-	}
-	
-	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(expectedIncomes, nil)
+	mockIncomeRepo.On("GetDeletedIncomeByID", ctx, "income-1").Return(domain.Income{}, errors.New("not found"))
 
-	incomes, err := service.GetActiveUserIncomes(ctx, "user-1")
+	err := service.RestoreIncome(ctx, "user-1", "income-1")
 
-	assert.NoError(t, err)
-	assert.Equal(t, expectedIncomes, incomes)
-	mockIncomeRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrIncomeNotFound)
+	mockIncomeRepo.AssertNotCalled(t, "RestoreIncome")
 }
 
-func TestFinanceService_GetUserExpenses_Success(t *testing.T) {
-	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+func TestFinanceService_RestoreIncome_OwnershipMismatch(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
 	ctx := context.Background()
 
-	expectedExpenses := []domain.Expense{
-		createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1),
-		createTestExpense("exp-2", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
-	}
-	
-	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expectedExpenses, nil)
+	deleted := createTestIncome("income-1", "different-user", "Salary", 5000.0, "monthly", true)
+	mockIncomeRepo.On("GetDeletedIncomeByID", ctx, "income-1").Return(deleted, nil)
 
-	expenses, err := service.GetUserExpenses(ctx, "user-1")
+	err := service.RestoreIncome(ctx, "user-1", "income-1")
 
-	assert.NoError(t, err)
-	assert.Equal(t, expectedExpenses, expenses)
-	mockExpenseRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrIncomeNotOwnedByUser)
+	mockIncomeRepo.AssertNotCalled(t, "RestoreIncome")
 }
 
-func TestFinanceService_GetUserExpensesByCategory_Success(t *testing.T) {
+func TestFinanceService_RestoreExpense_Success(t *testing.T) {
 	service, _, mockExpenseRepo, _, _ := setupFinanceService()
 	ctx := context.Background()
 
-	expectedExpenses := []domain.Expense{
-		createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1),
-		createTestExpense("exp-2", "user-1", "food", "Restaurant", 50.0, "weekly", false, 2),
-	}
-	
-	mockExpenseRepo.On("GetExpensesByCategory", ctx, "user-1", "food").Return(expectedExpenses, nil)
+	deleted := createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1)
+	mockExpenseRepo.On("GetDeletedExpenseByID", ctx, "exp-1").Return(deleted, nil)
+	mockExpenseRepo.On("RestoreExpense", ctx, "exp-1").Return(nil)
 
-	expenses, err := service.GetUserExpensesByCategory(ctx, "user-1", "food")
+	err := service.RestoreExpense(ctx, "user-1", "exp-1")
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedExpenses, expenses)
 	mockExpenseRepo.AssertExpectations(t)
 }
 
-func TestFinanceService_GetUserLoans_Success(t *testing.T) {
-	service, _, _, mockLoanRepo, _ := setupFinanceService()
+func TestFinanceService_RestoreExpense_OwnershipMismatch(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
 	ctx := context.Background()
 
-	expectedLoans := []domain.Loan{
-		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
-		createTestLoan("loan-2", "user-1", "Credit Union", "personal", 10000.0, 8000.0, 200.0, 7.0),
-	}
-	
-	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(expectedLoans, nil)
+	deleted := createTestExpense("exp-1", "different-user", "food", "Groceries", 100.0, "monthly", false, 1)
+	mockExpenseRepo.On("GetDeletedExpenseByID", ctx, "exp-1").Return(deleted, nil)
 
-	loans, err := service.GetUserLoans(ctx, "user-1")
+	err := service.RestoreExpense(ctx, "user-1", "exp-1")
 
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, domain.ErrExpenseNotOwnedByUser)
+	mockExpenseRepo.AssertNotCalled(t, "RestoreExpense")
+}
+
+func TestFinanceService_BulkDeleteExpenses_Success(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	recorder := &recordingAuditRecorder{}
+	service.audit = recorder
+	ctx := context.Background()
+
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-1").Return(createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1), nil)
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-2").Return(createTestExpense("exp-2", "user-1", "transport", "Bus pass", 60.0, "monthly", true, 2), nil)
+	mockExpenseRepo.On("DeleteExpenses", ctx, []string{"exp-1", "exp-2"}).Return(int64(2), nil)
+
+	// The repeated ID is deleted once
+	deleted, err := service.BulkDeleteExpenses(ctx, "user-1", []string{"exp-1", "exp-2", "exp-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+	assert.Len(t, recorder.records, 2)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_BulkDeleteExpenses_PartialOwnershipDeletesNothing(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-1").Return(createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1), nil)
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-2").Return(createTestExpense("exp-2", "different-user", "food", "Dinner", 80.0, "monthly", false, 3), nil)
+
+	deleted, err := service.BulkDeleteExpenses(ctx, "user-1", []string{"exp-1", "exp-2"})
+
+	assert.ErrorIs(t, err, domain.ErrExpenseNotOwnedByUser)
+	assert.Zero(t, deleted)
+	mockExpenseRepo.AssertNotCalled(t, "DeleteExpenses", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_BulkDeleteExpenses_MissingExpenseDeletesNothing(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-1").Return(domain.Expense{}, errors.New("expense with ID exp-1 not found"))
+
+	_, err := service.BulkDeleteExpenses(ctx, "user-1", []string{"exp-1", "exp-2"})
+
+	assert.ErrorIs(t, err, domain.ErrExpenseNotFound)
+	mockExpenseRepo.AssertNotCalled(t, "DeleteExpenses", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_BulkDeleteExpenses_EmptyIDs(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+
+	for _, ids := range [][]string{nil, {}, {""}} {
+		_, err := service.BulkDeleteExpenses(context.Background(), "user-1", ids)
+		assert.ErrorIs(t, err, domain.ErrInvalidExpenseData)
+	}
+	mockExpenseRepo.AssertNotCalled(t, "GetExpenseByID", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetUserIncomes_Success(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expectedIncomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true),
+		createTestIncome("income-2", "user-1", "Freelance", 1000.0, "monthly", true),
+	}
+	
+	mockIncomeRepo.On("GetUserIncomes", ctx, "user-1").Return(expectedIncomes, nil)
+
+	incomes, err := service.GetUserIncomes(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedIncomes, incomes)
+	mockIncomeRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_GetActiveUserIncomes_Success(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expectedIncomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true),
+	}
+	
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(expectedIncomes, nil)
+
+	incomes, err := service.GetActiveUserIncomes(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedIncomes, incomes)
+	mockIncomeRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_GetUserExpenses_Success(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expectedExpenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1),
+		createTestExpense("exp-2", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}
+	
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expectedExpenses, nil)
+
+	expenses, err := service.GetUserExpenses(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedExpenses, expenses)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_GetUserExpensesByCategory_Success(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expectedExpenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1),
+		createTestExpense("exp-2", "user-1", "food", "Restaurant", 50.0, "weekly", false, 2),
+	}
+	
+	mockExpenseRepo.On("GetExpensesByCategory", ctx, "user-1", "food").Return(expectedExpenses, nil)
+
+	expenses, err := service.GetUserExpensesByCategory(ctx, "user-1", "food")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedExpenses, expenses)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_SearchUserExpenses_Success(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	filter := domain.ExpenseFilter{Query: "rest", Category: "food", MinAmount: 20, MaxAmount: 100}
+	expectedExpenses := []domain.Expense{
+		createTestExpense("exp-2", "user-1", "food", "Restaurant", 50.0, "weekly", false, 2),
+	}
+
+	mockExpenseRepo.On("FindExpenses", ctx, "user-1", filter).Return(expectedExpenses, nil)
+
+	expenses, err := service.SearchUserExpenses(ctx, "user-1", filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedExpenses, expenses)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_SearchUserExpenses_InvalidFilter(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+
+	_, err := service.SearchUserExpenses(context.Background(), "user-1", domain.ExpenseFilter{MinAmount: 100, MaxAmount: 20})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidExpenseFilter)
+	mockExpenseRepo.AssertNotCalled(t, "FindExpenses", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetUserExpensesByTag(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expected := []domain.Expense{createTestExpense("exp-1", "user-1", "transport", "Flights", 400.0, "monthly", false, 3)}
+	mockExpenseRepo.On("FindExpenses", ctx, "user-1", domain.ExpenseFilter{Tag: "summer vacation"}).Return(expected, nil)
+
+	expenses, err := service.GetUserExpensesByTag(ctx, "user-1", "  Summer  Vacation ")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, expenses)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_GetUserExpensesByTag_InvalidTag(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+
+	for _, tag := range []string{"", "   ", "vacation!"} {
+		_, err := service.GetUserExpensesByTag(context.Background(), "user-1", tag)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidExpenseFilter, "tag %q", tag)
+	}
+	mockExpenseRepo.AssertNotCalled(t, "FindExpenses", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_ExportExpenses_ReadsInBatches(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+	filter := domain.ExpenseFilter{Category: "food"}
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// A full first batch means another must be read, starting after its last record
+	firstBatch := make([]domain.Expense, exportBatchSize)
+	for i := range firstBatch {
+		firstBatch[i] = createTestExpense(fmt.Sprintf("exp-%03d", i), "user-1", "food", "Groceries", 10, "weekly", false, 2)
+		firstBatch[i].CreatedAt = createdAt
+	}
+	last := firstBatch[exportBatchSize-1]
+	mockExpenseRepo.On("FindExpensesAfter", ctx, "user-1", filter, domain.Cursor{}, exportBatchSize).Return(firstBatch, nil)
+	mockExpenseRepo.On("FindExpensesAfter", ctx, "user-1", filter, domain.Cursor{CreatedAt: createdAt, ID: last.ID}, exportBatchSize).
+		Return([]domain.Expense{createTestExpense("exp-final", "user-1", "food", "Takeout", 25, "weekly", false, 3)}, nil)
+
+	var exported []string
+	err := service.ExportExpenses(ctx, "user-1", filter, func(expense domain.Expense) error {
+		exported = append(exported, expense.ID)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, exported, exportBatchSize+1)
+	assert.Equal(t, "exp-final", exported[exportBatchSize])
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_ExportExpenses_InvalidFilter(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+
+	err := service.ExportExpenses(context.Background(), "user-1", domain.ExpenseFilter{MinAmount: 100, MaxAmount: 20},
+		func(domain.Expense) error { return nil })
+
+	assert.ErrorIs(t, err, domain.ErrInvalidExpenseFilter)
+	mockExpenseRepo.AssertNotCalled(t, "FindExpensesAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_ExportIncomes_DateRange(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := domain.ExpenseFilter{CreatedFrom: from, CreatedBefore: from.AddDate(0, 1, 0)}
+
+	inRange := createTestIncome("income-1", "user-1", "Salary", 5000, "monthly", true)
+	inRange.CreatedAt = from
+	afterRange := createTestIncome("income-2", "user-1", "Bonus", 1000, "monthly", true)
+	afterRange.CreatedAt = filter.CreatedBefore
+	mockIncomeRepo.On("GetUserIncomesAfter", ctx, "user-1", domain.Cursor{CreatedAt: from}, exportBatchSize).
+		Return([]domain.Income{inRange, afterRange}, nil)
+
+	var exported []string
+	err := service.ExportIncomes(ctx, "user-1", filter, func(income domain.Income) error {
+		exported = append(exported, income.ID)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"income-1"}, exported)
+	mockIncomeRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_ExportLoans_StopsOnCallbackError(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+	writeErr := errors.New("client went away")
+
+	mockLoanRepo.On("GetUserLoansAfter", ctx, "user-1", domain.Cursor{}, exportBatchSize).Return([]domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000, 20000, 400, 5),
+		createTestLoan("loan-2", "user-1", "Bank", "personal", 5000, 1000, 200, 9),
+	}, nil)
+
+	calls := 0
+	err := service.ExportLoans(ctx, "user-1", domain.ExpenseFilter{}, func(domain.Loan) error {
+		calls++
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 1, calls)
+}
+
+func TestFinanceService_GetUserLoans_Success(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expectedLoans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+		createTestLoan("loan-2", "user-1", "Credit Union", "personal", 10000.0, 8000.0, 200.0, 7.0),
+	}
+	
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(expectedLoans, nil)
+
+	loans, err := service.GetUserLoans(ctx, "user-1")
+
+	assert.NoError(t, err)
 	assert.Equal(t, expectedLoans, loans)
 	mockLoanRepo.AssertExpectations(t)
 }
 
-func TestFinanceService_UpdateLoanBalance_Success(t *testing.T) {
-	service, _, _, mockLoanRepo, _ := setupFinanceService()
+func TestFinanceService_GetUserLoansPage_ReturnsNextCursorWhenMoreRemain(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	loans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+		createTestLoan("loan-2", "user-1", "Credit Union", "personal", 10000.0, 8000.0, 200.0, 7.0),
+		createTestLoan("loan-3", "user-1", "Lender", "personal", 5000.0, 4000.0, 100.0, 9.0),
+	}
+	// One more than the limit is fetched to detect the next page
+	mockLoanRepo.On("GetUserLoansAfter", ctx, "user-1", domain.Cursor{}, 3).Return(loans, nil)
+
+	page, next, err := service.GetUserLoansPage(ctx, "user-1", "", 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, loans[:2], page)
+	require.NotEmpty(t, next)
+
+	// The next cursor continues after the last loan returned
+	cursor, err := domain.DecodeCursor(next)
+	require.NoError(t, err)
+	assert.Equal(t, "loan-2", cursor.ID)
+	assert.True(t, loans[1].CreatedAt.Equal(cursor.CreatedAt))
+
+	mockLoanRepo.On("GetUserLoansAfter", ctx, "user-1", mock.MatchedBy(func(c domain.Cursor) bool {
+		return c.ID == "loan-2"
+	}), 3).Return(loans[2:], nil)
+
+	page, next, err = service.GetUserLoansPage(ctx, "user-1", next, 2)
+
+	require.NoError(t, err)
+	assert.Equal(t, loans[2:], page)
+	assert.Empty(t, next, "the last page has no next cursor")
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_GetUserIncomesPage_InvalidCursor_ReturnsError(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	_, _, err := service.GetUserIncomesPage(ctx, "user-1", "not a cursor!", 10)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	mockIncomeRepo.AssertNotCalled(t, "GetUserIncomesAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetUserIncomesPage_AppliesPageSizeBounds(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetUserIncomesAfter", ctx, "user-1", domain.Cursor{}, DefaultFinancePageSize+1).Return([]domain.Income{}, nil).Once()
+	mockIncomeRepo.On("GetUserIncomesAfter", ctx, "user-1", domain.Cursor{}, MaxFinancePageSize+1).Return([]domain.Income{}, nil).Once()
+
+	_, next, err := service.GetUserIncomesPage(ctx, "user-1", "", 0)
+	require.NoError(t, err)
+	assert.Empty(t, next)
+
+	_, _, err = service.GetUserIncomesPage(ctx, "user-1", "", 1000)
+	require.NoError(t, err)
+	mockIncomeRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_UpdateLoanBalance_Success(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+	mockLoanRepo.On("RecordBalanceChange", ctx, mock.MatchedBy(func(entry domain.LoanBalanceEntry) bool {
+		return entry.ID != "" && entry.Type == domain.LoanBalanceAdjustment &&
+			entry.OldBalance == 20000.0 && entry.NewBalance == 18000.0 && entry.Amount == -2000.0
+	})).Return(nil)
+
+	err := service.UpdateLoanBalance(ctx, "user-1", "loan-1", 18000.0)
+
+	assert.NoError(t, err)
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_UpdateLoanBalance_OwnershipMismatch(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+
+	err := service.UpdateLoanBalance(ctx, "user-1", "loan-1", 18000.0)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "loan does not belong to user")
+	mockLoanRepo.AssertNotCalled(t, "RecordBalanceChange", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_RecordLoanPayment_SplitsInterestAndPrincipal(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	// 6% on 20,000 is 100.00 of interest a month
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 6.0)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+	mockLoanRepo.On("RecordBalanceChange", ctx, mock.AnythingOfType("domain.LoanBalanceEntry")).Return(nil)
+
+	entry, err := service.RecordLoanPayment(ctx, "user-1", "loan-1", 400.0, "March")
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, domain.LoanBalancePayment, entry.Type)
+	assert.Equal(t, 100.0, entry.InterestPortion)
+	assert.Equal(t, 300.0, entry.PrincipalPortion)
+	assert.Equal(t, 19700.0, entry.NewBalance)
+	mockLoanRepo.AssertCalled(t, "RecordBalanceChange", ctx, entry)
+	mockLoanRepo.AssertNotCalled(t, "GetBalanceEntries", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_RecordLoanPayment_AutoAccruingLoan_PaysAccruedInterest(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20100.0, 400.0, 6.0)
+	existing.AutoAccrueInterest = true
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+	mockLoanRepo.On("GetBalanceEntries", ctx, "loan-1").Return([]domain.LoanBalanceEntry{
+		{Type: domain.LoanBalanceInterestAccrual, InterestPortion: 100.0},
+	}, nil)
+	mockLoanRepo.On("RecordBalanceChange", ctx, mock.AnythingOfType("domain.LoanBalanceEntry")).Return(nil)
+
+	entry, err := service.RecordLoanPayment(ctx, "user-1", "loan-1", 400.0, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, entry.InterestPortion)
+	assert.Equal(t, 300.0, entry.PrincipalPortion)
+	assert.Equal(t, 19700.0, entry.NewBalance)
+}
+
+func TestFinanceService_RecordLoanPayment_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not_owner", func(t *testing.T) {
+		service, _, _, mockLoanRepo, _ := setupFinanceService()
+		existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 6.0)
+		mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+
+		_, err := service.RecordLoanPayment(ctx, "user-1", "loan-1", 400.0, "")
+
+		assert.ErrorIs(t, err, domain.ErrLoanNotOwnedByUser)
+		mockLoanRepo.AssertNotCalled(t, "RecordBalanceChange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("more_than_owed", func(t *testing.T) {
+		service, _, _, mockLoanRepo, _ := setupFinanceService()
+		existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 500.0, 400.0, 0)
+		mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(existing, nil)
+
+		_, err := service.RecordLoanPayment(ctx, "user-1", "loan-1", 600.0, "")
+
+		assert.ErrorIs(t, err, domain.ErrInvalidLoanData)
+		assert.Contains(t, err.Error(), "more than the 500.00 owed")
+		mockLoanRepo.AssertNotCalled(t, "RecordBalanceChange", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFinanceService_GetLoanBalanceHistory_OwnershipMismatch(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+
+	_, err := service.GetLoanBalanceHistory(ctx, "user-1", "loan-1")
+
+	assert.ErrorIs(t, err, domain.ErrLoanNotOwnedByUser)
+	mockLoanRepo.AssertNotCalled(t, "GetBalanceEntries", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_AccrueMonthlyInterest(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+	now := time.Date(2025, time.March, 3, 2, 0, 0, 0, time.UTC)
+
+	due := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 6.0)
+	due.AutoAccrueInterest = true
+	repaidSinceQuery := createTestLoan("loan-2", "user-2", "Bank", "personal", 5000.0, 1000.0, 100.0, 6.0)
+	repaidSinceQuery.AutoAccrueInterest = true
+	broken := createTestLoan("loan-3", "user-3", "Bank", "personal", 5000.0, 1000.0, 100.0, 6.0)
+	broken.AutoAccrueInterest = true
+	repaid := repaidSinceQuery
+	repaid.RemainingBalance = 0
+
+	mockLoanRepo.On("GetLoansDueForAccrual", ctx, time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)).
+		Return([]domain.Loan{due, repaidSinceQuery, broken}, nil)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-1").Return(due, nil)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-2").Return(repaid, nil)
+	mockLoanRepo.On("GetLoanByIDForUpdate", ctx, "loan-3").Return(domain.Loan{}, errors.New("connection reset"))
+	mockLoanRepo.On("RecordBalanceChange", ctx, mock.MatchedBy(func(entry domain.LoanBalanceEntry) bool {
+		return entry.LoanID == "loan-1" && entry.Type == domain.LoanBalanceInterestAccrual &&
+			entry.InterestPortion == 100.0 && entry.NewBalance == 20100.0 && entry.CreatedAt.Equal(now)
+	})).Return(nil).Once()
+
+	accrued, err := service.AccrueMonthlyInterest(ctx, now)
+
+	assert.Equal(t, 1, accrued)
+	require.Error(t, err, "a failed loan is reported after the rest are accrued")
+	assert.Contains(t, err.Error(), "loan-3")
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_SimulateLoanPayoff_ReportsSavingsAndDTI(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true),
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{existing}, nil)
+
+	simulation, err := service.SimulateLoanPayoff(ctx, "user-1", "loan-1", 200.0, 0)
+
+	require.NoError(t, err)
+	assert.Greater(t, simulation.MonthsSaved, 0)
+	assert.Greater(t, simulation.InterestSaved, 0.0)
+	assert.InDelta(t, 0.1, simulation.DebtToIncomeRatio, 0.0001)
+	assert.InDelta(t, 0.15, simulation.SimulatedDebtToIncomeRatio, 0.0001)
+	assert.InDelta(t, 0.0, simulation.DebtToIncomeRatioAfterPayoff, 0.0001)
+	// The simulation only reads the loan
+	mockLoanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything)
+	mockLoanRepo.AssertNotCalled(t, "UpdateLoanBalance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_SimulateLoanPayoff_OwnershipMismatch(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+
+	_, err := service.SimulateLoanPayoff(ctx, "user-1", "loan-1", 200.0, 0)
+
+	assert.ErrorIs(t, err, domain.ErrLoanNotOwnedByUser)
+}
+
+func TestFinanceService_CalculateExtraPaymentImpact_Mortgage(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mortgage := createTestLoan("loan-1", "user-1", "Bank", "mortgage", 300000.0, 300000.0, 1798.66, 6.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(mortgage, nil)
+	mockLoanRepo.On("GetNearPayoffLoans", ctx, "user-1", domain.NearPayoffThreshold).Return([]domain.Loan{}, nil)
+
+	impact, err := service.CalculateExtraPaymentImpact(ctx, "user-1", "loan-1", 500.0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 360, impact.Baseline.Months())
+	assert.Equal(t, 212, impact.Accelerated.Months())
+	assert.Equal(t, 148, impact.MonthsSaved)
+	assert.InDelta(t, impact.Baseline.TotalInterest-impact.Accelerated.TotalInterest, impact.InterestSaved, 0.01)
+	assert.Greater(t, impact.InterestSaved, 100000.0)
+	assert.False(t, impact.NearPayoff)
+	mockLoanRepo.AssertNotCalled(t, "UpdateLoan", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_CalculateExtraPaymentImpact_NearPayoff(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	loan := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 900.0, 400.0, 5.0)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(loan, nil)
+	mockLoanRepo.On("GetNearPayoffLoans", ctx, "user-1", domain.NearPayoffThreshold).Return([]domain.Loan{loan}, nil)
+
+	impact, err := service.CalculateExtraPaymentImpact(ctx, "user-1", "loan-1", 1000.0)
+
+	require.NoError(t, err)
+	assert.True(t, impact.NearPayoff)
+	assert.Equal(t, 3, impact.Baseline.Months())
+	assert.Equal(t, 1, impact.Accelerated.Months())
+	assert.Equal(t, 2, impact.MonthsSaved)
+}
+
+func TestFinanceService_CalculateExtraPaymentImpact_RejectsInvalidRequests(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").
+		Return(createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0), nil)
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-2").
+		Return(createTestLoan("loan-2", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0), nil)
+
+	_, err := service.CalculateExtraPaymentImpact(ctx, "user-1", "loan-1", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidLoanData)
+
+	_, err = service.CalculateExtraPaymentImpact(ctx, "user-1", "loan-2", 200.0)
+	assert.ErrorIs(t, err, domain.ErrLoanNotOwnedByUser)
+
+	mockLoanRepo.AssertNotCalled(t, "GetNearPayoffLoans", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetNearPayoffLoans_ReturnsOnlyLoansWithinThreshold(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	nearlyPaid := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 1000.0, 400.0, 5.0)
+	halfPaid := createTestLoan("loan-2", "user-1", "Bank", "personal", 10000.0, 5000.0, 300.0, 8.0)
+	repaid := createTestLoan("loan-3", "user-1", "Bank", "student", 15000.0, 0, 200.0, 4.0)
+	mockLoanRepo.On("GetNearPayoffLoans", ctx, "user-1", 0.1).
+		Return([]domain.Loan{halfPaid, nearlyPaid, repaid}, nil)
+
+	loans, err := service.GetNearPayoffLoans(ctx, "user-1", 10)
+
+	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	assert.Equal(t, "loan-1", loans[0].Loan.ID)
+	assert.Equal(t, 2000.0, loans[0].ThresholdBalance)
+	assert.Equal(t, 5.0, loans[0].PercentRemaining)
+}
+
+func TestFinanceService_GetNearPayoffLoans_SortsBySmallestBalance(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mortgage := createTestLoan("loan-1", "user-1", "Bank", "mortgage", 300000.0, 45000.0, 1800.0, 6.0)
+	auto := createTestLoan("loan-2", "user-1", "Bank", "auto", 20000.0, 1500.0, 400.0, 5.0)
+	mockLoanRepo.On("GetNearPayoffLoans", ctx, "user-1", 0.2).Return([]domain.Loan{mortgage, auto}, nil)
+
+	loans, err := service.GetNearPayoffLoans(ctx, "user-1", 20)
+
+	require.NoError(t, err)
+	require.Len(t, loans, 2)
+	assert.Equal(t, "loan-2", loans[0].Loan.ID)
+	assert.Equal(t, "loan-1", loans[1].Loan.ID)
+	assert.Equal(t, 60000.0, loans[1].ThresholdBalance)
+}
+
+func TestFinanceService_GetNearPayoffLoans_RejectsInvalidThreshold(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	for _, threshold := range []float64{0, -5, 100.5} {
+		_, err := service.GetNearPayoffLoans(ctx, "user-1", threshold)
+		assert.ErrorIs(t, err, domain.ErrInvalidLoanData, "threshold %v", threshold)
+	}
+
+	mockLoanRepo.AssertNotCalled(t, "GetNearPayoffLoans", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetLoansByRateRange_ReturnsLoansWithinRange(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	// The user also has loans at 4% and 9%; the repository leaves them out of the 5-8% range
+	auto := createTestLoan("loan-2", "user-1", "Bank", "auto", 20000.0, 15000.0, 400.0, 6.0)
+	mockLoanRepo.On("GetLoansByInterestRateRange", ctx, "user-1", 5.0, 8.0).Return([]domain.Loan{auto}, nil)
+
+	loans, err := service.GetLoansByRateRange(ctx, "user-1", 5, 8)
+
+	require.NoError(t, err)
+	require.Len(t, loans, 1)
+	assert.Equal(t, "loan-2", loans[0].ID)
+	assert.Equal(t, 6.0, loans[0].InterestRate)
+}
+
+func TestFinanceService_GetLoansByRateRange_SortsByHighestRate(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	auto := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 15000.0, 400.0, 6.0)
+	personal := createTestLoan("loan-2", "user-1", "Bank", "personal", 10000.0, 5000.0, 300.0, 9.0)
+	mockLoanRepo.On("GetLoansByInterestRateRange", ctx, "user-1", 0.0, 100.0).Return([]domain.Loan{auto, personal}, nil)
+
+	loans, err := service.GetLoansByRateRange(ctx, "user-1", 0, 100)
+
+	require.NoError(t, err)
+	require.Len(t, loans, 2)
+	assert.Equal(t, "loan-2", loans[0].ID)
+	assert.Equal(t, "loan-1", loans[1].ID)
+}
+
+func TestFinanceService_GetLoansByRateRange_RejectsInvalidRange(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	for _, rates := range [][2]float64{{-1, 5}, {5, -1}, {8, 5}} {
+		_, err := service.GetLoansByRateRange(ctx, "user-1", rates[0], rates[1])
+		assert.ErrorIs(t, err, domain.ErrInvalidLoanData, "rates %v", rates)
+	}
+
+	mockLoanRepo.AssertNotCalled(t, "GetLoansByInterestRateRange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_GetDebtBreakdownByType_GroupsLoansByType(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mortgage := createTestLoan("loan-1", "user-1", "Bank", "mortgage", 300000.0, 240000.0, 1800.0, 4.0)
+	student := createTestLoan("loan-2", "user-1", "Servicer", "student", 40000.0, 20000.0, 250.0, 5.0)
+	otherStudent := createTestLoan("loan-3", "user-1", "Servicer", "student", 15000.0, 10000.0, 50.0, 6.0)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{student, mortgage, otherStudent}, nil)
+
+	breakdown, err := service.GetDebtBreakdownByType(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", breakdown.Currency)
+	assert.Equal(t, 270000.0, breakdown.TotalBalance)
+	assert.Equal(t, 2100.0, breakdown.TotalMonthlyPayment)
+	assert.Equal(t, []domain.LoanTypeDebt{
+		{Type: "mortgage", LoanCount: 1, RemainingBalance: 240000, MonthlyPayment: 1800, SharePercent: 88.89},
+		{Type: "student", LoanCount: 2, RemainingBalance: 30000, MonthlyPayment: 300, SharePercent: 11.11},
+	}, breakdown.Types)
+	assert.InDelta(t, 100.0, breakdown.Types[0].SharePercent+breakdown.Types[1].SharePercent, 1e-9)
+}
+
+func TestFinanceService_GetDebtBreakdownByType_SharesAddUpTo100(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	// Thirds round to 33.33 each; the largest type takes the remainder
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 10000.0, 300.0, 5.0),
+		createTestLoan("loan-2", "user-1", "Bank", "personal", 20000.0, 10000.0, 300.0, 9.0),
+		createTestLoan("loan-3", "user-1", "Bank", "student", 20000.0, 10000.0, 300.0, 4.0),
+	}, nil)
+
+	breakdown, err := service.GetDebtBreakdownByType(ctx, "user-1")
+
+	require.NoError(t, err)
+	require.Len(t, breakdown.Types, 3)
+	var total float64
+	for _, typeDebt := range breakdown.Types {
+		total += typeDebt.SharePercent
+	}
+	assert.InDelta(t, 100.0, total, 1e-9)
+	assert.Equal(t, 33.34, breakdown.Types[0].SharePercent)
+}
+
+func TestFinanceService_GetDebtBreakdownByType_ConvertsToBaseCurrency(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	WithExchangeRateProvider(NewStaticExchangeRateProvider("USD", map[string]float64{"EUR": 0.5}))(service)
+	ctx := context.Background()
+
+	auto := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 10000.0, 400.0, 5.0)
+	auto.Currency = "EUR"
+	mortgage := createTestLoan("loan-2", "user-1", "Bank", "mortgage", 300000.0, 20000.0, 1000.0, 4.0)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{auto, mortgage}, nil)
+
+	breakdown, err := service.GetDebtBreakdownByType(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 40000.0, breakdown.TotalBalance)
+	require.Len(t, breakdown.Types, 2)
+	assert.Equal(t, "mortgage", breakdown.Types[1].Type)
+	assert.Equal(t, 20000.0, breakdown.Types[0].RemainingBalance)
+	assert.Equal(t, 800.0, breakdown.Types[0].MonthlyPayment)
+	assert.Equal(t, 50.0, breakdown.Types[0].SharePercent)
+}
+
+func TestFinanceService_GetDebtBreakdownByType_NoLoans(t *testing.T) {
+	service, _, _, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	breakdown, err := service.GetDebtBreakdownByType(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Zero(t, breakdown.TotalBalance)
+	assert.NotNil(t, breakdown.Types)
+	assert.Empty(t, breakdown.Types)
+}
+
+func TestFinanceService_GetIncomeDiversification_SingleSource(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	// The weekly overtime comes from the same employer; the one-time gift isn't a recurring source
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Acme Corp", 5000.0, "monthly", true),
+		createTestIncome("income-2", "user-1", "acme corp ", 240.0, "weekly", true),
+		createTestIncome("income-3", "user-1", "Gift", 2000.0, "one-time", true),
+	}, nil)
+
+	diversification, err := service.GetIncomeDiversification(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.InDelta(t, 6039.2, diversification.MonthlyIncome, 1e-9)
+	assert.Equal(t, []domain.IncomeSourceShare{
+		{Source: "Acme Corp", IncomeCount: 2, MonthlyAmount: diversification.MonthlyIncome, Share: 1},
+	}, diversification.Sources)
+	assert.Equal(t, 1.0, diversification.LargestSourceShare)
+	assert.Equal(t, 1.0, diversification.ConcentrationIndex)
+	assert.True(t, diversification.SingleSourceDependent)
+}
+
+func TestFinanceService_GetIncomeDiversification_ThreeSources(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Rental", 1500.0, "monthly", true),
+		createTestIncome("income-2", "user-1", "Salary", 3000.0, "monthly", true),
+		createTestIncome("income-3", "user-1", "Freelance", 1500.0, "monthly", true),
+	}, nil)
+
+	diversification, err := service.GetIncomeDiversification(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 6000.0, diversification.MonthlyIncome)
+	require.Len(t, diversification.Sources, 3)
+	assert.Equal(t, "Salary", diversification.Sources[0].Source)
+	assert.Equal(t, "Rental", diversification.Sources[1].Source, "equal sources keep their order")
+	assert.Equal(t, 0.5, diversification.LargestSourceShare)
+	// 0.5² + 0.25² + 0.25²
+	assert.InDelta(t, 0.375, diversification.ConcentrationIndex, 1e-9)
+	assert.False(t, diversification.SingleSourceDependent)
+}
+
+func TestFinanceService_GetIncomeDiversification_DominantSourceIsFlagged(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	WithExchangeRateProvider(NewStaticExchangeRateProvider("USD", map[string]float64{"EUR": 0.5}))(service)
+	ctx := context.Background()
+
+	// 4750 EUR is 9500 USD, 95% of the income
+	salary := createTestIncome("income-1", "user-1", "Salary", 4750.0, "monthly", true)
+	salary.Currency = "EUR"
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		salary,
+		createTestIncome("income-2", "user-1", "Tutoring", 500.0, "monthly", true),
+	}, nil)
+
+	diversification, err := service.GetIncomeDiversification(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 10000.0, diversification.MonthlyIncome)
+	assert.InDelta(t, 0.95, diversification.LargestSourceShare, 1e-9)
+	assert.True(t, diversification.SingleSourceDependent)
+}
+
+func TestFinanceService_GetIncomeDiversification_NoIncome(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{}, nil)
+
+	diversification, err := service.GetIncomeDiversification(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Zero(t, diversification.MonthlyIncome)
+	assert.Zero(t, diversification.ConcentrationIndex)
+	assert.False(t, diversification.SingleSourceDependent)
+	assert.NotNil(t, diversification.Sources)
+	assert.Empty(t, diversification.Sources)
+}
+
+func TestFinanceService_CalculateDisposableIncome_Success(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	incomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
+	}
+	
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}
+	
+	loans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+	}
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+
+	disposableIncome, err := service.CalculateDisposableIncome(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3600.0, disposableIncome) // 6000 - 2000 - 400
+	
+	mockIncomeRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertExpectations(t)
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func setupExpenseCutsTest(t *testing.T) (*financeService, context.Context) {
+	t.Helper()
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+		createTestExpense("exp-2", "user-1", "food", "Groceries", 500.0, "monthly", false, 1),
+		createTestExpense("exp-3", "user-1", "transport", "Parking", 100.0, "monthly", true, 2),
+		createTestExpense("exp-4", "user-1", "transport", "Taxis", 150.0, "monthly", false, 2),
+		createTestExpense("exp-5", "user-1", "entertainment", "Dining out", 300.0, "monthly", false, 3),
+		createTestExpense("exp-6", "user-1", "entertainment", "Streaming", 20.0, "weekly", false, 3),
+	}
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").
+		Return([]domain.Income{createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	return service, ctx
+}
+
+func TestFinanceService_SuggestExpenseCuts_TargetReachable(t *testing.T) {
+	service, ctx := setupExpenseCutsTest(t)
+
+	suggestion, err := service.SuggestExpenseCuts(ctx, "user-1", 350.0)
+
+	require.NoError(t, err)
+	require.Len(t, suggestion.Cuts, 2)
+	assert.Equal(t, "exp-5", suggestion.Cuts[0].Expense.ID)
+	assert.Equal(t, "exp-6", suggestion.Cuts[1].Expense.ID)
+	assert.InDelta(t, 386.6, suggestion.TotalSavings, 0.001) // 300 + 20 * 4.33
+	assert.True(t, suggestion.TargetReachable)
+	assert.Zero(t, suggestion.Shortfall)
+	// 4000 - 2000 - 500 - 100 - 150 - 300 - 86.6 = 863.4 before the cuts
+	assert.InDelta(t, 863.4, suggestion.DisposableIncome, 0.001)
+	assert.InDelta(t, 1250.0, suggestion.ResultingDisposableIncome, 0.001)
+	assert.Equal(t, domain.DefaultCurrency, suggestion.Currency)
+}
+
+func TestFinanceService_SuggestExpenseCuts_TargetUnreachable(t *testing.T) {
+	service, ctx := setupExpenseCutsTest(t)
+
+	suggestion, err := service.SuggestExpenseCuts(ctx, "user-1", 1000.0)
+
+	require.NoError(t, err)
+	var cut []string
+	for _, c := range suggestion.Cuts {
+		cut = append(cut, c.Expense.ID)
+	}
+	// Fixed and essential expenses are never suggested, however far short the cuts fall
+	assert.Equal(t, []string{"exp-5", "exp-6", "exp-4"}, cut)
+	assert.False(t, suggestion.TargetReachable)
+	assert.InDelta(t, 536.6, suggestion.TotalSavings, 0.001)
+	assert.InDelta(t, 463.4, suggestion.Shortfall, 0.001)
+}
+
+func TestFinanceService_SuggestExpenseCuts_RejectsNonPositiveTarget(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	_, err := service.SuggestExpenseCuts(ctx, "user-1", 0)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidFinanceData)
+	mockExpenseRepo.AssertNotCalled(t, "GetUserExpenses", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_CalculateDebtToIncomeRatio_Success(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	incomes := []domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
+	}
+	
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}
+	
+	loans := []domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 1200.0, 5.0),
+	}
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+
+	dtiRatio, err := service.CalculateDebtToIncomeRatio(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, dtiRatio) // (1200 / 6000) * 100 = 20%
+	
+	mockIncomeRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertExpectations(t)
+	mockLoanRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_CalculateFinanceSummary_NoData(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", summary.UserID)
+	assert.Equal(t, 0.0, summary.MonthlyIncome)
+	assert.Equal(t, 0.0, summary.MonthlyExpenses)
+	assert.Equal(t, 0.0, summary.MonthlyLoanPayments)
+	assert.Equal(t, 0.0, summary.DisposableIncome)
+	assert.Equal(t, 0.0, summary.DebtToIncomeRatio)
+	assert.Equal(t, 0.0, summary.SavingsRate)
+}
+func TestFinanceService_CalculateFinanceSummary_PublishesTransitionEvents(t *testing.T) {
+	// The current data overspends income by 200, a Poor summary with a 0.5 debt-to-income ratio
+	incomes := []domain.Income{createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)}
+	expenses := []domain.Expense{createTestExpense("exp-1", "user-1", "housing", "Rent", 2200.0, "monthly", true, 1)}
+	loans := []domain.Loan{createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 2000.0, 5.0)}
+
+	tests := []struct {
+		name           string
+		previous       *domain.FinanceSummary
+		expectedEvents []string
+	}{
+		{
+			name:           "no previous summary",
+			expectedEvents: []string{domain.EventDebtToIncomeExceeded, domain.EventBudgetExceeded},
+		},
+		{
+			name:           "health dropped and ratio crossed the threshold",
+			previous:       &domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthGood, DebtToIncomeRatio: 0.2, BudgetRemaining: 300},
+			expectedEvents: []string{domain.EventFinancialHealthChanged, domain.EventDebtToIncomeExceeded, domain.EventBudgetExceeded},
+		},
+		{
+			name:           "ratio was already above the threshold",
+			previous:       &domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthFair, DebtToIncomeRatio: 0.45, BudgetRemaining: -50},
+			expectedEvents: []string{domain.EventFinancialHealthChanged},
+		},
+		{
+			name:           "only the budget was exceeded",
+			previous:       &domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthPoor, DebtToIncomeRatio: 0.5, BudgetRemaining: 0},
+			expectedEvents: []string{domain.EventBudgetExceeded},
+		},
+		{
+			name:     "nothing changed",
+			previous: &domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthPoor, DebtToIncomeRatio: 0.5, BudgetRemaining: -200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, mockFinanceSummaryRepo := setupFinanceService()
+			publisher := &recordingPublisher{}
+			service.events = publisher
+			ctx := context.Background()
+
+			mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+			mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+			mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+			if tt.previous != nil {
+				mockFinanceSummaryRepo.On("GetFinanceSummaryByUserID", ctx, "user-1").Return(*tt.previous, nil)
+			} else {
+				mockFinanceSummaryRepo.On("GetFinanceSummaryByUserID", ctx, "user-1").Return(domain.FinanceSummary{}, domain.ErrFinanceSummaryNotFound)
+			}
+
+			summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+			require.NoError(t, err)
+			require.Equal(t, domain.HealthPoor, summary.FinancialHealth)
+			var types []string
+			for _, event := range publisher.published() {
+				assert.Equal(t, "user-1", event.UserID)
+				types = append(types, event.Type)
+			}
+			assert.Equal(t, tt.expectedEvents, types)
+		})
+	}
+}
+
+func TestFinanceService_CalculateFinanceSummary_HealthChangedEventData(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, mockFinanceSummaryRepo := setupFinanceService()
+	publisher := &recordingPublisher{}
+	service.events = publisher
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+	mockFinanceSummaryRepo.On("GetFinanceSummaryByUserID", ctx, "user-1").
+		Return(domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthFair}, nil)
+
+	_, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	require.NoError(t, err)
+	events := publisher.published()
+	require.Len(t, events, 1)
+	assert.Equal(t, domain.EventFinancialHealthChanged, events[0].Type)
+	assert.Equal(t, domain.HealthFair, events[0].Data["previous_health"])
+	assert.Equal(t, domain.HealthExcellent, events[0].Data["financial_health"])
+}
+
+func setupSavingsGoalService() (*financeService, *MockSavingsGoalRepository) {
+	service, _, _, _, _ := setupFinanceService()
+	mockSavingsGoalRepo := &MockSavingsGoalRepository{}
+	service.repos.SavingsGoal = mockSavingsGoalRepo
+	return service, mockSavingsGoalRepo
+}
+
+func createTestSavingsGoal(id, userID string, target, current float64) domain.SavingsGoal {
+	return domain.SavingsGoal{
+		ID:            id,
+		UserID:        userID,
+		Name:          "Emergency fund",
+		TargetAmount:  target,
+		CurrentAmount: current,
+		TargetDate:    time.Now().AddDate(1, 0, 0),
+		Priority:      domain.GoalPriorityHigh,
+	}
+}
+
+func TestFinanceService_AddSavingsGoal_Success(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
+	ctx := context.Background()
+
+	goal := createTestSavingsGoal("", "user-1", 6000.0, 0)
+	// The service assigns the ID so the audit entry can name the goal
+	mockSavingsGoalRepo.On("SaveGoal", ctx, mock.MatchedBy(func(saved domain.SavingsGoal) bool {
+		return strings.HasPrefix(saved.ID, "goal-") && saved.UserID == goal.UserID && saved.TargetAmount == goal.TargetAmount
+	})).Return(nil)
+
+	err := service.AddSavingsGoal(ctx, goal)
+
+	assert.NoError(t, err)
+	mockSavingsGoalRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_AddSavingsGoal_PastTargetDate_ReturnsError(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
+	ctx := context.Background()
+
+	goal := createTestSavingsGoal("", "user-1", 6000.0, 0)
+	goal.TargetDate = time.Now().AddDate(0, 0, -1)
+
+	err := service.AddSavingsGoal(ctx, goal)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidSavingsGoalData)
+	assert.Contains(t, err.Error(), "target date must be in the future")
+	mockSavingsGoalRepo.AssertNotCalled(t, "SaveGoal")
+}
+
+func TestFinanceService_UpdateSavingsGoal_OwnershipMismatch(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
+	ctx := context.Background()
+
+	existing := createTestSavingsGoal("goal-1", "different-user", 6000.0, 0)
+	mockSavingsGoalRepo.On("GetGoalByID", ctx, "goal-1").Return(existing, nil)
+
+	err := service.UpdateSavingsGoal(ctx, createTestSavingsGoal("goal-1", "user-1", 8000.0, 0))
+
+	assert.ErrorIs(t, err, domain.ErrSavingsGoalNotOwnedByUser)
+	mockSavingsGoalRepo.AssertNotCalled(t, "UpdateGoal")
+}
+
+func TestFinanceService_DeleteSavingsGoal_NotFound(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
 	ctx := context.Background()
 
-	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
-	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
-	mockLoanRepo.On("UpdateLoanBalance", ctx, "loan-1", 18000.0).Return(nil)
+	mockSavingsGoalRepo.On("GetGoalByID", ctx, "goal-missing").Return(domain.SavingsGoal{}, domain.ErrSavingsGoalNotFound)
 
-	err := service.UpdateLoanBalance(ctx, "user-1", "loan-1", 18000.0)
+	err := service.DeleteSavingsGoal(ctx, "user-1", "goal-missing")
+
+	assert.ErrorIs(t, err, domain.ErrSavingsGoalNotFound)
+	mockSavingsGoalRepo.AssertNotCalled(t, "DeleteGoal")
+}
+
+func TestFinanceService_AddGoalContribution_Success(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
+	ctx := context.Background()
+
+	contribution := domain.GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 250.0, ContributedAt: time.Now().Add(-time.Minute)}
+	mockSavingsGoalRepo.On("GetGoalByID", ctx, "goal-1").Return(createTestSavingsGoal("goal-1", "user-1", 6000.0, 0), nil)
+	mockSavingsGoalRepo.On("AddContribution", ctx, mock.MatchedBy(func(saved domain.GoalContribution) bool {
+		return strings.HasPrefix(saved.ID, "contrib-") && saved.GoalID == "goal-1" && saved.Amount == contribution.Amount
+	})).Return(nil)
+
+	err := service.AddGoalContribution(ctx, contribution)
 
 	assert.NoError(t, err)
-	mockLoanRepo.AssertExpectations(t)
+	mockSavingsGoalRepo.AssertExpectations(t)
 }
 
-func TestFinanceService_UpdateLoanBalance_OwnershipMismatch(t *testing.T) {
-	service, _, _, mockLoanRepo, _ := setupFinanceService()
+func TestFinanceService_AddGoalContribution_OwnershipMismatch(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
 	ctx := context.Background()
 
-	existing := createTestLoan("loan-1", "different-user", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
-	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+	contribution := domain.GoalContribution{GoalID: "goal-1", UserID: "user-1", Amount: 250.0, ContributedAt: time.Now().Add(-time.Minute)}
+	mockSavingsGoalRepo.On("GetGoalByID", ctx, "goal-1").Return(createTestSavingsGoal("goal-1", "different-user", 6000.0, 0), nil)
 
-	err := service.UpdateLoanBalance(ctx, "user-1", "loan-1", 18000.0)
+	err := service.AddGoalContribution(ctx, contribution)
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "loan does not belong to user")
-	mockLoanRepo.AssertNotCalled(t, "UpdateLoanBalance")
+	assert.ErrorIs(t, err, domain.ErrSavingsGoalNotOwnedByUser)
+	mockSavingsGoalRepo.AssertNotCalled(t, "AddContribution")
 }
 
-func TestFinanceService_CalculateDisposableIncome_Success(t *testing.T) {
-	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+func TestFinanceService_GetGoalContributionHistory_CoversRequestedMonths(t *testing.T) {
+	service, mockSavingsGoalRepo := setupSavingsGoalService()
 	ctx := context.Background()
 
-	incomes := []domain.Income{
-		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
-	}
-	
-	expenses := []domain.Expense{
-		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
-	}
-	
-	loans := []domain.Loan{
-		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+	now := time.Now()
+	contributions := []domain.GoalContribution{
+		{ID: "contrib-1", GoalID: "goal-1", UserID: "user-1", Amount: 100.0, ContributedAt: now},
 	}
+	mockSavingsGoalRepo.On("GetUserContributions", ctx, "user-1", mock.MatchedBy(func(since time.Time) bool {
+		return since.Day() == 1 && since.Before(now) && since.After(now.AddDate(0, -3, 0))
+	})).Return(contributions, nil)
+
+	history, err := service.GetGoalContributionHistory(ctx, "user-1", 3)
+
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+	assert.Equal(t, 100.0, history[2].Total)
+	assert.Equal(t, 0.0, history[0].Total)
+	mockSavingsGoalRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_CalculateFinanceSummary_IncludesGoalProjections(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	mockSavingsGoalRepo := &MockSavingsGoalRepository{}
+	service.repos.SavingsGoal = mockSavingsGoalRepo
+	ctx := context.Background()
+
+	incomes := []domain.Income{createTestIncome("income-1", "user-1", "Salary", 3000.0, "monthly", true)}
+	expenses := []domain.Expense{createTestExpense("exp-1", "user-1", "housing", "Rent", 2800.0, "monthly", true, 1)}
+	goal := createTestSavingsGoal("goal-1", "user-1", 6000.0, 0)
+	goal.TargetDate = time.Now().AddDate(0, 12, 15)
 
 	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
 	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
-	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+	mockSavingsGoalRepo.On("GetUserGoals", ctx, "user-1").Return([]domain.SavingsGoal{goal}, nil)
 
-	disposableIncome, err := service.CalculateDisposableIncome(ctx, "user-1")
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	// 6000 over 12 months needs 500 a month but only 200 is left over
+	require.NoError(t, err)
+	require.Len(t, summary.GoalProjections, 1)
+	assert.InDelta(t, 500.0, summary.GoalsMonthlyCommitment, 0.001)
+	assert.InDelta(t, 200.0, summary.GoalProjections[0].AllocatedMonthly, 0.001)
+	assert.False(t, summary.GoalsAchievable())
+	assert.Len(t, summary.GoalShortfallWarnings(), 1)
+	require.NotNil(t, summary.SavingsBalance)
+	assert.Len(t, summary.HealthComponents, 5)
+}
+
+func TestFinanceService_CalculateFinanceSummary_GoalRepositoryError(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	mockSavingsGoalRepo := &MockSavingsGoalRepository{}
+	service.repos.SavingsGoal = mockSavingsGoalRepo
+	ctx := context.Background()
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+	mockSavingsGoalRepo.On("GetUserGoals", ctx, "user-1").Return([]domain.SavingsGoal{}, errors.New("database down"))
+
+	_, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get user savings goals")
+}
+
+// setupMultiCurrencyFinanceService returns a USD-based service that also accepts EUR,
+// at 0.5 EUR to the dollar so converted totals are exact
+func setupMultiCurrencyFinanceService() (*financeService, *MockIncomeRepository, *MockExpenseRepository, *MockLoanRepository) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	WithExchangeRateProvider(NewStaticExchangeRateProvider("USD", map[string]float64{"EUR": 0.5}))(service)
+	return service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo
+}
+
+func TestFinanceService_CalculateFinanceSummary_ConvertsMixedCurrencies(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo := setupMultiCurrencyFinanceService()
+	ctx := context.Background()
+
+	salary := createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)
+	consulting := createTestIncome("income-2", "user-1", "Consulting", 1000.0, "monthly", true)
+	consulting.Currency = "EUR"
+
+	rent := createTestExpense("exp-1", "user-1", "housing", "Rent", 1500.0, "monthly", true, 1)
+	groceries := createTestExpense("exp-2", "user-1", "food", "Groceries", 130.0, "monthly", false, 1)
+	groceries.Currency = "EUR"
+
+	carLoan := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 15000.0, 200.0, 5.0)
+	carLoan.Currency = "EUR"
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{salary, consulting}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{rent, groceries}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{carLoan}, nil)
+
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "USD", summary.Currency)
+	// 4000 USD + 1000 EUR at 2 USD per EUR
+	assert.InDelta(t, 6000.0, summary.MonthlyIncome, 0.01)
+	// 1500 USD + 130 EUR
+	assert.InDelta(t, 1760.0, summary.MonthlyExpenses, 0.01)
+	// 200 EUR
+	assert.InDelta(t, 400.0, summary.MonthlyLoanPayments, 0.01)
+	assert.InDelta(t, 3840.0, summary.DisposableIncome, 0.01)
+}
+
+func TestFinanceService_CalculateFinanceSummary_UnknownCurrency_ReturnsError(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo := setupMultiCurrencyFinanceService()
+	ctx := context.Background()
+
+	income := createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)
+	income.Currency = "JPY"
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{income}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	_, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	assert.True(t, errors.Is(err, domain.ErrUnsupportedCurrency))
+}
+
+func TestFinanceService_AddExpense_DefaultsToBaseCurrency(t *testing.T) {
+	service, _, mockExpenseRepo, _ := setupMultiCurrencyFinanceService()
+	ctx := context.Background()
+
+	expense := createTestExpense("exp-1", "user-1", "food", "Groceries", 60.0, "weekly", false, 1)
+	expense.Currency = ""
+	saved := expense
+	saved.Currency = "USD"
+	mockExpenseRepo.On("FindDuplicateExpenseID", ctx, saved, mock.Anything).Return("", nil)
+	mockExpenseRepo.On("SaveExpense", ctx, saved).Return(nil)
+
+	err := service.AddExpense(ctx, expense, false)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 3600.0, disposableIncome) // 6000 - 2000 - 400
-	
-	mockIncomeRepo.AssertExpectations(t)
 	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_AddIncome_UnsupportedCurrency_ReturnsError(t *testing.T) {
+	service, mockIncomeRepo, _, _ := setupMultiCurrencyFinanceService()
+	ctx := context.Background()
+
+	income := createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)
+	income.Currency = "JPY"
+
+	err := service.AddIncome(ctx, income, false)
+
+	assert.True(t, errors.Is(err, domain.ErrUnsupportedCurrency))
+	mockIncomeRepo.AssertNotCalled(t, "SaveIncome", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_UpdateLoan_KeepsExistingCurrency(t *testing.T) {
+	service, _, _, mockLoanRepo := setupMultiCurrencyFinanceService()
+	ctx := context.Background()
+
+	existing := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 15000.0, 200.0, 5.0)
+	existing.Currency = "EUR"
+	update := existing
+	update.Currency = ""
+	update.MonthlyPayment = 250.0
+	saved := update
+	saved.Currency = "EUR"
+
+	mockLoanRepo.On("GetLoanByID", ctx, "loan-1").Return(existing, nil)
+	mockLoanRepo.On("UpdateLoan", ctx, saved).Return(nil)
+
+	err := service.UpdateLoan(ctx, update)
+
+	assert.NoError(t, err)
 	mockLoanRepo.AssertExpectations(t)
 }
 
-func TestFinanceService_CalculateDebtToIncomeRatio_Success(t *testing.T) {
+func TestFinanceService_CustomFinancialThresholds(t *testing.T) {
 	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	thresholds := domain.DefaultFinancialThresholds()
+	thresholds.ExcellentDTI = 0.05
+	thresholds.HealthyDTI = 0.10
+	thresholds.AffordabilityMultipliers.Good = 1.5
+	WithFinancialThresholds(thresholds)(service)
 	ctx := context.Background()
 
-	incomes := []domain.Income{
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
 		createTestIncome("income-1", "user-1", "Salary", 6000.0, "monthly", true),
-	}
-	
-	expenses := []domain.Expense{
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{
 		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+	}, nil)
+
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	// A DTI of 0.067 would be Excellent by default
+	assert.Equal(t, domain.HealthGood, summary.FinancialHealth)
+
+	maxAffordable, err := service.GetMaxAffordableAmount(ctx, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, 5400.0, maxAffordable)
+}
+
+func TestFinanceService_AddExpense_Duplicate_ReturnsExistingID(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expense := createTestExpense("exp-2", "user-1", "housing", "Monthly Rent", 1500.0, "monthly", true, 1)
+	before := time.Now()
+	mockExpenseRepo.On("FindDuplicateExpenseID", ctx, expense, mock.MatchedBy(func(since time.Time) bool {
+		// Looks back over the default window
+		return !since.Before(before.Add(-DefaultDuplicateWindow)) && since.Before(before.Add(-DefaultDuplicateWindow+time.Minute))
+	})).Return("exp-1", nil)
+
+	err := service.AddExpense(ctx, expense, false)
+
+	var duplicate *domain.DuplicateRecordError
+	require.True(t, errors.As(err, &duplicate))
+	assert.True(t, errors.Is(err, domain.ErrDuplicateRecord))
+	assert.Equal(t, domain.AuditResourceExpense, duplicate.Resource)
+	assert.Equal(t, "exp-1", duplicate.ExistingID)
+	mockExpenseRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertNotCalled(t, "SaveExpense", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_AddIncome_Duplicate_ReturnsExistingID(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	income := createTestIncome("income-2", "user-1", "Salary", 5000.0, "monthly", true)
+	mockIncomeRepo.On("FindDuplicateIncomeID", ctx, income, mock.Anything).Return("income-1", nil)
+
+	err := service.AddIncome(ctx, income, false)
+
+	var duplicate *domain.DuplicateRecordError
+	require.True(t, errors.As(err, &duplicate))
+	assert.Equal(t, domain.AuditResourceIncome, duplicate.Resource)
+	assert.Equal(t, "income-1", duplicate.ExistingID)
+	mockIncomeRepo.AssertNotCalled(t, "SaveIncome", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_AddExpense_AllowDuplicate_SkipsCheck(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	expense := createTestExpense("exp-2", "user-1", "housing", "Monthly Rent", 1500.0, "monthly", true, 1)
+	mockExpenseRepo.On("SaveExpense", ctx, expense).Return(nil)
+
+	err := service.AddExpense(ctx, expense, true)
+
+	assert.NoError(t, err)
+	mockExpenseRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertNotCalled(t, "FindDuplicateExpenseID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_AddIncome_DuplicateWindowDisabled_SkipsCheck(t *testing.T) {
+	service, mockIncomeRepo, _, _, _ := setupFinanceService()
+	WithDuplicateWindow(0)(service)
+	ctx := context.Background()
+
+	income := createTestIncome("income-2", "user-1", "Salary", 5000.0, "monthly", true)
+	mockIncomeRepo.On("SaveIncome", ctx, income).Return(nil)
+
+	err := service.AddIncome(ctx, income, false)
+
+	assert.NoError(t, err)
+	mockIncomeRepo.AssertNotCalled(t, "FindDuplicateIncomeID", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func createTestInstallmentExpense(id, userID string, amount float64, total, paid int) domain.Expense {
+	expense := createTestExpense(id, userID, "other", "Laptop", amount, "monthly", true, 2)
+	expense.InstallmentsTotal = total
+	expense.InstallmentsPaid = paid
+	expense.InstallmentStart = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	return expense
+}
+
+func TestFinanceService_CalculateFinanceSummary_CountsRemainingInstallments(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+
+	incomes := []domain.Income{createTestIncome("income-1", "user-1", "Salary", 3000.0, "monthly", true)}
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 1000.0, "monthly", true, 1),
+		createTestInstallmentExpense("exp-2", "user-1", 1200.0, 12, 3),
+		createTestInstallmentExpense("exp-3", "user-1", 600.0, 6, 6),
 	}
-	
-	loans := []domain.Loan{
-		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 1200.0, 5.0),
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+
+	// Rent plus one installment of the laptop; the paid off purchase no longer counts
+	require.NoError(t, err)
+	assert.InDelta(t, 1100.0, summary.MonthlyExpenses, 0.001)
+	require.Len(t, summary.Installments, 1)
+	assert.Equal(t, "exp-2", summary.Installments[0].ExpenseID)
+	assert.InDelta(t, 100.0, summary.Installments[0].MonthlyAmount, 0.001)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), summary.Installments[0].FinalInstallmentDate)
+}
+
+func TestFinanceService_RecordInstallmentPaid_Success(t *testing.T) {
+	service, _, mockExpenseRepo, _, _ := setupFinanceService()
+	ctx := context.Background()
+
+	existing := createTestInstallmentExpense("exp-1", "user-1", 1200.0, 12, 11)
+	mockExpenseRepo.On("GetExpenseByID", ctx, "exp-1").Return(existing, nil)
+	mockExpenseRepo.On("UpdateExpense", ctx, mock.MatchedBy(func(expense domain.Expense) bool {
+		return expense.ID == "exp-1" && expense.InstallmentsPaid == 12
+	})).Return(nil)
+
+	expense, err := service.RecordInstallmentPaid(ctx, "user-1", "exp-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, 12, expense.InstallmentsPaid)
+	assert.False(t, expense.IsActive())
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_RecordInstallmentPaid_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing domain.Expense
+		wantErr  error
+	}{
+		{"already_paid_off", createTestInstallmentExpense("exp-1", "user-1", 1200.0, 12, 12), domain.ErrInstallmentsComplete},
+		{"not_an_installment_expense", createTestExpense("exp-1", "user-1", "food", "Groceries", 100.0, "monthly", false, 1), domain.ErrInvalidExpenseData},
+		{"other_users_expense", createTestInstallmentExpense("exp-1", "different-user", 1200.0, 12, 3), domain.ErrExpenseNotOwnedByUser},
 	}
 
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _, mockExpenseRepo, _, _ := setupFinanceService()
+			ctx := context.Background()
+			mockExpenseRepo.On("GetExpenseByID", ctx, "exp-1").Return(tt.existing, nil)
+
+			_, err := service.RecordInstallmentPaid(ctx, "user-1", "exp-1")
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockExpenseRepo.AssertNotCalled(t, "UpdateExpense", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func setupBudgetService() (*financeService, *MockBudgetRepository) {
+	service, _, _, _, _ := setupFinanceService()
+	mockBudgetRepo := &MockBudgetRepository{}
+	WithBudgetRepository(mockBudgetRepo)(service)
+	return service, mockBudgetRepo
+}
+
+func TestFinanceService_AddBudget_Success(t *testing.T) {
+	service, mockBudgetRepo := setupBudgetService()
+	ctx := context.Background()
+
+	mockBudgetRepo.On("GetUserBudgets", ctx, "user-1").Return([]domain.Budget{
+		{ID: "budget-1", UserID: "user-1", Category: "housing", MonthlyLimit: 1500},
+	}, nil)
+	// The service assigns the ID so the audit entry can name the budget
+	mockBudgetRepo.On("SaveBudget", ctx, mock.MatchedBy(func(saved domain.Budget) bool {
+		return strings.HasPrefix(saved.ID, "budget-") && saved.Category == "food" && saved.MonthlyLimit == 500
+	})).Return(nil)
+
+	err := service.AddBudget(ctx, domain.Budget{UserID: "user-1", Category: "food", MonthlyLimit: 500})
+
+	assert.NoError(t, err)
+	mockBudgetRepo.AssertExpectations(t)
+}
+
+func TestFinanceService_AddBudget_CategoryTaken_ReturnsConflict(t *testing.T) {
+	service, mockBudgetRepo := setupBudgetService()
+	ctx := context.Background()
+
+	mockBudgetRepo.On("GetUserBudgets", ctx, "user-1").Return([]domain.Budget{
+		{ID: "budget-1", UserID: "user-1", Category: "food", MonthlyLimit: 400},
+	}, nil)
+
+	err := service.AddBudget(ctx, domain.Budget{UserID: "user-1", Category: "food", MonthlyLimit: 500})
+
+	assert.ErrorIs(t, err, domain.ErrBudgetExists)
+	mockBudgetRepo.AssertNotCalled(t, "SaveBudget", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_UpdateBudget_OwnershipMismatch(t *testing.T) {
+	service, mockBudgetRepo := setupBudgetService()
+	ctx := context.Background()
+
+	mockBudgetRepo.On("GetBudgetByID", ctx, "budget-1").Return(domain.Budget{ID: "budget-1", UserID: "different-user", Category: "food", MonthlyLimit: 400}, nil)
+
+	err := service.UpdateBudget(ctx, domain.Budget{ID: "budget-1", UserID: "user-1", Category: "food", MonthlyLimit: 500})
+
+	assert.ErrorIs(t, err, domain.ErrBudgetNotOwnedByUser)
+	mockBudgetRepo.AssertNotCalled(t, "UpdateBudget", mock.Anything, mock.Anything)
+}
+
+func TestFinanceService_AddBudget_NotEnabled(t *testing.T) {
+	service, _, _, _, _ := setupFinanceService()
+
+	err := service.AddBudget(context.Background(), domain.Budget{UserID: "user-1", Category: "food", MonthlyLimit: 500})
+
+	assert.EqualError(t, err, "budgets are not enabled")
+}
+
+func TestFinanceService_CalculateFinanceSummary_ComparesBudgets(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	mockBudgetRepo := &MockBudgetRepository{}
+	WithBudgetRepository(mockBudgetRepo)(service)
+	ctx := context.Background()
+
+	incomes := []domain.Income{createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true)}
+	expenses := []domain.Expense{
+		createTestExpense("exp-1", "user-1", "food", "Groceries", 450.0, "monthly", false, 2),
+		createTestExpense("exp-2", "user-1", "food", "Takeaway", 150.0, "monthly", false, 3),
+		createTestExpense("exp-3", "user-1", "transport", "Fuel", 100.0, "monthly", false, 2),
+		createTestExpense("exp-4", "user-1", "entertainment", "Streaming", 20.0, "monthly", false, 3),
+	}
+	budgets := []domain.Budget{
+		{ID: "budget-1", UserID: "user-1", Category: "food", MonthlyLimit: 500, CreatedAt: time.Now()},
+		{ID: "budget-2", UserID: "user-1", Category: "transport", MonthlyLimit: 200, CreatedAt: time.Now()},
+	}
 	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return(incomes, nil)
 	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return(expenses, nil)
-	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return(loans, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+	mockBudgetRepo.On("GetUserBudgets", ctx, "user-1").Return(budgets, nil)
 
-	dtiRatio, err := service.CalculateDebtToIncomeRatio(ctx, "user-1")
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
 
-	assert.NoError(t, err)
-	assert.Equal(t, 20.0, dtiRatio) // (1200 / 6000) * 100 = 20%
-	
-	mockIncomeRepo.AssertExpectations(t)
-	mockExpenseRepo.AssertExpectations(t)
-	mockLoanRepo.AssertExpectations(t)
+	// Entertainment has no budget, so only food and transport are compared
+	require.NoError(t, err)
+	require.Len(t, summary.Budgets, 2)
+	assert.Equal(t, "food", summary.Budgets[0].Category)
+	assert.InDelta(t, 600.0, summary.Budgets[0].Spent, 0.001)
+	assert.True(t, summary.Budgets[0].OverBudget)
+	assert.False(t, summary.Budgets[1].OverBudget)
+	require.Len(t, summary.OverspentCategories, 1)
+	assert.Equal(t, "food", summary.OverspentCategories[0].Category)
 }
 
-func TestFinanceService_CalculateFinanceSummary_NoData(t *testing.T) {
+func TestFinanceService_GetMonthlyFinanceActivity(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	on := func(month time.Month, day int) time.Time { return time.Date(2025, month, day, 12, 0, 0, 0, time.UTC) }
+
+	salary := createTestIncome("income-1", "user-1", "Salary", 4000.0, "monthly", true)
+	salary.CreatedAt, salary.UpdatedAt = on(time.January, 10), on(time.January, 10)
+	bonus := createTestIncome("income-2", "user-1", "Bonus", 1000.0, "one-time", true)
+	bonus.CreatedAt, bonus.UpdatedAt = on(time.March, 15), on(time.March, 15)
+	gift := createTestIncome("income-3", "user-1", "Gift", 500.0, "one-time", true)
+	gift.CreatedAt, gift.UpdatedAt = on(time.February, 5), on(time.February, 5)
+	freelance := createTestIncome("income-4", "user-1", "Freelance", 800.0, "monthly", true)
+	freelance.CreatedAt, freelance.UpdatedAt = on(time.March, 20), on(time.March, 20)
+
+	rent := createTestExpense("exp-1", "user-1", "housing", "Rent", 1500.0, "monthly", true, 1)
+	rent.CreatedAt, rent.UpdatedAt = on(time.January, 10), on(time.January, 10)
+	groceries := createTestExpense("exp-2", "user-1", "food", "Groceries", 400.0, "monthly", false, 1)
+	groceries.CreatedAt, groceries.UpdatedAt = on(time.February, 1), on(time.February, 1)
+	laptop := createTestInstallmentExpense("exp-3", "user-1", 1200.0, 12, 0)
+	laptop.InstallmentStart = time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	laptop.CreatedAt, laptop.UpdatedAt = on(time.February, 20), on(time.February, 20)
+	cinema := createTestExpense("exp-4", "user-1", "entertainment", "Cinema", 30.0, "weekly", false, 3)
+	cinema.CreatedAt, cinema.UpdatedAt = on(time.April, 2), on(time.April, 2)
+
+	loan := createTestLoan("loan-1", "user-1", "Bank", "auto", 20000.0, 19000.0, 400.0, 5.5)
+	loan.CreatedAt, loan.UpdatedAt = on(time.January, 1), on(time.April, 5)
+	entries := []domain.LoanBalanceEntry{
+		{LoanID: "loan-1", Type: domain.LoanBalancePayment, OldBalance: 19310, NewBalance: 19000, Amount: 400, InterestPortion: 90, PrincipalPortion: 310, CreatedAt: on(time.April, 5)},
+		{LoanID: "loan-1", Type: domain.LoanBalancePayment, OldBalance: 19618, NewBalance: 19310, Amount: 400, InterestPortion: 92, PrincipalPortion: 308, CreatedAt: on(time.March, 5)},
+		{LoanID: "loan-1", Type: domain.LoanBalancePayment, OldBalance: 19925, NewBalance: 19618, Amount: 400, InterestPortion: 93, PrincipalPortion: 307, CreatedAt: on(time.February, 5)},
+	}
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{salary, bonus, gift, freelance}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{rent, groceries, laptop, cinema}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{loan}, nil)
+	mockLoanRepo.On("GetBalanceEntries", ctx, "loan-1").Return(entries, nil)
+
+	activity, err := service.GetMonthlyFinanceActivity(ctx, "user-1", start, end)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultCurrency, activity.Currency)
+	// The gift came in February and the cinema expense was added in April
+	assert.Equal(t, []domain.StatementLine{
+		{Label: "Salary", Amount: 4000, Count: 1},
+		{Label: "Bonus", Amount: 1000, Count: 1},
+		{Label: "Freelance", Amount: 800, Count: 1},
+	}, activity.Income)
+	assert.Equal(t, 5800.0, activity.TotalIncome)
+	// No laptop installment falls due until April
+	assert.Equal(t, []domain.StatementLine{
+		{Label: "housing", Amount: 1500, Count: 1},
+		{Label: "food", Amount: 400, Count: 1},
+	}, activity.Expenses)
+	assert.Equal(t, 1900.0, activity.TotalExpenses)
+	assert.Equal(t, []domain.StatementLoanPayment{
+		{LoanID: "loan-1", Lender: "Bank", Amount: 400, InterestPortion: 92, PrincipalPortion: 308, Payments: 1},
+	}, activity.LoanPayments)
+	assert.Equal(t, 400.0, activity.TotalLoanPayments)
+	assert.Equal(t, 7, activity.RecordCount)
+	assert.Equal(t, loan.UpdatedAt, activity.LastUpdated)
+
+	// Each end of the month is scored from the records that existed then, with the loan's balance at the time.
+	// Like the finance summary, the scores spread one-time incomes over a year.
+	startIncome := 4000 + 500.0/12
+	startSummary := domain.FinanceSummary{
+		MonthlyIncome: startIncome, MonthlyExpenses: 1900, MonthlyLoanPayments: 400, DisposableIncome: startIncome - 2300,
+		DebtToIncomeRatio: 400 / startIncome, SavingsRate: (startIncome - 2300) / startIncome, LoanPrincipal: 20000, LoanBalance: 19618,
+	}
+	endIncome := 4800 + 1500.0/12
+	endSummary := domain.FinanceSummary{
+		MonthlyIncome: endIncome, MonthlyExpenses: 1900, MonthlyLoanPayments: 400, DisposableIncome: endIncome - 2300,
+		DebtToIncomeRatio: 400 / endIncome, SavingsRate: (endIncome - 2300) / endIncome, LoanPrincipal: 20000, LoanBalance: 19310,
+	}
+	require.NotNil(t, activity.StartScore)
+	require.NotNil(t, activity.EndScore)
+	assert.InDelta(t, startSummary.ScoreHealthWith(service.thresholds).Score, activity.StartScore.Score, 0.0001)
+	assert.InDelta(t, endSummary.ScoreHealthWith(service.thresholds).Score, activity.EndScore.Score, 0.0001)
+	assert.Greater(t, activity.EndScore.Score, activity.StartScore.Score)
+}
+
+func TestFinanceService_GetMonthlyFinanceActivity_WeeklyIncomeScoresLikeSummary(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	ctx := context.Background()
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	created := start.AddDate(0, -2, 0)
+
+	wages := createTestIncome("income-1", "user-1", "Wages", 900.0, "weekly", true)
+	wages.CreatedAt, wages.UpdatedAt = created, created
+	rent := createTestExpense("exp-1", "user-1", "housing", "Rent", 1500.0, "monthly", true, 1)
+	rent.CreatedAt, rent.UpdatedAt = created, created
+
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{wages}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{rent}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+
+	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	activity, err := service.GetMonthlyFinanceActivity(ctx, "user-1", start, start.AddDate(0, 1, 0))
+	require.NoError(t, err)
+
+	// The statement converts weekly income with the same factor as the summary
+	assert.Equal(t, []domain.StatementLine{{Label: "Wages", Amount: summary.MonthlyIncome, Count: 1}}, activity.Income)
+	require.NotNil(t, activity.EndScore)
+	assert.InDelta(t, summary.HealthScore, activity.EndScore.Score, 0.0001)
+}
+
+func TestFinanceService_GetMonthlyFinanceActivity_NoRecords(t *testing.T) {
 	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
 	ctx := context.Background()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
 
 	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{}, nil)
 	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{}, nil)
 	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
 
-	summary, err := service.CalculateFinanceSummary(ctx, "user-1")
+	activity, err := service.GetMonthlyFinanceActivity(ctx, "user-1", start, start.AddDate(0, 1, 0))
 
-	assert.NoError(t, err)
-	assert.Equal(t, "user-1", summary.UserID)
-	assert.Equal(t, 0.0, summary.MonthlyIncome)
-	assert.Equal(t, 0.0, summary.MonthlyExpenses)
-	assert.Equal(t, 0.0, summary.MonthlyLoanPayments)
-	assert.Equal(t, 0.0, summary.DisposableIncome)
-	assert.Equal(t, 0.0, summary.DebtToIncomeRatio)
-	assert.Equal(t, 0.0, summary.SavingsRate)
-}
\ No newline at end of file
+	require.NoError(t, err)
+	assert.Equal(t, 0, activity.RecordCount)
+	assert.True(t, activity.LastUpdated.IsZero())
+	assert.NotNil(t, activity.Income)
+	assert.Empty(t, activity.Income)
+	assert.Empty(t, activity.Expenses)
+	assert.Empty(t, activity.LoanPayments)
+	assert.Nil(t, activity.StartScore, "there is nothing to score before the first record")
+	assert.Nil(t, activity.EndScore)
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"sync"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// DefaultFinanceSummaryCacheTTL is how long a computed finance summary is served
+// before it is recomputed, unless a write invalidates it first
+const DefaultFinanceSummaryCacheTTL = 30 * time.Second
+
+// financeSummaryCache keeps the last computed FinanceSummary per user for a short TTL.
+// Like healthSummaryCache, each user has a version counter that every write bumps,
+// so a summary computed before a write is never stored after it.
+type financeSummaryCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	now      func() time.Time
+	versions map[string]uint64
+	entries  map[string]financeSummaryEntry
+}
+
+type financeSummaryEntry struct {
+	version   uint64
+	expiresAt time.Time
+	summary   domain.FinanceSummary
+}
+
+// newFinanceSummaryCache creates an empty finance summary cache; a ttl of 0 disables it
+func newFinanceSummaryCache(ttl time.Duration) *financeSummaryCache {
+	return &financeSummaryCache{
+		ttl:      ttl,
+		now:      time.Now,
+		versions: make(map[string]uint64),
+		entries:  make(map[string]financeSummaryEntry),
+	}
+}
+
+// enabled returns true if summaries are cached at all
+func (c *financeSummaryCache) enabled() bool {
+	return c.ttl > 0
+}
+
+// get returns the cached summary when it is still current and unexpired, along with
+// the version the caller must pass to put after recomputing on a miss
+func (c *financeSummaryCache) get(userID string) (domain.FinanceSummary, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	version := c.versions[userID]
+	if !c.enabled() {
+		return domain.FinanceSummary{}, version, false
+	}
+
+	entry, ok := c.entries[userID]
+	if !ok || entry.version != version || !c.now().Before(entry.expiresAt) {
+		delete(c.entries, userID)
+		return domain.FinanceSummary{}, version, false
+	}
+
+	return entry.summary, version, true
+}
+
+// put stores a summary computed at the given version. It is dropped if a write
+// invalidated the user in the meantime, since the data it was built from may be stale.
+func (c *financeSummaryCache) put(userID string, version uint64, summary domain.FinanceSummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.enabled() || c.versions[userID] != version {
+		return
+	}
+	c.entries[userID] = financeSummaryEntry{
+		version:   version,
+		expiresAt: c.now().Add(c.ttl),
+		summary:   summary,
+	}
+}
+
+// invalidate bumps the user's version and drops any cached summary
+func (c *financeSummaryCache) invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[userID]++
+	delete(c.entries, userID)
+}
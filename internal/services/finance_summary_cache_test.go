@@ -0,0 +1,273 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCachedFinanceService returns a finance service with the summary cache enabled
+// and repositories that hold one income, expense and loan for user-1
+func setupCachedFinanceService(ttl time.Duration) (*financeService, *MockIncomeRepository, *MockExpenseRepository, *MockLoanRepository) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, _ := setupFinanceService()
+	service.summaryCache = newFinanceSummaryCache(ttl)
+
+	mockIncomeRepo.On("GetActiveIncomes", context.Background(), "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true),
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", context.Background(), "user-1").Return([]domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1),
+	}, nil)
+	mockLoanRepo.On("GetUserLoans", context.Background(), "user-1").Return([]domain.Loan{
+		createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0),
+	}, nil)
+
+	return service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo
+}
+
+func TestFinanceService_CalculateFinanceSummary_SecondCallHitsCache(t *testing.T) {
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo := setupCachedFinanceService(time.Minute)
+	ctx := context.Background()
+
+	first, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	second, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 2600.0, second.DisposableIncome)
+	mockIncomeRepo.AssertNumberOfCalls(t, "GetActiveIncomes", 1)
+	mockExpenseRepo.AssertNumberOfCalls(t, "GetUserExpenses", 1)
+	mockLoanRepo.AssertNumberOfCalls(t, "GetUserLoans", 1)
+}
+
+func TestFinanceService_CalculateFinanceSummary_MutationInvalidatesCache(t *testing.T) {
+	ctx := context.Background()
+	income := createTestIncome("income-1", "user-1", "Salary", 5000.0, "monthly", true)
+	expense := createTestExpense("exp-1", "user-1", "housing", "Rent", 2000.0, "monthly", true, 1)
+	loan := createTestLoan("loan-1", "user-1", "Bank", "auto", 25000.0, 20000.0, 400.0, 5.0)
+
+	tests := []struct {
+		name   string
+		mutate func(*financeService, *MockIncomeRepository, *MockExpenseRepository, *MockLoanRepository) error
+	}{
+		{
+			name: "AddIncome",
+			mutate: func(s *financeService, incomes *MockIncomeRepository, _ *MockExpenseRepository, _ *MockLoanRepository) error {
+				incomes.On("SaveIncome", ctx, income).Return(nil)
+				return s.AddIncome(ctx, income, true)
+			},
+		},
+		{
+			name: "UpdateIncome",
+			mutate: func(s *financeService, incomes *MockIncomeRepository, _ *MockExpenseRepository, _ *MockLoanRepository) error {
+				incomes.On("GetIncomeByID", ctx, income.ID).Return(income, nil)
+				incomes.On("UpdateIncome", ctx, income).Return(nil)
+				return s.UpdateIncome(ctx, income)
+			},
+		},
+		{
+			name: "DeleteIncome",
+			mutate: func(s *financeService, incomes *MockIncomeRepository, _ *MockExpenseRepository, _ *MockLoanRepository) error {
+				incomes.On("GetIncomeByID", ctx, income.ID).Return(income, nil)
+				incomes.On("DeleteIncome", ctx, income.ID).Return(nil)
+				return s.DeleteIncome(ctx, "user-1", income.ID)
+			},
+		},
+		{
+			name: "RestoreIncome",
+			mutate: func(s *financeService, incomes *MockIncomeRepository, _ *MockExpenseRepository, _ *MockLoanRepository) error {
+				incomes.On("GetDeletedIncomeByID", ctx, income.ID).Return(income, nil)
+				incomes.On("RestoreIncome", ctx, income.ID).Return(nil)
+				return s.RestoreIncome(ctx, "user-1", income.ID)
+			},
+		},
+		{
+			name: "AddExpense",
+			mutate: func(s *financeService, _ *MockIncomeRepository, expenses *MockExpenseRepository, _ *MockLoanRepository) error {
+				expenses.On("SaveExpense", ctx, expense).Return(nil)
+				return s.AddExpense(ctx, expense, true)
+			},
+		},
+		{
+			name: "UpdateExpense",
+			mutate: func(s *financeService, _ *MockIncomeRepository, expenses *MockExpenseRepository, _ *MockLoanRepository) error {
+				expenses.On("GetExpenseByID", ctx, expense.ID).Return(expense, nil)
+				expenses.On("UpdateExpense", ctx, expense).Return(nil)
+				return s.UpdateExpense(ctx, expense)
+			},
+		},
+		{
+			name: "DeleteExpense",
+			mutate: func(s *financeService, _ *MockIncomeRepository, expenses *MockExpenseRepository, _ *MockLoanRepository) error {
+				expenses.On("GetExpenseByID", ctx, expense.ID).Return(expense, nil)
+				expenses.On("DeleteExpense", ctx, expense.ID).Return(nil)
+				return s.DeleteExpense(ctx, "user-1", expense.ID)
+			},
+		},
+		{
+			name: "RestoreExpense",
+			mutate: func(s *financeService, _ *MockIncomeRepository, expenses *MockExpenseRepository, _ *MockLoanRepository) error {
+				expenses.On("GetDeletedExpenseByID", ctx, expense.ID).Return(expense, nil)
+				expenses.On("RestoreExpense", ctx, expense.ID).Return(nil)
+				return s.RestoreExpense(ctx, "user-1", expense.ID)
+			},
+		},
+		{
+			name: "AddLoan",
+			mutate: func(s *financeService, _ *MockIncomeRepository, _ *MockExpenseRepository, loans *MockLoanRepository) error {
+				loans.On("SaveLoan", ctx, loan).Return(nil)
+				return s.AddLoan(ctx, loan)
+			},
+		},
+		{
+			name: "UpdateLoan",
+			mutate: func(s *financeService, _ *MockIncomeRepository, _ *MockExpenseRepository, loans *MockLoanRepository) error {
+				loans.On("GetLoanByID", ctx, loan.ID).Return(loan, nil)
+				loans.On("UpdateLoan", ctx, loan).Return(nil)
+				return s.UpdateLoan(ctx, loan)
+			},
+		},
+		{
+			name: "UpdateLoanBalance",
+			mutate: func(s *financeService, _ *MockIncomeRepository, _ *MockExpenseRepository, loans *MockLoanRepository) error {
+				loans.On("GetLoanByIDForUpdate", ctx, loan.ID).Return(loan, nil)
+				loans.On("RecordBalanceChange", ctx, mock.Anything).Return(nil)
+				return s.UpdateLoanBalance(ctx, "user-1", loan.ID, 18000.0)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo := setupCachedFinanceService(time.Minute)
+
+			_, err := service.CalculateFinanceSummary(ctx, "user-1")
+			require.NoError(t, err)
+			require.NoError(t, tt.mutate(service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo))
+			_, err = service.CalculateFinanceSummary(ctx, "user-1")
+			require.NoError(t, err)
+
+			mockIncomeRepo.AssertNumberOfCalls(t, "GetActiveIncomes", 2)
+			mockExpenseRepo.AssertNumberOfCalls(t, "GetUserExpenses", 2)
+			mockLoanRepo.AssertNumberOfCalls(t, "GetUserLoans", 2)
+		})
+	}
+}
+
+func TestFinanceService_DeleteBudget_DropsAlertFromCachedSummary(t *testing.T) {
+	service, _, _, _ := setupCachedFinanceService(time.Minute)
+	mockBudgetRepo := &MockBudgetRepository{}
+	WithBudgetRepository(mockBudgetRepo)(service)
+	ctx := context.Background()
+
+	budget := domain.Budget{ID: "budget-1", UserID: "user-1", Category: "housing", MonthlyLimit: 1500, CreatedAt: time.Now()}
+	mockBudgetRepo.On("GetUserBudgets", ctx, "user-1").Return([]domain.Budget{budget}, nil).Once()
+	mockBudgetRepo.On("GetBudgetByID", ctx, "budget-1").Return(budget, nil)
+	mockBudgetRepo.On("DeleteBudget", ctx, "budget-1").Return(nil)
+	mockBudgetRepo.On("GetUserBudgets", ctx, "user-1").Return([]domain.Budget{}, nil)
+
+	before, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	require.Len(t, before.OverspentCategories, 1)
+
+	require.NoError(t, service.DeleteBudget(ctx, "user-1", "budget-1"))
+	after, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+
+	assert.Empty(t, after.Budgets)
+	assert.Empty(t, after.OverspentCategories)
+}
+
+func TestFinanceService_CalculateFinanceSummary_CacheExpiresAfterTTL(t *testing.T) {
+	service, mockIncomeRepo, _, _ := setupCachedFinanceService(30 * time.Second)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.summaryCache.now = func() time.Time { return now }
+
+	_, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+
+	now = now.Add(29 * time.Second)
+	_, err = service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	mockIncomeRepo.AssertNumberOfCalls(t, "GetActiveIncomes", 1)
+
+	now = now.Add(time.Second)
+	_, err = service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+	mockIncomeRepo.AssertNumberOfCalls(t, "GetActiveIncomes", 2)
+}
+
+func TestFinanceService_CalculateFinanceSummary_ZeroTTLDisablesCache(t *testing.T) {
+	service, mockIncomeRepo, _, _ := setupCachedFinanceService(0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := service.CalculateFinanceSummary(ctx, "user-1")
+		require.NoError(t, err)
+	}
+
+	mockIncomeRepo.AssertNumberOfCalls(t, "GetActiveIncomes", 3)
+}
+
+func TestFinanceSummaryCache_InvalidateIsPerUser(t *testing.T) {
+	cache := newFinanceSummaryCache(time.Minute)
+
+	_, v1, _ := cache.get("user-1")
+	cache.put("user-1", v1, domain.FinanceSummary{UserID: "user-1"})
+	_, v2, _ := cache.get("user-2")
+	cache.put("user-2", v2, domain.FinanceSummary{UserID: "user-2"})
+
+	cache.invalidate("user-1")
+
+	_, _, ok := cache.get("user-1")
+	assert.False(t, ok)
+	summary, _, ok := cache.get("user-2")
+	assert.True(t, ok)
+	assert.Equal(t, "user-2", summary.UserID)
+}
+
+func TestFinanceSummaryCache_DropsSummaryComputedBeforeWrite(t *testing.T) {
+	cache := newFinanceSummaryCache(time.Minute)
+
+	_, version, ok := cache.get("user-1")
+	require.False(t, ok)
+
+	// A write lands while the summary is being computed
+	cache.invalidate("user-1")
+	cache.put("user-1", version, domain.FinanceSummary{UserID: "user-1"})
+
+	_, _, ok = cache.get("user-1")
+	assert.False(t, ok)
+}
+
+func TestFinanceSummaryCache_ConcurrentAccess(t *testing.T) {
+	cache := newFinanceSummaryCache(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userID := []string{"user-1", "user-2"}[i%2]
+			if _, version, ok := cache.get(userID); !ok {
+				cache.put(userID, version, domain.FinanceSummary{UserID: userID})
+			}
+			if i%5 == 0 {
+				cache.invalidate(userID)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if summary, _, ok := cache.get("user-1"); ok {
+		assert.Equal(t, "user-1", summary.UserID)
+	}
+}
@@ -1,6 +1,10 @@
 package services
 
-import "time"
+import (
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
 
 // Budget Analysis Types
 
@@ -35,6 +39,9 @@ type SpendingInsights struct {
 	LowestCategory        CategorySpending
 	VariableVsFixedRatio  float64 // Variable expenses / Fixed expenses
 	SpendingEfficiency    string  // "Efficient", "Moderate", "Wasteful"
+	// Budgets compares the user's budgets with this month's spending, as in the summary
+	Budgets             []domain.BudgetStatus
+	OverspentCategories []domain.BudgetStatus
 }
 
 // CategorySpending represents spending in a specific category
@@ -48,6 +55,15 @@ type CategorySpending struct {
 	IsFixed            bool
 }
 
+// AffordabilityAssessment rates a user's finances under a budget analyzer's thresholds
+type AffordabilityAssessment struct {
+	UserID              string
+	FinancialHealth     string
+	DebtToIncomeRatio   float64
+	Multiplier          float64 // Applied to disposable income for the user's debt-to-income band
+	MaxAffordableAmount float64
+}
+
 // SavingsRecommendation based on 50/30/20 rule
 type SavingsRecommendation struct {
 	UserID                 string
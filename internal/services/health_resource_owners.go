@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"fmt"
+)
+
+// Health resources whose owner HealthResourceOwners can look up
+const (
+	HealthResourceCondition = "condition"
+	HealthResourceExpense   = "expense"
+	HealthResourcePolicy    = "policy"
+)
+
+// HealthResourceOwners looks up who owns a medical condition, medical expense or insurance policy
+type HealthResourceOwners struct {
+	conditionRepo MedicalConditionRepository
+	expenseRepo   MedicalExpenseRepository
+	policyRepo    InsurancePolicyRepository
+}
+
+// NewHealthResourceOwners creates an owner lookup over the health repositories
+func NewHealthResourceOwners(conditionRepo MedicalConditionRepository, expenseRepo MedicalExpenseRepository, policyRepo InsurancePolicyRepository) *HealthResourceOwners {
+	return &HealthResourceOwners{
+		conditionRepo: conditionRepo,
+		expenseRepo:   expenseRepo,
+		policyRepo:    policyRepo,
+	}
+}
+
+// GetResourceOwner returns the ID of the user who owns the resource with the given ID, reading
+// it once. Returns an error wrapping domain.ErrMedicalConditionNotFound,
+// domain.ErrMedicalExpenseNotFound or domain.ErrInsurancePolicyNotFound if it doesn't exist.
+func (o *HealthResourceOwners) GetResourceOwner(ctx context.Context, resource, id string) (string, error) {
+	switch resource {
+	case HealthResourceCondition:
+		condition, err := o.conditionRepo.GetByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return condition.UserID, nil
+	case HealthResourceExpense:
+		expense, err := o.expenseRepo.GetByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return expense.UserID, nil
+	case HealthResourcePolicy:
+		policy, err := o.policyRepo.GetByID(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return policy.UserID, nil
+	default:
+		return "", fmt.Errorf("unknown health resource %q", resource)
+	}
+}
@@ -3,18 +3,127 @@ package services
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 )
 
 // healthService implements the HealthService interface
 type healthService struct {
-	profileRepo   HealthProfileRepository
-	conditionRepo MedicalConditionRepository
-	expenseRepo   MedicalExpenseRepository
-	policyRepo    InsurancePolicyRepository
-	riskCalc      RiskCalculator
-	costAnalyzer  MedicalCostAnalyzer
+	profileRepo    HealthProfileRepository
+	conditionRepo  MedicalConditionRepository
+	expenseRepo    MedicalExpenseRepository
+	policyRepo     InsurancePolicyRepository
+	medicationRepo MedicationScheduleRepository
+	riskCalc       RiskCalculator
+	costAnalyzer   MedicalCostAnalyzer
+	insuranceEval  InsuranceEvaluator
+	summaryCache   *healthSummaryCache
+	hsaLimits      HSALimits
+	events         EventPublisher
+	riskLevels     *riskLevelTracker
+	audit          AuditRecorder
+	riskSnapshots  HealthRiskSnapshotRepository
+	occurrences    MedicalExpenseOccurrenceRepository
+	txManager      TxManager
+	catalog        ConditionCatalogRepository
+}
+
+// HealthServiceOption customizes a health service created by NewHealthService
+type HealthServiceOption func(*healthService)
+
+// WithHSALimits overrides the HSA contribution limits and HDHP minimum deductibles.
+// Zero fields keep their default values.
+func WithHSALimits(limits HSALimits) HealthServiceOption {
+	return func(h *healthService) {
+		defaults := DefaultHSALimits()
+		if limits.SelfOnlyContributionLimit <= 0 {
+			limits.SelfOnlyContributionLimit = defaults.SelfOnlyContributionLimit
+		}
+		if limits.FamilyContributionLimit <= 0 {
+			limits.FamilyContributionLimit = defaults.FamilyContributionLimit
+		}
+		if limits.SelfOnlyMinDeductible <= 0 {
+			limits.SelfOnlyMinDeductible = defaults.SelfOnlyMinDeductible
+		}
+		if limits.FamilyMinDeductible <= 0 {
+			limits.FamilyMinDeductible = defaults.FamilyMinDeductible
+		}
+		h.hsaLimits = limits
+	}
+}
+
+// WithHealthSummaryCache turns the per-user health summary cache on or off. It is on by default;
+// writes invalidate a user's summary, a summary is recomputed on the first call of each day, and
+// the cost projection and risk what-if reuse the data it was computed from. Off, every call reads
+// the repositories.
+func WithHealthSummaryCache(enabled bool) HealthServiceOption {
+	return func(h *healthService) {
+		h.summaryCache = newHealthSummaryCache(enabled)
+	}
+}
+
+// WithHealthEventPublisher publishes deductible met and high risk events
+func WithHealthEventPublisher(publisher EventPublisher) HealthServiceOption {
+	return func(h *healthService) {
+		h.events = publisher
+		h.riskLevels = newRiskLevelTracker()
+	}
+}
+
+// WithHealthAuditRecorder records profile, condition and policy changes in the audit log.
+// Only the action and the record's ID are logged, never the medical detail.
+func WithHealthAuditRecorder(recorder AuditRecorder) HealthServiceOption {
+	return func(h *healthService) {
+		h.audit = recorder
+	}
+}
+
+// WithRiskSnapshotRepository enables risk history. Once set, the risk score is snapshotted
+// whenever the profile or a condition changes, in addition to explicit SnapshotRisk calls.
+func WithRiskSnapshotRepository(repo HealthRiskSnapshotRepository) HealthServiceOption {
+	return func(h *healthService) {
+		h.riskSnapshots = repo
+	}
+}
+
+// WithExpenseOccurrenceRepository enables recording the actual costs of recurring medical expenses.
+// Once set, the health summary replaces the projected cost of recurring expenses with their
+// recorded costs for the months that have them.
+func WithExpenseOccurrenceRepository(repo MedicalExpenseOccurrenceRepository) HealthServiceOption {
+	return func(h *healthService) {
+		h.occurrences = repo
+	}
+}
+
+// WithHealthTxManager records a covered medical expense and the policy's deductible progress
+// in one transaction. Without it they are written one after the other.
+func WithHealthTxManager(txManager TxManager) HealthServiceOption {
+	return func(h *healthService) {
+		h.txManager = txManager
+	}
+}
+
+// WithConditionCatalog enables adding conditions from the condition catalog and searching it.
+// Without it a condition naming a catalog entry is rejected and searches find nothing.
+func WithConditionCatalog(repo ConditionCatalogRepository) HealthServiceOption {
+	return func(h *healthService) {
+		h.catalog = repo
+	}
+}
+
+// DefaultHSALimits returns the 2025 IRS HSA contribution limits and HDHP minimum deductibles
+func DefaultHSALimits() HSALimits {
+	return HSALimits{
+		SelfOnlyContributionLimit: 4300,
+		FamilyContributionLimit:   8550,
+		SelfOnlyMinDeductible:     1650,
+		FamilyMinDeductible:       3300,
+	}
 }
 
 // NewHealthService creates a new health service instance
@@ -23,218 +132,1700 @@ func NewHealthService(
 	conditionRepo MedicalConditionRepository,
 	expenseRepo MedicalExpenseRepository,
 	policyRepo InsurancePolicyRepository,
+	medicationRepo MedicationScheduleRepository,
 	riskCalc RiskCalculator,
 	costAnalyzer MedicalCostAnalyzer,
+	insuranceEval InsuranceEvaluator,
+	opts ...HealthServiceOption,
 ) HealthService {
-	return &healthService{
-		profileRepo:   profileRepo,
-		conditionRepo: conditionRepo,
-		expenseRepo:   expenseRepo,
-		policyRepo:    policyRepo,
-		riskCalc:      riskCalc,
-		costAnalyzer:  costAnalyzer,
+	h := &healthService{
+		profileRepo:    profileRepo,
+		conditionRepo:  conditionRepo,
+		expenseRepo:    expenseRepo,
+		policyRepo:     policyRepo,
+		medicationRepo: medicationRepo,
+		riskCalc:       riskCalc,
+		costAnalyzer:   costAnalyzer,
+		insuranceEval:  insuranceEval,
+		summaryCache:   newHealthSummaryCache(true),
+		hsaLimits:      DefaultHSALimits(),
+		audit:          nopAuditRecorder{},
+		txManager:      directTxManager{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Profile operations
+func (h *healthService) CreateProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	if !profile.IsSelf() {
+		return fmt.Errorf("dependent profiles must be created with CreateDependentProfile")
+	}
+	profile.RelationToOwner = domain.RelationSelf
+
+	// Check if user already has a profile (one self profile per user constraint). Concurrent requests
+	// can both pass the check; the repository then rejects the later insert with the same error.
+	exists, err := h.profileRepo.ExistsByUserID(ctx, profile.UserID)
+	if err != nil {
+		return fmt.Errorf("error checking existing profile: %w", err)
+	}
+
+	if exists {
+		return domain.ErrProfileAlreadyExists
+	}
+
+	// Validate the profile
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("profile validation failed: %w", err)
+	}
+
+	// Calculate BMI before saving
+	bmi, err := profile.CalculateBMI()
+	if err != nil {
+		return fmt.Errorf("BMI calculation failed: %w", err)
+	}
+	profile.BMI = bmi
+
+	created, err := h.profileRepo.Create(ctx, profile)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionCreate, domain.AuditResourceHealthProfile, created.ID)
+	return nil
+}
+
+func (h *healthService) GetProfile(ctx context.Context, userID string) (*domain.HealthProfile, error) {
+	return h.profileRepo.GetByUserID(ctx, userID)
+}
+
+func (h *healthService) UpdateProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("profile validation failed: %w", err)
+	}
+
+	// Recalculate BMI before updating
+	bmi, err := profile.CalculateBMI()
+	if err != nil {
+		return fmt.Errorf("BMI calculation failed: %w", err)
+	}
+	profile.BMI = bmi
+
+	_, err = h.profileRepo.Update(ctx, profile)
+	h.summaryCache.invalidate(profile.UserID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionUpdate, domain.AuditResourceHealthProfile, profile.ID)
+	h.snapshotRiskAfterChange(ctx, profile.UserID)
+	return nil
+}
+
+// DeleteProfile deletes the user's own health profile. The conditions, medical expenses and
+// policies recorded against it are deleted with it; dependent profiles are kept.
+func (h *healthService) DeleteProfile(ctx context.Context, userID string) error {
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(profile.ID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("health profile not found for user %s", userID)
+	}
+
+	err = h.profileRepo.Delete(ctx, uint(id))
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceHealthProfile, profile.ID)
+	return nil
+}
+
+// maxProfileHistoryPoints caps the number of snapshots returned in a profile history
+const maxProfileHistoryPoints = 100
+
+// GetProfileHistory returns the profile's weight and BMI over the last months months,
+// limited to the most recent maxProfileHistoryPoints snapshots plus the current profile
+func (h *healthService) GetProfileHistory(ctx context.Context, userID string, months int) (*ProfileHistory, error) {
+	if months <= 0 {
+		return nil, fmt.Errorf("months must be positive")
+	}
+
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	since := time.Now().AddDate(0, -months, 0)
+	snapshots, err := h.profileRepo.GetSnapshots(ctx, userID, since, maxProfileHistoryPoints)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile history: %w", err)
+	}
+
+	points := make([]domain.ProfileSnapshot, 0, len(snapshots)+1)
+	for _, snapshot := range snapshots {
+		points = append(points, *snapshot)
+	}
+	points = append(points, *domain.NewProfileSnapshot(profile, profile.UpdatedAt))
+
+	first, last := &points[0], &points[len(points)-1]
+	return &ProfileHistory{
+		UserID:       userID,
+		Months:       months,
+		Points:       points,
+		WeightChange: first.WeightChangeTo(last),
+		BMIChange:    first.BMIChangeTo(last),
+	}, nil
+}
+
+// CreateDependentProfile adds a family member's profile to the account of profile.UserID.
+// The owner must already have a self profile.
+func (h *healthService) CreateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	if profile.IsSelf() {
+		return fmt.Errorf("profile validation failed: dependent profiles need a relation other than self")
+	}
+
+	exists, err := h.profileRepo.ExistsByUserID(ctx, profile.UserID)
+	if err != nil {
+		return fmt.Errorf("error checking existing profile: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("owner must create their own health profile before adding dependents")
+	}
+
+	// Dependents are part of the owner's household, which FamilySize describes on the self profile
+	if profile.FamilySize == 0 {
+		profile.FamilySize = 1
+	}
+
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("profile validation failed: %w", err)
+	}
+
+	bmi, err := profile.CalculateBMI()
+	if err != nil {
+		return fmt.Errorf("BMI calculation failed: %w", err)
+	}
+	profile.BMI = bmi
+
+	created, err := h.profileRepo.Create(ctx, profile)
+	if err != nil {
+		return err
+	}
+	// The summary breaks expenses down by every family member, so it changes with the family
+	h.summaryCache.invalidate(profile.UserID)
+	h.recordAudit(ctx, domain.AuditActionCreate, domain.AuditResourceHealthProfile, created.ID)
+	return nil
+}
+
+// GetFamilyProfiles returns every profile on the user's account, self profile first
+func (h *healthService) GetFamilyProfiles(ctx context.Context, userID string) ([]domain.HealthProfile, error) {
+	profilePtrs, err := h.profileRepo.GetFamilyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]domain.HealthProfile, len(profilePtrs))
+	for i, profile := range profilePtrs {
+		profiles[i] = *profile
+	}
+
+	return profiles, nil
+}
+
+// GetFamilyMember returns one of the user's dependent profiles. Profiles belonging to other
+// users, and the user's own self profile, are reported as not found.
+func (h *healthService) GetFamilyMember(ctx context.Context, userID, profileID string) (*domain.HealthProfile, error) {
+	profiles, err := h.profileRepo.GetFamilyByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		if profile.ID == profileID && !profile.IsSelf() {
+			return profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("family member %s not found", profileID)
+}
+
+// UpdateDependentProfile updates a family member's profile on the account of profile.UserID
+func (h *healthService) UpdateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error {
+	if profile.IsSelf() {
+		return fmt.Errorf("profile validation failed: dependent profiles need a relation other than self")
+	}
+
+	if _, err := h.GetFamilyMember(ctx, profile.UserID, profile.ID); err != nil {
+		return err
+	}
+
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("profile validation failed: %w", err)
+	}
+
+	bmi, err := profile.CalculateBMI()
+	if err != nil {
+		return fmt.Errorf("BMI calculation failed: %w", err)
+	}
+	profile.BMI = bmi
+
+	_, err = h.profileRepo.Update(ctx, profile)
+	h.summaryCache.invalidate(profile.UserID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionUpdate, domain.AuditResourceHealthProfile, profile.ID)
+	return nil
+}
+
+// DeleteDependentProfile removes a family member's profile along with their conditions, expenses and policies
+func (h *healthService) DeleteDependentProfile(ctx context.Context, userID, profileID string) error {
+	if _, err := h.GetFamilyMember(ctx, userID, profileID); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(profileID, 10, 32)
+	if err != nil {
+		return fmt.Errorf("family member %s not found", profileID)
+	}
+
+	err = h.profileRepo.Delete(ctx, uint(id))
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceHealthProfile, profileID)
+	return nil
+}
+
+// resolveMemberProfileID returns the profile a new condition, expense or policy belongs to. An empty
+// profileID means the account owner; any other ID must be a profile on the user's own account.
+func (h *healthService) resolveMemberProfileID(ctx context.Context, userID, profileID string) (string, error) {
+	profiles, err := h.profileRepo.GetFamilyByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, profile := range profiles {
+		if profile.ID == profileID || (profileID == "" && profile.IsSelf()) {
+			return profile.ID, nil
+		}
+	}
+
+	if profileID == "" {
+		return "", fmt.Errorf("health profile not found for user %s", userID)
+	}
+	return "", fmt.Errorf("user is not authorized to record data for family member %s", profileID)
+}
+
+// GetFamilyHealthRollup calculates risk and medical costs for each profile on the account and
+// aggregates them. Conditions and expenses are matched to profiles by ProfileID; records that match
+// no profile are attributed to the self profile.
+func (h *healthService) GetFamilyHealthRollup(ctx context.Context, userID string) (*FamilyHealthRollup, error) {
+	profiles, err := h.GetFamilyProfiles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("health profile not found for user %s", userID)
+	}
+
+	conditionPtrs, err := h.conditionRepo.GetByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conditions: %w", err)
+	}
+
+	expensePtrs, err := h.expenseRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	indexFor := familyMemberIndex(profiles)
+
+	memberConditions := make([][]domain.MedicalCondition, len(profiles))
+	for _, condition := range conditionPtrs {
+		i := indexFor(condition.ProfileID)
+		memberConditions[i] = append(memberConditions[i], *condition)
+	}
+
+	memberExpenses := make([][]domain.MedicalExpense, len(profiles))
+	for _, expense := range expensePtrs {
+		i := indexFor(expense.ProfileID)
+		memberExpenses[i] = append(memberExpenses[i], *expense)
+	}
+
+	rollup := &FamilyHealthRollup{
+		UserID:  userID,
+		Members: make([]FamilyMemberRollup, len(profiles)),
+	}
+
+	totalRiskScore := 0
+	for i := range profiles {
+		profile := &profiles[i]
+		riskScore := h.riskCalc.CalculateHealthRiskScore(profile, memberConditions[i])
+
+		member := FamilyMemberRollup{
+			ProfileID:           profile.ID,
+			Name:                profile.Name,
+			RelationToOwner:     profile.RelationToOwner,
+			HealthRiskScore:     riskScore,
+			HealthRiskLevel:     h.riskCalc.DetermineRiskLevel(riskScore),
+			ActiveConditions:    len(memberConditions[i]),
+			MonthlyMedicalCost:  h.costAnalyzer.CalculateMonthlyAverage(memberExpenses[i]),
+			ProjectedAnnualCost: h.costAnalyzer.ProjectAnnualCosts(memberExpenses[i], memberConditions[i]),
+		}
+		rollup.Members[i] = member
+
+		totalRiskScore += riskScore
+		if riskScore > rollup.HighestRiskScore {
+			rollup.HighestRiskScore = riskScore
+		}
+		rollup.TotalMonthlyMedicalCost += member.MonthlyMedicalCost
+		rollup.TotalProjectedAnnualCost += member.ProjectedAnnualCost
+	}
+
+	rollup.AverageRiskScore = math.Round(float64(totalRiskScore)/float64(len(profiles))*100) / 100
+	rollup.HighestRiskLevel = h.riskCalc.DetermineRiskLevel(rollup.HighestRiskScore)
+
+	return rollup, nil
+}
+
+// familyMemberIndex returns a function mapping a ProfileID to its position in profiles.
+// IDs that match no profile map to the self profile.
+func familyMemberIndex(profiles []domain.HealthProfile) func(profileID string) int {
+	memberIndex := make(map[string]int, len(profiles))
+	selfIndex := 0
+	for i, profile := range profiles {
+		memberIndex[profile.ID] = i
+		if profile.IsSelf() {
+			selfIndex = i
+		}
+	}
+	return func(profileID string) int {
+		if i, ok := memberIndex[profileID]; ok {
+			return i
+		}
+		return selfIndex
+	}
+}
+
+// Medical conditions
+func (h *healthService) AddCondition(ctx context.Context, condition *domain.MedicalCondition) error {
+	profileID, err := h.resolveMemberProfileID(ctx, condition.UserID, condition.ProfileID)
+	if err != nil {
+		return err
+	}
+	condition.ProfileID = profileID
+
+	if condition.IsCatalogued() {
+		if err := h.applyCatalogEntry(ctx, condition); err != nil {
+			return err
+		}
+	}
+
+	if err := condition.Validate(); err != nil {
+		return fmt.Errorf("condition validation failed: %w", err)
+	}
+
+	// Calculate risk factor based on severity if not provided
+	if condition.RiskFactor == 0 {
+		condition.RiskFactor = h.calculateRiskFactorBySeverity(condition.Severity)
+	}
+
+	created, err := h.conditionRepo.Create(ctx, condition)
+	h.summaryCache.invalidate(condition.UserID)
+	if err != nil {
+		return err
+	}
+	*condition = *created
+	h.recordAudit(ctx, domain.AuditActionCreate, domain.AuditResourceMedicalCondition, created.ID)
+	h.snapshotRiskAfterChange(ctx, condition.UserID)
+	return nil
+}
+
+// applyCatalogEntry fills in a condition from the catalog entry it names.
+// Returns domain.ErrConditionCatalogEntryNotFound if there is no such entry.
+func (h *healthService) applyCatalogEntry(ctx context.Context, condition *domain.MedicalCondition) error {
+	if h.catalog == nil {
+		return fmt.Errorf("%w: %s", domain.ErrConditionCatalogEntryNotFound, condition.CatalogID)
+	}
+
+	entry, err := h.catalog.GetByID(ctx, condition.CatalogID)
+	if err != nil {
+		return err
+	}
+	entry.ApplyTo(condition)
+	return nil
+}
+
+// SearchConditionCatalog returns up to limit catalog entries matching query, best match first;
+// see domain.MatchConditionCatalog
+func (h *healthService) SearchConditionCatalog(ctx context.Context, query string, limit int) ([]domain.ConditionCatalogMatch, error) {
+	if h.catalog == nil {
+		return nil, nil
+	}
+
+	entries, err := h.catalog.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.MatchConditionCatalog(entries, query, limit), nil
+}
+
+func (h *healthService) GetConditions(ctx context.Context, userID string) ([]domain.MedicalCondition, error) {
+	conditions, err := h.conditionRepo.GetByUserID(ctx, userID, false) // Get all conditions
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*domain.MedicalCondition to []domain.MedicalCondition
+	result := make([]domain.MedicalCondition, len(conditions))
+	for i, condition := range conditions {
+		result[i] = *condition
+	}
+	return result, nil
+}
+
+// UpdateCondition updates a condition. A condition that becomes inactive gets a resolved date
+// instead of being removed; reactivating it clears the resolved date.
+func (h *healthService) UpdateCondition(ctx context.Context, condition *domain.MedicalCondition) error {
+	existing, err := h.conditionRepo.GetByID(ctx, condition.ID)
+	if err != nil {
+		return err
+	}
+	if existing.UserID != condition.UserID {
+		return fmt.Errorf("not authorized to update this condition")
+	}
+
+	// Fields that cannot be changed through an update are carried over
+	if condition.ProfileID == "" {
+		condition.ProfileID = existing.ProfileID
+	}
+	if condition.DiagnosedDate.IsZero() {
+		condition.DiagnosedDate = existing.DiagnosedDate
+	}
+	// A condition added from the catalog keeps its canonical name and category
+	condition.CatalogID = existing.CatalogID
+	condition.CatalogRiskWeight = existing.CatalogRiskWeight
+	if existing.IsCatalogued() {
+		condition.Name = existing.Name
+		condition.Category = existing.Category
+	}
+
+	if condition.IsActive {
+		condition.Reactivate()
+	} else {
+		condition.ResolvedDate = existing.ResolvedDate
+		condition.Resolve(time.Now())
+	}
+
+	if err := condition.Validate(); err != nil {
+		return fmt.Errorf("condition validation failed: %w", err)
+	}
+
+	_, err = h.conditionRepo.Update(ctx, condition)
+	h.summaryCache.invalidate(condition.UserID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionUpdate, domain.AuditResourceMedicalCondition, condition.ID)
+	h.snapshotRiskAfterChange(ctx, condition.UserID)
+	return nil
+}
+
+// RemoveCondition marks a condition as resolved. The record is kept so it stays in the condition timeline.
+func (h *healthService) RemoveCondition(ctx context.Context, userID, conditionID string) error {
+	condition, err := h.conditionRepo.GetByID(ctx, conditionID)
+	if err != nil {
+		return err
+	}
+	if condition.UserID != userID {
+		return fmt.Errorf("not authorized to remove this condition")
+	}
+
+	condition.Resolve(time.Now())
+
+	_, err = h.conditionRepo.Update(ctx, condition)
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceMedicalCondition, conditionID)
+	h.snapshotRiskAfterChange(ctx, userID)
+	return nil
+}
+
+// DeleteCondition permanently deletes one of the user's conditions. Unlike RemoveCondition it
+// keeps no resolved record, so it's meant for data that should never have been recorded.
+func (h *healthService) DeleteCondition(ctx context.Context, userID, conditionID string) error {
+	condition, err := h.conditionRepo.GetByID(ctx, conditionID)
+	if err != nil {
+		return err
+	}
+	if condition.UserID != userID {
+		return fmt.Errorf("not authorized to remove this condition")
+	}
+
+	err = h.conditionRepo.Delete(ctx, conditionID)
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceMedicalCondition, conditionID)
+	h.snapshotRiskAfterChange(ctx, userID)
+	return nil
+}
+
+// GetConditionTimeline returns the user's conditions in diagnosis order, split into active and resolved,
+// with how long each has lasted and its status changes
+func (h *healthService) GetConditionTimeline(ctx context.Context, userID string) (*ConditionTimeline, error) {
+	conditions, err := h.conditionRepo.GetByUserID(ctx, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conditions: %w", err)
+	}
+
+	sort.SliceStable(conditions, func(i, j int) bool {
+		return conditions[i].DiagnosedDate.Before(conditions[j].DiagnosedDate)
+	})
+
+	now := time.Now()
+	timeline := &ConditionTimeline{
+		UserID:   userID,
+		Active:   []ConditionTimelineEntry{},
+		Resolved: []ConditionTimelineEntry{},
+	}
+	for _, condition := range conditions {
+		entry := ConditionTimelineEntry{
+			Condition:          *condition,
+			Status:             ConditionStatusActive,
+			DaysSinceDiagnosis: condition.DaysSinceDiagnosis(now),
+			DurationDays:       condition.DurationDays(now),
+			StatusHistory: []ConditionStatusChange{
+				{Status: ConditionStatusDiagnosed, Date: condition.DiagnosedDate},
+			},
+		}
+
+		if condition.IsActive {
+			timeline.Active = append(timeline.Active, entry)
+			continue
+		}
+
+		entry.Status = ConditionStatusResolved
+		// Conditions resolved before resolved dates were recorded fall back to their last update
+		resolvedAt := condition.UpdatedAt
+		if condition.ResolvedDate != nil {
+			resolvedAt = *condition.ResolvedDate
+		}
+		entry.StatusHistory = append(entry.StatusHistory, ConditionStatusChange{Status: ConditionStatusResolved, Date: resolvedAt})
+		timeline.Resolved = append(timeline.Resolved, entry)
+	}
+
+	return timeline, nil
+}
+
+// Medical expenses
+func (h *healthService) AddExpense(ctx context.Context, expense *domain.MedicalExpense) error {
+	profileID, err := h.resolveMemberProfileID(ctx, expense.UserID, expense.ProfileID)
+	if err != nil {
+		return err
+	}
+	expense.ProfileID = profileID
+
+	if err := expense.Validate(); err != nil {
+		return fmt.Errorf("expense validation failed: %w", err)
+	}
+
+	if expense.IsCovered {
+		policy, err := h.findCoveringPolicy(ctx, expense)
+		if err != nil {
+			return err
+		}
+		if policy != nil {
+			return h.addCoveredExpense(ctx, expense, policy)
+		}
+	}
+
+	// Without a policy to apply, out-of-pocket is what the client says insurance didn't pay
+	if expense.IsCovered && expense.InsurancePayment > 0 {
+		expense.OutOfPocket = expense.Amount - expense.InsurancePayment
+		if expense.OutOfPocket < 0 {
+			expense.OutOfPocket = 0
+		}
+	} else {
+		expense.OutOfPocket = expense.Amount
+	}
+
+	_, err = h.expenseRepo.Create(ctx, expense)
+	h.summaryCache.invalidate(expense.UserID)
+	return err
+}
+
+// findCoveringPolicy returns the most recently added active policy of the expense's profile that
+// pays for its category on its date, or nil if none does. Another family member's policy never
+// pays, so one member's expenses can't use up another's deductible.
+func (h *healthService) findCoveringPolicy(ctx context.Context, expense *domain.MedicalExpense) (*domain.InsurancePolicy, error) {
+	policies, err := h.policyRepo.GetActivePolicies(ctx, expense.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+	for _, policy := range policies {
+		if policyCoversCategory(policy.Type, expense.Category) && policy.CoversMedicalExpense(*expense) {
+			return policy, nil
+		}
+	}
+	return nil, nil
+}
+
+// addCoveredExpense applies the policy to the expense, replacing any insurance payment the
+// client sent, and records the expense together with the policy's new deductible and
+// out-of-pocket progress. The policy is reread and locked inside the transaction, so
+// concurrent expenses each see the progress the one before left.
+func (h *healthService) addCoveredExpense(ctx context.Context, expense *domain.MedicalExpense, policy *domain.InsurancePolicy) error {
+	var newDeductibleMet float64
+	err := h.txManager.WithTx(ctx, func(ctx context.Context) error {
+		current, err := h.policyRepo.GetByIDForUpdate(ctx, policy.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get policy: %w", err)
+		}
+		policy = current
+
+		covered, outOfPocket, deductibleMet := policy.CalculateCoverage(expense.Amount)
+		expense.InsurancePayment = covered
+		expense.OutOfPocket = outOfPocket
+		newDeductibleMet = deductibleMet
+		newOutOfPocketCurrent := math.Min(policy.OutOfPocketCurrent+outOfPocket, policy.OutOfPocketMax)
+
+		if _, err := h.expenseRepo.Create(ctx, expense); err != nil {
+			return err
+		}
+		_, err = h.policyRepo.UpdateDeductibleProgress(ctx, policy.ID, newDeductibleMet, newOutOfPocketCurrent)
+		return err
+	})
+	h.summaryCache.invalidate(expense.UserID)
+	if err != nil {
+		return err
+	}
+
+	h.recordAudit(ctx, domain.AuditActionUpdate, domain.AuditResourceInsurancePolicy, policy.ID)
+	h.publishDeductibleMet(policy, newDeductibleMet)
+	return nil
+}
+
+func (h *healthService) GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
+	expenses, err := h.expenseRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*domain.MedicalExpense to []domain.MedicalExpense
+	result := make([]domain.MedicalExpense, len(expenses))
+	for i, expense := range expenses {
+		result[i] = *expense
+	}
+	return result, nil
+}
+
+// GetRecurringExpenses returns the user's recurring medical expenses. Each must have a frequency
+// the cost analyzer can normalize to a monthly amount, so the list always adds up to the summary's
+// monthly medical expenses.
+func (h *healthService) GetRecurringExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
+	expenses, err := h.expenseRepo.GetRecurring(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*domain.MedicalExpense to []domain.MedicalExpense
+	result := make([]domain.MedicalExpense, len(expenses))
+	for i, expense := range expenses {
+		if _, err := h.costAnalyzer.NormalizeMedicalExpenseToMonthly(*expense); err != nil {
+			return nil, fmt.Errorf("recurring expense %s: %w", expense.ID, err)
+		}
+		result[i] = *expense
+	}
+	return result, nil
+}
+
+// AddExpenseOccurrence records what one occurrence of a recurring medical expense actually cost.
+// The occurrence inherits the expense's category and coverage. Returns domain.ErrMedicalExpenseNotFound
+// if the expense doesn't belong to the user and domain.ErrExpenseNotRecurring if it is a one-time expense.
+func (h *healthService) AddExpenseOccurrence(ctx context.Context, userID, expenseID string, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error) {
+	if h.occurrences == nil {
+		return nil, fmt.Errorf("expense occurrences are not enabled")
+	}
+
+	expense, err := h.getOwnedExpense(ctx, userID, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if !expense.IsRecurring {
+		return nil, domain.ErrExpenseNotRecurring
+	}
+
+	if err := occurrence.Validate(); err != nil {
+		return nil, fmt.Errorf("occurrence validation failed: %w", err)
+	}
+	occurrence.InheritFrom(expense)
+
+	created, err := h.occurrences.Create(ctx, occurrence)
+	if err != nil {
+		return nil, err
+	}
+	h.summaryCache.invalidate(userID)
+	return created, nil
+}
+
+// GetExpenseOccurrences returns the occurrences recorded for one of the user's medical expenses,
+// oldest first. Returns domain.ErrMedicalExpenseNotFound if the expense doesn't belong to the user.
+func (h *healthService) GetExpenseOccurrences(ctx context.Context, userID, expenseID string) ([]domain.MedicalExpenseOccurrence, error) {
+	if h.occurrences == nil {
+		return nil, fmt.Errorf("expense occurrences are not enabled")
+	}
+
+	if _, err := h.getOwnedExpense(ctx, userID, expenseID); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := h.occurrences.GetByExpenseID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.MedicalExpenseOccurrence, len(occurrences))
+	for i, occurrence := range occurrences {
+		result[i] = *occurrence
+	}
+	return result, nil
+}
+
+// DeleteExpense deletes one of the user's medical expenses along with its occurrences. Deductible
+// progress already applied to a policy is left as it is.
+func (h *healthService) DeleteExpense(ctx context.Context, userID, expenseID string) error {
+	if _, err := h.getOwnedExpense(ctx, userID, expenseID); err != nil {
+		return err
+	}
+
+	err := h.expenseRepo.Delete(ctx, expenseID)
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceMedicalExpense, expenseID)
+	return nil
+}
+
+// getOwnedExpense retrieves one of the user's medical expenses
+func (h *healthService) getOwnedExpense(ctx context.Context, userID, expenseID string) (*domain.MedicalExpense, error) {
+	expense, err := h.expenseRepo.GetByID(ctx, expenseID)
+	if err != nil {
+		return nil, err
+	}
+	if expense.UserID != userID {
+		return nil, domain.ErrMedicalExpenseNotFound
+	}
+	return expense, nil
+}
+
+// GetExpenseAnalytics breaks down the user's medical spending for the current calendar year
+// and tracks each active policy's progress toward its deductible and out-of-pocket maximum.
+// Totals come from aggregate queries; the deductible projection assumes spending continues
+// at the monthly run-rate of recurring expenses.
+func (h *healthService) GetExpenseAnalytics(ctx context.Context, userID string) (*MedicalExpenseAnalytics, error) {
+	now := time.Now()
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+
+	totals, err := h.expenseRepo.CalculateTotals(ctx, userID, yearStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate year-to-date totals: %w", err)
+	}
+
+	byCategory, err := h.expenseRepo.GetCategoryTotals(ctx, userID, yearStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate category totals: %w", err)
+	}
+
+	runRate, err := h.expenseRepo.GetMonthlyRecurringTotal(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate monthly run-rate: %w", err)
+	}
+
+	policies, err := h.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	analytics := &MedicalExpenseAnalytics{
+		UserID:             userID,
+		Year:               now.Year(),
+		YearToDateTotal:    totals.TotalAmount,
+		OutOfPocketTotal:   totals.TotalOutOfPocket,
+		InsurancePaidTotal: totals.TotalInsurancePaid,
+		ExpenseCount:       totals.ExpenseCount,
+		ByCategory:         byCategory,
+		MonthlyRunRate:     math.Round(runRate*100) / 100,
+		Policies:           make([]DeductibleProgress, len(policies)),
+	}
+	if analytics.ByCategory == nil {
+		analytics.ByCategory = []CategoryTotals{}
+	}
+
+	yearEnd := time.Date(now.Year(), time.December, 31, 23, 59, 59, 0, now.Location())
+	for i, policy := range policies {
+		analytics.Policies[i] = trackDeductibleProgress(policy, runRate, now, yearEnd)
+	}
+
+	return analytics, nil
+}
+
+// GetExpenseBreakdown splits all of the user's recorded medical expenses by category into what
+// insurance paid and what the user paid out of pocket
+func (h *healthService) GetExpenseBreakdown(ctx context.Context, userID string) (*MedicalExpenseBreakdown, error) {
+	expenses, err := h.GetExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	breakdown := h.costAnalyzer.BreakdownByCategory(expenses)
+	breakdown.UserID = userID
+	return breakdown, nil
+}
+
+// Medications
+func (h *healthService) AddMedicationSchedule(ctx context.Context, schedule *domain.MedicationSchedule) error {
+	if err := schedule.Validate(); err != nil {
+		return fmt.Errorf("medication schedule validation failed: %w", err)
+	}
+
+	// The condition must belong to the user adding the medication
+	condition, err := h.conditionRepo.GetByID(ctx, schedule.ConditionID)
+	if err != nil {
+		return fmt.Errorf("failed to get condition: %w", err)
+	}
+	if condition.UserID != schedule.UserID {
+		return fmt.Errorf("not authorized to add medication for this condition")
+	}
+
+	_, err = h.medicationRepo.Create(ctx, schedule)
+	return err
+}
+
+// GetUpcomingRefills returns medications that are overdue or due for refill within the given days,
+// soonest first
+func (h *healthService) GetUpcomingRefills(ctx context.Context, userID string, withinDays int) ([]UpcomingRefill, error) {
+	if withinDays < 0 {
+		return nil, fmt.Errorf("within days must be non-negative")
+	}
+
+	schedules, err := h.medicationRepo.GetActiveByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get medication schedules: %w", err)
+	}
+
+	now := time.Now()
+	refills := make([]UpcomingRefill, 0)
+	for _, schedule := range schedules {
+		if !schedule.IsDueWithin(now, withinDays) {
+			continue
+		}
+		refills = append(refills, UpcomingRefill{
+			Schedule:     *schedule,
+			DueDate:      schedule.NextRefillDate(),
+			DaysUntilDue: schedule.DaysUntilRefill(now),
+			IsOverdue:    schedule.IsOverdue(now),
+		})
+	}
+
+	sort.SliceStable(refills, func(i, j int) bool {
+		return refills[i].DueDate.Before(refills[j].DueDate)
+	})
+
+	return refills, nil
+}
+
+// Insurance policies
+func (h *healthService) AddInsurancePolicy(ctx context.Context, policy *domain.InsurancePolicy) error {
+	profileID, err := h.resolveMemberProfileID(ctx, policy.UserID, policy.ProfileID)
+	if err != nil {
+		return err
+	}
+	policy.ProfileID = profileID
+
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("policy validation failed: %w", err)
+	}
+
+	// Validate no overlapping policies of the same type
+	existingPolicies, err := h.policyRepo.GetByType(ctx, policy.UserID, policy.Type)
+	if err != nil {
+		return fmt.Errorf("failed to check existing policies: %w", err)
+	}
+
+	for _, existing := range existingPolicies {
+		if existing.IsActive && existing.ID != policy.ID {
+			// Check for date overlap
+			if policy.StartDate.Before(existing.EndDate) && policy.EndDate.After(existing.StartDate) {
+				return fmt.Errorf("policy overlaps with existing active %s policy %s", policy.Type, existing.PolicyNumber)
+			}
+		}
+	}
+
+	created, err := h.policyRepo.Create(ctx, policy)
+	h.summaryCache.invalidate(policy.UserID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionCreate, domain.AuditResourceInsurancePolicy, created.ID)
+	return nil
+}
+
+// DeleteInsurancePolicy deletes one of the user's policies. Medical expenses it covered keep the
+// insurance payments already applied to them.
+func (h *healthService) DeleteInsurancePolicy(ctx context.Context, userID, policyID string) error {
+	policy, err := h.policyRepo.GetByID(ctx, policyID)
+	if err != nil {
+		return fmt.Errorf("failed to get policy: %w", err)
+	}
+	if policy.UserID != userID {
+		return fmt.Errorf("not authorized to delete this policy")
+	}
+
+	err = h.policyRepo.Delete(ctx, policyID)
+	h.summaryCache.invalidate(userID)
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionDelete, domain.AuditResourceInsurancePolicy, policyID)
+	return nil
+}
+
+// GetPolicies returns all of the user's policies, including inactive and expired ones
+func (h *healthService) GetPolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
+	policies, err := h.policyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.InsurancePolicy, len(policies))
+	for i, policy := range policies {
+		result[i] = *policy
+	}
+	return result, nil
+}
+
+func (h *healthService) GetActivePolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
+	policies, err := h.policyRepo.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert from []*domain.InsurancePolicy to []domain.InsurancePolicy
+	result := make([]domain.InsurancePolicy, len(policies))
+	for i, policy := range policies {
+		result[i] = *policy
+	}
+	return result, nil
+}
+
+// GetExpiringPolicies returns active policies that end within the given days, soonest first.
+// Policies that have already ended or are inactive are left out.
+func (h *healthService) GetExpiringPolicies(ctx context.Context, userID string, withinDays int) ([]ExpiringPolicy, error) {
+	if withinDays < 0 {
+		return nil, fmt.Errorf("within days must be non-negative")
+	}
+
+	policies, err := h.policyRepo.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	now := time.Now()
+	expiring := make([]ExpiringPolicy, 0)
+	for _, policy := range policies {
+		if !policy.IsExpiringWithin(now, withinDays) {
+			continue
+		}
+		expiring = append(expiring, ExpiringPolicy{
+			Policy:          *policy,
+			DaysUntilExpiry: policy.DaysUntilExpiry(now),
+		})
+	}
+
+	sort.SliceStable(expiring, func(i, j int) bool {
+		return expiring[i].Policy.EndDate.Before(expiring[j].Policy.EndDate)
+	})
+
+	return expiring, nil
+}
+
+// GetOutOfPocketStatus reports how close one of the user's policies is to its out-of-pocket maximum
+func (h *healthService) GetOutOfPocketStatus(ctx context.Context, userID, policyID string) (*domain.OutOfPocketStatus, error) {
+	policy, err := h.policyRepo.GetByID(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy: %w", err)
+	}
+	if policy.UserID != userID {
+		return nil, fmt.Errorf("not authorized to view this policy")
+	}
+
+	status := h.insuranceEval.OutOfPocketStatus(policy)
+	return &status, nil
+}
+
+// UpdateDeductibleProgress adds amount to the policy's deductible met and out-of-pocket spending,
+// capping each at its limit. The policy is locked while it's read and updated, so concurrent
+// updates add up instead of overwriting each other.
+func (h *healthService) UpdateDeductibleProgress(ctx context.Context, policyID string, amount float64) error {
+	var policy *domain.InsurancePolicy
+	var newDeductibleMet float64
+	err := h.txManager.WithTx(ctx, func(ctx context.Context) error {
+		current, err := h.policyRepo.GetByIDForUpdate(ctx, policyID)
+		if err != nil {
+			return fmt.Errorf("failed to get policy: %w", err)
+		}
+		policy = current
+
+		var newOutOfPocketCurrent float64
+		newDeductibleMet, newOutOfPocketCurrent = policy.ProgressAfter(amount)
+		_, err = h.policyRepo.UpdateDeductibleProgress(ctx, policyID, newDeductibleMet, newOutOfPocketCurrent)
+		return err
+	})
+	if policy != nil {
+		h.summaryCache.invalidate(policy.UserID)
+	}
+	if err != nil {
+		return err
+	}
+	h.recordAudit(ctx, domain.AuditActionUpdate, domain.AuditResourceInsurancePolicy, policyID)
+	h.publishDeductibleMet(policy, newDeductibleMet)
+	return nil
+}
+
+// publishDeductibleMet publishes a deductible met event if newDeductibleMet reaches a deductible
+// the policy hadn't met yet
+func (h *healthService) publishDeductibleMet(policy *domain.InsurancePolicy, newDeductibleMet float64) {
+	if h.events == nil || policy.IsDeductibleMet() || newDeductibleMet < policy.Deductible {
+		return
+	}
+	h.events.Publish(domain.Event{
+		Type:   domain.EventDeductibleMet,
+		UserID: policy.UserID,
+		Data: map[string]interface{}{
+			"policy_id":      policy.ID,
+			"provider":       policy.Provider,
+			"deductible":     policy.Deductible,
+			"deductible_met": newDeductibleMet,
+		},
+	})
+}
+
+// ComparePolicies ranks policies by projected annual cost for the expected spend.
+// When no candidates are supplied the user's active policies are compared.
+func (h *healthService) ComparePolicies(ctx context.Context, userID string, candidates []domain.InsurancePolicy, expectedAnnualSpend float64) (*PolicyComparison, error) {
+	policies := candidates
+	if len(policies) == 0 {
+		active, err := h.GetActivePolicies(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get policies: %w", err)
+		}
+		policies = active
+	}
+
+	return h.insuranceEval.ComparePolicies(policies, expectedAnnualSpend)
+}
+
+// GetCoverageGaps loads the user's policies and expenses and analyzes their coverage gaps
+func (h *healthService) GetCoverageGaps(ctx context.Context, userID string) (*CoverageGapAnalysis, error) {
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	// All policies, not just active ones, are needed to find lapses between policy periods
+	policyPtrs, err := h.policyRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+	policies := make([]domain.InsurancePolicy, len(policyPtrs))
+	for i, policy := range policyPtrs {
+		policies[i] = *policy
+	}
+
+	expenses, err := h.GetExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	return h.insuranceEval.AnalyzeCoverageGaps(ctx, profile, policies, expenses)
+}
+
+// EvaluateInsuranceAdequacy assesses the user's active policies against their projected annual
+// medical expenses and their risk level
+func (h *healthService) EvaluateInsuranceAdequacy(ctx context.Context, userID string) (*InsuranceAdequacy, error) {
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	conditionPtrs, err := h.conditionRepo.GetByUserID(ctx, userID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conditions: %w", err)
+	}
+	// Costs cover the whole family, but only the owner's own conditions count towards their risk
+	conditions := make([]domain.MedicalCondition, len(conditionPtrs))
+	var selfConditions []domain.MedicalCondition
+	for i, condition := range conditionPtrs {
+		conditions[i] = *condition
+		if condition.ProfileID == profile.ID {
+			selfConditions = append(selfConditions, *condition)
+		}
+	}
+
+	expenses, err := h.GetExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	policies, err := h.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	riskLevel := h.riskCalc.DetermineRiskLevel(h.riskCalc.CalculateHealthRiskScore(profile, selfConditions))
+	projected := h.costAnalyzer.ProjectAnnualCosts(expenses, conditions)
+
+	adequacy, err := h.insuranceEval.EvaluateAdequacy(policies, projected, riskLevel)
+	if err != nil {
+		return nil, err
+	}
+	adequacy.UserID = userID
+	return adequacy, nil
+}
+
+// GetCostProjection projects the user's medical costs and insurance payments for the next 12 months
+func (h *healthService) GetCostProjection(ctx context.Context, userID string) (*AnnualCostProjection, error) {
+	base, cached, err := h.cachedSummaryBase(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if cached {
+		return h.costAnalyzer.ProjectAnnualCost(&base.self, base.expenses, base.policies)
+	}
+
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+
+	expenses, err := h.GetExpenses(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expenses: %w", err)
+	}
+
+	policies, err := h.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
 	}
+
+	return h.costAnalyzer.ProjectAnnualCost(profile, expenses, policies)
 }
 
-// Profile operations
-func (h *healthService) CreateProfile(ctx context.Context, profile *domain.HealthProfile) error {
-	// Check if user already has a profile (one per user constraint)
-	exists, err := h.profileRepo.ExistsByUserID(ctx, profile.UserID)
+// RecommendHSAContribution suggests a monthly pre-tax HSA contribution that covers the
+// user's projected annual medical expenses, capped at the contribution limit for their
+// coverage tier. Only users with an active health plan whose deductible meets the
+// high-deductible threshold are eligible; everyone else gets a zero recommendation.
+func (h *healthService) RecommendHSAContribution(ctx context.Context, userID string) (*HSARecommendation, error) {
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("error checking existing profile: %w", err)
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
 
-	if exists {
-		return fmt.Errorf("user already has a health profile")
+	policies, err := h.GetActivePolicies(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
 	}
 
-	// Validate the profile
-	if err := profile.Validate(); err != nil {
-		return fmt.Errorf("profile validation failed: %w", err)
+	tier, limit, minDeductible := HSACoverageSelfOnly, h.hsaLimits.SelfOnlyContributionLimit, h.hsaLimits.SelfOnlyMinDeductible
+	if profile.FamilySize > 1 {
+		tier, limit, minDeductible = HSACoverageFamily, h.hsaLimits.FamilyContributionLimit, h.hsaLimits.FamilyMinDeductible
 	}
 
-	// Calculate BMI before saving
-	bmi, err := profile.CalculateBMI()
+	recommendation := &HSARecommendation{
+		UserID:                  userID,
+		CoverageTier:            tier,
+		AnnualContributionLimit: limit,
+	}
+
+	// Prefer the plan with the highest deductible; it is the one an HSA pairs with
+	var qualifying *domain.InsurancePolicy
+	for i := range policies {
+		policy := &policies[i]
+		if policy.Type != "health" && policy.Type != "comprehensive" {
+			continue
+		}
+		if policy.Deductible < minDeductible {
+			continue
+		}
+		if qualifying == nil || policy.Deductible > qualifying.Deductible {
+			qualifying = policy
+		}
+	}
+	if qualifying == nil {
+		recommendation.Explanation = fmt.Sprintf(
+			"No active health plan has a deductible of at least %.2f, the minimum for a high-deductible plan with %s coverage, so HSA contributions are not allowed",
+			minDeductible, strings.ReplaceAll(tier, "_", "-"))
+		return recommendation, nil
+	}
+
+	projected, err := h.expenseRepo.GetAnnualProjectedExpenses(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("BMI calculation failed: %w", err)
+		return nil, fmt.Errorf("failed to project annual expenses: %w", err)
 	}
-	profile.BMI = bmi
 
-	_, err = h.profileRepo.Create(ctx, profile)
-	return err
+	recommendation.Eligible = true
+	recommendation.QualifyingPolicyID = qualifying.ID
+	recommendation.ProjectedAnnualExpenses = projected
+
+	// Out-of-pocket spending stops at the plan's maximum, so saving beyond it buys nothing
+	annual := projected
+	capReason := ""
+	if qualifying.OutOfPocketMax > 0 && annual > qualifying.OutOfPocketMax {
+		annual = qualifying.OutOfPocketMax
+		capReason = "capped at your plan's out-of-pocket maximum"
+	}
+	if annual > limit {
+		annual = limit
+		capReason = "capped at the annual contribution limit"
+	}
+
+	recommendation.RecommendedAnnualContribution = math.Round(annual*100) / 100
+	recommendation.RecommendedMonthlyContribution = math.Round(annual/12*100) / 100
+
+	switch {
+	case annual == 0:
+		recommendation.Explanation = "You are eligible for an HSA, but no medical expenses are projected for the next year"
+	case capReason != "":
+		recommendation.Explanation = fmt.Sprintf("Contribute %.2f per month to cover projected medical expenses of %.2f, %s",
+			recommendation.RecommendedMonthlyContribution, projected, capReason)
+	default:
+		recommendation.Explanation = fmt.Sprintf("Contribute %.2f per month to cover projected medical expenses of %.2f pre-tax",
+			recommendation.RecommendedMonthlyContribution, projected)
+	}
+
+	return recommendation, nil
 }
 
-func (h *healthService) GetProfile(ctx context.Context, userID string) (*domain.HealthProfile, error) {
-	return h.profileRepo.GetByUserID(ctx, userID)
+// GetRiskModel returns the model the risk calculator scores with
+func (h *healthService) GetRiskModel() domain.RiskModel {
+	return h.riskCalc.Model()
 }
 
-func (h *healthService) UpdateProfile(ctx context.Context, profile *domain.HealthProfile) error {
-	if err := profile.Validate(); err != nil {
-		return fmt.Errorf("profile validation failed: %w", err)
+// SnapshotRisk records the user's current risk score and level in their risk history
+func (h *healthService) SnapshotRisk(ctx context.Context, userID string) (*domain.RiskSnapshot, error) {
+	if h.riskSnapshots == nil {
+		return nil, fmt.Errorf("risk history is not enabled")
 	}
 
-	// Recalculate BMI before updating
-	bmi, err := profile.CalculateBMI()
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("BMI calculation failed: %w", err)
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
-	profile.BMI = bmi
 
-	_, err = h.profileRepo.Update(ctx, profile)
-	return err
+	score, err := h.currentRiskScore(ctx, profile)
+	if err != nil {
+		return nil, err
+	}
+	return h.saveRiskSnapshot(ctx, userID, score)
 }
 
-// Medical conditions
-func (h *healthService) AddCondition(ctx context.Context, condition *domain.MedicalCondition) error {
-	if err := condition.Validate(); err != nil {
-		return fmt.Errorf("condition validation failed: %w", err)
+// currentRiskScore scores a self profile with its owner's active conditions
+func (h *healthService) currentRiskScore(ctx context.Context, profile *domain.HealthProfile) (int, error) {
+	selfConditions, err := h.activeSelfConditions(ctx, profile)
+	if err != nil {
+		return 0, err
 	}
+	return h.riskCalc.CalculateHealthRiskScore(profile, selfConditions), nil
+}
 
-	// Calculate risk factor based on severity if not provided
-	if condition.RiskFactor == 0 {
-		condition.RiskFactor = h.calculateRiskFactorBySeverity(condition.Severity)
+// activeSelfConditions returns the active conditions of a self profile. Like the health summary,
+// only the owner's own conditions count towards their risk.
+func (h *healthService) activeSelfConditions(ctx context.Context, profile *domain.HealthProfile) ([]domain.MedicalCondition, error) {
+	conditions, err := h.conditionRepo.GetByUserID(ctx, profile.UserID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conditions: %w", err)
 	}
-
-	_, err := h.conditionRepo.Create(ctx, condition)
-	return err
+	var selfConditions []domain.MedicalCondition
+	for _, condition := range conditions {
+		if condition.ProfileID == profile.ID {
+			selfConditions = append(selfConditions, *condition)
+		}
+	}
+	return selfConditions, nil
 }
 
-func (h *healthService) GetConditions(ctx context.Context, userID string) ([]domain.MedicalCondition, error) {
-	conditions, err := h.conditionRepo.GetByUserID(ctx, userID, false) // Get all conditions
+// EvaluateRiskWhatIf scores the user's self profile and active conditions with the changes of
+// whatIf applied, without saving anything, and attributes the change in score to each factor.
+// The changes must pass the same validation as a real update, and an ID that isn't one of the
+// user's own active conditions is reported as not found, whoever it belongs to.
+func (h *healthService) EvaluateRiskWhatIf(ctx context.Context, userID string, whatIf RiskWhatIf) (*RiskWhatIfResult, error) {
+	profile, conditions, err := h.currentRiskFactors(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert from []*domain.MedicalCondition to []domain.MedicalCondition
-	result := make([]domain.MedicalCondition, len(conditions))
-	for i, condition := range conditions {
-		result[i] = *condition
+	hypotheticalProfile := *profile
+	if whatIf.Weight != nil {
+		hypotheticalProfile.Weight = *whatIf.Weight
+	}
+	if whatIf.Age != nil {
+		hypotheticalProfile.Age = *whatIf.Age
+	}
+	if whatIf.Weight != nil {
+		// A non-positive weight keeps the stored BMI until Validate rejects it
+		_ = hypotheticalProfile.UpdateBMI()
+	}
+	if err := hypotheticalProfile.Validate(); err != nil {
+		return nil, fmt.Errorf("profile validation failed: %w", err)
 	}
-	return result, nil
-}
 
-func (h *healthService) UpdateCondition(ctx context.Context, condition *domain.MedicalCondition) error {
-	if err := condition.Validate(); err != nil {
-		return fmt.Errorf("condition validation failed: %w", err)
+	hypotheticalConditions, err := applyConditionChanges(conditions, whatIf)
+	if err != nil {
+		return nil, err
+	}
+	for _, condition := range whatIf.AddConditions {
+		condition.ID = ""
+		condition.UserID = userID
+		condition.ProfileID = profile.ID
+		condition.IsActive = true
+		if condition.DiagnosedDate.IsZero() {
+			condition.DiagnosedDate = time.Now()
+		}
+		if err := condition.Validate(); err != nil {
+			return nil, fmt.Errorf("condition validation failed: %w", err)
+		}
+		hypotheticalConditions = append(hypotheticalConditions, condition)
 	}
 
-	_, err := h.conditionRepo.Update(ctx, condition)
-	return err
+	currentScore := h.riskCalc.CalculateHealthRiskScore(profile, conditions)
+	hypotheticalScore := h.riskCalc.CalculateHealthRiskScore(&hypotheticalProfile, hypotheticalConditions)
+	model := h.riskCalc.Model()
+
+	return &RiskWhatIfResult{
+		UserID:            userID,
+		CurrentScore:      currentScore,
+		CurrentLevel:      h.riskCalc.DetermineRiskLevel(currentScore),
+		HypotheticalScore: hypotheticalScore,
+		HypotheticalLevel: h.riskCalc.DetermineRiskLevel(hypotheticalScore),
+		ScoreChange:       hypotheticalScore - currentScore,
+		Factors: compareRiskFactors(
+			model.Breakdown(profile, conditions),
+			model.Breakdown(&hypotheticalProfile, hypotheticalConditions)),
+	}, nil
 }
 
-func (h *healthService) RemoveCondition(ctx context.Context, userID, conditionID string) error {
-	return h.conditionRepo.Delete(ctx, conditionID)
+// currentRiskFactors returns the user's self profile and its active conditions, from the cached
+// health summary's data when the cache is enabled
+func (h *healthService) currentRiskFactors(ctx context.Context, userID string) (*domain.HealthProfile, []domain.MedicalCondition, error) {
+	base, cached, err := h.cachedSummaryBase(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cached {
+		return &base.self, base.selfConditions, nil
+	}
+
+	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	conditions, err := h.activeSelfConditions(ctx, profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return profile, conditions, nil
 }
 
-// Medical expenses
-func (h *healthService) AddExpense(ctx context.Context, expense *domain.MedicalExpense) error {
-	if err := expense.Validate(); err != nil {
-		return fmt.Errorf("expense validation failed: %w", err)
+// applyConditionChanges returns a copy of conditions with the severity changes and removals of
+// whatIf applied. Returns a not found error for an ID that isn't in conditions.
+func applyConditionChanges(conditions []domain.MedicalCondition, whatIf RiskWhatIf) ([]domain.MedicalCondition, error) {
+	changed := append([]domain.MedicalCondition(nil), conditions...)
+	indexes := make(map[string]int, len(changed))
+	for i, condition := range changed {
+		indexes[condition.ID] = i
 	}
 
-	// Calculate out-of-pocket after insurance if covered
-	if expense.IsCovered && expense.InsurancePayment > 0 {
-		expense.OutOfPocket = expense.Amount - expense.InsurancePayment
-		if expense.OutOfPocket < 0 {
-			expense.OutOfPocket = 0
+	for _, change := range whatIf.SeverityChanges {
+		i, ok := indexes[change.ConditionID]
+		if !ok {
+			return nil, fmt.Errorf("condition %s not found", change.ConditionID)
+		}
+		changed[i].Severity = change.Severity
+		if err := changed[i].Validate(); err != nil {
+			return nil, fmt.Errorf("condition validation failed: %w", err)
 		}
-	} else {
-		expense.OutOfPocket = expense.Amount
 	}
 
-	_, err := h.expenseRepo.Create(ctx, expense)
-	return err
+	removed := make(map[string]bool, len(whatIf.RemoveConditionIDs))
+	for _, id := range whatIf.RemoveConditionIDs {
+		if _, ok := indexes[id]; !ok {
+			return nil, fmt.Errorf("condition %s not found", id)
+		}
+		removed[id] = true
+	}
+
+	kept := changed[:0]
+	for _, condition := range changed {
+		if !removed[condition.ID] {
+			kept = append(kept, condition)
+		}
+	}
+	return kept, nil
 }
 
-func (h *healthService) GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
-	expenses, err := h.expenseRepo.GetByUserID(ctx, userID)
+// compareRiskFactors pairs each current factor with the same factor of the hypothetical score;
+// a removed condition has none. Added conditions, which have no ID yet, come last.
+func compareRiskFactors(current, hypothetical []domain.RiskFactorPoints) []RiskFactorChange {
+	key := func(f domain.RiskFactorPoints) string { return f.Factor + ":" + f.ConditionID }
+
+	hypotheticalPoints := make(map[string]int, len(hypothetical))
+	var added []domain.RiskFactorPoints
+	for _, factor := range hypothetical {
+		if factor.Factor == domain.RiskFactorCondition && factor.ConditionID == "" {
+			added = append(added, factor)
+			continue
+		}
+		hypotheticalPoints[key(factor)] = factor.Points
+	}
+
+	changes := make([]RiskFactorChange, 0, len(current)+len(added))
+	for _, factor := range current {
+		points := hypotheticalPoints[key(factor)]
+		changes = append(changes, RiskFactorChange{
+			Factor:             factor.Factor,
+			ConditionID:        factor.ConditionID,
+			Name:               factor.Name,
+			CurrentPoints:      factor.Points,
+			HypotheticalPoints: points,
+			Change:             points - factor.Points,
+		})
+	}
+	for _, factor := range added {
+		changes = append(changes, RiskFactorChange{
+			Factor:             factor.Factor,
+			Name:               factor.Name,
+			HypotheticalPoints: factor.Points,
+			Change:             factor.Points,
+		})
+	}
+	return changes
+}
+
+// saveRiskSnapshot records score, and the level it falls in, as the user's risk now
+func (h *healthService) saveRiskSnapshot(ctx context.Context, userID string, score int) (*domain.RiskSnapshot, error) {
+	snapshot, err := h.riskSnapshots.Create(ctx, &domain.RiskSnapshot{
+		UserID:     userID,
+		RiskScore:  score,
+		RiskLevel:  h.riskCalc.DetermineRiskLevel(score),
+		RecordedAt: time.Now(),
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to save risk snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// riskRecalculationBatchSize is how many profiles a risk recalculation loads at a time
+const riskRecalculationBatchSize = 100
+
+// RecalculateAllRisk rescores every user's self profile, a page at a time, and snapshots the
+// scores that differ from the user's latest snapshot. Unchanged scores are not snapshotted again,
+// so running it repeatedly over unchanged data only updates the first time.
+func (h *healthService) RecalculateAllRisk(ctx context.Context) (*RiskRecalculation, error) {
+	if h.riskSnapshots == nil {
+		return nil, fmt.Errorf("risk history is not enabled")
 	}
 
-	// Convert from []*domain.MedicalExpense to []domain.MedicalExpense
-	result := make([]domain.MedicalExpense, len(expenses))
-	for i, expense := range expenses {
-		result[i] = *expense
+	result := &RiskRecalculation{}
+	var afterID uint
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		profiles, err := h.profileRepo.ListSelfProfiles(ctx, afterID, riskRecalculationBatchSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list profiles: %w", err)
+		}
+
+		for _, profile := range profiles {
+			updated, err := h.recalculateRisk(ctx, profile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to recalculate risk for user %s: %w", profile.UserID, err)
+			}
+			result.Processed++
+			if updated {
+				result.Updated++
+			}
+		}
+
+		if len(profiles) < riskRecalculationBatchSize {
+			return result, nil
+		}
+		id, err := strconv.ParseUint(profiles[len(profiles)-1].ID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid profile ID: %w", err)
+		}
+		afterID = uint(id)
 	}
-	return result, nil
 }
 
-func (h *healthService) GetRecurringExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
-	expenses, err := h.expenseRepo.GetRecurring(ctx, userID)
+// recalculateRisk rescores a self profile and snapshots the score if it differs from the latest
+// snapshot, reporting whether it did
+func (h *healthService) recalculateRisk(ctx context.Context, profile *domain.HealthProfile) (bool, error) {
+	score, err := h.currentRiskScore(ctx, profile)
 	if err != nil {
-		return nil, err
+		return false, err
 	}
 
-	// Convert from []*domain.MedicalExpense to []domain.MedicalExpense
-	result := make([]domain.MedicalExpense, len(expenses))
-	for i, expense := range expenses {
-		result[i] = *expense
+	latest, err := h.riskSnapshots.GetByUserID(ctx, profile.UserID, time.Time{}, 1)
+	if err != nil {
+		return false, fmt.Errorf("failed to get risk snapshots: %w", err)
 	}
-	return result, nil
+	if len(latest) > 0 && latest[0].RiskScore == score && latest[0].RiskLevel == h.riskCalc.DetermineRiskLevel(score) {
+		return false, nil
+	}
+
+	if _, err := h.saveRiskSnapshot(ctx, profile.UserID, score); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
-// Insurance policies
-func (h *healthService) AddInsurancePolicy(ctx context.Context, policy *domain.InsurancePolicy) error {
-	if err := policy.Validate(); err != nil {
-		return fmt.Errorf("policy validation failed: %w", err)
+// snapshotRiskAfterChange snapshots the risk after a change that may have moved it. It is best
+// effort: the change itself has already been saved, so a failed snapshot is not reported.
+func (h *healthService) snapshotRiskAfterChange(ctx context.Context, userID string) {
+	if h.riskSnapshots == nil {
+		return
 	}
+	_, _ = h.SnapshotRisk(ctx, userID)
+}
 
-	// Validate no overlapping policies of the same type
-	existingPolicies, err := h.policyRepo.GetByType(ctx, policy.UserID, policy.Type)
+// maxRiskHistoryPoints caps the number of snapshots returned in a risk history
+const maxRiskHistoryPoints = 100
+
+// GetRiskHistory returns the user's risk snapshots over the last months months, oldest first,
+// limited to the most recent maxRiskHistoryPoints, with every change of risk level between them
+func (h *healthService) GetRiskHistory(ctx context.Context, userID string, months int) (*RiskHistory, error) {
+	if months <= 0 {
+		return nil, fmt.Errorf("months must be positive")
+	}
+	if h.riskSnapshots == nil {
+		return nil, fmt.Errorf("risk history is not enabled")
+	}
+
+	since := time.Now().AddDate(0, -months, 0)
+	snapshots, err := h.riskSnapshots.GetByUserID(ctx, userID, since, maxRiskHistoryPoints)
 	if err != nil {
-		return fmt.Errorf("failed to check existing policies: %w", err)
+		return nil, fmt.Errorf("failed to get risk history: %w", err)
 	}
 
-	for _, existing := range existingPolicies {
-		if existing.IsActive && existing.ID != policy.ID {
-			// Check for date overlap
-			if policy.StartDate.Before(existing.EndDate) && policy.EndDate.After(existing.StartDate) {
-				return fmt.Errorf("policy overlaps with existing active %s policy %s", policy.Type, existing.PolicyNumber)
-			}
+	history := &RiskHistory{
+		UserID:      userID,
+		Months:      months,
+		Entries:     make([]domain.RiskSnapshot, 0, len(snapshots)),
+		Transitions: []RiskLevelTransition{},
+	}
+	for i, snapshot := range snapshots {
+		history.Entries = append(history.Entries, *snapshot)
+		if i > 0 && snapshots[i-1].RiskLevel != snapshot.RiskLevel {
+			history.Transitions = append(history.Transitions, RiskLevelTransition{
+				From: snapshots[i-1].RiskLevel,
+				To:   snapshot.RiskLevel,
+				At:   snapshot.RecordedAt,
+			})
 		}
 	}
-
-	_, err = h.policyRepo.Create(ctx, policy)
-	return err
+	if len(snapshots) > 0 {
+		history.ScoreChange = snapshots[len(snapshots)-1].RiskScore - snapshots[0].RiskScore
+	}
+	return history, nil
 }
 
-func (h *healthService) GetActivePolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
-	policies, err := h.policyRepo.GetActivePolicies(ctx, userID)
+// Calculations & Analysis
+func (h *healthService) CalculateHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, error) {
+	// The version is captured before loading any data so that a write racing
+	// with this computation prevents the result from being cached
+	cached, version, ok := h.summaryCache.get(userID)
+	if ok {
+		return cached, nil
+	}
+
+	summary, base, err := h.computeHealthSummary(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert from []*domain.InsurancePolicy to []domain.InsurancePolicy
-	result := make([]domain.InsurancePolicy, len(policies))
-	for i, policy := range policies {
-		result[i] = *policy
-	}
-	return result, nil
+	h.summaryCache.put(userID, version, summary, base)
+	return summary, nil
 }
 
-func (h *healthService) UpdateDeductibleProgress(ctx context.Context, policyID string, amount float64) error {
-	// Get current policy
-	policy, err := h.policyRepo.GetByID(ctx, policyID)
+// cachedSummaryBase returns the data behind the user's cached health summary, computing and
+// caching the summary on a miss. Returns false without loading anything when the cache is
+// disabled, so callers can load only the data they need instead.
+func (h *healthService) cachedSummaryBase(ctx context.Context, userID string) (*healthSummaryBase, bool, error) {
+	if !h.summaryCache.enabled() {
+		return nil, false, nil
+	}
+
+	// As in CalculateHealthSummary, the version is captured before loading any data
+	cached, version, ok := h.summaryCache.getBase(userID)
+	if ok {
+		return cached, true, nil
+	}
+
+	summary, base, err := h.computeHealthSummary(ctx, userID)
 	if err != nil {
-		return fmt.Errorf("failed to get policy: %w", err)
+		return nil, false, err
 	}
 
-	// Update deductible progress
-	newDeductibleMet := policy.DeductibleMet + amount
-	newOutOfPocketCurrent := policy.OutOfPocketCurrent + amount
+	h.summaryCache.put(userID, version, summary, base)
+	return base, true, nil
+}
 
-	_, err = h.policyRepo.UpdateDeductibleProgress(ctx, policyID, newDeductibleMet, newOutOfPocketCurrent)
-	return err
+// computeHealthSummary loads the user's health data and builds a fresh summary from it
+func (h *healthService) computeHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, *healthSummaryBase, error) {
+	base, err := h.loadSummaryBase(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	summary, err := h.buildHealthSummary(ctx, userID, base)
+	if err != nil {
+		return nil, nil, err
+	}
+	return summary, base, nil
 }
 
-// Calculations & Analysis
-func (h *healthService) CalculateHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, error) {
-	// Get user's health profile
-	profile, err := h.profileRepo.GetByUserID(ctx, userID)
+// loadSummaryBase loads the health data a summary is computed from
+func (h *healthService) loadSummaryBase(ctx context.Context, userID string) (*healthSummaryBase, error) {
+	// Get every profile on the account; the self profile is the one being scored
+	profiles, err := h.GetFamilyProfiles(ctx, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user profile: %w", err)
 	}
+	indexFor := familyMemberIndex(profiles)
+	var profile *domain.HealthProfile
+	for i := range profiles {
+		if profiles[i].IsSelf() {
+			profile = &profiles[i]
+			break
+		}
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("failed to get user profile: health %w for user %s", ErrProfileNotFound, userID)
+	}
 
 	// Get medical conditions (active only for calculations)
 	conditionPtrs, err := h.conditionRepo.GetByUserID(ctx, userID, true)
@@ -242,10 +1833,15 @@ func (h *healthService) CalculateHealthSummary(ctx context.Context, userID strin
 		return nil, fmt.Errorf("failed to get conditions: %w", err)
 	}
 
-	// Convert to value slice for compatibility
+	// Convert to value slice for compatibility. Costs cover the whole family, but only the
+	// owner's own conditions count towards their risk score.
 	conditions := make([]domain.MedicalCondition, len(conditionPtrs))
+	var selfConditions []domain.MedicalCondition
 	for i, condition := range conditionPtrs {
 		conditions[i] = *condition
+		if profiles[indexFor(condition.ProfileID)].IsSelf() {
+			selfConditions = append(selfConditions, *condition)
+		}
 	}
 
 	// Get medical expenses
@@ -272,13 +1868,73 @@ func (h *healthService) CalculateHealthSummary(ctx context.Context, userID strin
 		policies[i] = *policy
 	}
 
+	return &healthSummaryBase{
+		profiles:       profiles,
+		self:           *profile,
+		conditions:     conditions,
+		selfConditions: selfConditions,
+		expenses:       expenses,
+		policies:       policies,
+	}, nil
+}
+
+// buildHealthSummary computes the user's summary from their health data
+func (h *healthService) buildHealthSummary(ctx context.Context, userID string, base *healthSummaryBase) (*domain.HealthSummary, error) {
+	profiles, profile := base.profiles, &base.self
+	conditions, selfConditions := base.conditions, base.selfConditions
+	expenses, policies := base.expenses, base.policies
+	indexFor := familyMemberIndex(profiles)
+
 	// Calculate risk score
-	riskScore := h.riskCalc.CalculateHealthRiskScore(profile, conditions)
+	riskScore := h.riskCalc.CalculateHealthRiskScore(profile, selfConditions)
 
 	// Calculate costs
 	monthlyAverage := h.costAnalyzer.CalculateMonthlyAverage(expenses)
 	projectedAnnual := h.costAnalyzer.ProjectAnnualCosts(expenses, conditions)
 
+	// Prefer the recorded costs of recurring expenses over their projection for the months that
+	// have them: the variance replaces those months in the annual projection and is spread over
+	// the year in the monthly expenses
+	now := time.Now()
+	var occurrences []domain.MedicalExpenseOccurrence
+	var recurringVariance *domain.RecurringExpenseVariance
+	if h.occurrences != nil {
+		yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+		occurrencePtrs, err := h.occurrences.GetByUserID(ctx, userID, yearStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get expense occurrences: %w", err)
+		}
+		occurrences = make([]domain.MedicalExpenseOccurrence, len(occurrencePtrs))
+		for i, occurrence := range occurrencePtrs {
+			occurrences[i] = *occurrence
+		}
+
+		variance := h.costAnalyzer.ReconcileRecurringExpenses(expenses, occurrences, now)
+		recurringVariance = &variance
+		monthlyAverage += variance.Variance / 12
+		projectedAnnual += variance.Variance
+	}
+
+	// Break the monthly expenses down by the family member they were recorded for
+	memberExpenses := make([][]domain.MedicalExpense, len(profiles))
+	for _, expense := range expenses {
+		i := indexFor(expense.ProfileID)
+		memberExpenses[i] = append(memberExpenses[i], expense)
+	}
+	expenseBreakdown := make([]domain.MemberMedicalExpenses, len(profiles))
+	for i := range profiles {
+		memberMonthly := h.costAnalyzer.CalculateMonthlyAverage(memberExpenses[i])
+		if recurringVariance != nil {
+			memberMonthly += h.costAnalyzer.ReconcileRecurringExpenses(memberExpenses[i], occurrences, now).Variance / 12
+		}
+		expenseBreakdown[i] = domain.MemberMedicalExpenses{
+			ProfileID:              profiles[i].ID,
+			Name:                   profiles[i].Name,
+			RelationToOwner:        profiles[i].RelationToOwner,
+			MonthlyMedicalExpenses: memberMonthly,
+		}
+	}
+
 	// Calculate out-of-pocket costs
 	totalOutOfPocket := 0.0
 	for _, expense := range expenses {
@@ -295,9 +1951,11 @@ func (h *healthService) CalculateHealthSummary(ctx context.Context, userID strin
 	// Calculate insurance premiums and deductible info from policies
 	monthlyPremiums := 0.0
 	totalDeductibleRemaining := 0.0
-	for _, policy := range policies {
+	oopStatuses := make([]domain.OutOfPocketStatus, len(policies))
+	for i, policy := range policies {
 		monthlyPremiums += policy.MonthlyPremium
 		totalDeductibleRemaining += policy.GetRemainingDeductible()
+		oopStatuses[i] = h.insuranceEval.OutOfPocketStatus(&policies[i])
 	}
 
 	// Calculate priority adjustment based on health risk
@@ -321,14 +1979,42 @@ func (h *healthService) CalculateHealthSummary(ctx context.Context, userID strin
 		TotalHealthCosts:          monthlyAverage + monthlyPremiums,
 		CoverageGapRisk:           projectedAnnual - totalOutOfPocket,
 		RecommendedEmergencyFund:  emergencyFund,
+		EmergencyFundBalance:      profile.EmergencyFundHealth,
 		FinancialVulnerability:    financialVulnerability,
 		PriorityAdjustment:        priorityAdjustment,
+		MemberExpenses:            expenseBreakdown,
+		OutOfPocketStatuses:       oopStatuses,
+		RecurringVariance:         recurringVariance,
 		UpdatedAt:                 profile.UpdatedAt,
 	}
 
+	if h.events != nil {
+		h.publishRiskLevelChange(summary)
+	}
+
 	return summary, nil
 }
 
+// publishRiskLevelChange publishes a high risk event when the user's risk level rises
+// to high or critical since the last summary computed for them
+func (h *healthService) publishRiskLevelChange(summary *domain.HealthSummary) {
+	previous := h.riskLevels.swap(summary.UserID, summary.HealthRiskLevel)
+	if riskLevelRank(summary.HealthRiskLevel) < riskLevelRank("high") ||
+		riskLevelRank(summary.HealthRiskLevel) <= riskLevelRank(previous) {
+		return
+	}
+
+	h.events.Publish(domain.Event{
+		Type:   domain.EventHighRiskDetected,
+		UserID: summary.UserID,
+		Data: map[string]interface{}{
+			"previous_risk_level": previous,
+			"risk_level":          summary.HealthRiskLevel,
+			"risk_score":          summary.HealthRiskScore,
+		},
+	})
+}
+
 // GetHealthContext prepares health data for Decision domain
 // TODO: Implement when domain.HealthContext is available
 /*
@@ -375,6 +2061,67 @@ func (h *healthService) GetHealthContext(ctx context.Context, userID string) (*d
 }
 */
 
+// trackDeductibleProgress reports how much of a policy's deductible and out-of-pocket
+// maximum is met and projects when the deductible will be met at monthlyRunRate.
+// The plan year ends at yearEnd, or at the policy's end date if that is earlier.
+func trackDeductibleProgress(policy domain.InsurancePolicy, monthlyRunRate float64, asOf, yearEnd time.Time) DeductibleProgress {
+	progress := DeductibleProgress{
+		PolicyID:             policy.ID,
+		Provider:             policy.Provider,
+		Type:                 policy.Type,
+		Deductible:           policy.Deductible,
+		DeductibleMet:        math.Min(policy.DeductibleMet, policy.Deductible),
+		DeductibleRemaining:  policy.GetRemainingDeductible(),
+		OutOfPocketMax:       policy.OutOfPocketMax,
+		OutOfPocketMet:       math.Min(policy.OutOfPocketCurrent, policy.OutOfPocketMax),
+		OutOfPocketRemaining: policy.GetRemainingOutOfPocket(),
+	}
+
+	if progress.DeductibleRemaining <= 0 {
+		progress.WillMeetDeductibleThisYear = true
+		return progress
+	}
+
+	deadline := yearEnd
+	if !policy.EndDate.IsZero() && policy.EndDate.Before(deadline) {
+		deadline = policy.EndDate
+	}
+	if month, ok := projectThresholdMonth(progress.DeductibleRemaining, monthlyRunRate, asOf, deadline); ok {
+		progress.ProjectedDeductibleMetMonth = &month
+		progress.WillMeetDeductibleThisYear = true
+	}
+
+	return progress
+}
+
+// projectThresholdMonth returns the first day of the month in which spending monthlyRunRate
+// each month, starting next month, adds up to remaining. It returns false if the run-rate is
+// zero or the threshold wouldn't be reached by the deadline.
+func projectThresholdMonth(remaining, monthlyRunRate float64, asOf, deadline time.Time) (time.Time, bool) {
+	if monthlyRunRate <= 0 {
+		return time.Time{}, false
+	}
+
+	// The small tolerance keeps exact multiples from rounding up a month
+	months := int(math.Ceil(remaining/monthlyRunRate - 1e-9))
+	if months < 1 {
+		months = 1
+	}
+
+	month := time.Date(asOf.Year(), asOf.Month()+time.Month(months), 1, 0, 0, 0, 0, asOf.Location())
+	if month.After(deadline) {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// recordAudit logs an action on a health record. Health records hold medical detail that
+// must not end up in the audit log, so unlike the other services no before and after
+// snapshots are recorded, only the action and the reference to the record.
+func (h *healthService) recordAudit(ctx context.Context, action, resourceType, resourceID string) {
+	h.audit.Record(ctx, action, resourceType, resourceID, nil, nil)
+}
+
 // Helper methods
 func (h *healthService) calculateRiskFactorBySeverity(severity string) float64 {
 	switch severity {
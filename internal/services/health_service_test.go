@@ -2,6 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -66,6 +71,30 @@ func (m *MockHealthProfileRepository) ExistsByUserID(ctx context.Context, userID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockHealthProfileRepository) GetFamilyByUserID(ctx context.Context, userID string) ([]*domain.HealthProfile, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HealthProfile), args.Error(1)
+}
+
+func (m *MockHealthProfileRepository) ListSelfProfiles(ctx context.Context, afterID uint, limit int) ([]*domain.HealthProfile, error) {
+	args := m.Called(ctx, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.HealthProfile), args.Error(1)
+}
+
+func (m *MockHealthProfileRepository) GetSnapshots(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.ProfileSnapshot, error) {
+	args := m.Called(ctx, userID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ProfileSnapshot), args.Error(1)
+}
+
 type MockMedicalConditionRepository struct {
 	mock.Mock
 }
@@ -238,6 +267,14 @@ func (m *MockMedicalExpenseRepository) CalculateTotals(ctx context.Context, user
 	return args.Get(0).(*ExpenseTotals), args.Error(1)
 }
 
+func (m *MockMedicalExpenseRepository) GetCategoryTotals(ctx context.Context, userID string, startDate, endDate time.Time) ([]CategoryTotals, error) {
+	args := m.Called(ctx, userID, startDate, endDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]CategoryTotals), args.Error(1)
+}
+
 func (m *MockMedicalExpenseRepository) GetMonthlyRecurringTotal(ctx context.Context, userID string) (float64, error) {
 	args := m.Called(ctx, userID)
 	return args.Get(0).(float64), args.Error(1)
@@ -268,6 +305,14 @@ func (m *MockInsurancePolicyRepository) GetByID(ctx context.Context, id string)
 	return args.Get(0).(*domain.InsurancePolicy), args.Error(1)
 }
 
+func (m *MockInsurancePolicyRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InsurancePolicy), args.Error(1)
+}
+
 func (m *MockInsurancePolicyRepository) Update(ctx context.Context, policy *domain.InsurancePolicy) (*domain.InsurancePolicy, error) {
 	args := m.Called(ctx, policy)
 	if args.Get(0) == nil {
@@ -297,8 +342,8 @@ func (m *MockInsurancePolicyRepository) GetByType(ctx context.Context, userID st
 	return args.Get(0).([]*domain.InsurancePolicy), args.Error(1)
 }
 
-func (m *MockInsurancePolicyRepository) GetByPolicyNumber(ctx context.Context, policyNumber string) (*domain.InsurancePolicy, error) {
-	args := m.Called(ctx, policyNumber)
+func (m *MockInsurancePolicyRepository) GetByPolicyNumber(ctx context.Context, userID, policyNumber string) (*domain.InsurancePolicy, error) {
+	args := m.Called(ctx, userID, policyNumber)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -345,6 +390,64 @@ func (m *MockInsurancePolicyRepository) GetPoliciesByProvider(ctx context.Contex
 	return args.Get(0).([]*domain.InsurancePolicy), args.Error(1)
 }
 
+type MockConditionCatalogRepository struct {
+	mock.Mock
+}
+
+func (m *MockConditionCatalogRepository) List(ctx context.Context) ([]domain.ConditionCatalogEntry, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.ConditionCatalogEntry), args.Error(1)
+}
+
+func (m *MockConditionCatalogRepository) GetByID(ctx context.Context, id string) (domain.ConditionCatalogEntry, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ConditionCatalogEntry), args.Error(1)
+}
+
+type MockMedicationScheduleRepository struct {
+	mock.Mock
+}
+
+func (m *MockMedicationScheduleRepository) Create(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error) {
+	args := m.Called(ctx, schedule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MedicationSchedule), args.Error(1)
+}
+
+func (m *MockMedicationScheduleRepository) GetByID(ctx context.Context, id string) (*domain.MedicationSchedule, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MedicationSchedule), args.Error(1)
+}
+
+func (m *MockMedicationScheduleRepository) Update(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error) {
+	args := m.Called(ctx, schedule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.MedicationSchedule), args.Error(1)
+}
+
+func (m *MockMedicationScheduleRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMedicationScheduleRepository) GetActiveByUserID(ctx context.Context, userID string) ([]*domain.MedicationSchedule, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.MedicationSchedule), args.Error(1)
+}
+
 type MockRiskCalculator struct {
 	mock.Mock
 }
@@ -369,6 +472,11 @@ func (m *MockRiskCalculator) DetermineRiskLevel(score int) string {
 	return args.String(0)
 }
 
+func (m *MockRiskCalculator) Model() domain.RiskModel {
+	args := m.Called()
+	return args.Get(0).(domain.RiskModel)
+}
+
 type MockMedicalCostAnalyzer struct {
 	mock.Mock
 }
@@ -378,11 +486,21 @@ func (m *MockMedicalCostAnalyzer) CalculateMonthlyAverage(expenses []domain.Medi
 	return args.Get(0).(float64)
 }
 
+func (m *MockMedicalCostAnalyzer) NormalizeMedicalExpenseToMonthly(expense domain.MedicalExpense) (float64, error) {
+	args := m.Called(expense)
+	return args.Get(0).(float64), args.Error(1)
+}
+
 func (m *MockMedicalCostAnalyzer) ProjectAnnualCosts(expenses []domain.MedicalExpense, conditions []domain.MedicalCondition) float64 {
 	args := m.Called(expenses, conditions)
 	return args.Get(0).(float64)
 }
 
+func (m *MockMedicalCostAnalyzer) ReconcileRecurringExpenses(expenses []domain.MedicalExpense, occurrences []domain.MedicalExpenseOccurrence, now time.Time) domain.RecurringExpenseVariance {
+	args := m.Called(expenses, occurrences, now)
+	return args.Get(0).(domain.RecurringExpenseVariance)
+}
+
 func (m *MockMedicalCostAnalyzer) IdentifyCostReductionOpportunities(expenses []domain.MedicalExpense) []CostReductionOpportunity {
 	args := m.Called(expenses)
 	if args.Get(0) == nil {
@@ -399,6 +517,19 @@ func (m *MockMedicalCostAnalyzer) AnalyzeTrends(expenses []domain.MedicalExpense
 	return args.Get(0).([]string)
 }
 
+func (m *MockMedicalCostAnalyzer) BreakdownByCategory(expenses []domain.MedicalExpense) *MedicalExpenseBreakdown {
+	args := m.Called(expenses)
+	return args.Get(0).(*MedicalExpenseBreakdown)
+}
+
+func (m *MockMedicalCostAnalyzer) ProjectAnnualCost(profile *domain.HealthProfile, expenses []domain.MedicalExpense, policies []domain.InsurancePolicy) (*AnnualCostProjection, error) {
+	args := m.Called(profile, expenses, policies)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*AnnualCostProjection), args.Error(1)
+}
+
 // Test cases
 func TestHealthService_CreateProfile_Success(t *testing.T) {
 	// Arrange
@@ -414,8 +545,10 @@ func TestHealthService_CreateProfile_Success(t *testing.T) {
 		mockConditionRepo,
 		mockExpenseRepo,
 		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
 		mockRiskCalc,
 		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
 	)
 
 	profile := &domain.HealthProfile{
@@ -456,8 +589,10 @@ func TestHealthService_CreateProfile_UserAlreadyHasProfile(t *testing.T) {
 		mockConditionRepo,
 		mockExpenseRepo,
 		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
 		mockRiskCalc,
 		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
 	)
 
 	profile := &domain.HealthProfile{
@@ -476,7 +611,7 @@ func TestHealthService_CreateProfile_UserAlreadyHasProfile(t *testing.T) {
 	err := service.CreateProfile(context.Background(), profile)
 
 	// Assert
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrProfileAlreadyExists)
 	assert.Contains(t, err.Error(), "user already has a health profile")
 	mockProfileRepo.AssertExpectations(t)
 }
@@ -495,8 +630,10 @@ func TestHealthService_CalculateHealthSummary_Success(t *testing.T) {
 		mockConditionRepo,
 		mockExpenseRepo,
 		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
 		mockRiskCalc,
 		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
 	)
 
 	userID := "user123"
@@ -536,21 +673,23 @@ func TestHealthService_CalculateHealthSummary_Success(t *testing.T) {
 
 	policies := []*domain.InsurancePolicy{
 		{
-			ID:             "pol1",
-			UserID:         userID,
-			MonthlyPremium: 300.0,
-			Deductible:     1500.0,
-			DeductibleMet:  500.0,
+			ID:                 "pol1",
+			UserID:             userID,
+			MonthlyPremium:     300.0,
+			Deductible:         1500.0,
+			DeductibleMet:      500.0,
+			OutOfPocketMax:     4000.0,
+			OutOfPocketCurrent: 3800.0,
 		},
 	}
 
 	// Set expectations
-	mockProfileRepo.On("GetByUserID", mock.Anything, userID).Return(profile, nil)
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil)
 	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return(conditions, nil)
 	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return(expenses, nil)
 	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return(policies, nil)
 
-	mockRiskCalc.On("CalculateHealthRiskScore", profile, mock.AnythingOfType("[]domain.MedicalCondition")).Return(35)
+	mockRiskCalc.On("CalculateHealthRiskScore", mock.AnythingOfType("*domain.HealthProfile"), mock.AnythingOfType("[]domain.MedicalCondition")).Return(35)
 	mockRiskCalc.On("DetermineRiskLevel", 35).Return("moderate")
 	mockRiskCalc.On("AssessFinancialVulnerability", mock.AnythingOfType("float64"), mock.AnythingOfType("float64")).Return("moderate")
 	mockRiskCalc.On("RecommendEmergencyFund", 35, mock.AnythingOfType("float64")).Return(15000.0)
@@ -572,6 +711,10 @@ func TestHealthService_CalculateHealthSummary_Success(t *testing.T) {
 	assert.Equal(t, 15000.0, summary.RecommendedEmergencyFund)
 	assert.Equal(t, "moderate", summary.FinancialVulnerability)
 	assert.Greater(t, summary.PriorityAdjustment, 1.0, "Priority adjustment should be > 1.0 for moderate risk")
+	require.Len(t, summary.OutOfPocketStatuses, 1)
+	assert.Equal(t, "pol1", summary.OutOfPocketStatuses[0].PolicyID)
+	assert.Equal(t, 95.0, summary.OutOfPocketStatuses[0].PercentReached)
+	assert.True(t, summary.OutOfPocketStatuses[0].NearMax)
 
 	mockProfileRepo.AssertExpectations(t)
 	mockConditionRepo.AssertExpectations(t)
@@ -595,8 +738,10 @@ func TestHealthService_AddCondition_Success(t *testing.T) {
 		mockConditionRepo,
 		mockExpenseRepo,
 		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
 		mockRiskCalc,
 		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
 	)
 
 	condition := &domain.MedicalCondition{
@@ -612,6 +757,9 @@ func TestHealthService_AddCondition_Success(t *testing.T) {
 	}
 
 	// Set expectations
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "profile123", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
 	mockConditionRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *domain.MedicalCondition) bool {
 		return c.Name == "Diabetes" && c.RiskFactor > 0
 	})).Return(condition, nil)
@@ -625,6 +773,112 @@ func TestHealthService_AddCondition_Success(t *testing.T) {
 	mockConditionRepo.AssertExpectations(t)
 }
 
+func TestHealthService_AddCondition_FromCatalog(t *testing.T) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockCatalog := &MockConditionCatalogRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithConditionCatalog(mockCatalog),
+	)
+
+	mockCatalog.On("GetByID", mock.Anything, "E11").Return(domain.ConditionCatalogEntry{
+		ID: "E11", Name: "Type 2 Diabetes", Category: "chronic", DefaultSeverity: "moderate", RiskWeight: 8,
+	}, nil)
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "profile123", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
+
+	condition := &domain.MedicalCondition{
+		UserID:        "user123",
+		ProfileID:     "profile123",
+		CatalogID:     "E11",
+		DiagnosedDate: time.Now().AddDate(-1, 0, 0),
+		IsActive:      true,
+	}
+	mockConditionRepo.On("Create", mock.Anything, condition).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.MedicalCondition).ID = "cond1"
+	}).Return(condition, nil)
+
+	err := service.AddCondition(context.Background(), condition)
+
+	require.NoError(t, err)
+	assert.Equal(t, "cond1", condition.ID)
+	assert.Equal(t, "Type 2 Diabetes", condition.Name)
+	assert.Equal(t, "chronic", condition.Category)
+	assert.Equal(t, "moderate", condition.Severity)
+	assert.Equal(t, 8, condition.CatalogRiskWeight)
+	mockCatalog.AssertExpectations(t)
+}
+
+func TestHealthService_AddCondition_UnknownCatalogEntry(t *testing.T) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockCatalog := &MockConditionCatalogRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithConditionCatalog(mockCatalog),
+	)
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "profile123", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
+	mockCatalog.On("GetByID", mock.Anything, "Z99").Return(domain.ConditionCatalogEntry{},
+		fmt.Errorf("%w: Z99", domain.ErrConditionCatalogEntryNotFound))
+
+	err := service.AddCondition(context.Background(), &domain.MedicalCondition{
+		UserID:    "user123",
+		ProfileID: "profile123",
+		CatalogID: "Z99",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrConditionCatalogEntryNotFound)
+	mockConditionRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_SearchConditionCatalog(t *testing.T) {
+	mockCatalog := &MockConditionCatalogRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithConditionCatalog(mockCatalog),
+	)
+
+	mockCatalog.On("List", mock.Anything).Return([]domain.ConditionCatalogEntry{
+		{ID: "I10", Name: "Hypertension", Category: "chronic"},
+		{ID: "E11", Name: "Type 2 Diabetes", Category: "chronic"},
+		{ID: "E10", Name: "Type 1 Diabetes", Category: "chronic"},
+	}, nil)
+
+	matches, err := service.SearchConditionCatalog(context.Background(), "diab", 1)
+
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "E10", matches[0].Entry.ID)
+}
+
 func TestHealthService_AddInsurancePolicy_OverlapValidation(t *testing.T) {
 	// Arrange
 	mockProfileRepo := &MockHealthProfileRepository{}
@@ -639,8 +893,10 @@ func TestHealthService_AddInsurancePolicy_OverlapValidation(t *testing.T) {
 		mockConditionRepo,
 		mockExpenseRepo,
 		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
 		mockRiskCalc,
 		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
 	)
 
 	// Existing active policy
@@ -655,14 +911,23 @@ func TestHealthService_AddInsurancePolicy_OverlapValidation(t *testing.T) {
 
 	// New policy that overlaps
 	newPolicy := &domain.InsurancePolicy{
-		UserID:    "user123",
-		Type:      "health",
-		IsActive:  true,
-		StartDate: time.Now().AddDate(0, -3, 0), // 3 months ago (overlaps)
-		EndDate:   time.Now().AddDate(1, 6, 0),  // 1.5 years from now
+		UserID:             "user123",
+		Provider:           "Aetna",
+		PolicyNumber:       "AET-2",
+		Type:               "health",
+		MonthlyPremium:     300,
+		Deductible:         1000,
+		OutOfPocketMax:     4000,
+		CoveragePercentage: 80,
+		IsActive:           true,
+		StartDate:          time.Now().AddDate(0, -3, 0), // 3 months ago (overlaps)
+		EndDate:            time.Now().AddDate(1, 6, 0),  // 1.5 years from now
 	}
 
 	// Set expectations
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "profile123", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
 	mockPolicyRepo.On("GetByType", mock.Anything, "user123", "health").Return([]*domain.InsurancePolicy{existingPolicy}, nil)
 
 	// Act
@@ -672,4 +937,1894 @@ func TestHealthService_AddInsurancePolicy_OverlapValidation(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "policy overlaps")
 	mockPolicyRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestHealthService_GetUpcomingRefills_DueSoonOverdueAndNotYetDue(t *testing.T) {
+	// Arrange
+	mockMedicationRepo := &MockMedicationScheduleRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		mockMedicationRepo,
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	now := time.Now()
+	schedules := []*domain.MedicationSchedule{
+		{ID: "1", UserID: userID, ConditionID: "1", MedicationName: "Not yet due", RefillEveryDays: 30, LastFilledDate: now.AddDate(0, 0, -5), IsActive: true},
+		{ID: "2", UserID: userID, ConditionID: "1", MedicationName: "Due soon", RefillEveryDays: 30, LastFilledDate: now.AddDate(0, 0, -27), IsActive: true},
+		{ID: "3", UserID: userID, ConditionID: "2", MedicationName: "Overdue", RefillEveryDays: 30, LastFilledDate: now.AddDate(0, 0, -34), IsActive: true},
+	}
+	mockMedicationRepo.On("GetActiveByUserID", mock.Anything, userID).Return(schedules, nil)
+
+	// Act
+	refills, err := service.GetUpcomingRefills(context.Background(), userID, 7)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, refills, 2)
+
+	assert.Equal(t, "Overdue", refills[0].Schedule.MedicationName)
+	assert.True(t, refills[0].IsOverdue)
+	assert.Equal(t, -4, refills[0].DaysUntilDue)
+
+	assert.Equal(t, "Due soon", refills[1].Schedule.MedicationName)
+	assert.False(t, refills[1].IsOverdue)
+	assert.Equal(t, 3, refills[1].DaysUntilDue)
+
+	mockMedicationRepo.AssertExpectations(t)
+}
+
+func TestHealthService_GetExpiringPolicies_WithinWindowOnly(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	now := time.Now()
+	start := now.AddDate(-1, 0, 0)
+	policies := []*domain.InsurancePolicy{
+		{ID: "1", UserID: userID, PolicyNumber: "LATER", StartDate: start, EndDate: now.AddDate(0, 0, 90), IsActive: true},
+		{ID: "2", UserID: userID, PolicyNumber: "SOON", StartDate: start, EndDate: now.AddDate(0, 0, 20), IsActive: true},
+		{ID: "3", UserID: userID, PolicyNumber: "INACTIVE", StartDate: start, EndDate: now.AddDate(0, 0, 10), IsActive: false},
+		{ID: "4", UserID: userID, PolicyNumber: "EXPIRED", StartDate: start, EndDate: now.AddDate(0, 0, -2), IsActive: true},
+	}
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return(policies, nil)
+
+	// Act
+	expiring, err := service.GetExpiringPolicies(context.Background(), userID, 30)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, expiring, 1)
+	assert.Equal(t, "SOON", expiring[0].Policy.PolicyNumber)
+	assert.Equal(t, 20, expiring[0].DaysUntilExpiry)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_GetOutOfPocketStatus_OwnPolicyOnly(t *testing.T) {
+	// Arrange
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	policy := &domain.InsurancePolicy{ID: "7", UserID: "user123", PolicyNumber: "POL-7", OutOfPocketMax: 2000, OutOfPocketCurrent: 500}
+	mockPolicyRepo.On("GetByID", mock.Anything, "7").Return(policy, nil)
+
+	// Act
+	status, err := service.GetOutOfPocketStatus(context.Background(), "user123", "7")
+	_, otherErr := service.GetOutOfPocketStatus(context.Background(), "other-user", "7")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, status.PercentReached)
+	assert.Equal(t, 1500.0, status.Remaining)
+	assert.False(t, status.NearMax)
+	require.Error(t, otherErr)
+	assert.Contains(t, otherErr.Error(), "not authorized")
+}
+
+func TestHealthService_AddMedicationSchedule_RejectsOtherUsersCondition(t *testing.T) {
+	// Arrange
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockMedicationRepo := &MockMedicationScheduleRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		mockMedicationRepo,
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	schedule := &domain.MedicationSchedule{
+		UserID:          "user123",
+		ConditionID:     "7",
+		MedicationName:  "Metformin",
+		RefillEveryDays: 30,
+		LastFilledDate:  time.Now().AddDate(0, 0, -1),
+		IsActive:        true,
+	}
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(&domain.MedicalCondition{ID: "7", UserID: "someone-else"}, nil)
+
+	// Act
+	err := service.AddMedicationSchedule(context.Background(), schedule)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorized")
+	mockMedicationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+
+func TestHealthService_GetProfileHistory_EndsWithCurrentProfile(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	now := time.Now()
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 35, Gender: "male", Height: 180, Weight: 78, BMI: 24.07, FamilySize: 1, UpdatedAt: now,
+	}
+	snapshots := []*domain.ProfileSnapshot{
+		{ID: "1", UserID: userID, ProfileID: "1", Age: 34, Height: 180, Weight: 84, BMI: 25.93, RecordedAt: now.AddDate(0, -6, 0)},
+		{ID: "2", UserID: userID, ProfileID: "1", Age: 35, Height: 180, Weight: 81, BMI: 25.0, RecordedAt: now.AddDate(0, -2, 0)},
+	}
+	mockProfileRepo.On("GetByUserID", mock.Anything, userID).Return(profile, nil)
+	mockProfileRepo.On("GetSnapshots", mock.Anything, userID, mock.AnythingOfType("time.Time"), maxProfileHistoryPoints).Return(snapshots, nil)
+
+	// Act
+	history, err := service.GetProfileHistory(context.Background(), userID, 12)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, history.Points, 3)
+	assert.Equal(t, 78.0, history.Points[2].Weight)
+	assert.Equal(t, now, history.Points[2].RecordedAt)
+	assert.Equal(t, -6.0, history.WeightChange)
+	assert.Equal(t, -1.86, history.BMIChange)
+	mockProfileRepo.AssertExpectations(t)
+}
+
+func TestHealthService_GetProfileHistory_InvalidMonths(t *testing.T) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	_, err := service.GetProfileHistory(context.Background(), "user123", 0)
+
+	assert.Error(t, err)
+	mockProfileRepo.AssertNotCalled(t, "GetByUserID", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_CreateDependentProfile_RequiresSelfProfile(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	dependent := &domain.HealthProfile{
+		UserID: "user123", Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26,
+	}
+	mockProfileRepo.On("ExistsByUserID", mock.Anything, "user123").Return(false, nil)
+
+	// Act
+	err := service.CreateDependentProfile(context.Background(), dependent)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "before adding dependents")
+	mockProfileRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_CreateDependentProfile_RejectsSelfRelation(t *testing.T) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	err := service.CreateDependentProfile(context.Background(), &domain.HealthProfile{
+		UserID: "user123", RelationToOwner: domain.RelationSelf, Age: 35, Gender: "male", Height: 180, Weight: 75, FamilySize: 1,
+	})
+
+	assert.Error(t, err)
+	mockProfileRepo.AssertNotCalled(t, "ExistsByUserID", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_GetFamilyHealthRollup_SelfAndTwoDependents(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockRiskCalc := &MockRiskCalculator{}
+	mockCostAnalyzer := &MockMedicalCostAnalyzer{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		mockRiskCalc,
+		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: userID, RelationToOwner: domain.RelationSelf, Age: 40, Gender: "female", Height: 168, Weight: 62, FamilySize: 3},
+		{ID: "2", UserID: userID, Name: "Alex", RelationToOwner: domain.RelationSpouse, Age: 42, Gender: "male", Height: 182, Weight: 90, FamilySize: 1},
+		{ID: "3", UserID: userID, Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1},
+	}
+	conditions := []*domain.MedicalCondition{
+		{ID: "c1", UserID: userID, ProfileID: "1", Name: "Migraine", Severity: "mild", IsActive: true},
+		{ID: "c2", UserID: userID, ProfileID: "3", Name: "Asthma", Severity: "moderate", IsActive: true},
+		{ID: "c3", UserID: userID, ProfileID: "99", Name: "Legacy", Severity: "mild", IsActive: true}, // matches no profile
+	}
+	expenses := []*domain.MedicalExpense{
+		{ID: "e1", UserID: userID, ProfileID: "1", Amount: 100},
+		{ID: "e2", UserID: userID, ProfileID: "2", Amount: 50},
+		{ID: "e3", UserID: userID, ProfileID: "3", Amount: 30},
+	}
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return(profiles, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return(conditions, nil)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return(expenses, nil)
+
+	memberScores := map[string]int{"1": 20, "2": 10, "3": 45}
+	memberMonthly := map[string]float64{"1": 100, "2": 50, "3": 30}
+	for profileID, score := range memberScores {
+		id := profileID
+		mockRiskCalc.On("CalculateHealthRiskScore", mock.MatchedBy(func(p *domain.HealthProfile) bool { return p.ID == id }), mock.Anything).Return(score)
+		mockCostAnalyzer.On("CalculateMonthlyAverage", mock.MatchedBy(func(e []domain.MedicalExpense) bool {
+			return len(e) == 1 && e[0].ProfileID == id
+		})).Return(memberMonthly[id])
+		mockCostAnalyzer.On("ProjectAnnualCosts", mock.MatchedBy(func(e []domain.MedicalExpense) bool {
+			return len(e) == 1 && e[0].ProfileID == id
+		}), mock.Anything).Return(memberMonthly[id] * 12)
+	}
+	mockRiskCalc.On("DetermineRiskLevel", mock.Anything).Return("moderate")
+
+	// Act
+	rollup, err := service.GetFamilyHealthRollup(context.Background(), userID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, rollup.Members, 3)
+
+	self, spouse, child := rollup.Members[0], rollup.Members[1], rollup.Members[2]
+	assert.Equal(t, domain.RelationSelf, self.RelationToOwner)
+	assert.Equal(t, 2, self.ActiveConditions, "conditions matching no profile belong to the self profile")
+	assert.Equal(t, "Alex", spouse.Name)
+	assert.Equal(t, 0, spouse.ActiveConditions)
+	assert.Equal(t, 45, child.HealthRiskScore)
+	assert.Equal(t, 1, child.ActiveConditions)
+
+	assert.Equal(t, 45, rollup.HighestRiskScore)
+	assert.Equal(t, 25.0, rollup.AverageRiskScore)
+	assert.Equal(t, 180.0, rollup.TotalMonthlyMedicalCost)
+	assert.Equal(t, 2160.0, rollup.TotalProjectedAnnualCost)
+	mockRiskCalc.AssertExpectations(t)
+	mockCostAnalyzer.AssertExpectations(t)
+}
+
+func TestHealthService_CalculateHealthSummary_ScoresOwnerAndBreaksDownFamilyExpenses(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	mockRiskCalc := &MockRiskCalculator{}
+	mockCostAnalyzer := &MockMedicalCostAnalyzer{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		mockRiskCalc,
+		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: userID, RelationToOwner: domain.RelationSelf, Age: 40, Gender: "female", Height: 168, Weight: 62, FamilySize: 2},
+		{ID: "2", UserID: userID, Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1},
+	}
+	conditions := []*domain.MedicalCondition{
+		{ID: "c1", UserID: userID, ProfileID: "1", Name: "Migraine", Severity: "mild", IsActive: true},
+		{ID: "c2", UserID: userID, ProfileID: "2", Name: "Asthma", Severity: "severe", IsActive: true},
+	}
+	expenses := []*domain.MedicalExpense{
+		{ID: "e1", UserID: userID, ProfileID: "1", Amount: 100},
+		{ID: "e2", UserID: userID, ProfileID: "2", Amount: 60},
+	}
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return(profiles, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return(conditions, nil)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return(expenses, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil)
+
+	mockRiskCalc.On("CalculateHealthRiskScore", mock.MatchedBy(func(p *domain.HealthProfile) bool { return p.ID == "1" }),
+		mock.MatchedBy(func(c []domain.MedicalCondition) bool { return len(c) == 1 && c[0].ProfileID == "1" })).Return(20)
+	mockRiskCalc.On("DetermineRiskLevel", 20).Return("low")
+	mockRiskCalc.On("AssessFinancialVulnerability", mock.Anything, mock.Anything).Return("secure")
+	mockRiskCalc.On("RecommendEmergencyFund", 20, mock.Anything).Return(1000.0)
+
+	mockCostAnalyzer.On("CalculateMonthlyAverage", mock.MatchedBy(func(e []domain.MedicalExpense) bool { return len(e) == 2 })).Return(160.0)
+	mockCostAnalyzer.On("ProjectAnnualCosts", mock.MatchedBy(func(e []domain.MedicalExpense) bool { return len(e) == 2 }),
+		mock.MatchedBy(func(c []domain.MedicalCondition) bool { return len(c) == 2 })).Return(1920.0)
+	for _, expense := range expenses {
+		profileID := expense.ProfileID
+		mockCostAnalyzer.On("CalculateMonthlyAverage", mock.MatchedBy(func(e []domain.MedicalExpense) bool {
+			return len(e) == 1 && e[0].ProfileID == profileID
+		})).Return(expense.Amount)
+	}
+
+	// Act
+	summary, err := service.CalculateHealthSummary(context.Background(), userID)
+
+	// Assert - the child's severe condition doesn't affect the owner's score, but their costs count
+	require.NoError(t, err)
+	assert.Equal(t, 20, summary.HealthRiskScore)
+	assert.Equal(t, 160.0, summary.MonthlyMedicalExpenses)
+	require.Len(t, summary.MemberExpenses, 2)
+	assert.Equal(t, "1", summary.MemberExpenses[0].ProfileID)
+	assert.Equal(t, domain.RelationSelf, summary.MemberExpenses[0].RelationToOwner)
+	assert.Equal(t, 100.0, summary.MemberExpenses[0].MonthlyMedicalExpenses)
+	assert.Equal(t, "Sam", summary.MemberExpenses[1].Name)
+	assert.Equal(t, 60.0, summary.MemberExpenses[1].MonthlyMedicalExpenses)
+	assert.Equal(t, domain.RelationChild, summary.MemberExpenses[1].RelationToOwner)
+	mockRiskCalc.AssertExpectations(t)
+	mockCostAnalyzer.AssertExpectations(t)
+}
+
+func TestHealthService_AddExpense_FamilyMemberOwnership(t *testing.T) {
+	userID := "user123"
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: userID, RelationToOwner: domain.RelationSelf},
+		{ID: "2", UserID: userID, Name: "Sam", RelationToOwner: domain.RelationChild},
+	}
+
+	tests := []struct {
+		name          string
+		profileID     string
+		wantProfileID string
+		wantErr       string
+	}{
+		{name: "defaults to owner", profileID: "", wantProfileID: "1"},
+		{name: "own dependent", profileID: "2", wantProfileID: "2"},
+		{name: "another user's member", profileID: "7", wantErr: "not authorized"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockProfileRepo := &MockHealthProfileRepository{}
+			mockExpenseRepo := &MockMedicalExpenseRepository{}
+			service := NewHealthService(
+				mockProfileRepo,
+				&MockMedicalConditionRepository{},
+				mockExpenseRepo,
+				&MockInsurancePolicyRepository{},
+				&MockMedicationScheduleRepository{},
+				NewRiskCalculator(domain.DefaultRiskModel()),
+				NewMedicalCostAnalyzer(),
+				NewInsuranceEvaluator(),
+			)
+
+			mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return(profiles, nil)
+			mockExpenseRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+
+			expense := &domain.MedicalExpense{
+				UserID: userID, ProfileID: tt.profileID, Amount: 40, Category: "doctor_visit", Description: "Checkup",
+				Frequency: "one_time", Date: time.Now(),
+			}
+
+			// Act
+			err := service.AddExpense(context.Background(), expense)
+
+			// Assert
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				mockExpenseRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantProfileID, expense.ProfileID)
+		})
+	}
+}
+
+// txMarker is set on the context by markingTxManager so mocks can check a call ran in the transaction
+type txMarker struct{}
+
+// markingTxManager runs fn with a context marked as inside a transaction
+type markingTxManager struct{}
+
+func (markingTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txMarker{}, true))
+}
+
+func inTx(ctx context.Context) bool {
+	return ctx.Value(txMarker{}) != nil
+}
+
+func setupCoveredExpenseService(policies []*domain.InsurancePolicy) (HealthService, *MockMedicalExpenseRepository, *MockInsurancePolicyRepository) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+		WithHealthTxManager(markingTxManager{}),
+	)
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, "user123").Return(policies, nil)
+	for _, policy := range policies {
+		mockPolicyRepo.On("GetByIDForUpdate", mock.MatchedBy(inTx), policy.ID).Return(policy, nil).Maybe()
+	}
+	return service, mockExpenseRepo, mockPolicyRepo
+}
+
+func TestHealthService_AddExpense_AppliesCoveringPolicy(t *testing.T) {
+	// Arrange: an 80% policy whose deductible is already met
+	policy := &domain.InsurancePolicy{
+		ID: "pol1", UserID: "user123", ProfileID: "1", Type: "health", IsActive: true,
+		Deductible: 1500, DeductibleMet: 1500, OutOfPocketMax: 6000, OutOfPocketCurrent: 1500,
+		CoveragePercentage: 80,
+		StartDate:          time.Now().AddDate(0, -6, 0),
+		EndDate:            time.Now().AddDate(0, 6, 0),
+	}
+	service, mockExpenseRepo, mockPolicyRepo := setupCoveredExpenseService([]*domain.InsurancePolicy{policy})
+
+	mockExpenseRepo.On("Create", mock.MatchedBy(inTx), mock.MatchedBy(func(expense *domain.MedicalExpense) bool {
+		return expense.InsurancePayment == 800 && expense.OutOfPocket == 200
+	})).Return(&domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("UpdateDeductibleProgress", mock.MatchedBy(inTx), "pol1", 1500.0, 1700.0).Return(policy, nil)
+
+	// The client's insurance payment is replaced by the policy's
+	expense := &domain.MedicalExpense{
+		UserID: "user123", Amount: 1000, Category: "hospital", Description: "ER visit",
+		Frequency: "one_time", IsCovered: true, InsurancePayment: 50, Date: time.Now().AddDate(0, 0, -2),
+	}
+
+	// Act
+	err := service.AddExpense(context.Background(), expense)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 800.0, expense.InsurancePayment)
+	assert.Equal(t, 200.0, expense.OutOfPocket)
+	mockExpenseRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_AddExpense_AppliesDeductibleFirst(t *testing.T) {
+	// Arrange: 300 of a 500 deductible is left
+	policy := &domain.InsurancePolicy{
+		ID: "pol1", UserID: "user123", ProfileID: "1", Type: "comprehensive", IsActive: true,
+		Deductible: 500, DeductibleMet: 200, OutOfPocketMax: 4000, OutOfPocketCurrent: 200,
+		CoveragePercentage: 80,
+		StartDate:          time.Now().AddDate(0, -6, 0),
+		EndDate:            time.Now().AddDate(0, 6, 0),
+	}
+	service, mockExpenseRepo, mockPolicyRepo := setupCoveredExpenseService([]*domain.InsurancePolicy{policy})
+
+	mockExpenseRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("UpdateDeductibleProgress", mock.Anything, "pol1", 500.0, 640.0).Return(policy, nil)
+
+	expense := &domain.MedicalExpense{
+		UserID: "user123", Amount: 1000, Category: "hospital", Description: "Surgery",
+		Frequency: "one_time", IsCovered: true, Date: time.Now(),
+	}
+
+	// Act
+	err := service.AddExpense(context.Background(), expense)
+
+	// Assert: 300 toward the deductible, then 80% of the other 700
+	require.NoError(t, err)
+	assert.InDelta(t, 560.0, expense.InsurancePayment, 0.001)
+	assert.InDelta(t, 440.0, expense.OutOfPocket, 0.001)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_AddExpense_NoCoveringPolicy_KeepsClientPayment(t *testing.T) {
+	// Arrange: dental policies don't cover medical expenses
+	dental := &domain.InsurancePolicy{
+		ID: "pol2", UserID: "user123", ProfileID: "1", Type: "dental", IsActive: true,
+		Deductible: 100, OutOfPocketMax: 1000, CoveragePercentage: 80,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	service, mockExpenseRepo, mockPolicyRepo := setupCoveredExpenseService([]*domain.InsurancePolicy{dental})
+	mockExpenseRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+
+	expense := &domain.MedicalExpense{
+		UserID: "user123", Amount: 1000, Category: "hospital", Description: "ER visit",
+		Frequency: "one_time", IsCovered: true, InsurancePayment: 600, Date: time.Now(),
+	}
+
+	// Act
+	err := service.AddExpense(context.Background(), expense)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 600.0, expense.InsurancePayment)
+	assert.Equal(t, 400.0, expense.OutOfPocket)
+	mockPolicyRepo.AssertNotCalled(t, "UpdateDeductibleProgress", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHealthService_AddExpense_FamilyMemberUsesOwnPolicy(t *testing.T) {
+	// Arrange: the owner and their child each have a health policy, and the child a vision plan
+	ownerPolicy := &domain.InsurancePolicy{
+		ID: "pol1", UserID: "user123", ProfileID: "1", Type: "health", IsActive: true,
+		Deductible: 1500, OutOfPocketMax: 6000, CoveragePercentage: 80,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	childVision := &domain.InsurancePolicy{
+		ID: "pol2", UserID: "user123", ProfileID: "2", Type: "vision", IsActive: true,
+		Deductible: 50, OutOfPocketMax: 500, CoveragePercentage: 90,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	childPolicy := &domain.InsurancePolicy{
+		ID: "pol3", UserID: "user123", ProfileID: "2", Type: "health", IsActive: true,
+		Deductible: 500, OutOfPocketMax: 3000, CoveragePercentage: 80,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+		WithHealthTxManager(markingTxManager{}),
+	)
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf},
+		{ID: "2", UserID: "user123", RelationToOwner: domain.RelationChild},
+	}, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, "user123").
+		Return([]*domain.InsurancePolicy{ownerPolicy, childVision, childPolicy}, nil)
+	mockPolicyRepo.On("GetByIDForUpdate", mock.MatchedBy(inTx), "pol3").Return(childPolicy, nil)
+	mockExpenseRepo.On("Create", mock.MatchedBy(inTx), mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("UpdateDeductibleProgress", mock.MatchedBy(inTx), "pol3", 500.0, 600.0).Return(childPolicy, nil)
+
+	expense := &domain.MedicalExpense{
+		UserID: "user123", ProfileID: "2", Amount: 1000, Category: "hospital", Description: "Broken arm",
+		Frequency: "one_time", IsCovered: true, Date: time.Now(),
+	}
+
+	// Act
+	err := service.AddExpense(context.Background(), expense)
+
+	// Assert: only the child's health policy moves; 500 deductible, then 80% of the other 500
+	require.NoError(t, err)
+	assert.InDelta(t, 400.0, expense.InsurancePayment, 0.001)
+	assert.InDelta(t, 600.0, expense.OutOfPocket, 0.001)
+	mockPolicyRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertNumberOfCalls(t, "UpdateDeductibleProgress", 1)
+}
+
+func TestHealthService_AddExpense_PolicyUpdateFails_ReturnsError(t *testing.T) {
+	// Arrange
+	policy := &domain.InsurancePolicy{
+		ID: "pol1", UserID: "user123", ProfileID: "1", Type: "health", IsActive: true,
+		Deductible: 0, OutOfPocketMax: 6000, CoveragePercentage: 80,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	service, mockExpenseRepo, mockPolicyRepo := setupCoveredExpenseService([]*domain.InsurancePolicy{policy})
+	mockExpenseRepo.On("Create", mock.MatchedBy(inTx), mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("UpdateDeductibleProgress", mock.MatchedBy(inTx), "pol1", 0.0, 200.0).Return(nil, errors.New("database error"))
+
+	expense := &domain.MedicalExpense{
+		UserID: "user123", Amount: 1000, Category: "hospital", Description: "ER visit",
+		Frequency: "one_time", IsCovered: true, Date: time.Now(),
+	}
+
+	// Act
+	err := service.AddExpense(context.Background(), expense)
+
+	// Assert: the error reaches the transaction, which rolls back the expense too
+	assert.EqualError(t, err, "database error")
+}
+
+// serialTxManager runs one transaction at a time, like transactions contending for the same
+// locked policy row
+type serialTxManager struct {
+	mu *sync.Mutex
+}
+
+func (m serialTxManager) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return fn(context.WithValue(ctx, txMarker{}, true))
+}
+
+// statefulPolicyRepository keeps one policy's progress so concurrent updates build on each other
+type statefulPolicyRepository struct {
+	*MockInsurancePolicyRepository
+	mu     sync.Mutex
+	policy domain.InsurancePolicy
+}
+
+func (r *statefulPolicyRepository) GetByIDForUpdate(ctx context.Context, id string) (*domain.InsurancePolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	policy := r.policy
+	return &policy, nil
+}
+
+func (r *statefulPolicyRepository) UpdateDeductibleProgress(ctx context.Context, policyID string, deductibleMet, outOfPocketCurrent float64) (*domain.InsurancePolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy.DeductibleMet = deductibleMet
+	r.policy.OutOfPocketCurrent = outOfPocketCurrent
+	policy := r.policy
+	return &policy, nil
+}
+
+func TestHealthService_AddExpense_ConcurrentCoveredExpenses_StayWithinCaps(t *testing.T) {
+	// Arrange
+	setupTestLogger()
+	policy := domain.InsurancePolicy{
+		ID: "pol1", UserID: "user123", ProfileID: "1", Type: "health", IsActive: true,
+		Deductible: 1500, OutOfPocketMax: 3000, CoveragePercentage: 80,
+		StartDate: time.Now().AddDate(0, -6, 0),
+		EndDate:   time.Now().AddDate(0, 6, 0),
+	}
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	policyRepo := &statefulPolicyRepository{MockInsurancePolicyRepository: &MockInsurancePolicyRepository{}, policy: policy}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		policyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+		WithHealthTxManager(serialTxManager{mu: &sync.Mutex{}}),
+	)
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf},
+	}, nil)
+	// Every request sees the policy as it was before any of them
+	policyRepo.On("GetActivePolicies", mock.Anything, "user123").Return([]*domain.InsurancePolicy{&policy}, nil)
+	mockExpenseRepo.On("Create", mock.MatchedBy(inTx), mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+
+	// Act - ten $1000 expenses at once; the deductible and out-of-pocket maximum are reached midway
+	const requests = 10
+	expenses := make([]*domain.MedicalExpense, requests)
+	results := make(chan error, requests)
+	for i := range expenses {
+		expenses[i] = &domain.MedicalExpense{
+			UserID: "user123", Amount: 1000, Category: "hospital", Description: "Treatment",
+			Frequency: "one_time", IsCovered: true, Date: time.Now(),
+		}
+		go func(expense *domain.MedicalExpense) {
+			results <- service.AddExpense(context.Background(), expense)
+		}(expenses[i])
+	}
+
+	// Assert
+	for i := 0; i < requests; i++ {
+		assert.NoError(t, <-results)
+	}
+
+	final, err := policyRepo.GetByIDForUpdate(context.Background(), "pol1")
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, final.DeductibleMet, "the deductible is counted once")
+	assert.InDelta(t, 3000.0, final.OutOfPocketCurrent, 0.001, "out-of-pocket stops at the maximum")
+
+	totalOutOfPocket := 0.0
+	for _, expense := range expenses {
+		assert.InDelta(t, expense.Amount, expense.InsurancePayment+expense.OutOfPocket, 0.001)
+		totalOutOfPocket += expense.OutOfPocket
+	}
+	assert.InDelta(t, final.OutOfPocketCurrent, totalOutOfPocket, 0.001, "each expense's share adds up to the policy's progress")
+	mockExpenseRepo.AssertNumberOfCalls(t, "Create", requests)
+}
+
+func TestHealthService_UpdateAndDeleteDependentProfile(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: userID, RelationToOwner: domain.RelationSelf, Age: 40, Gender: "female", Height: 168, Weight: 62, FamilySize: 2},
+		{ID: "2", UserID: userID, Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1},
+	}
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return(profiles, nil)
+	mockProfileRepo.On("Update", mock.Anything, mock.MatchedBy(func(p *domain.HealthProfile) bool {
+		return p.ID == "2" && p.Age == 9 && p.BMI > 0
+	})).Return(profiles[1], nil)
+	mockProfileRepo.On("Delete", mock.Anything, uint(2)).Return(nil)
+
+	// Act & Assert - the self profile isn't a family member
+	_, err := service.GetFamilyMember(context.Background(), userID, "1")
+	assert.ErrorContains(t, err, "family member 1 not found")
+
+	updated := *profiles[1]
+	updated.Age = 9
+	require.NoError(t, service.UpdateDependentProfile(context.Background(), &updated))
+
+	err = service.UpdateDependentProfile(context.Background(), &domain.HealthProfile{
+		ID: "9", UserID: userID, Name: "Kim", RelationToOwner: domain.RelationSpouse, Age: 40, Gender: "male", Height: 180, Weight: 80, FamilySize: 1,
+	})
+	assert.ErrorContains(t, err, "family member 9 not found")
+
+	require.NoError(t, service.DeleteDependentProfile(context.Background(), userID, "2"))
+	assert.ErrorContains(t, service.DeleteDependentProfile(context.Background(), userID, "1"), "not found")
+	mockProfileRepo.AssertExpectations(t)
+}
+
+func TestHealthService_RemoveCondition_ResolvesInsteadOfDeleting(t *testing.T) {
+	// Arrange
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	condition := &domain.MedicalCondition{
+		ID: "7", UserID: "user123", ProfileID: "1", Name: "Bronchitis", Category: "acute", Severity: "mild",
+		DiagnosedDate: time.Now().AddDate(0, -1, 0), IsActive: true,
+	}
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(condition, nil)
+	mockConditionRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.MedicalCondition) bool {
+		return !c.IsActive && c.ResolvedDate != nil
+	})).Return(condition, nil)
+
+	// Act
+	err := service.RemoveCondition(context.Background(), "user123", "7")
+
+	// Assert
+	require.NoError(t, err)
+	mockConditionRepo.AssertExpectations(t)
+	mockConditionRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_DeleteCondition_DeletesRecord(t *testing.T) {
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	condition := &domain.MedicalCondition{ID: "7", UserID: "user123", Name: "Bronchitis", IsActive: true}
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(condition, nil)
+	mockConditionRepo.On("Delete", mock.Anything, "7").Return(nil)
+
+	require.NoError(t, service.DeleteCondition(context.Background(), "user123", "7"))
+	assert.Error(t, service.DeleteCondition(context.Background(), "someone-else", "7"))
+
+	mockConditionRepo.AssertNumberOfCalls(t, "Delete", 1)
+}
+
+func TestHealthService_RemoveCondition_RecordsAudit(t *testing.T) {
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	recorder := &recordingAuditRecorder{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithHealthAuditRecorder(recorder),
+	)
+
+	condition := &domain.MedicalCondition{ID: "7", UserID: "user123", Name: "Bronchitis", IsActive: true}
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(condition, nil)
+	mockConditionRepo.On("Update", mock.Anything, mock.Anything).Return(condition, nil)
+
+	require.NoError(t, service.RemoveCondition(WithRequestUser(context.Background(), "user123"), "user123", "7"))
+
+	require.Len(t, recorder.records, 1)
+	record := recorder.records[0]
+	assert.Equal(t, "user123", record.UserID)
+	assert.Equal(t, domain.AuditActionDelete, record.Action)
+	assert.Equal(t, domain.AuditResourceMedicalCondition, record.ResourceType)
+	assert.Equal(t, "7", record.ResourceID)
+	// Only the reference is logged, never the condition itself
+	assert.Empty(t, record.Changes)
+}
+
+func TestHealthService_DeleteDependentProfile_RecordsAuditWithoutMedicalDetail(t *testing.T) {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	recorder := &recordingAuditRecorder{}
+	service := NewHealthService(
+		mockProfileRepo,
+		&MockMedicalConditionRepository{},
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithHealthAuditRecorder(recorder),
+	)
+
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf, Age: 40, Gender: "female", Height: 168, Weight: 62, FamilySize: 2},
+		{ID: "2", UserID: "user123", Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1},
+	}
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return(profiles, nil)
+	mockProfileRepo.On("Delete", mock.Anything, uint(2)).Return(nil)
+
+	require.NoError(t, service.DeleteDependentProfile(WithRequestUser(context.Background(), "user123"), "user123", "2"))
+
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, recordedAudit{
+		UserID:       "user123",
+		Action:       domain.AuditActionDelete,
+		ResourceType: domain.AuditResourceHealthProfile,
+		ResourceID:   "2",
+		Changes:      map[string]domain.AuditChange{},
+	}, recorder.records[0])
+}
+
+func TestHealthService_RemoveCondition_OtherUsersCondition(t *testing.T) {
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(&domain.MedicalCondition{ID: "7", UserID: "someone-else", IsActive: true}, nil)
+
+	err := service.RemoveCondition(context.Background(), "user123", "7")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not authorized")
+	mockConditionRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestHealthService_UpdateCondition_SetsAndClearsResolvedDate(t *testing.T) {
+	// Arrange
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	diagnosed := time.Now().AddDate(0, -3, 0)
+	existing := &domain.MedicalCondition{
+		ID: "7", UserID: "user123", ProfileID: "1", Name: "Back pain", Category: "acute", Severity: "moderate",
+		DiagnosedDate: diagnosed, IsActive: true,
+	}
+	mockConditionRepo.On("GetByID", mock.Anything, "7").Return(existing, nil)
+	mockConditionRepo.On("Update", mock.Anything, mock.AnythingOfType("*domain.MedicalCondition")).Return(existing, nil)
+
+	// Act - resolve, then reactivate
+	resolved := &domain.MedicalCondition{ID: "7", UserID: "user123", Name: "Back pain", Category: "acute", Severity: "moderate", IsActive: false}
+	require.NoError(t, service.UpdateCondition(context.Background(), resolved))
+
+	reactivated := &domain.MedicalCondition{ID: "7", UserID: "user123", Name: "Back pain", Category: "acute", Severity: "moderate", IsActive: true}
+	require.NoError(t, service.UpdateCondition(context.Background(), reactivated))
+
+	// Assert
+	require.NotNil(t, resolved.ResolvedDate)
+	assert.Equal(t, "1", resolved.ProfileID, "profile is carried over from the stored condition")
+	assert.Equal(t, diagnosed, resolved.DiagnosedDate)
+	assert.Nil(t, reactivated.ResolvedDate)
+}
+
+func TestHealthService_GetConditionTimeline_OrdersAndSeparatesByStatus(t *testing.T) {
+	// Arrange
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	now := time.Now()
+	resolvedAt := now.AddDate(0, -6, 0)
+	conditions := []*domain.MedicalCondition{
+		{ID: "1", UserID: userID, Name: "Asthma", DiagnosedDate: now.AddDate(-2, 0, 0), IsActive: true},
+		{ID: "2", UserID: userID, Name: "Fracture", DiagnosedDate: now.AddDate(-1, 0, 0), ResolvedDate: &resolvedAt, IsActive: false},
+		{ID: "3", UserID: userID, Name: "Hypertension", DiagnosedDate: now.AddDate(-5, 0, 0), IsActive: true},
+		{ID: "4", UserID: userID, Name: "Flu", DiagnosedDate: now.AddDate(-3, 0, 0), ResolvedDate: &resolvedAt, IsActive: false},
+	}
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, false).Return(conditions, nil)
+
+	// Act
+	timeline, err := service.GetConditionTimeline(context.Background(), userID)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, timeline.Active, 2)
+	require.Len(t, timeline.Resolved, 2)
+
+	assert.Equal(t, "Hypertension", timeline.Active[0].Condition.Name)
+	assert.Equal(t, "Asthma", timeline.Active[1].Condition.Name)
+	assert.Equal(t, "Flu", timeline.Resolved[0].Condition.Name)
+	assert.Equal(t, "Fracture", timeline.Resolved[1].Condition.Name)
+
+	assert.Equal(t, ConditionStatusActive, timeline.Active[0].Status)
+	assert.Len(t, timeline.Active[0].StatusHistory, 1)
+	assert.Equal(t, timeline.Active[0].DaysSinceDiagnosis, timeline.Active[0].DurationDays)
+
+	fracture := timeline.Resolved[1]
+	assert.Equal(t, ConditionStatusResolved, fracture.Status)
+	require.Len(t, fracture.StatusHistory, 2)
+	assert.Equal(t, ConditionStatusResolved, fracture.StatusHistory[1].Status)
+	assert.Equal(t, resolvedAt, fracture.StatusHistory[1].Date)
+	assert.Less(t, fracture.DurationDays, fracture.DaysSinceDiagnosis, "resolved conditions stop accruing duration")
+}
+
+func TestHealthService_RecommendHSAContribution(t *testing.T) {
+	hdhp := &domain.InsurancePolicy{ID: "10", UserID: "user123", Type: "health", Deductible: 3000, OutOfPocketMax: 7000, IsActive: true}
+	lowDeductible := &domain.InsurancePolicy{ID: "11", UserID: "user123", Type: "health", Deductible: 500, OutOfPocketMax: 3000, IsActive: true}
+	dental := &domain.InsurancePolicy{ID: "12", UserID: "user123", Type: "dental", Deductible: 5000, IsActive: true}
+	familyHDHP := &domain.InsurancePolicy{ID: "13", UserID: "user123", Type: "comprehensive", Deductible: 4000, OutOfPocketMax: 7000, IsActive: true}
+
+	tests := []struct {
+		name            string
+		familySize      int
+		policies        []*domain.InsurancePolicy
+		projected       float64
+		limits          []HealthServiceOption
+		wantEligible    bool
+		wantTier        string
+		wantPolicyID    string
+		wantAnnual      float64
+		wantMonthly     float64
+		wantExplanation string
+	}{
+		{
+			name:         "eligible with costs below the limit",
+			familySize:   1,
+			policies:     []*domain.InsurancePolicy{hdhp},
+			projected:    2400,
+			wantEligible: true, wantTier: HSACoverageSelfOnly, wantPolicyID: "10",
+			wantAnnual: 2400, wantMonthly: 200,
+		},
+		{
+			name:         "eligible with high costs capped at the self-only limit",
+			familySize:   1,
+			policies:     []*domain.InsurancePolicy{lowDeductible, hdhp},
+			projected:    6500,
+			wantEligible: true, wantTier: HSACoverageSelfOnly, wantPolicyID: "10",
+			wantAnnual: 4300, wantMonthly: 358.33, wantExplanation: "contribution limit",
+		},
+		{
+			name:         "family tier uses the family limit and deductible",
+			familySize:   3,
+			policies:     []*domain.InsurancePolicy{hdhp, familyHDHP},
+			projected:    12000,
+			wantEligible: true, wantTier: HSACoverageFamily, wantPolicyID: "13",
+			wantAnnual: 7000, wantMonthly: 583.33, wantExplanation: "out-of-pocket maximum",
+		},
+		{
+			name:         "configured limits replace the defaults",
+			familySize:   1,
+			policies:     []*domain.InsurancePolicy{hdhp},
+			projected:    6500,
+			limits:       []HealthServiceOption{WithHSALimits(HSALimits{SelfOnlyContributionLimit: 1200})},
+			wantEligible: true, wantTier: HSACoverageSelfOnly, wantPolicyID: "10",
+			wantAnnual: 1200, wantMonthly: 100,
+		},
+		{
+			name:            "ineligible without a high-deductible health plan",
+			familySize:      1,
+			policies:        []*domain.InsurancePolicy{lowDeductible, dental},
+			wantTier:        HSACoverageSelfOnly,
+			wantExplanation: "No active health plan",
+		},
+		{
+			name:            "ineligible without any policy",
+			familySize:      4,
+			policies:        []*domain.InsurancePolicy{},
+			wantTier:        HSACoverageFamily,
+			wantExplanation: "3300.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockProfileRepo := &MockHealthProfileRepository{}
+			mockExpenseRepo := &MockMedicalExpenseRepository{}
+			mockPolicyRepo := &MockInsurancePolicyRepository{}
+			service := NewHealthService(
+				mockProfileRepo,
+				&MockMedicalConditionRepository{},
+				mockExpenseRepo,
+				mockPolicyRepo,
+				&MockMedicationScheduleRepository{},
+				&MockRiskCalculator{},
+				&MockMedicalCostAnalyzer{},
+				NewInsuranceEvaluator(),
+				tt.limits...,
+			)
+
+			mockProfileRepo.On("GetByUserID", mock.Anything, "user123").Return(&domain.HealthProfile{ID: "1", UserID: "user123", FamilySize: tt.familySize}, nil)
+			mockPolicyRepo.On("GetActivePolicies", mock.Anything, "user123").Return(tt.policies, nil)
+			mockExpenseRepo.On("GetAnnualProjectedExpenses", mock.Anything, "user123").Return(tt.projected, nil)
+
+			// Act
+			recommendation, err := service.RecommendHSAContribution(context.Background(), "user123")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantEligible, recommendation.Eligible)
+			assert.Equal(t, tt.wantTier, recommendation.CoverageTier)
+			assert.Equal(t, tt.wantPolicyID, recommendation.QualifyingPolicyID)
+			assert.Equal(t, tt.wantAnnual, recommendation.RecommendedAnnualContribution)
+			assert.Equal(t, tt.wantMonthly, recommendation.RecommendedMonthlyContribution)
+			assert.NotEmpty(t, recommendation.Explanation)
+			if tt.wantExplanation != "" {
+				assert.Contains(t, recommendation.Explanation, tt.wantExplanation)
+			}
+			if !tt.wantEligible {
+				mockExpenseRepo.AssertNotCalled(t, "GetAnnualProjectedExpenses", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProjectThresholdMonth(t *testing.T) {
+	asOf := time.Date(2025, time.March, 15, 10, 0, 0, 0, time.UTC)
+	yearEnd := time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		remaining float64
+		runRate   float64
+		deadline  time.Time
+		wantMonth time.Time
+		wantOK    bool
+	}{
+		{name: "met next month", remaining: 150, runRate: 200, deadline: yearEnd, wantMonth: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "exact multiple doesn't round up", remaining: 600, runRate: 200, deadline: yearEnd, wantMonth: time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "partial month rounds up", remaining: 601, runRate: 200, deadline: yearEnd, wantMonth: time.Date(2025, time.July, 1, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "met in December", remaining: 1800, runRate: 200, deadline: yearEnd, wantMonth: time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{name: "will not meet deductible this year", remaining: 2000, runRate: 200, deadline: yearEnd},
+		{name: "policy ends before the deductible is met", remaining: 600, runRate: 200, deadline: time.Date(2025, time.May, 31, 0, 0, 0, 0, time.UTC)},
+		{name: "no recurring spending", remaining: 100, runRate: 0, deadline: yearEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			month, ok := projectThresholdMonth(tt.remaining, tt.runRate, asOf, tt.deadline)
+
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantMonth, month)
+		})
+	}
+}
+
+func TestTrackDeductibleProgress(t *testing.T) {
+	asOf := time.Date(2025, time.October, 10, 0, 0, 0, 0, time.UTC)
+	yearEnd := time.Date(2025, time.December, 31, 23, 59, 59, 0, time.UTC)
+	policy := domain.InsurancePolicy{ID: "10", Provider: "Acme", Type: "health", Deductible: 2000, DeductibleMet: 1700, OutOfPocketMax: 5000, OutOfPocketCurrent: 1900}
+
+	t.Run("projects the month the deductible is met", func(t *testing.T) {
+		progress := trackDeductibleProgress(policy, 150, asOf, yearEnd)
+
+		assert.Equal(t, 1700.0, progress.DeductibleMet)
+		assert.Equal(t, 300.0, progress.DeductibleRemaining)
+		assert.Equal(t, 3100.0, progress.OutOfPocketRemaining)
+		require.NotNil(t, progress.ProjectedDeductibleMetMonth)
+		assert.Equal(t, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC), *progress.ProjectedDeductibleMetMonth)
+		assert.True(t, progress.WillMeetDeductibleThisYear)
+	})
+
+	t.Run("will not meet deductible this year", func(t *testing.T) {
+		progress := trackDeductibleProgress(policy, 100, asOf, yearEnd)
+
+		assert.Nil(t, progress.ProjectedDeductibleMetMonth)
+		assert.False(t, progress.WillMeetDeductibleThisYear)
+	})
+
+	t.Run("already met", func(t *testing.T) {
+		met := policy
+		met.DeductibleMet = 2500
+
+		progress := trackDeductibleProgress(met, 0, asOf, yearEnd)
+
+		assert.Equal(t, 2000.0, progress.DeductibleMet)
+		assert.Zero(t, progress.DeductibleRemaining)
+		assert.Nil(t, progress.ProjectedDeductibleMetMonth)
+		assert.True(t, progress.WillMeetDeductibleThisYear)
+	})
+}
+
+func TestHealthService_GetExpenseAnalytics(t *testing.T) {
+	// Arrange
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+	)
+
+	categories := []CategoryTotals{
+		{Category: "hospital", TotalAmount: 1500, TotalInsurancePaid: 1200, TotalOutOfPocket: 300, ExpenseCount: 1},
+		{Category: "medication", TotalAmount: 300, TotalInsurancePaid: 0, TotalOutOfPocket: 300, ExpenseCount: 3},
+	}
+	mockExpenseRepo.On("CalculateTotals", mock.Anything, "user123", mock.Anything, mock.Anything).
+		Return(&ExpenseTotals{TotalAmount: 1800, TotalInsurancePaid: 1200, TotalOutOfPocket: 600, ExpenseCount: 4}, nil)
+	mockExpenseRepo.On("GetCategoryTotals", mock.Anything, "user123", mock.Anything, mock.Anything).Return(categories, nil)
+	mockExpenseRepo.On("GetMonthlyRecurringTotal", mock.Anything, "user123").Return(100.0, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, "user123").Return([]*domain.InsurancePolicy{
+		{ID: "10", Type: "health", Deductible: 1000, DeductibleMet: 1000, OutOfPocketMax: 4000, OutOfPocketCurrent: 600, IsActive: true},
+	}, nil)
+
+	// Act
+	analytics, err := service.GetExpenseAnalytics(context.Background(), "user123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, time.Now().Year(), analytics.Year)
+	assert.Equal(t, 1800.0, analytics.YearToDateTotal)
+	assert.Equal(t, 600.0, analytics.OutOfPocketTotal)
+	assert.Equal(t, 1200.0, analytics.InsurancePaidTotal)
+	assert.Equal(t, categories, analytics.ByCategory)
+	assert.Equal(t, 100.0, analytics.MonthlyRunRate)
+	require.Len(t, analytics.Policies, 1)
+	assert.Equal(t, 3400.0, analytics.Policies[0].OutOfPocketRemaining)
+	assert.True(t, analytics.Policies[0].WillMeetDeductibleThisYear)
+
+	// The year-to-date window starts on January 1st
+	start := mockExpenseRepo.Calls[0].Arguments.Get(2).(time.Time)
+	assert.Equal(t, time.January, start.Month())
+	assert.Equal(t, 1, start.Day())
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+func TestHealthService_GetExpenseBreakdown(t *testing.T) {
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+
+	mockExpenseRepo.On("GetByUserID", mock.Anything, "user123").Return([]*domain.MedicalExpense{
+		{ID: "1", UserID: "user123", Category: "medication", Amount: 100, IsCovered: true, InsurancePayment: 80, OutOfPocket: 20},
+		{ID: "2", UserID: "user123", Category: "hospital", Amount: 300, IsCovered: true, InsurancePayment: 150, OutOfPocket: 150},
+	}, nil)
+
+	breakdown, err := service.GetExpenseBreakdown(context.Background(), "user123")
+
+	require.NoError(t, err)
+	assert.Equal(t, "user123", breakdown.UserID)
+	assert.Equal(t, 400.0, breakdown.TotalAmount)
+	assert.Equal(t, 230.0, breakdown.CoveredAmount)
+	assert.Equal(t, 170.0, breakdown.OutOfPocketAmount)
+	require.Len(t, breakdown.Categories, 2)
+	assert.Equal(t, "hospital", breakdown.Categories[0].Category)
+	assert.Equal(t, 0.75, breakdown.Categories[0].Share)
+
+	mockExpenseRepo.ExpectedCalls = nil
+	mockExpenseRepo.On("GetByUserID", mock.Anything, "user123").Return(nil, errors.New("connection refused"))
+	_, err = service.GetExpenseBreakdown(context.Background(), "user123")
+	assert.EqualError(t, err, "failed to get expenses: connection refused")
+}
+
+func TestHealthService_EvaluateInsuranceAdequacy_ScoresOwnConditions(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	mockRiskCalc := &MockRiskCalculator{}
+	mockCostAnalyzer := &MockMedicalCostAnalyzer{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		mockRiskCalc,
+		mockCostAnalyzer,
+		NewInsuranceEvaluator(),
+	)
+
+	profile := &domain.HealthProfile{ID: "1", UserID: "user123", RelationToOwner: domain.RelationSelf}
+	ownCondition := &domain.MedicalCondition{ID: "1", ProfileID: "1", Name: "Diabetes", IsActive: true}
+	childCondition := &domain.MedicalCondition{ID: "2", ProfileID: "2", Name: "Asthma", IsActive: true}
+	policies := comparisonPlans()
+
+	mockProfileRepo.On("GetByUserID", mock.Anything, "user123").Return(profile, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, "user123", true).
+		Return([]*domain.MedicalCondition{ownCondition, childCondition}, nil)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, "user123").Return([]*domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, "user123").
+		Return([]*domain.InsurancePolicy{&policies[0], &policies[1]}, nil)
+	mockRiskCalc.On("CalculateHealthRiskScore", profile, []domain.MedicalCondition{*ownCondition}).Return(60)
+	mockRiskCalc.On("DetermineRiskLevel", 60).Return(domain.RiskLevelHigh)
+	mockCostAnalyzer.On("ProjectAnnualCosts", []domain.MedicalExpense{}, []domain.MedicalCondition{*ownCondition, *childCondition}).
+		Return(50000.0)
+
+	// Act
+	adequacy, err := service.EvaluateInsuranceAdequacy(context.Background(), "user123")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "user123", adequacy.UserID)
+	assert.Equal(t, domain.RiskLevelHigh, adequacy.RiskLevel)
+	assert.Equal(t, 50000.0, adequacy.ProjectedAnnualExpenses)
+	require.Len(t, adequacy.Policies, 2)
+	assert.InDelta(t, 2000.0, adequacy.Policies[0].ExpectedOutOfPocket, 0.01)
+	assert.False(t, adequacy.Policies[1].CoverageSufficient)
+	mockRiskCalc.AssertExpectations(t)
+	mockCostAnalyzer.AssertExpectations(t)
+}
+
+func TestHealthService_UpdateDeductibleProgress_PublishesDeductibleMet(t *testing.T) {
+	tests := []struct {
+		name          string
+		deductibleMet float64
+		amount        float64
+		expectEvent   bool
+	}{
+		{name: "reaches deductible", deductibleMet: 1200, amount: 300, expectEvent: true},
+		{name: "passes deductible", deductibleMet: 1200, amount: 500, expectEvent: true},
+		{name: "still below deductible", deductibleMet: 200, amount: 300},
+		{name: "already met", deductibleMet: 1500, amount: 100},
+	}
+	const deductible = 1500.0
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicyRepo := &MockInsurancePolicyRepository{}
+			publisher := &recordingPublisher{}
+			service := NewHealthService(
+				&MockHealthProfileRepository{},
+				&MockMedicalConditionRepository{},
+				&MockMedicalExpenseRepository{},
+				mockPolicyRepo,
+				&MockMedicationScheduleRepository{},
+				NewRiskCalculator(domain.DefaultRiskModel()),
+				NewMedicalCostAnalyzer(),
+				NewInsuranceEvaluator(),
+				WithHealthEventPublisher(publisher),
+			)
+
+			policy := &domain.InsurancePolicy{
+				ID:            "pol1",
+				UserID:        "user123",
+				Provider:      "Acme Health",
+				Deductible:     deductible,
+				DeductibleMet:  tt.deductibleMet,
+				OutOfPocketMax: 6000,
+			}
+			// Deductible met is capped at the deductible
+			newDeductibleMet := math.Min(tt.deductibleMet+tt.amount, deductible)
+			mockPolicyRepo.On("GetByIDForUpdate", mock.Anything, "pol1").Return(policy, nil)
+			mockPolicyRepo.On("UpdateDeductibleProgress", mock.Anything, "pol1", newDeductibleMet, tt.amount).Return(policy, nil)
+
+			err := service.UpdateDeductibleProgress(context.Background(), "pol1", tt.amount)
+
+			require.NoError(t, err)
+			events := publisher.published()
+			if !tt.expectEvent {
+				assert.Empty(t, events)
+				return
+			}
+			require.Len(t, events, 1)
+			assert.Equal(t, domain.EventDeductibleMet, events[0].Type)
+			assert.Equal(t, "user123", events[0].UserID)
+			assert.Equal(t, "pol1", events[0].Data["policy_id"])
+			assert.Equal(t, newDeductibleMet, events[0].Data["deductible_met"])
+		})
+	}
+}
+
+func TestHealthService_PublishRiskLevelChange(t *testing.T) {
+	publisher := &recordingPublisher{}
+	service := NewHealthService(nil, nil, nil, nil, nil, nil, nil, nil, WithHealthEventPublisher(publisher)).(*healthService)
+
+	// Each level is the next summary computed for the same user
+	levels := []string{"moderate", "high", "high", "moderate", "critical", "high", "critical"}
+	for i, level := range levels {
+		service.publishRiskLevelChange(&domain.HealthSummary{UserID: "user123", HealthRiskLevel: level, HealthRiskScore: 50 + i})
+	}
+	// A user whose first known level is already high is notified too
+	service.publishRiskLevelChange(&domain.HealthSummary{UserID: "user456", HealthRiskLevel: "high", HealthRiskScore: 60})
+
+	events := publisher.published()
+	require.Len(t, events, 4)
+	transitions := make([]string, len(events))
+	for i, event := range events {
+		assert.Equal(t, domain.EventHighRiskDetected, event.Type)
+		transitions[i] = event.UserID + ":" + event.Data["previous_risk_level"].(string) + "->" + event.Data["risk_level"].(string)
+	}
+	assert.Equal(t, []string{
+		"user123:moderate->high",
+		"user123:moderate->critical",
+		"user123:high->critical",
+		"user456:->high",
+	}, transitions)
+}
+
+// memoryRiskSnapshotRepository keeps risk snapshots in recording order
+type memoryRiskSnapshotRepository struct {
+	snapshots []*domain.RiskSnapshot
+}
+
+func (r *memoryRiskSnapshotRepository) Create(ctx context.Context, snapshot *domain.RiskSnapshot) (*domain.RiskSnapshot, error) {
+	created := *snapshot
+	created.ID = strconv.Itoa(len(r.snapshots) + 1)
+	r.snapshots = append(r.snapshots, &created)
+	return &created, nil
+}
+
+func (r *memoryRiskSnapshotRepository) GetByUserID(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.RiskSnapshot, error) {
+	var result []*domain.RiskSnapshot
+	for _, snapshot := range r.snapshots {
+		if snapshot.UserID == userID && !snapshot.RecordedAt.Before(since) {
+			result = append(result, snapshot)
+		}
+	}
+	if len(result) > limit {
+		result = result[len(result)-limit:]
+	}
+	return result, nil
+}
+
+func TestHealthService_RecalculateAllRisk_UpdatesOnlyChangedScores(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	snapshotRepo := &memoryRiskSnapshotRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithRiskSnapshotRepository(snapshotRepo),
+	)
+
+	profiles := make([]*domain.HealthProfile, 4)
+	for i := range profiles {
+		userID := "user-" + strconv.Itoa(i+1)
+		profiles[i] = &domain.HealthProfile{
+			ID: strconv.Itoa(i + 1), UserID: userID, Age: 30, Gender: "female", Height: 165, Weight: 60, BMI: 22.04,
+			FamilySize: 1, RelationToOwner: domain.RelationSelf,
+		}
+		mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return([]*domain.MedicalCondition{}, nil)
+	}
+	mockProfileRepo.On("ListSelfProfiles", mock.Anything, uint(0), riskRecalculationBatchSize).Return(profiles, nil)
+	ctx := context.Background()
+
+	// Act
+	first, err := service.RecalculateAllRisk(ctx)
+	require.NoError(t, err)
+	second, err := service.RecalculateAllRisk(ctx)
+	require.NoError(t, err)
+
+	profiles[2].Age = 70
+	profiles[2].Weight = 110
+	profiles[2].BMI = 40.4
+	third, err := service.RecalculateAllRisk(ctx)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, &RiskRecalculation{Processed: 4, Updated: 4}, first)
+	assert.Equal(t, &RiskRecalculation{Processed: 4, Updated: 0}, second, "unchanged data is not snapshotted again")
+	assert.Equal(t, &RiskRecalculation{Processed: 4, Updated: 1}, third)
+
+	history, err := snapshotRepo.GetByUserID(ctx, "user-3", time.Time{}, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Greater(t, history[1].RiskScore, history[0].RiskScore)
+}
+
+func TestHealthService_RiskHistory_SnapshotAfterAddingConditionScoresHigher(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	snapshotRepo := &memoryRiskSnapshotRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithRiskSnapshotRepository(snapshotRepo),
+	)
+
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: "user123", Age: 25, Gender: "female", Height: 165, Weight: 60, BMI: 22.04, FamilySize: 1,
+		RelationToOwner: domain.RelationSelf,
+	}
+	condition := &domain.MedicalCondition{
+		UserID:        "user123",
+		Name:          "Chronic Kidney Disease",
+		Category:      "chronic",
+		Severity:      "severe",
+		DiagnosedDate: time.Now().AddDate(-3, 0, 0),
+		IsActive:      true,
+	}
+	mockProfileRepo.On("GetByUserID", mock.Anything, "user123").Return(profile, nil)
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, "user123").Return([]*domain.HealthProfile{profile}, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, "user123", true).Return([]*domain.MedicalCondition{}, nil).Once()
+	mockConditionRepo.On("Create", mock.Anything, condition).Return(condition, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, "user123", true).Return([]*domain.MedicalCondition{condition}, nil)
+
+	// Act
+	before, err := service.SnapshotRisk(context.Background(), "user123")
+	require.NoError(t, err)
+	require.NoError(t, service.AddCondition(context.Background(), condition))
+	history, err := service.GetRiskHistory(context.Background(), "user123", 12)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, history.Entries, 2, "adding a condition should snapshot the risk")
+	assert.Equal(t, before.RiskScore, history.Entries[0].RiskScore)
+	assert.Greater(t, history.Entries[1].RiskScore, history.Entries[0].RiskScore)
+	assert.Equal(t, history.Entries[1].RiskScore-history.Entries[0].RiskScore, history.ScoreChange)
+	if history.Entries[0].RiskLevel != history.Entries[1].RiskLevel {
+		require.Len(t, history.Transitions, 1)
+		assert.Equal(t, history.Entries[1].RiskLevel, history.Transitions[0].To)
+	}
+}
+
+func TestHealthService_GetRiskHistory_InvalidMonthsAndDisabled(t *testing.T) {
+	enabled := NewHealthService(nil, nil, nil, nil, nil, nil, nil, nil, WithRiskSnapshotRepository(&memoryRiskSnapshotRepository{}))
+	_, err := enabled.GetRiskHistory(context.Background(), "user123", 0)
+	assert.EqualError(t, err, "months must be positive")
+
+	disabled := NewHealthService(nil, nil, nil, nil, nil, nil, nil, nil)
+	_, err = disabled.GetRiskHistory(context.Background(), "user123", 12)
+	assert.Error(t, err)
+	_, err = disabled.SnapshotRisk(context.Background(), "user123")
+	assert.Error(t, err)
+}
+
+// setupRiskWhatIfService returns a service scoring user123's self profile, aged 45 with a BMI
+// above 30, and its severe and mild conditions: 10 + 15 + 10 + 2 = 37, a moderate risk. The
+// user's dependent has a condition of its own that doesn't count. The summary cache is off, so
+// only the profile and conditions are loaded.
+func setupRiskWhatIfService() HealthService {
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		&MockMedicalExpenseRepository{},
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		&MockMedicalCostAnalyzer{},
+		NewInsuranceEvaluator(),
+		WithHealthSummaryCache(false),
+	)
+
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: "user123", Age: 45, Gender: "male", Height: 170, Weight: 95, BMI: 32.87, FamilySize: 1,
+		RelationToOwner: domain.RelationSelf,
+	}
+	diagnosed := time.Now().AddDate(-2, 0, 0)
+	conditions := []*domain.MedicalCondition{
+		{ID: "10", UserID: "user123", ProfileID: "1", Name: "Hypertension", Category: "chronic", Severity: "severe", DiagnosedDate: diagnosed, IsActive: true},
+		{ID: "11", UserID: "user123", ProfileID: "1", Name: "Asthma", Category: "chronic", Severity: "mild", DiagnosedDate: diagnosed, IsActive: true},
+		{ID: "12", UserID: "user123", ProfileID: "2", Name: "Eczema", Category: "chronic", Severity: "moderate", DiagnosedDate: diagnosed, IsActive: true},
+	}
+	mockProfileRepo.On("GetByUserID", mock.Anything, "user123").Return(profile, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, "user123", true).Return(conditions, nil)
+	return service
+}
+
+func TestHealthService_EvaluateRiskWhatIf_RemoveHighestWeightedCondition(t *testing.T) {
+	service := setupRiskWhatIfService()
+
+	result, err := service.EvaluateRiskWhatIf(context.Background(), "user123", RiskWhatIf{RemoveConditionIDs: []string{"10"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 37, result.CurrentScore)
+	assert.Equal(t, domain.RiskLevelModerate, result.CurrentLevel)
+	assert.Equal(t, 27, result.HypotheticalScore)
+	assert.Equal(t, domain.RiskLevelModerate, result.HypotheticalLevel)
+	assert.Equal(t, -10, result.ScoreChange)
+	assert.Equal(t, []RiskFactorChange{
+		{Factor: domain.RiskFactorAge, CurrentPoints: 10, HypotheticalPoints: 10},
+		{Factor: domain.RiskFactorBMI, CurrentPoints: 15, HypotheticalPoints: 15},
+		{Factor: domain.RiskFactorFamilySize},
+		{Factor: domain.RiskFactorCondition, ConditionID: "10", Name: "Hypertension", CurrentPoints: 10, Change: -10},
+		{Factor: domain.RiskFactorCondition, ConditionID: "11", Name: "Asthma", CurrentPoints: 2, HypotheticalPoints: 2},
+	}, result.Factors)
+}
+
+func TestHealthService_EvaluateRiskWhatIf_CrossesRiskLevelBoundary(t *testing.T) {
+	service := setupRiskWhatIfService()
+	weight := 70.0 // BMI 24.22, down from the 30+ band
+
+	result, err := service.EvaluateRiskWhatIf(context.Background(), "user123", RiskWhatIf{
+		Weight:          &weight,
+		SeverityChanges: []ConditionSeverityChange{{ConditionID: "10", Severity: "mild"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.RiskLevelModerate, result.CurrentLevel)
+	assert.Equal(t, 14, result.HypotheticalScore)
+	assert.Equal(t, domain.RiskLevelLow, result.HypotheticalLevel)
+	assert.Equal(t, -23, result.ScoreChange)
+	assert.Equal(t, -15, result.Factors[1].Change, "BMI")
+	assert.Equal(t, -8, result.Factors[3].Change, "hypertension")
+}
+
+func TestHealthService_EvaluateRiskWhatIf_AddCondition(t *testing.T) {
+	service := setupRiskWhatIfService()
+	age := 62
+
+	result, err := service.EvaluateRiskWhatIf(context.Background(), "user123", RiskWhatIf{
+		Age:           &age,
+		AddConditions: []domain.MedicalCondition{{Name: "Diabetes", Category: "chronic", Severity: "critical"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 37+10+15, result.HypotheticalScore)
+	assert.Equal(t, domain.RiskLevelHigh, result.HypotheticalLevel)
+	require.Len(t, result.Factors, 6)
+	assert.Equal(t, RiskFactorChange{Factor: domain.RiskFactorCondition, Name: "Diabetes", HypotheticalPoints: 15, Change: 15}, result.Factors[5])
+}
+
+func TestHealthService_EvaluateRiskWhatIf_UnknownCondition(t *testing.T) {
+	// "12" is the dependent's condition and "99" isn't the user's at all; neither is revealed
+	for _, id := range []string{"12", "99"} {
+		service := setupRiskWhatIfService()
+
+		_, removeErr := service.EvaluateRiskWhatIf(context.Background(), "user123", RiskWhatIf{RemoveConditionIDs: []string{id}})
+		_, changeErr := service.EvaluateRiskWhatIf(context.Background(), "user123", RiskWhatIf{
+			SeverityChanges: []ConditionSeverityChange{{ConditionID: id, Severity: "mild"}},
+		})
+
+		assert.EqualError(t, removeErr, "condition "+id+" not found")
+		assert.EqualError(t, changeErr, "condition "+id+" not found")
+	}
+}
+
+func TestHealthService_EvaluateRiskWhatIf_InvalidOverrides(t *testing.T) {
+	weight := -5.0
+	age := 200
+	tests := []struct {
+		name    string
+		whatIf  RiskWhatIf
+		message string
+	}{
+		{"negative_weight", RiskWhatIf{Weight: &weight}, "profile validation failed: weight must be positive"},
+		{"age_out_of_range", RiskWhatIf{Age: &age}, "profile validation failed: age must be between 1 and 150"},
+		{"invalid_severity", RiskWhatIf{SeverityChanges: []ConditionSeverityChange{{ConditionID: "10", Severity: "extreme"}}},
+			"condition validation failed: severity must be one of: mild, moderate, severe, critical"},
+		{"added_condition_without_name", RiskWhatIf{AddConditions: []domain.MedicalCondition{{Category: "acute", Severity: "mild"}}},
+			"condition validation failed: condition name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := setupRiskWhatIfService().EvaluateRiskWhatIf(context.Background(), "user123", tt.whatIf)
+
+			assert.EqualError(t, err, tt.message)
+		})
+	}
+}
+
+func TestHealthService_GetRecurringExpenses_RejectsUnsupportedFrequency(t *testing.T) {
+	// Arrange
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	service := NewHealthService(
+		&MockHealthProfileRepository{},
+		&MockMedicalConditionRepository{},
+		mockExpenseRepo,
+		&MockInsurancePolicyRepository{},
+		&MockMedicationScheduleRepository{},
+		&MockRiskCalculator{},
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+	mockExpenseRepo.On("GetRecurring", mock.Anything, "user123").Return([]*domain.MedicalExpense{
+		{ID: "1", UserID: "user123", Amount: 90, IsRecurring: true, Frequency: "quarterly"},
+		{ID: "2", UserID: "user123", Amount: 40, IsRecurring: true, Frequency: "fortnightly"},
+	}, nil).Once()
+	mockExpenseRepo.On("GetRecurring", mock.Anything, "user456").Return([]*domain.MedicalExpense{
+		{ID: "3", UserID: "user456", Amount: 600, IsRecurring: true, Frequency: "semiannually"},
+	}, nil).Once()
+
+	// Act & Assert
+	_, err := service.GetRecurringExpenses(context.Background(), "user123")
+	assert.ErrorContains(t, err, `recurring expense 2: unsupported medical expense frequency: "fortnightly"`)
+
+	expenses, err := service.GetRecurringExpenses(context.Background(), "user456")
+	require.NoError(t, err)
+	assert.Len(t, expenses, 1)
+	mockExpenseRepo.AssertExpectations(t)
+}
+
+// memoryOccurrenceRepository keeps expense occurrences in recording order
+type memoryOccurrenceRepository struct {
+	occurrences []*domain.MedicalExpenseOccurrence
+}
+
+func (r *memoryOccurrenceRepository) Create(ctx context.Context, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error) {
+	created := *occurrence
+	created.ID = strconv.Itoa(len(r.occurrences) + 1)
+	r.occurrences = append(r.occurrences, &created)
+	return &created, nil
+}
+
+func (r *memoryOccurrenceRepository) GetByExpenseID(ctx context.Context, expenseID string) ([]*domain.MedicalExpenseOccurrence, error) {
+	var result []*domain.MedicalExpenseOccurrence
+	for _, occurrence := range r.occurrences {
+		if occurrence.ExpenseID == expenseID {
+			result = append(result, occurrence)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryOccurrenceRepository) GetByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.MedicalExpenseOccurrence, error) {
+	var result []*domain.MedicalExpenseOccurrence
+	for _, occurrence := range r.occurrences {
+		if occurrence.UserID == userID && !occurrence.Date.Before(since) {
+			result = append(result, occurrence)
+		}
+	}
+	return result, nil
+}
+
+func TestHealthService_AddExpenseOccurrence(t *testing.T) {
+	recurring := &domain.MedicalExpense{
+		ID: "7", UserID: "user123", ProfileID: "1", Amount: 100, Category: "medication",
+		IsRecurring: true, Frequency: "monthly", IsCovered: true, InsurancePayment: 80, OutOfPocket: 20,
+	}
+	oneTime := &domain.MedicalExpense{ID: "8", UserID: "user123", ProfileID: "1", Amount: 250, Category: "hospital", Frequency: "one_time"}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockExpenseRepo.On("GetByID", mock.Anything, "7").Return(recurring, nil)
+	mockExpenseRepo.On("GetByID", mock.Anything, "8").Return(oneTime, nil)
+	mockExpenseRepo.On("GetByID", mock.Anything, "9").Return(nil, domain.ErrMedicalExpenseNotFound)
+
+	occurrenceRepo := &memoryOccurrenceRepository{}
+	service := NewHealthService(nil, nil, mockExpenseRepo, nil, nil, nil, NewMedicalCostAnalyzer(), NewInsuranceEvaluator(),
+		WithExpenseOccurrenceRepository(occurrenceRepo))
+	ctx := context.Background()
+	yesterday := time.Now().AddDate(0, 0, -1)
+
+	t.Run("inherits_category_and_coverage", func(t *testing.T) {
+		created, err := service.AddExpenseOccurrence(ctx, "user123", "7", &domain.MedicalExpenseOccurrence{Amount: 120, Date: yesterday})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1", created.ID)
+		assert.Equal(t, "7", created.ExpenseID)
+		assert.Equal(t, "medication", created.Category)
+		assert.Equal(t, 96.0, created.InsurancePayment)
+		assert.Equal(t, 24.0, created.OutOfPocket)
+
+		occurrences, err := service.GetExpenseOccurrences(ctx, "user123", "7")
+		require.NoError(t, err)
+		assert.Len(t, occurrences, 1)
+	})
+
+	t.Run("one_time_expense", func(t *testing.T) {
+		_, err := service.AddExpenseOccurrence(ctx, "user123", "8", &domain.MedicalExpenseOccurrence{Amount: 120, Date: yesterday})
+		assert.ErrorIs(t, err, domain.ErrExpenseNotRecurring)
+	})
+
+	t.Run("other_users_expense", func(t *testing.T) {
+		_, err := service.AddExpenseOccurrence(ctx, "user456", "7", &domain.MedicalExpenseOccurrence{Amount: 120, Date: yesterday})
+		assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+		_, err = service.GetExpenseOccurrences(ctx, "user456", "7")
+		assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+	})
+
+	t.Run("missing_expense", func(t *testing.T) {
+		_, err := service.AddExpenseOccurrence(ctx, "user123", "9", &domain.MedicalExpenseOccurrence{Amount: 120, Date: yesterday})
+		assert.ErrorIs(t, err, domain.ErrMedicalExpenseNotFound)
+	})
+
+	t.Run("future_date", func(t *testing.T) {
+		_, err := service.AddExpenseOccurrence(ctx, "user123", "7", &domain.MedicalExpenseOccurrence{Amount: 120, Date: time.Now().AddDate(0, 0, 1)})
+		assert.EqualError(t, err, "occurrence validation failed: date cannot be in the future")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := NewHealthService(nil, nil, mockExpenseRepo, nil, nil, nil, nil, nil)
+		_, err := disabled.AddExpenseOccurrence(ctx, "user123", "7", &domain.MedicalExpenseOccurrence{Amount: 120, Date: yesterday})
+		assert.EqualError(t, err, "expense occurrences are not enabled")
+		_, err = disabled.GetExpenseOccurrences(ctx, "user123", "7")
+		assert.EqualError(t, err, "expense occurrences are not enabled")
+	})
+}
+
+func TestHealthService_CalculateHealthSummary_PrefersRecordedOccurrences(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+	occurrenceRepo := &memoryOccurrenceRepository{}
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+		WithExpenseOccurrenceRepository(occurrenceRepo),
+	)
+
+	userID := "user123"
+	profiles := []*domain.HealthProfile{
+		{ID: "1", UserID: userID, RelationToOwner: domain.RelationSelf, Age: 40, Gender: "female", Height: 168, Weight: 62, FamilySize: 2},
+		{ID: "2", UserID: userID, Name: "Sam", RelationToOwner: domain.RelationChild, Age: 8, Gender: "male", Height: 128, Weight: 26, FamilySize: 1},
+	}
+	expense := &domain.MedicalExpense{
+		ID: "7", UserID: userID, ProfileID: "1", Amount: 100, Category: "medication", IsRecurring: true, Frequency: "monthly", OutOfPocket: 100,
+	}
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return(profiles, nil)
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return([]*domain.MedicalCondition{}, nil)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return([]*domain.MedicalExpense{expense}, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil)
+
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	for _, amount := range []float64{70, 60} {
+		occurrence := &domain.MedicalExpenseOccurrence{Amount: amount, Date: monthStart}
+		occurrence.InheritFrom(expense)
+		_, err := occurrenceRepo.Create(context.Background(), occurrence)
+		require.NoError(t, err)
+	}
+
+	// Act
+	summary, err := service.CalculateHealthSummary(context.Background(), userID)
+
+	// Assert - this month cost 130 rather than the projected 100, spread over the year
+	require.NoError(t, err)
+	require.NotNil(t, summary.RecurringVariance)
+	assert.Equal(t, domain.RecurringExpenseVariance{ProjectedYTD: 100, ActualYTD: 130, Variance: 30, ReconciledMonths: 1}, *summary.RecurringVariance)
+	assert.InDelta(t, 102.5, summary.MonthlyMedicalExpenses, 0.001)
+	assert.InDelta(t, 102.5, summary.MemberExpenses[0].MonthlyMedicalExpenses, 0.001)
+	assert.Zero(t, summary.MemberExpenses[1].MonthlyMedicalExpenses)
+	assert.InDelta(t, 1230.0, summary.CoverageGapRisk+summary.OutOfPocketRemaining, 0.001, "the annual projection uses the recorded month")
+}
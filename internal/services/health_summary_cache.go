@@ -0,0 +1,157 @@
+package services
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// healthSummaryCache keeps the last computed HealthSummary per user, along with the
+// healthSummaryBase it was computed from.
+// Each user has a version counter that every write bumps; a summary is only
+// served (or stored) while its version still matches, so a read that follows
+// a completed write always recomputes. Which policies are active, their coverage
+// windows and the plan year all depend on the date, so a summary is also only
+// served on the day it was computed.
+type healthSummaryCache struct {
+	mu       sync.Mutex
+	disabled bool
+	now      func() time.Time
+	versions map[string]uint64
+	entries  map[string]healthSummaryEntry
+}
+
+type healthSummaryEntry struct {
+	version uint64
+	day     time.Time // the day the summary was computed on
+	summary domain.HealthSummary
+	base    healthSummaryBase
+}
+
+// healthSummaryBase is the health data a summary is computed from. The cost projection and
+// risk what-if are computed from the same data, so they reuse the cached base.
+type healthSummaryBase struct {
+	profiles       []domain.HealthProfile    // every profile on the account
+	self           domain.HealthProfile      // the owner's own profile, the one being scored
+	conditions     []domain.MedicalCondition // active conditions of the whole family
+	selfConditions []domain.MedicalCondition // active conditions of the self profile
+	expenses       []domain.MedicalExpense
+	policies       []domain.InsurancePolicy // active policies
+}
+
+// newHealthSummaryCache creates an empty health summary cache; a disabled cache never stores anything
+func newHealthSummaryCache(enabled bool) *healthSummaryCache {
+	return &healthSummaryCache{
+		disabled: !enabled,
+		now:      time.Now,
+		versions: make(map[string]uint64),
+		entries:  make(map[string]healthSummaryEntry),
+	}
+}
+
+// enabled returns true if summaries are cached at all
+func (c *healthSummaryCache) enabled() bool {
+	return !c.disabled
+}
+
+// get returns a copy of the cached summary when it is still current, along with
+// the version the caller must pass to put after recomputing on a miss
+func (c *healthSummaryCache) get(userID string) (*domain.HealthSummary, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, version, ok := c.current(userID)
+	if !ok {
+		return nil, version, false
+	}
+
+	return cloneHealthSummary(&entry.summary), version, true
+}
+
+// getBase returns a copy of the base of the cached summary when it is still current, along
+// with the version the caller must pass to put after recomputing on a miss
+func (c *healthSummaryCache) getBase(userID string) (*healthSummaryBase, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, version, ok := c.current(userID)
+	if !ok {
+		return nil, version, false
+	}
+
+	return entry.base.clone(), version, true
+}
+
+// current returns the user's entry when its version still matches and it was computed today,
+// dropping an entry from an earlier day. The caller must hold c.mu.
+func (c *healthSummaryCache) current(userID string) (healthSummaryEntry, uint64, bool) {
+	version := c.versions[userID]
+	entry, ok := c.entries[userID]
+	if !ok || entry.version != version {
+		return healthSummaryEntry{}, version, false
+	}
+	if !entry.day.Equal(startOfDay(c.now())) {
+		delete(c.entries, userID)
+		return healthSummaryEntry{}, version, false
+	}
+	return entry, version, true
+}
+
+// put stores a summary and its base computed at the given version. It is dropped if a write
+// invalidated the user in the meantime, since the data it was built from may be stale.
+func (c *healthSummaryCache) put(userID string, version uint64, summary *domain.HealthSummary, base *healthSummaryBase) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.disabled || c.versions[userID] != version {
+		return
+	}
+	c.entries[userID] = healthSummaryEntry{
+		version: version,
+		day:     startOfDay(c.now()),
+		summary: *cloneHealthSummary(summary),
+		base:    *base.clone(),
+	}
+}
+
+// invalidate bumps the user's version and drops any cached summary
+func (c *healthSummaryCache) invalidate(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.versions[userID]++
+	delete(c.entries, userID)
+}
+
+// startOfDay returns midnight at the start of t's day, in t's location
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// cloneHealthSummary copies a summary, including its member breakdown, out-of-pocket statuses
+// and recurring variance, so cached entries never share memory with summaries handed to callers
+func cloneHealthSummary(summary *domain.HealthSummary) *domain.HealthSummary {
+	clone := *summary
+	clone.MemberExpenses = slices.Clone(summary.MemberExpenses)
+	clone.OutOfPocketStatuses = slices.Clone(summary.OutOfPocketStatuses)
+	if summary.RecurringVariance != nil {
+		variance := *summary.RecurringVariance
+		clone.RecurringVariance = &variance
+	}
+	return &clone
+}
+
+// clone copies a base, including its slices, so cached bases never share memory with callers
+func (b *healthSummaryBase) clone() *healthSummaryBase {
+	return &healthSummaryBase{
+		profiles:       append([]domain.HealthProfile(nil), b.profiles...),
+		self:           b.self,
+		conditions:     append([]domain.MedicalCondition(nil), b.conditions...),
+		selfConditions: append([]domain.MedicalCondition(nil), b.selfConditions...),
+		expenses:       append([]domain.MedicalExpense(nil), b.expenses...),
+		policies:       append([]domain.InsurancePolicy(nil), b.policies...),
+	}
+}
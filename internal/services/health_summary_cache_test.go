@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryConditionRepository stores conditions in memory so summaries reflect writes.
+// Methods not overridden fall through to the embedded mock.
+type memoryConditionRepository struct {
+	MockMedicalConditionRepository
+	mu         sync.Mutex
+	conditions []*domain.MedicalCondition
+}
+
+func (r *memoryConditionRepository) Create(ctx context.Context, condition *domain.MedicalCondition) (*domain.MedicalCondition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored := *condition
+	stored.ID = fmt.Sprintf("%d", len(r.conditions)+1)
+	r.conditions = append(r.conditions, &stored)
+	return &stored, nil
+}
+
+func (r *memoryConditionRepository) GetByUserID(ctx context.Context, userID string, activeOnly bool) ([]*domain.MedicalCondition, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*domain.MedicalCondition
+	for _, condition := range r.conditions {
+		if condition.UserID == userID && (!activeOnly || condition.IsActive) {
+			copied := *condition
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+func TestHealthService_CalculateHealthSummary_ServesCachedUntilWrite(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 35, Gender: "male", Height: 180, Weight: 75, BMI: 23.1, FamilySize: 2, UpdatedAt: time.Now(),
+	}
+
+	// Once per computed summary and once to check the expense's profile on write
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil).Times(3)
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return([]*domain.MedicalCondition{}, nil).Twice()
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return([]*domain.MedicalExpense{}, nil).Twice()
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil).Twice()
+	mockExpenseRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.MedicalExpense")).Return(&domain.MedicalExpense{}, nil)
+
+	// Act
+	first, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+	second, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+
+	err = service.AddExpense(context.Background(), &domain.MedicalExpense{
+		UserID: userID, ProfileID: "1", Amount: 50, Category: "medication", Description: "Refill",
+		Frequency: "monthly", Date: time.Now(),
+	})
+	require.NoError(t, err)
+	third, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+
+	// Assert - repositories hit once for the first read and once after the write
+	assert.Equal(t, first, second)
+	assert.NotSame(t, first, second, "cached summaries should be returned as copies")
+	assert.Equal(t, first.HealthRiskScore, third.HealthRiskScore)
+	mockProfileRepo.AssertExpectations(t)
+	mockConditionRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_CalculateHealthSummary_RecomputesOnNewDay(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+	now := time.Date(2025, time.June, 30, 9, 0, 0, 0, time.UTC)
+	service.(*healthService).summaryCache.now = func() time.Time { return now }
+
+	userID := "user123"
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 35, Gender: "male", Height: 180, Weight: 75, BMI: 23.1, FamilySize: 1, UpdatedAt: now,
+	}
+	policy := &domain.InsurancePolicy{
+		ID: "pol1", UserID: userID, ProfileID: "1", Type: "health", IsActive: true,
+		Deductible: 1000, OutOfPocketMax: 4000, CoveragePercentage: 80,
+		StartDate: time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, time.June, 30, 0, 0, 0, 0, time.UTC),
+	}
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil).Twice()
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return([]*domain.MedicalCondition{}, nil).Twice()
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return([]*domain.MedicalExpense{}, nil).Twice()
+	// The policy's last day is June 30th; from July 1st it is no longer active
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{policy}, nil).Once()
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil).Once()
+
+	// Act
+	first, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+	now = now.Add(14 * time.Hour)
+	later, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+	now = now.Add(2 * time.Hour)
+	nextDay, err := service.CalculateHealthSummary(context.Background(), userID)
+	require.NoError(t, err)
+
+	// Assert - the same day is served from the cache; the next day is recomputed without the policy
+	assert.Len(t, first.OutOfPocketStatuses, 1)
+	assert.Equal(t, first, later)
+	assert.Empty(t, nextDay.OutOfPocketStatuses)
+	mockProfileRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_CalculateHealthSummary_ConcurrentReadsDuringWrites(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	conditionRepo := &memoryConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		conditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+
+	userID := "user123"
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 25, Gender: "female", Height: 165, Weight: 60, BMI: 22.0, FamilySize: 1, UpdatedAt: time.Now(),
+	}
+
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return([]*domain.MedicalExpense{}, nil)
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil)
+
+	const readers = 8
+	const writes = 20
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				_, err := service.CalculateHealthSummary(context.Background(), userID)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	// Act & Assert - every write must be visible to the very next read
+	for i := 1; i <= writes; i++ {
+		err := service.AddCondition(context.Background(), &domain.MedicalCondition{
+			UserID: userID, ProfileID: "1", Name: fmt.Sprintf("Condition %d", i),
+			Category: "chronic", Severity: "mild", DiagnosedDate: time.Now(), IsActive: true,
+		})
+		require.NoError(t, err)
+
+		summary, err := service.CalculateHealthSummary(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, i*2, summary.HealthRiskScore, "summary after write %d should include the new condition", i)
+	}
+
+	close(done)
+	wg.Wait()
+}
+
+func TestHealthService_CostProjectionAndRiskWhatIf_ReuseCachedSummary(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	conditionRepo := &memoryConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		conditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+	)
+
+	ctx := context.Background()
+	userID := "user123"
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 25, Gender: "female", Height: 165, Weight: 60, BMI: 22.0, FamilySize: 1,
+		RelationToOwner: domain.RelationSelf, UpdatedAt: time.Now(),
+	}
+	expenses := []*domain.MedicalExpense{
+		{ID: "1", UserID: userID, ProfileID: "1", Amount: 100, Category: "medication", Description: "Refill", Frequency: "monthly", OutOfPocket: 100, Date: time.Now()},
+	}
+
+	// Once for the first summary, once for the new condition's profile and once after the write.
+	// GetByUserID is never called: the projection and what-if take the profile from the summary.
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil).Times(3)
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return(expenses, nil).Twice()
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil).Twice()
+
+	// Act - a summary, then the endpoints built on the same data
+	summary, err := service.CalculateHealthSummary(ctx, userID)
+	require.NoError(t, err)
+	repeat, err := service.CalculateHealthSummary(ctx, userID)
+	require.NoError(t, err)
+	projection, err := service.GetCostProjection(ctx, userID)
+	require.NoError(t, err)
+	whatIf, err := service.EvaluateRiskWhatIf(ctx, userID, RiskWhatIf{})
+	require.NoError(t, err)
+
+	// Assert - all served from the cached summary
+	assert.Equal(t, summary, repeat)
+	assert.Equal(t, userID, projection.UserID)
+	assert.Equal(t, summary.HealthRiskScore, whatIf.CurrentScore)
+
+	// Act - a new condition invalidates the cache, and the next summary scores it
+	require.NoError(t, service.AddCondition(ctx, &domain.MedicalCondition{
+		UserID: userID, ProfileID: "1", Name: "Diabetes", Category: "chronic", Severity: "severe",
+		DiagnosedDate: time.Now(), IsActive: true,
+	}))
+	whatIfAfter, err := service.EvaluateRiskWhatIf(ctx, userID, RiskWhatIf{})
+	require.NoError(t, err)
+	summaryAfter, err := service.CalculateHealthSummary(ctx, userID)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Greater(t, summaryAfter.HealthRiskScore, summary.HealthRiskScore)
+	assert.Equal(t, summaryAfter.HealthRiskScore, whatIfAfter.CurrentScore)
+	mockProfileRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthService_CalculateHealthSummary_CacheDisabled(t *testing.T) {
+	// Arrange
+	mockProfileRepo := &MockHealthProfileRepository{}
+	mockConditionRepo := &MockMedicalConditionRepository{}
+	mockExpenseRepo := &MockMedicalExpenseRepository{}
+	mockPolicyRepo := &MockInsurancePolicyRepository{}
+
+	service := NewHealthService(
+		mockProfileRepo,
+		mockConditionRepo,
+		mockExpenseRepo,
+		mockPolicyRepo,
+		&MockMedicationScheduleRepository{},
+		NewRiskCalculator(domain.DefaultRiskModel()),
+		NewMedicalCostAnalyzer(),
+		NewInsuranceEvaluator(),
+		WithHealthSummaryCache(false),
+	)
+
+	userID := "user123"
+	profile := &domain.HealthProfile{
+		ID: "1", UserID: userID, Age: 35, Gender: "male", Height: 180, Weight: 75, BMI: 23.1, FamilySize: 1, UpdatedAt: time.Now(),
+	}
+	mockProfileRepo.On("GetFamilyByUserID", mock.Anything, userID).Return([]*domain.HealthProfile{profile}, nil).Twice()
+	mockConditionRepo.On("GetByUserID", mock.Anything, userID, true).Return([]*domain.MedicalCondition{}, nil).Twice()
+	mockExpenseRepo.On("GetByUserID", mock.Anything, userID).Return([]*domain.MedicalExpense{}, nil).Twice()
+	mockPolicyRepo.On("GetActivePolicies", mock.Anything, userID).Return([]*domain.InsurancePolicy{}, nil).Twice()
+
+	// Act
+	for i := 0; i < 2; i++ {
+		_, err := service.CalculateHealthSummary(context.Background(), userID)
+		require.NoError(t, err)
+	}
+
+	// Assert - every call read the repositories
+	mockProfileRepo.AssertExpectations(t)
+	mockConditionRepo.AssertExpectations(t)
+	mockExpenseRepo.AssertExpectations(t)
+	mockPolicyRepo.AssertExpectations(t)
+}
+
+func TestHealthSummaryCache_ReturnsCopies(t *testing.T) {
+	cache := newHealthSummaryCache(true)
+	summary := &domain.HealthSummary{
+		UserID:              "user123",
+		MemberExpenses:      []domain.MemberMedicalExpenses{{ProfileID: "1", MonthlyMedicalExpenses: 100}},
+		OutOfPocketStatuses: []domain.OutOfPocketStatus{{PolicyID: "1", Remaining: 500}},
+		RecurringVariance:   &domain.RecurringExpenseVariance{Variance: 25, ReconciledMonths: 3},
+	}
+	_, version, _ := cache.get("user123")
+	cache.put("user123", version, summary, &healthSummaryBase{})
+
+	// Changing the stored summary or a served copy leaves the cached entry alone
+	summary.OutOfPocketStatuses[0].Remaining = 0
+	served, _, ok := cache.get("user123")
+	require.True(t, ok)
+	served.MemberExpenses[0].MonthlyMedicalExpenses = 0
+	served.OutOfPocketStatuses[0].Remaining = 0
+	served.RecurringVariance.Variance = 0
+
+	cached, _, ok := cache.get("user123")
+	require.True(t, ok)
+	assert.Equal(t, 100.0, cached.MemberExpenses[0].MonthlyMedicalExpenses)
+	assert.Equal(t, 500.0, cached.OutOfPocketStatuses[0].Remaining)
+	assert.Equal(t, 25.0, cached.RecurringVariance.Variance)
+	assert.NotSame(t, served.RecurringVariance, cached.RecurringVariance)
+}
@@ -1,17 +1,83 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 )
 
+// policyTypeCoveredCategories lists the medical expense categories each policy type pays for.
+// Dental and vision plans do not pay for general medical care.
+var policyTypeCoveredCategories = map[string][]string{
+	"health":        {"doctor_visit", "medication", "hospital", "lab_test", "therapy", "equipment"},
+	"comprehensive": {"doctor_visit", "medication", "hospital", "lab_test", "therapy", "equipment"},
+	"dental":        {},
+	"vision":        {"equipment"},
+}
+
+// policyCoversCategory reports whether a policy type pays for an expense category
+func policyCoversCategory(policyType, category string) bool {
+	for _, covered := range policyTypeCoveredCategories[policyType] {
+		if covered == category {
+			return true
+		}
+	}
+	return false
+}
+
+// DefaultOutOfPocketWarningPercent is how close to its out-of-pocket maximum, in percent of the
+// maximum, a policy must be for OutOfPocketStatus to flag it
+const DefaultOutOfPocketWarningPercent = 10.0
+
 // insuranceEvaluator implements the InsuranceEvaluator interface
-type insuranceEvaluator struct{}
+type insuranceEvaluator struct {
+	oopWarningPercent float64
+}
+
+// InsuranceEvaluatorOption customizes an insurance evaluator created by NewInsuranceEvaluator
+type InsuranceEvaluatorOption func(*insuranceEvaluator)
+
+// WithOutOfPocketWarningPercent flags policies within percent of their out-of-pocket maximum.
+// Values outside (0, 100] keep DefaultOutOfPocketWarningPercent.
+func WithOutOfPocketWarningPercent(percent float64) InsuranceEvaluatorOption {
+	return func(i *insuranceEvaluator) {
+		if percent > 0 && percent <= 100 {
+			i.oopWarningPercent = percent
+		}
+	}
+}
 
 // NewInsuranceEvaluator creates a new insurance evaluator instance
-func NewInsuranceEvaluator() InsuranceEvaluator {
-	return &insuranceEvaluator{}
+func NewInsuranceEvaluator(opts ...InsuranceEvaluatorOption) InsuranceEvaluator {
+	evaluator := &insuranceEvaluator{oopWarningPercent: DefaultOutOfPocketWarningPercent}
+	for _, opt := range opts {
+		opt(evaluator)
+	}
+	return evaluator
+}
+
+// OutOfPocketStatus reports how much of the policy's out-of-pocket maximum has been paid and
+// flags it once within the warning percent of the maximum, when further covered care is nearly free
+func (i *insuranceEvaluator) OutOfPocketStatus(policy *domain.InsurancePolicy) domain.OutOfPocketStatus {
+	percent := policy.OutOfPocketPercentReached()
+	maxReached := policy.OutOfPocketMax <= 0 || policy.IsOutOfPocketMaxReached()
+	// The tolerance keeps float error in the division from moving a policy across the threshold
+	nearMax := maxReached || percent >= 100-i.oopWarningPercent-1e-9
+
+	return domain.OutOfPocketStatus{
+		PolicyID:           policy.ID,
+		PolicyNumber:       policy.PolicyNumber,
+		OutOfPocketMax:     policy.OutOfPocketMax,
+		OutOfPocketCurrent: policy.OutOfPocketCurrent,
+		Remaining:          policy.GetRemainingOutOfPocket(),
+		PercentReached:     math.Round(percent*100) / 100,
+		MaxReached:         maxReached,
+		NearMax:            nearMax,
+	}
 }
 
 // CalculateCoverage applies deductible, then percentage, respects max out-of-pocket
@@ -273,3 +339,316 @@ func (i *insuranceEvaluator) TrackDeductibleProgress(policy *domain.InsurancePol
 	}, nil
 }
 
+// ComparePolicies projects the total annual cost (premiums + out-of-pocket) of each policy
+// for the expected annual spend and ranks them from cheapest to most expensive.
+// Each policy is evaluated as at the start of a plan year, with no deductible or
+// out-of-pocket progress, so plans are compared on equal terms.
+func (i *insuranceEvaluator) ComparePolicies(policies []domain.InsurancePolicy, expectedAnnualSpend float64) (*PolicyComparison, error) {
+	if len(policies) == 0 {
+		return nil, fmt.Errorf("at least one policy is required for comparison")
+	}
+
+	if expectedAnnualSpend < 0 {
+		return nil, fmt.Errorf("expected annual spend must be non-negative")
+	}
+
+	projections := make([]PolicyCostProjection, 0, len(policies))
+	for _, policy := range policies {
+		fresh := policy
+		fresh.DeductibleMet = 0
+		fresh.OutOfPocketCurrent = 0
+		fresh.IsActive = true
+
+		insuranceCoverage, outOfPocket := 0.0, 0.0
+		if expectedAnnualSpend > 0 {
+			coverage, err := i.CalculateCoverage(&fresh, expectedAnnualSpend)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate coverage for policy %s: %w", policy.PolicyNumber, err)
+			}
+			insuranceCoverage = coverage.TotalCovered
+			outOfPocket = coverage.PatientPays
+		}
+
+		annualPremium := fresh.GetAnnualPremium()
+		projections = append(projections, PolicyCostProjection{
+			PolicyID:             policy.ID,
+			PolicyNumber:         policy.PolicyNumber,
+			Provider:             policy.Provider,
+			Type:                 policy.Type,
+			AnnualPremium:        annualPremium,
+			InsuranceCoverage:    insuranceCoverage,
+			ProjectedOutOfPocket: outOfPocket,
+			TotalProjectedCost:   annualPremium + outOfPocket,
+		})
+	}
+
+	sort.SliceStable(projections, func(a, b int) bool {
+		return projections[a].TotalProjectedCost < projections[b].TotalProjectedCost
+	})
+	for idx := range projections {
+		projections[idx].Rank = idx + 1
+	}
+
+	return &PolicyComparison{
+		ExpectedAnnualSpend: expectedAnnualSpend,
+		Policies:            projections,
+		Cheapest:            projections[0],
+	}, nil
+}
+
+// adequacyStandard is the least coverage a policy should offer a user at a given risk level
+type adequacyStandard struct {
+	minCoveragePercentage float64
+	maxOutOfPocket        float64
+}
+
+// adequacyStandards tightens what counts as adequate coverage as risk rises, since a
+// higher-risk user is more likely to reach their out-of-pocket maximum
+var adequacyStandards = map[string]adequacyStandard{
+	domain.RiskLevelLow:      {minCoveragePercentage: 60, maxOutOfPocket: 9000},
+	domain.RiskLevelModerate: {minCoveragePercentage: 70, maxOutOfPocket: 7000},
+	domain.RiskLevelHigh:     {minCoveragePercentage: 80, maxOutOfPocket: 5000},
+	domain.RiskLevelCritical: {minCoveragePercentage: 90, maxOutOfPocket: 3000},
+}
+
+// EvaluateAdequacy projects what each policy would pay and what the user would pay out of
+// pocket for a year of the projected expenses, and flags policies that don't suit the user's
+// risk level. Like ComparePolicies, each policy is evaluated as at the start of a plan year.
+func (i *insuranceEvaluator) EvaluateAdequacy(policies []domain.InsurancePolicy, projectedAnnualExpenses float64, riskLevel string) (*InsuranceAdequacy, error) {
+	if projectedAnnualExpenses < 0 {
+		return nil, fmt.Errorf("projected annual expenses must be non-negative")
+	}
+
+	standard, ok := adequacyStandards[riskLevel]
+	if !ok {
+		return nil, fmt.Errorf("unknown risk level %q", riskLevel)
+	}
+
+	adequacy := &InsuranceAdequacy{
+		RiskLevel:               riskLevel,
+		ProjectedAnnualExpenses: projectedAnnualExpenses,
+		Policies:                make([]PolicyAdequacy, 0, len(policies)),
+	}
+
+	for _, policy := range policies {
+		fresh := policy
+		fresh.DeductibleMet = 0
+		fresh.OutOfPocketCurrent = 0
+		fresh.IsActive = true
+
+		benefit, outOfPocket := 0.0, 0.0
+		if projectedAnnualExpenses > 0 {
+			coverage, err := i.CalculateCoverage(&fresh, projectedAnnualExpenses)
+			if err != nil {
+				return nil, fmt.Errorf("failed to calculate coverage for policy %s: %w", policy.PolicyNumber, err)
+			}
+			benefit = coverage.TotalCovered
+			outOfPocket = coverage.PatientPays
+		}
+
+		annualPremium := fresh.GetAnnualPremium()
+		result := PolicyAdequacy{
+			PolicyID:                 policy.ID,
+			PolicyNumber:             policy.PolicyNumber,
+			Provider:                 policy.Provider,
+			Type:                     policy.Type,
+			AnnualPremium:            annualPremium,
+			ExpectedBenefit:          benefit,
+			ExpectedOutOfPocket:      outOfPocket,
+			CoverageSufficient:       policy.CoveragePercentage >= standard.minCoveragePercentage,
+			OutOfPocketMaxSufficient: policy.OutOfPocketMax <= standard.maxOutOfPocket,
+			Flags:                    make([]string, 0),
+		}
+		if benefit > 0 {
+			result.PremiumToBenefitRatio = annualPremium / benefit
+		}
+
+		if policy.Deductible > projectedAnnualExpenses {
+			result.Flags = append(result.Flags, fmt.Sprintf(
+				"Your deductible of %.2f exceeds your projected annual expenses of %.2f, so this policy is unlikely to pay out; consider a lower-premium plan",
+				policy.Deductible, projectedAnnualExpenses))
+		}
+		if !result.CoverageSufficient {
+			result.Flags = append(result.Flags, fmt.Sprintf(
+				"Coverage of %.0f%% is below the %.0f%% recommended for your %s risk level",
+				policy.CoveragePercentage, standard.minCoveragePercentage, riskLevel))
+		}
+		if !result.OutOfPocketMaxSufficient {
+			result.Flags = append(result.Flags, fmt.Sprintf(
+				"Out-of-pocket maximum of %.2f is above the %.2f recommended for your %s risk level",
+				policy.OutOfPocketMax, standard.maxOutOfPocket, riskLevel))
+		}
+		if annualPremium > benefit && policy.Deductible <= projectedAnnualExpenses {
+			result.Flags = append(result.Flags, fmt.Sprintf(
+				"Annual premiums of %.2f exceed the %.2f this policy is expected to pay",
+				annualPremium, benefit))
+		}
+
+		adequacy.Policies = append(adequacy.Policies, result)
+	}
+
+	return adequacy, nil
+}
+
+// AnalyzeCoverageGaps reports expense categories no active policy covers, the share of
+// recurring costs left uncovered, and periods with no policy in force
+func (i *insuranceEvaluator) AnalyzeCoverageGaps(ctx context.Context, profile *domain.HealthProfile, policies []domain.InsurancePolicy, expenses []domain.MedicalExpense) (*CoverageGapAnalysis, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return nil, fmt.Errorf("health profile is required")
+	}
+
+	analysis := &CoverageGapAnalysis{
+		UserID:              profile.UserID,
+		UncoveredCategories: make([]UncoveredCategory, 0),
+		CoverageLapses:      make([]CoverageLapse, 0),
+		Gaps:                make([]CoverageGap, 0),
+	}
+
+	// Categories covered by at least one active policy
+	covered := make(map[string]bool)
+	for _, policy := range policies {
+		if !policy.IsActive {
+			continue
+		}
+		for _, category := range policyTypeCoveredCategories[policy.Type] {
+			covered[category] = true
+		}
+	}
+
+	// Group uncovered expenses by category and split recurring costs
+	uncoveredByCategory := make(map[string]*UncoveredCategory)
+	for _, expense := range expenses {
+		monthly := 0.0
+		if expense.IsRecurring {
+			monthly = expense.GetAnnualizedCost() / 12
+			analysis.RecurringMonthlyCost += monthly
+		}
+
+		if covered[expense.Category] {
+			continue
+		}
+
+		analysis.UncoveredRecurringMonthly += monthly
+		entry, ok := uncoveredByCategory[expense.Category]
+		if !ok {
+			entry = &UncoveredCategory{Category: expense.Category}
+			uncoveredByCategory[expense.Category] = entry
+		}
+		entry.ExpenseCount++
+		entry.TotalAmount += expense.Amount
+	}
+
+	for _, entry := range uncoveredByCategory {
+		analysis.UncoveredCategories = append(analysis.UncoveredCategories, *entry)
+	}
+	sort.Slice(analysis.UncoveredCategories, func(a, b int) bool {
+		return analysis.UncoveredCategories[a].TotalAmount > analysis.UncoveredCategories[b].TotalAmount
+	})
+
+	if analysis.RecurringMonthlyCost > 0 {
+		analysis.UncoveredRecurringShare = analysis.UncoveredRecurringMonthly / analysis.RecurringMonthlyCost * 100
+	}
+
+	analysis.CoverageLapses = i.findCoverageLapses(policies, expenses, time.Now())
+
+	// Describe each gap concretely
+	for _, category := range analysis.UncoveredCategories {
+		analysis.Gaps = append(analysis.Gaps, CoverageGap{
+			Type:              "uncovered_category",
+			Description:       fmt.Sprintf("%d %s expense(s) totalling $%.2f are not covered by any active policy", category.ExpenseCount, category.Category, category.TotalAmount),
+			RiskLevel:         exposureRiskLevel(category.TotalAmount),
+			Recommendation:    fmt.Sprintf("Add a policy that covers %s expenses", category.Category),
+			EstimatedExposure: category.TotalAmount,
+		})
+	}
+
+	if analysis.UncoveredRecurringMonthly > 0 {
+		annualExposure := analysis.UncoveredRecurringMonthly * 12
+		analysis.Gaps = append(analysis.Gaps, CoverageGap{
+			Type:              "uncovered_recurring_costs",
+			Description:       fmt.Sprintf("$%.2f/month (%.1f%%) of recurring medical costs is not covered by any active policy", analysis.UncoveredRecurringMonthly, analysis.UncoveredRecurringShare),
+			RiskLevel:         exposureRiskLevel(annualExposure),
+			Recommendation:    "Review whether your recurring treatments are included in your plan's benefits",
+			EstimatedExposure: annualExposure,
+		})
+	}
+
+	for _, lapse := range analysis.CoverageLapses {
+		analysis.Gaps = append(analysis.Gaps, CoverageGap{
+			Type:              "coverage_lapse",
+			Description:       fmt.Sprintf("No policy was in force from %s to %s (%d days)", lapse.StartDate.Format("2006-01-02"), lapse.EndDate.Format("2006-01-02"), lapse.Days),
+			RiskLevel:         "high",
+			Recommendation:    "Avoid gaps between policy periods by renewing before the end date",
+			EstimatedExposure: 0,
+		})
+	}
+
+	return analysis, nil
+}
+
+// findCoverageLapses returns the periods between the earliest policy or expense date and now
+// during which no policy was in force
+func (i *insuranceEvaluator) findCoverageLapses(policies []domain.InsurancePolicy, expenses []domain.MedicalExpense, now time.Time) []CoverageLapse {
+	lapses := make([]CoverageLapse, 0)
+
+	windowStart := now
+	for _, policy := range policies {
+		if !policy.StartDate.IsZero() && policy.StartDate.Before(windowStart) {
+			windowStart = policy.StartDate
+		}
+	}
+	for _, expense := range expenses {
+		if !expense.Date.IsZero() && expense.Date.Before(windowStart) {
+			windowStart = expense.Date
+		}
+	}
+
+	periods := make([]domain.InsurancePolicy, 0, len(policies))
+	for _, policy := range policies {
+		if !policy.StartDate.IsZero() && policy.EndDate.After(policy.StartDate) {
+			periods = append(periods, policy)
+		}
+	}
+	sort.Slice(periods, func(a, b int) bool {
+		return periods[a].StartDate.Before(periods[b].StartDate)
+	})
+
+	addLapse := func(start, end time.Time) {
+		days := int(end.Sub(start).Hours() / 24)
+		if days >= 1 {
+			lapses = append(lapses, CoverageLapse{StartDate: start, EndDate: end, Days: days})
+		}
+	}
+
+	coveredUntil := windowStart
+	for _, period := range periods {
+		if period.StartDate.After(coveredUntil) {
+			addLapse(coveredUntil, period.StartDate)
+		}
+		if period.EndDate.After(coveredUntil) {
+			coveredUntil = period.EndDate
+		}
+	}
+	if now.After(coveredUntil) {
+		addLapse(coveredUntil, now)
+	}
+
+	return lapses
+}
+
+// exposureRiskLevel maps an uncovered dollar amount to a risk level
+func exposureRiskLevel(amount float64) string {
+	switch {
+	case amount >= 10000:
+		return "critical"
+	case amount >= 3000:
+		return "high"
+	case amount >= 500:
+		return "moderate"
+	default:
+		return "low"
+	}
+}
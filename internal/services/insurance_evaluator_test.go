@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// comparisonPlans returns a high-premium/low-deductible plan and a low-premium/high-deductible plan
+func comparisonPlans() []domain.InsurancePolicy {
+	return []domain.InsurancePolicy{
+		{
+			ID:                 "gold",
+			PolicyNumber:       "GOLD-001",
+			Provider:           "Acme Health",
+			Type:               "health",
+			MonthlyPremium:     500,
+			Deductible:         250,
+			OutOfPocketMax:     2000,
+			CoveragePercentage: 90,
+			IsActive:           true,
+		},
+		{
+			ID:                 "bronze",
+			PolicyNumber:       "BRONZE-001",
+			Provider:           "Acme Health",
+			Type:               "health",
+			MonthlyPremium:     200,
+			Deductible:         5000,
+			OutOfPocketMax:     7000,
+			CoveragePercentage: 70,
+			IsActive:           true,
+		},
+	}
+}
+
+func TestInsuranceEvaluator_ComparePolicies(t *testing.T) {
+	tests := []struct {
+		name                string
+		expectedAnnualSpend float64
+		expectedCheapest    string
+		expectedTotals      map[string]float64
+	}{
+		{
+			name:                "low utilizer favours low premium plan",
+			expectedAnnualSpend: 500,
+			expectedCheapest:    "bronze",
+			expectedTotals: map[string]float64{
+				"bronze": 2400 + 500,      // all spend falls under the deductible
+				"gold":   6000 + 250 + 25, // deductible plus 10% coinsurance on the remaining 250
+			},
+		},
+		{
+			name:                "high utilizer favours low deductible plan",
+			expectedAnnualSpend: 50000,
+			expectedCheapest:    "gold",
+			expectedTotals: map[string]float64{
+				"gold":   6000 + 2000, // capped at out-of-pocket max
+				"bronze": 2400 + 7000, // capped at out-of-pocket max
+			},
+		},
+		{
+			name:                "no expected spend ranks by premium alone",
+			expectedAnnualSpend: 0,
+			expectedCheapest:    "bronze",
+			expectedTotals: map[string]float64{
+				"gold":   6000,
+				"bronze": 2400,
+			},
+		},
+	}
+
+	evaluator := NewInsuranceEvaluator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			comparison, err := evaluator.ComparePolicies(comparisonPlans(), tt.expectedAnnualSpend)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, comparison.Policies, 2)
+			assert.Equal(t, tt.expectedCheapest, comparison.Cheapest.PolicyID)
+			assert.Equal(t, 1, comparison.Cheapest.Rank)
+			for i, projection := range comparison.Policies {
+				assert.Equal(t, i+1, projection.Rank)
+				assert.InDelta(t, tt.expectedTotals[projection.PolicyID], projection.TotalProjectedCost, 0.01)
+			}
+		})
+	}
+}
+
+func TestInsuranceEvaluator_ComparePolicies_IgnoresCurrentProgress(t *testing.T) {
+	// Arrange - a plan whose deductible is already met this year is compared from a fresh start
+	plans := comparisonPlans()
+	plans[1].DeductibleMet = plans[1].Deductible
+	plans[1].OutOfPocketCurrent = plans[1].Deductible
+
+	// Act
+	comparison, err := NewInsuranceEvaluator().ComparePolicies(plans, 500)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "bronze", comparison.Cheapest.PolicyID)
+	assert.InDelta(t, 500.0, comparison.Cheapest.ProjectedOutOfPocket, 0.01)
+}
+
+func TestInsuranceEvaluator_ComparePolicies_InvalidInput(t *testing.T) {
+	evaluator := NewInsuranceEvaluator()
+
+	_, err := evaluator.ComparePolicies(nil, 1000)
+	assert.Error(t, err)
+
+	_, err = evaluator.ComparePolicies(comparisonPlans(), -1)
+	assert.Error(t, err)
+}
+
+func TestInsuranceEvaluator_OutOfPocketStatus_WarningBoundary(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []InsuranceEvaluatorOption
+		max             float64
+		current         float64
+		expectedPercent float64
+		expectNearMax   bool
+		expectReached   bool
+	}{
+		{name: "just below the default threshold", max: 1000, current: 899, expectedPercent: 89.9, expectNearMax: false},
+		{name: "exactly at the default threshold", max: 1000, current: 900, expectedPercent: 90, expectNearMax: true},
+		{name: "already at the maximum", max: 1000, current: 1000, expectedPercent: 100, expectNearMax: true, expectReached: true},
+		{name: "very low maximum nearly reached", max: 5, current: 4.6, expectedPercent: 92, expectNearMax: true},
+		{name: "very low maximum untouched", max: 5, current: 0, expectedPercent: 0, expectNearMax: false},
+		{name: "custom threshold", opts: []InsuranceEvaluatorOption{WithOutOfPocketWarningPercent(25)}, max: 1000, current: 750, expectedPercent: 75, expectNearMax: true},
+		{name: "out of range threshold keeps default", opts: []InsuranceEvaluatorOption{WithOutOfPocketWarningPercent(150)}, max: 1000, current: 100, expectedPercent: 10, expectNearMax: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &domain.InsurancePolicy{ID: "policy-1", PolicyNumber: "POL-1", OutOfPocketMax: tt.max, OutOfPocketCurrent: tt.current}
+
+			status := NewInsuranceEvaluator(tt.opts...).OutOfPocketStatus(policy)
+
+			assert.InDelta(t, tt.expectedPercent, status.PercentReached, 0.01)
+			assert.Equal(t, tt.expectNearMax, status.NearMax)
+			assert.Equal(t, tt.expectReached, status.MaxReached)
+			assert.InDelta(t, tt.max-tt.current, status.Remaining, 0.0001)
+			assert.Equal(t, "policy-1", status.PolicyID)
+		})
+	}
+}
+
+func TestInsuranceEvaluator_EvaluateAdequacy(t *testing.T) {
+	type expectedPolicy struct {
+		benefit, outOfPocket, ratio          float64
+		coverageSufficient, outOfPocketMaxOK bool
+		flags                                []string
+	}
+
+	tests := []struct {
+		name      string
+		riskLevel string
+		projected float64
+		expected  map[string]expectedPolicy
+	}{
+		{
+			name:      "low-risk user with little spending",
+			riskLevel: domain.RiskLevelLow,
+			projected: 500,
+			expected: map[string]expectedPolicy{
+				// 250 deductible, then 90% of the remaining 250
+				"gold": {benefit: 225, outOfPocket: 275, ratio: 6000.0 / 225, coverageSufficient: true, outOfPocketMaxOK: true,
+					flags: []string{"Annual premiums of 6000.00 exceed the 225.00 this policy is expected to pay"}},
+				// all spend falls under the deductible
+				"bronze": {benefit: 0, outOfPocket: 500, ratio: 0, coverageSufficient: true, outOfPocketMaxOK: true,
+					flags: []string{"Your deductible of 5000.00 exceeds your projected annual expenses of 500.00, so this policy is unlikely to pay out; consider a lower-premium plan"}},
+			},
+		},
+		{
+			name:      "high-risk user with large spending",
+			riskLevel: domain.RiskLevelHigh,
+			projected: 50000,
+			expected: map[string]expectedPolicy{
+				// capped at the out-of-pocket maximum
+				"gold": {benefit: 48000, outOfPocket: 2000, ratio: 6000.0 / 48000, coverageSufficient: true, outOfPocketMaxOK: true,
+					flags: []string{}},
+				"bronze": {benefit: 43000, outOfPocket: 7000, ratio: 2400.0 / 43000, coverageSufficient: false, outOfPocketMaxOK: false,
+					flags: []string{
+						"Coverage of 70% is below the 80% recommended for your high risk level",
+						"Out-of-pocket maximum of 7000.00 is above the 5000.00 recommended for your high risk level",
+					}},
+			},
+		},
+	}
+
+	evaluator := NewInsuranceEvaluator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			adequacy, err := evaluator.EvaluateAdequacy(comparisonPlans(), tt.projected, tt.riskLevel)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, tt.riskLevel, adequacy.RiskLevel)
+			assert.Equal(t, tt.projected, adequacy.ProjectedAnnualExpenses)
+			require.Len(t, adequacy.Policies, 2)
+			for _, policy := range adequacy.Policies {
+				expected := tt.expected[policy.PolicyID]
+				assert.InDelta(t, expected.benefit, policy.ExpectedBenefit, 0.01, policy.PolicyID)
+				assert.InDelta(t, expected.outOfPocket, policy.ExpectedOutOfPocket, 0.01, policy.PolicyID)
+				assert.InDelta(t, expected.ratio, policy.PremiumToBenefitRatio, 0.0001, policy.PolicyID)
+				assert.Equal(t, expected.coverageSufficient, policy.CoverageSufficient, policy.PolicyID)
+				assert.Equal(t, expected.outOfPocketMaxOK, policy.OutOfPocketMaxSufficient, policy.PolicyID)
+				assert.Equal(t, expected.flags, policy.Flags, policy.PolicyID)
+			}
+		})
+	}
+}
+
+func TestInsuranceEvaluator_EvaluateAdequacy_InvalidInput(t *testing.T) {
+	evaluator := NewInsuranceEvaluator()
+
+	_, err := evaluator.EvaluateAdequacy(comparisonPlans(), -1, domain.RiskLevelLow)
+	assert.Error(t, err)
+
+	_, err = evaluator.EvaluateAdequacy(comparisonPlans(), 1000, "unknown")
+	assert.Error(t, err)
+
+	adequacy, err := evaluator.EvaluateAdequacy(nil, 1000, domain.RiskLevelLow)
+	require.NoError(t, err)
+	assert.Empty(t, adequacy.Policies)
+}
+
+func TestInsuranceEvaluator_AnalyzeCoverageGaps_DentalOnlyPolicy(t *testing.T) {
+	// Arrange - the only policy is dental, so general medical expenses fall outside its scope
+	now := time.Now()
+	profile := &domain.HealthProfile{UserID: "user123"}
+	policies := []domain.InsurancePolicy{
+		{
+			ID:        "dental1",
+			Type:      "dental",
+			IsActive:  true,
+			StartDate: now.AddDate(-1, 0, 0),
+			EndDate:   now.AddDate(1, 0, 0),
+		},
+	}
+	expenses := []domain.MedicalExpense{
+		{Category: "medication", Amount: 100, IsRecurring: true, Frequency: "monthly", Date: now.AddDate(0, -1, 0)},
+		{Category: "medication", Amount: 100, IsRecurring: true, Frequency: "monthly", Date: now.AddDate(0, -2, 0)},
+		{Category: "doctor_visit", Amount: 250, Date: now.AddDate(0, -3, 0)},
+	}
+
+	// Act
+	analysis, err := NewInsuranceEvaluator().AnalyzeCoverageGaps(context.Background(), profile, policies, expenses)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, analysis.UncoveredCategories, 2)
+	assert.Equal(t, "doctor_visit", analysis.UncoveredCategories[0].Category)
+	assert.Equal(t, "medication", analysis.UncoveredCategories[1].Category)
+	assert.Equal(t, 2, analysis.UncoveredCategories[1].ExpenseCount)
+	assert.InDelta(t, 200.0, analysis.RecurringMonthlyCost, 0.01)
+	assert.InDelta(t, 200.0, analysis.UncoveredRecurringMonthly, 0.01)
+	assert.InDelta(t, 100.0, analysis.UncoveredRecurringShare, 0.01)
+	assert.Empty(t, analysis.CoverageLapses)
+
+	gapTypes := make([]string, 0, len(analysis.Gaps))
+	for _, gap := range analysis.Gaps {
+		gapTypes = append(gapTypes, gap.Type)
+		assert.NotEmpty(t, gap.Description)
+	}
+	assert.ElementsMatch(t, []string{"uncovered_category", "uncovered_category", "uncovered_recurring_costs"}, gapTypes)
+}
+
+func TestInsuranceEvaluator_AnalyzeCoverageGaps_HealthPolicyWithLapse(t *testing.T) {
+	// Arrange - two health policies with a two month gap between them
+	now := time.Now()
+	profile := &domain.HealthProfile{UserID: "user123"}
+	policies := []domain.InsurancePolicy{
+		{ID: "old", Type: "health", IsActive: false, StartDate: now.AddDate(-2, 0, 0), EndDate: now.AddDate(-1, 0, 0)},
+		{ID: "new", Type: "health", IsActive: true, StartDate: now.AddDate(-1, 2, 0), EndDate: now.AddDate(1, 0, 0)},
+	}
+	expenses := []domain.MedicalExpense{
+		{Category: "medication", Amount: 100, IsRecurring: true, Frequency: "monthly", Date: now.AddDate(0, -1, 0)},
+	}
+
+	// Act
+	analysis, err := NewInsuranceEvaluator().AnalyzeCoverageGaps(context.Background(), profile, policies, expenses)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, analysis.UncoveredCategories)
+	assert.Zero(t, analysis.UncoveredRecurringMonthly)
+	require.Len(t, analysis.CoverageLapses, 1)
+	assert.True(t, analysis.CoverageLapses[0].StartDate.Equal(policies[0].EndDate))
+	assert.True(t, analysis.CoverageLapses[0].EndDate.Equal(policies[1].StartDate))
+	assert.InDelta(t, 60, analysis.CoverageLapses[0].Days, 2)
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
@@ -19,25 +20,62 @@ type HealthService interface {
 	CreateProfile(ctx context.Context, profile *domain.HealthProfile) error
 	GetProfile(ctx context.Context, userID string) (*domain.HealthProfile, error)
 	UpdateProfile(ctx context.Context, profile *domain.HealthProfile) error
+	DeleteProfile(ctx context.Context, userID string) error
+	GetProfileHistory(ctx context.Context, userID string, months int) (*ProfileHistory, error)
+	CreateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error
+	GetFamilyProfiles(ctx context.Context, userID string) ([]domain.HealthProfile, error)
+	GetFamilyMember(ctx context.Context, userID, profileID string) (*domain.HealthProfile, error)
+	UpdateDependentProfile(ctx context.Context, profile *domain.HealthProfile) error
+	DeleteDependentProfile(ctx context.Context, userID, profileID string) error
+	GetFamilyHealthRollup(ctx context.Context, userID string) (*FamilyHealthRollup, error)
 	
 	// Medical conditions
 	AddCondition(ctx context.Context, condition *domain.MedicalCondition) error
 	GetConditions(ctx context.Context, userID string) ([]domain.MedicalCondition, error)
 	UpdateCondition(ctx context.Context, condition *domain.MedicalCondition) error
 	RemoveCondition(ctx context.Context, userID, conditionID string) error
+	DeleteCondition(ctx context.Context, userID, conditionID string) error
+	GetConditionTimeline(ctx context.Context, userID string) (*ConditionTimeline, error)
+	SearchConditionCatalog(ctx context.Context, query string, limit int) ([]domain.ConditionCatalogMatch, error)
 	
 	// Medical expenses
 	AddExpense(ctx context.Context, expense *domain.MedicalExpense) error
 	GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error)
+	DeleteExpense(ctx context.Context, userID, expenseID string) error
 	GetRecurringExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error)
+	GetExpenseAnalytics(ctx context.Context, userID string) (*MedicalExpenseAnalytics, error)
+	GetExpenseBreakdown(ctx context.Context, userID string) (*MedicalExpenseBreakdown, error)
+	AddExpenseOccurrence(ctx context.Context, userID, expenseID string, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error)
+	GetExpenseOccurrences(ctx context.Context, userID, expenseID string) ([]domain.MedicalExpenseOccurrence, error)
+	
+	// Medications
+	AddMedicationSchedule(ctx context.Context, schedule *domain.MedicationSchedule) error
+	GetUpcomingRefills(ctx context.Context, userID string, withinDays int) ([]UpcomingRefill, error)
 	
 	// Insurance policies
 	AddInsurancePolicy(ctx context.Context, policy *domain.InsurancePolicy) error
 	GetActivePolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error)
+	// GetPolicies returns all of the user's policies, including inactive and expired ones
+	GetPolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error)
+	DeleteInsurancePolicy(ctx context.Context, userID, policyID string) error
+	GetExpiringPolicies(ctx context.Context, userID string, withinDays int) ([]ExpiringPolicy, error)
+	GetOutOfPocketStatus(ctx context.Context, userID, policyID string) (*domain.OutOfPocketStatus, error)
 	UpdateDeductibleProgress(ctx context.Context, policyID string, amount float64) error
+	ComparePolicies(ctx context.Context, userID string, candidates []domain.InsurancePolicy, expectedAnnualSpend float64) (*PolicyComparison, error)
+	GetCoverageGaps(ctx context.Context, userID string) (*CoverageGapAnalysis, error)
+	EvaluateInsuranceAdequacy(ctx context.Context, userID string) (*InsuranceAdequacy, error)
 	
 	// Calculations & Analysis
 	CalculateHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, error)
+	GetCostProjection(ctx context.Context, userID string) (*AnnualCostProjection, error)
+	RecommendHSAContribution(ctx context.Context, userID string) (*HSARecommendation, error)
+	GetRiskModel() domain.RiskModel
+	SnapshotRisk(ctx context.Context, userID string) (*domain.RiskSnapshot, error)
+	GetRiskHistory(ctx context.Context, userID string, months int) (*RiskHistory, error)
+	EvaluateRiskWhatIf(ctx context.Context, userID string, whatIf RiskWhatIf) (*RiskWhatIfResult, error)
+
+	// Administration
+	RecalculateAllRisk(ctx context.Context) (*RiskRecalculation, error)
 }
 
 // RiskCalculator defines health risk calculation operations
@@ -46,14 +84,20 @@ type RiskCalculator interface {
 	AssessFinancialVulnerability(healthCosts, income float64) string
 	RecommendEmergencyFund(riskScore int, monthlyExpenses float64) float64
 	DetermineRiskLevel(score int) string
+	// Model returns the point values behind the scores so clients can explain them
+	Model() domain.RiskModel
 }
 
 // MedicalCostAnalyzer defines medical cost analysis operations
 type MedicalCostAnalyzer interface {
 	CalculateMonthlyAverage(expenses []domain.MedicalExpense) float64
+	NormalizeMedicalExpenseToMonthly(expense domain.MedicalExpense) (float64, error)
 	ProjectAnnualCosts(expenses []domain.MedicalExpense, conditions []domain.MedicalCondition) float64
+	ReconcileRecurringExpenses(expenses []domain.MedicalExpense, occurrences []domain.MedicalExpenseOccurrence, now time.Time) domain.RecurringExpenseVariance
 	IdentifyCostReductionOpportunities(expenses []domain.MedicalExpense) []CostReductionOpportunity
 	AnalyzeTrends(expenses []domain.MedicalExpense) []string
+	BreakdownByCategory(expenses []domain.MedicalExpense) *MedicalExpenseBreakdown
+	ProjectAnnualCost(profile *domain.HealthProfile, expenses []domain.MedicalExpense, policies []domain.InsurancePolicy) (*AnnualCostProjection, error)
 }
 
 // InsuranceEvaluator defines insurance evaluation and coverage operations
@@ -62,6 +106,10 @@ type InsuranceEvaluator interface {
 	EvaluateCoverageGaps(policies []domain.InsurancePolicy, conditions []domain.MedicalCondition, expenses []domain.MedicalExpense) []CoverageGap
 	RecommendPolicyAdjustments(policies []domain.InsurancePolicy, expenses []domain.MedicalExpense) []PolicyRecommendation
 	TrackDeductibleProgress(policy *domain.InsurancePolicy, newExpenseAmount float64) (*DeductibleUpdate, error)
+	ComparePolicies(policies []domain.InsurancePolicy, expectedAnnualSpend float64) (*PolicyComparison, error)
+	EvaluateAdequacy(policies []domain.InsurancePolicy, projectedAnnualExpenses float64, riskLevel string) (*InsuranceAdequacy, error)
+	AnalyzeCoverageGaps(ctx context.Context, profile *domain.HealthProfile, policies []domain.InsurancePolicy, expenses []domain.MedicalExpense) (*CoverageGapAnalysis, error)
+	OutOfPocketStatus(policy *domain.InsurancePolicy) domain.OutOfPocketStatus
 }
 
 // CostReductionOpportunity represents a cost reduction opportunity
@@ -77,6 +125,7 @@ type CostReductionOpportunity struct {
 // HealthProfileRepository defines the interface for health profile persistence
 type HealthProfileRepository interface {
 	// CRUD operations
+	// Create returns domain.ErrProfileAlreadyExists if the user already has a self profile
 	Create(ctx context.Context, profile *domain.HealthProfile) (*domain.HealthProfile, error)
 	GetByID(ctx context.Context, id uint) (*domain.HealthProfile, error)
 	GetByUserID(ctx context.Context, userID string) (*domain.HealthProfile, error)
@@ -86,6 +135,24 @@ type HealthProfileRepository interface {
 	// Business queries
 	GetWithRelations(ctx context.Context, userID string) (*domain.HealthProfile, error)
 	ExistsByUserID(ctx context.Context, userID string) (bool, error)
+	GetFamilyByUserID(ctx context.Context, userID string) ([]*domain.HealthProfile, error)
+
+	// History queries
+	GetSnapshots(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.ProfileSnapshot, error)
+
+	// Batch queries
+	// ListSelfProfiles retrieves up to limit self profiles with an ID above afterID, in ID order,
+	// so every user's profile can be visited one page at a time
+	ListSelfProfiles(ctx context.Context, afterID uint, limit int) ([]*domain.HealthProfile, error)
+}
+
+// HealthRiskSnapshotRepository defines the interface for health risk snapshot persistence
+type HealthRiskSnapshotRepository interface {
+	// Create assigns the snapshot its ID
+	Create(ctx context.Context, snapshot *domain.RiskSnapshot) (*domain.RiskSnapshot, error)
+	// GetByUserID retrieves up to limit of the user's most recent snapshots recorded since the
+	// given time, ordered oldest first
+	GetByUserID(ctx context.Context, userID string, since time.Time, limit int) ([]*domain.RiskSnapshot, error)
 }
 
 // MedicalConditionRepository defines the interface for medical condition persistence
@@ -108,6 +175,61 @@ type MedicalConditionRepository interface {
 	GetMedicationRequiringConditions(ctx context.Context, userID string) ([]*domain.MedicalCondition, error)
 }
 
+// ConditionCatalogRepository defines the interface for reading the condition catalog, which is
+// seeded by the migrations
+type ConditionCatalogRepository interface {
+	// List returns every catalog entry in name order
+	List(ctx context.Context) ([]domain.ConditionCatalogEntry, error)
+	// GetByID returns domain.ErrConditionCatalogEntryNotFound if the entry doesn't exist
+	GetByID(ctx context.Context, id string) (domain.ConditionCatalogEntry, error)
+}
+
+// MedicationScheduleRepository defines the interface for medication schedule persistence
+type MedicationScheduleRepository interface {
+	Create(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error)
+	GetByID(ctx context.Context, id string) (*domain.MedicationSchedule, error)
+	Update(ctx context.Context, schedule *domain.MedicationSchedule) (*domain.MedicationSchedule, error)
+	Delete(ctx context.Context, id string) error
+	GetActiveByUserID(ctx context.Context, userID string) ([]*domain.MedicationSchedule, error)
+}
+
+// APIKeyAuthenticator authenticates requests made with an API key
+// This interface is consumed by the API key authentication middleware
+type APIKeyAuthenticator interface {
+	// AuthenticateAPIKey returns the key and its owner and records that the key was used.
+	// Returns domain.ErrInvalidAPIKey, domain.ErrAPIKeyRevoked or domain.ErrAccountInactive
+	// when the request must be rejected.
+	AuthenticateAPIKey(ctx context.Context, rawKey string) (domain.APIKey, *domain.User, error)
+}
+
+// TokenUserChecker confirms that the user an access token was issued to still has an account
+// This interface is consumed by the JWT authentication middleware
+type TokenUserChecker interface {
+	// CheckTokenUser returns domain.ErrInvalidToken when the user no longer exists,
+	// domain.ErrAccountInactive when they have been deactivated and domain.ErrTokenRoleChanged
+	// when role, the token's role claim, is no longer theirs
+	CheckTokenUser(ctx context.Context, userID, role string) error
+}
+
+// HealthResourceOwnerLookup finds the user who owns a health resource
+// This interface is consumed by the health ownership middleware
+type HealthResourceOwnerLookup interface {
+	// GetResourceOwner returns the ID of the user who owns the resource of the given kind, such
+	// as HealthResourceCondition. Returns an error wrapping the resource's not found error, such
+	// as domain.ErrMedicalConditionNotFound, if it doesn't exist.
+	GetResourceOwner(ctx context.Context, resource, id string) (string, error)
+}
+
+// IdempotencyStore defines the interface for idempotency key persistence
+type IdempotencyStore interface {
+	// Reserve claims the record's (user, key) pair. When the pair is already held by an
+	// unexpired record, that record is returned with reserved set to false.
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord) (existing *domain.IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, userID, key string, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, userID, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
 // MedicalExpenseRepository defines the interface for medical expense persistence
 type MedicalExpenseRepository interface {
 	// CRUD operations
@@ -126,13 +248,53 @@ type MedicalExpenseRepository interface {
 	
 	// Aggregation operations
 	CalculateTotals(ctx context.Context, userID string, startDate, endDate time.Time) (*ExpenseTotals, error)
+	GetCategoryTotals(ctx context.Context, userID string, startDate, endDate time.Time) ([]CategoryTotals, error)
 	GetMonthlyRecurringTotal(ctx context.Context, userID string) (float64, error)
 	GetAnnualProjectedExpenses(ctx context.Context, userID string) (float64, error)
 }
 
+// MedicalExpenseOccurrenceRepository defines the interface for persisting the actual costs of
+// recurring medical expenses. Occurrences are deleted together with their expense.
+type MedicalExpenseOccurrenceRepository interface {
+	// Create assigns the occurrence its ID and creation time
+	Create(ctx context.Context, occurrence *domain.MedicalExpenseOccurrence) (*domain.MedicalExpenseOccurrence, error)
+	// GetByExpenseID returns an expense's occurrences ordered by date, oldest first
+	GetByExpenseID(ctx context.Context, expenseID string) ([]*domain.MedicalExpenseOccurrence, error)
+	// GetByUserID returns the user's occurrences dated on or after since, oldest first
+	GetByUserID(ctx context.Context, userID string, since time.Time) ([]*domain.MedicalExpenseOccurrence, error)
+}
+
+// ExpenseAttachmentRepository defines the interface for expense attachment metadata persistence
+// This interface is consumed by AttachmentService
+type ExpenseAttachmentRepository interface {
+	// Create assigns the attachment its ID and creation time
+	Create(ctx context.Context, attachment *domain.ExpenseAttachment) error
+	// GetByID returns domain.ErrAttachmentNotFound if the attachment doesn't exist
+	GetByID(ctx context.Context, id string) (domain.ExpenseAttachment, error)
+	// GetByExpenseID returns an expense's attachments, oldest first
+	GetByExpenseID(ctx context.Context, expenseID string) ([]domain.ExpenseAttachment, error)
+	// GetStorageKeysByUserID returns the storage keys of every file attached by the user
+	GetStorageKeysByUserID(ctx context.Context, userID string) ([]string, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// BlobStorage stores the files behind expense attachments
+// Keys are generated by the caller and never derived from user input
+type BlobStorage interface {
+	// Put stores content under key, replacing anything already stored there
+	Put(ctx context.Context, key string, content io.Reader) error
+	// Get opens the content stored under key; the caller must close it
+	// Returns domain.ErrAttachmentNotFound if nothing is stored under key
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the content stored under key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
 // InsurancePolicyRepository defines the interface for insurance policy persistence
 type InsurancePolicyRepository interface {
 	// CRUD operations
+	// Create and Update return domain.ErrPolicyNumberExists if the user already has a policy with
+	// the number, deleted policies included
 	Create(ctx context.Context, policy *domain.InsurancePolicy) (*domain.InsurancePolicy, error)
 	GetByID(ctx context.Context, id string) (*domain.InsurancePolicy, error)
 	Update(ctx context.Context, policy *domain.InsurancePolicy) (*domain.InsurancePolicy, error)
@@ -141,11 +303,15 @@ type InsurancePolicyRepository interface {
 	// Query operations
 	GetByUserID(ctx context.Context, userID string) ([]*domain.InsurancePolicy, error)
 	GetByType(ctx context.Context, userID string, policyType string) ([]*domain.InsurancePolicy, error)
-	GetByPolicyNumber(ctx context.Context, policyNumber string) (*domain.InsurancePolicy, error)
+	GetByPolicyNumber(ctx context.Context, userID, policyNumber string) (*domain.InsurancePolicy, error)
 	GetActivePolicies(ctx context.Context, userID string) ([]*domain.InsurancePolicy, error)
 	GetByProfileID(ctx context.Context, profileID string) ([]*domain.InsurancePolicy, error)
 	
 	// Business operations
+	// GetByIDForUpdate retrieves a policy and locks it until the transaction in ctx ends, so
+	// progress read from it can't be overwritten by a concurrent update. Call it inside WithTx.
+	GetByIDForUpdate(ctx context.Context, id string) (*domain.InsurancePolicy, error)
+	// UpdateDeductibleProgress sets the policy's progress, capping each amount at its limit
 	UpdateDeductibleProgress(ctx context.Context, policyID string, deductibleMet, outOfPocketCurrent float64) (*domain.InsurancePolicy, error)
 	CalculateCoverageForExpense(ctx context.Context, policyID string, expenseAmount float64) (*CoverageCalculation, error)
 	GetPoliciesByProvider(ctx context.Context, userID string, provider string) ([]*domain.InsurancePolicy, error)
@@ -161,6 +327,15 @@ type ExpenseTotals struct {
 	ExpenseCount       int64   `json:"expense_count"`
 }
 
+// CategoryTotals represents aggregated expense data for one medical expense category
+type CategoryTotals struct {
+	Category           string  `json:"category"`
+	TotalAmount        float64 `json:"total_amount"`
+	TotalInsurancePaid float64 `json:"total_insurance_paid"`
+	TotalOutOfPocket   float64 `json:"total_out_of_pocket"`
+	ExpenseCount       int64   `json:"expense_count"`
+}
+
 // CoverageCalculation represents insurance coverage calculation results
 type CoverageCalculation struct {
 	InsurancePays         float64 `json:"insurance_pays"`
@@ -224,4 +399,325 @@ type DeductibleUpdate struct {
 	NewOutOfPocketUsed    float64 `json:"new_out_of_pocket_used"`
 	DeductibleCompleted   bool    `json:"deductible_completed"`
 	OutOfPocketMaxReached bool    `json:"out_of_pocket_max_reached"`
-}
\ No newline at end of file
+}
+
+// PolicyCostProjection represents the projected annual cost of a single policy
+type PolicyCostProjection struct {
+	PolicyID             string  `json:"policy_id,omitempty"`
+	PolicyNumber         string  `json:"policy_number"`
+	Provider             string  `json:"provider"`
+	Type                 string  `json:"type"`
+	AnnualPremium        float64 `json:"annual_premium"`
+	InsuranceCoverage    float64 `json:"insurance_coverage"`
+	ProjectedOutOfPocket float64 `json:"projected_out_of_pocket"`
+	TotalProjectedCost   float64 `json:"total_projected_cost"` // premiums + out-of-pocket
+	Rank                 int     `json:"rank"`                 // 1 = cheapest
+}
+
+// PolicyComparison represents policies ranked by total projected cost for an expected annual spend
+type PolicyComparison struct {
+	ExpectedAnnualSpend float64                `json:"expected_annual_spend"`
+	Policies            []PolicyCostProjection `json:"policies"`
+	Cheapest            PolicyCostProjection   `json:"cheapest"`
+}
+
+// InsuranceAdequacy represents how well each of a user's policies protects them against
+// their projected annual medical expenses at their risk level
+type InsuranceAdequacy struct {
+	UserID                  string           `json:"user_id"`
+	RiskLevel               string           `json:"risk_level"`
+	ProjectedAnnualExpenses float64          `json:"projected_annual_expenses"`
+	Policies                []PolicyAdequacy `json:"policies"`
+}
+
+// PolicyAdequacy represents one policy's expected cost and benefit and whether it is
+// sufficient for the user's risk level
+type PolicyAdequacy struct {
+	PolicyID                 string   `json:"policy_id"`
+	PolicyNumber             string   `json:"policy_number"`
+	Provider                 string   `json:"provider"`
+	Type                     string   `json:"type"`
+	AnnualPremium            float64  `json:"annual_premium"`
+	ExpectedBenefit          float64  `json:"expected_benefit"`       // what the policy is expected to pay in a year
+	ExpectedOutOfPocket      float64  `json:"expected_out_of_pocket"` // what the user is expected to pay on top of premiums
+	PremiumToBenefitRatio    float64  `json:"premium_to_benefit_ratio"`
+	CoverageSufficient       bool     `json:"coverage_sufficient"`
+	OutOfPocketMaxSufficient bool     `json:"out_of_pocket_max_sufficient"`
+	Flags                    []string `json:"flags"`
+}
+
+// UncoveredCategory represents an expense category no active policy covers
+type UncoveredCategory struct {
+	Category     string  `json:"category"`
+	ExpenseCount int     `json:"expense_count"`
+	TotalAmount  float64 `json:"total_amount"`
+}
+
+// CoverageLapse represents a period with no policy in force
+type CoverageLapse struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Days      int       `json:"days"`
+}
+
+// CoverageGapAnalysis represents a detailed breakdown of a user's coverage gaps
+type CoverageGapAnalysis struct {
+	UserID                    string              `json:"user_id"`
+	UncoveredCategories       []UncoveredCategory `json:"uncovered_categories"`
+	RecurringMonthlyCost      float64             `json:"recurring_monthly_cost"`
+	UncoveredRecurringMonthly float64             `json:"uncovered_recurring_monthly"`
+	UncoveredRecurringShare   float64             `json:"uncovered_recurring_share"` // percentage of recurring costs
+	CoverageLapses            []CoverageLapse     `json:"coverage_lapses"`
+	Gaps                      []CoverageGap       `json:"gaps"`
+}
+
+// UpcomingRefill represents a medication that is overdue or due for refill soon
+type UpcomingRefill struct {
+	Schedule     domain.MedicationSchedule `json:"schedule"`
+	DueDate      time.Time                 `json:"due_date"`
+	DaysUntilDue int                       `json:"days_until_due"` // negative when overdue
+	IsOverdue    bool                      `json:"is_overdue"`
+}
+
+// ExpiringPolicy represents an active insurance policy that ends soon
+type ExpiringPolicy struct {
+	Policy          domain.InsurancePolicy `json:"policy"`
+	DaysUntilExpiry int                    `json:"days_until_expiry"`
+}
+
+// ProfileHistory represents a user's weight and BMI over a time window.
+// Points are ordered oldest first and end with the current profile.
+type ProfileHistory struct {
+	UserID       string                   `json:"user_id"`
+	Months       int                      `json:"months"`
+	Points       []domain.ProfileSnapshot `json:"points"`
+	WeightChange float64                  `json:"weight_change"` // kg, last point minus first
+	BMIChange    float64                  `json:"bmi_change"`    // last point minus first
+}
+
+// RiskHistory represents a user's health risk score over a time window, oldest entry first
+type RiskHistory struct {
+	UserID      string                `json:"user_id"`
+	Months      int                   `json:"months"`
+	Entries     []domain.RiskSnapshot `json:"entries"`
+	ScoreChange int                   `json:"score_change"` // last entry minus first
+	Transitions []RiskLevelTransition `json:"transitions"`
+}
+
+// RiskRecalculation reports a batch recalculation of every user's risk score.
+// Updated counts the users whose score or level differed from their latest snapshot.
+type RiskRecalculation struct {
+	Processed int `json:"processed"`
+	Updated   int `json:"updated"`
+}
+
+// RiskWhatIf holds hypothetical changes to a user's self profile and active conditions.
+// Nil fields leave the profile as it is; the IDs must be of the user's own active conditions.
+type RiskWhatIf struct {
+	Weight             *float64
+	Age                *int
+	SeverityChanges    []ConditionSeverityChange
+	AddConditions      []domain.MedicalCondition
+	RemoveConditionIDs []string
+}
+
+// ConditionSeverityChange sets the severity of one of the user's conditions
+type ConditionSeverityChange struct {
+	ConditionID string
+	Severity    string
+}
+
+// RiskWhatIfResult compares a user's current risk score with the score they would have after
+// the changes of a RiskWhatIf
+type RiskWhatIfResult struct {
+	UserID            string             `json:"user_id"`
+	CurrentScore      int                `json:"current_score"`
+	CurrentLevel      string             `json:"current_level"`
+	HypotheticalScore int                `json:"hypothetical_score"`
+	HypotheticalLevel string             `json:"hypothetical_level"`
+	ScoreChange       int                `json:"score_change"` // hypothetical minus current
+	Factors           []RiskFactorChange `json:"factors"`
+}
+
+// RiskFactorChange compares what one factor adds to the current and the hypothetical score.
+// A removed condition has no hypothetical points and an added one no current points or ID.
+type RiskFactorChange struct {
+	Factor             string `json:"factor"`
+	ConditionID        string `json:"condition_id,omitempty"`
+	Name               string `json:"name,omitempty"`
+	CurrentPoints      int    `json:"current_points"`
+	HypotheticalPoints int    `json:"hypothetical_points"`
+	Change             int    `json:"change"`
+}
+
+// RiskLevelTransition represents a change of risk level between two consecutive snapshots
+type RiskLevelTransition struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+}
+
+// Condition timeline statuses
+const (
+	ConditionStatusDiagnosed = "diagnosed"
+	ConditionStatusActive    = "active"
+	ConditionStatusResolved  = "resolved"
+)
+
+// ConditionStatusChange represents a point in a condition's status history
+type ConditionStatusChange struct {
+	Status string    `json:"status"` // "diagnosed" or "resolved"
+	Date   time.Time `json:"date"`
+}
+
+// ConditionTimelineEntry represents a condition in the user's medical chronology
+type ConditionTimelineEntry struct {
+	Condition          domain.MedicalCondition `json:"condition"`
+	Status             string                  `json:"status"` // "active" or "resolved"
+	DaysSinceDiagnosis int                     `json:"days_since_diagnosis"`
+	DurationDays       int                     `json:"duration_days"` // until resolution, or until now while active
+	StatusHistory      []ConditionStatusChange `json:"status_history"`
+}
+
+// ConditionTimeline represents a user's conditions ordered by diagnosis date, oldest first
+type ConditionTimeline struct {
+	UserID   string                   `json:"user_id"`
+	Active   []ConditionTimelineEntry `json:"active"`
+	Resolved []ConditionTimelineEntry `json:"resolved"`
+}
+
+// FamilyMemberRollup represents the risk and medical costs of one profile on an account
+type FamilyMemberRollup struct {
+	ProfileID           string  `json:"profile_id"`
+	Name                string  `json:"name"`
+	RelationToOwner     string  `json:"relation_to_owner"`
+	HealthRiskScore     int     `json:"health_risk_score"`
+	HealthRiskLevel     string  `json:"health_risk_level"`
+	ActiveConditions    int     `json:"active_conditions"`
+	MonthlyMedicalCost  float64 `json:"monthly_medical_cost"`
+	ProjectedAnnualCost float64 `json:"projected_annual_cost"`
+}
+
+// FamilyHealthRollup aggregates risk and medical costs across every profile on an account
+type FamilyHealthRollup struct {
+	UserID                   string               `json:"user_id"`
+	Members                  []FamilyMemberRollup `json:"members"`
+	HighestRiskScore         int                  `json:"highest_risk_score"`
+	HighestRiskLevel         string               `json:"highest_risk_level"`
+	AverageRiskScore         float64              `json:"average_risk_score"`
+	TotalMonthlyMedicalCost  float64              `json:"total_monthly_medical_cost"`
+	TotalProjectedAnnualCost float64              `json:"total_projected_annual_cost"`
+}
+
+// AnnualCostProjection represents projected medical costs for the next 12 months.
+// Only recurring expenses are projected; one-time expenses are excluded.
+type AnnualCostProjection struct {
+	UserID                    string  `json:"user_id"`
+	ProjectedGrossCost        float64 `json:"projected_gross_cost"`
+	ExpectedInsurancePayments float64 `json:"expected_insurance_payments"`
+	NetOutOfPocket            float64 `json:"net_out_of_pocket"`
+	CoveredGrossCost          float64 `json:"covered_gross_cost"`   // gross cost of expenses a policy pays towards
+	UncoveredGrossCost        float64 `json:"uncovered_gross_cost"` // gross cost no active policy covers
+	AnnualPremiums            float64 `json:"annual_premiums"`      // reported separately, not included in NetOutOfPocket
+	RecurringExpenses         int     `json:"recurring_expenses"`
+	ExcludedOneTimeExpenses   int     `json:"excluded_one_time_expenses"`
+}
+
+// HSA coverage tiers; the family tier applies when the profile's family size is above one
+const (
+	HSACoverageSelfOnly = "self_only"
+	HSACoverageFamily   = "family"
+)
+
+// HSALimits holds the annual HSA contribution limits and the minimum deductible a
+// policy needs to count as a high-deductible health plan, per coverage tier
+type HSALimits struct {
+	SelfOnlyContributionLimit float64 `json:"self_only_contribution_limit"`
+	FamilyContributionLimit   float64 `json:"family_contribution_limit"`
+	SelfOnlyMinDeductible     float64 `json:"self_only_min_deductible"`
+	FamilyMinDeductible       float64 `json:"family_min_deductible"`
+}
+
+// HSARecommendation suggests a pre-tax HSA contribution sized to projected medical costs.
+// Ineligible users get zero contributions and an explanation of why.
+type HSARecommendation struct {
+	UserID                         string  `json:"user_id"`
+	Eligible                       bool    `json:"eligible"`
+	CoverageTier                   string  `json:"coverage_tier"`
+	QualifyingPolicyID             string  `json:"qualifying_policy_id,omitempty"`
+	ProjectedAnnualExpenses        float64 `json:"projected_annual_expenses"`
+	AnnualContributionLimit        float64 `json:"annual_contribution_limit"`
+	RecommendedAnnualContribution  float64 `json:"recommended_annual_contribution"`
+	RecommendedMonthlyContribution float64 `json:"recommended_monthly_contribution"`
+	Explanation                    string  `json:"explanation"`
+}
+
+// DeductibleProgress tracks how far an active policy's deductible and out-of-pocket maximum have been met
+type DeductibleProgress struct {
+	PolicyID             string  `json:"policy_id"`
+	Provider             string  `json:"provider"`
+	Type                 string  `json:"type"`
+	Deductible           float64 `json:"deductible"`
+	DeductibleMet        float64 `json:"deductible_met"`
+	DeductibleRemaining  float64 `json:"deductible_remaining"`
+	OutOfPocketMax       float64 `json:"out_of_pocket_max"`
+	OutOfPocketMet       float64 `json:"out_of_pocket_met"`
+	OutOfPocketRemaining float64 `json:"out_of_pocket_remaining"`
+	// ProjectedDeductibleMetMonth is the first day of the month the deductible is projected
+	// to be met at the current run-rate; nil when it is already met or won't be met this plan year
+	ProjectedDeductibleMetMonth *time.Time `json:"projected_deductible_met_month,omitempty"`
+	WillMeetDeductibleThisYear  bool       `json:"will_meet_deductible_this_year"`
+}
+
+// MedicalExpenseAnalytics breaks down a user's medical spending for the current calendar year
+type MedicalExpenseAnalytics struct {
+	UserID             string               `json:"user_id"`
+	Year               int                  `json:"year"`
+	YearToDateTotal    float64              `json:"year_to_date_total"`
+	OutOfPocketTotal   float64              `json:"out_of_pocket_total"`
+	InsurancePaidTotal float64              `json:"insurance_paid_total"`
+	ExpenseCount       int64                `json:"expense_count"`
+	ByCategory         []CategoryTotals     `json:"by_category"`
+	MonthlyRunRate     float64              `json:"monthly_run_rate"` // monthly equivalent of recurring expenses
+	Policies           []DeductibleProgress `json:"policies"`
+}
+
+// MedicalExpenseBreakdown splits a user's recorded medical spending by category into what
+// insurance paid and what the user paid out of pocket
+type MedicalExpenseBreakdown struct {
+	UserID            string                     `json:"user_id"`
+	TotalAmount       float64                    `json:"total_amount"`
+	CoveredAmount     float64                    `json:"covered_amount"`
+	OutOfPocketAmount float64                    `json:"out_of_pocket_amount"`
+	ExpenseCount      int                        `json:"expense_count"`
+	Categories        []ExpenseCategoryBreakdown `json:"categories"` // largest total first
+}
+
+// ExpenseCategoryBreakdown is one category of a MedicalExpenseBreakdown
+type ExpenseCategoryBreakdown struct {
+	Category          string  `json:"category"`
+	TotalAmount       float64 `json:"total_amount"`
+	CoveredAmount     float64 `json:"covered_amount"`
+	OutOfPocketAmount float64 `json:"out_of_pocket_amount"`
+	ExpenseCount      int     `json:"expense_count"`
+	Share             float64 `json:"share"` // fraction of the total medical spend, from 0 to 1
+}
+
+// EventPublisher receives the events emitted by FinanceService and HealthService
+// Publish must return immediately; delivery happens in the background
+type EventPublisher interface {
+	Publish(event domain.Event)
+}
+
+// AuditRecorder receives the audit entries recorded by AuthService, AdminService, FinanceService
+// and HealthService. Record must return immediately; entries are written in the background.
+type AuditRecorder interface {
+	Record(ctx context.Context, action, resourceType, resourceID string, before, after interface{})
+}
+
+// ExchangeRateProvider converts amounts between currencies for FinanceService
+// Rate returns how many units of to one unit of from buys, or an error wrapping
+// domain.ErrUnsupportedCurrency if it has no rate for the pair
+type ExchangeRateProvider interface {
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
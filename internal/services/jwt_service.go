@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	
 	"github.com/DuckDHD/BuyOrBye/internal/config"
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
@@ -15,34 +16,52 @@ import (
 // JWTService defines the interface for JWT token operations
 type JWTService interface {
 	// GenerateTokenPair creates both access and refresh tokens for a user
-	GenerateTokenPair(userID, email string) (*domain.TokenPair, error)
+	// An empty role is issued as domain.RoleUser
+	GenerateTokenPair(userID, email, role string) (*domain.TokenPair, error)
 	
 	// ValidateAccessToken validates an access token and returns its claims
 	ValidateAccessToken(tokenString string) (*domain.TokenClaims, error)
 	
 	// ValidateRefreshToken validates a refresh token and returns its claims
 	ValidateRefreshToken(tokenString string) (*domain.TokenClaims, error)
+
+	// ActiveKeyID returns the kid of the key new tokens are signed with
+	ActiveKeyID() string
 }
 
+// DefaultJWTKeyID is the kid of the signing key when none is configured
+const DefaultJWTKeyID = "primary"
+
+// errUnknownJWTKey is returned when a token's kid names no configured key
+var errUnknownJWTKey = errors.New("unknown signing key")
+
 // jwtService implements JWTService using github.com/golang-jwt/jwt/v5
 type jwtService struct {
-	secret            []byte
-	accessTokenTTL    time.Duration // 15 minutes
-	refreshTokenTTL   time.Duration // 7 days
+	activeKeyID string
+	// keys maps each accepted kid to its secret, including the active key
+	keys            map[string][]byte
+	accessTokenTTL  time.Duration // 15 minutes
+	refreshTokenTTL time.Duration // 7 days
 }
 
 // NewJWTService creates a new JWT service instance
-// Requires JWT_SECRET environment variable to be set
+// Requires JWT_SECRET environment variable to be set; JWT_KEY_ID optionally names its kid
 func NewJWTService() (JWTService, error) {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
 		return nil, fmt.Errorf("JWT_SECRET environment variable is required")
 	}
 
+	keyID := os.Getenv("JWT_KEY_ID")
+	if keyID == "" {
+		keyID = DefaultJWTKeyID
+	}
+
 	return &jwtService{
-		secret:            []byte(secret),
-		accessTokenTTL:    15 * time.Minute, // 15 minutes as specified
-		refreshTokenTTL:   7 * 24 * time.Hour, // 7 days as specified
+		activeKeyID:     keyID,
+		keys:            map[string][]byte{keyID: []byte(secret)},
+		accessTokenTTL:  15 * time.Minute,   // 15 minutes as specified
+		refreshTokenTTL: 7 * 24 * time.Hour, // 7 days as specified
 	}, nil
 }
 
@@ -60,21 +79,60 @@ func NewJWTServiceFromConfig(authConfig *config.AuthConfig) (JWTService, error)
 		return nil, fmt.Errorf("JWT secret must be at least 32 characters long")
 	}
 
+	activeKeyID := authConfig.JWTKeyID
+	if activeKeyID == "" {
+		activeKeyID = DefaultJWTKeyID
+	}
+
+	// Retired keys only verify, so tokens they signed stay valid until they expire
+	keys := map[string][]byte{activeKeyID: []byte(authConfig.JWTSecret)}
+	for _, key := range authConfig.JWTVerificationKeys {
+		if key.ID == "" {
+			return nil, fmt.Errorf("JWT verification keys must have an ID")
+		}
+		if len(key.Secret) < 32 {
+			return nil, fmt.Errorf("JWT verification key %q must be at least 32 characters long", key.ID)
+		}
+		if _, exists := keys[key.ID]; exists {
+			return nil, fmt.Errorf("JWT key ID %q is used more than once", key.ID)
+		}
+		keys[key.ID] = []byte(key.Secret)
+	}
+
 	return &jwtService{
-		secret:            []byte(authConfig.JWTSecret),
-		accessTokenTTL:    authConfig.AccessTokenTTL,
-		refreshTokenTTL:   authConfig.RefreshTokenTTL,
+		activeKeyID:     activeKeyID,
+		keys:            keys,
+		accessTokenTTL:  authConfig.AccessTokenTTL,
+		refreshTokenTTL: authConfig.RefreshTokenTTL,
 	}, nil
 }
 
+// ActiveKeyID returns the kid of the key new tokens are signed with
+func (js *jwtService) ActiveKeyID() string {
+	return js.activeKeyID
+}
+
+// signToken signs claims with the active key and names it in the kid header
+func (js *jwtService) signToken(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = js.activeKeyID
+	return token.SignedString(js.keys[js.activeKeyID])
+}
+
 // GenerateTokenPair creates both access and refresh tokens for a user
-func (js *jwtService) GenerateTokenPair(userID, email string) (*domain.TokenPair, error) {
+func (js *jwtService) GenerateTokenPair(userID, email, role string) (*domain.TokenPair, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("user ID cannot be empty")
 	}
 	if email == "" {
 		return nil, fmt.Errorf("email cannot be empty")
 	}
+	if role == "" {
+		role = domain.RoleUser
+	}
+	if !domain.IsValidRole(role) {
+		return nil, fmt.Errorf("invalid role %q", role)
+	}
 
 	now := time.Now()
 
@@ -82,26 +140,28 @@ func (js *jwtService) GenerateTokenPair(userID, email string) (*domain.TokenPair
 	accessClaims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    role,
 		"exp":     now.Add(js.accessTokenTTL).Unix(),
 		"iat":     now.Unix(),
 	}
 
-	accessToken := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-	accessTokenString, err := accessToken.SignedString(js.secret)
+	accessTokenString, err := js.signToken(accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign access token: %w", err)
 	}
 
-	// Create refresh token (7 days)
+	// Create refresh token (7 days). The random jti keeps two tokens issued to the same user in
+	// the same second apart, since refresh tokens are stored under a unique hash.
 	refreshClaims := jwt.MapClaims{
 		"user_id": userID,
 		"email":   email,
+		"role":    role,
 		"exp":     now.Add(js.refreshTokenTTL).Unix(),
 		"iat":     now.Unix(),
+		"jti":     uuid.NewString(),
 	}
 
-	refreshToken := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-	refreshTokenString, err := refreshToken.SignedString(js.secret)
+	refreshTokenString, err := js.signToken(refreshClaims)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign refresh token: %w", err)
 	}
@@ -135,10 +195,22 @@ func (js *jwtService) validateToken(tokenString, tokenType string) (*domain.Toke
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return js.secret, nil
+		// Tokens issued before key rotation carry no kid and were signed with the active key
+		keyID := js.activeKeyID
+		if kid, present := token.Header["kid"]; present {
+			keyID, _ = kid.(string)
+		}
+		key, ok := js.keys[keyID]
+		if !ok {
+			return nil, errUnknownJWTKey
+		}
+		return key, nil
 	})
 
 	if err != nil {
+		if errors.Is(err, errUnknownJWTKey) {
+			return nil, fmt.Errorf("%s was signed with an unknown key", tokenType)
+		}
 		// Check for specific error types using errors.Is (v5 approach)
 		if errors.Is(err, jwt.ErrTokenExpired) {
 			return nil, fmt.Errorf("%s is expired", tokenType)
@@ -172,6 +244,12 @@ func (js *jwtService) validateToken(tokenString, tokenType string) (*domain.Toke
 		return nil, fmt.Errorf("invalid email in %s claims", tokenType)
 	}
 
+	// Tokens issued before roles existed carry no role claim
+	role, _ := claims["role"].(string)
+	if role == "" {
+		role = domain.RoleUser
+	}
+
 	// Extract expiry time
 	exp, ok := claims["exp"].(float64)
 	if !ok {
@@ -181,6 +259,7 @@ func (js *jwtService) validateToken(tokenString, tokenType string) (*domain.Toke
 	return &domain.TokenClaims{
 		UserID:    userID,
 		Email:     email,
+		Role:      role,
 		ExpiresAt: int64(exp),
 	}, nil
 }
\ No newline at end of file
@@ -8,6 +8,9 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
 )
 
 func TestJWTService_GenerateTokenPair_ReturnsValidTokens(t *testing.T) {
@@ -22,7 +25,7 @@ func TestJWTService_GenerateTokenPair_ReturnsValidTokens(t *testing.T) {
 	email := "test@example.com"
 
 	// Act
-	tokenPair, err := service.GenerateTokenPair(userID, email)
+	tokenPair, err := service.GenerateTokenPair(userID, email, domain.RoleUser)
 
 	// Assert
 	assert.NoError(t, err)
@@ -42,6 +45,22 @@ func TestJWTService_GenerateTokenPair_ReturnsValidTokens(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestJWTService_GenerateTokenPair_RefreshTokensAreUnique(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	service, err := NewJWTService()
+	require.NoError(t, err)
+
+	// Both pairs are issued within the same second, so only the jti tells the refresh tokens apart
+	first, err := service.GenerateTokenPair("user-123", "test@example.com", domain.RoleUser)
+	require.NoError(t, err)
+	second, err := service.GenerateTokenPair("user-123", "test@example.com", domain.RoleUser)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.RefreshToken, second.RefreshToken)
+}
+
 func TestJWTService_GenerateTokenPair_CorrectTTL(t *testing.T) {
 	// Arrange
 	os.Setenv("JWT_SECRET", "test-secret-key")
@@ -55,7 +74,7 @@ func TestJWTService_GenerateTokenPair_CorrectTTL(t *testing.T) {
 	startTime := time.Now()
 
 	// Act
-	tokenPair, err := service.GenerateTokenPair(userID, email)
+	tokenPair, err := service.GenerateTokenPair(userID, email, domain.RoleUser)
 	require.NoError(t, err)
 
 	// Assert access token expiry (15 minutes)
@@ -86,7 +105,7 @@ func TestJWTService_ValidateAccessToken_ValidToken_ReturnsClaims(t *testing.T) {
 	userID := "user-123"
 	email := "test@example.com"
 	
-	tokenPair, err := service.GenerateTokenPair(userID, email)
+	tokenPair, err := service.GenerateTokenPair(userID, email, domain.RoleUser)
 	require.NoError(t, err)
 
 	// Act
@@ -172,7 +191,7 @@ func TestJWTService_ValidateRefreshToken_ValidToken_ReturnsClaims(t *testing.T)
 	userID := "user-123"
 	email := "test@example.com"
 	
-	tokenPair, err := service.GenerateTokenPair(userID, email)
+	tokenPair, err := service.GenerateTokenPair(userID, email, domain.RoleUser)
 	require.NoError(t, err)
 
 	// Act
@@ -272,4 +291,197 @@ func TestJWTService_NewJWTService_EmptySecret_ReturnsError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, service)
 	assert.Contains(t, err.Error(), "JWT_SECRET environment variable is required")
-}
\ No newline at end of file
+}
+func TestJWTService_GenerateTokenPair_RoleRoundTrips(t *testing.T) {
+	// Arrange
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	service, err := NewJWTService()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		role         string
+		expectedRole string
+	}{
+		{name: "admin", role: domain.RoleAdmin, expectedRole: domain.RoleAdmin},
+		{name: "user", role: domain.RoleUser, expectedRole: domain.RoleUser},
+		{name: "empty defaults to user", role: "", expectedRole: domain.RoleUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			tokenPair, err := service.GenerateTokenPair("user-123", "test@example.com", tt.role)
+			require.NoError(t, err)
+
+			accessClaims, err := service.ValidateAccessToken(tokenPair.AccessToken)
+			require.NoError(t, err)
+			refreshClaims, err := service.ValidateRefreshToken(tokenPair.RefreshToken)
+			require.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, tt.expectedRole, accessClaims.Role)
+			assert.Equal(t, tt.expectedRole, refreshClaims.Role)
+		})
+	}
+}
+
+func TestJWTService_GenerateTokenPair_UnknownRole_ReturnsError(t *testing.T) {
+	// Arrange
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	service, err := NewJWTService()
+	require.NoError(t, err)
+
+	// Act
+	tokenPair, err := service.GenerateTokenPair("user-123", "test@example.com", "superuser")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, tokenPair)
+}
+
+func TestJWTService_ValidateAccessToken_TokenWithoutRole_DefaultsToUser(t *testing.T) {
+	// Arrange
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	service, err := NewJWTService()
+	require.NoError(t, err)
+
+	// Tokens issued before roles were added have no role claim
+	legacyToken := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "user-123",
+		"email":   "test@example.com",
+		"exp":     time.Now().Add(15 * time.Minute).Unix(),
+		"iat":     time.Now().Unix(),
+	})
+	tokenString, err := legacyToken.SignedString([]byte("test-secret-key"))
+	require.NoError(t, err)
+
+	// Act
+	claims, err := service.ValidateAccessToken(tokenString)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleUser, claims.Role)
+}
+
+// rotatedAuthConfig signs with key-2026 and still verifies tokens signed with key-2025
+func rotatedAuthConfig() *config.AuthConfig {
+	return &config.AuthConfig{
+		JWTSecret: "new-signing-secret-that-is-32-characters",
+		JWTKeyID:  "key-2026",
+		JWTVerificationKeys: []config.JWTKeyConfig{
+			{ID: "key-2025", Secret: "old-signing-secret-that-is-32-characters"},
+		},
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 7 * 24 * time.Hour,
+	}
+}
+
+func TestJWTService_GenerateTokenPair_SignsWithActiveKey(t *testing.T) {
+	service, err := NewJWTServiceFromConfig(rotatedAuthConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "key-2026", service.ActiveKeyID())
+
+	tokenPair, err := service.GenerateTokenPair("user-123", "test@example.com", domain.RoleUser)
+	require.NoError(t, err)
+
+	for _, tokenString := range []string{tokenPair.AccessToken, tokenPair.RefreshToken} {
+		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+			return []byte("new-signing-secret-that-is-32-characters"), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "key-2026", token.Header["kid"])
+	}
+}
+
+func TestJWTService_ValidateTokens_SignedWithRetiredKey_ReturnsClaims(t *testing.T) {
+	// Tokens issued before the rotation, while key-2025 was the active key
+	oldConfig := &config.AuthConfig{
+		JWTSecret:       "old-signing-secret-that-is-32-characters",
+		JWTKeyID:        "key-2025",
+		AccessTokenTTL:  15 * time.Minute,
+		RefreshTokenTTL: 7 * 24 * time.Hour,
+	}
+	oldService, err := NewJWTServiceFromConfig(oldConfig)
+	require.NoError(t, err)
+	tokenPair, err := oldService.GenerateTokenPair("user-123", "test@example.com", domain.RoleAdmin)
+	require.NoError(t, err)
+
+	service, err := NewJWTServiceFromConfig(rotatedAuthConfig())
+	require.NoError(t, err)
+
+	claims, err := service.ValidateAccessToken(tokenPair.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.UserID)
+	assert.Equal(t, domain.RoleAdmin, claims.Role)
+
+	claims, err = service.ValidateRefreshToken(tokenPair.RefreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", claims.UserID)
+}
+
+func TestJWTService_ValidateAccessToken_UnknownKeyID_ReturnsError(t *testing.T) {
+	service, err := NewJWTServiceFromConfig(rotatedAuthConfig())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		kid     interface{}
+		secret  string
+		wantErr string
+	}{
+		// Signed with a key the service knows, but naming one it doesn't
+		{"unknown kid", "key-2024", "new-signing-secret-that-is-32-characters", "signed with an unknown key"},
+		{"non-string kid", 2026, "new-signing-secret-that-is-32-characters", "signed with an unknown key"},
+		// A retired key can't be passed off under another kid
+		{"kid of another key", "key-2026", "old-signing-secret-that-is-32-characters", "signature is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+				"user_id": "user-123",
+				"email":   "test@example.com",
+				"exp":     time.Now().Add(15 * time.Minute).Unix(),
+				"iat":     time.Now().Unix(),
+			})
+			token.Header["kid"] = tt.kid
+			tokenString, err := token.SignedString([]byte(tt.secret))
+			require.NoError(t, err)
+
+			claims, err := service.ValidateAccessToken(tokenString)
+
+			assert.ErrorContains(t, err, tt.wantErr)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestJWTService_NewJWTServiceFromConfig_InvalidVerificationKeys_ReturnsError(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []config.JWTKeyConfig
+	}{
+		{"missing ID", []config.JWTKeyConfig{{Secret: "old-signing-secret-that-is-32-characters"}}},
+		{"short secret", []config.JWTKeyConfig{{ID: "key-2025", Secret: "too-short"}}},
+		{"same ID as the active key", []config.JWTKeyConfig{{ID: "key-2026", Secret: "old-signing-secret-that-is-32-characters"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authConfig := rotatedAuthConfig()
+			authConfig.JWTVerificationKeys = tt.keys
+
+			service, err := NewJWTServiceFromConfig(authConfig)
+
+			assert.Error(t, err)
+			assert.Nil(t, service)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package services
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 )
@@ -15,7 +18,7 @@ func NewMedicalCostAnalyzer() MedicalCostAnalyzer {
 }
 
 // CalculateMonthlyAverage calculates average monthly medical expenses
-// Normalize all frequencies to monthly equivalent
+// Normalize all frequencies to monthly equivalent; see NormalizeMedicalExpenseToMonthly
 func (m *medicalCostAnalyzer) CalculateMonthlyAverage(expenses []domain.MedicalExpense) float64 {
 	if len(expenses) == 0 {
 		return 0.0
@@ -69,6 +72,52 @@ func (m *medicalCostAnalyzer) ProjectAnnualCosts(expenses []domain.MedicalExpens
 	return total
 }
 
+// ReconcileRecurringExpenses compares the projected monthly cost of each recurring expense with
+// the occurrences recorded for it this year, up to now. A month is only compared for an expense
+// when at least one of its occurrences falls in it; the actual cost of the month is the sum of
+// those occurrences. Expenses with an unsupported frequency are left out, as in the projections.
+func (m *medicalCostAnalyzer) ReconcileRecurringExpenses(expenses []domain.MedicalExpense, occurrences []domain.MedicalExpenseOccurrence, now time.Time) domain.RecurringExpenseVariance {
+	type expenseMonth struct {
+		expenseID string
+		month     time.Month
+	}
+
+	yearStart := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, now.Location())
+	actuals := make(map[expenseMonth]float64)
+	for _, occurrence := range occurrences {
+		date := occurrence.Date.In(now.Location())
+		if date.Before(yearStart) || date.After(now) {
+			continue
+		}
+		actuals[expenseMonth{occurrence.ExpenseID, date.Month()}] += occurrence.Amount
+	}
+
+	var variance domain.RecurringExpenseVariance
+	for _, expense := range expenses {
+		if !expense.IsRecurring {
+			continue
+		}
+		monthly, err := m.NormalizeMedicalExpenseToMonthly(expense)
+		if err != nil {
+			continue
+		}
+		for month := time.January; month <= now.Month(); month++ {
+			actual, ok := actuals[expenseMonth{expense.ID, month}]
+			if !ok {
+				continue
+			}
+			variance.ProjectedYTD += monthly
+			variance.ActualYTD += actual
+			variance.ReconciledMonths++
+		}
+	}
+
+	variance.ProjectedYTD = math.Round(variance.ProjectedYTD*100) / 100
+	variance.ActualYTD = math.Round(variance.ActualYTD*100) / 100
+	variance.Variance = math.Round((variance.ActualYTD-variance.ProjectedYTD)*100) / 100
+	return variance
+}
+
 // IdentifyCostReductionOpportunities identifies generic alternatives, preventive care opportunities
 func (m *medicalCostAnalyzer) IdentifyCostReductionOpportunities(expenses []domain.MedicalExpense) []CostReductionOpportunity {
 	opportunities := make([]CostReductionOpportunity, 0)
@@ -220,30 +269,144 @@ func (m *medicalCostAnalyzer) AnalyzeTrends(expenses []domain.MedicalExpense) []
 	return trends
 }
 
-// normalizeToMonthly converts expense amount to monthly equivalent
-func (m *medicalCostAnalyzer) normalizeToMonthly(expense domain.MedicalExpense) float64 {
+// BreakdownByCategory totals the recorded amount of each expense by category, splitting it into
+// the insurance payment applied to the expense and the rest, which the user paid out of pocket.
+// Each category's share is its fraction of the total. No expenses give an empty breakdown.
+func (m *medicalCostAnalyzer) BreakdownByCategory(expenses []domain.MedicalExpense) *MedicalExpenseBreakdown {
+	breakdown := &MedicalExpenseBreakdown{Categories: []ExpenseCategoryBreakdown{}}
+
+	byCategory := make(map[string]*ExpenseCategoryBreakdown)
+	for _, expense := range expenses {
+		category, ok := byCategory[expense.Category]
+		if !ok {
+			category = &ExpenseCategoryBreakdown{Category: expense.Category}
+			byCategory[expense.Category] = category
+		}
+		category.TotalAmount += expense.Amount
+		category.CoveredAmount += expense.InsurancePayment
+		category.OutOfPocketAmount += expense.CalculateOutOfPocket()
+		category.ExpenseCount++
+	}
+
+	for _, category := range byCategory {
+		breakdown.TotalAmount += category.TotalAmount
+		breakdown.CoveredAmount += category.CoveredAmount
+		breakdown.OutOfPocketAmount += category.OutOfPocketAmount
+		breakdown.ExpenseCount += category.ExpenseCount
+	}
+	for _, category := range byCategory {
+		if breakdown.TotalAmount > 0 {
+			category.Share = math.Round(category.TotalAmount/breakdown.TotalAmount*10000) / 10000
+		}
+		category.TotalAmount = roundCents(category.TotalAmount)
+		category.CoveredAmount = roundCents(category.CoveredAmount)
+		category.OutOfPocketAmount = roundCents(category.OutOfPocketAmount)
+		breakdown.Categories = append(breakdown.Categories, *category)
+	}
+	breakdown.TotalAmount = roundCents(breakdown.TotalAmount)
+	breakdown.CoveredAmount = roundCents(breakdown.CoveredAmount)
+	breakdown.OutOfPocketAmount = roundCents(breakdown.OutOfPocketAmount)
+
+	sort.Slice(breakdown.Categories, func(i, j int) bool {
+		a, b := breakdown.Categories[i], breakdown.Categories[j]
+		if a.TotalAmount != b.TotalAmount {
+			return a.TotalAmount > b.TotalAmount
+		}
+		return a.Category < b.Category
+	})
+
+	return breakdown
+}
+
+// ProjectAnnualCost projects medical costs for the next 12 months from recurring expenses,
+// split into the share insurance is expected to pay and the user's net out-of-pocket.
+// Each recurring expense is assigned to the active policy with the highest coverage
+// that covers its category, and each policy is evaluated from the start of a plan year.
+func (m *medicalCostAnalyzer) ProjectAnnualCost(profile *domain.HealthProfile, expenses []domain.MedicalExpense, policies []domain.InsurancePolicy) (*AnnualCostProjection, error) {
+	if profile == nil {
+		return nil, fmt.Errorf("profile is required for cost projection")
+	}
+
+	projection := &AnnualCostProjection{UserID: profile.UserID}
+
+	activePolicies := make([]domain.InsurancePolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.IsActive {
+			activePolicies = append(activePolicies, policy)
+			projection.AnnualPremiums += policy.GetAnnualPremium()
+		}
+	}
+
+	// Annualize recurring expenses and group them by the policy that will pay towards them
+	grossByPolicy := make([]float64, len(activePolicies))
+	for _, expense := range expenses {
+		if !expense.IsRecurring {
+			projection.ExcludedOneTimeExpenses++
+			continue
+		}
+
+		annualCost := m.calculateRecurringAnnualCost(expense)
+		projection.RecurringExpenses++
+		projection.ProjectedGrossCost += annualCost
+
+		best := -1
+		for i, policy := range activePolicies {
+			if !policyCoversCategory(policy.Type, expense.Category) {
+				continue
+			}
+			if best < 0 || policy.CoveragePercentage > activePolicies[best].CoveragePercentage {
+				best = i
+			}
+		}
+
+		if best < 0 {
+			projection.UncoveredGrossCost += annualCost
+			continue
+		}
+		grossByPolicy[best] += annualCost
+		projection.CoveredGrossCost += annualCost
+	}
+
+	for i, gross := range grossByPolicy {
+		if gross == 0 {
+			continue
+		}
+		fresh := activePolicies[i]
+		fresh.DeductibleMet = 0
+		fresh.OutOfPocketCurrent = 0
+
+		insurancePays, outOfPocket, _ := fresh.CalculateCoverage(gross)
+		projection.ExpectedInsurancePayments += insurancePays
+		projection.NetOutOfPocket += outOfPocket
+	}
+	projection.NetOutOfPocket += projection.UncoveredGrossCost
+
+	return projection, nil
+}
+
+// NormalizeMedicalExpenseToMonthly converts a recurring expense's amount to its monthly equivalent.
+// One-time expenses don't recur, so they count as 0. Returns an error if a recurring expense's
+// frequency is not one of domain.MedicalExpenseFrequencies.
+func (m *medicalCostAnalyzer) NormalizeMedicalExpenseToMonthly(expense domain.MedicalExpense) (float64, error) {
 	if !expense.IsRecurring {
-		return 0 // One-time expenses don't contribute to monthly recurring
+		return 0, nil
 	}
+	return domain.MedicalMonthlyAmount(expense.Amount, expense.Frequency)
+}
 
-	switch expense.Frequency {
-	case "daily":
-		return expense.Amount * 30 // Approximate month
-	case "weekly":
-		return expense.Amount * 4.33 // Approximate weeks per month
-	case "bi-weekly":
-		return expense.Amount * 2.17 // Approximate bi-weeks per month
-	case "monthly":
-		return expense.Amount
-	case "quarterly":
-		return expense.Amount / 3
-	case "semi-annually":
-		return expense.Amount / 6
-	case "annually":
-		return expense.Amount / 12
-	default: // "one_time" and others
+// normalizeToMonthly converts expense amount to monthly equivalent, counting an expense with an
+// unsupported frequency as 0
+func (m *medicalCostAnalyzer) normalizeToMonthly(expense domain.MedicalExpense) float64 {
+	monthly, err := m.NormalizeMedicalExpenseToMonthly(expense)
+	if err != nil {
 		return 0
 	}
+	return monthly
+}
+
+// roundCents rounds an amount to the cent
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
 }
 
 // calculateRecurringAnnualCost calculates annual cost for a medical expense based on frequency
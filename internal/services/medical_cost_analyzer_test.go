@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/stretchr/testify/assert"
@@ -110,6 +111,57 @@ func TestMedicalCostAnalyzer_CalculateMonthlyAverage(t *testing.T) {
 	}
 }
 
+func TestMedicalCostAnalyzer_NormalizeMedicalExpenseToMonthly_AllFrequencies(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+
+	tests := []struct {
+		name      string
+		amount    float64
+		frequency string
+		expected  float64
+	}{
+		{"Daily", 10.0, "daily", 300.0},
+		{"Weekly", 100.0, "weekly", 433.0},
+		{"Biweekly", 100.0, "biweekly", 217.0},
+		{"Monthly", 80.0, "monthly", 80.0},
+		{"Quarterly", 150.0, "quarterly", 50.0},
+		{"Semiannual", 600.0, "semiannual", 100.0},
+		{"Semiannually", 600.0, "semiannually", 100.0},
+		{"Annual", 1200.0, "annual", 100.0},
+		{"Annually", 1200.0, "annually", 100.0},
+		{"Yearly", 1200.0, "yearly", 100.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expense := domain.MedicalExpense{Amount: tt.amount, IsRecurring: true, Frequency: tt.frequency}
+
+			result, err := analyzer.NormalizeMedicalExpenseToMonthly(expense)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.expected, result, 0.01)
+		})
+	}
+}
+
+func TestMedicalCostAnalyzer_NormalizeMedicalExpenseToMonthly_OneTime(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+
+	result, err := analyzer.NormalizeMedicalExpenseToMonthly(domain.MedicalExpense{Amount: 250.0, Frequency: "one_time"})
+
+	assert.NoError(t, err)
+	assert.Zero(t, result)
+}
+
+func TestMedicalCostAnalyzer_NormalizeMedicalExpenseToMonthly_InvalidFrequency(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+
+	_, err := analyzer.NormalizeMedicalExpenseToMonthly(domain.MedicalExpense{Amount: 100.0, IsRecurring: true, Frequency: "fortnightly"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported medical expense frequency")
+}
+
 // Test ProjectAnnualCosts including recurring medications
 func TestMedicalCostAnalyzer_ProjectAnnualCosts(t *testing.T) {
 	tests := []struct {
@@ -257,4 +309,144 @@ func TestMedicalCostAnalyzer_IdentifyCostReductionOpportunities(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestMedicalCostAnalyzer_ProjectAnnualCost_RecurringFrequencies(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+	profile := &domain.HealthProfile{UserID: "user-1"}
+
+	expenses := []domain.MedicalExpense{
+		{Amount: 100, Category: "medication", IsRecurring: true, Frequency: "monthly"},  // 1200/year
+		{Amount: 150, Category: "medication", IsRecurring: true, Frequency: "quarterly"}, // 600/year
+		{Amount: 900, Category: "hospital", IsRecurring: false, Frequency: "one_time"},   // excluded
+	}
+
+	projection, err := analyzer.ProjectAnnualCost(profile, expenses, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", projection.UserID)
+	assert.InDelta(t, 1800.0, projection.ProjectedGrossCost, 0.01)
+	assert.Equal(t, 0.0, projection.ExpectedInsurancePayments)
+	assert.InDelta(t, 1800.0, projection.NetOutOfPocket, 0.01)
+	assert.Equal(t, 2, projection.RecurringExpenses)
+	assert.Equal(t, 1, projection.ExcludedOneTimeExpenses)
+}
+
+func TestMedicalCostAnalyzer_ProjectAnnualCost_CoveredVsUncovered(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+	profile := &domain.HealthProfile{UserID: "user-1"}
+
+	// Everything is assigned to the active health plan: the dental plan covers none of these
+	// categories and the better inactive health plan is ignored
+	policies := []domain.InsurancePolicy{
+		{ID: "1", Type: "health", CoveragePercentage: 80, Deductible: 500, OutOfPocketMax: 3000,
+			DeductibleMet: 500, OutOfPocketCurrent: 1000, MonthlyPremium: 200, IsActive: true},
+		{ID: "2", Type: "dental", CoveragePercentage: 100, Deductible: 0, OutOfPocketMax: 1000,
+			MonthlyPremium: 30, IsActive: true},
+		{ID: "3", Type: "health", CoveragePercentage: 90, Deductible: 0, OutOfPocketMax: 1000,
+			MonthlyPremium: 500, IsActive: false},
+	}
+	expenses := []domain.MedicalExpense{
+		{Amount: 200, Category: "medication", IsRecurring: true, Frequency: "monthly"}, // 2400/year, covered
+		{Amount: 300, Category: "therapy", IsRecurring: true, Frequency: "quarterly"},  // 1200/year, covered by health
+		{Amount: 50, Category: "equipment", IsRecurring: true, Frequency: "annually"},  // 50/year, covered by health
+	}
+
+	projection, err := analyzer.ProjectAnnualCost(profile, expenses, policies)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 3650.0, projection.ProjectedGrossCost, 0.01)
+	assert.InDelta(t, 3650.0, projection.CoveredGrossCost, 0.01)
+	assert.Equal(t, 0.0, projection.UncoveredGrossCost)
+	// Fresh plan year: 500 deductible, then 80% of 3150 = 2520 paid by insurance
+	assert.InDelta(t, 2520.0, projection.ExpectedInsurancePayments, 0.01)
+	assert.InDelta(t, 1130.0, projection.NetOutOfPocket, 0.01)
+	assert.InDelta(t, 2760.0, projection.AnnualPremiums, 0.01)
+
+	// Without the health plan, only the dental plan is active and nothing is covered
+	projection, err = analyzer.ProjectAnnualCost(profile, expenses, policies[1:])
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, projection.CoveredGrossCost)
+	assert.InDelta(t, 3650.0, projection.UncoveredGrossCost, 0.01)
+	assert.InDelta(t, 3650.0, projection.NetOutOfPocket, 0.01)
+}
+
+func TestMedicalCostAnalyzer_ProjectAnnualCost_RequiresProfile(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+
+	_, err := analyzer.ProjectAnnualCost(nil, nil, nil)
+
+	assert.Error(t, err)
+}
+
+func TestMedicalCostAnalyzer_ReconcileRecurringExpenses(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+	now := time.Date(2025, time.April, 15, 12, 0, 0, 0, time.UTC)
+	expenses := []domain.MedicalExpense{
+		{ID: "1", Amount: 100, IsRecurring: true, Frequency: "monthly"},
+		{ID: "2", Amount: 300, IsRecurring: true, Frequency: "quarterly"},
+		{ID: "3", Amount: 500, Frequency: "one_time"},
+		{ID: "4", Amount: 50, IsRecurring: true, Frequency: "fortnightly"},
+	}
+	occurrences := []domain.MedicalExpenseOccurrence{
+		{ExpenseID: "1", Amount: 90, Date: time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "1", Amount: 60, Date: time.Date(2025, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "1", Amount: 70, Date: time.Date(2025, time.March, 20, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "2", Amount: 310, Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		// Outside the year to date, for a one-time expense, or for an unsupported frequency
+		{ExpenseID: "1", Amount: 999, Date: time.Date(2024, time.December, 5, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "1", Amount: 999, Date: time.Date(2025, time.April, 20, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "3", Amount: 999, Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{ExpenseID: "4", Amount: 999, Date: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	variance := analyzer.ReconcileRecurringExpenses(expenses, occurrences, now)
+
+	// January (90 vs 100) and March (130 vs 100) for the monthly expense, February (310 vs 100)
+	// for the quarterly one
+	assert.Equal(t, domain.RecurringExpenseVariance{
+		ProjectedYTD:     300,
+		ActualYTD:        530,
+		Variance:         230,
+		ReconciledMonths: 3,
+	}, variance)
+}
+
+func TestMedicalCostAnalyzer_ReconcileRecurringExpenses_NoOccurrences(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+	expenses := []domain.MedicalExpense{{ID: "1", Amount: 100, IsRecurring: true, Frequency: "monthly"}}
+
+	variance := analyzer.ReconcileRecurringExpenses(expenses, nil, time.Now())
+
+	assert.Equal(t, domain.RecurringExpenseVariance{}, variance)
+}
+
+func TestMedicalCostAnalyzer_BreakdownByCategory(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+	expenses := []domain.MedicalExpense{
+		{Category: "medication", Amount: 120, IsCovered: true, InsurancePayment: 96, OutOfPocket: 24},
+		{Category: "medication", Amount: 80, IsCovered: false},
+		{Category: "doctor_visit", Amount: 150, IsCovered: true, InsurancePayment: 100, OutOfPocket: 50},
+		{Category: "hospital", Amount: 2650, IsCovered: true, InsurancePayment: 2000.5, OutOfPocket: 649.5},
+	}
+
+	breakdown := analyzer.BreakdownByCategory(expenses)
+
+	assert.Equal(t, 3000.0, breakdown.TotalAmount)
+	assert.Equal(t, 2196.5, breakdown.CoveredAmount)
+	assert.Equal(t, 803.5, breakdown.OutOfPocketAmount)
+	assert.Equal(t, 4, breakdown.ExpenseCount)
+	assert.Equal(t, []ExpenseCategoryBreakdown{
+		{Category: "hospital", TotalAmount: 2650, CoveredAmount: 2000.5, OutOfPocketAmount: 649.5, ExpenseCount: 1, Share: 0.8833},
+		{Category: "medication", TotalAmount: 200, CoveredAmount: 96, OutOfPocketAmount: 104, ExpenseCount: 2, Share: 0.0667},
+		{Category: "doctor_visit", TotalAmount: 150, CoveredAmount: 100, OutOfPocketAmount: 50, ExpenseCount: 1, Share: 0.05},
+	}, breakdown.Categories, "uncovered expenses are paid entirely out of pocket")
+}
+
+func TestMedicalCostAnalyzer_BreakdownByCategory_NoExpenses(t *testing.T) {
+	analyzer := NewMedicalCostAnalyzer()
+
+	breakdown := analyzer.BreakdownByCategory(nil)
+
+	assert.Equal(t, &MedicalExpenseBreakdown{Categories: []ExpenseCategoryBreakdown{}}, breakdown)
+}
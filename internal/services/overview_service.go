@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// Overview sections, used as keys of Overview.Errors
+const (
+	OverviewSectionFinance       = "finance"
+	OverviewSectionAffordability = "affordability"
+	OverviewSectionHealth        = "health"
+)
+
+// Overview combines a user's finance and health summaries with the figures derived from both.
+// A section that failed to load is nil and has an entry in Errors keyed by section.
+type Overview struct {
+	UserID        string
+	Finance       *domain.FinanceSummary
+	MaxAffordable *float64
+	// Health is nil without an error when the user has no health profile
+	Health *domain.HealthSummary
+	// Resilience is nil when the finance summary couldn't be loaded
+	Resilience *domain.FinancialResilience
+	Errors     map[string]error
+}
+
+// overviewService implements the OverviewService interface defined in handlers package
+type overviewService struct {
+	financeService FinanceService
+	healthService  HealthService
+}
+
+// NewOverviewService creates a new overview service instance
+// Returns concrete type that implements OverviewService interface defined in handlers package
+func NewOverviewService(financeService FinanceService, healthService HealthService) *overviewService {
+	return &overviewService{
+		financeService: financeService,
+		healthService:  healthService,
+	}
+}
+
+// GetOverview loads the finance summary, affordability and health summary concurrently.
+// A section that fails is reported in Overview.Errors rather than failing the whole overview;
+// an error is only returned when ctx is cancelled.
+func (s *overviewService) GetOverview(ctx context.Context, userID string) (*Overview, error) {
+	var (
+		finance                              domain.FinanceSummary
+		maxAffordable                        float64
+		health                               *domain.HealthSummary
+		financeErr, affordableErr, healthErr error
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		finance, financeErr = s.financeService.CalculateFinanceSummary(gctx, userID)
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		maxAffordable, affordableErr = s.financeService.GetMaxAffordableAmount(gctx, userID)
+		return ctx.Err()
+	})
+	g.Go(func() error {
+		health, healthErr = s.healthService.CalculateHealthSummary(gctx, userID)
+		return ctx.Err()
+	})
+	if err := g.Wait(); err != nil {
+		return nil, fmt.Errorf("failed to load overview: %w", err)
+	}
+
+	overview := &Overview{
+		UserID: userID,
+		Errors: make(map[string]error),
+	}
+
+	if financeErr != nil {
+		overview.Errors[OverviewSectionFinance] = financeErr
+	} else {
+		overview.Finance = &finance
+	}
+
+	if affordableErr != nil {
+		overview.Errors[OverviewSectionAffordability] = affordableErr
+	} else {
+		overview.MaxAffordable = &maxAffordable
+	}
+
+	// Users without a health profile get a finance-only overview
+	if healthErr != nil && !errors.Is(healthErr, ErrProfileNotFound) {
+		overview.Errors[OverviewSectionHealth] = healthErr
+	} else if healthErr == nil {
+		overview.Health = health
+	}
+
+	if overview.Finance != nil {
+		resilience := domain.CalculateFinancialResilience(*overview.Finance, overview.Health)
+		overview.Resilience = &resilience
+	}
+
+	return overview, nil
+}
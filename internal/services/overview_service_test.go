@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// MockOverviewFinanceService mocks the finance calls made by the overview service.
+// The embedded interface is nil; calling any other method panics.
+type MockOverviewFinanceService struct {
+	FinanceService
+	mock.Mock
+}
+
+func (m *MockOverviewFinanceService) CalculateFinanceSummary(ctx context.Context, userID string) (domain.FinanceSummary, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(domain.FinanceSummary), args.Error(1)
+}
+
+func (m *MockOverviewFinanceService) GetMaxAffordableAmount(ctx context.Context, userID string) (float64, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(float64), args.Error(1)
+}
+
+// MockOverviewHealthService mocks the health calls made by the overview service
+type MockOverviewHealthService struct {
+	HealthService
+	mock.Mock
+}
+
+func (m *MockOverviewHealthService) CalculateHealthSummary(ctx context.Context, userID string) (*domain.HealthSummary, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.HealthSummary), args.Error(1)
+}
+
+func setupOverviewService() (*overviewService, *MockOverviewFinanceService, *MockOverviewHealthService) {
+	financeService := &MockOverviewFinanceService{}
+	healthService := &MockOverviewHealthService{}
+	return NewOverviewService(financeService, healthService), financeService, healthService
+}
+
+func overviewTestFinanceSummary() domain.FinanceSummary {
+	return domain.FinanceSummary{
+		UserID:              "user-123",
+		MonthlyIncome:       8000,
+		MonthlyExpenses:     4000,
+		MonthlyLoanPayments: 1600,
+		DisposableIncome:    2400,
+		DebtToIncomeRatio:   0.20,
+	}
+}
+
+func TestOverviewService_GetOverview_AllSections(t *testing.T) {
+	service, financeService, healthService := setupOverviewService()
+	ctx := context.Background()
+
+	health := &domain.HealthSummary{
+		UserID:                   "user-123",
+		HealthRiskLevel:          "low",
+		MonthlyMedicalExpenses:   300,
+		MonthlyInsurancePremiums: 200,
+		EmergencyFundBalance:     12200,
+	}
+	financeService.On("CalculateFinanceSummary", mock.Anything, "user-123").Return(overviewTestFinanceSummary(), nil)
+	financeService.On("GetMaxAffordableAmount", mock.Anything, "user-123").Return(720.0, nil)
+	healthService.On("CalculateHealthSummary", mock.Anything, "user-123").Return(health, nil)
+
+	overview, err := service.GetOverview(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Empty(t, overview.Errors)
+	require.NotNil(t, overview.Finance)
+	assert.Equal(t, 2400.0, overview.Finance.DisposableIncome)
+	require.NotNil(t, overview.MaxAffordable)
+	assert.Equal(t, 720.0, *overview.MaxAffordable)
+	assert.Same(t, health, overview.Health)
+	require.NotNil(t, overview.Resilience)
+	assert.True(t, overview.Resilience.IncludesHealth)
+	assert.Equal(t, 1900.0, overview.Resilience.HealthAdjustedDisposableIncome)
+	assert.Equal(t, domain.ResilienceGradeB, overview.Resilience.Grade)
+	financeService.AssertExpectations(t)
+	healthService.AssertExpectations(t)
+}
+
+func TestOverviewService_GetOverview_NoHealthProfile_FinanceOnly(t *testing.T) {
+	service, financeService, healthService := setupOverviewService()
+	ctx := context.Background()
+
+	financeService.On("CalculateFinanceSummary", mock.Anything, "user-123").Return(overviewTestFinanceSummary(), nil)
+	financeService.On("GetMaxAffordableAmount", mock.Anything, "user-123").Return(720.0, nil)
+	healthService.On("CalculateHealthSummary", mock.Anything, "user-123").
+		Return(nil, fmt.Errorf("failed to get user profile: health %w for user user-123", ErrProfileNotFound))
+
+	overview, err := service.GetOverview(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Empty(t, overview.Errors, "a missing health profile is not an error")
+	assert.Nil(t, overview.Health)
+	require.NotNil(t, overview.Resilience)
+	assert.False(t, overview.Resilience.IncludesHealth)
+	assert.Nil(t, overview.Resilience.EmergencyFundCoverageMonths)
+	assert.Equal(t, domain.ResilienceGradeA, overview.Resilience.Grade)
+}
+
+func TestOverviewService_GetOverview_PartialFailure(t *testing.T) {
+	service, financeService, healthService := setupOverviewService()
+	ctx := context.Background()
+
+	financeErr := errors.New("database unavailable")
+	healthErr := errors.New("failed to get conditions: timeout")
+	financeService.On("CalculateFinanceSummary", mock.Anything, "user-123").Return(domain.FinanceSummary{}, financeErr)
+	financeService.On("GetMaxAffordableAmount", mock.Anything, "user-123").Return(720.0, nil)
+	healthService.On("CalculateHealthSummary", mock.Anything, "user-123").Return(nil, healthErr)
+
+	overview, err := service.GetOverview(ctx, "user-123")
+
+	require.NoError(t, err)
+	assert.Nil(t, overview.Finance)
+	assert.Nil(t, overview.Health)
+	assert.Nil(t, overview.Resilience, "resilience needs the finance summary")
+	require.NotNil(t, overview.MaxAffordable)
+	assert.Equal(t, 720.0, *overview.MaxAffordable)
+	assert.Len(t, overview.Errors, 2)
+	assert.ErrorIs(t, overview.Errors[OverviewSectionFinance], financeErr)
+	assert.ErrorIs(t, overview.Errors[OverviewSectionHealth], healthErr)
+}
+
+func TestOverviewService_GetOverview_ContextCancelled(t *testing.T) {
+	service, financeService, healthService := setupOverviewService()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	financeService.On("CalculateFinanceSummary", mock.Anything, "user-123").Return(domain.FinanceSummary{}, context.Canceled)
+	financeService.On("GetMaxAffordableAmount", mock.Anything, "user-123").Return(0.0, context.Canceled)
+	healthService.On("CalculateHealthSummary", mock.Anything, "user-123").Return(nil, context.Canceled)
+
+	overview, err := service.GetOverview(ctx, "user-123")
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, overview)
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+const (
+	// DefaultPasswordMinLength matches the minimum the registration request already enforces
+	DefaultPasswordMinLength = 8
+	// DefaultPasswordMaxLength is bcrypt's input limit; longer passwords can't be hashed
+	DefaultPasswordMaxLength = 72
+)
+
+// PasswordPolicy is the set of rules a new password must follow, at registration and when
+// it is changed. Existing passwords are never checked against it, so tightening the policy
+// doesn't lock anyone out.
+type PasswordPolicy struct {
+	// MinLength is the fewest characters allowed
+	MinLength int
+	// MaxLength is the most bytes allowed, since bcrypt limits its input in bytes
+	MaxLength     int
+	RequireDigit  bool
+	RequireUpper  bool
+	RequireSymbol bool
+}
+
+// DefaultPasswordPolicy requires 8 to 72 characters and no particular character classes
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength: DefaultPasswordMinLength,
+		MaxLength: DefaultPasswordMaxLength,
+	}
+}
+
+// NewPasswordPolicyFromConfig builds the policy from the auth configuration.
+// Unset lengths keep their defaults.
+func NewPasswordPolicyFromConfig(cfg config.PasswordPolicyConfig) PasswordPolicy {
+	policy := DefaultPasswordPolicy()
+	if cfg.MinLength > 0 {
+		policy.MinLength = cfg.MinLength
+	}
+	if cfg.MaxLength > 0 {
+		policy.MaxLength = cfg.MaxLength
+	}
+	policy.RequireDigit = cfg.RequireDigit
+	policy.RequireUpper = cfg.RequireUpper
+	policy.RequireSymbol = cfg.RequireSymbol
+	return policy
+}
+
+// Check returns a *domain.WeakPasswordError listing every rule the password breaks, or nil
+func (p PasswordPolicy) Check(password string) error {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", p.MinLength))
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes", p.MaxLength))
+	}
+
+	var hasDigit, hasUpper, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsPunct(r), unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+	if p.RequireDigit && !hasDigit {
+		violations = append(violations, "must contain a digit")
+	}
+	if p.RequireUpper && !hasUpper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if p.RequireSymbol && !hasSymbol {
+		violations = append(violations, "must contain a symbol")
+	}
+
+	if len(violations) > 0 {
+		return &domain.WeakPasswordError{Violations: violations}
+	}
+	return nil
+}
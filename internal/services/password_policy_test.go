@@ -0,0 +1,68 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/config"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func TestPasswordPolicy_Check(t *testing.T) {
+	strict := PasswordPolicy{MinLength: 12, MaxLength: 40, RequireDigit: true, RequireUpper: true, RequireSymbol: true}
+
+	tests := []struct {
+		name               string
+		policy             PasswordPolicy
+		password           string
+		expectedViolations []string
+	}{
+		{name: "compliant password", policy: strict, password: "Correct-Horse-42"},
+		{name: "default policy accepts today's passwords", policy: DefaultPasswordPolicy(), password: "password123"},
+		{name: "too short", policy: strict, password: "Short-1A", expectedViolations: []string{"must be at least 12 characters"}},
+		{name: "length counts characters, not bytes", policy: strict, password: "Ünïcödé-Pä55", expectedViolations: nil},
+		{name: "too long", policy: strict, password: "Correct-Horse-42" + strings.Repeat("x", 30), expectedViolations: []string{"must be at most 40 bytes"}},
+		{name: "default maximum is bcrypt's limit", policy: DefaultPasswordPolicy(), password: strings.Repeat("a", 73), expectedViolations: []string{"must be at most 72 bytes"}},
+		{name: "missing digit", policy: strict, password: "Correct-Horse-Battery", expectedViolations: []string{"must contain a digit"}},
+		{name: "missing uppercase letter", policy: strict, password: "correct-horse-42", expectedViolations: []string{"must contain an uppercase letter"}},
+		{name: "missing symbol", policy: strict, password: "CorrectHorse42", expectedViolations: []string{"must contain a symbol"}},
+		{
+			name:     "every broken rule is listed",
+			policy:   strict,
+			password: "short",
+			expectedViolations: []string{
+				"must be at least 12 characters",
+				"must contain a digit",
+				"must contain an uppercase letter",
+				"must contain a symbol",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password)
+
+			if tt.expectedViolations == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, domain.ErrWeakPassword)
+			var weak *domain.WeakPasswordError
+			require.ErrorAs(t, err, &weak)
+			assert.Equal(t, tt.expectedViolations, weak.Violations)
+		})
+	}
+}
+
+func TestNewPasswordPolicyFromConfig(t *testing.T) {
+	assert.Equal(t, DefaultPasswordPolicy(), NewPasswordPolicyFromConfig(config.PasswordPolicyConfig{}),
+		"unset lengths keep the defaults")
+
+	policy := NewPasswordPolicyFromConfig(config.PasswordPolicyConfig{MinLength: 14, RequireDigit: true, RequireSymbol: true})
+
+	assert.Equal(t, PasswordPolicy{MinLength: 14, MaxLength: DefaultPasswordMaxLength, RequireDigit: true, RequireSymbol: true}, policy)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// PersonalDataExportSchemaVersion is the version of the personal data export layout. Bump it
+// when a field is removed or renamed so consumers of older exports can tell them apart.
+const PersonalDataExportSchemaVersion = 1
+
+// PersonalDataExport is everything the application stores about one user, gathered for a
+// personal data download
+type PersonalDataExport struct {
+	SchemaVersion int
+	GeneratedAt   time.Time
+	User          domain.User
+	Finance       PersonalFinanceData
+	Health        PersonalHealthData
+}
+
+// PersonalFinanceData is the finance section of a personal data export
+type PersonalFinanceData struct {
+	Incomes      []domain.Income
+	Expenses     []domain.Expense
+	Loans        []domain.Loan
+	SavingsGoals []domain.SavingsGoal
+	Budgets      []domain.Budget
+}
+
+// PersonalHealthData is the health section of a personal data export. Profiles holds the
+// user's own profile and those of their dependents; every list is empty for users who never
+// created a health profile.
+type PersonalHealthData struct {
+	Profiles   []domain.HealthProfile
+	Conditions []domain.MedicalCondition
+	Expenses   []domain.MedicalExpense
+	Policies   []domain.InsurancePolicy
+}
+
+// PersonalDataFinanceService is the part of the finance service personal data is exported from
+type PersonalDataFinanceService interface {
+	GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error)
+	GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
+	GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error)
+	GetUserSavingsGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error)
+	GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error)
+}
+
+// personalDataExportService implements the PersonalDataExportService interface defined in handlers package
+type personalDataExportService struct {
+	userRepo UserRepository
+	finance  PersonalDataFinanceService
+	health   HealthService
+	now      func() time.Time
+}
+
+// NewPersonalDataExportService creates a new personal data export service instance
+// Returns concrete type that implements PersonalDataExportService interface defined in handlers package
+func NewPersonalDataExportService(userRepo UserRepository, finance PersonalDataFinanceService, health HealthService) *personalDataExportService {
+	return &personalDataExportService{
+		userRepo: userRepo,
+		finance:  finance,
+		health:   health,
+		now:      time.Now,
+	}
+}
+
+// ExportPersonalData gathers the user's account, finance and health records into one export.
+// The records are loaded concurrently through the finance and health services; if any of them
+// fails to load the whole export fails, since a partial download would look complete.
+// Returns domain.ErrUserNotFound if the user doesn't exist.
+func (s *personalDataExportService) ExportPersonalData(ctx context.Context, userID string) (*PersonalDataExport, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export personal data: %w", err)
+	}
+
+	export := &PersonalDataExport{
+		SchemaVersion: PersonalDataExportSchemaVersion,
+		GeneratedAt:   s.now().UTC(),
+		User:          *user,
+	}
+	finance, health := &export.Finance, &export.Health
+
+	g, gctx := errgroup.WithContext(ctx)
+	load := func(section string, fn func() error) {
+		g.Go(func() error {
+			if err := fn(); err != nil {
+				return fmt.Errorf("failed to export %s: %w", section, err)
+			}
+			return nil
+		})
+	}
+	load("incomes", func() (err error) {
+		finance.Incomes, err = s.finance.GetUserIncomes(gctx, userID)
+		return
+	})
+	load("expenses", func() (err error) {
+		finance.Expenses, err = s.finance.GetUserExpenses(gctx, userID)
+		return
+	})
+	load("loans", func() (err error) {
+		finance.Loans, err = s.finance.GetUserLoans(gctx, userID)
+		return
+	})
+	load("savings goals", func() (err error) {
+		finance.SavingsGoals, err = s.finance.GetUserSavingsGoals(gctx, userID)
+		return
+	})
+	load("budgets", func() (err error) {
+		finance.Budgets, err = s.finance.GetUserBudgets(gctx, userID)
+		return
+	})
+	load("health profiles", func() (err error) {
+		health.Profiles, err = s.health.GetFamilyProfiles(gctx, userID)
+		return
+	})
+	load("medical conditions", func() (err error) {
+		health.Conditions, err = s.health.GetConditions(gctx, userID)
+		return
+	})
+	load("medical expenses", func() (err error) {
+		health.Expenses, err = s.health.GetExpenses(gctx, userID)
+		return
+	})
+	load("insurance policies", func() (err error) {
+		health.Policies, err = s.health.GetPolicies(gctx, userID)
+		return
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	logging.ServiceLogger().Info("Personal data exported",
+		logging.WithOperation("export_personal_data"), logging.WithUserID(userID))
+	return export, nil
+}
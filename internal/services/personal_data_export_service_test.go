@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// fakeExportFinanceService returns fixed finance records for the personal data export
+type fakeExportFinanceService struct {
+	data PersonalFinanceData
+	err  error
+}
+
+func (f *fakeExportFinanceService) GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error) {
+	return f.data.Incomes, nil
+}
+
+func (f *fakeExportFinanceService) GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error) {
+	return f.data.Expenses, nil
+}
+
+func (f *fakeExportFinanceService) GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error) {
+	return f.data.Loans, f.err
+}
+
+func (f *fakeExportFinanceService) GetUserSavingsGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error) {
+	return f.data.SavingsGoals, nil
+}
+
+func (f *fakeExportFinanceService) GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error) {
+	return f.data.Budgets, nil
+}
+
+// fakeExportHealthService returns fixed health records for the personal data export.
+// The embedded interface is nil; calling any other method panics.
+type fakeExportHealthService struct {
+	HealthService
+	data PersonalHealthData
+	err  error
+}
+
+func (f *fakeExportHealthService) GetFamilyProfiles(ctx context.Context, userID string) ([]domain.HealthProfile, error) {
+	return f.data.Profiles, nil
+}
+
+func (f *fakeExportHealthService) GetConditions(ctx context.Context, userID string) ([]domain.MedicalCondition, error) {
+	return f.data.Conditions, nil
+}
+
+func (f *fakeExportHealthService) GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
+	return f.data.Expenses, nil
+}
+
+func (f *fakeExportHealthService) GetPolicies(ctx context.Context, userID string) ([]domain.InsurancePolicy, error) {
+	return f.data.Policies, f.err
+}
+
+func TestPersonalDataExportService_ExportPersonalData_AllSections(t *testing.T) {
+	userRepo := &MockUserRepository{}
+	user := &domain.User{ID: "user-123", Email: "user@example.com", Name: "Test User", PasswordHash: "hash", IsActive: true}
+	userRepo.On("GetByID", mock.Anything, "user-123").Return(user, nil)
+
+	finance := &fakeExportFinanceService{data: PersonalFinanceData{
+		Incomes:      []domain.Income{{ID: "inc-1", UserID: "user-123"}},
+		Expenses:     []domain.Expense{{ID: "exp-1", UserID: "user-123"}, {ID: "exp-2", UserID: "user-123"}},
+		Loans:        []domain.Loan{{ID: "loan-1", UserID: "user-123"}},
+		SavingsGoals: []domain.SavingsGoal{{ID: "goal-1", UserID: "user-123"}},
+		Budgets:      []domain.Budget{{ID: "budget-1", UserID: "user-123"}},
+	}}
+	health := &fakeExportHealthService{data: PersonalHealthData{
+		Profiles:   []domain.HealthProfile{{ID: "1", UserID: "user-123"}},
+		Conditions: []domain.MedicalCondition{{ID: "2", UserID: "user-123"}},
+		Expenses:   []domain.MedicalExpense{{ID: "3", UserID: "user-123"}},
+		Policies:   []domain.InsurancePolicy{{ID: "4", UserID: "user-123", IsActive: false}},
+	}}
+	service := NewPersonalDataExportService(userRepo, finance, health)
+	generatedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return generatedAt }
+
+	export, err := service.ExportPersonalData(context.Background(), "user-123")
+
+	require.NoError(t, err)
+	assert.Equal(t, PersonalDataExportSchemaVersion, export.SchemaVersion)
+	assert.Equal(t, generatedAt, export.GeneratedAt)
+	assert.Equal(t, *user, export.User)
+	assert.Equal(t, finance.data, export.Finance)
+	assert.Equal(t, health.data, export.Health)
+}
+
+func TestPersonalDataExportService_ExportPersonalData_UserNotFound(t *testing.T) {
+	userRepo := &MockUserRepository{}
+	userRepo.On("GetByID", mock.Anything, "missing").
+		Return(nil, fmt.Errorf("user with ID missing not found: %w", domain.ErrUserNotFound))
+	service := NewPersonalDataExportService(userRepo, &fakeExportFinanceService{}, &fakeExportHealthService{})
+
+	export, err := service.ExportPersonalData(context.Background(), "missing")
+
+	assert.Nil(t, export)
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+func TestPersonalDataExportService_ExportPersonalData_SectionFailureFailsExport(t *testing.T) {
+	tests := []struct {
+		name    string
+		finance *fakeExportFinanceService
+		health  *fakeExportHealthService
+		section string
+	}{
+		{"finance", &fakeExportFinanceService{err: errors.New("database unavailable")}, &fakeExportHealthService{}, "loans"},
+		{"health", &fakeExportFinanceService{}, &fakeExportHealthService{err: errors.New("database unavailable")}, "insurance policies"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &MockUserRepository{}
+			userRepo.On("GetByID", mock.Anything, "user-123").Return(&domain.User{ID: "user-123"}, nil)
+			service := NewPersonalDataExportService(userRepo, tt.finance, tt.health)
+
+			export, err := service.ExportPersonalData(context.Background(), "user-123")
+
+			assert.Nil(t, export)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "failed to export "+tt.section)
+			assert.Contains(t, err.Error(), "database unavailable")
+		})
+	}
+}
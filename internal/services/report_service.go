@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// ReportFinanceService is the part of the finance service monthly statements are built from
+type ReportFinanceService interface {
+	GetMonthlyFinanceActivity(ctx context.Context, userID string, start, end time.Time) (domain.MonthlyFinanceActivity, error)
+}
+
+// reportService implements the ReportService interface defined in handlers package
+type reportService struct {
+	finance ReportFinanceService
+	health  HealthService
+	now     func() time.Time
+}
+
+// NewReportService creates a new report service instance
+// Returns concrete type that implements ReportService interface defined in handlers package
+func NewReportService(finance ReportFinanceService, health HealthService) *reportService {
+	return &reportService{
+		finance: finance,
+		health:  health,
+		now:     time.Now,
+	}
+}
+
+// GetMonthlyStatement gathers the user's finances and the medical expenses dated in a calendar
+// month, in UTC. The finance activity and medical expenses are loaded concurrently; if either
+// fails the statement fails. A month without any records gives an empty statement, not an error.
+// Returns an error wrapping domain.ErrInvalidReportPeriod for a month that doesn't exist or
+// hasn't started yet.
+func (s *reportService) GetMonthlyStatement(ctx context.Context, userID string, year int, month time.Month) (*domain.MonthlyStatement, error) {
+	start, end, err := domain.StatementMonth(year, month, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &domain.MonthlyStatement{UserID: userID, Year: year, Month: month}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() error {
+		activity, err := s.finance.GetMonthlyFinanceActivity(gctx, userID, start, end)
+		if err != nil {
+			return fmt.Errorf("failed to get finance activity: %w", err)
+		}
+		statement.Finance = activity
+		return nil
+	})
+	g.Go(func() error {
+		expenses, err := s.health.GetExpenses(gctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get medical expenses: %w", err)
+		}
+		statement.Health = domain.SummarizeHealthSpend(expenses, start, end)
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+// MockReportFinanceService mocks the finance activity monthly statements are built from
+type MockReportFinanceService struct {
+	mock.Mock
+}
+
+func (m *MockReportFinanceService) GetMonthlyFinanceActivity(ctx context.Context, userID string, start, end time.Time) (domain.MonthlyFinanceActivity, error) {
+	args := m.Called(ctx, userID, start, end)
+	return args.Get(0).(domain.MonthlyFinanceActivity), args.Error(1)
+}
+
+// MockReportHealthService mocks the health calls made by the report service.
+// The embedded interface is nil; calling any other method panics.
+type MockReportHealthService struct {
+	HealthService
+	mock.Mock
+}
+
+func (m *MockReportHealthService) GetExpenses(ctx context.Context, userID string) ([]domain.MedicalExpense, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.MedicalExpense), args.Error(1)
+}
+
+func setupReportService() (*reportService, *MockReportFinanceService, *MockReportHealthService) {
+	financeService := &MockReportFinanceService{}
+	healthService := &MockReportHealthService{}
+	service := NewReportService(financeService, healthService)
+	service.now = func() time.Time { return time.Date(2025, time.April, 10, 0, 0, 0, 0, time.UTC) }
+	return service, financeService, healthService
+}
+
+func TestReportService_GetMonthlyStatement(t *testing.T) {
+	service, financeService, healthService := setupReportService()
+	start := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+	activity := domain.MonthlyFinanceActivity{
+		Currency:    "USD",
+		Income:      []domain.StatementLine{{Label: "Salary", Amount: 4000, Count: 1}},
+		TotalIncome: 4000,
+		RecordCount: 1,
+	}
+	financeService.On("GetMonthlyFinanceActivity", mock.Anything, "user-123", start, end).Return(activity, nil)
+	healthService.On("GetExpenses", mock.Anything, "user-123").Return([]domain.MedicalExpense{
+		{Amount: 150, InsurancePayment: 100, Date: start.AddDate(0, 0, 9)},
+		{Amount: 70, Date: end},
+	}, nil)
+
+	statement, err := service.GetMonthlyStatement(context.Background(), "user-123", 2025, time.March)
+
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", statement.UserID)
+	assert.Equal(t, "March 2025", statement.Period())
+	assert.Equal(t, activity, statement.Finance)
+	assert.Equal(t, domain.MonthlyHealthSpend{TotalAmount: 150, CoveredAmount: 100, OutOfPocketAmount: 50, ExpenseCount: 1}, statement.Health,
+		"medical expenses dated after the month are left out")
+	assert.Equal(t, 3950.0, statement.NetSavings())
+}
+
+func TestReportService_GetMonthlyStatement_EmptyMonth(t *testing.T) {
+	service, financeService, healthService := setupReportService()
+
+	financeService.On("GetMonthlyFinanceActivity", mock.Anything, "user-123", mock.Anything, mock.Anything).
+		Return(domain.MonthlyFinanceActivity{}, nil)
+	healthService.On("GetExpenses", mock.Anything, "user-123").Return([]domain.MedicalExpense{}, nil)
+
+	statement, err := service.GetMonthlyStatement(context.Background(), "user-123", 2024, time.June)
+
+	require.NoError(t, err)
+	assert.True(t, statement.IsEmpty())
+}
+
+func TestReportService_GetMonthlyStatement_InvalidPeriod(t *testing.T) {
+	service, financeService, healthService := setupReportService()
+
+	_, err := service.GetMonthlyStatement(context.Background(), "user-123", 2025, time.May)
+
+	assert.True(t, errors.Is(err, domain.ErrInvalidReportPeriod))
+	financeService.AssertNotCalled(t, "GetMonthlyFinanceActivity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	healthService.AssertNotCalled(t, "GetExpenses", mock.Anything, mock.Anything)
+}
+
+func TestReportService_GetMonthlyStatement_SourceFails(t *testing.T) {
+	service, financeService, healthService := setupReportService()
+	failure := errors.New("database unavailable")
+
+	financeService.On("GetMonthlyFinanceActivity", mock.Anything, "user-123", mock.Anything, mock.Anything).
+		Return(domain.MonthlyFinanceActivity{}, nil)
+	healthService.On("GetExpenses", mock.Anything, "user-123").Return([]domain.MedicalExpense(nil), failure)
+
+	statement, err := service.GetMonthlyStatement(context.Background(), "user-123", 2025, time.March)
+
+	assert.Nil(t, statement)
+	assert.ErrorIs(t, err, failure)
+	assert.Contains(t, err.Error(), "failed to get medical expenses")
+}
@@ -11,7 +11,7 @@ import (
 // These interfaces are consumed by services in this package, so they belong here
 
 // UserRepository defines the interface for user persistence operations
-// This interface is consumed by AuthService
+// This interface is consumed by AuthService and AdminService
 type UserRepository interface {
 	// Create saves a new user to the database
 	Create(ctx context.Context, user *domain.User) error
@@ -27,12 +27,23 @@ type UserRepository interface {
 
 	// UpdateLastLogin updates the last login timestamp for a user
 	UpdateLastLogin(ctx context.Context, userID string, loginTime time.Time) error
+
+	// UpdateRole changes a user's role
+	UpdateRole(ctx context.Context, userID, role string) error
+
+	// List returns a page of users ordered by ID along with the total number of users
+	List(ctx context.Context, offset, limit int) ([]domain.User, int64, error)
+
+	// Delete permanently removes a user and every record they own, including soft-deleted ones
+	// Returns domain.ErrUserNotFound if the user doesn't exist
+	Delete(ctx context.Context, userID string) error
 }
 
 // TokenRepository defines the interface for refresh token persistence operations
-// This interface is consumed by AuthService
+// This interface is consumed by AuthService and AdminService
 type TokenRepository interface {
 	// SaveRefreshToken stores a refresh token for a user
+	// Returns domain.ErrTokenAlreadyExists if the token is already stored
 	SaveRefreshToken(ctx context.Context, userID, token string, expiresAt time.Time) error
 
 	// GetRefreshToken retrieves a refresh token by the token string
@@ -44,8 +55,16 @@ type TokenRepository interface {
 	// RevokeAllUserTokens marks all refresh tokens for a user as revoked
 	RevokeAllUserTokens(ctx context.Context, userID string) error
 
-	// CleanupExpiredTokens removes expired tokens from the database
-	CleanupExpiredTokens(ctx context.Context) error
+	// CleanupExpiredTokens permanently removes expired tokens and returns how many were purged
+	CleanupExpiredTokens(ctx context.Context) (int64, error)
+}
+
+// TxManager runs multi-step operations in a single database transaction
+// This interface is consumed by services whose flows perform several writes
+type TxManager interface {
+	// WithTx runs fn in a transaction. Repository calls made with the context passed to fn
+	// take part in the transaction, which is rolled back if fn returns an error.
+	WithTx(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 // IncomeRepository defines the interface for income data persistence
@@ -56,11 +75,19 @@ type IncomeRepository interface {
 	GetIncomeByID(ctx context.Context, id string) (domain.Income, error)
 	UpdateIncome(ctx context.Context, income domain.Income) error
 	DeleteIncome(ctx context.Context, id string) error
+	// GetDeletedIncomeByID returns a soft-deleted income; incomes that aren't deleted are not found
+	GetDeletedIncomeByID(ctx context.Context, id string) (domain.Income, error)
+	RestoreIncome(ctx context.Context, id string) error
 
 	// User-scoped queries
 	GetUserIncomes(ctx context.Context, userID string) ([]domain.Income, error)
 	GetActiveIncomes(ctx context.Context, userID string) ([]domain.Income, error)
 	GetUserIncomesByFrequency(ctx context.Context, userID string, frequency string) ([]domain.Income, error)
+	// GetUserIncomesAfter returns up to limit incomes created after cursor, oldest first
+	GetUserIncomesAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Income, error)
+	// FindDuplicateIncomeID returns the ID of the newest active income of the same user with the same
+	// source, amount and frequency created at or after since, or "" if there is none
+	FindDuplicateIncomeID(ctx context.Context, income domain.Income, since time.Time) (string, error)
 
 	// Aggregation queries
 	CalculateUserTotalIncome(ctx context.Context, userID string, activeOnly bool) (float64, error)
@@ -74,6 +101,11 @@ type ExpenseRepository interface {
 	GetExpenseByID(ctx context.Context, id string) (domain.Expense, error)
 	UpdateExpense(ctx context.Context, expense domain.Expense) error
 	DeleteExpense(ctx context.Context, id string) error
+	// GetDeletedExpenseByID returns a soft-deleted expense; expenses that aren't deleted are not found
+	GetDeletedExpenseByID(ctx context.Context, id string) (domain.Expense, error)
+	RestoreExpense(ctx context.Context, id string) error
+	// DeleteExpenses soft-deletes the expenses with the given IDs in one statement and returns how many were deleted
+	DeleteExpenses(ctx context.Context, ids []string) (int64, error)
 
 	// User-scoped queries
 	GetUserExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
@@ -84,6 +116,12 @@ type ExpenseRepository interface {
 	// Filtered queries
 	GetFixedExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
 	GetVariableExpenses(ctx context.Context, userID string) ([]domain.Expense, error)
+	FindExpenses(ctx context.Context, userID string, filter domain.ExpenseFilter) ([]domain.Expense, error)
+	// FindExpensesAfter returns up to limit expenses matching filter created after cursor, oldest first
+	FindExpensesAfter(ctx context.Context, userID string, filter domain.ExpenseFilter, cursor domain.Cursor, limit int) ([]domain.Expense, error)
+	// FindDuplicateExpenseID returns the ID of the newest expense of the same user with the same
+	// name, amount and frequency created at or after since, or "" if there is none
+	FindDuplicateExpenseID(ctx context.Context, expense domain.Expense, since time.Time) (string, error)
 
 	// Aggregation queries
 	CalculateUserTotalExpenses(ctx context.Context, userID string) (float64, error)
@@ -103,16 +141,63 @@ type LoanRepository interface {
 	GetUserLoans(ctx context.Context, userID string) ([]domain.Loan, error)
 	GetLoansByType(ctx context.Context, userID string, loanType string) ([]domain.Loan, error)
 	GetLoansByInterestRateRange(ctx context.Context, userID string, minRate, maxRate float64) ([]domain.Loan, error)
+	// GetUserLoansAfter returns up to limit loans created after cursor, oldest first
+	GetUserLoansAfter(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.Loan, error)
 
 	// Balance management
 	UpdateLoanBalance(ctx context.Context, loanID string, newBalance float64) error
 	GetNearPayoffLoans(ctx context.Context, userID string, threshold float64) ([]domain.Loan, error)
+	// GetLoanByIDForUpdate retrieves a loan and locks it until the transaction in ctx ends, so
+	// concurrent balance changes each start from the balance the one before left
+	GetLoanByIDForUpdate(ctx context.Context, id string) (domain.Loan, error)
+	// RecordBalanceChange saves the entry and sets the loan's remaining balance to its new
+	// balance in one transaction, so the loan always carries the latest entry's balance
+	RecordBalanceChange(ctx context.Context, entry domain.LoanBalanceEntry) error
+	// GetBalanceEntries returns a loan's balance history, newest first
+	GetBalanceEntries(ctx context.Context, loanID string) ([]domain.LoanBalanceEntry, error)
+	// GetLoansDueForAccrual returns every user's auto-accruing loans with a balance left and no
+	// interest accrued at or after since
+	GetLoansDueForAccrual(ctx context.Context, since time.Time) ([]domain.Loan, error)
 
 	// Aggregation queries
 	CalculateUserTotalDebt(ctx context.Context, userID string) (float64, error)
 	CalculateUserMonthlyPayments(ctx context.Context, userID string) (float64, error)
 }
 
+// SavingsGoalRepository defines the interface for savings goal and contribution persistence
+// This interface is consumed by FinanceService
+type SavingsGoalRepository interface {
+	// Basic CRUD operations
+	SaveGoal(ctx context.Context, goal domain.SavingsGoal) error
+	// GetGoalByID returns domain.ErrSavingsGoalNotFound if the goal doesn't exist
+	GetGoalByID(ctx context.Context, id string) (domain.SavingsGoal, error)
+	UpdateGoal(ctx context.Context, goal domain.SavingsGoal) error
+	DeleteGoal(ctx context.Context, id string) error
+
+	// User-scoped queries
+	GetUserGoals(ctx context.Context, userID string) ([]domain.SavingsGoal, error)
+
+	// Contributions
+	// AddContribution records the contribution and adds its amount to the goal in one transaction
+	AddContribution(ctx context.Context, contribution domain.GoalContribution) error
+	// GetUserContributions returns the user's contributions made at or after since, oldest first
+	GetUserContributions(ctx context.Context, userID string, since time.Time) ([]domain.GoalContribution, error)
+}
+
+// BudgetRepository defines the interface for budget data persistence
+// This interface is consumed by FinanceService
+type BudgetRepository interface {
+	// SaveBudget returns domain.ErrBudgetExists if the user already has a budget for the category
+	SaveBudget(ctx context.Context, budget domain.Budget) error
+	// GetBudgetByID returns domain.ErrBudgetNotFound if the budget doesn't exist
+	GetBudgetByID(ctx context.Context, id string) (domain.Budget, error)
+	UpdateBudget(ctx context.Context, budget domain.Budget) error
+	DeleteBudget(ctx context.Context, id string) error
+
+	// GetUserBudgets returns the user's budgets ordered by category
+	GetUserBudgets(ctx context.Context, userID string) ([]domain.Budget, error)
+}
+
 // FinanceSummaryRepository defines the interface for finance summary data persistence
 // This interface is consumed by FinanceService
 type FinanceSummaryRepository interface {
@@ -128,12 +213,72 @@ type FinanceSummaryRepository interface {
 	GetUsersWithLowSavingsRate(ctx context.Context, threshold float64) ([]domain.FinanceSummary, error)
 }
 
+// WebhookRepository defines the interface for webhook and delivery log persistence
+// This interface is consumed by WebhookService and WebhookDispatcher
+type WebhookRepository interface {
+	// Basic CRUD operations
+	// Create assigns the webhook its ID and timestamps
+	Create(ctx context.Context, webhook *domain.Webhook) error
+	// GetByID returns domain.ErrWebhookNotFound if the webhook doesn't exist
+	GetByID(ctx context.Context, id string) (domain.Webhook, error)
+	Update(ctx context.Context, webhook *domain.Webhook) error
+	Delete(ctx context.Context, id string) error
+
+	// User-scoped queries
+	GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error)
+	// GetSubscribers returns the user's active webhooks subscribed to eventType
+	GetSubscribers(ctx context.Context, userID, eventType string) ([]domain.Webhook, error)
+
+	// Delivery log
+	RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error
+	// GetDeliveries returns the most recent delivery attempts for a webhook, newest first
+	GetDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error)
+}
+
+// APIKeyRepository defines the interface for API key persistence
+// This interface is consumed by APIKeyService
+type APIKeyRepository interface {
+	// Create assigns the key its ID and creation time
+	Create(ctx context.Context, key *domain.APIKey) error
+	// GetByID returns domain.ErrAPIKeyNotFound if the key doesn't exist
+	GetByID(ctx context.Context, id string) (domain.APIKey, error)
+	// GetByHashedKey returns domain.ErrAPIKeyNotFound if no key has the hash
+	GetByHashedKey(ctx context.Context, hashedKey string) (domain.APIKey, error)
+	// GetUserAPIKeys returns all of the user's keys, revoked ones included, oldest first
+	GetUserAPIKeys(ctx context.Context, userID string) ([]domain.APIKey, error)
+	// Revoke returns domain.ErrAPIKeyNotFound if the key doesn't exist
+	Revoke(ctx context.Context, id string, revokedAt time.Time) error
+	UpdateLastUsed(ctx context.Context, id string, usedAt time.Time) error
+}
+
+// AuditLogRepository defines the interface for audit log persistence
+// This interface is consumed by AuditService; entries are never updated or deleted
+type AuditLogRepository interface {
+	// SaveEntries appends entries to the audit log in one batch
+	SaveEntries(ctx context.Context, entries []domain.AuditEntry) error
+	// GetEntriesBefore returns up to limit entries older than cursor, newest first
+	// userID limits the entries to one user; "" returns every user's entries
+	GetEntriesBefore(ctx context.Context, userID string, cursor domain.Cursor, limit int) ([]domain.AuditEntry, error)
+}
+
+// DemoDataRepository defines the interface for the records that track seeded demo data
+// This interface is consumed by DemoDataService
+type DemoDataRepository interface {
+	// SaveRecords saves the records of one seeding together
+	SaveRecords(ctx context.Context, records []domain.DemoDataRecord) error
+	// GetUserRecords returns every demo data record of the user, oldest first
+	GetUserRecords(ctx context.Context, userID string) ([]domain.DemoDataRecord, error)
+	// DeleteUserRecords forgets the user's demo data records without touching the seeded records
+	DeleteUserRecords(ctx context.Context, userID string) error
+}
+
 // FinanceRepositories aggregates all finance-related repositories
 // Used by FinanceService for dependency injection
 type FinanceRepositories struct {
 	Income         IncomeRepository
 	Expense        ExpenseRepository
 	Loan           LoanRepository
+	SavingsGoal    SavingsGoalRepository
 	FinanceSummary FinanceSummaryRepository
 }
 
@@ -142,12 +287,14 @@ func NewFinanceRepositories(
 	income IncomeRepository,
 	expense ExpenseRepository,
 	loan LoanRepository,
+	savingsGoal SavingsGoalRepository,
 	financeSummary FinanceSummaryRepository,
 ) *FinanceRepositories {
 	return &FinanceRepositories{
 		Income:         income,
 		Expense:        expense,
 		Loan:           loan,
+		SavingsGoal:    savingsGoal,
 		FinanceSummary: financeSummary,
 	}
 }
\ No newline at end of file
@@ -5,11 +5,12 @@ import (
 
 	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Test CalculateHealthRiskScore with different scenarios
 func TestRiskCalculator_CalculateHealthRiskScore(t *testing.T) {
-	calculator := NewRiskCalculator()
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
 
 	tests := []struct {
 		name       string
@@ -120,7 +121,7 @@ func TestRiskCalculator_CalculateHealthRiskScore(t *testing.T) {
 
 // Test AssessFinancialVulnerability
 func TestRiskCalculator_AssessFinancialVulnerability(t *testing.T) {
-	calculator := NewRiskCalculator()
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
 
 	tests := []struct {
 		name        string
@@ -176,7 +177,7 @@ func TestRiskCalculator_AssessFinancialVulnerability(t *testing.T) {
 
 // Test RecommendEmergencyFund
 func TestRiskCalculator_RecommendEmergencyFund(t *testing.T) {
-	calculator := NewRiskCalculator()
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
 
 	tests := []struct {
 		name             string
@@ -245,7 +246,7 @@ func TestRiskCalculator_RecommendEmergencyFund(t *testing.T) {
 
 // Test DetermineRiskLevel
 func TestRiskCalculator_DetermineRiskLevel(t *testing.T) {
-	calculator := NewRiskCalculator()
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
 
 	tests := []struct {
 		name     string
@@ -301,3 +302,196 @@ func TestRiskCalculator_DetermineRiskLevel(t *testing.T) {
 		})
 	}
 }
+
+// legacyRiskScore is the hardcoded scoring the calculator used before the risk model was
+// configurable. The golden tests check the default model still produces exactly these scores.
+func legacyRiskScore(age int, bmi float64, familySize int, severities []string) int {
+	score := 0
+
+	switch {
+	case age < 30:
+	case age < 40:
+		score += 5
+	case age < 50:
+		score += 10
+	case age < 60:
+		score += 15
+	default:
+		score += 20
+	}
+
+	switch {
+	case bmi >= 18.5 && bmi <= 25:
+	case bmi > 25 && bmi <= 30:
+		score += 8
+	default:
+		score += 15
+	}
+
+	for _, severity := range severities {
+		switch severity {
+		case "moderate":
+			score += 5
+		case "severe":
+			score += 10
+		case "critical":
+			score += 15
+		default:
+			score += 2
+		}
+	}
+
+	switch {
+	case familySize <= 2:
+	case familySize <= 4:
+		score += 5
+	default:
+		score += 10
+	}
+
+	if score > 100 {
+		score = 100
+	}
+	return score
+}
+
+func legacyRiskLevel(score int) string {
+	switch {
+	case score <= 25:
+		return "low"
+	case score <= 50:
+		return "moderate"
+	case score <= 75:
+		return "high"
+	default:
+		return "critical"
+	}
+}
+
+// Golden test: the default risk model reproduces the legacy scores exactly
+func TestRiskCalculator_DefaultModel_MatchesLegacyScores(t *testing.T) {
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
+
+	ages := []int{0, 18, 29, 30, 35, 39, 40, 49, 50, 59, 60, 75, 120}
+	bmis := []float64{0, 15, 18.49, 18.5, 22, 24.99, 25, 25.01, 27.5, 30, 30.01, 35, 60}
+	familySizes := []int{1, 2, 3, 4, 5, 8}
+	conditionSets := [][]string{
+		nil,
+		{"mild"},
+		{"moderate"},
+		{"severe"},
+		{"critical"},
+		{"unknown"},
+		{"critical", "severe", "moderate"},
+		{"critical", "critical", "critical", "critical", "critical"},
+	}
+	categories := []string{"chronic", "acute", "mental_health", "preventive", "other"}
+
+	for _, age := range ages {
+		for _, bmi := range bmis {
+			for _, familySize := range familySizes {
+				for _, severities := range conditionSets {
+					for _, category := range categories {
+						profile := &domain.HealthProfile{Age: age, BMI: bmi, FamilySize: familySize}
+						conditions := make([]domain.MedicalCondition, 0, len(severities)+1)
+						for _, severity := range severities {
+							conditions = append(conditions, domain.MedicalCondition{Severity: severity, Category: category, IsActive: true})
+						}
+						// Inactive conditions never score
+						conditions = append(conditions, domain.MedicalCondition{Severity: "critical", Category: category, IsActive: false})
+
+						expected := legacyRiskScore(age, bmi, familySize, severities)
+						score := calculator.CalculateHealthRiskScore(profile, conditions)
+						if score != expected {
+							t.Fatalf("age=%d bmi=%v family=%d severities=%v category=%s: got %d, want %d",
+								age, bmi, familySize, severities, category, score, expected)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for score := 0; score <= 100; score++ {
+		assert.Equal(t, legacyRiskLevel(score), calculator.DetermineRiskLevel(score), "score %d", score)
+	}
+}
+
+// Golden test: exact scores for representative profiles under the default model
+func TestRiskCalculator_DefaultModel_GoldenScores(t *testing.T) {
+	calculator := NewRiskCalculator(domain.DefaultRiskModel())
+
+	tests := []struct {
+		name       string
+		profile    *domain.HealthProfile
+		conditions []domain.MedicalCondition
+		expected   int
+	}{
+		{
+			name:     "young_healthy",
+			profile:  &domain.HealthProfile{Age: 25, Height: 175, Weight: 70, FamilySize: 2},
+			expected: 0,
+		},
+		{
+			name:    "middle_aged_overweight_chronic",
+			profile: &domain.HealthProfile{Age: 45, Height: 170, Weight: 85, FamilySize: 4},
+			conditions: []domain.MedicalCondition{
+				{Severity: "moderate", Category: "chronic", IsActive: true},
+			},
+			expected: 28,
+		},
+		{
+			name:    "elderly_obese_two_conditions",
+			profile: &domain.HealthProfile{Age: 70, Height: 160, Weight: 95, FamilySize: 5},
+			conditions: []domain.MedicalCondition{
+				{Severity: "severe", Category: "chronic", IsActive: true},
+				{Severity: "moderate", Category: "chronic", IsActive: true},
+			},
+			expected: 60,
+		},
+		{
+			name:    "capped_at_maximum",
+			profile: &domain.HealthProfile{Age: 80, Height: 155, Weight: 45, FamilySize: 6},
+			conditions: []domain.MedicalCondition{
+				{Severity: "critical", Category: "chronic", IsActive: true},
+				{Severity: "critical", Category: "chronic", IsActive: true},
+				{Severity: "critical", Category: "chronic", IsActive: true},
+				{Severity: "critical", Category: "chronic", IsActive: true},
+				{Severity: "critical", Category: "chronic", IsActive: true},
+			},
+			expected: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, calculator.CalculateHealthRiskScore(tt.profile, tt.conditions))
+		})
+	}
+}
+
+// Test that a custom model changes the scoring
+func TestRiskCalculator_CustomModel(t *testing.T) {
+	model := domain.DefaultRiskModel()
+	model.AgeBands = []domain.RiskBand{
+		{Min: 0, Max: 50, Points: 0},
+		{Min: 50, Points: 30},
+	}
+	model.SeverityPoints["moderate"] = 7
+	model.CategoryWeights["mental_health"] = 2
+	model.LevelCutoffs = domain.RiskLevelCutoffs{Low: 10, Moderate: 40, High: 60}
+	require.NoError(t, model.Validate())
+
+	calculator := NewRiskCalculator(model)
+	profile := &domain.HealthProfile{Age: 55, BMI: 22, FamilySize: 1}
+	conditions := []domain.MedicalCondition{
+		{Severity: "moderate", Category: "mental_health", IsActive: true},
+		{Severity: "moderate", Category: "chronic", IsActive: true},
+	}
+
+	score := calculator.CalculateHealthRiskScore(profile, conditions)
+
+	assert.Equal(t, 30+14+7, score)
+	assert.Equal(t, "high", calculator.DetermineRiskLevel(score))
+	assert.Equal(t, model, calculator.Model())
+}
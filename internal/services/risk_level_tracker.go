@@ -0,0 +1,45 @@
+package services
+
+import "sync"
+
+// riskLevelTracker remembers the last health risk level computed for each user so
+// that rises in risk can be detected. It is in memory only, so after a restart the
+// first summary computed for a user is compared against an unknown level.
+type riskLevelTracker struct {
+	mu     sync.Mutex
+	levels map[string]string
+}
+
+// newRiskLevelTracker creates an empty risk level tracker
+func newRiskLevelTracker() *riskLevelTracker {
+	return &riskLevelTracker{
+		levels: make(map[string]string),
+	}
+}
+
+// swap stores the user's current level and returns the previous one, or "" if unknown
+func (t *riskLevelTracker) swap(userID, level string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous := t.levels[userID]
+	t.levels[userID] = level
+	return previous
+}
+
+// riskLevelRank orders the levels returned by RiskCalculator.DetermineRiskLevel
+// Unknown levels rank below low
+func riskLevelRank(level string) int {
+	switch level {
+	case "low":
+		return 0
+	case "moderate":
+		return 1
+	case "high":
+		return 2
+	case "critical":
+		return 3
+	default:
+		return -1
+	}
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// TokenCleanupJob periodically purges expired refresh tokens.
+// Runs are serialized: a tick or manual trigger that arrives while a cleanup
+// is still running is skipped instead of queued.
+type TokenCleanupJob struct {
+	tokenRepo TokenRepository
+	interval  time.Duration
+
+	running  sync.Mutex
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewTokenCleanupJob creates a cleanup job that runs every interval once started.
+// A non-positive interval disables the periodic run; RunOnce still works.
+func NewTokenCleanupJob(tokenRepo TokenRepository, interval time.Duration) *TokenCleanupJob {
+	return &TokenCleanupJob{
+		tokenRepo: tokenRepo,
+		interval:  interval,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start launches the periodic cleanup goroutine
+func (j *TokenCleanupJob) Start() {
+	if j.interval <= 0 {
+		close(j.done)
+		return
+	}
+
+	go func() {
+		defer close(j.done)
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), j.interval)
+				_, err := j.RunOnce(ctx)
+				cancel()
+				if err != nil && !errors.Is(err, domain.ErrTokenCleanupInProgress) {
+					j.logger().Error("Scheduled token cleanup failed", logging.WithError(err))
+				}
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the periodic cleanup and waits for a run in progress to finish.
+// It must only be called after Start.
+func (j *TokenCleanupJob) Stop() {
+	j.stopOnce.Do(func() {
+		close(j.stop)
+	})
+	<-j.done
+}
+
+// RunOnce purges expired tokens and returns how many rows were removed.
+// Returns domain.ErrTokenCleanupInProgress if another run has not finished yet.
+func (j *TokenCleanupJob) RunOnce(ctx context.Context) (int64, error) {
+	if !j.running.TryLock() {
+		return 0, domain.ErrTokenCleanupInProgress
+	}
+	defer j.running.Unlock()
+
+	start := time.Now()
+	purged, err := j.tokenRepo.CleanupExpiredTokens(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired tokens: %w", err)
+	}
+
+	j.logger().Info("Expired refresh tokens purged",
+		logging.WithRowsAffected(purged),
+		logging.WithDuration("duration", time.Since(start)))
+
+	return purged, nil
+}
+
+func (j *TokenCleanupJob) logger() *zap.Logger {
+	if logger := logging.ServiceLogger(); logger != nil {
+		return logger.With(logging.WithOperation("token_cleanup"))
+	}
+	return zap.NewNop()
+}
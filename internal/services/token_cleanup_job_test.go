@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+func TestTokenCleanupJob_RunOnce_ReturnsPurgedCount(t *testing.T) {
+	setupTestLogger()
+	tokenRepo := new(MockTokenRepository)
+	tokenRepo.On("CleanupExpiredTokens", mock.Anything).Return(int64(3), nil)
+	job := NewTokenCleanupJob(tokenRepo, 0)
+
+	purged, err := job.RunOnce(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	tokenRepo.AssertExpectations(t)
+}
+
+func TestTokenCleanupJob_RunOnce_RepositoryError(t *testing.T) {
+	setupTestLogger()
+	tokenRepo := new(MockTokenRepository)
+	tokenRepo.On("CleanupExpiredTokens", mock.Anything).Return(int64(0), errors.New("database unavailable"))
+	job := NewTokenCleanupJob(tokenRepo, 0)
+
+	_, err := job.RunOnce(context.Background())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "database unavailable")
+}
+
+func TestTokenCleanupJob_RunOnce_RejectsConcurrentRun(t *testing.T) {
+	setupTestLogger()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	tokenRepo := new(MockTokenRepository)
+	tokenRepo.On("CleanupExpiredTokens", mock.Anything).
+		Run(func(mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(int64(1), nil).Once()
+	job := NewTokenCleanupJob(tokenRepo, 0)
+
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := job.RunOnce(context.Background())
+		firstDone <- err
+	}()
+	<-started
+
+	_, err := job.RunOnce(context.Background())
+	assert.ErrorIs(t, err, domain.ErrTokenCleanupInProgress)
+
+	close(release)
+	require.NoError(t, <-firstDone)
+	tokenRepo.AssertNumberOfCalls(t, "CleanupExpiredTokens", 1)
+}
+
+func TestTokenCleanupJob_StartStop_RunsPeriodically(t *testing.T) {
+	setupTestLogger()
+	ran := make(chan struct{}, 10)
+	tokenRepo := new(MockTokenRepository)
+	tokenRepo.On("CleanupExpiredTokens", mock.Anything).
+		Run(func(mock.Arguments) { ran <- struct{}{} }).
+		Return(int64(0), nil)
+	job := NewTokenCleanupJob(tokenRepo, 10*time.Millisecond)
+
+	job.Start()
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("cleanup did not run within a second")
+	}
+	job.Stop()
+
+	// No further runs after Stop has returned
+	for len(ran) > 0 {
+		<-ran
+	}
+	time.Sleep(30 * time.Millisecond)
+	assert.Empty(t, ran)
+}
@@ -0,0 +1,349 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/DuckDHD/BuyOrBye/internal/logging"
+)
+
+// Headers sent with every webhook delivery
+const (
+	// WebhookSignatureHeader carries "sha256=" and the hex HMAC-SHA256 of the body keyed with the webhook secret
+	WebhookSignatureHeader = "X-BuyOrBye-Signature"
+	// WebhookEventHeader carries the event type
+	WebhookEventHeader = "X-BuyOrBye-Event"
+	// WebhookDeliveryHeader carries the event ID, which stays the same across retries
+	WebhookDeliveryHeader = "X-BuyOrBye-Delivery"
+)
+
+// maxWebhookResponseBytes caps how much of a receiver's response body is read
+const maxWebhookResponseBytes = 64 << 10
+
+// errWebhookTargetNotAllowed is returned when a webhook URL resolves to a private address
+var errWebhookTargetNotAllowed = errors.New("webhook target address is not allowed")
+
+// WebhookDispatcherConfig holds the delivery settings of a WebhookDispatcher.
+// Zero values fall back to DefaultWebhookDispatcherConfig.
+type WebhookDispatcherConfig struct {
+	Workers        int
+	QueueSize      int
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RequestTimeout time.Duration
+	// AllowPrivateNetworks permits deliveries to loopback, private and link-local addresses
+	AllowPrivateNetworks bool
+}
+
+// DefaultWebhookDispatcherConfig returns the default delivery settings
+func DefaultWebhookDispatcherConfig() WebhookDispatcherConfig {
+	return WebhookDispatcherConfig{
+		Workers:        2,
+		QueueSize:      256,
+		MaxAttempts:    5,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		RequestTimeout: 10 * time.Second,
+	}
+}
+
+// webhookPayload is the JSON body POSTed to webhooks
+type webhookPayload struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Data       map[string]interface{} `json:"data"`
+}
+
+// WebhookDispatcher delivers published events to the webhooks subscribed to them.
+// Events are queued and delivered by background workers, retrying failed deliveries
+// with exponential backoff. Every attempt is recorded in the delivery log.
+type WebhookDispatcher struct {
+	repo   WebhookRepository
+	config WebhookDispatcherConfig
+	client *http.Client
+	queue  chan domain.Event
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	workers   sync.WaitGroup
+	startOnce sync.Once
+}
+
+// NewWebhookDispatcher creates a dispatcher; call Start to begin delivering events
+func NewWebhookDispatcher(repo WebhookRepository, config WebhookDispatcherConfig) *WebhookDispatcher {
+	defaults := DefaultWebhookDispatcherConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = defaults.QueueSize
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = defaults.RequestTimeout
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookDispatcher{
+		repo:   repo,
+		config: config,
+		client: newWebhookHTTPClient(config),
+		queue:  make(chan domain.Event, config.QueueSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// newWebhookHTTPClient builds a client that doesn't follow redirects or use proxies,
+// and unless allowed refuses to connect to private addresses, so that webhook URLs
+// can't be used to reach internal services
+func newWebhookHTTPClient(config WebhookDispatcherConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: config.RequestTimeout}
+	if !config.AllowPrivateNetworks {
+		// Checked on the resolved address, so DNS names pointing inside are caught too
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || isPrivateAddress(ip) {
+				return errWebhookTargetNotAllowed
+			}
+			return nil
+		}
+	}
+
+	return &http.Client{
+		Timeout: config.RequestTimeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: config.RequestTimeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPrivateAddress returns true for addresses that webhooks may not target by default
+func isPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast()
+}
+
+// Start launches the delivery workers
+func (d *WebhookDispatcher) Start() {
+	d.startOnce.Do(func() {
+		for i := 0; i < d.config.Workers; i++ {
+			d.workers.Add(1)
+			go d.work()
+		}
+	})
+}
+
+// Stop stops the workers and waits for deliveries in progress to end.
+// Pending retries are abandoned and events still queued are dropped.
+func (d *WebhookDispatcher) Stop() {
+	d.cancel()
+	d.workers.Wait()
+}
+
+// Publish queues an event for delivery without blocking.
+// The event is dropped, and a warning logged, if the queue is full.
+func (d *WebhookDispatcher) Publish(event domain.Event) {
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+
+	select {
+	case d.queue <- event:
+	default:
+		d.logger().Warn("Webhook queue full, event dropped",
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.Type),
+			logging.WithUserID(event.UserID))
+	}
+}
+
+func (d *WebhookDispatcher) work() {
+	defer d.workers.Done()
+
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case event := <-d.queue:
+			d.dispatch(event)
+		}
+	}
+}
+
+// dispatch delivers an event to every webhook of its user subscribed to its type
+func (d *WebhookDispatcher) dispatch(event domain.Event) {
+	ctx, cancel := context.WithTimeout(d.ctx, d.config.RequestTimeout)
+	webhooks, err := d.repo.GetSubscribers(ctx, event.UserID, event.Type)
+	cancel()
+	if err != nil {
+		d.logger().Error("Failed to load webhook subscribers",
+			zap.String("event_id", event.ID),
+			logging.WithUserID(event.UserID),
+			logging.WithError(err))
+		return
+	}
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(webhookPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       event.Data,
+	})
+	if err != nil {
+		d.logger().Error("Failed to encode webhook payload", zap.String("event_id", event.ID), logging.WithError(err))
+		return
+	}
+
+	for _, webhook := range webhooks {
+		d.deliver(webhook, event, body)
+	}
+}
+
+// deliver POSTs the body to one webhook, retrying with exponential backoff until
+// it succeeds, MaxAttempts is reached or the dispatcher stops
+func (d *WebhookDispatcher) deliver(webhook domain.Webhook, event domain.Event, body []byte) {
+	backoff := d.config.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		delivery := d.attempt(webhook, event, body, attempt)
+		d.record(delivery)
+		if delivery.Succeeded {
+			return
+		}
+
+		if attempt >= d.config.MaxAttempts {
+			d.logger().Error("Webhook delivery failed, giving up",
+				logging.WithEntityID("webhook", webhook.ID),
+				zap.String("event_id", event.ID),
+				zap.Int("attempts", attempt),
+				zap.String("last_error", delivery.Error))
+			return
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-d.ctx.Done():
+			timer.Stop()
+			return
+		}
+
+		backoff *= 2
+		if backoff > d.config.MaxBackoff {
+			backoff = d.config.MaxBackoff
+		}
+	}
+}
+
+// attempt makes a single delivery request and describes its outcome
+func (d *WebhookDispatcher) attempt(webhook domain.Webhook, event domain.Event, body []byte, attempt int) domain.WebhookDelivery {
+	delivery := domain.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Attempt:   attempt,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "BuyOrBye-Webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, event.Type)
+	req.Header.Set(WebhookDeliveryHeader, event.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, body))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	delivery.Duration = time.Since(start)
+	if err != nil {
+		delivery.Error = err.Error()
+		return delivery
+	}
+	defer resp.Body.Close()
+	// Drain a bounded amount so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+
+	delivery.StatusCode = resp.StatusCode
+	delivery.Succeeded = resp.StatusCode >= 200 && resp.StatusCode < 300
+	if !delivery.Succeeded {
+		delivery.Error = fmt.Sprintf("unexpected status %d", resp.StatusCode)
+	}
+
+	return delivery
+}
+
+// record stores a delivery attempt; failures are only logged since the delivery itself already happened
+func (d *WebhookDispatcher) record(delivery domain.WebhookDelivery) {
+	// Not derived from d.ctx so the last attempt is still recorded while stopping
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := d.repo.RecordDelivery(ctx, delivery); err != nil {
+		d.logger().Error("Failed to record webhook delivery",
+			logging.WithEntityID("webhook", delivery.WebhookID),
+			zap.String("event_id", delivery.EventID),
+			logging.WithError(err))
+	}
+}
+
+func (d *WebhookDispatcher) logger() *zap.Logger {
+	if logger := logging.ServiceLogger(); logger != nil {
+		return logger.With(logging.WithOperation("webhook_dispatch"))
+	}
+	return zap.NewNop()
+}
+
+// SignWebhookPayload returns the signature header value for a delivery body:
+// "sha256=" followed by the hex HMAC-SHA256 of the body keyed with the secret.
+// Receivers should recompute it and compare with hmac.Equal.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
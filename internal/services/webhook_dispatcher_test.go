@@ -0,0 +1,395 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWebhookRepository keeps webhooks and deliveries in memory for dispatcher tests
+type memoryWebhookRepository struct {
+	mu         sync.Mutex
+	webhooks   []domain.Webhook
+	deliveries []domain.WebhookDelivery
+	recorded   chan domain.WebhookDelivery
+}
+
+func newMemoryWebhookRepository(webhooks ...domain.Webhook) *memoryWebhookRepository {
+	return &memoryWebhookRepository{
+		webhooks: webhooks,
+		recorded: make(chan domain.WebhookDelivery, 100),
+	}
+}
+
+func (r *memoryWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.webhooks = append(r.webhooks, *webhook)
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, webhook := range r.webhooks {
+		if webhook.ID == id {
+			return webhook, nil
+		}
+	}
+	return domain.Webhook{}, domain.ErrWebhookNotFound
+}
+
+func (r *memoryWebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	return nil
+}
+
+func (r *memoryWebhookRepository) Delete(ctx context.Context, id string) error {
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return nil, nil
+}
+
+func (r *memoryWebhookRepository) GetSubscribers(ctx context.Context, userID, eventType string) ([]domain.Webhook, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []domain.Webhook
+	for _, webhook := range r.webhooks {
+		if webhook.UserID == userID && webhook.Subscribes(eventType) {
+			result = append(result, webhook)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryWebhookRepository) RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	r.mu.Lock()
+	r.deliveries = append(r.deliveries, delivery)
+	r.mu.Unlock()
+	r.recorded <- delivery
+	return nil
+}
+
+func (r *memoryWebhookRepository) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	return nil, nil
+}
+
+// waitForDelivery returns the next recorded delivery attempt
+func (r *memoryWebhookRepository) waitForDelivery(t *testing.T) domain.WebhookDelivery {
+	t.Helper()
+	select {
+	case delivery := <-r.recorded:
+		return delivery
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a webhook delivery")
+		return domain.WebhookDelivery{}
+	}
+}
+
+// recordingPublisher collects published events for service tests
+type recordingPublisher struct {
+	mu     sync.Mutex
+	events []domain.Event
+}
+
+func (p *recordingPublisher) Publish(event domain.Event) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+}
+
+func (p *recordingPublisher) published() []domain.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]domain.Event(nil), p.events...)
+}
+
+func testWebhook(url string, eventTypes ...string) domain.Webhook {
+	return domain.Webhook{
+		ID:         "wh-1",
+		UserID:     "user-1",
+		URL:        url,
+		Secret:     "0123456789abcdef",
+		EventTypes: eventTypes,
+		IsActive:   true,
+	}
+}
+
+func testDispatcherConfig() WebhookDispatcherConfig {
+	return WebhookDispatcherConfig{
+		Workers:              1,
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RequestTimeout:       2 * time.Second,
+		AllowPrivateNetworks: true, // httptest listens on loopback
+	}
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+	type received struct {
+		headers http.Header
+		body    []byte
+	}
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{headers: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventDeductibleMet))
+	dispatcher := NewWebhookDispatcher(repo, testDispatcherConfig())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	dispatcher.Publish(domain.Event{
+		Type:   domain.EventDeductibleMet,
+		UserID: "user-1",
+		Data:   map[string]interface{}{"policy_id": "policy-1", "deductible": 1500.0},
+	})
+
+	delivery := repo.waitForDelivery(t)
+	assert.True(t, delivery.Succeeded)
+	assert.Equal(t, http.StatusNoContent, delivery.StatusCode)
+	assert.Equal(t, 1, delivery.Attempt)
+	assert.Equal(t, "wh-1", delivery.WebhookID)
+
+	req := <-requests
+	assert.Equal(t, "application/json", req.headers.Get("Content-Type"))
+	assert.Equal(t, domain.EventDeductibleMet, req.headers.Get(WebhookEventHeader))
+	assert.Equal(t, delivery.EventID, req.headers.Get(WebhookDeliveryHeader))
+	expected := SignWebhookPayload("0123456789abcdef", req.body)
+	assert.True(t, hmac.Equal([]byte(expected), []byte(req.headers.Get(WebhookSignatureHeader))))
+
+	var payload map[string]interface{}
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, delivery.EventID, payload["id"])
+	assert.Equal(t, domain.EventDeductibleMet, payload["type"])
+	assert.NotEmpty(t, payload["occurred_at"])
+	assert.Equal(t, map[string]interface{}{"policy_id": "policy-1", "deductible": 1500.0}, payload["data"])
+}
+
+func TestWebhookDispatcher_DeliversBudgetExceededFromFinanceSummary(t *testing.T) {
+	type received struct {
+		signature string
+		body      []byte
+	}
+	requests := make(chan received, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- received{signature: r.Header.Get(WebhookSignatureHeader), body: body}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventBudgetExceeded))
+	dispatcher := NewWebhookDispatcher(repo, testDispatcherConfig())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	service, mockIncomeRepo, mockExpenseRepo, mockLoanRepo, mockFinanceSummaryRepo := setupFinanceService()
+	service.events = dispatcher
+	ctx := context.Background()
+
+	// Spending 3500 a month on a 3000 income, where the last summary still had 250 left over
+	mockIncomeRepo.On("GetActiveIncomes", ctx, "user-1").Return([]domain.Income{
+		createTestIncome("income-1", "user-1", "Salary", 3000.0, "monthly", true),
+	}, nil)
+	mockExpenseRepo.On("GetUserExpenses", ctx, "user-1").Return([]domain.Expense{
+		createTestExpense("exp-1", "user-1", "housing", "Rent", 3500.0, "monthly", true, 1),
+	}, nil)
+	mockLoanRepo.On("GetUserLoans", ctx, "user-1").Return([]domain.Loan{}, nil)
+	mockFinanceSummaryRepo.On("GetFinanceSummaryByUserID", ctx, "user-1").
+		Return(domain.FinanceSummary{UserID: "user-1", FinancialHealth: domain.HealthFair, BudgetRemaining: 250}, nil)
+
+	_, err := service.CalculateFinanceSummary(ctx, "user-1")
+	require.NoError(t, err)
+
+	delivery := repo.waitForDelivery(t)
+	assert.True(t, delivery.Succeeded)
+	assert.Equal(t, domain.EventBudgetExceeded, delivery.EventType)
+
+	req := <-requests
+	assert.Equal(t, SignWebhookPayload("0123456789abcdef", req.body), req.signature)
+
+	var payload struct {
+		Type string                 `json:"type"`
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(req.body, &payload))
+	assert.Equal(t, domain.EventBudgetExceeded, payload.Type)
+	assert.Equal(t, -500.0, payload.Data["budget_remaining"])
+	assert.Equal(t, 3000.0, payload.Data["monthly_income"])
+	assert.Equal(t, 3500.0, payload.Data["monthly_expenses"])
+	assert.Equal(t, domain.DefaultCurrency, payload.Data["currency"])
+}
+
+func TestWebhookDispatcher_RetriesUntilSuccess(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventHighRiskDetected))
+	dispatcher := NewWebhookDispatcher(repo, testDispatcherConfig())
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	dispatcher.Publish(domain.Event{Type: domain.EventHighRiskDetected, UserID: "user-1"})
+
+	first := repo.waitForDelivery(t)
+	second := repo.waitForDelivery(t)
+	third := repo.waitForDelivery(t)
+
+	assert.Equal(t, []int{1, 2, 3}, []int{first.Attempt, second.Attempt, third.Attempt})
+	assert.False(t, first.Succeeded)
+	assert.Equal(t, "unexpected status 503", first.Error)
+	assert.True(t, third.Succeeded)
+	// Retries are the same delivery from the receiver's point of view
+	assert.Equal(t, first.EventID, third.EventID)
+}
+
+func TestWebhookDispatcher_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventHighRiskDetected))
+	dispatcher := NewWebhookDispatcher(repo, testDispatcherConfig())
+	dispatcher.Start()
+
+	dispatcher.Publish(domain.Event{Type: domain.EventHighRiskDetected, UserID: "user-1"})
+	for i := 0; i < 3; i++ {
+		assert.False(t, repo.waitForDelivery(t).Succeeded)
+	}
+	dispatcher.Stop()
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	assert.Len(t, repo.recorded, 0)
+}
+
+func TestWebhookDispatcher_SkipsUnsubscribedEvents(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventDeductibleMet))
+	dispatcher := NewWebhookDispatcher(repo, testDispatcherConfig())
+	dispatcher.Start()
+
+	dispatcher.Publish(domain.Event{Type: domain.EventHighRiskDetected, UserID: "user-1"})
+	dispatcher.Publish(domain.Event{Type: domain.EventDeductibleMet, UserID: "user-2"})
+	dispatcher.Publish(domain.Event{Type: domain.EventDeductibleMet, UserID: "user-1"})
+
+	// Events are handled in order by the single worker, so the last one's delivery
+	// means the others have already been skipped
+	assert.True(t, repo.waitForDelivery(t).Succeeded)
+	dispatcher.Stop()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDispatcher_RefusesPrivateAddressesByDefault(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	config := testDispatcherConfig()
+	config.AllowPrivateNetworks = false
+	config.MaxAttempts = 1
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventDeductibleMet))
+	dispatcher := NewWebhookDispatcher(repo, config)
+	dispatcher.Start()
+	defer dispatcher.Stop()
+
+	dispatcher.Publish(domain.Event{Type: domain.EventDeductibleMet, UserID: "user-1"})
+
+	delivery := repo.waitForDelivery(t)
+	assert.False(t, delivery.Succeeded)
+	assert.Contains(t, delivery.Error, errWebhookTargetNotAllowed.Error())
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestWebhookDispatcher_PublishDoesNotBlockWhenQueueIsFull(t *testing.T) {
+	config := testDispatcherConfig()
+	config.QueueSize = 1
+	// Not started, so nothing drains the queue
+	dispatcher := NewWebhookDispatcher(newMemoryWebhookRepository(), config)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			dispatcher.Publish(domain.Event{Type: domain.EventDeductibleMet, UserID: "user-1"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a full queue")
+	}
+	assert.Len(t, dispatcher.queue, 1)
+}
+
+func TestWebhookDispatcher_StopAbandonsPendingRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := testDispatcherConfig()
+	config.InitialBackoff = time.Hour
+	config.MaxBackoff = time.Hour
+	repo := newMemoryWebhookRepository(testWebhook(server.URL, domain.EventDeductibleMet))
+	dispatcher := NewWebhookDispatcher(repo, config)
+	dispatcher.Start()
+
+	dispatcher.Publish(domain.Event{Type: domain.EventDeductibleMet, UserID: "user-1"})
+	assert.False(t, repo.waitForDelivery(t).Succeeded)
+
+	stopped := make(chan struct{})
+	go func() {
+		dispatcher.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Stop waited for the retry backoff")
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// echo -n '{"id":"evt-1"}' | openssl dgst -sha256 -hmac 'secret'
+	assert.Equal(t,
+		"sha256=9fafdf780a4974eed4af6b3232450a1826bf0e7e5eb0f485264cf106b8af3574",
+		SignWebhookPayload("secret", []byte(`{"id":"evt-1"}`)))
+}
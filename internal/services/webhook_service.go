@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+)
+
+const (
+	// DefaultWebhookDeliveryLimit is used when a delivery log request doesn't specify a limit
+	DefaultWebhookDeliveryLimit = 50
+	// MaxWebhookDeliveryLimit caps how many delivery attempts are returned at once
+	MaxWebhookDeliveryLimit = 200
+)
+
+// webhookService implements the WebhookService interface defined in handlers package
+type webhookService struct {
+	repo WebhookRepository
+}
+
+// NewWebhookService creates a new webhook service instance
+// Returns concrete type that implements WebhookService interface defined in handlers package
+func NewWebhookService(repo WebhookRepository) *webhookService {
+	return &webhookService{
+		repo: repo,
+	}
+}
+
+// CreateWebhook validates and registers a new active webhook
+// A signing secret is generated when none is given
+func (s *webhookService) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if webhook.Secret == "" {
+		secret, err := generateWebhookSecret()
+		if err != nil {
+			return err
+		}
+		webhook.Secret = secret
+	}
+	webhook.IsActive = true
+
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Create(ctx, webhook)
+}
+
+// GetUserWebhooks retrieves all webhooks registered by a user
+func (s *webhookService) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	return s.repo.GetUserWebhooks(ctx, userID)
+}
+
+// GetWebhook retrieves one of the user's webhooks
+// Returns domain.ErrWebhookNotFound if it doesn't exist or belongs to another user
+func (s *webhookService) GetWebhook(ctx context.Context, userID, webhookID string) (domain.Webhook, error) {
+	webhook, err := s.repo.GetByID(ctx, webhookID)
+	if err != nil {
+		return domain.Webhook{}, err
+	}
+
+	// Other users' webhooks are reported as missing so IDs can't be probed
+	if webhook.UserID != userID {
+		return domain.Webhook{}, domain.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// UpdateWebhook validates and saves changes to one of the user's webhooks
+func (s *webhookService) UpdateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	if _, err := s.GetWebhook(ctx, webhook.UserID, webhook.ID); err != nil {
+		return err
+	}
+
+	if err := webhook.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.Update(ctx, webhook)
+}
+
+// DeleteWebhook removes one of the user's webhooks
+func (s *webhookService) DeleteWebhook(ctx context.Context, userID, webhookID string) error {
+	if _, err := s.GetWebhook(ctx, userID, webhookID); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(ctx, webhookID)
+}
+
+// GetDeliveries retrieves the most recent delivery attempts of one of the user's webhooks
+// Out of range limits fall back to the default or are capped at MaxWebhookDeliveryLimit
+func (s *webhookService) GetDeliveries(ctx context.Context, userID, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	if _, err := s.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	if limit < 1 {
+		limit = DefaultWebhookDeliveryLimit
+	}
+	if limit > MaxWebhookDeliveryLimit {
+		limit = MaxWebhookDeliveryLimit
+	}
+
+	return s.repo.GetDeliveries(ctx, webhookID, limit)
+}
+
+// generateWebhookSecret returns 32 random bytes, hex encoded
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetByID(ctx context.Context, id string) (domain.Webhook, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	args := m.Called(ctx, webhook)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetUserWebhooks(ctx context.Context, userID string) ([]domain.Webhook, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) GetSubscribers(ctx context.Context, userID, eventType string) ([]domain.Webhook, error) {
+	args := m.Called(ctx, userID, eventType)
+	return args.Get(0).([]domain.Webhook), args.Error(1)
+}
+
+func (m *MockWebhookRepository) RecordDelivery(ctx context.Context, delivery domain.WebhookDelivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) GetDeliveries(ctx context.Context, webhookID string, limit int) ([]domain.WebhookDelivery, error) {
+	args := m.Called(ctx, webhookID, limit)
+	return args.Get(0).([]domain.WebhookDelivery), args.Error(1)
+}
+
+func TestWebhookService_CreateWebhook_GeneratesSecret(t *testing.T) {
+	mockRepo := &MockWebhookRepository{}
+	service := NewWebhookService(mockRepo)
+	ctx := context.Background()
+
+	webhook := &domain.Webhook{
+		UserID:     "user-1",
+		URL:        "https://hooks.example.com/buyorbye",
+		EventTypes: []string{domain.EventDeductibleMet},
+	}
+	mockRepo.On("Create", ctx, webhook).Return(nil)
+
+	err := service.CreateWebhook(ctx, webhook)
+
+	require.NoError(t, err)
+	assert.Len(t, webhook.Secret, 64)
+	assert.True(t, webhook.IsActive)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_CreateWebhook_InvalidData(t *testing.T) {
+	mockRepo := &MockWebhookRepository{}
+	service := NewWebhookService(mockRepo)
+
+	err := service.CreateWebhook(context.Background(), &domain.Webhook{
+		UserID:     "user-1",
+		URL:        "ftp://hooks.example.com",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{domain.EventDeductibleMet},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookData)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_OtherUsersWebhooksAreNotFound(t *testing.T) {
+	mockRepo := &MockWebhookRepository{}
+	service := NewWebhookService(mockRepo)
+	ctx := context.Background()
+
+	owned := domain.Webhook{
+		ID:         "wh-1",
+		UserID:     "user-2",
+		URL:        "https://hooks.example.com/buyorbye",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{domain.EventDeductibleMet},
+		IsActive:   true,
+	}
+	mockRepo.On("GetByID", ctx, "wh-1").Return(owned, nil)
+
+	_, err := service.GetWebhook(ctx, "user-1", "wh-1")
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	update := owned
+	update.UserID = "user-1"
+	assert.ErrorIs(t, service.UpdateWebhook(ctx, &update), domain.ErrWebhookNotFound)
+	assert.ErrorIs(t, service.DeleteWebhook(ctx, "user-1", "wh-1"), domain.ErrWebhookNotFound)
+	_, err = service.GetDeliveries(ctx, "user-1", "wh-1", 10)
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "GetDeliveries", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookService_UpdateWebhook_Success(t *testing.T) {
+	mockRepo := &MockWebhookRepository{}
+	service := NewWebhookService(mockRepo)
+	ctx := context.Background()
+
+	webhook := domain.Webhook{
+		ID:         "wh-1",
+		UserID:     "user-1",
+		URL:        "https://hooks.example.com/buyorbye",
+		Secret:     "0123456789abcdef",
+		EventTypes: []string{domain.EventDeductibleMet},
+		IsActive:   true,
+	}
+	mockRepo.On("GetByID", ctx, "wh-1").Return(webhook, nil)
+
+	webhook.EventTypes = []string{domain.EventHighRiskDetected}
+	webhook.IsActive = false
+	mockRepo.On("Update", ctx, &webhook).Return(nil)
+
+	require.NoError(t, service.UpdateWebhook(ctx, &webhook))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestWebhookService_GetDeliveries_ClampsLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		expected int
+	}{
+		{name: "default", limit: 0, expected: DefaultWebhookDeliveryLimit},
+		{name: "in range", limit: 10, expected: 10},
+		{name: "capped", limit: 1000, expected: MaxWebhookDeliveryLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &MockWebhookRepository{}
+			service := NewWebhookService(mockRepo)
+			ctx := context.Background()
+
+			mockRepo.On("GetByID", ctx, "wh-1").Return(domain.Webhook{ID: "wh-1", UserID: "user-1"}, nil)
+			mockRepo.On("GetDeliveries", ctx, "wh-1", tt.expected).Return([]domain.WebhookDelivery{}, nil)
+
+			_, err := service.GetDeliveries(ctx, "user-1", "wh-1", tt.limit)
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
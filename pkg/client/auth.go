@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// Register creates an account and signs in as it
+func (c *Client) Register(ctx context.Context, req RegisterRequest) (*TokenResponse, error) {
+	return c.authenticate(ctx, apiPrefix+"/auth/register", req)
+}
+
+// Login signs in; the client sends the new access token with the requests that follow
+func (c *Client) Login(ctx context.Context, email, password string) (*TokenResponse, error) {
+	return c.authenticate(ctx, apiPrefix+"/auth/login", LoginRequest{Email: email, Password: password})
+}
+
+// Refresh exchanges the stored refresh token for new tokens. The client already does this when
+// a request is rejected with 401, so calling it is rarely needed.
+func (c *Client) Refresh(ctx context.Context) (*TokenResponse, error) {
+	_, refreshToken := c.Tokens()
+	return c.authenticate(ctx, apiPrefix+"/auth/refresh", RefreshTokenRequest{RefreshToken: refreshToken})
+}
+
+// Logout revokes the stored refresh token and forgets both tokens
+func (c *Client) Logout(ctx context.Context) error {
+	_, refreshToken := c.Tokens()
+	if err := c.call(ctx, http.MethodPost, apiPrefix+"/auth/logout", RefreshTokenRequest{RefreshToken: refreshToken}, nil); err != nil {
+		return err
+	}
+	c.SetTokens("", "")
+	return nil
+}
@@ -0,0 +1,277 @@
+// Package client is a Go client for the BuyOrBye API.
+//
+// A Client keeps the access and refresh tokens from Register or Login and sends the access token
+// with every request. When a request is rejected with 401 it refreshes the tokens once and
+// retries. GET requests that fail with a network error or a 429, 502, 503 or 504 response are
+// retried with exponential backoff. Error responses are returned as *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apiPrefix is the path every API route is under
+const apiPrefix = "/api/v1"
+
+const (
+	// DefaultTimeout bounds each HTTP request, including reading the response body
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRetries is how many times a failed GET is retried
+	DefaultMaxRetries = 2
+	// DefaultRetryBackoff is the wait before the first retry; it doubles for each retry after
+	DefaultRetryBackoff = 200 * time.Millisecond
+)
+
+// Client calls the BuyOrBye API. It is safe for concurrent use.
+type Client struct {
+	baseURL      string
+	httpClient   *http.Client
+	maxRetries   int
+	retryBackoff time.Duration
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+	// refreshMu serializes token refreshes so concurrent 401s refresh once
+	refreshMu sync.Mutex
+}
+
+// Option customizes a Client created by New
+type Option func(*Client)
+
+// WithHTTPClient sends requests through httpClient instead of a new http.Client.
+// Its Timeout is left as is.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTimeout bounds each HTTP request; 0 means no timeout
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.httpClient.Timeout = timeout
+	}
+}
+
+// WithRetry sets how many times a failed GET is retried and the wait before the first retry.
+// A maxRetries of 0 disables retries.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		c.maxRetries = maxRetries
+		c.retryBackoff = backoff
+	}
+}
+
+// WithTokens starts the client with tokens from an earlier session
+func WithTokens(accessToken, refreshToken string) Option {
+	return func(c *Client) {
+		c.accessToken = accessToken
+		c.refreshToken = refreshToken
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. "http://localhost:8080"
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:      strings.TrimRight(baseURL, "/"),
+		httpClient:   &http.Client{Timeout: DefaultTimeout},
+		maxRetries:   DefaultMaxRetries,
+		retryBackoff: DefaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Tokens returns the current access and refresh tokens
+func (c *Client) Tokens() (accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.accessToken, c.refreshToken
+}
+
+// SetTokens replaces the tokens; empty tokens make the following requests unauthenticated
+func (c *Client) SetTokens(accessToken, refreshToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = accessToken
+	c.refreshToken = refreshToken
+}
+
+// Response is an API response whose body has been read
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Do sends body, if not nil, as JSON to path, e.g. "/api/v1/finance/summary", and returns the
+// response without interpreting its status. Token refresh and GET retries apply as for the
+// typed methods. Returns an error only if no response was received.
+func (c *Client) Do(ctx context.Context, method, path string, body any) (*Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	accessToken, _ := c.Tokens()
+	resp, err := c.send(ctx, method, path, payload, accessToken)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || accessToken == "" || isAuthPath(path) {
+		return resp, err
+	}
+
+	// The access token has probably expired; refresh it and try once more
+	if err := c.refreshTokens(ctx, accessToken); err != nil {
+		return resp, nil
+	}
+	accessToken, _ = c.Tokens()
+	return c.send(ctx, method, path, payload, accessToken)
+}
+
+// send makes the request, retrying GETs that fail in a way a retry may fix
+func (c *Client) send(ctx context.Context, method, path string, payload []byte, accessToken string) (*Response, error) {
+	attempts := 1
+	if method == http.MethodGet {
+		attempts += c.maxRetries
+	}
+
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := c.sendOnce(ctx, method, path, payload, accessToken)
+		if attempt >= attempts || !shouldRetry(ctx, resp, err) {
+			return resp, err
+		}
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			if err == nil {
+				return resp, nil
+			}
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+}
+
+// sendOnce makes a single request and reads the whole response
+func (c *Client) sendOnce(ctx context.Context, method, path string, payload []byte, accessToken string) (*Response, error) {
+	var reqBody io.Reader
+	if payload != nil {
+		reqBody = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: body}, nil
+}
+
+// shouldRetry reports whether a failed attempt may succeed if repeated
+func shouldRetry(ctx context.Context, resp *Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isAuthPath reports whether path is a login, registration or refresh route, whose 401s mean
+// bad credentials rather than an expired access token
+func isAuthPath(path string) bool {
+	switch path {
+	case apiPrefix + "/auth/login", apiPrefix + "/auth/register", apiPrefix + "/auth/refresh":
+		return true
+	}
+	return false
+}
+
+// refreshTokens exchanges the refresh token for new tokens, unless another request already
+// replaced staleAccessToken while this one waited
+func (c *Client) refreshTokens(ctx context.Context, staleAccessToken string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	accessToken, refreshToken := c.Tokens()
+	if accessToken != staleAccessToken {
+		return nil
+	}
+	if refreshToken == "" {
+		return errors.New("no refresh token")
+	}
+
+	_, err := c.authenticate(ctx, apiPrefix+"/auth/refresh", RefreshTokenRequest{RefreshToken: refreshToken})
+	return err
+}
+
+// authenticate posts credentials or a refresh token to path and stores the tokens returned
+func (c *Client) authenticate(ctx context.Context, path string, body any) (*TokenResponse, error) {
+	var tokens TokenResponse
+	if err := c.call(ctx, http.MethodPost, path, body, &tokens); err != nil {
+		return nil, err
+	}
+	c.SetTokens(tokens.AccessToken, tokens.RefreshToken)
+	return &tokens, nil
+}
+
+// call sends body to path and decodes a successful response into out, if not nil.
+// Error responses are returned as *APIError.
+func (c *Client) call(ctx context.Context, method, path string, body, out any) error {
+	resp, err := c.Do(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp)
+	}
+
+	if out == nil || len(resp.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("failed to decode %s %s response: %w", method, path, err)
+	}
+	return nil
+}
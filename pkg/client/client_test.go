@@ -0,0 +1,239 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func TestClient_Login_StoresTokensAndSendsAccessToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var req LoginRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "user@example.com", req.Email)
+		writeJSON(w, http.StatusOK, TokenResponse{AccessToken: "access-1", RefreshToken: "refresh-1", TokenType: "Bearer"})
+	})
+	mux.HandleFunc("GET /api/v1/finance/summary", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer access-1", r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, FinanceSummary{MonthlyIncome: 5000})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := New(server.URL)
+	_, err := c.Login(context.Background(), "user@example.com", "password123")
+	require.NoError(t, err)
+
+	summary, err := c.GetFinanceSummary(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 5000.0, summary.MonthlyIncome)
+	access, refresh := c.Tokens()
+	assert.Equal(t, "access-1", access)
+	assert.Equal(t, "refresh-1", refresh)
+}
+
+func TestClient_RefreshesExpiredAccessToken(t *testing.T) {
+	var refreshes atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		refreshes.Add(1)
+		var req RefreshTokenRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "refresh-1", req.RefreshToken)
+		writeJSON(w, http.StatusOK, TokenResponse{AccessToken: "access-2", RefreshToken: "refresh-2"})
+	})
+	mux.HandleFunc("POST /api/v1/finance/income", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-2" {
+			writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "unauthorized", "message": "Token expired"})
+			return
+		}
+		var req AddIncomeRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req), "the body is sent again after the refresh")
+		assert.Equal(t, "Salary", req.Source)
+		writeJSON(w, http.StatusCreated, map[string]string{"message": "Income added successfully"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := New(server.URL, WithTokens("access-1", "refresh-1"))
+
+	err := c.AddIncome(context.Background(), AddIncomeRequest{Source: "Salary", Amount: 5000, Frequency: "monthly"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), refreshes.Load())
+	access, refresh := c.Tokens()
+	assert.Equal(t, "access-2", access)
+	assert.Equal(t, "refresh-2", refresh)
+}
+
+func TestClient_RefreshFails_ReturnsOriginal401(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/auth/refresh", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "unauthorized", "message": "Refresh token revoked"})
+	})
+	mux.HandleFunc("GET /api/v1/finance/budgets", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{"error": "unauthorized", "message": "Token expired"})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	c := New(server.URL, WithTokens("access-1", "refresh-1"))
+
+	_, err := c.GetBudgets(context.Background())
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	assert.Equal(t, "Token expired", apiErr.Message)
+}
+
+func TestClient_RetriesGETWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, []Budget{{Category: "food"}})
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(2, time.Millisecond))
+
+	budgets, err := c.GetBudgets(context.Background())
+
+	require.NoError(t, err)
+	assert.Len(t, budgets, 1)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestClient_RetriesGETUntilExhausted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(1, time.Millisecond))
+
+	_, err := c.GetFinanceSummary(context.Background())
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, "Bad Gateway", apiErr.Message)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_DoesNotRetryPOST(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := New(server.URL, WithRetry(3, time.Millisecond))
+
+	err := c.AddBudget(context.Background(), AddBudgetRequest{Category: "food", MonthlyLimit: 500})
+
+	require.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestClient_ValidationErrorCarriesFields(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]any{
+			"error":      "validation_error",
+			"message":    "Validation failed",
+			"code":       400,
+			"error_code": "VALIDATION_FAILED",
+			"fields":     map[string]any{"Amount": "Amount must be greater than 0"},
+		})
+	}))
+	defer server.Close()
+
+	err := New(server.URL).AddIncome(context.Background(), AddIncomeRequest{Source: "Salary"})
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.Equal(t, "VALIDATION_FAILED", apiErr.Code)
+	assert.Equal(t, "validation_error", apiErr.Kind)
+	assert.Equal(t, "Validation failed", apiErr.Message)
+	assert.Equal(t, map[string]string{"Amount": "Amount must be greater than 0"}, apiErr.Fields)
+}
+
+func TestClient_HealthErrorEnvelope(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]any{
+			"error":      "Health profile not found",
+			"error_code": "HEALTH_PROFILE_NOT_FOUND",
+		})
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL).GetHealthProfile(context.Background())
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, "HEALTH_PROFILE_NOT_FOUND", apiErr.Code)
+	assert.Equal(t, "Health profile not found", apiErr.Message)
+	assert.Empty(t, apiErr.Kind)
+}
+
+func TestClient_ContextCancelledDuringBackoff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	c := New(server.URL, WithRetry(5, time.Hour))
+
+	start := time.Now()
+	_, err := c.GetFinanceSummary(ctx)
+
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr, "the last response is returned when the wait is cut short")
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestClient_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := New(server.URL, WithTimeout(20*time.Millisecond), WithRetry(0, 0))
+
+	_, err := c.GetFinanceSummary(context.Background())
+
+	require.Error(t, err)
+	var apiErr *APIError
+	assert.False(t, errors.As(err, &apiErr))
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is an error response from the API
+type APIError struct {
+	StatusCode int
+	// Code is the stable error code, e.g. "FIN_BUDGET_EXISTS"; see the API documentation
+	Code string
+	// Kind is the broad error category, e.g. "validation_error" or "not_found"; empty for the
+	// health endpoints, which don't report one
+	Kind    string
+	Message string
+	// Fields maps each invalid request field to what is wrong with it, for validation errors
+	Fields map[string]string
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("buyorbye api: %d %s: %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("buyorbye api: %d: %s", e.StatusCode, e.Message)
+}
+
+// errorEnvelope is the body of the API's error responses. The health endpoints leave out
+// message and put it in error instead.
+type errorEnvelope struct {
+	Error     string         `json:"error"`
+	Message   string         `json:"message"`
+	ErrorCode string         `json:"error_code"`
+	Fields    map[string]any `json:"fields"`
+}
+
+// newAPIError builds an APIError from an error response. A body that isn't the API's error
+// envelope, such as one from a proxy, becomes the message.
+func newAPIError(resp *Response) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+
+	var envelope errorEnvelope
+	if err := json.Unmarshal(resp.Body, &envelope); err != nil || (envelope.Error == "" && envelope.Message == "") {
+		apiErr.Message = strings.TrimSpace(string(resp.Body))
+		if apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	apiErr.Code = envelope.ErrorCode
+	if envelope.Message == "" {
+		apiErr.Message = envelope.Error
+	} else {
+		apiErr.Kind = envelope.Error
+		apiErr.Message = envelope.Message
+	}
+	if len(envelope.Fields) > 0 {
+		apiErr.Fields = make(map[string]string, len(envelope.Fields))
+		for field, problem := range envelope.Fields {
+			apiErr.Fields[field] = fmt.Sprint(problem)
+		}
+	}
+	return apiErr
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const financePath = apiPrefix + "/finance"
+
+// AddIncome records an income source
+func (c *Client) AddIncome(ctx context.Context, req AddIncomeRequest) error {
+	return c.call(ctx, http.MethodPost, financePath+"/income", req, nil)
+}
+
+// GetIncomes returns all of the user's income sources
+func (c *Client) GetIncomes(ctx context.Context) ([]Income, error) {
+	var incomes []Income
+	if err := c.call(ctx, http.MethodGet, financePath+"/income", nil, &incomes); err != nil {
+		return nil, err
+	}
+	return incomes, nil
+}
+
+// AddExpense records an expense
+func (c *Client) AddExpense(ctx context.Context, req AddExpenseRequest) error {
+	return c.call(ctx, http.MethodPost, financePath+"/expense", req, nil)
+}
+
+// GetExpenses returns all of the user's expenses
+func (c *Client) GetExpenses(ctx context.Context) ([]Expense, error) {
+	var expenses []Expense
+	if err := c.call(ctx, http.MethodGet, financePath+"/expenses", nil, &expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+// AddLoan records a loan
+func (c *Client) AddLoan(ctx context.Context, req AddLoanRequest) error {
+	return c.call(ctx, http.MethodPost, financePath+"/loan", req, nil)
+}
+
+// GetLoans returns all of the user's loans
+func (c *Client) GetLoans(ctx context.Context) ([]Loan, error) {
+	var loans []Loan
+	if err := c.call(ctx, http.MethodGet, financePath+"/loans", nil, &loans); err != nil {
+		return nil, err
+	}
+	return loans, nil
+}
+
+// AddSavingsGoal creates a savings goal
+func (c *Client) AddSavingsGoal(ctx context.Context, req AddSavingsGoalRequest) error {
+	return c.call(ctx, http.MethodPost, financePath+"/goals", req, nil)
+}
+
+// GetSavingsGoals returns the user's savings goals
+func (c *Client) GetSavingsGoals(ctx context.Context) ([]SavingsGoal, error) {
+	var goals []SavingsGoal
+	if err := c.call(ctx, http.MethodGet, financePath+"/goals", nil, &goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// AddBudget sets a monthly budget for an expense category
+func (c *Client) AddBudget(ctx context.Context, req AddBudgetRequest) error {
+	return c.call(ctx, http.MethodPost, financePath+"/budgets", req, nil)
+}
+
+// GetBudgets returns the user's budgets
+func (c *Client) GetBudgets(ctx context.Context) ([]Budget, error) {
+	var budgets []Budget
+	if err := c.call(ctx, http.MethodGet, financePath+"/budgets", nil, &budgets); err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// GetFinanceSummary returns the user's monthly totals, financial health and budget status
+func (c *Client) GetFinanceSummary(ctx context.Context) (*FinanceSummary, error) {
+	var summary FinanceSummary
+	if err := c.call(ctx, http.MethodGet, financePath+"/summary", nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// GetAffordability returns the most the user can spend on new monthly commitments
+func (c *Client) GetAffordability(ctx context.Context) (*Affordability, error) {
+	var affordability Affordability
+	if err := c.call(ctx, http.MethodGet, financePath+"/affordability", nil, &affordability); err != nil {
+		return nil, err
+	}
+	return &affordability, nil
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+const healthPath = apiPrefix + "/health"
+
+// CreateHealthProfile creates the user's health profile
+func (c *Client) CreateHealthProfile(ctx context.Context, req CreateHealthProfileRequest) error {
+	return c.call(ctx, http.MethodPost, healthPath+"/profile", req, nil)
+}
+
+// GetHealthProfile returns the user's health profile
+func (c *Client) GetHealthProfile(ctx context.Context) (*HealthProfile, error) {
+	var profile HealthProfile
+	if err := c.call(ctx, http.MethodGet, healthPath+"/profile", nil, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// AddMedicalCondition adds a medical condition to the user's profile
+func (c *Client) AddMedicalCondition(ctx context.Context, req AddMedicalConditionRequest) error {
+	return c.call(ctx, http.MethodPost, healthPath+"/conditions", req, nil)
+}
+
+// GetMedicalConditions returns the user's medical conditions
+func (c *Client) GetMedicalConditions(ctx context.Context) (*MedicalConditionList, error) {
+	var conditions MedicalConditionList
+	if err := c.call(ctx, http.MethodGet, healthPath+"/conditions", nil, &conditions); err != nil {
+		return nil, err
+	}
+	return &conditions, nil
+}
+
+// AddMedicalExpense records a medical expense
+func (c *Client) AddMedicalExpense(ctx context.Context, req AddMedicalExpenseRequest) error {
+	return c.call(ctx, http.MethodPost, healthPath+"/expenses", req, nil)
+}
+
+// GetMedicalExpenses returns the user's medical expenses
+func (c *Client) GetMedicalExpenses(ctx context.Context) (*MedicalExpenseList, error) {
+	var expenses MedicalExpenseList
+	if err := c.call(ctx, http.MethodGet, healthPath+"/expenses", nil, &expenses); err != nil {
+		return nil, err
+	}
+	return &expenses, nil
+}
+
+// AddInsurancePolicy records an insurance policy
+func (c *Client) AddInsurancePolicy(ctx context.Context, req AddInsurancePolicyRequest) error {
+	return c.call(ctx, http.MethodPost, healthPath+"/insurance", req, nil)
+}
+
+// GetInsurancePolicies returns the user's active insurance policies
+func (c *Client) GetInsurancePolicies(ctx context.Context) (*InsurancePolicyList, error) {
+	var policies InsurancePolicyList
+	if err := c.call(ctx, http.MethodGet, healthPath+"/insurance", nil, &policies); err != nil {
+		return nil, err
+	}
+	return &policies, nil
+}
+
+// GetHealthSummary returns the user's risk, cost and coverage summary
+func (c *Client) GetHealthSummary(ctx context.Context) (*HealthSummary, error) {
+	var summary HealthSummary
+	if err := c.call(ctx, http.MethodGet, healthPath+"/summary", nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
@@ -0,0 +1,43 @@
+package client
+
+import "github.com/DuckDHD/BuyOrBye/internal/dtos"
+
+// The request and response bodies are the API's own DTOs; these aliases let code outside this
+// module name them.
+
+// Authentication
+type (
+	RegisterRequest     = dtos.RegisterRequestDTO
+	LoginRequest        = dtos.LoginRequestDTO
+	RefreshTokenRequest = dtos.RefreshTokenRequestDTO
+	TokenResponse       = dtos.TokenResponseDTO
+)
+
+// Finance
+type (
+	AddIncomeRequest      = dtos.AddIncomeDTO
+	Income                = dtos.IncomeResponseDTO
+	AddExpenseRequest     = dtos.AddExpenseDTO
+	Expense               = dtos.ExpenseResponseDTO
+	AddLoanRequest        = dtos.AddLoanDTO
+	Loan                  = dtos.LoanResponseDTO
+	AddSavingsGoalRequest = dtos.AddSavingsGoalDTO
+	SavingsGoal           = dtos.SavingsGoalResponseDTO
+	AddBudgetRequest      = dtos.AddBudgetDTO
+	Budget                = dtos.BudgetResponseDTO
+	FinanceSummary        = dtos.FinanceSummaryResponseDTO
+	Affordability         = dtos.AffordabilityResponseDTO
+)
+
+// Health
+type (
+	CreateHealthProfileRequest = dtos.CreateHealthProfileRequestDTO
+	HealthProfile              = dtos.HealthProfileResponseDTO
+	AddMedicalConditionRequest = dtos.CreateMedicalConditionRequestDTO
+	MedicalConditionList       = dtos.MedicalConditionListResponseDTO
+	AddMedicalExpenseRequest   = dtos.CreateMedicalExpenseRequestDTO
+	MedicalExpenseList         = dtos.MedicalExpenseListResponseDTO
+	AddInsurancePolicyRequest  = dtos.CreateInsurancePolicyRequestDTO
+	InsurancePolicyList        = dtos.InsurancePolicyListResponseDTO
+	HealthSummary              = dtos.HealthSummaryResponseDTO
+)
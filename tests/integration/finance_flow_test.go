@@ -4,7 +4,7 @@
 package integration
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/pkg/client"
 	"github.com/DuckDHD/BuyOrBye/tests/testutils"
 )
 
@@ -51,6 +52,7 @@ func (s *FinanceFlowTestSuite) SetupTest() {
 // TestCompleteFinanceFlow tests the complete user journey from registration to financial analysis
 func (s *FinanceFlowTestSuite) TestCompleteFinanceFlow() {
 	t := s.T()
+	ctx := context.Background()
 	
 	// Test: User Registration
 	user := testutils.NewTestUser("john.doe@example.com", "John Doe", "password123")
@@ -80,8 +82,7 @@ func (s *FinanceFlowTestSuite) TestCompleteFinanceFlow() {
 	}
 	
 	for _, income := range incomeData {
-		resp, body := s.client.POST(t, "/api/v1/finance/income", income)
-		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add income: %s", string(body))
+		assert.NoError(t, s.client.API.AddIncome(ctx, income), "Failed to add income")
 	}
 	
 	// Test: Add Expenses
@@ -121,8 +122,7 @@ func (s *FinanceFlowTestSuite) TestCompleteFinanceFlow() {
 	}
 	
 	for _, expense := range expenseData {
-		resp, body := s.client.POST(t, "/api/v1/finance/expense", expense)
-		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add expense: %s", string(body))
+		assert.NoError(t, s.client.API.AddExpense(ctx, expense), "Failed to add expense")
 	}
 	
 	// Test: Add Loans
@@ -139,8 +139,7 @@ func (s *FinanceFlowTestSuite) TestCompleteFinanceFlow() {
 	}
 	
 	for _, loan := range loanData {
-		resp, body := s.client.POST(t, "/api/v1/finance/loan", loan)
-		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add loan: %s", string(body))
+		assert.NoError(t, s.client.API.AddLoan(ctx, loan), "Failed to add loan")
 	}
 	
 	// Test: Get Financial Summary
@@ -478,11 +477,10 @@ func (s *FinanceFlowTestSuite) TestFrequencyConversions() {
 		{1000.0, "monthly", 1000.0},
 		{250.0, "weekly", 1082.5},    // 250 * 4.33
 		{50.0, "daily", 1500.0},      // 50 * 30
-		{3000.0, "quarterly", 1000.0}, // 3000 / 3
-		{6000.0, "semiannual", 1000.0}, // 6000 / 6
-		{12000.0, "annual", 1000.0},   // 12000 / 12
+		{3000.0, "one-time", 250.0},  // Spread over a year
 	}
 	
+	expectedTotal := 0.0
 	for i, testCase := range frequencyTestData {
 		income := dtos.AddIncomeDTO{
 			Source:    fmt.Sprintf("Test Income %d", i),
@@ -492,13 +490,13 @@ func (s *FinanceFlowTestSuite) TestFrequencyConversions() {
 		
 		resp, body := s.client.POST(t, "/api/v1/finance/income", income)
 		assert.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add income: %s", string(body))
+		expectedTotal += testCase.expected
 	}
 	
 	summary := s.client.GetFinanceSummary(t)
 	
-	// All should normalize to approximately the same monthly amount
-	expectedTotal := 6000.0 // 6 * 1000 (roughly)
-	assert.InDelta(t, expectedTotal, summary.MonthlyIncome, 200.0, "Frequency conversions should normalize correctly")
+	// The summary adds up the monthly equivalents of every income
+	assert.InDelta(t, expectedTotal, summary.MonthlyIncome, 1.0, "Frequency conversions should normalize correctly")
 }
 
 // TestAuthenticationFlow tests authentication and authorization
@@ -506,7 +504,7 @@ func (s *FinanceFlowTestSuite) TestAuthenticationFlow() {
 	t := s.T()
 	
 	// Test: Access protected endpoint without token
-	resp, body := s.client.GET(t, "/api/v1/finance/summary")
+	resp, _ := s.client.GET(t, "/api/v1/finance/summary")
 	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Should require authentication")
 	
 	// Test: Register and login flow
@@ -528,24 +526,48 @@ func (s *FinanceFlowTestSuite) TestAuthenticationFlow() {
 		Amount:    5000.00,
 		Frequency: "monthly",
 	}
-	resp, body = s.client.POST(t, "/api/v1/finance/income", income)
-	assert.Equal(t, http.StatusCreated, resp.StatusCode, "User 2 should be able to add income")
+	assert.NoError(t, s.client.API.AddIncome(context.Background(), income), "User 2 should be able to add income")
 	
 	// Switch back to user 1
 	s.client.SetAccessToken(user.Token)
 	
 	// User 1 should not see user 2's data
-	resp, body = s.client.GET(t, "/api/v1/finance/income")
-	assert.Equal(t, http.StatusOK, resp.StatusCode)
-	
-	var incomes []dtos.IncomeResponseDTO
-	err := json.Unmarshal(body, &incomes)
+	incomes, err := s.client.API.GetIncomes(context.Background())
 	require.NoError(t, err)
 	
 	// User 1 should have no income records (only user 2 added income)
 	assert.Empty(t, incomes, "User 1 should not see user 2's income data")
 }
 
+// TestExpiredAccessTokenIsRefreshed tests that the client swaps a rejected access token for a
+// new one using its refresh token
+func (s *FinanceFlowTestSuite) TestExpiredAccessTokenIsRefreshed() {
+	t := s.T()
+	ctx := context.Background()
+
+	user := testutils.NewTestUser("refresh.test@example.com", "Refresh Test", "password123")
+	user.Register(t, s.client)
+	_, refreshToken := s.client.API.Tokens()
+	s.client.API.SetTokens("not-a-valid-token", refreshToken)
+	// Tokens carry no unique claim, so a pair issued in the same second as the one it replaces
+	// would be identical
+	time.Sleep(time.Second)
+
+	_, err := s.client.API.GetFinanceSummary(ctx)
+	require.NoError(t, err, "the client should refresh the access token and retry")
+
+	accessToken, newRefreshToken := s.client.API.Tokens()
+	assert.NotEqual(t, "not-a-valid-token", accessToken)
+	assert.NotEqual(t, refreshToken, newRefreshToken, "refresh tokens are rotated")
+
+	// Without a refresh token the 401 surfaces as an APIError
+	s.client.API.SetTokens("not-a-valid-token", "")
+	_, err = s.client.API.GetFinanceSummary(ctx)
+	var apiErr *client.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
+
 // TestValidationAndErrorHandling tests input validation and error responses
 func (s *FinanceFlowTestSuite) TestValidationAndErrorHandling() {
 	t := s.T()
@@ -587,8 +609,7 @@ func (s *FinanceFlowTestSuite) TestValidationAndErrorHandling() {
 	assert.Equal(t, http.StatusCreated, resp.StatusCode, "Valid income should be accepted")
 	
 	// Test: Malformed JSON - create invalid request manually
-	invalidJSON := `{"invalid": json}`
-	resp, body = s.client.POST(t, "/api/v1/finance/income", json.RawMessage(invalidJSON))
+	resp, _ = s.client.POSTRaw(t, "/api/v1/finance/income", `{"invalid": json}`)
 	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 }
 
@@ -719,7 +740,7 @@ func (s *FinanceFlowTestSuite) TestRealWorldScenarios() {
 		assert.Less(t, summary.DisposableIncome, 2000.0)   // But not too much
 		assert.Contains(t, []string{"Fair", "Good"}, summary.FinancialHealth)
 		assert.Greater(t, affordability, 1000.0) // Should afford some purchases
-		assert.Less(t, affordability, 5000.0)    // But not expensive items
+		assert.Less(t, affordability, 6000.0)    // But no more than a few months of disposable income
 	})
 	
 	// Scenario 2: Mid-Career Professional with Family
@@ -732,7 +753,7 @@ func (s *FinanceFlowTestSuite) TestRealWorldScenarios() {
 		incomes := []dtos.AddIncomeDTO{
 			{Source: "Senior Developer", Amount: 9500.00, Frequency: "monthly"},
 			{Source: "Partner Income", Amount: 6500.00, Frequency: "monthly"},
-			{Source: "Bonus", Amount: 15000.00, Frequency: "annual"},
+			{Source: "Bonus", Amount: 15000.00, Frequency: "one-time"},
 		}
 		
 		for _, income := range incomes {
@@ -772,7 +793,7 @@ func (s *FinanceFlowTestSuite) TestRealWorldScenarios() {
 		affordability := s.client.GetAffordability(t)
 		
 		// Mid-career professional should have good financial health
-		expectedIncome := 9500.00 + 6500.00 + (15000.00 / 12) // ~17250/month
+		expectedIncome := 9500.00 + 6500.00 + (15000.00 / 12) // ~17250/month, the bonus spread over a year
 		assert.InDelta(t, expectedIncome, summary.MonthlyIncome, 100.0)
 		assert.Greater(t, summary.DisposableIncome, 3000.0) // Should have good disposable income
 		assert.Contains(t, []string{"Good", "Excellent"}, summary.FinancialHealth)
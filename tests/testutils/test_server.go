@@ -4,6 +4,7 @@
 package testutils
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
@@ -14,159 +15,94 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 
+	"github.com/DuckDHD/BuyOrBye/internal/config"
 	"github.com/DuckDHD/BuyOrBye/internal/database"
-	"github.com/DuckDHD/BuyOrBye/internal/handlers"
 	"github.com/DuckDHD/BuyOrBye/internal/logging"
-	"github.com/DuckDHD/BuyOrBye/internal/middleware"
-	"github.com/DuckDHD/BuyOrBye/internal/repositories"
-	"github.com/DuckDHD/BuyOrBye/internal/services"
+	"github.com/DuckDHD/BuyOrBye/internal/server"
 )
 
+// testJWTSecret signs the tokens of the test server; it is also exported as JWT_SECRET by
+// SetupIntegrationTest
+const testJWTSecret = "test-jwt-secret-key-for-integration-tests"
+
 // TestServer represents an integration test server instance
 type TestServer struct {
-	Server         *httptest.Server
-	Router         *gin.Engine
-	BaseURL        string
-	GormService    *database.GormService
-	AuthService    services.AuthService
-	FinanceService services.FinanceService
+	Server  *httptest.Server
+	Router  *gin.Engine
+	BaseURL string
+	DB      *gorm.DB
+	// Deps are the services and middleware the router was built from
+	Deps *server.Deps
 }
 
-// NewTestServer creates a new test server instance for integration tests
+// NewTestServer creates a new test server instance for integration tests.
+// It uses MySQL from the BLUEPRINT_DB_* variables, or in-memory SQLite when
+// BLUEPRINT_DB_DRIVER=sqlite, so the suites can run without Docker.
 func NewTestServer(t *testing.T) *TestServer {
-	// Set Gin to test mode
-	gin.SetMode(gin.TestMode)
-	
+	if os.Getenv("BLUEPRINT_DB_DRIVER") == database.DriverSQLite {
+		return NewSQLiteTestServer(t)
+	}
+
 	// Initialize test database
 	gormService, err := database.NewGormService()
 	require.NoError(t, err, "Failed to initialize test database")
-	
-	db := gormService.GetDB()
-	
-	// Initialize core services
-	passwordService := services.NewPasswordService()
-	jwtService, err := services.NewJWTService()
-	require.NoError(t, err, "Failed to initialize JWT service")
-	
-	// Initialize repositories
-	userRepo := repositories.NewUserRepository(db)
-	tokenRepo := repositories.NewTokenRepository(db)
-	
-	// Initialize finance repositories
-	incomeRepo := repositories.NewIncomeRepository(db)
-	expenseRepo := repositories.NewExpenseRepository(db)
-	loanRepo := repositories.NewLoanRepository(db)
-	financeSummaryRepo := repositories.NewFinanceSummaryRepository()
-	
-	// Create finance repositories aggregate
-	financeRepos := services.NewFinanceRepositories(incomeRepo, expenseRepo, loanRepo, financeSummaryRepo)
-	
-	// Initialize services
-	authService := services.NewAuthService(userRepo, tokenRepo, passwordService, jwtService)
-	financeService := services.NewFinanceService(financeRepos)
-	
-	// Initialize handlers
-	authHandler := handlers.NewAuthHandler(authService)
-	financeHandler := handlers.NewFinanceHandler(financeService)
-	
-	// Initialize middlewares
-	jwtAuthMiddleware := middleware.NewJWTAuthMiddleware(jwtService)
-	
-	// Setup Gin router
-	router := gin.New()
-	
-	// Global middleware
-	router.Use(middleware.CORS())
-	router.Use(middleware.Recovery())
-	router.Use(middleware.ValidateRequestLimits())
-	
-	// Health check
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{
-			"status": "ok",
-			"message": "BuyOrBye Test API is running",
-		})
+	require.NoError(t, database.RunAllMigrations(gormService.GetDB()), "Failed to migrate test database")
+
+	return newTestServer(t, gormService.GetDB())
+}
+
+// NewSQLiteTestServer creates a test server backed by a fresh in-memory SQLite database
+// with every migration applied
+func NewSQLiteTestServer(t *testing.T) *TestServer {
+	db, err := database.ConnectSQLite(database.SQLiteMemory, &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
 	})
-	
-	// API routes
-	api := router.Group("/api/v1")
-	
-	// Auth routes (public)
-	auth := api.Group("/auth")
-	{
-		auth.POST("/register", authHandler.Register)
-		auth.POST("/login", authHandler.Login)
-		auth.POST("/refresh", authHandler.RefreshToken)
-		
-		// Protected auth routes
-		protected := auth.Group("")
-		protected.Use(jwtAuthMiddleware.RequireAuth())
-		{
-			protected.POST("/logout", authHandler.Logout)
-		}
-	}
-	
-	// Finance routes (all require auth)
-	finance := api.Group("/finance")
-	finance.Use(jwtAuthMiddleware.RequireAuth())
-	finance.Use(middleware.ValidateOwnership())
-	{
-		// Income endpoints
-		finance.POST("/income", 
-			middleware.ValidateFinancialData(),
-			middleware.NormalizeFrequency(),
-			financeHandler.AddIncome)
-		finance.GET("/income", financeHandler.GetIncomes)
-		finance.PUT("/income/:id", 
-			middleware.ValidateUserOwnership("income"),
-			middleware.ValidateFinancialData(),
-			middleware.NormalizeFrequency(),
-			financeHandler.UpdateIncome)
-		finance.DELETE("/income/:id", 
-			middleware.ValidateUserOwnership("income"),
-			financeHandler.DeleteIncome)
-		
-		// Expense endpoints
-		finance.POST("/expense", 
-			middleware.ValidateFinancialData(),
-			middleware.NormalizeFrequency(),
-			financeHandler.AddExpense)
-		finance.GET("/expenses", financeHandler.GetExpenses)
-		finance.PUT("/expense/:id", 
-			middleware.ValidateUserOwnership("expense"),
-			middleware.ValidateFinancialData(),
-			middleware.NormalizeFrequency(),
-			financeHandler.UpdateExpense)
-		finance.DELETE("/expense/:id", 
-			middleware.ValidateUserOwnership("expense"),
-			financeHandler.DeleteExpense)
-		
-		// Loan endpoints
-		finance.POST("/loan", 
-			middleware.ValidateFinancialData(),
-			financeHandler.AddLoan)
-		finance.GET("/loans", financeHandler.GetLoans)
-		finance.PUT("/loan/:id", 
-			middleware.ValidateUserOwnership("loan"),
-			middleware.ValidateFinancialData(),
-			financeHandler.UpdateLoan)
-		
-		// Analysis endpoints
-		finance.GET("/summary", financeHandler.GetFinanceSummary)
-		finance.GET("/affordability", financeHandler.GetAffordability)
+	require.NoError(t, err, "Failed to open SQLite test database")
+	require.NoError(t, database.RunAllMigrations(db), "Failed to migrate SQLite test database")
+
+	return newTestServer(t, db)
+}
+
+// newTestServer builds the application's dependencies and router on db, the same way the
+// server does, and starts serving it
+func newTestServer(t *testing.T, db *gorm.DB) *TestServer {
+	// Set Gin to test mode
+	gin.SetMode(gin.TestMode)
+
+	// Handlers log through the global logger
+	require.NoError(t, logging.InitLogger(logging.LogConfig{Environment: "test", Level: "error"}), "Failed to initialize logger")
+
+	cfg := &config.Config{
+		Server:   config.ServerConfig{Environment: "test"},
+		Database: config.DatabaseConfig{Driver: db.Dialector.Name()},
+		Auth: config.AuthConfig{
+			JWTSecret:       testJWTSecret,
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+		},
+		Logging: config.LoggingConfig{Level: "error"},
 	}
-	
+	cfg.Health.Attachments.StoragePath = t.TempDir()
+
+	deps, err := server.NewDeps(cfg, &testDatabase{db: db})
+	require.NoError(t, err, "Failed to build server dependencies")
+	router, err := server.BuildRouter(deps)
+	require.NoError(t, err, "Failed to build router")
+	// Only the audit log writer runs; the other background jobs would race the tests' resets
+	deps.AuditService.Start()
+
 	// Create test server
-	server := httptest.NewServer(router)
-	
+	httpServer := httptest.NewServer(router)
+
 	return &TestServer{
-		Server:         server,
-		Router:         router,
-		BaseURL:        server.URL,
-		GormService:    gormService,
-		AuthService:    authService,
-		FinanceService: financeService,
+		Server:  httpServer,
+		Router:  router,
+		BaseURL: httpServer.URL,
+		DB:      db,
+		Deps:    deps,
 	}
 }
 
@@ -175,19 +111,52 @@ func (ts *TestServer) Close() {
 	if ts.Server != nil {
 		ts.Server.Close()
 	}
-	
+	if ts.Deps != nil {
+		ts.Deps.Idempotency.Stop()
+		ts.Deps.AuditService.Stop()
+	}
+
 	// Clean up database connections
-	if ts.GormService != nil {
-		db := ts.GormService.GetDB()
-		if db != nil {
-			sqlDB, err := db.DB()
-			if err == nil {
-				sqlDB.Close()
-			}
+	if ts.DB != nil {
+		sqlDB, err := ts.DB.DB()
+		if err == nil {
+			sqlDB.Close()
 		}
 	}
 }
 
+// testDatabase hands the test database to the server's dependencies
+type testDatabase struct {
+	db *gorm.DB
+}
+
+func (d *testDatabase) GetDB() *gorm.DB {
+	return d.db
+}
+
+func (d *testDatabase) Health() map[string]string {
+	if err := d.Ping(context.Background()); err != nil {
+		return map[string]string{"status": "down", "error": err.Error()}
+	}
+	return map[string]string{"status": "up"}
+}
+
+func (d *testDatabase) Ping(ctx context.Context) error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+func (d *testDatabase) Close() error {
+	sqlDB, err := d.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
 // GetPort returns the port number the test server is running on
 func (ts *TestServer) GetPort() string {
 	_, port, _ := net.SplitHostPort(ts.Server.Listener.Addr().String())
@@ -202,7 +171,7 @@ func SetupIntegrationTest() {
 	os.Setenv("BLUEPRINT_DB_DATABASE", "buyorbye_test")
 	os.Setenv("BLUEPRINT_DB_USERNAME", "test")
 	os.Setenv("BLUEPRINT_DB_PASSWORD", "test")
-	os.Setenv("JWT_SECRET", "test-jwt-secret-key-for-integration-tests")
+	os.Setenv("JWT_SECRET", testJWTSecret)
 	os.Setenv("JWT_EXPIRY", "15m")
 	os.Setenv("JWT_REFRESH_EXPIRY", "7d")
 	os.Setenv("GIN_MODE", "test")
@@ -239,35 +208,63 @@ func WaitForServer(baseURL string, maxAttempts int) error {
 
 // ResetDatabase truncates all tables to ensure clean state for tests
 func (ts *TestServer) ResetDatabase(t *testing.T) {
-	db := ts.GormService.GetDB()
+	db := ts.DB
 	
 	// List of tables to clear (in dependency order)
 	tables := []string{
 		"refresh_tokens",
-		"finance_summaries", 
+		"api_keys",
+		"audit_logs",
+		"demo_data_records",
+		"finance_summaries",
+		"goal_contributions",
+		"savings_goals",
+		"loan_balance_entries",
 		"loans",
-		"expenses", 
+		"budgets",
+		"expenses",
 		"incomes",
+		"idempotency_keys",
+		"webhook_deliveries",
+		"webhooks",
+		"profile_snapshots",
+		"health_risk_snapshots",
+		"medication_schedules",
+		"expense_attachments",
+		"medical_expense_occurrences",
+		"insurance_policies",
+		"medical_expenses",
+		"medical_conditions",
+		"health_profiles",
 		"users",
 	}
 	
-	// Disable foreign key checks for SQLite
-	err := db.Exec("PRAGMA foreign_keys = OFF").Error
-	require.NoError(t, err, "Failed to disable foreign key checks")
-	
-	// Clear all tables
-	for _, table := range tables {
-		err := db.Exec(fmt.Sprintf("DELETE FROM %s", table)).Error
-		if err != nil {
-			// Log warning but don't fail test if table doesn't exist
-			logger := logging.GetLogger()
-			logger.Warn("Failed to clear table during test cleanup",
-				logging.WithTable(table),
-				logging.WithError(err))
-		}
+	// Disable foreign key checks; the statement differs per driver
+	disableForeignKeys, enableForeignKeys := "SET FOREIGN_KEY_CHECKS = 0", "SET FOREIGN_KEY_CHECKS = 1"
+	if db.Dialector.Name() == database.DriverSQLite {
+		disableForeignKeys, enableForeignKeys = "PRAGMA foreign_keys = OFF", "PRAGMA foreign_keys = ON"
 	}
-	
-	// Re-enable foreign key checks
-	err = db.Exec("PRAGMA foreign_keys = ON").Error
-	require.NoError(t, err, "Failed to re-enable foreign key checks")
+	// The setting is per connection, so everything runs on one
+	err := db.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec(disableForeignKeys).Error; err != nil {
+			return fmt.Errorf("failed to disable foreign key checks: %w", err)
+		}
+
+		// Clear all tables
+		for _, table := range tables {
+			if err := conn.Exec(fmt.Sprintf("DELETE FROM %s", table)).Error; err != nil {
+				// Log warning but don't fail test if table doesn't exist
+				logging.GetLogger().Warn("Failed to clear table during test cleanup",
+					logging.WithTable(table),
+					logging.WithError(err))
+			}
+		}
+
+		// Re-enable foreign key checks
+		if err := conn.Exec(enableForeignKeys).Error; err != nil {
+			return fmt.Errorf("failed to re-enable foreign key checks: %w", err)
+		}
+		return nil
+	})
+	require.NoError(t, err, "Failed to reset database")
 }
\ No newline at end of file